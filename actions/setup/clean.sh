@@ -3,7 +3,8 @@ set +o histexpand
 
 # Clean Action Post Script
 # Mirror of actions/setup/post.js for script mode (run: bash steps).
-# Sends an OTLP conclusion span then removes the /tmp/gh-aw/ directory.
+# Sends an OTLP conclusion span then removes the per-job scratch directory
+# GH_AW_TMP_DIR created by setup.sh.
 #
 # Must be called from an `if: always()` step so it runs even when job steps fail,
 # ensuring both the trace span and the cleanup always complete.
@@ -12,13 +13,17 @@ set +o histexpand
 #   - name: Clean Scripts
 #     if: always()
 #     run: |
-#       bash /tmp/gh-aw/actions-source/actions/setup/clean.sh
+#       bash "${RUNNER_TEMP}/gh-aw/actions-source/actions/setup/clean.sh"
 #     env:
 #       INPUT_DESTINATION: ${{ runner.temp }}/gh-aw/actions
 
 set -e
 
 DESTINATION="${INPUT_DESTINATION:-${RUNNER_TEMP}/gh-aw/actions}"
+SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
+
+# shellcheck source=sh/gh_aw_tmp_dir_lib.sh
+source "${SCRIPT_DIR}/sh/gh_aw_tmp_dir_lib.sh"
 
 # Send OTLP job conclusion span (non-fatal).
 # Delegates to action_conclusion_otlp.cjs (same file used by actions/setup/post.js)
@@ -29,16 +34,21 @@ if command -v node &>/dev/null && [ -f "${DESTINATION}/action_conclusion_otlp.cj
   echo "OTLP conclusion span step complete"
 fi
 
-# Remove /tmp/gh-aw/ (mirrors post.js cleanup).
-tmpDir="/tmp/gh-aw"
-if [ -d "${tmpDir}" ]; then
-  if sudo rm -rf "${tmpDir}" 2>/dev/null; then
-    echo "Cleaned up ${tmpDir} (sudo)"
-  elif rm -rf "${tmpDir}" 2>/dev/null; then
+# Remove the scratch directory of this job (mirrors post.js cleanup), then the run
+# directory once no other job of the run uses it. Concurrent jobs on the same host
+# keep their own directories.
+tmpDir="${GH_AW_TMP_DIR:-}"
+if [ -z "${tmpDir}" ]; then
+  echo "Skipping cleanup of the gh-aw scratch directory: GH_AW_TMP_DIR is not set"
+elif ! gh_aw_is_tmp_dir "${tmpDir}"; then
+  echo "Skipping cleanup of ${tmpDir}: not a gh-aw job scratch directory"
+elif [ -d "${tmpDir}" ]; then
+  if gh_aw_remove_tmp_dir "${tmpDir}"; then
     echo "Cleaned up ${tmpDir}"
   else
     echo "Warning: failed to clean up ${tmpDir}" >&2
   fi
+  gh_aw_remove_empty_run_dir "${tmpDir}"
 fi
 
 # Remove AWF chroot directories under /tmp (e.g. /tmp/awf-*-chroot-home and
@@ -4,6 +4,7 @@
 const { spawnSync } = require("child_process");
 const path = require("path");
 const { getActionInput } = require("./js/action_input_utils.cjs");
+const { readTmpGhAwJobDirFromEnvFile } = require("./js/gh_aw_tmp_dir.cjs");
 
 // Record start time for the OTLP span before any setup work begins.
 const setupStartMs = Date.now();
@@ -40,6 +41,13 @@ if (result.status !== 0) {
   process.exit(result.status ?? 1);
 }
 
+// setup.sh exports GH_AW_TMP_DIR only to later steps, so point the OTLP helpers below
+// at the per-job scratch directory it created.
+const tmpGhAwJobDir = readTmpGhAwJobDirFromEnvFile(process.env.GITHUB_ENV);
+if (tmpGhAwJobDir) {
+  process.env.GH_AW_TMP_DIR = tmpGhAwJobDir;
+}
+
 // Send a gh-aw.<jobName>.setup span to the OTLP endpoint when configured.
 // Delegates to action_setup_otlp.cjs so that script mode (setup.sh) and
 // dev/release mode share the same implementation.
@@ -6,7 +6,7 @@
  */
 
 const { assembleMarkdownBodyParts } = require("./markdown_body_helpers.cjs");
-const { generateWorkflowCallIdMarker, matchesWorkflowId } = require("./generate_footer.cjs");
+const { generateWorkflowCallIdMarker, matchesWorkflowId, normalizeCloseOlderKey, generateDedupeKeyMarker } = require("./generate_footer.cjs");
 const { getRepositoryUrl } = require("./get_repository_url.cjs");
 const { replaceTemporaryIdReferences, resolveSafeOutputIssueTarget } = require("./temporary_id.cjs");
 const { getTrackerID } = require("./get_tracker_id.cjs");
@@ -22,7 +22,7 @@ const { sanitizeContent } = require("./sanitize_content.cjs");
 const { MAX_COMMENT_LENGTH, MAX_MENTIONS, MAX_LINKS, enforceCommentLimits } = require("./comment_limit_helpers.cjs");
 const { createDiscussionComment, resolveTopLevelDiscussionCommentId } = require("./github_api_helpers.cjs");
 const { logStagedPreviewInfo } = require("./staged_preview.cjs");
-const { ERR_NOT_FOUND } = require("./error_codes.cjs");
+const { ERR_NOT_FOUND, ERR_VALIDATION } = require("./error_codes.cjs");
 const { isPayloadUserBot } = require("./resolve_mentions.cjs");
 const { buildWorkflowRunUrl } = require("./workflow_metadata_helpers.cjs");
 const { generateHistoryUrl } = require("./generate_history_link.cjs");
@@ -208,6 +208,43 @@ async function findCommentsWithTrackerId(github, owner, repo, issueNumber, workf
   return comments;
 }
 
+/**
+ * Find the first comment on an issue/PR carrying the given dedupe-key marker
+ * @param {any} github - GitHub REST API instance
+ * @param {string} owner - Repository owner
+ * @param {string} repo - Repository name
+ * @param {number} issueNumber - Issue/PR number
+ * @param {string} dedupeKey - Normalized deduplication key
+ * @returns {Promise<number|null>} Comment ID, or null when no comment carries the marker
+ */
+async function findCommentByDedupeKey(github, owner, repo, issueNumber, dedupeKey) {
+  const exactMarker = generateDedupeKeyMarker(dedupeKey);
+  let page = 1;
+  const perPage = 100;
+
+  // Paginate through all comments
+  while (true) {
+    const { data } = await github.rest.issues.listComments({
+      owner,
+      repo,
+      issue_number: issueNumber,
+      per_page: perPage,
+      page,
+    });
+
+    const match = data.find(comment => typeof comment.body === "string" && comment.body.includes(exactMarker));
+    if (match) {
+      return match.id;
+    }
+
+    if (data.length < perPage) {
+      return null;
+    }
+
+    page++;
+  }
+}
+
 /**
  * Find comments on a discussion with any matching workflow ID marker
  * @param {any} github - GitHub GraphQL instance
@@ -407,6 +444,11 @@ async function main(config = {}) {
   const includeFooter = parseBoolTemplatable(config.footer, true);
   const requiredLabels = Array.isArray(config.required_labels) ? config.required_labels : [];
   const requiredTitlePrefix = config.required_title_prefix || "";
+  const rawDedupeKey = config.dedupe_key ? String(config.dedupe_key) : "";
+  const dedupeKey = rawDedupeKey ? normalizeCloseOlderKey(rawDedupeKey) : "";
+  if (rawDedupeKey && !dedupeKey) {
+    throw new Error(`${ERR_VALIDATION}: dedupe-key "${rawDedupeKey}" is invalid: it must contain at least one alphanumeric character after normalization`);
+  }
   const mentionsDisabled = config.mentions === false || config.mentions?.enabled === false;
   const preResolvedMentionAliases =
     !mentionsDisabled && Array.isArray(config.allowedMentionAliases) ? config.allowedMentionAliases.map(alias => (typeof alias === "string" ? alias.trim().replace(/^@+/, "") : "")).filter(alias => alias.length > 0) : [];
@@ -440,6 +482,9 @@ async function main(config = {}) {
   if (appendOnlyComments) {
     core.info("Append-only-comments is enabled - will not hide older comments");
   }
+  if (dedupeKey) {
+    core.info(`Dedupe key: "${dedupeKey}" - an existing comment with this key will be updated instead of posting a new one`);
+  }
 
   // Track state
   let processedCount = 0;
@@ -725,6 +770,11 @@ async function main(config = {}) {
       processedBody += "\n" + generateWorkflowCallIdMarker(callerWorkflowId);
     }
 
+    // Add dedupe-key marker so later runs can find and update this comment
+    if (dedupeKey) {
+      processedBody += "\n" + generateDedupeKeyMarker(dedupeKey);
+    }
+
     // Enforce max limits again after adding footer and metadata
     // This ensures the final body (including generated content) doesn't exceed limits
     try {
@@ -798,6 +848,20 @@ async function main(config = {}) {
     }
 
     try {
+      // Reuse an existing comment carrying the same dedupe-key marker so that
+      // recurring runs update a single comment instead of posting a new one.
+      if (dedupeKey && commentIdToReuse === null) {
+        if (isDiscussion) {
+          core.info("Skipping dedupe-key lookup: dedupe-key is only supported for issue and pull request comments");
+        } else {
+          const existingCommentId = await findCommentByDedupeKey(githubClient, repoParts.owner, repoParts.repo, itemNumber, dedupeKey);
+          if (existingCommentId !== null) {
+            core.info(`Dedupe-key: found existing comment ${existingCommentId} with key "${dedupeKey}"`);
+            commentIdToReuse = existingCommentId;
+          }
+        }
+      }
+
       // Hide older comments if enabled AND append-only-comments is not enabled
       // When append-only-comments is true, we want to keep all comments visible
       if (hideOlderCommentsEnabled) {
@@ -989,4 +1053,5 @@ module.exports = {
   MAX_LINKS,
   enforceCommentLimits,
   isDiscussionIntegrationAccessError,
+  findCommentByDedupeKey,
 };
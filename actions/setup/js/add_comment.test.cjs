@@ -3705,6 +3705,88 @@ describe("add_comment", () => {
     });
   });
 
+  describe("dedupe-key behavior", () => {
+    /** @type {any[]} */
+    let updateCalls;
+    /** @type {any[]} */
+    let createCalls;
+
+    beforeEach(() => {
+      updateCalls = [];
+      createCalls = [];
+      mockGithub.rest.issues.updateComment = async params => {
+        updateCalls.push(params);
+        return {
+          data: {
+            id: params.comment_id,
+            html_url: `https://github.com/owner/repo/issues/8535#issuecomment-${params.comment_id}`,
+          },
+        };
+      };
+      mockGithub.rest.issues.createComment = async params => {
+        createCalls.push(params);
+        return {
+          data: {
+            id: 12345,
+            html_url: "https://github.com/owner/repo/issues/8535#issuecomment-12345",
+          },
+        };
+      };
+    });
+
+    it("should update the comment carrying the dedupe-key marker instead of posting a new one", async () => {
+      const addCommentScript = fs.readFileSync(path.join(__dirname, "add_comment.cjs"), "utf8");
+      mockGithub.rest.issues.listComments = async () => ({
+        data: [
+          { id: 700, body: "Unrelated comment" },
+          { id: 701, body: "Last week's report\n\n<!-- gh-aw-dedupe-key: weekly-report -->" },
+        ],
+      });
+
+      const handler = await eval(`(async () => { ${addCommentScript}; return await main({ dedupe_key: 'Weekly Report' }); })()`);
+      const result = await handler({ type: "add_comment", body: "This week's report" }, {});
+
+      expect(result.success).toBe(true);
+      expect(createCalls).toHaveLength(0);
+      expect(updateCalls).toHaveLength(1);
+      expect(updateCalls[0]).toEqual(expect.objectContaining({ owner: "owner", repo: "repo", comment_id: 701 }));
+      expect(updateCalls[0].body).toContain("This week's report");
+      expect(updateCalls[0].body).toContain("<!-- gh-aw-dedupe-key: weekly-report -->");
+    });
+
+    it("should post a new comment with the dedupe-key marker when no comment carries the key", async () => {
+      const addCommentScript = fs.readFileSync(path.join(__dirname, "add_comment.cjs"), "utf8");
+      mockGithub.rest.issues.listComments = async () => ({
+        data: [{ id: 700, body: "Unrelated comment" }],
+      });
+
+      const handler = await eval(`(async () => { ${addCommentScript}; return await main({ dedupe_key: 'weekly-report' }); })()`);
+      const result = await handler({ type: "add_comment", body: "This week's report" }, {});
+
+      expect(result.success).toBe(true);
+      expect(updateCalls).toHaveLength(0);
+      expect(createCalls).toHaveLength(1);
+      expect(createCalls[0].issue_number).toBe(8535);
+      expect(createCalls[0].body).toContain("<!-- gh-aw-dedupe-key: weekly-report -->");
+    });
+
+    it("should post a new comment when only a different dedupe-key marker is present", async () => {
+      const addCommentScript = fs.readFileSync(path.join(__dirname, "add_comment.cjs"), "utf8");
+      mockGithub.rest.issues.listComments = async () => ({
+        data: [{ id: 702, body: "Archived report\n\n<!-- gh-aw-dedupe-key: weekly-report-archive -->" }],
+      });
+
+      const handler = await eval(`(async () => { ${addCommentScript}; return await main({ dedupe_key: 'weekly-report' }); })()`);
+      const result = await handler({ type: "add_comment", body: "This week's report" }, {});
+
+      expect(result.success).toBe(true);
+      expect(updateCalls).toHaveLength(0);
+      expect(createCalls).toHaveLength(1);
+      expect(createCalls[0].body).toContain("<!-- gh-aw-dedupe-key: weekly-report -->");
+      expect(createCalls[0].body).not.toContain("weekly-report-archive");
+    });
+  });
+
   let enforceCommentLimits;
   let MAX_COMMENT_LENGTH;
   let MAX_MENTIONS;
@@ -23,6 +23,7 @@ const { isStagedMode, checkRequiredFilter } = require("./safe_output_helpers.cjs
 const { createAuthenticatedGitHubClient } = require("./handler_auth.cjs");
 const { attachExecutionState, extractReviewStateFromData, fetchPullRequestReviewState } = require("./safe_output_execution_metadata.cjs");
 const { resolveTargetRepoConfig, resolveAndValidateRepo } = require("./repo_helpers.cjs");
+const { getGitHubServerUrl } = require("./get_repository_url.cjs");
 const { COPILOT_REVIEWER_BOT, COPILOT_REVIEWER_BOT_ID } = require("./constants.cjs");
 const { ERR_API } = require("./error_codes.cjs");
 
@@ -259,7 +260,7 @@ async function main(config = {}) {
           number: prNumber,
           repo: itemRepo,
           pull_request_number: prNumber,
-          pull_request_url: `https://github.com/${repoParts.owner}/${repoParts.repo}/pull/${prNumber}`,
+          pull_request_url: `${getGitHubServerUrl()}/${repoParts.owner}/${repoParts.repo}/pull/${prNumber}`,
           reviewersAdded: uniqueReviewers,
           teamReviewersAdded: uniqueTeamReviewers,
           metadata: {
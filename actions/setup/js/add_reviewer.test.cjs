@@ -361,6 +361,24 @@ describe("add_reviewer (Handler Factory Architecture)", () => {
     });
   });
 
+  it("should build the pull request URL from GITHUB_SERVER_URL on GHES", async () => {
+    const originalServerUrl = process.env.GITHUB_SERVER_URL;
+    process.env.GITHUB_SERVER_URL = "https://ghes.example.com/";
+
+    try {
+      const result = await handler({ type: "add_reviewer", reviewers: ["user1"] }, {});
+
+      expect(result.success).toBe(true);
+      expect(result.pull_request_url).toBe("https://ghes.example.com/testowner/testrepo/pull/123");
+    } finally {
+      if (originalServerUrl === undefined) {
+        delete process.env.GITHUB_SERVER_URL;
+      } else {
+        process.env.GITHUB_SERVER_URL = originalServerUrl;
+      }
+    }
+  });
+
   it("should deduplicate reviewers", async () => {
     const message = {
       type: "add_reviewer",
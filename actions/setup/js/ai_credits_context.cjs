@@ -3,6 +3,7 @@ require("./shim.cjs");
 
 const fs = require("fs");
 const path = require("path");
+const { TMP_GH_AW_PATH } = require("./constants.cjs");
 
 const MAX_AI_CREDITS_FIELDS = new Set(["max_ai_credits", "maxAiCredits"]);
 const AI_CREDITS_FIELDS = new Set(["ai_credits", "aiCredits"]);
@@ -17,7 +18,7 @@ const BUDGET_EXCEEDED_EVENT = "budget_exceeded";
 // and the requested model is not in the built-in pricing table.
 const UNKNOWN_MODEL_AI_CREDITS_TYPE = "unknown_model_ai_credits";
 const MAX_AI_CREDITS_EXCEEDED_STDIO_RE = /maximum ai credits exceeded(?:\s*\((\d+(?:\.\d+)?)\s*\/\s*(\d+(?:\.\d+)?)\))?/i;
-const DEFAULT_AGENT_STDIO_LOG = "/tmp/gh-aw/agent-stdio.log";
+const DEFAULT_AGENT_STDIO_LOG = `${TMP_GH_AW_PATH}/agent-stdio.log`;
 const AGENT_STDIO_LOG_MAX_TAIL = 64 * 1024; // 64 KB — sufficient for any realistic error block
 
 /**
@@ -81,8 +82,8 @@ function resolveFirewallAuditLogPath(auditJsonlPathOverride) {
     candidateBases.push(path.join(path.dirname(agentOutputFile), "sandbox", "firewall", "audit"));
     candidateBases.push(path.join(path.dirname(agentOutputFile), "sandbox", "firewall", "logs"));
   }
-  candidateBases.push("/tmp/gh-aw/sandbox/firewall/audit");
-  candidateBases.push("/tmp/gh-aw/sandbox/firewall/logs");
+  candidateBases.push(`${TMP_GH_AW_PATH}/sandbox/firewall/audit`);
+  candidateBases.push(`${TMP_GH_AW_PATH}/sandbox/firewall/logs`);
 
   for (const base of candidateBases) {
     for (const filename of ["log.jsonl", "audit.jsonl"]) {
@@ -65,9 +65,30 @@ function validateAllowedIssueFields(issueFields, allowedFields) {
   }
 }
 
+/**
+ * Resolve a requested issue type against configured allowed-types.
+ * Matching is case-insensitive and returns the configured spelling, so the
+ * GitHub API receives the exact type name. An empty name clears the type.
+ * @param {string} typeName
+ * @param {string[]} allowedTypes
+ * @returns {string}
+ */
+function resolveAllowedIssueType(typeName, allowedTypes) {
+  const name = String(typeName ?? "").trim();
+  if (!name) return "";
+  const typeSet = buildAllowedFieldSet(allowedTypes);
+  if (!typeSet) return name;
+  const match = allowedTypes.find(allowed => allowed.toLowerCase() === name.toLowerCase());
+  if (!match) {
+    throw new Error(`${ERR_VALIDATION}: issue type "${name}" is not in the allowed-types list: ${allowedTypes.join(", ")}`);
+  }
+  return match;
+}
+
 module.exports = {
   BUILTIN_ISSUE_FIELD_NAMES,
   parseAllowedIssueFields,
+  resolveAllowedIssueType,
   validateAllowedIssueFieldName,
   validateAllowedIssueFields,
 };
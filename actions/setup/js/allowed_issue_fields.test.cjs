@@ -3,7 +3,7 @@ import { describe, it, expect } from "vitest";
 import { createRequire } from "module";
 
 const req = createRequire(import.meta.url);
-const { parseAllowedIssueFields, validateAllowedIssueFieldName, validateAllowedIssueFields, BUILTIN_ISSUE_FIELD_NAMES } = req("./allowed_issue_fields.cjs");
+const { parseAllowedIssueFields, validateAllowedIssueFieldName, validateAllowedIssueFields, resolveAllowedIssueType, BUILTIN_ISSUE_FIELD_NAMES } = req("./allowed_issue_fields.cjs");
 
 describe("parseAllowedIssueFields", () => {
   it("returns empty array for undefined", () => {
@@ -126,6 +126,25 @@ describe("validateAllowedIssueFields", () => {
   });
 });
 
+describe("resolveAllowedIssueType", () => {
+  it("returns the type unchanged when no allowed-types are configured", () => {
+    expect(resolveAllowedIssueType("Bug", [])).toBe("Bug");
+    expect(resolveAllowedIssueType("Bug", ["*"])).toBe("Bug");
+  });
+
+  it("returns the configured spelling for a case-insensitive match", () => {
+    expect(resolveAllowedIssueType("feature", ["Bug", "Feature"])).toBe("Feature");
+  });
+
+  it("returns an empty string for an empty type", () => {
+    expect(resolveAllowedIssueType("  ", ["Bug"])).toBe("");
+  });
+
+  it("throws when the type is not in the allowed-types list", () => {
+    expect(() => resolveAllowedIssueType("Epic", ["Bug", "Feature"])).toThrow('issue type "Epic" is not in the allowed-types list: Bug, Feature');
+  });
+});
+
 describe("BUILTIN_ISSUE_FIELD_NAMES", () => {
   it("is a Set", () => {
     expect(BUILTIN_ISSUE_FIELD_NAMES).toBeInstanceOf(Set);
@@ -21,6 +21,7 @@ const fs = require("fs");
 const path = require("path");
 const { withRetry, sleep } = require("./error_recovery.cjs");
 const { getErrorMessage } = require("./error_helpers.cjs");
+const { TMP_GH_AW_PATH } = require("./constants.cjs");
 
 // AWF API proxy management endpoint for discovering configured LLM providers and available models.
 // The api-proxy sidecar exposes /reflect on its management port (port 10000) inside the AWF
@@ -28,7 +29,7 @@ const { getErrorMessage } = require("./error_helpers.cjs");
 const AWF_API_PROXY_REFLECT_URL = "http://api-proxy:10000/reflect";
 // Path inside the agent container where the reflect payload is persisted. The directory is
 // co-located with other AWF firewall observability data so it is included in the agent artifact.
-const AWF_REFLECT_OUTPUT_PATH = "/tmp/gh-aw/sandbox/firewall/awf-reflect.json";
+const AWF_REFLECT_OUTPUT_PATH = `${TMP_GH_AW_PATH}/sandbox/firewall/awf-reflect.json`;
 // Milliseconds to wait for the /reflect endpoint before giving up.
 const AWF_REFLECT_TIMEOUT_MS = 60000;
 // Milliseconds to wait for each models_url fallback fetch (shorter than the main reflect timeout).
@@ -2,10 +2,11 @@
 /// <reference types="@actions/github-script" />
 
 const fs = require("fs");
+const { TMP_GH_AW_PATH } = require("./constants.cjs");
 
-const AWF_CONFIG_PATH = "/tmp/gh-aw/awf-config.json";
-const AWF_REFLECT_PATH = "/tmp/gh-aw/sandbox/firewall/awf-reflect.json";
-const AWF_MODELS_PATH = "/tmp/gh-aw/sandbox/firewall/models.json";
+const AWF_CONFIG_PATH = `${TMP_GH_AW_PATH}/awf-config.json`;
+const AWF_REFLECT_PATH = `${TMP_GH_AW_PATH}/sandbox/firewall/awf-reflect.json`;
+const AWF_MODELS_PATH = `${TMP_GH_AW_PATH}/sandbox/firewall/models.json`;
 
 /**
  * Read the AWF reflect payload that was persisted to disk by copilot_harness.cjs.
@@ -0,0 +1,98 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+const { ERR_API } = require("./error_codes.cjs");
+const { getErrorMessage } = require("./error_helpers.cjs");
+const { globPatternToRegex } = require("./glob_pattern_helpers.cjs");
+const { writeDenialSummary } = require("./pre_activation_summary.cjs");
+
+/** Events whose changed paths are already filtered by the native `paths:` filter. */
+const NATIVE_PATH_FILTER_EVENTS = ["push", "pull_request", "pull_request_target"];
+
+/**
+ * Returns the pull request number for events that carry one but have no native
+ * `paths:` filter (pull_request_review, pull_request_review_comment, and
+ * issue_comment on a pull request).
+ * @param {any} payload
+ * @returns {number | undefined}
+ */
+function resolvePullRequestNumber(payload) {
+  if (payload?.pull_request?.number) {
+    return payload.pull_request.number;
+  }
+  if (payload?.issue?.pull_request && payload.issue.number) {
+    return payload.issue.number;
+  }
+  return undefined;
+}
+
+/**
+ * Reports whether any changed file matches the configured path patterns.
+ * Patterns follow GitHub Actions `paths:` semantics: a pattern starting with `!`
+ * excludes files, and the last matching pattern decides whether a file counts.
+ * @param {string[]} files
+ * @param {string[]} patterns
+ * @returns {boolean}
+ */
+function matchesChangedPaths(files, patterns) {
+  const compiled = patterns.map(pattern => {
+    const negated = pattern.startsWith("!");
+    return { negated, regex: globPatternToRegex(negated ? pattern.slice(1) : pattern) };
+  });
+  return files.some(file => {
+    let included = false;
+    for (const { negated, regex } of compiled) {
+      if (regex.test(file)) {
+        included = !negated;
+      }
+    }
+    return included;
+  });
+}
+
+/**
+ * Check whether the pull request behind the triggering event touches any of the
+ * paths in on.filters.paths. Events without a pull request always pass.
+ */
+async function main() {
+  const patterns = JSON.parse(process.env.GH_AW_FILTER_PATHS || "[]");
+  if (NATIVE_PATH_FILTER_EVENTS.includes(context.eventName)) {
+    core.info(`✅ ${context.eventName} is filtered by the native paths filter, workflow will proceed`);
+    core.setOutput("changed_paths_ok", "true");
+    return;
+  }
+
+  const pullNumber = resolvePullRequestNumber(context.payload);
+  if (patterns.length === 0 || !pullNumber) {
+    core.info("✅ No pull request to filter by changed paths, workflow will proceed");
+    core.setOutput("changed_paths_ok", "true");
+    return;
+  }
+
+  try {
+    const files = await github.paginate(github.rest.pulls.listFiles, {
+      owner: context.repo.owner,
+      repo: context.repo.repo,
+      pull_number: pullNumber,
+      per_page: 100,
+    });
+    const filenames = files.map(file => file.filename);
+    core.info(`Pull request #${pullNumber} changes ${filenames.length} file(s)`);
+
+    if (matchesChangedPaths(filenames, patterns)) {
+      core.info(`✅ Pull request #${pullNumber} touches on.filters.paths [${patterns.join(", ")}]. Workflow will proceed.`);
+      core.setOutput("changed_paths_ok", "true");
+      return;
+    }
+
+    const errorMessage = `Workflow skipped: pull request #${pullNumber} does not touch any of [${patterns.join(", ")}]`;
+    core.info(`❌ ${errorMessage}`);
+    core.setOutput("changed_paths_ok", "false");
+    await writeDenialSummary(errorMessage, "Update `on.filters.paths:` in the workflow frontmatter to change which paths activate the workflow.");
+  } catch (error) {
+    core.setOutput("changed_paths_ok", "false");
+    core.setFailed(`${ERR_API}: Failed to list pull request files: ${getErrorMessage(error)}`);
+  }
+}
+
+module.exports = { main, resolvePullRequestNumber, matchesChangedPaths };
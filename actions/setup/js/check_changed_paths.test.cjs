@@ -0,0 +1,140 @@
+import { describe, it, expect, beforeEach, afterEach, vi } from "vitest";
+
+describe("check_changed_paths.cjs", () => {
+  let mockCore;
+  let mockGithub;
+  let mockContext;
+
+  beforeEach(() => {
+    mockCore = {
+      info: vi.fn(),
+      warning: vi.fn(),
+      error: vi.fn(),
+      setFailed: vi.fn(),
+      setOutput: vi.fn(),
+      summary: {
+        addRaw: vi.fn().mockReturnThis(),
+        write: vi.fn().mockResolvedValue(undefined),
+      },
+    };
+
+    mockGithub = {
+      rest: {
+        pulls: {
+          listFiles: vi.fn(),
+        },
+      },
+      paginate: vi.fn(),
+    };
+
+    mockContext = {
+      repo: { owner: "test-owner", repo: "test-repo" },
+      eventName: "pull_request_review",
+      payload: {},
+    };
+
+    global.core = mockCore;
+    global.github = mockGithub;
+    global.context = mockContext;
+
+    vi.resetModules();
+  });
+
+  afterEach(() => {
+    vi.clearAllMocks();
+    delete global.core;
+    delete global.github;
+    delete global.context;
+    delete process.env.GH_AW_FILTER_PATHS;
+  });
+
+  describe("resolvePullRequestNumber", () => {
+    it("should read the pull request from review events", async () => {
+      const { resolvePullRequestNumber } = await import("./check_changed_paths.cjs");
+      expect(resolvePullRequestNumber({ pull_request: { number: 7 } })).toBe(7);
+    });
+
+    it("should read the pull request from comments on pull requests", async () => {
+      const { resolvePullRequestNumber } = await import("./check_changed_paths.cjs");
+      expect(resolvePullRequestNumber({ issue: { number: 9, pull_request: { url: "x" } } })).toBe(9);
+    });
+
+    it("should ignore comments on plain issues", async () => {
+      const { resolvePullRequestNumber } = await import("./check_changed_paths.cjs");
+      expect(resolvePullRequestNumber({ issue: { number: 9 } })).toBeUndefined();
+    });
+  });
+
+  describe("matchesChangedPaths", () => {
+    it("should match files under a directory glob", async () => {
+      const { matchesChangedPaths } = await import("./check_changed_paths.cjs");
+      expect(matchesChangedPaths(["docs/guide/intro.md"], ["docs/**"])).toBe(true);
+      expect(matchesChangedPaths(["src/main.go"], ["docs/**"])).toBe(false);
+    });
+
+    it("should let a later negated pattern exclude a file", async () => {
+      const { matchesChangedPaths } = await import("./check_changed_paths.cjs");
+      expect(matchesChangedPaths(["docs/generated/api.md"], ["docs/**", "!docs/generated/**"])).toBe(false);
+      expect(matchesChangedPaths(["docs/generated/api.md", "docs/index.md"], ["docs/**", "!docs/generated/**"])).toBe(true);
+    });
+  });
+
+  it("should not re-check events with a native paths filter", async () => {
+    process.env.GH_AW_FILTER_PATHS = JSON.stringify(["docs/**"]);
+    mockContext.eventName = "pull_request";
+    mockContext.payload = { pull_request: { number: 12 } };
+
+    const { main } = await import("./check_changed_paths.cjs");
+    await main();
+
+    expect(mockGithub.paginate).not.toHaveBeenCalled();
+    expect(mockCore.setOutput).toHaveBeenCalledWith("changed_paths_ok", "true");
+  });
+
+  it("should pass events without a pull request", async () => {
+    process.env.GH_AW_FILTER_PATHS = JSON.stringify(["docs/**"]);
+    mockContext.payload = { issue: { number: 3 } };
+
+    const { main } = await import("./check_changed_paths.cjs");
+    await main();
+
+    expect(mockGithub.paginate).not.toHaveBeenCalled();
+    expect(mockCore.setOutput).toHaveBeenCalledWith("changed_paths_ok", "true");
+  });
+
+  it("should pass when the pull request touches a configured path", async () => {
+    process.env.GH_AW_FILTER_PATHS = JSON.stringify(["docs/**"]);
+    mockContext.payload = { pull_request: { number: 12 } };
+    mockGithub.paginate.mockResolvedValue([{ filename: "README.md" }, { filename: "docs/setup.md" }]);
+
+    const { main } = await import("./check_changed_paths.cjs");
+    await main();
+
+    expect(mockGithub.paginate).toHaveBeenCalledWith(mockGithub.rest.pulls.listFiles, expect.objectContaining({ pull_number: 12 }));
+    expect(mockCore.setOutput).toHaveBeenCalledWith("changed_paths_ok", "true");
+  });
+
+  it("should skip when no changed file matches", async () => {
+    process.env.GH_AW_FILTER_PATHS = JSON.stringify(["docs/**"]);
+    mockContext.payload = { pull_request: { number: 12 } };
+    mockGithub.paginate.mockResolvedValue([{ filename: "pkg/main.go" }]);
+
+    const { main } = await import("./check_changed_paths.cjs");
+    await main();
+
+    expect(mockCore.setOutput).toHaveBeenCalledWith("changed_paths_ok", "false");
+    expect(mockCore.setFailed).not.toHaveBeenCalled();
+  });
+
+  it("should fail when the files cannot be listed", async () => {
+    process.env.GH_AW_FILTER_PATHS = JSON.stringify(["docs/**"]);
+    mockContext.payload = { pull_request: { number: 12 } };
+    mockGithub.paginate.mockRejectedValue(new Error("boom"));
+
+    const { main } = await import("./check_changed_paths.cjs");
+    await main();
+
+    expect(mockCore.setOutput).toHaveBeenCalledWith("changed_paths_ok", "false");
+    expect(mockCore.setFailed).toHaveBeenCalledWith(expect.stringContaining("boom"));
+  });
+});
@@ -4,6 +4,39 @@
 const { ERR_API, ERR_CONFIG, ERR_VALIDATION } = require("./error_codes.cjs");
 const { writeDenialSummary } = require("./pre_activation_summary.cjs");
 const { matchesCommandName, resolveMatchedCommand } = require("./slash_command_matcher.cjs");
+const { parseSubcommandInvocation } = require("./slash_command_subcommand.cjs");
+
+/**
+ * Parse the subcommand and arguments following a matched command and validate them
+ * against the subcommands declared in GH_AW_COMMAND_SUBCOMMANDS. On success the
+ * subcommand and a JSON object of its arguments are set as step outputs. Rejected
+ * invocations (including "help") write the generated help text to the step summary.
+ * @param {string} text - Triggering text starting with the matched command
+ * @param {string} matchedCommand - The matched command name
+ * @returns {Promise<boolean>} Whether the workflow should run
+ */
+async function checkSubcommand(text, matchedCommand) {
+  const subcommands = JSON.parse(process.env.GH_AW_COMMAND_SUBCOMMANDS || "[]");
+  const invocation = parseSubcommandInvocation(text, subcommands);
+  const help = process.env.GH_AW_COMMAND_HELP || "";
+
+  if (invocation.subcommand) {
+    core.info(`✓ Subcommand '${invocation.subcommand}' resolved with arguments: ${Object.keys(invocation.args).join(", ") || "(none)"}`);
+    core.setOutput("subcommand", invocation.subcommand);
+    core.setOutput("command_args", JSON.stringify(invocation.args));
+    return true;
+  }
+
+  if (invocation.help && !invocation.error) {
+    core.info(`Help requested for /${matchedCommand}. Workflow will be skipped.`);
+    await writeDenialSummary(`Help for \`/${matchedCommand}\` was requested.`, `Available subcommands:\n\n\`\`\`text\n${help}\n\`\`\``);
+    return false;
+  }
+
+  core.warning(`⚠️ ${invocation.error} Workflow will be skipped.`);
+  await writeDenialSummary(`${ERR_VALIDATION}: ${invocation.error}`, `Use one of the subcommands declared in \`on.slash_command.subcommands\`:\n\n\`\`\`text\n${help}\n\`\`\``);
+  return false;
+}
 
 /**
  * Check if command is the first word in the triggering text
@@ -127,6 +160,11 @@ async function main() {
 
     if (matchedCommand) {
       core.info(`✓ Command '/${matchedCommand}' matched at the start of the text`);
+      if (process.env.GH_AW_COMMAND_SUBCOMMANDS && !(await checkSubcommand(trimmedText, matchedCommand))) {
+        core.setOutput("command_position_ok", "false");
+        core.setOutput("matched_command", "");
+        return;
+      }
       core.setOutput("command_position_ok", "true");
       core.setOutput("matched_command", matchedCommand);
     } else {
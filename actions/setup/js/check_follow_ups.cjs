@@ -0,0 +1,28 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+const { readFollowUpQueue, splitDueFollowUps } = require("./follow_up_queue.cjs");
+
+/**
+ * Pre-activation check for the follow-up polling schedule.
+ * Scheduled runs only proceed when a follow-up in the restored queue is due;
+ * runs started by any other event always proceed.
+ * @returns {Promise<void>}
+ */
+async function main() {
+  if (context.eventName !== "schedule") {
+    core.setOutput("follow_ups_ok", "true");
+    return;
+  }
+
+  const queue = readFollowUpQueue();
+  const { due, pending } = splitDueFollowUps(queue, new Date());
+  core.info(`Follow-up queue: ${due.length} due, ${pending.length} pending`);
+  if (due.length === 0) {
+    const next = pending[0]?.due_at;
+    core.info(`No follow-up is due${next ? ` (next due ${next})` : ""}, skipping this scheduled run`);
+  }
+  core.setOutput("follow_ups_ok", due.length > 0 ? "true" : "false");
+}
+
+module.exports = { main };
@@ -0,0 +1,243 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+/**
+ * Check the latency SLOs declared in observability.slo against the timings of this run.
+ * This script runs in the conclusion job, after the agent job has finished, so the start
+ * and completion times of the agent job are known. Breaches are annotated on the run and,
+ * when GH_AW_SLO_NOTIFY is set, posted to the "[aw] SLO Breaches" tracking issue.
+ * SLO checks never fail the workflow.
+ */
+
+const fs = require("fs");
+const { getErrorMessage } = require("./error_helpers.cjs");
+const { ERR_API } = require("./error_codes.cjs");
+const { sanitizeContent } = require("./sanitize_content.cjs");
+const { generateFooterWithExpiration } = require("./ephemerals.cjs");
+const { renderTemplateFromFile, getPromptPath } = require("./messages_core.cjs");
+
+/**
+ * @typedef {Object} RunJob
+ * @property {string} name - Display name of the job
+ * @property {string | null | undefined} [created_at]
+ * @property {string | null | undefined} [started_at]
+ * @property {string | null | undefined} [completed_at]
+ */
+
+/**
+ * @typedef {Object} SLOBreach
+ * @property {string} slo - "start" or "finish"
+ * @property {number} limitSeconds
+ * @property {number} actualSeconds
+ */
+
+/**
+ * Formats a number of seconds as a short duration (e.g. "4m 12s").
+ * @param {number} seconds
+ * @returns {string}
+ */
+function formatDuration(seconds) {
+  const total = Math.max(0, Math.round(seconds));
+  const hours = Math.floor(total / 3600);
+  const minutes = Math.floor((total % 3600) / 60);
+  const secs = total % 60;
+  if (hours > 0) {
+    return `${hours}h ${minutes}m`;
+  }
+  if (minutes > 0) {
+    return `${minutes}m ${secs}s`;
+  }
+  return `${secs}s`;
+}
+
+/**
+ * Returns the seconds between two ISO timestamps, or null when either is missing.
+ * @param {string | null | undefined} from
+ * @param {string | null | undefined} to
+ * @returns {number | null}
+ */
+function secondsBetween(from, to) {
+  if (!from || !to) {
+    return null;
+  }
+  const delta = (Date.parse(to) - Date.parse(from)) / 1000;
+  return Number.isFinite(delta) ? delta : null;
+}
+
+/**
+ * Finds the agent job of the run. Matrix and reusable jobs carry a suffix after the job ID.
+ * @param {RunJob[]} jobs
+ * @param {string} agentJobName
+ * @returns {RunJob | undefined}
+ */
+function findAgentJob(jobs, agentJobName) {
+  return jobs.find(job => job.name === agentJobName || job.name.startsWith(`${agentJobName} (`));
+}
+
+/**
+ * Compares the agent job timings with the SLO limits.
+ * @param {string} runStartedAt - When the run attempt started
+ * @param {RunJob} agentJob
+ * @param {{start?: number, finish?: number}} limits - SLO limits in seconds
+ * @returns {SLOBreach[]}
+ */
+function evaluateSLOs(runStartedAt, agentJob, limits) {
+  /** @type {SLOBreach[]} */
+  const breaches = [];
+  const start = secondsBetween(runStartedAt, agentJob.started_at);
+  if (limits.start && start !== null && start > limits.start) {
+    breaches.push({ slo: "start", limitSeconds: limits.start, actualSeconds: start });
+  }
+  const finish = secondsBetween(runStartedAt, agentJob.completed_at);
+  if (limits.finish && finish !== null && finish > limits.finish) {
+    breaches.push({ slo: "finish", limitSeconds: limits.finish, actualSeconds: finish });
+  }
+  return breaches;
+}
+
+/**
+ * Renders the timing breakdown of the run's jobs as a markdown table.
+ * @param {string} runStartedAt
+ * @param {RunJob[]} jobs
+ * @returns {string}
+ */
+function renderTimingTable(runStartedAt, jobs) {
+  const rows = jobs
+    .filter(job => job.started_at)
+    .sort((a, b) => Date.parse(a.started_at || "") - Date.parse(b.started_at || ""))
+    .map(job => {
+      const queued = secondsBetween(job.created_at, job.started_at);
+      const startedAfter = secondsBetween(runStartedAt, job.started_at);
+      const duration = secondsBetween(job.started_at, job.completed_at);
+      const cell = (/** @type {number | null} */ value) => (value === null ? "—" : formatDuration(value));
+      return `| ${job.name} | ${cell(queued)} | ${cell(startedAfter)} | ${cell(duration)} |`;
+    });
+  return ["| Job | Queued | Started after | Duration |", "| --- | --- | --- | --- |", ...rows].join("\n");
+}
+
+/**
+ * Describes a breach for annotations and the tracking issue.
+ * @param {SLOBreach} breach
+ * @param {string} agentJobName
+ * @returns {string}
+ */
+function describeBreach(breach, agentJobName) {
+  const verb = breach.slo === "start" ? "started" : "finished";
+  return `The ${agentJobName} job ${verb} ${formatDuration(breach.actualSeconds)} after the run started (SLO: ${breach.slo} within ${formatDuration(breach.limitSeconds)})`;
+}
+
+/**
+ * Search for or create the tracking issue for SLO breaches.
+ * @returns {Promise<{number: number}>} Tracking issue number
+ */
+async function ensureSLOBreachesIssue() {
+  const { owner, repo } = context.repo;
+  const title = "[aw] SLO Breaches";
+  const label = "agentic-workflows";
+
+  const searchQuery = `repo:${owner}/${repo} is:issue is:open label:${label} in:title "${title}"`;
+  try {
+    const { data } = await github.rest.search.issuesAndPullRequests({ q: searchQuery, per_page: 1 });
+    if (data.total_count > 0) {
+      core.info(`Found existing SLO breaches issue #${data.items[0].number}`);
+      return { number: data.items[0].number };
+    }
+  } catch (error) {
+    throw new Error(`${ERR_API}: Failed to search for existing SLO breaches issue: ${getErrorMessage(error)}`, { cause: error });
+  }
+
+  const templatePath = getPromptPath("slo_breaches_issue.md");
+  let bodyContent;
+  try {
+    bodyContent = fs.readFileSync(templatePath, "utf8");
+  } catch (err) {
+    throw new Error(`Failed to read file ${templatePath}: ${String(err)}`, { cause: err });
+  }
+  const body = generateFooterWithExpiration({
+    footerText: bodyContent,
+    expiresHours: 24 * 30, // 30 days
+  });
+
+  const { data: newIssue } = await github.rest.issues.create({ owner, repo, title, body, labels: [label] });
+  core.info(`✓ Created SLO breaches issue #${newIssue.number}: ${newIssue.html_url}`);
+  return { number: newIssue.number };
+}
+
+async function main() {
+  try {
+    const agentJobName = process.env.GH_AW_SLO_AGENT_JOB || "agent";
+    const limits = {
+      start: Number(process.env.GH_AW_SLO_START_SECONDS || 0),
+      finish: Number(process.env.GH_AW_SLO_FINISH_SECONDS || 0),
+    };
+    const notify = process.env.GH_AW_SLO_NOTIFY === "true";
+    const workflowName = process.env.GH_AW_WORKFLOW_NAME || "unknown";
+    const runUrl = process.env.GH_AW_RUN_URL || "";
+    const attempt = Number(process.env.GITHUB_RUN_ATTEMPT || 1);
+    const { owner, repo } = context.repo;
+
+    const { data: run } = await github.rest.actions.getWorkflowRunAttempt({ owner, repo, run_id: context.runId, attempt_number: attempt });
+    const jobs = /** @type {RunJob[]} */ (
+      await github.paginate(github.rest.actions.listJobsForWorkflowRunAttempt, {
+        owner,
+        repo,
+        run_id: context.runId,
+        attempt_number: attempt,
+        per_page: 100,
+      })
+    );
+    const runStartedAt = run.run_started_at || run.created_at;
+
+    const agentJob = findAgentJob(jobs, agentJobName);
+    if (!agentJob || !agentJob.started_at) {
+      core.info(`The ${agentJobName} job did not run; skipping latency SLO checks`);
+      return;
+    }
+
+    const breaches = evaluateSLOs(runStartedAt, agentJob, limits);
+    core.setOutput("breaches", String(breaches.length));
+
+    const summary = core.summary.addRaw("### ⏱️ Latency SLOs\n\n").addRaw(`${renderTimingTable(runStartedAt, jobs)}\n\n`);
+    if (breaches.length === 0) {
+      summary.addRaw("All latency SLOs were met.\n");
+    }
+    for (const breach of breaches) {
+      const message = describeBreach(breach, agentJobName);
+      core.warning(message, { title: `Latency SLO breached: ${breach.slo}` });
+      summary.addRaw(`- ⚠️ ${message}\n`);
+    }
+    await summary.write();
+
+    if (breaches.length === 0 || !notify) {
+      return;
+    }
+
+    let issue;
+    try {
+      issue = await ensureSLOBreachesIssue();
+    } catch (error) {
+      core.warning(`Could not create SLO breaches issue: ${getErrorMessage(error)}`);
+      return;
+    }
+    const commentBody = renderTemplateFromFile(getPromptPath("slo_breach_comment.md"), {
+      workflow_name: workflowName,
+      breaches: breaches.map(breach => `- ${describeBreach(breach, agentJobName)}`).join("\n"),
+      run_url: runUrl,
+    });
+    try {
+      await github.rest.issues.createComment({
+        owner,
+        repo,
+        issue_number: issue.number,
+        body: sanitizeContent(commentBody, { maxLength: 65000 }),
+      });
+      core.info(`✓ Posted SLO breach comment to issue #${issue.number}`);
+    } catch (error) {
+      core.warning(`Failed to post comment to SLO breaches issue: ${getErrorMessage(error)}`);
+    }
+  } catch (error) {
+    core.warning(`Error in check_latency_slo: ${getErrorMessage(error)}`);
+  }
+}
+
+module.exports = { main, formatDuration, findAgentJob, evaluateSLOs, renderTimingTable, describeBreach };
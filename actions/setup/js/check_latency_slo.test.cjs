@@ -0,0 +1,138 @@
+// @ts-check
+import { describe, it, expect, beforeEach, afterEach, vi } from "vitest";
+import fs from "fs";
+import os from "os";
+import path from "path";
+
+describe("check_latency_slo", () => {
+  let mockCore;
+  let mockGithub;
+  let checkLatencySLO;
+  let tempDir;
+
+  const jobs = [
+    { name: "pre_activation", created_at: "2026-01-01T00:00:00Z", started_at: "2026-01-01T00:00:10Z", completed_at: "2026-01-01T00:00:40Z" },
+    { name: "activation", created_at: "2026-01-01T00:00:40Z", started_at: "2026-01-01T00:01:00Z", completed_at: "2026-01-01T00:01:30Z" },
+    { name: "agent", created_at: "2026-01-01T00:01:30Z", started_at: "2026-01-01T00:04:00Z", completed_at: "2026-01-01T00:16:00Z" },
+    { name: "conclusion", created_at: "2026-01-01T00:16:00Z", started_at: "2026-01-01T00:16:05Z", completed_at: null },
+  ];
+
+  beforeEach(async () => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), "check-latency-slo-test-"));
+    fs.writeFileSync(path.join(tempDir, "slo_breaches_issue.md"), "Tracks SLO breaches.");
+    fs.writeFileSync(path.join(tempDir, "slo_breach_comment.md"), "### {workflow_name}\n\n{breaches}\n\n> Generated from [{workflow_name}]({run_url})\n");
+    process.env.GH_AW_PROMPTS_DIR = tempDir;
+
+    mockCore = {
+      debug: vi.fn(),
+      info: vi.fn(),
+      warning: vi.fn(),
+      setOutput: vi.fn(),
+      summary: {
+        addRaw: vi.fn().mockReturnThis(),
+        write: vi.fn().mockResolvedValue(undefined),
+      },
+    };
+    mockGithub = {
+      paginate: vi.fn().mockResolvedValue(jobs),
+      rest: {
+        actions: {
+          getWorkflowRunAttempt: vi.fn().mockResolvedValue({ data: { run_started_at: "2026-01-01T00:00:00Z" } }),
+          listJobsForWorkflowRunAttempt: vi.fn(),
+        },
+        search: {
+          issuesAndPullRequests: vi.fn().mockResolvedValue({ data: { total_count: 1, items: [{ number: 42 }] } }),
+        },
+        issues: {
+          create: vi.fn(),
+          createComment: vi.fn().mockResolvedValue({}),
+        },
+      },
+    };
+    global.core = mockCore;
+    global.github = mockGithub;
+    global.context = { repo: { owner: "octo", repo: "demo" }, runId: 123 };
+
+    process.env.GH_AW_SLO_AGENT_JOB = "agent";
+    process.env.GH_AW_SLO_START_SECONDS = "180";
+    process.env.GH_AW_SLO_FINISH_SECONDS = "1200";
+    process.env.GH_AW_WORKFLOW_NAME = "Triage";
+    process.env.GH_AW_RUN_URL = "https://github.com/octo/demo/actions/runs/123";
+
+    vi.resetModules();
+    checkLatencySLO = await import("./check_latency_slo.cjs");
+  });
+
+  afterEach(() => {
+    for (const name of ["GH_AW_PROMPTS_DIR", "GH_AW_SLO_AGENT_JOB", "GH_AW_SLO_START_SECONDS", "GH_AW_SLO_FINISH_SECONDS", "GH_AW_SLO_NOTIFY", "GH_AW_WORKFLOW_NAME", "GH_AW_RUN_URL"]) {
+      delete process.env[name];
+    }
+    delete global.core;
+    delete global.github;
+    delete global.context;
+    fs.rmSync(tempDir, { recursive: true, force: true });
+    vi.clearAllMocks();
+  });
+
+  it("formats durations", () => {
+    expect(checkLatencySLO.formatDuration(42)).toBe("42s");
+    expect(checkLatencySLO.formatDuration(252)).toBe("4m 12s");
+    expect(checkLatencySLO.formatDuration(3900)).toBe("1h 5m");
+  });
+
+  it("finds matrix agent jobs by their job ID prefix", () => {
+    expect(checkLatencySLO.findAgentJob([{ name: "agent (linux)" }], "agent")).toEqual({ name: "agent (linux)" });
+    expect(checkLatencySLO.findAgentJob([{ name: "agentic" }], "agent")).toBeUndefined();
+  });
+
+  it("reports only the breached SLOs", () => {
+    const breaches = checkLatencySLO.evaluateSLOs("2026-01-01T00:00:00Z", jobs[2], { start: 180, finish: 1200 });
+
+    expect(breaches).toEqual([{ slo: "start", limitSeconds: 180, actualSeconds: 240 }]);
+  });
+
+  it("annotates breaches without notifying by default", async () => {
+    await checkLatencySLO.main();
+
+    expect(mockCore.warning).toHaveBeenCalledWith(expect.stringContaining("The agent job started 4m 0s after the run started (SLO: start within 3m 0s)"), { title: "Latency SLO breached: start" });
+    expect(mockCore.setOutput).toHaveBeenCalledWith("breaches", "1");
+    expect(mockCore.summary.write).toHaveBeenCalled();
+    expect(mockGithub.rest.issues.createComment).not.toHaveBeenCalled();
+  });
+
+  it("posts breaches to the tracking issue when notify is enabled", async () => {
+    process.env.GH_AW_SLO_NOTIFY = "true";
+
+    await checkLatencySLO.main();
+
+    expect(mockGithub.rest.issues.createComment).toHaveBeenCalledWith(expect.objectContaining({ issue_number: 42, body: expect.stringContaining("### Triage") }));
+  });
+
+  it("does not annotate when the SLOs are met", async () => {
+    process.env.GH_AW_SLO_START_SECONDS = "300";
+    process.env.GH_AW_SLO_NOTIFY = "true";
+
+    await checkLatencySLO.main();
+
+    expect(mockCore.warning).not.toHaveBeenCalled();
+    expect(mockCore.setOutput).toHaveBeenCalledWith("breaches", "0");
+    expect(mockGithub.rest.issues.createComment).not.toHaveBeenCalled();
+  });
+
+  it("skips the checks when the agent job did not run", async () => {
+    mockGithub.paginate.mockResolvedValue(jobs.filter(job => job.name !== "agent"));
+
+    await checkLatencySLO.main();
+
+    expect(mockCore.setOutput).not.toHaveBeenCalled();
+    expect(mockCore.warning).not.toHaveBeenCalled();
+  });
+
+  it("warns instead of failing when the API call fails", async () => {
+    mockGithub.rest.actions.getWorkflowRunAttempt.mockRejectedValue(new Error("Resource not accessible by integration"));
+
+    await checkLatencySLO.main();
+
+    expect(mockCore.warning).toHaveBeenCalledWith(expect.stringContaining("Error in check_latency_slo"));
+  });
+});
@@ -0,0 +1,172 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+const { ERR_API } = require("./error_codes.cjs");
+const { getErrorMessage } = require("./error_helpers.cjs");
+const { checkRepositoryPermission, parseRequiredPermissions } = require("./check_permissions_utils.cjs");
+const { writeDenialSummary } = require("./pre_activation_summary.cjs");
+
+/** Upper bound on how far back a poll looks, even when the previous run is older. */
+const MAX_LOOKBACK_MS = 24 * 60 * 60 * 1000;
+
+/** Number of recently updated issues and comments scanned for reactions. */
+const SCAN_PAGE_SIZE = 100;
+
+/**
+ * @typedef {Object} ReactionMatch
+ * @property {number} itemNumber - Issue or pull request number
+ * @property {string} commentId - Comment ID, or "" when the reaction is on the issue itself
+ * @property {string} user - Login of the user who reacted
+ * @property {string} createdAt - When the reaction was added
+ */
+
+/**
+ * Returns when the previous scheduled run of this workflow started, so that each poll
+ * picks up where the last one left off. Falls back to one polling interval ago.
+ * @param {number} intervalMinutes
+ * @param {Date} now
+ * @returns {Promise<Date>}
+ */
+async function resolvePollWindowStart(intervalMinutes, now) {
+  const fallback = new Date(now.getTime() - intervalMinutes * 60 * 1000);
+  const workflowFile = (process.env.GITHUB_WORKFLOW_REF || "").match(/\/\.github\/workflows\/([^@]+)@/)?.[1];
+  if (!workflowFile) {
+    return fallback;
+  }
+
+  try {
+    const { data } = await github.rest.actions.listWorkflowRuns({
+      owner: context.repo.owner,
+      repo: context.repo.repo,
+      workflow_id: workflowFile,
+      event: "schedule",
+      per_page: 5,
+    });
+    const previous = data.workflow_runs.find(run => run.id !== context.runId);
+    if (!previous) {
+      return fallback;
+    }
+    const start = new Date(previous.created_at);
+    return new Date(Math.max(start.getTime(), now.getTime() - MAX_LOOKBACK_MS));
+  } catch (error) {
+    core.warning(`Could not list previous scheduled runs, using the polling interval instead: ${getErrorMessage(error)}`);
+    return fallback;
+  }
+}
+
+/**
+ * Finds reactions with the given emoji added since `since` on recently updated issues,
+ * pull requests, and issue comments. Only items whose reaction summary already counts
+ * the emoji are inspected, which keeps the number of API calls small.
+ * @param {string} emoji
+ * @param {string[]} events - Any of "issues", "pull_request", "issue_comment"
+ * @param {Date} since
+ * @returns {Promise<ReactionMatch[]>} Matches sorted from oldest to newest
+ */
+async function findReactionMatches(emoji, events, since) {
+  const { owner, repo } = context.repo;
+  /** @type {ReactionMatch[]} */
+  const matches = [];
+
+  /** @param {any[]} reactions @param {number} itemNumber @param {string} commentId */
+  const collect = (reactions, itemNumber, commentId) => {
+    for (const reaction of reactions) {
+      if (reaction.user?.login && new Date(reaction.created_at) >= since) {
+        matches.push({ itemNumber, commentId, user: reaction.user.login, createdAt: reaction.created_at });
+      }
+    }
+  };
+
+  if (events.includes("issues") || events.includes("pull_request")) {
+    const { data: issues } = await github.rest.issues.listForRepo({ owner, repo, state: "open", sort: "updated", direction: "desc", per_page: SCAN_PAGE_SIZE });
+    for (const issue of issues) {
+      const type = issue.pull_request ? "pull_request" : "issues";
+      if (!events.includes(type) || !(issue.reactions?.[emoji] > 0)) {
+        continue;
+      }
+      const { data: reactions } = await github.rest.reactions.listForIssue({ owner, repo, issue_number: issue.number, content: emoji, per_page: 100 });
+      collect(reactions, issue.number, "");
+    }
+  }
+
+  if (events.includes("issue_comment")) {
+    const { data: comments } = await github.rest.issues.listCommentsForRepo({ owner, repo, sort: "updated", direction: "desc", per_page: SCAN_PAGE_SIZE });
+    for (const comment of comments) {
+      if (!(comment.reactions?.[emoji] > 0)) {
+        continue;
+      }
+      const itemNumber = Number(String(comment.issue_url).split("/").pop());
+      const { data: reactions } = await github.rest.reactions.listForIssueComment({ owner, repo, comment_id: comment.id, content: emoji, per_page: 100 });
+      collect(reactions, itemNumber, String(comment.id));
+    }
+  }
+
+  return matches.sort((a, b) => new Date(a.createdAt).getTime() - new Date(b.createdAt).getTime());
+}
+
+/**
+ * @param {boolean} ok
+ * @param {ReactionMatch | null} match
+ */
+function setReactionOutputs(ok, match) {
+  core.setOutput("reaction_command_ok", ok ? "true" : "false");
+  core.setOutput("reaction_item_number", match ? String(match.itemNumber) : "");
+  core.setOutput("reaction_comment_id", match ? match.commentId : "");
+  core.setOutput("reaction_user", match ? match.user : "");
+}
+
+/**
+ * Polls for a reaction that activates the workflow. Only scheduled runs are gated;
+ * manual workflow_dispatch runs always proceed. The oldest matching reaction added
+ * by a user with one of the required roles since the previous scheduled run wins.
+ */
+async function main() {
+  const emoji = process.env.GH_AW_REACTION_COMMAND_EMOJI || "";
+  const events = JSON.parse(process.env.GH_AW_REACTION_COMMAND_EVENTS || "[]");
+  const intervalMinutes = parseInt(process.env.GH_AW_REACTION_COMMAND_INTERVAL_MINUTES || "15", 10) || 15;
+  const requiredRoles = parseRequiredPermissions();
+
+  if (context.eventName !== "schedule") {
+    core.info(`Event ${context.eventName} does not require a reaction check`);
+    setReactionOutputs(true, null);
+    return;
+  }
+
+  try {
+    const since = await resolvePollWindowStart(intervalMinutes, new Date());
+    core.info(`Looking for '${emoji}' reactions on ${events.join(", ")} added since ${since.toISOString()}`);
+    const matches = await findReactionMatches(emoji, events, since);
+
+    /** @type {string[]} */
+    const unauthorized = [];
+    for (const [index, match] of matches.entries()) {
+      const { authorized } = requiredRoles.length === 0 ? { authorized: true } : await checkRepositoryPermission(match.user, context.repo.owner, context.repo.repo, requiredRoles);
+      if (!authorized) {
+        unauthorized.push(match.user);
+        continue;
+      }
+      core.info(`✓ '${emoji}' reaction by @${match.user} on #${match.itemNumber}${match.commentId ? ` (comment ${match.commentId})` : ""}`);
+      const remaining = matches.length - index - 1;
+      if (remaining > 0) {
+        core.warning(`${remaining} more '${emoji}' reaction(s) were found in this polling window and will not be processed. Use a shorter on.reaction_command.interval to handle them separately.`);
+      }
+      setReactionOutputs(true, match);
+      return;
+    }
+
+    setReactionOutputs(false, null);
+    if (unauthorized.length > 0) {
+      core.warning(`Ignored '${emoji}' reactions from users without the required roles: ${[...new Set(unauthorized)].join(", ")}`);
+      await writeDenialSummary(
+        `Only users without the required roles (${requiredRoles.join(", ")}) added a '${emoji}' reaction since the last poll.`,
+        "Ask a user with one of the roles in `on.roles` to add the reaction, or update `on.roles` in the workflow frontmatter."
+      );
+      return;
+    }
+    core.info(`No new '${emoji}' reactions found. Workflow will be skipped.`);
+  } catch (error) {
+    core.setFailed(`${ERR_API}: Failed to check reactions: ${getErrorMessage(error)}`);
+  }
+}
+
+module.exports = { main, resolvePollWindowStart, findReactionMatches };
@@ -0,0 +1,171 @@
+import { describe, it, expect, beforeEach, afterEach, vi } from "vitest";
+
+const NOW = new Date("2026-10-15T12:00:00Z").getTime();
+const minutesAgo = minutes => new Date(NOW - minutes * 60 * 1000).toISOString();
+
+describe("check_reaction_command.cjs", () => {
+  let mockCore;
+  let mockGithub;
+  let outputs;
+
+  beforeEach(() => {
+    vi.useFakeTimers();
+    vi.setSystemTime(NOW);
+    outputs = {};
+    mockCore = {
+      debug: vi.fn(),
+      info: vi.fn(),
+      warning: vi.fn(),
+      setFailed: vi.fn(),
+      setOutput: vi.fn((name, value) => {
+        outputs[name] = value;
+      }),
+      summary: {
+        addRaw: vi.fn().mockReturnThis(),
+        write: vi.fn().mockResolvedValue(),
+      },
+    };
+    mockGithub = {
+      rest: {
+        actions: {
+          listWorkflowRuns: vi.fn().mockResolvedValue({
+            data: {
+              workflow_runs: [
+                { id: 2, created_at: minutesAgo(0) },
+                { id: 1, created_at: minutesAgo(10) },
+              ],
+            },
+          }),
+        },
+        issues: {
+          listForRepo: vi.fn().mockResolvedValue({
+            data: [
+              { number: 5, reactions: { eyes: 2 } },
+              { number: 6, pull_request: {}, reactions: { eyes: 1 } },
+              { number: 7, reactions: { eyes: 0 } },
+            ],
+          }),
+          listCommentsForRepo: vi.fn().mockResolvedValue({
+            data: [{ id: 99, issue_url: "https://api.github.com/repos/testorg/testrepo/issues/8", reactions: { eyes: 1 } }],
+          }),
+        },
+        reactions: {
+          listForIssue: vi.fn().mockResolvedValue({
+            data: [
+              { user: { login: "earlier" }, created_at: minutesAgo(60) },
+              { user: { login: "maintainer" }, created_at: minutesAgo(2) },
+            ],
+          }),
+          listForIssueComment: vi.fn().mockResolvedValue({
+            data: [{ user: { login: "reader" }, created_at: minutesAgo(5) }],
+          }),
+        },
+        repos: {
+          getCollaboratorPermissionLevel: vi.fn(async ({ username }) => ({ data: { permission: username === "reader" ? "read" : "write" } })),
+        },
+      },
+    };
+
+    global.core = mockCore;
+    global.github = mockGithub;
+    global.context = { eventName: "schedule", runId: 2, repo: { owner: "testorg", repo: "testrepo" } };
+    process.env.GITHUB_WORKFLOW_REF = "testorg/testrepo/.github/workflows/look.lock.yml@refs/heads/main";
+    process.env.GH_AW_REACTION_COMMAND_EMOJI = "eyes";
+    process.env.GH_AW_REACTION_COMMAND_EVENTS = JSON.stringify(["issues", "issue_comment"]);
+    process.env.GH_AW_REACTION_COMMAND_INTERVAL_MINUTES = "15";
+    process.env.GH_AW_REQUIRED_ROLES = "admin,maintainer,write";
+  });
+
+  afterEach(() => {
+    vi.useRealTimers();
+    delete global.core;
+    delete global.github;
+    delete global.context;
+    delete process.env.GITHUB_WORKFLOW_REF;
+    delete process.env.GH_AW_REACTION_COMMAND_EMOJI;
+    delete process.env.GH_AW_REACTION_COMMAND_EVENTS;
+    delete process.env.GH_AW_REACTION_COMMAND_INTERVAL_MINUTES;
+    delete process.env.GH_AW_REQUIRED_ROLES;
+  });
+
+  const loadModule = async () => {
+    vi.resetModules();
+    return await import("./check_reaction_command.cjs");
+  };
+
+  it("should pass through non-scheduled runs", async () => {
+    global.context.eventName = "workflow_dispatch";
+    const { main } = await loadModule();
+
+    await main();
+
+    expect(outputs.reaction_command_ok).toBe("true");
+    expect(outputs.reaction_item_number).toBe("");
+    expect(mockGithub.rest.issues.listForRepo).not.toHaveBeenCalled();
+  });
+
+  it("should start the poll window at the previous scheduled run", async () => {
+    const { resolvePollWindowStart } = await loadModule();
+
+    const since = await resolvePollWindowStart(15, new Date(NOW));
+
+    expect(since.toISOString()).toBe(minutesAgo(10));
+    expect(mockGithub.rest.actions.listWorkflowRuns).toHaveBeenCalledWith(expect.objectContaining({ workflow_id: "look.lock.yml", event: "schedule" }));
+  });
+
+  it("should fall back to the polling interval without a previous run", async () => {
+    mockGithub.rest.actions.listWorkflowRuns.mockResolvedValue({ data: { workflow_runs: [{ id: 2, created_at: minutesAgo(0) }] } });
+    const { resolvePollWindowStart } = await loadModule();
+
+    const since = await resolvePollWindowStart(15, new Date(NOW));
+
+    expect(since.toISOString()).toBe(minutesAgo(15));
+  });
+
+  it("should only collect reactions on configured item types within the window", async () => {
+    const { findReactionMatches } = await loadModule();
+
+    const matches = await findReactionMatches("eyes", ["issues", "issue_comment"], new Date(NOW - 10 * 60 * 1000));
+
+    expect(mockGithub.rest.reactions.listForIssue).toHaveBeenCalledTimes(1);
+    expect(matches).toEqual([
+      { itemNumber: 8, commentId: "99", user: "reader", createdAt: minutesAgo(5) },
+      { itemNumber: 5, commentId: "", user: "maintainer", createdAt: minutesAgo(2) },
+    ]);
+  });
+
+  it("should activate on the oldest reaction by an authorized user", async () => {
+    const { main } = await loadModule();
+
+    await main();
+
+    expect(outputs).toEqual({
+      reaction_command_ok: "true",
+      reaction_item_number: "5",
+      reaction_comment_id: "",
+      reaction_user: "maintainer",
+    });
+  });
+
+  it("should skip when only unauthorized users reacted", async () => {
+    mockGithub.rest.issues.listForRepo.mockResolvedValue({ data: [] });
+    const { main } = await loadModule();
+
+    await main();
+
+    expect(outputs.reaction_command_ok).toBe("false");
+    expect(mockCore.warning).toHaveBeenCalledWith(expect.stringContaining("reader"));
+    expect(mockCore.summary.addRaw).toHaveBeenCalled();
+  });
+
+  it("should skip quietly when no reactions were added", async () => {
+    mockGithub.rest.issues.listForRepo.mockResolvedValue({ data: [] });
+    mockGithub.rest.issues.listCommentsForRepo.mockResolvedValue({ data: [] });
+    const { main } = await loadModule();
+
+    await main();
+
+    expect(outputs.reaction_command_ok).toBe("false");
+    expect(mockCore.summary.addRaw).not.toHaveBeenCalled();
+  });
+});
@@ -0,0 +1,133 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+const fs = require("fs");
+const path = require("path");
+const { getErrorMessage } = require("./error_helpers.cjs");
+
+/**
+ * @typedef {Object} AgentArtifactsConfig
+ * @property {string} sourceDir - Directory the agent writes files to publish
+ * @property {string} stagingDir - Directory receiving the files to upload
+ * @property {number} maxFileSize - Maximum size per file in bytes
+ * @property {number} maxFileCount - Maximum number of uploaded files
+ * @property {number} maxTotalSize - Maximum size of all uploaded files in bytes
+ * @property {string[]} blockedExtensions - Lowercase extensions that are never uploaded
+ */
+
+/**
+ * @typedef {Object} SkippedArtifact
+ * @property {string} file - Path relative to the source directory
+ * @property {string} reason - Why the file was not uploaded
+ */
+
+/**
+ * Copy the files the agent placed in the artifacts directory to the staging directory,
+ * skipping symbolic links, special files and files that exceed the limits or have a
+ * blocked extension. Files are considered in path order so the selection is stable.
+ *
+ * @param {AgentArtifactsConfig} config - Collection limits
+ * @returns {{ collected: string[], skipped: SkippedArtifact[], totalSize: number }}
+ */
+function collectArtifactFiles(config) {
+  /** @type {string[]} */
+  const collected = [];
+  /** @type {SkippedArtifact[]} */
+  const skipped = [];
+  let totalSize = 0;
+
+  if (!fs.existsSync(config.sourceDir)) {
+    return { collected, skipped, totalSize };
+  }
+
+  const blocked = new Set(config.blockedExtensions.map(ext => ext.toLowerCase()));
+
+  /** @type {string[]} */
+  const candidates = [];
+  /**
+   * @param {string} dirPath - Directory to scan
+   * @param {string} relativePath - Path relative to the source directory
+   */
+  const scan = (dirPath, relativePath) => {
+    const entries = fs.readdirSync(dirPath, { withFileTypes: true });
+    for (const entry of entries) {
+      const relativeFilePath = relativePath ? path.join(relativePath, entry.name) : entry.name;
+      if (entry.isSymbolicLink()) {
+        skipped.push({ file: relativeFilePath, reason: "symbolic link" });
+      } else if (entry.isDirectory()) {
+        scan(path.join(dirPath, entry.name), relativeFilePath);
+      } else if (entry.isFile()) {
+        candidates.push(relativeFilePath);
+      } else {
+        skipped.push({ file: relativeFilePath, reason: "not a regular file" });
+      }
+    }
+  };
+  scan(config.sourceDir, "");
+  candidates.sort();
+
+  for (const file of candidates) {
+    const ext = path.extname(file).toLowerCase();
+    if (ext && blocked.has(ext)) {
+      skipped.push({ file, reason: `blocked extension ${ext}` });
+      continue;
+    }
+    const size = fs.lstatSync(path.join(config.sourceDir, file)).size;
+    if (size > config.maxFileSize) {
+      skipped.push({ file, reason: `larger than ${config.maxFileSize} bytes` });
+      continue;
+    }
+    if (collected.length >= config.maxFileCount) {
+      skipped.push({ file, reason: `more than ${config.maxFileCount} files` });
+      continue;
+    }
+    if (totalSize + size > config.maxTotalSize) {
+      skipped.push({ file, reason: `total size over ${config.maxTotalSize} bytes` });
+      continue;
+    }
+    const destination = path.join(config.stagingDir, file);
+    fs.mkdirSync(path.dirname(destination), { recursive: true });
+    fs.copyFileSync(path.join(config.sourceDir, file), destination);
+    collected.push(file);
+    totalSize += size;
+  }
+
+  return { collected, skipped, totalSize };
+}
+
+/**
+ * Collect the agent's artifact files for upload and report the skipped files in the step summary.
+ *
+ * @param {AgentArtifactsConfig} config - Collection limits
+ */
+async function collectAgentArtifacts(config) {
+  let result;
+  try {
+    result = collectArtifactFiles(config);
+  } catch (error) {
+    core.setFailed(`Failed to collect agent artifacts: ${getErrorMessage(error)}`);
+    return;
+  }
+
+  const { collected, skipped, totalSize } = result;
+  core.info(`Collected ${collected.length} agent artifact file(s) (${totalSize} bytes) from ${config.sourceDir}`);
+  for (const { file, reason } of skipped) {
+    core.warning(`Agent artifact not uploaded: ${file} (${reason})`);
+  }
+  core.setOutput("count", collected.length);
+
+  if (collected.length === 0 && skipped.length === 0) {
+    return;
+  }
+  let summary = `### Agent artifacts\n\n${collected.length} file(s) uploaded (${totalSize} bytes).\n`;
+  if (skipped.length > 0) {
+    summary += `\n${skipped.length} file(s) not uploaded:\n\n`;
+    summary += skipped.map(({ file, reason }) => `- \`${file}\`: ${reason}`).join("\n") + "\n";
+  }
+  await core.summary.addRaw(summary).write();
+}
+
+module.exports = {
+  collectArtifactFiles,
+  collectAgentArtifacts,
+};
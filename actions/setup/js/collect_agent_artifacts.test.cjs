@@ -0,0 +1,117 @@
+// @ts-check
+
+import { describe, it, expect, beforeEach, afterEach, vi } from "vitest";
+import fs from "fs";
+import path from "path";
+import os from "os";
+
+const { collectArtifactFiles, collectAgentArtifacts } = require("./collect_agent_artifacts.cjs");
+
+const summary = { addRaw: vi.fn(), write: vi.fn() };
+summary.addRaw.mockReturnValue(summary);
+
+global.core = {
+  info: vi.fn(),
+  warning: vi.fn(),
+  setOutput: vi.fn(),
+  setFailed: vi.fn(),
+  summary,
+};
+
+describe("collect_agent_artifacts", () => {
+  let tempDir = "";
+  let sourceDir = "";
+  let stagingDir = "";
+
+  /** @param {Partial<import("./collect_agent_artifacts.cjs").AgentArtifactsConfig>} overrides */
+  const config = (overrides = {}) => ({
+    sourceDir,
+    stagingDir,
+    maxFileSize: 1024,
+    maxFileCount: 10,
+    maxTotalSize: 4096,
+    blockedExtensions: [".exe", ".sh"],
+    ...overrides,
+  });
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), "collect-agent-artifacts-test-"));
+    sourceDir = path.join(tempDir, "artifacts");
+    stagingDir = path.join(tempDir, "staging");
+    fs.mkdirSync(sourceDir);
+    vi.clearAllMocks();
+    summary.addRaw.mockReturnValue(summary);
+  });
+
+  afterEach(() => {
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it("returns nothing when the directory does not exist", () => {
+    const result = collectArtifactFiles(config({ sourceDir: path.join(tempDir, "missing") }));
+    expect(result).toEqual({ collected: [], skipped: [], totalSize: 0 });
+  });
+
+  it("copies regular files including nested directories", () => {
+    fs.writeFileSync(path.join(sourceDir, "report.md"), "# Report");
+    fs.mkdirSync(path.join(sourceDir, "charts"));
+    fs.writeFileSync(path.join(sourceDir, "charts", "trend.svg"), "<svg/>");
+
+    const result = collectArtifactFiles(config());
+
+    expect(result.collected).toEqual([path.join("charts", "trend.svg"), "report.md"]);
+    expect(fs.readFileSync(path.join(stagingDir, "report.md"), "utf8")).toBe("# Report");
+    expect(fs.existsSync(path.join(stagingDir, "charts", "trend.svg"))).toBe(true);
+  });
+
+  it("skips blocked extensions case-insensitively", () => {
+    fs.writeFileSync(path.join(sourceDir, "tool.EXE"), "MZ");
+    fs.writeFileSync(path.join(sourceDir, "notes.txt"), "ok");
+
+    const result = collectArtifactFiles(config());
+
+    expect(result.collected).toEqual(["notes.txt"]);
+    expect(result.skipped).toEqual([{ file: "tool.EXE", reason: "blocked extension .exe" }]);
+    expect(fs.existsSync(path.join(stagingDir, "tool.EXE"))).toBe(false);
+  });
+
+  it("skips symbolic links", () => {
+    const secret = path.join(tempDir, "secret.txt");
+    fs.writeFileSync(secret, "token");
+    fs.symlinkSync(secret, path.join(sourceDir, "link.txt"));
+
+    const result = collectArtifactFiles(config());
+
+    expect(result.collected).toEqual([]);
+    expect(result.skipped).toEqual([{ file: "link.txt", reason: "symbolic link" }]);
+  });
+
+  it("enforces the file size, file count and total size limits", () => {
+    fs.writeFileSync(path.join(sourceDir, "a.txt"), "x".repeat(600));
+    fs.writeFileSync(path.join(sourceDir, "b.txt"), "x".repeat(600));
+    fs.writeFileSync(path.join(sourceDir, "c.txt"), "x".repeat(2000));
+    fs.writeFileSync(path.join(sourceDir, "d.txt"), "x");
+
+    const bySize = collectArtifactFiles(config({ maxTotalSize: 1000 }));
+    expect(bySize.collected).toEqual(["a.txt", "d.txt"]);
+    expect(bySize.skipped.map(s => s.file)).toEqual(["b.txt", "c.txt"]);
+    expect(bySize.totalSize).toBe(601);
+
+    fs.rmSync(stagingDir, { recursive: true, force: true });
+    const byCount = collectArtifactFiles(config({ maxFileCount: 1 }));
+    expect(byCount.collected).toEqual(["a.txt"]);
+    expect(byCount.skipped.find(s => s.file === "d.txt")?.reason).toBe("more than 1 files");
+  });
+
+  it("reports skipped files in the step summary", async () => {
+    fs.writeFileSync(path.join(sourceDir, "report.md"), "# Report");
+    fs.writeFileSync(path.join(sourceDir, "run.sh"), "echo");
+
+    await collectAgentArtifacts(config());
+
+    expect(core.setOutput).toHaveBeenCalledWith("count", 1);
+    expect(core.warning).toHaveBeenCalledWith("Agent artifact not uploaded: run.sh (blocked extension .sh)");
+    expect(summary.addRaw).toHaveBeenCalledWith(expect.stringContaining("- `run.sh`: blocked extension .sh"));
+    expect(summary.write).toHaveBeenCalled();
+  });
+});
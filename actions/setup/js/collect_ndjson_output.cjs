@@ -8,6 +8,8 @@ const { ERR_API, ERR_PARSE } = require("./error_codes.cjs");
 const { isPayloadUserBot } = require("./resolve_mentions.cjs");
 const { parseIntTemplatable } = require("./templatable.cjs");
 const { parseAllowedRepos, validateTargetRepo } = require("./repo_helpers.cjs");
+const { findOutputLanguageMismatch } = require("./output_language.cjs");
+const { parsePrivacyMaintainers, restoreMaintainerMentionsInItem } = require("./privacy_pseudonymize.cjs");
 
 async function main() {
   try {
@@ -39,9 +41,16 @@ async function main() {
     // This determines which @mentions are allowed in the agent output
     const allowedMentions = await resolveAllowedMentionsFromPayload(context, github, core, mentionsConfig);
 
+    // Privacy mode: the agent only saw pseudonyms. Pseudonyms of allowlisted
+    // maintainers are turned back into @mentions before sanitization.
+    const privacySalt = process.env.GH_AW_PRIVACY_SALT || "";
+    const privacyMaintainers = parsePrivacyMaintainers(process.env.GH_AW_PRIVACY_MAINTAINERS);
+
     // maxBotMentions is populated after safeOutputsConfig is read below
     /** @type {number | undefined} */
     let maxBotMentions;
+    /** @type {string} */
+    let outputLanguage = "";
 
     function validateFieldWithInputSchema(value, fieldName, inputSchema, lineNum) {
       if (inputSchema.required && (value === undefined || value === null)) {
@@ -225,6 +234,10 @@ async function main() {
         }
         // Remove global config keys so they are not treated as valid output types
         delete expectedOutputTypes.max_bot_mentions;
+        if (typeof expectedOutputTypes.output_language === "string") {
+          outputLanguage = expectedOutputTypes.output_language;
+        }
+        delete expectedOutputTypes.output_language;
       } catch (error) {
         const errorMsg = getErrorMessage(error);
         core.info(`Warning: Could not parse safe-outputs config: ${errorMsg}`);
@@ -296,7 +309,7 @@ async function main() {
       if (line === "") continue;
       core.info(`[INGESTION] Processing line ${i + 1}: ${line.substring(0, 200)}...`);
       try {
-        const item = parseJsonWithRepair(line);
+        let item = parseJsonWithRepair(line);
         if (item === undefined) {
           errors.push(`Line ${i + 1}: Invalid JSON - JSON parsing failed`);
           continue;
@@ -305,6 +318,9 @@ async function main() {
           errors.push(`Line ${i + 1}: Missing required 'type' field`);
           continue;
         }
+        if (privacySalt && privacyMaintainers.length > 0) {
+          item = restoreMaintainerMentionsInItem(item, privacySalt, privacyMaintainers);
+        }
         // Normalize type to use underscores (convert any dashes to underscores for resilience)
         const originalType = item.type;
         const itemType = item.type.replace(/-/g, "_");
@@ -381,6 +397,16 @@ async function main() {
         }
       }
     }
+    // Post-run output language validation: the MCP server already asked the agent to
+    // rewrite mismatched outputs once, so remaining mismatches are reported, not dropped.
+    if (outputLanguage) {
+      for (const item of parsedItems) {
+        const detected = findOutputLanguageMismatch(item, outputLanguage);
+        if (detected) {
+          core.warning(`[OUTPUT-LANGUAGE] ${item.type} output appears to be written in '${detected}' instead of '${outputLanguage}'`);
+        }
+      }
+    }
     core.info(`Successfully parsed ${parsedItems.length} valid output items`);
     const validatedOutput = {
       items: parsedItems,
@@ -407,7 +433,7 @@ async function main() {
     // Check if any patch or bundle files exist for detection job conditional
     // Patches are named aw-{branch}.patch (format-patch transport, one per branch)
     // Bundles are named aw-{branch}.bundle (git bundle transport, preserves merge topology)
-    const patchDir = "/tmp/gh-aw";
+    const patchDir = TMP_GH_AW_PATH;
     let hasPatch = false;
     const patchFiles = [];
     try {
@@ -3,9 +3,10 @@
 
 const fs = require("fs");
 const { getErrorMessage } = require("./error_helpers.cjs");
+const { TMP_GH_AW_PATH } = require("./constants.cjs");
 
 /** Path written by install_frontmatter_skills.cjs during the activation job. */
-const SKILL_FAILURES_FILE = "/tmp/gh-aw/skill_install_failures.json";
+const SKILL_FAILURES_FILE = `${TMP_GH_AW_PATH}/skill_install_failures.json`;
 
 /**
  * Read the shared failures file produced by install_frontmatter_skills.cjs.
@@ -3,9 +3,10 @@
 const fs = require("fs");
 const path = require("path");
 const { getErrorMessage } = require("./error_helpers.cjs");
+const { TMP_GH_AW_PATH } = require("./constants.cjs");
 
 const COMMENT_MEMORY_TAG = "gh-aw-comment-memory";
-const COMMENT_MEMORY_DIR = "/tmp/gh-aw/comment-memory";
+const COMMENT_MEMORY_DIR = `${TMP_GH_AW_PATH}/comment-memory`;
 const COMMENT_MEMORY_EXTENSION = ".md";
 const MAX_MEMORY_ID_LENGTH = 128;
 const COMMENT_MEMORY_MAX_SCAN_PAGES = 50;
@@ -9,15 +9,39 @@
 const { sanitizeIncomingText, writeRedactedDomainsLog } = require("./sanitize_incoming_text.cjs");
 const { getErrorMessage } = require("./error_helpers.cjs");
 const { parseAllowedBots, isAllowedBot } = require("./check_permissions_utils.cjs");
+const { collectPayloadLogins, generatePrivacySalt, pseudonymForLogin, pseudonymizeText } = require("./privacy_pseudonymize.cjs");
+
+/**
+ * Sets every output to an empty string when the actor is not allowed to
+ * provide content to the agent.
+ */
+function setEmptyOutputs() {
+  for (const name of ["text", "title", "body", "discussion_number", "discussion_category", "discussion_body"]) {
+    core.setOutput(name, "");
+  }
+}
 
 async function main() {
   let text = "";
   let title = "";
   let body = "";
+  let discussionNumber = "";
+  let discussionCategory = "";
+  let discussionBody = "";
 
   const actor = context.actor;
   const { owner, repo } = context.repo;
 
+  // Privacy mode: usernames and emails in the outputs below are replaced with
+  // per-run pseudonyms. The salt is handed to the output collector (never to the
+  // agent) so that it can restore mentions of allowlisted maintainers.
+  const privacySalt = process.env.GH_AW_PRIVACY_PSEUDONYMIZE === "true" ? generatePrivacySalt() : "";
+  if (privacySalt) {
+    core.setOutput("privacy_salt", privacySalt);
+    core.setOutput("actor", pseudonymForLogin(actor, privacySalt));
+    core.info("Privacy mode: pseudonymizing usernames and email addresses");
+  }
+
   // Check if the actor has repository access (admin, maintain, write permissions)
   // Non-user actors (bots, GitHub Apps like "Copilot") may not have a user record,
   // causing the API to throw an error (e.g., "Copilot is not a user").
@@ -39,17 +63,13 @@ async function main() {
       core.info(`Actor '${actor}' is in the allowed bots list, treating as 'write' access`);
       permission = "write";
     } else {
-      core.setOutput("text", "");
-      core.setOutput("title", "");
-      core.setOutput("body", "");
+      setEmptyOutputs();
       return;
     }
   }
 
   if (permission !== "admin" && permission !== "maintain" && permission !== "write") {
-    core.setOutput("text", "");
-    core.setOutput("title", "");
-    core.setOutput("body", "");
+    setEmptyOutputs();
     return;
   }
 
@@ -180,6 +200,23 @@ async function main() {
       break;
   }
 
+  // Discussion context is extracted for both discussion and discussion_comment events,
+  // so that a reply in a thread can see the discussion it belongs to
+  if ((context.eventName === "discussion" || context.eventName === "discussion_comment") && context.payload.discussion) {
+    const discussion = context.payload.discussion;
+    discussionNumber = discussion.number != null ? String(discussion.number) : "";
+    discussionCategory = discussion.category?.name || "";
+    discussionBody = discussion.body || "";
+  }
+
+  if (privacySalt) {
+    const knownLogins = [actor, ...collectPayloadLogins(context.payload)];
+    text = pseudonymizeText(text, privacySalt, knownLogins);
+    title = pseudonymizeText(title, privacySalt, knownLogins);
+    body = pseudonymizeText(body, privacySalt, knownLogins);
+    discussionBody = pseudonymizeText(discussionBody, privacySalt, knownLogins);
+  }
+
   // Sanitize the text, title, and body before output
   // All mentions are escaped (wrapped in backticks) to prevent unintended notifications
   // Mention filtering will be applied by the agent output collector
@@ -196,6 +233,9 @@ async function main() {
   core.setOutput("text", sanitizedText);
   core.setOutput("title", sanitizedTitle);
   core.setOutput("body", sanitizedBody);
+  core.setOutput("discussion_number", discussionNumber);
+  core.setOutput("discussion_category", sanitizeIncomingText(discussionCategory));
+  core.setOutput("discussion_body", sanitizeIncomingText(discussionBody));
 
   // Write redacted URL domains to log file if any were collected
   const logPath = writeRedactedDomainsLog();
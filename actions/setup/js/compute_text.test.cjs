@@ -183,6 +183,30 @@ const mockCore = {
               expect(mockCore.setOutput).toHaveBeenCalledWith("title", ""),
               expect(mockCore.setOutput).toHaveBeenCalledWith("body", "Discussion comment text"));
           }),
+          it("should extract discussion context from discussion payload", async () => {
+            ((mockContext.eventName = "discussion"),
+              (mockContext.payload = { discussion: { number: 42, title: "Test Discussion", body: "Discussion description", category: { name: "Q&A" } } }),
+              await testMain(),
+              expect(mockCore.setOutput).toHaveBeenCalledWith("discussion_number", "42"),
+              expect(mockCore.setOutput).toHaveBeenCalledWith("discussion_category", "Q&A"),
+              expect(mockCore.setOutput).toHaveBeenCalledWith("discussion_body", "Discussion description"));
+          }),
+          it("should extract the parent discussion for discussion comments", async () => {
+            ((mockContext.eventName = "discussion_comment"),
+              (mockContext.payload = { comment: { body: "Reply text" }, discussion: { number: 7, title: "Parent", body: "Original post by @someone", category: { name: "Ideas" } } }),
+              await testMain(),
+              expect(mockCore.setOutput).toHaveBeenCalledWith("text", "Reply text"),
+              expect(mockCore.setOutput).toHaveBeenCalledWith("discussion_number", "7"),
+              expect(mockCore.setOutput).toHaveBeenCalledWith("discussion_category", "Ideas"),
+              expect(mockCore.setOutput).toHaveBeenCalledWith("discussion_body", "Original post by `@someone`"));
+          }),
+          it("should leave discussion outputs empty for other events", async () => {
+            ((mockContext.eventName = "issues"),
+              (mockContext.payload = { issue: { title: "Test Issue", body: "Issue description" } }),
+              await testMain(),
+              expect(mockCore.setOutput).toHaveBeenCalledWith("discussion_number", ""),
+              expect(mockCore.setOutput).toHaveBeenCalledWith("discussion_body", ""));
+          }),
           it("should handle unknown event types", async () => {
             ((mockContext.eventName = "unknown_event"),
               (mockContext.payload = {}),
@@ -9,6 +9,8 @@
  * Some constants are specific to the JavaScript implementation and do not have Go equivalents.
  */
 
+const os = require("os");
+
 /**
  * AgentOutputFilename is the filename of the agent output JSON file
  * @type {string}
@@ -16,10 +18,24 @@
 const AGENT_OUTPUT_FILENAME = "agent_output.json";
 
 /**
- * Base path for temporary gh-aw files
+ * Base path for temporary gh-aw files: the scratch directory of the current job, which
+ * setup.sh exports as GH_AW_TMP_DIR (see gh_aw_tmp_dir.cjs). There is deliberately no
+ * fallback to the shared /tmp/gh-aw root, where concurrent jobs on the same host would
+ * overwrite each other's files.
+ * @type {string}
+ */
+const TMP_GH_AW_PATH = process.env.GH_AW_TMP_DIR || "";
+if (!TMP_GH_AW_PATH) {
+  throw new Error("GH_AW_TMP_DIR is not set: the gh-aw setup step must run before this script");
+}
+
+/**
+ * Directory in which actions/cache steps restore and save gh-aw state. actions/cache
+ * finds an entry only at the path it was saved from, so unlike the scratch directory this
+ * path is the same in every run and job. Matches CacheStagingDir in pkg/constants/constants.go.
  * @type {string}
  */
-const TMP_GH_AW_PATH = "/tmp/gh-aw";
+const CACHE_STAGING_PATH = `${process.env.RUNNER_TEMP || os.tmpdir()}/gh-aw-cache`;
 
 // ---------------------------------------------------------------------------
 // GitHub reviewer bot
@@ -133,6 +149,7 @@ const DETECTION_RESULT_FILENAME = "detection_result.json";
 module.exports = {
   AGENT_OUTPUT_FILENAME,
   TMP_GH_AW_PATH,
+  CACHE_STAGING_PATH,
   COPILOT_REVIEWER_BOT,
   COPILOT_REVIEWER_BOT_ID,
   FAQ_CREATE_PR_PERMISSIONS_URL,
@@ -91,5 +91,17 @@ describe("convert gateway config adapters", () => {
     expect(toml).toContain("[mcp_servers.github]");
     expect(toml).toContain('url = "http://172.30.0.1:80/mcp/github"');
     expect(toml).toContain('http_headers = { Authorization = "Bearer abc" }');
+    expect(toml).not.toContain("disabled_tools");
+  });
+
+  it("codex adapter emits disabled_tools for blocked tools", () => {
+    const toml = toCodexTomlSection("fs", { headers: { Authorization: "Bearer abc" } }, "http://172.30.0.1:80", ["write_file", "move_file"]);
+    expect(toml).toContain('disabled_tools = ["write_file", "move_file"]');
+  });
+
+  it("codex adapter emits per-server startup and tool timeouts", () => {
+    const toml = toCodexTomlSection("notion", { headers: { Authorization: "Bearer abc" } }, "http://172.30.0.1:80", undefined, { startup: 20, tool: 45 });
+    expect(toml).toContain("startup_timeout_sec = 20");
+    expect(toml).toContain("tool_timeout_sec = 45");
   });
 });
@@ -20,6 +20,8 @@ require("./shim.cjs");
  *
  * Optional:
  * - GH_AW_MCP_CLI_SERVERS: JSON array of server names to exclude from agent config
+ * - GH_AW_MCP_BLOCKED_TOOLS: JSON object mapping server names to tools to disable
+ * - GH_AW_MCP_SERVER_TIMEOUTS: JSON object mapping server names to { startup, tool } timeouts in seconds
  */
 
 const path = require("path");
@@ -31,9 +33,11 @@ const OUTPUT_PATH = path.join(process.env.RUNNER_TEMP || "/tmp", "gh-aw/mcp-conf
  * @param {string} name
  * @param {Record<string, unknown>} value
  * @param {string} urlPrefix
+ * @param {string[]} [blockedTools] - Tools to disable for this server
+ * @param {{ startup?: number; tool?: number }} [timeouts] - Per-server timeouts in seconds
  * @returns {string}
  */
-function toCodexTomlSection(name, value, urlPrefix) {
+function toCodexTomlSection(name, value, urlPrefix, blockedTools, timeouts) {
   const url = `${urlPrefix}/mcp/${name}`;
   const rawHeaders = value.headers;
   /** @type {Record<string, string>} */
@@ -42,12 +46,21 @@ function toCodexTomlSection(name, value, urlPrefix) {
   let section = `[mcp_servers.${name}]\n`;
   section += `url = "${url}"\n`;
   section += `http_headers = { Authorization = "${authKey}" }\n`;
+  if (blockedTools && blockedTools.length > 0) {
+    section += `disabled_tools = [${blockedTools.map(tool => JSON.stringify(tool)).join(", ")}]\n`;
+  }
+  if (timeouts?.startup) {
+    section += `startup_timeout_sec = ${timeouts.startup}\n`;
+  }
+  if (timeouts?.tool) {
+    section += `tool_timeout_sec = ${timeouts.tool}\n`;
+  }
   section += "\n";
   return section;
 }
 
 function main() {
-  const { gatewayOutput, domain, port, cliServers, servers } = loadGatewayContext();
+  const { gatewayOutput, domain, port, cliServers, blockedTools, serverTimeouts, servers } = loadGatewayContext();
 
   core.info("Converting gateway configuration to Codex TOML format...");
   core.info(`Input: ${gatewayOutput}`);
@@ -70,7 +83,7 @@ function main() {
   let toml = '[history]\npersistence = "none"\n\n';
 
   for (const [name, value] of Object.entries(filteredServers)) {
-    toml += toCodexTomlSection(name, value, urlPrefix);
+    toml += toCodexTomlSection(name, value, urlPrefix, blockedTools[name], serverTimeouts[name]);
   }
 
   logServerStats(servers, Object.keys(filteredServers).length);
@@ -28,6 +28,8 @@ require("./shim.cjs");
  *
  * Optional:
  * - GH_AW_MCP_CLI_SERVERS: JSON array of server names to exclude from agent config
+ * - GH_AW_MCP_BLOCKED_TOOLS: JSON object mapping server names to tools to exclude
+ * - GH_AW_MCP_SERVER_TIMEOUTS: JSON object mapping server names to { startup, tool } timeouts in seconds
  */
 
 const path = require("path");
@@ -36,17 +38,31 @@ const { rewriteUrl, normalizeGatewayEntry, loadGatewayContext, logCLIFilters, fi
 /**
  * @param {Record<string, unknown>} entry
  * @param {string} urlPrefix
+ * @param {string[]} [blockedTools] - Tools to exclude for this server
+ * @param {{ startup?: number; tool?: number }} [timeouts] - Per-server timeouts in seconds
  * @returns {Record<string, unknown>}
  */
-function transformGeminiEntry(entry, urlPrefix) {
+function transformGeminiEntry(entry, urlPrefix, blockedTools, timeouts) {
   return normalizeGatewayEntry(entry, urlPrefix, transformed => {
     // Remove "type" field — Gemini uses transport auto-detection from url/httpUrl
     delete transformed.type;
+    // Mirror the tool filter into Gemini's native per-server includeTools/excludeTools
+    // so the CLI only advertises the permitted tools to the model.
+    if (Array.isArray(transformed.tools) && transformed.tools.length > 0 && !transformed.tools.includes("*")) {
+      transformed.includeTools = transformed.tools;
+    }
+    if (blockedTools && blockedTools.length > 0) {
+      transformed.excludeTools = blockedTools;
+    }
+    // Gemini's per-server request timeout is in milliseconds.
+    if (timeouts?.tool) {
+      transformed.timeout = timeouts.tool * 1000;
+    }
   });
 }
 
 function main() {
-  const { gatewayOutput, port, cliServers, servers, extraEnv } = loadGatewayContext({
+  const { gatewayOutput, port, cliServers, blockedTools, serverTimeouts, servers, extraEnv } = loadGatewayContext({
     extraRequiredEnv: ["GITHUB_WORKSPACE"],
   });
   const workspace = extraEnv.GITHUB_WORKSPACE;
@@ -61,7 +77,7 @@ function main() {
   core.info(`Input: ${gatewayOutput}`);
   core.info(`Target domain: ${hostDomain}:${port}`);
   logCLIFilters(cliServers);
-  const result = filterAndTransformServers(servers, cliServers, (_name, entry) => transformGeminiEntry(entry, urlPrefix));
+  const result = filterAndTransformServers(servers, cliServers, (name, entry) => transformGeminiEntry(entry, urlPrefix, blockedTools[name], serverTimeouts[name]));
 
   // Build settings with mcpServers and context.includeDirectories
   // Allow Gemini CLI to read/write files from /tmp/ (e.g. MCP payload files,
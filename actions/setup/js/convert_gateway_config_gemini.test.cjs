@@ -36,6 +36,32 @@ describe("convert_gateway_config_gemini", () => {
       expect(result.tools).toEqual(["read", "write"]);
     });
 
+    it("mirrors an explicit tools list into includeTools", () => {
+      const entry = { type: "http", url: "http://old/mcp/notes", tools: ["read_note"] };
+      const result = transformGeminiEntry(entry, urlPrefix);
+      expect(result.includeTools).toEqual(["read_note"]);
+    });
+
+    it("does not set includeTools for a wildcard tools list", () => {
+      const entry = { type: "http", url: "http://old/mcp/fs", tools: ["*"] };
+      const result = transformGeminiEntry(entry, urlPrefix);
+      expect(result).not.toHaveProperty("includeTools");
+    });
+
+    it("sets excludeTools from the blocked tools", () => {
+      const entry = { type: "http", url: "http://old/mcp/fs", tools: ["*"] };
+      const result = transformGeminiEntry(entry, urlPrefix, ["write_file", "move_file"]);
+      expect(result.excludeTools).toEqual(["write_file", "move_file"]);
+    });
+
+    it("sets the request timeout in milliseconds from the tool timeout", () => {
+      const entry = { type: "http", url: "http://old/mcp/notion", toolTimeout: 30, restart: "on-failure" };
+      const result = transformGeminiEntry(entry, urlPrefix, undefined, { tool: 30 });
+      expect(result.timeout).toBe(30000);
+      expect(result).not.toHaveProperty("toolTimeout");
+      expect(result).not.toHaveProperty("restart");
+    });
+
     it("does not mutate the original entry (including nested fields)", () => {
       const entry = {
         type: "http",
@@ -30,6 +30,14 @@ function rewriteUrl(url, urlPrefix) {
  */
 function normalizeGatewayEntry(entry, urlPrefix, mutate) {
   const transformed = { ...entry };
+  // The upstream transport (streamable-http or sse) is handled by the gateway;
+  // agents always reach the gateway over streamable HTTP.
+  delete transformed.transport;
+  // Per-server lifecycle limits are enforced by the gateway itself.
+  delete transformed.startupTimeout;
+  delete transformed.toolTimeout;
+  delete transformed.restart;
+  delete transformed.maxRestarts;
   if (mutate) {
     mutate(transformed);
   }
@@ -59,6 +67,8 @@ function requireEnvVar(name) {
  *   port: string;
  *   urlPrefix: string;
  *   cliServers: Set<string>;
+ *   blockedTools: Record<string, string[]>;
+ *   serverTimeouts: Record<string, { startup?: number; tool?: number }>;
  *   servers: Record<string, Record<string, unknown>>;
  *   extraEnv: Record<string, string>;
  * }}
@@ -70,8 +80,14 @@ function loadGatewayContext(options = {}) {
     throw new Error(`Gateway output file not found: ${gatewayOutput}`);
   }
 
-  const domain = requireEnvVar("MCP_GATEWAY_DOMAIN");
-  const port = requireEnvVar("MCP_GATEWAY_PORT");
+  let domain = requireEnvVar("MCP_GATEWAY_DOMAIN");
+  let port = requireEnvVar("MCP_GATEWAY_PORT");
+  // With sandbox.mcp chaos, record, or replay, agents reach the gateway through the
+  // MCP tool proxy that runs next to them inside the agent container.
+  if (process.env.GH_AW_MCP_PROXY_PORT) {
+    domain = "localhost";
+    port = process.env.GH_AW_MCP_PROXY_PORT;
+  }
 
   /** @type {Record<string, string>} */
   const extraEnv = {};
@@ -87,6 +103,22 @@ function loadGatewayContext(options = {}) {
     throw new Error("Failed to parse GH_AW_MCP_CLI_SERVERS: " + getErrorMessage(err), { cause: err });
   }
 
+  /** @type {Record<string, string[]>} */
+  let blockedTools;
+  try {
+    blockedTools = JSON.parse(process.env.GH_AW_MCP_BLOCKED_TOOLS || "{}");
+  } catch (err) {
+    throw new Error("Failed to parse GH_AW_MCP_BLOCKED_TOOLS: " + getErrorMessage(err), { cause: err });
+  }
+
+  /** @type {Record<string, { startup?: number; tool?: number }>} */
+  let serverTimeouts;
+  try {
+    serverTimeouts = JSON.parse(process.env.GH_AW_MCP_SERVER_TIMEOUTS || "{}");
+  } catch (err) {
+    throw new Error("Failed to parse GH_AW_MCP_SERVER_TIMEOUTS: " + getErrorMessage(err), { cause: err });
+  }
+
   /** @type {Record<string, unknown>} */
   let config;
   try {
@@ -107,6 +139,8 @@ function loadGatewayContext(options = {}) {
     port,
     urlPrefix: `http://${domain}:${port}`,
     cliServers,
+    blockedTools,
+    serverTimeouts,
     servers,
     extraEnv,
   };
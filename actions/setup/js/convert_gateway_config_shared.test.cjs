@@ -48,6 +48,19 @@ describe("normalizeGatewayEntry", () => {
     expect(result.url).toBe("http://host:80/mcp/github");
   });
 
+  it("drops the upstream transport field", () => {
+    const entry = { type: "http", url: "http://old/mcp/legacy", transport: "sse" };
+    const result = normalizeGatewayEntry(entry, "http://host:80");
+    expect(result.transport).toBeUndefined();
+    expect(entry.transport).toBe("sse");
+  });
+
+  it("drops the gateway-enforced lifecycle fields", () => {
+    const entry = { type: "http", url: "http://old/mcp/notion", startupTimeout: 20, toolTimeout: 30, restart: "on-failure", maxRestarts: 2 };
+    const result = normalizeGatewayEntry(entry, "http://host:80");
+    expect(result).toEqual({ type: "http", url: "http://host:80/mcp/notion" });
+  });
+
   it("skips url rewrite when url field is missing", () => {
     const entry = { type: "http" };
     const result = normalizeGatewayEntry(entry, "http://host:80");
@@ -183,6 +196,27 @@ describe("loadGatewayContext", () => {
       expect(ctx.urlPrefix).toBe("http://host.docker.internal:80");
       expect(ctx.cliServers).toBeInstanceOf(Set);
       expect(Object.keys(ctx.servers)).toContain("github");
+      expect(ctx.serverTimeouts).toEqual({});
+    } finally {
+      fs.rmSync(dir, { recursive: true, force: true });
+    }
+  });
+
+  it("points agents at the MCP tool proxy when GH_AW_MCP_PROXY_PORT is set", () => {
+    const dir = fs.mkdtempSync(path.join(os.tmpdir(), "gateway-test-"));
+    const gatewayFile = path.join(dir, "gateway.json");
+    fs.writeFileSync(gatewayFile, JSON.stringify({ mcpServers: {} }));
+    process.env.MCP_GATEWAY_OUTPUT = gatewayFile;
+    process.env.MCP_GATEWAY_DOMAIN = "host.docker.internal";
+    process.env.MCP_GATEWAY_PORT = "8080";
+    process.env.GH_AW_MCP_PROXY_PORT = "8090";
+    process.env.GH_AW_MCP_CLI_SERVERS = "[]";
+
+    try {
+      const ctx = loadGatewayContext();
+      expect(ctx.domain).toBe("localhost");
+      expect(ctx.port).toBe("8090");
+      expect(ctx.urlPrefix).toBe("http://localhost:8090");
     } finally {
       fs.rmSync(dir, { recursive: true, force: true });
     }
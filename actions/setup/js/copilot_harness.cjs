@@ -68,8 +68,9 @@ const { isCAPIQuotaExceededError } = require("./detect_agent_errors.cjs");
 const { applyModelFallback } = require("./model_fallback.cjs");
 const { loadModelsJson } = require("./model_costs.cjs");
 const { resolveConfiguredCopilotModel } = require("./resolve_model_alias.cjs");
+const { TMP_GH_AW_PATH } = require("./constants.cjs");
 
-const AWF_CONFIG_PATH = process.env.GH_AW_AWF_CONFIG_PATH || "/tmp/gh-aw/awf-config.json";
+const AWF_CONFIG_PATH = process.env.GH_AW_AWF_CONFIG_PATH || `${TMP_GH_AW_PATH}/awf-config.json`;
 
 // Additional startup retry budget for scheduled and push-triggered runs when Copilot exits with
 // code 2 before producing any output (typically transient API interruption at startup).
@@ -0,0 +1,55 @@
+// @ts-check
+
+/**
+ * Run correlation ID
+ *
+ * A short numeric ID that identifies a workflow run. It is derived from the
+ * repository and run ID, so every job of a run computes the same value without
+ * passing it around, and `gh aw logs --correlation <id>` can find the run again
+ * from the run list alone. Keep in sync with computeCorrelationID in
+ * pkg/cli/logs_correlation.go.
+ */
+
+/** Number of decimal digits in a correlation ID. */
+const CORRELATION_ID_DIGITS = 8;
+
+/**
+ * Computes the correlation ID of a run as the 32-bit FNV-1a hash of
+ * "<owner>/<repo>/<run_id>" reduced to CORRELATION_ID_DIGITS digits.
+ *
+ * @param {string} repository - Repository slug (owner/repo); compared case-insensitively
+ * @param {string|number} runId - Workflow run ID
+ * @returns {string} Zero-padded numeric correlation ID, or "" when either input is missing
+ */
+function computeCorrelationId(repository, runId) {
+  if (!repository || !runId) {
+    return "";
+  }
+  const input = `${repository.toLowerCase()}/${runId}`;
+  let hash = 0x811c9dc5;
+  for (const byte of Buffer.from(input, "utf8")) {
+    hash ^= byte;
+    hash = Math.imul(hash, 0x01000193) >>> 0;
+  }
+  return String(hash % 10 ** CORRELATION_ID_DIGITS).padStart(CORRELATION_ID_DIGITS, "0");
+}
+
+/**
+ * Computes the correlation ID of the current run from GITHUB_REPOSITORY and GITHUB_RUN_ID.
+ *
+ * @returns {string} Correlation ID, or "" outside of GitHub Actions
+ */
+function computeRunCorrelationId() {
+  return computeCorrelationId(process.env.GITHUB_REPOSITORY || "", process.env.GITHUB_RUN_ID || "");
+}
+
+/**
+ * Returns the correlation ID threaded into this job by the compiler (GH_AW_CORRELATION_ID).
+ *
+ * @returns {string} Correlation ID, or "" when the job does not receive one
+ */
+function getCorrelationId() {
+  return process.env.GH_AW_CORRELATION_ID || "";
+}
+
+module.exports = { CORRELATION_ID_DIGITS, computeCorrelationId, computeRunCorrelationId, getCorrelationId };
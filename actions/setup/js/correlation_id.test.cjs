@@ -0,0 +1,35 @@
+import { describe, it, expect, afterEach } from "vitest";
+
+const { computeCorrelationId, computeRunCorrelationId, getCorrelationId } = require("./correlation_id.cjs");
+
+describe("correlation_id", () => {
+  afterEach(() => {
+    delete process.env.GITHUB_REPOSITORY;
+    delete process.env.GITHUB_RUN_ID;
+    delete process.env.GH_AW_CORRELATION_ID;
+  });
+
+  it("derives a stable eight-digit ID from repository and run ID", () => {
+    expect(computeCorrelationId("github/gh-aw", 1234567890)).toBe("35625281");
+    expect(computeCorrelationId("GitHub/gh-aw", "1234567890")).toBe("35625281");
+  });
+
+  it("returns an empty ID when the repository or run ID is missing", () => {
+    expect(computeCorrelationId("", "1")).toBe("");
+    expect(computeCorrelationId("github/gh-aw", "")).toBe("");
+  });
+
+  it("computes the ID of the current run from the environment", () => {
+    process.env.GITHUB_REPOSITORY = "github/gh-aw";
+    process.env.GITHUB_RUN_ID = "1234567890";
+    expect(computeRunCorrelationId()).toBe("35625281");
+  });
+
+  it("reads the threaded ID from GH_AW_CORRELATION_ID", () => {
+    process.env.GITHUB_REPOSITORY = "github/gh-aw";
+    process.env.GITHUB_RUN_ID = "1234567890";
+    expect(getCorrelationId()).toBe("");
+    process.env.GH_AW_CORRELATION_ID = "35625281";
+    expect(getCorrelationId()).toBe("35625281");
+  });
+});
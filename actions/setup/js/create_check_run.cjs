@@ -24,6 +24,70 @@ const MAX_CONTENT_LENGTH = 65535;
 /** @type {number} Maximum length for the title field */
 const MAX_TITLE_LENGTH = 256;
 
+/** @type {Set<string>} Valid annotation levels for GitHub Check Run annotations */
+const VALID_ANNOTATION_LEVELS = new Set(["notice", "warning", "failure"]);
+
+/** @type {number} Maximum annotations accepted by a single Checks API request */
+const ANNOTATIONS_PER_REQUEST = 50;
+
+/** @type {number} Maximum length for an annotation message (GitHub API limit) */
+const MAX_ANNOTATION_MESSAGE_LENGTH = 64 * 1024;
+
+/**
+ * Validates and normalizes agent-supplied annotations for the Checks API.
+ * @param {any} rawAnnotations - The annotations array from the agent message
+ * @param {{max: number, rules: string[]}} options - Configured limits
+ * @returns {{annotations: Array<Object>, error?: string}}
+ */
+function normalizeAnnotations(rawAnnotations, options) {
+  if (rawAnnotations == null) {
+    return { annotations: [] };
+  }
+  if (!Array.isArray(rawAnnotations)) {
+    return { annotations: [], error: "create_check_run: 'annotations' must be an array" };
+  }
+  if (rawAnnotations.length > options.max) {
+    return { annotations: [], error: `create_check_run: ${rawAnnotations.length} annotations exceed the configured maximum of ${options.max}` };
+  }
+
+  const annotations = [];
+  for (const [index, item] of rawAnnotations.entries()) {
+    const label = `create_check_run: annotations[${index}]`;
+    const path = typeof item?.path === "string" ? item.path.trim().replace(/^\.\//, "") : "";
+    if (!path || path.startsWith("/") || path.split("/").includes("..")) {
+      return { annotations: [], error: `${label} must have a repository-relative 'path'` };
+    }
+    const startLine = Number(item.start_line);
+    const endLine = item.end_line != null ? Number(item.end_line) : startLine;
+    if (!Number.isInteger(startLine) || startLine < 1 || !Number.isInteger(endLine) || endLine < startLine) {
+      return { annotations: [], error: `${label} has an invalid line range (start_line=${item.start_line}, end_line=${item.end_line})` };
+    }
+    if (!VALID_ANNOTATION_LEVELS.has(item.annotation_level)) {
+      return { annotations: [], error: `${label} has invalid annotation_level '${item.annotation_level}'. Must be one of: ${[...VALID_ANNOTATION_LEVELS].join(", ")}` };
+    }
+    const rule = typeof item.rule === "string" ? item.rule.trim() : "";
+    if (options.rules.length > 0 && !options.rules.includes(rule)) {
+      return { annotations: [], error: `${label} has rule '${rule}' which is not allowed. Allowed rules: ${options.rules.join(", ")}` };
+    }
+    const message = typeof item.message === "string" ? sanitizeContent(item.message.trim(), MAX_ANNOTATION_MESSAGE_LENGTH) : "";
+    if (!message) {
+      return { annotations: [], error: `${label} requires a non-empty 'message'` };
+    }
+    const rawAnnotationTitle = typeof item.title === "string" ? item.title.trim() : "";
+    const annotationTitle = rawAnnotationTitle ? sanitizeContent(rawAnnotationTitle, MAX_TITLE_LENGTH) : rule;
+
+    annotations.push({
+      path,
+      start_line: startLine,
+      end_line: endLine,
+      annotation_level: item.annotation_level,
+      message,
+      ...(annotationTitle ? { title: annotationTitle } : {}),
+    });
+  }
+  return { annotations };
+}
+
 /**
  * Main handler factory for create_check_run
  * Returns a message handler function that processes individual create_check_run messages
@@ -41,6 +105,12 @@ async function main(config = {}) {
   const configOutputTitle = config.output_title ? sanitizeContent(String(config.output_title), MAX_TITLE_LENGTH) : "";
   const configOutputSummary = config.output_summary ? sanitizeContent(String(config.output_summary), MAX_CONTENT_LENGTH) : "";
 
+  // Optional annotations settings: only enabled when annotations_max is configured
+  const annotationsEnabled = config.annotations_max != null;
+  const annotationsMax = annotationsEnabled ? Number(config.annotations_max) : 0;
+  const annotationRules = Array.isArray(config.annotations_rules) ? config.annotations_rules.map(String) : [];
+  const annotationsRequired = config.annotations_required === true;
+
   // Resolve the check run name: config > workflow name env var > fallback.
   // Auto-deduplicate: if the resolved name equals the workflow name, GitHub's UI
   // may collapse the programmatic check run into the workflow's own check suite
@@ -55,6 +125,7 @@ async function main(config = {}) {
   core.info(`Create check run configuration: name="${defaultName}", max=${maxCount}${checkRunTarget ? `, target=${checkRunTarget}` : ""}`);
   if (configOutputTitle) core.info(`Config output.title fallback set (${configOutputTitle.length} chars)`);
   if (configOutputSummary) core.info(`Config output.summary fallback set (${configOutputSummary.length} chars)`);
+  if (annotationsEnabled) core.info(`Annotations enabled: max=${annotationsMax}, rules=${annotationRules.length ? annotationRules.join(",") : "any"}, required=${annotationsRequired}`);
 
   // Track how many check runs we've created for max limit enforcement
   let processedCount = 0;
@@ -110,6 +181,25 @@ async function main(config = {}) {
     const rawText = (message.text || "").trim();
     const resolvedText = rawText ? sanitizeContent(rawText, MAX_CONTENT_LENGTH) : "";
 
+    // Validate annotations against the configured contract
+    /** @type {Array<Object>} */
+    let annotations = [];
+    if (annotationsEnabled) {
+      const annotationResult = normalizeAnnotations(message.annotations, { max: annotationsMax, rules: annotationRules });
+      if (annotationResult.error) {
+        core.error(annotationResult.error);
+        return { success: false, error: annotationResult.error };
+      }
+      annotations = annotationResult.annotations;
+      if (annotationsRequired && conclusion !== "success" && annotations.length === 0) {
+        const msg = `create_check_run: annotations are required when conclusion is '${conclusion}'; report each finding with a path and line`;
+        core.error(msg);
+        return { success: false, error: msg };
+      }
+    } else if (Array.isArray(message.annotations) && message.annotations.length > 0) {
+      core.warning("create_check_run: ignoring annotations because safe-outputs.create-check-run.annotations is not configured");
+    }
+
     const owner = context.repo.owner;
     const repo = context.repo.repo;
     let headSha = "";
@@ -174,10 +264,15 @@ async function main(config = {}) {
       // For pull_request events, GITHUB_SHA is the ephemeral merge commit SHA which is
       // not visible in the PR checks UI or the GitHub mobile app. Use the actual PR head
       // SHA from the event payload instead so the check run appears on the PR.
+      // For workflow_run events, GITHUB_SHA is the latest commit on the default branch,
+      // so attach the check run to the commit the upstream run was triggered for.
       const prHeadSha = context.payload?.pull_request?.head?.sha;
-      headSha = prHeadSha || process.env.GITHUB_SHA || context.sha;
+      const workflowRunHeadSha = context.payload?.workflow_run?.head_sha;
+      headSha = prHeadSha || workflowRunHeadSha || process.env.GITHUB_SHA || context.sha;
       if (prHeadSha) {
         core.info(`Using PR head SHA ${prHeadSha} (pull_request event)`);
+      } else if (workflowRunHeadSha) {
+        core.info(`Using upstream run head SHA ${workflowRunHeadSha} (workflow_run event)`);
       }
     }
 
@@ -194,6 +289,7 @@ async function main(config = {}) {
           name: defaultName,
           conclusion,
           title: resolvedTitle,
+          annotations: annotations.length,
         },
       };
     }
@@ -215,6 +311,10 @@ async function main(config = {}) {
         ...(resolvedText ? { text: resolvedText } : {}),
       };
 
+      // The Checks API accepts at most 50 annotations per request: send the first
+      // batch with the create call and append the rest with update calls.
+      const firstBatch = annotations.slice(0, ANNOTATIONS_PER_REQUEST);
+
       const response = await withRetry(
         () =>
           githubClient.rest.checks.create({
@@ -225,7 +325,7 @@ async function main(config = {}) {
             status: "completed",
             conclusion,
             completed_at: new Date().toISOString(),
-            output,
+            output: firstBatch.length > 0 ? { ...output, annotations: firstBatch } : output,
           }),
         RATE_LIMIT_RETRY_CONFIG
       );
@@ -233,6 +333,23 @@ async function main(config = {}) {
       const checkRunId = response.data.id;
       const checkRunUrl = response.data.html_url;
 
+      for (let offset = ANNOTATIONS_PER_REQUEST; offset < annotations.length; offset += ANNOTATIONS_PER_REQUEST) {
+        const batch = annotations.slice(offset, offset + ANNOTATIONS_PER_REQUEST);
+        await withRetry(
+          () =>
+            githubClient.rest.checks.update({
+              owner,
+              repo,
+              check_run_id: checkRunId,
+              output: { ...output, annotations: batch },
+            }),
+          RATE_LIMIT_RETRY_CONFIG
+        );
+      }
+      if (annotations.length > 0) {
+        core.info(`Attached ${annotations.length} annotation(s) to check run #${checkRunId}`);
+      }
+
       core.info(`✓ Created check run "${checkRunName}" #${checkRunId}: ${checkRunUrl}`);
       processedCount++;
 
@@ -242,6 +359,7 @@ async function main(config = {}) {
         check_run_url: checkRunUrl,
         conclusion,
         name: checkRunName,
+        annotations: annotations.length,
       };
     } catch (error) {
       const errorMessage = getErrorMessage(error);
@@ -254,4 +372,4 @@ async function main(config = {}) {
   };
 }
 
-module.exports = { main };
+module.exports = { main, normalizeAnnotations };
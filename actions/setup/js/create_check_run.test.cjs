@@ -111,6 +111,25 @@ describe("create_check_run", () => {
       expect(infoCalls.some(m => m.includes(prHeadSha) && m.includes("pull_request"))).toBe(true);
     });
 
+    it("uses the upstream run head SHA on workflow_run events", async () => {
+      process.env.GITHUB_SHA = "default-branch-sha";
+      mockContext.eventName = "workflow_run";
+      mockContext.payload = {
+        workflow_run: { head_sha: "failed-run-sha-123" },
+      };
+
+      let capturedParams;
+      mockGithub.rest.checks.create = makeChecksCreate(p => {
+        capturedParams = p;
+      });
+
+      const { main } = require("./create_check_run.cjs");
+      const handler = await main({ name: "Test Check", max: 10 });
+      await handler({ type: "create_check_run", conclusion: "failure", title: "CI failed", summary: "Flaky test." }, {});
+
+      expect(capturedParams.head_sha).toBe("failed-run-sha-123");
+    });
+
     it("falls back to GITHUB_SHA on push events (no PR payload)", async () => {
       process.env.GITHUB_SHA = "push-sha-abc123";
       mockContext.eventName = "push";
@@ -659,4 +678,118 @@ describe("create_check_run", () => {
       expect(result.error).toContain("title");
     });
   });
+
+  describe("annotations", () => {
+    beforeEach(() => {
+      process.env.GITHUB_SHA = "sha-abc123";
+    });
+
+    const finding = (overrides = {}) => ({
+      path: "docs/guide.md",
+      start_line: 12,
+      annotation_level: "warning",
+      message: "Image is missing alt text.",
+      rule: "alt-text",
+      ...overrides,
+    });
+
+    it("attaches validated annotations to the check run", async () => {
+      let capturedParams;
+      mockGithub.rest.checks.create = makeChecksCreate(p => {
+        capturedParams = p;
+      });
+
+      const { main } = require("./create_check_run.cjs");
+      const handler = await main({ max: 1, annotations_max: 50, annotations_rules: ["alt-text", "heading-order"] });
+      const result = await handler({ type: "create_check_run", conclusion: "failure", title: "1 issue", summary: "Found issues.", annotations: [finding({ path: "./docs/guide.md" })] }, {});
+
+      expect(result.success).toBe(true);
+      expect(result.annotations).toBe(1);
+      expect(capturedParams.output.annotations).toEqual([
+        {
+          path: "docs/guide.md",
+          start_line: 12,
+          end_line: 12,
+          annotation_level: "warning",
+          message: "Image is missing alt text.",
+          title: "alt-text",
+        },
+      ]);
+    });
+
+    it("rejects annotations with a rule outside the allowlist", async () => {
+      const { main } = require("./create_check_run.cjs");
+      const handler = await main({ max: 1, annotations_max: 50, annotations_rules: ["alt-text"] });
+      const result = await handler({ type: "create_check_run", conclusion: "failure", title: "1 issue", summary: "Found issues.", annotations: [finding({ rule: "spelling" })] }, {});
+
+      expect(result.success).toBe(false);
+      expect(result.error).toContain("not allowed");
+    });
+
+    it("rejects annotations with paths outside the repository", async () => {
+      const { main } = require("./create_check_run.cjs");
+      const handler = await main({ max: 1, annotations_max: 50 });
+      const result = await handler({ type: "create_check_run", conclusion: "failure", title: "1 issue", summary: "Found issues.", annotations: [finding({ path: "../secrets.md" })] }, {});
+
+      expect(result.success).toBe(false);
+      expect(result.error).toContain("repository-relative");
+    });
+
+    it("rejects more annotations than the configured maximum", async () => {
+      const { main } = require("./create_check_run.cjs");
+      const handler = await main({ max: 1, annotations_max: 1 });
+      const result = await handler({ type: "create_check_run", conclusion: "failure", title: "2 issues", summary: "Found issues.", annotations: [finding(), finding({ start_line: 20 })] }, {});
+
+      expect(result.success).toBe(false);
+      expect(result.error).toContain("exceed the configured maximum of 1");
+    });
+
+    it("requires annotations for non-success conclusions when configured", async () => {
+      const { main } = require("./create_check_run.cjs");
+      const handler = await main({ max: 2, annotations_max: 50, annotations_required: true });
+
+      const failure = await handler({ type: "create_check_run", conclusion: "failure", title: "Issues", summary: "Found issues." }, {});
+      expect(failure.success).toBe(false);
+      expect(failure.error).toContain("annotations are required");
+
+      const success = await handler({ type: "create_check_run", conclusion: "success", title: "Clean", summary: "No issues." }, {});
+      expect(success.success).toBe(true);
+    });
+
+    it("sends annotations beyond the first 50 with checks.update", async () => {
+      let createdParams;
+      const updateCalls = [];
+      mockGithub.rest.checks.create = makeChecksCreate(p => {
+        createdParams = p;
+      });
+      mockGithub.rest.checks.update = async params => {
+        updateCalls.push(params);
+        return { data: {} };
+      };
+
+      const { main } = require("./create_check_run.cjs");
+      const handler = await main({ max: 1, annotations_max: 120 });
+      const annotations = Array.from({ length: 120 }, (_, i) => finding({ start_line: i + 1 }));
+      const result = await handler({ type: "create_check_run", conclusion: "failure", title: "120 issues", summary: "Found issues.", annotations }, {});
+
+      expect(result.success).toBe(true);
+      expect(createdParams.output.annotations).toHaveLength(50);
+      expect(updateCalls.map(c => c.output.annotations.length)).toEqual([50, 20]);
+      expect(updateCalls[0].check_run_id).toBe(77313480284);
+    });
+
+    it("ignores annotations when they are not configured", async () => {
+      let capturedParams;
+      mockGithub.rest.checks.create = makeChecksCreate(p => {
+        capturedParams = p;
+      });
+
+      const { main } = require("./create_check_run.cjs");
+      const handler = await main({ max: 1 });
+      const result = await handler({ type: "create_check_run", conclusion: "failure", title: "1 issue", summary: "Found issues.", annotations: [finding()] }, {});
+
+      expect(result.success).toBe(true);
+      expect(capturedParams.output.annotations).toBeUndefined();
+    });
+  });
 });
@@ -12,6 +12,7 @@ const { getTrackerID } = require("./get_tracker_id.cjs");
 const { sanitizeTitle, applyTitlePrefix } = require("./sanitize_title.cjs");
 const { generateTemporaryId, isTemporaryId, normalizeTemporaryId, getOrGenerateTemporaryId, replaceTemporaryIdReferences } = require("./temporary_id.cjs");
 const { resolveTargetRepoConfig, resolveAndValidateRepo } = require("./repo_helpers.cjs");
+const { getGitHubServerUrl } = require("./get_repository_url.cjs");
 const { createAuthenticatedGitHubClient } = require("./handler_auth.cjs");
 const { removeDuplicateTitleFromDescription } = require("./remove_duplicate_title.cjs");
 const { getErrorMessage } = require("./error_helpers.cjs");
@@ -442,7 +443,7 @@ async function main(config = {}) {
         const enhancedError =
           `Failed to fetch repository information for '${qualifiedItemRepo}': ${errorMessage}. ` +
           `This may indicate that discussions are not enabled for this repository. ` +
-          `Please verify that discussions are enabled in the repository settings at https://github.com/${qualifiedItemRepo}/settings.`;
+          `Please verify that discussions are enabled in the repository settings at ${getGitHubServerUrl()}/${qualifiedItemRepo}/settings.`;
         core.error(enhancedError);
         return {
           success: false,
@@ -774,7 +775,7 @@ async function main(config = {}) {
         `Failed to create discussion in '${qualifiedItemRepo}': ${errorMessage}. ` +
         `Common causes: (1) Discussions not enabled in repository settings, ` +
         `(2) Invalid category ID, or (3) Insufficient permissions. ` +
-        `Verify discussions are enabled at https://github.com/${qualifiedItemRepo}/settings and check the category configuration.`;
+        `Verify discussions are enabled at ${getGitHubServerUrl()}/${qualifiedItemRepo}/settings and check the category configuration.`;
       core.error(enhancedError);
       return {
         success: false,
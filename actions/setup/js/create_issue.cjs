@@ -6,7 +6,7 @@ const { sanitizeTitle, applyTitlePrefix } = require("./sanitize_title.cjs");
 const { sanitizeContent } = require("./sanitize_content.cjs");
 const { generateFooterWithMessages, getDetectionCautionAlert } = require("./messages_footer.cjs");
 const { getBodyHeader, getDisclosureHeader } = require("./messages_header.cjs");
-const { generateWorkflowIdMarker, generateWorkflowCallIdMarker, generateCloseKeyMarker, normalizeCloseOlderKey } = require("./generate_footer.cjs");
+const { generateWorkflowIdMarker, generateWorkflowCallIdMarker, generateCloseKeyMarker, normalizeCloseOlderKey, generateDedupeKeyMarker, getDedupeKeyMarkerContent } = require("./generate_footer.cjs");
 const { generateHistoryUrl } = require("./generate_history_link.cjs");
 const { getTrackerID } = require("./get_tracker_id.cjs");
 const { generateTemporaryId, isTemporaryId, normalizeTemporaryId, getOrGenerateTemporaryId, replaceTemporaryIdReferences } = require("./temporary_id.cjs");
@@ -24,7 +24,7 @@ const { parseBoolTemplatable } = require("./templatable.cjs");
 const { tryEnforceArrayLimit } = require("./limit_enforcement_helpers.cjs");
 const { logStagedPreviewInfo } = require("./staged_preview.cjs");
 const { isStagedMode } = require("./safe_output_helpers.cjs");
-const { parseAllowedIssueFields, validateAllowedIssueFields } = require("./allowed_issue_fields.cjs");
+const { parseAllowedIssueFields, resolveAllowedIssueType, validateAllowedIssueFields } = require("./allowed_issue_fields.cjs");
 const { buildWorkflowRunUrl } = require("./workflow_metadata_helpers.cjs");
 const { MAX_LABELS, MAX_ASSIGNEES } = require("./constants.cjs");
 const { findAgent, getIssueDetails, assignAgentToIssue } = require("./assign_agent_helpers.cjs");
@@ -511,6 +511,26 @@ async function shouldSkipRepoTitleDedupSearch(githubClient, owner, repo) {
   return false;
 }
 
+/**
+ * Search for an open issue carrying the given dedupe-key marker.
+ * Search results are re-checked against the exact marker because GitHub search
+ * matches quoted phrases loosely.
+ * @param {any} githubClient - Authenticated GitHub client
+ * @param {string} owner - Repository owner
+ * @param {string} repo - Repository name
+ * @param {string} dedupeKey - Normalized deduplication key
+ * @returns {Promise<{number: number, html_url: string}|null>} Matching issue, or null when none exists
+ */
+async function findIssueByDedupeKey(githubClient, owner, repo, dedupeKey) {
+  const exactMarker = generateDedupeKeyMarker(dedupeKey);
+  const { data } = await githubClient.rest.search.issuesAndPullRequests({
+    q: `repo:${owner}/${repo} is:issue is:open "${getDedupeKeyMarkerContent(dedupeKey)}" in:body`,
+    per_page: 10,
+  });
+  const match = (data.items || []).find(item => !item.pull_request && typeof item.body === "string" && item.body.includes(exactMarker));
+  return match ? { number: match.number, html_url: match.html_url } : null;
+}
+
 /**
  * Main handler factory for create_issue
  * Returns a message handler function that processes individual create_issue messages
@@ -520,6 +540,8 @@ async function main(config = {}) {
   // Extract configuration
   const envLabels = config.labels ? (Array.isArray(config.labels) ? config.labels : config.labels.split(",")).map(label => String(label).trim()).filter(Boolean) : [];
   const allowedIssueFields = parseAllowedIssueFields(config.allowed_fields);
+  const allowedIssueTypes = parseAllowedIssueFields(config.allowed_types);
+  const maxSubIssuesPerParent = config.max_sub_issues ? parseInt(String(config.max_sub_issues), 10) : 0;
   const envAssignees = config.assignees ? (Array.isArray(config.assignees) ? config.assignees : config.assignees.split(",")).map(assignee => String(assignee).trim()).filter(Boolean) : [];
   const titlePrefix = config.title_prefix ?? "";
   const expiresHours = config.expires ? parseInt(String(config.expires), 10) : 0;
@@ -539,6 +561,11 @@ async function main(config = {}) {
   if (rawCloseOlderKey && !closeOlderKey) {
     throw new Error(`${ERR_VALIDATION}: close-older-key "${rawCloseOlderKey}" is invalid: it must contain at least one alphanumeric character after normalization`);
   }
+  const rawDedupeKey = config.dedupe_key ? String(config.dedupe_key) : "";
+  const dedupeKey = rawDedupeKey ? normalizeCloseOlderKey(rawDedupeKey) : "";
+  if (rawDedupeKey && !dedupeKey) {
+    throw new Error(`${ERR_VALIDATION}: dedupe-key "${rawDedupeKey}" is invalid: it must contain at least one alphanumeric character after normalization`);
+  }
   const includeFooter = parseBoolTemplatable(config.footer, true);
 
   // Create an authenticated GitHub client. Uses config["github-token"] when set
@@ -598,6 +625,9 @@ async function main(config = {}) {
       core.warning(`Group-by-day mode has no effect: neither close-older-key nor GH_AW_WORKFLOW_ID is set — issues cannot be searched`);
     }
   }
+  if (dedupeKey) {
+    core.info(`Dedupe key: "${dedupeKey}" — an existing open issue with this key will be updated instead of creating a new one`);
+  }
   if (deduplicateByTitle.enabled) {
     const mode = deduplicateByTitle.maxDistance === 0 ? "exact title match" : `Levenshtein distance <= ${deduplicateByTitle.maxDistance}`;
     core.info(`Title deduplication enabled (${mode})`);
@@ -634,6 +664,9 @@ async function main(config = {}) {
   // Cache for parent issue per group ID
   const parentIssueCache = new Map();
 
+  // Number of issues linked under each parent ("owner/repo#number") in this run, for max-sub-issues
+  const subIssueLinkCounts = new Map();
+
   // Extract triggering context for footer generation
   const triggeringIssueNumber = context.payload?.issue?.number && !context.payload?.issue?.pull_request ? context.payload.issue.number : undefined;
   const triggeringPRNumber = context.payload?.pull_request?.number || (context.payload?.issue?.pull_request ? context.payload.issue.number : undefined);
@@ -737,9 +770,11 @@ async function main(config = {}) {
       .filter((assignee, index, arr) => arr.indexOf(assignee) === index);
 
     let issueFields;
+    let issueType;
     try {
       issueFields = normalizeIssueFields(message.fields);
       validateAllowedIssueFields(issueFields, allowedIssueFields);
+      issueType = message.issue_type !== undefined ? resolveAllowedIssueType(message.issue_type, allowedIssueTypes) : "";
     } catch (error) {
       return { success: false, error: getErrorMessage(error) };
     }
@@ -941,6 +976,10 @@ async function main(config = {}) {
     if (closeOlderKey) {
       bodyLines.push(generateCloseKeyMarker(closeOlderKey));
     }
+    // Add dedupe-key marker so later runs can find and update this issue
+    if (dedupeKey) {
+      bodyLines.push(generateDedupeKeyMarker(dedupeKey));
+    }
 
     bodyLines.push("");
     const body = bodyLines.join("\n").trim();
@@ -957,6 +996,46 @@ async function main(config = {}) {
     }
     processedCount++;
 
+    // Dedupe-key check: if an open issue already carries the same dedupe-key marker,
+    // update its title and body in place instead of creating a duplicate. This runs
+    // before group-by-day because the existing issue is rewritten, not appended to.
+    // The reserved max-count slot is released when an existing issue is updated.
+    if (dedupeKey && !isStaged) {
+      try {
+        const existingIssue = await findIssueByDedupeKey(githubClient, repoParts.owner, repoParts.repo, dedupeKey);
+        if (existingIssue) {
+          core.info(`Dedupe-key: found open issue #${existingIssue.number} with key "${dedupeKey}" — updating it instead of creating a new issue`);
+          const { data: updatedIssue } = await withRetry(
+            () =>
+              githubClient.rest.issues.update({
+                owner: repoParts.owner,
+                repo: repoParts.repo,
+                issue_number: existingIssue.number,
+                title,
+                body,
+              }),
+            RATE_LIMIT_RETRY_CONFIG,
+            `update_issue in ${qualifiedItemRepo}`
+          );
+          core.info(`Updated issue ${qualifiedItemRepo}#${updatedIssue.number}: ${updatedIssue.html_url}`);
+          processedCount--;
+          temporaryIdMap.set(normalizeTemporaryId(String(temporaryId)), { repo: qualifiedItemRepo, number: updatedIssue.number });
+          return {
+            success: true,
+            updated: true,
+            repo: qualifiedItemRepo,
+            number: updatedIssue.number,
+            url: updatedIssue.html_url,
+            temporaryId: temporaryId,
+            _repo: qualifiedItemRepo,
+          };
+        }
+      } catch (error) {
+        // Log but do not abort — fall through to normal creation
+        core.warning(`Dedupe-key pre-check failed: ${getErrorMessage(error)} — proceeding with issue creation`);
+      }
+    }
+
     // Group-by-day check: if enabled, search for an existing open issue created today.
     // When found, post the new content as a comment on the existing issue instead of
     // creating a duplicate. This groups multiple same-day runs into a single issue.
@@ -1024,6 +1103,8 @@ async function main(config = {}) {
           labels,
           assignees,
           fields: issueFields,
+          type: issueType || undefined,
+          parent: effectiveParentIssueNumber ? `${effectiveParentRepo}#${effectiveParentIssueNumber}` : undefined,
           bodyLength: body.length,
           temporaryId,
         },
@@ -1040,6 +1121,7 @@ async function main(config = {}) {
             body,
             labels,
             assignees,
+            ...(issueType ? { type: issueType } : {}),
           }),
         RATE_LIMIT_RETRY_CONFIG,
         `create_issue in ${qualifiedItemRepo}`
@@ -1168,6 +1250,13 @@ async function main(config = {}) {
         }
       }
 
+      // Cap the number of issues linked under the same parent in this run (max-sub-issues)
+      const parentKey = `${effectiveParentRepo}#${effectiveParentIssueNumber}`;
+      if (effectiveParentIssueNumber && maxSubIssuesPerParent > 0 && (subIssueLinkCounts.get(parentKey) || 0) >= maxSubIssuesPerParent) {
+        core.warning(`Not linking issue #${issue.number} to parent ${parentKey}: max-sub-issues limit of ${maxSubIssuesPerParent} reached`);
+        effectiveParentIssueNumber = undefined;
+      }
+
       // Sub-issue linking only works within the same repository
       if (effectiveParentIssueNumber && effectiveParentRepo === qualifiedItemRepo) {
         core.info(`Attempting to link issue #${issue.number} as sub-issue of #${effectiveParentIssueNumber}`);
@@ -1185,6 +1274,7 @@ async function main(config = {}) {
           core.info(`Child issue node ID: ${subIssueNodeId}`);
 
           core.info("✓ Successfully linked issue #" + issue.number + " as sub-issue of #" + effectiveParentIssueNumber);
+          subIssueLinkCounts.set(parentKey, (subIssueLinkCounts.get(parentKey) || 0) + 1);
         } catch (error) {
           core.info(`Warning: Could not link sub-issue to parent: ${getErrorMessage(error)}`);
           core.info(`Error details: ${error instanceof Error ? error.stack : String(error)}`);
@@ -1234,4 +1324,4 @@ async function main(config = {}) {
   };
 }
 
-module.exports = { main, createParentIssueTemplate, searchForExistingParent, getSubIssueCount };
+module.exports = { main, createParentIssueTemplate, searchForExistingParent, getSubIssueCount, findIssueByDedupeKey };
@@ -910,6 +910,50 @@ describe("create_issue", () => {
       });
       expect(mockCore.info).toHaveBeenCalledWith(expect.stringContaining("Warning: Could not link sub-issue to parent"));
     });
+
+    it("should stop linking sub-issues to a parent once max_sub_issues is reached", async () => {
+      mockGithub.graphql.mockImplementation(async (query, variables) => {
+        if (query.includes("issue(number: $issueNumber)")) {
+          return { repository: { issue: { id: `I_${variables.issueNumber}` } } };
+        }
+        return {};
+      });
+
+      const handler = await main({ max_sub_issues: 1 });
+      await handler({ title: "First child", parent: 456 });
+      await handler({ title: "Second child", parent: 456 });
+
+      const linkCalls = mockGithub.graphql.mock.calls.filter(([query]) => query.includes("mutation AddSubIssue"));
+      expect(linkCalls).toHaveLength(1);
+      expect(mockCore.warning).toHaveBeenCalledWith(expect.stringContaining("max-sub-issues limit of 1 reached"));
+      expect(mockGithub.rest.issues.createComment).not.toHaveBeenCalled();
+    });
+  });
+
+  describe("issue types", () => {
+    it("should pass the configured spelling of an allowed issue type", async () => {
+      const handler = await main({ allowed_types: ["Bug", "Feature"] });
+      const result = await handler({ title: "Test", issue_type: "feature" });
+
+      expect(result.success).toBe(true);
+      expect(mockGithub.rest.issues.create.mock.calls[0][0].type).toBe("Feature");
+    });
+
+    it("should reject an issue type outside allowed_types", async () => {
+      const handler = await main({ allowed_types: ["Bug"] });
+      const result = await handler({ title: "Test", issue_type: "Epic" });
+
+      expect(result.success).toBe(false);
+      expect(result.error).toContain('issue type "Epic" is not in the allowed-types list');
+      expect(mockGithub.rest.issues.create).not.toHaveBeenCalled();
+    });
+
+    it("should not send a type when none is requested", async () => {
+      const handler = await main({});
+      await handler({ title: "Test" });
+
+      expect(mockGithub.rest.issues.create.mock.calls[0][0]).not.toHaveProperty("type");
+    });
   });
 
   describe("max limit enforcement", () => {
@@ -1100,6 +1144,77 @@ describe("create_issue", () => {
     });
   });
 
+  describe("dedupe-key", () => {
+    beforeEach(() => {
+      mockGithub.rest.issues.update = vi.fn().mockResolvedValue({
+        data: {
+          number: 42,
+          html_url: "https://github.com/test-owner/test-repo/issues/42",
+        },
+      });
+    });
+
+    it("should update the open issue carrying the dedupe-key marker instead of creating a new one", async () => {
+      mockGithub.rest.search.issuesAndPullRequests.mockResolvedValue({
+        data: {
+          total_count: 1,
+          items: [
+            {
+              number: 42,
+              html_url: "https://github.com/test-owner/test-repo/issues/42",
+              body: "Last week's report\n\n<!-- gh-aw-dedupe-key: weekly-report -->",
+              pull_request: undefined,
+            },
+          ],
+        },
+      });
+
+      const handler = await main({ dedupe_key: "Weekly Report" });
+      const result = await handler({ title: "Weekly Report", body: "This week's report" });
+
+      expect(result.success).toBe(true);
+      expect(result.updated).toBe(true);
+      expect(result.number).toBe(42);
+      expect(mockGithub.rest.search.issuesAndPullRequests).toHaveBeenCalledWith(expect.objectContaining({ q: expect.stringContaining('"gh-aw-dedupe-key: weekly-report" in:body') }));
+      expect(mockGithub.rest.issues.update).toHaveBeenCalledWith(expect.objectContaining({ owner: "test-owner", repo: "test-repo", issue_number: 42 }));
+      expect(mockGithub.rest.issues.update.mock.calls[0][0].body).toContain("<!-- gh-aw-dedupe-key: weekly-report -->");
+      expect(mockGithub.rest.issues.create).not.toHaveBeenCalled();
+    });
+
+    it("should create an issue with the dedupe-key marker when no open issue carries the key", async () => {
+      const handler = await main({ dedupe_key: "weekly-report" });
+      const result = await handler({ title: "Weekly Report", body: "This week's report" });
+
+      expect(result.success).toBe(true);
+      expect(result.updated).toBeUndefined();
+      expect(mockGithub.rest.issues.update).not.toHaveBeenCalled();
+      expect(mockGithub.rest.issues.create).toHaveBeenCalledWith(expect.objectContaining({ body: expect.stringContaining("<!-- gh-aw-dedupe-key: weekly-report -->") }));
+    });
+
+    it("should create an issue when only a different dedupe-key marker is present", async () => {
+      mockGithub.rest.search.issuesAndPullRequests.mockResolvedValue({
+        data: {
+          total_count: 1,
+          items: [
+            {
+              number: 41,
+              html_url: "https://github.com/test-owner/test-repo/issues/41",
+              body: "Archived report\n\n<!-- gh-aw-dedupe-key: weekly-report-archive -->",
+              pull_request: undefined,
+            },
+          ],
+        },
+      });
+
+      const handler = await main({ dedupe_key: "weekly-report" });
+      const result = await handler({ title: "Weekly Report", body: "This week's report" });
+
+      expect(result.success).toBe(true);
+      expect(mockGithub.rest.issues.update).not.toHaveBeenCalled();
+      expect(mockGithub.rest.issues.create).toHaveBeenCalledWith(expect.objectContaining({ body: expect.stringContaining("<!-- gh-aw-dedupe-key: weekly-report -->") }));
+    });
+  });
+
   describe("body sanitization", () => {
     it("should neutralize @mentions in issue body", async () => {
       const handler = await main({});
@@ -0,0 +1,278 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+/**
+ * @typedef {import('./types/handler-factory').HandlerFactoryFunction} HandlerFactoryFunction
+ */
+
+const { getErrorMessage } = require("./error_helpers.cjs");
+const { resolveTargetRepoConfig, resolveAndValidateRepo } = require("./repo_helpers.cjs");
+const { sanitizeContent } = require("./sanitize_content.cjs");
+const { createAuthenticatedGitHubClient } = require("./handler_auth.cjs");
+const { buildWorkflowRunUrl } = require("./workflow_metadata_helpers.cjs");
+const { isTemplatableTrue, isStagedMode, logStagedPreviewInfo, checkRequiredFilter } = require("./safe_output_helpers.cjs");
+const { resolveAllowedMentionsFromPayload } = require("./resolve_mentions_from_payload.cjs");
+
+/** @type {string} Safe output type handled by this module */
+const HANDLER_TYPE = "create_pull_request_review_suggestion";
+
+/** Maximum number of pages of changed files fetched per pull request (1,000 files) */
+const MAX_LIST_FILES_PAGES = 10;
+
+/**
+ * @typedef {Object} DiffHunk
+ * @property {number} start - First line of the hunk on the RIGHT side (new file)
+ * @property {number} end - Last line of the hunk on the RIGHT side (new file)
+ */
+
+/**
+ * Parse the RIGHT-side line ranges of the hunks in a unified diff patch as returned
+ * by the pull request files API. Every line inside a hunk range is either an added
+ * or a context line, so a suggestion can anchor to any range within a single hunk.
+ * @param {string} patch - Unified diff patch of a single file
+ * @returns {DiffHunk[]} RIGHT-side line ranges, one per hunk with new-file lines
+ */
+function parseRightSideHunks(patch) {
+  /** @type {DiffHunk[]} */
+  const hunks = [];
+  const headerPattern = /^@@ -\d+(?:,\d+)? \+(\d+)(?:,(\d+))? @@/gm;
+  let match;
+  while ((match = headerPattern.exec(patch)) !== null) {
+    const start = parseInt(match[1], 10);
+    const count = match[2] === undefined ? 1 : parseInt(match[2], 10);
+    if (count > 0) {
+      hunks.push({ start, end: start + count - 1 });
+    }
+  }
+  return hunks;
+}
+
+/**
+ * Build the review comment body for a suggestion: the optional explanation followed
+ * by a suggestion block. The fence is made longer than any backtick run in the
+ * replacement so the replacement cannot close the block early.
+ * @param {string} explanation - Sanitized explanation, may be empty
+ * @param {string} suggestion - Replacement text for the anchored lines
+ * @returns {string} Comment body
+ */
+function buildSuggestionBody(explanation, suggestion) {
+  const longestRun = Math.max(0, ...(suggestion.match(/`+/g) || []).map(run => run.length));
+  const fence = "`".repeat(Math.max(3, longestRun + 1));
+  const block = `${fence}suggestion\n${suggestion.replace(/\r?\n$/, "")}\n${fence}`;
+  return explanation ? `${explanation}\n\n${block}` : block;
+}
+
+/**
+ * Main handler factory for create_pull_request_review_suggestion
+ * Returns a message handler function that validates suggestions against the current
+ * pull request diff and buffers them in the PR review buffer, so that they are submitted
+ * in the same review as the create_pull_request_review_comment comments.
+ *
+ * Suggestions are rejected when the pull request head moved since the triggering event,
+ * or when the anchored lines are not inside a single hunk of the current diff.
+ *
+ * @type {HandlerFactoryFunction}
+ */
+async function main(config = {}) {
+  const suggestionTarget = config.target || "triggering";
+  const maxCount = config.max || 10;
+  const registry = config._prReviewBufferRegistry || null;
+  const { defaultTargetRepo, allowedRepos } = resolveTargetRepoConfig(config);
+  const githubClient = await createAuthenticatedGitHubClient(config);
+  const requiredLabels = Array.isArray(config.required_labels) ? config.required_labels : [];
+  const requiredTitlePrefix = config.required_title_prefix || "";
+  let allowedMentionAliases = [];
+  if (Array.isArray(config.allowedMentionAliases)) {
+    allowedMentionAliases = config.allowedMentionAliases;
+  } else if (config.mentions != null) {
+    allowedMentionAliases = await resolveAllowedMentionsFromPayload(context, githubClient, core, config.mentions);
+  }
+
+  if (!registry) {
+    core.warning(`${HANDLER_TYPE}: No PR review buffer registry provided in config`);
+    return async function handleCreatePRReviewSuggestion() {
+      return { success: false, error: "No PR review buffer available" };
+    };
+  }
+
+  core.info(`PR review suggestion configuration: target=${suggestionTarget}, max=${maxCount}, default target repo=${defaultTargetRepo}`);
+
+  if (isTemplatableTrue(config.staged)) {
+    registry.setDefaultStaged(true);
+  }
+  if (isStagedMode(config)) {
+    logStagedPreviewInfo("PR review suggestions will be previewed without being submitted");
+  }
+
+  const triggeringIssueNumber = context.payload?.issue?.number && !context.payload?.issue?.pull_request ? context.payload.issue.number : undefined;
+  const triggeringPRNumber = context.payload?.pull_request?.number || (context.payload?.issue?.pull_request ? context.payload.issue.number : undefined);
+  const footerCtx = {
+    workflowName: process.env.GH_AW_WORKFLOW_NAME || "Workflow",
+    runUrl: buildWorkflowRunUrl(context, context.repo),
+    workflowSource: process.env.GH_AW_WORKFLOW_SOURCE || "",
+    workflowSourceURL: process.env.GH_AW_WORKFLOW_SOURCE_URL || "",
+    triggeringIssueNumber,
+    triggeringPRNumber,
+    triggeringDiscussionNumber: context.payload?.discussion?.number,
+  };
+  registry.setDefaultFooterContext(footerCtx);
+
+  /** @type {Map<string, {pullRequest: any, files: Map<string, any>}>} Current PR state, fetched once per PR */
+  const prStates = new Map();
+
+  /**
+   * Fetch the current head and changed files of a pull request.
+   * @param {{owner: string, repo: string}} repoParts
+   * @param {number} pullRequestNumber
+   */
+  async function getPullRequestState(repoParts, pullRequestNumber) {
+    const key = `${repoParts.owner}/${repoParts.repo}#${pullRequestNumber}`;
+    const cached = prStates.get(key);
+    if (cached) return cached;
+
+    const { data: pullRequest } = await githubClient.rest.pulls.get({
+      owner: repoParts.owner,
+      repo: repoParts.repo,
+      pull_number: pullRequestNumber,
+    });
+    /** @type {Map<string, any>} */
+    const files = new Map();
+    for (let page = 1; page <= MAX_LIST_FILES_PAGES; page++) {
+      const { data } = await githubClient.rest.pulls.listFiles({
+        owner: repoParts.owner,
+        repo: repoParts.repo,
+        pull_number: pullRequestNumber,
+        per_page: 100,
+        page,
+      });
+      if (!Array.isArray(data) || data.length === 0) break;
+      for (const file of data) {
+        files.set(file.filename, file);
+      }
+      if (data.length < 100) break;
+    }
+    const state = { pullRequest, files };
+    prStates.set(key, state);
+    return state;
+  }
+
+  let processedCount = 0;
+
+  /**
+   * Message handler function that validates and buffers a single create_pull_request_review_suggestion message
+   * @param {Object} message - The create_pull_request_review_suggestion message to process
+   * @param {Object} resolvedTemporaryIds - Map of temporary IDs to {repo, number} (unused)
+   * @returns {Promise<Object>} Result with success/error status and suggestion details
+   */
+  return async function handleCreatePRReviewSuggestion(message, resolvedTemporaryIds) {
+    if (processedCount >= maxCount) {
+      core.warning(`Skipping ${HANDLER_TYPE}: max count of ${maxCount} reached`);
+      return { success: false, error: `Max count of ${maxCount} reached` };
+    }
+    processedCount++;
+
+    core.info(`Processing ${HANDLER_TYPE}: path=${message.path}, line=${message.line}, suggestionLength=${message.suggestion?.length || 0}`);
+
+    const repoResult = resolveAndValidateRepo(message, defaultTargetRepo, allowedRepos, "PR review suggestion");
+    if (!repoResult.success) {
+      core.warning(`Skipping PR review suggestion: ${repoResult.error}`);
+      return { success: false, error: repoResult.error };
+    }
+    const { repo: itemRepo, repoParts } = repoResult;
+
+    if (!message.path || typeof message.path !== "string") {
+      return { success: false, error: 'Missing required field "path"' };
+    }
+    if (typeof message.suggestion !== "string") {
+      return { success: false, error: 'Missing or invalid required field "suggestion"' };
+    }
+    const line = parseInt(message.line, 10);
+    if (Number.isNaN(line) || line <= 0) {
+      return { success: false, error: `Invalid line number: ${message.line}` };
+    }
+    const startLine = message.start_line ? parseInt(message.start_line, 10) : line;
+    if (Number.isNaN(startLine) || startLine <= 0 || startLine > line) {
+      return { success: false, error: `Invalid start_line: ${message.start_line} (must be <= line: ${line})` };
+    }
+
+    // The head SHA the agent saw is only known when the run was triggered by the pull request itself
+    let pullRequestNumber;
+    let triggeringHeadSha;
+    if (suggestionTarget === "*") {
+      pullRequestNumber = parseInt(message.pull_request_number, 10);
+      if (Number.isNaN(pullRequestNumber) || pullRequestNumber <= 0) {
+        return { success: false, error: `Target is "*" but no valid pull_request_number specified: ${message.pull_request_number}` };
+      }
+    } else if (suggestionTarget !== "triggering") {
+      pullRequestNumber = parseInt(suggestionTarget, 10);
+      if (Number.isNaN(pullRequestNumber) || pullRequestNumber <= 0) {
+        return { success: false, error: `Invalid pull request number in target: ${suggestionTarget}` };
+      }
+    } else if (triggeringPRNumber) {
+      pullRequestNumber = triggeringPRNumber;
+    } else {
+      core.info('Target is "triggering" but not running in pull request context, skipping review suggestion');
+      return { success: false, error: "Not in pull request context", skipped: true };
+    }
+    if (context.payload?.pull_request?.number === pullRequestNumber && itemRepo === `${context.repo.owner}/${context.repo.repo}`) {
+      triggeringHeadSha = context.payload.pull_request.head?.sha;
+    }
+
+    let state;
+    try {
+      state = await getPullRequestState(repoParts, pullRequestNumber);
+    } catch (error) {
+      core.warning(`Failed to fetch the diff of PR #${pullRequestNumber}: ${getErrorMessage(error)}`);
+      return { success: false, error: `Failed to fetch pull request diff: ${getErrorMessage(error)}` };
+    }
+    const { pullRequest, files } = state;
+
+    if (triggeringHeadSha && pullRequest.head?.sha !== triggeringHeadSha) {
+      core.warning(`Skipping stale suggestion on ${message.path}:${line}: PR #${pullRequestNumber} head moved from ${triggeringHeadSha} to ${pullRequest.head?.sha}`);
+      return { success: false, error: `Pull request head moved from ${triggeringHeadSha} to ${pullRequest.head?.sha} since the run started; the suggestion may be stale` };
+    }
+
+    const file = files.get(message.path);
+    if (!file) {
+      return { success: false, error: `Path '${message.path}' is not part of the PR #${pullRequestNumber} diff` };
+    }
+    if (!file.patch) {
+      return { success: false, error: `Path '${message.path}' has no textual diff in PR #${pullRequestNumber}` };
+    }
+    const hunks = parseRightSideHunks(file.patch);
+    if (!hunks.some(hunk => hunk.start <= startLine && line <= hunk.end)) {
+      const ranges = hunks.map(hunk => `${hunk.start}-${hunk.end}`).join(", ") || "none";
+      return { success: false, error: `Lines ${startLine}-${line} of '${message.path}' are not within a single diff hunk (changed line ranges: ${ranges})` };
+    }
+
+    const filterResult = await checkRequiredFilter(githubClient, repoParts, pullRequestNumber, requiredLabels, requiredTitlePrefix, HANDLER_TYPE);
+    if (filterResult) return filterResult;
+
+    const buffer = registry.getOrCreate(itemRepo, pullRequestNumber);
+    if (!buffer) {
+      return { success: false, error: `Could not get review buffer for ${itemRepo}#${pullRequestNumber}` };
+    }
+    buffer.setFooterContext(footerCtx);
+    buffer.setReviewContext({ repo: itemRepo, repoParts, pullRequestNumber, pullRequest });
+
+    // The replacement is applied verbatim when a maintainer commits the suggestion, so only
+    // the explanation is sanitized; the suggestion block keeps mentions and links inert.
+    const explanation = typeof message.body === "string" ? sanitizeContent(message.body.trim(), { allowedAliases: allowedMentionAliases }) : "";
+    /** @type {import('./pr_review_buffer.cjs').BufferedComment} */
+    const bufferedComment = {
+      path: message.path,
+      line,
+      body: buildSuggestionBody(explanation, message.suggestion),
+      side: "RIGHT",
+    };
+    if (startLine !== line) {
+      bufferedComment.start_line = startLine;
+    }
+    buffer.addComment(bufferedComment);
+
+    core.info(`Buffered review suggestion on PR #${pullRequestNumber} in ${itemRepo} at ${message.path}:${startLine !== line ? `${startLine}-` : ""}${line}`);
+    return { success: true, buffered: true, pull_request_number: pullRequestNumber, repo: itemRepo };
+  };
+}
+
+module.exports = { main, parseRightSideHunks, buildSuggestionBody };
@@ -0,0 +1,165 @@
+import { describe, it, expect, beforeEach, vi } from "vitest";
+
+const PATCH = ["@@ -10,4 +10,5 @@ function greet() {", " const a = 1;", "-const b = 2;", "+const b = 3;", "+const c = 4;", " return a + b;", " }", "@@ -40,2 +41,3 @@", " x();", "+y();", " z();"].join("\n");
+
+describe("create_pr_review_suggestion.cjs", () => {
+  let mockGithub;
+  let comments;
+  let reviewContexts;
+  let registry;
+
+  beforeEach(() => {
+    global.core = {
+      info: vi.fn(),
+      warning: vi.fn(),
+      error: vi.fn(),
+      setOutput: vi.fn(),
+      summary: { addRaw: vi.fn().mockReturnThis(), write: vi.fn().mockResolvedValue() },
+    };
+    mockGithub = {
+      rest: {
+        pulls: {
+          get: vi.fn().mockResolvedValue({ data: { number: 123, head: { sha: "abc123" } } }),
+          listFiles: vi.fn().mockResolvedValue({ data: [{ filename: "src/greet.js", patch: PATCH }, { filename: "logo.png" }] }),
+        },
+        issues: { get: vi.fn() },
+      },
+    };
+    global.github = mockGithub;
+    global.context = {
+      eventName: "pull_request",
+      runId: 12345,
+      serverUrl: "https://github.com",
+      repo: { owner: "testowner", repo: "testrepo" },
+      payload: { pull_request: { number: 123, head: { sha: "abc123" } } },
+    };
+
+    comments = [];
+    reviewContexts = [];
+    const buffer = {
+      addComment: comment => comments.push(comment),
+      setFooterContext: vi.fn(),
+      setReviewContext: ctx => reviewContexts.push(ctx),
+    };
+    registry = {
+      getOrCreate: vi.fn().mockReturnValue(buffer),
+      setDefaultStaged: vi.fn(),
+      setDefaultFooterContext: vi.fn(),
+    };
+  });
+
+  async function createHandler(extraConfig = {}) {
+    const { main } = require("./create_pr_review_suggestion.cjs");
+    return await main({ _prReviewBufferRegistry: registry, ...extraConfig });
+  }
+
+  describe("parseRightSideHunks", () => {
+    it("should return the RIGHT-side line range of each hunk", () => {
+      const { parseRightSideHunks } = require("./create_pr_review_suggestion.cjs");
+      expect(parseRightSideHunks(PATCH)).toEqual([
+        { start: 10, end: 14 },
+        { start: 41, end: 43 },
+      ]);
+    });
+
+    it("should treat a missing count as one line and skip deletion-only hunks", () => {
+      const { parseRightSideHunks } = require("./create_pr_review_suggestion.cjs");
+      expect(parseRightSideHunks("@@ -1 +1 @@\n-a\n+b\n@@ -5,2 +4,0 @@\n-c\n-d")).toEqual([{ start: 1, end: 1 }]);
+    });
+  });
+
+  describe("buildSuggestionBody", () => {
+    it("should put the explanation before the suggestion block", () => {
+      const { buildSuggestionBody } = require("./create_pr_review_suggestion.cjs");
+      expect(buildSuggestionBody("Use const.", "const b = 3;\n")).toBe("Use const.\n\n```suggestion\nconst b = 3;\n```");
+    });
+
+    it("should use a longer fence when the replacement contains backticks", () => {
+      const { buildSuggestionBody } = require("./create_pr_review_suggestion.cjs");
+      expect(buildSuggestionBody("", "```js\nx\n```")).toBe("````suggestion\n```js\nx\n```\n````");
+    });
+  });
+
+  it("should buffer a suggestion anchored inside a hunk", async () => {
+    const handler = await createHandler();
+    const result = await handler({ path: "src/greet.js", start_line: 11, line: 12, suggestion: "const b = 5;", body: "Five is clearer." }, {});
+
+    expect(result.success).toBe(true);
+    expect(result.buffered).toBe(true);
+    expect(result.pull_request_number).toBe(123);
+    expect(comments).toEqual([{ path: "src/greet.js", line: 12, start_line: 11, side: "RIGHT", body: "Five is clearer.\n\n```suggestion\nconst b = 5;\n```" }]);
+    expect(reviewContexts[0].pullRequest.head.sha).toBe("abc123");
+  });
+
+  it("should reject lines outside the diff hunks", async () => {
+    const handler = await createHandler();
+    const result = await handler({ path: "src/greet.js", line: 20, suggestion: "x" }, {});
+
+    expect(result.success).toBe(false);
+    expect(result.error).toContain("not within a single diff hunk");
+    expect(comments).toHaveLength(0);
+  });
+
+  it("should reject ranges spanning two hunks", async () => {
+    const handler = await createHandler();
+    const result = await handler({ path: "src/greet.js", start_line: 13, line: 42, suggestion: "x" }, {});
+
+    expect(result.success).toBe(false);
+    expect(result.error).toContain("10-14, 41-43");
+  });
+
+  it("should reject paths that are not in the diff or have no patch", async () => {
+    const handler = await createHandler();
+    const missing = await handler({ path: "src/other.js", line: 1, suggestion: "x" }, {});
+    const binary = await handler({ path: "logo.png", line: 1, suggestion: "x" }, {});
+
+    expect(missing.error).toContain("is not part of the PR #123 diff");
+    expect(binary.error).toContain("has no textual diff");
+    expect(mockGithub.rest.pulls.get.mock.calls).toHaveLength(1);
+  });
+
+  it("should reject stale suggestions when the head moved since the triggering event", async () => {
+    mockGithub.rest.pulls.get = vi.fn().mockResolvedValue({ data: { number: 123, head: { sha: "def456" } } });
+    const handler = await createHandler();
+    const result = await handler({ path: "src/greet.js", line: 12, suggestion: "x" }, {});
+
+    expect(result.success).toBe(false);
+    expect(result.error).toContain("head moved from abc123 to def456");
+    expect(comments).toHaveLength(0);
+  });
+
+  it("should require pull_request_number when target is '*'", async () => {
+    global.context.payload = {};
+    const handler = await createHandler({ target: "*" });
+    const missing = await handler({ path: "src/greet.js", line: 12, suggestion: "x" }, {});
+    const explicit = await handler({ path: "src/greet.js", line: 12, suggestion: "x", pull_request_number: 123 }, {});
+
+    expect(missing.success).toBe(false);
+    expect(explicit.success).toBe(true);
+  });
+
+  it("should skip outside of a pull request context", async () => {
+    global.context.payload = { issue: { number: 7 } };
+    const handler = await createHandler();
+    const result = await handler({ path: "src/greet.js", line: 12, suggestion: "x" }, {});
+
+    expect(result.skipped).toBe(true);
+  });
+
+  it("should enforce the max count", async () => {
+    const handler = await createHandler({ max: 1 });
+    await handler({ path: "src/greet.js", line: 12, suggestion: "x" }, {});
+    const result = await handler({ path: "src/greet.js", line: 13, suggestion: "y" }, {});
+
+    expect(result.success).toBe(false);
+    expect(result.error).toContain("Max count of 1 reached");
+  });
+
+  it("should return an error without a PR review buffer registry", async () => {
+    const { main } = require("./create_pr_review_suggestion.cjs");
+    const handler = await main({});
+    const result = await handler({}, {});
+
+    expect(result.error).toContain("No PR review buffer available");
+  });
+});
@@ -28,10 +28,10 @@ const { findRepoCheckout } = require("./find_repo_checkout.cjs");
 const { getBaseBranch } = require("./get_base_branch.cjs");
 const { createAuthenticatedGitHubClient } = require("./handler_auth.cjs");
 const { buildWorkflowRunUrl } = require("./workflow_metadata_helpers.cjs");
-const { checkFileProtection, checkFileProtectionPostApply } = require("./manifest_file_helpers.cjs");
+const { checkDirectoryGroups, checkFileProtection, checkFileProtectionPostApply } = require("./manifest_file_helpers.cjs");
 const { renderTemplateFromFile, renderFilesList, buildProtectedFileList, getPromptPath } = require("./messages_core.cjs");
 const { overridePersistedExtraheader, restorePersistedExtraheader } = require("./git_auth_helpers.cjs");
-const { COPILOT_REVIEWER_BOT, FAQ_CREATE_PR_PERMISSIONS_URL } = require("./constants.cjs");
+const { COPILOT_REVIEWER_BOT, FAQ_CREATE_PR_PERMISSIONS_URL, TMP_GH_AW_PATH } = require("./constants.cjs");
 const { isStagedMode } = require("./safe_output_helpers.cjs");
 const { normalizeCommitSHA } = require("./commit_sha_helpers.cjs");
 const { withRetry, RATE_LIMIT_RETRY_CONFIG } = require("./error_recovery.cjs");
@@ -1306,6 +1306,21 @@ async function main(config = {}) {
         }
       }
 
+      // group-by-directory: every file must be inside one directory matching the pattern,
+      // so that each pull request covers exactly one group (e.g. one language directory).
+      if (!isEmpty && config.group_by_directory) {
+        const { groups, ungroupedFiles } = checkDirectoryGroups(patchContent, config.group_by_directory);
+        if (ungroupedFiles.length > 0 || groups.length > 1) {
+          const details = ungroupedFiles.length > 0 ? `files outside any matching directory: ${ungroupedFiles.join(", ")}` : `files in several directories: ${groups.join(", ")}`;
+          const message = `Cannot create pull request: group-by-directory "${config.group_by_directory}" requires all changes to be inside a single matching directory, but the patch has ${details}. Create a separate branch and pull request for each directory.`;
+          core.error(message);
+          return { success: false, error: message };
+        }
+        if (groups.length === 1) {
+          core.info(`Pull request groups changes under ${groups[0]}`);
+        }
+      }
+
       if (isEmpty && !isStaged && !allowEmpty) {
         const message = "Patch file is empty - no changes to apply (noop operation)";
 
@@ -1731,7 +1746,7 @@ async function main(config = {}) {
                 const runUrl = buildWorkflowRunUrl(context, context.repo);
                 const runId = context.runId;
 
-                const artifactFileName = bundleFilePath ? bundleFilePath.replace("/tmp/gh-aw/", "") : "aw-unknown.bundle";
+                const artifactFileName = bundleFilePath ? bundleFilePath.replace(`${TMP_GH_AW_PATH}/`, "") : "aw-unknown.bundle";
                 const fallbackBundleSourceRef = `refs/heads/${originalAgentBranch || branchName}`;
                 const fallbackBundleTempRef = createBundleTempRef(branchName);
                 const pushFailureMessage = sanitizeContent(neutralizeClosingKeywordsForIssueBody(getErrorMessage(pushError)), { allowedAliases: allowedMentionAliases })
@@ -2099,7 +2114,7 @@ gh pr create --title '${title}' --base ${baseBranch} --head ${getPullRequestHead
                   patchPreview = generatePatchPreview(patchContent);
                 }
 
-                const patchFileName = patchFilePath ? patchFilePath.replace("/tmp/gh-aw/", "") : "aw-unknown.patch";
+                const patchFileName = patchFilePath ? patchFilePath.replace(`${TMP_GH_AW_PATH}/`, "") : "aw-unknown.patch";
                 const pushFailureMessage = sanitizeContent(neutralizeClosingKeywordsForIssueBody(getErrorMessage(pushError)), { allowedAliases: allowedMentionAliases })
                   .replace(/\s+/g, " ")
                   .trim();
@@ -2280,7 +2295,7 @@ ${patchPreview}`;
           // Push failed — branch not on remote, so compare URL is unavailable.
           // Use the push-failed template with artifact download instructions.
           const runId = context.runId;
-          const patchFileName = patchFilePath ? patchFilePath.replace("/tmp/gh-aw/", "") : "aw-unknown.patch";
+          const patchFileName = patchFilePath ? patchFilePath.replace(`${TMP_GH_AW_PATH}/`, "") : "aw-unknown.patch";
           const pushFailedTemplatePath = getPromptPath("manifest_protection_push_failed_fallback.md");
           fallbackBody = renderTemplateFromFile(pushFailedTemplatePath, {
             main_body: issueSafeMainBodyContent,
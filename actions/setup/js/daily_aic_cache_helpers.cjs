@@ -9,8 +9,10 @@
  * this module is the single source of truth for that logic.
  */
 
+const { TMP_GH_AW_PATH } = require("./constants.cjs");
+
 /** Path where the per-workflow usage cache lives on the runner. */
-const AIC_USAGE_CACHE_FILE_PATH = "/tmp/gh-aw/agentic-workflow-usage-cache.jsonl";
+const AIC_USAGE_CACHE_FILE_PATH = `${TMP_GH_AW_PATH}/agentic-workflow-usage-cache.jsonl`;
 
 /** Cache entries older than this threshold (in ms) are pruned when reading or writing. */
 const CACHE_RETENTION_MS = 48 * 60 * 60 * 1000;
@@ -39,8 +39,9 @@
 
 const fs = require("fs");
 const { MAX_RUNS_EXCEEDED_PATTERNS, isMaxRunsExceededError } = require("./harness_retry_guard.cjs");
+const { TMP_GH_AW_PATH } = require("./constants.cjs");
 
-const LOG_FILE = "/tmp/gh-aw/agent-stdio.log";
+const LOG_FILE = `${TMP_GH_AW_PATH}/agent-stdio.log`;
 
 // Pattern: Copilot CLI inference access denied
 const INFERENCE_ACCESS_ERROR_PATTERN = /Access denied by policy settings|invalid access to inference/;
@@ -0,0 +1,61 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+/**
+ * Disable a workflow whose on.stop-after time has passed.
+ * This script runs in the disable_expired job, which only runs after the
+ * pre-activation stop-time check reports that the stop time was reached, so
+ * an expired workflow stops being scheduled instead of being skipped forever.
+ */
+
+const { getErrorMessage } = require("./error_helpers.cjs");
+const { ERR_CONFIG } = require("./error_codes.cjs");
+
+/**
+ * Returns true when the running workflow is the given lock file. Runs invoked
+ * through workflow_call report the caller's workflow ref, which must not be disabled.
+ *
+ * @param {string} workflowRef - GITHUB_WORKFLOW_REF (owner/repo/.github/workflows/file.yml@ref)
+ * @param {string} workflowFile - Lock file name (e.g. my-workflow.lock.yml)
+ * @returns {boolean}
+ */
+function isRunningWorkflowFile(workflowRef, workflowFile) {
+  const [workflowPath] = workflowRef.split("@");
+  return workflowPath.endsWith(`/.github/workflows/${workflowFile}`);
+}
+
+async function main() {
+  const workflowFile = process.env.GH_AW_WORKFLOW_FILE;
+  const stopTime = process.env.GH_AW_STOP_TIME || "";
+
+  if (!workflowFile) {
+    core.setFailed(`${ERR_CONFIG}: Configuration error: GH_AW_WORKFLOW_FILE not specified.`);
+    return;
+  }
+
+  const workflowRef = process.env.GITHUB_WORKFLOW_REF || "";
+  if (workflowRef && !isRunningWorkflowFile(workflowRef, workflowFile)) {
+    core.info(`ℹ️ Running as part of ${workflowRef}; not disabling ${workflowFile}`);
+    return;
+  }
+
+  const { owner, repo } = context.repo;
+  core.info(`Disabling ${workflowFile} in ${owner}/${repo}: stop-after time ${stopTime} has passed`);
+
+  try {
+    await github.rest.actions.disableWorkflow({ owner, repo, workflow_id: workflowFile });
+  } catch (error) {
+    // Disabling is best effort: the stop-time check keeps skipping runs either way.
+    core.warning(`Failed to disable ${workflowFile}: ${getErrorMessage(error)}. Disable it manually with: gh aw disable`);
+    return;
+  }
+
+  core.info(`✅ Disabled ${workflowFile}`);
+  await core.summary
+    .addRaw("### ⏰ Workflow disabled\n\n")
+    .addRaw(`\`${workflowFile}\` passed its \`on.stop-after\` time (${stopTime}) and has been disabled.\n\n`)
+    .addRaw("To run it again, update or remove `on.stop-after:`, recompile with `gh aw compile --refresh-stop-time`, and re-enable it with `gh aw enable`.\n")
+    .write();
+}
+
+module.exports = { main, isRunningWorkflowFile };
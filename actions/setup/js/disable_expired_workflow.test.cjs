@@ -0,0 +1,87 @@
+// @ts-check
+import { describe, it, expect, beforeEach, afterEach, vi } from "vitest";
+
+describe("disable_expired_workflow", () => {
+  let mockCore;
+  let mockGithub;
+  let disableExpiredWorkflow;
+
+  beforeEach(async () => {
+    mockCore = {
+      info: vi.fn(),
+      warning: vi.fn(),
+      setFailed: vi.fn(),
+      summary: {
+        addRaw: vi.fn().mockReturnThis(),
+        write: vi.fn().mockResolvedValue(undefined),
+      },
+    };
+    mockGithub = {
+      rest: {
+        actions: {
+          disableWorkflow: vi.fn().mockResolvedValue({}),
+        },
+      },
+    };
+    global.core = mockCore;
+    global.github = mockGithub;
+    global.context = { repo: { owner: "octo", repo: "demo" } };
+
+    process.env.GH_AW_WORKFLOW_FILE = "trial.lock.yml";
+    process.env.GH_AW_STOP_TIME = "2026-01-01 00:00:00";
+    process.env.GITHUB_WORKFLOW_REF = "octo/demo/.github/workflows/trial.lock.yml@refs/heads/main";
+
+    vi.resetModules();
+    disableExpiredWorkflow = await import("./disable_expired_workflow.cjs");
+  });
+
+  afterEach(() => {
+    delete process.env.GH_AW_WORKFLOW_FILE;
+    delete process.env.GH_AW_STOP_TIME;
+    delete process.env.GITHUB_WORKFLOW_REF;
+    delete global.core;
+    delete global.github;
+    delete global.context;
+    vi.clearAllMocks();
+  });
+
+  it("disables the expired workflow by lock file name", async () => {
+    await disableExpiredWorkflow.main();
+
+    expect(mockGithub.rest.actions.disableWorkflow).toHaveBeenCalledWith({ owner: "octo", repo: "demo", workflow_id: "trial.lock.yml" });
+    expect(mockCore.summary.write).toHaveBeenCalled();
+    expect(mockCore.setFailed).not.toHaveBeenCalled();
+  });
+
+  it("does not disable the caller when invoked through workflow_call", async () => {
+    process.env.GITHUB_WORKFLOW_REF = "octo/demo/.github/workflows/caller.yml@refs/heads/main";
+
+    await disableExpiredWorkflow.main();
+
+    expect(mockGithub.rest.actions.disableWorkflow).not.toHaveBeenCalled();
+  });
+
+  it("warns instead of failing when the API call fails", async () => {
+    mockGithub.rest.actions.disableWorkflow.mockRejectedValue(new Error("Resource not accessible by integration"));
+
+    await disableExpiredWorkflow.main();
+
+    expect(mockCore.warning).toHaveBeenCalledWith(expect.stringContaining("Failed to disable trial.lock.yml"));
+    expect(mockCore.setFailed).not.toHaveBeenCalled();
+  });
+
+  it("fails when the workflow file is not configured", async () => {
+    delete process.env.GH_AW_WORKFLOW_FILE;
+
+    await disableExpiredWorkflow.main();
+
+    expect(mockCore.setFailed).toHaveBeenCalledWith(expect.stringContaining("GH_AW_WORKFLOW_FILE"));
+  });
+
+  describe("isRunningWorkflowFile", () => {
+    it("matches the lock file in the workflow ref", () => {
+      expect(disableExpiredWorkflow.isRunningWorkflowFile("o/r/.github/workflows/a.lock.yml@refs/heads/main", "a.lock.yml")).toBe(true);
+      expect(disableExpiredWorkflow.isRunningWorkflowFile("o/r/.github/workflows/ba.lock.yml@refs/heads/main", "a.lock.yml")).toBe(false);
+    });
+  });
+});
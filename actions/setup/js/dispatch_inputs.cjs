@@ -0,0 +1,49 @@
+// @ts-check
+
+// dispatch_inputs.cjs
+// Sanitizes workflow_dispatch input values before they are interpolated into the prompt.
+// Dispatch inputs are free-form text typed by whoever triggers the run, so they receive
+// the same treatment as issue and comment bodies (see compute_text.cjs).
+
+const { sanitizeIncomingText } = require("./sanitize_incoming_text.cjs");
+
+/**
+ * Matches inputs.<name> or github.event.inputs.<name> anywhere in an expression.
+ * aw_context is an internal JSON input managed by gh-aw and is never sanitized.
+ */
+const DISPATCH_INPUT_EXPRESSION_PATTERN = /(?:^|[^\w.])(?:github\.event\.)?inputs\.(?!aw_context\b)[a-zA-Z0-9_-]+/;
+
+/**
+ * Matches the placeholder names the compiler generates for input expressions,
+ * e.g. GH_AW_INPUTS_TOPIC or GH_AW_GITHUB_EVENT_INPUTS_TOPIC.
+ */
+const DISPATCH_INPUT_PLACEHOLDER_PATTERN = /^GH_AW_(?:GITHUB_EVENT_)?INPUTS_(?!AW_CONTEXT$)[A-Z0-9_-]+$/;
+
+/**
+ * Reports whether a GitHub Actions expression reads a dispatch input.
+ * @param {string} expr - Expression without the ${{ }} wrapper
+ * @returns {boolean}
+ */
+function referencesDispatchInput(expr) {
+  return DISPATCH_INPUT_EXPRESSION_PATTERN.test(expr.trim());
+}
+
+/**
+ * Reports whether a placeholder name was generated for a dispatch input expression.
+ * @param {string} name - Placeholder or environment variable name
+ * @returns {boolean}
+ */
+function isDispatchInputPlaceholder(name) {
+  return DISPATCH_INPUT_PLACEHOLDER_PATTERN.test(name);
+}
+
+/**
+ * Sanitizes a dispatch input value for inclusion in the prompt.
+ * @param {string} value
+ * @returns {string}
+ */
+function sanitizeDispatchInput(value) {
+  return sanitizeIncomingText(value);
+}
+
+module.exports = { referencesDispatchInput, isDispatchInputPlaceholder, sanitizeDispatchInput };
@@ -23,6 +23,7 @@ require("./shim.cjs");
 const fs = require("fs");
 const { nowMs } = require("./performance_now.cjs");
 const { getErrorMessage } = require("./error_helpers.cjs");
+const { TMP_GH_AW_PATH } = require("./constants.cjs");
 
 const {
   generateTraceId,
@@ -38,9 +39,9 @@ const {
   buildCustomOTLPAttributes,
 } = require("./send_otlp_span.cjs");
 
-const AW_INFO_PATH = "/tmp/gh-aw/aw_info.json";
-const EVALUATIONS_PATH = "/tmp/gh-aw/outcome-evaluations.jsonl";
-const SUMMARY_PATH = "/tmp/gh-aw/outcome-summary.json";
+const AW_INFO_PATH = `${TMP_GH_AW_PATH}/aw_info.json`;
+const EVALUATIONS_PATH = `${TMP_GH_AW_PATH}/outcome-evaluations.jsonl`;
+const SUMMARY_PATH = `${TMP_GH_AW_PATH}/outcome-summary.json`;
 const OTLP_STATUS_UNSET = 0;
 const OTLP_STATUS_OK = 1;
 const OTLP_STATUS_ERROR = 2;
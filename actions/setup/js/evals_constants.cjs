@@ -1,5 +1,7 @@
 "use strict";
 
-const EVALS_OUTPUT_PATH = "/tmp/gh-aw/evals.jsonl";
+const { TMP_GH_AW_PATH } = require("./constants.cjs");
+
+const EVALS_OUTPUT_PATH = `${TMP_GH_AW_PATH}/evals.jsonl`;
 
 module.exports = { EVALS_OUTPUT_PATH };
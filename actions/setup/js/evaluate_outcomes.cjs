@@ -29,15 +29,16 @@ const fs = require("fs");
 const path = require("path");
 const crypto = require("crypto");
 const { execFileSync } = require("child_process");
+const { TMP_GH_AW_PATH } = require("./constants.cjs");
 
 // ---------------------------------------------------------------------------
 // Paths
 // ---------------------------------------------------------------------------
-const CACHE_DIR = "/tmp/gh-aw/cache-memory/outcome-collector";
+const CACHE_DIR = `${TMP_GH_AW_PATH}/cache-memory/outcome-collector`;
 const SEEN_FILE = path.join(CACHE_DIR, "seen-runs.json");
-const OUTCOMES_DIR = "/tmp/gh-aw/outcomes";
-const EVAL_JSONL = "/tmp/gh-aw/outcome-evaluations.jsonl";
-const SUMMARY_PATH = "/tmp/gh-aw/outcome-summary.json";
+const OUTCOMES_DIR = `${TMP_GH_AW_PATH}/outcomes`;
+const EVAL_JSONL = `${TMP_GH_AW_PATH}/outcome-evaluations.jsonl`;
+const SUMMARY_PATH = `${TMP_GH_AW_PATH}/outcome-summary.json`;
 
 // ---------------------------------------------------------------------------
 // Noop types that are tracked but not counted as actionable
@@ -2,6 +2,7 @@
 
 const fs = require("fs");
 const path = require("path");
+const { TMP_GH_AW_PATH } = require("./constants.cjs");
 
 /**
  * Path to the experiment assignments file written by pick_experiment.cjs.
@@ -11,7 +12,7 @@ const path = require("path");
  * Used as the default fallback when `GH_AW_EXPERIMENT_STATE_DIR` is not set.
  * @type {string}
  */
-const EXPERIMENT_ASSIGNMENTS_PATH = "/tmp/gh-aw/experiments/assignments.json";
+const EXPERIMENT_ASSIGNMENTS_PATH = `${TMP_GH_AW_PATH}/experiments/assignments.json`;
 
 /**
  * Read the experiment assignments written by pick_experiment.cjs.
@@ -0,0 +1,38 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+// extract_agentic_jobs.cjs
+//
+// Removes ## job: `name` blocks from workflow markdown.
+//
+// Agentic job blocks are compiled into companion workflows that run as separate
+// jobs, so they must not reach the prompt of the main agent. The compiler strips
+// them from inlined prompts; runtime_import.cjs strips them from the markdown body
+// when it is loaded at runtime, before its expressions are validated.
+//
+// Marker syntax
+// ─────────────
+//   ## job: `name`       Opens an agentic job block.
+//
+// Job blocks follow the main prompt: everything from the first marker to EOF
+// belongs to agentic jobs.
+
+// Regex for the start marker: ## job: `name` (lowercase identifier)
+const JOB_MARKER_RE = /^##[ \t]+job:[ \t]+`[a-z][a-z0-9_-]*`[ \t]*$/m;
+
+/**
+ * Returns the content before the first ## job: marker, with trailing newlines
+ * stripped. Content without markers is returned unchanged.
+ *
+ * @param {string} content - Markdown with potential agentic job blocks.
+ * @returns {string}
+ */
+function stripAgenticJobs(content) {
+  const match = JOB_MARKER_RE.exec(content);
+  if (!match) {
+    return content;
+  }
+  return content.slice(0, match.index).replace(/\n+$/, "");
+}
+
+module.exports = { stripAgenticJobs };
@@ -0,0 +1,33 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+import { describe, it, expect } from "vitest";
+
+const { stripAgenticJobs } = require("./extract_agentic_jobs.cjs");
+
+describe("stripAgenticJobs", () => {
+  it("returns content unchanged when no markers are present", () => {
+    const content = "# Hello\n\n## Details\n\nThis is a workflow.";
+    expect(stripAgenticJobs(content)).toBe(content);
+  });
+
+  it("removes everything from the first job marker", () => {
+    const content = "# Main\n\nDo the work.\n\n## job: `research`\n---\nengine: claude\n---\nResearch.\n\n## job: `review`\nReview.\n";
+    expect(stripAgenticJobs(content)).toBe("# Main\n\nDo the work.");
+  });
+
+  it("keeps other level-2 headings before the first job marker", () => {
+    const content = "# Main\n\n## Steps\n\nStep one.\n\n## job: `research`\nResearch.";
+    expect(stripAgenticJobs(content)).toBe("# Main\n\n## Steps\n\nStep one.");
+  });
+
+  it("ignores job markers that are not at the start of a line", () => {
+    const content = "Use a heading like ## job: `name` to add a job.";
+    expect(stripAgenticJobs(content)).toBe(content);
+  });
+
+  it("ignores job names that are not lowercase identifiers", () => {
+    const content = "# Main\n\n## job: `Research`\nResearch.";
+    expect(stripAgenticJobs(content)).toBe(content);
+  });
+});
@@ -13,6 +13,7 @@ const path = require("path");
 const { sanitizeDomainName } = require("./sanitize_content_core.cjs");
 const { renderTemplateFromFile, getPromptPath } = require("./messages_core.cjs");
 const { renderMarkdownTemplate } = require("./render_template.cjs");
+const { TMP_GH_AW_PATH } = require("./constants.cjs");
 
 /**
  * Parses a single firewall log line
@@ -112,7 +113,7 @@ function extractAndSanitizeDomain(domainWithPort) {
  * @returns {string[]} Array of unique blocked domains (sanitized, sorted)
  */
 function getBlockedDomains(logsDir) {
-  const squidLogsDir = logsDir || "/tmp/gh-aw/sandbox/firewall/logs/";
+  const squidLogsDir = logsDir || `${TMP_GH_AW_PATH}/sandbox/firewall/logs/`;
 
   // Check if logs directory exists
   if (!fs.existsSync(squidLogsDir)) {
@@ -0,0 +1,130 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+/**
+ * Queue of follow-up runs scheduled by the schedule_follow_up safe output.
+ *
+ * The queue is a JSON file restored from and saved to the actions cache by the
+ * compiler-generated steps around the handlers that use it. The safe outputs job
+ * appends entries, the pre-activation job checks whether any entry is due on the
+ * polling schedule, and the activation job takes the due entries out of the queue
+ * and hands them to the agent.
+ */
+
+const fs = require("fs");
+const path = require("path");
+const { getErrorMessage } = require("./error_helpers.cjs");
+const { CACHE_STAGING_PATH } = require("./constants.cjs");
+
+/** Directory restored from and saved to the actions cache */
+const FOLLOW_UP_QUEUE_DIR = `${CACHE_STAGING_PATH}/follow-ups`;
+
+/** Queue file inside FOLLOW_UP_QUEUE_DIR */
+const FOLLOW_UP_QUEUE_FILE = path.join(FOLLOW_UP_QUEUE_DIR, "queue.json");
+
+/** Follow-ups cannot be due sooner than the polling interval */
+const MIN_FOLLOW_UP_DELAY_MS = 60 * 60 * 1000;
+
+const DELAY_UNITS_MS = {
+  w: 7 * 24 * 60 * 60 * 1000,
+  d: 24 * 60 * 60 * 1000,
+  h: 60 * 60 * 1000,
+  m: 60 * 1000,
+};
+
+/**
+ * @typedef {Object} FollowUp
+ * @property {string} id - Unique ID made of the scheduling run ID and a sequence number
+ * @property {string} due_at - When the follow-up is due (ISO 8601)
+ * @property {string} scheduled_at - When the follow-up was scheduled (ISO 8601)
+ * @property {string} reason - What the follow-up run should check
+ * @property {number} [item_number] - Issue or pull request the follow-up is about
+ * @property {string} [run_url] - URL of the run that scheduled the follow-up
+ */
+
+/**
+ * Parses a follow-up delay such as "90m", "12h", "3d", "1w" or "1d12h".
+ * @param {any} value
+ * @returns {number | null} Delay in milliseconds, or null when the value is not a delay
+ */
+function parseFollowUpDelay(value) {
+  const match = String(value ?? "")
+    .trim()
+    .toLowerCase()
+    .match(/^(?:(\d+)w)?(?:(\d+)d)?(?:(\d+)h)?(?:(\d+)m)?$/);
+  if (!match || match.slice(1).every(part => part === undefined)) {
+    return null;
+  }
+  const [, weeks, days, hours, minutes] = match;
+  return Number(weeks ?? 0) * DELAY_UNITS_MS.w + Number(days ?? 0) * DELAY_UNITS_MS.d + Number(hours ?? 0) * DELAY_UNITS_MS.h + Number(minutes ?? 0) * DELAY_UNITS_MS.m;
+}
+
+/**
+ * Reads the follow-up queue. A missing or unreadable file is an empty queue.
+ * @param {string} [file]
+ * @returns {FollowUp[]}
+ */
+function readFollowUpQueue(file = FOLLOW_UP_QUEUE_FILE) {
+  if (!fs.existsSync(file)) {
+    return [];
+  }
+  try {
+    const queue = JSON.parse(fs.readFileSync(file, "utf8"));
+    return Array.isArray(queue?.follow_ups) ? queue.follow_ups.filter(entry => entry && typeof entry.due_at === "string") : [];
+  } catch (error) {
+    core.warning(`Ignoring unreadable follow-up queue ${file}: ${getErrorMessage(error)}`);
+    return [];
+  }
+}
+
+/**
+ * Writes the follow-up queue, ordered by due time.
+ * @param {FollowUp[]} followUps
+ * @param {string} [file]
+ */
+function writeFollowUpQueue(followUps, file = FOLLOW_UP_QUEUE_FILE) {
+  const sorted = followUps.slice().sort((a, b) => Date.parse(a.due_at) - Date.parse(b.due_at));
+  fs.mkdirSync(path.dirname(file), { recursive: true });
+  fs.writeFileSync(file, JSON.stringify({ version: 1, follow_ups: sorted }, null, 2) + "\n");
+}
+
+/**
+ * Splits the queue into the follow-ups that are due and those still pending.
+ * @param {FollowUp[]} followUps
+ * @param {Date} now
+ * @returns {{due: FollowUp[], pending: FollowUp[]}}
+ */
+function splitDueFollowUps(followUps, now) {
+  const due = [];
+  const pending = [];
+  for (const followUp of followUps) {
+    (Date.parse(followUp.due_at) <= now.getTime() ? due : pending).push(followUp);
+  }
+  return { due, pending };
+}
+
+/**
+ * Describes due follow-ups for the agent prompt, one Markdown list item each.
+ * @param {FollowUp[]} followUps
+ * @returns {string}
+ */
+function formatFollowUps(followUps) {
+  return followUps
+    .map(followUp => {
+      const target = followUp.item_number ? ` about #${followUp.item_number}` : "";
+      const origin = followUp.run_url ? ` by ${followUp.run_url}` : "";
+      return `- Scheduled${origin} on ${followUp.scheduled_at}${target}, due ${followUp.due_at}: ${followUp.reason}`;
+    })
+    .join("\n");
+}
+
+module.exports = {
+  FOLLOW_UP_QUEUE_DIR,
+  FOLLOW_UP_QUEUE_FILE,
+  MIN_FOLLOW_UP_DELAY_MS,
+  parseFollowUpDelay,
+  readFollowUpQueue,
+  writeFollowUpQueue,
+  splitDueFollowUps,
+  formatFollowUps,
+};
@@ -10,6 +10,7 @@ const validateLockdownRequirements = require("./validate_lockdown_requirements.c
 const { writeMergedModelsJSON } = require("./merge_frontmatter_models.cjs");
 const { getErrorMessage } = require("./error_helpers.cjs");
 const { ERR_CONFIG } = require("./error_codes.cjs");
+const { computeCorrelationId } = require("./correlation_id.cjs");
 
 /**
  * Generate aw_info.json with workflow run metadata.
@@ -78,6 +79,7 @@ async function main(core, ctx) {
     run_id: ctx.runId,
     run_number: ctx.runNumber,
     run_attempt: process.env.GITHUB_RUN_ATTEMPT,
+    correlation_id: ctx.repo ? computeCorrelationId(ctx.repo.owner + "/" + ctx.repo.repo, ctx.runId) : "",
     repository: ctx.repo ? ctx.repo.owner + "/" + ctx.repo.repo : "",
     ref: ctx.ref,
     sha: ctx.sha,
@@ -95,6 +95,7 @@ describe("generate_aw_info.cjs", () => {
     expect(awInfo.run_number).toBe(42);
     expect(awInfo.sha).toBe("abc123def456");
     expect(awInfo.repository).toBe("github/my-repo");
+    expect(awInfo.correlation_id).toBe("98829356");
     expect(awInfo.actor).toBe("octocat");
     expect(awInfo.event_name).toBe("push");
     expect(awInfo.staged).toBe(false);
@@ -2,6 +2,7 @@
 /// <reference types="@actions/github-script" />
 
 const { getDetectionReasonText, getThreatDetectedMarker } = require("./threat_detection_warning.cjs");
+const { getCorrelationId } = require("./correlation_id.cjs");
 
 /**
  * Generates a standalone workflow-id XML comment marker for searchability.
@@ -60,6 +61,7 @@ function generateXMLMarker(workflowName, runUrl) {
   const trackerId = process.env.GH_AW_TRACKER_ID || "";
   const runId = process.env.GITHUB_RUN_ID || "";
   const workflowId = process.env.GH_AW_WORKFLOW_ID || "";
+  const correlationId = getCorrelationId();
 
   // Build the key-value pairs for the marker
   const parts = [];
@@ -97,6 +99,11 @@ function generateXMLMarker(workflowName, runUrl) {
     parts.push(`workflow_id: ${workflowId}`);
   }
 
+  // Add run correlation ID if available (for `gh aw logs --correlation`)
+  if (correlationId) {
+    parts.push(`correlation_id: ${correlationId}`);
+  }
+
   // Always include run URL
   parts.push(`run: ${runUrl}`);
 
@@ -211,6 +218,29 @@ function getCloseKeyMarkerContent(closeKey) {
   return `gh-aw-close-key: ${closeKey}`;
 }
 
+/**
+ * Generates a standalone dedupe-key XML comment marker.
+ * When `dedupe-key` is configured on create-issue or add-comment, this marker is
+ * embedded in the body so later runs can find the existing issue or comment and
+ * update it in place instead of creating a duplicate.
+ *
+ * @param {string} dedupeKey - Normalized deduplication key
+ * @returns {string} Standalone dedupe-key XML comment marker
+ */
+function generateDedupeKeyMarker(dedupeKey) {
+  return `<!-- gh-aw-dedupe-key: ${dedupeKey} -->`;
+}
+
+/**
+ * Gets the dedupe-key marker content (without XML comment wrapper) for searching.
+ *
+ * @param {string} dedupeKey - Normalized deduplication key
+ * @returns {string} Dedupe-key marker content for search queries
+ */
+function getDedupeKeyMarkerContent(dedupeKey) {
+  return `gh-aw-dedupe-key: ${dedupeKey}`;
+}
+
 /**
  * Validate that an extracted workflow ID has a safe, expected format.
  * Workflow IDs are file basenames (without .md) and must not contain
@@ -300,4 +330,6 @@ module.exports = {
   normalizeCloseOlderKey,
   generateCloseKeyMarker,
   getCloseKeyMarkerContent,
+  generateDedupeKeyMarker,
+  getDedupeKeyMarkerContent,
 };
@@ -47,6 +47,7 @@ describe("generate_footer.cjs", () => {
     delete process.env.GH_AW_ENGINE_MODEL;
     delete process.env.GH_AW_TRACKER_ID;
     delete process.env.GH_AW_WORKFLOW_ID;
+    delete process.env.GH_AW_CORRELATION_ID;
     delete process.env.GITHUB_RUN_ID;
     delete process.env.GH_AW_DETECTION_CONCLUSION;
     delete process.env.GH_AW_DETECTION_REASON;
@@ -156,6 +157,20 @@ describe("generate_footer.cjs", () => {
       expect(result).toBe("<!-- gh-aw-agentic-workflow: Test Workflow, workflow_id: smoke-copilot, run: https://github.com/test/repo/actions/runs/123 -->");
     });
 
+    it("should include correlation_id when GH_AW_CORRELATION_ID env var is set", async () => {
+      process.env.GH_AW_WORKFLOW_ID = "smoke-copilot";
+      process.env.GH_AW_CORRELATION_ID = "35625281";
+
+      vi.resetModules();
+      const freshModule = await import("./generate_footer.cjs");
+
+      const result = freshModule.generateXMLMarker("Test Workflow", "https://github.com/test/repo/actions/runs/123");
+
+      expect(result).toBe("<!-- gh-aw-agentic-workflow: Test Workflow, workflow_id: smoke-copilot, correlation_id: 35625281, run: https://github.com/test/repo/actions/runs/123 -->");
+      expect(freshModule.matchesWorkflowId(result, "smoke-copilot")).toBe(true);
+      expect(freshModule.extractWorkflowId(result)).toBe("smoke-copilot");
+    });
+
     it("should include all identifiers when all standard env vars are set", async () => {
       process.env.GH_AW_ENGINE_ID = "copilot";
       process.env.GH_AW_TRACKER_ID = "tracker-abc";
@@ -334,6 +349,20 @@ describe("generate_footer.cjs", () => {
     });
   });
 
+  describe("dedupe-key markers", () => {
+    it("should generate a dedupe-key XML marker", async () => {
+      const { generateDedupeKeyMarker } = await import("./generate_footer.cjs");
+
+      expect(generateDedupeKeyMarker("daily-report")).toBe("<!-- gh-aw-dedupe-key: daily-report -->");
+    });
+
+    it("should generate dedupe-key marker content for search queries", async () => {
+      const { getDedupeKeyMarkerContent } = await import("./generate_footer.cjs");
+
+      expect(getDedupeKeyMarkerContent("daily-report")).toBe("gh-aw-dedupe-key: daily-report");
+    });
+  });
+
   describe("generateExpiredEntityFooter", () => {
     let generateExpiredEntityFooter;
     let getExpiredEntityCautionAlert;
@@ -12,6 +12,7 @@ const path = require("path");
 const { getErrorMessage } = require("./error_helpers.cjs");
 const { ensureOriginRemoteTrackingRef, execGitSync } = require("./git_helpers.cjs");
 const { ERR_SYSTEM } = require("./error_codes.cjs");
+const { TMP_GH_AW_PATH } = require("./constants.cjs");
 
 /**
  * Debug logging helper - logs to stderr when DEBUG env var matches
@@ -56,7 +57,7 @@ function sanitizeBranchNameForBundle(branchName) {
  */
 function getBundlePathForBranch(branchName) {
   const sanitized = sanitizeBranchNameForBundle(branchName);
-  return `/tmp/gh-aw/aw-${sanitized}.bundle`;
+  return `${TMP_GH_AW_PATH}/aw-${sanitized}.bundle`;
 }
 
 /**
@@ -78,7 +79,7 @@ function sanitizeRepoSlugForBundle(repoSlug) {
 function getBundlePathForBranchInRepo(branchName, repoSlug) {
   const sanitizedBranch = sanitizeBranchNameForBundle(branchName);
   const sanitizedRepo = sanitizeRepoSlugForBundle(repoSlug);
-  return `/tmp/gh-aw/aw-${sanitizedRepo}-${sanitizedBranch}.bundle`;
+  return `${TMP_GH_AW_PATH}/aw-${sanitizedRepo}-${sanitizedBranch}.bundle`;
 }
 
 /**
@@ -11,6 +11,7 @@
 const fs = require("fs");
 const { globSync } = require("node:fs");
 const path = require("path");
+const { TMP_GH_AW_PATH } = require("./constants.cjs");
 
 require("./shim.cjs");
 
@@ -94,7 +95,7 @@ function parseFirewallLogs() {
 
   // The sandbox firewall logs may be emitted in nested directories (for example,
   // api-proxy-logs/*.log), so these patterns are intentionally recursive.
-  const firewallPaths = ["/tmp/gh-aw/sandbox/firewall/logs/**/*.log", "/tmp/gh-aw/threat-detection/sandbox/firewall/logs/**/*.log", "/tmp/gh-aw/squid-logs-*/**/*.log", "/tmp/gh-aw/threat-detection/squid-logs-*/**/*.log"];
+  const firewallPaths = [`${TMP_GH_AW_PATH}/sandbox/firewall/logs/**/*.log`, `${TMP_GH_AW_PATH}/threat-detection/sandbox/firewall/logs/**/*.log`, `${TMP_GH_AW_PATH}/squid-logs-*/**/*.log`, `${TMP_GH_AW_PATH}/threat-detection/squid-logs-*/**/*.log`];
 
   for (const pattern of firewallPaths) {
     const files = globSync(pattern);
@@ -215,7 +216,7 @@ function parseSessionLogs() {
     failed_tool_executions: 0,
   };
 
-  const sessionPaths = ["/tmp/gh-aw/sandbox/agent/logs/copilot-session-state/*/events.jsonl", "/tmp/gh-aw/threat-detection/sandbox/agent/logs/copilot-session-state/*/events.jsonl"];
+  const sessionPaths = [`${TMP_GH_AW_PATH}/sandbox/agent/logs/copilot-session-state/*/events.jsonl`, `${TMP_GH_AW_PATH}/threat-detection/sandbox/agent/logs/copilot-session-state/*/events.jsonl`];
 
   for (const pattern of sessionPaths) {
     const files = globSync(pattern);
@@ -284,8 +285,8 @@ function parseGatewayLogs() {
   const gatewayPaths = [];
 
   const pathPairs = [
-    ["/tmp/gh-aw/sandbox/agent/logs/mcp-logs/gateway.jsonl", "/tmp/gh-aw/sandbox/agent/logs/gateway.jsonl"],
-    ["/tmp/gh-aw/threat-detection/sandbox/agent/logs/mcp-logs/gateway.jsonl", "/tmp/gh-aw/threat-detection/sandbox/agent/logs/gateway.jsonl"],
+    [`${TMP_GH_AW_PATH}/sandbox/agent/logs/mcp-logs/gateway.jsonl`, `${TMP_GH_AW_PATH}/sandbox/agent/logs/gateway.jsonl`],
+    [`${TMP_GH_AW_PATH}/threat-detection/sandbox/agent/logs/mcp-logs/gateway.jsonl`, `${TMP_GH_AW_PATH}/threat-detection/sandbox/agent/logs/gateway.jsonl`],
   ];
 
   for (const [modernPath, legacyPath] of pathPairs) {
@@ -384,7 +385,7 @@ function parseGatewayLogs() {
  * @param {string} [manifestPath] - Path to the manifest file (defaults to MANIFEST_FILE_PATH)
  * @returns {{ total_items: number, items_by_type: Record<string, number> } | null}
  */
-const MANIFEST_FILE_PATH = "/tmp/gh-aw/safe-output-items.jsonl";
+const MANIFEST_FILE_PATH = `${TMP_GH_AW_PATH}/safe-output-items.jsonl`;
 
 function parseSafeOutputsManifest(manifestPath = MANIFEST_FILE_PATH) {
   if (!fs.existsSync(manifestPath)) {
@@ -464,7 +465,7 @@ function main() {
   }
 
   // Write summary to file
-  const outputPath = "/tmp/gh-aw/usage/activity/summary.json";
+  const outputPath = `${TMP_GH_AW_PATH}/usage/activity/summary.json`;
   try {
     fs.writeFileSync(outputPath, JSON.stringify(summary, null, 2), "utf-8");
   } catch (err) {
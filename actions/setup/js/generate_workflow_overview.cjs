@@ -3,6 +3,7 @@
 
 const { jsonObjectToMarkdown } = require("./json_object_to_markdown.cjs");
 const { getErrorMessage } = require("./error_helpers.cjs");
+const { TMP_GH_AW_PATH } = require("./constants.cjs");
 
 /**
  * Generate workflow overview step that writes an agentic workflow run overview
@@ -14,7 +15,7 @@ const { getErrorMessage } = require("./error_helpers.cjs");
  */
 async function generateWorkflowOverview(core) {
   const fs = require("fs");
-  const awInfoPath = "/tmp/gh-aw/aw_info.json";
+  const awInfoPath = `${TMP_GH_AW_PATH}/aw_info.json`;
 
   // Load aw_info.json
   let awInfo;
@@ -1,6 +1,16 @@
 // @ts-check
 /// <reference types="@actions/github-script" />
 
+/**
+ * Get the base URL of the GitHub server the workflow runs on, without a trailing slash.
+ * Falls back to https://github.com when GITHUB_SERVER_URL is not set.
+ *
+ * @returns {string} GitHub server URL, e.g. https://github.com or https://github.example.com
+ */
+function getGitHubServerUrl() {
+  return (process.env.GITHUB_SERVER_URL || "https://github.com").replace(/\/+$/, "");
+}
+
 /**
  * Get the repository URL for different purposes
  * This helper handles trial mode where target repository URLs are different from execution context
@@ -27,18 +37,17 @@ function getRepositoryUrl(config) {
 
   if (targetRepoSlug) {
     // Use target repository for issue/PR URLs in trial mode
-    const githubServer = process.env.GITHUB_SERVER_URL || "https://github.com";
-    return `${githubServer}/${targetRepoSlug}`;
+    return `${getGitHubServerUrl()}/${targetRepoSlug}`;
   } else if (context.payload.repository?.html_url) {
     // Use execution context repository (default behavior)
     return context.payload.repository.html_url;
   } else {
     // Final fallback for action runs when context repo is not available
-    const githubServer = process.env.GITHUB_SERVER_URL || "https://github.com";
-    return `${githubServer}/${context.repo.owner}/${context.repo.repo}`;
+    return `${getGitHubServerUrl()}/${context.repo.owner}/${context.repo.repo}`;
   }
 }
 
 module.exports = {
+  getGitHubServerUrl,
   getRepositoryUrl,
 };
@@ -33,6 +33,7 @@ global.context = mockContext;
 
 describe("get_repository_url.cjs", () => {
   let getRepositoryUrl;
+  let getGitHubServerUrl;
 
   beforeEach(async () => {
     // Reset mocks
@@ -59,6 +60,19 @@ describe("get_repository_url.cjs", () => {
     // Dynamic import to get fresh module state
     const module = await import("./get_repository_url.cjs");
     getRepositoryUrl = module.getRepositoryUrl;
+    getGitHubServerUrl = module.getGitHubServerUrl;
+  });
+
+  describe("getGitHubServerUrl", () => {
+    it("should default to github.com", () => {
+      expect(getGitHubServerUrl()).toBe("https://github.com");
+    });
+
+    it("should use GITHUB_SERVER_URL without trailing slashes", () => {
+      process.env.GITHUB_SERVER_URL = "https://github.enterprise.com/";
+
+      expect(getGitHubServerUrl()).toBe("https://github.enterprise.com");
+    });
   });
 
   describe("getRepositoryUrl", () => {
@@ -0,0 +1,95 @@
+// @ts-check
+
+/**
+ * gh_aw_tmp_dir.cjs
+ *
+ * Helpers for the per-job scratch directory /tmp/gh-aw/<run-id>-<run-attempt>/<job>-<suffix>.
+ *
+ * Several self-hosted runner instances on one host share /tmp, so every job works in
+ * a directory of its own below /tmp/gh-aw. setup.sh creates the directory and exports it
+ * as GH_AW_TMP_DIR; the compiler refers to it as ${{ env.GH_AW_TMP_DIR }}.
+ * Keep in sync with actions/setup/sh/gh_aw_tmp_dir_lib.sh.
+ */
+
+const fs = require("fs");
+const path = require("path");
+const { spawnSync } = require("child_process");
+
+/** Shared root of the gh-aw scratch directories. */
+const GH_AW_TMP_ROOT = "/tmp/gh-aw";
+
+/** Shape of a job scratch directory created by gh_aw_create_tmp_dir. */
+const JOB_DIR_PATTERN = /^\/tmp\/gh-aw\/[0-9]+-[0-9]+\/[A-Za-z0-9_-]+-[A-Za-z0-9]{8}$/;
+
+/**
+ * Returns true when dir has the shape of a job scratch directory.
+ * @param {string} dir
+ * @returns {boolean}
+ */
+function isTmpGhAwJobDir(dir) {
+  return JOB_DIR_PATTERN.test(dir);
+}
+
+/**
+ * Returns the scratch directory of the current job exported by setup.sh, or "" when
+ * GH_AW_TMP_DIR is unset or not a job scratch directory.
+ * @param {NodeJS.ProcessEnv} [env]
+ * @returns {string}
+ */
+function getTmpGhAwJobDir(env = process.env) {
+  const dir = env.GH_AW_TMP_DIR || "";
+  return isTmpGhAwJobDir(dir) ? dir : "";
+}
+
+/**
+ * Returns the GH_AW_TMP_DIR that setup.sh appended to the GITHUB_ENV file of the running
+ * step. Values written to GITHUB_ENV only reach later steps, so index.js reads the file
+ * to find the directory setup.sh created. Returns "" when the file or the entry is missing.
+ * @param {string | undefined} envFile
+ * @returns {string}
+ */
+function readTmpGhAwJobDirFromEnvFile(envFile) {
+  if (!envFile || !fs.existsSync(envFile)) {
+    return "";
+  }
+  const lines = fs.readFileSync(envFile, "utf8").split("\n");
+  let dir = "";
+  for (const line of lines) {
+    if (line.startsWith("GH_AW_TMP_DIR=")) {
+      dir = line.slice("GH_AW_TMP_DIR=".length).trim();
+    }
+  }
+  return isTmpGhAwJobDir(dir) ? dir : "";
+}
+
+/**
+ * Removes the scratch directory of a job, then the run directory that contains it once no
+ * other job of the run uses it. Only job scratch directories are removed, so a bad
+ * GH_AW_TMP_DIR can never delete the shared root, the run directory or anything outside.
+ *
+ * Files inside may be owned by root (written by Docker containers or privileged scripts),
+ * so `sudo rm -rf` is tried first — GitHub-hosted runners have passwordless sudo — with a
+ * fallback to fs.rmSync for self-hosted runners without sudo.
+ * @param {string} jobDir
+ * @param {typeof spawnSync} [spawn]
+ * @returns {boolean} true when jobDir was a job scratch directory and has been removed
+ */
+function removeTmpGhAwJobDir(jobDir, spawn = spawnSync) {
+  if (!isTmpGhAwJobDir(jobDir)) {
+    return false;
+  }
+  if (fs.existsSync(jobDir)) {
+    const result = spawn("sudo", ["-n", "rm", "-rf", jobDir], { stdio: "inherit" });
+    if (result.status !== 0) {
+      fs.rmSync(jobDir, { recursive: true, force: true });
+    }
+  }
+  try {
+    fs.rmdirSync(path.dirname(jobDir));
+  } catch {
+    // Another job of the run still uses the run directory.
+  }
+  return true;
+}
+
+module.exports = { GH_AW_TMP_ROOT, isTmpGhAwJobDir, getTmpGhAwJobDir, readTmpGhAwJobDirFromEnvFile, removeTmpGhAwJobDir };
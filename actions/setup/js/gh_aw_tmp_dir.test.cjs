@@ -0,0 +1,89 @@
+import { describe, it, expect, afterEach, vi } from "vitest";
+import fs from "fs";
+
+const { isTmpGhAwJobDir, getTmpGhAwJobDir, readTmpGhAwJobDirFromEnvFile, removeTmpGhAwJobDir } = require("./gh_aw_tmp_dir.cjs");
+
+describe("gh_aw_tmp_dir.cjs", () => {
+  describe("isTmpGhAwJobDir", () => {
+    it("should accept job scratch directories", () => {
+      expect(isTmpGhAwJobDir("/tmp/gh-aw/123456-1/agent-Ab3dE9xZ")).toBe(true);
+      expect(isTmpGhAwJobDir("/tmp/gh-aw/123456-2/safe_outputs-2-00000000")).toBe(true);
+    });
+
+    it("should refuse the shared root, run directories and other paths", () => {
+      expect(isTmpGhAwJobDir("/tmp/gh-aw")).toBe(false);
+      expect(isTmpGhAwJobDir("/tmp/gh-aw/123456-1")).toBe(false);
+      expect(isTmpGhAwJobDir("/tmp/gh-aw/123456/agent")).toBe(false);
+      expect(isTmpGhAwJobDir("/tmp/gh-aw/123456-1/agent")).toBe(false);
+      expect(isTmpGhAwJobDir("/tmp/gh-aw/123456-1/../etc-Ab3dE9xZ")).toBe(false);
+      expect(isTmpGhAwJobDir("/home/runner/work")).toBe(false);
+    });
+  });
+
+  describe("getTmpGhAwJobDir", () => {
+    it("should return GH_AW_TMP_DIR exported by setup.sh", () => {
+      expect(getTmpGhAwJobDir({ GH_AW_TMP_DIR: "/tmp/gh-aw/1-1/agent-Ab3dE9xZ" })).toBe("/tmp/gh-aw/1-1/agent-Ab3dE9xZ");
+    });
+
+    it("should return an empty string for a missing or foreign directory", () => {
+      expect(getTmpGhAwJobDir({})).toBe("");
+      expect(getTmpGhAwJobDir({ GH_AW_TMP_DIR: "/tmp/gh-aw" })).toBe("");
+      expect(getTmpGhAwJobDir({ GH_AW_TMP_DIR: "/tmp/gh-aw/1-1" })).toBe("");
+    });
+  });
+
+  describe("readTmpGhAwJobDirFromEnvFile", () => {
+    const envFile = `/tmp/gh-aw-env-${process.pid}`;
+
+    afterEach(() => {
+      fs.rmSync(envFile, { force: true });
+    });
+
+    it("should return the last GH_AW_TMP_DIR written to the env file", () => {
+      fs.writeFileSync(envFile, "FOO=bar\nGH_AW_TMP_DIR=/tmp/gh-aw/1-1/agent-Ab3dE9xZ\nGH_AW_TMP_DIR=/tmp/gh-aw/1-1/agent-Zx9Ed3bA\n");
+      expect(readTmpGhAwJobDirFromEnvFile(envFile)).toBe("/tmp/gh-aw/1-1/agent-Zx9Ed3bA");
+    });
+
+    it("should return an empty string without an entry or a file", () => {
+      fs.writeFileSync(envFile, "FOO=bar\n");
+      expect(readTmpGhAwJobDirFromEnvFile(envFile)).toBe("");
+      expect(readTmpGhAwJobDirFromEnvFile(undefined)).toBe("");
+      expect(readTmpGhAwJobDirFromEnvFile(`${envFile}-missing`)).toBe("");
+    });
+  });
+
+  describe("removeTmpGhAwJobDir", () => {
+    const runDir = `/tmp/gh-aw/${900000000 + process.pid}-1`;
+    const noSudo = vi.fn().mockReturnValue({ status: 1 });
+
+    afterEach(() => {
+      fs.rmSync(runDir, { recursive: true, force: true });
+    });
+
+    it("should remove the job directory and keep concurrent legs of the run", () => {
+      fs.mkdirSync(`${runDir}/agent-Ab3dE9xZ/mcp-logs`, { recursive: true });
+      fs.mkdirSync(`${runDir}/agent-Zx9Ed3bA`, { recursive: true });
+      fs.writeFileSync(`${runDir}/agent-Ab3dE9xZ/mcp-logs/gateway.jsonl`, "{}");
+
+      expect(removeTmpGhAwJobDir(`${runDir}/agent-Ab3dE9xZ`, noSudo)).toBe(true);
+      expect(fs.existsSync(`${runDir}/agent-Ab3dE9xZ`)).toBe(false);
+      expect(fs.existsSync(`${runDir}/agent-Zx9Ed3bA`)).toBe(true);
+    });
+
+    it("should remove the run directory with its last job", () => {
+      fs.mkdirSync(`${runDir}/agent-Ab3dE9xZ`, { recursive: true });
+
+      expect(removeTmpGhAwJobDir(`${runDir}/agent-Ab3dE9xZ`, noSudo)).toBe(true);
+      expect(fs.existsSync(runDir)).toBe(false);
+    });
+
+    it("should refuse paths that are not job scratch directories", () => {
+      const spawn = vi.fn();
+      expect(removeTmpGhAwJobDir("/tmp/gh-aw", spawn)).toBe(false);
+      expect(removeTmpGhAwJobDir("/tmp/gh-aw/123-1", spawn)).toBe(false);
+      expect(removeTmpGhAwJobDir("/tmp/gh-aw/123-1/../../etc-Ab3dE9xZ", spawn)).toBe(false);
+      expect(removeTmpGhAwJobDir("/home/runner/work", spawn)).toBe(false);
+      expect(spawn).not.toHaveBeenCalled();
+    });
+  });
+});
@@ -21,14 +21,15 @@ const { extractShellCommandFromToolData } = require("./tool_call_details.cjs");
 const fs = require("fs");
 const os = require("os");
 const path = require("path");
+const { TMP_GH_AW_PATH } = require("./constants.cjs");
 
 const DEFAULT_ACTION_FAILURE_ISSUE_EXPIRES_HOURS = 24 * 7;
 const FAILURE_ISSUE_DEDUP_WINDOW_HOURS = 24;
 const FAILURE_ISSUE_CATEGORY_DAILY_CAP = 50;
 const FAILURE_ISSUE_WINDOW_MS = FAILURE_ISSUE_DEDUP_WINDOW_HOURS * 60 * 60 * 1000;
-const DEFAULT_OTEL_JSONL_PATH = "/tmp/gh-aw/otel.jsonl";
+const DEFAULT_OTEL_JSONL_PATH = `${TMP_GH_AW_PATH}/otel.jsonl`;
 /** Path to the failure categories file written by handle_agent_failure and read by the OTLP conclusion span. */
-const FAILURE_CATEGORIES_PATH = "/tmp/gh-aw/failure_categories.json";
+const FAILURE_CATEGORIES_PATH = `${TMP_GH_AW_PATH}/failure_categories.json`;
 const GITHUB_API_VERSION = "2022-11-28";
 const COPILOT_SESSION_STATE_DIR = path.join(os.tmpdir(), "gh-aw", "sandbox", "agent", "logs", "copilot-session-state");
 const RECENT_TOOL_CALLS_WITH_COMMAND_PREVIEW = new Set(["bash", "shell"]);
@@ -2311,7 +2312,7 @@ function buildSecretVerificationContext(secretVerificationResult, engineId) {
  */
 function hasAgentTerminalReasonCompleted() {
   const agentOutputFile = process.env.GH_AW_AGENT_OUTPUT;
-  const stdioLogPath = agentOutputFile ? path.join(path.dirname(agentOutputFile), "agent-stdio.log") : "/tmp/gh-aw/agent-stdio.log";
+  const stdioLogPath = agentOutputFile ? path.join(path.dirname(agentOutputFile), "agent-stdio.log") : `${TMP_GH_AW_PATH}/agent-stdio.log`;
   try {
     if (!fs.existsSync(stdioLogPath)) {
       return false;
@@ -2359,7 +2360,7 @@ function detectAWFStartupSignals(logContent, errorMessages = undefined) {
  */
 function detectAWFFirewallStartupFailureFromLog() {
   const agentOutputFile = process.env.GH_AW_AGENT_OUTPUT;
-  const stdioLogPath = agentOutputFile ? path.join(path.dirname(agentOutputFile), "agent-stdio.log") : "/tmp/gh-aw/agent-stdio.log";
+  const stdioLogPath = agentOutputFile ? path.join(path.dirname(agentOutputFile), "agent-stdio.log") : `${TMP_GH_AW_PATH}/agent-stdio.log`;
   try {
     if (!fs.existsSync(stdioLogPath)) return false;
     const logContent = fs.readFileSync(stdioLogPath, "utf8");
@@ -2381,7 +2382,7 @@ function buildEngineFailureContext(options = {}) {
   const suppressEngineRateLimit429 = options.suppressEngineRateLimit429 === true;
   // Derive agent-stdio.log path from the agent output file path (same directory)
   const agentOutputFile = process.env.GH_AW_AGENT_OUTPUT;
-  const stdioLogPath = agentOutputFile ? path.join(path.dirname(agentOutputFile), "agent-stdio.log") : "/tmp/gh-aw/agent-stdio.log";
+  const stdioLogPath = agentOutputFile ? path.join(path.dirname(agentOutputFile), "agent-stdio.log") : `${TMP_GH_AW_PATH}/agent-stdio.log`;
 
   // Include engine ID in failure messages when available (e.g. "copilot", "claude", "codex")
   const engineId = process.env.GH_AW_ENGINE_ID || "";
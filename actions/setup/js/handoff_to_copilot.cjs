@@ -0,0 +1,105 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+/**
+ * @typedef {import('./types/handler-factory').HandlerFactoryFunction} HandlerFactoryFunction
+ */
+
+const { main: createIssueMain } = require("./create_issue.cjs");
+
+/** @type {string} Safe output type handled by this module */
+const HANDLER_TYPE = "handoff_to_copilot";
+
+/**
+ * Normalize a list field from a handoff message into trimmed, non-empty strings.
+ * @param {any} value
+ * @returns {string[]}
+ */
+function toStringList(value) {
+  if (!Array.isArray(value)) {
+    return [];
+  }
+  return value.map(item => String(item ?? "").trim()).filter(Boolean);
+}
+
+/**
+ * Build the issue body for a Copilot coding agent handoff.
+ * The structure mirrors what the coding agent expects from a well-scoped task:
+ * the problem statement first, then checkable acceptance criteria, followed by
+ * optional pointers to relevant files and explicit non-goals.
+ *
+ * @param {{description: string, acceptanceCriteria: string[], relevantFiles: string[], outOfScope: string[]}} task
+ * @returns {string}
+ */
+function buildHandoffBody(task) {
+  const sections = [task.description.trim()];
+
+  sections.push(["## Acceptance criteria", "", ...task.acceptanceCriteria.map(criterion => `- [ ] ${criterion}`)].join("\n"));
+
+  if (task.relevantFiles.length > 0) {
+    sections.push(["## Relevant files", "", ...task.relevantFiles.map(file => `- \`${file.replace(/`/g, "")}\``)].join("\n"));
+  }
+
+  if (task.outOfScope.length > 0) {
+    sections.push(["## Out of scope", "", ...task.outOfScope.map(item => `- ${item}`)].join("\n"));
+  }
+
+  return sections.join("\n\n");
+}
+
+/**
+ * Main handler factory for handoff_to_copilot.
+ * Each message creates an issue with a structured task description and assigns
+ * the Copilot coding agent to it. Issue creation, footers, labels, cross-repository
+ * validation and the Copilot assignment are delegated to the create_issue handler.
+ * @type {HandlerFactoryFunction}
+ */
+async function main(config = {}) {
+  const handleCreateIssue = await createIssueMain({
+    ...config,
+    assignees: ["copilot"],
+  });
+
+  /**
+   * Message handler function that processes a single handoff_to_copilot message
+   * @param {Object} message - The handoff_to_copilot message to process
+   * @param {Object} resolvedTemporaryIds - Map of temporary IDs to {repo, number}
+   * @returns {Promise<Object>} Result with success/error status and issue details
+   */
+  return async function handleHandoffToCopilot(message, resolvedTemporaryIds) {
+    const title = String(message.title ?? "").trim();
+    const description = String(message.description ?? "").trim();
+    const acceptanceCriteria = toStringList(message.acceptance_criteria);
+
+    if (!title || !description) {
+      const error = "Handoff requires a title and description";
+      core.warning(`Skipping ${HANDLER_TYPE}: ${error}`);
+      return { success: false, error };
+    }
+    if (acceptanceCriteria.length === 0) {
+      const error = "Handoff requires at least one acceptance criterion";
+      core.warning(`Skipping ${HANDLER_TYPE}: ${error}`);
+      return { success: false, error };
+    }
+
+    const body = buildHandoffBody({
+      description,
+      acceptanceCriteria,
+      relevantFiles: toStringList(message.relevant_files),
+      outOfScope: toStringList(message.out_of_scope),
+    });
+
+    core.info(`Handing off to Copilot: title=${title}, criteria=${acceptanceCriteria.length}`);
+    return handleCreateIssue(
+      {
+        type: "create_issue",
+        title,
+        body,
+        ...(message.repo ? { repo: message.repo } : {}),
+      },
+      resolvedTemporaryIds
+    );
+  };
+}
+
+module.exports = { main, buildHandoffBody };
@@ -0,0 +1,133 @@
+import { describe, it, expect, beforeEach, vi } from "vitest";
+
+const mockCore = {
+  debug: vi.fn(),
+  info: vi.fn(),
+  warning: vi.fn(),
+  error: vi.fn(),
+  setOutput: vi.fn(),
+  summary: { addRaw: vi.fn().mockReturnThis(), write: vi.fn().mockResolvedValue() },
+};
+
+const mockGithub = {
+  rest: {
+    issues: {
+      create: vi.fn(),
+      createComment: vi.fn(),
+    },
+  },
+  graphql: vi.fn(),
+};
+
+const mockContext = {
+  runId: 12345,
+  repo: { owner: "testowner", repo: "testrepo" },
+  payload: {
+    repository: { html_url: "https://github.com/testowner/testrepo" },
+  },
+};
+
+global.core = mockCore;
+global.github = mockGithub;
+global.context = mockContext;
+
+describe("handoff_to_copilot.cjs", () => {
+  let handler;
+
+  beforeEach(async () => {
+    vi.clearAllMocks();
+    delete process.env.GH_AW_ASSIGN_COPILOT;
+
+    const { main } = require("./handoff_to_copilot.cjs");
+    handler = await main({
+      max: 5,
+      labels: ["copilot-task"],
+      title_prefix: "[copilot] ",
+    });
+  });
+
+  describe("buildHandoffBody", () => {
+    it("should render description, acceptance criteria, relevant files and out of scope sections", () => {
+      const { buildHandoffBody } = require("./handoff_to_copilot.cjs");
+      const body = buildHandoffBody({
+        description: "The config loader crashes on empty files.",
+        acceptanceCriteria: ["Empty files load as defaults", "A regression test covers the empty case"],
+        relevantFiles: ["pkg/config/loader.go"],
+        outOfScope: ["Changing the config format"],
+      });
+
+      expect(body).toBe(
+        [
+          "The config loader crashes on empty files.",
+          "",
+          "## Acceptance criteria",
+          "",
+          "- [ ] Empty files load as defaults",
+          "- [ ] A regression test covers the empty case",
+          "",
+          "## Relevant files",
+          "",
+          "- `pkg/config/loader.go`",
+          "",
+          "## Out of scope",
+          "",
+          "- Changing the config format",
+        ].join("\n")
+      );
+    });
+
+    it("should omit optional sections when empty", () => {
+      const { buildHandoffBody } = require("./handoff_to_copilot.cjs");
+      const body = buildHandoffBody({ description: "Fix it.", acceptanceCriteria: ["It works"], relevantFiles: [], outOfScope: [] });
+
+      expect(body).not.toContain("## Relevant files");
+      expect(body).not.toContain("## Out of scope");
+    });
+  });
+
+  it("should create an issue assigned to copilot with a structured body", async () => {
+    const mockIssue = { number: 42, html_url: "https://github.com/testowner/testrepo/issues/42", node_id: "I_42" };
+    mockGithub.rest.issues.create.mockResolvedValue({ data: mockIssue });
+
+    const result = await handler(
+      {
+        type: "handoff_to_copilot",
+        title: "Handle empty config files",
+        description: "The config loader crashes on empty files.",
+        acceptance_criteria: ["Empty files load as defaults", " "],
+        relevant_files: ["pkg/config/loader.go"],
+      },
+      {}
+    );
+
+    expect(result.success).toBe(true);
+    expect(result.number).toBe(42);
+    expect(mockGithub.rest.issues.create).toHaveBeenCalledWith(
+      expect.objectContaining({
+        owner: "testowner",
+        repo: "testrepo",
+        title: "[copilot] Handle empty config files",
+        body: expect.stringContaining("- [ ] Empty files load as defaults"),
+        labels: ["copilot-task"],
+      })
+    );
+    const body = mockGithub.rest.issues.create.mock.calls[0][0].body;
+    expect(body).toContain("- `pkg/config/loader.go`");
+    expect(body).not.toContain("- [ ] \n");
+  });
+
+  it("should reject handoffs without acceptance criteria", async () => {
+    const result = await handler({ type: "handoff_to_copilot", title: "Task", description: "Do the thing.", acceptance_criteria: [] }, {});
+
+    expect(result.success).toBe(false);
+    expect(result.error).toContain("acceptance criterion");
+    expect(mockGithub.rest.issues.create).not.toHaveBeenCalled();
+  });
+
+  it("should reject handoffs without a description", async () => {
+    const result = await handler({ type: "handoff_to_copilot", title: "Task", acceptance_criteria: ["Done"] }, {});
+
+    expect(result.success).toBe(false);
+    expect(mockGithub.rest.issues.create).not.toHaveBeenCalled();
+  });
+});
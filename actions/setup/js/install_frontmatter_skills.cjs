@@ -4,6 +4,7 @@
 const fs = require("fs");
 const path = require("path");
 const { getErrorMessage } = require("./error_helpers.cjs");
+const { TMP_GH_AW_PATH } = require("./constants.cjs");
 
 /**
  * @param {string} rawSkills
@@ -93,7 +94,7 @@ function countInstalledSkillFiles(skillsDst) {
 }
 
 /** Path to the shared skill install failures JSON file within the activation job's runner. */
-const SKILL_FAILURES_FILE = "/tmp/gh-aw/skill_install_failures.json";
+const SKILL_FAILURES_FILE = `${TMP_GH_AW_PATH}/skill_install_failures.json`;
 
 /**
  * Append a skill install failure record to the shared failures file.
@@ -150,7 +151,7 @@ async function main() {
   const skillDir = process.env.GH_AW_SKILL_DIR || "";
   const skillInstallAgent = process.env.GH_AW_GH_SKILL_AGENT_NAME || "";
   const skills = parseSkillSpecs(process.env.GH_AW_FRONTMATTER_SKILLS || "");
-  const skillsDst = path.join("/tmp/gh-aw", skillDir);
+  const skillsDst = path.join(TMP_GH_AW_PATH, skillDir);
 
   try {
     fs.mkdirSync(skillsDst, { recursive: true });
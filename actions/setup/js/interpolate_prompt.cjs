@@ -16,6 +16,7 @@ const { writeInlineSkills } = require("./extract_inline_skills.cjs");
 const { getErrorMessage } = require("./error_helpers.cjs");
 const { ERR_API, ERR_CONFIG, ERR_VALIDATION } = require("./error_codes.cjs");
 const { renderMarkdownTemplate } = require("./render_template.cjs");
+const { parsePromptBudget, applyContextBudget, applyInstructionsBudget, writePromptBudgetSummary } = require("./prompt_budget.cjs");
 
 /**
  * @typedef {Object} ImportTreeNode
@@ -191,6 +192,22 @@ async function main() {
       }
     }
 
+    // Token budgets from the prompt-budget: frontmatter field. The context budget caps the
+    // injected values before they are interpolated.
+    const promptBudget = parsePromptBudget(process.env.GH_AW_PROMPT_BUDGET);
+    /** @type {import("./prompt_budget.cjs").PromptBudgetUsage[]} */
+    const budgetUsages = [];
+    if (promptBudget?.context) {
+      const budgeted = applyContextBudget(content, variables, promptBudget.context);
+      budgetUsages.push(budgeted.usage);
+      if (budgeted.error) {
+        await writePromptBudgetSummary(budgetUsages);
+        core.setFailed(`${ERR_VALIDATION}: ${budgeted.error}`);
+        return;
+      }
+      Object.assign(variables, budgeted.variables);
+    }
+
     const varCount = Object.keys(variables).length;
     if (varCount > 0) {
       core.info(`Found ${varCount} expression variable(s) to interpolate:`);
@@ -270,6 +287,22 @@ async function main() {
       core.info("No conditional blocks found in prompt, skipping template rendering");
     }
 
+    // Step 3.5: Enforce the instructions budget on the rendered prompt
+    if (promptBudget?.instructions) {
+      core.info("\n========================================");
+      core.info("[main] STEP 3.5: Prompt Budget");
+      core.info("========================================");
+      const budgeted = applyInstructionsBudget(content, promptBudget.instructions);
+      budgetUsages.push(budgeted.usage);
+      if (budgeted.error) {
+        await writePromptBudgetSummary(budgetUsages);
+        core.setFailed(`${ERR_VALIDATION}: ${budgeted.error}`);
+        return;
+      }
+      content = budgeted.content;
+    }
+    await writePromptBudgetSummary(budgetUsages);
+
     // Write back to the same file
     core.info("\n========================================");
     core.info("[main] STEP 4: Writing Output");
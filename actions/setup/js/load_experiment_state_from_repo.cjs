@@ -23,6 +23,7 @@ const fs = require("fs");
 const path = require("path");
 const { getErrorMessage } = require("./error_helpers.cjs");
 const { ERR_SYSTEM, ERR_API } = require("./error_codes.cjs");
+const { TMP_GH_AW_PATH } = require("./constants.cjs");
 
 const MAX_STATE_FILE_BYTES = 102400;
 // Keep this allowlist aligned with actions/setup/js/normalize_branch_name.cjs valid characters.
@@ -108,8 +109,8 @@ async function fetchFileFromBranch(octokit, owner, repo, branch, filePath) {
  * Main entry point called by the actions/github-script step.
  */
 async function main() {
-  const stateFile = process.env.GH_AW_EXPERIMENT_STATE_FILE || "/tmp/gh-aw/experiments/state.json";
-  const stateDir = process.env.GH_AW_EXPERIMENT_STATE_DIR || "/tmp/gh-aw/experiments";
+  const stateFile = process.env.GH_AW_EXPERIMENT_STATE_FILE || `${TMP_GH_AW_PATH}/experiments/state.json`;
+  const stateDir = process.env.GH_AW_EXPERIMENT_STATE_DIR || `${TMP_GH_AW_PATH}/experiments`;
   const branch = process.env.GH_AW_EXPERIMENT_BRANCH || "";
   const repository = process.env.GITHUB_REPOSITORY || "";
   const [owner, repo] = repository.split("/");
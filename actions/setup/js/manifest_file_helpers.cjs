@@ -146,6 +146,45 @@ function checkAllowedFiles(patchContent, allowedFilePatterns) {
   return { hasDisallowedFiles: disallowedFiles.length > 0, disallowedFiles };
 }
 
+/**
+ * Groups the files in a patch by the directory that matches a `group-by-directory`
+ * glob pattern (for example `docs/*` groups `docs/fr/index.md` under `docs/fr`).
+ * Each file is assigned to its shortest parent directory that matches the pattern;
+ * files without a matching parent directory are returned as ungrouped.
+ *
+ * @param {string} patchContent - The git patch content
+ * @param {string} directoryPattern - Glob pattern for the grouping directories
+ * @returns {{ groups: string[], ungroupedFiles: string[] }}
+ */
+function checkDirectoryGroups(patchContent, directoryPattern) {
+  if (!directoryPattern) {
+    return { groups: [], ungroupedFiles: [] };
+  }
+  const { globPatternToRegex } = require("./glob_pattern_helpers.cjs");
+  const directoryRegex = globPatternToRegex(directoryPattern.replace(/\/+$/, ""));
+  /** @type {Set<string>} */
+  const groups = new Set();
+  /** @type {string[]} */
+  const ungroupedFiles = [];
+  for (const filePath of extractPathsFromPatch(patchContent)) {
+    const parts = filePath.split("/");
+    let group = null;
+    for (let i = 1; i < parts.length; i++) {
+      const directory = parts.slice(0, i).join("/");
+      if (directoryRegex.test(directory)) {
+        group = directory;
+        break;
+      }
+    }
+    if (group === null) {
+      ungroupedFiles.push(filePath);
+    } else {
+      groups.add(group);
+    }
+  }
+  return { groups: [...groups].sort(), ungroupedFiles };
+}
+
 /**
  * Identifies which files in a patch match the given list of excluded-file glob patterns.
  * Matching is done against the full file path (e.g. `.github/workflows/ci.yml`).
@@ -340,4 +379,4 @@ function checkFileProtectionPostApply(actualFiles, config) {
   return { action: "deny", source: "protected", files: allProtected };
 }
 
-module.exports = { extractFilenamesFromPatch, extractPathsFromPatch, checkForManifestFiles, checkForProtectedPaths, checkForTopLevelDotFolders, checkAllowedFiles, checkExcludedFiles, checkFileProtection, checkFileProtectionPostApply };
+module.exports = { extractFilenamesFromPatch, extractPathsFromPatch, checkForManifestFiles, checkForProtectedPaths, checkForTopLevelDotFolders, checkAllowedFiles, checkDirectoryGroups, checkExcludedFiles, checkFileProtection, checkFileProtectionPostApply };
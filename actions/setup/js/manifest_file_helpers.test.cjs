@@ -3,7 +3,7 @@ import { describe, it, expect } from "vitest";
 import { createRequire } from "module";
 
 const require = createRequire(import.meta.url);
-const { extractFilenamesFromPatch, checkForManifestFiles, checkAllowedFiles, checkExcludedFiles, checkFileProtection, checkForTopLevelDotFolders } = require("./manifest_file_helpers.cjs");
+const { extractFilenamesFromPatch, checkForManifestFiles, checkAllowedFiles, checkDirectoryGroups, checkExcludedFiles, checkFileProtection, checkForTopLevelDotFolders } = require("./manifest_file_helpers.cjs");
 
 describe("manifest_file_helpers", () => {
   describe("extractFilenamesFromPatch", () => {
@@ -459,6 +459,32 @@ index abc..def 100644
     });
   });
 
+  describe("checkDirectoryGroups", () => {
+    const makePatch = (...filePaths) => filePaths.map(p => `diff --git a/${p} b/${p}\nindex abc..def 100644\n`).join("\n");
+
+    it("should return empty when no pattern is set", () => {
+      const result = checkDirectoryGroups(makePatch("docs/fr/index.md"), "");
+      expect(result).toEqual({ groups: [], ungroupedFiles: [] });
+    });
+
+    it("should group files under the matching directory", () => {
+      const result = checkDirectoryGroups(makePatch("docs/fr/index.md", "docs/fr/guide/setup.md"), "docs/*");
+      expect(result.groups).toEqual(["docs/fr"]);
+      expect(result.ungroupedFiles).toEqual([]);
+    });
+
+    it("should report every group touched by the patch", () => {
+      const result = checkDirectoryGroups(makePatch("docs/fr/index.md", "docs/de/index.md"), "docs/*/");
+      expect(result.groups).toEqual(["docs/de", "docs/fr"]);
+    });
+
+    it("should report files outside any matching directory", () => {
+      const result = checkDirectoryGroups(makePatch("docs/fr/index.md", "docs/index.md", "README.md"), "docs/*");
+      expect(result.groups).toEqual(["docs/fr"]);
+      expect(result.ungroupedFiles).toEqual(["docs/index.md", "README.md"]);
+    });
+  });
+
   describe("checkFileProtection", () => {
     const makePatch = (...filePaths) => filePaths.map(p => `diff --git a/${p} b/${p}\nindex abc..def 100644\n`).join("\n");
 
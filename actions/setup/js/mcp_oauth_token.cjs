@@ -0,0 +1,111 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+/**
+ * Exchanges an OAuth refresh token for an access token for a remote MCP server
+ * declared with `auth.type: oauth`.
+ *
+ * Runs before the MCP gateway starts. The access token is masked and exposed as
+ * the `access_token` step output, which the compiler wires into the server's
+ * Authorization header.
+ *
+ * Inputs (environment variables):
+ * - GH_AW_MCP_OAUTH_SERVER: MCP server name (for log messages)
+ * - GH_AW_MCP_OAUTH_TOKEN_URL: OAuth token endpoint
+ * - GH_AW_MCP_OAUTH_CLIENT_ID: OAuth client ID
+ * - GH_AW_MCP_OAUTH_CLIENT_SECRET: optional client secret
+ * - GH_AW_MCP_OAUTH_REFRESH_TOKEN: refresh token
+ * - GH_AW_MCP_OAUTH_SCOPES: optional space-separated scopes
+ */
+
+const { getErrorMessage } = require("./error_helpers.cjs");
+
+/**
+ * Builds the form body of a refresh_token grant request (RFC 6749, section 6).
+ * @param {{ clientId: string, clientSecret?: string, refreshToken: string, scopes?: string }} params
+ * @returns {URLSearchParams}
+ */
+function buildRefreshTokenRequest({ clientId, clientSecret, refreshToken, scopes }) {
+  const body = new URLSearchParams({ grant_type: "refresh_token", refresh_token: refreshToken, client_id: clientId });
+  if (clientSecret) {
+    body.set("client_secret", clientSecret);
+  }
+  if (scopes) {
+    body.set("scope", scopes);
+  }
+  return body;
+}
+
+/**
+ * Parses a token endpoint response. Most providers return JSON; some (such as
+ * GitHub without an Accept header) return a form-encoded body.
+ * @param {string} text
+ * @returns {Record<string, any>}
+ */
+function parseTokenResponse(text) {
+  try {
+    const parsed = JSON.parse(text);
+    return parsed && typeof parsed === "object" ? parsed : {};
+  } catch {
+    return Object.fromEntries(new URLSearchParams(text));
+  }
+}
+
+async function main() {
+  const server = process.env.GH_AW_MCP_OAUTH_SERVER || "mcp";
+  const tokenUrl = process.env.GH_AW_MCP_OAUTH_TOKEN_URL || "";
+  const clientId = process.env.GH_AW_MCP_OAUTH_CLIENT_ID || "";
+  const clientSecret = process.env.GH_AW_MCP_OAUTH_CLIENT_SECRET || "";
+  const refreshToken = process.env.GH_AW_MCP_OAUTH_REFRESH_TOKEN || "";
+  const scopes = process.env.GH_AW_MCP_OAUTH_SCOPES || "";
+
+  if (!tokenUrl || !clientId) {
+    core.setFailed(`MCP server '${server}': OAuth token-url and client-id are required`);
+    return;
+  }
+  if (!refreshToken) {
+    core.setFailed(`MCP server '${server}': the OAuth refresh token is empty. Check that the secret referenced by auth.refresh-token is set in this repository.`);
+    return;
+  }
+
+  core.info(`Exchanging OAuth refresh token for MCP server '${server}' at ${tokenUrl}`);
+
+  /** @type {Response} */
+  let response;
+  try {
+    response = await fetch(tokenUrl, {
+      method: "POST",
+      headers: { "Content-Type": "application/x-www-form-urlencoded", Accept: "application/json" },
+      body: buildRefreshTokenRequest({ clientId, clientSecret, refreshToken, scopes }),
+    });
+  } catch (error) {
+    core.setFailed(`MCP server '${server}': OAuth token request failed: ${getErrorMessage(error)}`);
+    return;
+  }
+
+  const data = parseTokenResponse(await response.text());
+  if (!response.ok || data.error) {
+    const reason = data.error ? `${data.error}${data.error_description ? `: ${data.error_description}` : ""}` : `HTTP ${response.status}`;
+    core.setFailed(`MCP server '${server}': OAuth token exchange failed (${reason}). The refresh token may have expired or been revoked; authorize again and update the secret.`);
+    return;
+  }
+
+  const accessToken = typeof data.access_token === "string" ? data.access_token : "";
+  if (!accessToken) {
+    core.setFailed(`MCP server '${server}': OAuth token response did not include an access_token`);
+    return;
+  }
+  core.setSecret(accessToken);
+
+  if (typeof data.refresh_token === "string" && data.refresh_token && data.refresh_token !== refreshToken) {
+    core.setSecret(data.refresh_token);
+    core.warning(`MCP server '${server}': the OAuth provider rotated the refresh token. Update the secret referenced by auth.refresh-token, or later runs will fail once the previous token is invalidated.`);
+  }
+
+  if (data.expires_in) {
+    core.info(`Access token for MCP server '${server}' expires in ${data.expires_in}s`);
+  }
+  core.setOutput("access_token", accessToken);
+}
+
+module.exports = { main, buildRefreshTokenRequest, parseTokenResponse };
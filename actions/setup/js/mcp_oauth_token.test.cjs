@@ -0,0 +1,108 @@
+// @ts-check
+import { describe, it, expect, beforeEach, afterEach, vi } from "vitest";
+import { main, buildRefreshTokenRequest, parseTokenResponse } from "./mcp_oauth_token.cjs";
+
+describe("buildRefreshTokenRequest", () => {
+  it("builds a refresh_token grant", () => {
+    const body = buildRefreshTokenRequest({ clientId: "client", refreshToken: "refresh" });
+    expect(body.toString()).toBe("grant_type=refresh_token&refresh_token=refresh&client_id=client");
+  });
+
+  it("includes the client secret and scopes when set", () => {
+    const body = buildRefreshTokenRequest({ clientId: "client", clientSecret: "secret", refreshToken: "refresh", scopes: "read write" });
+    expect(body.get("client_secret")).toBe("secret");
+    expect(body.get("scope")).toBe("read write");
+  });
+});
+
+describe("parseTokenResponse", () => {
+  it("parses JSON responses", () => {
+    expect(parseTokenResponse('{"access_token":"abc","expires_in":3600}')).toEqual({ access_token: "abc", expires_in: 3600 });
+  });
+
+  it("parses form-encoded responses", () => {
+    expect(parseTokenResponse("access_token=abc&token_type=bearer")).toEqual({ access_token: "abc", token_type: "bearer" });
+  });
+});
+
+describe("mcp_oauth_token main", () => {
+  /** @type {Record<string, string>} */
+  let outputs;
+  /** @type {NodeJS.ProcessEnv} */
+  let savedEnv;
+  let mockCore;
+
+  beforeEach(() => {
+    savedEnv = { ...process.env };
+    outputs = {};
+    mockCore = {
+      info: vi.fn(),
+      warning: vi.fn(),
+      setSecret: vi.fn(),
+      setFailed: vi.fn(),
+      setOutput: vi.fn((name, value) => {
+        outputs[name] = value;
+      }),
+    };
+    global.core = mockCore;
+    process.env.GH_AW_MCP_OAUTH_SERVER = "linear";
+    process.env.GH_AW_MCP_OAUTH_TOKEN_URL = "https://auth.example.com/oauth/token";
+    process.env.GH_AW_MCP_OAUTH_CLIENT_ID = "client";
+    process.env.GH_AW_MCP_OAUTH_REFRESH_TOKEN = "refresh";
+    delete process.env.GH_AW_MCP_OAUTH_CLIENT_SECRET;
+    delete process.env.GH_AW_MCP_OAUTH_SCOPES;
+  });
+
+  afterEach(() => {
+    process.env = savedEnv;
+    vi.unstubAllGlobals();
+    delete global.core;
+  });
+
+  /**
+   * @param {number} status
+   * @param {Record<string, any>} body
+   */
+  function stubFetch(status, body) {
+    const fetchMock = vi.fn().mockResolvedValue({ ok: status >= 200 && status < 300, status, text: async () => JSON.stringify(body) });
+    vi.stubGlobal("fetch", fetchMock);
+    return fetchMock;
+  }
+
+  it("exchanges the refresh token and outputs a masked access token", async () => {
+    const fetchMock = stubFetch(200, { access_token: "access", token_type: "Bearer", expires_in: 3600 });
+    await main();
+    expect(fetchMock).toHaveBeenCalledWith("https://auth.example.com/oauth/token", expect.objectContaining({ method: "POST" }));
+    expect(mockCore.setSecret).toHaveBeenCalledWith("access");
+    expect(outputs.access_token).toBe("access");
+    expect(mockCore.setFailed).not.toHaveBeenCalled();
+  });
+
+  it("warns when the provider rotates the refresh token", async () => {
+    stubFetch(200, { access_token: "access", refresh_token: "rotated" });
+    await main();
+    expect(mockCore.setSecret).toHaveBeenCalledWith("rotated");
+    expect(mockCore.warning).toHaveBeenCalledWith(expect.stringContaining("rotated the refresh token"));
+  });
+
+  it("fails with the provider error", async () => {
+    stubFetch(400, { error: "invalid_grant", error_description: "Token expired" });
+    await main();
+    expect(mockCore.setFailed).toHaveBeenCalledWith(expect.stringContaining("invalid_grant: Token expired"));
+    expect(outputs.access_token).toBeUndefined();
+  });
+
+  it("fails when the response has no access token", async () => {
+    stubFetch(200, { token_type: "Bearer" });
+    await main();
+    expect(mockCore.setFailed).toHaveBeenCalledWith(expect.stringContaining("did not include an access_token"));
+  });
+
+  it("fails without calling the token endpoint when the refresh token is empty", async () => {
+    const fetchMock = stubFetch(200, { access_token: "access" });
+    process.env.GH_AW_MCP_OAUTH_REFRESH_TOKEN = "";
+    await main();
+    expect(fetchMock).not.toHaveBeenCalled();
+    expect(mockCore.setFailed).toHaveBeenCalledWith(expect.stringContaining("refresh token is empty"));
+  });
+});
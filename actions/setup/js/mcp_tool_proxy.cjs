@@ -0,0 +1,537 @@
+#!/usr/bin/env node
+// @ts-check
+
+// mcp_tool_proxy.cjs
+//
+// HTTP proxy between the agent and the MCP gateway for `sandbox.mcp.chaos`,
+// `sandbox.mcp.record`, and `sandbox.mcp.replay`.
+//
+// Runs inside the agent container, listens on localhost:GH_AW_MCP_PROXY_PORT, and
+// forwards every request to the MCP gateway at GH_AW_MCP_PROXY_UPSTREAM. JSON-RPC
+// `tools/call` requests to /mcp/<server> are intercepted:
+//
+// Chaos mode injects faults:
+//   - error:    the call is answered with an error result and never reaches the server
+//   - timeout:  the call is held for timeout_ms and then answered with an error result
+//   - truncate: the call is forwarded and the text content of its result is cut in half
+//
+// The fault for a call is derived from the seed, the server name, and the call's
+// position in that server's call sequence, so runs with the same seed and the same
+// call order see the same faults. Every injected fault is appended to
+// /tmp/gh-aw/mcp-logs/chaos/faults.jsonl.
+//
+// Record mode appends every forwarded call and its result to
+// /tmp/gh-aw/mcp-logs/mcp-recording.jsonl. Replay mode answers calls from such a
+// recording instead of forwarding them: a call matches a recorded entry with the same
+// server, tool, and arguments, and repeated calls are answered in recorded order.
+//
+// Env contract:
+//   GH_AW_MCP_CHAOS          — JSON config: {seed, error_rate, timeout_rate, truncate_rate, timeout_ms, servers}
+//   GH_AW_MCP_RECORD         — "true" to record tool calls
+//   GH_AW_MCP_REPLAY         — recording to replay, relative to GITHUB_WORKSPACE
+//   GH_AW_MCP_PROXY_PORT     — port to listen on
+//   GH_AW_MCP_PROXY_UPSTREAM — MCP gateway base URL (e.g. http://awmg-mcpg:8080)
+
+require("./shim.cjs");
+
+const fs = require("fs");
+const http = require("http");
+const path = require("path");
+const { getErrorMessage } = require("./error_helpers.cjs");
+const { TMP_GH_AW_PATH } = require("./constants.cjs");
+
+const FAULT_LOG_PATH = `${TMP_GH_AW_PATH}/mcp-logs/chaos/faults.jsonl`;
+const RECORDING_PATH = `${TMP_GH_AW_PATH}/mcp-logs/mcp-recording.jsonl`;
+
+/**
+ * @typedef {Object} ChaosConfig
+ * @property {number} seed
+ * @property {number} error_rate
+ * @property {number} timeout_rate
+ * @property {number} truncate_rate
+ * @property {number} timeout_ms
+ * @property {string[]} servers
+ */
+
+/**
+ * Parse the chaos configuration, filling in defaults for missing fields.
+ * @param {string} raw
+ * @returns {ChaosConfig}
+ */
+function parseChaosConfig(raw) {
+  const parsed = JSON.parse(raw || "{}");
+  return {
+    seed: Number(parsed.seed) || 0,
+    error_rate: Number(parsed.error_rate) || 0,
+    timeout_rate: Number(parsed.timeout_rate) || 0,
+    truncate_rate: Number(parsed.truncate_rate) || 0,
+    timeout_ms: Number(parsed.timeout_ms) || 30000,
+    servers: Array.isArray(parsed.servers) ? parsed.servers.map(String) : [],
+  };
+}
+
+/**
+ * 32-bit FNV-1a hash of a string.
+ * @param {string} text
+ * @returns {number}
+ */
+function fnv1a(text) {
+  let hash = 0x811c9dc5;
+  for (let i = 0; i < text.length; i++) {
+    hash ^= text.charCodeAt(i);
+    hash = Math.imul(hash, 0x01000193);
+  }
+  return hash >>> 0;
+}
+
+/**
+ * Deterministic value in [0, 1) for the given seed, server, and call index
+ * (one mulberry32 step over the FNV-1a hash of the inputs).
+ * @param {number} seed
+ * @param {string} server
+ * @param {number} callIndex
+ * @returns {number}
+ */
+function chaosRoll(seed, server, callIndex) {
+  let t = (fnv1a(`${seed}:${server}:${callIndex}`) + 0x6d2b79f5) >>> 0;
+  t = Math.imul(t ^ (t >>> 15), t | 1);
+  t ^= t + Math.imul(t ^ (t >>> 7), t | 61);
+  return ((t ^ (t >>> 14)) >>> 0) / 4294967296;
+}
+
+/**
+ * Select the fault for a tool call, or null when the call should pass through.
+ * @param {ChaosConfig} config
+ * @param {string} server
+ * @param {number} callIndex
+ * @returns {"error" | "timeout" | "truncate" | null}
+ */
+function selectFault(config, server, callIndex) {
+  if (config.servers.length > 0 && !config.servers.includes(server)) {
+    return null;
+  }
+  const roll = chaosRoll(config.seed, server, callIndex);
+  if (roll < config.error_rate) {
+    return "error";
+  }
+  if (roll < config.error_rate + config.timeout_rate) {
+    return "timeout";
+  }
+  if (roll < config.error_rate + config.timeout_rate + config.truncate_rate) {
+    return "truncate";
+  }
+  return null;
+}
+
+/**
+ * Extract the server name from a gateway request path (/mcp/<server>[/...]).
+ * @param {string | undefined} url
+ * @returns {string}
+ */
+function serverFromPath(url) {
+  const match = /^\/mcp\/([^/?]+)/.exec(url || "");
+  return match ? decodeURIComponent(match[1]) : "";
+}
+
+/**
+ * Build the JSON-RPC response for an injected failure. Failures are reported as tool
+ * results with isError set, the way MCP servers report tool execution errors.
+ * @param {unknown} id
+ * @param {string} message
+ * @returns {Record<string, unknown>}
+ */
+function faultResponse(id, message) {
+  return {
+    jsonrpc: "2.0",
+    id,
+    result: {
+      content: [{ type: "text", text: `${message} (injected by gh-aw MCP chaos mode)` }],
+      isError: true,
+    },
+  };
+}
+
+/**
+ * Cut the text content of a JSON-RPC tool result to half its length.
+ * Returns true when the message was modified.
+ * @param {any} message
+ * @returns {boolean}
+ */
+function truncateToolResult(message) {
+  const content = message && message.result && message.result.content;
+  if (!Array.isArray(content)) {
+    return false;
+  }
+  let truncated = false;
+  for (const item of content) {
+    if (item && item.type === "text" && typeof item.text === "string" && item.text.length > 1) {
+      item.text = item.text.slice(0, Math.floor(item.text.length / 2));
+      truncated = true;
+    }
+  }
+  return truncated;
+}
+
+/**
+ * Truncate the tool result in a gateway response body, which is either a JSON
+ * message or a server-sent event stream of JSON messages.
+ * @param {string} body
+ * @param {string} contentType
+ * @returns {string}
+ */
+function truncateResponseBody(body, contentType) {
+  if (contentType.includes("text/event-stream")) {
+    return body
+      .split("\n")
+      .map(line => {
+        if (!line.startsWith("data:")) {
+          return line;
+        }
+        try {
+          const message = JSON.parse(line.slice(5));
+          return truncateToolResult(message) ? `data: ${JSON.stringify(message)}` : line;
+        } catch {
+          return line;
+        }
+      })
+      .join("\n");
+  }
+  try {
+    const message = JSON.parse(body);
+    return truncateToolResult(message) ? JSON.stringify(message) : body;
+  } catch {
+    return body;
+  }
+}
+
+/**
+ * Parse the JSON-RPC messages in a gateway response body, which is either a JSON
+ * message or a server-sent event stream of JSON messages.
+ * @param {string} body
+ * @param {string} contentType
+ * @returns {any[]}
+ */
+function parseResponseMessages(body, contentType) {
+  const payloads = contentType.includes("text/event-stream")
+    ? body
+        .split("\n")
+        .filter(line => line.startsWith("data:"))
+        .map(line => line.slice(5))
+    : [body];
+  /** @type {any[]} */
+  const messages = [];
+  for (const payload of payloads) {
+    try {
+      messages.push(JSON.parse(payload));
+    } catch {
+      // Not a JSON-RPC message (e.g. keepalive or empty body)
+    }
+  }
+  return messages;
+}
+
+/**
+ * Serialize a value as JSON with object keys sorted, so that equal tool arguments
+ * produce the same key regardless of property order.
+ * @param {unknown} value
+ * @returns {string}
+ */
+function stableStringify(value) {
+  if (Array.isArray(value)) {
+    return `[${value.map(stableStringify).join(",")}]`;
+  }
+  if (value && typeof value === "object") {
+    const obj = /** @type {Record<string, unknown>} */ (value);
+    return `{${Object.keys(obj)
+      .sort()
+      .map(key => `${JSON.stringify(key)}:${stableStringify(obj[key])}`)
+      .join(",")}}`;
+  }
+  return JSON.stringify(value === undefined ? null : value);
+}
+
+/**
+ * Key under which a tool call is recorded and looked up during replay.
+ * @param {string} server
+ * @param {string} tool
+ * @param {unknown} args
+ * @returns {string}
+ */
+function recordingKey(server, tool, args) {
+  return `${server}/${tool}/${stableStringify(args || {})}`;
+}
+
+/**
+ * @typedef {Object} RecordedCall
+ * @property {string} server
+ * @property {string} tool
+ * @property {unknown} arguments
+ * @property {unknown} [result]
+ * @property {unknown} [error]
+ */
+
+/**
+ * Load a recording into a replay table: a queue of recorded calls per key.
+ * @param {string} content - JSONL recording
+ * @returns {Map<string, RecordedCall[]>}
+ */
+function loadRecording(content) {
+  /** @type {Map<string, RecordedCall[]>} */
+  const table = new Map();
+  for (const line of content.split("\n")) {
+    if (!line.trim()) {
+      continue;
+    }
+    /** @type {RecordedCall} */
+    const entry = JSON.parse(line);
+    const key = recordingKey(entry.server, entry.tool, entry.arguments);
+    const queue = table.get(key) || [];
+    queue.push(entry);
+    table.set(key, queue);
+  }
+  return table;
+}
+
+/**
+ * Answer a tool call from the replay table. Recorded calls are consumed in order; once
+ * a key's queue is down to its last entry, that entry answers every further call.
+ * @param {Map<string, RecordedCall[]>} table
+ * @param {unknown} id
+ * @param {string} server
+ * @param {string} tool
+ * @param {unknown} args
+ * @returns {Record<string, unknown>}
+ */
+function replayResponse(table, id, server, tool, args) {
+  const queue = table.get(recordingKey(server, tool, args));
+  if (!queue || queue.length === 0) {
+    return {
+      jsonrpc: "2.0",
+      id,
+      result: {
+        content: [{ type: "text", text: `No recorded response for ${server}.${tool} with these arguments (gh-aw MCP replay mode)` }],
+        isError: true,
+      },
+    };
+  }
+  const entry = queue.length > 1 ? queue.shift() : queue[0];
+  if (entry && entry.error !== undefined) {
+    return { jsonrpc: "2.0", id, error: entry.error };
+  }
+  return { jsonrpc: "2.0", id, result: entry && entry.result };
+}
+
+/**
+ * Append an injected fault to the fault log.
+ * @param {Record<string, unknown>} entry
+ */
+function recordFault(entry) {
+  try {
+    fs.mkdirSync(path.dirname(FAULT_LOG_PATH), { recursive: true });
+    fs.appendFileSync(FAULT_LOG_PATH, JSON.stringify({ timestamp: new Date().toISOString(), ...entry }) + "\n");
+  } catch (err) {
+    core.warning(`Failed to record MCP chaos fault: ${getErrorMessage(err)}`);
+  }
+}
+
+/**
+ * Append a forwarded tool call and its response to the recording.
+ * @param {string} server
+ * @param {any} request - JSON-RPC tools/call request
+ * @param {any[]} messages - JSON-RPC messages from the gateway response
+ */
+function recordCall(server, request, messages) {
+  const response = messages.find(message => message && message.id === request.id && ("result" in message || "error" in message));
+  if (!response) {
+    return;
+  }
+  const params = request.params || {};
+  /** @type {RecordedCall} */
+  const entry = { server, tool: params.name, arguments: params.arguments || {} };
+  if ("error" in response) {
+    entry.error = response.error;
+  } else {
+    entry.result = response.result;
+  }
+  try {
+    fs.mkdirSync(path.dirname(RECORDING_PATH), { recursive: true });
+    fs.appendFileSync(RECORDING_PATH, JSON.stringify(entry) + "\n");
+  } catch (err) {
+    core.warning(`Failed to record MCP tool call: ${getErrorMessage(err)}`);
+  }
+}
+
+/**
+ * @typedef {Object} ProxyOptions
+ * @property {ChaosConfig | null} chaos - fault injection settings, or null when chaos mode is off
+ * @property {boolean} record - whether to record forwarded tool calls
+ * @property {Map<string, RecordedCall[]> | null} replay - replay table, or null when replay mode is off
+ */
+
+/**
+ * Write a JSON-RPC message as the response.
+ * @param {http.ServerResponse} res
+ * @param {Record<string, unknown>} message
+ */
+function sendMessage(res, message) {
+  res.writeHead(200, { "Content-Type": "application/json" });
+  res.end(JSON.stringify(message));
+}
+
+/**
+ * Create the MCP tool proxy server.
+ * @param {ProxyOptions} options
+ * @param {URL} upstream - MCP gateway base URL
+ * @returns {http.Server}
+ */
+function createToolProxy(options, upstream) {
+  /** @type {Map<string, number>} */
+  const callCounts = new Map();
+
+  return http.createServer((req, res) => {
+    /** @type {Buffer[]} */
+    const chunks = [];
+    req.on("data", chunk => chunks.push(chunk));
+    req.on("end", () => {
+      const body = Buffer.concat(chunks);
+      const server = serverFromPath(req.url);
+
+      /** @type {any} */
+      let message = null;
+      if (req.method === "POST" && server) {
+        try {
+          message = JSON.parse(body.toString("utf8"));
+        } catch {
+          message = null;
+        }
+      }
+      const isToolCall = message && !Array.isArray(message) && message.method === "tools/call";
+      const tool = isToolCall && message.params ? message.params.name : undefined;
+
+      /** @type {"error" | "timeout" | "truncate" | null} */
+      let fault = null;
+      if (isToolCall && options.chaos) {
+        const callIndex = callCounts.get(server) || 0;
+        callCounts.set(server, callIndex + 1);
+        fault = selectFault(options.chaos, server, callIndex);
+        if (fault) {
+          core.info(`Injecting ${fault} into ${server}.${tool} (call ${callIndex})`);
+          recordFault({ server, tool, call_index: callIndex, fault });
+        }
+      }
+
+      if (fault === "error") {
+        sendMessage(res, faultResponse(message.id, "Tool call failed"));
+        return;
+      }
+      if (fault === "timeout") {
+        const timeoutMs = options.chaos ? options.chaos.timeout_ms : 0;
+        setTimeout(() => sendMessage(res, faultResponse(message.id, `Tool call timed out after ${timeoutMs}ms`)), timeoutMs);
+        return;
+      }
+
+      if (isToolCall && options.replay) {
+        const replayed = replayResponse(options.replay, message.id, server, tool, message.params && message.params.arguments);
+        if (fault === "truncate") {
+          truncateToolResult(replayed);
+        }
+        sendMessage(res, replayed);
+        return;
+      }
+
+      const headers = { ...req.headers, host: upstream.host };
+      if (req.headers["content-length"] !== undefined) {
+        headers["content-length"] = String(body.length);
+      }
+      const bufferResponse = fault === "truncate" || (isToolCall && options.record);
+      const upstreamReq = http.request({ hostname: upstream.hostname, port: upstream.port || 80, method: req.method, path: req.url, headers }, upstreamRes => {
+        if (!bufferResponse) {
+          res.writeHead(upstreamRes.statusCode || 502, upstreamRes.headers);
+          upstreamRes.pipe(res);
+          return;
+        }
+        /** @type {Buffer[]} */
+        const responseChunks = [];
+        upstreamRes.on("data", chunk => responseChunks.push(chunk));
+        upstreamRes.on("end", () => {
+          const contentType = String(upstreamRes.headers["content-type"] || "");
+          let responseBody = Buffer.concat(responseChunks).toString("utf8");
+          if (isToolCall && options.record) {
+            recordCall(server, message, parseResponseMessages(responseBody, contentType));
+          }
+          if (fault === "truncate") {
+            responseBody = truncateResponseBody(responseBody, contentType);
+          }
+          const responseHeaders = { ...upstreamRes.headers };
+          delete responseHeaders["content-length"];
+          delete responseHeaders["transfer-encoding"];
+          res.writeHead(upstreamRes.statusCode || 502, responseHeaders);
+          res.end(responseBody);
+        });
+      });
+      upstreamReq.on("error", err => {
+        core.warning(`MCP tool proxy could not reach the gateway: ${getErrorMessage(err)}`);
+        if (!res.headersSent) {
+          res.writeHead(502, { "Content-Type": "text/plain" });
+        }
+        res.end("MCP gateway unavailable");
+      });
+      upstreamReq.end(body);
+    });
+  });
+}
+
+function main() {
+  const port = Number(process.env.GH_AW_MCP_PROXY_PORT);
+  const upstreamURL = process.env.GH_AW_MCP_PROXY_UPSTREAM;
+  if (!port || !upstreamURL) {
+    throw new Error("GH_AW_MCP_PROXY_PORT and GH_AW_MCP_PROXY_UPSTREAM environment variables are required");
+  }
+
+  /** @type {ProxyOptions} */
+  const options = {
+    chaos: process.env.GH_AW_MCP_CHAOS ? parseChaosConfig(process.env.GH_AW_MCP_CHAOS) : null,
+    record: process.env.GH_AW_MCP_RECORD === "true",
+    replay: null,
+  };
+  const replayPath = process.env.GH_AW_MCP_REPLAY;
+  if (replayPath) {
+    const resolved = path.resolve(process.env.GITHUB_WORKSPACE || process.cwd(), replayPath);
+    if (!fs.existsSync(resolved)) {
+      throw new Error(`MCP recording not found: ${replayPath}. Record one with sandbox.mcp.record and commit it to the repository.`);
+    }
+    options.replay = loadRecording(fs.readFileSync(resolved, "utf8"));
+    core.info(`Replaying ${options.replay.size} recorded tool call(s) from ${replayPath}`);
+  }
+
+  const upstream = new URL(upstreamURL);
+  const server = createToolProxy(options, upstream);
+  server.listen(port, "127.0.0.1", () => {
+    const modes = [options.chaos ? `chaos (seed ${options.chaos.seed})` : "", options.record ? "record" : "", options.replay ? "replay" : ""].filter(Boolean);
+    core.info(`MCP tool proxy listening on port ${port}, forwarding to ${upstream.origin} (${modes.join(", ")})`);
+  });
+}
+
+if (require.main === module) {
+  try {
+    main();
+  } catch (err) {
+    core.setFailed(getErrorMessage(err));
+  }
+}
+
+module.exports = {
+  parseChaosConfig,
+  chaosRoll,
+  selectFault,
+  serverFromPath,
+  faultResponse,
+  truncateToolResult,
+  truncateResponseBody,
+  parseResponseMessages,
+  stableStringify,
+  recordingKey,
+  loadRecording,
+  replayResponse,
+  createToolProxy,
+};
@@ -0,0 +1,178 @@
+// @ts-check
+import { describe, it, expect } from "vitest";
+
+import { parseChaosConfig, chaosRoll, selectFault, serverFromPath, faultResponse, truncateToolResult, truncateResponseBody, parseResponseMessages, stableStringify, recordingKey, loadRecording, replayResponse } from "./mcp_tool_proxy.cjs";
+
+describe("parseChaosConfig", () => {
+  it("fills in defaults for missing fields", () => {
+    expect(parseChaosConfig("{}")).toEqual({ seed: 0, error_rate: 0, timeout_rate: 0, truncate_rate: 0, timeout_ms: 30000, servers: [] });
+  });
+
+  it("reads all fields", () => {
+    const config = parseChaosConfig(JSON.stringify({ seed: 42, error_rate: 0.1, timeout_rate: 0.2, truncate_rate: 0.3, timeout_ms: 500, servers: ["github"] }));
+    expect(config).toEqual({ seed: 42, error_rate: 0.1, timeout_rate: 0.2, truncate_rate: 0.3, timeout_ms: 500, servers: ["github"] });
+  });
+});
+
+describe("chaosRoll", () => {
+  it("is deterministic for the same seed, server, and call index", () => {
+    expect(chaosRoll(42, "github", 3)).toBe(chaosRoll(42, "github", 3));
+  });
+
+  it("varies with the seed, server, and call index", () => {
+    const base = chaosRoll(42, "github", 3);
+    expect(chaosRoll(43, "github", 3)).not.toBe(base);
+    expect(chaosRoll(42, "notion", 3)).not.toBe(base);
+    expect(chaosRoll(42, "github", 4)).not.toBe(base);
+  });
+
+  it("returns values in [0, 1)", () => {
+    for (let i = 0; i < 1000; i++) {
+      const roll = chaosRoll(7, "github", i);
+      expect(roll).toBeGreaterThanOrEqual(0);
+      expect(roll).toBeLessThan(1);
+    }
+  });
+});
+
+describe("selectFault", () => {
+  it("never injects faults when all rates are zero", () => {
+    const config = parseChaosConfig(JSON.stringify({ seed: 1 }));
+    for (let i = 0; i < 100; i++) {
+      expect(selectFault(config, "github", i)).toBeNull();
+    }
+  });
+
+  it("always injects the only configured fault at rate 1", () => {
+    const config = parseChaosConfig(JSON.stringify({ seed: 1, timeout_rate: 1 }));
+    for (let i = 0; i < 100; i++) {
+      expect(selectFault(config, "github", i)).toBe("timeout");
+    }
+  });
+
+  it("roughly follows the configured rates", () => {
+    const config = parseChaosConfig(JSON.stringify({ seed: 5, error_rate: 0.2, timeout_rate: 0.1, truncate_rate: 0.3 }));
+    /** @type {Record<string, number>} */
+    const counts = { error: 0, timeout: 0, truncate: 0, none: 0 };
+    for (let i = 0; i < 10000; i++) {
+      counts[selectFault(config, "github", i) || "none"]++;
+    }
+    expect(counts.error / 10000).toBeCloseTo(0.2, 1);
+    expect(counts.timeout / 10000).toBeCloseTo(0.1, 1);
+    expect(counts.truncate / 10000).toBeCloseTo(0.3, 1);
+  });
+
+  it("skips servers that are not listed", () => {
+    const config = parseChaosConfig(JSON.stringify({ seed: 1, error_rate: 1, servers: ["github"] }));
+    expect(selectFault(config, "notion", 0)).toBeNull();
+    expect(selectFault(config, "github", 0)).toBe("error");
+  });
+});
+
+describe("serverFromPath", () => {
+  it("extracts the server name from gateway paths", () => {
+    expect(serverFromPath("/mcp/github")).toBe("github");
+    expect(serverFromPath("/mcp/safeoutputs/extra?x=1")).toBe("safeoutputs");
+    expect(serverFromPath("/health")).toBe("");
+    expect(serverFromPath(undefined)).toBe("");
+  });
+});
+
+describe("faultResponse", () => {
+  it("reports the fault as an error tool result", () => {
+    const response = faultResponse(7, "Tool call failed");
+    expect(response.id).toBe(7);
+    expect(response.result).toEqual({ content: [{ type: "text", text: "Tool call failed (injected by gh-aw MCP chaos mode)" }], isError: true });
+  });
+});
+
+describe("truncateToolResult", () => {
+  it("cuts text content in half", () => {
+    const message = { result: { content: [{ type: "text", text: "abcdefgh" }, { type: "image", data: "xyz" }] } };
+    expect(truncateToolResult(message)).toBe(true);
+    expect(message.result.content[0].text).toBe("abcd");
+    expect(message.result.content[1]).toEqual({ type: "image", data: "xyz" });
+  });
+
+  it("leaves messages without text content unchanged", () => {
+    expect(truncateToolResult({ error: { code: -32603 } })).toBe(false);
+  });
+});
+
+describe("truncateResponseBody", () => {
+  it("truncates JSON responses", () => {
+    const body = JSON.stringify({ jsonrpc: "2.0", id: 1, result: { content: [{ type: "text", text: "abcdefgh" }] } });
+    expect(JSON.parse(truncateResponseBody(body, "application/json")).result.content[0].text).toBe("abcd");
+  });
+
+  it("truncates server-sent event data lines", () => {
+    const message = JSON.stringify({ jsonrpc: "2.0", id: 1, result: { content: [{ type: "text", text: "abcdefgh" }] } });
+    const body = `event: message\ndata: ${message}\n\n`;
+    const lines = truncateResponseBody(body, "text/event-stream").split("\n");
+    expect(lines[0]).toBe("event: message");
+    expect(JSON.parse(lines[1].slice(5)).result.content[0].text).toBe("abcd");
+  });
+
+  it("returns unparseable bodies unchanged", () => {
+    expect(truncateResponseBody("not json", "application/json")).toBe("not json");
+  });
+});
+
+describe("parseResponseMessages", () => {
+  it("parses JSON responses", () => {
+    expect(parseResponseMessages('{"jsonrpc":"2.0","id":1,"result":{}}', "application/json")).toEqual([{ jsonrpc: "2.0", id: 1, result: {} }]);
+  });
+
+  it("parses server-sent event data lines", () => {
+    const body = 'event: message\ndata: {"jsonrpc":"2.0","method":"notifications/progress"}\n\ndata: {"jsonrpc":"2.0","id":2,"result":{}}\n\n';
+    const messages = parseResponseMessages(body, "text/event-stream");
+    expect(messages).toHaveLength(2);
+    expect(messages[1].id).toBe(2);
+  });
+
+  it("skips unparseable payloads", () => {
+    expect(parseResponseMessages("", "application/json")).toEqual([]);
+  });
+});
+
+describe("stableStringify", () => {
+  it("sorts object keys at every level", () => {
+    expect(stableStringify({ b: 1, a: { d: [2, { f: 3, e: 4 }], c: null } })).toBe('{"a":{"c":null,"d":[2,{"e":4,"f":3}]},"b":1}');
+  });
+
+  it("matches regardless of property order", () => {
+    expect(recordingKey("github", "get_issue", { repo: "x", issue: 1 })).toBe(recordingKey("github", "get_issue", { issue: 1, repo: "x" }));
+  });
+
+  it("treats missing arguments as an empty object", () => {
+    expect(recordingKey("github", "list", undefined)).toBe(recordingKey("github", "list", {}));
+  });
+});
+
+describe("replayResponse", () => {
+  const recording = [
+    { server: "github", tool: "get_issue", arguments: { issue: 1 }, result: { content: [{ type: "text", text: "first" }] } },
+    { server: "github", tool: "get_issue", arguments: { issue: 1 }, result: { content: [{ type: "text", text: "second" }] } },
+    { server: "github", tool: "delete_repo", arguments: {}, error: { code: -32603, message: "denied" } },
+  ]
+    .map(entry => JSON.stringify(entry))
+    .join("\n");
+
+  it("answers repeated calls in recorded order and then repeats the last answer", () => {
+    const table = loadRecording(recording);
+    expect(replayResponse(table, 1, "github", "get_issue", { issue: 1 }).result).toEqual({ content: [{ type: "text", text: "first" }] });
+    expect(replayResponse(table, 2, "github", "get_issue", { issue: 1 }).result).toEqual({ content: [{ type: "text", text: "second" }] });
+    expect(replayResponse(table, 3, "github", "get_issue", { issue: 1 }).result).toEqual({ content: [{ type: "text", text: "second" }] });
+  });
+
+  it("replays recorded JSON-RPC errors", () => {
+    const response = replayResponse(loadRecording(recording), 4, "github", "delete_repo", {});
+    expect(response).toEqual({ jsonrpc: "2.0", id: 4, error: { code: -32603, message: "denied" } });
+  });
+
+  it("answers unrecorded calls with an error result", () => {
+    const response = replayResponse(loadRecording(recording), 5, "github", "get_issue", { issue: 2 });
+    expect(response.id).toBe(5);
+    expect(response.result).toMatchObject({ isError: true });
+  });
+});
@@ -211,6 +211,29 @@ describe("messages.cjs", () => {
       expect(result).toBe("> Custom: [Custom Workflow](https://example.com/run/456)");
     });
 
+    it("should render {current_time} in the project timezone", async () => {
+      process.env.GH_AW_SAFE_OUTPUT_MESSAGES = JSON.stringify({
+        footer: "> Generated by {workflow_name} at {current_time}",
+      });
+      process.env.GH_AW_PROJECT_UTC = "-08:00";
+      vi.useFakeTimers();
+      vi.setSystemTime(new Date("2026-03-05T20:15:00Z"));
+
+      try {
+        const { getFooterMessage } = await import("./messages.cjs");
+
+        const result = getFooterMessage({
+          workflowName: "Custom Workflow",
+          runUrl: "https://example.com/run/456",
+        });
+
+        expect(result).toBe("> Generated by Custom Workflow at 2026-03-05 12:15 (Thursday) UTC-08:00");
+      } finally {
+        vi.useRealTimers();
+        delete process.env.GH_AW_PROJECT_UTC;
+      }
+    });
+
     it("should NOT append triggering number suffix when custom footer is configured", async () => {
       process.env.GH_AW_SAFE_OUTPUT_MESSAGES = JSON.stringify({
         footer: "> Custom: [{workflow_name}]({run_url})",
@@ -14,6 +14,8 @@
  * - {workflow_source} - Source specification (owner/repo/path@ref)
  * - {workflow_source_url} - GitHub URL for the workflow source
  * - {triggering_number} - Issue/PR/Discussion number that triggered this workflow
+ * - {current_time} - Current time in the project timezone (footer only), e.g. "2026-03-05 14:07 (Thursday) UTC+01:00"
+ * - {correlation_id} - Short numeric run correlation ID (footer only), e.g. "35625281"
  * - {ai_credits} - Raw total AI Credits for the run, only present when > 0
  * - {ai_credits_formatted} - Compact formatted AI Credits, only present when > 0
  * - {ai_credits_suffix} - Pre-formatted suffix (e.g. " · 1.25 AIC"), or "" when not available
@@ -16,6 +16,8 @@ const { formatCompactInteger } = require("./compact_numbers.cjs");
 const { formatAIC } = require("./model_costs.cjs");
 const { reduceModelNameToIdentifier } = require("./model_aliases.cjs");
 const { getDetectionWarningMessage } = require("./messages_run_status.cjs");
+const { formatCurrentTime } = require("./project_timezone.cjs");
+const { getCorrelationId } = require("./correlation_id.cjs");
 
 /**
  * Get the detection caution alert if the detection job found a potential issue.
@@ -202,9 +204,11 @@ function getFooterMessage(ctx) {
   }
   const aiCreditsSuffixForTemplate = `${aiCreditsSuffix}${envAmbientContextSuffix}`;
 
-  // Create context with both camelCase and snake_case keys, including computed history_link and agentic_workflow_url
+  // Create context with both camelCase and snake_case keys, including computed history_link, agentic_workflow_url, current_time and correlation_id
   const templateContext = toSnakeCase({
     ...ctx,
+    currentTime: formatCurrentTime(new Date()),
+    correlationId: getCorrelationId(),
     aiCredits,
     historyLink,
     agenticWorkflowUrl,
@@ -548,7 +552,7 @@ function getFooterAgentFailureCommentMessage(ctx) {
  * This marker enables searching and tracing back items generated by an agentic workflow.
  *
  * The marker format is:
- * <!-- gh-aw-agentic-workflow: workflow-name, gh-aw-tracker-id: id, engine: copilot, version: 1.0.0, model: gpt-5, run: https://github.com/... -->
+ * <!-- gh-aw-agentic-workflow: workflow-name, gh-aw-tracker-id: id, engine: copilot, version: 1.0.0, model: gpt-5, correlation_id: 12345678, run: https://github.com/... -->
  *
  * @param {string} workflowName - Name of the workflow
  * @param {string} runUrl - URL of the workflow run
@@ -562,6 +566,7 @@ function generateXMLMarker(workflowName, runUrl) {
   const trackerId = process.env.GH_AW_TRACKER_ID || "";
   const runId = process.env.GITHUB_RUN_ID || "";
   const workflowId = process.env.GH_AW_WORKFLOW_ID || "";
+  const correlationId = getCorrelationId();
 
   // Build the key-value pairs for the marker
   const parts = [];
@@ -599,6 +604,11 @@ function generateXMLMarker(workflowName, runUrl) {
     parts.push(`workflow_id: ${workflowId}`);
   }
 
+  // Add run correlation ID if available (for `gh aw logs --correlation`)
+  if (correlationId) {
+    parts.push(`correlation_id: ${correlationId}`);
+  }
+
   // Always include run URL
   parts.push(`run: ${runUrl}`);
 
@@ -146,8 +146,12 @@ function shellEscapeDoubleQuoted(str) {
  */
 function toContainerUrl(rawUrl) {
   let domain = process.env.MCP_GATEWAY_DOMAIN;
-  const port = process.env.MCP_GATEWAY_PORT;
-  if (domain === "host.docker.internal") {
+  let port = process.env.MCP_GATEWAY_PORT;
+  if (process.env.GH_AW_MCP_PROXY_PORT) {
+    // CLI wrappers go through the MCP tool proxy like agents do.
+    domain = "localhost";
+    port = process.env.GH_AW_MCP_PROXY_PORT;
+  } else if (domain === "host.docker.internal") {
     // The CLI wrappers may run inside a chrooted host environment where
     // host.docker.internal is not resolvable. Use the AWF gateway IP instead.
     domain = AWF_GATEWAY_IP;
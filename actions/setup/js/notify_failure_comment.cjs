@@ -0,0 +1,127 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+// This script posts a brief comment on the triggering issue or pull request when
+// a run fails, so users do not read a silent failure as the agent ignoring them.
+// When the activation job created a status comment, notify_comment_error.cjs
+// updates that comment instead and this script does nothing.
+
+const { getRunFailureMessage } = require("./messages_run_status.cjs");
+const { getErrorMessage } = require("./error_helpers.cjs");
+const { sanitizeContent } = require("./sanitize_content.cjs");
+const { parseBoolTemplatable } = require("./templatable.cjs");
+
+// Failure comments are kept short: the run logs carry the details.
+const MAX_FAILURE_COMMENT_LENGTH = 1000;
+
+/**
+ * @typedef {Object} RunFailureClass
+ * @property {string} status - Status text for the run-failure message (e.g. "timed out")
+ * @property {string} reason - One-sentence explanation of the failure class
+ */
+
+/**
+ * Classify the run failure from the conclusion job environment.
+ * Returns null when the run did not fail or was cancelled on purpose.
+ * @param {NodeJS.ProcessEnv} env - Environment of the conclusion job
+ * @returns {RunFailureClass | null}
+ */
+function classifyRunFailure(env) {
+  const agentConclusion = env.GH_AW_AGENT_CONCLUSION || "failure";
+  const isTrue = (/** @type {string | undefined} */ value) => value === "true";
+
+  if (agentConclusion === "cancelled") {
+    return null;
+  }
+  if (agentConclusion === "skipped") {
+    if (isTrue(env.GH_AW_LOCKDOWN_CHECK_FAILED) || isTrue(env.GH_AW_OAUTH_TOKEN_CHECK_FAILED) || isTrue(env.GH_AW_STALE_LOCK_FILE_FAILED)) {
+      return { status: "failed its pre-run checks", reason: "The workflow configuration or credentials need attention before the agent can run." };
+    }
+    if (isTrue(env.GH_AW_DAILY_AI_CREDITS_EXCEEDED)) {
+      return { status: "ran out of AI credits", reason: "The daily AI credit budget for this workflow is used up." };
+    }
+    return null;
+  }
+  if (agentConclusion === "timed_out" || isTrue(env.GH_AW_AGENTIC_ENGINE_TIMEOUT)) {
+    return { status: "timed out", reason: "The agent ran out of time before it finished." };
+  }
+  if (agentConclusion !== "success") {
+    if (isTrue(env.GH_AW_AI_CREDITS_RATE_LIMIT_ERROR)) {
+      return { status: "ran out of AI credits", reason: "The AI model rejected the request because the credit limit was reached." };
+    }
+    if (isTrue(env.GH_AW_INFERENCE_ACCESS_ERROR) || isTrue(env.GH_AW_MODEL_NOT_SUPPORTED_ERROR)) {
+      return { status: "could not reach the AI model", reason: "The configured token or model does not have access to inference." };
+    }
+    if (isTrue(env.GH_AW_MCP_POLICY_ERROR)) {
+      return { status: "was blocked by policy", reason: "The MCP servers used by this workflow are blocked by an organization policy." };
+    }
+    return { status: "failed", reason: "The agent stopped with an error." };
+  }
+  if (env.GH_AW_DETECTION_CONCLUSION === "failure") {
+    return { status: "was blocked by threat detection", reason: "The agent's output was withheld because threat detection flagged it." };
+  }
+  if (env.GH_AW_SAFE_OUTPUTS_RESULT === "failure") {
+    return { status: "failed to deliver outputs", reason: "The agent finished, but its results could not be applied." };
+  }
+  if (parseInt(env.GH_AW_ASSIGNMENT_ERROR_COUNT || "0", 10) > 0) {
+    return { status: "failed to assign the coding agent", reason: "The agent finished, but the coding agent could not be assigned." };
+  }
+  return null;
+}
+
+/**
+ * Build the failure comment body, capped at MAX_FAILURE_COMMENT_LENGTH characters.
+ * @param {string} workflowName - Name of the workflow
+ * @param {string} runUrl - URL of the workflow run
+ * @param {RunFailureClass} failure - Classified failure
+ * @returns {string}
+ */
+function buildFailureComment(workflowName, runUrl, failure) {
+  const body = `${getRunFailureMessage({ workflowName, runUrl, status: failure.status })}\n\n${failure.reason}`;
+  if (body.length <= MAX_FAILURE_COMMENT_LENGTH) {
+    return body;
+  }
+  return body.slice(0, MAX_FAILURE_COMMENT_LENGTH - 1) + "…";
+}
+
+async function main() {
+  if (!parseBoolTemplatable(process.env.GH_AW_FAILURE_REPORT_AS_COMMENT, true)) {
+    core.info("report-failure-as-comment is disabled: skipping failure comment");
+    return;
+  }
+  if (process.env.GH_AW_COMMENT_ID) {
+    core.info("A status comment exists for this run: skipping failure comment");
+    return;
+  }
+
+  const failure = classifyRunFailure(process.env);
+  if (!failure) {
+    core.info(`Run did not fail (agent conclusion: ${process.env.GH_AW_AGENT_CONCLUSION}): skipping failure comment`);
+    return;
+  }
+
+  const issueNumber = context.payload?.issue?.number || context.payload?.pull_request?.number;
+  if (!issueNumber) {
+    core.info("No triggering issue or pull request: skipping failure comment");
+    return;
+  }
+
+  const workflowName = process.env.GH_AW_WORKFLOW_NAME || "Workflow";
+  const runUrl = process.env.GH_AW_RUN_URL || "";
+  const body = sanitizeContent(buildFailureComment(workflowName, runUrl, failure));
+
+  try {
+    const response = await github.rest.issues.createComment({
+      owner: context.repo.owner,
+      repo: context.repo.repo,
+      issue_number: issueNumber,
+      body,
+    });
+    core.info(`Posted failure comment: ${response.data.html_url}`);
+  } catch (error) {
+    // Best-effort: a missing comment must not turn the conclusion job red.
+    core.warning(`Failed to post failure comment on #${issueNumber}: ${getErrorMessage(error)}`);
+  }
+}
+
+module.exports = { main, classifyRunFailure, buildFailureComment, MAX_FAILURE_COMMENT_LENGTH };
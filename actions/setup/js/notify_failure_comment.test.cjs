@@ -0,0 +1,146 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+import { describe, it, expect, beforeEach, afterEach, vi } from "vitest";
+
+const mockCore = {
+  debug: vi.fn(),
+  info: vi.fn(),
+  warning: vi.fn(),
+  setFailed: vi.fn(),
+};
+const mockGithub = {
+  rest: {
+    issues: {
+      createComment: vi.fn().mockResolvedValue({ data: { html_url: "https://github.com/owner/repo/issues/7#issuecomment-1" } }),
+    },
+  },
+};
+const mockContext = { repo: { owner: "owner", repo: "repo" }, payload: {} };
+
+global.core = mockCore;
+global.github = mockGithub;
+global.context = mockContext;
+
+const { main, classifyRunFailure, buildFailureComment, MAX_FAILURE_COMMENT_LENGTH } = require("./notify_failure_comment.cjs");
+
+const ENV_KEYS = [
+  "GH_AW_AGENT_CONCLUSION",
+  "GH_AW_COMMENT_ID",
+  "GH_AW_FAILURE_REPORT_AS_COMMENT",
+  "GH_AW_RUN_URL",
+  "GH_AW_WORKFLOW_NAME",
+  "GH_AW_SAFE_OUTPUTS_RESULT",
+  "GH_AW_DETECTION_CONCLUSION",
+  "GH_AW_AGENTIC_ENGINE_TIMEOUT",
+];
+
+describe("classifyRunFailure", () => {
+  it("returns null for successful and cancelled runs", () => {
+    expect(classifyRunFailure({ GH_AW_AGENT_CONCLUSION: "success" })).toBeNull();
+    expect(classifyRunFailure({ GH_AW_AGENT_CONCLUSION: "cancelled" })).toBeNull();
+    expect(classifyRunFailure({ GH_AW_AGENT_CONCLUSION: "skipped" })).toBeNull();
+  });
+
+  it("classifies timeouts from the job conclusion and the engine", () => {
+    expect(classifyRunFailure({ GH_AW_AGENT_CONCLUSION: "timed_out" })?.status).toBe("timed out");
+    expect(classifyRunFailure({ GH_AW_AGENT_CONCLUSION: "failure", GH_AW_AGENTIC_ENGINE_TIMEOUT: "true" })?.status).toBe("timed out");
+  });
+
+  it("classifies AI model errors", () => {
+    expect(classifyRunFailure({ GH_AW_AGENT_CONCLUSION: "failure", GH_AW_INFERENCE_ACCESS_ERROR: "true" })?.status).toBe("could not reach the AI model");
+    expect(classifyRunFailure({ GH_AW_AGENT_CONCLUSION: "failure", GH_AW_AI_CREDITS_RATE_LIMIT_ERROR: "true" })?.status).toBe("ran out of AI credits");
+  });
+
+  it("classifies failures after a successful agent run", () => {
+    expect(classifyRunFailure({ GH_AW_AGENT_CONCLUSION: "success", GH_AW_DETECTION_CONCLUSION: "failure" })?.status).toBe("was blocked by threat detection");
+    expect(classifyRunFailure({ GH_AW_AGENT_CONCLUSION: "success", GH_AW_SAFE_OUTPUTS_RESULT: "failure" })?.status).toBe("failed to deliver outputs");
+  });
+
+  it("classifies failed pre-run checks", () => {
+    expect(classifyRunFailure({ GH_AW_AGENT_CONCLUSION: "skipped", GH_AW_STALE_LOCK_FILE_FAILED: "true" })?.status).toBe("failed its pre-run checks");
+  });
+
+  it("falls back to a generic failure", () => {
+    expect(classifyRunFailure({ GH_AW_AGENT_CONCLUSION: "failure" })?.status).toBe("failed");
+  });
+});
+
+describe("buildFailureComment", () => {
+  it("links the run and explains the failure", () => {
+    const body = buildFailureComment("Triage", "https://github.com/owner/repo/actions/runs/1", { status: "timed out", reason: "Out of time." });
+    expect(body).toContain("[Triage](https://github.com/owner/repo/actions/runs/1) timed out");
+    expect(body).toContain("Out of time.");
+  });
+
+  it("caps the comment length", () => {
+    const body = buildFailureComment("Triage", "https://example.com", { status: "failed", reason: "x".repeat(5000) });
+    expect(body.length).toBe(MAX_FAILURE_COMMENT_LENGTH);
+  });
+});
+
+describe("main", () => {
+  let originalEnv;
+
+  beforeEach(() => {
+    vi.clearAllMocks();
+    originalEnv = Object.fromEntries(ENV_KEYS.map(key => [key, process.env[key]]));
+    for (const key of ENV_KEYS) {
+      delete process.env[key];
+    }
+    process.env.GH_AW_WORKFLOW_NAME = "Triage";
+    process.env.GH_AW_RUN_URL = "https://github.com/owner/repo/actions/runs/1";
+    mockContext.payload = { issue: { number: 7 } };
+  });
+
+  afterEach(() => {
+    for (const [key, value] of Object.entries(originalEnv)) {
+      if (value === undefined) {
+        delete process.env[key];
+      } else {
+        process.env[key] = value;
+      }
+    }
+  });
+
+  it("posts a comment on the triggering issue when the run fails", async () => {
+    process.env.GH_AW_AGENT_CONCLUSION = "timed_out";
+    await main();
+    expect(mockGithub.rest.issues.createComment).toHaveBeenCalledWith(expect.objectContaining({ owner: "owner", repo: "repo", issue_number: 7, body: expect.stringContaining("timed out") }));
+  });
+
+  it("does not comment when the run succeeded", async () => {
+    process.env.GH_AW_AGENT_CONCLUSION = "success";
+    await main();
+    expect(mockGithub.rest.issues.createComment).not.toHaveBeenCalled();
+  });
+
+  it("does not comment when a status comment is updated instead", async () => {
+    process.env.GH_AW_AGENT_CONCLUSION = "failure";
+    process.env.GH_AW_COMMENT_ID = "123";
+    await main();
+    expect(mockGithub.rest.issues.createComment).not.toHaveBeenCalled();
+  });
+
+  it("does not comment when disabled at runtime", async () => {
+    process.env.GH_AW_AGENT_CONCLUSION = "failure";
+    process.env.GH_AW_FAILURE_REPORT_AS_COMMENT = "false";
+    await main();
+    expect(mockGithub.rest.issues.createComment).not.toHaveBeenCalled();
+  });
+
+  it("does not comment without a triggering issue or pull request", async () => {
+    process.env.GH_AW_AGENT_CONCLUSION = "failure";
+    mockContext.payload = {};
+    await main();
+    expect(mockGithub.rest.issues.createComment).not.toHaveBeenCalled();
+  });
+
+  it("only warns when the comment cannot be posted", async () => {
+    process.env.GH_AW_AGENT_CONCLUSION = "failure";
+    mockGithub.rest.issues.createComment.mockRejectedValueOnce(new Error("forbidden"));
+    await main();
+    expect(mockCore.warning).toHaveBeenCalledWith(expect.stringContaining("forbidden"));
+    expect(mockCore.setFailed).not.toHaveBeenCalled();
+  });
+});
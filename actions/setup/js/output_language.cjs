@@ -0,0 +1,140 @@
+// @ts-check
+/**
+ * Lightweight language detection for agent output (output-language frontmatter)
+ * @module output_language
+ */
+
+/**
+ * Minimum number of prose words before a Latin-script text is classified.
+ * Shorter snippets (e.g. "LGTM", "Fixes #12") are too ambiguous to judge.
+ */
+const MIN_LATIN_WORDS = 8;
+
+/**
+ * Minimum share of letters in a non-Latin script for that script to decide the language.
+ */
+const MIN_SCRIPT_SHARE = 0.3;
+
+/**
+ * High-frequency function words per Latin-script language. Words shared by
+ * several languages (e.g. "de", "a", "en") are kept out so they do not
+ * skew the score.
+ * @type {Record<string, Set<string>>}
+ */
+const STOPWORDS = {
+  en: new Set(["the", "and", "is", "are", "this", "that", "with", "for", "of", "to", "it", "be", "was", "not", "have", "has", "you", "we", "on", "should", "which", "from", "will", "can"]),
+  de: new Set(["der", "die", "das", "und", "ist", "nicht", "ein", "eine", "mit", "für", "auf", "sich", "den", "dem", "des", "wir", "ich", "wird", "sind", "auch", "werden", "oder", "wurde", "bitte"]),
+  fr: new Set(["le", "la", "les", "et", "est", "une", "des", "du", "pour", "pas", "que", "qui", "dans", "sur", "avec", "nous", "vous", "sont", "cette", "ce", "être", "mais", "il", "au"]),
+  es: new Set(["el", "los", "las", "y", "es", "una", "del", "por", "para", "que", "con", "no", "se", "está", "son", "esta", "pero", "como", "lo", "más", "al", "su", "hay", "muy"]),
+  it: new Set(["il", "gli", "e", "è", "una", "della", "per", "che", "con", "non", "sono", "questo", "questa", "nel", "alla", "anche", "come", "ma", "più", "dei", "delle", "stato", "essere", "ci"]),
+  pt: new Set(["o", "os", "as", "e", "é", "uma", "do", "da", "para", "que", "com", "não", "em", "está", "são", "esta", "mas", "como", "mais", "ao", "dos", "das", "foi", "isso"]),
+  nl: new Set(["het", "een", "en", "is", "niet", "van", "dat", "die", "met", "voor", "op", "zijn", "wij", "ik", "wordt", "ook", "maar", "deze", "naar", "bij", "kan", "er", "worden", "geen"]),
+  pl: new Set(["jest", "nie", "się", "na", "że", "w", "z", "do", "jak", "czy", "ale", "tak", "są", "dla", "oraz", "przez", "być", "już", "tym", "który", "może", "jeśli", "jako", "gdy"]),
+  sv: new Set(["och", "är", "att", "det", "som", "inte", "med", "för", "på", "av", "ett", "den", "har", "vi", "jag", "kan", "ska", "till", "från", "eller", "också", "om", "men", "detta"]),
+};
+
+/**
+ * Letters that only appear in Ukrainian among the Cyrillic languages we support.
+ */
+const UKRAINIAN_LETTERS = /[іїєґ]/iu;
+
+/**
+ * Remove content that is not prose written by the agent: code, URLs, mentions,
+ * issue references and HTML. These are language-neutral and must not count
+ * towards detection.
+ * @param {string} text
+ * @returns {string}
+ */
+function stripNonProse(text) {
+  return text
+    .replace(/```[\s\S]*?```/g, " ")
+    .replace(/~~~[\s\S]*?~~~/g, " ")
+    .replace(/`[^`\n]*`/g, " ")
+    .replace(/^>.*$/gm, " ")
+    .replace(/<[^>]+>/g, " ")
+    .replace(/\]\([^)]*\)/g, "] ")
+    .replace(/https?:\/\/\S+/g, " ")
+    .replace(/[@#][\w./-]+/g, " ");
+}
+
+/**
+ * Detect the language of a text.
+ * Non-Latin scripts are classified by script; Latin-script languages are
+ * classified by counting common function words.
+ * @param {string} text - Text to classify
+ * @returns {string | null} ISO 639-1 code, or null when the text is too short or ambiguous
+ */
+function detectLanguage(text) {
+  if (!text || typeof text !== "string") {
+    return null;
+  }
+  const prose = stripNonProse(text);
+
+  const kana = (prose.match(/[\u3040-\u30ff]/g) || []).length;
+  const han = (prose.match(/[\u4e00-\u9fff]/g) || []).length;
+  const hangul = (prose.match(/[\uac00-\ud7af]/g) || []).length;
+  const cyrillic = (prose.match(/[\u0400-\u04ff]/g) || []).length;
+  const latin = (prose.match(/[a-z\u00c0-\u024f]/gi) || []).length;
+  const total = kana + han + hangul + cyrillic + latin;
+  if (total === 0) {
+    return null;
+  }
+
+  if ((kana + han + hangul) / total >= MIN_SCRIPT_SHARE) {
+    if (hangul >= kana + han) return "ko";
+    if (kana > 0) return "ja";
+    return "zh";
+  }
+  if (cyrillic / total >= MIN_SCRIPT_SHARE) {
+    return UKRAINIAN_LETTERS.test(prose) ? "uk" : "ru";
+  }
+
+  const words = prose.toLowerCase().match(/[a-z\u00c0-\u024f]+/g) || [];
+  if (words.length < MIN_LATIN_WORDS) {
+    return null;
+  }
+  let best = null;
+  let bestScore = 0;
+  let secondScore = 0;
+  for (const [lang, stopwords] of Object.entries(STOPWORDS)) {
+    const score = words.filter(word => stopwords.has(word)).length;
+    if (score > bestScore) {
+      secondScore = bestScore;
+      bestScore = score;
+      best = lang;
+    } else if (score > secondScore) {
+      secondScore = score;
+    }
+  }
+  // Require a clear winner so mixed or code-heavy text is not misclassified
+  if (bestScore < 2 || bestScore === secondScore) {
+    return null;
+  }
+  return best;
+}
+
+/**
+ * Check the user-facing text fields of a safe-output entry against the expected language.
+ * @param {Record<string, any>} entry - Safe-output entry
+ * @param {string} expected - Expected ISO 639-1 code
+ * @returns {string | null} The detected language when it differs from the expected one, otherwise null
+ */
+function findOutputLanguageMismatch(entry, expected) {
+  if (!expected || !entry || typeof entry !== "object") {
+    return null;
+  }
+  const text = ["title", "body"]
+    .map(field => entry[field])
+    .filter(value => typeof value === "string")
+    .join("\n\n");
+  const detected = detectLanguage(text);
+  if (detected === null || detected === expected) {
+    return null;
+  }
+  return detected;
+}
+
+module.exports = {
+  detectLanguage,
+  findOutputLanguageMismatch,
+};
@@ -0,0 +1,47 @@
+// @ts-check
+import { describe, it, expect } from "vitest";
+
+const { detectLanguage, findOutputLanguageMismatch } = require("./output_language.cjs");
+
+describe("output_language", () => {
+  describe("detectLanguage", () => {
+    it("should detect Latin-script languages by function words", () => {
+      expect(detectLanguage("This pull request fixes the failing test and updates the documentation for the new flag.")).toBe("en");
+      expect(detectLanguage("Dieser Pull Request behebt den fehlschlagenden Test und aktualisiert die Dokumentation für das neue Flag.")).toBe("de");
+      expect(detectLanguage("Cette pull request corrige le test qui échoue et met à jour la documentation pour le nouveau flag.")).toBe("fr");
+      expect(detectLanguage("Este pull request corrige el test que falla y actualiza la documentación para el nuevo flag.")).toBe("es");
+    });
+
+    it("should detect non-Latin scripts", () => {
+      expect(detectLanguage("このプルリクエストは失敗しているテストを修正します。")).toBe("ja");
+      expect(detectLanguage("此拉取请求修复了失败的测试。")).toBe("zh");
+      expect(detectLanguage("이 풀 리퀘스트는 실패한 테스트를 수정합니다.")).toBe("ko");
+      expect(detectLanguage("Этот пул-реквест исправляет падающий тест.")).toBe("ru");
+      expect(detectLanguage("Цей пул-реквест виправляє тест і оновлює документацію.")).toBe("uk");
+    });
+
+    it("should return null for short or empty text", () => {
+      expect(detectLanguage("")).toBeNull();
+      expect(detectLanguage("LGTM")).toBeNull();
+      expect(detectLanguage("Fixes #123")).toBeNull();
+    });
+
+    it("should ignore code, URLs and mentions", () => {
+      const text = "Dieser Fix ist klein und wir haben die Tests ergänzt, bitte prüfen.\n\n```go\n// This is the code that should be ignored by the detector\nfunc main() {}\n```\n\nSee https://example.com/the/docs/for/this and @octocat";
+      expect(detectLanguage(text)).toBe("de");
+    });
+  });
+
+  describe("findOutputLanguageMismatch", () => {
+    it("should return the detected language when it differs", () => {
+      const entry = { type: "add_comment", body: "This change fixes the failing test and is ready for review by the team." };
+      expect(findOutputLanguageMismatch(entry, "de")).toBe("en");
+    });
+
+    it("should return null when the language matches or cannot be detected", () => {
+      expect(findOutputLanguageMismatch({ type: "add_comment", body: "Dieser Pull Request behebt den Test und die Dokumentation ist auch aktualisiert." }, "de")).toBeNull();
+      expect(findOutputLanguageMismatch({ type: "add_labels", labels: ["bug"] }, "de")).toBeNull();
+      expect(findOutputLanguageMismatch({ type: "add_comment", body: "The body" }, "")).toBeNull();
+    });
+  });
+});
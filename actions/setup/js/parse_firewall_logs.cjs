@@ -6,6 +6,7 @@ const path = require("path");
 const { sanitizeWorkflowName } = require("./sanitize_workflow_name.cjs");
 const { ERR_PARSE } = require("./error_codes.cjs");
 const { getErrorMessage } = require("./error_helpers.cjs");
+const { TMP_GH_AW_PATH } = require("./constants.cjs");
 
 /**
  * Parses firewall logs and creates a step summary
@@ -15,7 +16,7 @@ const { getErrorMessage } = require("./error_helpers.cjs");
 async function main() {
   try {
     // Get the firewall logs directory path - awf writes logs to /tmp/gh-aw/sandbox/firewall/logs
-    const squidLogsDir = `/tmp/gh-aw/sandbox/firewall/logs/`;
+    const squidLogsDir = `${TMP_GH_AW_PATH}/sandbox/firewall/logs/`;
 
     if (!fs.existsSync(squidLogsDir)) {
       core.info(`No firewall logs directory found at: ${squidLogsDir}`);
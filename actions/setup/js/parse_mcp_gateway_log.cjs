@@ -9,6 +9,7 @@ const { formatModelEmojiAlias } = require("./model_aliases.cjs");
 const { computeInferenceAIC, formatAIC } = require("./model_costs.cjs");
 const { generateUnifiedTimelineSummary } = require("./unified_timeline.cjs");
 const { parseUnknownModelAICreditsFromAuditLog } = require("./ai_credits_context.cjs");
+const { TMP_GH_AW_PATH } = require("./constants.cjs");
 
 /**
  * Parses MCP gateway logs and creates a step summary
@@ -20,7 +21,7 @@ const { parseUnknownModelAICreditsFromAuditLog } = require("./ai_credits_context
  *  - /tmp/gh-aw/sandbox/firewall/logs/api-proxy-logs/token-usage.jsonl (token usage from firewall proxy)
  */
 
-const TOKEN_USAGE_PATH = "/tmp/gh-aw/sandbox/firewall/logs/api-proxy-logs/token-usage.jsonl";
+const TOKEN_USAGE_PATH = `${TMP_GH_AW_PATH}/sandbox/firewall/logs/api-proxy-logs/token-usage.jsonl`;
 const MAX_RPC_SUMMARY_DETAILS_LENGTH = 120;
 const MAX_RPC_SUMMARY_GENERIC_LENGTH = 160;
 const MAX_RPC_MESSAGE_LABEL_LENGTH = 80;
@@ -287,7 +288,7 @@ function setUnknownModelAICreditsOutput(coreObj, value) {
  * Prints all gateway-related files to core.info for debugging
  */
 function printAllGatewayFiles() {
-  const gatewayDirs = ["/tmp/gh-aw/mcp-logs"];
+  const gatewayDirs = [`${TMP_GH_AW_PATH}/mcp-logs`];
   displayDirectories(gatewayDirs, 64 * 1024);
 }
 
@@ -821,11 +822,11 @@ async function main() {
     // First, print all gateway-related files for debugging
     printAllGatewayFiles();
 
-    const gatewayJsonlPath = "/tmp/gh-aw/mcp-logs/gateway.jsonl";
-    const rpcMessagesPath = "/tmp/gh-aw/mcp-logs/rpc-messages.jsonl";
-    const gatewayMdPath = "/tmp/gh-aw/mcp-logs/gateway.md";
-    const gatewayLogPath = "/tmp/gh-aw/mcp-logs/gateway.log";
-    const stderrLogPath = "/tmp/gh-aw/mcp-logs/stderr.log";
+    const gatewayJsonlPath = `${TMP_GH_AW_PATH}/mcp-logs/gateway.jsonl`;
+    const rpcMessagesPath = `${TMP_GH_AW_PATH}/mcp-logs/rpc-messages.jsonl`;
+    const gatewayMdPath = `${TMP_GH_AW_PATH}/mcp-logs/gateway.md`;
+    const gatewayLogPath = `${TMP_GH_AW_PATH}/mcp-logs/gateway.log`;
+    const stderrLogPath = `${TMP_GH_AW_PATH}/mcp-logs/stderr.log`;
     let aiCreditsRateLimitError = false;
     let unknownModelAICredits = false;
 
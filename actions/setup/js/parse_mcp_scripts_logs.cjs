@@ -5,6 +5,7 @@ const fs = require("fs");
 const path = require("path");
 const { getErrorMessage } = require("./error_helpers.cjs");
 const { ERR_PARSE } = require("./error_codes.cjs");
+const { TMP_GH_AW_PATH } = require("./constants.cjs");
 
 /**
  * Parses mcp-scripts MCP server logs and creates a step summary
@@ -17,7 +18,7 @@ const { ERR_PARSE } = require("./error_codes.cjs");
 async function main() {
   try {
     // Get the mcp-scripts logs directory path
-    const mcpScriptsLogsDir = `/tmp/gh-aw/mcp-scripts/logs/`;
+    const mcpScriptsLogsDir = `${TMP_GH_AW_PATH}/mcp-scripts/logs/`;
 
     if (!fs.existsSync(mcpScriptsLogsDir)) {
       core.info(`No mcp-scripts logs directory found at: ${mcpScriptsLogsDir}`);
@@ -20,7 +20,7 @@ const fs = require("fs");
 const path = require("path");
 const { getErrorMessage } = require("./error_helpers.cjs");
 const { listFilesRecursively } = require("./file_helpers.cjs");
-const { DETECTION_LOG_FILENAME, DETECTION_RESULT_FILENAME } = require("./constants.cjs");
+const { DETECTION_LOG_FILENAME, DETECTION_RESULT_FILENAME, TMP_GH_AW_PATH } = require("./constants.cjs");
 const { ERR_SYSTEM, ERR_PARSE, ERR_VALIDATION } = require("./error_codes.cjs");
 
 const RESULT_PREFIX = "THREAT_DETECTION_RESULT:";
@@ -448,7 +448,7 @@ function parseStructuredResultFile(resultFilePath) {
  * @returns {Promise<void>}
  */
 async function main() {
-  const threatDetectionDir = "/tmp/gh-aw/threat-detection";
+  const threatDetectionDir = `${TMP_GH_AW_PATH}/threat-detection`;
   const logPath = path.join(threatDetectionDir, DETECTION_LOG_FILENAME);
   const runDetection = process.env.RUN_DETECTION;
   const continueOnError = process.env.GH_AW_DETECTION_CONTINUE_ON_ERROR !== "false";
@@ -5,6 +5,7 @@ const fs = require("fs");
 const { getErrorMessage } = require("./error_helpers.cjs");
 const { ERR_PARSE } = require("./error_codes.cjs");
 const { parseTokenUsageJsonl, generateTokenUsageSummary } = require("./parse_mcp_gateway_log.cjs");
+const { TMP_GH_AW_PATH } = require("./constants.cjs");
 
 /**
  * Parses the firewall proxy token-usage.jsonl and appends a collapsible markdown
@@ -14,13 +15,13 @@ const { parseTokenUsageJsonl, generateTokenUsageSummary } = require("./parse_mcp
  * is bundled in the agent artifact and accessible to third-party tools.
  */
 
-const TOKEN_USAGE_AUDIT_PATH = "/tmp/gh-aw/sandbox/firewall-audit-logs/api-proxy-logs/token-usage.jsonl";
-const TOKEN_USAGE_PATH = "/tmp/gh-aw/sandbox/firewall/logs/api-proxy-logs/token-usage.jsonl";
+const TOKEN_USAGE_AUDIT_PATH = `${TMP_GH_AW_PATH}/sandbox/firewall-audit-logs/api-proxy-logs/token-usage.jsonl`;
+const TOKEN_USAGE_PATH = `${TMP_GH_AW_PATH}/sandbox/firewall/logs/api-proxy-logs/token-usage.jsonl`;
 // AWF v0.27.7+ may write token-usage.jsonl under --audit-dir as well as --proxy-logs-dir.
 // Include this path so the agent job captures token data regardless of which dir AWF chose.
-const TOKEN_USAGE_AWF_AUDIT_PATH = "/tmp/gh-aw/sandbox/firewall/audit/api-proxy-logs/token-usage.jsonl";
+const TOKEN_USAGE_AWF_AUDIT_PATH = `${TMP_GH_AW_PATH}/sandbox/firewall/audit/api-proxy-logs/token-usage.jsonl`;
 const TOKEN_USAGE_PATHS = [TOKEN_USAGE_AUDIT_PATH, TOKEN_USAGE_AWF_AUDIT_PATH, TOKEN_USAGE_PATH];
-const AGENT_USAGE_PATH = "/tmp/gh-aw/agent_usage.json";
+const AGENT_USAGE_PATH = `${TMP_GH_AW_PATH}/agent_usage.json`;
 const DEFAULT_SUMMARY_TITLE = "Token Usage";
 
 /**
@@ -4,6 +4,7 @@ const fs = require("fs");
 const os = require("os");
 const path = require("path");
 const { getErrorMessage } = require("./error_helpers.cjs");
+const { TMP_GH_AW_PATH } = require("./constants.cjs");
 
 /**
  * Patch the AWF config file with chroot settings for ARC/DinD runners.
@@ -20,8 +21,8 @@ function patchAWFChrootConfig(options = {}) {
     throw new Error("RUNNER_TEMP is required");
   }
 
-  const binariesSourcePath = options.binariesSourcePath || process.env.GH_AW_CHROOT_BINARIES_SOURCE_PATH || "/tmp/gh-aw";
-  const identityHome = options.identityHome || process.env.GH_AW_CHROOT_IDENTITY_HOME || "/tmp/gh-aw/home";
+  const binariesSourcePath = options.binariesSourcePath || process.env.GH_AW_CHROOT_BINARIES_SOURCE_PATH || TMP_GH_AW_PATH;
+  const identityHome = options.identityHome || process.env.GH_AW_CHROOT_IDENTITY_HOME || `${TMP_GH_AW_PATH}/home`;
   const configPath = path.join(runnerTemp, "gh-aw", "awf-config.json");
   const artifactConfigPath = path.join(binariesSourcePath, "awf-config.json");
   let config;
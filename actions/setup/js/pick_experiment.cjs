@@ -29,6 +29,8 @@
 const fs = require("fs");
 const path = require("path");
 const { getErrorMessage } = require("./error_helpers.cjs");
+const { TMP_GH_AW_PATH } = require("./constants.cjs");
+const { getGitHubServerUrl } = require("./get_repository_url.cjs");
 
 /** Maximum number of per-run records retained in state.runs. Older entries are pruned to keep state.json small. */
 const MAX_RUN_HISTORY = 512;
@@ -342,7 +344,7 @@ async function writeSummary(assignments, configs, state, core) {
       if (issue) {
         lines.push("");
         if (repo) {
-          lines.push(`Tracking issue: [#${issue}](https://github.com/${repo}/issues/${issue})`);
+          lines.push(`Tracking issue: [#${issue}](${getGitHubServerUrl()}/${repo}/issues/${issue})`);
         } else {
           lines.push(`Tracking issue: #${issue}`);
         }
@@ -362,8 +364,8 @@ async function writeSummary(assignments, configs, state, core) {
  */
 async function main() {
   const specRaw = process.env.GH_AW_EXPERIMENT_SPEC || "{}";
-  const stateFile = process.env.GH_AW_EXPERIMENT_STATE_FILE || "/tmp/gh-aw/experiments/state.json";
-  const stateDir = process.env.GH_AW_EXPERIMENT_STATE_DIR || "/tmp/gh-aw/experiments";
+  const stateFile = process.env.GH_AW_EXPERIMENT_STATE_FILE || `${TMP_GH_AW_PATH}/experiments/state.json`;
+  const stateDir = process.env.GH_AW_EXPERIMENT_STATE_DIR || `${TMP_GH_AW_PATH}/experiments`;
 
   /** @type {Record<string, string[]|ExperimentConfig>} */
   let rawSpec;
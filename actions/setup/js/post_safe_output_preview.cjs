@@ -0,0 +1,159 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+/**
+ * post_safe_output_preview — safe-output preview comment for pull requests.
+ *
+ * Runs in the preview companion of a workflow with `preview:` enabled. Reads the
+ * safe outputs the staged agent run produced and posts them as a comment on the
+ * pull request that changed the workflow. The comment is marked with the workflow
+ * ID and updated in place on each push, so the pull request carries one preview
+ * per workflow.
+ */
+
+"use strict";
+
+const { loadAgentOutput } = require("./load_agent_output.cjs");
+const { sanitizeContent } = require("./sanitize_content.cjs");
+const { getErrorMessage } = require("./error_helpers.cjs");
+
+/** Maximum number of comment pages scanned for an existing preview. */
+const PREVIEW_MAX_SCAN_PAGES = 10;
+
+/** Maximum length of the rendered preview, below the GitHub comment limit. */
+const PREVIEW_MAX_BODY_LENGTH = 60000;
+
+/**
+ * Returns the hidden marker identifying the preview comment of a workflow.
+ * @param {string} workflowID
+ * @returns {string}
+ */
+function previewMarker(workflowID) {
+  return `<!-- gh-aw-preview:${workflowID} -->`;
+}
+
+/**
+ * Renders one safe output: its text fields as quoted markdown and the remaining
+ * fields as JSON.
+ * @param {any} item
+ * @param {number} index
+ * @returns {string}
+ */
+function renderPreviewItem(item, index) {
+  const type = String(item?.type ?? "unknown");
+  let markdown = `### ${index + 1}. \`${type}\``;
+  if (typeof item?.title === "string" && item.title) {
+    markdown += ` — ${sanitizeContent(item.title)}`;
+  }
+  markdown += "\n\n";
+
+  if (typeof item?.body === "string" && item.body) {
+    const quoted = sanitizeContent(item.body)
+      .split("\n")
+      .map(line => `> ${line}`)
+      .join("\n");
+    markdown += `${quoted}\n\n`;
+  }
+
+  const rest = Object.fromEntries(Object.entries(item ?? {}).filter(([key]) => !["type", "title", "body"].includes(key)));
+  if (Object.keys(rest).length > 0) {
+    markdown += "```json\n" + sanitizeContent(JSON.stringify(rest, null, 2)) + "\n```\n\n";
+  }
+  return markdown;
+}
+
+/**
+ * Builds the preview comment body.
+ * @param {{workflowID: string, workflowName: string, conclusion: string, items: any[], runURL: string}} options
+ * @returns {string}
+ */
+function buildPreviewBody({ workflowID, workflowName, conclusion, items, runURL }) {
+  let body = `${previewMarker(workflowID)}\n## 🎭 Safe-output preview: ${workflowName}\n\n`;
+  if (conclusion && conclusion !== "success") {
+    body += `> [!WARNING]\n> The agent run did not succeed (\`${conclusion}\`); the preview may be incomplete.\n\n`;
+  }
+  if (items.length === 0) {
+    body += "The agent produced no safe outputs with this change.\n\n";
+  } else {
+    body += `With this change, the agent would produce ${items.length} safe output(s). They ran in staged mode, so nothing was written.\n\n`;
+    let rendered = "";
+    for (let i = 0; i < items.length; i++) {
+      const section = renderPreviewItem(items[i], i);
+      if (rendered.length + section.length > PREVIEW_MAX_BODY_LENGTH) {
+        rendered += `_${items.length - i} more safe output(s) omitted; see the run for the full output._\n\n`;
+        break;
+      }
+      rendered += section;
+    }
+    body += rendered;
+  }
+  body += `---\n_Preview of [this run](${runURL}). It is updated when the pull request changes the workflow._\n`;
+  return body;
+}
+
+/**
+ * Finds the preview comment of the workflow on the pull request.
+ * @param {number} pullNumber
+ * @param {string} marker
+ * @returns {Promise<any | null>}
+ */
+async function findPreviewComment(pullNumber, marker) {
+  const perPage = 100;
+  for (let page = 1; page <= PREVIEW_MAX_SCAN_PAGES; page++) {
+    const { data } = await github.rest.issues.listComments({
+      owner: context.repo.owner,
+      repo: context.repo.repo,
+      issue_number: pullNumber,
+      per_page: perPage,
+      page,
+    });
+    if (!Array.isArray(data) || data.length === 0) {
+      return null;
+    }
+    const match = data.find(comment => typeof comment.body === "string" && comment.body.startsWith(marker));
+    if (match) {
+      return match;
+    }
+    if (data.length < perPage) {
+      return null;
+    }
+  }
+  return null;
+}
+
+/**
+ * Main entry point: posts or updates the preview comment on the pull request.
+ * @returns {Promise<void>}
+ */
+async function main() {
+  const pullNumber = parseInt(process.env.GH_AW_PULL_REQUEST_NUMBER || "", 10);
+  if (!pullNumber) {
+    core.info("Not running for a pull request; skipping safe-output preview");
+    return;
+  }
+  const workflowID = process.env.GH_AW_WORKFLOW_ID || "workflow";
+  const workflowName = process.env.GH_AW_WORKFLOW_NAME || workflowID;
+  const conclusion = process.env.GH_AW_AGENT_CONCLUSION || "";
+
+  const result = loadAgentOutput();
+  const items = result.success ? result.items : [];
+  const runURL = `${context.serverUrl}/${context.repo.owner}/${context.repo.repo}/actions/runs/${context.runId}`;
+  const body = buildPreviewBody({ workflowID, workflowName, conclusion, items, runURL });
+
+  try {
+    const existing = await findPreviewComment(pullNumber, previewMarker(workflowID));
+    if (existing) {
+      await github.rest.issues.updateComment({ owner: context.repo.owner, repo: context.repo.repo, comment_id: existing.id, body });
+      core.info(`Updated safe-output preview comment ${existing.id} on #${pullNumber}`);
+    } else {
+      const { data } = await github.rest.issues.createComment({ owner: context.repo.owner, repo: context.repo.repo, issue_number: pullNumber, body });
+      core.info(`Posted safe-output preview comment ${data.id} on #${pullNumber}`);
+    }
+  } catch (error) {
+    // Pull requests from forks get a read-only token; keep the preview in the summary.
+    core.warning(`Failed to post safe-output preview: ${getErrorMessage(error)}`);
+  }
+  await core.summary.addRaw(body).write();
+}
+
+module.exports = { main, previewMarker, renderPreviewItem, buildPreviewBody, findPreviewComment };
@@ -0,0 +1,107 @@
+import { afterEach, beforeEach, describe, expect, it, vi } from "vitest";
+import fs from "fs";
+import os from "os";
+import path from "path";
+
+const mockCore = {
+  info: vi.fn(),
+  warning: vi.fn(),
+  error: vi.fn(),
+  debug: vi.fn(),
+  summary: {
+    addRaw: vi.fn().mockReturnThis(),
+    write: vi.fn().mockResolvedValue(),
+  },
+};
+
+const mockGithub = {
+  rest: {
+    issues: {
+      listComments: vi.fn(),
+      createComment: vi.fn(),
+      updateComment: vi.fn(),
+    },
+  },
+};
+
+global.core = mockCore;
+global.github = mockGithub;
+global.context = {
+  repo: { owner: "octo", repo: "repo" },
+  serverUrl: "https://github.com",
+  runId: 42,
+};
+
+describe("post_safe_output_preview.cjs", () => {
+  let module;
+  let tmpDir;
+
+  beforeEach(async () => {
+    vi.clearAllMocks();
+    tmpDir = fs.mkdtempSync(path.join(os.tmpdir(), "preview-"));
+    process.env.GH_AW_WORKFLOW_ID = "triage";
+    process.env.GH_AW_WORKFLOW_NAME = "Issue Triage";
+    process.env.GH_AW_PULL_REQUEST_NUMBER = "7";
+    process.env.GH_AW_AGENT_CONCLUSION = "success";
+    process.env.GH_AW_AGENT_OUTPUT = path.join(tmpDir, "agent_output.json");
+    module = await import("./post_safe_output_preview.cjs");
+  });
+
+  afterEach(() => {
+    fs.rmSync(tmpDir, { recursive: true, force: true });
+    for (const name of ["GH_AW_WORKFLOW_ID", "GH_AW_WORKFLOW_NAME", "GH_AW_PULL_REQUEST_NUMBER", "GH_AW_AGENT_CONCLUSION", "GH_AW_AGENT_OUTPUT"]) {
+      delete process.env[name];
+    }
+  });
+
+  it("renders the title, quoted body and remaining fields of an output", () => {
+    const markdown = module.renderPreviewItem({ type: "create_issue", title: "Flaky test", body: "line 1\nline 2", labels: ["bug"] }, 0);
+    expect(markdown).toContain("### 1. `create_issue` — Flaky test");
+    expect(markdown).toContain("> line 1\n> line 2");
+    expect(markdown).toContain('"labels"');
+    expect(markdown).not.toContain('"title"');
+  });
+
+  it("starts the body with the workflow marker and reports empty output", () => {
+    const body = module.buildPreviewBody({ workflowID: "triage", workflowName: "Issue Triage", conclusion: "success", items: [], runURL: "https://example.com/run" });
+    expect(body.startsWith("<!-- gh-aw-preview:triage -->")).toBe(true);
+    expect(body).toContain("no safe outputs");
+  });
+
+  it("warns when the agent run did not succeed", () => {
+    const body = module.buildPreviewBody({ workflowID: "triage", workflowName: "Issue Triage", conclusion: "failure", items: [], runURL: "https://example.com/run" });
+    expect(body).toContain("did not succeed (`failure`)");
+  });
+
+  it("creates a comment when none exists", async () => {
+    fs.writeFileSync(process.env.GH_AW_AGENT_OUTPUT, JSON.stringify({ items: [{ type: "add_comment", body: "Thanks!" }] }));
+    mockGithub.rest.issues.listComments.mockResolvedValue({ data: [] });
+    mockGithub.rest.issues.createComment.mockResolvedValue({ data: { id: 1 } });
+
+    await module.main();
+
+    expect(mockGithub.rest.issues.createComment).toHaveBeenCalledWith(expect.objectContaining({ issue_number: 7, body: expect.stringContaining("`add_comment`") }));
+    expect(mockGithub.rest.issues.updateComment).not.toHaveBeenCalled();
+  });
+
+  it("updates the existing preview comment of the workflow", async () => {
+    fs.writeFileSync(process.env.GH_AW_AGENT_OUTPUT, JSON.stringify({ items: [] }));
+    mockGithub.rest.issues.listComments.mockResolvedValue({
+      data: [
+        { id: 5, body: "<!-- gh-aw-preview:other -->\nother" },
+        { id: 6, body: "<!-- gh-aw-preview:triage -->\nold" },
+      ],
+    });
+
+    await module.main();
+
+    expect(mockGithub.rest.issues.updateComment).toHaveBeenCalledWith(expect.objectContaining({ comment_id: 6 }));
+    expect(mockGithub.rest.issues.createComment).not.toHaveBeenCalled();
+  });
+
+  it("skips runs outside pull requests", async () => {
+    delete process.env.GH_AW_PULL_REQUEST_NUMBER;
+    await module.main();
+    expect(mockGithub.rest.issues.listComments).not.toHaveBeenCalled();
+  });
+});
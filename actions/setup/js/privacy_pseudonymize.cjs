@@ -0,0 +1,217 @@
+// @ts-check
+
+/**
+ * Privacy mode: pseudonymize user identities
+ *
+ * When a workflow sets `privacy.pseudonymize: true`, the activation job replaces
+ * GitHub usernames and email addresses in the injected event context with stable
+ * per-run pseudonyms before the agent sees them. The pseudonyms are keyed with a
+ * random salt that only the activation job and the output collector receive, so
+ * the agent cannot map them back to real accounts. When the agent output is
+ * collected, pseudonyms of the maintainers listed in `privacy.maintainers` are
+ * turned back into @mentions; all other pseudonyms stay as they are.
+ */
+
+const crypto = require("crypto");
+
+/** Prefix of user and email pseudonyms. */
+const USER_PSEUDONYM_PREFIX = "anon-";
+
+/** Domain of email pseudonyms (.invalid is reserved and never resolves). */
+const EMAIL_PSEUDONYM_DOMAIN = "redacted.invalid";
+
+/** Number of hex digits kept from the keyed hash. */
+const PSEUDONYM_HASH_LENGTH = 8;
+
+/** Bare (non-@) logins shorter than this are not replaced, to avoid rewriting ordinary words. */
+const MIN_BARE_LOGIN_LENGTH = 3;
+
+const EMAIL_PATTERN = /[A-Za-z0-9._%+-]+@[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,}/g;
+
+// Same login shape as neutralizeAllMentions in sanitize_content_core.cjs; team mentions (@org/team) are kept.
+const MENTION_PATTERN = /(^|[^A-Za-z0-9`._%+-])@([A-Za-z0-9](?:[A-Za-z0-9_-]{0,37}[A-Za-z0-9])?)(?![A-Za-z0-9_/-])/g;
+
+const PSEUDONYM_PATTERN = new RegExp(`@?\\b${USER_PSEUDONYM_PREFIX}[0-9a-f]{${PSEUDONYM_HASH_LENGTH}}\\b`, "g");
+
+/**
+ * Generates the random per-run salt used to key pseudonyms.
+ *
+ * @returns {string} Hex-encoded salt
+ */
+function generatePrivacySalt() {
+  return crypto.randomBytes(16).toString("hex");
+}
+
+/**
+ * @param {string} value - Value to hash (compared case-insensitively)
+ * @param {string} salt - Per-run salt
+ * @returns {string} Truncated hex HMAC
+ */
+function keyedHash(value, salt) {
+  return crypto.createHmac("sha256", salt).update(value.toLowerCase()).digest("hex").slice(0, PSEUDONYM_HASH_LENGTH);
+}
+
+/**
+ * Returns the pseudonym of a GitHub login, without a leading @.
+ *
+ * @param {string} login - GitHub login
+ * @param {string} salt - Per-run salt
+ * @returns {string} Pseudonym such as "anon-1f2e3d4c"
+ */
+function pseudonymForLogin(login, salt) {
+  return `${USER_PSEUDONYM_PREFIX}${keyedHash(login, salt)}`;
+}
+
+/**
+ * Returns the pseudonym of an email address.
+ *
+ * @param {string} email - Email address
+ * @param {string} salt - Per-run salt
+ * @returns {string} Pseudonymous address such as "anon-1f2e3d4c@redacted.invalid"
+ */
+function pseudonymForEmail(email, salt) {
+  return `${USER_PSEUDONYM_PREFIX}${keyedHash(email, salt)}@${EMAIL_PSEUDONYM_DOMAIN}`;
+}
+
+/**
+ * Collects the logins of the users that appear in an event payload
+ * (sender, author, assignees and requested reviewers of the triggering item).
+ *
+ * @param {any} payload - GitHub event payload
+ * @returns {string[]} Unique logins
+ */
+function collectPayloadLogins(payload) {
+  /** @type {Set<string>} */
+  const logins = new Set();
+  /** @param {any} user */
+  const add = user => {
+    if (user && typeof user.login === "string" && user.login) {
+      logins.add(user.login);
+    }
+  };
+  if (!payload || typeof payload !== "object") {
+    return [];
+  }
+  add(payload.sender);
+  for (const key of ["issue", "pull_request", "comment", "review", "discussion"]) {
+    const item = payload[key];
+    if (!item) continue;
+    add(item.user);
+    for (const assignee of Array.isArray(item.assignees) ? item.assignees : []) {
+      add(assignee);
+    }
+    for (const reviewer of Array.isArray(item.requested_reviewers) ? item.requested_reviewers : []) {
+      add(reviewer);
+    }
+  }
+  add(payload.release?.author);
+  return [...logins];
+}
+
+/**
+ * @param {string} s
+ * @returns {string}
+ */
+function escapeRegExp(s) {
+  return s.replace(/[.*+?^${}()|[\]\\]/g, "\\$&");
+}
+
+/**
+ * Replaces email addresses, @mentions and bare occurrences of the known logins
+ * with their pseudonyms.
+ *
+ * @param {string} text - Text to pseudonymize
+ * @param {string} salt - Per-run salt
+ * @param {string[]} [knownLogins] - Logins to replace even when they are not @mentioned
+ * @returns {string} Pseudonymized text
+ */
+function pseudonymizeText(text, salt, knownLogins = []) {
+  if (!text || !salt) {
+    return text;
+  }
+  let result = text.replace(EMAIL_PATTERN, email => pseudonymForEmail(email, salt));
+  result = result.replace(MENTION_PATTERN, (match, prefix, login) => {
+    // Pseudonyms produced by the email pass are left alone
+    if (login.toLowerCase().startsWith(USER_PSEUDONYM_PREFIX) && login.length === USER_PSEUDONYM_PREFIX.length + PSEUDONYM_HASH_LENGTH) {
+      return match;
+    }
+    return `${prefix}@${pseudonymForLogin(login, salt)}`;
+  });
+  for (const login of knownLogins) {
+    if (login.length < MIN_BARE_LOGIN_LENGTH) continue;
+    const bare = new RegExp(`(^|[^A-Za-z0-9_@/-])${escapeRegExp(login)}(?![A-Za-z0-9_-])`, "gi");
+    result = result.replace(bare, (match, prefix) => `${prefix}${pseudonymForLogin(login, salt)}`);
+  }
+  return result;
+}
+
+/**
+ * Turns pseudonyms of the allowlisted maintainers back into @mentions.
+ * Pseudonyms of anyone else are left unchanged.
+ *
+ * @param {string} text - Agent output text
+ * @param {string} salt - Per-run salt used when the context was pseudonymized
+ * @param {string[]} maintainers - Logins whose mentions may be restored
+ * @returns {string} Text with maintainer mentions restored
+ */
+function restoreMaintainerMentions(text, salt, maintainers) {
+  if (!text || !salt || maintainers.length === 0) {
+    return text;
+  }
+  /** @type {Map<string, string>} */
+  const byPseudonym = new Map(maintainers.map(login => [pseudonymForLogin(login, salt), login]));
+  return text.replace(PSEUDONYM_PATTERN, match => {
+    const login = byPseudonym.get(match.replace(/^@/, ""));
+    return login ? `@${login}` : match;
+  });
+}
+
+/**
+ * Applies restoreMaintainerMentions to every string field of a safe output item.
+ *
+ * @param {any} value - Safe output item or nested value
+ * @param {string} salt - Per-run salt
+ * @param {string[]} maintainers - Logins whose mentions may be restored
+ * @returns {any} Copy of the value with maintainer mentions restored
+ */
+function restoreMaintainerMentionsInItem(value, salt, maintainers) {
+  if (typeof value === "string") {
+    return restoreMaintainerMentions(value, salt, maintainers);
+  }
+  if (Array.isArray(value)) {
+    return value.map(v => restoreMaintainerMentionsInItem(v, salt, maintainers));
+  }
+  if (value && typeof value === "object") {
+    /** @type {Record<string, any>} */
+    const result = {};
+    for (const [key, v] of Object.entries(value)) {
+      result[key] = key === "type" ? v : restoreMaintainerMentionsInItem(v, salt, maintainers);
+    }
+    return result;
+  }
+  return value;
+}
+
+/**
+ * Parses the comma-separated maintainer list passed in GH_AW_PRIVACY_MAINTAINERS.
+ *
+ * @param {string | undefined} value - Environment variable value
+ * @returns {string[]} Maintainer logins
+ */
+function parsePrivacyMaintainers(value) {
+  return (value || "")
+    .split(",")
+    .map(s => s.trim().replace(/^@/, ""))
+    .filter(Boolean);
+}
+
+module.exports = {
+  collectPayloadLogins,
+  generatePrivacySalt,
+  parsePrivacyMaintainers,
+  pseudonymForEmail,
+  pseudonymForLogin,
+  pseudonymizeText,
+  restoreMaintainerMentions,
+  restoreMaintainerMentionsInItem,
+};
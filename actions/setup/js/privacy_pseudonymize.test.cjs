@@ -0,0 +1,72 @@
+// @ts-check
+import { describe, it, expect } from "vitest";
+
+const { collectPayloadLogins, parsePrivacyMaintainers, pseudonymForLogin, pseudonymizeText, restoreMaintainerMentions, restoreMaintainerMentionsInItem } = require("./privacy_pseudonymize.cjs");
+
+const SALT = "test-salt";
+
+describe("privacy_pseudonymize", () => {
+  describe("pseudonymForLogin", () => {
+    it("should be stable and case-insensitive within a run", () => {
+      expect(pseudonymForLogin("Octocat", SALT)).toBe(pseudonymForLogin("octocat", SALT));
+      expect(pseudonymForLogin("octocat", SALT)).toMatch(/^anon-[0-9a-f]{8}$/);
+    });
+
+    it("should differ between salts", () => {
+      expect(pseudonymForLogin("octocat", SALT)).not.toBe(pseudonymForLogin("octocat", "other-salt"));
+    });
+  });
+
+  describe("pseudonymizeText", () => {
+    it("should replace mentions, emails and known logins", () => {
+      const alice = pseudonymForLogin("alice", SALT);
+      const result = pseudonymizeText("@alice reported it, contact alice@example.com. Thanks alice!", SALT, ["alice"]);
+      expect(result).not.toContain("alice");
+      expect(result).toContain(`@${alice} reported it`);
+      expect(result).toContain(`Thanks ${alice}!`);
+      expect(result).toMatch(/anon-[0-9a-f]{8}@redacted\.invalid/);
+    });
+
+    it("should keep team mentions, code spans and partial words", () => {
+      const text = "cc @org/team, see `@alice` and malice";
+      expect(pseudonymizeText(text, SALT, ["alice"])).toBe(text);
+    });
+
+    it("should return the text unchanged without a salt", () => {
+      expect(pseudonymizeText("@alice", "")).toBe("@alice");
+    });
+  });
+
+  describe("restoreMaintainerMentions", () => {
+    it("should restore only maintainer pseudonyms", () => {
+      const maintainer = pseudonymForLogin("octocat", SALT);
+      const reporter = pseudonymForLogin("alice", SALT);
+      const result = restoreMaintainerMentions(`Pinging @${maintainer} and ${maintainer}; thanks @${reporter}`, SALT, ["octocat"]);
+      expect(result).toBe(`Pinging @octocat and @octocat; thanks @${reporter}`);
+    });
+
+    it("should restore nested item fields but not the type", () => {
+      const maintainer = pseudonymForLogin("octocat", SALT);
+      const item = { type: "add_comment", body: `cc @${maintainer}`, labels: [maintainer] };
+      expect(restoreMaintainerMentionsInItem(item, SALT, ["octocat"])).toEqual({ type: "add_comment", body: "cc @octocat", labels: ["@octocat"] });
+    });
+  });
+
+  describe("collectPayloadLogins", () => {
+    it("should collect sender, authors, assignees and reviewers", () => {
+      const payload = {
+        sender: { login: "sender" },
+        issue: { user: { login: "author" }, assignees: [{ login: "assignee" }] },
+        pull_request: { user: { login: "author" }, requested_reviewers: [{ login: "reviewer" }] },
+      };
+      expect(collectPayloadLogins(payload)).toEqual(["sender", "author", "assignee", "reviewer"]);
+    });
+  });
+
+  describe("parsePrivacyMaintainers", () => {
+    it("should split and trim the list", () => {
+      expect(parsePrivacyMaintainers(" octocat, @hubot ,")).toEqual(["octocat", "hubot"]);
+      expect(parsePrivacyMaintainers(undefined)).toEqual([]);
+    });
+  });
+});
@@ -86,7 +86,23 @@ function formatDateInProjectTimeZone(date) {
   return `${formatted} UTC${utcOffset}`;
 }
 
+/**
+ * Formats the given time for the agent prompt and safe-output footers, e.g.
+ * "2026-03-05 14:07 (Thursday) UTC+01:00". Falls back to UTC when no project
+ * timezone is configured so the value is always unambiguous.
+ * @param {Date} date
+ * @returns {string}
+ */
+function formatCurrentTime(date) {
+  const utcOffset = resolveProjectTimeZone() || "+00:00";
+  const shiftedDate = new Date(date.getTime() + parseUTCOffsetMinutes(utcOffset) * 60 * 1000);
+  const iso = shiftedDate.toISOString();
+  const weekday = new Intl.DateTimeFormat("en-US", { weekday: "long", timeZone: "UTC" }).format(shiftedDate);
+  return `${iso.slice(0, 10)} ${iso.slice(11, 16)} (${weekday}) UTC${utcOffset}`;
+}
+
 module.exports = {
+  formatCurrentTime,
   formatDateInProjectTimeZone,
   resolveProjectTimeZone,
 };
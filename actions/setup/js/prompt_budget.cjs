@@ -0,0 +1,203 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+// prompt_budget.cjs
+// Enforces the prompt-budget: frontmatter field while interpolate_prompt.cjs assembles
+// the prompt. The compiler passes the budget as JSON in GH_AW_PROMPT_BUDGET:
+//   {"instructions": {"tokens": N, "truncate": "head"}, "context": {"tokens": N, "truncate": "middle"}}
+//
+// - context: shared by the values injected through ${{ }} expressions (GH_AW_EXPR_*).
+//   Short values are kept intact and the largest values are truncated to a common cap.
+// - instructions: the workflow's prompt after interpolation and template rendering,
+//   i.e. everything after the built-in </system> block, injected values included.
+
+const { estimateTokens } = require("./estimate_tokens.cjs");
+const { ERR_CONFIG } = require("./error_codes.cjs");
+
+/** Characters per token, matching estimateTokens. */
+const CHARS_PER_TOKEN = 4;
+
+/** Marks the end of the built-in system instructions in the prompt file. */
+const SYSTEM_END_TAG = "</system>";
+
+/**
+ * @typedef {Object} PromptBudgetSection
+ * @property {number} tokens - Token budget of the section
+ * @property {"head"|"tail"|"middle"|"fail"} truncate - Truncation strategy
+ */
+
+/**
+ * @typedef {Object} PromptBudget
+ * @property {PromptBudgetSection} [instructions]
+ * @property {PromptBudgetSection} [context]
+ */
+
+/**
+ * @typedef {Object} PromptBudgetUsage
+ * @property {string} section - Section name
+ * @property {number} budget - Token budget
+ * @property {number} estimated - Estimated tokens before truncation
+ * @property {number} truncated - Estimated tokens removed
+ */
+
+/**
+ * Parses the GH_AW_PROMPT_BUDGET value.
+ * @param {string|undefined} raw - JSON budget, or empty when no budget is configured
+ * @returns {PromptBudget|null}
+ */
+function parsePromptBudget(raw) {
+  if (!raw) {
+    return null;
+  }
+  try {
+    return JSON.parse(raw);
+  } catch (error) {
+    throw new Error(`${ERR_CONFIG}: GH_AW_PROMPT_BUDGET is not valid JSON: ${raw}`);
+  }
+}
+
+/**
+ * Truncates text to a token budget with the given strategy, leaving a marker where
+ * content was removed. Text within the budget is returned unchanged.
+ * @param {string} text - Text to truncate
+ * @param {number} tokens - Token budget
+ * @param {string} strategy - "head" keeps the beginning, "tail" the end, "middle" both ends
+ * @param {string} section - Section name used in the marker
+ * @returns {{text: string, truncated: number}}
+ */
+function truncateToTokens(text, tokens, strategy, section) {
+  const estimated = estimateTokens(text);
+  if (estimated <= tokens) {
+    return { text, truncated: 0 };
+  }
+  const keep = Math.max(0, tokens) * CHARS_PER_TOKEN;
+  const truncated = estimated - Math.max(0, tokens);
+  const marker = `[… ${truncated} tokens truncated to fit the ${section} budget …]`;
+  switch (strategy) {
+    case "tail":
+      return { text: `${marker}\n${text.slice(text.length - keep)}`, truncated };
+    case "middle": {
+      const headChars = Math.ceil(keep / 2);
+      const tailChars = keep - headChars;
+      return { text: `${text.slice(0, headChars)}\n${marker}\n${text.slice(text.length - tailChars)}`, truncated };
+    }
+    default:
+      return { text: `${text.slice(0, keep)}\n${marker}`, truncated };
+  }
+}
+
+/**
+ * Computes the largest per-value token cap such that the values, counted once per
+ * occurrence, fit the budget. Values below the cap are kept whole.
+ * @param {Array<{tokens: number, occurrences: number}>} entries
+ * @param {number} budget
+ * @returns {number}
+ */
+function contextValueCap(entries, budget) {
+  const sorted = [...entries].sort((a, b) => a.tokens - b.tokens);
+  let remaining = budget;
+  let weight = sorted.reduce((sum, entry) => sum + entry.occurrences, 0);
+  for (const entry of sorted) {
+    if (entry.tokens * weight > remaining) {
+      break;
+    }
+    remaining -= entry.tokens * entry.occurrences;
+    weight -= entry.occurrences;
+  }
+  return weight > 0 ? Math.floor(remaining / weight) : remaining;
+}
+
+/**
+ * Applies the context budget to the GH_AW_EXPR_* values that the prompt references.
+ * @param {string} content - Prompt content with ${GH_AW_EXPR_*} placeholders
+ * @param {Record<string, string>} variables - Values to interpolate
+ * @param {PromptBudgetSection} budget - Context budget
+ * @returns {{variables: Record<string, string>, usage: PromptBudgetUsage, error?: string}}
+ */
+function applyContextBudget(content, variables, budget) {
+  /** @type {Array<{name: string, tokens: number, occurrences: number}>} */
+  const entries = [];
+  for (const [name, value] of Object.entries(variables)) {
+    const occurrences = content.split(`\${${name}}`).length - 1;
+    if (occurrences > 0) {
+      entries.push({ name, tokens: estimateTokens(value), occurrences });
+    }
+  }
+  const estimated = entries.reduce((sum, entry) => sum + entry.tokens * entry.occurrences, 0);
+  /** @type {PromptBudgetUsage} */
+  const usage = { section: "context", budget: budget.tokens, estimated, truncated: 0 };
+  if (estimated <= budget.tokens) {
+    return { variables, usage };
+  }
+  if (budget.truncate === "fail") {
+    return { variables, usage, error: `Injected context is about ${estimated} tokens, which exceeds the prompt-budget.context budget of ${budget.tokens} tokens` };
+  }
+
+  const cap = contextValueCap(entries, budget.tokens);
+  const result = { ...variables };
+  for (const entry of entries) {
+    if (entry.tokens > cap) {
+      const truncated = truncateToTokens(variables[entry.name], cap, budget.truncate, "context");
+      result[entry.name] = truncated.text;
+      usage.truncated += truncated.truncated * entry.occurrences;
+      core.info(`[promptBudget] Truncated ${entry.name} from ${entry.tokens} to ${cap} tokens`);
+    }
+  }
+  return { variables: result, usage };
+}
+
+/**
+ * Applies the instructions budget to the part of the prompt after the built-in
+ * system instructions. The system instructions are never truncated.
+ * @param {string} content - Rendered prompt content
+ * @param {PromptBudgetSection} budget - Instructions budget
+ * @returns {{content: string, usage: PromptBudgetUsage, error?: string}}
+ */
+function applyInstructionsBudget(content, budget) {
+  const systemEnd = content.lastIndexOf(SYSTEM_END_TAG);
+  const splitAt = systemEnd === -1 ? 0 : systemEnd + SYSTEM_END_TAG.length;
+  const system = content.slice(0, splitAt);
+  const instructions = content.slice(splitAt);
+  const estimated = estimateTokens(instructions);
+  /** @type {PromptBudgetUsage} */
+  const usage = { section: "instructions", budget: budget.tokens, estimated, truncated: 0 };
+  if (estimated <= budget.tokens) {
+    return { content, usage };
+  }
+  if (budget.truncate === "fail") {
+    return { content, usage, error: `The prompt is about ${estimated} tokens, which exceeds the prompt-budget.instructions budget of ${budget.tokens} tokens` };
+  }
+
+  const truncated = truncateToTokens(instructions, budget.tokens, budget.truncate, "instructions");
+  usage.truncated = truncated.truncated;
+  core.info(`[promptBudget] Truncated instructions from ${estimated} to ${budget.tokens} tokens`);
+  const separator = system && !truncated.text.startsWith("\n") ? "\n" : "";
+  return { content: system + separator + truncated.text, usage };
+}
+
+/**
+ * Writes the per-section budget usage to the step summary.
+ * @param {PromptBudgetUsage[]} usages
+ * @returns {Promise<void>}
+ */
+async function writePromptBudgetSummary(usages) {
+  if (usages.length === 0) {
+    return;
+  }
+  let summary = "### Prompt budget\n\n";
+  summary += "| Section | Budget | Estimated tokens | Truncated |\n";
+  summary += "| --- | ---: | ---: | ---: |\n";
+  for (const usage of usages) {
+    const truncated = usage.truncated > 0 ? `${usage.truncated} tokens` : usage.estimated > usage.budget ? "exceeded" : "—";
+    summary += `| ${usage.section} | ${usage.budget} | ${usage.estimated} | ${truncated} |\n`;
+  }
+  await core.summary.addRaw(summary).write();
+}
+
+module.exports = {
+  parsePromptBudget,
+  truncateToTokens,
+  applyContextBudget,
+  applyInstructionsBudget,
+  writePromptBudgetSummary,
+};
@@ -0,0 +1,126 @@
+import { describe, it, expect, vi, beforeEach } from "vitest";
+
+const mockCore = {
+  info: vi.fn(),
+  summary: { addRaw: vi.fn().mockReturnThis(), write: vi.fn().mockResolvedValue(undefined) },
+};
+global.core = mockCore;
+
+const { parsePromptBudget, truncateToTokens, applyContextBudget, applyInstructionsBudget, writePromptBudgetSummary } = require("./prompt_budget.cjs");
+
+describe("prompt_budget", () => {
+  beforeEach(() => {
+    vi.clearAllMocks();
+  });
+
+  describe("parsePromptBudget", () => {
+    it("returns null when no budget is configured", () => {
+      expect(parsePromptBudget(undefined)).toBeNull();
+      expect(parsePromptBudget("")).toBeNull();
+    });
+
+    it("parses the budget JSON", () => {
+      expect(parsePromptBudget('{"context":{"tokens":10,"truncate":"tail"}}')).toEqual({ context: { tokens: 10, truncate: "tail" } });
+    });
+
+    it("rejects invalid JSON", () => {
+      expect(() => parsePromptBudget("{")).toThrow("GH_AW_PROMPT_BUDGET is not valid JSON");
+    });
+  });
+
+  describe("truncateToTokens", () => {
+    const text = "a".repeat(20) + "b".repeat(20);
+
+    it("leaves text within the budget unchanged", () => {
+      expect(truncateToTokens(text, 10, "head", "context")).toEqual({ text, truncated: 0 });
+    });
+
+    it("keeps the beginning with head", () => {
+      const result = truncateToTokens(text, 2, "head", "context");
+      expect(result.truncated).toBe(8);
+      expect(result.text).toBe("aaaaaaaa\n[… 8 tokens truncated to fit the context budget …]");
+    });
+
+    it("keeps the end with tail", () => {
+      const result = truncateToTokens(text, 2, "tail", "context");
+      expect(result.text).toBe("[… 8 tokens truncated to fit the context budget …]\nbbbbbbbb");
+    });
+
+    it("keeps both ends with middle", () => {
+      const result = truncateToTokens(text, 2, "middle", "instructions");
+      expect(result.text).toBe("aaaa\n[… 8 tokens truncated to fit the instructions budget …]\nbbbb");
+    });
+  });
+
+  describe("applyContextBudget", () => {
+    const content = "A ${GH_AW_EXPR_A} B ${GH_AW_EXPR_B} C ${GH_AW_EXPR_C}";
+    const variables = { GH_AW_EXPR_A: "x".repeat(8), GH_AW_EXPR_B: "y".repeat(400), GH_AW_EXPR_C: "z".repeat(200), GH_AW_EXPR_UNUSED: "u".repeat(4000) };
+
+    it("keeps values within the budget", () => {
+      const result = applyContextBudget(content, variables, { tokens: 200, truncate: "head" });
+      expect(result.variables).toEqual(variables);
+      expect(result.usage).toEqual({ section: "context", budget: 200, estimated: 152, truncated: 0 });
+    });
+
+    it("truncates the largest values to a shared cap and ignores unused values", () => {
+      const result = applyContextBudget(content, variables, { tokens: 50, truncate: "head" });
+      expect(result.variables.GH_AW_EXPR_A).toBe(variables.GH_AW_EXPR_A);
+      expect(result.variables.GH_AW_EXPR_B.startsWith("y".repeat(96) + "\n[… 76 tokens")).toBe(true);
+      expect(result.variables.GH_AW_EXPR_C.startsWith("z".repeat(96) + "\n[… 26 tokens")).toBe(true);
+      expect(result.variables.GH_AW_EXPR_UNUSED).toBe(variables.GH_AW_EXPR_UNUSED);
+      expect(result.usage.truncated).toBe(102);
+    });
+
+    it("counts every occurrence of a value", () => {
+      const result = applyContextBudget("${GH_AW_EXPR_A} ${GH_AW_EXPR_A}", { GH_AW_EXPR_A: "x".repeat(40) }, { tokens: 10, truncate: "head" });
+      expect(result.usage.estimated).toBe(20);
+      expect(result.variables.GH_AW_EXPR_A.startsWith("x".repeat(20) + "\n")).toBe(true);
+    });
+
+    it("reports an error instead of truncating with fail", () => {
+      const result = applyContextBudget(content, variables, { tokens: 50, truncate: "fail" });
+      expect(result.error).toContain("exceeds the prompt-budget.context budget of 50 tokens");
+      expect(result.variables).toEqual(variables);
+    });
+  });
+
+  describe("applyInstructionsBudget", () => {
+    const prompt = "<system>\nbuilt-in instructions\n</system>\n" + "w".repeat(100);
+
+    it("never truncates the system instructions", () => {
+      const result = applyInstructionsBudget(prompt, { tokens: 5, truncate: "head" });
+      expect(result.content.startsWith("<system>\nbuilt-in instructions\n</system>\n" + "w".repeat(19))).toBe(true);
+      expect(result.usage).toEqual({ section: "instructions", budget: 5, estimated: 26, truncated: 21 });
+    });
+
+    it("budgets the whole prompt when there is no system block", () => {
+      const result = applyInstructionsBudget("w".repeat(100), { tokens: 30, truncate: "head" });
+      expect(result.content).toBe("w".repeat(100));
+      expect(result.usage.truncated).toBe(0);
+    });
+
+    it("reports an error instead of truncating with fail", () => {
+      const result = applyInstructionsBudget(prompt, { tokens: 5, truncate: "fail" });
+      expect(result.error).toContain("exceeds the prompt-budget.instructions budget of 5 tokens");
+      expect(result.content).toBe(prompt);
+    });
+  });
+
+  describe("writePromptBudgetSummary", () => {
+    it("writes a table row per section", async () => {
+      await writePromptBudgetSummary([
+        { section: "context", budget: 50, estimated: 152, truncated: 102 },
+        { section: "instructions", budget: 100, estimated: 20, truncated: 0 },
+      ]);
+      const summary = mockCore.summary.addRaw.mock.calls[0][0];
+      expect(summary).toContain("| context | 50 | 152 | 102 tokens |");
+      expect(summary).toContain("| instructions | 100 | 20 | — |");
+      expect(mockCore.summary.write).toHaveBeenCalled();
+    });
+
+    it("writes nothing without budgets", async () => {
+      await writePromptBudgetSummary([]);
+      expect(mockCore.summary.addRaw).not.toHaveBeenCalled();
+    });
+  });
+});
@@ -30,6 +30,7 @@ const path = require("path");
 const { getErrorMessage } = require("./error_helpers.cjs");
 const { execGitSync, getGitAuthEnv } = require("./git_helpers.cjs");
 const { pushSignedCommits } = require("./push_signed_commits.cjs");
+const { TMP_GH_AW_PATH } = require("./constants.cjs");
 
 /**
  * Checkout or create an orphan git branch for experiment state.
@@ -76,7 +77,7 @@ function checkoutOrCreateBranch(branchName, repoUrl, workspaceDir) {
  * Main entry point called by the actions/github-script step.
  */
 async function main() {
-  const stateDir = process.env.GH_AW_STATE_DIR || process.env.GH_AW_EXPERIMENT_STATE_DIR || "/tmp/gh-aw/experiments";
+  const stateDir = process.env.GH_AW_STATE_DIR || process.env.GH_AW_EXPERIMENT_STATE_DIR || `${TMP_GH_AW_PATH}/experiments`;
   const branchName = process.env.GH_AW_STATE_BRANCH || process.env.GH_AW_EXPERIMENT_BRANCH || "";
   const stateLabel = process.env.GH_AW_STATE_LABEL || "experiment state";
   const filesEnv = process.env.GH_AW_STATE_FILES || "state.json,assignments.json";
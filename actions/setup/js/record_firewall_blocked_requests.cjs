@@ -0,0 +1,154 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+const fs = require("fs");
+const path = require("path");
+const { getErrorMessage } = require("./error_helpers.cjs");
+const { parseFirewallLogLine, isRequestBlocked, extractAndSanitizeDomain } = require("./firewall_blocked_domains.cjs");
+const { TMP_GH_AW_PATH } = require("./constants.cjs");
+
+/**
+ * Records the egress attempts denied by the firewall proxy in a structured
+ * artifact, blocked-requests.json, next to the proxy logs. `gh aw logs` and
+ * `gh aw audit` read it to render the blocked domains of each run, so authors
+ * can extend network.allowed based on what the agent actually tried to reach.
+ *
+ * Format:
+ *   {
+ *     "version": 1,
+ *     "blocked_requests": 3,
+ *     "domains": [
+ *       { "domain": "example.com", "requests": 3, "first_seen": "...", "last_seen": "...", "urls": ["example.com:443"] }
+ *     ]
+ *   }
+ */
+
+const DEFAULT_LOGS_DIR = `${TMP_GH_AW_PATH}/sandbox/firewall/logs`;
+const BLOCKED_REQUESTS_FILE = "blocked-requests.json";
+const BLOCKED_REQUESTS_VERSION = 1;
+// Sample URLs kept per domain; the proxy logs hold the full history.
+const MAX_URLS_PER_DOMAIN = 5;
+
+/**
+ * Returns the proxy log files, preferring the squid-logs/ subdirectory AWF writes
+ * access.log to over *.log files at the top of the logs directory (older layout).
+ * @param {string} logsDir
+ * @returns {string[]}
+ */
+function findProxyLogFiles(logsDir) {
+  for (const dir of [path.join(logsDir, "squid-logs"), logsDir]) {
+    let files;
+    try {
+      files = fs.readdirSync(dir).filter(file => file.endsWith(".log"));
+    } catch {
+      continue;
+    }
+    if (files.length > 0) {
+      return files.sort().map(file => path.join(dir, file));
+    }
+  }
+  return [];
+}
+
+/**
+ * Converts a proxy log timestamp (seconds since the epoch) to ISO 8601.
+ * @param {number} seconds
+ * @returns {string}
+ */
+function toISOTimestamp(seconds) {
+  return Number.isFinite(seconds) ? new Date(seconds * 1000).toISOString() : "";
+}
+
+/**
+ * Collects the denied requests from proxy log lines, grouped by domain.
+ * @param {string[]} lines
+ * @returns {{version: number, blocked_requests: number, domains: Array<{domain: string, requests: number, first_seen: string, last_seen: string, urls: string[]}>}}
+ */
+function collectBlockedRequests(lines) {
+  /** @type {Map<string, {domain: string, requests: number, first: number, last: number, urls: string[]}>} */
+  const byDomain = new Map();
+  let blockedRequests = 0;
+
+  for (const line of lines) {
+    const entry = parseFirewallLogLine(line);
+    if (!entry || !isRequestBlocked(entry.decision, entry.status)) {
+      continue;
+    }
+    // Skip internal Squid errors that carry no destination (client ::1, no domain)
+    if (entry.clientIpPort.startsWith("::1:") && entry.domain === "-" && (entry.destIpPort === "-" || entry.destIpPort === "-:-")) {
+      continue;
+    }
+    // iptables-dropped traffic has no domain; fall back to the destination IP:port
+    let domainField = entry.domain;
+    if (domainField === "-" && entry.destIpPort !== "-" && entry.destIpPort !== "-:-") {
+      domainField = entry.destIpPort;
+    }
+    const domain = extractAndSanitizeDomain(domainField);
+    if (!domain || domain === "-") {
+      continue;
+    }
+
+    blockedRequests++;
+    const seconds = parseFloat(entry.timestamp);
+    const stats = byDomain.get(domain) || { domain, requests: 0, first: seconds, last: seconds, urls: [] };
+    stats.requests++;
+    stats.first = Math.min(stats.first, seconds);
+    stats.last = Math.max(stats.last, seconds);
+    if (entry.url !== "-" && !stats.urls.includes(entry.url) && stats.urls.length < MAX_URLS_PER_DOMAIN) {
+      stats.urls.push(entry.url);
+    }
+    byDomain.set(domain, stats);
+  }
+
+  const domains = Array.from(byDomain.values())
+    .sort((a, b) => b.requests - a.requests || a.domain.localeCompare(b.domain))
+    .map(stats => ({
+      domain: stats.domain,
+      requests: stats.requests,
+      first_seen: toISOTimestamp(stats.first),
+      last_seen: toISOTimestamp(stats.last),
+      urls: stats.urls,
+    }));
+
+  return { version: BLOCKED_REQUESTS_VERSION, blocked_requests: blockedRequests, domains };
+}
+
+async function main() {
+  const logsDir = process.env.AWF_LOGS_DIR || DEFAULT_LOGS_DIR;
+  const outputPath = path.join(path.dirname(logsDir), BLOCKED_REQUESTS_FILE);
+
+  const files = findProxyLogFiles(logsDir);
+  if (files.length === 0) {
+    core.info(`No firewall proxy logs found in ${logsDir}, skipping blocked requests artifact`);
+    return;
+  }
+
+  /** @type {string[]} */
+  const lines = [];
+  for (const file of files) {
+    try {
+      lines.push(...fs.readFileSync(file, "utf8").split("\n"));
+    } catch (error) {
+      core.warning(`Skipping firewall log ${file}: ${getErrorMessage(error)}`);
+    }
+  }
+
+  const blocked = collectBlockedRequests(lines);
+  fs.writeFileSync(outputPath, JSON.stringify(blocked, null, 2) + "\n");
+
+  if (blocked.domains.length === 0) {
+    core.info("The firewall did not block any requests");
+    return;
+  }
+  core.info(`The firewall blocked ${blocked.blocked_requests} request(s) to ${blocked.domains.length} domain(s):`);
+  for (const domain of blocked.domains) {
+    core.info(`  ${domain.domain} (${domain.requests})`);
+  }
+}
+
+module.exports = {
+  main,
+  findProxyLogFiles,
+  collectBlockedRequests,
+  BLOCKED_REQUESTS_FILE,
+};
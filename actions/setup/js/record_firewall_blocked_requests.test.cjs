@@ -0,0 +1,88 @@
+import { describe, it, expect, beforeEach, afterEach, vi } from "vitest";
+import fs from "fs";
+import path from "path";
+import os from "os";
+
+const { main, findProxyLogFiles, collectBlockedRequests, BLOCKED_REQUESTS_FILE } = require("./record_firewall_blocked_requests.cjs");
+
+const ALLOWED_LINE = '1761332530.474 172.30.0.20:35288 api.github.com:443 140.82.112.22:443 1.1 CONNECT 200 TCP_TUNNEL:HIER_DIRECT api.github.com:443 "-"';
+const BLOCKED_LINE = '1761332531.000 172.30.0.20:35289 blocked.example.com:443 -:- 1.1 CONNECT 403 NONE_NONE:HIER_NONE blocked.example.com:443 "-"';
+const BLOCKED_LINE_LATER = '1761332541.000 172.30.0.20:35290 blocked.example.com:443 -:- 1.1 CONNECT 403 NONE_NONE:HIER_NONE blocked.example.com:443 "-"';
+const BLOCKED_OTHER_LINE = '1761332535.000 172.30.0.20:35291 other.test:80 -:- 1.1 GET 403 TCP_DENIED:HIER_NONE http://other.test/path "curl/8.0"';
+const SQUID_ERROR_LINE = '1773003472.027 ::1:52010 - -:- 0.0 - 0 NONE_NONE:HIER_NONE error:transaction-end-before-headers "-"';
+
+describe("record_firewall_blocked_requests.cjs", () => {
+  describe("collectBlockedRequests", () => {
+    it("groups denied requests by domain", () => {
+      const result = collectBlockedRequests([ALLOWED_LINE, BLOCKED_LINE, BLOCKED_OTHER_LINE, BLOCKED_LINE_LATER, SQUID_ERROR_LINE, ""]);
+
+      expect(result.version).toBe(1);
+      expect(result.blocked_requests).toBe(3);
+      expect(result.domains).toEqual([
+        {
+          domain: "blocked.example.com",
+          requests: 2,
+          first_seen: "2025-10-24T19:02:11.000Z",
+          last_seen: "2025-10-24T19:02:21.000Z",
+          urls: ["blocked.example.com:443"],
+        },
+        {
+          domain: "other.test",
+          requests: 1,
+          first_seen: "2025-10-24T19:02:15.000Z",
+          last_seen: "2025-10-24T19:02:15.000Z",
+          urls: ["http://other.test/path"],
+        },
+      ]);
+    });
+
+    it("returns no domains when nothing was blocked", () => {
+      const result = collectBlockedRequests([ALLOWED_LINE]);
+      expect(result.blocked_requests).toBe(0);
+      expect(result.domains).toEqual([]);
+    });
+  });
+
+  describe("with log files", () => {
+    let testDir;
+    let logsDir;
+
+    beforeEach(() => {
+      testDir = fs.mkdtempSync(path.join(os.tmpdir(), "gh-aw-blocked-requests-"));
+      logsDir = path.join(testDir, "logs");
+      fs.mkdirSync(path.join(logsDir, "squid-logs"), { recursive: true });
+      global.core = { info: vi.fn(), warning: vi.fn() };
+    });
+
+    afterEach(() => {
+      fs.rmSync(testDir, { recursive: true, force: true });
+      delete global.core;
+      delete process.env.AWF_LOGS_DIR;
+    });
+
+    it("prefers the squid-logs subdirectory", () => {
+      fs.writeFileSync(path.join(logsDir, "old.log"), BLOCKED_LINE);
+      fs.writeFileSync(path.join(logsDir, "squid-logs", "access.log"), BLOCKED_LINE);
+      expect(findProxyLogFiles(logsDir)).toEqual([path.join(logsDir, "squid-logs", "access.log")]);
+    });
+
+    it("writes blocked-requests.json next to the logs directory", async () => {
+      fs.writeFileSync(path.join(logsDir, "squid-logs", "access.log"), [ALLOWED_LINE, BLOCKED_LINE].join("\n"));
+      process.env.AWF_LOGS_DIR = logsDir;
+
+      await main();
+
+      const written = JSON.parse(fs.readFileSync(path.join(testDir, BLOCKED_REQUESTS_FILE), "utf8"));
+      expect(written.blocked_requests).toBe(1);
+      expect(written.domains.map(d => d.domain)).toEqual(["blocked.example.com"]);
+    });
+
+    it("skips the artifact when there are no proxy logs", async () => {
+      process.env.AWF_LOGS_DIR = path.join(testDir, "missing");
+
+      await main();
+
+      expect(fs.existsSync(path.join(testDir, BLOCKED_REQUESTS_FILE))).toBe(false);
+    });
+  });
+});
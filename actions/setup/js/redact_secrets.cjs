@@ -3,13 +3,41 @@
 
 /**
  * Redacts secrets from files in /tmp/gh-aw and ${RUNNER_TEMP}/gh-aw directories before uploading artifacts
- * This script processes all .txt, .json, .log, .md, .mdx, .yml, .jsonl files under /tmp/gh-aw and ${RUNNER_TEMP}/gh-aw
- * and redacts any strings matching the actual secret values provided via environment variables.
+ * This script processes all text files (see TARGET_EXTENSIONS) under /tmp/gh-aw and ${RUNNER_TEMP}/gh-aw
+ * and redacts any strings matching the actual secret values provided via environment variables,
+ * their common encodings, and built-in credential formats.
+ *
+ * When GH_AW_REDACT_PATHS is set (newline-separated files, directories or globs, as passed to
+ * actions/upload-artifact), only those paths are scanned. The compiler uses this for the final
+ * pass over an artifact's files right before upload.
  */
 const fs = require("fs");
 const path = require("path");
 const { getErrorMessage } = require("./error_helpers.cjs");
 const { ERR_VALIDATION } = require("./error_codes.cjs");
+const { TMP_GH_AW_PATH } = require("./constants.cjs");
+
+/**
+ * Extensions of the text files scanned for secrets: logs, prompts, transcripts,
+ * safe-output records and git patches produced for safe outputs.
+ */
+const TARGET_EXTENSIONS = [".txt", ".json", ".log", ".md", ".mdx", ".yml", ".yaml", ".jsonl", ".ndjson", ".patch", ".diff", ".csv", ".xml", ".html", ".sarif"];
+
+/**
+ * Replacement for every redacted value
+ */
+const REDACTED = "***REDACTED***";
+
+/**
+ * Reports whether a file name has one of the given extensions
+ * @param {string} fileName - File name or path
+ * @param {string[]} extensions - File extensions to match (e.g., ['.txt', '.json', '.log'])
+ * @returns {boolean}
+ */
+function hasExtension(fileName, extensions) {
+  return extensions.includes(path.extname(fileName).toLowerCase());
+}
+
 /**
  * Recursively finds all files matching the specified extensions
  * @param {string} dir - Directory to search
@@ -30,8 +58,7 @@ function findFiles(dir, extensions) {
         results.push(...findFiles(fullPath, extensions));
       } else if (entry.isFile()) {
         // Check if file has one of the target extensions
-        const ext = path.extname(entry.name).toLowerCase();
-        if (extensions.includes(ext)) {
+        if (hasExtension(entry.name, extensions)) {
           results.push(fullPath);
         }
       }
@@ -42,6 +69,53 @@ function findFiles(dir, extensions) {
   return results;
 }
 
+/**
+ * Resolves artifact upload paths to the files to scan. Directories are searched
+ * recursively, "*" globs in the last path segment are expanded, and exclusions ("!path")
+ * are skipped. Only files with target extensions are returned, whether they were found in
+ * a directory, matched by a glob or listed explicitly: other files, such as git bundles,
+ * may be binary and must not be rewritten.
+ * @param {string[]} uploadPaths - Paths as passed to actions/upload-artifact
+ * @param {string[]} extensions - File extensions to match in directories
+ * @returns {string[]} Unique file paths
+ */
+function findFilesInPaths(uploadPaths, extensions) {
+  /** @type {Set<string>} */
+  const files = new Set();
+  for (const rawPath of uploadPaths) {
+    const uploadPath = rawPath.trim();
+    if (!uploadPath || uploadPath.startsWith("!")) {
+      continue;
+    }
+    if (path.basename(uploadPath).includes("*")) {
+      const dir = path.dirname(uploadPath);
+      const escaped = path.basename(uploadPath).replace(/[.+?^${}()|[\]\\]/g, "\\$&");
+      const matcher = new RegExp(`^${escaped.replace(/\*/g, ".*")}$`);
+      try {
+        for (const entry of fs.readdirSync(dir, { withFileTypes: true })) {
+          if (entry.isFile() && matcher.test(entry.name) && hasExtension(entry.name, extensions)) {
+            files.add(path.join(dir, entry.name));
+          }
+        }
+      } catch {
+        // The glob matched nothing; upload-artifact ignores it as well
+      }
+      continue;
+    }
+    try {
+      const stat = fs.statSync(uploadPath);
+      if (stat.isDirectory()) {
+        findFiles(uploadPath, extensions).forEach(file => files.add(file));
+      } else if (stat.isFile() && hasExtension(uploadPath, extensions)) {
+        files.add(uploadPath);
+      }
+    } catch {
+      // Missing paths are normal: upload-artifact runs with if-no-files-found: ignore
+    }
+  }
+  return [...files];
+}
+
 /**
  * Built-in regex patterns for common credential types
  * Each pattern is designed to match legitimate credential formats
@@ -75,6 +149,16 @@ const BUILT_IN_PATTERNS = [
 
   // Anthropic tokens
   { name: "Anthropic API Key", pattern: /sk-ant-api03-[a-zA-Z0-9_-]{95}/g },
+  { name: "Anthropic Admin API Key", pattern: /sk-ant-admin01-[a-zA-Z0-9_-]{95}/g },
+  { name: "Anthropic OAuth Token", pattern: /sk-ant-oat01-[a-zA-Z0-9_-]{95}/g },
+
+  // Package registry and SaaS tokens
+  { name: "npm Access Token", pattern: /npm_[0-9a-zA-Z]{36}/g },
+  { name: "Slack Token", pattern: /xox[abposr]-[0-9A-Za-z-]{10,250}/g },
+  { name: "Stripe Live Secret Key", pattern: /[sr]k_live_[0-9a-zA-Z]{24,99}/g },
+
+  // Private keys (PEM blocks, including keys embedded in JSON with escaped newlines)
+  { name: "Private Key", pattern: /-----BEGIN [A-Z ]{0,20}PRIVATE KEY-----[\s\S]{1,10000}?-----END [A-Z ]{0,20}PRIVATE KEY-----/g },
 ];
 
 /**
@@ -139,9 +223,8 @@ function redactBuiltInPatterns(content) {
     const matches = redacted.match(pattern);
     if (matches && matches.length > 0) {
       // Redact each match with fixed-length string
-      const replacement = "***REDACTED***";
       for (const match of matches) {
-        redacted = redacted.split(match).join(replacement);
+        redacted = redacted.split(match).join(REDACTED);
       }
       redactionCount += matches.length;
       detectedPatterns.push(name);
@@ -152,6 +235,26 @@ function redactBuiltInPatterns(content) {
   return { content: redacted, redactionCount, detectedPatterns };
 }
 
+/**
+ * Returns a secret value together with the encodings engine CLIs and git commonly log it in:
+ * base64 (e.g. decoded-by-hand headers), the base64 Basic auth credential git sends for
+ * x-access-token URLs and http.extraheader, and URL encoding. Padding is dropped from the
+ * base64 forms so they also match unpadded or embedded occurrences.
+ * @param {string} secretValue - Secret value
+ * @returns {string[]} The value and its encoded forms, without duplicates
+ */
+function secretVariants(secretValue) {
+  const variants = new Set([secretValue]);
+  for (const plain of [secretValue, `x-access-token:${secretValue}`]) {
+    const encoded = Buffer.from(plain, "utf8").toString("base64").replace(/=+$/, "");
+    if (encoded.length >= 6) {
+      variants.add(encoded);
+    }
+  }
+  variants.add(encodeURIComponent(secretValue));
+  return [...variants];
+}
+
 /**
  * Redacts secrets from file content using exact string matching
  * @param {string} content - File content to process
@@ -162,7 +265,10 @@ function redactSecrets(content, secretValues) {
   let redactionCount = 0;
   let redacted = content;
   // Sort secret values by length (longest first) to handle overlapping secrets
-  const sortedSecrets = secretValues.slice().sort((a, b) => b.length - a.length);
+  const sortedSecrets = secretValues
+    .filter(value => value && value.length >= 6)
+    .flatMap(secretVariants)
+    .sort((a, b) => b.length - a.length);
   for (const secretValue of sortedSecrets) {
     // Skip empty or very short values (likely not actual secrets)
     if (!secretValue || secretValue.length < 6) {
@@ -172,11 +278,10 @@ function redactSecrets(content, secretValues) {
     // Use split and join for exact string matching (not regex)
     // This is safer than regex as it doesn't interpret special characters
     // Use fixed-length redaction string without prefix preservation
-    const replacement = "***REDACTED***";
     const parts = redacted.split(secretValue);
     const occurrences = parts.length - 1;
     if (occurrences > 0) {
-      redacted = parts.join(replacement);
+      redacted = parts.join(REDACTED);
       redactionCount += occurrences;
       core.info(`Redacted ${occurrences} occurrence(s) of a secret`);
     }
@@ -192,7 +297,13 @@ function redactSecrets(content, secretValues) {
  */
 function processFile(filePath, secretValues) {
   try {
-    const content = fs.readFileSync(filePath, "utf8");
+    const buffer = fs.readFileSync(filePath);
+    // A NUL byte marks a binary file; rewriting it as UTF-8 text would corrupt it
+    if (buffer.includes(0)) {
+      core.info(`Skipping binary file ${filePath}`);
+      return 0;
+    }
+    const content = buffer.toString("utf8");
 
     // First, redact built-in patterns
     const builtInResult = redactBuiltInPatterns(content);
@@ -222,7 +333,11 @@ async function main() {
   // Get the list of secret names from environment variable
   const secretNames = process.env.GH_AW_SECRET_NAMES;
 
-  core.info(`Starting secret redaction in /tmp/gh-aw and ${process.env.RUNNER_TEMP}/gh-aw directories`);
+  if (process.env.GH_AW_REDACT_PATHS) {
+    core.info("Starting secret redaction in the artifact paths");
+  } else {
+    core.info(`Starting secret redaction in ${TMP_GH_AW_PATH} and ${process.env.RUNNER_TEMP}/gh-aw directories`);
+  }
   try {
     // Collect custom secret values from environment variables
     const secretValues = [];
@@ -256,12 +371,19 @@ async function main() {
     // Always scan for built-in patterns, even if there are no custom secrets
     core.info("Scanning for built-in credential patterns and custom secrets");
 
-    // Find all target files in /tmp/gh-aw and ${RUNNER_TEMP}/gh-aw directories
-    const targetExtensions = [".txt", ".json", ".log", ".md", ".mdx", ".yml", ".jsonl"];
-    const tmpFiles = findFiles("/tmp/gh-aw", targetExtensions);
-    const optFiles = findFiles(`${process.env.RUNNER_TEMP}/gh-aw`, targetExtensions);
-    const files = [...tmpFiles, ...optFiles];
-    core.info(`Found ${files.length} file(s) to scan for secrets (${tmpFiles.length} in /tmp/gh-aw, ${optFiles.length} in ${process.env.RUNNER_TEMP}/gh-aw)`);
+    // Find all target files in /tmp/gh-aw and ${RUNNER_TEMP}/gh-aw directories,
+    // or in the artifact paths when GH_AW_REDACT_PATHS is set
+    const targetExtensions = TARGET_EXTENSIONS;
+    let files;
+    if (process.env.GH_AW_REDACT_PATHS) {
+      files = findFilesInPaths(process.env.GH_AW_REDACT_PATHS.split("\n"), targetExtensions);
+      core.info(`Found ${files.length} file(s) to scan for secrets in the artifact paths`);
+    } else {
+      const tmpFiles = findFiles(TMP_GH_AW_PATH, targetExtensions);
+      const optFiles = findFiles(`${process.env.RUNNER_TEMP}/gh-aw`, targetExtensions);
+      files = [...tmpFiles, ...optFiles];
+      core.info(`Found ${files.length} file(s) to scan for secrets (${tmpFiles.length} in ${TMP_GH_AW_PATH}, ${optFiles.length} in ${process.env.RUNNER_TEMP}/gh-aw)`);
+    }
     let totalRedactions = 0;
     let filesWithRedactions = 0;
     // Process each file
@@ -282,4 +404,4 @@ async function main() {
   }
 }
 
-module.exports = { main, redactSecrets, redactBuiltInPatterns, extractMCPGatewayTokens, BUILT_IN_PATTERNS, MCP_GATEWAY_CONFIG_PATHS };
+module.exports = { main, redactSecrets, redactBuiltInPatterns, secretVariants, findFilesInPaths, extractMCPGatewayTokens, BUILT_IN_PATTERNS, MCP_GATEWAY_CONFIG_PATHS, TARGET_EXTENSIONS };
@@ -27,6 +27,7 @@ const path = require("path");
 const os = require("os");
 const { DefaultArtifactClient } = require("./artifact_client.cjs");
 const { getErrorMessage } = require("./error_helpers.cjs");
+const { TMP_GH_AW_PATH } = require("./constants.cjs");
 
 /**
  * Retrieve the bearer token from the builtin `github` Octokit instance.
@@ -45,7 +46,7 @@ async function getTokenFromGithub() {
 }
 
 /** Path where the activation job expects the usage cache to be restored. */
-const CACHE_FILE_PATH = "/tmp/gh-aw/agentic-workflow-usage-cache.jsonl";
+const CACHE_FILE_PATH = `${TMP_GH_AW_PATH}/agentic-workflow-usage-cache.jsonl`;
 
 /** Name of the artifact uploaded by the conclusion job that holds the aggregated cache. */
 const AIC_USAGE_CACHE_ARTIFACT_NAME = "aic-usage-cache";
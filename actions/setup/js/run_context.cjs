@@ -0,0 +1,23 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+const { formatCurrentTime } = require("./project_timezone.cjs");
+const { computeRunCorrelationId } = require("./correlation_id.cjs");
+
+/**
+ * Resolves the run context values that are only known at runtime and exposes
+ * them as step outputs, so the prompt states the actual current time instead
+ * of leaving the agent to guess today's date, and carries the run's
+ * correlation ID so the agent can quote it.
+ */
+async function main() {
+  const currentTime = formatCurrentTime(new Date());
+  core.info(`Current time: ${currentTime}`);
+  core.setOutput("current_time", currentTime);
+
+  const correlationId = computeRunCorrelationId();
+  core.info(`Correlation ID: ${correlationId || "(unavailable)"}`);
+  core.setOutput("correlation_id", correlationId);
+}
+
+module.exports = { main };
@@ -0,0 +1,49 @@
+import { describe, it, expect, vi, beforeEach, afterEach } from "vitest";
+
+describe("run_context", () => {
+  /** @type {any} */
+  let mockCore;
+
+  beforeEach(() => {
+    mockCore = { info: vi.fn(), warning: vi.fn(), setOutput: vi.fn() };
+    global.core = mockCore;
+    vi.useFakeTimers();
+    vi.setSystemTime(new Date("2026-03-05T23:30:00Z"));
+  });
+
+  afterEach(() => {
+    vi.useRealTimers();
+    delete process.env.GH_AW_PROJECT_UTC;
+    delete process.env.GH_AW_DEFAULT_UTC;
+    delete process.env.GITHUB_REPOSITORY;
+    delete process.env.GITHUB_RUN_ID;
+    delete global.core;
+  });
+
+  it("outputs the current time in UTC when no project timezone is configured", async () => {
+    const { main } = require("./run_context.cjs");
+    await main();
+    expect(mockCore.setOutput).toHaveBeenCalledWith("current_time", "2026-03-05 23:30 (Thursday) UTC+00:00");
+  });
+
+  it("outputs the current time in the project timezone", async () => {
+    process.env.GH_AW_PROJECT_UTC = "+05:30";
+    const { main } = require("./run_context.cjs");
+    await main();
+    expect(mockCore.setOutput).toHaveBeenCalledWith("current_time", "2026-03-06 05:00 (Friday) UTC+05:30");
+  });
+
+  it("falls back to the enterprise default timezone", async () => {
+    process.env.GH_AW_DEFAULT_UTC = "-08:00";
+    const { formatCurrentTime } = require("./project_timezone.cjs");
+    expect(formatCurrentTime(new Date())).toBe("2026-03-05 15:30 (Thursday) UTC-08:00");
+  });
+
+  it("outputs the run correlation ID", async () => {
+    process.env.GITHUB_REPOSITORY = "github/gh-aw";
+    process.env.GITHUB_RUN_ID = "1234567890";
+    const { main } = require("./run_context.cjs");
+    await main();
+    expect(mockCore.setOutput).toHaveBeenCalledWith("correlation_id", "35625281");
+  });
+});
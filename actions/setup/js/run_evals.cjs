@@ -37,9 +37,10 @@ const { ERR_VALIDATION } = require("./error_codes.cjs");
 const { getErrorMessage } = require("./error_helpers.cjs");
 const { EVALS_OUTPUT_PATH } = require("./evals_constants.cjs");
 const { resolveModelWithFallback } = require("./model_fallback.cjs");
+const { TMP_GH_AW_PATH } = require("./constants.cjs");
 
-const EVALS_DIR = "/tmp/gh-aw/evals";
-const EVALS_LOG_PATH = "/tmp/gh-aw/evals/evals.log";
+const EVALS_DIR = `${TMP_GH_AW_PATH}/evals`;
+const EVALS_LOG_PATH = `${TMP_GH_AW_PATH}/evals/evals.log`;
 const AGENT_OUTPUT_FILENAME = "agent_output.json";
 
 // ---------------------------------------------------------------------------
@@ -101,12 +102,12 @@ async function setupMain() {
   const prompt = buildEvalPrompt(questions, agentOutputContent);
 
   try {
-    fs.mkdirSync("/tmp/gh-aw/aw-prompts", { recursive: true });
-    fs.writeFileSync("/tmp/gh-aw/aw-prompts/prompt.txt", prompt);
+    fs.mkdirSync(`${TMP_GH_AW_PATH}/aw-prompts`, { recursive: true });
+    fs.writeFileSync(`${TMP_GH_AW_PATH}/aw-prompts/prompt.txt`, prompt);
   } catch (err) {
     throw new Error(`Failed to prepare eval prompt file: ${String(err)}`, { cause: err });
   }
-  core.exportVariable("GH_AW_PROMPT", "/tmp/gh-aw/aw-prompts/prompt.txt");
+  core.exportVariable("GH_AW_PROMPT", `${TMP_GH_AW_PATH}/aw-prompts/prompt.txt`);
 
   core.info(`BinEval setup complete: wrote prompt with ${questions.length} question(s)`);
 
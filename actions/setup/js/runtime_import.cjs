@@ -9,11 +9,14 @@
 const { getErrorMessage } = require("./error_helpers.cjs");
 const { ERR_API, ERR_CONFIG, ERR_PARSE, ERR_SYSTEM, ERR_VALIDATION } = require("./error_codes.cjs");
 const { isTruthy } = require("./is_truthy.cjs");
+const { referencesDispatchInput, sanitizeDispatchInput } = require("./dispatch_inputs.cjs");
+const { stripAgenticJobs } = require("./extract_agentic_jobs.cjs");
 
 const fs = require("fs");
 const path = require("path");
 const https = require("https");
 const http = require("http");
+const { TMP_GH_AW_PATH } = require("./constants.cjs");
 
 /**
  * Checks if a file starts with front matter (---\n)
@@ -212,6 +215,7 @@ function isSafeExpression(expr) {
   // - github.aw.inputs.* (shared workflow inputs)
   // - inputs.* (workflow_call inputs)
   // - env.* (environment variables)
+  // - context.* (context: frontmatter values)
   // Limit nesting depth to max 5 levels to prevent deep traversal attacks
   const dynamicPatterns = [
     /^(needs|steps)\.[a-zA-Z0-9_-]+\.[a-zA-Z0-9_-]+(\.[a-zA-Z0-9_-]+){0,2}$/, // Max depth: needs.job.outputs.foo.bar (5 levels)
@@ -220,6 +224,7 @@ function isSafeExpression(expr) {
     /^inputs\.[a-zA-Z0-9_-]+$/,
     /^env\.[a-zA-Z0-9_-]+$/,
     /^experiments\.[a-zA-Z0-9_-]+$/,
+    /^context\.[a-zA-Z0-9_]+$/,
   ];
 
   for (const pattern of dynamicPatterns) {
@@ -421,13 +426,14 @@ function evaluateExpression(expr) {
     return evaluateExpression(rightExpr);
   }
 
-  // Check if this is a needs.*, steps.*, or inputs.* expression that should be looked up from environment variables
+  // Check if this is a needs.*, steps.*, inputs.*, or context.* expression that should be looked up from environment variables
   // The compiler extracts these expressions and makes them available as GH_AW_* environment variables
   // For example: needs.search_issues.outputs.issue_list → GH_AW_NEEDS_SEARCH_ISSUES_OUTPUTS_ISSUE_LIST
   // For inputs: inputs.errors → GH_AW_INPUTS_ERRORS
+  // For context: values: context.team → GH_AW_CONTEXT_TEAM
   // This is required for workflow_call where inputs are not in context.payload.inputs;
   // for workflow_dispatch, context.payload.inputs is populated but the env var lookup takes precedence.
-  if (trimmed.startsWith("needs.") || trimmed.startsWith("steps.") || trimmed.startsWith("inputs.")) {
+  if (trimmed.startsWith("needs.") || trimmed.startsWith("steps.") || trimmed.startsWith("inputs.") || trimmed.startsWith("context.")) {
     // Convert expression to environment variable name
     // e.g., "needs.search_issues.outputs.issue_list" → "GH_AW_NEEDS_SEARCH_ISSUES_OUTPUTS_ISSUE_LIST"
     const envVarName = "GH_AW_" + trimmed.toUpperCase().replace(/\./g, "_");
@@ -579,7 +585,7 @@ function processExpressions(content, source) {
           "Safe expressions include:\n" +
           "  - github.actor, github.repository, github.run_id, etc.\n" +
           "  - github.event.issue.number, github.event.pull_request.number, etc.\n" +
-          "  - needs.*, steps.*, env.*, inputs.*, experiments.*\n\n" +
+          "  - needs.*, steps.*, env.*, inputs.*, experiments.*, context.*\n\n" +
           "See documentation for the complete list of allowed expressions."
       );
     }
@@ -613,8 +619,12 @@ function processExpressions(content, source) {
       continue;
     }
 
-    // Expression is safe - evaluate it
-    const evaluated = evaluateExpression(trimmed);
+    // Expression is safe - evaluate it. Dispatch inputs are user-typed text and are
+    // sanitized like issue bodies; unevaluated expressions are left for GitHub Actions.
+    let evaluated = evaluateExpression(trimmed);
+    if (referencesDispatchInput(trimmed) && !evaluated.startsWith("${{")) {
+      evaluated = sanitizeDispatchInput(evaluated);
+    }
     replacements.set(fullMatch, evaluated);
   }
 
@@ -628,7 +638,7 @@ function processExpressions(content, source) {
       "Safe expressions include:\n" +
       "  - github.actor, github.repository, github.run_id, etc.\n" +
       "  - github.event.issue.number, github.event.pull_request.number, etc.\n" +
-      "  - needs.*, steps.*, env.*, inputs.*, experiments.*\n\n" +
+      "  - needs.*, steps.*, env.*, inputs.*, experiments.*, context.*\n\n" +
       "See documentation for the complete list of allowed expressions.";
     throw new Error(errorMsg);
   }
@@ -746,7 +756,7 @@ async function fetchUrlContent(url, cacheDir) {
  * @throws {Error} - If URL fetch fails or content is invalid
  */
 async function processUrlImport(url, optional, startLine, endLine) {
-  const cacheDir = "/tmp/gh-aw/url-cache";
+  const cacheDir = `${TMP_GH_AW_PATH}/url-cache`;
 
   // Fetch URL content (with caching)
   let content;
@@ -823,16 +833,139 @@ async function processUrlImport(url, optional, startLine, endLine) {
 }
 
 /**
- * Wraps bare GitHub expressions in template conditionals with ${{ }}
- * Transforms {{#if expression}} to {{#if ${{ expression }} }} if expression looks like a GitHub Actions expression
+ * Root namespaces that template conditions may reference. Conditions that reference anything
+ * else (e.g. experiments.*, which interpolate_prompt.cjs substitutes later) are left unchanged.
+ */
+const TEMPLATE_CONDITION_NAMESPACES = ["github.", "needs.", "steps.", "env.", "inputs."];
+
+/**
+ * Evaluates a bare template condition such as `github.event_name == 'pull_request'` with
+ * GitHub Actions expression semantics: `!`, `&&`, `||`, `==`, `!=` and parentheses are
+ * supported, string comparisons are case-insensitive, and references that cannot be
+ * resolved evaluate to ''.
+ * @param {string} expr - The condition (without ${{ }})
+ * @returns {boolean | null} - The result, or null if the condition cannot be evaluated here
+ */
+function evaluateTemplateCondition(expr) {
+  const tokens = expr.match(/\s*(\|\||&&|==|!=|!|\(|\)|'(?:[^']|'')*'|"[^"]*"|[^\s!=&|()'"]+)/gy);
+  if (!tokens || tokens.join("").trim() !== expr.trim()) {
+    return null;
+  }
+  const items = tokens.map(t => t.trim());
+  let pos = 0;
+
+  /** @param {any} v */
+  const truthy = v => (typeof v === "boolean" ? v : typeof v === "number" ? v !== 0 : isTruthy(String(v ?? "")));
+
+  /** @param {any} a @param {any} b */
+  const equals = (a, b) => {
+    const na = Number(a);
+    const nb = Number(b);
+    if (a !== "" && b !== "" && !isNaN(na) && !isNaN(nb)) {
+      return na === nb;
+    }
+    return String(a).toLowerCase() === String(b).toLowerCase();
+  };
+
+  /** @returns {any} */
+  const primary = () => {
+    const item = items[pos++];
+    if (item === undefined) {
+      throw new Error("unexpected end of condition");
+    }
+    if (item === "(") {
+      const value = or();
+      if (items[pos++] !== ")") {
+        throw new Error("expected )");
+      }
+      return value;
+    }
+    if (/^'.*'$/.test(item)) {
+      return item.slice(1, -1).replace(/''/g, "'");
+    }
+    if (/^".*"$/.test(item)) {
+      return item.slice(1, -1);
+    }
+    if (item === "true" || item === "false") {
+      return item === "true";
+    }
+    if (item === "null") {
+      return "";
+    }
+    if (/^-?\d+(\.\d+)?$/.test(item)) {
+      return Number(item);
+    }
+    if (!TEMPLATE_CONDITION_NAMESPACES.some(ns => item.startsWith(ns)) || !/^[a-z]+(\.[a-zA-Z0-9_-]+(\[\d+\])?)+$/.test(item)) {
+      throw new Error(`unsupported operand: ${item}`);
+    }
+    const value = evaluateExpression(item);
+    return value.startsWith("${{") ? "" : value;
+  };
+
+  /** @returns {any} */
+  const comparison = () => {
+    const left = primary();
+    const op = items[pos];
+    if (op === "==" || op === "!=") {
+      pos++;
+      const right = primary();
+      return op === "==" ? equals(left, right) : !equals(left, right);
+    }
+    return left;
+  };
+
+  /** @returns {any} */
+  const unary = () => {
+    if (items[pos] === "!") {
+      pos++;
+      return !truthy(unary());
+    }
+    return comparison();
+  };
+
+  /** @returns {any} */
+  const and = () => {
+    let value = unary();
+    while (items[pos] === "&&") {
+      pos++;
+      const right = unary();
+      value = truthy(value) ? right : value;
+    }
+    return value;
+  };
+
+  /** @returns {any} */
+  const or = () => {
+    let value = and();
+    while (items[pos] === "||") {
+      pos++;
+      const right = and();
+      value = truthy(value) ? value : right;
+    }
+    return value;
+  };
+
+  try {
+    const value = or();
+    return pos === items.length ? truthy(value) : null;
+  } catch (_error) {
+    return null;
+  }
+}
+
+/**
+ * Evaluates bare GitHub expressions in template conditionals
+ * Transforms {{#if expression}} (and {{#elseif expression}} in all its variants) to
+ * {{#if true}} / {{#if }} when the expression only references GitHub Actions namespaces
  * @param {string} content - The markdown content
- * @returns {string} - Content with GitHub expressions wrapped
+ * @returns {string} - Content with GitHub expressions evaluated
  */
 function wrapExpressionsInTemplateConditionals(content) {
-  // Pattern to match {{#if expression}} where expression is not already wrapped in ${{ }}
-  const pattern = /\{\{#if\s+((?:\$\{\{[^\}]*\}\}|[^\}])*?)\s*\}\}/g;
+  // Pattern to match {{#if expression}} and {{#elseif expression}} variants where expression
+  // is not already wrapped in ${{ }}
+  const pattern = /\{\{(#if|#?else[-_]?if)\s+((?:\$\{\{[^\}]*\}\}|[^\}])*?)\s*\}\}/g;
 
-  return content.replace(pattern, (match, expr) => {
+  return content.replace(pattern, (match, tag, expr) => {
     const trimmed = expr.trim();
 
     // If already wrapped in ${{ }}, return as-is
@@ -862,7 +995,7 @@ function wrapExpressionsInTemplateConditionals(content) {
     // Restricting to explicit prefixes prevents non-GH dotted identifiers such as
     // `experiments.foo` (resolved later by interpolate_prompt.cjs via experiment
     // substitution) from being incorrectly collapsed to {{#if }} (falsy) here.
-    const looksLikeGitHubExpr = trimmed.startsWith("github.") || trimmed.startsWith("needs.") || trimmed.startsWith("steps.") || trimmed.startsWith("env.") || trimmed.startsWith("inputs.");
+    const looksLikeGitHubExpr = TEMPLATE_CONDITION_NAMESPACES.some(ns => trimmed.replace(/^[!(\s]+/, "").startsWith(ns));
 
     if (!looksLikeGitHubExpr) {
       // Not a GitHub Actions expression, leave as-is
@@ -871,14 +1004,19 @@ function wrapExpressionsInTemplateConditionals(content) {
 
     // Evaluate the condition inline so that the template renderer (renderMarkdownTemplate /
     // isTruthy) receives a concrete boolean sentinel rather than a raw value string or an
-    // always-truthy __GH_AW__ placeholder.
+    // always-truthy __GH_AW__ placeholder. Comparisons and compound conditions such as
+    // github.event_name == 'pull_request' are evaluated by evaluateTemplateCondition; a
+    // condition it cannot evaluate (e.g. one that also references experiments.*) is left as-is.
     //
     // We emit "{{#if true}}" / "{{#if }}" rather than the raw resolved value to prevent
     // template tag injection: if the resolved value contained "}}" it would prematurely
     // close the {{#if ...}} tag and corrupt the rendered output.
-    const evaluated = evaluateExpression(trimmed);
-    const shouldRenderBlock = !evaluated.startsWith("${{") && isTruthy(evaluated);
-    return shouldRenderBlock ? `{{#if true}}` : `{{#if }}`;
+    const shouldRenderBlock = evaluateTemplateCondition(trimmed);
+    if (shouldRenderBlock === null) {
+      return match;
+    }
+    const prefix = tag === "#if" ? "{{#if" : "{{#elseif";
+    return shouldRenderBlock ? `${prefix} true}}` : `${prefix} }}`;
   });
 }
 
@@ -1118,6 +1256,9 @@ async function processRuntimeImport(filepathOrUrl, optional, workspaceDir, start
     }
   }
 
+  // Remove agentic job blocks, which run as separate jobs with their own prompts
+  content = stripAgenticJobs(content);
+
   // Remove XML comments
   content = removeXMLComments(content);
 
@@ -1381,6 +1522,7 @@ module.exports = {
   isSafeExpression,
   evaluateExpression,
   processExpressions,
+  evaluateTemplateCondition,
   wrapExpressionsInTemplateConditionals,
   extractAndReplacePlaceholders,
   generatePlaceholderName,
@@ -13,6 +13,7 @@ const {
   hasGitHubActionsMacros,
   isSafeExpression,
   evaluateExpression,
+  evaluateTemplateCondition,
   wrapExpressionsInTemplateConditionals,
   extractAndReplacePlaceholders,
   generatePlaceholderName,
@@ -1136,6 +1137,7 @@ describe("runtime_import", () => {
           expect(isSafeExpression("inputs.version")).toBe(true);
           expect(isSafeExpression("env.NODE_VERSION")).toBe(true);
           expect(isSafeExpression("experiments.prompt_style")).toBe(true);
+          expect(isSafeExpression("context.issue_title")).toBe(true);
         });
 
         it("should reject unsafe expressions", () => {
@@ -1203,6 +1205,15 @@ describe("runtime_import", () => {
           }
         });
 
+        it("should return env var value for context.* expressions when set", () => {
+          process.env.GH_AW_CONTEXT_TEAM = "platform";
+          try {
+            expect(evaluateExpression("context.team")).toBe("platform");
+          } finally {
+            delete process.env.GH_AW_CONTEXT_TEAM;
+          }
+        });
+
         it("should return empty string for inputs.* env var set to empty", () => {
           process.env.GH_AW_INPUTS_BRANCH = "";
           try {
@@ -2036,6 +2047,51 @@ describe("runtime_import", () => {
     });
   });
 
+  describe("evaluateTemplateCondition", () => {
+    beforeEach(() => {
+      global.context = {
+        actor: "testuser",
+        eventName: "pull_request",
+        job: "test-job",
+        repo: { owner: "testorg", repo: "testrepo" },
+        runId: 12345,
+        runNumber: 67,
+        workflow: "test-workflow",
+        payload: { action: "opened", pull_request: { number: 42, draft: false }, inputs: {} },
+      };
+    });
+    afterEach(() => {
+      delete global.context;
+    });
+    it("should evaluate equality against single- and double-quoted literals", () => {
+      expect(evaluateTemplateCondition("github.event_name == 'pull_request'")).toBe(true);
+      expect(evaluateTemplateCondition('github.event_name == "issues"')).toBe(false);
+    });
+    it("should compare strings case-insensitively and numbers numerically", () => {
+      expect(evaluateTemplateCondition("github.event_name == 'Pull_Request'")).toBe(true);
+      expect(evaluateTemplateCondition("github.event.pull_request.number == 42.0")).toBe(true);
+    });
+    it("should support !=, !, && and || with parentheses", () => {
+      expect(evaluateTemplateCondition("github.event_name != 'pull_request'")).toBe(false);
+      expect(evaluateTemplateCondition("github.event.action == 'opened' && !github.event.pull_request.draft")).toBe(true);
+      expect(evaluateTemplateCondition("(github.event_name == 'issues' || github.event_name == 'pull_request') && github.actor")).toBe(true);
+    });
+    it("should treat unresolved references as empty", () => {
+      expect(evaluateTemplateCondition("github.event.issue.number")).toBe(false);
+      expect(evaluateTemplateCondition("github.event.issue.title == ''")).toBe(true);
+    });
+    it("should return null for conditions it cannot evaluate", () => {
+      expect(evaluateTemplateCondition("experiments.mode == 'a'")).toBeNull();
+      expect(evaluateTemplateCondition("github.actor == experiments.mode")).toBeNull();
+      expect(evaluateTemplateCondition("contains(github.event_name, 'pull')")).toBeNull();
+      expect(evaluateTemplateCondition("github.event_name ==")).toBeNull();
+    });
+    it("should select the matching branch of an if/elseif/else chain", () => {
+      const input = "{{#if github.event_name == 'issues'}}\nI\n{{#elseif github.event_name == \"pull_request\"}}\nP\n{{#else}}\nO\n{{/if}}";
+      expect(wrapExpressionsInTemplateConditionals(input)).toBe("{{#if }}\nI\n{{#elseif true}}\nP\n{{#else}}\nO\n{{/if}}");
+    });
+  });
+
   describe("extractAndReplacePlaceholders", () => {
     it("should convert {{#if ${{ github.actor }} }} to __GH_AW_GITHUB_ACTOR__", () => {
       const input = "{{#if ${{ github.actor }} }}body{{/if}}";
@@ -49,6 +49,7 @@ const HANDLER_MAP = {
   link_sub_issue: "./link_sub_issue.cjs",
   update_release: "./update_release.cjs",
   create_pull_request_review_comment: "./create_pr_review_comment.cjs",
+  create_pull_request_review_suggestion: "./create_pr_review_suggestion.cjs",
   submit_pull_request_review: "./submit_pr_review.cjs",
   dismiss_pull_request_review: "./dismiss_pull_request_review.cjs",
   reply_to_pull_request_review_comment: "./reply_to_pr_review_comment.cjs",
@@ -68,6 +69,8 @@ const HANDLER_MAP = {
   unassign_from_user: "./unassign_from_user.cjs",
   assign_to_agent: "./assign_to_agent.cjs",
   create_agent_session: "./create_agent_session.cjs",
+  handoff_to_copilot: "./handoff_to_copilot.cjs",
+  schedule_follow_up: "./schedule_follow_up.cjs",
   create_code_scanning_alert: "./create_code_scanning_alert.cjs",
   autofix_code_scanning_alert: "./autofix_code_scanning_alert.cjs",
   create_check_run: "./create_check_run.cjs",
@@ -131,6 +134,7 @@ const THREAT_WARNING_REVIEWABLE_TYPES = new Set([
   "update_discussion",
   "update_pull_request",
   "create_pull_request_review_comment",
+  "create_pull_request_review_suggestion",
   "submit_pull_request_review",
   "reply_to_pull_request_review_comment",
   "create_project_status_update",
@@ -189,6 +193,7 @@ const THREAT_WARNING_ABORT_TYPES = new Set([
   "call_workflow",
   "autofix_code_scanning_alert",
   "create_agent_session",
+  "handoff_to_copilot",
 ]);
 
 /**
@@ -285,7 +290,7 @@ function loadConfig() {
 }
 
 /** @type {Set<string>} Handler types that participate in the PR review buffer */
-const PR_REVIEW_HANDLER_TYPES = new Set(["create_pull_request_review_comment", "submit_pull_request_review"]);
+const PR_REVIEW_HANDLER_TYPES = new Set(["create_pull_request_review_comment", "create_pull_request_review_suggestion", "submit_pull_request_review"]);
 
 /**
  * Wrap a handler so project-safe-output execution can temporarily bind global.github
@@ -564,16 +569,17 @@ function logCreatedItemFromResult(onItemCreated, messageType, result) {
 
 /**
  * Retroactively mark buffered review results as failed when the finalization POST fails.
- * Both submit_pull_request_review and create_pull_request_review_comment return
- * success:true during message processing (they only buffer), so the failure must be
- * reflected here to ensure the Processing Summary shows the correct counts.
+ * The PR review handlers (submit_pull_request_review, create_pull_request_review_comment
+ * and create_pull_request_review_suggestion) return success:true during message processing
+ * (they only buffer), so the failure must be reflected here to ensure the Processing Summary
+ * shows the correct counts.
  *
  * @param {Array<{type: string, success: boolean, error?: string}>} results - Processing results to mutate
  * @param {string} errorMessage - Error message to attach to the rolled-back results
  */
 function rollbackReviewResults(results, errorMessage) {
   for (const r of results) {
-    if ((r.type === "submit_pull_request_review" || r.type === "create_pull_request_review_comment") && r.success === true) {
+    if (PR_REVIEW_HANDLER_TYPES.has(r.type) && r.success === true) {
       r.success = false;
       r.error = `Review finalization failed: ${errorMessage}`;
     }
@@ -595,7 +601,7 @@ function rollbackReviewResultsForPR(results, repo, prNumber, errorMessage) {
   // are nested there. Fall back to top-level for backward compatibility with callers
   // that pass raw handler results directly.
   const prResults = results.filter(
-    r => (r.type === "submit_pull_request_review" || r.type === "create_pull_request_review_comment") && r.success === true && (r.result?.repo ?? r.repo) === repo && (r.result?.pull_request_number ?? r.pull_request_number) === prNumber
+    r => PR_REVIEW_HANDLER_TYPES.has(r.type) && r.success === true && (r.result?.repo ?? r.repo) === repo && (r.result?.pull_request_number ?? r.pull_request_number) === prNumber
   );
   if (prResults.length > 0) {
     for (const r of prResults) {
@@ -607,7 +613,7 @@ function rollbackReviewResultsForPR(results, repo, prNumber, errorMessage) {
     // Fall back to rolling back all buffered review results for this run.
     core.warning(`rollbackReviewResultsForPR: no results matched ${repo}#${prNumber} — falling back to rolling back all review results`);
     for (const r of results) {
-      if ((r.type === "submit_pull_request_review" || r.type === "create_pull_request_review_comment") && r.success === true) {
+      if (PR_REVIEW_HANDLER_TYPES.has(r.type) && r.success === true) {
         r.success = false;
         r.error = `Review finalization failed: ${errorMessage}`;
       }
@@ -617,10 +623,9 @@ function rollbackReviewResultsForPR(results, repo, prNumber, errorMessage) {
 
 /**
  * Mark buffered review results as skipped when the PR is locked and submission was
- * soft-skipped (success:true, skipped:true). Both submit_pull_request_review and
- * create_pull_request_review_comment handlers buffer during message processing, so
- * the skip must be back-propagated here so the Processing Summary reflects the actual
- * outcome (skipped) rather than a misleading success count.
+ * soft-skipped (success:true, skipped:true). The PR review handlers buffer during
+ * message processing, so the skip must be back-propagated here so the Processing Summary
+ * reflects the actual outcome (skipped) rather than a misleading success count.
  *
  * Note: uses `skipReason` (not `reason`) so that the step-summary generator does not
  * treat these entries as delegated-step skips and omit them from the output.
@@ -630,7 +635,7 @@ function rollbackReviewResultsForPR(results, repo, prNumber, errorMessage) {
  */
 function skipReviewResults(results, skipReason) {
   for (const r of results) {
-    if ((r.type === "submit_pull_request_review" || r.type === "create_pull_request_review_comment") && r.success === true) {
+    if (PR_REVIEW_HANDLER_TYPES.has(r.type) && r.success === true) {
       r.skipped = true;
       r.skipReason = skipReason;
     }
@@ -650,7 +655,7 @@ function skipReviewResultsForPR(results, repo, prNumber, skipReason) {
     // processMessages wraps each handler result under r.result, so per-PR identifiers
     // are nested there. Fall back to top-level for backward compatibility with callers
     // that pass raw handler results directly.
-    if ((r.type === "submit_pull_request_review" || r.type === "create_pull_request_review_comment") && r.success === true && (r.result?.repo ?? r.repo) === repo && (r.result?.pull_request_number ?? r.pull_request_number) === prNumber) {
+    if (PR_REVIEW_HANDLER_TYPES.has(r.type) && r.success === true && (r.result?.repo ?? r.repo) === repo && (r.result?.pull_request_number ?? r.pull_request_number) === prNumber) {
       r.skipped = true;
       r.skipReason = skipReason;
     }
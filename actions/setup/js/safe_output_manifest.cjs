@@ -20,6 +20,7 @@ const CREATE_ITEM_TYPES = new Set([
   "create_project",
   "create_project_status_update",
   "create_pull_request_review_comment",
+  "create_pull_request_review_suggestion",
   "submit_pull_request_review",
   "reply_to_pull_request_review_comment",
   "create_code_scanning_alert",
@@ -19,14 +19,17 @@ const { getErrorMessage } = require("./error_helpers.cjs");
 const { ERR_CONFIG, ERR_SYSTEM, ERR_VALIDATION } = require("./error_codes.cjs");
 const { findRepoCheckout } = require("./find_repo_checkout.cjs");
 const { resolveTargetRepoConfig, resolveAndValidateRepo } = require("./repo_helpers.cjs");
+const { getGitHubServerUrl } = require("./get_repository_url.cjs");
 const { getOrGenerateTemporaryId } = require("./temporary_id.cjs");
 const { parseAllowedExtensionsEnv } = require("./allowed_extensions_helpers.cjs");
 const { sanitizeTitle, applyTitlePrefix } = require("./sanitize_title.cjs");
 const { parseDeduplicateByTitle, normalizeTitleForDedup, findDuplicateByTitle } = require("./issue_title_dedup.cjs");
 const { validateCreatePullRequestIntent, validatePushToPullRequestBranchIntent, validateCreateIssueIntent, validateAddCommentIntent } = require("./intent_probe.cjs");
 const { globPatternToRegex } = require("./glob_pattern_helpers.cjs");
+const { parseAllowedBaseBranches, isBaseBranchAllowed } = require("./create_pull_request_helpers.cjs");
 const { resolveInvocationContext } = require("./invocation_context_helpers.cjs");
 const { lstatGuard } = require("./symlink_guard.cjs");
+const { findOutputLanguageMismatch } = require("./output_language.cjs");
 
 /** PR event names used for target:triggering context validation across all safe-output handlers. */
 const PR_EVENT_NAMES = new Set(["pull_request", "pull_request_target", "pull_request_review", "pull_request_review_comment"]);
@@ -280,6 +283,53 @@ function createHandlers(server, appendSafeOutput, config = {}) {
     }
   }
 
+  /**
+   * Expected language of user-facing output (from output-language frontmatter).
+   * @type {string}
+   */
+  const outputLanguage = typeof config.output_language === "string" ? config.output_language : "";
+
+  /**
+   * Safe-output types whose last call was rejected for being in the wrong language.
+   * Each type gets one retry: the next call of that type is accepted even if it
+   * still does not match, so a misdetection cannot block the run.
+   * @type {Set<string>}
+   */
+  const languageRetryTypes = new Set();
+
+  /**
+   * Enforce the configured output language at invocation time.
+   * Throws a JSON-RPC -32602 error asking the agent to rewrite the output the
+   * first time a type is written in another language; the retry is accepted.
+   * @param {Record<string, any>} entry
+   */
+  function enforceOutputLanguage(entry) {
+    if (!outputLanguage) return;
+    const type = entry?.type;
+    const detected = findOutputLanguageMismatch(entry, outputLanguage);
+    if (!detected) {
+      if (type) languageRetryTypes.delete(type);
+      return;
+    }
+    if (type && languageRetryTypes.has(type)) {
+      languageRetryTypes.delete(type);
+      server.debug(`Accepting ${type} retry despite language mismatch: expected ${outputLanguage}, detected ${detected}`);
+      return;
+    }
+    if (type) languageRetryTypes.add(type);
+    throw {
+      code: -32602,
+      message: `${ERR_VALIDATION}: ${type} output must be written in language '${outputLanguage}' but appears to be '${detected}'`,
+      data: {
+        constraint: "output-language",
+        type,
+        expected: outputLanguage,
+        detected,
+        guidance: `Rewrite the title and body in language '${outputLanguage}' and call ${type} again. ` + `Keep code, commands, file paths and quoted text unchanged.`,
+      },
+    };
+  }
+
   /**
    * Append a safe-output entry after enforcing the per-type max count.
    * Increments the session counter only after a successful write, mirroring the
@@ -291,6 +341,7 @@ function createHandlers(server, appendSafeOutput, config = {}) {
   const appendSafeOutputCounted = entry => {
     const type = entry?.type;
     if (type) enforcePerTypeMax(type);
+    enforceOutputLanguage(entry);
     appendSafeOutput(entry);
     if (type) operationCounts.set(type, (operationCounts.get(type) || 0) + 1);
   };
@@ -502,13 +553,13 @@ function createHandlers(server, appendSafeOutput, config = {}) {
     // Generate target filename as sha + extension (lowercased)
     const targetFileName = (sha + fileExt).toLowerCase();
 
-    const githubServer = process.env.GITHUB_SERVER_URL || "https://github.com";
+    const githubServer = getGitHubServerUrl();
     const repo = process.env.GITHUB_REPOSITORY || "owner/repo";
     let url;
     try {
       const serverHostname = new URL(githubServer).hostname;
       if (serverHostname === "github.com") {
-        url = `https://github.com/${repo}/blob/${normalizedBranchName}/${targetFileName}?raw=true`;
+        url = `${githubServer}/${repo}/blob/${normalizedBranchName}/${targetFileName}?raw=true`;
       } else {
         // GitHub Enterprise Server - raw content is served from the same host with /raw/ path
         url = `${githubServer}/${repo}/raw/${normalizedBranchName}/${targetFileName}`;
@@ -669,6 +720,16 @@ function createHandlers(server, appendSafeOutput, config = {}) {
       }
     }
 
+    // An agent-provided base (for example a backport target such as release/1.2)
+    // replaces the default base when it matches allowed-base-branches, so the patch
+    // only contains the commits on top of that branch. Anything else is left to the
+    // safe_outputs job, which rejects disallowed overrides.
+    const requestedBase = typeof entry.base === "string" ? entry.base.trim() : "";
+    if (requestedBase && requestedBase !== baseBranch && isBaseBranchAllowed(requestedBase, parseAllowedBaseBranches(prConfig.allowed_base_branches))) {
+      server.debug(`Using agent-provided base branch for create_pull_request: ${requestedBase}`);
+      baseBranch = requestedBase;
+    }
+
     // Store the resolved base branch in the entry so the apply-time checkout step
     // can use it directly instead of inferring from event context.
     // This makes the safe output "self-describing" and fixes checkout for events
@@ -1930,7 +1991,8 @@ function createHandlers(server, appendSafeOutput, config = {}) {
 
   /**
    * Session-scoped counter for buffered inline review comments.
-   * Incremented by createPullRequestReviewCommentHandler, read by submitPullRequestReviewHandler
+   * Incremented by createPullRequestReviewCommentHandler and createPullRequestReviewSuggestionHandler,
+   * read by submitPullRequestReviewHandler
    * to guard against empty review submissions at the MCP server phase.
    */
   let inlineReviewCommentCount = 0;
@@ -1953,6 +2015,19 @@ function createHandlers(server, appendSafeOutput, config = {}) {
     return result;
   };
 
+  /**
+   * Handler for create_pull_request_review_suggestion tool (MCP server phase).
+   * Suggestions are buffered as inline review comments, so they count towards the
+   * same session-scoped counter as createPullRequestReviewCommentHandler.
+   */
+  const createPullRequestReviewSuggestionHandler = args => {
+    const result = defaultHandler("create_pull_request_review_suggestion")(args);
+    if (!result?.isError) {
+      inlineReviewCommentCount++;
+    }
+    return result;
+  };
+
   /**
    * Handler for submit_pull_request_review tool (MCP server phase).
    * Validates the review before writing it to the NDJSON output so that the agent
@@ -2262,6 +2337,7 @@ function createHandlers(server, appendSafeOutput, config = {}) {
     createProjectHandler,
     addCommentHandler,
     createPullRequestReviewCommentHandler,
+    createPullRequestReviewSuggestionHandler,
     submitPullRequestReviewHandler,
     dismissPullRequestReviewHandler,
     updateIssueHandler,
@@ -265,6 +265,21 @@ describe("safe_outputs_handlers", () => {
       expect(entry.url).not.toContain("raw.githubusercontent.com");
     });
 
+    it("should not double the slash after a GitHub Enterprise Server URL with a trailing slash", () => {
+      process.env.GH_AW_ASSETS_BRANCH = "test-branch";
+      process.env.GITHUB_SERVER_URL = "https://github.example.com/";
+      process.env.GITHUB_REPOSITORY = "myorg/myrepo";
+
+      const testFile = path.join(testWorkspaceDir, "test3.png");
+      fs.writeFileSync(testFile, "test content");
+
+      handlers = createHandlers(mockServer, mockAppendSafeOutput);
+      handlers.uploadAssetHandler({ path: testFile });
+
+      const entry = mockAppendSafeOutput.mock.calls[0][0];
+      expect(entry.url).toMatch(/^https:\/\/github\.example\.com\/myorg\/myrepo\/raw\/test-branch\//);
+    });
+
     it("should validate and process valid asset upload", () => {
       process.env.GH_AW_ASSETS_BRANCH = "test-branch";
 
@@ -1219,6 +1234,58 @@ describe("safe_outputs_handlers", () => {
       }
     });
 
+    it("should use an agent-provided base that matches allowed-base-branches (allow-empty mode)", async () => {
+      // Backport flows target a release branch chosen by the agent; the patch and the
+      // apply-time checkout must both use that branch instead of the default base.
+      handlers = createHandlers(mockServer, mockAppendSafeOutput, {
+        create_pull_request: {
+          allow_empty: true,
+          base_branch: "main",
+          allowed_base_branches: ["release/*"],
+        },
+      });
+
+      const result = await handlers.createPullRequestHandler({
+        branch: "backport/release-1.2/abc1234",
+        base: "release/1.2",
+        title: "Backport fix",
+        body: "Backport description",
+      });
+
+      expect(result.isError).toBeUndefined();
+      expect(mockAppendSafeOutput).toHaveBeenCalledWith(
+        expect.objectContaining({
+          type: "create_pull_request",
+          base_branch: "release/1.2",
+        })
+      );
+    });
+
+    it("should keep the default base when the agent-provided base is not allowed (allow-empty mode)", async () => {
+      handlers = createHandlers(mockServer, mockAppendSafeOutput, {
+        create_pull_request: {
+          allow_empty: true,
+          base_branch: "main",
+          allowed_base_branches: ["release/*"],
+        },
+      });
+
+      const result = await handlers.createPullRequestHandler({
+        branch: "feature/my-work",
+        base: "hotfix/1.2",
+        title: "Test PR",
+        body: "Test description",
+      });
+
+      expect(result.isError).toBeUndefined();
+      expect(mockAppendSafeOutput).toHaveBeenCalledWith(
+        expect.objectContaining({
+          type: "create_pull_request",
+          base_branch: "main",
+        })
+      );
+    });
+
     it("should use side-repo origin/HEAD base branch so patch includes branch commits since main", async () => {
       const { targetRepoDir } = createSideRepoOnReleaseBranchWithLocalCommit();
 
@@ -2811,6 +2878,15 @@ describe("safe_outputs_handlers", () => {
     });
   });
 
+  describe("createPullRequestReviewSuggestionHandler", () => {
+    it("should count suggestions as inline comments for an empty-body submit", () => {
+      const result = handlers.createPullRequestReviewSuggestionHandler({ path: "src/foo.js", line: 5, suggestion: "const x = 1;" });
+      expect(JSON.parse(result.content[0].text).result).toBe("success");
+      expect(mockAppendSafeOutput).toHaveBeenCalledWith(expect.objectContaining({ type: "create_pull_request_review_suggestion", path: "src/foo.js" }));
+      expect(() => handlers.submitPullRequestReviewHandler({ event: "COMMENT" })).not.toThrow();
+    });
+  });
+
   describe("createPullRequestReviewCommentHandler", () => {
     it("should write entry and return success", () => {
       const result = handlers.createPullRequestReviewCommentHandler({ path: "src/foo.js", line: 5, body: "Consider renaming." });
@@ -3429,3 +3505,47 @@ describe("hasUpdatePullRequestFields", () => {
     expect(hasUpdatePullRequestFields({ title: "" })).toBe(true);
   });
 });
+
+describe("output-language enforcement", () => {
+  let mockServer;
+  let mockAppendSafeOutput;
+
+  const englishBody = "This pull request fixes the failing test and updates the documentation for the new flag.";
+  const germanBody = "Dieser Pull Request behebt den fehlschlagenden Test und aktualisiert die Dokumentation für das neue Flag.";
+
+  beforeEach(() => {
+    vi.clearAllMocks();
+    mockServer = { debug: vi.fn() };
+    mockAppendSafeOutput = vi.fn();
+  });
+
+  it("accepts output written in the configured language", () => {
+    const h = createHandlers(mockServer, mockAppendSafeOutput, { create_discussion: {}, output_language: "de" });
+
+    h.defaultHandler("create_discussion")({ title: "Testergebnisse", body: germanBody });
+    expect(mockAppendSafeOutput).toHaveBeenCalledTimes(1);
+  });
+
+  it("rejects the first mismatch and accepts the retry", () => {
+    const h = createHandlers(mockServer, mockAppendSafeOutput, { create_discussion: {}, output_language: "de" });
+
+    expect(() => h.defaultHandler("create_discussion")({ title: "Test results", body: englishBody })).toThrow(
+      expect.objectContaining({
+        code: -32602,
+        data: expect.objectContaining({ constraint: "output-language", expected: "de", detected: "en" }),
+      })
+    );
+    expect(mockAppendSafeOutput).not.toHaveBeenCalled();
+
+    h.defaultHandler("create_discussion")({ title: "Test results", body: englishBody });
+    expect(mockAppendSafeOutput).toHaveBeenCalledTimes(1);
+    expect(mockServer.debug).toHaveBeenCalledWith(expect.stringContaining("language mismatch"));
+  });
+
+  it("does not check the language when output-language is not configured", () => {
+    const h = createHandlers(mockServer, mockAppendSafeOutput, { create_discussion: {} });
+
+    h.defaultHandler("create_discussion")({ title: "Test results", body: englishBody });
+    expect(mockAppendSafeOutput).toHaveBeenCalledTimes(1);
+  });
+});
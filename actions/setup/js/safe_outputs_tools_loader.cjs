@@ -138,6 +138,7 @@ function attachHandlers(tools, handlers, logger) {
     create_project: handlers.createProjectHandler,
     add_comment: handlers.addCommentHandler,
     create_pull_request_review_comment: handlers.createPullRequestReviewCommentHandler,
+    create_pull_request_review_suggestion: handlers.createPullRequestReviewSuggestionHandler,
     submit_pull_request_review: handlers.submitPullRequestReviewHandler,
     dismiss_pull_request_review: handlers.dismissPullRequestReviewHandler,
     update_issue: handlers.updateIssueHandler,
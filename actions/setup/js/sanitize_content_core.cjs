@@ -8,6 +8,7 @@
 
 const { isRepoAllowed } = require("./repo_helpers.cjs");
 const { resolveMatchedCommand } = require("./slash_command_matcher.cjs");
+const { TMP_GH_AW_PATH } = require("./constants.cjs");
 
 const SAFE_OUTPUTS_URLS_ENV = "GH_AW_SAFE_OUTPUTS_URLS";
 const SAFE_OUTPUTS_URLS_ALLOWED_ONLY = "allowed-only";
@@ -56,7 +57,7 @@ function writeRedactedDomainsLog(filePath) {
 
   const fs = require("fs");
   const path = require("path");
-  const targetPath = filePath || "/tmp/gh-aw/redacted-urls.log";
+  const targetPath = filePath || `${TMP_GH_AW_PATH}/redacted-urls.log`;
 
   // Ensure directory exists
   const dir = path.dirname(targetPath);
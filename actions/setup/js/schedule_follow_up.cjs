@@ -0,0 +1,102 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+/**
+ * @typedef {import('./types/handler-factory').HandlerFactoryFunction} HandlerFactoryFunction
+ */
+
+const { logStagedPreviewInfo } = require("./staged_preview.cjs");
+const { isStagedMode } = require("./safe_output_helpers.cjs");
+const { MIN_FOLLOW_UP_DELAY_MS, parseFollowUpDelay, readFollowUpQueue, writeFollowUpQueue } = require("./follow_up_queue.cjs");
+
+/** @type {string} Safe output type handled by this module */
+const HANDLER_TYPE = "schedule_follow_up";
+
+/** Default furthest a follow-up can be scheduled, in hours (7 days) */
+const DEFAULT_MAX_HORIZON_HOURS = 7 * 24;
+
+/** Default number of follow-ups that may be pending at the same time */
+const DEFAULT_MAX_PENDING = 5;
+
+/**
+ * Main handler factory for schedule_follow_up.
+ * Each message adds a one-shot follow-up run of this workflow to the follow-up queue.
+ * The queue is saved to the actions cache by the step that follows the handler manager
+ * and drained by the workflow's polling schedule.
+ * @type {HandlerFactoryFunction}
+ */
+async function main(config = {}) {
+  const maxCount = config.max || 1;
+  const maxHorizonHours = config.max_horizon_hours || DEFAULT_MAX_HORIZON_HOURS;
+  const maxPending = config.max_pending || DEFAULT_MAX_PENDING;
+  const isStaged = isStagedMode(config);
+  const runUrl = `${context.serverUrl}/${context.repo.owner}/${context.repo.repo}/actions/runs/${context.runId}`;
+  const triggeringItem = context.payload?.issue?.number ?? context.payload?.pull_request?.number;
+
+  core.info(`Schedule follow-up configuration: max=${maxCount}, max_horizon_hours=${maxHorizonHours}, max_pending=${maxPending}`);
+
+  let processedCount = 0;
+
+  /**
+   * Message handler function that processes a single schedule_follow_up message
+   * @param {Object} message - The schedule_follow_up message to process
+   * @param {Object} resolvedTemporaryIds - Map of temporary IDs to {repo, number} (unused)
+   * @returns {Promise<Object>} Result with success/error status and the follow-up details
+   */
+  return async function handleScheduleFollowUp(message, resolvedTemporaryIds) {
+    if (processedCount >= maxCount) {
+      core.warning(`Skipping ${HANDLER_TYPE}: max count of ${maxCount} reached`);
+      return { success: false, error: `Max count of ${maxCount} reached` };
+    }
+
+    const reason = String(message.reason ?? "").trim();
+    if (!reason) {
+      return { success: false, error: "A follow-up requires a reason" };
+    }
+    const delayMs = parseFollowUpDelay(message.delay);
+    if (delayMs === null) {
+      return { success: false, error: `Invalid delay '${message.delay}': use a duration such as '12h', '3d' or '1w'` };
+    }
+    if (delayMs < MIN_FOLLOW_UP_DELAY_MS) {
+      return { success: false, error: `Delay '${message.delay}' is shorter than the minimum of 1h` };
+    }
+    if (delayMs > maxHorizonHours * 60 * 60 * 1000) {
+      return { success: false, error: `Delay '${message.delay}' exceeds the maximum horizon of ${maxHorizonHours}h` };
+    }
+
+    const itemNumber = message.item_number !== undefined && message.item_number !== null && message.item_number !== "" ? Number(message.item_number) : triggeringItem;
+    if (itemNumber !== undefined && (!Number.isInteger(itemNumber) || itemNumber <= 0)) {
+      return { success: false, error: `Invalid item_number '${message.item_number}'` };
+    }
+
+    const queue = readFollowUpQueue();
+    const now = new Date();
+    if (queue.length >= maxPending) {
+      core.warning(`Skipping ${HANDLER_TYPE}: ${queue.length} follow-up(s) already pending (max-pending: ${maxPending})`);
+      return { success: false, error: `${queue.length} follow-up(s) already pending, the maximum is ${maxPending}` };
+    }
+
+    processedCount++;
+    /** @type {import('./follow_up_queue.cjs').FollowUp} */
+    const followUp = {
+      id: `${context.runId}-${processedCount}`,
+      due_at: new Date(now.getTime() + delayMs).toISOString(),
+      scheduled_at: now.toISOString(),
+      reason,
+      ...(itemNumber ? { item_number: itemNumber } : {}),
+      run_url: runUrl,
+    };
+
+    if (isStaged) {
+      logStagedPreviewInfo(`Would schedule a follow-up run due ${followUp.due_at}: ${reason}`);
+      return { success: true, staged: true, due_at: followUp.due_at };
+    }
+
+    writeFollowUpQueue([...queue, followUp]);
+    core.setOutput("follow_ups_scheduled", String(processedCount));
+    core.info(`✓ Scheduled follow-up ${followUp.id} due ${followUp.due_at}${itemNumber ? ` about #${itemNumber}` : ""}`);
+    return { success: true, follow_up_id: followUp.id, due_at: followUp.due_at };
+  };
+}
+
+module.exports = { main };
@@ -0,0 +1,192 @@
+import { describe, it, expect, beforeEach, afterEach, vi } from "vitest";
+import fs from "fs";
+import os from "os";
+import path from "path";
+
+const HOUR_MS = 60 * 60 * 1000;
+
+const mockCore = {
+  debug: vi.fn(),
+  info: vi.fn(),
+  warning: vi.fn(),
+  error: vi.fn(),
+  setOutput: vi.fn(),
+  summary: { addRaw: vi.fn().mockReturnThis(), write: vi.fn().mockResolvedValue() },
+};
+
+const mockContext = {
+  runId: 12345,
+  serverUrl: "https://github.com",
+  eventName: "issues",
+  repo: { owner: "testowner", repo: "testrepo" },
+  payload: { issue: { number: 42 } },
+};
+
+global.core = mockCore;
+global.context = mockContext;
+
+const { FOLLOW_UP_QUEUE_FILE, parseFollowUpDelay, readFollowUpQueue, writeFollowUpQueue, splitDueFollowUps, formatFollowUps } = require("./follow_up_queue.cjs");
+
+describe("follow_up_queue.cjs", () => {
+  let tempDir;
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), "follow-ups-"));
+  });
+
+  afterEach(() => {
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it("should parse delays in weeks, days, hours and minutes", () => {
+    expect(parseFollowUpDelay("90m")).toBe(90 * 60 * 1000);
+    expect(parseFollowUpDelay("12h")).toBe(12 * HOUR_MS);
+    expect(parseFollowUpDelay("3d")).toBe(72 * HOUR_MS);
+    expect(parseFollowUpDelay("1w")).toBe(168 * HOUR_MS);
+    expect(parseFollowUpDelay("1d12h")).toBe(36 * HOUR_MS);
+    expect(parseFollowUpDelay(" 2D ")).toBe(48 * HOUR_MS);
+  });
+
+  it("should reject values that are not delays", () => {
+    expect(parseFollowUpDelay("")).toBeNull();
+    expect(parseFollowUpDelay("3 days")).toBeNull();
+    expect(parseFollowUpDelay("12h1d")).toBeNull();
+    expect(parseFollowUpDelay("2026-10-20")).toBeNull();
+    expect(parseFollowUpDelay(undefined)).toBeNull();
+  });
+
+  it("should round-trip the queue ordered by due time and treat a missing file as empty", () => {
+    const file = path.join(tempDir, "queue.json");
+    expect(readFollowUpQueue(file)).toEqual([]);
+
+    writeFollowUpQueue(
+      [
+        { id: "1-1", due_at: "2026-10-20T00:00:00.000Z", scheduled_at: "2026-10-15T00:00:00.000Z", reason: "later" },
+        { id: "2-1", due_at: "2026-10-18T00:00:00.000Z", scheduled_at: "2026-10-15T00:00:00.000Z", reason: "sooner" },
+      ],
+      file
+    );
+
+    expect(readFollowUpQueue(file).map(followUp => followUp.id)).toEqual(["2-1", "1-1"]);
+  });
+
+  it("should split due and pending follow-ups and describe the due ones", () => {
+    const followUps = [
+      { id: "1-1", due_at: "2026-10-15T11:00:00.000Z", scheduled_at: "2026-10-12T11:00:00.000Z", reason: "Check whether the fix landed", item_number: 42, run_url: "https://github.com/o/r/actions/runs/1" },
+      { id: "2-1", due_at: "2026-10-16T11:00:00.000Z", scheduled_at: "2026-10-15T11:00:00.000Z", reason: "Not yet" },
+    ];
+
+    const { due, pending } = splitDueFollowUps(followUps, new Date("2026-10-15T12:00:00Z"));
+
+    expect(due.map(followUp => followUp.id)).toEqual(["1-1"]);
+    expect(pending.map(followUp => followUp.id)).toEqual(["2-1"]);
+    expect(formatFollowUps(due)).toBe("- Scheduled by https://github.com/o/r/actions/runs/1 on 2026-10-12T11:00:00.000Z about #42, due 2026-10-15T11:00:00.000Z: Check whether the fix landed");
+  });
+});
+
+describe("schedule_follow_up.cjs", () => {
+  beforeEach(() => {
+    vi.clearAllMocks();
+    fs.rmSync(FOLLOW_UP_QUEUE_FILE, { force: true });
+  });
+
+  afterEach(() => {
+    fs.rmSync(FOLLOW_UP_QUEUE_FILE, { force: true });
+  });
+
+  it("should add the follow-up to the queue, defaulting to the triggering item", async () => {
+    const { main } = require("./schedule_follow_up.cjs");
+    const handler = await main({ max: 2 });
+
+    const before = Date.now();
+    const result = await handler({ type: "schedule_follow_up", delay: "3d", reason: "Check whether the fix landed" }, {});
+
+    expect(result.success).toBe(true);
+    const queue = readFollowUpQueue();
+    expect(queue).toHaveLength(1);
+    expect(queue[0].id).toBe("12345-1");
+    expect(queue[0].item_number).toBe(42);
+    expect(queue[0].reason).toBe("Check whether the fix landed");
+    expect(queue[0].run_url).toBe("https://github.com/testowner/testrepo/actions/runs/12345");
+    expect(Date.parse(queue[0].due_at) - before).toBeGreaterThanOrEqual(72 * HOUR_MS);
+    expect(mockCore.setOutput).toHaveBeenCalledWith("follow_ups_scheduled", "1");
+  });
+
+  it("should reject delays outside the bounds and invalid items", async () => {
+    const { main } = require("./schedule_follow_up.cjs");
+    const handler = await main({ max: 5, max_horizon_hours: 48 });
+
+    expect((await handler({ delay: "30m", reason: "too soon" }, {})).error).toContain("minimum of 1h");
+    expect((await handler({ delay: "3d", reason: "too late" }, {})).error).toContain("maximum horizon of 48h");
+    expect((await handler({ delay: "soon", reason: "not a delay" }, {})).error).toContain("Invalid delay");
+    expect((await handler({ delay: "1d" }, {})).error).toContain("requires a reason");
+    expect((await handler({ delay: "1d", reason: "bad item", item_number: "abc" }, {})).error).toContain("Invalid item_number");
+    expect(readFollowUpQueue()).toEqual([]);
+  });
+
+  it("should enforce max and max-pending", async () => {
+    writeFollowUpQueue([{ id: "1-1", due_at: new Date(Date.now() + HOUR_MS).toISOString(), scheduled_at: new Date().toISOString(), reason: "pending" }]);
+    const { main } = require("./schedule_follow_up.cjs");
+
+    const limited = await main({ max: 1, max_pending: 1 });
+    expect((await limited({ delay: "1d", reason: "one too many" }, {})).error).toContain("already pending");
+
+    fs.rmSync(FOLLOW_UP_QUEUE_FILE, { force: true });
+    const handler = await main({ max: 1 });
+    expect((await handler({ delay: "1d", reason: "first" }, {})).success).toBe(true);
+    expect((await handler({ delay: "1d", reason: "second" }, {})).error).toContain("Max count of 1 reached");
+  });
+
+  it("should not write the queue in staged mode", async () => {
+    const { main } = require("./schedule_follow_up.cjs");
+    const handler = await main({ staged: true });
+
+    const result = await handler({ delay: "1d", reason: "preview" }, {});
+
+    expect(result.success).toBe(true);
+    expect(result.staged).toBe(true);
+    expect(fs.existsSync(FOLLOW_UP_QUEUE_FILE)).toBe(false);
+  });
+});
+
+describe("check_follow_ups.cjs and take_due_follow_ups.cjs", () => {
+  let outputs;
+
+  beforeEach(() => {
+    outputs = {};
+    mockCore.setOutput = vi.fn((name, value) => {
+      outputs[name] = value;
+    });
+    fs.rmSync(FOLLOW_UP_QUEUE_FILE, { force: true });
+  });
+
+  afterEach(() => {
+    mockContext.eventName = "issues";
+    fs.rmSync(FOLLOW_UP_QUEUE_FILE, { force: true });
+  });
+
+  it("should let non-scheduled runs through", async () => {
+    const { main } = require("./check_follow_ups.cjs");
+    await main();
+    expect(outputs.follow_ups_ok).toBe("true");
+  });
+
+  it("should only let scheduled runs through when a follow-up is due, then take it out of the queue", async () => {
+    mockContext.eventName = "schedule";
+    const { main: check } = require("./check_follow_ups.cjs");
+    const { main: take } = require("./take_due_follow_ups.cjs");
+
+    writeFollowUpQueue([{ id: "2-1", due_at: new Date(Date.now() + HOUR_MS).toISOString(), scheduled_at: new Date().toISOString(), reason: "later" }]);
+    await check();
+    expect(outputs.follow_ups_ok).toBe("false");
+
+    writeFollowUpQueue([...readFollowUpQueue(), { id: "1-1", due_at: new Date(Date.now() - HOUR_MS).toISOString(), scheduled_at: new Date().toISOString(), reason: "now", item_number: 7 }]);
+    await check();
+    expect(outputs.follow_ups_ok).toBe("true");
+
+    await take();
+    expect(outputs.taken).toBe("1");
+    expect(outputs.due).toContain("about #7");
+    expect(readFollowUpQueue().map(followUp => followUp.id)).toEqual(["2-1"]);
+  });
+});
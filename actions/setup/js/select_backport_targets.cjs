@@ -0,0 +1,83 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+/**
+ * Select backport target branches from the labels of the triggering pull request.
+ *
+ * A label such as "backport release/1.2" (with the default "backport " prefix) selects
+ * release/1.2 as a target. Only targets matching
+ * safe-outputs.create-pull-request.allowed-base-branches are kept, so a label can never
+ * point the agent at a branch the safe_outputs job would refuse.
+ *
+ * Outputs:
+ * - targets: comma-separated list of selected target branches (empty when none)
+ */
+
+const { parseAllowedBaseBranches, isBaseBranchAllowed } = require("./create_pull_request_helpers.cjs");
+const { normalizeBranchName } = require("./normalize_branch_name.cjs");
+
+const DEFAULT_LABEL_PREFIX = "backport ";
+
+/**
+ * Collect label names from the triggering pull request. Comments on pull requests
+ * carry the labels on the issue object.
+ *
+ * @param {any} payload - The webhook event payload
+ * @returns {string[]}
+ */
+function getTriggeringLabels(payload) {
+  const labels = payload?.pull_request?.labels ?? payload?.issue?.labels ?? [];
+  if (!Array.isArray(labels)) {
+    return [];
+  }
+  return labels.map(label => (typeof label === "string" ? label : label?.name)).filter(name => typeof name === "string" && name !== "");
+}
+
+/**
+ * Map labels to backport target branches.
+ *
+ * @param {string[]} labels - Label names on the pull request
+ * @param {string} prefix - Label prefix that marks a backport request
+ * @param {Set<string>} allowedBaseBranches - Allowed base branch names and globs
+ * @returns {{targets: string[], rejected: string[]}}
+ */
+function selectBackportTargets(labels, prefix, allowedBaseBranches) {
+  const targets = [];
+  const rejected = [];
+  for (const label of labels) {
+    if (!label.startsWith(prefix)) {
+      continue;
+    }
+    const branch = label.slice(prefix.length).trim();
+    if (!branch || targets.includes(branch)) {
+      continue;
+    }
+    if (normalizeBranchName(branch) !== branch || !isBaseBranchAllowed(branch, allowedBaseBranches)) {
+      rejected.push(branch);
+      continue;
+    }
+    targets.push(branch);
+  }
+  return { targets, rejected };
+}
+
+async function main() {
+  const prefix = process.env.GH_AW_BACKPORT_LABEL_PREFIX || DEFAULT_LABEL_PREFIX;
+  const allowedBaseBranches = parseAllowedBaseBranches(process.env.GH_AW_BACKPORT_ALLOWED_BRANCHES || "");
+
+  const labels = getTriggeringLabels(context.payload);
+  const { targets, rejected } = selectBackportTargets(labels, prefix, allowedBaseBranches);
+
+  for (const branch of rejected) {
+    core.warning(`Ignoring backport label for '${branch}': not a valid branch name matching allowed-base-branches (${Array.from(allowedBaseBranches).join(", ")})`);
+  }
+
+  if (targets.length === 0) {
+    core.info(`No backport targets selected (label prefix: ${JSON.stringify(prefix)})`);
+  } else {
+    core.info(`Selected backport targets: ${targets.join(", ")}`);
+  }
+  core.setOutput("targets", targets.join(","));
+}
+
+module.exports = { main, getTriggeringLabels, selectBackportTargets };
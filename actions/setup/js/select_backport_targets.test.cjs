@@ -0,0 +1,92 @@
+// @ts-check
+import { describe, it, expect, beforeEach, afterEach, vi } from "vitest";
+
+describe("select_backport_targets", () => {
+  let mockCore;
+  let selectBackportTargetsModule;
+
+  beforeEach(async () => {
+    mockCore = {
+      info: vi.fn(),
+      warning: vi.fn(),
+      setOutput: vi.fn(),
+    };
+    global.core = mockCore;
+    global.context = { payload: {} };
+
+    delete process.env.GH_AW_BACKPORT_LABEL_PREFIX;
+    delete process.env.GH_AW_BACKPORT_ALLOWED_BRANCHES;
+
+    vi.resetModules();
+    selectBackportTargetsModule = await import("./select_backport_targets.cjs");
+  });
+
+  afterEach(() => {
+    delete global.core;
+    delete global.context;
+    vi.clearAllMocks();
+  });
+
+  describe("getTriggeringLabels", () => {
+    it("reads labels from pull_request events", () => {
+      const labels = selectBackportTargetsModule.getTriggeringLabels({
+        pull_request: { labels: [{ name: "bug" }, { name: "backport release/1.2" }] },
+      });
+      expect(labels).toEqual(["bug", "backport release/1.2"]);
+    });
+
+    it("reads labels from comments on pull requests", () => {
+      const labels = selectBackportTargetsModule.getTriggeringLabels({
+        issue: { pull_request: {}, labels: [{ name: "backport release/2.0" }] },
+      });
+      expect(labels).toEqual(["backport release/2.0"]);
+    });
+
+    it("returns an empty list when there are no labels", () => {
+      expect(selectBackportTargetsModule.getTriggeringLabels({})).toEqual([]);
+    });
+  });
+
+  describe("selectBackportTargets", () => {
+    it("keeps prefixed labels that match allowed-base-branches", () => {
+      const result = selectBackportTargetsModule.selectBackportTargets(["bug", "backport release/1.2", "backport release/1.2", "backport main"], "backport ", new Set(["release/*"]));
+      expect(result.targets).toEqual(["release/1.2"]);
+      expect(result.rejected).toEqual(["main"]);
+    });
+
+    it("rejects branch names with invalid characters", () => {
+      const result = selectBackportTargetsModule.selectBackportTargets(["backport release/1.2;rm -rf"], "backport ", new Set(["*"]));
+      expect(result.targets).toEqual([]);
+      expect(result.rejected).toEqual(["release/1.2;rm -rf"]);
+    });
+
+    it("supports a custom prefix", () => {
+      const result = selectBackportTargetsModule.selectBackportTargets(["backport release/1.2", "backport-to:release/1.3"], "backport-to:", new Set(["release/*"]));
+      expect(result.targets).toEqual(["release/1.3"]);
+    });
+  });
+
+  describe("main", () => {
+    it("sets the targets output from the pull request labels", async () => {
+      process.env.GH_AW_BACKPORT_ALLOWED_BRANCHES = "release/*,hotfix/*";
+      global.context = {
+        payload: { pull_request: { labels: [{ name: "backport release/1.2" }, { name: "backport hotfix/9" }] } },
+      };
+
+      await selectBackportTargetsModule.main();
+
+      expect(mockCore.setOutput).toHaveBeenCalledWith("targets", "release/1.2,hotfix/9");
+      expect(mockCore.warning).not.toHaveBeenCalled();
+    });
+
+    it("warns about disallowed targets and sets an empty output", async () => {
+      process.env.GH_AW_BACKPORT_ALLOWED_BRANCHES = "release/*";
+      global.context = { payload: { pull_request: { labels: [{ name: "backport main" }] } } };
+
+      await selectBackportTargetsModule.main();
+
+      expect(mockCore.warning).toHaveBeenCalledWith(expect.stringContaining("'main'"));
+      expect(mockCore.setOutput).toHaveBeenCalledWith("targets", "");
+    });
+  });
+});
@@ -0,0 +1,170 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+const { getErrorMessage } = require("./error_helpers.cjs");
+const { sleep } = require("./error_recovery.cjs");
+
+/**
+ * Prefix of the activation step that records the slot a run holds. The compiler emits
+ * "Claim concurrency pool slot <pool>/<slot>" right after the selection step, so the slot
+ * a run holds is visible to other pool members through the jobs API.
+ */
+const CLAIM_STEP_PREFIX = "Claim concurrency pool slot ";
+const AGENT_JOB_NAME = "agent";
+const POLL_INTERVAL_MS = 30 * 1000;
+const MAX_WAIT_MS = 20 * 60 * 1000;
+const MAX_RUNS_PER_POLL = 100;
+
+/**
+ * Returns the slot claimed by a job, or null when the job has no successful claim step
+ * for the given pool.
+ *
+ * @param {any} job
+ * @param {string} poolName
+ * @returns {number | null}
+ */
+function getClaimedSlot(job, poolName) {
+  const prefix = `${CLAIM_STEP_PREFIX}${poolName}/`;
+  for (const step of job.steps || []) {
+    if (typeof step.name !== "string" || !step.name.startsWith(prefix) || step.conclusion !== "success") {
+      continue;
+    }
+    const slot = Number.parseInt(step.name.slice(prefix.length), 10);
+    if (!Number.isNaN(slot)) {
+      return slot;
+    }
+  }
+  return null;
+}
+
+/**
+ * Reports whether the agent job of a run has finished. Jobs of reusable workflows are
+ * named "<caller> / agent".
+ *
+ * @param {any[]} jobs
+ * @returns {boolean}
+ */
+function isAgentJobDone(jobs) {
+  const agentJob = jobs.find(job => job.name === AGENT_JOB_NAME || (typeof job.name === "string" && job.name.endsWith(` / ${AGENT_JOB_NAME}`)));
+  return !!agentJob && agentJob.status === "completed";
+}
+
+/**
+ * Lists the slots held by other in-progress runs of the pool. A run holds its slot from
+ * its claim step until its agent job completes. Runs that turned out not to be pool
+ * members are added to `nonMembers` so later polls skip them.
+ *
+ * @param {string} poolName
+ * @param {number} ownRunId
+ * @param {Set<number>} nonMembers
+ * @returns {Promise<Set<number>>}
+ */
+async function listOccupiedSlots(poolName, ownRunId, nonMembers) {
+  const { owner, repo } = context.repo;
+  const { data } = await github.rest.actions.listWorkflowRunsForRepo({
+    owner,
+    repo,
+    status: "in_progress",
+    per_page: MAX_RUNS_PER_POLL,
+  });
+
+  const occupied = new Set();
+  for (const run of data.workflow_runs || []) {
+    if (run.id === ownRunId || nonMembers.has(run.id)) {
+      continue;
+    }
+    const jobs = await github.paginate(github.rest.actions.listJobsForWorkflowRun, {
+      owner,
+      repo,
+      run_id: run.id,
+      per_page: 100,
+    });
+
+    let slot = null;
+    for (const job of jobs) {
+      slot = getClaimedSlot(job, poolName);
+      if (slot !== null) {
+        break;
+      }
+    }
+    if (slot === null) {
+      // Runs whose activation job finished without a claim are not in this pool.
+      const activationDone = jobs.some(job => job.status === "completed" && (job.name === "activation" || (typeof job.name === "string" && job.name.endsWith(" / activation"))));
+      if (activationDone) {
+        nonMembers.add(run.id);
+      }
+      continue;
+    }
+    if (!isAgentJobDone(jobs)) {
+      occupied.add(slot);
+    }
+  }
+  return occupied;
+}
+
+/**
+ * Picks the lowest slot that is not occupied, or null when every slot is taken.
+ *
+ * @param {Set<number>} occupied
+ * @param {number} max
+ * @returns {number | null}
+ */
+function pickFreeSlot(occupied, max) {
+  for (let slot = 0; slot < max; slot++) {
+    if (!occupied.has(slot)) {
+      return slot;
+    }
+  }
+  return null;
+}
+
+/**
+ * Waits for a free slot in the pool and returns it. When no slot frees up within
+ * `maxWaitMs`, or the runs cannot be listed, the run falls back to `runId % max` and
+ * queues on that slot's concurrency group.
+ *
+ * @param {{ poolName: string, max: number, runId: number, maxWaitMs?: number, pollIntervalMs?: number }} options
+ * @returns {Promise<number>}
+ */
+async function selectPoolSlot({ poolName, max, runId, maxWaitMs = MAX_WAIT_MS, pollIntervalMs = POLL_INTERVAL_MS }) {
+  const fallbackSlot = runId % max;
+  const nonMembers = new Set();
+  const deadline = Date.now() + maxWaitMs;
+
+  for (;;) {
+    let occupied;
+    try {
+      occupied = await listOccupiedSlots(poolName, runId, nonMembers);
+    } catch (error) {
+      core.warning(`Could not list runs of concurrency pool ${poolName}: ${getErrorMessage(error)}. Queueing on slot ${fallbackSlot}.`);
+      return fallbackSlot;
+    }
+
+    const slot = pickFreeSlot(occupied, max);
+    if (slot !== null) {
+      core.info(`Concurrency pool ${poolName}: ${occupied.size} of ${max} slot(s) in use, taking slot ${slot}`);
+      return slot;
+    }
+    if (Date.now() + pollIntervalMs > deadline) {
+      core.warning(`All ${max} slot(s) of concurrency pool ${poolName} are still in use. Queueing on slot ${fallbackSlot}.`);
+      return fallbackSlot;
+    }
+    core.info(`Concurrency pool ${poolName}: all ${max} slot(s) in use, waiting ${Math.round(pollIntervalMs / 1000)}s`);
+    await sleep(pollIntervalMs);
+  }
+}
+
+async function main() {
+  const poolName = process.env.GH_AW_POOL_NAME || "";
+  const max = Number.parseInt(process.env.GH_AW_POOL_MAX || "", 10);
+  const runId = Number.parseInt(process.env.GITHUB_RUN_ID || String(context.runId), 10);
+  if (!poolName || Number.isNaN(max) || max < 1) {
+    core.setFailed(`Invalid concurrency pool configuration: name=${poolName}, max=${process.env.GH_AW_POOL_MAX}`);
+    return;
+  }
+
+  const slot = await selectPoolSlot({ poolName, max, runId });
+  core.setOutput("pool_slot", String(slot));
+}
+
+module.exports = { main, selectPoolSlot, getClaimedSlot, pickFreeSlot, CLAIM_STEP_PREFIX };
@@ -0,0 +1,136 @@
+import { describe, it, expect, beforeEach, afterEach, vi } from "vitest";
+
+describe("select_pool_slot.cjs", () => {
+  let mockCore;
+  let mockGithub;
+  let jobsByRun;
+
+  const claimStep = (pool, slot, conclusion = "success") => ({ name: `Claim concurrency pool slot ${pool}/${slot}`, conclusion });
+  const poolRun = (slot, agentStatus) => [
+    { name: "activation", status: "completed", steps: [claimStep("nightly", slot)] },
+    { name: "agent", status: agentStatus, steps: [] },
+  ];
+
+  beforeEach(() => {
+    vi.resetModules();
+    jobsByRun = {};
+    mockCore = {
+      info: vi.fn(),
+      warning: vi.fn(),
+      setFailed: vi.fn(),
+      setOutput: vi.fn(),
+    };
+    mockGithub = {
+      rest: {
+        actions: {
+          listWorkflowRunsForRepo: vi.fn(async () => ({ data: { workflow_runs: Object.keys(jobsByRun).map(id => ({ id: Number(id) })) } })),
+          listJobsForWorkflowRun: vi.fn(),
+        },
+      },
+      paginate: vi.fn(async (_fn, params) => jobsByRun[params.run_id] || []),
+    };
+    global.core = mockCore;
+    global.github = mockGithub;
+    global.context = { repo: { owner: "test-owner", repo: "test-repo" }, runId: 7 };
+  });
+
+  afterEach(() => {
+    delete global.core;
+    delete global.github;
+    delete global.context;
+    delete process.env.GH_AW_POOL_NAME;
+    delete process.env.GH_AW_POOL_MAX;
+  });
+
+  it("reads the claimed slot from a successful claim step of the pool", async () => {
+    const { getClaimedSlot } = await import("./select_pool_slot.cjs");
+    expect(getClaimedSlot({ steps: [claimStep("nightly", 2)] }, "nightly")).toBe(2);
+    expect(getClaimedSlot({ steps: [claimStep("nightly", 2, "skipped")] }, "nightly")).toBeNull();
+    expect(getClaimedSlot({ steps: [claimStep("other", 2)] }, "nightly")).toBeNull();
+    expect(getClaimedSlot({}, "nightly")).toBeNull();
+  });
+
+  it("picks the lowest free slot", async () => {
+    const { pickFreeSlot } = await import("./select_pool_slot.cjs");
+    expect(pickFreeSlot(new Set([0, 2]), 3)).toBe(1);
+    expect(pickFreeSlot(new Set([0, 1, 2]), 3)).toBeNull();
+  });
+
+  it("skips slots held by runs whose agent job has not completed", async () => {
+    jobsByRun[101] = poolRun(0, "in_progress");
+    jobsByRun[102] = poolRun(1, "queued");
+    jobsByRun[103] = poolRun(2, "completed");
+    jobsByRun[104] = [{ name: "activation", status: "completed", steps: [{ name: "Checkout", conclusion: "success" }] }];
+
+    const { selectPoolSlot } = await import("./select_pool_slot.cjs");
+    const slot = await selectPoolSlot({ poolName: "nightly", max: 3, runId: 7, maxWaitMs: 0 });
+
+    expect(slot).toBe(2);
+  });
+
+  it("ignores its own run", async () => {
+    jobsByRun[7] = poolRun(0, "queued");
+
+    const { selectPoolSlot } = await import("./select_pool_slot.cjs");
+    const slot = await selectPoolSlot({ poolName: "nightly", max: 2, runId: 7, maxWaitMs: 0 });
+
+    expect(slot).toBe(0);
+  });
+
+  it("waits for a slot to free up", async () => {
+    jobsByRun[101] = poolRun(0, "in_progress");
+    jobsByRun[102] = poolRun(1, "in_progress");
+    mockGithub.rest.actions.listWorkflowRunsForRepo.mockImplementationOnce(async () => ({ data: { workflow_runs: [{ id: 101 }, { id: 102 }] } }));
+    mockGithub.rest.actions.listWorkflowRunsForRepo.mockImplementationOnce(async () => {
+      jobsByRun[102] = poolRun(1, "completed");
+      return { data: { workflow_runs: [{ id: 101 }, { id: 102 }] } };
+    });
+
+    const { selectPoolSlot } = await import("./select_pool_slot.cjs");
+    const slot = await selectPoolSlot({ poolName: "nightly", max: 2, runId: 7, maxWaitMs: 1000, pollIntervalMs: 1 });
+
+    expect(slot).toBe(1);
+    expect(mockGithub.rest.actions.listWorkflowRunsForRepo).toHaveBeenCalledTimes(2);
+  });
+
+  it("falls back to the run ID slot when every slot stays in use", async () => {
+    jobsByRun[101] = poolRun(0, "in_progress");
+    jobsByRun[102] = poolRun(1, "in_progress");
+
+    const { selectPoolSlot } = await import("./select_pool_slot.cjs");
+    const slot = await selectPoolSlot({ poolName: "nightly", max: 2, runId: 7, maxWaitMs: 0 });
+
+    expect(slot).toBe(1);
+    expect(mockCore.warning).toHaveBeenCalledWith(expect.stringContaining("are still in use"));
+  });
+
+  it("falls back to the run ID slot when runs cannot be listed", async () => {
+    mockGithub.rest.actions.listWorkflowRunsForRepo.mockRejectedValue(new Error("forbidden"));
+
+    const { selectPoolSlot } = await import("./select_pool_slot.cjs");
+    const slot = await selectPoolSlot({ poolName: "nightly", max: 3, runId: 7, maxWaitMs: 0 });
+
+    expect(slot).toBe(1);
+    expect(mockCore.warning).toHaveBeenCalledWith(expect.stringContaining("forbidden"));
+  });
+
+  it("sets the pool_slot output", async () => {
+    process.env.GH_AW_POOL_NAME = "nightly";
+    process.env.GH_AW_POOL_MAX = "3";
+
+    const { main } = await import("./select_pool_slot.cjs");
+    await main();
+
+    expect(mockCore.setOutput).toHaveBeenCalledWith("pool_slot", "0");
+  });
+
+  it("fails on an invalid pool configuration", async () => {
+    process.env.GH_AW_POOL_NAME = "nightly";
+    process.env.GH_AW_POOL_MAX = "zero";
+
+    const { main } = await import("./select_pool_slot.cjs");
+    await main();
+
+    expect(mockCore.setFailed).toHaveBeenCalledWith(expect.stringContaining("Invalid concurrency pool configuration"));
+  });
+});
@@ -12,6 +12,7 @@ const { readExperimentAssignments, EXPERIMENT_ASSIGNMENTS_PATH } = require("./ex
 const { parseJsonlContent } = require("./jsonl_helpers.cjs");
 const { countSteeringEventsInApiProxyJsonl } = require("./steering_helpers.cjs");
 const { resolveAICreditsFailureState } = require("./ai_credits_context.cjs");
+const { TMP_GH_AW_PATH } = require("./constants.cjs");
 
 /**
  * send_otlp_span.cjs
@@ -646,7 +647,7 @@ function buildOTLPPayload({ traceId, spanId, parentSpanId, spanName, startMs, en
  * be inspected via GitHub Actions artifacts without needing a live collector.
  * @type {string}
  */
-const OTEL_JSONL_PATH = "/tmp/gh-aw/otel.jsonl";
+const OTEL_JSONL_PATH = `${TMP_GH_AW_PATH}/otel.jsonl`;
 
 /**
  * Append an OTLP payload as a single JSON line to the local telemetry mirror
@@ -658,7 +659,7 @@ const OTEL_JSONL_PATH = "/tmp/gh-aw/otel.jsonl";
  */
 function appendToOTLPJSONL(payload) {
   try {
-    fs.mkdirSync("/tmp/gh-aw", { recursive: true });
+    fs.mkdirSync(TMP_GH_AW_PATH, { recursive: true });
     fs.appendFileSync(OTEL_JSONL_PATH, JSON.stringify(payload) + "\n");
   } catch {
     // Mirror failures are non-fatal; do not propagate.
@@ -1233,7 +1234,7 @@ async function sendJobSetupSpan(options = {}) {
   // When this job was dispatched by a parent workflow, the parent's trace ID is
   // propagated via aw_context.otel_trace_id → aw_info.context.otel_trace_id so that
   // composite-action spans share a single trace with their caller.
-  const awInfo = readJSONIfExists("/tmp/gh-aw/aw_info.json") || {};
+  const awInfo = readJSONIfExists(`${TMP_GH_AW_PATH}/aw_info.json`) || {};
   const setupAwContext = parseSetupAwContext(process.env.GH_AW_SETUP_AW_CONTEXT);
   if ((!awInfo.context || typeof awInfo.context !== "object") && Object.keys(setupAwContext).length > 0) {
     awInfo.context = setupAwContext;
@@ -1417,20 +1418,20 @@ function readJSONIfExists(filePath) {
  * a runtime require() dependency on that module.
  * @type {string}
  */
-const GITHUB_RATE_LIMITS_JSONL_PATH = "/tmp/gh-aw/github_rate_limits.jsonl";
+const GITHUB_RATE_LIMITS_JSONL_PATH = `${TMP_GH_AW_PATH}/github_rate_limits.jsonl`;
 
 /**
  * Path to the persisted OTLP export error counter.
  * @type {string}
  */
-const OTLP_EXPORT_ERRORS_PATH = "/tmp/gh-aw/otlp-export-errors.count";
+const OTLP_EXPORT_ERRORS_PATH = `${TMP_GH_AW_PATH}/otlp-export-errors.count`;
 
 /**
  * Path to the persisted OTLP export failure detail log.
  * @type {string}
  */
-const OTLP_EXPORT_ERROR_DETAILS_PATH = "/tmp/gh-aw/otlp-export-errors.jsonl";
-const AGENT_EXECUTION_EXIT_CODE_PATH = "/tmp/gh-aw/agent_execution_exit_code.txt";
+const OTLP_EXPORT_ERROR_DETAILS_PATH = `${TMP_GH_AW_PATH}/otlp-export-errors.jsonl`;
+const AGENT_EXECUTION_EXIT_CODE_PATH = `${TMP_GH_AW_PATH}/agent_execution_exit_code.txt`;
 
 /**
  * Path to the failure categories file written by handle_agent_failure and read
@@ -1439,18 +1440,18 @@ const AGENT_EXECUTION_EXIT_CODE_PATH = "/tmp/gh-aw/agent_execution_exit_code.txt
  * introducing a runtime require() dependency on that module.
  * @type {string}
  */
-const FAILURE_CATEGORIES_PATH = "/tmp/gh-aw/failure_categories.json";
+const FAILURE_CATEGORIES_PATH = `${TMP_GH_AW_PATH}/failure_categories.json`;
 
 /**
  * Path to the agent stdio log file.
  * @type {string}
  */
-const AGENT_STDIO_LOG_PATH = "/tmp/gh-aw/agent-stdio.log";
+const AGENT_STDIO_LOG_PATH = `${TMP_GH_AW_PATH}/agent-stdio.log`;
 const API_PROXY_EVENT_LOG_PATHS = [
-  "/tmp/gh-aw/sandbox/firewall/logs/api-proxy-logs/event-logs.jsonl",
-  "/tmp/gh-aw/sandbox/firewall/logs/api-proxy-logs/events.jsonl",
-  "/tmp/gh-aw/sandbox/firewall-audit-logs/api-proxy-logs/event-logs.jsonl",
-  "/tmp/gh-aw/sandbox/firewall-audit-logs/api-proxy-logs/events.jsonl",
+  `${TMP_GH_AW_PATH}/sandbox/firewall/logs/api-proxy-logs/event-logs.jsonl`,
+  `${TMP_GH_AW_PATH}/sandbox/firewall/logs/api-proxy-logs/events.jsonl`,
+  `${TMP_GH_AW_PATH}/sandbox/firewall-audit-logs/api-proxy-logs/event-logs.jsonl`,
+  `${TMP_GH_AW_PATH}/sandbox/firewall-audit-logs/api-proxy-logs/events.jsonl`,
 ];
 const PERMISSION_DENIED_RE = /\b(?:permissions?\s+denied(?!\s+counts?\b)|EACCES|EPERM)\b/i;
 
@@ -1644,7 +1645,7 @@ function formatOTLPExportErrorDetails() {
  */
 function recordOTLPExportError(detail = {}) {
   try {
-    fs.mkdirSync("/tmp/gh-aw", { recursive: true });
+    fs.mkdirSync(TMP_GH_AW_PATH, { recursive: true });
     fs.writeFileSync(OTLP_EXPORT_ERRORS_PATH, String(readOTLPExportErrorCount() + 1));
   } catch {
     // Export-health tracking is best-effort only.
@@ -1653,7 +1654,7 @@ function recordOTLPExportError(detail = {}) {
     return;
   }
   try {
-    fs.mkdirSync("/tmp/gh-aw", { recursive: true });
+    fs.mkdirSync(TMP_GH_AW_PATH, { recursive: true });
     fs.appendFileSync(
       OTLP_EXPORT_ERROR_DETAILS_PATH,
       JSON.stringify(
@@ -1962,7 +1963,7 @@ async function sendJobConclusionSpan(spanName, options = {}) {
   const endMs = nowMs();
 
   // Read workflow metadata from aw_info.json (written by the agent job setup step).
-  const awInfo = readJSONIfExists("/tmp/gh-aw/aw_info.json") || {};
+  const awInfo = readJSONIfExists(`${TMP_GH_AW_PATH}/aw_info.json`) || {};
 
   const serviceName = process.env.OTEL_SERVICE_NAME || "gh-aw";
   const version = (typeof awInfo.cli_version === "string" ? awInfo.cli_version : "") || process.env.GH_AW_INFO_CLI_VERSION || awInfo.agent_version || awInfo.version || process.env.GH_AW_INFO_VERSION || process.env.GITHUB_SHA || "unknown";
@@ -2031,7 +2032,7 @@ async function sendJobConclusionSpan(spanName, options = {}) {
   const detectionReason = process.env.GH_AW_DETECTION_REASON || "";
   const runtimeMetrics = readAgentRuntimeMetrics();
   // Read once and reuse for both gh-aw.aic and gen_ai.usage.* attributes.
-  const agentUsageFilePath = "/tmp/gh-aw/agent_usage.json";
+  const agentUsageFilePath = `${TMP_GH_AW_PATH}/agent_usage.json`;
   const agentUsageRaw = readJSONIfExists(agentUsageFilePath);
   const agentUsageNormalized = normalizeRuntimeTokenUsage(agentUsageRaw);
   const agentUsage = agentUsageNormalized || runtimeMetrics.tokenUsage || {};
@@ -2050,7 +2051,7 @@ async function sendJobConclusionSpan(spanName, options = {}) {
   }
 
   // Always read agent_output.json so output metrics are available on all outcomes.
-  const rawAgentOutput = readJSONIfExists("/tmp/gh-aw/agent_output.json");
+  const rawAgentOutput = readJSONIfExists(`${TMP_GH_AW_PATH}/agent_output.json`);
   const agentOutput = rawAgentOutput || {};
   // readJSONIfExists returns null when the file is absent OR unreadable (e.g. partial/corrupt write).
   const hasNoReadableAgentOutput = rawAgentOutput === null;
@@ -2240,7 +2241,7 @@ async function sendJobConclusionSpan(spanName, options = {}) {
 
   // Enrich conclusion span with outcome evaluation fleet metrics when available.
   // Written by the outcome-collector workflow's pre-agent step.
-  const outcomeSummary = readJSONIfExists("/tmp/gh-aw/outcome-summary.json");
+  const outcomeSummary = readJSONIfExists(`${TMP_GH_AW_PATH}/outcome-summary.json`);
   if (outcomeSummary && typeof outcomeSummary.total_outcomes === "number" && outcomeSummary.total_outcomes > 0) {
     attributes.push(buildAttr("gh-aw.outcome.total", outcomeSummary.total_outcomes));
     attributes.push(buildAttr("gh-aw.outcome.accepted", outcomeSummary.accepted || 0));
@@ -2323,7 +2324,7 @@ async function sendJobConclusionSpan(spanName, options = {}) {
   // absent — e.g. on older compiled workflows or during local development.
   const agentStartMs = (() => {
     try {
-      const raw = fs.readFileSync("/tmp/gh-aw/agent_cli_start_ms.txt", "utf8").trim();
+      const raw = fs.readFileSync(`${TMP_GH_AW_PATH}/agent_cli_start_ms.txt`, "utf8").trim();
       const ms = Number(raw);
       return Number.isFinite(ms) && ms > 0 ? ms : options.startMs;
     } catch {
@@ -2333,7 +2334,7 @@ async function sendJobConclusionSpan(spanName, options = {}) {
   /** @type {any} */
   let agentEndMs = null;
   try {
-    agentEndMs = fs.statSync("/tmp/gh-aw/agent_output.json").mtimeMs;
+    agentEndMs = fs.statSync(`${TMP_GH_AW_PATH}/agent_output.json`).mtimeMs;
   } catch {
     // agent_output.json may be absent for agent failures and cancellations,
     // including timed-out or manually-cancelled runs where the process was
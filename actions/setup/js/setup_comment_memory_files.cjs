@@ -7,6 +7,7 @@ const path = require("path");
 const { ERR_VALIDATION } = require("./error_codes.cjs");
 const { getErrorMessage } = require("./error_helpers.cjs");
 const { parseAllowedRepos, validateTargetRepo } = require("./repo_helpers.cjs");
+const { TMP_GH_AW_PATH } = require("./constants.cjs");
 const {
   COMMENT_MEMORY_DIR,
   COMMENT_MEMORY_MAX_SCAN_PAGES,
@@ -19,7 +20,7 @@ const {
   resolveCommentMemoryConfig,
 } = require("./comment_memory_helpers.cjs");
 
-const PROMPT_PATH = "/tmp/gh-aw/aw-prompts/prompt.txt";
+const PROMPT_PATH = `${TMP_GH_AW_PATH}/aw-prompts/prompt.txt`;
 
 function loadSafeOutputsConfig() {
   const configPath = process.env.GH_AW_SAFE_OUTPUTS_CONFIG_PATH || `${process.env.RUNNER_TEMP}/gh-aw/safeoutputs/config.json`;
@@ -15,7 +15,7 @@
 const fs = require("fs");
 const path = require("path");
 const { checkFileExists } = require("./file_helpers.cjs");
-const { AGENT_OUTPUT_FILENAME } = require("./constants.cjs");
+const { AGENT_OUTPUT_FILENAME, TMP_GH_AW_PATH } = require("./constants.cjs");
 const { ERR_VALIDATION } = require("./error_codes.cjs");
 const { getErrorMessage } = require("./error_helpers.cjs");
 const { getPromptPath } = require("./messages_core.cjs");
@@ -42,7 +42,7 @@ async function main() {
   // GitHub Actions preserves the directory structure from the uploaded artifact
   // (stripping the common /tmp/gh-aw/ prefix from the uploaded paths)
   // So /tmp/gh-aw/aw-prompts/prompt.txt becomes /tmp/gh-aw/threat-detection/aw-prompts/prompt.txt
-  const threatDetectionDir = "/tmp/gh-aw/threat-detection";
+  const threatDetectionDir = `${TMP_GH_AW_PATH}/threat-detection`;
   const promptPath = path.join(threatDetectionDir, "aw-prompts/prompt.txt");
   let promptFileInfo;
   if (!fs.existsSync(promptPath)) {
@@ -164,12 +164,12 @@ async function main() {
 
   // Write prompt file
   try {
-    fs.mkdirSync("/tmp/gh-aw/aw-prompts", { recursive: true });
-    fs.writeFileSync("/tmp/gh-aw/aw-prompts/prompt.txt", promptContent);
+    fs.mkdirSync(`${TMP_GH_AW_PATH}/aw-prompts`, { recursive: true });
+    fs.writeFileSync(`${TMP_GH_AW_PATH}/aw-prompts/prompt.txt`, promptContent);
   } catch (err) {
     throw new Error(`Failed to prepare threat detection prompt file: ${String(err)}`, { cause: err });
   }
-  core.exportVariable("GH_AW_PROMPT", "/tmp/gh-aw/aw-prompts/prompt.txt");
+  core.exportVariable("GH_AW_PROMPT", `${TMP_GH_AW_PATH}/aw-prompts/prompt.txt`);
 
   // Note: creation of /tmp/gh-aw/threat-detection and detection.log is handled by a separate shell step
 
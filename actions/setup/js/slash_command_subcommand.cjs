@@ -0,0 +1,112 @@
+// @ts-check
+
+const { sanitizeContent } = require("./sanitize_content.cjs");
+
+/** Maximum length of a single argument value exposed to the workflow. */
+const MAX_ARG_VALUE_LENGTH = 1024;
+
+/**
+ * @typedef {Object} SubcommandArg
+ * @property {string} name
+ * @property {string} [description]
+ * @property {boolean} [required]
+ * @property {string[]} [choices]
+ */
+
+/**
+ * @typedef {Object} Subcommand
+ * @property {string} name
+ * @property {string} [description]
+ * @property {SubcommandArg[]} [args]
+ */
+
+/**
+ * @typedef {Object} SubcommandInvocation
+ * @property {string} subcommand - The resolved subcommand name ("" when parsing failed)
+ * @property {Record<string, string | string[]>} args - Named arguments plus positional words under "_"
+ * @property {string} [error] - Why the invocation was rejected
+ * @property {boolean} [help] - True when the user asked for help
+ */
+
+/**
+ * Splits a command line into tokens. Single and double quotes group words,
+ * so `--title "fix login"` yields ["--title", "fix login"].
+ * @param {string} line
+ * @returns {string[]}
+ */
+function tokenizeCommandLine(line) {
+  const tokens = line.match(/(?:"[^"]*"|'[^']*'|[^\s"']+|["'])+/g) || [];
+  return tokens.map(token => token.replace(/"([^"]*)"|'([^']*)'/g, (_, double, single) => double ?? single));
+}
+
+/**
+ * Parses the subcommand and arguments that follow a slash command.
+ * Only the first line of the text is considered, so the rest of the comment
+ * can contain free-form context without being mistaken for arguments.
+ *
+ * @param {string} text - Triggering text starting with the slash command
+ * @param {Subcommand[]} subcommands - Subcommands declared in the workflow frontmatter
+ * @returns {SubcommandInvocation}
+ */
+function parseSubcommandInvocation(text, subcommands) {
+  const firstLine = String(text).trim().split(/\r?\n/)[0];
+  const [, name = "", ...rest] = tokenizeCommandLine(firstLine);
+
+  if (name === "" || name === "help" || name === "--help") {
+    return { subcommand: "", args: {}, help: true, error: name === "" ? "No subcommand given." : "" };
+  }
+
+  const subcommand = subcommands.find(s => s.name === name);
+  if (!subcommand) {
+    return { subcommand: "", args: {}, error: `Unknown subcommand '${name}'.` };
+  }
+
+  const declared = new Map((subcommand.args || []).map(arg => [arg.name, arg]));
+  /** @type {Record<string, string | string[]>} */
+  const args = {};
+  /** @type {string[]} */
+  const positional = [];
+
+  for (let i = 0; i < rest.length; i++) {
+    const token = rest[i];
+    if (!token.startsWith("--") || token === "--") {
+      positional.push(token);
+      continue;
+    }
+
+    let key = token.slice(2);
+    /** @type {string} */
+    let value;
+    const eq = key.indexOf("=");
+    if (eq !== -1) {
+      value = key.slice(eq + 1);
+      key = key.slice(0, eq);
+    } else if (i + 1 < rest.length && !rest[i + 1].startsWith("--")) {
+      value = rest[++i];
+    } else {
+      value = "true";
+    }
+
+    const arg = declared.get(key);
+    if (!arg) {
+      return { subcommand: "", args: {}, error: `Unknown argument '--${key}' for subcommand '${name}'.` };
+    }
+    if (arg.choices && arg.choices.length > 0 && !arg.choices.includes(value)) {
+      return { subcommand: "", args: {}, error: `Invalid value '${value}' for '--${key}'. Expected one of: ${arg.choices.join(", ")}.` };
+    }
+    args[key] = sanitizeContent(value, MAX_ARG_VALUE_LENGTH);
+  }
+
+  for (const arg of declared.values()) {
+    if (arg.required && !(arg.name in args)) {
+      return { subcommand: "", args: {}, error: `Missing required argument '--${arg.name}' for subcommand '${name}'.` };
+    }
+  }
+
+  if (positional.length > 0) {
+    args._ = positional.map(word => sanitizeContent(word, MAX_ARG_VALUE_LENGTH));
+  }
+  return { subcommand: name, args };
+}
+
+module.exports = { tokenizeCommandLine, parseSubcommandInvocation };
@@ -0,0 +1,85 @@
+import { describe, it, expect } from "vitest";
+
+const { tokenizeCommandLine, parseSubcommandInvocation } = require("./slash_command_subcommand.cjs");
+
+const subcommands = [
+  { name: "summarize", description: "Summarize the discussion" },
+  {
+    name: "triage",
+    args: [
+      { name: "area", description: "Component" },
+      { name: "dry-run" },
+      { name: "priority", required: true, choices: ["low", "medium", "high"] },
+    ],
+  },
+];
+
+describe("slash_command_subcommand.cjs", () => {
+  describe("tokenizeCommandLine", () => {
+    it("should split on whitespace and group quoted words", () => {
+      expect(tokenizeCommandLine(`/mybot triage --area "auth flow" --note='a b' it's`)).toEqual(["/mybot", "triage", "--area", "auth flow", "--note=a b", "it's"]);
+    });
+  });
+
+  describe("parseSubcommandInvocation", () => {
+    it("should parse named and positional arguments", () => {
+      const result = parseSubcommandInvocation("/mybot triage --priority high please look --area=auth", subcommands);
+
+      expect(result.error).toBeUndefined();
+      expect(result.subcommand).toBe("triage");
+      expect(result.args).toEqual({ priority: "high", area: "auth", _: ["please", "look"] });
+    });
+
+    it("should treat a flag without a value as true", () => {
+      const result = parseSubcommandInvocation("/mybot triage --dry-run --priority low", subcommands);
+
+      expect(result.args).toEqual({ "dry-run": "true", priority: "low" });
+    });
+
+    it("should only parse the first line", () => {
+      const result = parseSubcommandInvocation("/mybot summarize\n--area ignored", subcommands);
+
+      expect(result.subcommand).toBe("summarize");
+      expect(result.args).toEqual({});
+    });
+
+    it("should reject unknown subcommands", () => {
+      const result = parseSubcommandInvocation("/mybot deploy", subcommands);
+
+      expect(result.subcommand).toBe("");
+      expect(result.error).toContain("Unknown subcommand 'deploy'");
+    });
+
+    it("should reject a missing subcommand", () => {
+      const result = parseSubcommandInvocation("/mybot", subcommands);
+
+      expect(result.subcommand).toBe("");
+      expect(result.error).toContain("No subcommand given");
+    });
+
+    it("should report help requests without an error", () => {
+      const result = parseSubcommandInvocation("/mybot help", subcommands);
+
+      expect(result.help).toBe(true);
+      expect(result.error).toBe("");
+    });
+
+    it("should reject undeclared arguments", () => {
+      const result = parseSubcommandInvocation("/mybot summarize --priority high", subcommands);
+
+      expect(result.error).toContain("Unknown argument '--priority'");
+    });
+
+    it("should reject values outside the declared choices", () => {
+      const result = parseSubcommandInvocation("/mybot triage --priority urgent", subcommands);
+
+      expect(result.error).toContain("Expected one of: low, medium, high");
+    });
+
+    it("should reject missing required arguments", () => {
+      const result = parseSubcommandInvocation("/mybot triage --area auth", subcommands);
+
+      expect(result.error).toContain("Missing required argument '--priority'");
+    });
+  });
+});
@@ -36,6 +36,8 @@ const path = require("path");
 const { withRetry } = require("./error_recovery.cjs");
 const { lstatGuard } = require("./symlink_guard.cjs");
 const { getErrorMessage } = require("./error_helpers.cjs");
+const { computeRunCorrelationId } = require("./correlation_id.cjs");
+const { TMP_GH_AW_PATH } = require("./constants.cjs");
 
 /** @type {number | null} */
 let activeGatewayPid = null;
@@ -348,6 +350,7 @@ async function main() {
   core.info(`Runner temp: ${runnerTemp || "(not set)"}`);
   core.info(`Gateway port: ${gatewayPort || "(not set)"}`);
   core.info(`Gateway domain: ${gatewayDomain || "(not set)"}`);
+  core.info(`Correlation ID: ${computeRunCorrelationId() || "(not set)"}`);
   core.info("");
 
   // -----------------------------------------------------------------------
@@ -362,9 +365,9 @@ async function main() {
   const cliDir = path.join(runnerTemp || "/tmp", "gh-aw/mcp-cli");
 
   try {
-    fs.mkdirSync("/tmp/gh-aw/mcp-logs", { recursive: true });
+    fs.mkdirSync(`${TMP_GH_AW_PATH}/mcp-logs`, { recursive: true });
   } catch (err) {
-    throw new Error(`Failed to create directory /tmp/gh-aw/mcp-logs: ${String(err)}`, { cause: err });
+    throw new Error(`Failed to create directory ${TMP_GH_AW_PATH}/mcp-logs: ${String(err)}`, { cause: err });
   }
 
   // Symlink attack prevention on the config directory
@@ -493,9 +496,9 @@ async function main() {
   // -----------------------------------------------------------------------
   // Start gateway container
   // -----------------------------------------------------------------------
-  const logDir = "/tmp/gh-aw/mcp-logs/";
+  const logDir = `${TMP_GH_AW_PATH}/mcp-logs/`;
   const outputPath = path.join(configDir, "gateway-output.json");
-  const stderrLogPath = "/tmp/gh-aw/mcp-logs/stderr.log";
+  const stderrLogPath = `${TMP_GH_AW_PATH}/mcp-logs/stderr.log`;
 
   // Clean up any stale gateway container from a previous run on this runner.
   // On persistent self-hosted runners a prior job's gateway container may still
@@ -928,7 +931,7 @@ async function main() {
     // as a shell argument to avoid shell metacharacter injection risks.
     const safePort = String(gatewayPort).replace(/[^0-9]/g, "");
     try {
-      execSync(`bash "${checkScript}" "${outputPath}" "http://localhost:${safePort}" "$MCP_GATEWAY_API_KEY" 2>&1 | tee /tmp/gh-aw/mcp-logs/start-gateway.log`, { stdio: "inherit", env: process.env });
+      execSync(`bash "${checkScript}" "${outputPath}" "http://localhost:${safePort}" "$MCP_GATEWAY_API_KEY" 2>&1 | tee ${TMP_GH_AW_PATH}/mcp-logs/start-gateway.log`, { stdio: "inherit", env: process.env });
     } catch {
       core.error("ERROR: MCP server checks failed - no servers could be connected");
       core.error("Gateway process will be terminated");
@@ -6,10 +6,12 @@ require("./shim.cjs");
 const fs = require("fs");
 const { getErrorMessage } = require("./error_helpers.cjs");
 const { ERR_SYSTEM } = require("./error_codes.cjs");
+const { isDispatchInputPlaceholder, sanitizeDispatchInput } = require("./dispatch_inputs.cjs");
 
 /**
  * Substitutes `__KEY__` placeholders in a file with values from the substitutions map.
- * Undefined/null values are treated as empty strings.
+ * Undefined/null values are treated as empty strings. Values for workflow_dispatch
+ * inputs are sanitized, since they are free-form text typed by whoever triggered the run.
  *
  * @param {{ file: string, substitutions: Record<string, string | null | undefined> }} params
  * @returns {Promise<string>}
@@ -38,7 +40,10 @@ const substitutePlaceholders = async ({ file, substitutions }) => {
   for (const [key, value] of Object.entries(substitutions)) {
     const placeholder = `__${key}__`;
     // Convert undefined/null to empty string to avoid leaving "undefined" or "null" in the output
-    const safeValue = value == null ? "" : value;
+    let safeValue = value == null ? "" : value;
+    if (safeValue && isDispatchInputPlaceholder(key)) {
+      safeValue = sanitizeDispatchInput(safeValue);
+    }
     content = content.split(placeholder).join(safeValue);
   }
 
@@ -63,6 +63,15 @@ describe("substitutePlaceholders", () => {
     expect(fs.readFileSync(testFile, "utf8")).toBe("Value: ");
   });
 
+  it("should sanitize workflow_dispatch input values", async () => {
+    fs.writeFileSync(testFile, "Topic: __GH_AW_INPUTS_TOPIC__\nActor: __GH_AW_GITHUB_ACTOR__", "utf8");
+    await substitutePlaceholders({
+      file: testFile,
+      substitutions: { GH_AW_INPUTS_TOPIC: "ping @octocat", GH_AW_GITHUB_ACTOR: "@octocat" },
+    });
+    expect(fs.readFileSync(testFile, "utf8")).toBe("Topic: ping `@octocat`\nActor: @octocat");
+  });
+
   it("should throw error if file parameter is missing", async () => {
     // @ts-expect-error - testing missing file param
     await expect(substitutePlaceholders({ substitutions: { NAME: "test" } })).rejects.toThrow("file parameter is required");
@@ -0,0 +1,28 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+const { readFollowUpQueue, writeFollowUpQueue, splitDueFollowUps, formatFollowUps } = require("./follow_up_queue.cjs");
+
+/**
+ * Takes the due follow-ups out of the restored queue at the start of a scheduled run.
+ * The remaining queue is written back for the cache save step, and the due follow-ups
+ * are exposed as the "due" output for the prompt and "taken" for the save condition.
+ * @returns {Promise<void>}
+ */
+async function main() {
+  const { due, pending } = splitDueFollowUps(readFollowUpQueue(), new Date());
+  if (due.length === 0) {
+    core.info("No follow-up is due");
+    core.setOutput("taken", "0");
+    return;
+  }
+
+  writeFollowUpQueue(pending);
+  for (const followUp of due) {
+    core.info(`Running follow-up ${followUp.id} (due ${followUp.due_at})`);
+  }
+  core.setOutput("taken", String(due.length));
+  core.setOutput("due", formatFollowUps(due));
+}
+
+module.exports = { main };
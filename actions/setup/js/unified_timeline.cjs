@@ -18,12 +18,13 @@
 
 const fs = require("fs");
 const path = require("path");
+const { TMP_GH_AW_PATH } = require("./constants.cjs");
 
 // ---------------------------------------------------------------------------
 // Path constants
 // ---------------------------------------------------------------------------
 
-const TMP_GH_AW = "/tmp/gh-aw";
+const TMP_GH_AW = TMP_GH_AW_PATH;
 const GATEWAY_JSONL_PATH = `${TMP_GH_AW}/mcp-logs/gateway.jsonl`;
 const RPC_MESSAGES_PATH = `${TMP_GH_AW}/mcp-logs/rpc-messages.jsonl`;
 const FIREWALL_AUDIT_PATH = `${TMP_GH_AW}/sandbox/firewall/audit/audit.jsonl`;
@@ -21,6 +21,8 @@ const { fetchIssueState, mergeIssueState } = require("./safe_output_execution_me
 const { MAX_LABELS, MAX_ASSIGNEES } = require("./constants.cjs");
 const { fetchAllRepoLabels } = require("./github_api_helpers.cjs");
 const { buildIssueIntentLabelUpdates, getIssueIntentLabelNames, normalizeIssueIntentLabelSpecs } = require("./issue_intents.cjs");
+const { parseAllowedIssueFields, resolveAllowedIssueType } = require("./allowed_issue_fields.cjs");
+const { linkSubIssue } = require("./sub_issue_helpers.cjs");
 
 /**
  * Execute the issue update API call
@@ -42,7 +44,7 @@ async function executeIssueUpdate(github, context, issueNumber, updateData) {
   const useIssueIntentLabels = Boolean(labelSpecs);
 
   // Remove internal fields
-  const { _operation, _rawBody, _includeFooter, _titlePrefix, _workflowRepo, ...apiData } = updateData;
+  const { _operation, _rawBody, _includeFooter, _titlePrefix, _workflowRepo, parent, ...apiData } = updateData;
   if (labelSpecs) {
     apiData.labels = getIssueIntentLabelNames(labelSpecs);
   }
@@ -172,6 +174,21 @@ async function executeIssueUpdate(github, context, issueNumber, updateData) {
     };
   }
 
+  // The parent is set through the sub-issues API rather than the issue update
+  if (parent !== undefined) {
+    core.info(`Linking issue #${issueNumber} as sub-issue of #${parent}`);
+    await linkSubIssue({ owner: context.repo.owner, repo: context.repo.repo, parentIssueNumber: parent, subIssueNumber: issueNumber }, github);
+    core.info(`✓ Linked issue #${issueNumber} as sub-issue of #${parent}`);
+    if (!issue) {
+      const response = await github.rest.issues.get({
+        owner: context.repo.owner,
+        repo: context.repo.repo,
+        issue_number: issueNumber,
+      });
+      issue = response.data;
+    }
+  }
+
   return issue;
 }
 
@@ -218,6 +235,28 @@ function buildIssueUpdateData(item, config) {
   if (item.milestone !== undefined) {
     updateData.milestone = item.milestone;
   }
+  if (item.issue_type !== undefined) {
+    if (config.allow_type !== true) {
+      return { success: false, error: "Issue type updates are not allowed by the safe-outputs configuration" };
+    }
+    try {
+      // The REST API clears the issue type when type is null
+      updateData.type = resolveAllowedIssueType(item.issue_type, parseAllowedIssueFields(config.allowed_types)) || null;
+    } catch (error) {
+      return { success: false, error: error instanceof Error ? error.message : String(error) };
+    }
+  }
+  if (item.parent !== undefined) {
+    if (config.allow_parent !== true) {
+      return { success: false, error: "Setting the parent issue is not allowed by the safe-outputs configuration" };
+    }
+    const parentText = String(item.parent).trim();
+    const parentNumber = /^#?\d+$/.test(parentText) ? parseInt(parentText.replace(/^#/, ""), 10) : 0;
+    if (parentNumber <= 0) {
+      return { success: false, error: `${ERR_VALIDATION}: Invalid parent issue number: ${item.parent}` };
+    }
+    updateData.parent = parentNumber;
+  }
 
   // Enforce max limits on labels and assignees before API calls
   const labelsLimitResult = tryEnforceArrayLimit(updateData.labels, MAX_LABELS, "labels");
@@ -1132,3 +1132,57 @@ describe("update_issue.cjs - cross-repo and operation integration", () => {
     );
   });
 });
+
+describe("update_issue.cjs - issue type and parent", () => {
+  beforeEach(async () => {
+    vi.resetAllMocks();
+    vi.resetModules();
+  });
+
+  it("should reject type and parent updates unless they are allowed", async () => {
+    const { buildIssueUpdateData } = await import("./update_issue.cjs");
+
+    expect(buildIssueUpdateData({ issue_type: "Bug" }, {})).toEqual({ success: false, error: "Issue type updates are not allowed by the safe-outputs configuration" });
+    expect(buildIssueUpdateData({ parent: 12 }, {})).toEqual({ success: false, error: "Setting the parent issue is not allowed by the safe-outputs configuration" });
+  });
+
+  it("should resolve the type against allowed_types and clear it with an empty string", async () => {
+    const { buildIssueUpdateData } = await import("./update_issue.cjs");
+    const config = { allow_type: true, allowed_types: ["Bug", "Feature"] };
+
+    expect(buildIssueUpdateData({ issue_type: "feature" }, config).data.type).toBe("Feature");
+    expect(buildIssueUpdateData({ issue_type: "" }, config).data.type).toBeNull();
+    const rejected = buildIssueUpdateData({ issue_type: "Epic" }, config);
+    expect(rejected.success).toBe(false);
+    expect(rejected.error).toContain("allowed-types");
+  });
+
+  it("should parse the parent issue number", async () => {
+    const { buildIssueUpdateData } = await import("./update_issue.cjs");
+    const config = { allow_parent: true };
+
+    expect(buildIssueUpdateData({ parent: "#12" }, config).data.parent).toBe(12);
+    expect(buildIssueUpdateData({ parent: 7 }, config).data.parent).toBe(7);
+    expect(buildIssueUpdateData({ parent: "aw_abc123" }, config).success).toBe(false);
+  });
+
+  it("should link the issue as a sub-issue of the parent", async () => {
+    mockGithub.rest.issues.get.mockResolvedValue({
+      data: { number: 100, title: "Task", body: "", html_url: "https://github.com/testowner/testrepo/issues/100" },
+    });
+    mockGithub.graphql.mockImplementation(async (query, variables) => {
+      if (query.includes("repository(owner: $owner")) {
+        return { repository: { issue: { id: `I_${variables.issueNumber}` } } };
+      }
+      return {};
+    });
+
+    const { main } = await import("./update_issue.cjs");
+    const handler = await main({ target: "*", allow_parent: true });
+    const result = await handler({ issue_number: 100, parent: 12 }, {});
+
+    expect(result.success).toBe(true);
+    expect(mockGithub.rest.issues.update).not.toHaveBeenCalled();
+    expect(mockGithub.graphql).toHaveBeenCalledWith(expect.stringContaining("addSubIssue"), { parentId: "I_12", subIssueId: "I_100" });
+  });
+});
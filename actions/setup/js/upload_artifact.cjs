@@ -42,6 +42,7 @@ const { globPatternToRegex } = require("./glob_pattern_helpers.cjs");
 const { ERR_VALIDATION } = require("./error_codes.cjs");
 const { isTemporaryId, normalizeTemporaryId } = require("./temporary_id.cjs");
 const { lstatGuard } = require("./symlink_guard.cjs");
+const { TMP_GH_AW_PATH } = require("./constants.cjs");
 
 /**
  * Staging directory where the model places files to be uploaded.
@@ -53,7 +54,7 @@ const { lstatGuard } = require("./symlink_guard.cjs");
 const STAGING_DIR = path.join(process.env.RUNNER_TEMP || "/tmp", "gh-aw", "safeoutputs", "upload-artifacts") + path.sep;
 
 /** Path where the resolver mapping (tmpId → artifact name) is written. */
-const RESOLVER_FILE = "/tmp/gh-aw/artifact-resolver.json";
+const RESOLVER_FILE = `${TMP_GH_AW_PATH}/artifact-resolver.json`;
 
 /**
  * Generate a temporary artifact ID using the same aw_ prefix format as other safe outputs.
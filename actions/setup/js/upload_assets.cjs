@@ -7,6 +7,7 @@ const crypto = require("crypto");
 const { loadAgentOutput } = require("./load_agent_output.cjs");
 const { getErrorMessage } = require("./error_helpers.cjs");
 const { ERR_API, ERR_CONFIG, ERR_SYSTEM, ERR_VALIDATION } = require("./error_codes.cjs");
+const { TMP_GH_AW_PATH } = require("./constants.cjs");
 
 /**
  * @typedef {{ type: string, fileName: string, sha: string, size: number, targetFileName: string, url?: string }} UploadAssetItem
@@ -96,7 +97,7 @@ async function main() {
   // directory, and the Go generator passes the same path via GH_AW_ASSETS_DIR, so
   // producer and consumer can never disagree on the location. The literal fallback
   // matches constants.TmpGhAwAssetsDir for robustness if the env var is unset.
-  const assetsDir = process.env.GH_AW_ASSETS_DIR || "/tmp/gh-aw/safeoutputs/assets";
+  const assetsDir = process.env.GH_AW_ASSETS_DIR || `${TMP_GH_AW_PATH}/safeoutputs/assets`;
   core.info(`Reading staged assets from: ${assetsDir}`);
   let uploadCount = 0;
   let missingAssetCount = 0;
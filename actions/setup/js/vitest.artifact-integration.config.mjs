@@ -4,6 +4,8 @@ export default defineConfig({
   test: {
     environment: "node",
     globals: true,
+    // Scripts resolve their scratch files against GH_AW_TMP_DIR, which setup.sh exports in workflows.
+    env: { GH_AW_TMP_DIR: "/tmp/gh-aw" },
     include: ["artifact_client_live_api.test.cjs"],
     // Allow enough time for real network I/O against GitHub's artifact storage.
     testTimeout: 120000,
@@ -4,6 +4,8 @@ export default defineConfig({
   test: {
     environment: "node",
     globals: true,
+    // Scripts resolve their scratch files against GH_AW_TMP_DIR, which setup.sh exports in workflows.
+    env: { GH_AW_TMP_DIR: "/tmp/gh-aw" },
     include: ["**/*.test.{js,cjs}"],
     exclude: ["**/frontmatter_hash_github_api.test.cjs", ...configDefaults.exclude],
     testTimeout: 10000,
@@ -4,6 +4,8 @@ export default defineConfig({
   test: {
     environment: "node",
     globals: true,
+    // Scripts resolve their scratch files against GH_AW_TMP_DIR, which setup.sh exports in workflows.
+    env: { GH_AW_TMP_DIR: "/tmp/gh-aw" },
     include: ["frontmatter_hash_github_api.test.cjs", "set_issue_field_api_query.integration.test.cjs"],
     testTimeout: 30000,
     hookTimeout: 10000,
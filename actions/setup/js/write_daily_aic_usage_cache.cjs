@@ -17,13 +17,14 @@ const path = require("path");
 const { findJSONLFiles, sumAICFromUsageJSONLFiles } = require("./daily_aic_workflow_helpers.cjs");
 const { AIC_USAGE_CACHE_FILE_PATH, CACHE_RETENTION_MS, pruneStaleJSONLCacheLines } = require("./daily_aic_cache_helpers.cjs");
 const { getErrorMessage } = require("./error_helpers.cjs");
+const { TMP_GH_AW_PATH } = require("./constants.cjs");
 
 /**
  * Directory prepared by the "Collect usage artifact files" step in the conclusion job.
  * Contains agent_usage.jsonl and agent/token_usage.jsonl which mirror the contents of
  * the "usage" artifact that getRunAIC() downloads during the daily-AIC guardrail check.
  */
-const USAGE_DIR = "/tmp/gh-aw/usage";
+const USAGE_DIR = `${TMP_GH_AW_PATH}/usage`;
 
 /**
  * @param {string} message
@@ -5,6 +5,7 @@ const path = require("path");
 const crypto = require("crypto");
 
 const { generateCompactSchema } = require("./generate_compact_schema.cjs");
+const { TMP_GH_AW_PATH } = require("./constants.cjs");
 
 /**
  * Writes large content to a file and returns metadata
@@ -12,7 +13,7 @@ const { generateCompactSchema } = require("./generate_compact_schema.cjs");
  * @returns {{ filename: string, description: string }} Object with filename and description
  */
 function writeLargeContentToFile(content) {
-  const logsDir = "/tmp/gh-aw/safeoutputs";
+  const logsDir = `${TMP_GH_AW_PATH}/safeoutputs`;
 
   try {
     fs.mkdirSync(logsDir, { recursive: true });
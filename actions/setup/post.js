@@ -1,17 +1,16 @@
 // Setup Activation Action - Post Step
-// Sends an OTLP conclusion span for the job, then removes the /tmp/gh-aw/
-// directory created during the main action step.
+// Sends an OTLP conclusion span for the job, then removes the per-job scratch
+// directory GH_AW_TMP_DIR created during the main action step.
 // Runs in the post-job phase so that temporary files are erased after the
 // workflow job completes, regardless of success or failure.
 //
-// Files inside /tmp/gh-aw/ may be owned by root (written by Docker containers
-// or privileged scripts), so we use `sudo rm -rf` — GitHub-hosted runners have
-// passwordless sudo.  We fall back to fs.rmSync for self-hosted runners that
-// don't have sudo but do have direct write access.
+// Only the directory of this job is removed (see removeTmpGhAwJobDir), so
+// concurrent jobs on the same host keep their files.
 
 const path = require("path");
 const { spawnSync } = require("child_process");
 const fs = require("fs");
+const { getTmpGhAwJobDir, removeTmpGhAwJobDir } = require("./js/gh_aw_tmp_dir.cjs");
 
 function isDebugModeEnabled() {
   const toBool = value => {
@@ -81,8 +80,10 @@ function listTmpGhAwFiles(tmpDir, maxDepth, maxFiles) {
 }
 
 // Wrap everything in an async IIFE so that the OTLP span is fully sent before
-// the cleanup deletes /tmp/gh-aw/ (which contains aw_info.json and otel.jsonl).
+// the cleanup deletes the scratch directory (which contains aw_info.json and otel.jsonl).
 (async () => {
+  const tmpDir = getTmpGhAwJobDir();
+
   // Send a gh-aw.<jobName>.conclusion span to the configured OTLP endpoint, if any.
   // Delegates to action_conclusion_otlp.cjs so that script mode (clean.sh) and
   // dev/release mode share the same implementation.  Non-fatal: errors are
@@ -94,26 +95,23 @@ function listTmpGhAwFiles(tmpDir, maxDepth, maxFiles) {
     // Non-fatal: silently ignore any OTLP export errors in the post step.
   }
 
-  const tmpDir = "/tmp/gh-aw";
   const maxDebugDepth = 4;
   const maxDebugFiles = 200;
 
-  if (isDebugModeEnabled()) {
-    listTmpGhAwFiles(tmpDir, maxDebugDepth, maxDebugFiles);
-  }
-
-  console.log(`Cleaning up ${tmpDir}...`);
-
-  // Try sudo rm -rf first (handles root-owned files on GitHub-hosted runners)
-  const result = spawnSync("sudo", ["rm", "-rf", tmpDir], { stdio: "inherit" });
-
-  if (result.status === 0) {
-    console.log(`Cleaned up ${tmpDir}`);
+  if (!tmpDir) {
+    console.log("Skipping cleanup of the gh-aw scratch directory: GH_AW_TMP_DIR is not set to a job scratch directory");
   } else {
-    // Fall back to fs.rmSync for environments without sudo
+    if (isDebugModeEnabled()) {
+      listTmpGhAwFiles(tmpDir, maxDebugDepth, maxDebugFiles);
+    }
+
+    console.log(`Cleaning up ${tmpDir}...`);
     try {
-      fs.rmSync(tmpDir, { recursive: true, force: true });
-      console.log(`Cleaned up ${tmpDir}`);
+      if (removeTmpGhAwJobDir(tmpDir)) {
+        console.log(`Cleaned up ${tmpDir}`);
+      } else {
+        console.log(`Skipping cleanup of ${tmpDir}: not a gh-aw job scratch directory`);
+      }
     } catch (err) {
       // Log but do not fail — cleanup is best-effort
       console.error(`Warning: failed to clean up ${tmpDir}: ${err.message}`);
@@ -91,38 +91,27 @@ debug_log "Safe-output custom tokens support: ${SAFE_OUTPUT_CUSTOM_TOKENS_ENABLE
 create_dir "${DESTINATION}"
 debug_log "Created directory: ${DESTINATION}"
 
-# Remove and recreate /tmp/gh-aw directory to ensure a clean state.
-# On persistent runners, a previous AWF run may leave this directory (or subdirectories
-# like sandbox/firewall/) owned by root. Plain rm -rf fails with EACCES in that case,
-# so we fall back to sudo rm -rf which is available passwordless on GitHub-hosted runners.
-if [ -d /tmp/gh-aw ] && [ ! -w /tmp/gh-aw ]; then
-  debug_log "/tmp/gh-aw exists but is not writable (likely root-owned from prior run); using sudo to remove"
-  if command -v sudo >/dev/null 2>&1; then
-    sudo -n rm -rf /tmp/gh-aw
-  else
-    echo "::error::/tmp/gh-aw exists but is not writable, and sudo is not available to reclaim it."
-    exit 1
-  fi
-elif [ -d /tmp/gh-aw ]; then
-  # Directory is writable — but subdirectories may not be (e.g., sandbox/firewall/ owned by root).
-  # Attempt plain rm first; if it fails, escalate to sudo.
-  if ! rm -rf /tmp/gh-aw 2>/dev/null; then
-    debug_log "/tmp/gh-aw has non-writable children (likely root-owned from prior AWF run); using sudo to remove"
-    if command -v sudo >/dev/null 2>&1; then
-      sudo -n rm -rf /tmp/gh-aw
-    else
-      echo "::error::/tmp/gh-aw contains non-writable children, and sudo is not available to reclaim it."
-      exit 1
-    fi
-  fi
-fi
-mkdir -p /tmp/gh-aw
-debug_log "Created /tmp/gh-aw directory"
-
 # Get the directory where this script is located
 SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
 JS_SOURCE_DIR="${SCRIPT_DIR}/js"
 
+# Create the per-job scratch directory /tmp/gh-aw/<run-id>-<run-attempt>/<job>-<suffix>.
+# Several self-hosted runner instances on one host share /tmp, so each job works in a
+# fresh directory of its own and never touches the files of a concurrent job, including
+# the other legs of a matrix or fan-out. The directory is exported as GH_AW_TMP_DIR for
+# the scripts of later steps, and GH_AW_JOB_SCOPE (e.g. 123456-1-agent-Ab3dE9xZ) names
+# the Docker networks and containers the job creates.
+# shellcheck source=sh/gh_aw_tmp_dir_lib.sh
+source "${SCRIPT_DIR}/sh/gh_aw_tmp_dir_lib.sh"
+GH_AW_TMP_DIR="$(gh_aw_create_tmp_dir "${GITHUB_RUN_ID:-}" "${GITHUB_RUN_ATTEMPT:-}" "${GITHUB_JOB:-}")"
+GH_AW_JOB_SCOPE="$(gh_aw_tmp_dir_scope "${GH_AW_TMP_DIR}")"
+export GH_AW_TMP_DIR GH_AW_JOB_SCOPE
+debug_log "Created ${GH_AW_TMP_DIR} directory"
+if [ -n "${GITHUB_ENV:-}" ]; then
+  echo "GH_AW_TMP_DIR=${GH_AW_TMP_DIR}" >>"${GITHUB_ENV}"
+  echo "GH_AW_JOB_SCOPE=${GH_AW_JOB_SCOPE}" >>"${GITHUB_ENV}"
+fi
+
 debug_log "Script directory: ${SCRIPT_DIR}"
 debug_log "Looking for JavaScript sources in: ${JS_SOURCE_DIR}"
 
@@ -364,6 +353,7 @@ SAFE_OUTPUTS_FILES=(
   "markdown_code_region_balancer.cjs"
   "temporary_id.cjs"
   "invocation_context_helpers.cjs"
+  "output_language.cjs"
 )
 
 SAFE_OUTPUTS_COUNT=0
@@ -1,9 +1,12 @@
 #!/usr/bin/env bash
 set +o histexpand
 
+# Scratch directory of this job, exported by actions/setup/setup.sh.
+: "${GH_AW_TMP_DIR:?GH_AW_TMP_DIR is not set: the gh-aw setup step must run before this script}"
+
 # Append the agent's step summary to the real $GITHUB_STEP_SUMMARY.
 # The file was written by the agent and already redacted for secrets.
 # This is a no-op when the file is empty (agent wrote nothing).
-if [ -s /tmp/gh-aw/agent-step-summary.md ]; then
-  cat /tmp/gh-aw/agent-step-summary.md >> "$GITHUB_STEP_SUMMARY"
+if [ -s "${GH_AW_TMP_DIR}/agent-step-summary.md" ]; then
+  cat "${GH_AW_TMP_DIR}/agent-step-summary.md" >> "$GITHUB_STEP_SUMMARY"
 fi
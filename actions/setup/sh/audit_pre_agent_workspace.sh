@@ -47,8 +47,11 @@ set +o histexpand
 
 set -euo pipefail
 
-AUDIT_FILE="/tmp/gh-aw/pre-agent-audit.txt"
-mkdir -p /tmp/gh-aw
+# Scratch directory of this job, exported by actions/setup/setup.sh.
+: "${GH_AW_TMP_DIR:?GH_AW_TMP_DIR is not set: the gh-aw setup step must run before this script}"
+
+AUDIT_FILE="${GH_AW_TMP_DIR}/pre-agent-audit.txt"
+mkdir -p "${GH_AW_TMP_DIR}"
 
 # list_dir prints a section header and runs find on the given directory,
 # pruning common cache folders so find does not descend into them.
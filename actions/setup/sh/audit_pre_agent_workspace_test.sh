@@ -9,6 +9,9 @@ set -e
 SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
 SCRIPT_PATH="${SCRIPT_DIR}/audit_pre_agent_workspace.sh"
 
+# The script runs in the job scratch directory that setup.sh exports in workflows.
+export GH_AW_TMP_DIR="${GH_AW_TMP_DIR:-/tmp/gh-aw}"
+
 # Test counters
 TESTS_PASSED=0
 TESTS_FAILED=0
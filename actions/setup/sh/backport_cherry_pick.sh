@@ -0,0 +1,120 @@
+#!/usr/bin/env bash
+# Cherry-pick commits onto a fresh branch cut from a backport target branch.
+#
+# Usage: backport_cherry_pick.sh <target-branch> <commit>...
+#
+# The target branch must match one of the globs in
+# ${GH_AW_BACKPORT_DIR}/allowed-branches.txt (one per line, written by the
+# "Install backport helper" step from safe-outputs.create-pull-request.allowed-base-branches)
+# and must have been fetched as origin/<target-branch>.
+#
+# On success the new local branch is checked out and its name is printed.
+# Commits that are already present on the target branch are skipped.
+# On conflict the cherry-pick is aborted, the conflicting files and hunks are written to
+# ${GH_AW_BACKPORT_DIR}/conflicts-<target>.md and the script exits with status 2.
+set -euo pipefail
+set +o histexpand
+
+# Scratch directory of this job, exported by actions/setup/setup.sh.
+: "${GH_AW_TMP_DIR:?GH_AW_TMP_DIR is not set: the gh-aw setup step must run before this script}"
+
+BACKPORT_DIR="${GH_AW_BACKPORT_DIR:-${GH_AW_TMP_DIR}/backport}"
+ALLOWED_FILE="${BACKPORT_DIR}/allowed-branches.txt"
+
+if [ "$#" -lt 2 ]; then
+  echo "Usage: $(basename "$0") <target-branch> <commit>..." >&2
+  exit 64
+fi
+
+TARGET="$1"
+shift
+
+allowed=false
+if [ -f "${ALLOWED_FILE}" ]; then
+  while IFS= read -r pattern || [ -n "${pattern}" ]; do
+    [ -z "${pattern}" ] && continue
+    # shellcheck disable=SC2053 # pattern is intentionally unquoted so globs match
+    if [[ "${TARGET}" == ${pattern} ]]; then
+      allowed=true
+      break
+    fi
+  done <"${ALLOWED_FILE}"
+fi
+if [ "${allowed}" != "true" ]; then
+  echo "Target branch '${TARGET}' is not an allowed backport target (see ${ALLOWED_FILE})" >&2
+  exit 1
+fi
+
+if ! git rev-parse --verify --quiet "refs/remotes/origin/${TARGET}" >/dev/null; then
+  echo "Branch 'origin/${TARGET}' is not available in this checkout. Add it to checkout.fetch in the workflow frontmatter." >&2
+  exit 1
+fi
+
+# Resolve every commit up front: symbolic refs such as HEAD move once the backport branch is checked out.
+commits=()
+for commit in "$@"; do
+  if ! sha="$(git rev-parse --verify --quiet "${commit}^{commit}")"; then
+    echo "Commit '${commit}' was not found. Use checkout.fetch-depth: 0 so the source history is available." >&2
+    exit 1
+  fi
+  commits+=("${sha}")
+done
+
+if [ -n "$(git status --porcelain --untracked-files=no)" ]; then
+  echo "The working tree has uncommitted changes; commit or discard them before backporting" >&2
+  exit 1
+fi
+
+SAFE_TARGET="$(printf '%s' "${TARGET}" | tr -c 'A-Za-z0-9._-' '-')"
+BRANCH="backport/${SAFE_TARGET}/$(git rev-parse --short "${commits[-1]}")"
+REPORT="${BACKPORT_DIR}/conflicts-${SAFE_TARGET}.md"
+mkdir -p "${BACKPORT_DIR}"
+rm -f "${REPORT}"
+
+git checkout --quiet -B "${BRANCH}" "refs/remotes/origin/${TARGET}"
+
+for commit in "${commits[@]}"; do
+  pick_args=(-x)
+  # Merge commits need a mainline parent; backport the changes relative to the first parent.
+  if [ "$(git rev-list --parents -n 1 "${commit}" | wc -w)" -gt 2 ]; then
+    pick_args+=(-m 1)
+  fi
+
+  if git cherry-pick "${pick_args[@]}" "${commit}" >"${BACKPORT_DIR}/cherry-pick.log" 2>&1; then
+    continue
+  fi
+
+  conflicted="$(git diff --name-only --diff-filter=U)"
+  if [ -z "${conflicted}" ] && git cherry-pick --skip >/dev/null 2>&1; then
+    echo "Skipped ${commit}: its changes are already present on ${TARGET}" >&2
+    continue
+  fi
+
+  git diff >"${BACKPORT_DIR}/conflicts.diff" || true
+  {
+    echo "# Cherry-pick conflict: ${commit} onto ${TARGET}"
+    echo
+    echo "## Conflicting files"
+    echo
+    printf '%s\n' "${conflicted}" | sed '/^$/d; s/^/- /'
+    echo
+    echo "## Conflicting hunks"
+    echo
+    echo '```diff'
+    head -n 500 "${BACKPORT_DIR}/conflicts.diff"
+    echo '```'
+    echo
+    echo "## git output"
+    echo
+    echo '```'
+    cat "${BACKPORT_DIR}/cherry-pick.log"
+    echo '```'
+  } >"${REPORT}"
+  rm -f "${BACKPORT_DIR}/conflicts.diff"
+
+  git cherry-pick --abort >/dev/null 2>&1 || true
+  echo "Conflict while cherry-picking ${commit} onto ${TARGET}; details written to ${REPORT}" >&2
+  exit 2
+done
+
+echo "${BRANCH}"
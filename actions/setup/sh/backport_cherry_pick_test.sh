@@ -0,0 +1,127 @@
+#!/usr/bin/env bash
+set +o histexpand
+
+set -euo pipefail
+
+SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
+SCRIPT="${SCRIPT_DIR}/backport_cherry_pick.sh"
+
+# The script runs in the job scratch directory that setup.sh exports in workflows.
+export GH_AW_TMP_DIR="${GH_AW_TMP_DIR:-/tmp/gh-aw}"
+
+TESTS_PASSED=0
+TESTS_FAILED=0
+WORKSPACE="$(mktemp -d)"
+
+cleanup() {
+  rm -rf "${WORKSPACE}"
+}
+trap cleanup EXIT
+
+assert() {
+  local name="$1"
+  local condition="$2"
+  if eval "${condition}" 2>/dev/null; then
+    echo "  ✓ ${name}"
+    TESTS_PASSED=$((TESTS_PASSED + 1))
+  else
+    echo "  ✗ ${name}"
+    TESTS_FAILED=$((TESTS_FAILED + 1))
+  fi
+}
+
+# make_clone creates an origin with main and release/1.2, then clones it into $1.
+# main gets a clean fix (fix.txt) and a change to shared.txt that conflicts with release/1.2.
+make_clone() {
+  local dir="$1"
+  local origin="${dir}-origin"
+  mkdir -p "${origin}"
+  pushd "${origin}" >/dev/null
+  git init -q -b main
+  git config user.email "test@example.com"
+  git config user.name "test"
+  echo "base" >shared.txt
+  git add shared.txt
+  git commit -q -m "init"
+  git branch release/1.2
+  echo "fix" >fix.txt
+  git add fix.txt
+  git commit -q -m "fix"
+  echo "main change" >shared.txt
+  git commit -q -am "main change"
+  git checkout -q release/1.2
+  echo "release change" >shared.txt
+  git commit -q -am "release change"
+  git checkout -q main
+  popd >/dev/null
+  git clone -q "${origin}" "${dir}"
+  git -C "${dir}" config user.email "test@example.com"
+  git -C "${dir}" config user.name "test"
+}
+
+run_script() {
+  local dir="$1"
+  shift
+  (cd "${dir}" && GH_AW_BACKPORT_DIR="${dir}-backport" bash "${SCRIPT}" "$@" 2>&1)
+}
+
+echo "Testing backport_cherry_pick.sh"
+echo ""
+
+echo "Test 1: Script syntax is valid"
+assert "script passes bash -n" "bash -n '${SCRIPT}'"
+echo ""
+
+echo "Test 2: Target outside the allowed globs is rejected"
+D="${WORKSPACE}/test2"
+make_clone "${D}"
+mkdir -p "${D}-backport"
+echo "hotfix/*" >"${D}-backport/allowed-branches.txt"
+set +e
+OUTPUT="$(run_script "${D}" release/1.2 HEAD~1)"
+EXIT_CODE=$?
+set -e
+assert "script fails" "[ '${EXIT_CODE}' -eq 1 ]"
+assert "error names the target" "printf '%s' \"${OUTPUT}\" | grep -q 'not an allowed backport target'"
+echo ""
+
+echo "Test 3: Clean cherry-pick creates a backport branch"
+D="${WORKSPACE}/test3"
+make_clone "${D}"
+mkdir -p "${D}-backport"
+echo "release/*" >"${D}-backport/allowed-branches.txt"
+FIX_SHA="$(git -C "${D}" rev-parse HEAD~1)"
+set +e
+OUTPUT="$(run_script "${D}" release/1.2 "${FIX_SHA}")"
+EXIT_CODE=$?
+set -e
+assert "script exits successfully" "[ '${EXIT_CODE}' -eq 0 ]"
+assert "branch name is printed" "printf '%s' \"${OUTPUT}\" | grep -q '^backport/release-1.2/'"
+assert "backport branch is checked out" "git -C '${D}' rev-parse --abbrev-ref HEAD | grep -q '^backport/release-1.2/'"
+assert "fix is applied on top of release/1.2" "[ -f '${D}/fix.txt' ] && grep -q 'release change' '${D}/shared.txt'"
+assert "commit records its origin" "git -C '${D}' log -1 --format=%B | grep -q 'cherry picked from commit ${FIX_SHA}'"
+echo ""
+
+echo "Test 4: Conflicts are captured and the cherry-pick is aborted"
+D="${WORKSPACE}/test4"
+make_clone "${D}"
+mkdir -p "${D}-backport"
+echo "release/*" >"${D}-backport/allowed-branches.txt"
+set +e
+OUTPUT="$(run_script "${D}" release/1.2 HEAD)"
+EXIT_CODE=$?
+set -e
+assert "script exits with status 2" "[ '${EXIT_CODE}' -eq 2 ]"
+assert "conflict report is written" "[ -f '${D}-backport/conflicts-release-1.2.md' ]"
+assert "conflict report lists the file" "grep -q '^- shared.txt' '${D}-backport/conflicts-release-1.2.md'"
+assert "cherry-pick is aborted" "[ ! -f '${D}/.git/CHERRY_PICK_HEAD' ] && [ -z \"\$(git -C '${D}' status --porcelain --untracked-files=no)\" ]"
+echo ""
+
+echo "Tests passed: ${TESTS_PASSED}"
+echo "Tests failed: ${TESTS_FAILED}"
+
+if [ "${TESTS_FAILED}" -gt 0 ]; then
+  exit 1
+fi
+
+echo "✓ All tests passed!"
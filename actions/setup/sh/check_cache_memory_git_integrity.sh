@@ -3,7 +3,10 @@ set +o histexpand
 
 set -euo pipefail
 
-CACHE_DIR="${GH_AW_CACHE_DIR:-/tmp/gh-aw/cache-memory}"
+# Scratch directory of this job, exported by actions/setup/setup.sh.
+: "${GH_AW_TMP_DIR:?GH_AW_TMP_DIR is not set: the gh-aw setup step must run before this script}"
+
+CACHE_DIR="${GH_AW_CACHE_DIR:-${GH_AW_TMP_DIR}/cache-memory}"
 
 if [ -d "$CACHE_DIR/.git" ]; then
   if ! git -C "$CACHE_DIR" fsck --no-dangling >/dev/null 2>&1; then
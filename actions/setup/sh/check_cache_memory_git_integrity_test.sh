@@ -6,6 +6,9 @@ set -euo pipefail
 SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
 SCRIPT="${SCRIPT_DIR}/check_cache_memory_git_integrity.sh"
 
+# The script runs in the job scratch directory that setup.sh exports in workflows.
+export GH_AW_TMP_DIR="${GH_AW_TMP_DIR:-/tmp/gh-aw}"
+
 TESTS_PASSED=0
 TESTS_FAILED=0
 WORKSPACE="$(mktemp -d)"
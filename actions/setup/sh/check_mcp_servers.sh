@@ -16,6 +16,9 @@ set +o histexpand
 
 set -e
 
+# Scratch directory of this job, exported by actions/setup/setup.sh.
+: "${GH_AW_TMP_DIR:?GH_AW_TMP_DIR is not set: the gh-aw setup step must run before this script}"
+
 # Timing helper functions
 print_timing() {
   local start_time=$1
@@ -261,8 +264,8 @@ if [ $REQUIRED_SERVERS_FAILED -gt 0 ]; then
   echo "  - Network connectivity or DNS issues"
   echo ""
   echo "Check the gateway logs and individual server logs for more details:"
-  echo "  /tmp/gh-aw/mcp-logs/stderr.log"
-  echo "  /tmp/gh-aw/mcp-logs/start-gateway.log"
+  echo "  ${GH_AW_TMP_DIR}/mcp-logs/stderr.log"
+  echo "  ${GH_AW_TMP_DIR}/mcp-logs/start-gateway.log"
   exit 1
 elif [ $SERVERS_SUCCEEDED -eq 0 ] && [ $SERVERS_FAILED -eq 0 ]; then
   echo "ERROR: No HTTP servers were successfully checked"
@@ -280,8 +283,8 @@ elif [ $SERVERS_SUCCEEDED -eq 0 ]; then
   echo "At least one server must connect for the gateway to be considered healthy."
   echo ""
   echo "Check the gateway logs and individual server logs for more details:"
-  echo "  /tmp/gh-aw/mcp-logs/stderr.log"
-  echo "  /tmp/gh-aw/mcp-logs/start-gateway.log"
+  echo "  ${GH_AW_TMP_DIR}/mcp-logs/stderr.log"
+  echo "  ${GH_AW_TMP_DIR}/mcp-logs/start-gateway.log"
   exit 1
 else
   if [ $SERVERS_FAILED -gt 0 ]; then
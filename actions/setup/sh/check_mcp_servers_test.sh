@@ -7,6 +7,9 @@ set -e
 SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
 SCRIPT_PATH="$SCRIPT_DIR/check_mcp_servers.sh"
 
+# The script runs in the job scratch directory that setup.sh exports in workflows.
+export GH_AW_TMP_DIR="${GH_AW_TMP_DIR:-/tmp/gh-aw}"
+
 # Color codes for output
 GREEN='\033[0;32m'
 RED='\033[0;31m'
@@ -3,7 +3,10 @@ set +o histexpand
 
 set -euo pipefail
 
-RESULT_FILE="${1:-/tmp/gh-aw/threat-detection/detection_result.json}"
+# Scratch directory of this job, exported by actions/setup/setup.sh.
+: "${GH_AW_TMP_DIR:?GH_AW_TMP_DIR is not set: the gh-aw setup step must run before this script}"
+
+RESULT_FILE="${1:-${GH_AW_TMP_DIR}/threat-detection/detection_result.json}"
 RESULT_DIR="$(dirname "${RESULT_FILE}")"
 DETECTION_LOG_FILE="${DETECTION_LOG_FILE:-${RESULT_DIR}/detection.log}"
 DETECTION_STATUS_PREFIX="THREAT_DETECTION_STATUS:"
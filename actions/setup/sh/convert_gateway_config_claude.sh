@@ -7,6 +7,9 @@ set +o histexpand
 
 set -e
 
+# Scratch directory of this job, exported by actions/setup/setup.sh.
+: "${GH_AW_TMP_DIR:?GH_AW_TMP_DIR is not set: the gh-aw setup step must run before this script}"
+
 # Restrict default file creation mode to owner-only (rw-------) for all new files.
 # This prevents the race window between file creation via output redirection and
 # a subsequent chmod, which would leave credential-bearing files world-readable
@@ -82,19 +85,23 @@ jq --arg urlPrefix "$URL_PREFIX" '
   .mcpServers |= with_entries(
     .value |= (
       (.type = "http") |
+      # The upstream transport is handled by the gateway; clients always use streamable HTTP
+      (del(.transport)) |
+      # Per-server lifecycle limits are enforced by the gateway itself
+      (del(.startupTimeout, .toolTimeout, .restart, .maxRestarts)) |
       # Fix the URL to use the correct domain
       .url |= (. | sub("^http://[^/]+/mcp/"; $urlPrefix + "/mcp/"))
     )
   )
-' "$MCP_GATEWAY_OUTPUT" > /tmp/gh-aw/mcp-config/mcp-servers.json
+' "$MCP_GATEWAY_OUTPUT" > "${GH_AW_TMP_DIR}/mcp-config/mcp-servers.json"
 
 # Restrict permissions so only the runner process owner can read this file.
 # mcp-servers.json contains the bearer token for the MCP gateway; an attacker
 # who reads it could bypass the --allowed-tools constraint by issuing raw
 # JSON-RPC calls directly to the gateway.
-chmod 600 /tmp/gh-aw/mcp-config/mcp-servers.json
+chmod 600 "${GH_AW_TMP_DIR}/mcp-config/mcp-servers.json"
 
-echo "Claude configuration written to /tmp/gh-aw/mcp-config/mcp-servers.json"
+echo "Claude configuration written to ${GH_AW_TMP_DIR}/mcp-config/mcp-servers.json"
 echo ""
 echo "Converted configuration:"
-cat /tmp/gh-aw/mcp-config/mcp-servers.json
+cat "${GH_AW_TMP_DIR}/mcp-config/mcp-servers.json"
@@ -7,6 +7,9 @@ set +o histexpand
 
 set -e
 
+# Scratch directory of this job, exported by actions/setup/setup.sh.
+: "${GH_AW_TMP_DIR:?GH_AW_TMP_DIR is not set: the gh-aw setup step must run before this script}"
+
 # Restrict default file creation mode to owner-only (rw-------) for all new files.
 # This prevents the race window between file creation via output redirection and
 # a subsequent chmod, which would leave credential-bearing files world-readable
@@ -80,7 +83,7 @@ fi
 URL_PREFIX="http://${RESOLVED_DOMAIN}:${MCP_GATEWAY_PORT}"
 
 # Create the TOML configuration
-cat > /tmp/gh-aw/mcp-config/config.toml << 'TOML_EOF'
+cat > "${GH_AW_TMP_DIR}/mcp-config/config.toml" << 'TOML_EOF'
 [history]
 persistence = "none"
 
@@ -91,14 +94,14 @@ jq -r --arg urlPrefix "$URL_PREFIX" '
   "[mcp_servers.\(.key)]\n" +
   "url = \"" + ($urlPrefix + "/mcp/" + .key) + "\"\n" +
   "http_headers = { Authorization = \"\(.value.headers.Authorization)\" }\n"
-' "$MCP_GATEWAY_OUTPUT" >> /tmp/gh-aw/mcp-config/config.toml
+' "$MCP_GATEWAY_OUTPUT" >> "${GH_AW_TMP_DIR}/mcp-config/config.toml"
 
 # Restrict permissions so only the runner process owner can read this file.
 # config.toml contains the bearer token for the MCP gateway; an attacker
 # who reads it could issue raw JSON-RPC calls directly to the gateway.
-chmod 600 /tmp/gh-aw/mcp-config/config.toml
+chmod 600 "${GH_AW_TMP_DIR}/mcp-config/config.toml"
 
-echo "Codex configuration written to /tmp/gh-aw/mcp-config/config.toml"
+echo "Codex configuration written to ${GH_AW_TMP_DIR}/mcp-config/config.toml"
 echo ""
 echo "Converted configuration:"
-cat /tmp/gh-aw/mcp-config/config.toml
+cat "${GH_AW_TMP_DIR}/mcp-config/config.toml"
@@ -92,6 +92,10 @@ jq --arg urlPrefix "$URL_PREFIX" '
     .value |= (
       # Add tools field if not present
       (if .tools then . else . + {"tools": ["*"]} end) |
+      # The upstream transport is handled by the gateway; clients always use streamable HTTP
+      del(.transport) |
+      # Per-server lifecycle limits are enforced by the gateway itself
+      del(.startupTimeout, .toolTimeout, .restart, .maxRestarts) |
       # Fix the URL to use the correct domain
       # Replace http://anything:port/mcp/ with http://domain:port/mcp/
       .url |= (. | sub("^http://[^/]+/mcp/"; $urlPrefix + "/mcp/"))
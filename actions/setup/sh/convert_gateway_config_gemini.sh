@@ -105,6 +105,10 @@ jq --arg urlPrefix "$URL_PREFIX" --argjson cliServers "${GH_AW_MCP_CLI_SERVERS:-
     select(.key | IN($cliServers[]) | not) |
     .value |= (
       (del(.type)) |
+      # The upstream transport is handled by the gateway; clients always use streamable HTTP
+      (del(.transport)) |
+      # Per-server lifecycle limits are enforced by the gateway itself
+      (del(.startupTimeout, .toolTimeout, .restart, .maxRestarts)) |
       # Fix the URL to use the correct domain
       .url |= (. | sub("^http://[^/]+/mcp/"; $urlPrefix + "/mcp/"))
     )
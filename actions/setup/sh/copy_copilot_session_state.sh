@@ -15,8 +15,11 @@ set +o histexpand
 
 set -euo pipefail
 
+# Scratch directory of this job, exported by actions/setup/setup.sh.
+: "${GH_AW_TMP_DIR:?GH_AW_TMP_DIR is not set: the gh-aw setup step must run before this script}"
+
 SESSION_STATE_DIR="$HOME/.copilot/session-state"
-LOGS_DIR="/tmp/gh-aw/sandbox/agent/logs/copilot-session-state"
+LOGS_DIR="${GH_AW_TMP_DIR}/sandbox/agent/logs/copilot-session-state"
 
 if [ -d "$SESSION_STATE_DIR" ]; then
   echo "Copying Copilot session state from $SESSION_STATE_DIR to $LOGS_DIR"
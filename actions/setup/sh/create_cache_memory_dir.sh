@@ -1,6 +1,10 @@
 #!/usr/bin/env bash
 set +o histexpand
-mkdir -p /tmp/gh-aw/cache-memory
-echo "Cache memory directory created at /tmp/gh-aw/cache-memory"
+
+# Scratch directory of this job, exported by actions/setup/setup.sh.
+: "${GH_AW_TMP_DIR:?GH_AW_TMP_DIR is not set: the gh-aw setup step must run before this script}"
+
+mkdir -p "${GH_AW_TMP_DIR}/cache-memory"
+echo "Cache memory directory created at ${GH_AW_TMP_DIR}/cache-memory"
 echo "This folder provides persistent file storage across workflow runs"
 echo "LLMs and agentic tools can freely read and write files in this directory"
@@ -0,0 +1,87 @@
+#!/usr/bin/env bash
+set +o histexpand
+
+#
+# encrypt_artifact.sh - Encrypt artifact files with age before upload
+#
+# Packs the given files and directories (all under ${GH_AW_TMP_DIR}/) into a gzip-compressed
+# tar archive and encrypts it to the age recipients configured in the workflow's
+# artifact-encryption frontmatter. Paths inside the archive are relative to ${GH_AW_TMP_DIR}/,
+# so `gh aw logs` can decrypt and extract them into the same layout as the plaintext
+# artifacts. Glob patterns are expanded; paths that do not exist are skipped.
+#
+# Usage:
+#   encrypt_artifact.sh <output-file> <path>...
+#
+# Environment variables:
+#   GH_AW_AGE_RECIPIENTS (required) newline-separated age recipients (age1... or ssh-... keys)
+#   GH_AW_TMP_DIR        (required) scratch directory of the job, exported by setup.sh
+#   GH_AW_ENCRYPT_ROOT   (optional) root of the archived paths, defaults to ${GH_AW_TMP_DIR}
+#
+# When none of the paths exist, no output file is written and the script exits 0
+# (the upload step ignores missing files).
+
+set -euo pipefail
+
+# Scratch directory of this job, exported by actions/setup/setup.sh.
+: "${GH_AW_TMP_DIR:?GH_AW_TMP_DIR is not set: the gh-aw setup step must run before this script}"
+
+shopt -s nullglob
+
+if [ "$#" -lt 2 ]; then
+  echo "Usage: encrypt_artifact.sh <output-file> <path>..." >&2
+  exit 2
+fi
+
+OUTPUT="$1"
+shift
+ROOT="${GH_AW_ENCRYPT_ROOT:-${GH_AW_TMP_DIR}}"
+
+RECIPIENT_ARGS=()
+while IFS= read -r recipient; do
+  recipient="${recipient#"${recipient%%[![:space:]]*}"}"
+  recipient="${recipient%"${recipient##*[![:space:]]}"}"
+  if [ -n "${recipient}" ]; then
+    RECIPIENT_ARGS+=(--recipient "${recipient}")
+  fi
+done <<< "${GH_AW_AGE_RECIPIENTS:-}"
+
+if [ "${#RECIPIENT_ARGS[@]}" -eq 0 ]; then
+  echo "::error::GH_AW_AGE_RECIPIENTS is empty; cannot encrypt artifact" >&2
+  exit 1
+fi
+
+FILES=()
+for path in "$@"; do
+  path="${path%/}"
+  rel="${path#"${ROOT}"/}"
+  if [ "${rel}" = "${path}" ]; then
+    echo "::warning::Skipping ${path}: not under ${ROOT}" >&2
+    continue
+  fi
+  # Unquoted on purpose: expand glob patterns such as *.err
+  # shellcheck disable=SC2206
+  matches=("${ROOT}"/${rel})
+  for match in "${matches[@]}"; do
+    if [ -e "${match}" ]; then
+      FILES+=("${match#"${ROOT}"/}")
+    fi
+  done
+done
+
+if [ "${#FILES[@]}" -eq 0 ]; then
+  echo "No files to encrypt for $(basename "${OUTPUT}")"
+  exit 0
+fi
+
+if ! command -v age >/dev/null 2>&1; then
+  echo "Installing age..."
+  if ! sudo DEBIAN_FRONTEND=noninteractive apt-get install -y -qq age >/dev/null 2>&1; then
+    sudo apt-get update -qq >/dev/null
+    sudo DEBIAN_FRONTEND=noninteractive apt-get install -y -qq age >/dev/null
+  fi
+fi
+
+mkdir -p "$(dirname "${OUTPUT}")"
+tar -C "${ROOT}" -czf - "${FILES[@]}" | age --encrypt "${RECIPIENT_ARGS[@]}" --output "${OUTPUT}"
+echo "Encrypted ${#FILES[@]} path(s) into $(basename "${OUTPUT}")"
@@ -0,0 +1,97 @@
+#!/usr/bin/env bash
+set +o histexpand
+
+# Test script for encrypt_artifact.sh
+# Run: bash encrypt_artifact_test.sh
+#
+# A stub `age` on PATH records its arguments and copies stdin to --output, so the
+# archive contents can be checked without real keys.
+
+set -e
+
+SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
+SCRIPT_PATH="${SCRIPT_DIR}/encrypt_artifact.sh"
+
+# The script runs in the job scratch directory that setup.sh exports in workflows.
+export GH_AW_TMP_DIR="${GH_AW_TMP_DIR:-/tmp/gh-aw}"
+
+TESTS_PASSED=0
+TESTS_FAILED=0
+
+TEST_ROOT="$(mktemp -d)"
+
+cleanup() {
+  rm -rf "${TEST_ROOT}"
+}
+trap cleanup EXIT
+
+assert() {
+  local name="$1"
+  local condition="$2"
+  if eval "${condition}"; then
+    echo "✓ ${name}"
+    TESTS_PASSED=$((TESTS_PASSED + 1))
+  else
+    echo "✗ ${name}"
+    TESTS_FAILED=$((TESTS_FAILED + 1))
+  fi
+}
+
+mkdir -p "${TEST_ROOT}/bin"
+cat > "${TEST_ROOT}/bin/age" <<'STUB'
+#!/usr/bin/env bash
+echo "$@" > "${AGE_STUB_ARGS}"
+out=""
+while [ "$#" -gt 0 ]; do
+  if [ "$1" = "--output" ]; then out="$2"; shift; fi
+  shift
+done
+cat > "${out}"
+STUB
+chmod +x "${TEST_ROOT}/bin/age"
+export PATH="${TEST_ROOT}/bin:${PATH}"
+export AGE_STUB_ARGS="${TEST_ROOT}/age-args"
+
+echo "Testing encrypt_artifact.sh..."
+echo ""
+
+echo "Test 1: Script syntax is valid"
+assert "Script syntax is valid" "bash -n '${SCRIPT_PATH}'"
+echo ""
+
+echo "Test 2: Archives existing paths relative to the root"
+ROOT="${TEST_ROOT}/gh-aw"
+mkdir -p "${ROOT}/mcp-logs"
+echo "stdio" > "${ROOT}/agent-stdio.log"
+echo "gateway" > "${ROOT}/mcp-logs/gateway.jsonl"
+echo "err" > "${ROOT}/client.err"
+OUT="${ROOT}/agent-transcripts.tar.gz.age"
+GH_AW_ENCRYPT_ROOT="${ROOT}" GH_AW_AGE_RECIPIENTS=$'age1first\n age1second ' \
+  bash "${SCRIPT_PATH}" "${OUT}" "${ROOT}/agent-stdio.log" "${ROOT}/mcp-logs/" "${ROOT}/*.err" "${ROOT}/missing.log" >/dev/null
+LISTING="$(tar -tzf "${OUT}")"
+assert "Output file is written" "[ -s '${OUT}' ]"
+assert "Archive contains agent-stdio.log" "grep -qx 'agent-stdio.log' <<< \"\${LISTING}\""
+assert "Archive contains mcp-logs/gateway.jsonl" "grep -qx 'mcp-logs/gateway.jsonl' <<< \"\${LISTING}\""
+assert "Archive expands globs" "grep -qx 'client.err' <<< \"\${LISTING}\""
+assert "Every recipient is passed to age" "grep -q -- '--recipient age1first --recipient age1second' '${AGE_STUB_ARGS}'"
+echo ""
+
+echo "Test 3: No output when none of the paths exist"
+OUT3="${ROOT}/none.tar.gz.age"
+GH_AW_ENCRYPT_ROOT="${ROOT}" GH_AW_AGE_RECIPIENTS="age1first" bash "${SCRIPT_PATH}" "${OUT3}" "${ROOT}/missing.log" >/dev/null
+assert "No output file is written" "[ ! -e '${OUT3}' ]"
+echo ""
+
+echo "Test 4: Fails without recipients"
+if GH_AW_ENCRYPT_ROOT="${ROOT}" GH_AW_AGE_RECIPIENTS="" bash "${SCRIPT_PATH}" "${ROOT}/x.age" "${ROOT}/agent-stdio.log" >/dev/null 2>&1; then
+  assert "Missing recipients is an error" "false"
+else
+  assert "Missing recipients is an error" "true"
+fi
+echo ""
+
+echo "Tests passed: ${TESTS_PASSED}"
+echo "Tests failed: ${TESTS_FAILED}"
+if [ "${TESTS_FAILED}" -gt 0 ]; then
+  exit 1
+fi
@@ -1,5 +1,9 @@
 #!/usr/bin/env bash
 set +o histexpand
+
+# Scratch directory of this job, exported by actions/setup/setup.sh.
+: "${GH_AW_TMP_DIR:?GH_AW_TMP_DIR is not set: the gh-aw setup step must run before this script}"
+
 # Diagnostic logging: Show environment information
 echo "=== Diagnostic: Environment Information ==="
 echo "GITHUB_SHA: ${GITHUB_SHA@Q}"
@@ -30,7 +34,7 @@ get_patch_path() {
   if [ -z "$sanitized" ]; then
     sanitized="unknown"
   fi
-  echo "/tmp/gh-aw/aw-${sanitized}.patch"
+  echo "${GH_AW_TMP_DIR}/aw-${sanitized}.patch"
 }
 
 # Extract all branch names from JSONL output (for all create_pull_request and push_to_pull_request_branch entries)
@@ -65,7 +69,7 @@ if [ "${#BRANCH_NAMES[@]}" -eq 0 ]; then
 fi
 
 # Ensure /tmp/gh-aw directory exists
-mkdir -p /tmp/gh-aw
+mkdir -p "${GH_AW_TMP_DIR}"
 
 # Strategy 1: If we have branch names, generate a patch for each one
 PATCH_GENERATED=false
@@ -210,7 +214,7 @@ else
 fi
 
 # Show patch info for all aw-*.patch files
-for patch_file in /tmp/gh-aw/aw-*.patch; do
+for patch_file in "${GH_AW_TMP_DIR}/aw"-*.patch; do
   if [ -f "$patch_file" ]; then
     echo ""
     echo "=== Diagnostic: Patch file information: ${patch_file} ==="
@@ -0,0 +1,110 @@
+#!/usr/bin/env bash
+# Shared helpers for the per-job scratch directory under /tmp/gh-aw, sourced by
+# actions/setup/setup.sh and actions/setup/clean.sh.
+# Do not invoke this file directly — source it from those scripts.
+#
+# Several self-hosted runner instances on one host share /tmp, so every job works in
+# its own directory, /tmp/gh-aw/<run-id>-<run-attempt>/<job>-<random suffix>, and only
+# ever creates and removes that directory. The random suffix keeps matrix legs and
+# fan-out legs apart, which share the run ID, the attempt and the job ID. setup.sh
+# exports the directory as GH_AW_TMP_DIR and the compiler refers to it as
+# ${{ env.GH_AW_TMP_DIR }} (see constants.TmpGhAwDirExpr in pkg/constants/constants.go).
+
+GH_AW_TMP_ROOT="/tmp/gh-aw"
+
+# gh_aw_create_tmp_dir creates a new scratch directory for a job and prints its path.
+# The run ID and the attempt must be numeric and the job ID may only contain the
+# characters GitHub Actions allows in job IDs, so the result never escapes
+# ${GH_AW_TMP_ROOT}. The directory is created by mktemp and is therefore never shared
+# with another job.
+#
+# Examples:
+#   gh_aw_create_tmp_dir 123456 1 agent      → /tmp/gh-aw/123456-1/agent-Ab3dE9xZ
+#   gh_aw_create_tmp_dir 123456 1 ../agent   → error (exit status 1)
+gh_aw_create_tmp_dir() {
+  local run_id="$1"
+  local run_attempt="$2"
+  local job="$3"
+
+  if [[ ! "$run_id" =~ ^[0-9]+$ ]]; then
+    echo "::error::Invalid GITHUB_RUN_ID for the gh-aw scratch directory: '${run_id}'" >&2
+    return 1
+  fi
+  if [[ ! "$run_attempt" =~ ^[0-9]+$ ]]; then
+    echo "::error::Invalid GITHUB_RUN_ATTEMPT for the gh-aw scratch directory: '${run_attempt}'" >&2
+    return 1
+  fi
+  if [[ ! "$job" =~ ^[A-Za-z0-9_-]+$ ]]; then
+    echo "::error::Invalid GITHUB_JOB for the gh-aw scratch directory: '${job}'" >&2
+    return 1
+  fi
+
+  local run_dir="${GH_AW_TMP_ROOT}/${run_id}-${run_attempt}"
+  # Retry once: a concurrent job of this run may remove the empty run directory
+  # between mkdir and mktemp.
+  local attempt
+  for attempt in 1 2; do
+    mkdir -p "$run_dir" 2>/dev/null || true
+    if mktemp -d "${run_dir}/${job}-XXXXXXXX" 2>/dev/null; then
+      return 0
+    fi
+  done
+  echo "::error::Failed to create the gh-aw scratch directory in ${run_dir}" >&2
+  return 1
+}
+
+# gh_aw_is_tmp_dir succeeds when a path has the shape of a job scratch directory
+# created by gh_aw_create_tmp_dir.
+gh_aw_is_tmp_dir() {
+  [[ "$1" =~ ^/tmp/gh-aw/[0-9]+-[0-9]+/[A-Za-z0-9_-]+-[A-Za-z0-9]{8}$ ]]
+}
+
+# gh_aw_tmp_dir_scope prints an identifier of the job that owns a job scratch directory:
+# the path below ${GH_AW_TMP_ROOT} with slashes replaced by dashes. Like the directory, it
+# is unique on the host and names other host-wide resources of the job, such as Docker
+# networks and containers.
+#
+# Examples:
+#   gh_aw_tmp_dir_scope /tmp/gh-aw/123456-1/agent-Ab3dE9xZ → 123456-1-agent-Ab3dE9xZ
+gh_aw_tmp_dir_scope() {
+  local dir="$1"
+
+  if ! gh_aw_is_tmp_dir "$dir"; then
+    echo "::error::Not a gh-aw job scratch directory: '${dir}'" >&2
+    return 1
+  fi
+  local relative="${dir#"${GH_AW_TMP_ROOT}/"}"
+  echo "${relative//\//-}"
+}
+
+# gh_aw_remove_tmp_dir removes the scratch directory of the current job. Anything that
+# is not a job scratch directory is refused, so a bad GH_AW_TMP_DIR can never delete the
+# shared root, the directory of the run or anything outside of them. Files written by
+# containers or privileged AWF processes may be owned by root, so a failed plain removal
+# is retried with passwordless sudo (available on GitHub-hosted runners).
+gh_aw_remove_tmp_dir() {
+  local dir="$1"
+
+  if ! gh_aw_is_tmp_dir "$dir"; then
+    return 1
+  fi
+  if [ ! -e "$dir" ]; then
+    return 0
+  fi
+  if rm -rf "$dir" 2>/dev/null; then
+    return 0
+  fi
+  if command -v sudo >/dev/null 2>&1 && sudo -n rm -rf "$dir" 2>/dev/null; then
+    return 0
+  fi
+  return 1
+}
+
+# gh_aw_remove_empty_run_dir removes the run directory that contains a job scratch
+# directory once no other job of the run uses it. Directories that are not empty are
+# kept, so concurrent jobs of the same run are never affected.
+gh_aw_remove_empty_run_dir() {
+  local job_dir="$1"
+
+  rmdir "$(dirname "$job_dir")" 2>/dev/null || true
+}
@@ -0,0 +1,162 @@
+#!/usr/bin/env bash
+set +o histexpand
+
+# Test script for gh_aw_tmp_dir_lib.sh and the scratch directory handling of
+# actions/setup/setup.sh and actions/setup/clean.sh.
+# Run: bash gh_aw_tmp_dir_lib_test.sh
+
+set -euo pipefail
+
+SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
+# shellcheck source=gh_aw_tmp_dir_lib.sh
+source "${SCRIPT_DIR}/gh_aw_tmp_dir_lib.sh"
+
+TESTS_PASSED=0
+TESTS_FAILED=0
+
+# Unique run IDs keep the test away from the directories of real jobs on this host.
+RUN_ID="$((900000000 + $$))"
+OTHER_RUN_ID="$((RUN_ID + 1))"
+RUN_DIR="/tmp/gh-aw/${RUN_ID}-1"
+OTHER_RUN_DIR="/tmp/gh-aw/${OTHER_RUN_ID}-1"
+
+cleanup() {
+  rm -rf "${RUN_DIR}" "/tmp/gh-aw/${RUN_ID}-2" "${OTHER_RUN_DIR}" 2>/dev/null || true
+}
+trap cleanup EXIT
+
+assert() {
+  local name="$1"
+  local condition="$2"
+  if eval "${condition}" 2>/dev/null; then
+    echo "  ✓ ${name}"
+    TESTS_PASSED=$((TESTS_PASSED + 1))
+  else
+    echo "  ✗ ${name}"
+    TESTS_FAILED=$((TESTS_FAILED + 1))
+  fi
+}
+
+echo "Testing gh_aw_tmp_dir_lib.sh"
+echo ""
+
+# ── Test 1: Directory creation ──────────────────────────────────────────────
+echo "Test 1: Directory creation"
+FIRST_DIR="$(gh_aw_create_tmp_dir "${RUN_ID}" 1 agent)"
+SECOND_DIR="$(gh_aw_create_tmp_dir "${RUN_ID}" 1 agent)"
+RETRY_DIR="$(gh_aw_create_tmp_dir "${RUN_ID}" 2 agent)"
+assert "creates /tmp/gh-aw/<run-id>-<run-attempt>/<job>-<suffix>" "[[ '${FIRST_DIR}' == ${RUN_DIR}/agent-???????? ]] && [ -d '${FIRST_DIR}' ]"
+assert "creates a separate directory for each leg of the same job" "[ '${FIRST_DIR}' != '${SECOND_DIR}' ] && [ -d '${SECOND_DIR}' ]"
+assert "creates a separate directory for each attempt" "[[ '${RETRY_DIR}' == /tmp/gh-aw/${RUN_ID}-2/agent-???????? ]]"
+assert "recognizes the created directories" "gh_aw_is_tmp_dir '${FIRST_DIR}' && gh_aw_is_tmp_dir '${RETRY_DIR}'"
+assert "accepts job IDs with dashes and underscores" "gh_aw_is_tmp_dir \"\$(gh_aw_create_tmp_dir '${RUN_ID}' 1 safe_outputs-2)\""
+echo ""
+
+# ── Test 2: Guard rejects values that would escape the run directory ────────
+echo "Test 2: Guard rejects invalid run, attempt and job IDs"
+assert "rejects an empty run ID" "! gh_aw_create_tmp_dir '' 1 agent"
+assert "rejects a non-numeric run ID" "! gh_aw_create_tmp_dir 12a 1 agent"
+assert "rejects an empty run attempt" "! gh_aw_create_tmp_dir '${RUN_ID}' '' agent"
+assert "rejects an empty job ID" "! gh_aw_create_tmp_dir '${RUN_ID}' 1 ''"
+assert "rejects a job ID with a path separator" "! gh_aw_create_tmp_dir '${RUN_ID}' 1 ../agent"
+assert "rejects a job ID with a space" "! gh_aw_create_tmp_dir '${RUN_ID}' 1 'a b'"
+echo ""
+
+# ── Test 3: Cleanup removes only the job directory ──────────────────────────
+echo "Test 3: Cleanup removes only the job directory"
+OTHER_RUN_JOB_DIR="$(gh_aw_create_tmp_dir "${OTHER_RUN_ID}" 1 agent)"
+mkdir -p "${FIRST_DIR}/mcp-logs"
+touch "${FIRST_DIR}/mcp-logs/gateway.jsonl"
+gh_aw_remove_tmp_dir "${FIRST_DIR}"
+gh_aw_remove_empty_run_dir "${FIRST_DIR}"
+assert "removes the job directory" "[ ! -e '${FIRST_DIR}' ]"
+assert "keeps a concurrent leg of the same job" "[ -d '${SECOND_DIR}' ]"
+assert "keeps a concurrent run" "[ -d '${OTHER_RUN_JOB_DIR}' ]"
+echo ""
+
+# ── Test 4: Guard refuses paths that are not job scratch directories ────────
+echo "Test 4: Guard refuses paths that are not job scratch directories"
+assert "refuses the shared root" "! gh_aw_remove_tmp_dir /tmp/gh-aw"
+assert "refuses the run directory" "! gh_aw_remove_tmp_dir '${RUN_DIR}'"
+assert "refuses a directory without a random suffix" "! gh_aw_remove_tmp_dir '${RUN_DIR}/agent'"
+assert "refuses a path that escapes the run directory" "! gh_aw_remove_tmp_dir '${RUN_DIR}/../../etc-12345678'"
+assert "keeps the run directory" "[ -d '${RUN_DIR}' ]"
+echo ""
+
+# ── Test 5: Empty run directory is removed with the last job ────────────────
+echo "Test 5: Empty run directory is removed with the last job"
+for dir in "${RUN_DIR}"/*; do
+  gh_aw_remove_tmp_dir "${dir}"
+  gh_aw_remove_empty_run_dir "${dir}"
+done
+assert "removes the empty run directory" "[ ! -e '${RUN_DIR}' ]"
+assert "succeeds when the directory is already gone" "gh_aw_remove_tmp_dir '${SECOND_DIR}'"
+echo ""
+
+# ── Test 6: clean.sh removes the directory of the current job ───────────────
+echo "Test 6: clean.sh removes the directory of the current job"
+AGENT_DIR="$(gh_aw_create_tmp_dir "${RUN_ID}" 1 agent)"
+ACTIVATION_DIR="$(gh_aw_create_tmp_dir "${RUN_ID}" 1 activation)"
+set +e
+OUTPUT="$(GH_AW_TMP_DIR="${AGENT_DIR}" INPUT_DESTINATION=/nonexistent bash "${SCRIPT_DIR}/../clean.sh" 2>&1)"
+set -e
+assert "removes the job directory" "[ ! -e '${AGENT_DIR}' ]"
+assert "keeps the other job of the run" "[ -d '${ACTIVATION_DIR}' ]"
+assert "reports the cleanup" "printf '%s' \"${OUTPUT}\" | grep -q 'Cleaned up ${AGENT_DIR}'"
+echo ""
+
+# ── Test 7: clean.sh skips cleanup without a job scratch directory ──────────
+echo "Test 7: clean.sh skips cleanup without a job scratch directory"
+set +e
+OUTPUT="$(env -u GH_AW_TMP_DIR INPUT_DESTINATION=/nonexistent bash "${SCRIPT_DIR}/../clean.sh" 2>&1)"
+OUTPUT_RUN_DIR="$(GH_AW_TMP_DIR="${RUN_DIR}" INPUT_DESTINATION=/nonexistent bash "${SCRIPT_DIR}/../clean.sh" 2>&1)"
+set -e
+assert "keeps the run directory" "[ -d '${ACTIVATION_DIR}' ]"
+assert "reports the skipped cleanup" "printf '%s' \"${OUTPUT}\" | grep -q 'Skipping cleanup'"
+assert "refuses to remove the run directory" "printf '%s' \"${OUTPUT_RUN_DIR}\" | grep -q 'not a gh-aw job scratch directory'"
+echo ""
+
+# ── Test 8: setup.sh creates a fresh directory for the current job ──────────
+echo "Test 8: setup.sh creates a fresh directory for the current job"
+RUNNER_TEMP_DIR="$(mktemp -d)"
+set +e
+GITHUB_RUN_ID="${RUN_ID}" GITHUB_RUN_ATTEMPT=1 GITHUB_JOB=agent RUNNER_TEMP="${RUNNER_TEMP_DIR}" GITHUB_ENV="${RUNNER_TEMP_DIR}/env" \
+  INPUT_DESTINATION="${RUNNER_TEMP_DIR}/actions" GH_AW_SKIP_SETUP_OTLP=1 bash "${SCRIPT_DIR}/../setup.sh" >/dev/null 2>&1
+EXIT_CODE=$?
+set -e
+EXPORTED_DIR="$(sed -n 's/^GH_AW_TMP_DIR=//p' "${RUNNER_TEMP_DIR}/env" 2>/dev/null | tail -n 1)"
+assert "exits 0" "[ '${EXIT_CODE}' -eq 0 ]"
+assert "exports GH_AW_TMP_DIR" "gh_aw_is_tmp_dir '${EXPORTED_DIR}'"
+assert "exports GH_AW_JOB_SCOPE" "grep -qx \"GH_AW_JOB_SCOPE=\$(gh_aw_tmp_dir_scope '${EXPORTED_DIR}')\" '${RUNNER_TEMP_DIR}/env'"
+assert "creates the exported directory" "[ -d '${EXPORTED_DIR}' ]"
+assert "keeps the directory of a concurrent job" "[ -d '${ACTIVATION_DIR}' ]"
+echo ""
+
+# ── Test 9: setup.sh fails without a valid job ID ───────────────────────────
+echo "Test 9: setup.sh fails without a valid job ID"
+set +e
+OUTPUT="$(GITHUB_RUN_ID="${RUN_ID}" GITHUB_RUN_ATTEMPT=1 GITHUB_JOB=../detection RUNNER_TEMP="${RUNNER_TEMP_DIR}" GITHUB_ENV="${RUNNER_TEMP_DIR}/env" \
+  INPUT_DESTINATION="${RUNNER_TEMP_DIR}/actions" GH_AW_SKIP_SETUP_OTLP=1 bash "${SCRIPT_DIR}/../setup.sh" 2>&1)"
+EXIT_CODE=$?
+set -e
+rm -rf "${RUNNER_TEMP_DIR}"
+assert "exits non-zero" "[ '${EXIT_CODE}' -ne 0 ]"
+assert "reports the invalid job ID" "printf '%s' \"${OUTPUT}\" | grep -q 'Invalid GITHUB_JOB'"
+echo ""
+
+# ── Test 10: Job scope ──────────────────────────────────────────────────────
+echo "Test 10: Job scope"
+FIRST_SCOPE="$(gh_aw_tmp_dir_scope "${FIRST_DIR}")"
+assert "derives <run-id>-<run-attempt>-<job>-<suffix>" "[ '${FIRST_SCOPE}' = '${RUN_ID}-1-agent-${FIRST_DIR##*-}' ]"
+assert "differs for each leg of the same job" "[ '${FIRST_SCOPE}' != \"\$(gh_aw_tmp_dir_scope '${SECOND_DIR}')\" ]"
+assert "rejects a path that is not a job scratch directory" "! gh_aw_tmp_dir_scope '${RUN_DIR}'"
+echo ""
+
+echo "========================================"
+echo "Tests passed: ${TESTS_PASSED}"
+echo "Tests failed: ${TESTS_FAILED}"
+echo "========================================"
+
+if [ "${TESTS_FAILED}" -gt 0 ]; then
+  exit 1
+fi
@@ -0,0 +1,23 @@
+#!/usr/bin/env bash
+set +o histexpand
+set -euo pipefail
+
+# Replace the __GH_AW_TMP_DIR__ placeholder in the prompt with the scratch directory of
+# this job. The prompt is built in the activation job, which runs in a scratch directory
+# of its own, so framework prompt text refers to the directory through the placeholder
+# and the agent job resolves it after downloading the activation artifact.
+
+# Scratch directory of this job, exported by actions/setup/setup.sh.
+: "${GH_AW_TMP_DIR:?GH_AW_TMP_DIR is not set: the gh-aw setup step must run before this script}"
+
+PROMPT_FILE="${GH_AW_PROMPT:-${GH_AW_TMP_DIR}/aw-prompts/prompt.txt}"
+
+if [ ! -f "$PROMPT_FILE" ]; then
+  echo "❌ Error: Prompt file not found at $PROMPT_FILE"
+  exit 1
+fi
+
+# The scratch directory only contains characters that are literal in a sed replacement
+# (see gh_aw_create_tmp_dir), so it can be used as is.
+sed -i "s|__GH_AW_TMP_DIR__|${GH_AW_TMP_DIR}|g" "$PROMPT_FILE"
+echo "Resolved the scratch directory in $PROMPT_FILE to ${GH_AW_TMP_DIR}"
@@ -0,0 +1,70 @@
+#!/usr/bin/env bash
+set +o histexpand
+
+# Tests for resolve_prompt_tmp_dir.sh
+# Run: bash resolve_prompt_tmp_dir_test.sh
+
+set -e
+
+SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
+SCRIPT_PATH="${SCRIPT_DIR}/resolve_prompt_tmp_dir.sh"
+
+TESTS_PASSED=0
+TESTS_FAILED=0
+TEST_DIR="$(mktemp -d)"
+
+cleanup() {
+  rm -rf "${TEST_DIR}"
+}
+trap cleanup EXIT
+
+assert() {
+  local description="$1"
+  local condition="$2"
+  if eval "$condition"; then
+    echo "  ✓ ${description}"
+    TESTS_PASSED=$((TESTS_PASSED + 1))
+  else
+    echo "  ✗ ${description}"
+    TESTS_FAILED=$((TESTS_FAILED + 1))
+  fi
+}
+
+JOB_DIR="/tmp/gh-aw/123456-1/agent-Ab3dE9xZ"
+
+# ── Test 1: Replaces every placeholder with the scratch directory ───────────
+echo "Test 1: Replaces every placeholder with the scratch directory"
+cat > "${TEST_DIR}/prompt.txt" << 'PROMPT'
+<path>__GH_AW_TMP_DIR__/agent/</path>
+Use __GH_AW_TMP_DIR__/agent/ and `__GH_AW_TMP_DIR__/cache-memory/`.
+PROMPT
+GH_AW_TMP_DIR="${JOB_DIR}" GH_AW_PROMPT="${TEST_DIR}/prompt.txt" bash "${SCRIPT_PATH}" > /dev/null
+assert "no placeholder is left" "! grep -q '__GH_AW_TMP_DIR__' '${TEST_DIR}/prompt.txt'"
+assert "path element is resolved" "grep -qF '<path>${JOB_DIR}/agent/</path>' '${TEST_DIR}/prompt.txt'"
+assert "all occurrences on a line are resolved" "grep -qF 'Use ${JOB_DIR}/agent/ and \`${JOB_DIR}/cache-memory/\`.' '${TEST_DIR}/prompt.txt'"
+
+# ── Test 2: Leaves other text and placeholders untouched ────────────────────
+echo ""
+echo "Test 2: Leaves other text untouched"
+printf 'Repository: __GH_AW_GITHUB_REPOSITORY__\nPath: /tmp/gh-aw/agent/\n' > "${TEST_DIR}/other.txt"
+cp "${TEST_DIR}/other.txt" "${TEST_DIR}/other.expected"
+GH_AW_TMP_DIR="${JOB_DIR}" GH_AW_PROMPT="${TEST_DIR}/other.txt" bash "${SCRIPT_PATH}" > /dev/null
+assert "file is unchanged" "cmp -s '${TEST_DIR}/other.txt' '${TEST_DIR}/other.expected'"
+
+# ── Test 3: Fails without GH_AW_TMP_DIR ─────────────────────────────────────
+echo ""
+echo "Test 3: Fails without GH_AW_TMP_DIR"
+assert "exits non-zero" "! env -u GH_AW_TMP_DIR GH_AW_PROMPT='${TEST_DIR}/prompt.txt' bash '${SCRIPT_PATH}' > /dev/null 2>&1"
+
+# ── Test 4: Fails when the prompt file is missing ───────────────────────────
+echo ""
+echo "Test 4: Fails when the prompt file is missing"
+assert "exits non-zero" "! GH_AW_TMP_DIR='${JOB_DIR}' GH_AW_PROMPT='${TEST_DIR}/missing.txt' bash '${SCRIPT_PATH}' > /dev/null 2>&1"
+
+echo ""
+echo "Tests passed: ${TESTS_PASSED}"
+echo "Tests failed: ${TESTS_FAILED}"
+
+if [ "${TESTS_FAILED}" -gt 0 ]; then
+  exit 1
+fi
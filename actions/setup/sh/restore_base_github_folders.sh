@@ -30,8 +30,11 @@ set +o histexpand
 
 set -euo pipefail
 
+# Scratch directory of this job, exported by actions/setup/setup.sh.
+: "${GH_AW_TMP_DIR:?GH_AW_TMP_DIR is not set: the gh-aw setup step must run before this script}"
+
 WORKSPACE="${GITHUB_WORKSPACE:-$(pwd)}"
-SRC="/tmp/gh-aw/base"
+SRC="${GH_AW_TMP_DIR}/base"
 
 # Parse the engine-registry-derived lists from environment variables.
 # These must match the values used in save_base_github_folders.sh.
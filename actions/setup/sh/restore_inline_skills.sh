@@ -9,7 +9,10 @@ set +o histexpand
 
 set -euo pipefail
 
-SRC="/tmp/gh-aw/${GH_AW_SKILL_DIR}"
+# Scratch directory of this job, exported by actions/setup/setup.sh.
+: "${GH_AW_TMP_DIR:?GH_AW_TMP_DIR is not set: the gh-aw setup step must run before this script}"
+
+SRC="${GH_AW_TMP_DIR}/${GH_AW_SKILL_DIR}"
 DST="${GITHUB_WORKSPACE}/${GH_AW_SKILL_DIR}"
 
 echo "[restore-inline-skills] source: $SRC"
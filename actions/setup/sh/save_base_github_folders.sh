@@ -25,8 +25,11 @@ set +o histexpand
 
 set -euo pipefail
 
+# Scratch directory of this job, exported by actions/setup/setup.sh.
+: "${GH_AW_TMP_DIR:?GH_AW_TMP_DIR is not set: the gh-aw setup step must run before this script}"
+
 WORKSPACE="${GITHUB_WORKSPACE:-$(pwd)}"
-DEST="/tmp/gh-aw/base"
+DEST="${GH_AW_TMP_DIR}/base"
 
 # Parse the engine-registry-derived lists from environment variables.
 # The compiler sets these so this script never needs manual maintenance.
@@ -9,6 +9,9 @@ set -e
 SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
 SAVE_SCRIPT="${SCRIPT_DIR}/save_base_github_folders.sh"
 
+# The script runs in the job scratch directory that setup.sh exports in workflows.
+export GH_AW_TMP_DIR="${GH_AW_TMP_DIR:-/tmp/gh-aw}"
+
 TESTS_PASSED=0
 TESTS_FAILED=0
 
@@ -19,10 +19,17 @@ set +o histexpand
 #   GH_AW_ALLOWED_EXTENSIONS:    Colon-separated list of allowed file extensions for pre-agent
 #                                sanitization (e.g. .json:.md:.txt). When set, any restored file
 #                                whose extension is not in this list is removed before the agent runs.
+#   GH_AW_CACHE_RETENTION_DAYS:  Number of days after which files are removed. A file's age is the
+#                                time of the last commit that changed it (its modification time
+#                                when it is not tracked yet). The deletion is committed by
+#                                commit_cache_memory_git.sh after the agent runs.
 
 set -euo pipefail
 
-CACHE_DIR="${GH_AW_CACHE_DIR:-/tmp/gh-aw/cache-memory}"
+# Scratch directory of this job, exported by actions/setup/setup.sh.
+: "${GH_AW_TMP_DIR:?GH_AW_TMP_DIR is not set: the gh-aw setup step must run before this script}"
+
+CACHE_DIR="${GH_AW_CACHE_DIR:-${GH_AW_TMP_DIR}/cache-memory}"
 INTEGRITY="${GH_AW_MIN_INTEGRITY:-none}"
 
 # All integrity levels in descending order (highest first)
@@ -284,6 +291,24 @@ if [ -n "${GH_AW_ALLOWED_EXTENSIONS:-}" ]; then
   echo "Pre-agent sanitization complete: removed ${removed} file(s) with disallowed extensions"
 fi
 
+# --- Retention: remove files that were not updated within the retention period ---
+if [ -n "${GH_AW_CACHE_RETENTION_DAYS:-}" ]; then
+  _cutoff=$(( $(date +%s) - GH_AW_CACHE_RETENTION_DAYS * 86400 ))
+  expired=0
+  while IFS= read -r -d '' file; do
+    _updated_at=$(git log -1 --format=%ct -- "$file" 2>/dev/null || true)
+    if [ -z "$_updated_at" ]; then
+      _updated_at=$(stat -c %Y "$file" 2>/dev/null || echo 0)
+    fi
+    if [ "$_updated_at" -lt "$_cutoff" ]; then
+      echo "Removing expired file: $file (retention: ${GH_AW_CACHE_RETENTION_DAYS} days)"
+      rm -f "$file"
+      expired=$((expired + 1))
+    fi
+  done < <(find . -not -path './.git/*' -type f ! -name 'cache-hit-history.json' -print0)
+  echo "Retention cleanup complete: removed ${expired} file(s) older than ${GH_AW_CACHE_RETENTION_DAYS} days"
+fi
+
 # --- Log cache directory contents after full setup ---
 echo "=== Cache directory: non-git files available for agent after setup ==="
 _post_files=$(find . -not -path './.git/*' -type f 2>/dev/null | sort || true)
@@ -9,6 +9,9 @@ set -euo pipefail
 SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
 SCRIPT="${SCRIPT_DIR}/setup_cache_memory_git.sh"
 
+# The script runs in the job scratch directory that setup.sh exports in workflows.
+export GH_AW_TMP_DIR="${GH_AW_TMP_DIR:-/tmp/gh-aw}"
+
 # Test counters
 TESTS_PASSED=0
 TESTS_FAILED=0
@@ -288,6 +291,20 @@ assert "preflight success message logged" \
   "printf '%s' \"${OUTPUT}\" | grep -q 'Cache memory preflight write checks passed'"
 echo ""
 
+# ── Test 16: Retention removes files not updated within the period ──────────
+echo "Test 16: Retention removes expired files"
+D="${WORKSPACE}/test16"
+make_cache_dir "${D}" "fresh.json"
+pushd "${D}" >/dev/null
+echo "old" > old.json
+git add old.json
+GIT_AUTHOR_DATE="2000-01-01T00:00:00Z" GIT_COMMITTER_DATE="2000-01-01T00:00:00Z" git commit -m "old" -q
+popd >/dev/null
+GH_AW_CACHE_RETENTION_DAYS=30 run_script "${D}" none >/dev/null
+assert "old.json removed" "[ ! -f '${D}/old.json' ]"
+assert "fresh.json kept"  "[ -f '${D}/fresh.json' ]"
+echo ""
+
 # ── Summary ──────────────────────────────────────────────────────────────────
 echo "Tests passed: ${TESTS_PASSED}"
 echo "Tests failed: ${TESTS_FAILED}"
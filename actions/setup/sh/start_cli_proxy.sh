@@ -17,6 +17,9 @@ set +o histexpand
 
 set -e
 
+# Scratch directory of this job, exported by actions/setup/setup.sh.
+: "${GH_AW_TMP_DIR:?GH_AW_TMP_DIR is not set: the gh-aw setup step must run before this script}"
+
 SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
 # shellcheck source=proxy_env_lib.sh
 source "${SCRIPT_DIR}/proxy_env_lib.sh"
@@ -29,8 +32,8 @@ if [ -z "$CONTAINER_IMAGE" ]; then
   exit 0
 fi
 
-TLS_DIR=/tmp/gh-aw/difc-proxy-tls
-MCP_LOG_DIR=/tmp/gh-aw/mcp-logs
+TLS_DIR="${GH_AW_TMP_DIR}/difc-proxy-tls"
+MCP_LOG_DIR="${GH_AW_TMP_DIR}/mcp-logs"
 
 mkdir -p "$TLS_DIR" "$MCP_LOG_DIR"
 
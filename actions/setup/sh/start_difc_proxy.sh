@@ -19,6 +19,9 @@ set +o histexpand
 
 set -e
 
+# Scratch directory of this job, exported by actions/setup/setup.sh.
+: "${GH_AW_TMP_DIR:?GH_AW_TMP_DIR is not set: the gh-aw setup step must run before this script}"
+
 SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
 # shellcheck source=proxy_env_lib.sh
 source "${SCRIPT_DIR}/proxy_env_lib.sh"
@@ -36,8 +39,8 @@ if [ -z "$CONTAINER_IMAGE" ]; then
   exit 0
 fi
 
-PROXY_LOG_DIR=/tmp/gh-aw/proxy-logs
-MCP_LOG_DIR=/tmp/gh-aw/mcp-logs
+PROXY_LOG_DIR="${GH_AW_TMP_DIR}/proxy-logs"
+MCP_LOG_DIR="${GH_AW_TMP_DIR}/mcp-logs"
 
 mkdir -p "$PROXY_LOG_DIR" "$MCP_LOG_DIR"
 
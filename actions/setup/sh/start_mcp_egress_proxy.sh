@@ -0,0 +1,91 @@
+#!/usr/bin/env bash
+set +o histexpand
+
+# Start the egress proxy for an MCP server that declares network.allowed
+#
+# The MCP server container is attached (by the MCP gateway) to an internal Docker
+# network that has no route to the outside. This script creates that network and
+# starts a Squid proxy on it that is also attached to the default bridge network and
+# only forwards requests for the allowed domains.
+#
+# Docker networks and containers are shared by all jobs on a host, so their names are
+# scoped to the job (GH_AW_JOB_SCOPE, exported by actions/setup/setup.sh). The MCP server
+# reaches the proxy through the network alias gh-aw-mcp-egress-proxy, which only resolves
+# on the network of that server. stop_mcp_egress_proxy.sh removes both after the agent run.
+#
+# Usage: start_mcp_egress_proxy.sh SERVER_NAME NETWORK_NAME IMAGE DOMAIN [DOMAIN ...]
+#
+# Arguments:
+#   SERVER_NAME  - MCP server name (used for log paths)
+#   NETWORK_NAME - Internal Docker network the MCP server joins; the proxy container
+#                  is named NETWORK_NAME-proxy. Must contain GH_AW_JOB_SCOPE.
+#   IMAGE        - Squid container image
+#   DOMAIN       - Allowed domains in Squid dstdomain form (e.g. .api.example.com)
+#
+# Access logs are written to /tmp/gh-aw/mcp-logs/egress/SERVER_NAME/access.log.
+
+set -e
+
+# Scratch directory and scope of this job, exported by actions/setup/setup.sh.
+: "${GH_AW_TMP_DIR:?GH_AW_TMP_DIR is not set: the gh-aw setup step must run before this script}"
+: "${GH_AW_JOB_SCOPE:?GH_AW_JOB_SCOPE is not set: the gh-aw setup step must run before this script}"
+
+if [ "$#" -lt 4 ]; then
+  echo "Usage: $0 SERVER_NAME NETWORK_NAME IMAGE DOMAIN [DOMAIN ...]" >&2
+  exit 1
+fi
+
+SERVER_NAME="$1"
+NETWORK_NAME="$2"
+CONTAINER_IMAGE="$3"
+shift 3
+
+if [[ "$NETWORK_NAME" != *"${GH_AW_JOB_SCOPE}"* ]]; then
+  echo "::error::MCP egress network ${NETWORK_NAME} is not scoped to this job (${GH_AW_JOB_SCOPE})" >&2
+  exit 1
+fi
+
+PROXY_NAME="${NETWORK_NAME}-proxy"
+# Hostname of the proxy on the internal network; keep in sync with mcpEgressProxyAlias
+# in pkg/workflow/mcp_egress.go.
+PROXY_ALIAS="gh-aw-mcp-egress-proxy"
+CONFIG_DIR="${RUNNER_TEMP}/gh-aw/mcp-egress/${SERVER_NAME}"
+LOG_DIR="${GH_AW_TMP_DIR}/mcp-logs/egress/${SERVER_NAME}"
+
+mkdir -p "$CONFIG_DIR" "$LOG_DIR"
+chmod 777 "$LOG_DIR"
+
+cat > "${CONFIG_DIR}/squid.conf" <<EOF
+http_port 3128
+acl allowed_domains dstdomain $*
+acl SSL_ports port 443
+acl Safe_ports port 80 443
+acl CONNECT method CONNECT
+http_access deny !Safe_ports
+http_access deny CONNECT !SSL_ports
+http_access allow allowed_domains
+http_access deny all
+access_log stdio:/var/log/squid/access.log
+cache deny all
+EOF
+
+docker network create --internal "$NETWORK_NAME" >/dev/null
+docker run -d --name "$PROXY_NAME" --network bridge \
+  -v "${CONFIG_DIR}/squid.conf:/etc/squid/squid.conf:ro" \
+  -v "${LOG_DIR}:/var/log/squid" \
+  "$CONTAINER_IMAGE" >/dev/null
+docker network connect --alias "$PROXY_ALIAS" "$NETWORK_NAME" "$PROXY_NAME"
+
+# Wait until Squid accepts connections on the bridge network (up to 30s)
+PROXY_IP=$(docker inspect -f '{{.NetworkSettings.Networks.bridge.IPAddress}}' "$PROXY_NAME")
+for _ in $(seq 1 30); do
+  if (exec 3<>"/dev/tcp/${PROXY_IP}/3128") 2>/dev/null; then
+    echo "MCP egress proxy for ${SERVER_NAME} is ready (allowed: $*)"
+    exit 0
+  fi
+  sleep 1
+done
+
+echo "::error::MCP egress proxy for ${SERVER_NAME} did not become ready within 30s"
+docker logs "$PROXY_NAME" 2>&1 | tail -20 || true
+exit 1
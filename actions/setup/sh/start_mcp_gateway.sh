@@ -10,6 +10,9 @@ set +o histexpand
 
 set -e
 
+# Scratch directory of this job, exported by actions/setup/setup.sh.
+: "${GH_AW_TMP_DIR:?GH_AW_TMP_DIR is not set: the gh-aw setup step must run before this script}"
+
 # Restrict default file creation mode to owner-only (rw-------) for all new files.
 # This prevents the race window between file creation via output redirection and
 # a subsequent chmod, which would leave credential-bearing files world-readable
@@ -36,30 +39,30 @@ if [ -z "$MCP_GATEWAY_DOCKER_COMMAND" ]; then
 fi
 
 # Create logs directory for gateway
-mkdir -p /tmp/gh-aw/mcp-logs
+mkdir -p "${GH_AW_TMP_DIR}/mcp-logs"
 
 # Guard against symlink attacks on the predictable /tmp/gh-aw/mcp-config path.
 # An attacker who can create files in /tmp could pre-create /tmp/gh-aw or
 # /tmp/gh-aw/mcp-config as symlinks and redirect our credential writes to an
 # arbitrary location.  Check both path components before and after creation.
-if [ -L /tmp/gh-aw ]; then
-  echo "ERROR: /tmp/gh-aw is a symlink — possible symlink attack, aborting"
+if [ -L "${GH_AW_TMP_DIR}" ]; then
+  echo "ERROR: ${GH_AW_TMP_DIR} is a symlink — possible symlink attack, aborting"
   exit 1
 fi
-if [ -L /tmp/gh-aw/mcp-config ]; then
-  echo "ERROR: /tmp/gh-aw/mcp-config is a symlink — possible symlink attack, aborting"
+if [ -L "${GH_AW_TMP_DIR}/mcp-config" ]; then
+  echo "ERROR: ${GH_AW_TMP_DIR}/mcp-config is a symlink — possible symlink attack, aborting"
   exit 1
 fi
-mkdir -p /tmp/gh-aw/mcp-config
+mkdir -p "${GH_AW_TMP_DIR}/mcp-config"
 # Post-creation check: verify neither path was replaced with a symlink after mkdir
 # (mitigates the TOCTOU window between the pre-check and mkdir).
-if [ -L /tmp/gh-aw ] || [ -L /tmp/gh-aw/mcp-config ]; then
-  echo "ERROR: /tmp/gh-aw/mcp-config was replaced with a symlink — possible symlink attack, aborting"
+if [ -L "${GH_AW_TMP_DIR}" ] || [ -L "${GH_AW_TMP_DIR}/mcp-config" ]; then
+  echo "ERROR: ${GH_AW_TMP_DIR}/mcp-config was replaced with a symlink — possible symlink attack, aborting"
   exit 1
 fi
 # Restrict directory permissions so only the runner process owner can read config files
 # (which contain bearer tokens and API keys)
-chmod 700 /tmp/gh-aw/mcp-config
+chmod 700 "${GH_AW_TMP_DIR}/mcp-config"
 
 # Validate container syntax first (before accessing files)
 # Container should be a valid docker command starting with "docker run"
@@ -104,12 +107,12 @@ echo ""
 
 # Validate configuration is valid JSON
 CONFIG_VALIDATION_START=$(date +%s%3N)
-if ! echo "$MCP_CONFIG" | jq empty 2>/tmp/gh-aw/mcp-config/jq-error.log; then
+if ! echo "$MCP_CONFIG" | jq empty 2>"${GH_AW_TMP_DIR}/mcp-config/jq-error.log"; then
   echo "ERROR: Configuration is not valid JSON"
   echo ""
   echo "JSON validation error:"
-  if [ -f /tmp/gh-aw/mcp-config/jq-error.log ]; then
-    cat /tmp/gh-aw/mcp-config/jq-error.log
+  if [ -f "${GH_AW_TMP_DIR}/mcp-config/jq-error.log" ]; then
+    cat "${GH_AW_TMP_DIR}/mcp-config/jq-error.log"
   fi
   echo ""
   echo "Configuration content:"
@@ -148,7 +151,7 @@ print_timing $CONFIG_VALIDATION_START "Configuration validation"
 echo ""
 
 # Set MCP_GATEWAY_LOG_DIR environment variable for use by the gateway
-export MCP_GATEWAY_LOG_DIR="/tmp/gh-aw/mcp-logs/"
+export MCP_GATEWAY_LOG_DIR="${GH_AW_TMP_DIR}/mcp-logs/"
 
 # Clean up any stale gh-aw gateway container from a previous run on this runner.
 # On persistent self-hosted runners a prior job's gateway container may still be
@@ -168,7 +171,7 @@ GATEWAY_START_TIME=$(date +%s%3N)
 # Note: MCP_GATEWAY_DOCKER_COMMAND is the full docker command with all flags, mounts, and image
 # Pass MCP_GATEWAY_LOG_DIR to the container via -e flag
 echo "$MCP_CONFIG" | MCP_GATEWAY_LOG_DIR="$MCP_GATEWAY_LOG_DIR" $MCP_GATEWAY_DOCKER_COMMAND \
-  > /tmp/gh-aw/mcp-config/gateway-output.json 2> /tmp/gh-aw/mcp-logs/stderr.log &
+  > "${GH_AW_TMP_DIR}/mcp-config/gateway-output.json" 2> "${GH_AW_TMP_DIR}/mcp-logs/stderr.log" &
 
 GATEWAY_PID=$!
 echo "Gateway started with PID: $GATEWAY_PID"
@@ -180,10 +183,10 @@ else
   echo "ERROR: Gateway process exited immediately after start"
   echo ""
   echo "Gateway stdout output:"
-  cat /tmp/gh-aw/mcp-config/gateway-output.json 2>/dev/null || echo "No stdout output available"
+  cat "${GH_AW_TMP_DIR}/mcp-config/gateway-output.json" 2>/dev/null || echo "No stdout output available"
   echo ""
   echo "Gateway stderr logs:"
-  cat /tmp/gh-aw/mcp-logs/stderr.log 2>/dev/null || echo "No stderr logs available"
+  cat "${GH_AW_TMP_DIR}/mcp-logs/stderr.log" 2>/dev/null || echo "No stderr logs available"
   exit 1
 fi
 echo ""
@@ -199,10 +202,10 @@ if ! ps -p $GATEWAY_PID > /dev/null 2>&1; then
   echo "Gateway exit status: $WAIT_STATUS"
   echo ""
   echo "Gateway stdout (errors are written here per MCP Gateway Specification):"
-  cat /tmp/gh-aw/mcp-config/gateway-output.json 2>/dev/null || echo "No stdout output available"
+  cat "${GH_AW_TMP_DIR}/mcp-config/gateway-output.json" 2>/dev/null || echo "No stdout output available"
   echo ""
   echo "Gateway stderr logs (debug output):"
-  cat /tmp/gh-aw/mcp-logs/stderr.log || echo "No stderr logs available"
+  cat "${GH_AW_TMP_DIR}/mcp-logs/stderr.log" || echo "No stderr logs available"
   exit 1
 fi
 echo "Gateway process is still running (PID: $GATEWAY_PID)"
@@ -314,10 +317,10 @@ else
   docker ps -a 2>/dev/null | head -20 || echo "Could not list docker containers"
   echo ""
   echo "Gateway stdout (errors are written here per MCP Gateway Specification):"
-  cat /tmp/gh-aw/mcp-config/gateway-output.json 2>/dev/null || echo "No stdout output available"
+  cat "${GH_AW_TMP_DIR}/mcp-config/gateway-output.json" 2>/dev/null || echo "No stdout output available"
   echo ""
   echo "Gateway stderr logs (debug output):"
-  cat /tmp/gh-aw/mcp-logs/stderr.log || echo "No stderr logs available"
+  cat "${GH_AW_TMP_DIR}/mcp-logs/stderr.log" || echo "No stderr logs available"
   echo ""
   echo "Checking network connectivity to gateway port..."
   netstat -tlnp 2>/dev/null | grep ":${MCP_GATEWAY_PORT}" || ss -tlnp 2>/dev/null | grep ":${MCP_GATEWAY_PORT}" || echo "Port ${MCP_GATEWAY_PORT} does not appear to be listening"
@@ -332,7 +335,7 @@ OUTPUT_WAIT_START=$(date +%s%3N)
 WAIT_ATTEMPTS=10
 WAIT_ATTEMPT=0
 while [ $WAIT_ATTEMPT -lt $WAIT_ATTEMPTS ]; do
-  if [ -s /tmp/gh-aw/mcp-config/gateway-output.json ]; then
+  if [ -s "${GH_AW_TMP_DIR}/mcp-config/gateway-output.json" ]; then
     echo "Gateway output received!"
     break
   fi
@@ -345,31 +348,31 @@ print_timing $OUTPUT_WAIT_START "Gateway output wait"
 echo ""
 
 # Verify output was written
-if [ ! -s /tmp/gh-aw/mcp-config/gateway-output.json ]; then
+if [ ! -s "${GH_AW_TMP_DIR}/mcp-config/gateway-output.json" ]; then
   echo "ERROR: Gateway did not write output configuration"
   echo ""
   echo "Gateway stdout (should contain error or config):"
-  cat /tmp/gh-aw/mcp-config/gateway-output.json 2>/dev/null || echo "No stdout output available"
+  cat "${GH_AW_TMP_DIR}/mcp-config/gateway-output.json" 2>/dev/null || echo "No stdout output available"
   echo ""
   echo "Gateway stderr logs:"
-  cat /tmp/gh-aw/mcp-logs/stderr.log || echo "No stderr logs available"
+  cat "${GH_AW_TMP_DIR}/mcp-logs/stderr.log" || echo "No stderr logs available"
   kill $GATEWAY_PID 2>/dev/null || true
   exit 1
 fi
 
 # Restrict gateway output file permissions - it contains the bearer token / API key
-chmod 600 /tmp/gh-aw/mcp-config/gateway-output.json
+chmod 600 "${GH_AW_TMP_DIR}/mcp-config/gateway-output.json"
 
 # Check if output contains an error payload instead of valid configuration
 # Per MCP Gateway Specification v1.0.0 section 9.1, errors are written to stdout as error payloads
-if jq -e '.error' /tmp/gh-aw/mcp-config/gateway-output.json >/dev/null 2>&1; then
+if jq -e '.error' "${GH_AW_TMP_DIR}/mcp-config/gateway-output.json" >/dev/null 2>&1; then
   echo "ERROR: Gateway returned an error payload instead of configuration"
   echo ""
   echo "Gateway error details:"
-  cat /tmp/gh-aw/mcp-config/gateway-output.json
+  cat "${GH_AW_TMP_DIR}/mcp-config/gateway-output.json"
   echo ""
   echo "Gateway stderr logs:"
-  cat /tmp/gh-aw/mcp-logs/stderr.log || echo "No stderr logs available"
+  cat "${GH_AW_TMP_DIR}/mcp-logs/stderr.log" || echo "No stderr logs available"
   kill $GATEWAY_PID 2>/dev/null || true
   exit 1
 fi
@@ -377,7 +380,7 @@ fi
 # Convert gateway output to agent-specific format
 echo "Converting gateway configuration to agent format..."
 CONFIG_CONVERT_START=$(date +%s%3N)
-export MCP_GATEWAY_OUTPUT=/tmp/gh-aw/mcp-config/gateway-output.json
+export MCP_GATEWAY_OUTPUT="${GH_AW_TMP_DIR}/mcp-config/gateway-output.json"
 
 # Validate MCP_GATEWAY_API_KEY is set (required by converter scripts)
 if [ -z "$MCP_GATEWAY_API_KEY" ]; then
@@ -401,9 +404,9 @@ elif [ -n "$GITHUB_COPILOT_CLI_MODE" ]; then
   ENGINE_TYPE="copilot"
 elif [ -n "${HOME:-}" ] && [ -d "$HOME/.copilot" ]; then
   ENGINE_TYPE="copilot"
-elif [ -f "/tmp/gh-aw/mcp-config/config.toml" ]; then
+elif [ -f "${GH_AW_TMP_DIR}/mcp-config/config.toml" ]; then
   ENGINE_TYPE="codex"
-elif [ -f "/tmp/gh-aw/mcp-config/mcp-servers.json" ]; then
+elif [ -f "${GH_AW_TMP_DIR}/mcp-config/mcp-servers.json" ]; then
   ENGINE_TYPE="claude"
 else
   ENGINE_TYPE="unknown"
@@ -437,13 +440,13 @@ case "$ENGINE_TYPE" in
     if [ -n "$GH_AW_MCP_CLI_SERVERS" ]; then
       if ! jq --argjson cliServers "$GH_AW_MCP_CLI_SERVERS" \
         '.mcpServers |= with_entries(select(.key | IN($cliServers[]) | not))' \
-        /tmp/gh-aw/mcp-config/gateway-output.json > "$HOME/.copilot/mcp-config.json"; then
+        "${GH_AW_TMP_DIR}/mcp-config/gateway-output.json" > "$HOME/.copilot/mcp-config.json"; then
         echo "ERROR: Failed to filter CLI-mounted servers from agent MCP config"
         echo "Falling back to unfiltered config"
-        cp /tmp/gh-aw/mcp-config/gateway-output.json "$HOME/.copilot/mcp-config.json"
+        cp "${GH_AW_TMP_DIR}/mcp-config/gateway-output.json" "$HOME/.copilot/mcp-config.json"
       fi
     else
-      cp /tmp/gh-aw/mcp-config/gateway-output.json "$HOME/.copilot/mcp-config.json"
+      cp "${GH_AW_TMP_DIR}/mcp-config/gateway-output.json" "$HOME/.copilot/mcp-config.json"
     fi
     cat "$HOME/.copilot/mcp-config.json"
     ;;
@@ -461,9 +464,9 @@ if [ -f ${RUNNER_TEMP}/gh-aw/actions/check_mcp_servers.sh ]; then
   # Enable pipefail so the exit code comes from check_mcp_servers.sh, not tee
   set -o pipefail
   if ! bash ${RUNNER_TEMP}/gh-aw/actions/check_mcp_servers.sh \
-    /tmp/gh-aw/mcp-config/gateway-output.json \
+    "${GH_AW_TMP_DIR}/mcp-config/gateway-output.json" \
     "http://localhost:${MCP_GATEWAY_PORT}" \
-    "${MCP_GATEWAY_API_KEY}" 2>&1 | tee /tmp/gh-aw/mcp-logs/start-gateway.log; then
+    "${MCP_GATEWAY_API_KEY}" 2>&1 | tee "${GH_AW_TMP_DIR}/mcp-logs/start-gateway.log"; then
     echo "ERROR: MCP server checks failed - no servers could be connected"
     echo "Gateway process will be terminated"
     kill $GATEWAY_PID 2>/dev/null || true
@@ -480,13 +483,13 @@ echo ""
 # Save CLI manifest for mount_mcp_as_cli.cjs before gateway config is deleted
 # The manifest contains server names and their local localhost URLs
 echo "Saving MCP CLI manifest..."
-mkdir -p /tmp/gh-aw/mcp-cli
-if [ -f /tmp/gh-aw/mcp-config/gateway-output.json ] && \
-   jq -e '.mcpServers' /tmp/gh-aw/mcp-config/gateway-output.json >/dev/null 2>&1; then
+mkdir -p "${GH_AW_TMP_DIR}/mcp-cli"
+if [ -f "${GH_AW_TMP_DIR}/mcp-config/gateway-output.json" ] && \
+   jq -e '.mcpServers' "${GH_AW_TMP_DIR}/mcp-config/gateway-output.json" >/dev/null 2>&1; then
   jq '{servers: [.mcpServers | to_entries[] | select(.value.url != null) | {name: .key, url: .value.url}]}' \
-    /tmp/gh-aw/mcp-config/gateway-output.json > /tmp/gh-aw/mcp-cli/manifest.json
-  chmod 600 /tmp/gh-aw/mcp-cli/manifest.json
-  SERVER_COUNT=$(jq '.servers | length' /tmp/gh-aw/mcp-cli/manifest.json)
+    "${GH_AW_TMP_DIR}/mcp-config/gateway-output.json" > "${GH_AW_TMP_DIR}/mcp-cli/manifest.json"
+  chmod 600 "${GH_AW_TMP_DIR}/mcp-cli/manifest.json"
+  SERVER_COUNT=$(jq '.servers | length' "${GH_AW_TMP_DIR}/mcp-cli/manifest.json")
   echo "CLI manifest saved with ${SERVER_COUNT} server(s)"
 else
   echo "WARNING: No mcpServers in gateway output, CLI manifest not created"
@@ -495,8 +498,8 @@ echo ""
 
 # Delete gateway configuration file after conversion and checks are complete
 echo "Cleaning up gateway configuration file..."
-if [ -f /tmp/gh-aw/mcp-config/gateway-output.json ]; then
-  rm /tmp/gh-aw/mcp-config/gateway-output.json
+if [ -f "${GH_AW_TMP_DIR}/mcp-config/gateway-output.json" ]; then
+  rm "${GH_AW_TMP_DIR}/mcp-config/gateway-output.json"
   echo "Gateway configuration file deleted"
 else
   echo "Gateway configuration file not found (already deleted or never created)"
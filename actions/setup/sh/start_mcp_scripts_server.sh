@@ -6,6 +6,9 @@ set +o histexpand
 
 set -e
 
+# Scratch directory of this job, exported by actions/setup/setup.sh.
+: "${GH_AW_TMP_DIR:?GH_AW_TMP_DIR is not set: the gh-aw setup step must run before this script}"
+
 cd ${RUNNER_TEMP}/gh-aw/mcp-scripts || exit 1
 
 # Verify required files exist
@@ -73,7 +76,7 @@ echo "  API Key: ${GH_AW_MCP_SCRIPTS_API_KEY:0:8}..."
 echo "  Working directory: $(pwd)"
 
 # Ensure logs directory exists
-mkdir -p /tmp/gh-aw/mcp-scripts/logs
+mkdir -p "${GH_AW_TMP_DIR}/mcp-scripts/logs"
 
 # Create initial server.log file for artifact upload
 {
@@ -81,11 +84,11 @@ mkdir -p /tmp/gh-aw/mcp-scripts/logs
   echo "Start time: $(date)"
   echo "==========================================="
   echo ""
-} > /tmp/gh-aw/mcp-scripts/logs/server.log
+} > "${GH_AW_TMP_DIR}/mcp-scripts/logs/server.log"
 
 # Start the HTTP server in the background with DEBUG enabled
 echo "Starting mcp-scripts MCP HTTP server..."
-DEBUG="*" node mcp-server.cjs >> /tmp/gh-aw/mcp-scripts/logs/server.log 2>&1 &
+DEBUG="*" node mcp-server.cjs >> "${GH_AW_TMP_DIR}/mcp-scripts/logs/server.log" 2>&1 &
 SERVER_PID=$!
 echo "Started mcp-scripts MCP server with PID $SERVER_PID"
 
@@ -96,7 +99,7 @@ for i in {1..10}; do
   if ! kill -0 $SERVER_PID 2>/dev/null; then
     echo "ERROR: Server process $SERVER_PID has died"
     echo "Server log contents:"
-    cat /tmp/gh-aw/mcp-scripts/logs/server.log
+    cat "${GH_AW_TMP_DIR}/mcp-scripts/logs/server.log"
     exit 1
   fi
   
@@ -106,7 +109,7 @@ for i in {1..10}; do
     
     # Print the startup log for debugging
     echo "::group::Server Log Contents"
-    cat /tmp/gh-aw/mcp-scripts/logs/server.log
+    cat "${GH_AW_TMP_DIR}/mcp-scripts/logs/server.log"
     echo "::endgroup::"
     
     break
@@ -116,7 +119,7 @@ for i in {1..10}; do
     echo "ERROR: MCP Scripts server failed to start after 10 seconds"
     echo "Process status: $(pgrep -f 'mcp-server.cjs' || echo 'not running')"
     echo "Server log contents:"
-    cat /tmp/gh-aw/mcp-scripts/logs/server.log
+    cat "${GH_AW_TMP_DIR}/mcp-scripts/logs/server.log"
     echo "Checking port availability:"
     netstat -tuln | grep "$GH_AW_MCP_SCRIPTS_PORT" || echo "Port $GH_AW_MCP_SCRIPTS_PORT not listening"
     exit 1
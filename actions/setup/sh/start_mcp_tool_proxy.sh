@@ -0,0 +1,52 @@
+#!/usr/bin/env bash
+# Start the MCP tool proxy for sandbox.mcp.chaos, sandbox.mcp.record, and sandbox.mcp.replay
+#
+# This script is sourced inside the agent container before the engine starts. The
+# proxy listens on localhost:GH_AW_MCP_PROXY_PORT and forwards requests to the MCP
+# gateway at GH_AW_MCP_PROXY_UPSTREAM. Depending on the environment it injects tool
+# errors, timeouts, and truncated results (GH_AW_MCP_CHAOS), records tool calls
+# (GH_AW_MCP_RECORD), or answers them from a recording (GH_AW_MCP_REPLAY). The
+# engine's MCP configuration points at the proxy instead of the gateway.
+#
+# The script is sourced rather than executed so that the EXIT trap it installs stops
+# the proxy when the engine command finishes.
+#
+# Environment:
+#   GH_AW_MCP_CHAOS          - JSON chaos configuration (see mcp_tool_proxy.cjs)
+#   GH_AW_MCP_RECORD         - "true" to record tool calls
+#   GH_AW_MCP_REPLAY         - Recording to replay, relative to GITHUB_WORKSPACE
+#   GH_AW_MCP_PROXY_PORT     - Port the proxy listens on
+#   GH_AW_MCP_PROXY_UPSTREAM - MCP gateway URL as seen from the agent container
+#
+# Proxy output is written to /tmp/gh-aw/mcp-logs/tool-proxy.log, injected faults to
+# /tmp/gh-aw/mcp-logs/chaos/faults.jsonl, and recordings to
+# /tmp/gh-aw/mcp-logs/mcp-recording.jsonl.
+
+# Scratch directory of this job, exported by actions/setup/setup.sh.
+: "${GH_AW_TMP_DIR:?GH_AW_TMP_DIR is not set: the gh-aw setup step must run before this script}"
+
+GH_AW_MCP_PROXY_LOG="${GH_AW_TMP_DIR}/mcp-logs/tool-proxy.log"
+mkdir -p "$(dirname "$GH_AW_MCP_PROXY_LOG")"
+
+node "${RUNNER_TEMP}/gh-aw/actions/mcp_tool_proxy.cjs" >> "$GH_AW_MCP_PROXY_LOG" 2>&1 &
+GH_AW_MCP_PROXY_PID=$!
+# shellcheck disable=SC2064 # expand the PID now, not when the trap fires
+trap "kill ${GH_AW_MCP_PROXY_PID} 2>/dev/null || true" EXIT
+
+# Wait until the proxy accepts connections (up to 10s)
+for _ in $(seq 1 50); do
+  if ! kill -0 "$GH_AW_MCP_PROXY_PID" 2>/dev/null; then
+    echo "MCP tool proxy exited during startup:" >&2
+    cat "$GH_AW_MCP_PROXY_LOG" >&2 || true
+    return 1
+  fi
+  if (exec 3<>"/dev/tcp/127.0.0.1/${GH_AW_MCP_PROXY_PORT}") 2>/dev/null; then
+    echo "MCP tool proxy is ready on port ${GH_AW_MCP_PROXY_PORT}, forwarding to ${GH_AW_MCP_PROXY_UPSTREAM}" >&2
+    return 0
+  fi
+  sleep 0.2
+done
+
+echo "MCP tool proxy did not become ready within 10s:" >&2
+cat "$GH_AW_MCP_PROXY_LOG" >&2 || true
+return 1
@@ -6,6 +6,9 @@ set +o histexpand
 
 set -e
 
+# Scratch directory of this job, exported by actions/setup/setup.sh.
+: "${GH_AW_TMP_DIR:?GH_AW_TMP_DIR is not set: the gh-aw setup step must run before this script}"
+
 cd ${RUNNER_TEMP}/gh-aw/safeoutputs || exit 1
 
 # Verify required files exist
@@ -79,7 +82,7 @@ echo "  API Key: ${GH_AW_SAFE_OUTPUTS_API_KEY:0:8}..."
 echo "  Working directory: $(pwd)"
 
 # Ensure logs directory exists
-mkdir -p /tmp/gh-aw/mcp-logs/safeoutputs
+mkdir -p "${GH_AW_TMP_DIR}/mcp-logs/safeoutputs"
 
 # Create initial server.log file for artifact upload
 {
@@ -87,11 +90,11 @@ mkdir -p /tmp/gh-aw/mcp-logs/safeoutputs
   echo "Start time: $(date)"
   echo "==========================================="
   echo ""
-} > /tmp/gh-aw/mcp-logs/safeoutputs/server.log
+} > "${GH_AW_TMP_DIR}/mcp-logs/safeoutputs/server.log"
 
 # Start the HTTP server in the background with DEBUG enabled
 echo "Starting safe-outputs MCP HTTP server..."
-DEBUG="*" node mcp-server.cjs >> /tmp/gh-aw/mcp-logs/safeoutputs/server.log 2>&1 &
+DEBUG="*" node mcp-server.cjs >> "${GH_AW_TMP_DIR}/mcp-logs/safeoutputs/server.log" 2>&1 &
 SERVER_PID=$!
 echo "Started safe-outputs MCP server with PID $SERVER_PID"
 
@@ -102,7 +105,7 @@ for i in {1..60}; do
   if ! kill -0 $SERVER_PID 2>/dev/null; then
     echo "ERROR: Server process $SERVER_PID has died"
     echo "Server log contents:"
-    cat /tmp/gh-aw/mcp-logs/safeoutputs/server.log
+    cat "${GH_AW_TMP_DIR}/mcp-logs/safeoutputs/server.log"
     exit 1
   fi
   
@@ -112,7 +115,7 @@ for i in {1..60}; do
     
     # Print the startup log for debugging
     echo "::group::Server Log Contents"
-    cat /tmp/gh-aw/mcp-logs/safeoutputs/server.log
+    cat "${GH_AW_TMP_DIR}/mcp-logs/safeoutputs/server.log"
     echo "::endgroup::"
     
     break
@@ -122,7 +125,7 @@ for i in {1..60}; do
     echo "ERROR: Safe Outputs MCP server failed to start after 60 seconds"
     echo "Process status: $(pgrep -f 'mcp-server.cjs' || echo 'not running')"
     echo "Server log contents:"
-    cat /tmp/gh-aw/mcp-logs/safeoutputs/server.log
+    cat "${GH_AW_TMP_DIR}/mcp-logs/safeoutputs/server.log"
     echo "Checking port availability:"
     netstat -tuln | grep "$GH_AW_SAFE_OUTPUTS_PORT" || echo "Port $GH_AW_SAFE_OUTPUTS_PORT not listening"
     exit 1
@@ -0,0 +1,46 @@
+#!/usr/bin/env bash
+set +o histexpand
+
+# Stop the egress proxies of MCP servers that declare network.allowed
+#
+# Removes the proxy container and the internal Docker network that
+# start_mcp_egress_proxy.sh created for each server. Runs after the MCP gateway has
+# stopped, from an `if: always()` step, so that cancelled or failed jobs do not leave
+# them behind on the host. Only networks scoped to this job (GH_AW_JOB_SCOPE) are
+# touched; the resources of concurrent jobs are never removed.
+#
+# Usage: stop_mcp_egress_proxy.sh NETWORK_NAME [NETWORK_NAME ...]
+#
+# Arguments:
+#   NETWORK_NAME - Internal Docker network of an MCP server; the proxy container is
+#                  named NETWORK_NAME-proxy
+
+if [ -z "${GH_AW_JOB_SCOPE:-}" ]; then
+  echo "GH_AW_JOB_SCOPE is not set: no MCP egress proxies were started by this job"
+  exit 0
+fi
+
+status=0
+for NETWORK_NAME in "$@"; do
+  if [[ "$NETWORK_NAME" != *"${GH_AW_JOB_SCOPE}"* ]]; then
+    echo "::warning::Skipping MCP egress network ${NETWORK_NAME}: not scoped to this job (${GH_AW_JOB_SCOPE})"
+    continue
+  fi
+
+  docker rm -f "${NETWORK_NAME}-proxy" >/dev/null 2>&1 || true
+  # MCP server containers still attached to the network (e.g. after the gateway was
+  # killed) belong to this job and would keep the network alive.
+  docker ps -aq --filter "network=${NETWORK_NAME}" | xargs -r docker rm -f >/dev/null 2>&1 || true
+
+  if ! docker network inspect "$NETWORK_NAME" >/dev/null 2>&1; then
+    continue
+  fi
+  if docker network rm "$NETWORK_NAME" >/dev/null; then
+    echo "Removed MCP egress network ${NETWORK_NAME}"
+  else
+    echo "::warning::Failed to remove MCP egress network ${NETWORK_NAME}"
+    status=1
+  fi
+done
+
+exit "$status"
@@ -6,7 +6,10 @@ set +o histexpand
 
 set -e
 
-PROMPT_FILE="${GH_AW_PROMPT:-/tmp/gh-aw/aw-prompts/prompt.txt}"
+# Scratch directory of this job, exported by actions/setup/setup.sh.
+: "${GH_AW_TMP_DIR:?GH_AW_TMP_DIR is not set: the gh-aw setup step must run before this script}"
+
+PROMPT_FILE="${GH_AW_PROMPT:-${GH_AW_TMP_DIR}/aw-prompts/prompt.txt}"
 
 if [ ! -f "$PROMPT_FILE" ]; then
     echo "❌ Error: Prompt file not found at $PROMPT_FILE"
@@ -18,11 +21,13 @@ echo "🔍 Validating prompt placeholders..."
 # Check for unreplaced environment variable placeholders (format: __GH_AW_*__)
 # Strip inline code spans (`...`) before checking, since backtick-quoted occurrences
 # are documentation/code examples (e.g., in PR descriptions) and not actual placeholders.
-STRIPPED_PROMPT=$(sed 's/`[^`]*`//g' "$PROMPT_FILE")
+# __GH_AW_TMP_DIR__ is skipped as well: the agent job replaces it with its own scratch
+# directory (see resolve_prompt_tmp_dir.sh).
+STRIPPED_PROMPT=$(sed -e 's/`[^`]*`//g' -e 's/__GH_AW_TMP_DIR__//g' "$PROMPT_FILE")
 if printf '%s\n' "$STRIPPED_PROMPT" | grep -q "__GH_AW_"; then
     echo "❌ Error: Found unreplaced placeholders in prompt file:"
     echo ""
-    grep -n "__GH_AW_" "$PROMPT_FILE" | grep -v '`[^`]*__GH_AW_' | head -20
+    sed 's/__GH_AW_TMP_DIR__//g' "$PROMPT_FILE" | grep -n "__GH_AW_" | grep -v '`[^`]*__GH_AW_' | head -20
     echo ""
     echo "These placeholders should have been replaced with their actual values."
     echo "This indicates a problem with the placeholder substitution step."
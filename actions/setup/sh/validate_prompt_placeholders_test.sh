@@ -9,6 +9,9 @@ set -e
 TEST_DIR=$(mktemp -d)
 SCRIPT_PATH="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)/validate_prompt_placeholders.sh"
 
+# The script runs in the job scratch directory that setup.sh exports in workflows.
+export GH_AW_TMP_DIR="${GH_AW_TMP_DIR:-/tmp/gh-aw}"
+
 cleanup() {
     rm -rf "$TEST_DIR"
 }
@@ -119,4 +122,39 @@ else
 fi
 echo ""
 
+# Test 6: Prompt with the scratch directory placeholder (should pass - resolved by the agent job)
+echo "Test 6: Prompt with the scratch directory placeholder (should pass)"
+cat > "$TEST_DIR/prompt_tmp_dir.txt" << 'EOF'
+<system>
+<path>__GH_AW_TMP_DIR__/agent/</path>
+</system>
+
+# User Task
+Write notes to __GH_AW_TMP_DIR__/cache-memory/notes.md.
+EOF
+
+export GH_AW_PROMPT="$TEST_DIR/prompt_tmp_dir.txt"
+if bash "$SCRIPT_PATH"; then
+    echo "✅ Test 6 passed: Scratch directory placeholder accepted"
+else
+    echo "❌ Test 6 failed: Scratch directory placeholder incorrectly rejected"
+    exit 1
+fi
+echo ""
+
+# Test 7: Other placeholders are still reported next to the scratch directory placeholder
+echo "Test 7: Unreplaced placeholder next to the scratch directory placeholder (should fail)"
+cat > "$TEST_DIR/prompt_tmp_dir_bad.txt" << 'EOF'
+Write notes to __GH_AW_TMP_DIR__/agent/ for __GH_AW_GITHUB_ACTOR__.
+EOF
+
+export GH_AW_PROMPT="$TEST_DIR/prompt_tmp_dir_bad.txt"
+if bash "$SCRIPT_PATH" 2>&1; then
+    echo "❌ Test 7 failed: Unreplaced placeholder not detected"
+    exit 1
+else
+    echo "✅ Test 7 passed: Unreplaced placeholder detected"
+fi
+echo ""
+
 echo "🎉 All validation tests passed!"
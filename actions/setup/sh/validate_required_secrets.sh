@@ -0,0 +1,80 @@
+#!/bin/bash
+set +o histexpand
+
+set -e
+
+# validate_required_secrets.sh - Validate that every secret listed in required-secrets is configured
+#
+# Usage: validate_required_secrets.sh SECRET_NAME1 [SECRET_NAME2 ...]
+#
+# Arguments:
+#   SECRET_NAME1, SECRET_NAME2, ... : Environment variable names to check (all required)
+#
+# Environment:
+#   The script expects the secret values to be available as environment variables
+#
+# Exit codes:
+#   0 - All secrets are configured
+#   1 - One or more secrets are empty or not set
+
+if [ "$#" -lt 1 ]; then
+  echo "Usage: $0 SECRET_NAME1 [SECRET_NAME2 ...]" >&2
+  exit 1
+fi
+
+missing=()
+for secret_name in "$@"; do
+  # Use indirect expansion to get the value of the variable named by secret_name
+  secret_value="${!secret_name}"
+  if [ -z "$secret_value" ]; then
+    missing+=("$secret_name")
+  fi
+done
+
+if [ "${#missing[@]}" -gt 0 ]; then
+  missing_list=$(printf '%s, ' "${missing[@]}")
+  missing_list="${missing_list%, }"
+
+  {
+    echo "❌ Error: The following required secrets are not set: $missing_list"
+    echo ""
+    echo "This workflow lists them under \`required-secrets:\` because its MCP servers cannot start without them."
+    echo ""
+    echo "**How to fix:**"
+    echo "1. Go to your repository Settings → Secrets and variables → Actions"
+    echo "2. Add each missing secret, or run:"
+    echo ""
+    echo '```bash'
+    for secret_name in "${missing[@]}"; do
+      echo "gh secret set $secret_name"
+    done
+    echo '```'
+    echo ""
+    echo "**Common causes if you believe the secret is already configured:**"
+    echo "- **Organization secrets** must have repository access granted (Settings → Secrets → Repository access)"
+    echo "- **Environment secrets** are only available if the job specifies that environment"
+    echo "- **Secret name mismatch** - verify the exact spelling (case-sensitive)"
+  } >> "$GITHUB_STEP_SUMMARY"
+
+  for secret_name in "${missing[@]}"; do
+    echo "::error::Required secret $secret_name is not set. Run: gh secret set $secret_name"
+  done
+  echo "Error: The following required secrets are not set: $missing_list" >&2
+
+  if [ -n "$GITHUB_OUTPUT" ]; then
+    echo "verification_result=failed" >> "$GITHUB_OUTPUT"
+  fi
+  exit 1
+fi
+
+echo "<details>"
+echo "<summary>Required Secrets Validation</summary>"
+echo ""
+for secret_name in "$@"; do
+  echo "✅ $secret_name: Configured"
+done
+echo "</details>"
+
+if [ -n "$GITHUB_OUTPUT" ]; then
+  echo "verification_result=success" >> "$GITHUB_OUTPUT"
+fi
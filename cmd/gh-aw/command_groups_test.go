@@ -49,6 +49,7 @@ func TestCommandGroupAssignments(t *testing.T) {
 		{name: "health command in analysis group", commandName: "health", expectedGroup: "analysis", shouldHaveGroup: true},
 		{name: "outcomes command in analysis group", commandName: "outcomes", expectedGroup: "analysis", shouldHaveGroup: true},
 		{name: "checks command in analysis group", commandName: "checks", expectedGroup: "analysis", shouldHaveGroup: true},
+		{name: "graph command in analysis group", commandName: "graph", expectedGroup: "analysis", shouldHaveGroup: true},
 		// Hidden commands should still be grouped so they appear in the correct
 		// section when explicitly shown (for example in full help/test contexts).
 		{name: "view command in analysis group", commandName: "view", expectedGroup: "analysis", shouldHaveGroup: true},
@@ -59,7 +60,9 @@ func TestCommandGroupAssignments(t *testing.T) {
 		{name: "pr command in utilities group", commandName: "pr", expectedGroup: "utilities", shouldHaveGroup: true},
 		{name: "completion command in utilities group", commandName: "completion", expectedGroup: "utilities", shouldHaveGroup: true},
 		{name: "hash-frontmatter command in utilities group", commandName: "hash-frontmatter", expectedGroup: "utilities", shouldHaveGroup: true},
+		{name: "schema command in utilities group", commandName: "schema", expectedGroup: "utilities", shouldHaveGroup: true},
 		{name: "project command in utilities group", commandName: "project", expectedGroup: "utilities", shouldHaveGroup: true},
+		{name: "telemetry command in utilities group", commandName: "telemetry", expectedGroup: "utilities", shouldHaveGroup: true},
 
 		// Commands without groups (intentionally)
 		{name: "version command without group", commandName: "version", expectedGroup: "", shouldHaveGroup: false},
@@ -123,18 +123,39 @@ When called with a workflow name, creates a template file with comprehensive exa
 - Tools configuration (GitHub, Claude, MCPs)
 - All frontmatter options with explanations
 
+With --preset, creates a ready-to-compile workflow from a built-in preset with recommended
+guardrails instead of the template. Customize the preset with --preset-param key=value.
+Available presets:
+- triage: Label new issues by type and priority and flag likely duplicates
+  (parameters: labels, priorities, dedup=comment|label|off)
+- ci-failure: Summarize the logs of failed CI runs and report the likely cause and a suggested fix as a check run
+  (parameters: workflows, branches, log-lines, issue=on|off)
+- dependency-review: Review dependency update pull requests from Dependabot or Renovate and post an advisory risk assessment
+  (parameters: bots, high-risk-label)
+- stale-gardener: Review stale issues in daily batches and ask clarifying questions or propose closure
+  (parameters: stale-days, batch-size, stale-label, exempt-labels, close=on|off)
+- docs-drift: Compare the documentation to recent code changes each week and file issues for drift
+  (parameters: docs-paths, days, max-issues, label)
+- translation: Translate documentation changes into each target language and open one pull request per language
+  (parameters: source, languages, glossary, branch, label)
+
 ` + cli.WorkflowIDExplanation,
 	Example: `  ` + string(constants.CLIExtensionPrefix) + ` new                      # Interactive mode
   ` + string(constants.CLIExtensionPrefix) + ` new my-workflow          # Create template file
   ` + string(constants.CLIExtensionPrefix) + ` new my-workflow.md       # Same as above (.md extension stripped)
   ` + string(constants.CLIExtensionPrefix) + ` new my-workflow --force  # Overwrite if exists
-  ` + string(constants.CLIExtensionPrefix) + ` new my-workflow --engine copilot  # Create template with specific engine`,
+  ` + string(constants.CLIExtensionPrefix) + ` new my-workflow --engine copilot  # Create template with specific engine
+  ` + string(constants.CLIExtensionPrefix) + ` new --preset triage      # Create triage.md from the triage preset
+  ` + string(constants.CLIExtensionPrefix) + ` new issue-triage --preset triage --preset-param priorities=high,medium,low
+  ` + string(constants.CLIExtensionPrefix) + ` new --preset ci-failure --preset-param workflows=Build,Test`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		forceFlag, _ := cmd.Flags().GetBool("force")
 		verbose, _ := cmd.Flags().GetBool("verbose")
 		interactiveFlag, _ := cmd.Flags().GetBool("interactive")
 		engineOverride, _ := cmd.Flags().GetString("engine")
+		preset, _ := cmd.Flags().GetString("preset")
+		presetParams, _ := cmd.Flags().GetStringArray("preset-param")
 
 		if engineOverride != "" {
 			if err := validateEngine(engineOverride); err != nil {
@@ -142,6 +163,20 @@ When called with a workflow name, creates a template file with comprehensive exa
 			}
 		}
 
+		if preset != "" {
+			if interactiveFlag {
+				return errors.New("--preset cannot be combined with --interactive")
+			}
+			workflowName := preset
+			if len(args) > 0 {
+				workflowName = args[0]
+			}
+			return cli.CreateWorkflowFromPreset(workflowName, preset, presetParams, verbose, forceFlag, engineOverride)
+		}
+		if len(presetParams) > 0 {
+			return errors.New("--preset-param requires --preset")
+		}
+
 		// If no arguments provided or interactive flag is set, use interactive mode
 		if len(args) == 0 || interactiveFlag {
 			// Check if running in CI environment
@@ -194,36 +229,46 @@ by any workflow. Use --no-remove-orphans to skip this cleanup.`,
 var enableCmd = &cobra.Command{
 	Use:   "enable [workflow]...",
 	Short: "Enable agentic workflows",
-	Long: `Enable one or more workflows by ID, or all workflows if no IDs are provided.
+	Long: `Enable one or more workflows by ID or glob pattern, or all workflows if no IDs are provided.
+
+Use --reason to record why the workflows were enabled; the reason is shown by the
+status command. Recording a reason requires permission to manage repository variables.
 
 ` + cli.WorkflowIDExplanation,
 	Example: `  ` + string(constants.CLIExtensionPrefix) + ` enable                   # Enable all workflows
   ` + string(constants.CLIExtensionPrefix) + ` enable ci-doctor         # Enable specific workflow
   ` + string(constants.CLIExtensionPrefix) + ` enable ci-doctor.md      # Enable specific workflow (alternative format)
   ` + string(constants.CLIExtensionPrefix) + ` enable ci-doctor daily   # Enable multiple workflows
+  ` + string(constants.CLIExtensionPrefix) + ` enable 'triage-*' --reason "incident resolved"  # Enable matching workflows and record why
   ` + string(constants.CLIExtensionPrefix) + ` enable ci-doctor --repo owner/repo  # Enable workflow in specific repository`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		repoOverride, _ := cmd.Flags().GetString("repo")
-		return cli.EnableWorkflowsByNames(cmd.Context(), args, repoOverride)
+		reason, _ := cmd.Flags().GetString("reason")
+		return cli.EnableWorkflowsByNames(cmd.Context(), args, repoOverride, reason)
 	},
 }
 
 var disableCmd = &cobra.Command{
 	Use:   "disable [workflow]...",
 	Short: "Disable agentic workflows",
-	Long: `Disable one or more workflows by ID, or all workflows if no IDs are provided.
+	Long: `Disable one or more workflows by ID or glob pattern, or all workflows if no IDs are provided.
 
 Any in-progress runs will be canceled before disabling.
 
+Use --reason to record why the workflows were disabled; the reason is shown by the
+status command. Recording a reason requires permission to manage repository variables.
+
 ` + cli.WorkflowIDExplanation,
 	Example: `  ` + string(constants.CLIExtensionPrefix) + ` disable                   # Disable all workflows
   ` + string(constants.CLIExtensionPrefix) + ` disable ci-doctor         # Disable specific workflow
   ` + string(constants.CLIExtensionPrefix) + ` disable ci-doctor.md      # Disable specific workflow (alternative format)
   ` + string(constants.CLIExtensionPrefix) + ` disable ci-doctor daily   # Disable multiple workflows
+  ` + string(constants.CLIExtensionPrefix) + ` disable 'triage-*' --reason "posting duplicate comments"  # Pause matching workflows and record why
   ` + string(constants.CLIExtensionPrefix) + ` disable ci-doctor --repo owner/repo  # Disable workflow in specific repository`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		repoOverride, _ := cmd.Flags().GetString("repo")
-		return cli.DisableWorkflowsByNames(cmd.Context(), args, repoOverride)
+		reason, _ := cmd.Flags().GetString("reason")
+		return cli.DisableWorkflowsByNames(cmd.Context(), args, repoOverride, reason)
 	},
 }
 
@@ -281,6 +326,12 @@ Unlike ` + "`gh aw upgrade`" + `, ` + "`gh aw compile`" + ` only applies codemod
   ` + string(constants.CLIExtensionPrefix) + ` compile --dir custom/workflows  # Compile from custom directory
   ` + string(constants.CLIExtensionPrefix) + ` compile ci-doctor --watch     # Watch and auto-compile
   ` + string(constants.CLIExtensionPrefix) + ` compile --trial --logical-repo owner/repo  # Compile for trial mode
+  ` + string(constants.CLIExtensionPrefix) + ` compile --no-emit --format github  # Annotate workflow sources in a pull request check
+  ` + string(constants.CLIExtensionPrefix) + ` compile ci-doctor --stdout   # Print the compiled workflow for post-processing
+  ` + string(constants.CLIExtensionPrefix) + ` compile --output-dir build/workflows  # Write lock files to another directory
+  ` + string(constants.CLIExtensionPrefix) + ` compile ci-doctor --ir json  # Also write ci-doctor.ir.json for downstream tooling
+  ` + string(constants.CLIExtensionPrefix) + ` compile ci-doctor --explain-network  # Show how network.allowed expands into domains
+  ` + string(constants.CLIExtensionPrefix) + ` compile --update-mcp        # Refresh MCP server image digest pins
   ` + string(constants.CLIExtensionPrefix) + ` compile --dependabot        # Generate Dependabot manifests
   ` + string(constants.CLIExtensionPrefix) + ` compile --dependabot --force  # Force overwrite existing dependabot.yml
   ` + string(constants.CLIExtensionPrefix) + ` compile --gh-aw-ref main       # Pin workflows to the SHA of github/gh-aw main at compile time
@@ -309,14 +360,17 @@ Unlike ` + "`gh aw upgrade`" + `, ` + "`gh aw compile`" + ` only applies codemod
 		dir, _ := cmd.Flags().GetString("dir")
 		workflowsDir, _ := cmd.Flags().GetString("workflows-dir")
 		noEmit, _ := cmd.Flags().GetBool("no-emit")
+		check, _ := cmd.Flags().GetBool("check")
 		purge, _ := cmd.Flags().GetBool("purge")
 		strict, _ := cmd.Flags().GetBool("strict")
+		updateLintBaseline, _ := cmd.Flags().GetBool("update-lint-baseline")
 		trial, _ := cmd.Flags().GetBool("trial")
 		logicalRepo, _ := cmd.Flags().GetString("logical-repo")
 		dependabot, _ := cmd.Flags().GetBool("dependabot")
 		forceOverwrite, _ := cmd.Flags().GetBool("force")
 		refreshStopTime, _ := cmd.Flags().GetBool("refresh-stop-time")
 		forceRefreshActionPins, _ := cmd.Flags().GetBool("force-refresh-action-pins")
+		updateMCP, _ := cmd.Flags().GetBool("update-mcp")
 		allowActionRefs, _ := cmd.Flags().GetBool("allow-action-refs")
 		zizmor, _ := cmd.Flags().GetBool("zizmor")
 		poutine, _ := cmd.Flags().GetBool("poutine")
@@ -327,9 +381,14 @@ Unlike ` + "`gh aw upgrade`" + `, ` + "`gh aw compile`" + ` only applies codemod
 		grant, _ := cmd.Flags().GetBool("grant")
 		yamllint, _ := cmd.Flags().GetBool("yamllint")
 		jsonOutput, _ := cmd.Flags().GetBool("json")
+		format, _ := cmd.Flags().GetString("format")
 		showAllErrors, _ := cmd.Flags().GetBool("show-all")
 		fix, _ := cmd.Flags().GetBool("fix")
 		stats, _ := cmd.Flags().GetBool("stats")
+		explainNetwork, _ := cmd.Flags().GetBool("explain-network")
+		irFormat, _ := cmd.Flags().GetString("ir")
+		stdout, _ := cmd.Flags().GetBool("stdout")
+		outputDir, _ := cmd.Flags().GetString("output-dir")
 		failFast, _ := cmd.Flags().GetBool("fail-fast")
 		noCheckUpdate, _ := cmd.Flags().GetBool("no-check-update")
 		scheduleSeed, _ := cmd.Flags().GetString("schedule-seed")
@@ -341,13 +400,18 @@ Unlike ` + "`gh aw upgrade`" + `, ` + "`gh aw compile`" + ` only applies codemod
 		ghes, _ := cmd.Flags().GetBool("ghes")
 		verbose, _ := cmd.Flags().GetBool("verbose")
 		useSamples, _ := cmd.Flags().GetBool("use-samples")
+		offline, _ := cmd.Flags().GetBool("offline")
 		if err := validateEngine(engineOverride); err != nil {
 			return err
 		}
 
-		finishCompileUpdateCheck := cli.StartCompileUpdateCheck(cmd.Context(), noCheckUpdate, verbose)
+		finishCompileUpdateCheck := cli.StartCompileUpdateCheck(cmd.Context(), noCheckUpdate || offline, verbose)
 		defer finishCompileUpdateCheck()
 
+		if check && fix {
+			return errors.New("--check cannot be used with --fix")
+		}
+
 		// If --fix is specified, run fix --write first
 		if fix {
 			fixConfig := cli.FixConfig{
@@ -378,14 +442,19 @@ Unlike ` + "`gh aw upgrade`" + `, ` + "`gh aw compile`" + ` only applies codemod
 			WorkflowDir:            workflowDir,
 			SkipInstructions:       false, // Deprecated field, kept for backward compatibility
 			NoEmit:                 noEmit,
+			Check:                  check,
+			Stdout:                 stdout,
+			OutputDir:              outputDir,
 			Purge:                  purge,
 			TrialMode:              trial,
 			TrialLogicalRepoSlug:   logicalRepo,
 			Strict:                 strict,
+			UpdateLintBaseline:     updateLintBaseline,
 			Dependabot:             dependabot,
 			ForceOverwrite:         forceOverwrite,
 			RefreshStopTime:        refreshStopTime,
 			ForceRefreshActionPins: forceRefreshActionPins,
+			UpdateMCP:              updateMCP,
 			AllowActionRefs:        allowActionRefs,
 			Zizmor:                 zizmor,
 			Poutine:                poutine,
@@ -396,8 +465,11 @@ Unlike ` + "`gh aw upgrade`" + `, ` + "`gh aw compile`" + ` only applies codemod
 			Grant:                  grant,
 			Yamllint:               yamllint,
 			JSONOutput:             jsonOutput,
+			Format:                 format,
 			ShowAllErrors:          showAllErrors,
 			Stats:                  stats,
+			ExplainNetwork:         explainNetwork,
+			IR:                     irFormat,
 			FailFast:               failFast,
 			ScheduleSeed:           scheduleSeed,
 			Staged:                 staged,
@@ -407,6 +479,7 @@ Unlike ` + "`gh aw upgrade`" + `, ` + "`gh aw compile`" + ` only applies codemod
 			PriorManifestFile:      priorManifestFile,
 			GHESCompat:             ghes,
 			UseSamples:             useSamples,
+			Offline:                offline,
 		}
 		if _, err := cli.CompileWorkflows(cmd.Context(), config); err != nil {
 			// Return error as-is without additional formatting
@@ -444,8 +517,10 @@ This command only works with workflows that have workflow_dispatch triggers.
   ` + string(constants.CLIExtensionPrefix) + ` run daily-perf-improver --auto-merge-prs  # Auto-merge any PRs created during execution
   ` + string(constants.CLIExtensionPrefix) + ` run daily-perf-improver --raw-field name=value --raw-field env=prod  # Pass workflow inputs
   ` + string(constants.CLIExtensionPrefix) + ` run daily-perf-improver --push  # Commit, push, and dispatch the workflow
+  ` + string(constants.CLIExtensionPrefix) + ` run daily-perf-improver --watch  # Stream the agent's output live until the run completes
   ` + string(constants.CLIExtensionPrefix) + ` run daily-perf-improver --dry-run  # Preview without triggering workflow runs
-  ` + string(constants.CLIExtensionPrefix) + ` run daily-perf-improver --json  # Output results in JSON format`,
+  ` + string(constants.CLIExtensionPrefix) + ` run daily-perf-improver --json  # Output results in JSON format
+  ` + string(constants.CLIExtensionPrefix) + ` run issue-triage --fixture event.json  # Simulate which jobs would run for a fixture event`,
 	Args: cobra.ArbitraryArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		repeatCount, _ := cmd.Flags().GetInt("repeat")
@@ -459,10 +534,21 @@ This command only works with workflows that have workflow_dispatch triggers.
 		dryRun, _ := cmd.Flags().GetBool("dry-run")
 		jsonOutput, _ := cmd.Flags().GetBool("json")
 		approveRun, _ := cmd.Flags().GetBool("approve")
+		watch, _ := cmd.Flags().GetBool("watch")
+		fixture, _ := cmd.Flags().GetString("fixture")
 
 		if err := validateEngine(engineOverride); err != nil {
 			return err
 		}
+		if watch && jsonOutput {
+			return errors.New("--watch cannot be combined with --json")
+		}
+		if watch && dryRun {
+			return errors.New("--watch cannot be combined with --dry-run")
+		}
+		if fixture != "" && (watch || push || repoOverride != "") {
+			return errors.New("--fixture simulates local lock files and cannot be combined with --watch, --push or --repo")
+		}
 
 		// If no arguments provided, enter interactive mode
 		if len(args) == 0 {
@@ -481,6 +567,12 @@ This command only works with workflows that have workflow_dispatch triggers.
 			if len(inputs) > 0 {
 				return errors.New("workflow inputs cannot be specified in interactive mode (they will be collected interactively)")
 			}
+			if watch {
+				return errors.New("--watch flag is not supported in interactive mode")
+			}
+			if fixture != "" {
+				return errors.New("--fixture flag is not supported in interactive mode")
+			}
 
 			return cli.RunWorkflowInteractively(cmd.Context(), verboseFlag, repoOverride, refOverride, autoMergePRs, push, engineOverride, dryRun)
 		}
@@ -498,6 +590,8 @@ This command only works with workflows that have workflow_dispatch triggers.
 			DryRun:         dryRun,
 			JSON:           jsonOutput,
 			Approve:        approveRun,
+			Watch:          watch,
+			Fixture:        fixture,
 		})
 	},
 }
@@ -715,6 +809,7 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 
 	// Create and setup trial command
 	trialCmd := cli.NewTrialCommand(validateEngine)
+	benchCmd := cli.NewBenchCommand(validateEngine)
 
 	// Create and setup init command
 	initCmd := cli.NewInitCommand()
@@ -724,6 +819,8 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	newCmd.Flags().BoolP("force", "f", false, "Overwrite existing workflow files without confirmation")
 	newCmd.Flags().BoolP("interactive", "i", false, "Launch interactive workflow creation wizard")
 	newCmd.Flags().StringP("engine", "e", "", cli.EngineFlagOverrideUsage)
+	newCmd.Flags().String("preset", "", "Create the workflow from a built-in preset: "+strings.Join(cli.WorkflowPresetNames(), ", "))
+	newCmd.Flags().StringArray("preset-param", []string{}, "Set a preset parameter in key=value format (can be specified multiple times)")
 	cli.RegisterEngineFlagCompletion(newCmd)
 
 	// Add AI flag to compile and add commands
@@ -738,8 +835,12 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	compileCmd.Flags().String("workflows-dir", "", "Deprecated: use --dir instead")
 	_ = compileCmd.Flags().MarkDeprecated("workflows-dir", "use --dir instead")
 	compileCmd.Flags().Bool("no-emit", false, "Validate workflow without generating lock files")
+	compileCmd.Flags().Bool("check", false, "Compile without writing and fail when a .lock.yml file is missing or differs byte-for-byte from its markdown source (for CI)")
+	compileCmd.Flags().Bool("stdout", false, "Write the compiled workflows to stdout instead of .lock.yml files, separated by YAML document markers. Diagnostics stay on stderr")
+	compileCmd.Flags().String("output-dir", "", "Write the .lock.yml files to this directory instead of next to their markdown source")
 	compileCmd.Flags().Bool("purge", false, "Delete .lock.yml files that were not regenerated during compilation (only when no specific files are provided)")
-	compileCmd.Flags().Bool("strict", false, "Override frontmatter to enforce strict mode validation for all workflows (enforces action pinning, network config, safe-outputs, disallows write permissions and deprecated fields). Note: Workflows default to strict mode unless frontmatter sets strict: false")
+	compileCmd.Flags().Bool("strict", false, "Override frontmatter to enforce strict mode validation for all workflows (enforces action pinning, network config, safe-outputs, disallows write permissions and deprecated fields) and fail on security lint findings not accepted in .github/aw/security-lints-baseline.json. Note: Workflows default to strict mode unless frontmatter sets strict: false")
+	compileCmd.Flags().Bool("update-lint-baseline", false, "Accept the current security lint findings by writing them to .github/aw/security-lints-baseline.json (requires --strict)")
 	compileCmd.Flags().Bool("trial", false, "Enable trial mode compilation (modifies workflows for trial execution)")
 	compileCmd.Flags().StringP("logical-repo", "l", "", "Repository to simulate workflow execution against (for trial mode)")
 	compileCmd.Flags().Bool("use-samples", false, "Hidden: replace the agentic 'Execute coding agent' step with a deterministic driver that replays the workflow's safe-outputs `samples` frontmatter entries through the safe-outputs MCP server. Used to make end-to-end tests deterministic.")
@@ -748,6 +849,7 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	compileCmd.Flags().BoolP("force", "f", false, "Force overwrite of existing dependency files (only applies when --dependabot is set; e.g., dependabot.yml)")
 	compileCmd.Flags().Bool("refresh-stop-time", false, "Force regeneration of stop-after times instead of preserving existing values from lock files")
 	compileCmd.Flags().Bool("force-refresh-action-pins", false, "Force refresh of action pins by clearing the cache and resolving all action SHAs from GitHub API")
+	compileCmd.Flags().Bool("update-mcp", false, "Re-resolve the digests of MCP server container images and store the pins in .github/aw/actions-lock.json (requires Docker or crane)")
 	compileCmd.Flags().Bool("allow-action-refs", false, "Allow unresolved action refs and emit warnings instead of failing validation")
 	compileCmd.Flags().Bool("zizmor", false, "Run zizmor security scanner on generated .lock.yml files")
 	compileCmd.Flags().Bool("poutine", false, "Run poutine security scanner on generated .lock.yml files")
@@ -759,8 +861,11 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	compileCmd.Flags().Bool("yamllint", false, "Run yamllint YAML linter on generated .lock.yml files (uses Docker image "+cli.YamllintImage+")")
 	compileCmd.Flags().Bool("fix", false, "Apply automatic codemod fixes to workflows before compiling")
 	compileCmd.Flags().BoolP("json", "j", false, "Output results in JSON format")
+	compileCmd.Flags().String("format", "text", "Diagnostics format: text, json (same as --json), or github (::error/::warning annotations for pull request checks)")
 	compileCmd.Flags().Bool("show-all", false, "Display all compilation errors instead of only the highest-priority subset (default: top 5)")
 	compileCmd.Flags().Bool("stats", false, "Display statistics table sorted by workflow file size (shows jobs, steps, scripts, and shells)")
+	compileCmd.Flags().Bool("explain-network", false, "Print how each network.allowed entry (domain or ecosystem bundle such as defaults, node, python, go) expands into the firewall allow-list")
+	compileCmd.Flags().String("ir", "", "Also write an intermediate representation of each compiled workflow next to its lock file (<workflow>.ir.json) for tools that target other orchestrators. Supported format: json")
 	compileCmd.Flags().Bool("fail-fast", false, "Stop at the first validation error instead of collecting all errors")
 	compileCmd.Flags().Bool("no-check-update", false, "Skip checking for gh-aw updates")
 	compileCmd.Flags().String("schedule-seed", "", "Override the repository slug (owner/repo) used as seed for fuzzy schedule scattering (e.g., \"github/gh-aw\"). Bypasses git remote detection entirely. Use this when your git remote is not named \"origin\" and you have multiple remotes configured")
@@ -770,6 +875,7 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	compileCmd.Flags().Bool("no-models-dev-lookup", false, "Disable compile-time models.dev pricing lookup for models missing from the embedded catalog")
 	compileCmd.Flags().String("prior-manifest-file", "", "Path to a JSON file containing pre-cached gh-aw-manifests (map[lockFile]*GHAWManifest); used by the MCP server to supply a tamper-proof manifest baseline captured at startup")
 	compileCmd.Flags().Bool("ghes", false, "Enable GitHub Enterprise Server (GHES) compatibility mode. Artifact actions continue using latest non-v3 pins (v3 is deprecated). Overrides the aw.json ghes field")
+	compileCmd.Flags().Bool("offline", false, "Compile without network access for air-gapped environments. Action SHAs come from .github/aw/actions-lock.json and the embedded pins, remote imports from .github/aw/imports, and the update check and models.dev lookup are skipped. Fails with a clear error when something is only available online")
 	if err := compileCmd.Flags().MarkHidden("prior-manifest-file"); err != nil {
 		// Non-fatal: flag is registered even if MarkHidden fails
 		_ = err
@@ -779,6 +885,8 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	// combining it with either of those flags leads to one silently overwriting the other.
 	compileCmd.MarkFlagsMutuallyExclusive("gh-aw-ref", "action-tag")
 	compileCmd.MarkFlagsMutuallyExclusive("gh-aw-ref", "action-mode")
+	// --gh-aw-ref resolves a ref against the GitHub API before compilation starts.
+	compileCmd.MarkFlagsMutuallyExclusive("gh-aw-ref", "offline")
 
 	// Register completions for compile command
 	compileCmd.ValidArgsFunction = cli.CompleteWorkflowNames
@@ -799,6 +907,8 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	// Add flags to enable/disable commands
 	enableCmd.Flags().StringP("repo", "r", "", "Target repository ([HOST/]owner/repo format). Defaults to current repository")
 	disableCmd.Flags().StringP("repo", "r", "", "Target repository ([HOST/]owner/repo format). Defaults to current repository")
+	enableCmd.Flags().String("reason", "", "Record why the workflows were enabled; shown by the status command")
+	disableCmd.Flags().String("reason", "", "Record why the workflows were disabled; shown by the status command")
 	// Register completions for enable/disable commands
 	enableCmd.ValidArgsFunction = cli.CompleteWorkflowNames
 	disableCmd.ValidArgsFunction = cli.CompleteWorkflowNames
@@ -814,7 +924,9 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	_ = runCmd.Flags().MarkShorthandDeprecated("raw-field", "use the long form --raw-field instead")
 	runCmd.Flags().Bool("push", false, "Commit and push workflow files (including transitive imports) before running. Refuses to proceed when unrelated files are already staged.")
 	runCmd.Flags().Bool("dry-run", false, "Preview workflow execution without triggering runs on GitHub Actions")
+	runCmd.Flags().Bool("watch", false, "Follow the run after dispatching it and stream the agent's output live until the run completes")
 	runCmd.Flags().BoolP("json", "j", false, "Output results in JSON format")
+	runCmd.Flags().String("fixture", "", "Simulate which jobs would run for the event in this JSON fixture file instead of dispatching the workflow")
 	runCmd.Flags().Bool("approve", false, "Approve safe update manifest changes when --push triggers an automatic recompile step. When strict mode is active (the default), the recompile step enforces safe update checking; pass this flag to approve those changes.")
 	// Register completions for run command
 	runCmd.ValidArgsFunction = cli.CompleteWorkflowNames
@@ -830,6 +942,8 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	mcpCmd := cli.NewMCPCommand()
 	logsCmd := cli.NewLogsCommand()
 	auditCmd := cli.NewAuditCommand()
+	explainRunCmd := cli.NewExplainRunCommand()
+	graphCmd := cli.NewGraphCommand()
 	viewCmd := cli.NewViewCommand()
 	healthCmd := cli.NewHealthCommand()
 	outcomesCmd := cli.NewOutcomesCommand()
@@ -840,6 +954,7 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	upgradeCmd := cli.NewUpgradeCommand(validateEngine)
 	completionCmd := cli.NewCompletionCommand()
 	hashCmd := cli.NewHashCommand()
+	schemaCmd := cli.NewSchemaCommand()
 	projectCmd := cli.NewProjectCommand()
 	doctorCmd := cli.NewDoctorCommand()
 	checksCmd := cli.NewChecksCommand()
@@ -849,6 +964,9 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	experimentsCmd := cli.NewExperimentsCommand()
 	forecastCmd := cli.NewForecastCommand()
 	envCmd := cli.NewEnvCommand()
+	telemetryCmd := cli.NewTelemetryCommand()
+	rollbackCmd := cli.NewRollbackCommand()
+	rollbackCmd.ValidArgsFunction = cli.CompleteWorkflowNames
 
 	// Assign commands to groups
 	// Setup Commands
@@ -877,10 +995,13 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	enableCmd.GroupID = "execution"
 	disableCmd.GroupID = "execution"
 	trialCmd.GroupID = "execution"
+	benchCmd.GroupID = "execution"
+	rollbackCmd.GroupID = "execution"
 
 	// Analysis Commands
 	logsCmd.GroupID = "analysis"
 	auditCmd.GroupID = "analysis"
+	explainRunCmd.GroupID = "analysis"
 	viewCmd.GroupID = "analysis"
 	healthCmd.GroupID = "analysis"
 	outcomesCmd.GroupID = "analysis"
@@ -889,13 +1010,16 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	listCmd.GroupID = "analysis"
 	experimentsCmd.GroupID = "analysis"
 	forecastCmd.GroupID = "analysis"
+	graphCmd.GroupID = "analysis"
 
 	// Utilities
 	mcpServerCmd.GroupID = "utilities"
 	prCmd.GroupID = "utilities"
 	completionCmd.GroupID = "utilities"
 	hashCmd.GroupID = "utilities"
+	schemaCmd.GroupID = "utilities"
 	projectCmd.GroupID = "utilities"
+	telemetryCmd.GroupID = "utilities"
 
 	// version command is intentionally left without a group (common practice)
 
@@ -906,6 +1030,7 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	rootCmd.AddCommand(deployCmd)
 	rootCmd.AddCommand(upgradeCmd)
 	rootCmd.AddCommand(trialCmd)
+	rootCmd.AddCommand(benchCmd)
 	rootCmd.AddCommand(newCmd)
 	rootCmd.AddCommand(initCmd)
 
@@ -917,6 +1042,8 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	rootCmd.AddCommand(disableCmd)
 	rootCmd.AddCommand(logsCmd)
 	rootCmd.AddCommand(auditCmd)
+	rootCmd.AddCommand(explainRunCmd)
+	rootCmd.AddCommand(graphCmd)
 	rootCmd.AddCommand(viewCmd)
 	rootCmd.AddCommand(healthCmd)
 	rootCmd.AddCommand(outcomesCmd)
@@ -931,12 +1058,15 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	rootCmd.AddCommand(lintCmd)
 	rootCmd.AddCommand(completionCmd)
 	rootCmd.AddCommand(hashCmd)
+	rootCmd.AddCommand(schemaCmd)
 	rootCmd.AddCommand(projectCmd)
+	rootCmd.AddCommand(telemetryCmd)
 	rootCmd.AddCommand(doctorCmd)
 	rootCmd.AddCommand(domainsCmd)
 	rootCmd.AddCommand(experimentsCmd)
 	rootCmd.AddCommand(forecastCmd)
 	rootCmd.AddCommand(envCmd)
+	rootCmd.AddCommand(rollbackCmd)
 
 	// Fix help flag descriptions for all subcommands to be consistent with the
 	// root command ("Show help for gh aw" vs the Cobra default "help for [cmd]").
@@ -374,6 +374,8 @@ export default defineConfig({
 					label: 'Reference',
 					items: [
 						{ label: 'AI Engines', link: '/reference/engines/' },
+						{ label: 'Agentic Jobs', link: '/reference/agentic-jobs/' },
+						{ label: 'Fan-Out', link: '/reference/fan-out/' },
 						{ label: 'Artifacts', link: '/reference/artifacts/' },
 						{ label: 'Auditing Workflows', link: '/reference/audit/' },
 						{ label: 'Authentication', link: '/reference/auth/' },
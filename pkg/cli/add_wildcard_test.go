@@ -75,6 +75,21 @@ func TestParseWorkflowSpecWithWildcard(t *testing.T) {
 			expectedVer:    "",
 			expectedPath:   "agentic-workflows/business-deviation-tracker.md",
 		},
+		{
+			name:           "workflow_path_without_extension_pinned_to_tag",
+			spec:           "owner/repo/workflows/triage@v1.2.0",
+			expectWildcard: false,
+			expectError:    false,
+			expectedRepo:   "owner/repo",
+			expectedVer:    "v1.2.0",
+			expectedPath:   "workflows/triage.md",
+		},
+		{
+			name:           "workflow_path_with_non_markdown_extension",
+			spec:           "owner/repo/workflows/triage.yml",
+			expectWildcard: false,
+			expectError:    true,
+		},
 		{
 			name:           "invalid_spec_too_few_parts",
 			spec:           "owner/*",
@@ -57,7 +57,10 @@ Each argument accepts:
 When a job URL is provided (single-run mode only):
 - If a step number is included (#step:7:1), extracts that specific step's output
 - If no step number, finds and extracts the first failing step's output
-- Saves job logs to the output directory`
+- Saves job logs to the output directory
+
+To aggregate many runs of a workflow over a time window (success rate, cost,
+most-used tools, firewall denials, safe-output counts), use 'audit trends'.`
 
 var auditCommandExample = `  ` + string(constants.CLIExtensionPrefix) + ` audit 1234567890 --repo owner/repo  # Audit with bare run ID (--repo required)
   ` + string(constants.CLIExtensionPrefix) + ` audit https://github.com/owner/repo/actions/runs/1234567890  # Audit from run URL
@@ -71,7 +74,8 @@ var auditCommandExample = `  ` + string(constants.CLIExtensionPrefix) + ` audit
   ` + string(constants.CLIExtensionPrefix) + ` audit 1234567890 --repo owner/repo  # Audit run from a specific repository
   ` + string(constants.CLIExtensionPrefix) + ` audit 1234567890 1234567891         # Diff two runs (base vs comparison)
   ` + string(constants.CLIExtensionPrefix) + ` audit 1234567890 1234567891 1234567892  # Diff base against multiple runs
-  ` + string(constants.CLIExtensionPrefix) + ` audit 1234567890 1234567891 --format markdown  # Markdown diff output for PR comments`
+  ` + string(constants.CLIExtensionPrefix) + ` audit 1234567890 1234567891 --format markdown  # Markdown diff output for PR comments
+  ` + string(constants.CLIExtensionPrefix) + ` audit trends daily-triage --start-date -1mo  # Trend report across a month of runs`
 
 type auditCommandOptions struct {
 	outputDir        string
@@ -99,6 +103,7 @@ func NewAuditCommand() *cobra.Command {
 	}
 	registerAuditCommandFlags(cmd)
 	cmd.AddCommand(NewAuditDiffSubcommand())
+	cmd.AddCommand(NewAuditTrendsSubcommand())
 	return cmd
 }
 
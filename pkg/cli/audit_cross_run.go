@@ -22,12 +22,16 @@ const mcpConnectionRateThreshold = 0.75
 const spikeDetectionMultiplier = 2.0
 
 // CrossRunAuditReport represents aggregated audit data across multiple workflow runs.
-// It includes firewall analysis, metrics trends, MCP server health, and error trends.
+// It includes run outcomes and cost, firewall analysis, metrics trends, tool and
+// safe-output usage, MCP server health, and error trends.
 type CrossRunAuditReport struct {
 	RunsAnalyzed    int                       `json:"runs_analyzed"`
 	RunsWithData    int                       `json:"runs_with_data"`
 	RunsWithoutData int                       `json:"runs_without_data"`
 	Summary         CrossRunSummary           `json:"summary"`
+	Outcomes        RunOutcomeTrend           `json:"outcomes"`
+	TopTools        []CrossRunToolUsage       `json:"top_tools,omitempty"`
+	SafeOutputs     []CrossRunSafeOutputCount `json:"safe_outputs,omitempty"`
 	MetricsTrend    MetricsTrendData          `json:"metrics_trend"`
 	MCPHealth       []MCPServerCrossRunHealth `json:"mcp_health,omitempty"`
 	ErrorTrend      ErrorTrendData            `json:"error_trend"`
@@ -140,7 +144,11 @@ type crossRunInput struct {
 	RunID            int64
 	WorkflowName     string
 	Conclusion       string
+	CreatedAt        time.Time
 	Duration         time.Duration
+	AIC              float64
+	ActionMinutes    float64
+	SafeOutputCounts map[string]int // safe-output items written to GitHub, by type
 	FirewallAnalysis *FirewallAnalysis
 	Metrics          LogMetrics
 	MCPToolUsage     *MCPToolUsageData
@@ -158,6 +166,9 @@ func buildCrossRunAuditReport(inputs []crossRunInput) *CrossRunAuditReport {
 	metricsRows, mcpServerMap := collectCrossRunInputs(report, inputs, domainMap)
 
 	finalizeCrossRunSummary(report)
+	report.Outcomes = buildRunOutcomeTrend(inputs)
+	report.TopTools = buildCrossRunTopTools(inputs)
+	report.SafeOutputs = buildCrossRunSafeOutputCounts(inputs)
 	applyCrossRunMetricsTrend(report, metricsRows)
 	report.MCPHealth = buildCrossRunMCPHealth(mcpServerMap, len(inputs))
 	finalizeCrossRunErrorTrend(report, len(inputs))
@@ -7,9 +7,11 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/sliceutil"
 	"github.com/github/gh-aw/pkg/stringutil"
 	"github.com/github/gh-aw/pkg/timeutil"
 )
@@ -35,7 +37,10 @@ func renderCrossRunReportMarkdownToWriter(w io.Writer, report *CrossRunAuditRepo
 	fmt.Fprintln(w)
 
 	renderMarkdownExecutiveSummaryToWriter(w, report)
+	renderMarkdownOutcomesToWriter(w, report)
 	renderMarkdownMetricsTrendToWriter(w, report.MetricsTrend)
+	renderMarkdownTopToolsToWriter(w, report.TopTools)
+	renderMarkdownSafeOutputsToWriter(w, report.SafeOutputs)
 	renderMarkdownMCPHealthToWriter(w, report)
 	renderMarkdownErrorTrendToWriter(w, report)
 	renderMarkdownDomainInventoryToWriter(w, report)
@@ -59,6 +64,70 @@ func renderMarkdownExecutiveSummaryToWriter(w io.Writer, report *CrossRunAuditRe
 	fmt.Fprintln(w)
 }
 
+func renderMarkdownOutcomesToWriter(w io.Writer, report *CrossRunAuditReport) {
+	o := report.Outcomes
+	if report.RunsAnalyzed == 0 {
+		return
+	}
+	fmt.Fprintln(w, "## Run Outcomes")
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "| Metric | Value |\n")
+	fmt.Fprintf(w, "|--------|-------|\n")
+	if !o.FirstRunAt.IsZero() {
+		fmt.Fprintf(w, "| Time window | %s → %s |\n", o.FirstRunAt.UTC().Format(time.DateOnly), o.LastRunAt.UTC().Format(time.DateOnly))
+	}
+	fmt.Fprintf(w, "| Success rate | %.1f%% (%d/%d completed) |\n", o.SuccessRate*100, o.Succeeded, o.Succeeded+o.Failed)
+	fmt.Fprintf(w, "| Conclusions | %s |\n", formatConclusionCounts(o.Conclusions))
+	if o.TotalAIC > 0 {
+		fmt.Fprintf(w, "| AI Credits | %.2f total, %.2f avg/run |\n", o.TotalAIC, o.AvgAIC)
+	}
+	if o.TotalActionMins > 0 {
+		fmt.Fprintf(w, "| Actions minutes | %.1f total, %.1f avg/run |\n", o.TotalActionMins, o.AvgActionMins)
+	}
+	fmt.Fprintf(w, "| Safe-output items | %d |\n", o.TotalSafeOutputs)
+	fmt.Fprintln(w)
+}
+
+func renderMarkdownTopToolsToWriter(w io.Writer, tools []CrossRunToolUsage) {
+	if len(tools) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "## Most-Used Tools")
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "| Tool | Calls | Runs |\n")
+	fmt.Fprintf(w, "|------|-------|------|\n")
+	for _, t := range tools {
+		fmt.Fprintf(w, "| `%s` | %d | %d |\n", t.Name, t.Calls, t.Runs)
+	}
+	fmt.Fprintln(w)
+}
+
+func renderMarkdownSafeOutputsToWriter(w io.Writer, counts []CrossRunSafeOutputCount) {
+	if len(counts) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "## Safe Outputs")
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "| Type | Items | Runs |\n")
+	fmt.Fprintf(w, "|------|-------|------|\n")
+	for _, c := range counts {
+		fmt.Fprintf(w, "| `%s` | %d | %d |\n", c.Type, c.Count, c.Runs)
+	}
+	fmt.Fprintln(w)
+}
+
+// formatConclusionCounts renders conclusion counts as "success: 8, failure: 2" in a stable order.
+func formatConclusionCounts(conclusions map[string]int) string {
+	if len(conclusions) == 0 {
+		return "—"
+	}
+	parts := make([]string, 0, len(conclusions))
+	for _, conclusion := range sliceutil.SortedKeys(conclusions) {
+		parts = append(parts, fmt.Sprintf("%s: %d", conclusion, conclusions[conclusion]))
+	}
+	return strings.Join(parts, ", ")
+}
+
 func renderMarkdownMetricsTrendToWriter(w io.Writer, mt MetricsTrendData) {
 	if mt.TotalTokens == 0 && mt.TotalTurns == 0 && mt.AvgDurationNs == 0 {
 		return
@@ -214,7 +283,10 @@ func renderCrossRunReportPretty(report *CrossRunAuditReport) {
 	fmt.Fprintln(os.Stderr)
 
 	renderPrettyExecutiveSummary(report)
+	renderPrettyOutcomes(report)
 	renderPrettyMetricsTrend(report.MetricsTrend)
+	renderPrettyTopTools(report.TopTools)
+	renderPrettySafeOutputs(report.SafeOutputs)
 	renderPrettyMCPHealth(report)
 	renderPrettyErrorTrend(report)
 	renderPrettyDomainInventory(report)
@@ -235,6 +307,49 @@ func renderPrettyExecutiveSummary(report *CrossRunAuditReport) {
 	fmt.Fprintln(os.Stderr)
 }
 
+func renderPrettyOutcomes(report *CrossRunAuditReport) {
+	o := report.Outcomes
+	if report.RunsAnalyzed == 0 {
+		return
+	}
+	fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Run Outcomes"))
+	if !o.FirstRunAt.IsZero() {
+		fmt.Fprintf(os.Stderr, "  Time window:       %s → %s\n", o.FirstRunAt.UTC().Format(time.DateOnly), o.LastRunAt.UTC().Format(time.DateOnly))
+	}
+	fmt.Fprintf(os.Stderr, "  Success rate:      %.1f%% (%d/%d completed)\n", o.SuccessRate*100, o.Succeeded, o.Succeeded+o.Failed)
+	fmt.Fprintf(os.Stderr, "  Conclusions:       %s\n", formatConclusionCounts(o.Conclusions))
+	if o.TotalAIC > 0 {
+		fmt.Fprintf(os.Stderr, "  AI Credits:        total=%.2f  avg=%.2f/run\n", o.TotalAIC, o.AvgAIC)
+	}
+	if o.TotalActionMins > 0 {
+		fmt.Fprintf(os.Stderr, "  Actions minutes:   total=%.1f  avg=%.1f/run\n", o.TotalActionMins, o.AvgActionMins)
+	}
+	fmt.Fprintf(os.Stderr, "  Safe-output items: %d\n", o.TotalSafeOutputs)
+	fmt.Fprintln(os.Stderr)
+}
+
+func renderPrettyTopTools(tools []CrossRunToolUsage) {
+	if len(tools) == 0 {
+		return
+	}
+	fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Most-Used Tools"))
+	for _, t := range tools {
+		fmt.Fprintf(os.Stderr, "  %-40s calls=%d  runs=%d\n", t.Name, t.Calls, t.Runs)
+	}
+	fmt.Fprintln(os.Stderr)
+}
+
+func renderPrettySafeOutputs(counts []CrossRunSafeOutputCount) {
+	if len(counts) == 0 {
+		return
+	}
+	fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Safe Outputs"))
+	for _, c := range counts {
+		fmt.Fprintf(os.Stderr, "  %-40s items=%d  runs=%d\n", c.Type, c.Count, c.Runs)
+	}
+	fmt.Fprintln(os.Stderr)
+}
+
 func renderPrettyMetricsTrend(mt MetricsTrendData) {
 	if mt.TotalTokens == 0 && mt.TotalTurns == 0 && mt.AvgDurationNs == 0 {
 		return
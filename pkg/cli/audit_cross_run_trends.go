@@ -0,0 +1,164 @@
+package cli
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/github/gh-aw/pkg/sliceutil"
+)
+
+// maxCrossRunTopTools caps the number of tools listed in the cross-run tool usage section.
+const maxCrossRunTopTools = 10
+
+// RunOutcomeTrend summarizes run conclusions and cost across the analyzed runs.
+type RunOutcomeTrend struct {
+	FirstRunAt       time.Time      `json:"first_run_at,omitzero"`
+	LastRunAt        time.Time      `json:"last_run_at,omitzero"`
+	Succeeded        int            `json:"succeeded"`
+	Failed           int            `json:"failed"`
+	Other            int            `json:"other"`        // cancelled, skipped, timed out, or still running
+	SuccessRate      float64        `json:"success_rate"` // 0.0–1.0, over completed runs (succeeded + failed)
+	Conclusions      map[string]int `json:"conclusions,omitempty"`
+	TotalAIC         float64        `json:"total_aic,omitempty"`
+	AvgAIC           float64        `json:"avg_aic,omitempty"`
+	TotalActionMins  float64        `json:"total_action_minutes,omitempty"`
+	AvgActionMins    float64        `json:"avg_action_minutes,omitempty"`
+	TotalSafeOutputs int            `json:"total_safe_outputs"`
+}
+
+// CrossRunToolUsage describes how often a tool was called across runs.
+type CrossRunToolUsage struct {
+	Name  string `json:"name"`
+	Calls int    `json:"calls"`
+	Runs  int    `json:"runs"`
+}
+
+// CrossRunSafeOutputCount describes how many items of a safe-output type were
+// written to GitHub across runs.
+type CrossRunSafeOutputCount struct {
+	Type  string `json:"type"`
+	Count int    `json:"count"`
+	Runs  int    `json:"runs"`
+}
+
+// buildRunOutcomeTrend aggregates conclusions, cost, and safe-output totals.
+func buildRunOutcomeTrend(inputs []crossRunInput) RunOutcomeTrend {
+	var trend RunOutcomeTrend
+	for _, in := range inputs {
+		if !in.CreatedAt.IsZero() {
+			if trend.FirstRunAt.IsZero() || in.CreatedAt.Before(trend.FirstRunAt) {
+				trend.FirstRunAt = in.CreatedAt
+			}
+			if in.CreatedAt.After(trend.LastRunAt) {
+				trend.LastRunAt = in.CreatedAt
+			}
+		}
+
+		conclusion := in.Conclusion
+		if conclusion == "" {
+			conclusion = "in_progress"
+		}
+		if trend.Conclusions == nil {
+			trend.Conclusions = make(map[string]int)
+		}
+		trend.Conclusions[conclusion]++
+		switch conclusion {
+		case "success":
+			trend.Succeeded++
+		case "failure":
+			trend.Failed++
+		default:
+			trend.Other++
+		}
+
+		trend.TotalAIC += in.AIC
+		trend.TotalActionMins += in.ActionMinutes
+		for _, count := range in.SafeOutputCounts {
+			trend.TotalSafeOutputs += count
+		}
+	}
+
+	if completed := trend.Succeeded + trend.Failed; completed > 0 {
+		trend.SuccessRate = float64(trend.Succeeded) / float64(completed)
+	}
+	if len(inputs) > 0 {
+		trend.AvgAIC = trend.TotalAIC / float64(len(inputs))
+		trend.AvgActionMins = trend.TotalActionMins / float64(len(inputs))
+	}
+	return trend
+}
+
+// buildCrossRunTopTools returns the most-called tools across runs, ordered by call count.
+func buildCrossRunTopTools(inputs []crossRunInput) []CrossRunToolUsage {
+	byName := make(map[string]*CrossRunToolUsage)
+	for _, in := range inputs {
+		for _, call := range in.Metrics.ToolCalls {
+			if !isValidToolName(call.Name) {
+				continue
+			}
+			usage, ok := byName[call.Name]
+			if !ok {
+				usage = &CrossRunToolUsage{Name: call.Name}
+				byName[call.Name] = usage
+			}
+			usage.Calls += call.CallCount
+			usage.Runs++
+		}
+	}
+	if len(byName) == 0 {
+		return nil
+	}
+
+	tools := make([]CrossRunToolUsage, 0, len(byName))
+	for _, name := range sliceutil.SortedKeys(byName) {
+		tools = append(tools, *byName[name])
+	}
+	slices.SortStableFunc(tools, func(a, b CrossRunToolUsage) int {
+		return cmp.Compare(b.Calls, a.Calls)
+	})
+	if len(tools) > maxCrossRunTopTools {
+		tools = tools[:maxCrossRunTopTools]
+	}
+	return tools
+}
+
+// buildCrossRunSafeOutputCounts totals the safe-output items written per type.
+func buildCrossRunSafeOutputCounts(inputs []crossRunInput) []CrossRunSafeOutputCount {
+	byType := make(map[string]*CrossRunSafeOutputCount)
+	for _, in := range inputs {
+		for outputType, count := range in.SafeOutputCounts {
+			entry, ok := byType[outputType]
+			if !ok {
+				entry = &CrossRunSafeOutputCount{Type: outputType}
+				byType[outputType] = entry
+			}
+			entry.Count += count
+			entry.Runs++
+		}
+	}
+	if len(byType) == 0 {
+		return nil
+	}
+
+	counts := make([]CrossRunSafeOutputCount, 0, len(byType))
+	for _, outputType := range sliceutil.SortedKeys(byType) {
+		counts = append(counts, *byType[outputType])
+	}
+	slices.SortStableFunc(counts, func(a, b CrossRunSafeOutputCount) int {
+		return cmp.Compare(b.Count, a.Count)
+	})
+	return counts
+}
+
+// countSafeOutputItemsByType groups the items of a run's safe-output manifest by type.
+func countSafeOutputItemsByType(items []CreatedItemReport) map[string]int {
+	if len(items) == 0 {
+		return nil
+	}
+	counts := make(map[string]int)
+	for _, item := range items {
+		counts[item.Type]++
+	}
+	return counts
+}
@@ -0,0 +1,94 @@
+//go:build !integration
+
+package cli
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildCrossRunAuditReport_OutcomesAndUsage(t *testing.T) {
+	day := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	inputs := []crossRunInput{
+		{
+			RunID: 1, Conclusion: "success", CreatedAt: day.AddDate(0, 0, 2),
+			AIC: 1.5, ActionMinutes: 4,
+			SafeOutputCounts: map[string]int{"create_issue": 1, "add_comment": 2},
+			Metrics:          LogMetrics{ToolCalls: []ToolCallInfo{{Name: "github_search_issues", CallCount: 3}, {Name: "Bash", CallCount: 5}}},
+		},
+		{
+			RunID: 2, Conclusion: "failure", CreatedAt: day,
+			AIC: 0.5, ActionMinutes: 2,
+			SafeOutputCounts: map[string]int{"add_comment": 1},
+			Metrics:          LogMetrics{ToolCalls: []ToolCallInfo{{Name: "github_search_issues", CallCount: 4}, {Name: "the", CallCount: 9}}},
+		},
+		{RunID: 3, Conclusion: "cancelled", CreatedAt: day.AddDate(0, 0, 1)},
+	}
+
+	report := buildCrossRunAuditReport(inputs)
+
+	o := report.Outcomes
+	assert.Equal(t, day, o.FirstRunAt, "first run should be the earliest")
+	assert.Equal(t, day.AddDate(0, 0, 2), o.LastRunAt, "last run should be the latest")
+	assert.Equal(t, 1, o.Succeeded, "unexpected succeeded count")
+	assert.Equal(t, 1, o.Failed, "unexpected failed count")
+	assert.Equal(t, 1, o.Other, "cancelled runs should count as other")
+	assert.InDelta(t, 0.5, o.SuccessRate, 0.001, "success rate should only consider completed runs")
+	assert.InDelta(t, 2.0, o.TotalAIC, 0.001, "unexpected total AIC")
+	assert.InDelta(t, 2.0, o.AvgActionMins, 0.001, "unexpected average Actions minutes")
+	assert.Equal(t, 4, o.TotalSafeOutputs, "unexpected safe-output total")
+
+	require.Len(t, report.TopTools, 2, "invalid tool names should be dropped")
+	assert.Equal(t, CrossRunToolUsage{Name: "github_search_issues", Calls: 7, Runs: 2}, report.TopTools[0], "most-called tool should come first")
+
+	require.Len(t, report.SafeOutputs, 2, "expected two safe-output types")
+	assert.Equal(t, CrossRunSafeOutputCount{Type: "add_comment", Count: 3, Runs: 2}, report.SafeOutputs[0], "largest safe-output type should come first")
+}
+
+func TestRenderCrossRunReportMarkdown_Trends(t *testing.T) {
+	report := buildCrossRunAuditReport([]crossRunInput{
+		{
+			RunID: 1, Conclusion: "success", CreatedAt: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), AIC: 1.25,
+			SafeOutputCounts: map[string]int{"create_issue": 2},
+			Metrics:          LogMetrics{ToolCalls: []ToolCallInfo{{Name: "github_get_issue", CallCount: 2}}},
+		},
+	})
+
+	var buf bytes.Buffer
+	renderCrossRunReportMarkdownToWriter(&buf, report)
+	output := buf.String()
+
+	assert.Contains(t, output, "## Run Outcomes", "should render run outcomes")
+	assert.Contains(t, output, "| Success rate | 100.0% (1/1 completed) |", "should render success rate")
+	assert.Contains(t, output, "| AI Credits | 1.25 total, 1.25 avg/run |", "should render cost")
+	assert.Contains(t, output, "| `github_get_issue` | 2 | 1 |", "should render most-used tools")
+	assert.Contains(t, output, "| `create_issue` | 2 | 1 |", "should render safe-output counts")
+}
+
+func TestLoadAuditTrendsOptions(t *testing.T) {
+	newCmd := func(args ...string) *cobra.Command {
+		cmd := NewAuditTrendsSubcommand()
+		cmd.Flags().BoolP("verbose", "v", false, "")
+		require.NoError(t, cmd.ParseFlags(args), "flags should parse")
+		return cmd
+	}
+
+	opts, err := loadAuditTrendsOptions(newCmd("--repo", "octo/remote", "--json"), []string{"daily-triage"})
+	require.NoError(t, err, "default options should be valid")
+	assert.Equal(t, "daily-triage", opts.WorkflowName, "workflow should be normalized for remote repos")
+	assert.Equal(t, "markdown", opts.Format, "markdown should be the default format")
+	assert.Equal(t, 100, opts.Count, "unexpected default count")
+	assert.NotEmpty(t, opts.StartDate, "default time window should be resolved")
+	assert.True(t, opts.JSONOutput, "json flag should be forwarded")
+
+	_, err = loadAuditTrendsOptions(newCmd("--repo", "octo/remote", "--format", "tsv"), []string{"daily-triage"})
+	require.Error(t, err, "unsupported formats should be rejected")
+
+	_, err = loadAuditTrendsOptions(newCmd("--repo", "octo/remote", "--format", "pretty", "--report-file", "out.md"), []string{"daily-triage"})
+	require.Error(t, err, "report file requires markdown output")
+}
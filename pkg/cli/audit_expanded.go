@@ -190,7 +190,13 @@ func inferFallbackLogMetrics(logsPath string) (LogMetrics, string) {
 }
 
 func findAgentStdioLogPath(logsPath string) string {
-	root := filepath.Join(logsPath, "agent-stdio.log")
+	return findAgentLogByName(logsPath, "agent-stdio.log")
+}
+
+// findAgentLogByName returns the path of the named agent log (agent-stdio.log,
+// agent-stdout.log, ...) at the root of logsPath or, failing that, anywhere below it.
+func findAgentLogByName(logsPath, name string) string {
+	root := filepath.Join(logsPath, name)
 	if fileutil.FileExists(root) {
 		return root
 	}
@@ -200,14 +206,14 @@ func findAgentStdioLogPath(logsPath string) string {
 		if err != nil || info == nil || info.IsDir() {
 			return nil
 		}
-		if info.Name() == "agent-stdio.log" {
+		if info.Name() == name {
 			found = path
 			return filepath.SkipAll
 		}
 		return nil
 	})
 	if walkErr != nil && !errors.Is(walkErr, filepath.SkipAll) {
-		auditExpandedLog.Printf("Failed while searching for agent-stdio.log in %s: %v", logsPath, walkErr)
+		auditExpandedLog.Printf("Failed while searching for %s in %s: %v", name, logsPath, walkErr)
 	}
 	return found
 }
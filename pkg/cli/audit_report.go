@@ -629,6 +629,8 @@ func describeFile(filename string) string {
 		constants.AgentOutputFilename:   "Validated safe outputs",
 		"aw.patch":                      "Git patch of changes made during execution",
 		"agent-stdio.log":               "Agent standard output/error logs",
+		"agent-stdout.log":              "Agent standard output (agent content)",
+		"agent-stderr.log":              "Agent standard error (engine diagnostics)",
 		"log.md":                        "Human-readable agent session summary",
 		"firewall.md":                   "Firewall log analysis report",
 		"run_summary.json":              "Cached summary of workflow run analysis",
@@ -311,6 +311,7 @@ func renderConsoleOperationalSections(data AuditData) {
 	if data.FirewallAnalysis != nil && data.FirewallAnalysis.TotalRequests > 0 {
 		renderCompactFirewall(data.FirewallAnalysis)
 	}
+	renderConsoleBlockedDomains(data.FirewallAnalysis)
 }
 
 func renderConsoleMissingTools(missingTools []MissingToolReport) {
@@ -486,3 +487,20 @@ func renderCompactFirewall(fa *FirewallAnalysis) {
 	}
 	fmt.Fprintln(os.Stderr, line)
 }
+
+// renderConsoleBlockedDomains lists the domains the firewall denied, most requests first,
+// so the allow-list can be extended from evidence.
+func renderConsoleBlockedDomains(fa *FirewallAnalysis) {
+	attempts := blockedDomainAttempts(fa)
+	if len(attempts) == 0 {
+		return
+	}
+	fmt.Fprintln(os.Stderr, "  blocked_domains:")
+	for _, attempt := range attempts {
+		line := fmt.Sprintf("    %s: %d requests", attempt.Domain, attempt.Requests)
+		if attempt.FirstSeen != "" {
+			line += fmt.Sprintf(" (first %s, last %s)", attempt.FirstSeen, attempt.LastSeen)
+		}
+		fmt.Fprintln(os.Stderr, line)
+	}
+}
@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+var auditTrendsLog = logger.New("cli:audit_trends")
+
+// auditTrendsFormats lists the supported values of --format for audit trends.
+var auditTrendsFormats = []string{"markdown", "pretty"}
+
+// NewAuditTrendsSubcommand creates the audit trends subcommand.
+func NewAuditTrendsSubcommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "trends <workflow>",
+		Short: "Aggregate the runs of a workflow over a time window into a trend report",
+		Long: `Download the runs of a workflow created within a time window and aggregate them
+into a single trend report:
+
+- Run outcomes: success rate and conclusions
+- Cost: AI Credits and billable Actions minutes (total and per run)
+- Token, turn, and duration trends with spike detection
+- Most-used tools
+- Safe-output items written to GitHub, by type
+- Firewall requests, denials, and domain inventory
+- MCP server health and error trends
+
+The Markdown report is suitable for posting to a dashboard issue or discussion; use
+--json for scripts and agents. Runs already present in the output directory are reused.`,
+		Example: `  ` + string(constants.CLIExtensionPrefix) + ` audit trends daily-triage                                 # Last week, Markdown report
+  ` + string(constants.CLIExtensionPrefix) + ` audit trends daily-triage --start-date -1mo -c 200          # Last month, up to 200 runs
+  ` + string(constants.CLIExtensionPrefix) + ` audit trends daily-triage --report-file reports/trends.md   # Write Markdown report to a file
+  ` + string(constants.CLIExtensionPrefix) + ` audit trends daily-triage --format pretty                   # Console report
+  ` + string(constants.CLIExtensionPrefix) + ` audit trends daily-triage --json                            # JSON report`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := loadAuditTrendsOptions(cmd, args)
+			if err != nil {
+				return err
+			}
+			auditTrendsLog.Printf("Building trend report: workflow=%s, start=%s, end=%s, count=%d, format=%s",
+				opts.WorkflowName, opts.StartDate, opts.EndDate, opts.Count, opts.Format)
+			return DownloadWorkflowLogs(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().String("start-date", "-1w", "Include runs created after this date (YYYY-MM-DD or delta like -1d, -1w, -1mo)")
+	cmd.Flags().String("end-date", "", "Include runs created before this date (YYYY-MM-DD or delta like -1d, -1w, -1mo)")
+	cmd.Flags().IntP("count", "c", 100, "Maximum number of runs to aggregate")
+	cmd.Flags().String("format", "markdown", "Output format: "+strings.Join(auditTrendsFormats, ", "))
+	cmd.Flags().String("report-file", "", "Write the Markdown report to this file path instead of stdout (creates parent directories as needed)")
+	cmd.Flags().StringSlice("artifacts", nil, "Artifact sets to download (default: all, because tool and firewall trends need the agent and firewall artifacts). Valid sets: "+strings.Join(ValidArtifactSetNames(), ", "))
+	cmd.Flags().Int("timeout", 0, "Download timeout in minutes (0 = no timeout)")
+	addOutputFlag(cmd, defaultLogsOutputDir)
+	addEngineFilterFlag(cmd)
+	addRepoFlag(cmd)
+	addJSONFlag(cmd)
+	RegisterDirFlagCompletion(cmd, "output")
+
+	return cmd
+}
+
+// loadAuditTrendsOptions converts the audit trends flags into logs download options
+// that render the cross-run report.
+func loadAuditTrendsOptions(cmd *cobra.Command, args []string) (LogsDownloadOptions, error) {
+	format := getStringFlag(cmd, "format")
+	if !slices.Contains(auditTrendsFormats, format) {
+		return LogsDownloadOptions{}, fmt.Errorf("invalid --format %q: expected one of %s", format, strings.Join(auditTrendsFormats, ", "))
+	}
+	workflowName, err := resolveLogsWorkflowName(cmd, args)
+	if err != nil {
+		return LogsDownloadOptions{}, err
+	}
+	startDate, endDate, err := resolveLogsDateRange(getStringFlag(cmd, "start-date"), getStringFlag(cmd, "end-date"), time.Now())
+	if err != nil {
+		return LogsDownloadOptions{}, err
+	}
+
+	opts := LogsDownloadOptions{
+		WorkflowName:   workflowName,
+		Count:          getIntFlag(cmd, "count"),
+		StartDate:      startDate,
+		EndDate:        endDate,
+		OutputDir:      getStringFlag(cmd, "output"),
+		Engine:         getStringFlag(cmd, "engine"),
+		RepoOverride:   getStringFlag(cmd, "repo"),
+		Verbose:        getBoolFlag(cmd, "verbose"),
+		JSONOutput:     getBoolFlag(cmd, "json"),
+		TimeoutMinutes: getIntFlag(cmd, "timeout"),
+		Format:         format,
+		ReportFile:     getStringFlag(cmd, "report-file"),
+		ArtifactSets:   getStringSliceFlag(cmd, "artifacts"),
+	}
+	if err := validateLogsOptions(opts); err != nil {
+		return LogsDownloadOptions{}, err
+	}
+	return opts, nil
+}
@@ -0,0 +1,403 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+var benchLog = logger.New("cli:bench_command")
+
+// BenchOptions holds configuration for `gh aw bench`.
+type BenchOptions struct {
+	Tasks          []string // Task names to run; all built-in tasks when empty
+	Engines        []string // Engines to compare
+	Model          string   // Optional model passed to every engine
+	HostRepo       string   // Repository the bench workflows run in
+	RepeatCount    int      // Additional runs per engine and task
+	TimeoutMinutes int      // Timeout per run
+	HistoryPath    string   // JSONL file results are appended to
+	JSONOutput     bool
+	Verbose        bool
+}
+
+// NewBenchCommand creates the bench command.
+func NewBenchCommand(validateEngine func(string) error) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bench [task]...",
+		Short: "Benchmark engines on synthetic tasks and track latency, token usage, and success rate",
+		Long: `Run a suite of synthetic agentic workflows against one or more engines and report
+latency, token usage, and success rate for each engine and task.
+
+Each task is installed as a small workflow_dispatch workflow in the host repository
+(defaults to '<username>/gh-aw-trial', the same repository used by 'trial') and run
+once per engine. Safe outputs are staged, so tasks never write to the host repository.
+A run succeeds when it completes successfully and produces the task's expected safe output.
+
+Results are appended to ` + defaultBenchHistoryPath + ` and each report compares the
+median latency with the previous session, so that engines and models can be compared
+over time. Use 'bench history' to review past sessions without running anything.
+
+Available tasks:
+` + formatBenchTaskList(),
+		Example: `  ` + string(constants.CLIExtensionPrefix) + ` bench                                          # Run all tasks with the copilot engine
+  ` + string(constants.CLIExtensionPrefix) + ` bench --engine copilot --engine claude         # Compare two engines
+  ` + string(constants.CLIExtensionPrefix) + ` bench noop summarize --repeat 2                # Run two tasks three times each
+  ` + string(constants.CLIExtensionPrefix) + ` bench --engine claude --model claude-sonnet-4  # Benchmark a specific model
+  ` + string(constants.CLIExtensionPrefix) + ` bench --json                                   # Output results in JSON format
+  ` + string(constants.CLIExtensionPrefix) + ` bench history                                  # Show the results of past sessions`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			engines, _ := cmd.Flags().GetStringSlice("engine")
+			model, _ := cmd.Flags().GetString("model")
+			hostRepo, _ := cmd.Flags().GetString("host-repo")
+			repeatCount, _ := cmd.Flags().GetInt("repeat")
+			timeout, _ := cmd.Flags().GetInt("timeout")
+			historyPath, _ := cmd.Flags().GetString("history")
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			verbose, _ := cmd.Root().PersistentFlags().GetBool("verbose")
+
+			for _, engine := range engines {
+				if err := validateEngine(engine); err != nil {
+					return err
+				}
+			}
+
+			return RunBench(cmd.Context(), BenchOptions{
+				Tasks:          args,
+				Engines:        engines,
+				Model:          model,
+				HostRepo:       hostRepo,
+				RepeatCount:    repeatCount,
+				TimeoutMinutes: timeout,
+				HistoryPath:    historyPath,
+				JSONOutput:     jsonOutput,
+				Verbose:        verbose,
+			})
+		},
+	}
+
+	cmd.Flags().StringSliceP("engine", "e", []string{"copilot"}, "Engine to benchmark (repeat or comma-separate to compare engines)")
+	cmd.Flags().String("model", "", "Model to use for every benchmarked engine (defaults to each engine's default model)")
+	cmd.Flags().String("host-repo", "", "Repository to run the bench workflows in (defaults to '<username>/gh-aw-trial'). Use '.' for current repository")
+	cmd.Flags().Int("repeat", 0, "Number of additional runs per engine and task (e.g., --repeat 2 runs each task 3 times)")
+	cmd.Flags().Int("timeout", 30, "Timeout in minutes for each run")
+	cmd.Flags().String("history", defaultBenchHistoryPath, "File that bench results are appended to and compared against")
+	addJSONFlag(cmd)
+
+	cmd.AddCommand(newBenchHistorySubcommand())
+
+	return cmd
+}
+
+// newBenchHistorySubcommand creates the bench history subcommand.
+func newBenchHistorySubcommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Show the results of past bench sessions",
+		Long: `Show the results of past bench sessions from the bench history file, one table
+per session, with the latency change against the session before it.`,
+		Example: `  ` + string(constants.CLIExtensionPrefix) + ` bench history           # Show all sessions
+  ` + string(constants.CLIExtensionPrefix) + ` bench history --last 3  # Show the three most recent sessions
+  ` + string(constants.CLIExtensionPrefix) + ` bench history --json    # Output in JSON format`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			historyPath, _ := cmd.Flags().GetString("history")
+			last, _ := cmd.Flags().GetInt("last")
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			return RunBenchHistory(historyPath, last, jsonOutput)
+		},
+	}
+	cmd.Flags().String("history", defaultBenchHistoryPath, "Bench history file to read")
+	cmd.Flags().Int("last", 0, "Only show the most recent N sessions (0 = all)")
+	addJSONFlag(cmd)
+	return cmd
+}
+
+// formatBenchTaskList formats the built-in tasks for the command help.
+func formatBenchTaskList() string {
+	lines := make([]string, 0, len(benchTasks))
+	for _, task := range benchTasks {
+		lines = append(lines, fmt.Sprintf("  - %-12s %s", task.Name, task.Description))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// RunBench runs the selected bench tasks for every engine, appends the results to the
+// bench history, and reports a summary compared with the previous session.
+func RunBench(ctx context.Context, opts BenchOptions) error {
+	tasks, err := selectBenchTasks(opts.Tasks)
+	if err != nil {
+		return err
+	}
+	if len(opts.Engines) == 0 {
+		opts.Engines = []string{"copilot"}
+	}
+	if opts.RepeatCount < 0 {
+		return errors.New("--repeat must be zero or greater")
+	}
+	if opts.HistoryPath == "" {
+		opts.HistoryPath = defaultBenchHistoryPath
+	}
+	benchLog.Printf("Starting bench: tasks=%d, engines=%v, model=%s, repeat=%d", len(tasks), opts.Engines, opts.Model, opts.RepeatCount)
+
+	hostRepoSlug, err := resolveBenchHostRepo(ctx, opts.HostRepo)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Host repository: "+hostRepoSlug))
+
+	if err := ensureTrialRepository(hostRepoSlug, "", false, false, opts.Verbose); err != nil {
+		return fmt.Errorf("failed to ensure host repository: %w", err)
+	}
+
+	existingSecrets, err := getExistingSecretsInRepo(hostRepoSlug)
+	if err != nil {
+		benchLog.Printf("Could not check existing secrets: %v", err)
+		existingSecrets = make(map[string]struct{})
+	}
+	for _, engine := range opts.Engines {
+		if err := checkAndEnsureEngineSecretsForEngine(EngineSecretConfig{
+			Ctx:             ctx,
+			RepoSlug:        hostRepoSlug,
+			Engine:          engine,
+			Verbose:         opts.Verbose,
+			ExistingSecrets: existingSecrets,
+		}); err != nil {
+			return fmt.Errorf("failed to configure secret for engine '%s': %w", engine, err)
+		}
+	}
+
+	tempDir, err := cloneTrialHostRepository(hostRepoSlug, opts.Verbose)
+	if err != nil {
+		return fmt.Errorf("failed to clone host repository: %w", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	sourceDir, err := os.MkdirTemp("", "gh-aw-bench-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(sourceDir)
+
+	// Run artifacts are downloaded into the logs cache shared with 'logs' and 'audit'.
+	_ = ensureLogsGitignoreWithWarning(opts.Verbose)
+
+	sessionID := time.Now().UTC().Format("20060102-150405")
+	var results []BenchResult
+	for _, engine := range opts.Engines {
+		for _, task := range tasks {
+			for i := 0; i <= opts.RepeatCount; i++ {
+				fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("=== Bench %s on %s (run %d/%d) ===", task.Name, engine, i+1, opts.RepeatCount+1)))
+				result := runBenchTask(ctx, benchTaskRun{
+					task:         task,
+					engine:       engine,
+					model:        opts.Model,
+					hostRepoSlug: hostRepoSlug,
+					tempDir:      tempDir,
+					sourceDir:    sourceDir,
+					timeout:      opts.TimeoutMinutes,
+					verbose:      opts.Verbose,
+				})
+				if ctxErr := ctx.Err(); ctxErr != nil {
+					return ctxErr
+				}
+				result.SessionID = sessionID
+				if result.Error != "" {
+					fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Bench %s on %s failed: %s", task.Name, engine, result.Error)))
+				}
+				results = append(results, result)
+			}
+		}
+	}
+
+	history, err := loadBenchHistory(opts.HistoryPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Could not read bench history: %v", err)))
+	}
+	if err := appendBenchHistory(opts.HistoryPath, results); err != nil {
+		return err
+	}
+
+	summary := summarizeBenchResults(results)
+	compareWithPreviousSessions(summary, history, sessionID)
+
+	if opts.JSONOutput {
+		return printBenchJSON(BenchReport{SessionID: sessionID, Results: results, Summary: summary})
+	}
+	fmt.Fprint(os.Stderr, renderBenchSummary("Bench session "+sessionID, summary))
+	fmt.Fprintln(os.Stderr, console.FormatSuccessMessage("Results appended to "+opts.HistoryPath))
+	return nil
+}
+
+// RunBenchHistory renders the sessions recorded in the bench history file.
+func RunBenchHistory(historyPath string, last int, jsonOutput bool) error {
+	if historyPath == "" {
+		historyPath = defaultBenchHistoryPath
+	}
+	history, err := loadBenchHistory(historyPath)
+	if err != nil {
+		return err
+	}
+	sessions := benchSessions(history)
+	reports := make([]BenchReport, 0, len(sessions))
+	for i, session := range sessions {
+		summary := summarizeBenchResults(session)
+		compareWithPreviousSessions(summary, flattenBenchSessions(sessions[:i]), session[0].SessionID)
+		reports = append(reports, BenchReport{SessionID: session[0].SessionID, Results: session, Summary: summary})
+	}
+	if last > 0 && len(reports) > last {
+		reports = reports[len(reports)-last:]
+	}
+
+	if jsonOutput {
+		data, err := json.MarshalIndent(reports, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal bench history: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+	if len(reports) == 0 {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("No bench results in %s. Run '%s bench' to record some.", historyPath, constants.CLIExtensionPrefix)))
+		return nil
+	}
+	for _, report := range reports {
+		fmt.Fprint(os.Stderr, renderBenchSummary("Bench session "+report.SessionID, report.Summary))
+		fmt.Fprintln(os.Stderr)
+	}
+	return nil
+}
+
+// flattenBenchSessions flattens sessions back into a single result list.
+func flattenBenchSessions(sessions [][]BenchResult) []BenchResult {
+	var results []BenchResult
+	for _, session := range sessions {
+		results = append(results, session...)
+	}
+	return results
+}
+
+func printBenchJSON(report BenchReport) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal bench report: %w", err)
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+// resolveBenchHostRepo returns the repository bench workflows run in, defaulting to the
+// trial repository of the current user.
+func resolveBenchHostRepo(ctx context.Context, hostRepo string) (string, error) {
+	if hostRepo != "" {
+		spec, err := parseRepoSpec(hostRepo)
+		if err != nil {
+			return "", fmt.Errorf("invalid --host-repo specification '%s': %w", hostRepo, err)
+		}
+		return spec.RepoSlug, nil
+	}
+	username, err := getCurrentGitHubUsername(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get GitHub username for default bench repo: %w", err)
+	}
+	return username + "/gh-aw-trial", nil
+}
+
+// benchTaskRun describes one run of a bench task.
+type benchTaskRun struct {
+	task         benchTask
+	engine       string
+	model        string
+	hostRepoSlug string
+	tempDir      string // Local clone of the host repository
+	sourceDir    string // Directory the rendered workflow is written to before installation
+	timeout      int
+	verbose      bool
+}
+
+// runBenchTask installs, runs, and measures one bench task. Failures are recorded in the
+// result rather than returned so that the remaining tasks still run.
+func runBenchTask(ctx context.Context, run benchTaskRun) BenchResult {
+	result := BenchResult{
+		Timestamp: time.Now().UTC(),
+		Engine:    run.engine,
+		Model:     run.model,
+		Task:      run.task.Name,
+	}
+
+	workflowName := benchWorkflowName(run.task)
+	sourcePath := filepath.Join(run.sourceDir, workflowName+".md")
+	if err := os.WriteFile(sourcePath, []byte(renderBenchWorkflow(run.task, run.engine, run.model)), constants.FilePermPublic); err != nil {
+		result.Error = fmt.Sprintf("failed to write workflow: %v", err)
+		return result
+	}
+	spec, err := parseWorkflowSpec(sourcePath)
+	if err != nil {
+		result.Error = fmt.Sprintf("invalid workflow spec: %v", err)
+		return result
+	}
+	if err := installWorkflowInTrialMode(ctx, run.tempDir, spec, "", "", run.hostRepoSlug, true, &TrialOptions{Verbose: run.verbose}); err != nil {
+		result.Error = fmt.Sprintf("failed to install workflow: %v", err)
+		return result
+	}
+
+	runID, err := triggerWorkflowRun(run.hostRepoSlug, workflowName, "", run.verbose)
+	if err != nil {
+		result.Error = fmt.Sprintf("failed to trigger workflow: %v", err)
+		return result
+	}
+	result.RunID, _ = strconv.ParseInt(runID, 10, 64)
+	fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Workflow run started: %s/%s/actions/runs/%s", getGitHubHost(), run.hostRepoSlug, runID)))
+
+	if err := WaitForWorkflowCompletion(ctx, run.hostRepoSlug, runID, run.timeout, run.verbose); err != nil {
+		if ctx.Err() != nil {
+			return result
+		}
+		// Failed runs are still measured below; only the error is recorded here.
+		result.Error = err.Error()
+	}
+
+	owner, repo, _ := strings.Cut(run.hostRepoSlug, "/")
+	if metadata, err := fetchWorkflowRunMetadata(ctx, result.RunID, owner, repo, "", run.verbose); err != nil {
+		benchLog.Printf("Failed to fetch run metadata for %s: %v", runID, err)
+	} else {
+		result.Conclusion = metadata.Conclusion
+		if !metadata.StartedAt.IsZero() && metadata.UpdatedAt.After(metadata.StartedAt) {
+			result.DurationSeconds = metadata.UpdatedAt.Sub(metadata.StartedAt).Seconds()
+		}
+	}
+
+	runDir := filepath.Join(defaultLogsOutputDir, "run-"+runID)
+	if err := downloadRunArtifacts(ctx, downloadArtifactsOptions{
+		runID:     result.RunID,
+		outputDir: runDir,
+		verbose:   run.verbose,
+		owner:     owner,
+		repo:      repo,
+	}); err != nil && !errors.Is(err, ErrNoArtifacts) {
+		benchLog.Printf("Failed to download artifacts for %s: %v", runID, err)
+	}
+	if metrics, err := extractLogMetrics(runDir, run.verbose); err == nil {
+		result.TokenUsage = metrics.TokenUsage
+		result.Turns = metrics.Turns
+		result.EstimatedCost = metrics.EstimatedCost
+	}
+
+	produced, _ := runContainsSafeOutputType(runDir, run.task.SafeOutput, run.verbose)
+	result.Success = result.Conclusion == "success" && produced
+	if result.Conclusion == "success" && !produced {
+		result.Error = fmt.Sprintf("expected safe output '%s' was not produced", run.task.SafeOutput)
+	}
+	return result
+}
@@ -0,0 +1,289 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/tty"
+)
+
+// defaultBenchHistoryPath is where `gh aw bench` appends its results, one JSON
+// object per run, so that later sessions can be compared against earlier ones.
+const defaultBenchHistoryPath = ".github/aw/bench-history.jsonl"
+
+// BenchResult is the outcome of a single bench task run.
+type BenchResult struct {
+	SessionID       string    `json:"session_id"`
+	Timestamp       time.Time `json:"timestamp"`
+	Engine          string    `json:"engine"`
+	Model           string    `json:"model,omitempty"`
+	Task            string    `json:"task"`
+	RunID           int64     `json:"run_id,omitempty"`
+	Conclusion      string    `json:"conclusion,omitempty"`
+	Success         bool      `json:"success"`
+	DurationSeconds float64   `json:"duration_seconds,omitempty"`
+	TokenUsage      int       `json:"token_usage,omitempty"`
+	Turns           int       `json:"turns,omitempty"`
+	EstimatedCost   float64   `json:"estimated_cost,omitempty"`
+	Error           string    `json:"error,omitempty"`
+}
+
+// BenchSummary aggregates the runs of one engine, model, and task combination.
+type BenchSummary struct {
+	Engine                string  `json:"engine"`
+	Model                 string  `json:"model,omitempty"`
+	Task                  string  `json:"task"`
+	Runs                  int     `json:"runs"`
+	Successes             int     `json:"successes"`
+	SuccessRate           float64 `json:"success_rate"`
+	MedianDurationSeconds float64 `json:"median_duration_seconds"`
+	AvgTokenUsage         int     `json:"avg_token_usage"`
+	AvgEstimatedCost      float64 `json:"avg_estimated_cost,omitempty"`
+	// PreviousMedianDurationSeconds is the median latency of the same combination in the
+	// previous bench session, zero when there is no earlier result to compare against.
+	PreviousMedianDurationSeconds float64 `json:"previous_median_duration_seconds,omitempty"`
+	PreviousSuccessRate           float64 `json:"previous_success_rate,omitempty"`
+	HasPrevious                   bool    `json:"has_previous"`
+}
+
+// BenchReport is the JSON output of `gh aw bench`.
+type BenchReport struct {
+	SessionID string         `json:"session_id"`
+	Results   []BenchResult  `json:"results"`
+	Summary   []BenchSummary `json:"summary"`
+}
+
+// benchKey identifies an engine, model, and task combination.
+func benchKey(engine, model, task string) string {
+	return engine + "\x00" + model + "\x00" + task
+}
+
+// appendBenchHistory appends results to the JSONL history file, creating it if needed.
+func appendBenchHistory(path string, results []BenchResult) error {
+	if len(results) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), constants.DirPermPublic); err != nil {
+		return fmt.Errorf("failed to create bench history directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, constants.FilePermPublic)
+	if err != nil {
+		return fmt.Errorf("failed to open bench history: %w", err)
+	}
+	defer f.Close()
+	encoder := json.NewEncoder(f)
+	for _, result := range results {
+		if err := encoder.Encode(result); err != nil {
+			return fmt.Errorf("failed to write bench history: %w", err)
+		}
+	}
+	return nil
+}
+
+// loadBenchHistory reads every result from the JSONL history file. A missing file
+// yields no results.
+func loadBenchHistory(path string) ([]BenchResult, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open bench history: %w", err)
+	}
+	defer f.Close()
+
+	var results []BenchResult
+	scanner := bufio.NewScanner(f)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var result BenchResult
+		if err := json.Unmarshal([]byte(text), &result); err != nil {
+			return nil, fmt.Errorf("invalid bench history entry at %s:%d: %w", path, line, err)
+		}
+		results = append(results, result)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read bench history: %w", err)
+	}
+	return results, nil
+}
+
+// benchSessions groups history results by session, ordered from oldest to newest.
+func benchSessions(history []BenchResult) [][]BenchResult {
+	bySession := make(map[string][]BenchResult)
+	var order []string
+	for _, result := range history {
+		if _, seen := bySession[result.SessionID]; !seen {
+			order = append(order, result.SessionID)
+		}
+		bySession[result.SessionID] = append(bySession[result.SessionID], result)
+	}
+	sessions := make([][]BenchResult, 0, len(order))
+	for _, id := range order {
+		sessions = append(sessions, bySession[id])
+	}
+	return sessions
+}
+
+// summarizeBenchResults aggregates results per engine, model, and task. Latency is the
+// median over runs that completed, tokens and cost are averaged over runs that reported them.
+func summarizeBenchResults(results []BenchResult) []BenchSummary {
+	type group struct {
+		summary   BenchSummary
+		durations []float64
+		tokens    []int
+		costs     []float64
+	}
+	groups := make(map[string]*group)
+	for _, result := range results {
+		key := benchKey(result.Engine, result.Model, result.Task)
+		g, ok := groups[key]
+		if !ok {
+			g = &group{summary: BenchSummary{Engine: result.Engine, Model: result.Model, Task: result.Task}}
+			groups[key] = g
+		}
+		g.summary.Runs++
+		if result.Success {
+			g.summary.Successes++
+		}
+		if result.DurationSeconds > 0 {
+			g.durations = append(g.durations, result.DurationSeconds)
+		}
+		if result.TokenUsage > 0 {
+			g.tokens = append(g.tokens, result.TokenUsage)
+		}
+		if result.EstimatedCost > 0 {
+			g.costs = append(g.costs, result.EstimatedCost)
+		}
+	}
+
+	summaries := make([]BenchSummary, 0, len(groups))
+	for _, g := range groups {
+		s := g.summary
+		s.SuccessRate = float64(s.Successes) / float64(s.Runs)
+		s.MedianDurationSeconds = benchMedian(g.durations)
+		if len(g.tokens) > 0 {
+			total := 0
+			for _, tokens := range g.tokens {
+				total += tokens
+			}
+			s.AvgTokenUsage = total / len(g.tokens)
+		}
+		if len(g.costs) > 0 {
+			total := 0.0
+			for _, cost := range g.costs {
+				total += cost
+			}
+			s.AvgEstimatedCost = total / float64(len(g.costs))
+		}
+		summaries = append(summaries, s)
+	}
+	slices.SortFunc(summaries, func(a, b BenchSummary) int {
+		return strings.Compare(benchKey(a.Engine, a.Model, a.Task), benchKey(b.Engine, b.Model, b.Task))
+	})
+	return summaries
+}
+
+// benchMedian returns the median of values, or zero when values is empty.
+func benchMedian(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+// compareWithPreviousSessions fills in the previous-session fields of each summary from
+// the most recent earlier session in history that ran the same engine, model, and task.
+// Sessions with the given currentSessionID are ignored.
+func compareWithPreviousSessions(summaries []BenchSummary, history []BenchResult, currentSessionID string) {
+	previous := make(map[string]BenchSummary)
+	for _, session := range benchSessions(history) {
+		if session[0].SessionID == currentSessionID {
+			continue
+		}
+		// Later sessions overwrite earlier ones, leaving the most recent result per key.
+		for _, summary := range summarizeBenchResults(session) {
+			previous[benchKey(summary.Engine, summary.Model, summary.Task)] = summary
+		}
+	}
+	for i := range summaries {
+		prev, ok := previous[benchKey(summaries[i].Engine, summaries[i].Model, summaries[i].Task)]
+		if !ok {
+			continue
+		}
+		summaries[i].HasPrevious = true
+		summaries[i].PreviousMedianDurationSeconds = prev.MedianDurationSeconds
+		summaries[i].PreviousSuccessRate = prev.SuccessRate
+	}
+}
+
+// formatBenchTrend describes the latency change of a summary against the previous
+// session, e.g. "-12%" when the current median is 12% faster.
+func formatBenchTrend(summary BenchSummary) string {
+	if !summary.HasPrevious || summary.PreviousMedianDurationSeconds == 0 || summary.MedianDurationSeconds == 0 {
+		return "-"
+	}
+	change := (summary.MedianDurationSeconds - summary.PreviousMedianDurationSeconds) / summary.PreviousMedianDurationSeconds * 100
+	return fmt.Sprintf("%+.0f%%", math.Round(change))
+}
+
+// renderBenchSummary renders the summary table written to stderr.
+func renderBenchSummary(title string, summaries []BenchSummary) string {
+	rows := make([][]string, 0, len(summaries))
+	for _, s := range summaries {
+		model := s.Model
+		if model == "" {
+			model = "default"
+		}
+		latency := "-"
+		if s.MedianDurationSeconds > 0 {
+			latency = time.Duration(s.MedianDurationSeconds * float64(time.Second)).Round(time.Second).String()
+		}
+		tokens := "-"
+		if s.AvgTokenUsage > 0 {
+			tokens = strconv.Itoa(s.AvgTokenUsage)
+		}
+		cost := "-"
+		if s.AvgEstimatedCost > 0 {
+			cost = fmt.Sprintf("$%.3f", s.AvgEstimatedCost)
+		}
+		rows = append(rows, []string{
+			s.Engine,
+			model,
+			s.Task,
+			fmt.Sprintf("%d/%d (%.0f%%)", s.Successes, s.Runs, s.SuccessRate*100),
+			latency,
+			tokens,
+			cost,
+			formatBenchTrend(s),
+		})
+	}
+	return console.RenderTable(console.TableConfig{
+		Title:   title,
+		Headers: []string{"Engine", "Model", "Task", "Success", "Median latency", "Avg tokens", "Avg cost", "Latency vs previous"},
+		Rows:    rows,
+		TTYFunc: tty.IsStderrTerminal,
+	})
+}
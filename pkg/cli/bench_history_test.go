@@ -0,0 +1,96 @@
+//go:build !integration
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBenchHistoryRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "aw", "bench-history.jsonl")
+
+	history, err := loadBenchHistory(path)
+	require.NoError(t, err, "missing history should not fail")
+	assert.Empty(t, history, "missing history should yield no results")
+
+	first := []BenchResult{{SessionID: "s1", Engine: "copilot", Task: "noop", Success: true, DurationSeconds: 30}}
+	second := []BenchResult{{SessionID: "s2", Engine: "claude", Task: "noop", TokenUsage: 1200, Error: "workflow failed"}}
+	require.NoError(t, appendBenchHistory(path, first), "first session should be appended")
+	require.NoError(t, appendBenchHistory(path, second), "second session should be appended")
+
+	history, err = loadBenchHistory(path)
+	require.NoError(t, err, "history should load")
+	assert.Equal(t, append(first, second...), history, "history should contain both sessions in order")
+
+	require.NoError(t, os.WriteFile(path, []byte("{not json}\n"), 0644), "corrupt history should be written")
+	_, err = loadBenchHistory(path)
+	require.Error(t, err, "corrupt history should be reported")
+	assert.Contains(t, err.Error(), ":1", "error should point at the corrupt line")
+}
+
+func TestSummarizeBenchResults(t *testing.T) {
+	results := []BenchResult{
+		{Engine: "copilot", Task: "noop", Success: true, DurationSeconds: 40, TokenUsage: 1000, EstimatedCost: 0.02},
+		{Engine: "copilot", Task: "noop", Success: true, DurationSeconds: 20, TokenUsage: 3000},
+		{Engine: "copilot", Task: "noop", Success: false, DurationSeconds: 100},
+		{Engine: "copilot", Task: "noop", Success: false, Error: "failed to trigger workflow"},
+		{Engine: "claude", Task: "noop", Success: true, DurationSeconds: 25},
+		{Engine: "claude", Model: "claude-sonnet-4", Task: "noop", Success: true, DurationSeconds: 35},
+	}
+
+	summaries := summarizeBenchResults(results)
+	require.Len(t, summaries, 3, "results should be grouped by engine, model, and task")
+	assert.Equal(t, "claude", summaries[0].Engine, "summaries should be sorted by engine")
+	assert.Empty(t, summaries[0].Model, "default model should sort before named models")
+	assert.Equal(t, "claude-sonnet-4", summaries[1].Model, "named models should be summarized separately")
+
+	copilot := summaries[2]
+	assert.Equal(t, 4, copilot.Runs, "every run should be counted")
+	assert.Equal(t, 2, copilot.Successes, "only successful runs should be counted as successes")
+	assert.InDelta(t, 0.5, copilot.SuccessRate, 0.001, "success rate should be successes over runs")
+	assert.InDelta(t, 40, copilot.MedianDurationSeconds, 0.001, "median should ignore runs without a duration")
+	assert.Equal(t, 2000, copilot.AvgTokenUsage, "token average should ignore runs without token usage")
+	assert.InDelta(t, 0.02, copilot.AvgEstimatedCost, 0.0001, "cost average should ignore runs without a cost")
+}
+
+func TestCompareWithPreviousSessions(t *testing.T) {
+	history := []BenchResult{
+		{SessionID: "s1", Engine: "copilot", Task: "noop", Success: true, DurationSeconds: 100},
+		{SessionID: "s2", Engine: "copilot", Task: "noop", Success: true, DurationSeconds: 50},
+		{SessionID: "s2", Engine: "copilot", Task: "summarize", Success: false, DurationSeconds: 80},
+		{SessionID: "s3", Engine: "copilot", Task: "noop", Success: true, DurationSeconds: 10},
+	}
+	summaries := summarizeBenchResults([]BenchResult{
+		{SessionID: "s3", Engine: "copilot", Task: "noop", Success: true, DurationSeconds: 40},
+		{SessionID: "s3", Engine: "copilot", Task: "summarize", Success: true, DurationSeconds: 80},
+		{SessionID: "s3", Engine: "claude", Task: "noop", Success: true, DurationSeconds: 30},
+	})
+
+	compareWithPreviousSessions(summaries, history, "s3")
+	require.Len(t, summaries, 3, "every combination should be summarized")
+
+	claude, noop, summarize := summaries[0], summaries[1], summaries[2]
+	assert.False(t, claude.HasPrevious, "combinations never run before should have no previous result")
+	assert.Equal(t, "-", formatBenchTrend(claude), "trend should be empty without a previous result")
+
+	assert.True(t, noop.HasPrevious, "noop should be compared with an earlier session")
+	assert.InDelta(t, 50, noop.PreviousMedianDurationSeconds, 0.001, "the most recent earlier session should be used, not the current one")
+	assert.Equal(t, "-20%", formatBenchTrend(noop), "faster runs should show a negative change")
+
+	assert.InDelta(t, 0, summarize.PreviousSuccessRate, 0.001, "previous success rate should be kept")
+	assert.Equal(t, "+0%", formatBenchTrend(summarize), "unchanged latency should show no change")
+}
+
+func TestRenderBenchSummary(t *testing.T) {
+	output := renderBenchSummary("Bench session s1", []BenchSummary{
+		{Engine: "copilot", Task: "noop", Runs: 2, Successes: 1, SuccessRate: 0.5, MedianDurationSeconds: 65.4, AvgTokenUsage: 1500, AvgEstimatedCost: 0.0123},
+	})
+	for _, want := range []string{"copilot", "default", "noop", "1/2 (50%)", "1m5s", "1500", "$0.012"} {
+		assert.Contains(t, output, want, "summary table should include %q", want)
+	}
+}
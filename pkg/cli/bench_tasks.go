@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// benchTask is a synthetic task run by `gh aw bench`. Each task is a small
+// workflow_dispatch workflow whose success is measured by the run conclusion and
+// the presence of the expected safe output.
+type benchTask struct {
+	Name        string // Task name, used as the workflow ID suffix (bench-<name>)
+	Description string // One-line description shown in help and reports
+	Tools       string // Frontmatter tools block (without the "tools:" key), empty for none
+	SafeOutput  string // Safe output type the agent is expected to produce
+	Prompt      string // Markdown body of the workflow
+}
+
+// benchTasks is the built-in benchmark suite. Tasks are ordered from the cheapest
+// (pure engine overhead) to the most tool-intensive.
+var benchTasks = []benchTask{
+	{
+		Name:        "noop",
+		Description: "Reply without tool calls (measures engine startup overhead)",
+		SafeOutput:  "noop",
+		Prompt: `Do not call any repository or shell tools.
+
+Report completion with a single noop message whose text is exactly "ready".`,
+	},
+	{
+		Name:        "summarize",
+		Description: "Read the README and summarize the repository in an issue",
+		Tools: `  bash:
+    - "cat"
+    - "ls"`,
+		SafeOutput: "create-issue",
+		Prompt: `Read README.md at the repository root. If it does not exist, list the files at the
+repository root instead.
+
+Create an issue titled "Repository summary" whose body summarizes the purpose of
+the repository in at most three sentences.`,
+	},
+	{
+		Name:        "count-files",
+		Description: "Count files by extension with shell tools and report a table",
+		Tools: `  bash:
+    - "find"
+    - "wc"
+    - "sort"
+    - "uniq"`,
+		SafeOutput: "create-issue",
+		Prompt: `Count the tracked files in the repository grouped by file extension, ignoring
+the .git directory.
+
+Create an issue titled "File statistics" whose body is a markdown table with the
+columns "Extension" and "Files", sorted by file count in descending order.`,
+	},
+}
+
+// benchTaskNames returns the names of the built-in benchmark tasks.
+func benchTaskNames() []string {
+	names := make([]string, 0, len(benchTasks))
+	for _, task := range benchTasks {
+		names = append(names, task.Name)
+	}
+	return names
+}
+
+// selectBenchTasks returns the tasks named in names, or every task when names is empty.
+func selectBenchTasks(names []string) ([]benchTask, error) {
+	if len(names) == 0 {
+		return benchTasks, nil
+	}
+	var selected []benchTask
+	for _, name := range names {
+		idx := slices.IndexFunc(benchTasks, func(task benchTask) bool { return task.Name == name })
+		if idx < 0 {
+			return nil, fmt.Errorf("unknown bench task '%s': available tasks are %s", name, strings.Join(benchTaskNames(), ", "))
+		}
+		selected = append(selected, benchTasks[idx])
+	}
+	return selected, nil
+}
+
+// benchWorkflowName returns the workflow ID a bench task is installed under.
+func benchWorkflowName(task benchTask) string {
+	return "bench-" + task.Name
+}
+
+// renderBenchWorkflow renders the agentic workflow markdown for a bench task run
+// with the given engine and optional model. Safe outputs are staged so that the
+// benchmark never writes to the host repository.
+func renderBenchWorkflow(task benchTask, engine, model string) string {
+	var b strings.Builder
+	b.WriteString("---\n")
+	fmt.Fprintf(&b, "name: \"Bench: %s\"\n", task.Name)
+	fmt.Fprintf(&b, "description: %q\n", task.Description)
+	b.WriteString("on:\n  workflow_dispatch:\n")
+	b.WriteString("permissions:\n  contents: read\n")
+	b.WriteString("engine:\n")
+	fmt.Fprintf(&b, "  id: %s\n", engine)
+	if model != "" {
+		fmt.Fprintf(&b, "  model: %s\n", model)
+	}
+	b.WriteString("timeout-minutes: 10\n")
+	if task.Tools != "" {
+		b.WriteString("tools:\n" + task.Tools + "\n")
+	}
+	b.WriteString("safe-outputs:\n  staged: true\n")
+	fmt.Fprintf(&b, "  %s:\n", task.SafeOutput)
+	b.WriteString("---\n\n")
+	fmt.Fprintf(&b, "# Bench: %s\n\n", task.Name)
+	b.WriteString(task.Prompt + "\n")
+	return b.String()
+}
@@ -0,0 +1,51 @@
+//go:build !integration
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectBenchTasks(t *testing.T) {
+	all, err := selectBenchTasks(nil)
+	require.NoError(t, err, "selecting no tasks should not fail")
+	assert.Len(t, all, len(benchTasks), "no task names should select every task")
+
+	selected, err := selectBenchTasks([]string{"summarize", "noop"})
+	require.NoError(t, err, "known task names should be selected")
+	require.Len(t, selected, 2, "both named tasks should be selected")
+	assert.Equal(t, "summarize", selected[0].Name, "tasks should keep the requested order")
+
+	_, err = selectBenchTasks([]string{"missing"})
+	require.Error(t, err, "unknown task names should be rejected")
+	assert.Contains(t, err.Error(), "noop, summarize, count-files", "error should list the available tasks")
+}
+
+func TestBenchWorkflowsCompile(t *testing.T) {
+	for _, task := range benchTasks {
+		for _, model := range []string{"", "gpt-5"} {
+			t.Run(task.Name+"/"+model, func(t *testing.T) {
+				dir := t.TempDir()
+				path := filepath.Join(dir, benchWorkflowName(task)+".md")
+				content := renderBenchWorkflow(task, "copilot", model)
+				require.NoError(t, os.WriteFile(path, []byte(content), 0644), "workflow should be written")
+
+				compiler := workflow.NewCompiler()
+				require.NoError(t, compiler.CompileWorkflow(path), "bench workflow should compile:\n%s", content)
+
+				lockContent, err := os.ReadFile(filepath.Join(dir, benchWorkflowName(task)+".lock.yml"))
+				require.NoError(t, err, "lock file should be written")
+				assert.Contains(t, string(lockContent), "workflow_dispatch", "bench workflows should be dispatchable")
+				if model != "" {
+					assert.Contains(t, content, "model: "+model, "model should be set on the engine")
+				}
+			})
+		}
+	}
+}
@@ -11,12 +11,13 @@ import (
 
 var mcpNetworkCodemodLog = logger.New("cli:codemod_mcp_network")
 
-// getMCPNetworkMigrationCodemod creates a codemod for migrating per-server MCP network configuration to top-level network configuration
+// getMCPNetworkMigrationCodemod creates a codemod for migrating per-server MCP network configuration to top-level network configuration.
+// Servers that run as containers keep their network configuration, which restricts their own egress.
 func getMCPNetworkMigrationCodemod() Codemod {
 	return Codemod{
 		ID:           "mcp-network-to-top-level-migration",
 		Name:         "Migrate MCP network config to top-level",
-		Description:  "Moves per-server MCP 'network.allowed' configuration to top-level workflow 'network.allowed'. Per-server network configuration is deprecated.",
+		Description:  "Moves per-server MCP 'network.allowed' configuration of servers that do not run as containers to top-level workflow 'network.allowed'. Container-based servers keep it as their own egress allowlist.",
 		IntroducedIn: "0.6.0",
 		Apply: func(content string, frontmatter map[string]any) (string, bool, error) {
 			// Check if mcp-servers section exists
@@ -47,6 +48,11 @@ func getMCPNetworkMigrationCodemod() Codemod {
 					continue
 				}
 
+				// Container-based servers enforce their own network.allowed
+				if container, _ := serverConfig["container"].(string); container != "" {
+					continue
+				}
+
 				networkMap, ok := networkValue.(map[string]any)
 				if !ok {
 					continue
@@ -80,7 +80,7 @@ func TestMCPNetworkCodemod_SingleServerWithNetwork(t *testing.T) {
 on: workflow_dispatch
 mcp-servers:
   my-server:
-    container: my-image
+    command: ./my-server
     network:
       allowed:
         - example.com
@@ -93,7 +93,7 @@ mcp-servers:
 		"on": "workflow_dispatch",
 		"mcp-servers": map[string]any{
 			"my-server": map[string]any{
-				"container": "my-image",
+				"command": "./my-server",
 				"network": map[string]any{
 					"allowed": []any{"example.com", "api.example.com"},
 				},
@@ -110,7 +110,7 @@ mcp-servers:
 	assert.Contains(t, result, "  allowed:", "Should add allowed field")
 	assert.Contains(t, result, "    - example.com", "Should include domain")
 	assert.Contains(t, result, "    - api.example.com", "Should include domain")
-	assert.Contains(t, result, "container: my-image", "Should preserve container field")
+	assert.Contains(t, result, "command: ./my-server", "Should preserve command field")
 }
 
 func TestMCPNetworkCodemod_MultipleServersWithSameNetwork(t *testing.T) {
@@ -120,12 +120,12 @@ func TestMCPNetworkCodemod_MultipleServersWithSameNetwork(t *testing.T) {
 on: workflow_dispatch
 mcp-servers:
   server1:
-    container: image1
+    command: ./server1
     network:
       allowed:
         - example.com
   server2:
-    container: image2
+    command: ./server2
     network:
       allowed:
         - example.com
@@ -137,13 +137,13 @@ mcp-servers:
 		"on": "workflow_dispatch",
 		"mcp-servers": map[string]any{
 			"server1": map[string]any{
-				"container": "image1",
+				"command": "./server1",
 				"network": map[string]any{
 					"allowed": []any{"example.com"},
 				},
 			},
 			"server2": map[string]any{
-				"container": "image2",
+				"command": "./server2",
 				"network": map[string]any{
 					"allowed": []any{"example.com"},
 				},
@@ -175,12 +175,12 @@ func TestMCPNetworkCodemod_MultipleServersWithDifferentNetworks(t *testing.T) {
 on: workflow_dispatch
 mcp-servers:
   server1:
-    container: image1
+    command: ./server1
     network:
       allowed:
         - example.com
   server2:
-    container: image2
+    command: ./server2
     network:
       allowed:
         - api.github.com
@@ -192,13 +192,13 @@ mcp-servers:
 		"on": "workflow_dispatch",
 		"mcp-servers": map[string]any{
 			"server1": map[string]any{
-				"container": "image1",
+				"command": "./server1",
 				"network": map[string]any{
 					"allowed": []any{"example.com"},
 				},
 			},
 			"server2": map[string]any{
-				"container": "image2",
+				"command": "./server2",
 				"network": map[string]any{
 					"allowed": []any{"api.github.com"},
 				},
@@ -225,7 +225,7 @@ network:
     - existing.com
 mcp-servers:
   my-server:
-    container: my-image
+    command: ./my-server
     network:
       allowed:
         - new.com
@@ -240,7 +240,7 @@ mcp-servers:
 		},
 		"mcp-servers": map[string]any{
 			"my-server": map[string]any{
-				"container": "my-image",
+				"command": "./my-server",
 				"network": map[string]any{
 					"allowed": []any{"new.com"},
 				},
@@ -265,7 +265,7 @@ on: workflow_dispatch
 mcp-servers:
   my-server:
     type: stdio
-    container: my-image
+    command: ./my-server
     env:
       API_KEY: secret
     network:
@@ -282,8 +282,8 @@ mcp-servers:
 		"on": "workflow_dispatch",
 		"mcp-servers": map[string]any{
 			"my-server": map[string]any{
-				"type":      "stdio",
-				"container": "my-image",
+				"type":    "stdio",
+				"command": "./my-server",
 				"env": map[string]any{
 					"API_KEY": "secret",
 				},
@@ -300,7 +300,7 @@ mcp-servers:
 	require.NoError(t, err)
 	assert.True(t, applied)
 	assert.Contains(t, result, "type: stdio", "Should preserve type")
-	assert.Contains(t, result, "container: my-image", "Should preserve container")
+	assert.Contains(t, result, "command: ./my-server", "Should preserve command")
 	assert.Contains(t, result, "env:", "Should preserve env")
 	assert.Contains(t, result, "API_KEY: secret", "Should preserve env value")
 	assert.Contains(t, result, "    allowed:", "Should preserve tool allowed list")
@@ -317,14 +317,14 @@ func TestMCPNetworkCodemod_MixedServersWithAndWithoutNetwork(t *testing.T) {
 on: workflow_dispatch
 mcp-servers:
   server1:
-    container: image1
+    command: ./server1
     network:
       allowed:
         - example.com
   server2:
     command: node server.js
   server3:
-    container: image3
+    command: ./server3
     network:
       allowed:
         - api.com
@@ -336,7 +336,7 @@ mcp-servers:
 		"on": "workflow_dispatch",
 		"mcp-servers": map[string]any{
 			"server1": map[string]any{
-				"container": "image1",
+				"command": "./server1",
 				"network": map[string]any{
 					"allowed": []any{"example.com"},
 				},
@@ -345,7 +345,7 @@ mcp-servers:
 				"command": "node server.js",
 			},
 			"server3": map[string]any{
-				"container": "image3",
+				"command": "./server3",
 				"network": map[string]any{
 					"allowed": []any{"api.com"},
 				},
@@ -370,7 +370,7 @@ func TestMCPNetworkCodemod_PreservesMarkdown(t *testing.T) {
 on: workflow_dispatch
 mcp-servers:
   my-server:
-    container: my-image
+    command: ./my-server
     network:
       allowed:
         - example.com
@@ -390,7 +390,7 @@ key: value
 		"on": "workflow_dispatch",
 		"mcp-servers": map[string]any{
 			"my-server": map[string]any{
-				"container": "my-image",
+				"command": "./my-server",
 				"network": map[string]any{
 					"allowed": []any{"example.com"},
 				},
@@ -415,7 +415,7 @@ on: workflow_dispatch
 # MCP server configuration
 mcp-servers:
   my-server:
-    container: my-image
+    command: ./my-server
     # Network configuration (deprecated)
     network:
       allowed:
@@ -428,7 +428,7 @@ mcp-servers:
 		"on": "workflow_dispatch",
 		"mcp-servers": map[string]any{
 			"my-server": map[string]any{
-				"container": "my-image",
+				"command": "./my-server",
 				"network": map[string]any{
 					"allowed": []any{"example.com"},
 				},
@@ -452,7 +452,7 @@ func TestMCPNetworkCodemod_EmptyNetworkAllowed(t *testing.T) {
 on: workflow_dispatch
 mcp-servers:
   my-server:
-    container: my-image
+    command: ./my-server
     network:
       allowed: []
 ---
@@ -463,7 +463,7 @@ mcp-servers:
 		"on": "workflow_dispatch",
 		"mcp-servers": map[string]any{
 			"my-server": map[string]any{
-				"container": "my-image",
+				"command": "./my-server",
 				"network": map[string]any{
 					"allowed": []any{},
 				},
@@ -485,7 +485,7 @@ func TestMCPNetworkCodemod_NetworkWithoutAllowed(t *testing.T) {
 on: workflow_dispatch
 mcp-servers:
   my-server:
-    container: my-image
+    command: ./my-server
     network:
       timeout: 30
 ---
@@ -496,7 +496,7 @@ mcp-servers:
 		"on": "workflow_dispatch",
 		"mcp-servers": map[string]any{
 			"my-server": map[string]any{
-				"container": "my-image",
+				"command": "./my-server",
 				"network": map[string]any{
 					"timeout": 30,
 				},
@@ -522,13 +522,13 @@ network:
     - api.com
 mcp-servers:
   server1:
-    container: image1
+    command: ./server1
     network:
       allowed:
         - example.com
         - new1.com
   server2:
-    container: image2
+    command: ./server2
     network:
       allowed:
         - api.com
@@ -544,13 +544,13 @@ mcp-servers:
 		},
 		"mcp-servers": map[string]any{
 			"server1": map[string]any{
-				"container": "image1",
+				"command": "./server1",
 				"network": map[string]any{
 					"allowed": []any{"example.com", "new1.com"},
 				},
 			},
 			"server2": map[string]any{
-				"container": "image2",
+				"command": "./server2",
 				"network": map[string]any{
 					"allowed": []any{"api.com", "new2.com"},
 				},
@@ -587,6 +587,101 @@ mcp-servers:
 	assert.Equal(t, 1, domainCounts["new2.com"], "Should have exactly one new2.com")
 }
 
+func TestMCPNetworkCodemod_KeepsContainerServerNetwork(t *testing.T) {
+	codemod := getMCPNetworkMigrationCodemod()
+
+	content := `---
+on: workflow_dispatch
+mcp-servers:
+  notion:
+    container: mcp/notion
+    network:
+      allowed:
+        - api.notion.com
+---
+
+# Test`
+
+	frontmatter := map[string]any{
+		"on": "workflow_dispatch",
+		"mcp-servers": map[string]any{
+			"notion": map[string]any{
+				"container": "mcp/notion",
+				"network": map[string]any{
+					"allowed": []any{"api.notion.com"},
+				},
+			},
+		},
+	}
+
+	result, applied, err := codemod.Apply(content, frontmatter)
+
+	require.NoError(t, err)
+	assert.False(t, applied, "Container servers restrict their own egress with network.allowed")
+	assert.Equal(t, content, result)
+}
+
+func TestMCPNetworkCodemod_MixedContainerAndNonContainerServers(t *testing.T) {
+	codemod := getMCPNetworkMigrationCodemod()
+
+	content := `---
+on: workflow_dispatch
+mcp-servers:
+  notion:
+    container: mcp/notion
+    network:
+      allowed:
+        - api.notion.com
+  local:
+    command: node server.js
+    network:
+      allowed:
+        - example.com
+  remote:
+    url: https://mcp.example.com/mcp
+    network:
+      allowed:
+        - api.example.com
+---
+
+# Test`
+
+	frontmatter := map[string]any{
+		"on": "workflow_dispatch",
+		"mcp-servers": map[string]any{
+			"notion": map[string]any{
+				"container": "mcp/notion",
+				"network": map[string]any{
+					"allowed": []any{"api.notion.com"},
+				},
+			},
+			"local": map[string]any{
+				"command": "node server.js",
+				"network": map[string]any{
+					"allowed": []any{"example.com"},
+				},
+			},
+			"remote": map[string]any{
+				"url": "https://mcp.example.com/mcp",
+				"network": map[string]any{
+					"allowed": []any{"api.example.com"},
+				},
+			},
+		},
+	}
+
+	result, applied, err := codemod.Apply(content, frontmatter)
+
+	require.NoError(t, err)
+	assert.True(t, applied)
+	assert.Contains(t, result, "  notion:\n    container: mcp/notion\n    network:\n      allowed:\n        - api.notion.com\n", "Should keep network on the container server")
+	assert.Contains(t, result, "  local:\n    command: node server.js\n  remote:", "Should remove network from the command server")
+	assert.Contains(t, result, "    url: https://mcp.example.com/mcp\n---", "Should remove network from the HTTP server")
+	assert.Contains(t, result, "    - example.com", "Should move the command server domain to the top level")
+	assert.Contains(t, result, "    - api.example.com", "Should move the HTTP server domain to the top level")
+	assert.NotContains(t, result, "\n    - api.notion.com\n", "Should not move the container server domain to the top level")
+}
+
 // Helper function to split content into lines
 func splitLines(content string) []string {
 	lines := []string{}
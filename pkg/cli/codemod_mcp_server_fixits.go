@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+)
+
+var mcpServerFixitsCodemodLog = logger.New("cli:codemod_mcp_server_fixits")
+
+// getMCPServerFixitsCodemod creates a codemod that applies the mechanical fix-its reported
+// for mcp-servers entries: misspelled or aliased keys are renamed, and single strings
+// given for list fields (args, allowed, ...) are converted to lists.
+func getMCPServerFixitsCodemod() Codemod {
+	return Codemod{
+		ID:           "mcp-servers-fixits",
+		Name:         "Fix MCP server configuration keys and list values",
+		Description:  "Renames misspelled or aliased keys in mcp-servers entries (e.g. 'comand' to 'command', 'environment' to 'env') and converts string values of list fields such as args and allowed to lists.",
+		IntroducedIn: "1.5.0",
+		Apply: func(content string, frontmatter map[string]any) (string, bool, error) {
+			servers, ok := frontmatter["mcp-servers"].(map[string]any)
+			if !ok {
+				return content, false, nil
+			}
+
+			fixes := make(map[string]map[string]parser.MCPServerIssue)
+			for _, issue := range parser.DiagnoseMCPServers(servers) {
+				if issue.Field == "" || (issue.FixRename == "" && issue.FixList == nil) {
+					continue
+				}
+				// Renaming onto a key that is already set would silently drop one of the values
+				if config, _ := servers[issue.Server].(map[string]any); issue.FixRename != "" && config[issue.FixRename] != nil {
+					continue
+				}
+				if fixes[issue.Server] == nil {
+					fixes[issue.Server] = make(map[string]parser.MCPServerIssue)
+				}
+				fixes[issue.Server][issue.Field] = issue
+			}
+			if len(fixes) == 0 {
+				return content, false, nil
+			}
+
+			newContent, applied, err := applyFrontmatterLineTransform(content, func(lines []string) ([]string, bool) {
+				return applyMCPServerFixits(lines, fixes)
+			})
+			if applied {
+				mcpServerFixitsCodemodLog.Printf("Applied MCP server fix-its to %d server(s)", len(fixes))
+			}
+			return newContent, applied, err
+		},
+	}
+}
+
+// applyMCPServerFixits applies fixes (keyed by server name, then field) to the keys
+// directly under each server in the mcp-servers block.
+func applyMCPServerFixits(lines []string, fixes map[string]map[string]parser.MCPServerIssue) ([]string, bool) {
+	var result []string
+	var modified bool
+	var inMCPServers bool
+	var mcpServersIndent, serverIndent, fieldIndent, currentServer string
+
+	for i, line := range lines {
+		trimmedLine := strings.TrimSpace(line)
+
+		if isTopLevelKey(line) && strings.HasPrefix(trimmedLine, "mcp-servers:") {
+			inMCPServers = true
+			mcpServersIndent = getIndentation(line)
+			serverIndent, fieldIndent, currentServer = "", "", ""
+			result = append(result, line)
+			continue
+		}
+		if inMCPServers && hasExitedBlock(line, mcpServersIndent) {
+			inMCPServers = false
+		}
+		if !inMCPServers || trimmedLine == "" || strings.HasPrefix(trimmedLine, "#") {
+			result = append(result, line)
+			continue
+		}
+
+		indent := getIndentation(line)
+		if serverIndent == "" {
+			serverIndent = indent
+		}
+		switch {
+		case indent == serverIndent:
+			currentServer = strings.TrimSpace(strings.SplitN(trimmedLine, ":", 2)[0])
+			fieldIndent = ""
+		case fieldIndent == "" && len(indent) > len(serverIndent):
+			fieldIndent = indent
+		}
+
+		key := strings.TrimSpace(strings.SplitN(trimmedLine, ":", 2)[0])
+		issue, ok := fixes[currentServer][key]
+		if !ok || indent != fieldIndent {
+			result = append(result, line)
+			continue
+		}
+
+		if issue.FixRename != "" {
+			if newLine, replaced := findAndReplaceInLine(line, key, issue.FixRename); replaced {
+				line = newLine
+				key = issue.FixRename
+				modified = true
+				mcpServerFixitsCodemodLog.Printf("Renamed mcp-servers.%s.%s to %s on line %d", currentServer, issue.Field, issue.FixRename, i+1)
+			}
+		}
+		// Only rewrite single-line scalar values without comments; anything else is left
+		// for the author to fix using the compiler message.
+		if issue.FixList != nil && !strings.Contains(line, "#") {
+			value := strings.TrimSpace(strings.SplitN(line, ":", 2)[1])
+			if value != "" && !strings.HasPrefix(value, "|") && !strings.HasPrefix(value, ">") {
+				line = fmt.Sprintf("%s%s: %s", indent, key, formatFlowStringList(issue.FixList))
+				modified = true
+				mcpServerFixitsCodemodLog.Printf("Converted mcp-servers.%s.%s to a list on line %d", currentServer, key, i+1)
+			}
+		}
+		result = append(result, line)
+	}
+
+	return result, modified
+}
+
+// formatFlowStringList formats items as a YAML flow sequence of double-quoted strings.
+func formatFlowStringList(items []string) string {
+	quoted := make([]string, len(items))
+	for i, item := range items {
+		quoted[i] = fmt.Sprintf("%q", item)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
@@ -0,0 +1,100 @@
+//go:build !integration
+
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMCPServerFixitsCodemod(t *testing.T) {
+	codemod := getMCPServerFixitsCodemod()
+
+	t.Run("renames misspelled keys and converts list values", func(t *testing.T) {
+		content := `---
+engine: copilot
+mcp-servers:
+  notion:
+    comand: npx
+    args: -y @notion/server
+    enviroment:
+      NOTION_TOKEN: ${{ secrets.NOTION_TOKEN }}
+    allowed: search, fetch
+---
+
+# Test Workflow
+`
+		frontmatter := map[string]any{
+			"engine": "copilot",
+			"mcp-servers": map[string]any{
+				"notion": map[string]any{
+					"comand":     "npx",
+					"args":       "-y @notion/server",
+					"enviroment": map[string]any{"NOTION_TOKEN": "${{ secrets.NOTION_TOKEN }}"},
+					"allowed":    "search, fetch",
+				},
+			},
+		}
+
+		result, applied, err := codemod.Apply(content, frontmatter)
+		require.NoError(t, err, "codemod should not fail")
+		assert.True(t, applied, "codemod should be applied")
+		assert.Contains(t, result, "    command: npx", "comand should be renamed")
+		assert.Contains(t, result, `    args: ["-y", "@notion/server"]`, "args should be a list")
+		assert.Contains(t, result, "    env:\n      NOTION_TOKEN:", "enviroment should be renamed")
+		assert.Contains(t, result, `    allowed: ["search", "fetch"]`, "allowed should be a list")
+		assert.Contains(t, result, "# Test Workflow", "markdown body should be preserved")
+	})
+
+	t.Run("leaves nested keys with the same name alone", func(t *testing.T) {
+		content := `---
+mcp-servers:
+  notion:
+    command: npx
+    env:
+      cmd: value
+    cmd: ignored
+---
+`
+		frontmatter := map[string]any{
+			"mcp-servers": map[string]any{
+				"notion": map[string]any{
+					"command": "npx",
+					"env":     map[string]any{"cmd": "value"},
+					"cmd":     "ignored",
+				},
+			},
+		}
+
+		result, applied, err := codemod.Apply(content, frontmatter)
+		require.NoError(t, err, "codemod should not fail")
+		assert.False(t, applied, "rename onto an existing key should be skipped")
+		assert.Equal(t, content, result, "content should be unchanged")
+	})
+
+	t.Run("no change without fix-its", func(t *testing.T) {
+		content := `---
+mcp-servers:
+  notion:
+    command: npx
+    args: ["-y", "@notion/server"]
+---
+`
+		frontmatter := map[string]any{
+			"mcp-servers": map[string]any{
+				"notion": map[string]any{"command": "npx", "args": []any{"-y", "@notion/server"}},
+			},
+		}
+
+		result, applied, err := codemod.Apply(content, frontmatter)
+		require.NoError(t, err, "codemod should not fail")
+		assert.False(t, applied, "valid servers should not be changed")
+		assert.Equal(t, content, result, "content should be unchanged")
+
+		_, applied, err = codemod.Apply("---\non: push\n---\n", map[string]any{"on": "push"})
+		require.NoError(t, err, "codemod should not fail")
+		assert.False(t, applied, "workflows without mcp-servers should not be changed")
+	})
+}
@@ -18,6 +18,33 @@ var commandsLog = logger.New("cli:commands")
 // CreateWorkflowMarkdownFile creates a new workflow markdown file with template content
 func CreateWorkflowMarkdownFile(workflowName string, verbose bool, force bool, engine string) error {
 	commandsLog.Printf("Creating new workflow: name=%s, force=%v, engine=%s", workflowName, force, engine)
+	return writeNewWorkflowFile(workflowName, verbose, force, createWorkflowTemplate(strings.TrimSuffix(workflowName, ".md"), engine))
+}
+
+// CreateWorkflowFromPreset creates a workflow from a built-in preset such as "triage".
+// params holds key=value overrides for the preset's parameters; unset parameters use
+// the preset defaults.
+func CreateWorkflowFromPreset(workflowName string, presetName string, params []string, verbose bool, force bool, engine string) error {
+	commandsLog.Printf("Creating new workflow from preset: name=%s, preset=%s, force=%v, engine=%s", workflowName, presetName, force, engine)
+
+	preset, err := lookupWorkflowPreset(presetName)
+	if err != nil {
+		return err
+	}
+	resolved, err := resolvePresetParams(preset, params)
+	if err != nil {
+		return err
+	}
+	content, err := preset.Render(strings.TrimSuffix(workflowName, ".md"), engine, resolved)
+	if err != nil {
+		return err
+	}
+	return writeNewWorkflowFile(workflowName, verbose, force, content)
+}
+
+// writeNewWorkflowFile writes content to .github/workflows/<workflowName>.md,
+// refusing to overwrite an existing file unless force is set.
+func writeNewWorkflowFile(workflowName string, verbose bool, force bool, content string) error {
 
 	// Normalize the workflow name by removing .md extension if present
 	// This ensures consistent behavior whether user provides "my-workflow" or "my-workflow.md"
@@ -66,11 +93,8 @@ func CreateWorkflowMarkdownFile(workflowName string, verbose bool, force bool, e
 		return fmt.Errorf("workflow file '%s' already exists. Use --force to overwrite", destFile)
 	}
 
-	// Create the template content
-	template := createWorkflowTemplate(workflowName, engine)
-
 	// Write the template to file with restrictive permissions (owner-only)
-	if err := os.WriteFile(destFile, []byte(template), constants.FilePermSensitive); err != nil {
+	if err := os.WriteFile(destFile, []byte(content), constants.FilePermSensitive); err != nil {
 		return fmt.Errorf("failed to write workflow file '%s': %w", destFile, err)
 	}
 
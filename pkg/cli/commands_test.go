@@ -939,7 +939,7 @@ func TestCalculateTimeRemaining(t *testing.T) {
 	t.Run("future time formatting", func(t *testing.T) {
 		// Create a time 2 hours and 30 minutes in the future
 		// Add a small buffer to account for execution time
-		futureTime := time.Now().Add(2*time.Hour + 30*time.Minute + 1*time.Second)
+		futureTime := time.Now().UTC().Add(2*time.Hour + 30*time.Minute + 1*time.Second)
 		stopTimeStr := futureTime.Format("2006-01-02 15:04:05")
 
 		result := calculateTimeRemaining(stopTimeStr)
@@ -958,7 +958,7 @@ func TestCalculateTimeRemaining(t *testing.T) {
 	// Test with past time
 	t.Run("past time - expired", func(t *testing.T) {
 		// Create a time 1 hour in the past
-		pastTime := time.Now().Add(-1 * time.Hour)
+		pastTime := time.Now().UTC().Add(-1 * time.Hour)
 		stopTimeStr := pastTime.Format("2006-01-02 15:04:05")
 
 		result := calculateTimeRemaining(stopTimeStr)
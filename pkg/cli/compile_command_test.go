@@ -298,6 +298,195 @@ func TestCompileWorkflows_DependabotValidation(t *testing.T) {
 	}
 }
 
+// TestCompileWorkflows_UpdateLintBaselineValidation tests that --update-lint-baseline requires --strict
+func TestCompileWorkflows_UpdateLintBaselineValidation(t *testing.T) {
+	err := validateCompileConfig(CompileConfig{UpdateLintBaseline: true})
+	if err == nil || !strings.Contains(err.Error(), "--update-lint-baseline requires --strict") {
+		t.Errorf("Expected --update-lint-baseline without --strict to fail, got: %v", err)
+	}
+
+	if err := validateCompileConfig(CompileConfig{UpdateLintBaseline: true, Strict: true}); err != nil {
+		t.Errorf("Expected no error but got: %v", err)
+	}
+}
+
+// TestCompileWorkflows_OutputRedirectionValidation tests --stdout and --output-dir flag validation
+func TestCompileWorkflows_OutputRedirectionValidation(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      CompileConfig
+		expectError bool
+		errorMsg    string
+	}{
+		{
+			name:   "stdout alone",
+			config: CompileConfig{Stdout: true},
+		},
+		{
+			name:   "output dir with scanners",
+			config: CompileConfig{OutputDir: "build/workflows", Actionlint: true, IR: CompileIRFormatJSON},
+		},
+		{
+			name:        "stdout with output dir",
+			config:      CompileConfig{Stdout: true, OutputDir: "build/workflows"},
+			expectError: true,
+			errorMsg:    "--stdout cannot be used with --output-dir",
+		},
+		{
+			name:        "stdout with json format",
+			config:      CompileConfig{Stdout: true, Format: CompileFormatJSON},
+			expectError: true,
+			errorMsg:    "--stdout cannot be used with --json",
+		},
+		{
+			name:        "stdout with actionlint",
+			config:      CompileConfig{Stdout: true, Actionlint: true},
+			expectError: true,
+			errorMsg:    "--stdout cannot be used with --actionlint",
+		},
+		{
+			name:        "output dir with purge",
+			config:      CompileConfig{OutputDir: "build/workflows", Purge: true},
+			expectError: true,
+			errorMsg:    "--output-dir cannot be used with --purge",
+		},
+		{
+			name:        "output dir with no-emit",
+			config:      CompileConfig{OutputDir: "build/workflows", NoEmit: true},
+			expectError: true,
+			errorMsg:    "--output-dir cannot be used with --no-emit",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCompileConfig(tt.config)
+
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got nil")
+				} else if !strings.Contains(err.Error(), tt.errorMsg) {
+					t.Errorf("Expected error containing %q, got %q", tt.errorMsg, err.Error())
+				}
+			} else if err != nil {
+				t.Errorf("Expected no error but got: %v", err)
+			}
+		})
+	}
+}
+
+// TestCompileWorkflows_OfflineValidation tests that --offline rejects options that need the network
+func TestCompileWorkflows_OfflineValidation(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      CompileConfig
+		expectError bool
+		errorMsg    string
+	}{
+		{
+			name: "offline with validate",
+			config: CompileConfig{
+				Offline:  true,
+				Validate: true,
+			},
+			expectError: true,
+			errorMsg:    "--offline cannot be used with --validate",
+		},
+		{
+			name: "offline with update-mcp",
+			config: CompileConfig{
+				Offline:   true,
+				UpdateMCP: true,
+			},
+			expectError: true,
+			errorMsg:    "--offline cannot be used with --update-mcp",
+		},
+		{
+			name: "offline with force refresh of action pins",
+			config: CompileConfig{
+				Offline:                true,
+				ForceRefreshActionPins: true,
+			},
+			expectError: true,
+			errorMsg:    "--offline cannot be used with --force-refresh-action-pins",
+		},
+		{
+			name: "offline alone",
+			config: CompileConfig{
+				Offline: true,
+			},
+			expectError: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCompileConfig(tt.config)
+
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got nil")
+				} else if !strings.Contains(err.Error(), tt.errorMsg) {
+					t.Errorf("Expected error containing %q, got %q", tt.errorMsg, err.Error())
+				}
+			} else if err != nil {
+				t.Errorf("Expected no error but got: %v", err)
+			}
+		})
+	}
+}
+
+// TestCompileWorkflows_FormatValidation tests --format flag validation
+func TestCompileWorkflows_FormatValidation(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      CompileConfig
+		expectError bool
+		errorMsg    string
+	}{
+		{
+			name:   "default format",
+			config: CompileConfig{},
+		},
+		{
+			name:   "github format",
+			config: CompileConfig{Format: CompileFormatGitHub},
+		},
+		{
+			name:   "json format with --json",
+			config: CompileConfig{Format: CompileFormatJSON, JSONOutput: true},
+		},
+		{
+			name:        "github format with --json",
+			config:      CompileConfig{Format: CompileFormatGitHub, JSONOutput: true},
+			expectError: true,
+			errorMsg:    "--format github cannot be used with --json",
+		},
+		{
+			name:        "unknown format",
+			config:      CompileConfig{Format: "sarif"},
+			expectError: true,
+			errorMsg:    "unknown --format",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCompileConfig(tt.config)
+
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got nil")
+				} else if !strings.Contains(err.Error(), tt.errorMsg) {
+					t.Errorf("Expected error containing %q, got %q", tt.errorMsg, err.Error())
+				}
+			} else if err != nil {
+				t.Errorf("Expected no error but got: %v", err)
+			}
+		})
+	}
+}
+
 // TestCompileWorkflows_PurgeValidation tests purge flag validation
 // Uses the fast validateCompileConfig function instead of full compilation
 func TestCompileWorkflows_PurgeValidation(t *testing.T) {
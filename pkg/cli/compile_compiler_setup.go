@@ -28,6 +28,7 @@
 package cli
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -116,14 +117,19 @@ func createAndConfigureCompiler(config CompileConfig) *workflow.Compiler {
 	// Set up repository context
 	setupRepositoryContext(compiler, config)
 
-	if config.DisableModelsDevLookup {
-		compileCompilerSetupLog.Print("models.dev pricing lookup disabled via --no-models-dev-lookup")
+	if config.DisableModelsDevLookup || config.Offline {
+		compileCompilerSetupLog.Print("models.dev pricing lookup disabled via --no-models-dev-lookup or --offline")
 	} else {
 		// Register the models.dev pricing resolver so the compiler can inject pricing for
 		// models absent from the embedded catalog into GH_AW_INFO_MODEL_COSTS in the lock.yml.
 		compiler.SetModelPricingResolver(FindOrFetchModelPricing)
 	}
 
+	if !config.Offline {
+		// engine: auto selects the first preferred engine whose secret is configured
+		compiler.SetRepositorySecretsResolver(listRepositorySecretNames)
+	}
+
 	return compiler
 }
 
@@ -140,9 +146,24 @@ func configureCompilerFlags(compiler *workflow.Compiler, config CompileConfig) {
 	if config.NoEmit {
 		compileCompilerSetupLog.Print("No-emit mode enabled: validating without generating lock files")
 	}
+	compiler.SetCheckLockFiles(config.Check)
+	if config.Check {
+		compileCompilerSetupLog.Print("Check mode enabled: comparing compiled workflows with existing lock files")
+	}
 
-	// Set strict mode if specified
+	// Redirect the compiled workflows to stdout or another directory
+	if config.Stdout {
+		compiler.SetOutputWriter(os.Stdout)
+		compileCompilerSetupLog.Print("Stdout mode enabled: writing compiled workflows to stdout")
+	} else if config.OutputDir != "" {
+		compiler.SetOutputDir(config.OutputDir)
+		compileCompilerSetupLog.Printf("Output directory set: %s", config.OutputDir)
+	}
+
+	// Set strict mode if specified; --strict also runs the security lints
 	compiler.SetStrictMode(config.Strict)
+	compiler.SetSecurityLints(config.Strict)
+	compiler.SetUpdateLintBaseline(config.UpdateLintBaseline)
 	compiler.SetAllowActionRefs(config.AllowActionRefs)
 	compiler.SetForceStaged(config.Staged)
 
@@ -173,6 +194,14 @@ func configureCompilerFlags(compiler *workflow.Compiler, config CompileConfig) {
 		compileCompilerSetupLog.Print("Force refresh action pins enabled: will clear cache and resolve all actions from GitHub API")
 	}
 
+	// Re-resolve MCP server image digests with the same Docker/crane lookup used by upgrade
+	if config.UpdateMCP {
+		compiler.SetContainerDigestResolver(func(ctx context.Context, image string) (string, error) {
+			return fetchContainerDigest(ctx, image, config.Verbose)
+		})
+		compileCompilerSetupLog.Print("MCP image pin refresh enabled: will re-resolve MCP server image digests")
+	}
+
 	// Set safe update flag: when set via CLI it disables/skips safe update enforcement
 	// regardless of the workflow's strict mode setting.
 	compiler.SetApprove(config.Approve)
@@ -1,5 +1,14 @@
 package cli
 
+import "github.com/github/gh-aw/pkg/workflow"
+
+// Diagnostics formats accepted by compile --format.
+const (
+	CompileFormatText   = "text"
+	CompileFormatJSON   = "json"
+	CompileFormatGitHub = "github"
+)
+
 // CompileConfig holds configuration options for compiling workflows
 type CompileConfig struct {
 	MarkdownFiles          []string // Files to compile (empty for all files)
@@ -10,15 +19,20 @@ type CompileConfig struct {
 	WorkflowDir            string   // Custom workflow directory
 	SkipInstructions       bool     // Deprecated: Instructions are no longer written during compilation
 	NoEmit                 bool     // Validate without generating lock files
+	Check                  bool     // Fail when a lock file differs from the compiled workflow instead of writing it (implies NoEmit)
+	Stdout                 bool     // Write compiled workflows to stdout instead of lock files
+	OutputDir              string   // Write lock files to this directory instead of next to their markdown source
 	Purge                  bool     // Remove orphaned lock files
 	TrialMode              bool     // Enable trial mode (suppress safe outputs)
 	TrialLogicalRepoSlug   string   // Target repository for trial mode
 	UseSamples             bool     // Hidden: replace agentic step with a deterministic samples replay driver
 	Strict                 bool     // Enable strict mode validation
+	UpdateLintBaseline     bool     // Accept the current security lint findings in the baseline file (requires Strict)
 	Dependabot             bool     // Generate Dependabot manifests for npm dependencies
 	ForceOverwrite         bool     // Force overwrite of existing files (dependabot.yml)
 	RefreshStopTime        bool     // Force regeneration of stop-after times instead of preserving existing ones
 	ForceRefreshActionPins bool     // Force refresh of action pins by clearing cache and resolving from GitHub API
+	UpdateMCP              bool     // Re-resolve MCP server container image digests and update their pins in actions-lock.json
 	AllowActionRefs        bool     // Allow unresolved action refs as warnings instead of errors
 	Staged                 bool     // Force all safe-outputs into staged mode
 	Zizmor                 bool     // Run zizmor security scanner on generated .lock.yml files
@@ -30,11 +44,14 @@ type CompileConfig struct {
 	Grant                  bool     // Run grant license scanner on container images referenced in compiled .lock.yml files
 	Yamllint               bool     // Run yamllint YAML linter on generated .lock.yml files
 	JSONOutput             bool     // Output validation results as JSON
+	Format                 string   // Diagnostics format: "text" (default), "json" (same as JSONOutput), or "github" (workflow command annotations)
 	ShowAllErrors          bool     // Display all prioritized errors instead of the default top five
 	ActionMode             string   // How action scripts are referenced: dev, release, or action. Auto-detected if empty.
 	ActionTag              string   // Pin action refs to this SHA or version tag (e.g. v1, <full-sha>). Sets release mode unless ActionMode is already "action". Mutually exclusive with GHAwRef at the CLI layer.
 	ActionsRepo            string   // Override the external actions repository (default: github/gh-aw-actions)
 	Stats                  bool     // Display statistics table sorted by file size
+	ExplainNetwork         bool     // Print how network.allowed entries expand into the firewall allow-list
+	IR                     string   // Write an intermediate representation of each compiled workflow next to its lock file ("json")
 	FailFast               bool     // Stop at first error instead of collecting all errors
 	ScheduleSeed           string   // Override repository slug used for fuzzy schedule scattering (e.g. owner/repo)
 	Approve                bool     // Approve all safe update changes, skipping safe update enforcement regardless of strict mode setting.
@@ -42,6 +59,7 @@ type CompileConfig struct {
 	PriorManifestFile      string   // Path to a JSON file containing pre-cached manifests (map[lockFile]*GHAWManifest) collected at MCP server startup; takes precedence over git HEAD / filesystem reads for safe update enforcement
 	GHESCompat             bool     // Enable GHES compatibility mode (overrides aw.json ghes field); artifact actions still use latest non-v3 pins
 	DisableModelsDevLookup bool     // Disable compile-time models.dev pricing lookup for models missing from the embedded catalog
+	Offline                bool     // Compile without network access, using only actions-lock.json, embedded pins, and the import cache
 }
 
 // CompileValidationError represents a single validation error or warning
@@ -53,10 +71,12 @@ type CompileValidationError struct {
 
 // ValidationResult represents the validation result for a single workflow
 type ValidationResult struct {
-	Workflow     string                   `json:"workflow"`
-	Valid        bool                     `json:"valid"`
-	Errors       []CompileValidationError `json:"errors"`
-	Warnings     []CompileValidationError `json:"warnings"`
-	CompiledFile string                   `json:"compiled_file,omitempty"`
-	Labels       []string                 `json:"labels,omitempty"` // Labels referenced in safe-outputs configurations
+	Workflow      string                     `json:"workflow"`
+	Valid         bool                       `json:"valid"`
+	Errors        []CompileValidationError   `json:"errors"`
+	Warnings      []CompileValidationError   `json:"warnings"`
+	CompiledFile  string                     `json:"compiled_file,omitempty"`
+	Labels        []string                   `json:"labels,omitempty"`         // Labels referenced in safe-outputs configurations
+	ChangeRisk    *workflow.ChangeRiskReport `json:"change_risk,omitempty"`    // Risk score of the changes since the last commit
+	SecurityLints []workflow.SecurityLint    `json:"security_lints,omitempty"` // Security lint findings (compile --strict)
 }
@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/workflow"
+)
+
+var compileExplainNetworkLog = logger.New("cli:compile_explain_network")
+
+// displayNetworkExplanations prints, for each compiled workflow, how its network.allowed
+// entries expand into domains and the final allow-list passed to the firewall
+// (compile --explain-network).
+func displayNetworkExplanations(workflowDataList []*workflow.WorkflowData) {
+	compileExplainNetworkLog.Printf("Explaining network allow-lists for %d workflows", len(workflowDataList))
+	for _, data := range workflowDataList {
+		if data == nil {
+			continue
+		}
+		fmt.Fprint(os.Stderr, formatNetworkExplanation(data.WorkflowID, workflow.ExplainNetworkPermissions(data)))
+	}
+}
+
+// formatNetworkExplanation renders the network explanation of one workflow.
+func formatNetworkExplanation(workflowID string, explanation *workflow.NetworkExplanation) string {
+	var sb strings.Builder
+	sb.WriteString(console.FormatSectionHeader("Network: "+workflowID) + "\n")
+	if explanation.Implicit {
+		sb.WriteString(console.FormatInfoMessage("network is not configured; the defaults bundle applies") + "\n")
+	}
+	if len(explanation.Allowed) == 0 {
+		sb.WriteString(console.FormatListItem("network.allowed is empty: all network access is denied") + "\n")
+	}
+	for _, entry := range explanation.Allowed {
+		if entry.Ecosystem {
+			sb.WriteString(console.FormatListItem(fmt.Sprintf("%s (ecosystem, %d domains): %s", entry.Entry, len(entry.Domains), strings.Join(entry.Domains, ", "))) + "\n")
+		} else {
+			sb.WriteString(console.FormatListItem(entry.Entry+" (domain)") + "\n")
+		}
+	}
+	if len(explanation.Added) > 0 {
+		sb.WriteString(console.FormatListItem(fmt.Sprintf("added by the engine, tools and runtimes (%d domains): %s", len(explanation.Added), strings.Join(explanation.Added, ", "))) + "\n")
+	}
+	if len(explanation.Blocked) > 0 {
+		sb.WriteString(console.FormatListItem(fmt.Sprintf("blocked (%d domains): %s", len(explanation.Blocked), strings.Join(explanation.Blocked, ", "))) + "\n")
+	}
+	sb.WriteString(console.FormatInfoMessage(fmt.Sprintf("Effective allow-list: %d domains", len(explanation.Effective))) + "\n")
+	return sb.String()
+}
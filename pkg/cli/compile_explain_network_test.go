@@ -0,0 +1,32 @@
+//go:build !integration
+
+package cli
+
+import (
+	"testing"
+
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatNetworkExplanation(t *testing.T) {
+	explanation := &workflow.NetworkExplanation{
+		Allowed: []workflow.NetworkAllowedEntry{
+			{Entry: "go", Ecosystem: true, Domains: []string{"go.dev", "proxy.golang.org"}},
+			{Entry: "example.com", Domains: []string{"example.com"}},
+		},
+		Added:     []string{"api.githubcopilot.com"},
+		Blocked:   []string{"goproxy.io"},
+		Effective: []string{"api.githubcopilot.com", "example.com", "go.dev", "proxy.golang.org"},
+	}
+
+	output := formatNetworkExplanation("ci-doctor", explanation)
+
+	assert.Contains(t, output, "Network: ci-doctor", "output should name the workflow")
+	assert.Contains(t, output, "go (ecosystem, 2 domains): go.dev, proxy.golang.org", "output should show the bundle expansion")
+	assert.Contains(t, output, "example.com (domain)", "output should list plain domains")
+	assert.Contains(t, output, "added by the engine, tools and runtimes (1 domains): api.githubcopilot.com", "output should list added domains")
+	assert.Contains(t, output, "blocked (1 domains): goproxy.io", "output should list blocked domains")
+	assert.Contains(t, output, "Effective allow-list: 4 domains", "output should summarize the effective list")
+	assert.NotContains(t, output, "not configured", "explicit network should not mention the defaults fallback")
+}
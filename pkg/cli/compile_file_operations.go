@@ -128,20 +128,23 @@ func compileAllWorkflowFiles(ctx context.Context, compiler *workflow.Compiler, w
 
 	// Get warning count from compiler
 	stats.Warnings = compiler.GetWarningCount()
+	stats.ChangeRisks = compiler.GetChangeRiskReports()
 
 	// Save action cache and update .gitattributes (shared post-compile helpers)
 	actionCache := compiler.GetSharedActionCache()
 	successCount := stats.Total - stats.Errors
 	_ = saveActionCache(actionCache, verbose)
+	_ = savePromptRegistry(compiler.GetPromptRegistry(), verbose)
 	_ = updateGitAttributes(successCount, actionCache, verbose)
 
 	return stats, nil
 }
 
-// compileModifiedFilesWithDependencies compiles modified files and their dependencies using the dependency graph
-func compileModifiedFilesWithDependencies(ctx context.Context, compiler *workflow.Compiler, depGraph *DependencyGraph, files []string, verbose bool) {
+// compileModifiedFilesWithDependencies compiles modified files and their dependencies using the dependency graph.
+// It returns the compilation statistics and the workflows that were recompiled.
+func compileModifiedFilesWithDependencies(ctx context.Context, compiler *workflow.Compiler, depGraph *DependencyGraph, files []string, verbose bool) (*CompilationStats, []string) {
 	if len(files) == 0 {
-		return
+		return nil, nil
 	}
 
 	// Clear screen before emitting new output in watch mode
@@ -191,6 +194,7 @@ func compileModifiedFilesWithDependencies(ctx context.Context, compiler *workflo
 
 	// Get warning count from compiler
 	stats.Warnings = compiler.GetWarningCount()
+	stats.ChangeRisks = compiler.GetChangeRiskReports()
 
 	// Save the action cache after compilations
 	actionCache := compiler.GetSharedActionCache()
@@ -208,6 +212,7 @@ func compileModifiedFilesWithDependencies(ctx context.Context, compiler *workflo
 			compileHelpersLog.Print("Action cache saved successfully")
 		}
 	}
+	_ = savePromptRegistry(compiler.GetPromptRegistry(), verbose)
 
 	// Ensure .gitattributes marks .lock.yml files as generated
 	// Only update if we successfully compiled workflows or have action cache entries
@@ -223,6 +228,7 @@ func compileModifiedFilesWithDependencies(ctx context.Context, compiler *workflo
 
 	// Print summary instead of just "Recompiled"
 	printCompilationSummary(stats, false)
+	return stats, workflowsToCompile
 }
 
 // handleFileDeleted handles the deletion of a markdown file by removing its corresponding lock file
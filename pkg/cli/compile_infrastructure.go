@@ -66,3 +66,20 @@ func saveActionCache(actionCache *workflow.ActionCache, verbose bool) error {
 
 	return nil
 }
+
+// savePromptRegistry saves the prompt version registry after all compilations
+func savePromptRegistry(registry *workflow.PromptRegistry, verbose bool) error {
+	if registry == nil {
+		return nil
+	}
+
+	compileInfrastructureLog.Print("Saving prompt registry")
+
+	if err := registry.Save(); err != nil {
+		compileInfrastructureLog.Printf("Failed to save prompt registry: %v", err)
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Failed to save prompt registry: %v", err)))
+		return err
+	}
+
+	return nil
+}
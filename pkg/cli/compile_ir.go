@@ -0,0 +1,234 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/goccy/go-yaml"
+)
+
+var compileIRLog = logger.New("cli:compile_ir")
+
+// CompileIRFormatJSON is the only intermediate representation format accepted by compile --ir.
+const CompileIRFormatJSON = "json"
+
+// workflowIRVersion is bumped whenever a field of WorkflowIR changes meaning or is removed.
+const workflowIRVersion = "1"
+
+// WorkflowIR is the intermediate representation of a compiled workflow written by
+// compile --ir json. It describes the jobs and steps of the lock file in a neutral shape
+// so tools that target other orchestrators (jsonnet, importers, internal schedulers) do
+// not have to parse GitHub Actions YAML.
+type WorkflowIR struct {
+	IRVersion       string            `json:"ir_version"`
+	Source          string            `json:"source"`
+	LockFile        string            `json:"lock_file"`
+	Name            string            `json:"name,omitempty"`
+	Engine          *WorkflowIREngine `json:"engine,omitempty"`
+	CompilerVersion string            `json:"compiler_version,omitempty"`
+	Triggers        any               `json:"triggers,omitempty"`
+	Permissions     any               `json:"permissions,omitempty"`
+	Env             map[string]any    `json:"env,omitempty"`
+	Concurrency     any               `json:"concurrency,omitempty"`
+	Jobs            []WorkflowIRJob   `json:"jobs"`
+}
+
+// WorkflowIREngine identifies the AI engine that runs the agent job.
+type WorkflowIREngine struct {
+	ID    string `json:"id"`
+	Model string `json:"model,omitempty"`
+}
+
+// WorkflowIRJob is a job of the compiled workflow. Jobs are sorted by ID; Needs carries
+// the dependency edges.
+type WorkflowIRJob struct {
+	ID             string           `json:"id"`
+	Name           string           `json:"name,omitempty"`
+	RunsOn         any              `json:"runs_on,omitempty"`
+	Needs          []string         `json:"needs,omitempty"`
+	If             string           `json:"if,omitempty"`
+	Permissions    any              `json:"permissions,omitempty"`
+	Environment    any              `json:"environment,omitempty"`
+	Concurrency    any              `json:"concurrency,omitempty"`
+	TimeoutMinutes any              `json:"timeout_minutes,omitempty"`
+	Container      any              `json:"container,omitempty"`
+	Services       any              `json:"services,omitempty"`
+	Env            map[string]any   `json:"env,omitempty"`
+	Outputs        map[string]any   `json:"outputs,omitempty"`
+	Steps          []WorkflowIRStep `json:"steps"`
+}
+
+// WorkflowIRStep is a step of a job. Exactly one of Uses and Run is set.
+type WorkflowIRStep struct {
+	ID               string         `json:"id,omitempty"`
+	Name             string         `json:"name,omitempty"`
+	If               string         `json:"if,omitempty"`
+	Uses             string         `json:"uses,omitempty"`
+	With             map[string]any `json:"with,omitempty"`
+	Run              string         `json:"run,omitempty"`
+	Shell            string         `json:"shell,omitempty"`
+	WorkingDirectory string         `json:"working_directory,omitempty"`
+	Env              map[string]any `json:"env,omitempty"`
+	ContinueOnError  any            `json:"continue_on_error,omitempty"`
+	TimeoutMinutes   any            `json:"timeout_minutes,omitempty"`
+}
+
+// workflowIRPath returns the path of the IR file written next to a lock file,
+// e.g. triage.lock.yml -> triage.ir.json.
+func workflowIRPath(lockFilePath string) string {
+	return strings.TrimSuffix(lockFilePath, ".lock.yml") + ".ir.json"
+}
+
+// writeWorkflowIR builds the intermediate representation of a compiled lock file and
+// writes it next to the lock file.
+func writeWorkflowIR(lockFilePath string) (string, error) {
+	content, err := os.ReadFile(lockFilePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read lock file: %w", err)
+	}
+	ir, err := buildWorkflowIR(filepath.Base(lockFilePath), content)
+	if err != nil {
+		return "", err
+	}
+	data, err := json.MarshalIndent(ir, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal intermediate representation: %w", err)
+	}
+	irPath := workflowIRPath(lockFilePath)
+	if err := os.WriteFile(irPath, append(data, '\n'), constants.FilePermPublic); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", filepath.Base(irPath), err)
+	}
+	compileIRLog.Printf("Wrote intermediate representation: %s (%d jobs)", irPath, len(ir.Jobs))
+	return irPath, nil
+}
+
+// buildWorkflowIR converts the content of a compiled lock file into a WorkflowIR.
+func buildWorkflowIR(lockFileName string, content []byte) (*WorkflowIR, error) {
+	var workflowYAML map[string]any
+	if err := yaml.Unmarshal(content, &workflowYAML); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+
+	ir := &WorkflowIR{
+		IRVersion:   workflowIRVersion,
+		Source:      strings.TrimSuffix(lockFileName, ".lock.yml") + ".md",
+		LockFile:    lockFileName,
+		Name:        irString(workflowYAML["name"]),
+		Triggers:    workflowYAML["on"],
+		Permissions: workflowYAML["permissions"],
+		Env:         irMap(workflowYAML["env"]),
+		Concurrency: workflowYAML["concurrency"],
+		Jobs:        []WorkflowIRJob{},
+	}
+	if meta, _, err := workflow.ExtractMetadataFromLockFile(string(content)); err == nil && meta != nil {
+		ir.CompilerVersion = meta.CompilerVersion
+		if meta.AgentID != "" {
+			ir.Engine = &WorkflowIREngine{ID: meta.AgentID, Model: meta.AgentModel}
+		}
+	}
+
+	jobs, _ := workflowYAML["jobs"].(map[string]any)
+	jobIDs := make([]string, 0, len(jobs))
+	for id := range jobs {
+		jobIDs = append(jobIDs, id)
+	}
+	slices.Sort(jobIDs)
+	for _, id := range jobIDs {
+		job, ok := jobs[id].(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("job %s is not a mapping", id)
+		}
+		ir.Jobs = append(ir.Jobs, buildWorkflowIRJob(id, job))
+	}
+	return ir, nil
+}
+
+// buildWorkflowIRJob converts one job of the lock file.
+func buildWorkflowIRJob(id string, job map[string]any) WorkflowIRJob {
+	irJob := WorkflowIRJob{
+		ID:             id,
+		Name:           irString(job["name"]),
+		RunsOn:         job["runs-on"],
+		If:             irString(job["if"]),
+		Permissions:    job["permissions"],
+		Environment:    job["environment"],
+		Concurrency:    job["concurrency"],
+		TimeoutMinutes: job["timeout-minutes"],
+		Container:      job["container"],
+		Services:       job["services"],
+		Env:            irMap(job["env"]),
+		Outputs:        irMap(job["outputs"]),
+		Steps:          []WorkflowIRStep{},
+	}
+	switch needs := job["needs"].(type) {
+	case string:
+		irJob.Needs = []string{needs}
+	case []any:
+		for _, need := range needs {
+			if s := irString(need); s != "" {
+				irJob.Needs = append(irJob.Needs, s)
+			}
+		}
+	}
+	steps, _ := job["steps"].([]any)
+	for _, stepData := range steps {
+		step, ok := stepData.(map[string]any)
+		if !ok {
+			continue
+		}
+		irJob.Steps = append(irJob.Steps, WorkflowIRStep{
+			ID:               irString(step["id"]),
+			Name:             irString(step["name"]),
+			If:               irString(step["if"]),
+			Uses:             irString(step["uses"]),
+			With:             irMap(step["with"]),
+			Run:              irString(step["run"]),
+			Shell:            irString(step["shell"]),
+			WorkingDirectory: irString(step["working-directory"]),
+			Env:              irMap(step["env"]),
+			ContinueOnError:  step["continue-on-error"],
+			TimeoutMinutes:   step["timeout-minutes"],
+		})
+	}
+	return irJob
+}
+
+// irString returns the string form of a scalar YAML value, or an empty string when the
+// value is missing.
+func irString(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// irMap returns value as a mapping, or nil when it is not one.
+func irMap(value any) map[string]any {
+	m, _ := value.(map[string]any)
+	return m
+}
+
+// writeWorkflowIRWithWarning writes the intermediate representation of a lock file and
+// reports failures as warnings, so a malformed IR never fails an otherwise valid compile.
+func writeWorkflowIRWithWarning(lockFilePath string, verbose bool) {
+	irPath, err := writeWorkflowIR(lockFilePath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Failed to write intermediate representation for %s: %v", filepath.Base(lockFilePath), err)))
+		return
+	}
+	if verbose {
+		fmt.Fprintln(os.Stderr, console.FormatVerboseMessage("Wrote "+irPath))
+	}
+}
@@ -0,0 +1,127 @@
+//go:build !integration
+
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testIRLockFile = `# gh-aw-metadata: {"schema_version":"v3","compiler_version":"v1.4.0","agent_id":"copilot","agent_model":"gpt-5"}
+name: "Issue Triage"
+"on":
+  issues:
+    types: [opened]
+permissions: {}
+jobs:
+  agent:
+    needs: activation
+    runs-on: ubuntu-latest
+    timeout-minutes: 20
+    outputs:
+      output: ${{ steps.collect.outputs.output }}
+    steps:
+      - name: Checkout
+        uses: actions/checkout@abc123 # v5
+        with:
+          persist-credentials: false
+      - id: collect
+        name: Collect output
+        run: echo done
+        env:
+          GH_AW_SAFE_OUTPUTS: /tmp/gh-aw/safeoutputs.jsonl
+  activation:
+    runs-on: ubuntu-slim
+    if: github.event.issue.state == 'open'
+    steps:
+      - run: echo activate
+        shell: bash
+  conclusion:
+    needs: [activation, agent]
+    runs-on: ubuntu-slim
+    steps: []
+`
+
+func TestBuildWorkflowIR(t *testing.T) {
+	ir, err := buildWorkflowIR("triage.lock.yml", []byte(testIRLockFile))
+	require.NoError(t, err, "lock file should convert")
+
+	assert.Equal(t, workflowIRVersion, ir.IRVersion, "unexpected IR version")
+	assert.Equal(t, "triage.md", ir.Source, "unexpected source")
+	assert.Equal(t, "Issue Triage", ir.Name, "unexpected name")
+	assert.Equal(t, &WorkflowIREngine{ID: "copilot", Model: "gpt-5"}, ir.Engine, "engine should come from lock metadata")
+	assert.Equal(t, "v1.4.0", ir.CompilerVersion, "unexpected compiler version")
+	assert.Contains(t, ir.Triggers, "issues", "triggers should be kept")
+
+	require.Len(t, ir.Jobs, 3, "all jobs should be converted")
+	assert.Equal(t, []string{"activation", "agent", "conclusion"}, []string{ir.Jobs[0].ID, ir.Jobs[1].ID, ir.Jobs[2].ID}, "jobs should be sorted by ID")
+	assert.Equal(t, "github.event.issue.state == 'open'", ir.Jobs[0].If, "unexpected job condition")
+	assert.Equal(t, "bash", ir.Jobs[0].Steps[0].Shell, "unexpected shell")
+
+	agent := ir.Jobs[1]
+	assert.Equal(t, []string{"activation"}, agent.Needs, "a single needs entry should become a list")
+	assert.Equal(t, "ubuntu-latest", agent.RunsOn, "unexpected runner")
+	assert.Contains(t, agent.Outputs, "output", "outputs should be kept")
+	require.Len(t, agent.Steps, 2, "all steps should be converted")
+	assert.Equal(t, "actions/checkout@abc123", agent.Steps[0].Uses, "unexpected action")
+	assert.Equal(t, false, agent.Steps[0].With["persist-credentials"], "action inputs should be kept")
+	assert.Equal(t, "collect", agent.Steps[1].ID, "unexpected step id")
+	assert.Equal(t, "echo done", agent.Steps[1].Run, "unexpected script")
+	assert.Contains(t, agent.Steps[1].Env, "GH_AW_SAFE_OUTPUTS", "step env should be kept")
+
+	assert.Equal(t, []string{"activation", "agent"}, ir.Jobs[2].Needs, "needs lists should be kept")
+	assert.NotNil(t, ir.Jobs[2].Steps, "jobs without steps should have an empty list")
+}
+
+func TestWriteWorkflowIR(t *testing.T) {
+	dir := t.TempDir()
+	lockFile := filepath.Join(dir, "triage.lock.yml")
+	require.NoError(t, os.WriteFile(lockFile, []byte(testIRLockFile), 0o644), "write lock file")
+
+	irPath, err := writeWorkflowIR(lockFile)
+	require.NoError(t, err, "IR should be written")
+	assert.Equal(t, filepath.Join(dir, "triage.ir.json"), irPath, "IR should be written next to the lock file")
+
+	content, err := os.ReadFile(irPath)
+	require.NoError(t, err, "IR file should exist")
+	var ir WorkflowIR
+	require.NoError(t, json.Unmarshal(content, &ir), "IR file should be valid JSON")
+	assert.Equal(t, "triage.lock.yml", ir.LockFile, "unexpected lock file")
+	assert.Len(t, ir.Jobs, 3, "unexpected job count")
+}
+
+func TestValidateCompileConfigIR(t *testing.T) {
+	require.NoError(t, validateCompileConfig(CompileConfig{IR: CompileIRFormatJSON}), "--ir json should be accepted")
+
+	err := validateCompileConfig(CompileConfig{IR: "jsonnet"})
+	require.Error(t, err, "unknown IR format should be rejected")
+	assert.Contains(t, err.Error(), `unknown --ir "jsonnet"`, "unexpected error message")
+
+	err = validateCompileConfig(CompileConfig{IR: CompileIRFormatJSON, NoEmit: true})
+	require.Error(t, err, "--ir with --no-emit should be rejected")
+	assert.Contains(t, err.Error(), "--ir cannot be used with --no-emit", "unexpected error message")
+}
+
+func TestValidateCompileConfigCheck(t *testing.T) {
+	require.NoError(t, validateCompileConfig(CompileConfig{Check: true, Strict: true}), "--check should be accepted")
+
+	for _, tt := range []struct {
+		config CompileConfig
+		flag   string
+	}{
+		{CompileConfig{Check: true, Stdout: true}, "--stdout"},
+		{CompileConfig{Check: true, OutputDir: "out"}, "--output-dir"},
+		{CompileConfig{Check: true, Watch: true}, "--watch"},
+		{CompileConfig{Check: true, Purge: true}, "--purge"},
+		{CompileConfig{Check: true, IR: CompileIRFormatJSON}, "--ir"},
+	} {
+		err := validateCompileConfig(tt.config)
+		require.Error(t, err, "--check with %s should be rejected", tt.flag)
+		assert.Contains(t, err.Error(), "--check cannot be used with "+tt.flag, "unexpected error message")
+	}
+}
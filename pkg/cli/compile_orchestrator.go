@@ -41,6 +41,25 @@ func CompileWorkflows(ctx context.Context, config CompileConfig) ([]*workflow.Wo
 		return nil, err
 	}
 
+	switch config.Format {
+	case CompileFormatJSON:
+		config.JSONOutput = true
+	case CompileFormatGitHub:
+		// Compiler diagnostics are formatted deep inside pkg/workflow, so switch the
+		// console formatter for the duration of the run.
+		console.SetGitHubAnnotations(true)
+		defer console.SetGitHubAnnotations(false)
+	}
+
+	// Check mode compiles in memory and compares the result with the existing lock files
+	if config.Check {
+		config.NoEmit = true
+	}
+
+	// Offline mode is process-wide; reset it on every run so the MCP server's
+	// compile tool does not inherit it from a previous invocation.
+	workflow.SetOfflineMode(config.Offline)
+
 	// Validate action mode if specified
 	if err := validateActionModeConfig(config.ActionMode); err != nil {
 		return nil, err
@@ -110,11 +129,17 @@ func CompileWorkflows(ctx context.Context, config CompileConfig) ([]*workflow.Wo
 	}
 
 	// Compile specific files or all files in directory
+	var workflowDataList []*workflow.WorkflowData
+	var err error
 	if len(config.MarkdownFiles) > 0 {
 		// Compile specific workflow files
-		return compileSpecificFiles(ctx, compiler, config, stats, &validationResults)
+		workflowDataList, err = compileSpecificFiles(ctx, compiler, config, stats, &validationResults)
+	} else {
+		// Compile all workflow files in directory
+		workflowDataList, err = compileAllFilesInDirectory(ctx, compiler, config, workflowDir, stats, &validationResults)
 	}
 
-	// Compile all workflow files in directory
-	return compileAllFilesInDirectory(ctx, compiler, config, workflowDir, stats, &validationResults)
+	// Record feature usage of the compiled workflows when telemetry is enabled
+	recordCompilerUsage(compiler, workflowDataList)
+	return workflowDataList, err
 }
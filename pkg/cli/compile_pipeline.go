@@ -145,6 +145,9 @@ func compileSpecificFiles(
 			// Collect lock files for batch security tools
 			if !config.NoEmit && fileResult.lockFile != "" {
 				if _, err := os.Stat(fileResult.lockFile); err == nil {
+					if config.IR != "" {
+						writeWorkflowIRWithWarning(fileResult.lockFile, config.Verbose)
+					}
 					if config.Actionlint {
 						lockFilesForActionlint = append(lockFilesForActionlint, fileResult.lockFile)
 					}
@@ -286,6 +289,7 @@ func compileSpecificFiles(
 
 	// Get warning count from compiler
 	stats.Warnings = compiler.GetWarningCount()
+	stats.ChangeRisks = compiler.GetChangeRiskReports()
 
 	// Display schedule warnings
 	displayScheduleWarnings(compiler, config.JSONOutput)
@@ -293,6 +297,16 @@ func compileSpecificFiles(
 	// Display safe update warnings (emitted as prompts for the calling agent)
 	displaySafeUpdateWarnings(compiler, config.JSONOutput)
 
+	// Explain the network allow-list of each workflow (--explain-network)
+	if config.ExplainNetwork {
+		displayNetworkExplanations(workflowDataList)
+	}
+
+	// Accept the current security lint findings (--update-lint-baseline)
+	if err := updateSecurityLintBaseline(compiler, config); err != nil {
+		return workflowDataList, err
+	}
+
 	// Post-processing
 	if err := runPostProcessing(compiler, workflowDataList, config, compiledCount); err != nil {
 		return workflowDataList, err
@@ -432,6 +446,9 @@ func compileAllFilesInDirectory(
 			// Collect lock files for batch security tools
 			if !config.NoEmit && fileResult.lockFile != "" {
 				if _, err := os.Stat(fileResult.lockFile); err == nil {
+					if config.IR != "" {
+						writeWorkflowIRWithWarning(fileResult.lockFile, config.Verbose)
+					}
 					if config.Actionlint {
 						lockFilesForActionlint = append(lockFilesForActionlint, fileResult.lockFile)
 					}
@@ -572,6 +589,7 @@ func compileAllFilesInDirectory(
 
 	// Get warning count from compiler
 	stats.Warnings = compiler.GetWarningCount()
+	stats.ChangeRisks = compiler.GetChangeRiskReports()
 
 	// Display schedule warnings
 	displayScheduleWarnings(compiler, config.JSONOutput)
@@ -579,6 +597,16 @@ func compileAllFilesInDirectory(
 	// Display safe update warnings (emitted as prompts for the calling agent)
 	displaySafeUpdateWarnings(compiler, config.JSONOutput)
 
+	// Explain the network allow-list of each workflow (--explain-network)
+	if config.ExplainNetwork {
+		displayNetworkExplanations(workflowDataList)
+	}
+
+	// Accept the current security lint findings (--update-lint-baseline)
+	if err := updateSecurityLintBaseline(compiler, config); err != nil {
+		return workflowDataList, err
+	}
+
 	if config.Verbose {
 		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Successfully compiled %d out of %d workflow files", successCount, len(mdFiles))))
 	}
@@ -698,6 +726,9 @@ func runPostProcessing(
 	// Save action cache (errors are logged but non-fatal)
 	_ = saveActionCache(actionCache, config.Verbose)
 
+	// Save prompt version registry (errors are logged but non-fatal)
+	_ = savePromptRegistry(compiler.GetPromptRegistry(), config.Verbose)
+
 	return nil
 }
 
@@ -739,9 +770,10 @@ func runPostProcessingForDirectory(
 	}
 
 	// Generate maintenance workflow if needed.
-	// Skip maintenance workflow generation when using custom --dir option.
+	// Skip maintenance workflow generation when using custom --dir option or when the
+	// compiled workflows are redirected with --stdout or --output-dir.
 	// Keep invoking generators for empty workflowDataList so stale generated files are cleaned up.
-	if !config.NoEmit && config.WorkflowDir == "" {
+	if !config.NoEmit && config.WorkflowDir == "" && !config.Stdout && config.OutputDir == "" {
 		absWorkflowDir := getAbsoluteWorkflowDir(workflowsDir, gitRoot)
 		if err := generateMaintenanceWorkflowWrapper(ctx, compiler, workflowDataList, absWorkflowDir, gitRoot, config.Verbose, config.Strict); err != nil {
 			if config.Strict {
@@ -753,6 +785,11 @@ func runPostProcessingForDirectory(
 				return err
 			}
 		}
+		if err := generateWarmRunnersWorkflowWrapper(ctx, compiler, workflowDataList, absWorkflowDir, gitRoot, config.Strict); err != nil {
+			if config.Strict {
+				return err
+			}
+		}
 	}
 
 	// Prune stale gh-aw-actions entries before saving
@@ -766,6 +803,9 @@ func runPostProcessingForDirectory(
 	// Save action cache (errors are logged but non-fatal)
 	_ = saveActionCache(actionCache, config.Verbose)
 
+	// Save prompt version registry (errors are logged but non-fatal)
+	_ = savePromptRegistry(compiler.GetPromptRegistry(), config.Verbose)
+
 	return nil
 }
 
@@ -792,6 +832,8 @@ func outputResults(
 			return err
 		}
 		fmt.Fprintln(os.Stdout, jsonStr)
+	} else if config.Format == CompileFormatGitHub {
+		printGitHubAnnotationSummary(stats)
 	} else if !config.Stats {
 		// Print summary for text output (skip if stats mode)
 		printCompilationSummary(stats, config.ShowAllErrors)
@@ -47,6 +47,7 @@ permissions:
   contents: read
   issues: read
   pull-requests: read
+timeout-minutes: 10
 strict: false
 ---
 
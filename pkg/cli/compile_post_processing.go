@@ -19,6 +19,7 @@
 // Generation:
 //   - generateDependabotManifestsWrapper() - Generate Dependabot manifests
 //   - generateMaintenanceWorkflowWrapper() - Generate maintenance workflow
+//   - generateWarmRunnersWorkflowWrapper() - Generate warm runners workflow
 //
 // Cleanup:
 //   - purgeOrphanedLockFiles() - Remove orphaned .lock.yml files
@@ -142,6 +143,45 @@ func generateCentralSlashCommandWorkflowWrapper(
 	return nil
 }
 
+// generateWarmRunnersWorkflowWrapper generates the warm runners workflow when warm_runners
+// is set in aw.json, and removes it otherwise.
+func generateWarmRunnersWorkflowWrapper(
+	ctx context.Context,
+	compiler *workflow.Compiler,
+	workflowDataList []*workflow.WorkflowData,
+	workflowsDir string,
+	gitRoot string,
+	strict bool,
+) error {
+	compilePostProcessingLog.Print("Generating warm runners workflow")
+
+	repoConfig, err := workflow.LoadRepoConfig(gitRoot)
+	if err != nil {
+		if strict {
+			return fmt.Errorf("failed to load repo config: %w", err)
+		}
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Failed to load repo config: %v", err)))
+		return nil
+	}
+
+	if err := workflow.GenerateWarmRunnersWorkflow(ctx, workflow.GenerateWarmRunnersWorkflowOptions{
+		WorkflowDataList: workflowDataList,
+		WorkflowDir:      workflowsDir,
+		Version:          compiler.GetVersion(),
+		ActionMode:       compiler.GetActionMode(),
+		ActionTag:        compiler.GetActionTag(),
+		RepoConfig:       repoConfig,
+		RepoSlug:         compiler.GetRepositorySlug(),
+	}); err != nil {
+		if strict {
+			return fmt.Errorf("failed to generate warm runners workflow: %w", err)
+		}
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Failed to generate warm runners workflow: %v", err)))
+	}
+
+	return nil
+}
+
 // purgeOrphanedLockFiles removes orphaned .lock.yml files
 // These are lock files that exist but don't have a corresponding .md file
 func purgeOrphanedLockFiles(workflowsDir string, expectedLockFiles []string, verbose bool) error {
@@ -177,6 +217,10 @@ func purgeOrphanedLockFiles(workflowsDir string, expectedLockFiles []string, ver
 		if strings.HasSuffix(existing, ".campaign.lock.yml") {
 			continue
 		}
+		// Keep the companion lock files of agentic jobs while their workflow is compiled
+		if parent, ok := agenticJobParentLockFile(existing); ok && setutil.Contains(expectedLockFileSet, parent) {
+			continue
+		}
 		if !setutil.Contains(expectedLockFileSet, existing) {
 			orphanedFiles = append(orphanedFiles, existing)
 		}
@@ -200,6 +244,20 @@ func purgeOrphanedLockFiles(workflowsDir string, expectedLockFiles []string, ver
 	return nil
 }
 
+// agenticJobParentLockFile returns the lock file of the workflow that defines an agentic
+// job, given the job's companion lock file (<workflow>.<job>.job.lock.yml).
+func agenticJobParentLockFile(lockFile string) (string, bool) {
+	base, ok := strings.CutSuffix(lockFile, ".job.lock.yml")
+	if !ok {
+		return "", false
+	}
+	dot := strings.LastIndex(base, ".")
+	if dot <= len(filepath.Dir(base)) {
+		return "", false
+	}
+	return base[:dot] + ".lock.yml", true
+}
+
 // purgeInvalidFiles removes all .invalid.yml files
 // These are temporary debugging artifacts that should not persist
 func purgeInvalidFiles(workflowsDir string, verbose bool) error {
@@ -261,6 +319,22 @@ func displaySafeUpdateWarnings(compiler *workflow.Compiler, jsonOutput bool) {
 	}
 }
 
+// updateSecurityLintBaseline writes the security lint findings of the compiled workflows
+// to the baseline file when --update-lint-baseline is set.
+func updateSecurityLintBaseline(compiler *workflow.Compiler, config CompileConfig) error {
+	if !config.UpdateLintBaseline {
+		return nil
+	}
+	count, err := compiler.WriteSecurityLintBaseline()
+	if err != nil {
+		return err
+	}
+	if !config.JSONOutput {
+		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Wrote %d security lint exception(s) to %s", count, workflow.SecurityLintBaselineFileName)))
+	}
+	return nil
+}
+
 // displayCentralizedSlashCommandRecommendation warns when a repository has many
 // slash commands still using non-centralized strategy.
 func displayCentralizedSlashCommandRecommendation(compiler *workflow.Compiler, workflowDataList []*workflow.WorkflowData, jsonOutput bool) {
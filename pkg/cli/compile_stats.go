@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/logger"
@@ -28,8 +29,9 @@ type CompilationStats struct {
 	Total           int
 	Errors          int
 	Warnings        int
-	FailedWorkflows []string          // Names of workflows that failed compilation (deprecated, use FailedWorkflowDetails)
-	FailureDetails  []WorkflowFailure // Detailed information about failed workflows
+	FailedWorkflows []string                     // Names of workflows that failed compilation (deprecated, use FailedWorkflowDetails)
+	FailureDetails  []WorkflowFailure            // Detailed information about failed workflows
+	ChangeRisks     []*workflow.ChangeRiskReport // Risk scores of workflows changed since the last commit
 }
 
 // WorkflowStats holds statistics about a compiled workflow
@@ -220,6 +222,56 @@ func printCompilationSummary(stats *CompilationStats, showAllErrors bool) {
 	} else {
 		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(summary))
 	}
+
+	printChangeRiskSummary(stats.ChangeRisks)
+}
+
+// printChangeRiskSummary lists the risk findings of workflows changed since the last commit
+// so reviewers can see at a glance which prompt or tool changes deserve a closer look.
+func printChangeRiskSummary(reports []*workflow.ChangeRiskReport) {
+	if len(reports) == 0 {
+		return
+	}
+
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Change risk (compared to the last commit):"))
+	for _, report := range reports {
+		line := fmt.Sprintf("%s: %s risk (score %d)", report.Workflow, report.Level, report.Score)
+		switch report.Level {
+		case workflow.ChangeRiskHigh:
+			fmt.Fprintln(os.Stderr, console.FormatErrorMessage(line))
+		case workflow.ChangeRiskMedium:
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(line))
+		default:
+			fmt.Fprintln(os.Stderr, console.FormatInfoMessage(line))
+		}
+		for _, finding := range report.Findings {
+			fmt.Fprintln(os.Stderr, console.FormatListItem(fmt.Sprintf("[%s] %s (+%d)", finding.Category, finding.Message, finding.Points)))
+		}
+	}
+}
+
+// printGitHubAnnotationSummary prints every compilation error as a GitHub Actions workflow
+// command followed by a one-line summary. Errors that were not formatted by the compiler
+// (for example, file read errors) are annotated against the workflow file.
+func printGitHubAnnotationSummary(stats *CompilationStats) {
+	if stats.Total == 0 {
+		return
+	}
+	for _, failure := range stats.FailureDetails {
+		for _, message := range failure.ErrorMessages {
+			if strings.HasPrefix(message, "::") {
+				fmt.Fprintln(os.Stderr, strings.TrimRight(message, "\n"))
+				continue
+			}
+			fmt.Fprint(os.Stderr, console.FormatGitHubAnnotation(console.CompilerError{
+				Position: console.ErrorPosition{File: failure.Path},
+				Type:     "error",
+				Message:  message,
+			}))
+		}
+	}
+	fmt.Fprintf(os.Stderr, "Compiled %d workflow(s): %d error(s), %d warning(s)\n", stats.Total, stats.Errors, stats.Warnings)
 }
 
 // collectWorkflowStatisticsWrapper collects and returns workflow statistics
@@ -8,6 +8,8 @@ import (
 	"regexp"
 	"strings"
 	"testing"
+
+	"github.com/github/gh-aw/pkg/workflow"
 )
 
 // TestPrintCompilationSummaryWithFailedWorkflows tests that printCompilationSummary
@@ -202,3 +204,48 @@ func assertHeadingContainsMessage(t *testing.T, output string, heading string, m
 		t.Fatalf("Expected heading %q to contain message %q.\nFull output:\n%s", heading, message, output)
 	}
 }
+
+// TestPrintCompilationSummaryWithChangeRisk tests that change risk findings are listed
+// after the summary line so reviewers see them next to the compile result
+func TestPrintCompilationSummaryWithChangeRisk(t *testing.T) {
+	stats := &CompilationStats{
+		Total: 2,
+		ChangeRisks: []*workflow.ChangeRiskReport{
+			{
+				Workflow: "triage",
+				Score:    10,
+				Level:    workflow.ChangeRiskHigh,
+				Findings: []workflow.ChangeRiskFinding{
+					{Category: workflow.ChangeRiskCategoryWrite, Message: "new safe output 'create-pull-request'", Points: 5},
+					{Category: workflow.ChangeRiskCategoryGuardrail, Message: "strict mode was disabled", Points: 5},
+				},
+			},
+		},
+	}
+
+	oldStderr := os.Stderr
+	r, w, _ := os.Pipe()
+	os.Stderr = w
+	printCompilationSummary(stats, false)
+	w.Close()
+	os.Stderr = oldStderr
+
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	output := buf.String()
+
+	for _, expected := range []string{
+		"Compiled 2 workflow(s): 0 error(s), 0 warning(s)",
+		"Change risk (compared to the last commit):",
+		"triage: high risk (score 10)",
+		"[write-capability] new safe output 'create-pull-request' (+5)",
+		"[guardrail] strict mode was disabled (+5)",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected output to contain %q, but it didn't.\nFull output:\n%s", expected, output)
+		}
+	}
+	if strings.Index(output, "Compiled 2 workflow(s)") > strings.Index(output, "Change risk") {
+		t.Errorf("Expected change risk to follow the summary line.\nFull output:\n%s", output)
+	}
+}
@@ -61,10 +61,10 @@ func CompileWorkflowWithValidation(ctx context.Context, compiler *workflow.Compi
 	}
 
 	// Always validate that the generated lock file is valid YAML (CLI requirement)
-	lockFile := stringutil.MarkdownToLockFile(filePath)
+	lockFile := compiler.OutputLockFile(filePath)
 	if _, err := os.Stat(lockFile); err != nil {
-		compileValidationLog.Print("Lock file not found, skipping validation (likely no-emit mode)")
-		// Lock file doesn't exist (likely due to no-emit), skip YAML validation
+		compileValidationLog.Print("Lock file not found, skipping validation (likely no-emit or stdout mode)")
+		// Lock file doesn't exist (likely due to no-emit or --stdout), skip YAML validation
 		return nil
 	}
 
@@ -131,10 +131,10 @@ func CompileWorkflowDataWithValidation(ctx context.Context, compiler *workflow.C
 	}
 
 	// Always validate that the generated lock file is valid YAML (CLI requirement)
-	lockFile := stringutil.MarkdownToLockFile(filePath)
+	lockFile := compiler.OutputLockFile(filePath)
 	if _, err := os.Stat(lockFile); err != nil {
-		compileValidationLog.Print("Lock file not found, skipping validation (likely no-emit mode)")
-		// Lock file doesn't exist (likely due to no-emit), skip YAML validation
+		compileValidationLog.Print("Lock file not found, skipping validation (likely no-emit or stdout mode)")
+		// Lock file doesn't exist (likely due to no-emit or --stdout), skip YAML validation
 		return nil
 	}
 
@@ -204,6 +204,96 @@ func validateCompileConfig(config CompileConfig) error {
 		}
 	}
 
+	// Validate output format
+	switch config.Format {
+	case "", CompileFormatText, CompileFormatJSON:
+	case CompileFormatGitHub:
+		if config.JSONOutput {
+			return errors.New("--format github cannot be used with --json")
+		}
+	default:
+		compileValidationLog.Printf("Config validation failed: unknown format: %s", config.Format)
+		return fmt.Errorf("unknown --format %q: expected text, json, or github", config.Format)
+	}
+
+	// Validate intermediate representation output
+	switch config.IR {
+	case "":
+	case CompileIRFormatJSON:
+		if config.NoEmit {
+			return errors.New("--ir cannot be used with --no-emit")
+		}
+	default:
+		compileValidationLog.Printf("Config validation failed: unknown IR format: %s", config.IR)
+		return fmt.Errorf("unknown --ir %q: expected json", config.IR)
+	}
+
+	// Validate output redirection: the compiled workflows do not land next to their source,
+	// so options that read, purge, or add to the lock files in the workflow directory are rejected
+	if config.Stdout || config.OutputDir != "" {
+		flag := "--output-dir"
+		if config.Stdout {
+			flag = "--stdout"
+		}
+		// --stdout leaves no lock file on disk for the JSON output and scanners to use
+		conflicts := []struct {
+			flag string
+			set  bool
+		}{
+			{"--output-dir", config.Stdout && config.OutputDir != ""},
+			{"--no-emit", config.NoEmit},
+			{"--watch", config.Watch},
+			{"--purge", config.Purge},
+			{"--dependabot", config.Dependabot},
+			{"--stats", config.Stats},
+			{"--json", config.Stdout && (config.JSONOutput || config.Format == CompileFormatJSON)},
+			{"--ir", config.Stdout && config.IR != ""},
+			{"--actionlint", config.Stdout && config.Actionlint},
+			{"--zizmor", config.Stdout && config.Zizmor},
+			{"--poutine", config.Stdout && config.Poutine},
+			{"--runner-guard", config.Stdout && config.RunnerGuard},
+			{"--syft", config.Stdout && config.Syft},
+			{"--grype", config.Stdout && config.Grype},
+			{"--grant", config.Stdout && config.Grant},
+			{"--yamllint", config.Stdout && config.Yamllint},
+		}
+		for _, conflict := range conflicts {
+			if conflict.set {
+				compileValidationLog.Printf("Config validation failed: %s with %s", flag, conflict.flag)
+				return fmt.Errorf("%s cannot be used with %s", flag, conflict.flag)
+			}
+		}
+	}
+
+	// Validate check mode: it compares the lock files next to their source and writes nothing
+	if config.Check {
+		conflicts := []struct {
+			flag string
+			set  bool
+		}{
+			{"--stdout", config.Stdout},
+			{"--output-dir", config.OutputDir != ""},
+			{"--watch", config.Watch},
+			{"--purge", config.Purge},
+			{"--dependabot", config.Dependabot},
+			{"--ir", config.IR != ""},
+			{"--update-lint-baseline", config.UpdateLintBaseline},
+			{"--update-mcp", config.UpdateMCP},
+		}
+		for _, conflict := range conflicts {
+			if conflict.set {
+				compileValidationLog.Printf("Config validation failed: --check with %s", conflict.flag)
+				return fmt.Errorf("--check cannot be used with %s", conflict.flag)
+			}
+		}
+	}
+
+	// Validate lint baseline flag usage
+	if config.UpdateLintBaseline && !config.Strict {
+		compileValidationLog.Print("Config validation failed: update-lint-baseline without strict")
+		return errors.New("--update-lint-baseline requires --strict")
+	}
+
 	// Validate purge flag usage
 	if config.Purge && len(config.MarkdownFiles) > 0 {
 		compileValidationLog.Print("Config validation failed: purge flag with specific files")
@@ -216,6 +306,24 @@ func validateCompileConfig(config CompileConfig) error {
 		return fmt.Errorf("--dir must be a relative path, got: %s", config.WorkflowDir)
 	}
 
+	// Validate offline flag usage: these options always need the network
+	if config.Offline {
+		for _, conflict := range []struct {
+			flag string
+			set  bool
+		}{
+			{"--force-refresh-action-pins", config.ForceRefreshActionPins},
+			{"--update-mcp", config.UpdateMCP},
+			{"--validate", config.Validate},
+			{"--validate-images", config.ValidateImages},
+		} {
+			if conflict.set {
+				compileValidationLog.Printf("Config validation failed: offline with %s", conflict.flag)
+				return fmt.Errorf("--offline cannot be used with %s, which requires network access", conflict.flag)
+			}
+		}
+	}
+
 	compileValidationLog.Print("Config validation successful")
 	return nil
 }
@@ -257,12 +365,14 @@ func sanitizeValidationResults(results []ValidationResult) []ValidationResult {
 
 	return sliceutil.Map(results, func(result ValidationResult) ValidationResult {
 		return ValidationResult{
-			Workflow:     result.Workflow,
-			Valid:        result.Valid,
-			CompiledFile: result.CompiledFile,
-			Errors:       sliceutil.Map(result.Errors, sanitizeError),
-			Warnings:     sliceutil.Map(result.Warnings, sanitizeError),
-			Labels:       result.Labels,
+			Workflow:      result.Workflow,
+			Valid:         result.Valid,
+			CompiledFile:  result.CompiledFile,
+			Errors:        sliceutil.Map(result.Errors, sanitizeError),
+			Warnings:      sliceutil.Map(result.Warnings, sanitizeError),
+			Labels:        result.Labels,
+			ChangeRisk:    result.ChangeRisk,
+			SecurityLints: result.SecurityLints,
 		}
 	})
 }
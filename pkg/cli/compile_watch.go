@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -66,11 +67,25 @@ func watchAndCompileWorkflows(ctx context.Context, markdownFile string, compiler
 
 	// addWatchPath adds a path to the watcher with platform-specific configuration.
 	// On Windows, uses a larger buffer (64KB) to prevent event overflow in busy directories.
+	// Paths that are already watched are skipped.
+	var watchedMu sync.Mutex
+	watchedDirs := make(map[string]struct{})
 	addWatchPath := func(path string) error {
+		watchedMu.Lock()
+		defer watchedMu.Unlock()
+		if _, ok := watchedDirs[path]; ok {
+			return nil
+		}
+		var err error
 		if runtime.GOOS == "windows" {
-			return watcher.AddWith(path, fsnotify.WithBufferSize(64*1024))
+			err = watcher.AddWith(path, fsnotify.WithBufferSize(64*1024))
+		} else {
+			err = watcher.Add(path)
+		}
+		if err == nil {
+			watchedDirs[path] = struct{}{}
 		}
-		return watcher.Add(path)
+		return err
 	}
 
 	// Add the workflows directory to the watcher
@@ -79,23 +94,40 @@ func watchAndCompileWorkflows(ctx context.Context, markdownFile string, compiler
 	}
 
 	// Also watch subdirectories for include files (recursive watching)
-	err = filepath.Walk(workflowsDir, func(path string, info os.FileInfo, err error) error {
+	watchSubdirectories := func(root string) {
+		err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return nil // Skip errors but continue walking
+			}
+			if info.IsDir() && path != workflowsDir {
+				// Add subdirectories to the watcher
+				if err := addWatchPath(path); err != nil {
+					compileWatchLog.Printf("Failed to watch subdirectory %s: %v", path, err)
+				} else {
+					compileWatchLog.Printf("Watching subdirectory: %s", path)
+				}
+			}
+			return nil
+		})
 		if err != nil {
-			return nil // Skip errors but continue walking
+			compileWatchLog.Printf("Failed to walk subdirectories: %v", err)
 		}
-		if info.IsDir() && path != workflowsDir {
-			// Add subdirectories to the watcher
-			if err := addWatchPath(path); err != nil {
-				compileWatchLog.Printf("Failed to watch subdirectory %s: %v", path, err)
+	}
+	watchSubdirectories(workflowsDir)
+
+	// Watch the directories of imported files that live outside the workflows
+	// directory (e.g. .github/agents). Called again after each recompile because
+	// edits can add new imports.
+	watchExternalImports := func() {
+		for _, dir := range depGraph.ExternalImportDirs() {
+			if err := addWatchPath(dir); err != nil {
+				compileWatchLog.Printf("Failed to watch import directory %s: %v", dir, err)
 			} else {
-				compileWatchLog.Printf("Watching subdirectory: %s", path)
+				compileWatchLog.Printf("Watching import directory: %s", dir)
 			}
 		}
-		return nil
-	})
-	if err != nil {
-		compileWatchLog.Printf("Failed to walk subdirectories: %v", err)
 	}
+	watchExternalImports()
 
 	// Always emit the begin pattern for task integration
 	if markdownFile != "" {
@@ -114,6 +146,9 @@ func watchAndCompileWorkflows(ctx context.Context, markdownFile string, compiler
 	var debounceMu sync.Mutex
 	modifiedFiles := make(map[string]struct{})
 
+	// Track failing workflows across recompiles for incremental error reporting
+	errorTracker := newWatchErrorTracker()
+
 	// Compile initially if no specific file provided
 	if markdownFile == "" {
 		fmt.Fprintln(os.Stderr, "Watching for file changes")
@@ -127,6 +162,7 @@ func watchAndCompileWorkflows(ctx context.Context, markdownFile string, compiler
 		}
 		// Print summary instead of just "Recompiled"
 		printCompilationSummary(stats, false)
+		errorTracker.record(stats.FailedWorkflows, stats.FailedWorkflows)
 	} else {
 		// Reset warning count before compilation
 		compiler.ResetWarningCount()
@@ -147,6 +183,44 @@ func watchAndCompileWorkflows(ctx context.Context, markdownFile string, compiler
 
 		// Print summary instead of just "Recompiled"
 		printCompilationSummary(stats, false)
+		errorTracker.record(stats.FailedWorkflows, stats.FailedWorkflows)
+	}
+
+	// scheduleCompile adds a file to the debounced compilation batch
+	scheduleCompile := func(path string) {
+		debounceMu.Lock()
+		defer debounceMu.Unlock()
+		modifiedFiles[path] = struct{}{}
+
+		// Reset debounce timer
+		if debounceTimer != nil {
+			debounceTimer.Stop()
+		}
+		debounceTimer = time.AfterFunc(debounceDelay, func() {
+			filesToCompile := func() []string {
+				debounceMu.Lock()
+				defer debounceMu.Unlock()
+				files := make([]string, 0, len(modifiedFiles))
+				for file := range modifiedFiles {
+					files = append(files, file)
+				}
+				// Clear the modifiedFiles map
+				modifiedFiles = make(map[string]struct{})
+				return files
+			}()
+
+			// Compile the modified files using dependency graph
+			stats, compiled := compileModifiedFilesWithDependencies(ctx, compiler, depGraph, filesToCompile, verbose)
+			if stats == nil {
+				return
+			}
+			compiledNames := make([]string, 0, len(compiled))
+			for _, file := range compiled {
+				compiledNames = append(compiledNames, filepath.Base(file))
+			}
+			printWatchErrorDelta(errorTracker.record(compiledNames, stats.FailedWorkflows), time.Now())
+			watchExternalImports()
+		})
 	}
 
 	// Main watch loop
@@ -162,14 +236,32 @@ func watchAndCompileWorkflows(ctx context.Context, markdownFile string, compiler
 				continue
 			}
 
-			// Only process markdown files and ignore lock files
-			if !strings.HasSuffix(event.Name, ".md") {
+			// Start watching directories created inside the workflows directory
+			if event.Has(fsnotify.Create) && isWithinDir(event.Name, workflowsDir) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					watchSubdirectories(event.Name)
+					if err := addWatchPath(event.Name); err != nil {
+						compileWatchLog.Printf("Failed to watch new directory %s: %v", event.Name, err)
+					}
+					continue
+				}
+			}
+
+			// Only process markdown files in the workflows directory and imported
+			// files (which may live elsewhere); lock files are ignored
+			isWorkflowMarkdown := strings.HasSuffix(event.Name, ".md") && isWithinDir(event.Name, workflowsDir)
+			if !isWorkflowMarkdown && !depGraph.IsImported(event.Name) {
 				continue
 			}
 
-			// If watching a specific file, only process that file
+			// If watching a specific file, only process that file and its imports;
+			// an import change recompiles the watched file
+			target := event.Name
 			if markdownFile != "" && event.Name != markdownFile {
-				continue
+				if !slices.Contains(depGraph.GetAffectedWorkflows(event.Name), markdownFile) {
+					continue
+				}
+				target = markdownFile
 			}
 
 			compileWatchLog.Printf("Detected change: %s (%s)", event.Name, event.Op.String())
@@ -179,39 +271,21 @@ func watchAndCompileWorkflows(ctx context.Context, markdownFile string, compiler
 
 			// Handle file operations
 			switch {
-			case event.Has(fsnotify.Remove):
+			case event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename):
+				if target != event.Name || depGraph.IsImported(event.Name) {
+					// A removed import breaks the workflows that use it: recompile
+					// them so the missing import is reported right away
+					scheduleCompile(target)
+					continue
+				}
 				// Handle file deletion
 				handleFileDeleted(event.Name, verbose)
-				// Remove from dependency graph
+				// Remove from dependency graph and error tracking
 				depGraph.RemoveWorkflow(event.Name)
+				errorTracker.forget(filepath.Base(event.Name))
 			case event.Has(fsnotify.Write) || event.Has(fsnotify.Create):
 				// Handle file modification or creation - add to debounced compilation
-				func() {
-					debounceMu.Lock()
-					defer debounceMu.Unlock()
-					modifiedFiles[event.Name] = struct{}{}
-
-					// Reset debounce timer
-					if debounceTimer != nil {
-						debounceTimer.Stop()
-					}
-					debounceTimer = time.AfterFunc(debounceDelay, func() {
-						filesToCompile := func() []string {
-							debounceMu.Lock()
-							defer debounceMu.Unlock()
-							files := make([]string, 0, len(modifiedFiles))
-							for file := range modifiedFiles {
-								files = append(files, file)
-							}
-							// Clear the modifiedFiles map
-							modifiedFiles = make(map[string]struct{})
-							return files
-						}()
-
-						// Compile the modified files using dependency graph
-						compileModifiedFilesWithDependencies(ctx, compiler, depGraph, filesToCompile, verbose)
-					})
-				}()
+				scheduleCompile(target)
 			}
 
 		case err, ok := <-watcher.Errors:
@@ -234,3 +308,9 @@ func watchAndCompileWorkflows(ctx context.Context, markdownFile string, compiler
 		}
 	}
 }
+
+// isWithinDir reports whether path is dir itself or lies inside it.
+func isWithinDir(path, dir string) bool {
+	relPath, err := filepath.Rel(dir, path)
+	return err == nil && relPath != ".." && !strings.HasPrefix(relPath, ".."+string(filepath.Separator))
+}
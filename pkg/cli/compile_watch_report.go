@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/sliceutil"
+)
+
+// watchErrorDelta describes how the set of failing workflows changed after a recompile.
+type watchErrorDelta struct {
+	Fixed        []string // workflows that failed before and compile now
+	NewlyFailing []string // workflows that compiled before and fail now
+	StillFailing []string // recompiled workflows that keep failing
+	OtherFailing []string // failing workflows that were not part of this recompile
+}
+
+// watchErrorTracker remembers which workflows fail to compile across watch mode
+// recompiles, so each recompile can report what it fixed and what it broke
+// instead of only the errors of the files it just compiled.
+type watchErrorTracker struct {
+	mu      sync.Mutex
+	failing map[string]struct{} // workflow file names (base names) that currently fail
+}
+
+func newWatchErrorTracker() *watchErrorTracker {
+	return &watchErrorTracker{failing: make(map[string]struct{})}
+}
+
+// record updates the tracker with the result of compiling the given workflows
+// (base names) and returns the change relative to the previous state.
+func (t *watchErrorTracker) record(compiled []string, failed []string) watchErrorDelta {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var delta watchErrorDelta
+	for _, name := range sliceutil.Deduplicate(compiled) {
+		_, wasFailing := t.failing[name]
+		isFailing := slices.Contains(failed, name)
+		switch {
+		case wasFailing && !isFailing:
+			delta.Fixed = append(delta.Fixed, name)
+			delete(t.failing, name)
+		case !wasFailing && isFailing:
+			delta.NewlyFailing = append(delta.NewlyFailing, name)
+			t.failing[name] = struct{}{}
+		case isFailing:
+			delta.StillFailing = append(delta.StillFailing, name)
+		}
+	}
+	for _, name := range sliceutil.SortedKeys(t.failing) {
+		if !slices.Contains(compiled, name) {
+			delta.OtherFailing = append(delta.OtherFailing, name)
+		}
+	}
+	slices.Sort(delta.Fixed)
+	slices.Sort(delta.NewlyFailing)
+	slices.Sort(delta.StillFailing)
+	return delta
+}
+
+// forget drops a workflow from the tracker, for example when its file is deleted.
+func (t *watchErrorTracker) forget(name string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.failing, name)
+}
+
+// printWatchErrorDelta prints the incremental error report after a watch mode recompile.
+func printWatchErrorDelta(delta watchErrorDelta, now time.Time) {
+	stamp := now.Format("15:04:05")
+	if len(delta.Fixed) > 0 {
+		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("[%s] Fixed: %s", stamp, strings.Join(delta.Fixed, ", "))))
+	}
+	if len(delta.NewlyFailing) > 0 {
+		fmt.Fprintln(os.Stderr, console.FormatErrorMessage(fmt.Sprintf("[%s] New errors: %s", stamp, strings.Join(delta.NewlyFailing, ", "))))
+	}
+	if len(delta.StillFailing) > 0 {
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("[%s] Still failing: %s", stamp, strings.Join(delta.StillFailing, ", "))))
+	}
+	if len(delta.OtherFailing) > 0 {
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("[%s] %d other workflow(s) still have errors: %s", stamp, len(delta.OtherFailing), strings.Join(delta.OtherFailing, ", "))))
+	}
+	if len(delta.NewlyFailing) == 0 && len(delta.StillFailing) == 0 && len(delta.OtherFailing) == 0 {
+		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("[%s] All watched workflows compile", stamp)))
+	}
+}
@@ -0,0 +1,47 @@
+//go:build !integration
+
+package cli
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatchErrorTracker_Record(t *testing.T) {
+	tracker := newWatchErrorTracker()
+
+	delta := tracker.record([]string{"a.md", "b.md", "c.md"}, []string{"b.md", "c.md"})
+	assert.Equal(t, []string{"b.md", "c.md"}, delta.NewlyFailing, "initial failures should be reported as new")
+	assert.Empty(t, delta.Fixed, "nothing can be fixed on the first compile")
+
+	delta = tracker.record([]string{"b.md"}, nil)
+	assert.Equal(t, []string{"b.md"}, delta.Fixed, "b.md compiles now")
+	assert.Empty(t, delta.NewlyFailing, "no new failures expected")
+	assert.Equal(t, []string{"c.md"}, delta.OtherFailing, "c.md was not recompiled and still fails")
+
+	delta = tracker.record([]string{"a.md", "c.md"}, []string{"a.md", "c.md"})
+	assert.Equal(t, []string{"a.md"}, delta.NewlyFailing, "a.md broke in this recompile")
+	assert.Equal(t, []string{"c.md"}, delta.StillFailing, "c.md keeps failing")
+	assert.Empty(t, delta.OtherFailing, "every failing workflow was recompiled")
+}
+
+func TestWatchErrorTracker_Forget(t *testing.T) {
+	tracker := newWatchErrorTracker()
+	tracker.record([]string{"a.md", "b.md"}, []string{"a.md", "b.md"})
+
+	tracker.forget("a.md")
+
+	delta := tracker.record([]string{"c.md"}, nil)
+	assert.Equal(t, []string{"b.md"}, delta.OtherFailing, "deleted workflows should no longer be reported")
+}
+
+func TestIsWithinDir(t *testing.T) {
+	dir := filepath.Join("repo", ".github", "workflows")
+
+	assert.True(t, isWithinDir(dir, dir), "a directory is within itself")
+	assert.True(t, isWithinDir(filepath.Join(dir, "shared", "tools.md"), dir), "nested file should be within dir")
+	assert.False(t, isWithinDir(filepath.Join("repo", ".github", "agents", "a.md"), dir), "sibling directory should not be within dir")
+	assert.False(t, isWithinDir(filepath.Join("repo", ".github", "workflows..md"), dir), "prefix match is not containment")
+}
@@ -27,11 +27,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime/debug"
 
 	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/logger"
 	"github.com/github/gh-aw/pkg/setutil"
-	"github.com/github/gh-aw/pkg/stringutil"
 	"github.com/github/gh-aw/pkg/workflow"
 )
 
@@ -73,10 +73,25 @@ func compileWorkflowFile(
 	compiler *workflow.Compiler,
 	resolvedFile string,
 	opts compileWorkflowFileOptions,
-) compileWorkflowFileResult {
+) (result compileWorkflowFileResult) {
 	compileWorkflowProcessorLog.Printf("Processing workflow file: %s", resolvedFile)
 
-	result := compileWorkflowFileResult{
+	// A panic while compiling one workflow must not abort the whole batch: report it as
+	// an error for this file so the remaining workflows still compile and write lock files.
+	defer func() {
+		if r := recover(); r != nil {
+			compileWorkflowProcessorLog.Errorf("Recovered from panic while compiling %s: %v\n%s", resolvedFile, r, debug.Stack())
+			result.success = false
+			result.validationResult.Valid = false
+			result.validationResult.CompiledFile = ""
+			result.validationResult.Errors = append(result.validationResult.Errors, CompileValidationError{
+				Type:    "internal_error",
+				Message: fmt.Sprintf("%s: internal compiler error: %v (re-run with GH_AW_LOG=cli:compile_workflow_processor=error for the stack trace)", resolvedFile, r),
+			})
+		}
+	}()
+
+	result = compileWorkflowFileResult{
 		validationResult: ValidationResult{
 			Workflow: filepath.Base(resolvedFile),
 			Valid:    true,
@@ -86,8 +101,8 @@ func compileWorkflowFile(
 		success: false,
 	}
 
-	// Generate lock file name
-	lockFile := stringutil.MarkdownToLockFile(resolvedFile)
+	// Generate lock file name (empty when compiling to stdout)
+	lockFile := compiler.OutputLockFile(resolvedFile)
 	result.lockFile = lockFile
 
 	// Parse workflow file to get data
@@ -174,6 +189,7 @@ func compileWorkflowFile(
 		// The error is stored in ValidationResult for JSON output and summary display
 		result.validationResult.Valid = false
 		result.validationResult.Errors = appendValidationErrors(result.validationResult.Errors, "compilation_error", err)
+		result.validationResult.SecurityLints = compiler.GetSecurityLints(resolvedFile)
 		return result
 	}
 
@@ -182,6 +198,9 @@ func compileWorkflowFile(
 		result.validationResult.CompiledFile = lockFile
 	}
 
+	result.validationResult.ChangeRisk = compiler.GetChangeRiskReport(resolvedFile)
+	result.validationResult.SecurityLints = compiler.GetSecurityLints(resolvedFile)
+
 	// Collect labels for JSON output (used by create-labels maintenance operation)
 	result.validationResult.Labels = extractSafeOutputLabels(workflowData)
 
@@ -3,8 +3,12 @@
 package cli
 
 import (
+	"context"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	"github.com/github/gh-aw/pkg/workflow"
 )
 
@@ -52,3 +56,14 @@ func TestExtractSafeOutputLabels_DeduplicatesAcrossSources(t *testing.T) {
 		t.Fatalf("expected deduplicated labels [deploy], got %v", got)
 	}
 }
+
+func TestCompileWorkflowFile_RecoversFromPanic(t *testing.T) {
+	// A nil compiler panics on first use, standing in for an internal compiler bug.
+	result := compileWorkflowFile(context.Background(), nil, "broken.md", compileWorkflowFileOptions{})
+
+	assert.False(t, result.success, "panicking workflow should fail")
+	assert.False(t, result.validationResult.Valid, "panicking workflow should be invalid")
+	require.Len(t, result.validationResult.Errors, 1, "panic should be reported as one error")
+	assert.Equal(t, "internal_error", result.validationResult.Errors[0].Type, "panic should be an internal error")
+	assert.Contains(t, result.validationResult.Errors[0].Message, "broken.md: internal compiler error", "error should name the file")
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/github/gh-aw/pkg/logger"
 	"github.com/github/gh-aw/pkg/parser"
 	"github.com/github/gh-aw/pkg/setutil"
+	"github.com/github/gh-aw/pkg/sliceutil"
 	"github.com/github/gh-aw/pkg/workflow"
 )
 
@@ -364,6 +365,25 @@ func (g *DependencyGraph) removeReverseImport(importPath string, importer string
 	}
 }
 
+// IsImported reports whether any workflow in the graph imports the given file.
+func (g *DependencyGraph) IsImported(path string) bool {
+	return len(g.reverseImports[path]) > 0
+}
+
+// ExternalImportDirs returns the sorted directories of imported files that live
+// outside the workflows directory (for example .github/agents), so watch mode can
+// observe them as well.
+func (g *DependencyGraph) ExternalImportDirs() []string {
+	dirs := make(map[string]struct{})
+	for importPath := range g.reverseImports {
+		if isWithinDir(importPath, g.workflowsDir) {
+			continue
+		}
+		dirs[filepath.Dir(importPath)] = struct{}{}
+	}
+	return sliceutil.SortedKeys(dirs)
+}
+
 // RemoveWorkflow removes a workflow from the graph (e.g., when deleted)
 func (g *DependencyGraph) RemoveWorkflow(workflowPath string) {
 	depGraphLog.Printf("Removing workflow from graph: %s", workflowPath)
@@ -946,3 +946,52 @@ tools:
 		})
 	}
 }
+
+func TestDependencyGraph_ExternalImports(t *testing.T) {
+	tmpDir := t.TempDir()
+	workflowsDir := filepath.Join(tmpDir, ".github", "workflows")
+	sharedDir := filepath.Join(workflowsDir, "shared")
+	agentsDir := filepath.Join(tmpDir, ".github", "agents")
+	for _, dir := range []string{sharedDir, agentsDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sharedWorkflow := filepath.Join(sharedDir, "tools.md")
+	agentFile := filepath.Join(agentsDir, "planner.md")
+	for _, path := range []string{sharedWorkflow, agentFile} {
+		if err := os.WriteFile(path, []byte("# Shared"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	topWorkflow := filepath.Join(workflowsDir, "main.md")
+	topContent := `---
+description: Main workflow
+imports:
+  - shared/tools.md
+  - ../agents/planner.md
+---
+# Main`
+	if err := os.WriteFile(topWorkflow, []byte(topContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	graph := NewDependencyGraph(workflowsDir)
+	if err := graph.BuildGraph(workflow.NewCompiler()); err != nil {
+		t.Fatalf("BuildGraph() error = %v", err)
+	}
+
+	if !graph.IsImported(sharedWorkflow) || !graph.IsImported(agentFile) {
+		t.Errorf("IsImported() should report both imports, reverse imports: %v", graph.reverseImports)
+	}
+	if graph.IsImported(topWorkflow) {
+		t.Error("IsImported() should be false for a workflow nobody imports")
+	}
+
+	dirs := graph.ExternalImportDirs()
+	if len(dirs) != 1 || dirs[0] != agentsDir {
+		t.Errorf("ExternalImportDirs() = %v, want [%s]", dirs, agentsDir)
+	}
+}
@@ -21,20 +21,22 @@ import (
 
 var enableLog = logger.New("cli:enable")
 
-// EnableWorkflowsByNames enables workflows by specific names, or all if no names provided
-func EnableWorkflowsByNames(ctx context.Context, workflowNames []string, repoOverride string) error {
+// EnableWorkflowsByNames enables workflows by specific names or glob patterns, or all if
+// no names provided. A non-empty reason is recorded for `status` to display.
+func EnableWorkflowsByNames(ctx context.Context, workflowNames []string, repoOverride string, reason string) error {
 	enableLog.Printf("EnableWorkflowsByNames called: workflow_count=%d, repo=%s", len(workflowNames), repoOverride)
-	return toggleWorkflowsByNames(ctx, workflowNames, true, repoOverride)
+	return toggleWorkflowsByNames(ctx, workflowNames, true, repoOverride, reason)
 }
 
-// DisableWorkflowsByNames disables workflows by specific names, or all if no names provided
-func DisableWorkflowsByNames(ctx context.Context, workflowNames []string, repoOverride string) error {
+// DisableWorkflowsByNames disables workflows by specific names or glob patterns, or all if
+// no names provided. A non-empty reason is recorded for `status` to display.
+func DisableWorkflowsByNames(ctx context.Context, workflowNames []string, repoOverride string, reason string) error {
 	enableLog.Printf("DisableWorkflowsByNames called: workflow_count=%d, repo=%s", len(workflowNames), repoOverride)
-	return toggleWorkflowsByNames(ctx, workflowNames, false, repoOverride)
+	return toggleWorkflowsByNames(ctx, workflowNames, false, repoOverride, reason)
 }
 
 // toggleWorkflowsByNames toggles workflows by specific names, or all if no names provided
-func toggleWorkflowsByNames(ctx context.Context, workflowNames []string, enable bool, repoOverride string) error {
+func toggleWorkflowsByNames(ctx context.Context, workflowNames []string, enable bool, repoOverride string, reason string) error {
 	action := "enable"
 	if !enable {
 		action = "disable"
@@ -65,7 +67,7 @@ func toggleWorkflowsByNames(ctx context.Context, workflowNames []string, enable
 		}
 
 		// Recursively call with all workflow names
-		return toggleWorkflowsByNames(ctx, allWorkflowNames, enable, repoOverride)
+		return toggleWorkflowsByNames(ctx, allWorkflowNames, enable, repoOverride, reason)
 	}
 
 	// Check if gh CLI is available
@@ -79,6 +81,13 @@ func toggleWorkflowsByNames(ctx context.Context, workflowNames []string, enable
 		return fmt.Errorf("no workflow files found to %s: %w", action, err)
 	}
 
+	// Expand glob patterns (e.g. "triage-*") against the local workflow IDs
+	var availableNames []string
+	for _, file := range mdFiles {
+		availableNames = append(availableNames, normalizeWorkflowID(filepath.Base(file)))
+	}
+	workflowNames = expandWorkflowNamePatterns(workflowNames, availableNames)
+
 	// Get GitHub workflows status for comparison; warn but continue if unavailable
 	enableLog.Print("Fetching GitHub workflows status for comparison")
 	githubWorkflows, err := fetchGitHubWorkflows(repoOverride, false)
@@ -100,6 +109,8 @@ func toggleWorkflowsByNames(ctx context.Context, workflowNames []string, enable
 
 	var targets []workflowTarget
 	var notFoundNames []string
+	// Workflows that end up in the requested state, whose reason is recorded
+	var settledNames []string
 
 	// Find matching workflows by name
 	for _, workflowName := range workflowNames {
@@ -136,11 +147,13 @@ func toggleWorkflowsByNames(ctx context.Context, workflowNames []string, enable
 					if enable && githubWorkflow.State == "active" {
 						// Already enabled
 						fmt.Fprintf(os.Stderr, "Workflow %s is already enabled\n", name)
+						settledNames = append(settledNames, name)
 						continue
 					}
 					if !enable && githubWorkflow.State == "disabled_manually" {
 						// Already disabled
 						fmt.Fprintf(os.Stderr, "Workflow %s is already disabled\n", name)
+						settledNames = append(settledNames, name)
 						continue
 					}
 				}
@@ -192,7 +205,7 @@ func toggleWorkflowsByNames(ctx context.Context, workflowNames []string, enable
 	if len(targets) == 0 {
 		enableLog.Printf("No workflows need to be %sd - all already in desired state", action)
 		fmt.Fprintf(os.Stderr, "All specified workflows are already %sd\n", action)
-		return nil
+		return recordToggleReason(repoOverride, settledNames, action, reason)
 	}
 
 	enableLog.Printf("Proceeding to %s %d workflows", action, len(targets))
@@ -260,9 +273,14 @@ func toggleWorkflowsByNames(ctx context.Context, workflowNames []string, enable
 			failures = append(failures, t.Name)
 		} else {
 			fmt.Fprintf(os.Stderr, "%sd workflow: %s\n", strings.ToUpper(action[:1])+action[1:], t.Name)
+			settledNames = append(settledNames, t.Name)
 		}
 	}
 
+	if err := recordToggleReason(repoOverride, settledNames, action, reason); err != nil {
+		return err
+	}
+
 	// Return error if any workflows failed to be processed
 	if len(failures) > 0 {
 		if enable {
@@ -275,6 +293,22 @@ func toggleWorkflowsByNames(ctx context.Context, workflowNames []string, enable
 	return nil
 }
 
+// recordToggleReason records the reason for the workflows that reached the requested
+// state. Workflows are already toggled at this point, so the error explains that only
+// the reason is missing.
+func recordToggleReason(repoOverride string, names []string, action string, reason string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	if err := recordWorkflowStateReasons(repoOverride, names, action, reason); err != nil {
+		return fmt.Errorf("workflows were %sd but the reason could not be recorded (requires permission to manage repository variables): %w", action, err)
+	}
+	if reason != "" {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Recorded reason for %d workflow(s): %s", len(names), reason)))
+	}
+	return nil
+}
+
 // DisableAllWorkflowsExcept disables all workflows except the specified ones
 // Typically used to disable all workflows except the one being trialled
 func DisableAllWorkflowsExcept(repoSlug string, exceptWorkflows []string, verbose bool) error {
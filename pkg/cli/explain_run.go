@@ -0,0 +1,272 @@
+// This file provides command-line interface functionality for gh-aw.
+// This file (explain_run.go) builds a human-readable post-mortem of a single
+// workflow run for the explain-run command.
+//
+// Key responsibilities:
+//   - Combining the conversation transcript, MCP gateway and firewall events, and
+//     safe outputs of a downloaded run into a PostMortem
+//   - Rendering the post-mortem as Markdown (what the agent tried, what failed,
+//     what it changed)
+
+package cli
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/stringutil"
+)
+
+var explainRunLog = logger.New("cli:explain_run")
+
+const (
+	// maxPostMortemSteps caps the number of tool calls listed under "What the agent tried".
+	maxPostMortemSteps = 40
+	// maxPostMortemDetailLength caps tool inputs, error details and the final message.
+	maxPostMortemDetailLength = 300
+)
+
+// PostMortemStep is a tool call made by the agent.
+type PostMortemStep struct {
+	Tool   string `json:"tool"`
+	Input  string `json:"input,omitempty"`
+	Failed bool   `json:"failed,omitempty"`
+}
+
+// PostMortemFailure is something that went wrong during the run.
+type PostMortemFailure struct {
+	// Source is where the failure was recorded: tool, gateway, firewall, or missing_tool.
+	Source string `json:"source"`
+	What   string `json:"what"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// PostMortem is a human-readable account of a single workflow run.
+type PostMortem struct {
+	RunID        int64               `json:"run_id"`
+	Workflow     string              `json:"workflow,omitempty"`
+	URL          string              `json:"url,omitempty"`
+	Conclusion   string              `json:"conclusion,omitempty"`
+	Engine       string              `json:"engine,omitempty"`
+	Summary      string              `json:"summary,omitempty"`
+	Steps        []PostMortemStep    `json:"steps"`
+	Failures     []PostMortemFailure `json:"failures"`
+	Changes      []CreatedItemReport `json:"changes"`
+	Noops        []string            `json:"noops,omitempty"`
+	FinalMessage string              `json:"final_message,omitempty"`
+}
+
+// postMortemInputs holds the run data a post-mortem is built from.
+type postMortemInputs struct {
+	run          WorkflowRun
+	transcript   *ConversationTranscript
+	events       []UnifiedTimelineEvent
+	createdItems []CreatedItemReport
+	missingTools []MissingToolReport
+	noops        []NoopReport
+}
+
+// buildPostMortem combines the transcript, timeline events and safe outputs of a run.
+func buildPostMortem(inputs postMortemInputs) *PostMortem {
+	pm := &PostMortem{
+		RunID:      inputs.run.DatabaseID,
+		Workflow:   inputs.run.WorkflowName,
+		URL:        inputs.run.URL,
+		Conclusion: inputs.run.Conclusion,
+		Steps:      []PostMortemStep{},
+		Failures:   []PostMortemFailure{},
+		Changes:    inputs.createdItems,
+	}
+	if pm.Changes == nil {
+		pm.Changes = []CreatedItemReport{}
+	}
+
+	if inputs.transcript != nil {
+		pm.Engine = inputs.transcript.Engine
+		addTranscriptToPostMortem(pm, inputs.transcript)
+	}
+	addTimelineFailuresToPostMortem(pm, inputs.events)
+	for _, missing := range inputs.missingTools {
+		pm.Failures = append(pm.Failures, PostMortemFailure{Source: "missing_tool", What: missing.Tool, Detail: missing.Reason})
+	}
+	for _, noop := range inputs.noops {
+		pm.Noops = append(pm.Noops, noop.Message)
+	}
+
+	explainRunLog.Printf("Built post-mortem for run %d: steps=%d, failures=%d, changes=%d", pm.RunID, len(pm.Steps), len(pm.Failures), len(pm.Changes))
+	return pm
+}
+
+// addTranscriptToPostMortem records the tool calls of the session, the failed ones,
+// and the last message of the agent.
+func addTranscriptToPostMortem(pm *PostMortem, transcript *ConversationTranscript) {
+	stepByCallID := make(map[string]int)
+	for _, entry := range transcript.Entries {
+		switch entry.Kind {
+		case ConversationEntryToolCall:
+			if entry.ToolCallID != "" {
+				stepByCallID[entry.ToolCallID] = len(pm.Steps)
+			}
+			pm.Steps = append(pm.Steps, PostMortemStep{Tool: entry.Tool, Input: postMortemDetail(entry.Input)})
+		case ConversationEntryToolOutput:
+			if !entry.IsError {
+				continue
+			}
+			tool := entry.Tool
+			if i, ok := stepByCallID[entry.ToolCallID]; ok {
+				pm.Steps[i].Failed = true
+				if tool == "" {
+					tool = pm.Steps[i].Tool
+				}
+			}
+			pm.Failures = append(pm.Failures, PostMortemFailure{Source: "tool", What: tool, Detail: postMortemDetail(entry.Text)})
+		case ConversationEntryAssistant:
+			if text := strings.TrimSpace(entry.Text); text != "" {
+				pm.FinalMessage = postMortemDetail(text)
+			}
+		}
+	}
+}
+
+// addTimelineFailuresToPostMortem records failed and blocked MCP tool calls and
+// blocked network requests. Each blocked host is reported once.
+func addTimelineFailuresToPostMortem(pm *PostMortem, events []UnifiedTimelineEvent) {
+	var blockedHosts []string
+	for _, event := range events {
+		switch event.Kind {
+		case TimelineKindToolCall:
+			if event.Status == "error" {
+				pm.Failures = append(pm.Failures, PostMortemFailure{Source: "gateway", What: postMortemToolName(event), Detail: postMortemDetail(event.Error)})
+			}
+		case TimelineKindDIFCFiltered, TimelineKindGuardPolicyBlocked:
+			detail := event.Reason
+			if detail == "" {
+				detail = "blocked by the MCP gateway policy"
+			}
+			pm.Failures = append(pm.Failures, PostMortemFailure{Source: "gateway", What: postMortemToolName(event), Detail: postMortemDetail(detail)})
+		case TimelineKindNetworkBlocked:
+			if event.Host != "" && !slices.Contains(blockedHosts, event.Host) {
+				blockedHosts = append(blockedHosts, event.Host)
+			}
+		}
+	}
+	for _, host := range blockedHosts {
+		pm.Failures = append(pm.Failures, PostMortemFailure{Source: "firewall", What: host, Detail: "network request blocked by the firewall"})
+	}
+}
+
+func postMortemToolName(event UnifiedTimelineEvent) string {
+	tool := event.ToolName
+	if tool == "" {
+		tool = event.Method
+	}
+	if event.ServerName != "" {
+		return event.ServerName + "." + tool
+	}
+	return tool
+}
+
+// postMortemDetail collapses whitespace and truncates text for a single report line.
+func postMortemDetail(s string) string {
+	return stringutil.Truncate(strings.Join(strings.Fields(s), " "), maxPostMortemDetailLength)
+}
+
+// renderPostMortemMarkdown renders a post-mortem as Markdown.
+func renderPostMortemMarkdown(pm *PostMortem) string {
+	var b strings.Builder
+
+	title := fmt.Sprintf("run %d", pm.RunID)
+	if pm.Workflow != "" {
+		title = pm.Workflow + " " + title
+	}
+	fmt.Fprintf(&b, "# Post-mortem: %s\n\n", title)
+
+	var facts []string
+	if pm.Conclusion != "" {
+		facts = append(facts, "**Outcome:** "+pm.Conclusion)
+	}
+	if pm.Engine != "" {
+		facts = append(facts, "**Engine:** "+pm.Engine)
+	}
+	if pm.URL != "" {
+		facts = append(facts, fmt.Sprintf("[View run](%s)", pm.URL))
+	}
+	if len(facts) > 0 {
+		b.WriteString(strings.Join(facts, " · ") + "\n\n")
+	}
+
+	if pm.Summary != "" {
+		b.WriteString("## Summary\n\n" + strings.TrimSpace(pm.Summary) + "\n\n")
+	}
+
+	b.WriteString("## What the agent tried\n\n")
+	if len(pm.Steps) == 0 {
+		b.WriteString("No tool calls were recorded.\n\n")
+	} else {
+		failed := 0
+		for _, step := range pm.Steps {
+			if step.Failed {
+				failed++
+			}
+		}
+		fmt.Fprintf(&b, "%d tool call(s), %d failed.\n\n", len(pm.Steps), failed)
+		for i, step := range pm.Steps {
+			if i == maxPostMortemSteps {
+				fmt.Fprintf(&b, "\n…and %d more.\n", len(pm.Steps)-maxPostMortemSteps)
+				break
+			}
+			line := fmt.Sprintf("%d. `%s`", i+1, step.Tool)
+			if step.Input != "" {
+				line += ": " + step.Input
+			}
+			if step.Failed {
+				line += " — **failed**"
+			}
+			b.WriteString(line + "\n")
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("## What failed\n\n")
+	if len(pm.Failures) == 0 {
+		b.WriteString("Nothing failed.\n\n")
+	} else {
+		for _, failure := range pm.Failures {
+			line := fmt.Sprintf("- **%s** `%s`", failure.Source, failure.What)
+			if failure.Detail != "" {
+				line += ": " + failure.Detail
+			}
+			b.WriteString(line + "\n")
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("## What it changed\n\n")
+	if len(pm.Changes) == 0 {
+		b.WriteString("No changes were made to GitHub.\n")
+	} else {
+		for _, item := range pm.Changes {
+			line := "- " + item.Type
+			if item.Number > 0 {
+				line += fmt.Sprintf(" #%d", item.Number)
+			}
+			if item.Repo != "" {
+				line += " in " + item.Repo
+			}
+			if item.URL != "" {
+				line += ": " + item.URL
+			}
+			b.WriteString(line + "\n")
+		}
+	}
+	for _, noop := range pm.Noops {
+		b.WriteString("- noop: " + postMortemDetail(noop) + "\n")
+	}
+
+	if pm.FinalMessage != "" {
+		b.WriteString("\n## Final message\n\n> " + pm.FinalMessage + "\n")
+	}
+	return b.String()
+}
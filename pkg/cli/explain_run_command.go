@@ -0,0 +1,193 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/spf13/cobra"
+)
+
+// defaultExplainRunModel is the GitHub Models model used by --summarize.
+const defaultExplainRunModel = "openai/gpt-4.1-mini"
+
+// explainRunSummaryPrompt asks the model for a short summary of the post-mortem piped on stdin.
+const explainRunSummaryPrompt = "Summarize this post-mortem of an agentic workflow run for a reviewer in at most five sentences: what the agent tried, what failed and why, and what it changed. Use only the facts in the post-mortem."
+
+// NewExplainRunCommand creates the explain-run command.
+func NewExplainRunCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "explain-run <run-id-or-url>",
+		Short: "Generate a human-readable post-mortem of a workflow run",
+		Long: `Download the artifacts of a workflow run and combine the agent transcript, the MCP
+gateway and firewall logs, and the safe outputs into a post-mortem:
+
+  - What the agent tried: the tool calls it made, in order
+  - What failed: failed tool calls, calls blocked by the MCP gateway, network
+    requests blocked by the firewall, and tools the agent reported as missing
+  - What it changed: the issues, comments, pull requests and other items created
+    through safe outputs, or the noop message when nothing was changed
+
+With --summarize, the post-mortem is also sent to GitHub Models (through the
+gh models extension) and the returned summary is added at the top.`,
+		Example: `  ` + string(constants.CLIExtensionPrefix) + ` explain-run 1234567890                  # Markdown post-mortem
+  ` + string(constants.CLIExtensionPrefix) + ` explain-run 1234567890 --summarize      # Add a model-written summary
+  ` + string(constants.CLIExtensionPrefix) + ` explain-run 1234567890 --json           # JSON for scripts
+  ` + string(constants.CLIExtensionPrefix) + ` explain-run https://github.com/owner/repo/actions/runs/1234567890`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			verbose, _ := cmd.Flags().GetBool("verbose")
+			outputDir, _ := cmd.Flags().GetString("output")
+			repoFlag, _ := cmd.Flags().GetString("repo")
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			summarize, _ := cmd.Flags().GetBool("summarize")
+			model, _ := cmd.Flags().GetString("model")
+
+			components, err := parser.ParseRunURLExtended(args[0])
+			if err != nil {
+				return err
+			}
+			if err := applyAuditRepoFlag(repoFlag, components); err != nil {
+				return err
+			}
+
+			return RunExplainRun(cmd.Context(), components.Number, ExplainRunOptions{
+				Owner:      components.Owner,
+				Repo:       components.Repo,
+				Hostname:   components.Host,
+				OutputDir:  outputDir,
+				JSONOutput: jsonOutput,
+				Summarize:  summarize,
+				Model:      model,
+				Verbose:    verbose,
+			})
+		},
+	}
+
+	addOutputFlag(cmd, defaultLogsOutputDir)
+	addRepoFlag(cmd)
+	addJSONFlag(cmd)
+	cmd.Flags().Bool("summarize", false, "Add a summary written by a GitHub Models model (requires the gh models extension)")
+	cmd.Flags().String("model", defaultExplainRunModel, "GitHub Models model used by --summarize")
+	RegisterDirFlagCompletion(cmd, "output")
+
+	return cmd
+}
+
+// ExplainRunOptions holds configuration for the explain-run command.
+type ExplainRunOptions struct {
+	Owner      string
+	Repo       string
+	Hostname   string
+	OutputDir  string
+	JSONOutput bool
+	Summarize  bool
+	Model      string
+	Verbose    bool
+}
+
+// RunExplainRun downloads the artifacts of a run (if not already cached) and prints
+// its post-mortem to stdout.
+func RunExplainRun(ctx context.Context, runID int64, opts ExplainRunOptions) error {
+	explainRunLog.Printf("Explaining run %d: summarize=%v, model=%s", runID, opts.Summarize, opts.Model)
+
+	hostname := opts.Hostname
+	if hostname == "" {
+		hostname = getHostFromOriginRemote()
+	}
+	if opts.OutputDir == "" {
+		opts.OutputDir = defaultLogsOutputDir
+	}
+	runDir := filepath.Join(opts.OutputDir, fmt.Sprintf("run-%d", runID))
+	if absDir, err := filepath.Abs(runDir); err == nil {
+		runDir = absDir
+	}
+
+	run, err := fetchWorkflowRunMetadata(ctx, runID, opts.Owner, opts.Repo, hostname, opts.Verbose)
+	if err != nil {
+		return err
+	}
+
+	// The activation and agent artifacts hold aw_info.json, the session and gateway logs,
+	// and agent_output.json; the safe outputs job records created items separately.
+	artifactFilter := append(ResolveArtifactFilter([]string{string(ArtifactSetActivation), string(ArtifactSetAgent)}), constants.SafeOutputItemsArtifactName)
+	if err := downloadRunArtifacts(ctx, downloadArtifactsOptions{runID: runID, outputDir: runDir, verbose: opts.Verbose, owner: opts.Owner, repo: opts.Repo, hostname: hostname, artifactFilter: artifactFilter}); err != nil {
+		if !errors.Is(err, ErrNoArtifacts) {
+			return fmt.Errorf("failed to download artifacts for run %d: %w", runID, err)
+		}
+		if opts.Verbose {
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage("No artifacts attached to this run."))
+		}
+	}
+
+	pm := buildPostMortem(collectPostMortemInputs(run, runDir, opts.Verbose))
+
+	if opts.Summarize {
+		summary, err := summarizePostMortem(ctx, pm, opts.Model)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Could not summarize the post-mortem with %s: %v", opts.Model, err)))
+		} else {
+			pm.Summary = summary
+		}
+	}
+
+	if opts.JSONOutput {
+		output, err := json.MarshalIndent(pm, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal post-mortem: %w", err)
+		}
+		fmt.Println(string(output))
+		return nil
+	}
+	fmt.Print(renderPostMortemMarkdown(pm))
+	return nil
+}
+
+// collectPostMortemInputs reads the transcript, timeline events and safe outputs from a
+// downloaded run directory. Missing sources are skipped so that partial runs still
+// produce a post-mortem.
+func collectPostMortemInputs(run WorkflowRun, runDir string, verbose bool) postMortemInputs {
+	inputs := postMortemInputs{run: run, createdItems: extractCreatedItemsFromManifest(runDir)}
+
+	if transcript, err := BuildConversationTranscript(runDir, verbose); err == nil {
+		inputs.transcript = transcript
+	} else {
+		explainRunLog.Printf("No transcript for run %d: %v", run.DatabaseID, err)
+		if verbose {
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(err.Error()))
+		}
+	}
+	if events, err := BuildUnifiedTimeline(runDir, verbose); err == nil {
+		inputs.events = events
+	} else {
+		explainRunLog.Printf("No timeline for run %d: %v", run.DatabaseID, err)
+	}
+	if missingTools, err := extractMissingToolsFromRun(runDir, run, verbose, "", ""); err == nil {
+		inputs.missingTools = missingTools
+	}
+	if noops, err := extractNoopsFromRun(runDir, run, verbose, "", ""); err == nil {
+		inputs.noops = noops
+	}
+	return inputs
+}
+
+// summarizePostMortem pipes the post-mortem to `gh models run` and returns the summary.
+func summarizePostMortem(ctx context.Context, pm *PostMortem, model string) (string, error) {
+	output, err := workflow.RunGHInputContext(ctx, "Summarizing run with "+model+"...", strings.NewReader(renderPostMortemMarkdown(pm)), "models", "run", model, explainRunSummaryPrompt)
+	if err != nil {
+		return "", err
+	}
+	summary := strings.TrimSpace(string(output))
+	if summary == "" {
+		return "", errors.New("the model returned an empty summary")
+	}
+	return summary, nil
+}
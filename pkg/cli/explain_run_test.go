@@ -0,0 +1,86 @@
+//go:build !integration
+
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildPostMortem(t *testing.T) {
+	inputs := postMortemInputs{
+		run: WorkflowRun{DatabaseID: 42, WorkflowName: "Issue Triage", Conclusion: "success", URL: "https://github.com/o/r/actions/runs/42"},
+		transcript: &ConversationTranscript{
+			Engine: "claude",
+			Entries: []ConversationEntry{
+				{Kind: ConversationEntryUser, Text: "Triage the issue"},
+				{Kind: ConversationEntryToolCall, Tool: "bash", ToolCallID: "1", Input: "{\"command\":\"gh issue view 7\"}"},
+				{Kind: ConversationEntryToolOutput, ToolCallID: "1", Text: "authentication required\n", IsError: true},
+				{Kind: ConversationEntryToolCall, Tool: "github.issue_read", ToolCallID: "2", Input: "{\"issue_number\":7}"},
+				{Kind: ConversationEntryToolOutput, ToolCallID: "2", Text: "{...}"},
+				{Kind: ConversationEntryAssistant, Text: "Labelled the issue as a bug."},
+			},
+		},
+		events: []UnifiedTimelineEvent{
+			{Kind: TimelineKindToolCall, ServerName: "github", ToolName: "search_code", Status: "error", Error: "rate limited"},
+			{Kind: TimelineKindGuardPolicyBlocked, ServerName: "github", ToolName: "get_file_contents"},
+			{Kind: TimelineKindNetworkBlocked, Host: "example.com:443"},
+			{Kind: TimelineKindNetworkBlocked, Host: "example.com:443"},
+			{Kind: TimelineKindNetworkAllowed, Host: "api.github.com:443"},
+		},
+		createdItems: []CreatedItemReport{{Type: "add_labels", Number: 7, URL: "https://github.com/o/r/issues/7"}},
+		missingTools: []MissingToolReport{{Tool: "jira", Reason: "needed to link the ticket"}},
+	}
+
+	pm := buildPostMortem(inputs)
+
+	assert.Equal(t, "claude", pm.Engine, "engine should come from the transcript")
+	require.Len(t, pm.Steps, 2, "every tool call should be a step")
+	assert.True(t, pm.Steps[0].Failed, "the bash call returned an error")
+	assert.False(t, pm.Steps[1].Failed, "the issue_read call succeeded")
+	assert.Equal(t, []PostMortemFailure{
+		{Source: "tool", What: "bash", Detail: "authentication required"},
+		{Source: "gateway", What: "github.search_code", Detail: "rate limited"},
+		{Source: "gateway", What: "github.get_file_contents", Detail: "blocked by the MCP gateway policy"},
+		{Source: "firewall", What: "example.com:443", Detail: "network request blocked by the firewall"},
+		{Source: "missing_tool", What: "jira", Detail: "needed to link the ticket"},
+	}, pm.Failures, "failures should combine transcript, gateway, firewall and missing tools")
+	assert.Equal(t, "Labelled the issue as a bug.", pm.FinalMessage, "final message should be the last assistant text")
+}
+
+func TestRenderPostMortemMarkdown(t *testing.T) {
+	pm := &PostMortem{
+		RunID:        42,
+		Workflow:     "Issue Triage",
+		Conclusion:   "failure",
+		Engine:       "copilot",
+		Summary:      "The agent could not read the issue.",
+		Steps:        []PostMortemStep{{Tool: "bash", Input: "ls", Failed: true}},
+		Failures:     []PostMortemFailure{{Source: "tool", What: "bash", Detail: "exit 1"}},
+		Changes:      []CreatedItemReport{{Type: "create_issue", Number: 9, Repo: "o/r", URL: "https://github.com/o/r/issues/9"}},
+		FinalMessage: "Done.",
+	}
+
+	out := renderPostMortemMarkdown(pm)
+
+	assert.Contains(t, out, "# Post-mortem: Issue Triage run 42", "title should name the workflow and run")
+	assert.Contains(t, out, "**Outcome:** failure · **Engine:** copilot", "should state outcome and engine")
+	assert.Contains(t, out, "## Summary\n\nThe agent could not read the issue.", "should include the model summary")
+	assert.Contains(t, out, "1 tool call(s), 1 failed.", "should count failed calls")
+	assert.Contains(t, out, "1. `bash`: ls — **failed**", "should list the steps")
+	assert.Contains(t, out, "- **tool** `bash`: exit 1", "should list failures")
+	assert.Contains(t, out, "- create_issue #9 in o/r: https://github.com/o/r/issues/9", "should list changes")
+	assert.Contains(t, out, "## Final message\n\n> Done.", "should quote the final message")
+}
+
+func TestRenderPostMortemMarkdown_Empty(t *testing.T) {
+	out := renderPostMortemMarkdown(buildPostMortem(postMortemInputs{run: WorkflowRun{DatabaseID: 7}, noops: []NoopReport{{Message: "Nothing to do"}}}))
+
+	assert.Contains(t, out, "# Post-mortem: run 7", "title should fall back to the run ID")
+	assert.Contains(t, out, "No tool calls were recorded.", "should explain missing steps")
+	assert.Contains(t, out, "Nothing failed.", "should state that nothing failed")
+	assert.Contains(t, out, "No changes were made to GitHub.\n- noop: Nothing to do", "should report the noop message")
+	assert.NotContains(t, out, "## Summary", "summary is only rendered with --summarize")
+}
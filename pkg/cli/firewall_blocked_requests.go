@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var firewallBlockedRequestsLog = logger.New("cli:firewall_blocked_requests")
+
+// blockedRequestsFileName is the structured record of denied egress attempts written by
+// record_firewall_blocked_requests.cjs next to the firewall proxy logs. After artifact
+// download it is found at sandbox/firewall/blocked-requests.json in the run directory.
+const blockedRequestsFileName = "blocked-requests.json"
+
+// BlockedDomainAttempts describes the egress attempts to one domain denied by the firewall.
+type BlockedDomainAttempts struct {
+	Domain    string   `json:"domain"`
+	Requests  int      `json:"requests"`
+	FirstSeen string   `json:"first_seen,omitempty"`
+	LastSeen  string   `json:"last_seen,omitempty"`
+	URLs      []string `json:"urls,omitempty"`
+}
+
+// blockedRequestsFile is the content of blocked-requests.json.
+type blockedRequestsFile struct {
+	Version         int                     `json:"version"`
+	BlockedRequests int                     `json:"blocked_requests"`
+	Domains         []BlockedDomainAttempts `json:"domains"`
+}
+
+// parseBlockedRequestsFile reads the blocked-requests.json artifact of a run.
+// It returns nil, nil when the run predates the artifact or ran without the firewall.
+func parseBlockedRequestsFile(runDir string) (*blockedRequestsFile, error) {
+	path := filepath.Join(runDir, "sandbox", "firewall", blockedRequestsFileName)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", blockedRequestsFileName, err)
+	}
+	var blocked blockedRequestsFile
+	if err := json.Unmarshal(content, &blocked); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", blockedRequestsFileName, err)
+	}
+	firewallBlockedRequestsLog.Printf("Loaded %s: %d blocked requests to %d domains", path, blocked.BlockedRequests, len(blocked.Domains))
+	return &blocked, nil
+}
+
+// attachBlockedRequests adds the blocked-requests.json artifact of a run to its firewall
+// analysis. When the proxy logs are missing the analysis is built from the artifact alone.
+func attachBlockedRequests(analysis *FirewallAnalysis, runDir string) (*FirewallAnalysis, error) {
+	blocked, err := parseBlockedRequestsFile(runDir)
+	if err != nil || blocked == nil {
+		return analysis, err
+	}
+	if analysis == nil {
+		analysis = &FirewallAnalysis{
+			AnalysisBase: AnalysisBase{
+				TotalRequests:   blocked.BlockedRequests,
+				BlockedRequests: blocked.BlockedRequests,
+			},
+			RequestsByDomain: make(map[string]DomainRequestStats),
+		}
+		domains := make([]string, 0, len(blocked.Domains))
+		for _, attempts := range blocked.Domains {
+			domains = append(domains, attempts.Domain)
+			analysis.RequestsByDomain[attempts.Domain] = DomainRequestStats{Blocked: attempts.Requests}
+		}
+		slices.Sort(domains)
+		analysis.SetBlockedDomains(domains)
+	}
+	analysis.BlockedAttempts = blocked.Domains
+	return analysis, nil
+}
+
+// blockedDomainAttempts returns the blocked egress attempts of a run, most requests first.
+// Runs without blocked-requests.json fall back to the per-domain counts of the proxy logs.
+func blockedDomainAttempts(analysis *FirewallAnalysis) []BlockedDomainAttempts {
+	if analysis == nil {
+		return nil
+	}
+	if len(analysis.BlockedAttempts) > 0 {
+		return analysis.BlockedAttempts
+	}
+	var attempts []BlockedDomainAttempts
+	for domain, stats := range analysis.RequestsByDomain {
+		if stats.Blocked > 0 && domain != unknownDomain {
+			attempts = append(attempts, BlockedDomainAttempts{Domain: domain, Requests: stats.Blocked})
+		}
+	}
+	slices.SortFunc(attempts, func(a, b BlockedDomainAttempts) int {
+		return cmp.Or(cmp.Compare(b.Requests, a.Requests), cmp.Compare(a.Domain, b.Domain))
+	})
+	return attempts
+}
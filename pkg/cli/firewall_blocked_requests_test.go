@@ -0,0 +1,103 @@
+//go:build !integration
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeBlockedRequestsFile(t *testing.T, runDir, content string) {
+	t.Helper()
+	dir := filepath.Join(runDir, "sandbox", "firewall")
+	require.NoError(t, os.MkdirAll(dir, 0755), "create firewall dir")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, blockedRequestsFileName), []byte(content), 0644), "write blocked requests")
+}
+
+func TestAnalyzeFirewallLogsWithBlockedRequestsFile(t *testing.T) {
+	runDir := t.TempDir()
+	logsDir := filepath.Join(runDir, "sandbox", "firewall", "logs")
+	require.NoError(t, os.MkdirAll(logsDir, 0755), "create logs dir")
+	require.NoError(t, os.WriteFile(filepath.Join(logsDir, "access.log"), []byte(
+		`1761332530.474 172.30.0.20:35288 api.github.com:443 140.82.112.22:443 1.1 CONNECT 200 TCP_TUNNEL:HIER_DIRECT api.github.com:443 "-"`+"\n"+
+			`1761332531.123 172.30.0.20:35289 blocked.example.com:443 -:- 1.1 CONNECT 403 NONE_NONE:HIER_NONE blocked.example.com:443 "-"`+"\n"), 0644), "write access log")
+	writeBlockedRequestsFile(t, runDir, `{
+  "version": 1,
+  "blocked_requests": 1,
+  "domains": [{"domain": "blocked.example.com", "requests": 1, "first_seen": "2025-10-24T19:02:11.123Z", "last_seen": "2025-10-24T19:02:11.123Z", "urls": ["blocked.example.com:443"]}]
+}`)
+
+	analysis, err := analyzeFirewallLogs(runDir, false)
+	require.NoError(t, err, "analyze firewall logs")
+	require.NotNil(t, analysis, "analysis")
+	assert.Equal(t, 2, analysis.TotalRequests, "proxy log counts should be kept")
+	require.Len(t, analysis.BlockedAttempts, 1, "blocked attempts from artifact")
+	assert.Equal(t, "blocked.example.com", analysis.BlockedAttempts[0].Domain, "blocked domain")
+	assert.Equal(t, []string{"blocked.example.com:443"}, analysis.BlockedAttempts[0].URLs, "sample URLs")
+}
+
+func TestAnalyzeFirewallLogsWithOnlyBlockedRequestsFile(t *testing.T) {
+	runDir := t.TempDir()
+	writeBlockedRequestsFile(t, runDir, `{"version": 1, "blocked_requests": 3, "domains": [{"domain": "b.test", "requests": 1}, {"domain": "a.test", "requests": 2}]}`)
+
+	analysis, err := analyzeFirewallLogs(runDir, false)
+	require.NoError(t, err, "analyze firewall logs")
+	require.NotNil(t, analysis, "analysis should be built from the artifact")
+	assert.Equal(t, 3, analysis.TotalRequests, "total requests")
+	assert.Equal(t, 3, analysis.BlockedRequests, "blocked requests")
+	assert.Equal(t, []string{"a.test", "b.test"}, analysis.BlockedDomains, "blocked domains")
+	assert.Equal(t, DomainRequestStats{Blocked: 2}, analysis.RequestsByDomain["a.test"], "per-domain stats")
+}
+
+func TestAnalyzeFirewallLogsWithInvalidBlockedRequestsFile(t *testing.T) {
+	runDir := t.TempDir()
+	writeBlockedRequestsFile(t, runDir, `not json`)
+
+	_, err := analyzeFirewallLogs(runDir, false)
+	require.Error(t, err, "invalid artifact should fail")
+	assert.Contains(t, err.Error(), blockedRequestsFileName, "error should name the artifact")
+}
+
+func TestBlockedDomainAttemptsFallsBackToProxyLogCounts(t *testing.T) {
+	analysis := &FirewallAnalysis{RequestsByDomain: map[string]DomainRequestStats{
+		"api.github.com:443": {Allowed: 4},
+		"b.test:443":         {Blocked: 1},
+		"a.test:443":         {Blocked: 1},
+		"c.test:443":         {Allowed: 1, Blocked: 3},
+		unknownDomain:        {Blocked: 5},
+	}}
+
+	attempts := blockedDomainAttempts(analysis)
+	assert.Equal(t, []BlockedDomainAttempts{
+		{Domain: "c.test:443", Requests: 3},
+		{Domain: "a.test:443", Requests: 1},
+		{Domain: "b.test:443", Requests: 1},
+	}, attempts, "blocked domains sorted by requests then name, without unknown destinations")
+	assert.Nil(t, blockedDomainAttempts(nil), "nil analysis")
+}
+
+func TestBuildBlockedDomainsByRun(t *testing.T) {
+	processedRuns := []ProcessedRun{
+		{
+			Run: WorkflowRun{DatabaseID: 2, WorkflowName: "triage"},
+			FirewallAnalysis: &FirewallAnalysis{BlockedAttempts: []BlockedDomainAttempts{
+				{Domain: "registry.npmjs.org", Requests: 4, FirstSeen: "2025-10-24T19:02:11Z"},
+			}},
+		},
+		{Run: WorkflowRun{DatabaseID: 3, WorkflowName: "triage"}},
+		{
+			Run:              WorkflowRun{DatabaseID: 1, WorkflowName: "docs"},
+			FirewallAnalysis: &FirewallAnalysis{RequestsByDomain: map[string]DomainRequestStats{"pypi.org:443": {Blocked: 2}}},
+		},
+	}
+
+	blocked := buildBlockedDomainsByRun(processedRuns)
+	assert.Equal(t, []RunBlockedDomain{
+		{RunID: 2, Workflow: "triage", Domain: "registry.npmjs.org", Requests: 4, FirstSeen: "2025-10-24T19:02:11Z"},
+		{RunID: 1, Workflow: "docs", Domain: "pypi.org:443", Requests: 2},
+	}, blocked, "blocked domains grouped by run in report order")
+}
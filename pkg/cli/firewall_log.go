@@ -133,6 +133,8 @@ type FirewallLogEntry struct {
 type FirewallAnalysis struct {
 	AnalysisBase
 	RequestsByDomain map[string]DomainRequestStats `json:"requests_by_domain,omitempty"`
+	// BlockedAttempts lists the denied egress attempts recorded in blocked-requests.json
+	BlockedAttempts []BlockedDomainAttempts `json:"blocked_attempts,omitempty"`
 }
 
 // AddMetrics adds metrics from another analysis
@@ -331,10 +333,20 @@ func parseFirewallLog(logPath string, verbose bool) (*FirewallAnalysis, error) {
 	return analysis, nil
 }
 
-// analyzeFirewallLogs analyzes firewall logs in a run directory
+// analyzeFirewallLogs analyzes firewall logs in a run directory together with the
+// blocked-requests.json record of denied egress attempts, when present
+func analyzeFirewallLogs(runDir string, verbose bool) (*FirewallAnalysis, error) {
+	analysis, err := analyzeFirewallProxyLogs(runDir, verbose)
+	if err != nil {
+		return nil, err
+	}
+	return attachBlockedRequests(analysis, runDir)
+}
+
+// analyzeFirewallProxyLogs analyzes the firewall proxy logs in a run directory
 // Firewall logs are stored in /tmp/gh-aw/squid-logs-{workflow-name}/ during execution
 // and uploaded as artifacts to the logs directory
-func analyzeFirewallLogs(runDir string, verbose bool) (*FirewallAnalysis, error) {
+func analyzeFirewallProxyLogs(runDir string, verbose bool) (*FirewallAnalysis, error) {
 	firewallLogLog.Printf("Analyzing firewall logs in: %s", runDir)
 	// Look for firewall logs in the run directory
 	// The logs could be in several locations depending on how they were uploaded
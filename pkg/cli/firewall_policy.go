@@ -466,7 +466,7 @@ func detectFirewallAuditArtifacts(runDir string) (manifestPath, auditJSONLPath s
 			if !checkDir(filepath.Join(agentDir, "sandbox", "firewall", "audit"), agentBase+"/sandbox/firewall/audit") {
 				// Old artifact structure (/tmp/gh-aw/ prefix preserved inside the artifact):
 				//   <agentDir>/tmp/gh-aw/sandbox/firewall/audit/
-				checkDir(filepath.Join(agentDir, "tmp", "gh-aw", "sandbox", "firewall", "audit"), agentBase+constants.AWFAuditDir)
+				checkDir(filepath.Join(agentDir, "tmp", "gh-aw", "sandbox", "firewall", "audit"), agentBase+constants.TmpGhAwDir+"/sandbox/firewall/audit")
 			}
 			if manifestPath != "" && auditJSONLPath != "" {
 				return manifestPath, auditJSONLPath, nil
@@ -108,6 +108,7 @@ func GetAllCodemods() []Codemod {
 		getMentionsAllowTeamMembersCodemod(),                       // Rename allow-team-members to allowed-collaborators in safe-outputs.mentions
 		getEngineCopilotSDKDriverToDriverCodemod(),                 // Rename deprecated engine.copilot-sdk-driver to engine.driver
 		getEngineModelToTopLevelCodemod(),                          // Move engine.model to top-level model
+		getMCPServerFixitsCodemod(),                                // Rename misspelled mcp-servers keys and convert string list values
 	}
 	fixCodemodsLog.Printf("Loaded codemod registry: %d codemods available", len(codemods))
 	return codemods
@@ -130,6 +130,7 @@ func TestGetAllCodemods_ContainsExpectedCodemods(t *testing.T) {
 		"mentions-allow-team-members-to-allowed-collaborators",
 		"engine-copilot-sdk-driver-to-driver",
 		"engine-model-to-top-level",
+		"mcp-servers-fixits",
 	}
 
 	for _, expectedID := range expectedIDs {
@@ -247,5 +248,6 @@ func expectedCodemodOrder() []string {
 		"mentions-allow-team-members-to-allowed-collaborators",
 		"engine-copilot-sdk-driver-to-driver",
 		"engine-model-to-top-level",
+		"mcp-servers-fixits",
 	}
 }
@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/spf13/cobra"
+)
+
+// NewGraphCommand creates the graph command
+func NewGraphCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "graph",
+		Short: "Show how workflows, their triggers and their safe outputs connect",
+		Long: `Print a graph of the agentic workflows in the repository: the events that trigger
+each workflow, the safe outputs it can produce, and the other workflows those safe
+outputs can start. Use it to spot unintended chains and events no workflow handles.
+
+A safe output is linked to every workflow whose triggers listen to the event it
+causes (for example create-issue to workflows triggered by issues, add-comment to
+slash commands), to the workflows listed in dispatch-workflow and call-workflow, and
+to workflows with a workflow_run trigger naming it.
+
+GitHub does not start new workflow runs for events caused by the GITHUB_TOKEN, except
+workflow_dispatch and repository_dispatch. Links from safe outputs that use the
+GITHUB_TOKEN are drawn dotted; set safe-outputs.github-token or github-app to make
+them live. Cycles of live links are reported as warnings.
+
+Formats:
+- mermaid  Mermaid flowchart (default)
+- dot      Graphviz DOT
+- json     Workflows, links and cycles as JSON`,
+		Example: `  ` + string(constants.CLIExtensionPrefix) + ` graph                          # Mermaid flowchart of .github/workflows
+  ` + string(constants.CLIExtensionPrefix) + ` graph --format dot             # Graphviz DOT, render with dot -Tsvg
+  ` + string(constants.CLIExtensionPrefix) + ` graph --json                   # Links and cycles for scripts`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir, _ := cmd.Flags().GetString("dir")
+			format, _ := cmd.Flags().GetString("format")
+			if jsonOutput, _ := cmd.Flags().GetBool("json"); jsonOutput {
+				format = "json"
+			}
+			return RunGraph(dir, format)
+		},
+	}
+
+	cmd.Flags().StringP("dir", "d", "", "Workflow directory (default: .github/workflows)")
+	cmd.Flags().String("format", "mermaid", "Output format: mermaid, dot or json")
+	addJSONFlag(cmd)
+	RegisterDirFlagCompletion(cmd, "dir")
+
+	return cmd
+}
+
+// RunGraph prints the dependency graph of the workflows in dir.
+func RunGraph(dir, format string) error {
+	workflowGraphLog.Printf("Generating workflow graph: dir=%s, format=%s", dir, format)
+
+	var render func(*WorkflowGraph) string
+	switch format {
+	case "mermaid":
+		render = renderWorkflowGraphMermaid
+	case "dot":
+		render = renderWorkflowGraphDOT
+	case "json":
+	default:
+		return fmt.Errorf("unknown format %q: expected mermaid, dot or json", format)
+	}
+
+	graph, err := buildWorkflowGraph(dir)
+	if err != nil {
+		return err
+	}
+
+	if render == nil {
+		output, err := json.MarshalIndent(graph, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal workflow graph: %w", err)
+		}
+		fmt.Println(string(output))
+		return nil
+	}
+
+	fmt.Print(render(graph))
+	for _, cycle := range graph.Cycles {
+		chain := strings.Join(append(cycle, cycle[0]), " → ")
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("Workflows can trigger each other in a loop: "+chain))
+	}
+	return nil
+}
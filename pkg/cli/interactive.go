@@ -534,28 +534,35 @@ func (b *InteractiveWorkflowBuilder) generateWorkflowContent() string {
 	interactiveLog.Printf("Generating workflow content: trigger=%s, engine=%s, tools=%v, safe_outputs=%v", b.Trigger, b.Engine, b.Tools, b.SafeOutputs)
 	var content strings.Builder
 
-	// Write frontmatter
+	// Write frontmatter; each section is preceded by a comment explaining it
 	content.WriteString("---\n")
+	content.WriteString(b.headerComment())
 
 	// Add trigger configuration
+	content.WriteString("\n" + b.triggerComment())
 	content.WriteString(b.generateTriggerConfig())
 
 	// Add permissions
+	content.WriteString("\n" + b.permissionsComment())
 	content.WriteString(b.generatePermissionsConfig())
 
 	// Add engine configuration
+	content.WriteString("\n" + b.engineComment())
 	fmt.Fprintf(&content, "engine: %s\n", b.Engine)
 
 	// Add network configuration
+	content.WriteString("\n" + b.networkComment())
 	content.WriteString(b.generateNetworkConfig())
 
 	// Add tools configuration
 	if len(b.Tools) > 0 {
+		content.WriteString("\n" + b.toolsComment())
 		content.WriteString(b.generateToolsConfig())
 	}
 
 	// Add safe outputs configuration
 	if len(b.SafeOutputs) > 0 {
+		content.WriteString("\n" + b.safeOutputsComment())
 		content.WriteString(b.generateSafeOutputsConfig())
 	}
 
@@ -694,7 +701,8 @@ func (b *InteractiveWorkflowBuilder) generateToolsConfig() string {
 			// which matches the DefaultGitHubToolsets constant.
 			config.WriteString("  github:\n    toolsets: [default]\n")
 		case "bash":
-			config.WriteString("  bash:\n")
+			// The anonymous "bash:" form is rejected by the compiler
+			config.WriteString("  bash: true\n")
 		default:
 			fmt.Fprintf(&config, "  %s:\n", tool)
 		}
@@ -789,13 +797,7 @@ func buildSafeOutputOptions() []huh.Option[string] {
 	options := make([]huh.Option[string], 0, len(tools))
 	for _, t := range tools {
 		// Truncate long descriptions so option labels remain readable
-		desc := t.Description
-		if idx := strings.IndexByte(desc, '.'); idx > 0 && idx < 120 {
-			desc = desc[:idx]
-		} else if len(desc) > 120 {
-			desc = desc[:120] + "…"
-		}
-		label := fmt.Sprintf("%s - %s", t.Key, desc)
+		label := fmt.Sprintf("%s - %s", t.Key, shortSafeOutputDescription(t.Description))
 		options = append(options, huh.NewOption(label, t.Key))
 	}
 	return options
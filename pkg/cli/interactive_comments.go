@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/workflow"
+)
+
+// docsReferenceURL is the base URL of the reference documentation linked from
+// the comments of generated workflows.
+const docsReferenceURL = "https://github.github.com/gh-aw/reference/"
+
+// interactiveToolDescriptions explains each tool offered by the wizard in the
+// comments of the generated workflow.
+var interactiveToolDescriptions = map[string]string{
+	"github":     "read issues, pull requests, comments and repository content",
+	"edit":       "create and edit files in the checked-out repository",
+	"bash":       "run shell commands (true allows all; a list such as [\"git status\"] restricts them)",
+	"web-fetch":  "download web pages from domains allowed by network",
+	"web-search": "search the web",
+	"playwright": "drive a headless browser",
+}
+
+// commentBlock renders lines as a YAML comment block followed by a "See" link to
+// the given reference page.
+func commentBlock(docsPage string, lines ...string) string {
+	var b strings.Builder
+	for _, line := range lines {
+		fmt.Fprintf(&b, "# %s\n", line)
+	}
+	fmt.Fprintf(&b, "# See %s%s/\n", docsReferenceURL, docsPage)
+	return b.String()
+}
+
+// agentNoticePattern matches the agent-facing notice (e.g. "WRITE-ONCE: ...") that
+// some safe output tool descriptions open with.
+var agentNoticePattern = regexp.MustCompile(`^[A-Z][A-Z-]+: [^.]*\. `)
+
+// shortSafeOutputDescription returns the first sentence of a safe output tool
+// description, truncated so it fits on one line. A leading agent-facing notice is
+// skipped because it does not describe what the output does.
+func shortSafeOutputDescription(desc string) string {
+	desc = agentNoticePattern.ReplaceAllString(desc, "")
+	if idx := strings.IndexByte(desc, '.'); idx > 0 && idx < 120 {
+		return desc[:idx]
+	}
+	if len(desc) > 120 {
+		return desc[:120] + "…"
+	}
+	return desc
+}
+
+func (b *InteractiveWorkflowBuilder) headerComment() string {
+	cli := string(constants.CLIExtensionPrefix)
+	return commentBlock("frontmatter",
+		fmt.Sprintf("Generated by `%s new`. Every setting below can be changed; run", cli),
+		fmt.Sprintf("`%s compile` after editing to regenerate the .lock.yml workflow.", cli),
+	)
+}
+
+func (b *InteractiveWorkflowBuilder) triggerComment() string {
+	lines := []string{
+		"When the workflow runs: " + b.describeTrigger() + ".",
+		"Add more events under `on:` to run on several triggers.",
+	}
+	switch b.Trigger {
+	case "schedule_daily", "schedule_weekly":
+		lines = append(lines, "Fuzzy schedules pick a stable, scattered time for each workflow to spread load.")
+	case "command":
+		lines = append(lines, "The workflow runs when someone comments /<name> on an issue or pull request.")
+	}
+	return commentBlock("triggers", lines...)
+}
+
+func (b *InteractiveWorkflowBuilder) permissionsComment() string {
+	return commentBlock("permissions",
+		"Permissions of the agent job. Keep them read-only: write operations go",
+		"through safe-outputs, which run in a separate job after the agent finishes.",
+	)
+}
+
+func (b *InteractiveWorkflowBuilder) engineComment() string {
+	return commentBlock("engines",
+		"AI engine that runs the agent. It needs an API key or token stored as a",
+		fmt.Sprintf("repository secret; `%s secrets bootstrap` reports which ones are missing.", string(constants.CLIExtensionPrefix)),
+	)
+}
+
+func (b *InteractiveWorkflowBuilder) networkComment() string {
+	return commentBlock("network",
+		"Domains the agent can reach, enforced by a firewall. `defaults` covers basic",
+		"infrastructure; add ecosystems (node, python, go, ...) or individual domains.",
+	)
+}
+
+func (b *InteractiveWorkflowBuilder) toolsComment() string {
+	lines := []string{"Tools the agent can use:"}
+	for _, tool := range b.Tools {
+		if desc, ok := interactiveToolDescriptions[tool]; ok {
+			lines = append(lines, fmt.Sprintf("  %s - %s", tool, desc))
+		}
+	}
+	return commentBlock("tools", lines...)
+}
+
+func (b *InteractiveWorkflowBuilder) safeOutputsComment() string {
+	descriptions := make(map[string]string)
+	for _, option := range workflow.GetSafeOutputToolOptions() {
+		descriptions[option.Key] = shortSafeOutputDescription(option.Description)
+	}
+	lines := []string{
+		"GitHub write actions the agent can request. Each request is validated and",
+		"applied by a separate job; the agent itself never gets write access.",
+	}
+	for _, output := range b.SafeOutputs {
+		if desc, ok := descriptions[output]; ok {
+			lines = append(lines, fmt.Sprintf("  %s - %s", output, desc))
+		}
+	}
+	return commentBlock("safe-outputs", lines...)
+}
@@ -0,0 +1,72 @@
+//go:build !integration
+
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateWorkflowContent_CommentsEverySection(t *testing.T) {
+	builder := &InteractiveWorkflowBuilder{
+		WorkflowName:  "issue-triage",
+		Trigger:       "issues",
+		Engine:        "copilot",
+		Tools:         []string{"github", "bash"},
+		SafeOutputs:   []string{"add-comment", "add-labels"},
+		Intent:        "Label new issues",
+		NetworkAccess: "defaults,node",
+	}
+
+	content := builder.generateWorkflowContent()
+
+	for _, page := range []string{"frontmatter", "triggers", "permissions", "engines", "network", "tools", "safe-outputs"} {
+		assert.Contains(t, content, "# See "+docsReferenceURL+page+"/", "section %s should link its reference page", page)
+	}
+	assert.Contains(t, content, "# When the workflow runs: Issue opened or reopened.", "trigger comment should describe the selection")
+	assert.Contains(t, content, "#   bash - run shell commands", "tools comment should describe each selected tool")
+	assert.Contains(t, content, "#   add-comment - Adds a comment to an existing GitHub issue, pull request, or discussion", "safe outputs comment should describe each selected output")
+
+	result, err := parser.ExtractFrontmatterFromContent(content)
+	require.NoError(t, err, "commented frontmatter should still parse")
+	assert.Equal(t, "copilot", result.Frontmatter["engine"], "engine should survive the comments")
+	assert.Contains(t, result.Frontmatter, "safe-outputs", "safe-outputs should survive the comments")
+	tools, ok := result.Frontmatter["tools"].(map[string]any)
+	require.True(t, ok, "tools should be a map")
+	assert.Equal(t, true, tools["bash"], "bash should use the boolean form accepted by the compiler")
+}
+
+func TestGenerateWorkflowContent_OmitsUnselectedSections(t *testing.T) {
+	builder := &InteractiveWorkflowBuilder{
+		WorkflowName:  "manual",
+		Trigger:       "workflow_dispatch",
+		Engine:        "claude",
+		NetworkAccess: "defaults",
+	}
+
+	content := builder.generateWorkflowContent()
+
+	assert.NotContains(t, content, docsReferenceURL+"tools/", "tools comment should be omitted without tools")
+	assert.NotContains(t, content, docsReferenceURL+"safe-outputs/", "safe outputs comment should be omitted without safe outputs")
+}
+
+func TestShortSafeOutputDescription(t *testing.T) {
+	tests := []struct {
+		name     string
+		desc     string
+		expected string
+	}{
+		{name: "first sentence", desc: "Add labels to an issue. Labels must exist.", expected: "Add labels to an issue"},
+		{name: "agent notice skipped", desc: "WRITE-ONCE: do NOT call this tool to probe its schema — call `noop` instead. Creates a new GitHub issue. More text.", expected: "Creates a new GitHub issue"},
+		{name: "long description truncated", desc: strings.Repeat("a", 130), expected: strings.Repeat("a", 120) + "…"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, shortSafeOutputDescription(tt.desc), "unexpected short description")
+		})
+	}
+}
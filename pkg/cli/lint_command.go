@@ -8,6 +8,7 @@ import (
 	"slices"
 	"strings"
 
+	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/constants"
 	"github.com/github/gh-aw/pkg/logger"
 	"github.com/spf13/cobra"
@@ -42,12 +43,22 @@ By default, shellcheck and pyflakes integrations are disabled for generated run
 		Example: `  ` + string(constants.CLIExtensionPrefix) + ` lint                                  # Lint all .lock.yml workflows in the default directory
   ` + string(constants.CLIExtensionPrefix) + ` lint .github/workflows/foo.lock.yml    # Lint a specific lock file
   ` + string(constants.CLIExtensionPrefix) + ` lint --dir custom-workflows/            # Lint all lock files in a custom directory
-  ` + string(constants.CLIExtensionPrefix) + ` lint --shellcheck --pyflakes            # Enable actionlint script integrations`,
+  ` + string(constants.CLIExtensionPrefix) + ` lint --shellcheck --pyflakes            # Enable actionlint script integrations
+  ` + string(constants.CLIExtensionPrefix) + ` lint --format github                    # Emit findings as pull request annotations`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			workflowDir, _ := cmd.Flags().GetString("dir")
 			includeShellcheck, _ := cmd.Flags().GetBool("shellcheck")
 			includePyflakes, _ := cmd.Flags().GetBool("pyflakes")
 			verbose, _ := cmd.Flags().GetBool("verbose")
+			format, _ := cmd.Flags().GetString("format")
+			switch format {
+			case "", CompileFormatText:
+			case CompileFormatGitHub:
+				console.SetGitHubAnnotations(true)
+				defer console.SetGitHubAnnotations(false)
+			default:
+				return fmt.Errorf("unknown --format %q: expected text or github", format)
+			}
 			effectiveWorkflowDir := workflowDir
 			if effectiveWorkflowDir == "" && len(args) == 0 {
 				effectiveWorkflowDir = constants.GetWorkflowDir()
@@ -79,6 +90,7 @@ By default, shellcheck and pyflakes integrations are disabled for generated run
 	cmd.Flags().StringP("dir", "d", "", "Workflow directory (default: $GH_AW_WORKFLOWS_DIR or .github/workflows)")
 	cmd.Flags().Bool("shellcheck", false, "Enable shellcheck integration in actionlint")
 	cmd.Flags().Bool("pyflakes", false, "Enable pyflakes integration in actionlint")
+	cmd.Flags().String("format", "text", "Diagnostics format: text or github (::error/::warning annotations for pull request checks)")
 
 	RegisterDirFlagCompletion(cmd, "dir")
 
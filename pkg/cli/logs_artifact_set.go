@@ -81,10 +81,11 @@ const (
 var artifactSetArtifacts = map[ArtifactSet][]string{
 	ArtifactSetAll:        nil, // no filtering – download all artifacts
 	ArtifactSetActivation: {constants.ActivationArtifactName},
-	ArtifactSetAgent:      {constants.AgentArtifactName},
-	ArtifactSetMCP:        {constants.AgentArtifactName},
-	ArtifactSetFirewall:   {constants.AgentArtifactName},
-	ArtifactSetDetection:  {constants.DetectionArtifactName},
+	// agent and mcp: transcripts are uploaded separately when artifact-encryption is set.
+	ArtifactSetAgent:     {constants.AgentArtifactName, constants.AgentTranscriptsArtifactName},
+	ArtifactSetMCP:       {constants.AgentArtifactName, constants.AgentTranscriptsArtifactName},
+	ArtifactSetFirewall:  {constants.AgentArtifactName},
+	ArtifactSetDetection: {constants.DetectionArtifactName},
 	// github-api: both jobs upload github_rate_limits.jsonl; fetch both for a complete view.
 	ArtifactSetGitHubAPI: {constants.ActivationArtifactName, constants.AgentArtifactName},
 	// experiment: A/B experiment state uploaded by the activation job.
@@ -123,14 +123,14 @@ func TestResolveArtifactFilter(t *testing.T) {
 			expected: []string{"activation"},
 		},
 		{
-			name:     "agent resolves to agent artifact",
+			name:     "agent resolves to agent and transcripts artifacts",
 			sets:     []string{"agent"},
-			expected: []string{"agent"},
+			expected: []string{"agent", "agent-transcripts"},
 		},
 		{
-			name:     "mcp resolves to agent artifact",
+			name:     "mcp resolves to agent and transcripts artifacts",
 			sets:     []string{"mcp"},
-			expected: []string{"agent"},
+			expected: []string{"agent", "agent-transcripts"},
 		},
 		{
 			name:     "firewall resolves to agent artifact",
@@ -140,7 +140,7 @@ func TestResolveArtifactFilter(t *testing.T) {
 		{
 			name:     "mcp and firewall both deduplicate to single agent",
 			sets:     []string{"mcp", "firewall"},
-			expected: []string{"agent"},
+			expected: []string{"agent", "agent-transcripts"},
 		},
 		{
 			name:     "detection resolves to detection artifact",
@@ -165,12 +165,12 @@ func TestResolveArtifactFilter(t *testing.T) {
 		{
 			name:     "multiple sets are merged and deduplicated",
 			sets:     []string{"activation", "agent"},
-			expected: []string{"activation", "agent"},
+			expected: []string{"activation", "agent", "agent-transcripts"},
 		},
 		{
 			name:     "github-api and agent deduplicates agent",
 			sets:     []string{"github-api", "agent"},
-			expected: []string{"activation", "agent"},
+			expected: []string{"activation", "agent", "agent-transcripts"},
 		},
 	}
 
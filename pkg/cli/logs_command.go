@@ -68,6 +68,10 @@ const logsCommandExampleTemplate = `  # Basic usage
   %[1]s logs --before-run-id 2000      # Filter runs before run ID 2000
   %[1]s logs --after-run-id 1000 --before-run-id 2000  # Filter runs in range
 
+  # Correlation lookup
+  %[1]s logs --correlation 35625281    # Find the run behind an agent comment or issue
+  %[1]s logs --correlation 35625281 --artifacts all --start-date -1mo  # Full artifacts, search the last month
+
   # Artifact selection (default: usage only - the compact conclusion artifact)
   %[1]s logs --artifacts all           # Download all artifacts (agent logs, firewall, etc.)
   %[1]s logs --artifacts agent         # Download only agent logs
@@ -86,6 +90,7 @@ const logsCommandExampleTemplate = `  # Basic usage
   %[1]s logs --format markdown         # Cross-run security audit report (Markdown)
   %[1]s logs --format pretty           # Cross-run security audit report (console)
   %[1]s logs weekly-research --format markdown --last 10  # Cross-run report for last 10 runs
+  %[1]s logs conversation 1234567890   # Engine-independent conversation transcript (markdown, json, html)
   %[1]s logs --train                   # Train log pattern weights from last 10 runs
   %[1]s logs my-workflow --train -c 50 # Train log pattern weights from up to 50 runs of a specific workflow
 
@@ -106,12 +111,14 @@ func NewLogsCommand() *cobra.Command {
 		Short:   "Download and analyze agentic workflow logs and artifacts",
 		Long:    buildLogsCommandLongDescription(validArtifactSets),
 		Example: buildLogsCommandExample(),
+		Args:    cobra.ArbitraryArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runLogsCommand(cmd, args)
 		},
 	}
 	addLogsCommandFlags(logsCmd, validArtifactSets)
 	registerLogsCommandCompletions(logsCmd)
+	logsCmd.AddCommand(NewLogsConversationSubcommand())
 	return logsCmd
 }
 
@@ -133,6 +140,7 @@ Downloaded artifacts include (when using --artifacts all):
 - safe_output.jsonl: Agent's final output content (available when non-empty)
 - agent_output/: Agent logs directory (if the workflow produced logs)
 - agent-stdio.log: Agent standard output/error logs
+- agent-stdout.log, agent-stderr.log: Agent standard output and error captured separately
 - aw.patch: Git patch of changes made during execution (legacy; see aw-{branch}.patch)
 - aw-{branch}.patch: Git patch of changes for each branch (one file per PR/push)
 - workflow-logs/: GitHub Actions workflow run logs (job logs organized in subdirectory)
@@ -234,6 +242,11 @@ func loadCommonLogsOptions(cmd *cobra.Command) (LogsDownloadOptions, error) {
 	if last, _ := cmd.Flags().GetInt("last"); last > 0 {
 		count = last
 	}
+	correlation := getStringFlag(cmd, "correlation")
+	if correlation != "" && !cmd.Flags().Changed("count") && !cmd.Flags().Changed("last") {
+		// A correlation ID identifies a single run.
+		count = 1
+	}
 	startDate, _ := cmd.Flags().GetString("start-date")
 	endDate, _ := cmd.Flags().GetString("end-date")
 	startDate, endDate, err := resolveLogsDateRange(startDate, endDate, time.Now())
@@ -249,6 +262,7 @@ func loadCommonLogsOptions(cmd *cobra.Command) (LogsDownloadOptions, error) {
 		Ref:               getStringFlag(cmd, "ref"),
 		BeforeRunID:       getInt64Flag(cmd, "before-run-id"),
 		AfterRunID:        getInt64Flag(cmd, "after-run-id"),
+		Correlation:       correlation,
 		RepoOverride:      getStringFlag(cmd, "repo"),
 		Verbose:           getBoolFlag(cmd, "verbose"),
 		ToolGraph:         getBoolFlag(cmd, "tool-graph"),
@@ -304,6 +318,9 @@ func validateLogsOptions(options LogsDownloadOptions) error {
 	if err := validateLogsEngine(options.Engine); err != nil {
 		return err
 	}
+	if err := validateCorrelationID(options.Correlation); err != nil {
+		return err
+	}
 	return validateReportFileFlags(options.ReportFile, options.Format, options.JSONOutput)
 }
 
@@ -375,6 +392,7 @@ func addLogsCommandFlags(logsCmd *cobra.Command, validArtifactSets string) {
 	logsCmd.Flags().String("ref", "", "Filter runs by branch or tag name (e.g., main, v1.0.0)")
 	logsCmd.Flags().Int64("before-run-id", 0, "Filter runs with database ID before this value (exclusive)")
 	logsCmd.Flags().Int64("after-run-id", 0, "Filter runs with database ID after this value (exclusive)")
+	logsCmd.Flags().String("correlation", "", "Find the run with this correlation ID (the 8-digit ID shown in the prompt, safe-output footers, and markers); implies --count 1 unless --count is set")
 	addRepoFlag(logsCmd)
 	logsCmd.Flags().Bool("tool-graph", false, "Generate Mermaid tool sequence graph from agent logs")
 	logsCmd.Flags().Bool("exclude-staged", false, "Exclude workflow runs that executed in staged mode (safe outputs previewed but not applied)")
@@ -0,0 +1,634 @@
+// This file provides command-line interface functionality for gh-aw.
+// This file (logs_conversation.go) normalizes engine session logs into a single
+// conversation transcript format.
+//
+// Key responsibilities:
+//   - Locating the agent session log for a downloaded run (events.jsonl or agent-stdio.log)
+//   - Parsing Claude, Copilot, Codex, Gemini and Pi logs into conversation entries
+//   - Aggregating token usage for the transcript
+
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/fileutil"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var logsConversationLog = logger.New("cli:logs_conversation")
+
+// ConversationEntryKind identifies the role of a conversation entry.
+type ConversationEntryKind string
+
+const (
+	// ConversationEntryUser is a prompt or follow-up message sent to the agent.
+	ConversationEntryUser ConversationEntryKind = "user"
+	// ConversationEntryAssistant is text produced by the agent.
+	ConversationEntryAssistant ConversationEntryKind = "assistant"
+	// ConversationEntryToolCall is a tool invocation requested by the agent.
+	ConversationEntryToolCall ConversationEntryKind = "tool_call"
+	// ConversationEntryToolOutput is the result returned to the agent for a tool call.
+	ConversationEntryToolOutput ConversationEntryKind = "tool_output"
+)
+
+// ConversationEntry is a single normalized step of an agent session.
+type ConversationEntry struct {
+	Kind         ConversationEntryKind `json:"kind"`
+	Text         string                `json:"text,omitempty"`
+	Tool         string                `json:"tool,omitempty"`
+	ToolCallID   string                `json:"tool_call_id,omitempty"`
+	Input        string                `json:"input,omitempty"`
+	IsError      bool                  `json:"is_error,omitempty"`
+	InputTokens  int                   `json:"input_tokens,omitempty"`
+	OutputTokens int                   `json:"output_tokens,omitempty"`
+}
+
+// ConversationTokenUsage holds the token totals of a session.
+type ConversationTokenUsage struct {
+	Input      int `json:"input"`
+	Output     int `json:"output"`
+	CacheRead  int `json:"cache_read,omitempty"`
+	CacheWrite int `json:"cache_write,omitempty"`
+	Total      int `json:"total"`
+}
+
+// ConversationTranscript is an engine-independent view of an agent session.
+type ConversationTranscript struct {
+	RunID         int64                  `json:"run_id,omitempty"`
+	CorrelationID string                 `json:"correlation_id,omitempty"`
+	Engine        string                 `json:"engine"`
+	Source        string                 `json:"source"`
+	Tokens        ConversationTokenUsage `json:"tokens"`
+	Entries       []ConversationEntry    `json:"entries"`
+}
+
+// conversationParsers maps engine IDs to the parser for their agent-stdio.log format.
+// Copilot is handled separately because its structured session lives in events.jsonl.
+var conversationParsers = map[string]func(string) ConversationTranscript{
+	"claude": parseClaudeConversation,
+	"codex":  parseCodexConversation,
+	"gemini": parseStreamEventConversation,
+	"pi":     parseStreamEventConversation,
+}
+
+// stdoutTranscriptEngines lists the engines whose session is a JSON event stream on
+// stdout. Their transcript is read from agent-stdout.log, which holds stdout alone,
+// when the run has one; codex reports part of its session on stderr.
+var stdoutTranscriptEngines = map[string]bool{
+	"claude": true,
+	"gemini": true,
+	"pi":     true,
+}
+
+// BuildConversationTranscript builds a conversation transcript from a downloaded run directory.
+// The engine is read from aw_info.json; Copilot sessions are read from events.jsonl and all
+// other engines from agent-stdio.log.
+func BuildConversationTranscript(runDir string, verbose bool) (*ConversationTranscript, error) {
+	engineID := ""
+	correlationID := ""
+	if info, err := parseAwInfo(filepath.Join(runDir, "aw_info.json"), verbose); err == nil {
+		engineID = info.EngineID
+		correlationID = awInfoCorrelationID(info)
+	}
+	logsConversationLog.Printf("Building conversation transcript: run_dir=%s, engine=%s", runDir, engineID)
+
+	if engineID == "copilot" || engineID == "" {
+		if eventsPath := findEventsJSONLFile(runDir); eventsPath != "" {
+			content, err := os.ReadFile(eventsPath)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s: %w", eventsPath, err)
+			}
+			transcript := parseCopilotEventsConversation(string(content))
+			transcript.Engine = "copilot"
+			transcript.CorrelationID = correlationID
+			transcript.Source = eventsPath
+			return &transcript, nil
+		}
+	}
+
+	logPath := findAgentStdioLogPath(runDir)
+	if stdoutTranscriptEngines[engineID] {
+		if stdoutPath := findAgentLogByName(runDir, "agent-stdout.log"); stdoutPath != "" {
+			if info, err := os.Stat(stdoutPath); err == nil && info.Size() > 0 {
+				logPath = stdoutPath
+			}
+		}
+	}
+	if logPath == "" || !fileutil.FileExists(logPath) {
+		return nil, errors.New("no agent session log found (expected events.jsonl or agent-stdio.log); download the agent artifact for this run")
+	}
+	content, err := os.ReadFile(logPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", logPath, err)
+	}
+
+	var transcript ConversationTranscript
+	if parse, ok := conversationParsers[engineID]; ok {
+		transcript = parse(string(content))
+	} else {
+		// Unknown or custom engines: most emit one of the JSON event formats.
+		logsConversationLog.Printf("No conversation parser for engine %q, trying JSON event formats", engineID)
+		transcript = parseClaudeConversation(string(content))
+		if len(transcript.Entries) == 0 {
+			transcript = parseStreamEventConversation(string(content))
+		}
+	}
+	transcript.Engine = engineID
+	transcript.CorrelationID = correlationID
+	transcript.Source = logPath
+	logsConversationLog.Printf("Built transcript: entries=%d, total_tokens=%d", len(transcript.Entries), transcript.Tokens.Total)
+	return &transcript, nil
+}
+
+// forEachJSONLine calls fn for every line of content that decodes as a JSON object into T.
+// Non-JSON lines (runner output, progress messages) are skipped.
+func forEachJSONLine[T any](content string, fn func(T)) {
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), 32*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "{") {
+			continue
+		}
+		var value T
+		if err := json.Unmarshal([]byte(line), &value); err != nil {
+			continue
+		}
+		fn(value)
+	}
+}
+
+// conversationToolInput encodes tool arguments as compact JSON for display.
+func conversationToolInput(input any) string {
+	if input == nil {
+		return ""
+	}
+	if s, ok := input.(string); ok {
+		return s
+	}
+	data, err := json.Marshal(input)
+	if err != nil {
+		return fmt.Sprint(input)
+	}
+	if string(data) == "{}" || string(data) == "null" {
+		return ""
+	}
+	return string(data)
+}
+
+// conversationContentText flattens message content that is either a string or an
+// array of content blocks into plain text.
+func conversationContentText(content any) string {
+	switch v := content.(type) {
+	case string:
+		return v
+	case []any:
+		var parts []string
+		for _, block := range v {
+			if m, ok := block.(map[string]any); ok {
+				if text, ok := m["text"].(string); ok {
+					parts = append(parts, text)
+				}
+			} else if s, ok := block.(string); ok {
+				parts = append(parts, s)
+			}
+		}
+		return strings.Join(parts, "\n")
+	case map[string]any:
+		if text, ok := v["content"].(string); ok {
+			return text
+		}
+		if text, ok := v["text"].(string); ok {
+			return text
+		}
+	}
+	return ""
+}
+
+// appendAssistantText adds assistant text, merging streamed deltas into the previous entry.
+func (t *ConversationTranscript) appendAssistantText(text string, delta bool) {
+	if text == "" {
+		return
+	}
+	if delta && len(t.Entries) > 0 && t.Entries[len(t.Entries)-1].Kind == ConversationEntryAssistant {
+		t.Entries[len(t.Entries)-1].Text += text
+		return
+	}
+	t.Entries = append(t.Entries, ConversationEntry{Kind: ConversationEntryAssistant, Text: text})
+}
+
+// toolNameForCall returns the tool name of an earlier tool call with the given ID.
+func (t *ConversationTranscript) toolNameForCall(id string) string {
+	if id == "" {
+		return ""
+	}
+	for i := len(t.Entries) - 1; i >= 0; i-- {
+		if t.Entries[i].Kind == ConversationEntryToolCall && t.Entries[i].ToolCallID == id {
+			return t.Entries[i].Tool
+		}
+	}
+	return ""
+}
+
+// finalizeTokens fills in the total when only the input and output counts are known.
+func (u *ConversationTokenUsage) finalizeTokens() {
+	if u.Total == 0 {
+		u.Total = u.Input + u.Output + u.CacheRead + u.CacheWrite
+	}
+}
+
+// claudeConversationEvent is a stream-json event emitted by Claude Code.
+type claudeConversationEvent struct {
+	Type    string `json:"type"`
+	Message struct {
+		Role    string `json:"role"`
+		Content any    `json:"content"`
+		Usage   *struct {
+			InputTokens              int `json:"input_tokens"`
+			OutputTokens             int `json:"output_tokens"`
+			CacheReadInputTokens     int `json:"cache_read_input_tokens"`
+			CacheCreationInputTokens int `json:"cache_creation_input_tokens"`
+		} `json:"usage"`
+	} `json:"message"`
+	Usage *struct {
+		InputTokens              int `json:"input_tokens"`
+		OutputTokens             int `json:"output_tokens"`
+		CacheReadInputTokens     int `json:"cache_read_input_tokens"`
+		CacheCreationInputTokens int `json:"cache_creation_input_tokens"`
+	} `json:"usage"`
+}
+
+// parseClaudeConversation parses Claude Code stream-json output (JSONL, or a JSON array
+// of the same events in older logs).
+func parseClaudeConversation(content string) ConversationTranscript {
+	var transcript ConversationTranscript
+	var fromResult bool
+
+	handle := func(event claudeConversationEvent) {
+		switch event.Type {
+		case "user":
+			blocks, ok := event.Message.Content.([]any)
+			if !ok {
+				if text := conversationContentText(event.Message.Content); text != "" {
+					transcript.Entries = append(transcript.Entries, ConversationEntry{Kind: ConversationEntryUser, Text: text})
+				}
+				return
+			}
+			for _, raw := range blocks {
+				block, ok := raw.(map[string]any)
+				if !ok {
+					continue
+				}
+				switch block["type"] {
+				case "tool_result":
+					id, _ := block["tool_use_id"].(string)
+					isError, _ := block["is_error"].(bool)
+					transcript.Entries = append(transcript.Entries, ConversationEntry{
+						Kind:       ConversationEntryToolOutput,
+						Tool:       transcript.toolNameForCall(id),
+						ToolCallID: id,
+						Text:       conversationContentText(block["content"]),
+						IsError:    isError,
+					})
+				case "text":
+					if text, _ := block["text"].(string); text != "" {
+						transcript.Entries = append(transcript.Entries, ConversationEntry{Kind: ConversationEntryUser, Text: text})
+					}
+				}
+			}
+		case "assistant":
+			blocks, _ := event.Message.Content.([]any)
+			start := len(transcript.Entries)
+			for _, raw := range blocks {
+				block, ok := raw.(map[string]any)
+				if !ok {
+					continue
+				}
+				switch block["type"] {
+				case "text":
+					text, _ := block["text"].(string)
+					transcript.appendAssistantText(text, false)
+				case "tool_use":
+					name, _ := block["name"].(string)
+					id, _ := block["id"].(string)
+					transcript.Entries = append(transcript.Entries, ConversationEntry{
+						Kind:       ConversationEntryToolCall,
+						Tool:       name,
+						ToolCallID: id,
+						Input:      conversationToolInput(block["input"]),
+					})
+				}
+			}
+			if usage := event.Message.Usage; usage != nil {
+				if start < len(transcript.Entries) {
+					transcript.Entries[start].InputTokens = usage.InputTokens
+					transcript.Entries[start].OutputTokens = usage.OutputTokens
+				}
+				if !fromResult {
+					transcript.Tokens.Input += usage.InputTokens
+					transcript.Tokens.Output += usage.OutputTokens
+					transcript.Tokens.CacheRead += usage.CacheReadInputTokens
+					transcript.Tokens.CacheWrite += usage.CacheCreationInputTokens
+				}
+			}
+		case "result":
+			// The result event carries the authoritative session totals.
+			if usage := event.Usage; usage != nil {
+				fromResult = true
+				transcript.Tokens = ConversationTokenUsage{
+					Input:      usage.InputTokens,
+					Output:     usage.OutputTokens,
+					CacheRead:  usage.CacheReadInputTokens,
+					CacheWrite: usage.CacheCreationInputTokens,
+				}
+			}
+		}
+	}
+
+	trimmed := strings.TrimSpace(content)
+	var events []claudeConversationEvent
+	if strings.HasPrefix(trimmed, "[") && json.Unmarshal([]byte(trimmed), &events) == nil {
+		for _, event := range events {
+			handle(event)
+		}
+	} else {
+		forEachJSONLine(content, handle)
+	}
+	transcript.Tokens.finalizeTokens()
+	return transcript
+}
+
+// streamConversationEvent is a stream-json event emitted by the Gemini and Pi CLIs.
+type streamConversationEvent struct {
+	Type       string         `json:"type"`
+	Role       string         `json:"role"`
+	Content    any            `json:"content"`
+	Delta      bool           `json:"delta"`
+	ToolName   string         `json:"tool_name"`
+	ToolID     string         `json:"tool_id"`
+	Parameters any            `json:"parameters"`
+	Status     string         `json:"status"`
+	Output     any            `json:"output"`
+	Response   string         `json:"response"`
+	Stats      map[string]any `json:"stats"`
+	Error      *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// parseStreamEventConversation parses Gemini and Pi stream-json output. The legacy
+// single-response Gemini format ({"response": ..., "stats": ...}) is also accepted.
+func parseStreamEventConversation(content string) ConversationTranscript {
+	var transcript ConversationTranscript
+
+	forEachJSONLine(content, func(event streamConversationEvent) {
+		switch event.Type {
+		case "message", "assistant":
+			text := conversationContentText(event.Content)
+			if event.Type == "message" && event.Role != "assistant" {
+				if event.Role == "user" && text != "" {
+					transcript.Entries = append(transcript.Entries, ConversationEntry{Kind: ConversationEntryUser, Text: text})
+				}
+				return
+			}
+			transcript.appendAssistantText(text, event.Delta)
+		case "tool_use":
+			transcript.Entries = append(transcript.Entries, ConversationEntry{
+				Kind:       ConversationEntryToolCall,
+				Tool:       event.ToolName,
+				ToolCallID: event.ToolID,
+				Input:      conversationToolInput(event.Parameters),
+			})
+		case "tool_result":
+			text := conversationContentText(event.Output)
+			if text == "" && event.Error != nil {
+				text = event.Error.Message
+			}
+			transcript.Entries = append(transcript.Entries, ConversationEntry{
+				Kind:       ConversationEntryToolOutput,
+				Tool:       transcript.toolNameForCall(event.ToolID),
+				ToolCallID: event.ToolID,
+				Text:       text,
+				IsError:    event.Status == "error",
+			})
+		case "result":
+			applyStreamConversationStats(event.Stats, &transcript.Tokens)
+		case "":
+			if event.Response != "" {
+				transcript.appendAssistantText(event.Response, false)
+			}
+			applyStreamConversationStats(event.Stats, &transcript.Tokens)
+		}
+	})
+	transcript.Tokens.finalizeTokens()
+	return transcript
+}
+
+// applyStreamConversationStats reads token totals from a Gemini or Pi stats object,
+// which is either flat or nested per model under "models".
+func applyStreamConversationStats(stats map[string]any, usage *ConversationTokenUsage) {
+	if stats == nil {
+		return
+	}
+	read := func(m map[string]any, key string) int {
+		v, _ := m[key].(float64)
+		return int(v)
+	}
+	if models, ok := stats["models"].(map[string]any); ok {
+		for _, raw := range models {
+			if m, ok := raw.(map[string]any); ok {
+				usage.Input += read(m, "input_tokens")
+				usage.Output += read(m, "output_tokens")
+			}
+		}
+		return
+	}
+	usage.Input += read(stats, "input_tokens")
+	usage.Output += read(stats, "output_tokens")
+	usage.CacheRead += read(stats, "cached")
+	usage.Total += read(stats, "total_tokens")
+}
+
+var (
+	codexTimestampPrefix = regexp.MustCompile(`^\[[^\]]+\]\s*`)
+	codexExecHeader      = regexp.MustCompile(`^exec (.+?) in \S+`)
+	codexToolHeader      = regexp.MustCompile(`^tool (\S+?)\((.*)\)\s*$`)
+	codexToolResult      = regexp.MustCompile(`(succeeded|success|failed|failure|exited -?\d+) in [\d.]+m?s:?\s*$`)
+	codexTokensUsed      = regexp.MustCompile(`(?i)^tokens used:?\s*([\d,]+)`)
+)
+
+// parseCodexConversation parses the plain-text Codex CLI transcript. Codex prints a
+// header line ("user", "codex", "thinking", "exec …", "tool …") before each block.
+func parseCodexConversation(content string) ConversationTranscript {
+	var transcript ConversationTranscript
+	var current *ConversationEntry
+	var skipping bool
+	var lastTool string
+
+	flush := func() {
+		if current != nil {
+			current.Text = strings.TrimSpace(current.Text)
+			if current.Text != "" || current.Kind == ConversationEntryToolCall {
+				transcript.Entries = append(transcript.Entries, *current)
+			}
+		}
+		current = nil
+		skipping = false
+	}
+
+	for rawLine := range strings.SplitSeq(content, "\n") {
+		line := strings.TrimRight(rawLine, "\r")
+		header := strings.TrimSpace(codexTimestampPrefix.ReplaceAllString(line, ""))
+
+		switch {
+		case header == "user":
+			flush()
+			current = &ConversationEntry{Kind: ConversationEntryUser}
+			continue
+		case header == "codex":
+			flush()
+			current = &ConversationEntry{Kind: ConversationEntryAssistant}
+			continue
+		case header == "thinking":
+			// Reasoning summaries are not part of the conversation.
+			flush()
+			skipping = true
+			continue
+		case codexTokensUsed.MatchString(header):
+			flush()
+			if m := codexTokensUsed.FindStringSubmatch(header); m != nil {
+				if total, err := strconv.Atoi(strings.ReplaceAll(m[1], ",", "")); err == nil {
+					transcript.Tokens.Total = total
+				}
+			}
+			skipping = true
+			continue
+		}
+		if m := codexExecHeader.FindStringSubmatch(header); m != nil {
+			flush()
+			lastTool = "exec"
+			transcript.Entries = append(transcript.Entries, ConversationEntry{Kind: ConversationEntryToolCall, Tool: lastTool, Input: m[1]})
+			continue
+		}
+		if m := codexToolHeader.FindStringSubmatch(header); m != nil {
+			flush()
+			lastTool = m[1]
+			transcript.Entries = append(transcript.Entries, ConversationEntry{Kind: ConversationEntryToolCall, Tool: lastTool, Input: m[2]})
+			continue
+		}
+		if m := codexToolResult.FindStringSubmatch(header); m != nil && lastTool != "" {
+			flush()
+			status := m[1]
+			current = &ConversationEntry{
+				Kind:    ConversationEntryToolOutput,
+				Tool:    lastTool,
+				IsError: !strings.HasPrefix(status, "succe") && status != "exited 0",
+			}
+			continue
+		}
+		if skipping || current == nil {
+			continue
+		}
+		current.Text += line + "\n"
+	}
+	flush()
+	transcript.Tokens.finalizeTokens()
+	return transcript
+}
+
+// copilotConversationEvent is an event from a Copilot CLI events.jsonl session log.
+type copilotConversationEvent struct {
+	Type string `json:"type"`
+	Data struct {
+		Content    string         `json:"content"`
+		ToolCallID string         `json:"toolCallId"`
+		ToolName   string         `json:"toolName"`
+		Arguments  any            `json:"arguments"`
+		Command    string         `json:"command"`
+		Success    *bool          `json:"success"`
+		Result     any            `json:"result"`
+		Usage      map[string]any `json:"usage"`
+		// session.shutdown per-model totals
+		ModelMetrics map[string]*copilotModelMetrics `json:"modelMetrics"`
+	} `json:"data"`
+}
+
+// parseCopilotEventsConversation parses a Copilot CLI events.jsonl session log.
+func parseCopilotEventsConversation(content string) ConversationTranscript {
+	var transcript ConversationTranscript
+	var fromShutdown bool
+
+	forEachJSONLine(content, func(event copilotConversationEvent) {
+		switch event.Type {
+		case "user.message":
+			if event.Data.Content != "" {
+				transcript.Entries = append(transcript.Entries, ConversationEntry{Kind: ConversationEntryUser, Text: event.Data.Content})
+			}
+		case "assistant.message":
+			if event.Data.Content == "" {
+				return
+			}
+			entry := ConversationEntry{Kind: ConversationEntryAssistant, Text: event.Data.Content}
+			if event.Data.Usage != nil {
+				in, _ := event.Data.Usage["input_tokens"].(float64)
+				out, _ := event.Data.Usage["output_tokens"].(float64)
+				entry.InputTokens, entry.OutputTokens = int(in), int(out)
+				if !fromShutdown {
+					transcript.Tokens.Input += entry.InputTokens
+					transcript.Tokens.Output += entry.OutputTokens
+				}
+			}
+			transcript.Entries = append(transcript.Entries, entry)
+		case "tool.execution_start":
+			input := conversationToolInput(event.Data.Arguments)
+			if input == "" {
+				input = event.Data.Command
+			}
+			transcript.Entries = append(transcript.Entries, ConversationEntry{
+				Kind:       ConversationEntryToolCall,
+				Tool:       event.Data.ToolName,
+				ToolCallID: event.Data.ToolCallID,
+				Input:      input,
+			})
+		case "tool.execution_complete":
+			tool := event.Data.ToolName
+			if tool == "" || tool == "unknown" {
+				tool = transcript.toolNameForCall(event.Data.ToolCallID)
+			}
+			transcript.Entries = append(transcript.Entries, ConversationEntry{
+				Kind:       ConversationEntryToolOutput,
+				Tool:       tool,
+				ToolCallID: event.Data.ToolCallID,
+				Text:       conversationContentText(event.Data.Result),
+				IsError:    event.Data.Success != nil && !*event.Data.Success,
+			})
+		case "session.shutdown":
+			// Shutdown model metrics are the authoritative session totals.
+			var usage ConversationTokenUsage
+			for _, metrics := range event.Data.ModelMetrics {
+				if metrics == nil || metrics.Usage == nil {
+					continue
+				}
+				usage.Input += metrics.Usage.InputTokens
+				usage.Output += metrics.Usage.OutputTokens
+				usage.CacheRead += metrics.Usage.CacheReadTokens
+				usage.CacheWrite += metrics.Usage.CacheWriteTokens
+			}
+			if usage.Input+usage.Output > 0 {
+				fromShutdown = true
+				transcript.Tokens = usage
+			}
+		}
+	})
+	transcript.Tokens.finalizeTokens()
+	return transcript
+}
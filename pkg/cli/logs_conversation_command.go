@@ -0,0 +1,138 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/spf13/cobra"
+)
+
+// NewLogsConversationSubcommand creates the logs conversation subcommand.
+func NewLogsConversationSubcommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "conversation <run-id-or-url>",
+		Short: "Render the agent conversation of a workflow run in an engine-independent format",
+		Long: `Download the agent artifacts of a workflow run and render the agent session as a
+single conversation transcript: user messages, assistant turns, tool calls, tool
+outputs, and token counts.
+
+Claude, Copilot, Codex, Gemini, and Pi session logs are normalized into the same
+format, so a run can be analyzed without knowing each engine's log schema.
+
+Output formats (--format):
+  - markdown  Readable transcript (default)
+  - json      Structured transcript for scripts and agents
+  - html      Self-contained page for sharing or archiving
+
+Tool outputs longer than 4000 characters are truncated in markdown and html
+output; json output always contains the full text.`,
+		Example: `  ` + string(constants.CLIExtensionPrefix) + ` logs conversation 1234567890                       # Markdown transcript
+  ` + string(constants.CLIExtensionPrefix) + ` logs conversation 1234567890 --format json         # JSON transcript
+  ` + string(constants.CLIExtensionPrefix) + ` logs conversation 1234567890 --format html > run.html  # Shareable HTML page
+  ` + string(constants.CLIExtensionPrefix) + ` logs conversation https://github.com/owner/repo/actions/runs/1234567890`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			verbose, _ := cmd.Flags().GetBool("verbose")
+			outputDir, _ := cmd.Flags().GetString("output")
+			repoFlag, _ := cmd.Flags().GetString("repo")
+			format, _ := cmd.Flags().GetString("format")
+
+			if !slices.Contains(conversationFormats, format) {
+				return fmt.Errorf("invalid --format %q: expected one of %s", format, strings.Join(conversationFormats, ", "))
+			}
+
+			components, err := parser.ParseRunURLExtended(args[0])
+			if err != nil {
+				return err
+			}
+			if repoFlag != "" && components.Owner == "" {
+				parts := strings.SplitN(repoFlag, "/", 2)
+				if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+					return fmt.Errorf("invalid repository format %q: expected 'owner/repo'", repoFlag)
+				}
+				components.Owner = parts[0]
+				components.Repo = parts[1]
+			}
+
+			return RunLogsConversation(cmd.Context(), components.Number, LogsConversationOptions{
+				Owner:     components.Owner,
+				Repo:      components.Repo,
+				Hostname:  components.Host,
+				OutputDir: outputDir,
+				Format:    format,
+				Verbose:   verbose,
+			})
+		},
+	}
+
+	addOutputFlag(cmd, defaultLogsOutputDir)
+	addRepoFlag(cmd)
+	cmd.Flags().String("format", "markdown", "Output format: "+strings.Join(conversationFormats, ", "))
+	RegisterDirFlagCompletion(cmd, "output")
+
+	return cmd
+}
+
+// LogsConversationOptions holds configuration for the logs conversation subcommand.
+type LogsConversationOptions struct {
+	Owner     string
+	Repo      string
+	Hostname  string
+	OutputDir string
+	Format    string
+	Verbose   bool
+}
+
+// RunLogsConversation downloads the agent artifacts of a run (if not already cached)
+// and prints its conversation transcript to stdout.
+func RunLogsConversation(ctx context.Context, runID int64, opts LogsConversationOptions) error {
+	logsConversationLog.Printf("Rendering conversation for run %d: format=%s", runID, opts.Format)
+
+	hostname := opts.Hostname
+	if hostname == "" {
+		hostname = getHostFromOriginRemote()
+	}
+	if opts.OutputDir == "" {
+		opts.OutputDir = defaultLogsOutputDir
+	}
+	runDir := filepath.Join(opts.OutputDir, fmt.Sprintf("run-%d", runID))
+	if absDir, err := filepath.Abs(runDir); err == nil {
+		runDir = absDir
+	}
+
+	// aw_info.json (engine) lives in the activation artifact and the session logs in
+	// the agent artifact; nothing else is needed for the transcript.
+	artifactFilter := ResolveArtifactFilter([]string{string(ArtifactSetActivation), string(ArtifactSetAgent)})
+	if err := downloadRunArtifacts(ctx, downloadArtifactsOptions{runID: runID, outputDir: runDir, verbose: opts.Verbose, owner: opts.Owner, repo: opts.Repo, hostname: hostname, artifactFilter: artifactFilter}); err != nil {
+		if !errors.Is(err, ErrNoArtifacts) {
+			return fmt.Errorf("failed to download artifacts for run %d: %w", runID, err)
+		}
+		if opts.Verbose {
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage("No artifacts attached to this run."))
+		}
+	}
+
+	transcript, err := BuildConversationTranscript(runDir, opts.Verbose)
+	if err != nil {
+		return fmt.Errorf("failed to build conversation for run %d: %w", runID, err)
+	}
+	transcript.RunID = runID
+	if opts.Verbose {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Read %d conversation entries from %s", len(transcript.Entries), transcript.Source)))
+	}
+
+	output, err := RenderConversationTranscript(transcript, opts.Format)
+	if err != nil {
+		return err
+	}
+	fmt.Print(output)
+	return nil
+}
@@ -0,0 +1,181 @@
+// This file provides command-line interface functionality for gh-aw.
+// This file (logs_conversation_render.go) renders conversation transcripts
+// produced by logs_conversation.go.
+//
+// Key responsibilities:
+//   - Rendering transcripts as JSON, Markdown, or a self-contained HTML page
+//   - Truncating long tool outputs in human-readable formats
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/stringutil"
+)
+
+// conversationFormats lists the supported values of --format for logs conversation.
+var conversationFormats = []string{"json", "markdown", "html"}
+
+// maxConversationToolOutputChars caps tool outputs in Markdown and HTML transcripts.
+// JSON output always contains the full text.
+const maxConversationToolOutputChars = 4000
+
+// RenderConversationTranscript renders a transcript in the given format (json, markdown, or html).
+func RenderConversationTranscript(transcript *ConversationTranscript, format string) (string, error) {
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(transcript, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal conversation transcript: %w", err)
+		}
+		return string(data) + "\n", nil
+	case "markdown", "":
+		return renderConversationMarkdown(transcript), nil
+	case "html":
+		return renderConversationHTML(transcript), nil
+	default:
+		return "", fmt.Errorf("unsupported conversation format %q: expected one of %s", format, strings.Join(conversationFormats, ", "))
+	}
+}
+
+// conversationTitle returns the heading used by the Markdown and HTML renderers.
+func conversationTitle(transcript *ConversationTranscript) string {
+	title := "Agent conversation"
+	if transcript.RunID > 0 {
+		title += fmt.Sprintf(" for run %d", transcript.RunID)
+	}
+	if transcript.CorrelationID != "" {
+		title += " [correlation " + transcript.CorrelationID + "]"
+	}
+	if transcript.Engine != "" {
+		title += " (" + transcript.Engine + ")"
+	}
+	return title
+}
+
+// conversationTokenSummary returns a one-line token usage summary.
+func conversationTokenSummary(tokens ConversationTokenUsage) string {
+	parts := []string{
+		console.FormatTokens(tokens.Total) + " total",
+		console.FormatTokens(tokens.Input) + " input",
+		console.FormatTokens(tokens.Output) + " output",
+	}
+	if tokens.CacheRead > 0 {
+		parts = append(parts, console.FormatTokens(tokens.CacheRead)+" cache read")
+	}
+	if tokens.CacheWrite > 0 {
+		parts = append(parts, console.FormatTokens(tokens.CacheWrite)+" cache write")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// conversationEntryHeading returns the heading for a single entry.
+func conversationEntryHeading(entry ConversationEntry) string {
+	switch entry.Kind {
+	case ConversationEntryUser:
+		return "User"
+	case ConversationEntryAssistant:
+		heading := "Assistant"
+		if entry.InputTokens > 0 || entry.OutputTokens > 0 {
+			heading += fmt.Sprintf(" (%s in, %s out)", console.FormatTokens(entry.InputTokens), console.FormatTokens(entry.OutputTokens))
+		}
+		return heading
+	case ConversationEntryToolCall:
+		return "Tool call: " + entry.Tool
+	case ConversationEntryToolOutput:
+		heading := "Tool output"
+		if entry.Tool != "" {
+			heading += ": " + entry.Tool
+		}
+		if entry.IsError {
+			heading += " (error)"
+		}
+		return heading
+	}
+	return string(entry.Kind)
+}
+
+// markdownFence returns a code fence long enough not to clash with backticks in text.
+func markdownFence(text string) string {
+	fence := "```"
+	for strings.Contains(text, fence) {
+		fence += "`"
+	}
+	return fence
+}
+
+func renderConversationMarkdown(transcript *ConversationTranscript) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %s\n\n", conversationTitle(transcript))
+	fmt.Fprintf(&sb, "**Tokens:** %s\n\n", conversationTokenSummary(transcript.Tokens))
+	if len(transcript.Entries) == 0 {
+		sb.WriteString("_No conversation entries found._\n")
+		return sb.String()
+	}
+	for _, entry := range transcript.Entries {
+		fmt.Fprintf(&sb, "### %s\n\n", conversationEntryHeading(entry))
+		switch entry.Kind {
+		case ConversationEntryToolCall:
+			if entry.Input != "" {
+				fence := markdownFence(entry.Input)
+				fmt.Fprintf(&sb, "%s\n%s\n%s\n\n", fence, entry.Input, fence)
+			}
+		case ConversationEntryToolOutput:
+			text := stringutil.Truncate(entry.Text, maxConversationToolOutputChars)
+			if text == "" {
+				sb.WriteString("_(no output)_\n\n")
+				continue
+			}
+			fence := markdownFence(text)
+			fmt.Fprintf(&sb, "%s\n%s\n%s\n\n", fence, text, fence)
+		default:
+			fmt.Fprintf(&sb, "%s\n\n", strings.TrimSpace(entry.Text))
+		}
+	}
+	return sb.String()
+}
+
+// conversationHTMLStyle keeps the HTML transcript self-contained.
+const conversationHTMLStyle = `body{font-family:-apple-system,BlinkMacSystemFont,"Segoe UI",Helvetica,Arial,sans-serif;max-width:960px;margin:2rem auto;padding:0 1rem;color:#1f2328}
+.entry{border:1px solid #d0d7de;border-radius:6px;margin:0.75rem 0;padding:0.5rem 0.75rem}
+.entry h3{font-size:0.9rem;margin:0 0 0.5rem;color:#59636e}
+.user{background:#ddf4ff}.assistant{background:#fff}.tool_call{background:#f6f8fa}.tool_output{background:#f6f8fa}.error{border-color:#cf222e}
+pre{white-space:pre-wrap;word-break:break-word;margin:0;font-size:0.85rem}
+.text{white-space:pre-wrap}`
+
+func renderConversationHTML(transcript *ConversationTranscript) string {
+	title := html.EscapeString(conversationTitle(transcript))
+	var sb strings.Builder
+	sb.WriteString("<!DOCTYPE html>\n<html lang=\"en\">\n<head>\n<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(&sb, "<title>%s</title>\n<style>%s</style>\n</head>\n<body>\n", title, conversationHTMLStyle)
+	fmt.Fprintf(&sb, "<h1>%s</h1>\n", title)
+	fmt.Fprintf(&sb, "<p><strong>Tokens:</strong> %s</p>\n", html.EscapeString(conversationTokenSummary(transcript.Tokens)))
+	if len(transcript.Entries) == 0 {
+		sb.WriteString("<p><em>No conversation entries found.</em></p>\n")
+	}
+	for _, entry := range transcript.Entries {
+		class := string(entry.Kind)
+		if entry.IsError {
+			class += " error"
+		}
+		fmt.Fprintf(&sb, "<div class=\"entry %s\">\n<h3>%s</h3>\n", class, html.EscapeString(conversationEntryHeading(entry)))
+		switch entry.Kind {
+		case ConversationEntryToolCall:
+			if entry.Input != "" {
+				fmt.Fprintf(&sb, "<pre>%s</pre>\n", html.EscapeString(entry.Input))
+			}
+		case ConversationEntryToolOutput:
+			fmt.Fprintf(&sb, "<pre>%s</pre>\n", html.EscapeString(stringutil.Truncate(entry.Text, maxConversationToolOutputChars)))
+		default:
+			fmt.Fprintf(&sb, "<div class=\"text\">%s</div>\n", html.EscapeString(strings.TrimSpace(entry.Text)))
+		}
+		sb.WriteString("</div>\n")
+	}
+	sb.WriteString("</body>\n</html>\n")
+	return sb.String()
+}
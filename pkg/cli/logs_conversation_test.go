@@ -0,0 +1,153 @@
+//go:build !integration
+
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseClaudeConversation(t *testing.T) {
+	log := strings.Join([]string{
+		`{"type":"system","subtype":"init"}`,
+		`{"type":"assistant","message":{"content":[{"type":"text","text":"Running the tests."},{"type":"tool_use","id":"tu1","name":"Bash","input":{"command":"go test ./..."}}],"usage":{"input_tokens":100,"output_tokens":20}}}`,
+		`{"type":"user","message":{"content":[{"type":"tool_result","tool_use_id":"tu1","content":"FAIL","is_error":true}]}}`,
+		`{"type":"result","usage":{"input_tokens":300,"output_tokens":50,"cache_read_input_tokens":10}}`,
+		"not json",
+	}, "\n")
+
+	transcript := parseClaudeConversation(log)
+
+	require.Len(t, transcript.Entries, 3, "expected assistant text, tool call and tool output")
+	assert.Equal(t, ConversationEntryAssistant, transcript.Entries[0].Kind, "first entry should be assistant text")
+	assert.Equal(t, 100, transcript.Entries[0].InputTokens, "assistant entry should carry message usage")
+	assert.Equal(t, ConversationEntry{Kind: ConversationEntryToolCall, Tool: "Bash", ToolCallID: "tu1", Input: `{"command":"go test ./..."}`}, transcript.Entries[1], "unexpected tool call")
+	assert.Equal(t, "Bash", transcript.Entries[2].Tool, "tool output should be linked to its call")
+	assert.True(t, transcript.Entries[2].IsError, "tool output should be marked as an error")
+	assert.Equal(t, ConversationTokenUsage{Input: 300, Output: 50, CacheRead: 10, Total: 360}, transcript.Tokens, "result usage should be authoritative")
+}
+
+func TestParseStreamEventConversation(t *testing.T) {
+	log := strings.Join([]string{
+		`{"type":"init","model":"gemini-2.5-pro"}`,
+		`{"type":"message","role":"user","content":"Triage the issue"}`,
+		`{"type":"message","role":"assistant","content":"Looking ","delta":true}`,
+		`{"type":"message","role":"assistant","content":"at it.","delta":true}`,
+		`{"type":"tool_use","tool_name":"read_file","tool_id":"t1","parameters":{"file_path":"README.md"}}`,
+		`{"type":"tool_result","tool_id":"t1","status":"success","output":"# Project"}`,
+		`{"type":"result","stats":{"input_tokens":40,"output_tokens":8,"total_tokens":48}}`,
+	}, "\n")
+
+	transcript := parseStreamEventConversation(log)
+
+	require.Len(t, transcript.Entries, 4, "deltas should be merged into one assistant entry")
+	assert.Equal(t, ConversationEntryUser, transcript.Entries[0].Kind, "first entry should be the user message")
+	assert.Equal(t, "Looking at it.", transcript.Entries[1].Text, "deltas should be concatenated")
+	assert.Equal(t, "read_file", transcript.Entries[3].Tool, "tool output should be linked to its call")
+	assert.Equal(t, "# Project", transcript.Entries[3].Text, "unexpected tool output")
+	assert.Equal(t, 48, transcript.Tokens.Total, "unexpected total tokens")
+}
+
+func TestParseCodexConversation(t *testing.T) {
+	log := strings.Join([]string{
+		"[2026-01-01T00:00:00] OpenAI Codex v0.1",
+		"[2026-01-01T00:00:01] user",
+		"Fix the build",
+		"[2026-01-01T00:00:02] thinking",
+		"**Planning the fix**",
+		"[2026-01-01T00:00:03] exec bash -lc 'go build ./...' in /workspace",
+		"[2026-01-01T00:00:04] bash -lc 'go build ./...' exited 1 in 1.2s:",
+		"main.go:3: undefined: foo",
+		"[2026-01-01T00:00:05] codex",
+		"The build fails because foo is undefined.",
+		"[2026-01-01T00:00:06] tokens used: 1,234",
+	}, "\n")
+
+	transcript := parseCodexConversation(log)
+
+	require.Len(t, transcript.Entries, 4, "expected user, tool call, tool output and assistant entries")
+	assert.Equal(t, ConversationEntry{Kind: ConversationEntryUser, Text: "Fix the build"}, transcript.Entries[0], "unexpected user entry")
+	assert.Equal(t, ConversationEntry{Kind: ConversationEntryToolCall, Tool: "exec", Input: "bash -lc 'go build ./...'"}, transcript.Entries[1], "unexpected tool call")
+	assert.True(t, transcript.Entries[2].IsError, "non-zero exit should be an error")
+	assert.Equal(t, "main.go:3: undefined: foo", transcript.Entries[2].Text, "unexpected tool output")
+	assert.Equal(t, "The build fails because foo is undefined.", transcript.Entries[3].Text, "unexpected assistant text")
+	assert.Equal(t, 1234, transcript.Tokens.Total, "unexpected total tokens")
+}
+
+func TestParseCopilotEventsConversation(t *testing.T) {
+	log := strings.Join([]string{
+		`{"type":"session.start","data":{"sessionId":"s1"}}`,
+		`{"type":"user.message","data":{"content":"Summarize the PR"}}`,
+		`{"type":"tool.execution_start","data":{"toolCallId":"c1","toolName":"bash","arguments":{"command":"git log -1"}}}`,
+		`{"type":"tool.execution_complete","data":{"toolCallId":"c1","toolName":"unknown","success":true,"result":{"content":"abc123 Fix"}}}`,
+		`{"type":"assistant.message","data":{"content":"The PR fixes a bug.","usage":{"input_tokens":70,"output_tokens":7}}}`,
+		`{"type":"session.shutdown","data":{"modelMetrics":{"gpt-5":{"usage":{"inputTokens":90,"outputTokens":9,"cacheReadTokens":30}}}}}`,
+	}, "\n")
+
+	transcript := parseCopilotEventsConversation(log)
+
+	require.Len(t, transcript.Entries, 4, "expected user, tool call, tool output and assistant entries")
+	assert.Equal(t, "bash", transcript.Entries[2].Tool, "tool output should resolve the tool name from its call")
+	assert.Equal(t, "abc123 Fix", transcript.Entries[2].Text, "unexpected tool output")
+	assert.False(t, transcript.Entries[2].IsError, "successful tool output should not be an error")
+	assert.Equal(t, 7, transcript.Entries[3].OutputTokens, "assistant entry should carry usage")
+	assert.Equal(t, ConversationTokenUsage{Input: 90, Output: 9, CacheRead: 30, Total: 129}, transcript.Tokens, "shutdown metrics should be authoritative")
+}
+
+func TestBuildConversationTranscript(t *testing.T) {
+	runDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(runDir, "aw_info.json"), []byte(`{"engine_id":"claude","repository":"github/gh-aw","run_id":1234567890}`), 0o600), "failed to write aw_info.json")
+	agentDir := filepath.Join(runDir, "agent")
+	require.NoError(t, os.MkdirAll(agentDir, 0o755), "failed to create agent dir")
+	log := `{"type":"assistant","message":{"content":[{"type":"text","text":"Done."}]}}`
+	require.NoError(t, os.WriteFile(filepath.Join(agentDir, "agent-stdio.log"), []byte(log), 0o600), "failed to write agent log")
+
+	transcript, err := BuildConversationTranscript(runDir, false)
+	require.NoError(t, err, "transcript should be built")
+	assert.Equal(t, "claude", transcript.Engine, "engine should come from aw_info.json")
+	assert.Equal(t, "35625281", transcript.CorrelationID, "correlation ID should be derived from aw_info.json")
+	require.Len(t, transcript.Entries, 1, "expected one entry")
+	assert.Equal(t, "Done.", transcript.Entries[0].Text, "unexpected entry text")
+
+	_, err = BuildConversationTranscript(t.TempDir(), false)
+	assert.Error(t, err, "missing session logs should be reported")
+}
+
+func TestRenderConversationTranscript(t *testing.T) {
+	transcript := &ConversationTranscript{
+		RunID:  42,
+		Engine: "claude",
+		Tokens: ConversationTokenUsage{Input: 1500, Output: 200, Total: 1700},
+		Entries: []ConversationEntry{
+			{Kind: ConversationEntryUser, Text: "Check <main>"},
+			{Kind: ConversationEntryToolCall, Tool: "Bash", Input: `{"command":"ls"}`},
+			{Kind: ConversationEntryToolOutput, Tool: "Bash", Text: "```go\nx\n```", IsError: true},
+		},
+	}
+
+	markdown, err := RenderConversationTranscript(transcript, "markdown")
+	require.NoError(t, err, "markdown rendering should succeed")
+	assert.Contains(t, markdown, "# Agent conversation for run 42 (claude)", "markdown should have a title")
+	assert.Contains(t, markdown, "### Tool output: Bash (error)", "markdown should label tool errors")
+	assert.Contains(t, markdown, "````\n```go", "fences should not clash with backticks in output")
+
+	htmlOut, err := RenderConversationTranscript(transcript, "html")
+	require.NoError(t, err, "html rendering should succeed")
+	assert.Contains(t, htmlOut, "Check &lt;main&gt;", "html should escape text")
+	assert.Contains(t, htmlOut, `class="entry tool_output error"`, "html should mark tool errors")
+
+	jsonOut, err := RenderConversationTranscript(transcript, "json")
+	require.NoError(t, err, "json rendering should succeed")
+	var decoded ConversationTranscript
+	require.NoError(t, json.Unmarshal([]byte(jsonOut), &decoded), "json output should be valid")
+	assert.Equal(t, *transcript, decoded, "json output should round-trip")
+
+	_, err = RenderConversationTranscript(transcript, "yaml")
+	assert.Error(t, err, "unsupported formats should be rejected")
+}
@@ -0,0 +1,90 @@
+// This file provides command-line interface functionality for gh-aw.
+// This file (logs_correlation.go) computes run correlation IDs: short numeric IDs
+// that compiled workflows print in the prompt, safe-output markers and footers,
+// aw_info.json, and the MCP gateway startup log.
+//
+// The ID is derived from the repository and run ID, so `gh aw logs --correlation`
+// resolves it by recomputing the ID of each listed run without downloading any
+// artifacts. Keep computeCorrelationID in sync with
+// actions/setup/js/correlation_id.cjs.
+
+package cli
+
+import (
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+)
+
+var logsCorrelationLog = logger.New("cli:logs_correlation")
+
+// correlationIDDigits is the number of decimal digits in a correlation ID.
+const correlationIDDigits = 8
+
+var correlationIDPattern = regexp.MustCompile(`^[0-9]{8}$`)
+
+// computeCorrelationID returns the correlation ID of a run: the 32-bit FNV-1a hash of
+// "<owner>/<repo>/<run_id>" (repository lowercased) reduced to eight digits.
+// Returns "" when the repository or run ID is unknown.
+func computeCorrelationID(repository string, runID int64) string {
+	if repository == "" || runID <= 0 {
+		return ""
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(strings.ToLower(repository) + "/" + strconv.FormatInt(runID, 10)))
+	return fmt.Sprintf("%0*d", correlationIDDigits, h.Sum32()%100_000_000)
+}
+
+// workflowRunCorrelationID returns the correlation ID of a listed workflow run,
+// reading the repository from the run URL.
+func workflowRunCorrelationID(run WorkflowRun) string {
+	components, err := parser.ParseRunURLExtended(run.URL)
+	if err != nil || components.Owner == "" || components.Repo == "" {
+		return ""
+	}
+	return computeCorrelationID(components.Owner+"/"+components.Repo, run.DatabaseID)
+}
+
+// awInfoCorrelationID returns the correlation ID recorded in aw_info.json, or computes
+// it from the recorded repository and run ID for runs compiled before it was recorded.
+func awInfoCorrelationID(info *AwInfo) string {
+	if info == nil {
+		return ""
+	}
+	if info.CorrelationID != "" {
+		return info.CorrelationID
+	}
+	var runID int64
+	switch v := info.RunID.(type) {
+	case float64:
+		runID = int64(v)
+	case string:
+		runID, _ = strconv.ParseInt(v, 10, 64)
+	}
+	return computeCorrelationID(info.Repository, runID)
+}
+
+// validateCorrelationID checks the --correlation flag value.
+func validateCorrelationID(id string) error {
+	if id == "" || correlationIDPattern.MatchString(id) {
+		return nil
+	}
+	return fmt.Errorf("invalid --correlation %q: expected an %d-digit correlation ID as shown in the run's prompt or safe-output footer", id, correlationIDDigits)
+}
+
+// filterRunsByCorrelationID keeps the runs whose correlation ID matches id.
+func filterRunsByCorrelationID(runs []WorkflowRun, id string) []WorkflowRun {
+	var matched []WorkflowRun
+	for _, run := range runs {
+		if workflowRunCorrelationID(run) == id {
+			matched = append(matched, run)
+		}
+	}
+	logsCorrelationLog.Printf("Correlation filter %s matched %d of %d runs", id, len(matched), len(runs))
+	return matched
+}
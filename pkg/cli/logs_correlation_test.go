@@ -0,0 +1,50 @@
+//go:build !integration
+
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeCorrelationID(t *testing.T) {
+	// Vectors shared with actions/setup/js/correlation_id.test.cjs.
+	assert.Equal(t, "35625281", computeCorrelationID("github/gh-aw", 1234567890), "unexpected correlation ID")
+	assert.Equal(t, "35625281", computeCorrelationID("GitHub/gh-aw", 1234567890), "repository should be compared case-insensitively")
+	assert.Equal(t, "98829356", computeCorrelationID("github/my-repo", 12345), "unexpected correlation ID")
+	assert.Empty(t, computeCorrelationID("", 12345), "missing repository should yield no ID")
+	assert.Empty(t, computeCorrelationID("github/gh-aw", 0), "missing run ID should yield no ID")
+}
+
+func TestWorkflowRunCorrelationID(t *testing.T) {
+	run := WorkflowRun{DatabaseID: 1234567890, URL: "https://github.com/github/gh-aw/actions/runs/1234567890"}
+	assert.Equal(t, "35625281", workflowRunCorrelationID(run), "repository should be read from the run URL")
+	assert.Empty(t, workflowRunCorrelationID(WorkflowRun{DatabaseID: 1}), "runs without a URL have no correlation ID")
+}
+
+func TestAwInfoCorrelationID(t *testing.T) {
+	assert.Equal(t, "12345678", awInfoCorrelationID(&AwInfo{CorrelationID: "12345678", Repository: "github/gh-aw", RunID: float64(1234567890)}), "recorded ID should win")
+	assert.Equal(t, "35625281", awInfoCorrelationID(&AwInfo{Repository: "github/gh-aw", RunID: float64(1234567890)}), "ID should be computed for older aw_info.json files")
+	assert.Equal(t, "35625281", awInfoCorrelationID(&AwInfo{Repository: "github/gh-aw", RunID: "1234567890"}), "string run IDs should be accepted")
+	assert.Empty(t, awInfoCorrelationID(nil), "nil aw_info should yield no ID")
+}
+
+func TestValidateCorrelationID(t *testing.T) {
+	require.NoError(t, validateCorrelationID(""), "empty correlation should be accepted")
+	require.NoError(t, validateCorrelationID("00042000"), "eight digits should be accepted")
+	assert.Error(t, validateCorrelationID("4200"), "short IDs should be rejected")
+	assert.Error(t, validateCorrelationID("1234567a"), "non-numeric IDs should be rejected")
+}
+
+func TestFilterRunsByCorrelationID(t *testing.T) {
+	runs := []WorkflowRun{
+		{DatabaseID: 1234567890, URL: "https://github.com/github/gh-aw/actions/runs/1234567890"},
+		{DatabaseID: 1234567891, URL: "https://github.com/github/gh-aw/actions/runs/1234567891"},
+	}
+	matched := filterRunsByCorrelationID(runs, "35625281")
+	require.Len(t, matched, 1, "exactly one run should match")
+	assert.Equal(t, int64(1234567890), matched[0].DatabaseID, "wrong run matched")
+	assert.Empty(t, filterRunsByCorrelationID(runs, "00000000"), "unknown IDs should match nothing")
+}
@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var logsDecryptLog = logger.New("cli:logs_decrypt")
+
+// ageIdentityEnvVar names the environment variable holding the path of the age identity
+// (private key) file used to decrypt artifacts encrypted with artifact-encryption.
+const ageIdentityEnvVar = "GH_AW_AGE_IDENTITY"
+
+// decryptEncryptedArtifacts decrypts the *.tar.gz.age archives at the run directory
+// root (uploaded by workflows that configure artifact-encryption) and extracts them into
+// outputDir, so encrypted transcripts and safe output items land where the plaintext
+// artifacts would. Without an identity the archives are left in place with a warning.
+func decryptEncryptedArtifacts(outputDir string, verbose bool) error {
+	entries, err := os.ReadDir(outputDir)
+	if err != nil {
+		return fmt.Errorf("failed to read output directory: %w", err)
+	}
+	var archives []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), constants.EncryptedArtifactSuffix) {
+			archives = append(archives, filepath.Join(outputDir, entry.Name()))
+		}
+	}
+	if len(archives) == 0 {
+		return nil
+	}
+
+	identity := os.Getenv(ageIdentityEnvVar)
+	if identity == "" {
+		logsDecryptLog.Printf("Found %d encrypted artifact(s) but %s is not set", len(archives), ageIdentityEnvVar)
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Run %s has %d encrypted artifact(s); set %s to the path of your age identity file to decrypt them", filepath.Base(outputDir), len(archives), ageIdentityEnvVar)))
+		return nil
+	}
+	if _, err := exec.LookPath("age"); err != nil {
+		return errors.New("the age CLI is required to decrypt encrypted artifacts; install it from https://github.com/FiloSottile/age")
+	}
+
+	for _, archive := range archives {
+		logsDecryptLog.Printf("Decrypting %s", archive)
+		cmd := exec.Command("age", "--decrypt", "--identity", identity, archive)
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		plaintext, err := cmd.Output()
+		if err != nil {
+			return fmt.Errorf("failed to decrypt %s: %s", filepath.Base(archive), strings.TrimSpace(stderr.String()))
+		}
+		if err := extractTarGz(plaintext, outputDir); err != nil {
+			return fmt.Errorf("failed to extract %s: %w", filepath.Base(archive), err)
+		}
+		if err := os.Remove(archive); err != nil {
+			logsDecryptLog.Printf("Failed to remove %s: %v", archive, err)
+		}
+		if verbose {
+			fmt.Fprintln(os.Stderr, console.FormatVerboseMessage("Decrypted "+filepath.Base(archive)))
+		}
+	}
+	return nil
+}
+
+// extractTarGz extracts the regular files and directories of a gzip-compressed tar
+// archive into destDir. Entries that are not local paths are rejected so a crafted
+// archive cannot write outside destDir.
+func extractTarGz(data []byte, destDir string) error {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to read gzip stream: %w", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read tar archive: %w", err)
+		}
+		name := filepath.FromSlash(strings.TrimPrefix(header.Name, "./"))
+		if name == "" || name == "." {
+			continue
+		}
+		if !filepath.IsLocal(name) {
+			return fmt.Errorf("unsafe path in archive: %q", header.Name)
+		}
+		target := filepath.Join(destDir, name)
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, constants.DirPermSensitive); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), constants.DirPermSensitive); err != nil {
+				return err
+			}
+			file, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, constants.FilePermSensitive)
+			if err != nil {
+				return err
+			}
+			_, copyErr := io.Copy(file, tr)
+			closeErr := file.Close()
+			if copyErr != nil {
+				return copyErr
+			}
+			if closeErr != nil {
+				return closeErr
+			}
+		default:
+			logsDecryptLog.Printf("Skipping non-regular archive entry: %s", header.Name)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+//go:build !integration
+
+package cli
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func buildTestTarGz(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}), "write header")
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err, "write content")
+	}
+	require.NoError(t, tw.Close(), "close tar writer")
+	require.NoError(t, gz.Close(), "close gzip writer")
+	return buf.Bytes()
+}
+
+func TestExtractTarGz(t *testing.T) {
+	destDir := t.TempDir()
+	data := buildTestTarGz(t, map[string]string{
+		"agent-stdio.log":             "stdio",
+		"mcp-logs/rpc-messages.jsonl": "{}",
+	})
+
+	require.NoError(t, extractTarGz(data, destDir), "archive should extract")
+
+	content, err := os.ReadFile(filepath.Join(destDir, "agent-stdio.log"))
+	require.NoError(t, err, "agent-stdio.log should exist")
+	assert.Equal(t, "stdio", string(content), "unexpected file content")
+	assert.FileExists(t, filepath.Join(destDir, "mcp-logs", "rpc-messages.jsonl"), "nested files should keep their layout")
+}
+
+func TestExtractTarGzRejectsPathTraversal(t *testing.T) {
+	destDir := t.TempDir()
+	data := buildTestTarGz(t, map[string]string{"../escape.txt": "x"})
+
+	err := extractTarGz(data, destDir)
+	require.Error(t, err, "path traversal should be rejected")
+	assert.Contains(t, err.Error(), "unsafe path in archive", "unexpected error message")
+	assert.NoFileExists(t, filepath.Join(filepath.Dir(destDir), "escape.txt"), "no file should be written outside the destination")
+}
+
+func TestDecryptEncryptedArtifactsWithoutIdentity(t *testing.T) {
+	t.Setenv(ageIdentityEnvVar, "")
+	outputDir := t.TempDir()
+	archive := filepath.Join(outputDir, "agent-transcripts.tar.gz.age")
+	require.NoError(t, os.WriteFile(archive, []byte("age-encryption.org/v1"), 0o644), "write archive")
+
+	require.NoError(t, decryptEncryptedArtifacts(outputDir, false), "missing identity should not fail the download")
+	assert.FileExists(t, archive, "archive should be kept when it cannot be decrypted")
+}
@@ -955,6 +955,12 @@ func downloadRunArtifacts(ctx context.Context, opts downloadArtifactsOptions) er
 		return fmt.Errorf("failed to flatten safe-outputs-items artifact: %w", err)
 	}
 
+	// Decrypt artifacts uploaded with artifact-encryption (agent transcripts and safe
+	// output items). Single-file flattening has moved the archives to the run root.
+	if err := decryptEncryptedArtifacts(opts.outputDir, opts.verbose); err != nil {
+		return fmt.Errorf("failed to decrypt encrypted artifacts: %w", err)
+	}
+
 	// Download and unzip workflow run logs unless caller requested usage-only mode.
 	if !isUsageOnlyArtifactFilter(opts.artifactFilter) {
 		if err := downloadWorkflowRunLogs(ctx, opts.runID, opts.outputDir, opts.verbose, opts.owner, opts.repo, opts.hostname); err != nil {
@@ -517,6 +517,24 @@ func TestFindAgentLogFile(t *testing.T) {
 	})
 }
 
+func TestArtifactLogPath(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{path: "${{ env.GH_AW_TMP_DIR }}/agent-stdout.log", expected: "/tmp/gh-aw/agent-stdout.log"},
+		{path: "${{ env.GH_AW_TMP_DIR }}/sandbox/agent/logs/", expected: "/tmp/gh-aw/sandbox/agent/logs/"},
+		{path: "/tmp/gh-aw/agent-stdio.log", expected: "/tmp/gh-aw/agent-stdio.log"},
+		{path: "", expected: ""},
+	}
+
+	for _, tt := range tests {
+		if got := artifactLogPath(tt.path); got != tt.expected {
+			t.Errorf("artifactLogPath(%q) = %q, want %q", tt.path, got, tt.expected)
+		}
+	}
+}
+
 // TestRunHasDifcFilteredItems verifies the DIFC filtered-integrity filter helper.
 func TestRunHasDifcFilteredItems(t *testing.T) {
 	const gatewayWithDifc = `{"timestamp":"2025-01-01T00:00:00Z","type":"DIFC_FILTERED","server_id":"github","tool_name":"create_issue","reason":"integrity"}` + "\n"
@@ -180,6 +180,7 @@ func renderLogsCompactToWriter(w io.Writer, data LogsData) {
 			fmt.Fprintf(w, "  blocked: %s\n", strings.Join(fw.BlockedDomains, " "))
 		}
 	}
+	writeCompactBlockedDomains(w, data.BlockedDomains)
 
 	// [tools] — top tools by call count
 	if len(data.ToolUsage) > 0 {
@@ -343,6 +344,7 @@ func renderLogsCompactVerboseToWriter(w io.Writer, data LogsData) {
 			}
 		}
 	}
+	writeCompactBlockedDomains(w, data.BlockedDomains)
 
 	// [tools]
 	if len(data.ToolUsage) > 0 {
@@ -411,3 +413,15 @@ func formatCompactAIC(value float64) string {
 	}
 	return fmt.Sprintf("%.3f", value)
 }
+
+// writeCompactBlockedDomains writes the [blocked-domains] section: one line per run and
+// blocked domain.
+func writeCompactBlockedDomains(w io.Writer, blocked []RunBlockedDomain) {
+	if len(blocked) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "[blocked-domains]")
+	for _, b := range blocked {
+		fmt.Fprintf(w, "run=%d %s requests=%d\n", b.RunID, b.Domain, b.Requests)
+	}
+}
@@ -163,6 +163,7 @@ type ListWorkflowRunsOptions struct {
 	Ref          string // filter by branch or tag name
 	BeforeRunID  int64  // filter by run database ID (< this ID)
 	AfterRunID   int64  // filter by run database ID (> this ID)
+	Correlation  string // filter by run correlation ID (see logs_correlation.go)
 	RepoOverride string // fetch from a specific repository instead of current
 	// OldestFetchedCreatedAt, when set, is populated with the oldest run creation
 	// timestamp returned by GitHub in this batch before any workflow/conclusion filtering.
@@ -364,6 +365,12 @@ func listWorkflowRunsWithPagination(opts ListWorkflowRunsOptions) ([]WorkflowRun
 		agenticRuns = filteredRuns
 	}
 
+	// Apply correlation ID filtering if specified. The ID is derived from the
+	// repository and run ID, so no artifacts are needed to match it.
+	if opts.Correlation != "" {
+		agenticRuns = filterRunsByCorrelationID(agenticRuns, opts.Correlation)
+	}
+
 	// Filter out skipped and cancelled runs — they carry no useful agentic data
 	// and should not count toward the requested run count.
 	{
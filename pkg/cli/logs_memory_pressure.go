@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var memoryPressureLog = logger.New("cli:logs_memory_pressure")
+
+// memoryPressureMarkers are log fragments written when a process on the runner ran
+// out of memory: Node.js heap exhaustion, the kernel OOM killer (SIGKILL, exit code
+// 137), and allocation failures of native tools.
+var memoryPressureMarkers = []string{
+	"javascript heap out of memory",
+	"process completed with exit code 137",
+	"exited with code 137",
+	"oomkilled",
+	"out of memory: killed process",
+	"fatal error: runtime: out of memory",
+	"cannot allocate memory",
+	"std::bad_alloc",
+}
+
+// countMemoryPressureSignals returns the number of log lines in the run directory,
+// including the runner captures in workflow-logs/, that show a process running out
+// of memory.
+func countMemoryPressureSignals(runDir string) int {
+	signals := 0
+	_ = filepath.Walk(runDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info == nil || info.IsDir() {
+			return nil
+		}
+		name := strings.ToLower(info.Name())
+		if !strings.HasSuffix(name, ".log") && !strings.HasSuffix(name, ".txt") {
+			return nil
+		}
+		signals += countMemoryPressureLines(path)
+		return nil
+	})
+	if signals > 0 {
+		memoryPressureLog.Printf("Found %d memory pressure signal(s) in %s", signals, runDir)
+	}
+	return signals
+}
+
+// countMemoryPressureLines returns the number of lines of a log file that contain
+// a memory pressure marker.
+func countMemoryPressureLines(path string) int {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0
+	}
+	defer file.Close()
+
+	count := 0
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.ToLower(scanner.Text())
+		for _, marker := range memoryPressureMarkers {
+			if strings.Contains(line, marker) {
+				count++
+				break
+			}
+		}
+	}
+	return count
+}
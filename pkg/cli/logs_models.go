@@ -14,6 +14,8 @@ var logsModelsLog = logger.New("cli:logs_models")
 const (
 	// defaultAgentStdioLogPath is the default log file path for agent stdout/stderr
 	defaultAgentStdioLogPath = "/tmp/gh-aw/agent-stdio.log"
+	// defaultAgentStdoutLogPath is the log file path for agent stdout only (ANSI stripped)
+	defaultAgentStdoutLogPath = "/tmp/gh-aw/agent-stdout.log"
 	// runSummaryFileName is the name of the summary file created in each run folder
 	runSummaryFileName = "run_summary.json"
 	// defaultLogsOutputDir is the default directory for downloaded workflow logs
@@ -82,6 +84,7 @@ type WorkflowRun struct {
 	SafeItemsCount      int           `json:"safe_items_count,omitempty"` // Count of safe-output items actually written to GitHub
 	EffectiveTokens     int           // Cost-normalized token count computed from per-model multipliers
 	AvgTimeBetweenTurns time.Duration // Average time between consecutive LLM API calls (from per-turn timestamps when available)
+	MemoryPressure      int           `json:"memory_pressure,omitempty"` // Log lines showing a process running out of memory
 	LogsPath            string
 }
 
@@ -320,15 +323,16 @@ type AwInfo struct {
 	Context         *AwContext          `json:"context,omitempty"`       // aw_context data passed via workflow_dispatch inputs
 	TokenWeights    *types.TokenWeights `json:"token_weights,omitempty"` // Historical/custom model cost data stored in aw_info.json
 	// Additional fields that might be present
-	RunID      any    `json:"run_id,omitempty"`
-	RunNumber  any    `json:"run_number,omitempty"`
-	RunAttempt string `json:"run_attempt,omitempty"`
-	Repository string `json:"repository,omitempty"`
-	Ref        string `json:"ref,omitempty"`
-	SHA        string `json:"sha,omitempty"`
-	Actor      string `json:"actor,omitempty"`
-	EventName  string `json:"event_name,omitempty"`
-	TargetRepo string `json:"target_repo,omitempty"`
+	RunID         any    `json:"run_id,omitempty"`
+	RunNumber     any    `json:"run_number,omitempty"`
+	RunAttempt    string `json:"run_attempt,omitempty"`
+	CorrelationID string `json:"correlation_id,omitempty"`
+	Repository    string `json:"repository,omitempty"`
+	Ref           string `json:"ref,omitempty"`
+	SHA           string `json:"sha,omitempty"`
+	Actor         string `json:"actor,omitempty"`
+	EventName     string `json:"event_name,omitempty"`
+	TargetRepo    string `json:"target_repo,omitempty"`
 }
 
 // GetFirewallVersion returns the AWF firewall version, preferring the new field name
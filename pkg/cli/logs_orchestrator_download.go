@@ -304,6 +304,7 @@ func fetchWorkflowRunBatch(ctx context.Context, opts LogsDownloadOptions, before
 		Ref:                    opts.Ref,
 		BeforeRunID:            opts.BeforeRunID,
 		AfterRunID:             opts.AfterRunID,
+		Correlation:            opts.Correlation,
 		RepoOverride:           opts.RepoOverride,
 		OldestFetchedCreatedAt: &oldestFetchedCreatedAt,
 		ProcessedCount:         processedCount,
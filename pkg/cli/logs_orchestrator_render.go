@@ -64,15 +64,24 @@ func renderLogsOutput(processedRuns []ProcessedRun, opts renderLogsOutputOptions
 	case "markdown", "pretty":
 		inputs := make([]crossRunInput, 0, len(processedRuns))
 		for _, pr := range processedRuns {
+			var aic float64
+			if pr.TokenUsage != nil {
+				aic = pr.TokenUsage.TotalAIC
+			}
 			inputs = append(inputs, crossRunInput{
 				RunID:            pr.Run.DatabaseID,
 				WorkflowName:     pr.Run.WorkflowName,
 				Conclusion:       pr.Run.Conclusion,
+				CreatedAt:        pr.Run.CreatedAt,
 				Duration:         pr.Run.Duration,
+				AIC:              aic,
+				ActionMinutes:    pr.Run.ActionMinutes,
+				SafeOutputCounts: countSafeOutputItemsByType(extractCreatedItemsFromManifest(pr.Run.LogsPath)),
 				FirewallAnalysis: pr.FirewallAnalysis,
 				Metrics: LogMetrics{
 					TokenUsage: pr.Run.TokenUsage,
 					Turns:      pr.Run.Turns,
+					ToolCalls:  ExtractLogMetricsFromRun(pr).ToolCalls,
 				},
 				MCPToolUsage: pr.MCPToolUsage,
 				MCPFailures:  pr.MCPFailures,
@@ -15,6 +15,7 @@ type LogsDownloadOptions struct {
 	Ref               string
 	BeforeRunID       int64
 	AfterRunID        int64
+	Correlation       string
 	RepoOverride      string
 	Verbose           bool
 	ToolGraph         bool
@@ -34,6 +34,7 @@ type LogsData struct {
 	MCPFailures       []MCPFailureSummary        `json:"mcp_failures,omitempty" console:"title:⚠️  MCP Server Failures,omitempty"`
 	AccessLog         *AccessLogSummary          `json:"access_log,omitempty" console:"title:Access Log Analysis,omitempty"`
 	FirewallLog       *FirewallLogSummary        `json:"firewall_log,omitempty" console:"title:🔥 Firewall Log Analysis,omitempty"`
+	BlockedDomains    []RunBlockedDomain         `json:"blocked_domains,omitempty" console:"title:🚫 Blocked Domains by Run,omitempty"`
 	RedactedDomains   *RedactedDomainsLogSummary `json:"redacted_domains,omitempty" console:"title:🔒 Redacted URL Domains,omitempty"`
 	Continuation      *ContinuationData          `json:"continuation,omitempty" console:"-"`
 	LogsLocation      string                     `json:"logs_location" console:"-"`
@@ -111,10 +112,12 @@ type LogsSummary struct {
 
 // RunData contains information about a single workflow run
 type RunData struct {
-	RunID        int64  `json:"run_id" console:"header:Run ID"`
-	Number       int    `json:"number" console:"-"`
-	WorkflowName string `json:"workflow_name" console:"header:Workflow"`
-	WorkflowPath string `json:"workflow_path" console:"-"`
+	RunID  int64 `json:"run_id" console:"header:Run ID"`
+	Number int   `json:"number" console:"-"`
+	// CorrelationID is the short run ID printed in the prompt and safe-output footers and markers.
+	CorrelationID string `json:"correlation_id,omitempty" console:"-"`
+	WorkflowName  string `json:"workflow_name" console:"header:Workflow"`
+	WorkflowPath  string `json:"workflow_path" console:"-"`
 	// IntentionalFailure is true when the workflow is tagged with intentional-failure: true
 	// in its frontmatter (e.g. credit-guardrail stress tests that are expected to fail).
 	// Agents and dashboards MUST exclude these runs from prod-main and fleet-health
@@ -315,6 +318,7 @@ func buildLogsData(processedRuns []ProcessedRun, outputDir string, continuation
 		runData := RunData{
 			RunID:                      run.DatabaseID,
 			Number:                     run.Number,
+			CorrelationID:              workflowRunCorrelationID(run),
 			WorkflowName:               run.WorkflowName,
 			WorkflowPath:               run.WorkflowPath,
 			Agent:                      engineID,
@@ -470,6 +474,9 @@ func buildLogsData(processedRuns []ProcessedRun, outputDir string, continuation
 	// Build firewall log summary
 	firewallLog := buildFirewallLogSummary(processedRuns)
 
+	// Build per-run blocked domains
+	blockedDomains := buildBlockedDomainsByRun(processedRuns)
+
 	// Build redacted domains summary
 	redactedDomains := buildRedactedDomainsSummary(processedRuns)
 
@@ -492,6 +499,7 @@ func buildLogsData(processedRuns []ProcessedRun, outputDir string, continuation
 		MCPFailures:       mcpFailures,
 		AccessLog:         accessLog,
 		FirewallLog:       firewallLog,
+		BlockedDomains:    blockedDomains,
 		RedactedDomains:   redactedDomains,
 		Continuation:      continuation,
 		LogsLocation:      absOutputDir,
@@ -158,6 +158,36 @@ func buildFirewallLogSummary(processedRuns []ProcessedRun) *FirewallLogSummary {
 	}
 }
 
+// RunBlockedDomain is one domain the firewall denied in a run
+type RunBlockedDomain struct {
+	RunID     int64    `json:"run_id" console:"header:Run ID"`
+	Workflow  string   `json:"workflow" console:"header:Workflow,maxlen:40"`
+	Domain    string   `json:"domain" console:"header:Blocked Domain"`
+	Requests  int      `json:"requests" console:"header:Requests"`
+	FirstSeen string   `json:"first_seen,omitempty" console:"header:First Seen,omitempty"`
+	URLs      []string `json:"urls,omitempty" console:"-"`
+}
+
+// buildBlockedDomainsByRun lists the blocked domains of each run, grouped by run in
+// report order, so authors can see which run needed which allow-list entry
+func buildBlockedDomainsByRun(processedRuns []ProcessedRun) []RunBlockedDomain {
+	var result []RunBlockedDomain
+	for _, pr := range processedRuns {
+		for _, attempt := range blockedDomainAttempts(pr.FirewallAnalysis) {
+			result = append(result, RunBlockedDomain{
+				RunID:     pr.Run.DatabaseID,
+				Workflow:  pr.Run.WorkflowName,
+				Domain:    attempt.Domain,
+				Requests:  attempt.Requests,
+				FirstSeen: attempt.FirstSeen,
+				URLs:      attempt.URLs,
+			})
+		}
+	}
+	firewallReportLog.Printf("Built blocked domains by run: %d entries", len(result))
+	return result
+}
+
 // buildRedactedDomainsSummary aggregates redacted domains data across all runs
 func buildRedactedDomainsSummary(processedRuns []ProcessedRun) *RedactedDomainsLogSummary {
 	allDomainsSet := make(map[string]struct {
@@ -371,6 +371,7 @@ func extractRunMetricsAndMetadata(result *DownloadResult, runOutputDir string, v
 	result.Run.TurnsAvailable = (metricsErr == nil)
 	result.Run.AvgTimeBetweenTurns = metrics.AvgTimeBetweenTurns
 	result.Run.LogsPath = runOutputDir
+	result.Run.MemoryPressure = countMemoryPressureSignals(runOutputDir)
 
 	// If the GitHub API returned an empty workflow path (e.g. for scheduled or agentic
 	// workflow runs), infer it from aw_info.json so the cached RunSummary and downstream
@@ -72,7 +72,7 @@ func buildSafeOutputChainMetrics(logsPath string) SafeOutputChainMetrics {
 		key := safeOutputTargetKey(item.Repo, item.Number)
 		itemCounts[key]++
 		switch item.Type {
-		case "assign_to_agent", "create_agent_session":
+		case "assign_to_agent", "create_agent_session", "handoff_to_copilot":
 			delegatedTargets[key] = struct {
 			}{}
 		case "close_issue", "close_pull_request", "close_discussion", "merge_pull_request":
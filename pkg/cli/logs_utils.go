@@ -115,17 +115,37 @@ func findAgentOutputFile(logDir string) (string, bool) {
 	return foundPath, true
 }
 
+// artifactLogPath maps a log path in the scratch directory of the job, as returned by
+// engine.GetLogFileForParsing(), to its place below /tmp/gh-aw/ in the downloaded artifacts.
+// Artifacts are uploaded relative to the scratch directory, so both share the same layout.
+func artifactLogPath(path string) string {
+	if after, ok := strings.CutPrefix(path, constants.TmpGhAwDirExpr); ok {
+		return constants.TmpGhAwDir + after
+	}
+	return path
+}
+
 // findAgentLogFile searches for agent logs within the logDir.
 // It uses engine.GetLogFileForParsing() to determine which log file to use:
 //   - If GetLogFileForParsing() returns a non-empty value that doesn't point to agent-stdio.log,
 //     look for files in the "agent_output" artifact directory (before flattening)
 //     or in the flattened location (after flattening)
+//   - If GetLogFileForParsing() points to agent-stdout.log, look for that file and fall
+//     back to agent-stdio.log for runs compiled before stdout and stderr were split
 //   - Otherwise, look for the "agent-stdio.log" artifact file
 //
 // Returns the first path found and a boolean indicating success.
 func findAgentLogFile(logDir string, engine workflow.CodingAgentEngine) (string, bool) {
 	// Use GetLogFileForParsing to determine which log file to use
-	logFileForParsing := engine.GetLogFileForParsing()
+	logFileForParsing := artifactLogPath(engine.GetLogFileForParsing())
+
+	if logFileForParsing == defaultAgentStdoutLogPath {
+		if path := findAgentLogByName(logDir, filepath.Base(defaultAgentStdoutLogPath)); path != "" {
+			return path, true
+		}
+		logsUtilsLog.Printf("No agent-stdout.log in %s, falling back to agent-stdio.log", logDir)
+		logFileForParsing = defaultAgentStdioLogPath
+	}
 
 	// If the engine specifies a log file that isn't the default agent-stdio.log,
 	// look in the agent_output artifact directory or flattened location
@@ -9,6 +9,7 @@ import (
 
 	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/gitutil"
 	"github.com/github/gh-aw/pkg/logger"
 	"github.com/github/gh-aw/pkg/parser"
 	"github.com/github/gh-aw/pkg/stringutil"
@@ -18,6 +19,9 @@ import (
 
 var mcpAddLog = logger.New("cli:mcp_add")
 
+// fetchContainerDigestFunc resolves container digests; replaced in tests.
+var fetchContainerDigestFunc = fetchContainerDigest
+
 // AddMCPTool adds an MCP tool to an agentic workflow
 func AddMCPTool(ctx context.Context, workflowFile string, mcpServerID string, registryURL string, transportType string, customToolID string, verbose bool) error {
 	mcpAddLog.Printf("Adding MCP tool: serverID=%s, registryURL=%s, transport=%s", mcpServerID, registryURL, transportType)
@@ -35,7 +39,7 @@ func AddMCPTool(ctx context.Context, workflowFile string, mcpServerID string, re
 	}
 
 	// Create registry client
-	registryClient := NewMCPRegistryClient(registryURL)
+	registryClient := NewMCPRegistryClient(resolveMCPRegistryURL(registryURL))
 
 	// Search for the MCP server in the registry
 	if verbose {
@@ -109,16 +113,12 @@ func AddMCPTool(ctx context.Context, workflowFile string, mcpServerID string, re
 		return fmt.Errorf("failed to parse workflow file: %w", err)
 	}
 
-	// Check if tool already exists
-	if workflowData.Frontmatter["tools"] != nil {
-		if tools, ok := workflowData.Frontmatter["tools"].(map[string]any); ok {
-			if _, exists := tools[toolID]; exists {
-				return fmt.Errorf("tool '%s' already exists in workflow", toolID)
-			}
-		}
+	// Check if the server ID is already in use
+	if mcpServerIDExists(workflowData.Frontmatter, toolID) {
+		return fmt.Errorf("tool '%s' already exists in workflow", toolID)
 	}
 
-	// Create MCP tool configuration based on server info and preferences
+	// Create MCP server configuration based on server info and preferences
 	mcpConfig, err := createMCPToolConfig(selectedServer, transportType, registryClient.registryURL, verbose)
 	if err != nil {
 		return fmt.Errorf("failed to create MCP tool configuration: %w", err)
@@ -129,7 +129,15 @@ func AddMCPTool(ctx context.Context, workflowFile string, mcpServerID string, re
 		return fmt.Errorf("failed to add tool to workflow: %w", err)
 	}
 
-	fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Added MCP tool '%s' to workflow %s", toolID, console.ToRelativePath(workflowPath))))
+	fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Added MCP server '%s' to workflow %s", toolID, console.ToRelativePath(workflowPath))))
+	if inputs := selectedServer.RequiredInputs(); len(inputs) > 0 {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Required environment: "+strings.Join(inputs, ", ")))
+	}
+
+	// Pin the container image by digest for reproducible runs
+	if image := mcpServerContainerImage(mcpConfig); image != "" {
+		pinMCPServerContainer(ctx, image, verbose)
+	}
 
 	// Check for required secrets and provide CLI commands if missing
 	if err := checkAndSuggestSecrets(mcpConfig, verbose); err != nil {
@@ -163,10 +171,8 @@ func AddMCPTool(ctx context.Context, workflowFile string, mcpServerID string, re
 	return nil
 }
 
-// createMCPToolConfig creates the MCP tool configuration based on registry server info
+// createMCPToolConfig creates the mcp-servers entry for a registry server
 func createMCPToolConfig(server *MCPRegistryServerForProcessing, preferredTransport string, registryURL string, verbose bool) (map[string]any, error) {
-	config := make(map[string]any)
-
 	// Determine transport type (use preference if provided and supported)
 	transport := server.Transport
 	if preferredTransport != "" {
@@ -181,126 +187,174 @@ func createMCPToolConfig(server *MCPRegistryServerForProcessing, preferredTransp
 		}
 	}
 
-	// Create MCP configuration based on transport type
-	mcpSection := map[string]any{
-		"type":     transport,
+	config := map[string]any{
 		"registry": fmt.Sprintf("%s/servers/%s", registryURL, server.Name),
 	}
 
 	switch transport {
 	case "stdio":
-		// Handle container field (simplified Docker run)
-		if server.Config != nil {
-			if container, hasContainer := server.Config["container"]; hasContainer {
-				if containerStr, ok := container.(string); ok {
-					mcpSection["container"] = containerStr
-
-					// Add environment variables for Docker container
-					if env, hasEnv := server.Config["env"]; hasEnv {
-						mcpSection["env"] = convertToGitHubActionsEnv(env, server.EnvironmentVariables)
-					}
-				}
-			} else {
-				// Handle regular command and args
-				// Use runtime_hint for command if available, otherwise fall back to Command
-				if server.RuntimeHint != "" {
-					mcpSection["command"] = server.RuntimeHint
-				} else if server.Command != "" {
-					mcpSection["command"] = server.Command
-				}
-
-				// Combine runtime_arguments and package arguments for args
-				var allArgs []string
-				allArgs = append(allArgs, server.RuntimeArguments...)
-				allArgs = append(allArgs, server.Args...)
-				if len(allArgs) > 0 {
-					mcpSection["args"] = allArgs
-				}
-
-				// Add environment variables if present
-				if env, hasEnv := server.Config["env"]; hasEnv {
-					mcpSection["env"] = convertToGitHubActionsEnv(env, server.EnvironmentVariables)
-				}
-			}
-		} else {
-			// Handle command and args when no config
-			// Use runtime_hint for command if available, otherwise fall back to Command
-			if server.RuntimeHint != "" {
-				mcpSection["command"] = server.RuntimeHint
-			} else if server.Command != "" {
-				mcpSection["command"] = server.Command
-			}
+		config["type"] = "stdio"
+		if container, ok := server.Config["container"].(string); ok {
+			setMCPContainerConfig(config, server, container)
+			break
+		}
 
-			// Combine runtime_arguments and package arguments for args
-			var allArgs []string
-			allArgs = append(allArgs, server.RuntimeArguments...)
-			allArgs = append(allArgs, server.Args...)
-			if len(allArgs) > 0 {
-				mcpSection["args"] = allArgs
-			}
+		// Use runtime_hint for command if available, otherwise fall back to Command
+		if server.RuntimeHint != "" {
+			config["command"] = server.RuntimeHint
+		} else if server.Command != "" {
+			config["command"] = server.Command
 		}
 
-	case "http":
-		// For HTTP transport, we need a URL
-		if server.Config != nil {
-			if url, hasURL := server.Config["url"]; hasURL {
-				mcpSection["url"] = url
-			} else {
-				return nil, errors.New("HTTP transport requires URL configuration")
-			}
+		// Combine runtime_arguments and package arguments for args
+		var allArgs []string
+		allArgs = append(allArgs, server.RuntimeArguments...)
+		allArgs = append(allArgs, server.Args...)
+		if len(allArgs) > 0 {
+			config["args"] = allArgs
+		}
 
-			// Add headers if present
-			if headers, hasHeaders := server.Config["headers"]; hasHeaders {
-				mcpSection["headers"] = headers
-			}
-		} else {
-			return nil, errors.New("HTTP transport requires configuration")
+		if env, hasEnv := server.Config["env"]; hasEnv {
+			config["env"] = convertToGitHubActionsEnv(env, server.EnvironmentVariables)
 		}
 
-	case "docker":
-		// For Docker transport, use container configuration
-		if server.Config != nil {
-			if container, hasContainer := server.Config["container"]; hasContainer {
-				mcpSection["container"] = container
-			} else {
-				return nil, errors.New("docker transport requires container configuration")
-			}
+	case "http", "streamable-http":
+		config["type"] = "http"
+		url, hasURL := server.Config["url"]
+		if !hasURL {
+			return nil, errors.New("HTTP transport requires URL configuration")
+		}
+		config["url"] = url
 
-			// Add environment variables if present
-			if env, hasEnv := server.Config["env"]; hasEnv {
-				mcpSection["env"] = convertToGitHubActionsEnv(env, server.EnvironmentVariables)
-			}
-		} else {
-			return nil, errors.New("docker transport requires configuration")
+		if headers, hasHeaders := server.Config["headers"]; hasHeaders {
+			config["headers"] = convertToGitHubActionsEnv(headers, server.HeaderVariables)
+		}
+
+	case "docker":
+		// Docker servers are stdio servers that run in a container
+		container, ok := server.Config["container"].(string)
+		if !ok {
+			return nil, errors.New("docker transport requires container configuration")
 		}
+		config["type"] = "stdio"
+		setMCPContainerConfig(config, server, container)
 
 	default:
 		return nil, fmt.Errorf("unsupported transport type: %s", transport)
 	}
 
-	config["mcp"] = mcpSection
-
 	return config, nil
 }
 
-// addToolToWorkflow adds a tool configuration to the workflow file
-func addToolToWorkflow(workflowPath string, toolID string, toolConfig map[string]any, verbose bool) error {
-	// Use frontmatter helper to update the workflow file
-	return parser.UpdateWorkflowFrontmatter(workflowPath, func(frontmatter map[string]any) error {
-		// Ensure tools section exists
-		tools := parser.EnsureToolsSection(frontmatter)
+// setMCPContainerConfig fills the container, version, and env fields of a stdio server.
+func setMCPContainerConfig(config map[string]any, server *MCPRegistryServerForProcessing, container string) {
+	config["container"] = container
+	if version, ok := server.Config["version"].(string); ok && version != "" {
+		config["version"] = version
+	}
+	if len(server.Args) > 0 {
+		config["args"] = server.Args
+	}
+	if env, hasEnv := server.Config["env"]; hasEnv {
+		config["env"] = convertToGitHubActionsEnv(env, server.EnvironmentVariables)
+	}
+}
+
+// mcpServerContainerImage returns the full image reference ("image:tag") of a
+// container-based mcp-servers entry, or "" when the server does not use a container.
+func mcpServerContainerImage(config map[string]any) string {
+	container, _ := config["container"].(string)
+	if container == "" {
+		return ""
+	}
+	if version, ok := config["version"].(string); ok && version != "" {
+		return container + ":" + version
+	}
+	return container
+}
 
-		// Check if tool already exists
-		if _, exists := tools[toolID]; exists {
+// pinMCPServerContainer resolves the digest of the server's container image and records
+// it in .github/aw/actions-lock.json, so compiled workflows reference the image by digest.
+// Resolution needs Docker or crane; failures are reported as warnings.
+func pinMCPServerContainer(ctx context.Context, image string, verbose bool) {
+	gitRoot, err := gitutil.FindGitRoot()
+	if err != nil {
+		gitRoot = "."
+	}
+	actionCache := workflow.NewActionCache(gitRoot)
+	if err := actionCache.Load(); err != nil {
+		mcpAddLog.Printf("Failed to load actions-lock.json: %v", err)
+	}
+	if pin, ok := actionCache.GetContainerPin(image); ok && pin.Digest != "" {
+		if verbose {
+			fmt.Fprintln(os.Stderr, console.FormatVerboseMessage(fmt.Sprintf("%s already pinned: %s", image, pin.Digest)))
+		}
+		return
+	}
+
+	digest, err := fetchContainerDigestFunc(ctx, image, verbose)
+	if err != nil {
+		mcpAddLog.Printf("Failed to resolve digest for %s: %v", image, err)
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Could not pin %s to a digest: %v", image, err)))
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Run 'gh aw update' with Docker available to pin it later"))
+		return
+	}
+
+	actionCache.SetContainerPin(image, digest, image+"@"+digest)
+	if err := actionCache.Save(); err != nil {
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Failed to save container pin for %s: %v", image, err)))
+		return
+	}
+	fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Pinned %s → %s", image, digest)))
+}
+
+// addToolToWorkflow adds an mcp-servers entry to the workflow file
+func addToolToWorkflow(workflowPath string, toolID string, serverConfig map[string]any, verbose bool) error {
+	return parser.UpdateWorkflowFrontmatter(workflowPath, func(frontmatter map[string]any) error {
+		if mcpServerIDExists(frontmatter, toolID) {
 			return fmt.Errorf("tool '%s' already exists in workflow", toolID)
 		}
 
-		// Add the new tool
-		tools[toolID] = toolConfig
+		servers, ok := frontmatter["mcp-servers"].(map[string]any)
+		if !ok {
+			servers = make(map[string]any)
+			frontmatter["mcp-servers"] = servers
+		}
+		servers[toolID] = serverConfig
 		return nil
 	}, verbose)
 }
 
+// mcpServerIDExists reports whether the ID is already used under mcp-servers or tools.
+func mcpServerIDExists(frontmatter map[string]any, toolID string) bool {
+	for _, section := range []string{"mcp-servers", "tools"} {
+		if entries, ok := frontmatter[section].(map[string]any); ok {
+			if _, exists := entries[toolID]; exists {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// resolveMCPRegistryURL returns the registry to use: the --registry flag, then the
+// mcp_registry field in aw.json, then the default public registry.
+func resolveMCPRegistryURL(flagValue string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+	if gitRoot, err := gitutil.FindGitRoot(); err == nil {
+		repoConfig, err := workflow.LoadRepoConfig(gitRoot)
+		if err != nil {
+			mcpAddLog.Printf("Failed to load repo config for MCP registry: %v", err)
+		} else if repoConfig != nil && repoConfig.MCPRegistry != "" {
+			mcpAddLog.Printf("Using MCP registry from %s: %s", workflow.RepoConfigFileName, repoConfig.MCPRegistry)
+			return repoConfig.MCPRegistry
+		}
+	}
+	return string(constants.DefaultMCPRegistryURL)
+}
+
 // NewMCPAddSubcommand creates the mcp add subcommand
 func NewMCPAddSubcommand() *cobra.Command {
 	var registryURL string
@@ -312,10 +366,14 @@ func NewMCPAddSubcommand() *cobra.Command {
 		Short: "Add an MCP server to an agentic workflow",
 		Long: `Add an MCP server to an agentic workflow by searching the MCP registry.
 
-This command searches the MCP registry for the specified server, adds it to the workflow's tools section,
+This command searches the MCP registry for the specified server, adds it to the workflow's mcp-servers section,
 and automatically compiles the workflow. If the server already exists, the command will fail.
 
-When called with no arguments, it will show a list of available MCP servers from the registry.
+When called with no arguments, it will show a list of available MCP servers from the registry
+together with the environment variables and secrets each server requires.
+
+The registry is taken from --registry, then from "mcp_registry" in .github/workflows/aw.json,
+then defaults to the public GitHub MCP registry.
 
 The workflow-id-or-file can be:
 - A workflow ID (basename without .md extension, e.g., "weekly-research")
@@ -325,6 +383,8 @@ The command will:
 - Search the MCP registry for the specified server
 - Check that the server doesn't already exist in the workflow
 - Add the MCP server configuration to the workflow's frontmatter
+- Pin container-based servers by digest in .github/aw/actions-lock.json (requires Docker or crane)
+- Suggest 'gh secret set' commands for required secrets that are missing
 - Automatically compile the workflow to generate the .lock.yml file`,
 		Example: `  gh aw mcp add                                          # List available MCP servers
   gh aw mcp add weekly-research makenotion/notion-mcp-server  # Add Notion MCP server to weekly-research.md
@@ -338,11 +398,7 @@ The command will:
 
 			// If no arguments provided, show list of available servers
 			if len(args) == 0 {
-				// Use default registry URL if not provided
-				if registryURL == "" {
-					registryURL = string(constants.DefaultMCPRegistryURL)
-				}
-				return listAvailableServers(cmd.Context(), registryURL, verbose)
+				return listAvailableServers(cmd.Context(), resolveMCPRegistryURL(registryURL), verbose)
 			}
 
 			// If only workflow ID/file is provided, show error (need both workflow and server)
@@ -358,7 +414,7 @@ The command will:
 		},
 	}
 
-	cmd.Flags().StringVar(&registryURL, "registry", "", "MCP registry URL (default: https://api.mcp.github.com/v0.1)")
+	cmd.Flags().StringVar(&registryURL, "registry", "", "MCP registry URL (default: mcp_registry in aw.json, then https://api.mcp.github.com/v0.1)")
 	cmd.Flags().StringVar(&transportType, "transport", "", "Preferred transport type (stdio, http, docker)")
 	cmd.Flags().StringVar(&customToolID, "tool-id", "", "Custom tool ID to use in the workflow (default: uses server ID)")
 
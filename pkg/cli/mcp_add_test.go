@@ -136,9 +136,9 @@ This is a test workflow.
 		t.Error("Expected MCP tool (notion-mcp-server) to be added to workflow")
 	}
 
-	// Check that it has MCP configuration
-	if !strings.Contains(updatedContentStr, "mcp:") {
-		t.Error("Expected MCP configuration to be added")
+	// Check that it was added under mcp-servers
+	if !strings.Contains(updatedContentStr, "mcp-servers:") {
+		t.Error("Expected MCP server to be added under mcp-servers")
 	}
 
 	// Check that it has the correct transport type
@@ -457,10 +457,7 @@ func TestCreateMCPToolConfig_StdioTransport(t *testing.T) {
 		t.Fatalf("createMCPToolConfig failed: %v", err)
 	}
 
-	mcpSection, ok := config["mcp"].(map[string]any)
-	if !ok {
-		t.Fatal("Expected mcp section to be a map")
-	}
+	mcpSection := config
 
 	if mcpSection["type"] != "stdio" {
 		t.Errorf("Expected type 'stdio', got '%v'", mcpSection["type"])
@@ -509,13 +506,15 @@ func TestCreateMCPToolConfig_PreferredTransport(t *testing.T) {
 		t.Fatalf("createMCPToolConfig failed: %v", err)
 	}
 
-	mcpSection, ok := config["mcp"].(map[string]any)
-	if !ok {
-		t.Fatal("Expected mcp section to be a map")
+	mcpSection := config
+
+	// Docker servers are written as stdio servers that run in a container
+	if mcpSection["type"] != "stdio" {
+		t.Errorf("Expected type 'stdio', got '%v'", mcpSection["type"])
 	}
 
-	if mcpSection["type"] != "docker" {
-		t.Errorf("Expected type 'docker', got '%v'", mcpSection["type"])
+	if mcpSection["container"] != "test-image:latest" {
+		t.Errorf("Expected container 'test-image:latest', got '%v'", mcpSection["container"])
 	}
 
 	// Check that registry field contains the direct server URL with server name
@@ -629,3 +628,128 @@ func TestCleanMCPToolID(t *testing.T) {
 		})
 	}
 }
+
+func TestAddMCPTool_OCIServerPinsContainer(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Chdir(tempDir)
+
+	workflowsDir := constants.GetWorkflowDir()
+	if err := os.MkdirAll(workflowsDir, 0755); err != nil {
+		t.Fatalf("Failed to create workflows directory: %v", err)
+	}
+	workflowPath := filepath.Join(workflowsDir, "test-workflow.md")
+	workflowContent := "---\non: issues\nengine: copilot\n---\n\n# Test Workflow\n"
+	if err := os.WriteFile(workflowPath, []byte(workflowContent), 0644); err != nil {
+		t.Fatalf("Failed to write test workflow: %v", err)
+	}
+
+	registryServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"servers": [{"server": {
+			"name": "io.github.example/notion",
+			"description": "Notion",
+			"version": "1.2.0",
+			"packages": [{
+				"registryType": "oci",
+				"identifier": "docker.io/mcp/notion",
+				"version": "1.2.0",
+				"transport": {"type": "stdio"},
+				"environmentVariables": [{"name": "NOTION_TOKEN", "isRequired": true, "isSecret": true}]
+			}]
+		}}]}`))
+	}))
+	defer registryServer.Close()
+
+	const digest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	originalFetch := fetchContainerDigestFunc
+	fetchContainerDigestFunc = func(_ context.Context, image string, _ bool) (string, error) {
+		if image != "docker.io/mcp/notion:1.2.0" {
+			t.Errorf("Unexpected image to pin: %s", image)
+		}
+		return digest, nil
+	}
+	t.Cleanup(func() { fetchContainerDigestFunc = originalFetch })
+
+	if err := AddMCPTool(context.Background(), "test-workflow", "notion", registryServer.URL, "", "", false); err != nil {
+		t.Fatalf("AddMCPTool failed: %v", err)
+	}
+
+	updatedContent, err := os.ReadFile(workflowPath)
+	if err != nil {
+		t.Fatalf("Failed to read updated workflow: %v", err)
+	}
+	for _, expected := range []string{"mcp-servers:", "container: docker.io/mcp/notion", "version: 1.2.0", "NOTION_TOKEN: ${{ secrets.NOTION_TOKEN }}"} {
+		if !strings.Contains(string(updatedContent), expected) {
+			t.Errorf("Expected workflow to contain %q, got:\n%s", expected, updatedContent)
+		}
+	}
+
+	lockContent, err := os.ReadFile(filepath.Join(".github", "aw", "actions-lock.json"))
+	if err != nil {
+		t.Fatalf("Expected actions-lock.json to be written: %v", err)
+	}
+	if !strings.Contains(string(lockContent), "docker.io/mcp/notion:1.2.0@"+digest) {
+		t.Errorf("Expected container pin in actions-lock.json, got:\n%s", lockContent)
+	}
+}
+
+func TestResolveMCPRegistryURL(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Chdir(tempDir)
+	if err := os.MkdirAll(filepath.Join(tempDir, ".git"), 0755); err != nil {
+		t.Fatalf("Failed to create .git directory: %v", err)
+	}
+
+	if got := resolveMCPRegistryURL(""); got != string(constants.DefaultMCPRegistryURL) {
+		t.Errorf("Expected default registry without aw.json, got %q", got)
+	}
+
+	if err := os.MkdirAll(filepath.Join(tempDir, ".github", "workflows"), 0755); err != nil {
+		t.Fatalf("Failed to create workflows directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tempDir, ".github", "workflows", "aw.json"), []byte(`{"mcp_registry": "https://mcp.acme.example/v0.1/"}`), 0644); err != nil {
+		t.Fatalf("Failed to write aw.json: %v", err)
+	}
+
+	if got := resolveMCPRegistryURL(""); got != "https://mcp.acme.example/v0.1" {
+		t.Errorf("Expected registry from aw.json, got %q", got)
+	}
+	if got := resolveMCPRegistryURL("https://flag.example/v1"); got != "https://flag.example/v1" {
+		t.Errorf("Expected --registry to take precedence, got %q", got)
+	}
+}
+
+func TestMCPRegistryServerRequiredInputs(t *testing.T) {
+	server := MCPRegistryServerForProcessing{
+		EnvironmentVariables: []EnvironmentVariable{
+			{Name: "API_TOKEN", IsSecret: true},
+			{Name: "REGION", IsRequired: true},
+			{Name: "LOG_LEVEL"},
+		},
+		HeaderVariables: []EnvironmentVariable{{Name: "Authorization", IsSecret: true, IsRequired: true}},
+	}
+
+	got := strings.Join(server.RequiredInputs(), ", ")
+	if got != "API_TOKEN (secret), REGION, Authorization (secret)" {
+		t.Errorf("Unexpected required inputs: %q", got)
+	}
+}
+
+func TestSplitContainerImageTag(t *testing.T) {
+	tests := []struct {
+		reference string
+		image     string
+		tag       string
+	}{
+		{"mcp/notion:1.2.0", "mcp/notion", "1.2.0"},
+		{"ghcr.io/org/server", "ghcr.io/org/server", ""},
+		{"localhost:5000/server", "localhost:5000/server", ""},
+		{"localhost:5000/server:v1", "localhost:5000/server", "v1"},
+	}
+	for _, tt := range tests {
+		image, tag := splitContainerImageTag(tt.reference)
+		if image != tt.image || tag != tt.tag {
+			t.Errorf("splitContainerImageTag(%q) = (%q, %q), want (%q, %q)", tt.reference, image, tag, tt.image, tt.tag)
+		}
+	}
+}
@@ -26,10 +26,11 @@ var mcpInspectLog = logger.New("cli:mcp_inspect")
 // mcpScriptsServerShutdownDelay gives the embedded mcp-scripts server a brief window to stop gracefully.
 const mcpScriptsServerShutdownDelay = 500 * time.Millisecond
 
-// InspectWorkflowMCP inspects MCP servers used by a workflow and lists available tools, resources, and roots
-func InspectWorkflowMCP(ctx context.Context, workflowFile string, serverFilter string, toolFilter string, verbose bool, useActionsSecrets bool) error {
-	mcpInspectLog.Printf("Inspecting workflow MCP: workflow=%s, serverFilter=%s, toolFilter=%s",
-		workflowFile, serverFilter, toolFilter)
+// InspectWorkflowMCP inspects MCP servers used by a workflow and lists available tools, resources, and roots.
+// When interactive is true it instead opens a REPL for calling the tools of one server.
+func InspectWorkflowMCP(ctx context.Context, workflowFile string, serverFilter string, toolFilter string, verbose bool, useActionsSecrets bool, interactive bool) error {
+	mcpInspectLog.Printf("Inspecting workflow MCP: workflow=%s, serverFilter=%s, toolFilter=%s, interactive=%t",
+		workflowFile, serverFilter, toolFilter, interactive)
 
 	workflowsDir := getWorkflowsDir()
 
@@ -160,6 +161,10 @@ func InspectWorkflowMCP(ctx context.Context, workflowFile string, serverFilter s
 		return nil
 	}
 
+	if interactive {
+		return runMCPInspectREPL(ctx, mcpConfigs, verbose)
+	}
+
 	// Inspect each MCP server
 	if toolFilter != "" {
 		fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Found %d MCP server(s), looking for tool '%s'", len(mcpConfigs), toolFilter)))
@@ -237,6 +242,7 @@ func NewMCPInspectSubcommand() *cobra.Command {
 	var toolFilter string
 	var spawnInspector bool
 	var checkSecrets bool
+	var interactive bool
 
 	cmd := &cobra.Command{
 		Use:   "inspect [workflow]",
@@ -261,7 +267,11 @@ The command will:
 - Automatically start and inspect mcp-scripts server if present
 - Query available tools, resources, and roots
 - Validate required secrets are available
-- Display results in formatted tables with error details`,
+- Display results in formatted tables with error details
+
+With --interactive, the command connects to one server (picked from a list, or the one
+selected by --server) and loops: choose a tool, fill in its arguments from the tool's
+input schema, call it, and pretty-print the result. Choose "exit" or press Ctrl+C to quit.`,
 		Example: `  gh aw mcp inspect                    # List workflows with MCP servers
   gh aw mcp inspect weekly-research    # Inspect MCP servers in weekly-research.md
   gh aw mcp inspect daily-news --server tavily  # Inspect only the tavily server
@@ -269,6 +279,7 @@ The command will:
   gh aw mcp inspect weekly-research -v # Verbose output with detailed connection info
   gh aw mcp inspect weekly-research --inspector  # Launch @modelcontextprotocol/inspector
   gh aw mcp inspect weekly-research --check-secrets  # Check GitHub Actions secrets
+  gh aw mcp inspect weekly-research --server github -i  # Call tools interactively
 `,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -295,12 +306,21 @@ The command will:
 				return errors.New("--tool flag requires --server flag to be specified")
 			}
 
+			if interactive {
+				if workflowFile == "" {
+					return errors.New("--interactive requires a workflow argument")
+				}
+				if toolFilter != "" || spawnInspector {
+					return errors.New("--interactive cannot be combined with --tool or --inspector")
+				}
+			}
+
 			// Handle spawn inspector flag
 			if spawnInspector {
 				return spawnMCPInspector(cmd.Context(), workflowFile, serverFilter, verbose)
 			}
 
-			return InspectWorkflowMCP(cmd.Context(), workflowFile, serverFilter, toolFilter, verbose, checkSecrets)
+			return InspectWorkflowMCP(cmd.Context(), workflowFile, serverFilter, toolFilter, verbose, checkSecrets, interactive)
 		},
 	}
 
@@ -308,6 +328,7 @@ The command will:
 	cmd.Flags().StringVar(&toolFilter, "tool", "", "Show detailed information about a specific tool (requires --server)")
 	cmd.Flags().BoolVar(&spawnInspector, "inspector", false, "Launch the official @modelcontextprotocol/inspector tool")
 	cmd.Flags().BoolVar(&checkSecrets, "check-secrets", false, "Check GitHub Actions repository secrets for missing secrets")
+	cmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Interactively pick and call a server's tools")
 
 	// Register completions for mcp inspect command
 	cmd.ValidArgsFunction = CompleteWorkflowNames
@@ -131,8 +131,33 @@ func connectToMCPServer(config parser.RegistryMCPServerConfig, verbose bool) (*p
 	}
 }
 
+// openMCPSession starts or connects to the MCP server described by config and returns
+// an initialized client session. The caller owns the session and must close it.
+func openMCPSession(ctx context.Context, config parser.RegistryMCPServerConfig, verbose bool) (*mcp.ClientSession, error) {
+	switch config.Type {
+	case "stdio", "docker":
+		// Docker MCP servers are treated as stdio servers that run via docker command
+		return openStdioMCPSession(ctx, config, verbose)
+	case "http":
+		return openHTTPMCPSession(ctx, config, verbose)
+	default:
+		return nil, fmt.Errorf("unsupported MCP server type: %s", config.Type)
+	}
+}
+
 // connectStdioMCPServer connects to a stdio-based MCP server using the Go SDK
 func connectStdioMCPServer(ctx context.Context, config parser.RegistryMCPServerConfig, verbose bool) (*parser.MCPServerInfo, error) {
+	session, err := openStdioMCPSession(ctx, config, verbose)
+	if err != nil {
+		return nil, err
+	}
+	defer session.Close()
+
+	return queryMCPServerInfo(ctx, session, config, verbose), nil
+}
+
+// openStdioMCPSession starts a stdio-based MCP server and connects to it using the Go SDK
+func openStdioMCPSession(ctx context.Context, config parser.RegistryMCPServerConfig, verbose bool) (*mcp.ClientSession, error) {
 	mcpInspectServerLog.Printf("Connecting to stdio MCP server: command=%s, args=%d", config.Command, len(config.Args))
 	if verbose {
 		console.PrintInfoMessage(fmt.Sprintf("Starting stdio MCP server: %s %s", config.Command, strings.Join(config.Args, " ")))
@@ -180,13 +205,16 @@ func connectStdioMCPServer(ctx context.Context, config parser.RegistryMCPServerC
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to MCP server: %w", err)
 	}
-	defer session.Close()
 
 	if verbose {
 		console.PrintSuccessMessage("Successfully connected to MCP server")
 	}
+	return session, nil
+}
 
-	// Query server capabilities
+// queryMCPServerInfo lists the tools and resources exposed by an initialized session.
+// Listing failures are reported in verbose mode and otherwise leave the lists empty.
+func queryMCPServerInfo(ctx context.Context, session *mcp.ClientSession, config parser.RegistryMCPServerConfig, verbose bool) *parser.MCPServerInfo {
 	info := &parser.MCPServerInfo{
 		Config:    config,
 		Connected: true,
@@ -225,11 +253,22 @@ func connectStdioMCPServer(ctx context.Context, config parser.RegistryMCPServerC
 	// so we'll keep an empty list or try to infer from resources
 	info.Roots = extractRootsFromResources(info.Resources)
 
-	return info, nil
+	return info
 }
 
 // connectHTTPMCPServer connects to an HTTP-based MCP server using the Go SDK
 func connectHTTPMCPServer(ctx context.Context, config parser.RegistryMCPServerConfig, verbose bool) (*parser.MCPServerInfo, error) {
+	session, err := openHTTPMCPSession(ctx, config, verbose)
+	if err != nil {
+		return nil, err
+	}
+	defer session.Close()
+
+	return queryMCPServerInfo(ctx, session, config, verbose), nil
+}
+
+// openHTTPMCPSession connects to an HTTP-based MCP server using the Go SDK
+func openHTTPMCPSession(ctx context.Context, config parser.RegistryMCPServerConfig, verbose bool) (*mcp.ClientSession, error) {
 	if verbose {
 		console.PrintInfoMessage("Connecting to HTTP MCP server: " + config.URL)
 	}
@@ -239,15 +278,8 @@ func connectHTTPMCPServer(ctx context.Context, config parser.RegistryMCPServerCo
 		Logger: logger.NewSlogLoggerWithHandler(mcpInspectServerLog),
 	})
 
-	// Create streamable client transport for HTTP.
-	// DisableStandaloneSSE reduces resource usage: the inspector only queries
-	// capabilities and never needs to receive server-initiated messages.
-	transport := &mcp.StreamableClientTransport{
-		Endpoint:             config.URL,
-		DisableStandaloneSSE: true,
-	}
-
 	// Add custom headers if provided
+	var httpClient *http.Client
 	if len(config.Headers) > 0 {
 		// Create a custom HTTP client with header injection
 		baseTransport := http.DefaultTransport
@@ -255,7 +287,7 @@ func connectHTTPMCPServer(ctx context.Context, config parser.RegistryMCPServerCo
 			baseTransport = &http.Transport{}
 		}
 
-		transport.HTTPClient = &http.Client{
+		httpClient = &http.Client{
 			Transport: &headerRoundTripper{
 				base:    baseTransport,
 				headers: config.Headers,
@@ -263,6 +295,23 @@ func connectHTTPMCPServer(ctx context.Context, config parser.RegistryMCPServerCo
 		}
 	}
 
+	var transport mcp.Transport
+	if config.Transport == parser.MCPTransportSSE {
+		transport = &mcp.SSEClientTransport{
+			Endpoint:   config.URL,
+			HTTPClient: httpClient,
+		}
+	} else {
+		// Create streamable client transport for HTTP.
+		// DisableStandaloneSSE reduces resource usage: the inspector only queries
+		// capabilities and never needs to receive server-initiated messages.
+		transport = &mcp.StreamableClientTransport{
+			Endpoint:             config.URL,
+			HTTPClient:           httpClient,
+			DisableStandaloneSSE: true,
+		}
+	}
+
 	// Create a timeout context for connection
 	connectCtx, cancel := context.WithTimeout(ctx, MCPConnectTimeout)
 	defer cancel()
@@ -271,51 +320,11 @@ func connectHTTPMCPServer(ctx context.Context, config parser.RegistryMCPServerCo
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to HTTP MCP server: %w", err)
 	}
-	defer session.Close()
 
 	if verbose {
 		console.PrintSuccessMessage("Successfully connected to HTTP MCP server")
 	}
-
-	// Query server capabilities
-	info := &parser.MCPServerInfo{
-		Config:    config,
-		Connected: true,
-		Tools:     []*mcp.Tool{},
-		Resources: []*mcp.Resource{},
-		Roots:     []*mcp.Root{},
-	}
-
-	// List tools
-	listToolsCtx, cancel := context.WithTimeout(ctx, MCPOperationTimeout)
-	defer cancel()
-	toolsResult, err := session.ListTools(listToolsCtx, &mcp.ListToolsParams{})
-	cancel()
-	if err != nil {
-		if verbose {
-			console.PrintWarningMessage(fmt.Sprintf("Failed to list tools: %v", err))
-		}
-	} else {
-		info.Tools = append(info.Tools, toolsResult.Tools...)
-	}
-
-	// List resources
-	listResourcesCtx, cancel := context.WithTimeout(ctx, MCPOperationTimeout)
-	defer cancel()
-	resourcesResult, err := session.ListResources(listResourcesCtx, &mcp.ListResourcesParams{})
-	cancel()
-	if err != nil {
-		if verbose {
-			console.PrintWarningMessage(fmt.Sprintf("Failed to list resources: %v", err))
-		}
-	} else {
-		info.Resources = append(info.Resources, resourcesResult.Resources...)
-	}
-
-	// Extract root URIs from resources (simple heuristic)
-	info.Roots = extractRootsFromResources(info.Resources)
-
-	return info, nil
+	return session, nil
 }
 
 // extractRootsFromResources infers root URIs from a list of resources by extracting
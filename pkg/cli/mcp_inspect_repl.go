@@ -0,0 +1,383 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"charm.land/huh/v2"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/tty"
+)
+
+var mcpInspectREPLLog = logger.New("cli:mcp_inspect_repl")
+
+// mcpREPLExitValue is the list value used for the "exit" entry of the tool picker.
+const mcpREPLExitValue = "\x00exit"
+
+// mcpToolArgument describes one top-level property of a tool's input schema.
+type mcpToolArgument struct {
+	Name        string
+	Type        string
+	Description string
+	Enum        []string
+	Required    bool
+}
+
+// mcpToolInputSchema is the subset of a tool's JSON input schema used to prompt for arguments.
+type mcpToolInputSchema struct {
+	Properties map[string]struct {
+		Type        any    `json:"type"`
+		Description string `json:"description"`
+		Enum        []any  `json:"enum"`
+	} `json:"properties"`
+	Required []string `json:"required"`
+}
+
+// runMCPInspectREPL connects to one of the given MCP servers and repeatedly lets the
+// user pick a tool, enter its arguments, and see the result of invoking it.
+func runMCPInspectREPL(ctx context.Context, configs []parser.RegistryMCPServerConfig, verbose bool) error {
+	if !tty.IsStderrTerminal() {
+		return errors.New("--interactive requires a terminal")
+	}
+
+	config, err := selectMCPServerForREPL(configs)
+	if err != nil {
+		return err
+	}
+	mcpInspectREPLLog.Printf("Starting REPL for server: name=%s, type=%s", config.Name, config.Type)
+
+	session, err := openMCPSession(ctx, config, verbose)
+	if err != nil {
+		return fmt.Errorf("failed to connect to MCP server '%s': %w", config.Name, err)
+	}
+	defer session.Close()
+
+	listCtx, cancel := context.WithTimeout(ctx, MCPOperationTimeout)
+	toolsResult, err := session.ListTools(listCtx, &mcp.ListToolsParams{})
+	cancel()
+	if err != nil {
+		return fmt.Errorf("failed to list tools for '%s': %w", config.Name, err)
+	}
+	if len(toolsResult.Tools) == 0 {
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("MCP server '%s' does not expose any tools", config.Name)))
+		return nil
+	}
+
+	tools := toolsResult.Tools
+	sort.Slice(tools, func(i, j int) bool { return tools[i].Name < tools[j].Name })
+	fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Connected to '%s' (%d tools)", config.Name, len(tools))))
+
+	for {
+		tool, err := selectMCPToolForREPL(tools)
+		if err != nil {
+			if console.IsCancelled(err) {
+				return nil
+			}
+			return err
+		}
+		if tool == nil {
+			return nil
+		}
+
+		args, err := promptMCPToolArguments(tool)
+		if err != nil {
+			if console.IsCancelled(err) {
+				continue
+			}
+			return err
+		}
+
+		fmt.Fprintln(os.Stderr, console.FormatProgressMessage("Calling "+tool.Name+"..."))
+		result, err := session.CallTool(ctx, &mcp.CallToolParams{Name: tool.Name, Arguments: args})
+		if err != nil {
+			fmt.Fprintln(os.Stderr, console.FormatErrorMessage(fmt.Sprintf("Tool call failed: %v", err)))
+			continue
+		}
+		fmt.Fprintln(os.Stderr)
+		fmt.Println(formatMCPToolCallResult(result))
+		fmt.Fprintln(os.Stderr)
+	}
+}
+
+// selectMCPServerForREPL returns the only configured server, or asks the user to pick one.
+func selectMCPServerForREPL(configs []parser.RegistryMCPServerConfig) (parser.RegistryMCPServerConfig, error) {
+	if len(configs) == 1 {
+		return configs[0], nil
+	}
+	items := make([]console.ListItem, len(configs))
+	for i, config := range configs {
+		items[i] = console.NewListItem(config.Name, buildConnectionString(config), config.Name)
+	}
+	selected, err := console.ShowInteractiveList("Select an MCP server", items)
+	if err != nil {
+		return parser.RegistryMCPServerConfig{}, err
+	}
+	for _, config := range configs {
+		if config.Name == selected {
+			return config, nil
+		}
+	}
+	return parser.RegistryMCPServerConfig{}, fmt.Errorf("unknown MCP server: %s", selected)
+}
+
+// selectMCPToolForREPL asks the user to pick a tool. Returns nil when the user chooses to exit.
+func selectMCPToolForREPL(tools []*mcp.Tool) (*mcp.Tool, error) {
+	items := make([]console.ListItem, 0, len(tools)+1)
+	for _, tool := range tools {
+		items = append(items, console.NewListItem(tool.Name, stringutil.Truncate(strings.SplitN(tool.Description, "\n", 2)[0], 60), tool.Name))
+	}
+	items = append(items, console.NewListItem("exit", "Leave interactive mode", mcpREPLExitValue))
+
+	selected, err := console.ShowInteractiveList("Select a tool to call", items)
+	if err != nil {
+		return nil, err
+	}
+	if selected == mcpREPLExitValue {
+		return nil, nil
+	}
+	for _, tool := range tools {
+		if tool.Name == selected {
+			return tool, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown tool: %s", selected)
+}
+
+// promptMCPToolArguments prompts for each argument in the tool's input schema and returns
+// the parsed argument map. Optional arguments left empty are omitted.
+func promptMCPToolArguments(tool *mcp.Tool) (map[string]any, error) {
+	arguments, err := parseMCPToolArguments(tool.InputSchema)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]*string, len(arguments))
+	var fields []huh.Field
+	for _, arg := range arguments {
+		value := new(string)
+		values[arg.Name] = value
+		fields = append(fields, mcpToolArgumentField(arg, value))
+	}
+	if len(fields) > 0 {
+		if err := console.NewForm(huh.NewGroup(fields...)).Run(); err != nil {
+			return nil, err
+		}
+	}
+
+	args := make(map[string]any, len(arguments))
+	for _, arg := range arguments {
+		parsed, ok, err := parseMCPToolArgumentValue(arg, *values[arg.Name])
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			args[arg.Name] = parsed
+		}
+	}
+	mcpInspectREPLLog.Printf("Collected %d argument(s) for tool %s", len(args), tool.Name)
+	return args, nil
+}
+
+// mcpToolArgumentField builds the form field used to enter one tool argument.
+func mcpToolArgumentField(arg mcpToolArgument, value *string) huh.Field {
+	title := arg.Name
+	if arg.Required {
+		title += " *"
+	}
+	description := arg.Description
+	if arg.Type != "" {
+		description = strings.TrimSpace(fmt.Sprintf("(%s) %s", arg.Type, description))
+	}
+
+	if len(arg.Enum) > 0 {
+		var options []huh.Option[string]
+		if !arg.Required {
+			options = append(options, huh.NewOption("(none)", ""))
+		}
+		for _, enumValue := range arg.Enum {
+			options = append(options, huh.NewOption(enumValue, enumValue))
+		}
+		return huh.NewSelect[string]().Title(title).Description(description).Options(options...).Value(value)
+	}
+
+	placeholder := ""
+	switch arg.Type {
+	case "array":
+		placeholder = `["a", "b"]`
+	case "object":
+		placeholder = `{"key": "value"}`
+	case "boolean":
+		placeholder = "true or false"
+	}
+	return huh.NewInput().Title(title).Description(description).Placeholder(placeholder).Value(value).
+		Validate(func(raw string) error {
+			_, _, err := parseMCPToolArgumentValue(arg, raw)
+			return err
+		})
+}
+
+// parseMCPToolArguments extracts the top-level arguments from a tool input schema,
+// required arguments first and otherwise sorted by name.
+func parseMCPToolArguments(inputSchema any) ([]mcpToolArgument, error) {
+	if inputSchema == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(inputSchema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tool input schema: %w", err)
+	}
+	var schema mcpToolInputSchema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("failed to parse tool input schema: %w", err)
+	}
+
+	arguments := make([]mcpToolArgument, 0, len(schema.Properties))
+	for name, property := range schema.Properties {
+		arg := mcpToolArgument{
+			Name:        name,
+			Type:        mcpSchemaTypeName(property.Type),
+			Description: property.Description,
+			Required:    slices.Contains(schema.Required, name),
+		}
+		for _, enumValue := range property.Enum {
+			arg.Enum = append(arg.Enum, fmt.Sprint(enumValue))
+		}
+		arguments = append(arguments, arg)
+	}
+	sort.Slice(arguments, func(i, j int) bool {
+		if arguments[i].Required != arguments[j].Required {
+			return arguments[i].Required
+		}
+		return arguments[i].Name < arguments[j].Name
+	})
+	return arguments, nil
+}
+
+// mcpSchemaTypeName returns the JSON schema type of a property. For union types such as
+// ["string", "null"] the first non-null type is used.
+func mcpSchemaTypeName(schemaType any) string {
+	switch t := schemaType.(type) {
+	case string:
+		return t
+	case []any:
+		for _, entry := range t {
+			if name, ok := entry.(string); ok && name != "null" {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// parseMCPToolArgumentValue converts raw user input into a value of the argument's schema type.
+// The boolean result is false when the argument was left empty and should be omitted.
+func parseMCPToolArgumentValue(arg mcpToolArgument, raw string) (any, bool, error) {
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" {
+		if arg.Required {
+			return nil, false, fmt.Errorf("%s is required", arg.Name)
+		}
+		return nil, false, nil
+	}
+
+	switch arg.Type {
+	case "integer":
+		value, err := strconv.ParseInt(trimmed, 10, 64)
+		if err != nil {
+			return nil, false, fmt.Errorf("%s must be an integer", arg.Name)
+		}
+		return value, true, nil
+	case "number":
+		value, err := strconv.ParseFloat(trimmed, 64)
+		if err != nil {
+			return nil, false, fmt.Errorf("%s must be a number", arg.Name)
+		}
+		return value, true, nil
+	case "boolean":
+		value, err := strconv.ParseBool(trimmed)
+		if err != nil {
+			return nil, false, fmt.Errorf("%s must be true or false", arg.Name)
+		}
+		return value, true, nil
+	case "array", "object":
+		var value any
+		if err := json.Unmarshal([]byte(trimmed), &value); err != nil {
+			return nil, false, fmt.Errorf("%s must be a JSON %s: %w", arg.Name, arg.Type, err)
+		}
+		if _, isArray := value.([]any); arg.Type == "array" && !isArray {
+			return nil, false, fmt.Errorf("%s must be a JSON array", arg.Name)
+		}
+		if _, isObject := value.(map[string]any); arg.Type == "object" && !isObject {
+			return nil, false, fmt.Errorf("%s must be a JSON object", arg.Name)
+		}
+		return value, true, nil
+	default:
+		return raw, true, nil
+	}
+}
+
+// formatMCPToolCallResult renders a tool call result for the terminal: text content is
+// printed as-is (pretty-printed when it is JSON), other content and structured content
+// are shown as indented JSON.
+func formatMCPToolCallResult(result *mcp.CallToolResult) string {
+	var sb strings.Builder
+	if result.IsError {
+		sb.WriteString(console.FormatErrorMessage("Tool returned an error"))
+		sb.WriteString("\n")
+	}
+	for _, content := range result.Content {
+		switch c := content.(type) {
+		case *mcp.TextContent:
+			sb.WriteString(prettyJSONOrText(c.Text))
+		case *mcp.ImageContent:
+			fmt.Fprintf(&sb, "[image %s, %d bytes]", c.MIMEType, len(c.Data))
+		case *mcp.AudioContent:
+			fmt.Fprintf(&sb, "[audio %s, %d bytes]", c.MIMEType, len(c.Data))
+		default:
+			if data, err := json.MarshalIndent(content, "", "  "); err == nil {
+				sb.Write(data)
+			}
+		}
+		sb.WriteString("\n")
+	}
+	if result.StructuredContent != nil {
+		if data, err := json.MarshalIndent(result.StructuredContent, "", "  "); err == nil {
+			sb.WriteString(console.FormatSectionHeader("Structured content"))
+			sb.WriteString("\n")
+			sb.Write(data)
+			sb.WriteString("\n")
+		}
+	}
+	if sb.Len() == 0 {
+		return console.FormatInfoMessage("(empty result)")
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// prettyJSONOrText indents text that parses as a JSON object or array and returns other text unchanged.
+func prettyJSONOrText(text string) string {
+	trimmed := strings.TrimSpace(text)
+	if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+		return text
+	}
+	var value any
+	if err := json.Unmarshal([]byte(trimmed), &value); err != nil {
+		return text
+	}
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return text
+	}
+	return string(data)
+}
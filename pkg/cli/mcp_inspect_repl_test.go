@@ -0,0 +1,91 @@
+//go:build !integration
+
+package cli
+
+import (
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMCPToolArguments(t *testing.T) {
+	schema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"state":  map[string]any{"type": "string", "enum": []any{"open", "closed"}},
+			"limit":  map[string]any{"type": []any{"integer", "null"}, "description": "Max results"},
+			"labels": map[string]any{"type": "array"},
+			"repo":   map[string]any{"type": "string"},
+		},
+		"required": []any{"repo"},
+	}
+
+	args, err := parseMCPToolArguments(schema)
+	require.NoError(t, err, "schema should parse")
+	require.Len(t, args, 4, "all properties should become arguments")
+
+	assert.Equal(t, mcpToolArgument{Name: "repo", Type: "string", Required: true}, args[0], "required arguments should come first")
+	assert.Equal(t, "labels", args[1].Name, "optional arguments should be sorted by name")
+	assert.Equal(t, mcpToolArgument{Name: "limit", Type: "integer", Description: "Max results"}, args[2], "nullable union type should use the non-null type")
+	assert.Equal(t, []string{"open", "closed"}, args[3].Enum, "enum values should be kept")
+
+	none, err := parseMCPToolArguments(nil)
+	require.NoError(t, err, "nil schema should be accepted")
+	assert.Empty(t, none, "nil schema should have no arguments")
+}
+
+func TestParseMCPToolArgumentValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		arg      mcpToolArgument
+		raw      string
+		expected any
+		present  bool
+		wantErr  string
+	}{
+		{name: "string kept verbatim", arg: mcpToolArgument{Name: "q", Type: "string"}, raw: " a b ", expected: " a b ", present: true},
+		{name: "integer", arg: mcpToolArgument{Name: "n", Type: "integer"}, raw: "42", expected: int64(42), present: true},
+		{name: "invalid integer", arg: mcpToolArgument{Name: "n", Type: "integer"}, raw: "4.2", wantErr: "n must be an integer"},
+		{name: "number", arg: mcpToolArgument{Name: "x", Type: "number"}, raw: "1.5", expected: 1.5, present: true},
+		{name: "boolean", arg: mcpToolArgument{Name: "b", Type: "boolean"}, raw: "true", expected: true, present: true},
+		{name: "array", arg: mcpToolArgument{Name: "l", Type: "array"}, raw: `["a"]`, expected: []any{"a"}, present: true},
+		{name: "object given for array", arg: mcpToolArgument{Name: "l", Type: "array"}, raw: `{"a":1}`, wantErr: "l must be a JSON array"},
+		{name: "object", arg: mcpToolArgument{Name: "o", Type: "object"}, raw: `{"a":1}`, expected: map[string]any{"a": float64(1)}, present: true},
+		{name: "optional empty is omitted", arg: mcpToolArgument{Name: "q", Type: "string"}, raw: "  "},
+		{name: "required empty is rejected", arg: mcpToolArgument{Name: "q", Type: "string", Required: true}, raw: "", wantErr: "q is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, present, err := parseMCPToolArgumentValue(tt.arg, tt.raw)
+			if tt.wantErr != "" {
+				require.Error(t, err, "value should be rejected")
+				assert.Contains(t, err.Error(), tt.wantErr, "error should name the problem")
+				return
+			}
+			require.NoError(t, err, "value should parse")
+			assert.Equal(t, tt.present, present, "presence should match")
+			assert.Equal(t, tt.expected, value, "parsed value should match")
+		})
+	}
+}
+
+func TestFormatMCPToolCallResult(t *testing.T) {
+	result := &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: `{"b":1,"a":[1,2]}`},
+			&mcp.TextContent{Text: "plain text"},
+		},
+	}
+	output := formatMCPToolCallResult(result)
+	assert.Contains(t, output, "{\n  \"a\": [\n    1,\n    2\n  ],\n  \"b\": 1\n}", "JSON text should be pretty-printed")
+	assert.Contains(t, output, "plain text", "plain text should be printed as-is")
+
+	errorOutput := formatMCPToolCallResult(&mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "boom"}}})
+	assert.Contains(t, errorOutput, "Tool returned an error", "error results should be flagged")
+	assert.Contains(t, errorOutput, "boom", "error text should be shown")
+
+	assert.Contains(t, formatMCPToolCallResult(&mcp.CallToolResult{}), "(empty result)", "empty results should be reported")
+}
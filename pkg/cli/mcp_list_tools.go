@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -11,6 +12,7 @@ import (
 	"github.com/github/gh-aw/pkg/logger"
 	"github.com/github/gh-aw/pkg/parser"
 	"github.com/github/gh-aw/pkg/sliceutil"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/spf13/cobra"
 )
 
@@ -21,15 +23,40 @@ const (
 	maxDescriptionLength = 60
 )
 
+// MCPToolListing is the JSON output of mcp list-tools for a single server
+type MCPToolListing struct {
+	Workflow string          `json:"workflow"`
+	Server   string          `json:"server"`
+	Type     string          `json:"type"`
+	Tools    []MCPToolDetail `json:"tools"`
+}
+
+// MCPToolDetail describes a tool as the agent sees it, including its schemas and annotations
+type MCPToolDetail struct {
+	Name         string               `json:"name"`
+	Title        string               `json:"title,omitempty"`
+	Description  string               `json:"description,omitempty"`
+	Allowed      bool                 `json:"allowed"`
+	InputSchema  any                  `json:"input_schema,omitempty"`
+	OutputSchema any                  `json:"output_schema,omitempty"`
+	Annotations  *mcp.ToolAnnotations `json:"annotations,omitempty"`
+}
+
+// MCPServerWorkflows is the JSON output of mcp list-tools when no workflow is given
+type MCPServerWorkflows struct {
+	Server    string   `json:"server"`
+	Workflows []string `json:"workflows"`
+}
+
 // ListToolsForMCP lists available tools for a specific MCP server
-func ListToolsForMCP(workflowFile string, mcpServerName string, verbose bool) error {
-	mcpListToolsLog.Printf("Listing tools for MCP server: %s, workflow: %s", mcpServerName, workflowFile)
+func ListToolsForMCP(workflowFile string, mcpServerName string, verbose bool, jsonOutput bool) error {
+	mcpListToolsLog.Printf("Listing tools for MCP server: %s, workflow: %s, jsonOutput=%v", mcpServerName, workflowFile, jsonOutput)
 	workflowsDir := getWorkflowsDir()
 
 	// If no workflow file specified, search for workflows containing the MCP server
 	if workflowFile == "" {
 		mcpListToolsLog.Printf("No workflow file specified, searching in: %s", workflowsDir)
-		return findWorkflowsWithMCPServer(workflowsDir, mcpServerName, verbose)
+		return findWorkflowsWithMCPServer(workflowsDir, mcpServerName, verbose, jsonOutput)
 	}
 
 	// Resolve the workflow file path
@@ -98,6 +125,10 @@ func ListToolsForMCP(workflowFile string, mcpServerName string, verbose bool) er
 		fmt.Fprintln(os.Stderr, console.FormatSuccessMessageStderr("Successfully connected to MCP server"))
 	}
 
+	if jsonOutput {
+		return writeToolsListJSON(buildMCPToolListing(info, normalizeWorkflowID(workflowPath)))
+	}
+
 	// Display the tools
 	displayToolsList(info, verbose)
 
@@ -105,14 +136,14 @@ func ListToolsForMCP(workflowFile string, mcpServerName string, verbose bool) er
 }
 
 // findWorkflowsWithMCPServer searches for workflows containing a specific MCP server
-func findWorkflowsWithMCPServer(workflowsDir string, mcpServerName string, verbose bool) error {
+func findWorkflowsWithMCPServer(workflowsDir string, mcpServerName string, verbose bool, jsonOutput bool) error {
 	// Scan workflows for MCP configurations, filtering by server name
 	results, err := ScanWorkflowsForMCP(workflowsDir, mcpServerName, verbose)
 	if err != nil {
 		return err
 	}
 
-	var matchingWorkflows []string
+	matchingWorkflows := []string{}
 
 	for _, result := range results {
 		// Check if this workflow contains the target MCP server
@@ -124,6 +155,10 @@ func findWorkflowsWithMCPServer(workflowsDir string, mcpServerName string, verbo
 		}
 	}
 
+	if jsonOutput {
+		return writeToolsListJSON(MCPServerWorkflows{Server: mcpServerName, Workflows: matchingWorkflows})
+	}
+
 	if len(matchingWorkflows) == 0 {
 		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("MCP server '%s' not found in any workflow", mcpServerName)))
 		return nil
@@ -170,6 +205,38 @@ func displayToolsList(info *parser.MCPServerInfo, verbose bool) {
 	fmt.Fprint(os.Stdout, renderMCPToolTable(info, opts))
 }
 
+// buildMCPToolListing converts the tools reported by an MCP server into the JSON listing
+func buildMCPToolListing(info *parser.MCPServerInfo, workflowName string) MCPToolListing {
+	listing := MCPToolListing{
+		Workflow: workflowName,
+		Server:   info.Config.Name,
+		Type:     info.Config.Type,
+		Tools:    make([]MCPToolDetail, 0, len(info.Tools)),
+	}
+	for _, tool := range info.Tools {
+		listing.Tools = append(listing.Tools, MCPToolDetail{
+			Name:         tool.Name,
+			Title:        tool.Title,
+			Description:  tool.Description,
+			Allowed:      isMCPToolAllowed(info.Config.Allowed, tool.Name),
+			InputSchema:  tool.InputSchema,
+			OutputSchema: tool.OutputSchema,
+			Annotations:  tool.Annotations,
+		})
+	}
+	return listing
+}
+
+// writeToolsListJSON prints a list-tools result as indented JSON on stdout
+func writeToolsListJSON(v any) error {
+	jsonBytes, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	fmt.Fprintln(os.Stdout, string(jsonBytes))
+	return nil
+}
+
 // NewMCPListToolsSubcommand creates the mcp list-tools subcommand
 func NewMCPListToolsSubcommand() *cobra.Command {
 	var serverFilter string
@@ -192,11 +259,15 @@ The workflow-id-or-file can be:
 The command will:
 - Parse the workflow to find the specified MCP server configuration
 - Connect to the MCP server using the same logic as 'mcp inspect'
-- Display available tools with their descriptions and allowance status`,
+- Display available tools with their descriptions and allowance status
+
+With --json, the tools are printed as JSON including each tool's input and output
+schemas and annotations, so other tooling can assert on exactly what the agent sees.`,
 		Example: `  gh aw mcp list-tools --server github                    # Search for workflows containing the 'github' MCP server
   gh aw mcp list-tools weekly-research --server github    # List tools for 'github' server in weekly-research.md
   gh aw mcp list-tools issue-triage --server safe-outputs # List tools for 'safe-outputs' server in issue-triage.md
   gh aw mcp list-tools test-workflow --server playwright -v  # Verbose output with tool descriptions
+  gh aw mcp list-tools weekly-research --server github --json  # Tools with input schemas and annotations as JSON
 `,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -217,8 +288,9 @@ The command will:
 			}
 
 			verbose, _ := cmd.Flags().GetBool("verbose")
+			jsonOutput, _ := cmd.Flags().GetBool("json")
 
-			return ListToolsForMCP(workflowFile, serverFilter, verbose)
+			return ListToolsForMCP(workflowFile, serverFilter, verbose, jsonOutput)
 		},
 		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 			if len(args) > 0 {
@@ -229,6 +301,7 @@ The command will:
 	}
 
 	cmd.Flags().StringVar(&serverFilter, "server", "", "MCP server name to list tools for (required)")
+	addJSONFlag(cmd)
 
 	return cmd
 }
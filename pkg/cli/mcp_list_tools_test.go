@@ -3,6 +3,7 @@
 package cli
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"strings"
@@ -16,6 +17,7 @@ import (
 	"github.com/github/gh-aw/pkg/parser"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -92,7 +94,7 @@ This workflow has no GitHub MCP server.`
 
 	t.Run("find_workflows_with_mcp_server", func(t *testing.T) {
 		// Test searching for workflows containing a specific MCP server
-		err := ListToolsForMCP("", "github", false)
+		err := ListToolsForMCP("", "github", false, false)
 		// This should not error, but should output info about finding workflows
 		if err != nil {
 			t.Errorf("ListToolsForMCP search failed: %v", err)
@@ -101,7 +103,7 @@ This workflow has no GitHub MCP server.`
 
 	t.Run("find_workflows_with_safe_outputs", func(t *testing.T) {
 		// Test searching for workflows containing safe-outputs
-		err := ListToolsForMCP("", constants.SafeOutputsMCPServerID.String(), false)
+		err := ListToolsForMCP("", constants.SafeOutputsMCPServerID.String(), false, false)
 		// This should not error, but should output info about finding workflows
 		if err != nil {
 			t.Errorf("ListToolsForMCP safe-outputs search failed: %v", err)
@@ -110,7 +112,7 @@ This workflow has no GitHub MCP server.`
 
 	t.Run("mcp_server_not_found_in_any_workflow", func(t *testing.T) {
 		// Test searching for a non-existent MCP server
-		err := ListToolsForMCP("", "nonexistent-server", false)
+		err := ListToolsForMCP("", "nonexistent-server", false, false)
 		// This should not error, but should output warning about not finding the server
 		if err != nil {
 			t.Errorf("ListToolsForMCP nonexistent server search failed: %v", err)
@@ -119,7 +121,7 @@ This workflow has no GitHub MCP server.`
 
 	t.Run("mcp_server_not_found_in_specific_workflow", func(t *testing.T) {
 		// Test looking for MCP server in workflow that doesn't have it
-		err := ListToolsForMCP("other-workflow", "github", false)
+		err := ListToolsForMCP("other-workflow", "github", false, false)
 		// This should not error, but should output warning about not finding the server
 		if err != nil {
 			t.Errorf("ListToolsForMCP specific workflow without server failed: %v", err)
@@ -128,7 +130,7 @@ This workflow has no GitHub MCP server.`
 
 	t.Run("nonexistent_workflow", func(t *testing.T) {
 		// Test with non-existent workflow file
-		err := ListToolsForMCP("nonexistent", "github", false)
+		err := ListToolsForMCP("nonexistent", "github", false, false)
 		if err == nil {
 			t.Error("Expected error for nonexistent workflow, got nil")
 		}
@@ -139,7 +141,7 @@ This workflow has no GitHub MCP server.`
 
 	t.Run("verbose_mode", func(t *testing.T) {
 		// Test verbose output (should not crash)
-		err := ListToolsForMCP("", "github", true)
+		err := ListToolsForMCP("", "github", true, false)
 		if err != nil {
 			t.Errorf("ListToolsForMCP verbose search failed: %v", err)
 		}
@@ -200,7 +202,7 @@ tools:
 
 	t.Run("find_github_server", func(t *testing.T) {
 		// Should find workflow1 and workflow3 (both have github MCP server)
-		err := findWorkflowsWithMCPServer(workflowsDir, "github", false)
+		err := findWorkflowsWithMCPServer(workflowsDir, "github", false, false)
 		if err != nil {
 			t.Errorf("findWorkflowsWithMCPServer failed: %v", err)
 		}
@@ -208,7 +210,7 @@ tools:
 
 	t.Run("find_safe_outputs_server", func(t *testing.T) {
 		// Should find workflow2 (has safe-outputs)
-		err := findWorkflowsWithMCPServer(workflowsDir, constants.SafeOutputsMCPServerID.String(), false)
+		err := findWorkflowsWithMCPServer(workflowsDir, constants.SafeOutputsMCPServerID.String(), false, false)
 		if err != nil {
 			t.Errorf("findWorkflowsWithMCPServer for safe-outputs failed: %v", err)
 		}
@@ -216,7 +218,7 @@ tools:
 
 	t.Run("find_nonexistent_server", func(t *testing.T) {
 		// Should not find any workflows
-		err := findWorkflowsWithMCPServer(workflowsDir, "nonexistent", false)
+		err := findWorkflowsWithMCPServer(workflowsDir, "nonexistent", false, false)
 		if err != nil {
 			t.Errorf("findWorkflowsWithMCPServer for nonexistent server should not error: %v", err)
 		}
@@ -224,11 +226,87 @@ tools:
 
 	t.Run("verbose_output", func(t *testing.T) {
 		// Test verbose mode
-		err := findWorkflowsWithMCPServer(workflowsDir, "github", true)
+		err := findWorkflowsWithMCPServer(workflowsDir, "github", true, false)
 		if err != nil {
 			t.Errorf("findWorkflowsWithMCPServer verbose failed: %v", err)
 		}
 	})
+
+	t.Run("json_output", func(t *testing.T) {
+		stdout, _ := captureOutput(t, func() error {
+			return findWorkflowsWithMCPServer(workflowsDir, "github", false, true)
+		})
+		var result MCPServerWorkflows
+		require.NoError(t, json.Unmarshal([]byte(stdout), &result), "stdout should be valid JSON")
+		assert.Equal(t, "github", result.Server, "server should be reported")
+		assert.ElementsMatch(t, []string{"workflow1", "workflow3"}, result.Workflows, "matching workflows should be listed")
+	})
+
+	t.Run("json_output_no_matches", func(t *testing.T) {
+		stdout, _ := captureOutput(t, func() error {
+			return findWorkflowsWithMCPServer(workflowsDir, "nonexistent", false, true)
+		})
+		assert.JSONEq(t, `{"server":"nonexistent","workflows":[]}`, stdout, "no matches should produce an empty list")
+	})
+}
+
+func TestBuildMCPToolListing(t *testing.T) {
+	openWorld := false
+	info := &parser.MCPServerInfo{
+		Config: parser.RegistryMCPServerConfig{BaseMCPServerConfig: types.BaseMCPServerConfig{Type: "stdio"}, Name: "github", Allowed: []string{"get_issue"}},
+		Tools: []*mcp.Tool{
+			{
+				Name:        "get_issue",
+				Title:       "Get issue",
+				Description: "Get an issue",
+				InputSchema: map[string]any{
+					"type":       "object",
+					"properties": map[string]any{"issue_number": map[string]any{"type": "number"}},
+					"required":   []any{"issue_number"},
+				},
+				Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true, OpenWorldHint: &openWorld},
+			},
+			{Name: "create_issue", InputSchema: map[string]any{"type": "object"}},
+		},
+	}
+
+	listing := buildMCPToolListing(info, "triage")
+	assert.Equal(t, "triage", listing.Workflow, "workflow should be reported")
+	assert.Equal(t, "github", listing.Server, "server should be reported")
+	require.Len(t, listing.Tools, 2, "every tool should be listed")
+	assert.True(t, listing.Tools[0].Allowed, "allowed tool should be marked allowed")
+	assert.False(t, listing.Tools[1].Allowed, "tool outside the allowed list should be marked not allowed")
+
+	data, err := json.Marshal(listing)
+	require.NoError(t, err, "listing should marshal")
+	assert.JSONEq(t, `{
+		"workflow": "triage",
+		"server": "github",
+		"type": "stdio",
+		"tools": [
+			{
+				"name": "get_issue",
+				"title": "Get issue",
+				"description": "Get an issue",
+				"allowed": true,
+				"input_schema": {"type": "object", "properties": {"issue_number": {"type": "number"}}, "required": ["issue_number"]},
+				"annotations": {"readOnlyHint": true, "openWorldHint": false}
+			},
+			{"name": "create_issue", "allowed": false, "input_schema": {"type": "object"}}
+		]
+	}`, string(data), "JSON should include schemas and annotations")
+
+	empty := buildMCPToolListing(&parser.MCPServerInfo{Config: parser.RegistryMCPServerConfig{Name: "empty"}}, "triage")
+	data, err = json.Marshal(empty)
+	require.NoError(t, err, "empty listing should marshal")
+	assert.Contains(t, string(data), `"tools":[]`, "servers without tools should produce an empty list")
+}
+
+func TestIsMCPToolAllowed(t *testing.T) {
+	assert.True(t, isMCPToolAllowed(nil, "any"), "empty allowed list should allow every tool")
+	assert.True(t, isMCPToolAllowed([]string{"*"}, "any"), "wildcard should allow every tool")
+	assert.True(t, isMCPToolAllowed([]string{"a", "b"}, "b"), "listed tool should be allowed")
+	assert.False(t, isMCPToolAllowed([]string{"a"}, "b"), "unlisted tool should not be allowed")
 }
 
 func TestDisplayToolsList(t *testing.T) {
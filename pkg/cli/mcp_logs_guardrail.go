@@ -18,14 +18,31 @@ const (
 	// CharsPerToken is the approximate number of characters per token
 	// Using OpenAI's rule of thumb: ~4 characters per token
 	CharsPerToken = 4
-
-	// mcpLogsCacheDir is the directory where MCP logs data files are cached.
-	// This lives under /tmp/gh-aw/ so that agents can read the files, but
-	// is separate from the artifact download directory (/tmp/gh-aw/aw-mcp/logs)
-	// so that these JSON summary files are not included in artifact uploads.
-	mcpLogsCacheDir = "/tmp/gh-aw/logs-cache"
 )
 
+// mcpScratchDir returns the scratch directory of the job the MCP server runs in
+// (GH_AW_TMP_DIR), or /tmp/gh-aw outside of a workflow run.
+func mcpScratchDir() string {
+	if tmpDir := os.Getenv(constants.TmpGhAwDirEnvVar); tmpDir != "" {
+		return tmpDir
+	}
+	return constants.TmpGhAwDir
+}
+
+// mcpLogsCacheDir returns the directory where MCP logs data files are cached.
+// This lives under the scratch directory of the job so that agents can read the
+// files, but is separate from the artifact download directory (mcpLogsOutputDir) so
+// that these JSON summary files are not included in artifact uploads.
+func mcpLogsCacheDir() string {
+	return filepath.Join(mcpScratchDir(), "logs-cache")
+}
+
+// mcpLogsOutputDir returns the directory the logs, audit and audit-diff tools
+// download run artifacts to.
+func mcpLogsOutputDir() string {
+	return filepath.Join(mcpScratchDir(), "aw-mcp", "logs")
+}
+
 // MCPLogsGuardrailResponse represents the response returned by the logs tool.
 // The full data is always written to a file; this response provides the file
 // path so the caller can read the data.
@@ -43,15 +60,16 @@ type MCPLogsGuardrailResponse struct {
 func buildLogsFileResponse(outputStr string) string {
 	// Verify or create the cache directory. Use Lstat to detect symlinks and
 	// refuse to follow them, hardening against symlink-based directory attacks.
-	if info, err := os.Lstat(mcpLogsCacheDir); err == nil {
+	cacheDir := mcpLogsCacheDir()
+	if info, err := os.Lstat(cacheDir); err == nil {
 		if info.Mode()&os.ModeSymlink != 0 {
-			return buildLogsFileErrorResponse(fmt.Sprintf("logs cache path %q is a symlink; refusing to use it", mcpLogsCacheDir))
+			return buildLogsFileErrorResponse(fmt.Sprintf("logs cache path %q is a symlink; refusing to use it", cacheDir))
 		}
 		if !info.IsDir() {
-			return buildLogsFileErrorResponse(fmt.Sprintf("logs cache path %q is not a directory", mcpLogsCacheDir))
+			return buildLogsFileErrorResponse(fmt.Sprintf("logs cache path %q is not a directory", cacheDir))
 		}
 	} else if os.IsNotExist(err) {
-		if mkErr := os.MkdirAll(mcpLogsCacheDir, constants.DirPermPublic); mkErr != nil && !os.IsExist(mkErr) {
+		if mkErr := os.MkdirAll(cacheDir, constants.DirPermPublic); mkErr != nil && !os.IsExist(mkErr) {
 			mcpLogsGuardrailLog.Printf("Failed to create logs cache directory: %v", mkErr)
 			return buildLogsFileErrorResponse(fmt.Sprintf("failed to create logs cache directory: %v", mkErr))
 		}
@@ -59,7 +77,7 @@ func buildLogsFileResponse(outputStr string) string {
 		mcpLogsGuardrailLog.Printf("Failed to stat logs cache directory: %v", err)
 		return buildLogsFileErrorResponse(fmt.Sprintf("failed to access logs cache directory: %v", err))
 	}
-	if chmodErr := os.Chmod(mcpLogsCacheDir, constants.DirPermPublic); chmodErr != nil {
+	if chmodErr := os.Chmod(cacheDir, constants.DirPermPublic); chmodErr != nil {
 		mcpLogsGuardrailLog.Printf("Failed to set logs cache directory permissions: %v", chmodErr)
 		return buildLogsFileErrorResponse(fmt.Sprintf("failed to set logs cache directory permissions: %v", chmodErr))
 	}
@@ -67,7 +85,7 @@ func buildLogsFileResponse(outputStr string) string {
 	// Use SHA256 of content as filename for content-addressed deduplication.
 	sum := sha256.Sum256([]byte(outputStr))
 	fileName := hex.EncodeToString(sum[:]) + ".json"
-	filePath := filepath.Join(mcpLogsCacheDir, fileName)
+	filePath := filepath.Join(cacheDir, fileName)
 
 	// Skip writing if a file with identical content already exists.
 	if fileInfo, err := os.Lstat(filePath); err == nil {
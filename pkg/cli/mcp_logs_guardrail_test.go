@@ -32,8 +32,8 @@ func TestBuildLogsFileResponse_WritesFile(t *testing.T) {
 	}
 
 	// Verify the file is in the cache directory (not the artifact directory)
-	if !strings.HasPrefix(response.FilePath, mcpLogsCacheDir) {
-		t.Errorf("File should be in cache dir %q, got %q", mcpLogsCacheDir, response.FilePath)
+	if !strings.HasPrefix(response.FilePath, mcpLogsCacheDir()) {
+		t.Errorf("File should be in cache dir %q, got %q", mcpLogsCacheDir(), response.FilePath)
 	}
 
 	// Verify the file was actually created and contains the output
@@ -61,7 +61,7 @@ func TestBuildLogsFileResponse_SetsReadablePermissions(t *testing.T) {
 		t.Fatal("Response should include file_path")
 	}
 
-	cacheInfo, err := os.Stat(mcpLogsCacheDir)
+	cacheInfo, err := os.Stat(mcpLogsCacheDir())
 	if err != nil {
 		t.Fatalf("Cache directory should exist: %v", err)
 	}
@@ -159,3 +159,30 @@ func TestBuildLogsFileResponse_ResponseStructure(t *testing.T) {
 	// Cleanup
 	_ = os.Remove(response.FilePath)
 }
+
+func TestBuildLogsFileResponse_UsesJobScratchDir(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("GH_AW_TMP_DIR", tmpDir)
+
+	result := buildLogsFileResponse(`{"runs": []}`)
+
+	var response MCPLogsGuardrailResponse
+	if err := json.Unmarshal([]byte(result), &response); err != nil {
+		t.Fatalf("Response should be valid JSON: %v", err)
+	}
+	if !strings.HasPrefix(response.FilePath, tmpDir+"/logs-cache/") {
+		t.Errorf("File should be in the logs cache of GH_AW_TMP_DIR %q, got %q", tmpDir, response.FilePath)
+	}
+}
+
+func TestMCPLogsOutputDir(t *testing.T) {
+	t.Setenv("GH_AW_TMP_DIR", "/tmp/gh-aw/123456-1/agent-Ab3dE9xZ")
+	if got, want := mcpLogsOutputDir(), "/tmp/gh-aw/123456-1/agent-Ab3dE9xZ/aw-mcp/logs"; got != want {
+		t.Errorf("mcpLogsOutputDir() = %q, want %q", got, want)
+	}
+
+	t.Setenv("GH_AW_TMP_DIR", "")
+	if got, want := mcpLogsOutputDir(), "/tmp/gh-aw/aw-mcp/logs"; got != want {
+		t.Errorf("mcpLogsOutputDir() without GH_AW_TMP_DIR = %q, want %q", got, want)
+	}
+}
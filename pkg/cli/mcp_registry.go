@@ -27,6 +27,24 @@ type MCPRegistryServerForProcessing struct {
 	Transport            string                `json:"transport"`
 	Config               map[string]any        `json:"config"`
 	EnvironmentVariables []EnvironmentVariable `json:"environment_variables"`
+	HeaderVariables      []EnvironmentVariable `json:"header_variables"`
+}
+
+// RequiredInputs returns the environment variables and headers the server needs,
+// marking secrets, e.g. "NOTION_TOKEN (secret)".
+func (s *MCPRegistryServerForProcessing) RequiredInputs() []string {
+	var inputs []string
+	for _, variable := range append(append([]EnvironmentVariable{}, s.EnvironmentVariables...), s.HeaderVariables...) {
+		if !variable.IsRequired && !variable.IsSecret {
+			continue
+		}
+		if variable.IsSecret {
+			inputs = append(inputs, variable.Name+" (secret)")
+		} else {
+			inputs = append(inputs, variable.Name)
+		}
+	}
+	return inputs
 }
 
 // MCPRegistryClient handles communication with MCP registries
@@ -183,9 +201,24 @@ func (c *MCPRegistryClient) SearchServers(ctx context.Context, query string) ([]
 			}
 			processedServer.Args = args
 
+			// OCI packages run as containers; split the identifier into image and tag
+			// so the tag lands in the version field and the image can be digest-pinned.
+			if pkg.RegistryType == RegistryTypeOCI {
+				image, tag := splitContainerImageTag(pkg.Identifier)
+				if tag == "" {
+					tag = pkg.Version
+				}
+				processedServer.Config = map[string]any{"container": image}
+				if tag != "" {
+					processedServer.Config["version"] = tag
+				}
+			}
+
 			// Convert environment variables to config
 			if len(pkg.EnvironmentVariables) > 0 {
-				processedServer.Config = make(map[string]any)
+				if processedServer.Config == nil {
+					processedServer.Config = make(map[string]any)
+				}
 				envVars := make(map[string]any)
 
 				for _, envVar := range pkg.EnvironmentVariables {
@@ -224,6 +257,7 @@ func (c *MCPRegistryClient) SearchServers(ctx context.Context, query string) ([]
 					}
 				}
 				processedServer.Config["headers"] = headers
+				processedServer.HeaderVariables = remote.Headers
 			}
 		} else {
 			processedServer.Transport = "stdio" // default fallback
@@ -258,3 +292,13 @@ func (c *MCPRegistryClient) SearchServers(ctx context.Context, query string) ([]
 
 	return servers, nil
 }
+
+// splitContainerImageTag splits "registry/image:tag" into image and tag. A colon that
+// belongs to a registry port (e.g. "localhost:5000/image") is not treated as a tag.
+func splitContainerImageTag(reference string) (string, string) {
+	lastSlash := strings.LastIndex(reference, "/")
+	if colon := strings.LastIndex(reference, ":"); colon > lastSlash {
+		return reference[:colon], reference[colon+1:]
+	}
+	return reference, ""
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/logger"
@@ -45,7 +46,7 @@ func listAvailableServers(ctx context.Context, registryURL string, verbose bool)
 	}
 
 	// Prepare table data
-	headers := []string{"Name", "Description"}
+	headers := []string{"Name", "Description", "Requires"}
 	rows := make([][]string, 0, len(servers))
 
 	for _, server := range servers {
@@ -61,9 +62,15 @@ func listAvailableServers(ctx context.Context, registryURL string, verbose bool)
 			description = "-"
 		}
 
+		requires := strings.Join(server.RequiredInputs(), ", ")
+		if requires == "" {
+			requires = "-"
+		}
+
 		rows = append(rows, []string{
 			name,
 			description,
+			requires,
 		})
 	}
 
@@ -73,7 +80,7 @@ func listAvailableServers(ctx context.Context, registryURL string, verbose bool)
 		Headers:   headers,
 		Rows:      rows,
 		ShowTotal: true,
-		TotalRow:  []string{fmt.Sprintf("Total: %d servers", len(servers)), ""},
+		TotalRow:  []string{fmt.Sprintf("Total: %d servers", len(servers)), "", ""},
 	}
 
 	fmt.Fprint(os.Stderr, console.RenderTable(tableConfig))
@@ -109,6 +109,11 @@ const (
 	StatusInactive = "inactive"
 )
 
+// Package registry type constants
+const (
+	RegistryTypeOCI = "oci"
+)
+
 // Argument type constants
 const (
 	ArgumentTypePositional = "positional"
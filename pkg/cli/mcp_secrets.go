@@ -19,14 +19,21 @@ func checkAndSuggestSecrets(toolConfig map[string]any, verbose bool) error {
 	// Extract environment variables from the tool config
 	var requiredSecrets []string
 
-	if mcpSection, ok := toolConfig["mcp"].(map[string]any); ok {
-		if env, hasEnv := mcpSection["env"].(map[string]string); hasEnv {
-			for _, value := range env {
-				// Extract secret name from GitHub Actions syntax: ${{ secrets.SECRET_NAME }}
-				if strings.HasPrefix(value, "${{ secrets.") && strings.HasSuffix(value, " }}") {
-					secretName := value[12 : len(value)-3] // Remove "${{ secrets." and " }}"
-					requiredSecrets = append(requiredSecrets, secretName)
-				}
+	// Accept both an mcp-servers entry and the legacy tools.<id>.mcp wrapper
+	mcpSection := toolConfig
+	if nested, ok := toolConfig["mcp"].(map[string]any); ok {
+		mcpSection = nested
+	}
+	for _, field := range []string{"env", "headers"} {
+		values, ok := mcpSection[field].(map[string]string)
+		if !ok {
+			continue
+		}
+		for _, value := range values {
+			// Extract secret name from GitHub Actions syntax: ${{ secrets.SECRET_NAME }}
+			if strings.HasPrefix(value, "${{ secrets.") && strings.HasSuffix(value, " }}") {
+				secretName := value[12 : len(value)-3] // Remove "${{ secrets." and " }}"
+				requiredSecrets = append(requiredSecrets, secretName)
 			}
 		}
 	}
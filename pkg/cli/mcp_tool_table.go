@@ -2,6 +2,7 @@ package cli
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/logger"
@@ -112,3 +113,9 @@ func renderMCPToolTable(info *parser.MCPServerInfo, opts MCPToolTableOptions) st
 
 	return result
 }
+
+// isMCPToolAllowed reports whether a tool is permitted by the workflow's allowed list.
+// An empty list or a "*" entry allows every tool.
+func isMCPToolAllowed(allowed []string, toolName string) bool {
+	return len(allowed) == 0 || slices.Contains(allowed, "*") || slices.Contains(allowed, toolName)
+}
@@ -10,7 +10,6 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/github/gh-aw/pkg/constants"
 	"github.com/modelcontextprotocol/go-sdk/jsonrpc"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
@@ -178,8 +177,8 @@ from where the previous request stopped due to timeout.`,
 		}
 
 		// Build command arguments
-		// Force output directory to /tmp/gh-aw/aw-mcp/logs for MCP server
-		cmdArgs := []string{"logs", "-o", constants.TmpAwMcpLogsDir}
+		// Force output directory to the aw-mcp/logs directory of the job for MCP server
+		cmdArgs := []string{"logs", "-o", mcpLogsOutputDir()}
 		if args.WorkflowName != "" {
 			cmdArgs = append(cmdArgs, args.WorkflowName)
 		}
@@ -425,12 +424,12 @@ Multi-run diff returns JSON describing changes between the base and each compari
 		}
 
 		// Build command arguments.
-		// Force output directory to /tmp/gh-aw/aw-mcp/logs for MCP server (same as logs).
+		// Force output directory to the aw-mcp/logs directory of the job for MCP server (same as logs).
 		// Use --json flag to output structured JSON for MCP consumption.
 		// Pass all run IDs/URLs directly - the audit command handles single vs. diff mode.
 		cmdArgs := []string{"audit"}
 		cmdArgs = append(cmdArgs, runItems...)
-		cmdArgs = append(cmdArgs, "-o", constants.TmpAwMcpLogsDir, "--json")
+		cmdArgs = append(cmdArgs, "-o", mcpLogsOutputDir(), "--json")
 		if len(args.Artifacts) > 0 {
 			cmdArgs = append(cmdArgs, "--artifacts", strings.Join(args.Artifacts, ","))
 		}
@@ -569,7 +568,7 @@ Returns JSON describing the differences between the base run and each comparison
 		// Build: gh aw audit diff <base> <compare...> -o ... --json [--artifacts ...]
 		cmdArgs := []string{"audit", "diff", args.BaseRunID}
 		cmdArgs = append(cmdArgs, args.CompareRunIDs...)
-		cmdArgs = append(cmdArgs, "-o", constants.TmpAwMcpLogsDir, "--json")
+		cmdArgs = append(cmdArgs, "-o", mcpLogsOutputDir(), "--json")
 		if len(args.Artifacts) > 0 {
 			cmdArgs = append(cmdArgs, "--artifacts", strings.Join(args.Artifacts, ","))
 		}
@@ -0,0 +1,321 @@
+//go:build !integration
+
+package cli
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolvePresetParams(t *testing.T) {
+	preset, err := lookupWorkflowPreset("triage")
+	require.NoError(t, err, "triage preset should exist")
+
+	params, err := resolvePresetParams(preset, []string{"priorities= high, medium ,low", "dedup=off"})
+	require.NoError(t, err, "valid overrides should resolve")
+	assert.Equal(t, map[string]string{
+		"labels":     "bug,enhancement,documentation,question",
+		"priorities": "high, medium ,low",
+		"dedup":      "off",
+	}, params, "overrides should replace defaults and unset parameters should keep them")
+}
+
+func TestResolvePresetParamsErrors(t *testing.T) {
+	preset, err := lookupWorkflowPreset("triage")
+	require.NoError(t, err, "triage preset should exist")
+
+	tests := []struct {
+		name     string
+		override string
+		wantErr  string
+	}{
+		{name: "missing equals", override: "labels", wantErr: "expected key=value"},
+		{name: "unknown key", override: "owners=a", wantErr: "Available parameters: dedup, labels, priorities"},
+		{name: "invalid choice", override: "dedup=close", wantErr: "Expected one of: comment, label, off"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := resolvePresetParams(preset, []string{tt.override})
+			require.Error(t, err, "invalid override should be rejected")
+			assert.Contains(t, err.Error(), tt.wantErr, "error should explain the problem")
+		})
+	}
+}
+
+func TestLookupWorkflowPresetUnknown(t *testing.T) {
+	_, err := lookupWorkflowPreset("release")
+	require.Error(t, err, "unknown preset should be rejected")
+	assert.Contains(t, err.Error(), "Available presets: triage, ci-failure, dependency-review, stale-gardener, docs-drift, translation", "error should list the presets")
+}
+
+// TestPresetsCompile ensures every preset passes strict compilation for each
+// parameter variant.
+func TestPresetsCompile(t *testing.T) {
+	tests := []struct {
+		name         string
+		preset       string
+		engine       string
+		overrides    []string
+		lockContains []string
+	}{
+		{name: "triage dedup=comment", preset: "triage", overrides: []string{"dedup=comment"}},
+		{name: "triage dedup=label", preset: "triage", overrides: []string{"dedup=label"}},
+		{name: "triage dedup=off", preset: "triage", overrides: []string{"dedup=off"}},
+		{name: "ci-failure issue=on", preset: "ci-failure", overrides: []string{"issue=on"}, lockContains: []string{"name: Summarize failed job logs"}},
+		{name: "ci-failure issue=off", preset: "ci-failure", overrides: []string{"issue=off"}, lockContains: []string{"name: Summarize failed job logs"}},
+		{name: "dependency-review defaults", preset: "dependency-review"},
+		{name: "dependency-review high-risk-label", preset: "dependency-review", overrides: []string{"high-risk-label=needs-careful-review"}},
+		{name: "stale-gardener defaults", preset: "stale-gardener"},
+		{name: "stale-gardener close=on", preset: "stale-gardener", overrides: []string{"close=on", "exempt-labels="}},
+		{name: "docs-drift defaults", preset: "docs-drift"},
+		{name: "translation claude", preset: "translation", engine: "claude"},
+		{name: "translation copilot", preset: "translation"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			preset, err := lookupWorkflowPreset(tt.preset)
+			require.NoError(t, err, "preset should exist")
+			params, err := resolvePresetParams(preset, tt.overrides)
+			require.NoError(t, err, "parameters should resolve")
+			engine := tt.engine
+			if engine == "" {
+				engine = "copilot"
+			}
+			content, err := preset.Render(tt.preset, engine, params)
+			require.NoError(t, err, "preset should render")
+
+			dir := t.TempDir()
+			markdownPath := filepath.Join(dir, tt.preset+".md")
+			require.NoError(t, os.WriteFile(markdownPath, []byte(content), 0o644), "workflow should be written")
+			// Fuzzy schedules are scattered using the workflow identifier
+			compiler := workflow.NewCompiler(workflow.WithWorkflowIdentifier(".github/workflows/" + tt.preset + ".md"))
+			require.NoError(t, compiler.CompileWorkflow(markdownPath), "generated preset should compile")
+
+			lock, err := os.ReadFile(filepath.Join(dir, tt.preset+".lock.yml"))
+			require.NoError(t, err, "lock file should be written")
+			for _, want := range tt.lockContains {
+				assert.Contains(t, string(lock), want, "lock file should contain the preset's steps")
+			}
+		})
+	}
+}
+
+func TestRenderTriagePreset(t *testing.T) {
+	content, err := renderTriagePreset("issue-triage", "claude", map[string]string{
+		"labels":     "bug, feature,bug",
+		"priorities": "high,low",
+		"dedup":      "label",
+	})
+	require.NoError(t, err, "triage preset should render")
+
+	assert.Contains(t, content, `allowed: ["bug", "feature", "high", "low", "duplicate"]`, "allowed labels should combine the taxonomy, rubric, and duplicate label")
+	assert.Contains(t, content, "engine: claude", "engine should be injected")
+	assert.Contains(t, content, "- `bug`: Something is broken", "known labels should be described")
+	assert.Contains(t, content, "- `feature`\n", "custom labels should be listed without a description")
+	assert.Contains(t, content, "apply only the `duplicate` label and do not comment", "dedup policy should shape the duplicate instructions")
+
+	content, err = renderTriagePreset("issue-triage", "", map[string]string{"labels": "bug", "priorities": "P1", "dedup": "off"})
+	require.NoError(t, err, "triage preset should render without dedup")
+	assert.NotContains(t, content, "duplicate", "dedup=off should not mention duplicates")
+
+	_, err = renderTriagePreset("issue-triage", "", map[string]string{"labels": " , ", "priorities": "P1", "dedup": "off"})
+	require.Error(t, err, "an empty taxonomy should be rejected")
+}
+
+func TestRenderCIFailurePreset(t *testing.T) {
+	content, err := renderCIFailurePreset("ci-failure", "copilot", map[string]string{
+		"workflows": "CI, Release",
+		"branches":  "main,release/*",
+		"log-lines": "50",
+		"issue":     "on",
+	})
+	require.NoError(t, err, "ci-failure preset should render")
+
+	assert.Contains(t, content, `workflows: ["CI", "Release"]`, "watched workflows should be quoted")
+	assert.Contains(t, content, `branches: ["main", "release/*"]`, "branches should be quoted")
+	assert.Contains(t, content, `LOG_LINES: "50"`, "log-lines should be passed to the summarizer step")
+	assert.Contains(t, content, "create-check-run:\n    name: CI failure analysis", "diagnosis should be reported as a check run")
+	assert.Contains(t, content, "create-issue:", "issue=on should add create-issue")
+	assert.Contains(t, content, "--preset ci-failure --force --preset-param workflows=...", "notes should show how to regenerate the preset")
+
+	content, err = renderCIFailurePreset("ci-failure", "", map[string]string{"workflows": "CI", "branches": "main", "log-lines": "200", "issue": "off"})
+	require.NoError(t, err, "ci-failure preset should render without an issue")
+	assert.NotContains(t, content, "create-issue", "issue=off should not open issues")
+
+	for _, logLines := range []string{"0", "lots", "10000"} {
+		_, err = renderCIFailurePreset("ci-failure", "", map[string]string{"workflows": "CI", "branches": "main", "log-lines": logLines, "issue": "off"})
+		require.Error(t, err, "log-lines=%s should be rejected", logLines)
+	}
+}
+
+func TestRenderDependencyReviewPreset(t *testing.T) {
+	content, err := renderDependencyReviewPreset("deps", "claude", map[string]string{
+		"bots":            "dependabot, renovate[bot]",
+		"high-risk-label": "risk:high",
+	})
+	require.NoError(t, err, "dependency-review preset should render")
+
+	assert.Contains(t, content, `bots: ["dependabot[bot]", "renovate[bot]"]`, "bots should be normalized with the [bot] suffix")
+	assert.Contains(t, content, "if: github.event.pull_request.user.login == 'dependabot[bot]' || github.event.pull_request.user.login == 'renovate[bot]'", "only bot pull requests should be reviewed")
+	assert.Contains(t, content, "hide-older-comments: true", "earlier assessments should be hidden")
+	assert.Contains(t, content, `allowed: ["risk:high"]`, "the high-risk label should be the only allowed label")
+	assert.NotContains(t, content, "contents: write", "the agent should stay read-only")
+
+	content, err = renderDependencyReviewPreset("deps", "", map[string]string{"bots": "dependabot", "high-risk-label": ""})
+	require.NoError(t, err, "dependency-review preset should render without a label")
+	assert.NotContains(t, content, "add-labels", "no label should be applied when high-risk-label is empty")
+}
+
+func TestRenderStaleGardenerPreset(t *testing.T) {
+	content, err := renderStaleGardenerPreset("gardener", "claude", map[string]string{
+		"stale-days":    "90",
+		"batch-size":    "5",
+		"stale-label":   "stale",
+		"exempt-labels": "pinned, security",
+		"close":         "on",
+	})
+	require.NoError(t, err, "stale-gardener preset should render")
+
+	assert.Contains(t, content, "schedule: daily", "the gardener should run on a schedule")
+	assert.Contains(t, content, "cache-memory:", "the cursor should be kept in cache-memory")
+	assert.Contains(t, content, `STALE_DAYS: "90"`, "the stale threshold should be passed to the batch step")
+	assert.Contains(t, content, `EXEMPT_LABELS: "[\"pinned\",\"security\"]"`, "exempt labels should be passed as JSON")
+	assert.Contains(t, content, "add-comment:\n    target: \"*\"\n    max: 5", "comments should be capped at the batch size")
+	assert.Contains(t, content, `required-labels: ["stale"]`, "only issues labeled stale should be closable")
+	assert.NotContains(t, content, "issues: write", "the agent should stay read-only")
+
+	content, err = renderStaleGardenerPreset("gardener", "", map[string]string{
+		"stale-days": "60", "batch-size": "10", "stale-label": "stale", "exempt-labels": "", "close": "off",
+	})
+	require.NoError(t, err, "stale-gardener preset should render without exempt labels")
+	assert.NotContains(t, content, "close-issue", "issues should not be closed when close is off")
+	assert.Contains(t, content, `EXEMPT_LABELS: "[]"`, "no labels should be exempt")
+}
+
+func TestRenderStaleGardenerPresetErrors(t *testing.T) {
+	base := map[string]string{"stale-days": "60", "batch-size": "10", "stale-label": "stale", "exempt-labels": "", "close": "off"}
+	tests := []struct {
+		name    string
+		key     string
+		value   string
+		wantErr string
+	}{
+		{name: "stale days too small", key: "stale-days", value: "3", wantErr: "between 7 and 365"},
+		{name: "batch size not a number", key: "batch-size", value: "many", wantErr: "between 1 and 30"},
+		{name: "batch size too large", key: "batch-size", value: "100", wantErr: "between 1 and 30"},
+		{name: "empty stale label", key: "stale-label", value: " ", wantErr: "must not be empty"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := maps.Clone(base)
+			params[tt.key] = tt.value
+			_, err := renderStaleGardenerPreset("gardener", "", params)
+			require.Error(t, err, "invalid parameter should be rejected")
+			assert.Contains(t, err.Error(), tt.wantErr, "error should explain the valid range")
+		})
+	}
+}
+
+func TestRenderDocsDriftPreset(t *testing.T) {
+	content, err := renderDocsDriftPreset("drift", "claude", map[string]string{
+		"docs-paths": "docs, README.md",
+		"days":       "14",
+		"max-issues": "2",
+		"label":      "docs",
+	})
+	require.NoError(t, err, "docs-drift preset should render")
+
+	assert.Contains(t, content, "schedule: weekly", "drift should be checked on a schedule")
+	assert.Contains(t, content, "fetch-depth: 0", "the repository map needs the commit history")
+	assert.Contains(t, content, "name: Build repository map", "the repository map should be built before the agent starts")
+	assert.Contains(t, content, `DOCS_PATHS: "[\"docs\",\"README.md\"]"`, "documentation paths should be passed as JSON")
+	assert.Contains(t, content, `DAYS: "14"`, "the review window should be passed to the map step")
+	assert.Contains(t, content, "labels: [\"docs\"]\n    max: 2", "issues should be labeled and capped")
+	assert.NotContains(t, content, "issues: write", "the agent should stay read-only")
+
+	content, err = renderDocsDriftPreset("drift", "", map[string]string{"docs-paths": "docs", "days": "7", "max-issues": "3", "label": ""})
+	require.NoError(t, err, "docs-drift preset should render without a label")
+	assert.NotContains(t, content, "labels:", "no label should be applied when label is empty")
+}
+
+func TestRenderDocsDriftPresetErrors(t *testing.T) {
+	base := map[string]string{"docs-paths": "docs", "days": "7", "max-issues": "3", "label": ""}
+	tests := []struct {
+		name    string
+		key     string
+		value   string
+		wantErr string
+	}{
+		{name: "no docs paths", key: "docs-paths", value: " , ", wantErr: "must list at least one value"},
+		{name: "days too large", key: "days", value: "365", wantErr: "between 1 and 90"},
+		{name: "max issues zero", key: "max-issues", value: "0", wantErr: "between 1 and 10"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := maps.Clone(base)
+			params[tt.key] = tt.value
+			_, err := renderDocsDriftPreset("drift", "", params)
+			require.Error(t, err, "invalid parameter should be rejected")
+			assert.Contains(t, err.Error(), tt.wantErr, "error should explain the valid values")
+		})
+	}
+}
+
+func TestRenderTranslationPreset(t *testing.T) {
+	content, err := renderTranslationPreset("translate", "claude", map[string]string{
+		"source":    "docs/en",
+		"languages": "fr, pt-BR",
+		"glossary":  ".github/glossary.md",
+		"branch":    "main",
+		"label":     "i18n",
+	})
+	require.NoError(t, err, "translation preset should render")
+
+	assert.Contains(t, content, `paths: ["docs/en/**"]`, "translation should run when the source docs change")
+	assert.Contains(t, content, `read: ["docs/en"]`, "the agent should read the source language")
+	assert.Contains(t, content, `write: ["docs/fr", "docs/pt-BR"]`, "the agent should only write the language directories")
+	assert.Contains(t, content, `group-by-directory: "docs/*"`, "pull requests should be grouped by language directory")
+	assert.Contains(t, content, `allowed-files: ["docs/fr/**", "docs/pt-BR/**"]`, "pull requests should only change the language directories")
+	assert.Contains(t, content, "max: 2", "at most one pull request per language")
+	assert.Contains(t, content, `labels: ["i18n"]`, "pull requests should be labeled")
+	assert.Contains(t, content, "{{#runtime-import? .github/glossary.md}}", "the glossary should be imported into the prompt")
+	assert.NotContains(t, content, "contents: write", "the agent should stay read-only")
+
+	content, err = renderTranslationPreset("translate", "", map[string]string{"source": "en", "languages": "fr", "glossary": "", "branch": "main", "label": ""})
+	require.NoError(t, err, "translation preset should render for top-level language directories")
+	assert.Contains(t, content, `group-by-directory: "*"`, "top-level language directories should be grouped at the root")
+	assert.NotContains(t, content, "runtime-import", "no glossary should be imported when glossary is empty")
+	assert.NotContains(t, content, "labels:", "no label should be applied when label is empty")
+}
+
+func TestRenderTranslationPresetErrors(t *testing.T) {
+	base := map[string]string{"source": "docs/en", "languages": "fr", "glossary": "", "branch": "main", "label": ""}
+	tests := []struct {
+		name    string
+		key     string
+		value   string
+		wantErr string
+	}{
+		{name: "source escapes the repository", key: "source", value: "../docs", wantErr: "relative directory"},
+		{name: "source with glob", key: "source", value: "docs/*", wantErr: "relative directory"},
+		{name: "language with path", key: "languages", value: "fr/ca", wantErr: "directory names"},
+		{name: "source language as target", key: "languages", value: "en,fr", wantErr: "must not include the source language"},
+		{name: "glossary outside .github", key: "glossary", value: "docs/glossary.md", wantErr: "under .github/"},
+		{name: "empty branch", key: "branch", value: " ", wantErr: "must not be empty"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := maps.Clone(base)
+			params[tt.key] = tt.value
+			_, err := renderTranslationPreset("translate", "", params)
+			require.Error(t, err, "invalid parameter should be rejected")
+			assert.Contains(t, err.Error(), tt.wantErr, "error should explain the valid values")
+		})
+	}
+}
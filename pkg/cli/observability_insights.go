@@ -35,6 +35,7 @@ type workflowObservabilityStats struct {
 	blocked      int
 	totalNet     int
 	blockedAtCap bool
+	oomRuns      int
 }
 
 func buildAuditObservabilityInsights(processedRun ProcessedRun, metrics MetricsData, toolUsage []ToolUsageInfo, createdItems []CreatedItemReport) []ObservabilityInsight {
@@ -177,6 +178,9 @@ func buildLogsObservabilityInsights(processedRuns []ProcessedRun, toolUsage []To
 		if pr.Run.Conclusion == "timed_out" {
 			stats.timedOuts++
 		}
+		if pr.Run.MemoryPressure > 0 {
+			stats.oomRuns++
+		}
 		stats.missingTools += len(pr.MissingTools)
 		stats.mcpFailures += len(pr.MCPFailures)
 		stats.missingData += len(pr.MissingData)
@@ -269,6 +273,31 @@ func buildLogsObservabilityInsights(processedRuns []ProcessedRun, toolUsage []To
 		})
 	}
 
+	var memoryHotspot *workflowObservabilityStats
+	for _, stats := range workflowStats {
+		if stats.oomRuns == 0 {
+			continue
+		}
+		if memoryHotspot == nil || stats.oomRuns > memoryHotspot.oomRuns || (stats.oomRuns == memoryHotspot.oomRuns && stats.workflowName < memoryHotspot.workflowName) {
+			memoryHotspot = stats
+		}
+	}
+	if memoryHotspot != nil {
+		oomRate := float64(memoryHotspot.oomRuns) / float64(memoryHotspot.runs)
+		severity := "medium"
+		if oomRate >= 0.5 {
+			severity = "high"
+		}
+		observabilityInsightsLog.Printf("Memory pressure detected: workflow=%s oom_runs=%d runs=%d", memoryHotspot.workflowName, memoryHotspot.oomRuns, memoryHotspot.runs)
+		insights = append(insights, ObservabilityInsight{
+			Category: "resources",
+			Severity: severity,
+			Title:    "Memory pressure observed",
+			Summary:  fmt.Sprintf("Workflow %s ran out of memory in %d of %d run(s). Declare resources.memory in its frontmatter so the compiler selects a larger runner.", memoryHotspot.workflowName, memoryHotspot.oomRuns, memoryHotspot.runs),
+			Evidence: fmt.Sprintf("workflow=%s oom_runs=%d runs=%d", memoryHotspot.workflowName, memoryHotspot.oomRuns, memoryHotspot.runs),
+		})
+	}
+
 	var networkHotspot *workflowObservabilityStats
 	var networkRate float64
 	for _, stats := range workflowStats {
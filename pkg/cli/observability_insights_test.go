@@ -4,6 +4,8 @@ package cli
 
 import (
 	"bytes"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -227,3 +229,36 @@ func TestRenderObservabilityInsightsUsesConsoleFormatting(t *testing.T) {
 	assert.Equal(t, console.FormatListItemStderr("Evidence: missing_tools=1"), lines[2])
 	assert.Empty(t, lines[3])
 }
+
+func TestBuildLogsObservabilityInsightsMemoryPressure(t *testing.T) {
+	processedRuns := []ProcessedRun{
+		{Run: WorkflowRun{WorkflowName: "build", Conclusion: "failure", MemoryPressure: 2}},
+		{Run: WorkflowRun{WorkflowName: "build", Conclusion: "success"}},
+		{Run: WorkflowRun{WorkflowName: "docs", Conclusion: "success"}},
+	}
+
+	insights := buildLogsObservabilityInsights(processedRuns, nil)
+
+	var memoryInsight *ObservabilityInsight
+	for i := range insights {
+		if insights[i].Title == "Memory pressure observed" {
+			memoryInsight = &insights[i]
+		}
+	}
+	require.NotNil(t, memoryInsight, "expected a memory pressure insight")
+	assert.Equal(t, "high", memoryInsight.Severity, "half of the runs ran out of memory")
+	assert.Contains(t, memoryInsight.Summary, "Workflow build ran out of memory in 1 of 2 run(s)")
+	assert.Contains(t, memoryInsight.Summary, "resources.memory")
+}
+
+func TestCountMemoryPressureSignals(t *testing.T) {
+	runDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(runDir, "workflow-logs", "agent"), 0o755))
+	stepLog := "Running tests\n<--- JS stacktrace --->\nFATAL ERROR: Reached heap limit Allocation failed - JavaScript heap out of memory\n##[error]Process completed with exit code 137.\n"
+	require.NoError(t, os.WriteFile(filepath.Join(runDir, "workflow-logs", "agent", "5_Execute.txt"), []byte(stepLog), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(runDir, "agent-stdio.log"), []byte("all good\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(runDir, "aw_info.json"), []byte(`{"note": "exited with code 137"}`), 0o644))
+
+	assert.Equal(t, 2, countMemoryPressureSignals(runDir), "only log lines should be counted")
+	assert.Equal(t, 0, countMemoryPressureSignals(t.TempDir()))
+}
@@ -104,6 +104,7 @@ var outcomeEvaluators = map[string]outcomeEvaluator{
 	"hide_comment":                          evalHideComment,
 	"assign_milestone":                      evalAssignMilestone,
 	"create_pull_request_review_comment":    evalReviewComment,
+	"create_pull_request_review_suggestion": evalReviewComment,
 	"resolve_pull_request_review_thread":    evalResolveThread,
 	"mark_pull_request_as_ready_for_review": evalMarkReady,
 	"push_to_pull_request_branch":           evalPushToPRBranch,
@@ -476,7 +477,7 @@ func resolveOutcomeIntent(report OutcomeReport, repo string, mapping *github.Obj
 
 func isPullRequestOutcomeType(outcomeType string) bool {
 	switch outcomeType {
-	case "create_pull_request", "update_pull_request", "create_pull_request_review_comment",
+	case "create_pull_request", "update_pull_request", "create_pull_request_review_comment", "create_pull_request_review_suggestion",
 		"resolve_pull_request_review_thread", "mark_pull_request_as_ready_for_review",
 		"push_to_pull_request_branch", "add_reviewer", "submit_pull_request_review":
 		return true
@@ -0,0 +1,239 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/gitutil"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/spf13/cobra"
+)
+
+var rollbackLog = logger.New("cli:rollback")
+
+// RollbackConfig holds configuration for the rollback command
+type RollbackConfig struct {
+	WorkflowName string
+	To           string
+	JSONOutput   bool
+	Verbose      bool
+}
+
+// rollbackVersionInfo is the JSON representation of a recorded prompt version
+type rollbackVersionInfo struct {
+	workflow.PromptVersion
+	Current bool `json:"current"`
+}
+
+// NewRollbackCommand creates the rollback command
+func NewRollbackCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rollback <workflow>",
+		Short: "Restore a previously compiled version of a workflow prompt",
+		Long: `Restore the markdown and lock file of a workflow to a previously compiled prompt version.
+
+Once a lock file whose prompt changed has been committed, the next
+` + string(constants.CLIExtensionPrefix) + ` compile records a new version in .github/aw/prompt-registry.json
+together with its prompt hash (also stored as prompt_hash in the lock file header)
+and the commit. The registry is a local index of the git history: add it to
+.gitignore rather than committing it.
+
+When called without --to, lists the recorded versions of the workflow.
+When called with --to, restores both the markdown and the lock file from the
+commit of that prompt version. The restored files are left uncommitted so they
+can be reviewed before pushing.
+
+The --to value can be a version number (3 or v3) or a prompt hash prefix.
+
+Imported files are not restored: if the regression came from a shared import,
+roll that file back separately.
+
+` + WorkflowIDExplanation,
+		Example: `  ` + string(constants.CLIExtensionPrefix) + ` rollback issue-triage              # List recorded prompt versions
+  ` + string(constants.CLIExtensionPrefix) + ` rollback issue-triage --to v3      # Restore version 3
+  ` + string(constants.CLIExtensionPrefix) + ` rollback issue-triage --to 4f2a9c1 # Restore by prompt hash prefix
+  ` + string(constants.CLIExtensionPrefix) + ` rollback issue-triage --json       # List versions in JSON format`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			to, _ := cmd.Flags().GetString("to")
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			verbose, _ := cmd.Flags().GetBool("verbose")
+
+			return RunRollback(RollbackConfig{
+				WorkflowName: args[0],
+				To:           to,
+				JSONOutput:   jsonOutput,
+				Verbose:      verbose,
+			})
+		},
+	}
+
+	cmd.Flags().String("to", "", "Prompt version to restore (version number such as v3, or prompt hash prefix)")
+	addJSONFlag(cmd)
+
+	return cmd
+}
+
+// RunRollback lists or restores the recorded prompt versions of a workflow
+func RunRollback(config RollbackConfig) error {
+	rollbackLog.Printf("Running rollback: workflow=%s, to=%s", config.WorkflowName, config.To)
+
+	gitRoot, err := gitutil.FindGitRoot()
+	if err != nil {
+		return errors.New("rollback requires a git repository")
+	}
+
+	registry, err := workflow.LoadPromptRegistry(gitRoot)
+	if err != nil {
+		return err
+	}
+
+	workflowID := normalizeWorkflowID(config.WorkflowName)
+	entry, ok := registry.Workflows[workflowID]
+	if !ok || len(entry.Versions) == 0 {
+		return fmt.Errorf("no prompt versions recorded for workflow '%s'. Versions are recorded when '%s compile' writes the lock file", workflowID, string(constants.CLIExtensionPrefix))
+	}
+
+	lockPath := filepath.Join(gitRoot, filepath.FromSlash(entry.Lock))
+	markdownPath := filepath.Join(gitRoot, filepath.FromSlash(entry.Markdown))
+	currentHash := readLockPromptHash(lockPath)
+
+	if config.To == "" {
+		return printPromptVersions(workflowID, entry, currentHash, config.JSONOutput)
+	}
+
+	target, err := entry.ResolveVersion(config.To)
+	if err != nil {
+		return fmt.Errorf("cannot roll back '%s': %w", workflowID, err)
+	}
+
+	if currentHash == target.PromptHash {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Workflow '%s' is already at prompt %s (%s)", workflowID, target.Label(), workflow.ShortPromptHash(target.PromptHash))))
+		return nil
+	}
+
+	commit, lockContent, err := findPromptVersionCommit(gitRoot, lockPath, entry.Lock, target)
+	if err != nil {
+		return err
+	}
+
+	markdownContent, err := gitutil.ReadFileFromCommit(markdownPath, gitRoot, commit)
+	if err != nil {
+		return fmt.Errorf("found prompt %s in commit %s but could not read %s from it: %w", target.Label(), shortCommit(commit), entry.Markdown, err)
+	}
+
+	if err := os.WriteFile(markdownPath, []byte(markdownContent), constants.FilePermPublic); err != nil {
+		return fmt.Errorf("failed to restore %s: %w", entry.Markdown, err)
+	}
+	if err := os.WriteFile(lockPath, []byte(lockContent), constants.FilePermPublic); err != nil {
+		return fmt.Errorf("failed to restore %s: %w", entry.Lock, err)
+	}
+
+	fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Rolled back '%s' to prompt %s (%s) from commit %s", workflowID, target.Label(), workflow.ShortPromptHash(target.PromptHash), shortCommit(commit))))
+	fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Restored %s and %s. Review the changes and commit them to deploy the rollback.", entry.Markdown, entry.Lock)))
+	return nil
+}
+
+// printPromptVersions renders the recorded versions of a workflow, newest first
+func printPromptVersions(workflowID string, entry *workflow.PromptRegistryEntry, currentHash string, jsonOutput bool) error {
+	if jsonOutput {
+		versions := make([]rollbackVersionInfo, 0, len(entry.Versions))
+		for _, version := range entry.Versions {
+			versions = append(versions, rollbackVersionInfo{PromptVersion: version, Current: version.PromptHash == currentHash})
+		}
+		data, err := json.MarshalIndent(versions, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal prompt versions: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	rows := make([][]string, 0, len(entry.Versions))
+	for i := len(entry.Versions) - 1; i >= 0; i-- {
+		version := entry.Versions[i]
+		current := ""
+		if version.PromptHash == currentHash {
+			current = "*"
+		}
+		rows = append(rows, []string{current, version.Label(), workflow.ShortPromptHash(version.PromptHash), shortCommit(version.Commit), version.CommittedAt, version.CompilerVersion})
+	}
+	fmt.Print(console.RenderTable(console.TableConfig{
+		Title:   "Prompt versions of " + workflowID,
+		Headers: []string{"", "Version", "Prompt Hash", "Commit", "Committed", "Compiler"},
+		Rows:    rows,
+	}))
+	return nil
+}
+
+// readLockPromptHash returns the prompt hash of the lock file on disk, or "" when unavailable
+func readLockPromptHash(lockPath string) string {
+	content, err := os.ReadFile(lockPath)
+	if err != nil {
+		return ""
+	}
+	metadata, _, err := workflow.ExtractMetadataFromLockFile(string(content))
+	if err != nil || metadata == nil {
+		return ""
+	}
+	if metadata.PromptHash != "" {
+		return metadata.PromptHash
+	}
+	return workflow.ComputePromptHash(metadata.FrontmatterHash, metadata.BodyHash)
+}
+
+// findPromptVersionCommit returns the commit whose lock file carries the target prompt
+// together with that lock content. The recorded commit is used when it is still
+// reachable; otherwise (e.g. after a squash merge) the history of the lock file is
+// searched, newest first.
+func findPromptVersionCommit(gitRoot, lockPath, lockRel string, target workflow.PromptVersion) (string, string, error) {
+	if content, err := gitutil.ReadFileFromCommit(lockPath, gitRoot, target.Commit); err == nil && lockCarriesPrompt(content, target) {
+		return target.Commit, content, nil
+	}
+
+	cmd := exec.Command("git", "-C", gitRoot, "log", "--format=%H", "--", lockRel)
+	output, err := cmd.Output()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read git history of %s: %w", lockRel, err)
+	}
+
+	commits := strings.Fields(string(output))
+	rollbackLog.Printf("Searching %d commits of %s for prompt %s", len(commits), lockRel, target.PromptHash)
+	for _, commit := range commits {
+		content, err := gitutil.ReadFileFromCommit(lockPath, gitRoot, commit)
+		if err != nil {
+			continue
+		}
+		if lockCarriesPrompt(content, target) {
+			return commit, content, nil
+		}
+	}
+
+	return "", "", fmt.Errorf("prompt %s (%s) is no longer in the git history: no commit of %s has that prompt hash", target.Label(), workflow.ShortPromptHash(target.PromptHash), lockRel)
+}
+
+// lockCarriesPrompt reports whether the lock file content carries the target prompt
+func lockCarriesPrompt(content string, target workflow.PromptVersion) bool {
+	metadata, _, err := workflow.ExtractMetadataFromLockFile(content)
+	if err != nil || metadata == nil {
+		return false
+	}
+	return metadata.PromptHash == target.PromptHash ||
+		(metadata.FrontmatterHash == target.FrontmatterHash && metadata.BodyHash == target.BodyHash)
+}
+
+// shortCommit abbreviates a commit SHA for display
+func shortCommit(commit string) string {
+	if len(commit) > 7 {
+		return commit[:7]
+	}
+	return commit
+}
@@ -0,0 +1,128 @@
+//go:build !integration
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rollbackTestLock returns a minimal lock file carrying the given hashes in its metadata header
+func rollbackTestLock(frontmatterHash, bodyHash string) string {
+	promptHash := workflow.ComputePromptHash(frontmatterHash, bodyHash)
+	return fmt.Sprintf("# gh-aw-metadata: {\"schema_version\":\"v4\",\"frontmatter_hash\":%q,\"body_hash\":%q,\"prompt_hash\":%q}\nname: triage\n", frontmatterHash, bodyHash, promptHash)
+}
+
+// setupRollbackRepo creates a git repository with two committed versions of a workflow
+// and a registry recording both, and changes into it.
+func setupRollbackRepo(t *testing.T) (string, *workflow.PromptRegistry) {
+	t.Helper()
+	root := t.TempDir()
+	t.Chdir(root)
+
+	git := func(args ...string) string {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = root
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, "git %v failed: %s", args, output)
+		return strings.TrimSpace(string(output))
+	}
+	git("init", "-q")
+
+	workflowsDir := filepath.Join(root, ".github", "workflows")
+	require.NoError(t, os.MkdirAll(workflowsDir, 0o755), "workflows dir should be created")
+	markdownPath := filepath.Join(workflowsDir, "triage.md")
+	lockPath := filepath.Join(workflowsDir, "triage.lock.yml")
+
+	registry := workflow.NewPromptRegistry(root)
+	for _, body := range []string{"b1", "b2"} {
+		require.NoError(t, os.WriteFile(markdownPath, []byte("Prompt "+body+"\n"), 0o644), "markdown should be written")
+		lock := rollbackTestLock("fm", body)
+		require.NoError(t, os.WriteFile(lockPath, []byte(lock), 0o644), "lock should be written")
+		metadata, _, err := workflow.ExtractMetadataFromLockFile(lock)
+		require.NoError(t, err, "test lock metadata should parse")
+		git("add", ".")
+		git("commit", "-q", "-m", "prompt "+body)
+		registry.Record("triage", markdownPath, lockPath, metadata, git("rev-parse", "HEAD"), time.Now())
+	}
+	require.NoError(t, registry.Save(), "registry should save")
+	return root, registry
+}
+
+func TestRunRollbackRestoresVersion(t *testing.T) {
+	root, _ := setupRollbackRepo(t)
+
+	require.NoError(t, RunRollback(RollbackConfig{WorkflowName: "triage", To: "v1"}), "rollback should succeed")
+
+	markdown, err := os.ReadFile(filepath.Join(root, ".github", "workflows", "triage.md"))
+	require.NoError(t, err, "markdown should exist")
+	assert.Equal(t, "Prompt b1\n", string(markdown), "markdown should be restored")
+	lock, err := os.ReadFile(filepath.Join(root, ".github", "workflows", "triage.lock.yml"))
+	require.NoError(t, err, "lock should exist")
+	assert.Equal(t, rollbackTestLock("fm", "b1"), string(lock), "lock should be restored")
+
+	registry, err := workflow.LoadPromptRegistry(root)
+	require.NoError(t, err, "registry should load")
+	assert.Len(t, registry.Workflows["triage"].Versions, 2, "the uncommitted rollback should not be recorded")
+
+	require.NoError(t, RunRollback(RollbackConfig{WorkflowName: "triage", To: "v1"}), "rolling back to the current prompt should be a no-op")
+}
+
+func TestRunRollbackByPromptHash(t *testing.T) {
+	root, registry := setupRollbackRepo(t)
+	hash := registry.Workflows["triage"].Versions[0].PromptHash
+
+	require.NoError(t, RunRollback(RollbackConfig{WorkflowName: ".github/workflows/triage.md", To: hash[:10]}), "rollback by hash prefix should succeed")
+
+	markdown, err := os.ReadFile(filepath.Join(root, ".github", "workflows", "triage.md"))
+	require.NoError(t, err, "markdown should exist")
+	assert.Equal(t, "Prompt b1\n", string(markdown), "markdown should be restored")
+}
+
+func TestRunRollbackSearchesHistoryForUnreachableCommit(t *testing.T) {
+	root, registry := setupRollbackRepo(t)
+	// The recorded commit is gone (e.g. squash-merged branch) but the prompt is still in history
+	registry.Workflows["triage"].Versions[0].Commit = strings.Repeat("0", 40)
+	data, err := json.MarshalIndent(registry, "", "  ")
+	require.NoError(t, err, "registry should marshal")
+	require.NoError(t, os.WriteFile(registry.GetPath(), data, 0o644), "registry should be written")
+
+	require.NoError(t, RunRollback(RollbackConfig{WorkflowName: "triage", To: "v1"}), "rollback should find the prompt in the history")
+
+	markdown, err := os.ReadFile(filepath.Join(root, ".github", "workflows", "triage.md"))
+	require.NoError(t, err, "markdown should exist")
+	assert.Equal(t, "Prompt b1\n", string(markdown), "markdown should be restored")
+}
+
+func TestRunRollbackErrors(t *testing.T) {
+	root, registry := setupRollbackRepo(t)
+
+	err := RunRollback(RollbackConfig{WorkflowName: "unknown", To: "v1"})
+	require.Error(t, err, "unknown workflow should fail")
+	assert.Contains(t, err.Error(), "no prompt versions recorded", "error should explain that nothing was recorded")
+
+	err = RunRollback(RollbackConfig{WorkflowName: "triage", To: "v7"})
+	require.Error(t, err, "unknown version should fail")
+	assert.Contains(t, err.Error(), "not recorded", "error should name the missing version")
+
+	// A version whose commit is gone from the history (e.g. a deleted branch) cannot be restored
+	metadata, _, err := workflow.ExtractMetadataFromLockFile(rollbackTestLock("fm", "b3"))
+	require.NoError(t, err, "test lock metadata should parse")
+	lockPath := filepath.Join(root, ".github", "workflows", "triage.lock.yml")
+	registry.Record("triage", filepath.Join(root, ".github", "workflows", "triage.md"), lockPath, metadata, strings.Repeat("0", 40), time.Now())
+	require.NoError(t, registry.Save(), "registry should save")
+
+	err = RunRollback(RollbackConfig{WorkflowName: "triage", To: "v3"})
+	require.Error(t, err, "unreachable version should fail")
+	assert.Contains(t, err.Error(), "no longer in the git history", "error should explain that the version is not in git history")
+}
@@ -0,0 +1,105 @@
+// This file provides command-line interface functionality for gh-aw.
+// This file (run_fixture.go) implements `gh aw run --fixture`, which simulates how the
+// job conditions of compiled workflows evaluate for a fixture event without dispatching
+// anything to GitHub Actions.
+//
+// Key responsibilities:
+//   - Loading the fixture event and the local lock files
+//   - Evaluating the job conditions in dependency order
+//   - Rendering the simulated job results as a table or JSON
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/workflow"
+)
+
+var runFixtureLog = logger.New("cli:run_fixture")
+
+// WorkflowSimulationResult contains the simulated jobs of one workflow for JSON output
+type WorkflowSimulationResult struct {
+	Workflow string                   `json:"workflow"`
+	LockFile string                   `json:"lock_file"`
+	Event    string                   `json:"event"`
+	Jobs     []workflow.JobSimulation `json:"jobs"`
+}
+
+// simulateWorkflowsWithFixture evaluates the job conditions of the local lock files of
+// the given workflows against the fixture in opts.Fixture.
+func simulateWorkflowsWithFixture(workflowNames []string, opts RunOptions) error {
+	fixture, err := workflow.LoadExpressionFixture(opts.Fixture)
+	if err != nil {
+		return err
+	}
+	runFixtureLog.Printf("Simulating %d workflow(s) with fixture %s (event=%s)", len(workflowNames), opts.Fixture, fixture.EventName)
+
+	results := make([]WorkflowSimulationResult, 0, len(workflowNames))
+	for _, workflowName := range workflowNames {
+		result, err := simulateWorkflowWithFixture(workflowName, fixture)
+		if err != nil {
+			return err
+		}
+		results = append(results, result)
+	}
+
+	if opts.JSON {
+		jsonBytes, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Fprintln(os.Stdout, string(jsonBytes))
+		return nil
+	}
+	for _, result := range results {
+		renderWorkflowSimulation(result, opts.Verbose)
+	}
+	return nil
+}
+
+func simulateWorkflowWithFixture(workflowName string, fixture *workflow.ExpressionFixture) (WorkflowSimulationResult, error) {
+	normalizedID := normalizeWorkflowID(workflowName)
+	lockFileName := normalizedID + ".lock.yml"
+	lockFilePath := filepath.Join(constants.GetWorkflowDir(), lockFileName)
+	content, err := os.ReadFile(lockFilePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return WorkflowSimulationResult{}, fmt.Errorf("workflow lock file '%s' not found in %s; run '%s compile %s' first", lockFileName, constants.GetWorkflowDir(), string(constants.CLIExtensionPrefix), normalizedID)
+		}
+		return WorkflowSimulationResult{}, fmt.Errorf("failed to read %s: %w", lockFilePath, err)
+	}
+	jobs, err := workflow.SimulateWorkflowJobs(content, fixture)
+	if err != nil {
+		return WorkflowSimulationResult{}, fmt.Errorf("failed to simulate %s: %w", lockFileName, err)
+	}
+	return WorkflowSimulationResult{Workflow: normalizedID, LockFile: lockFileName, Event: fixture.EventName, Jobs: jobs}, nil
+}
+
+// renderWorkflowSimulation prints the simulated jobs of a workflow, truncating long
+// conditions unless verbose output is enabled.
+func renderWorkflowSimulation(result WorkflowSimulationResult, verbose bool) {
+	fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Simulated %s for %s event", result.LockFile, result.Event)))
+	config := console.TableConfig{
+		Headers: []string{"Job", "Result", "Condition"},
+		Rows:    make([][]string, 0, len(result.Jobs)),
+	}
+	for _, job := range result.Jobs {
+		condition := job.Condition
+		if job.Error != "" {
+			condition = "error: " + job.Error
+		}
+		if !verbose {
+			condition = stringutil.Truncate(condition, 80)
+		}
+		config.Rows = append(config.Rows, []string{job.Job, job.Result, condition})
+	}
+	fmt.Fprint(os.Stderr, console.RenderTable(config))
+}
@@ -0,0 +1,40 @@
+//go:build !integration
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulateWorkflowWithFixture(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("GH_AW_WORKFLOWS_DIR", dir)
+	lockContent := `jobs:
+  activation:
+    if: github.event_name == 'issues' && github.event.action == 'opened'
+    runs-on: ubuntu-latest
+  agent:
+    needs: activation
+    runs-on: ubuntu-latest
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "triage.lock.yml"), []byte(lockContent), 0o644), "failed to write lock file")
+
+	fixture := &workflow.ExpressionFixture{EventName: "issues", Event: map[string]any{"action": "closed"}}
+	result, err := simulateWorkflowWithFixture("triage.md", fixture)
+	require.NoError(t, err, "simulation should succeed")
+	assert.Equal(t, "triage", result.Workflow, "workflow ID should be normalized")
+	assert.Equal(t, "issues", result.Event, "event name should be reported")
+	require.Len(t, result.Jobs, 2, "both jobs should be simulated")
+	assert.Equal(t, "skipped", result.Jobs[0].Result, "activation should be skipped for closed issues")
+	assert.Equal(t, "skipped", result.Jobs[1].Result, "agent should be skipped when activation is skipped")
+
+	_, err = simulateWorkflowWithFixture("missing", fixture)
+	require.Error(t, err, "missing lock file should fail")
+	assert.Contains(t, err.Error(), "compile missing", "error should suggest compiling the workflow")
+}
@@ -142,6 +142,47 @@ jobs:
 			providedInputs: []string{"issue_url=https://github.com/owner/repo/issues/123"},
 			expectError:    false,
 		},
+		{
+			name: "choice and boolean values outside their types",
+			lockContent: `name: "Test Workflow"
+on:
+  workflow_dispatch:
+    inputs:
+      depth:
+        type: choice
+        options: [quick, deep]
+      dry_run:
+        type: boolean
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo "test"
+`,
+			providedInputs: []string{"depth=thorough", "dry_run=yes"},
+			expectError:    true,
+			errorContains:  []string{"Invalid input value(s)", "'depth' must be one of quick, deep", "'dry_run' must be true or false"},
+		},
+		{
+			name: "choice and boolean values within their types",
+			lockContent: `name: "Test Workflow"
+on:
+  workflow_dispatch:
+    inputs:
+      depth:
+        type: choice
+        options: [quick, deep]
+      dry_run:
+        type: boolean
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo "test"
+`,
+			providedInputs: []string{"depth=deep", "dry_run=true"},
+			expectError:    false,
+		},
 		{
 			name: "missing required input",
 			lockContent: `name: "Test Workflow"
@@ -264,49 +264,24 @@ func collectWorkflowInputs(ctx context.Context, wf *WorkflowOption) ([]string, e
 	return collectInputsWithMap(ctx, wf.Inputs)
 }
 
-// collectInputsWithMap collects inputs using a map to properly capture values
+// collectInputsWithMap collects inputs using a map to properly capture values.
+// Inputs are prompted in name order: choice inputs as a select of their options,
+// boolean inputs as a true/false select, and all other inputs as free text that is
+// validated against the input type.
 func collectInputsWithMap(ctx context.Context, inputs map[string]*workflow.InputDefinition) ([]string, error) {
-	// Create a map to store string values for the form
-	inputValues := make(map[string]string)
-	// Create a map to track the string pointers we'll pass to huh
+	// Track the string pointers we'll pass to huh
 	inputPtrs := make(map[string]*string)
 	var formGroups []*huh.Group
 
-	// Create input fields for each workflow input
-	for name, input := range inputs {
-		inputName := name
-		inputDef := input
+	// Create a field for each workflow input
+	for _, inputName := range sliceutil.SortedKeys(inputs) {
+		inputDef := inputs[inputName]
 
-		// Initialize with default value (convert any to string)
-		defaultStr := ""
-		if inputDef.Default != nil {
-			defaultStr = fmt.Sprintf("%v", inputDef.Default)
-		}
-		inputValues[inputName] = defaultStr
-
-		// Create a string variable for this input that huh can update
-		valueStr := defaultStr
+		// Initialize with default value
+		valueStr := inputDef.GetDefaultAsString()
 		inputPtrs[inputName] = &valueStr
 
-		// Create input field that updates the string variable
-		field := huh.NewInput().
-			Title(fmt.Sprintf("Enter value for '%s'", inputName)).
-			Value(inputPtrs[inputName])
-
-		if inputDef.Description != "" {
-			field = field.Description(inputDef.Description)
-		}
-
-		if inputDef.Required {
-			field = field.Validate(func(s string) error {
-				if s == "" {
-					return errors.New("this input is required")
-				}
-				return nil
-			})
-		}
-
-		group := huh.NewGroup(field)
+		group := huh.NewGroup(buildInputField(inputName, inputDef, inputPtrs[inputName]))
 		formGroups = append(formGroups, group)
 	}
 
@@ -318,8 +293,8 @@ func collectInputsWithMap(ctx context.Context, inputs map[string]*workflow.Input
 
 	// Collect the final values from the pointers
 	var result []string
-	for name, valuePtr := range inputPtrs {
-		value := *valuePtr
+	for _, name := range sliceutil.SortedKeys(inputPtrs) {
+		value := *inputPtrs[name]
 		if value != "" {
 			result = append(result, fmt.Sprintf("%s=%s", name, value))
 		}
@@ -329,6 +304,55 @@ func collectInputsWithMap(ctx context.Context, inputs map[string]*workflow.Input
 	return result, nil
 }
 
+// buildInputField returns the form field used to prompt for a workflow input.
+func buildInputField(inputName string, inputDef *workflow.InputDefinition, value *string) huh.Field {
+	var options []string
+	switch inputDef.Type {
+	case "choice":
+		options = inputDef.Options
+	case "boolean":
+		options = []string{"true", "false"}
+	}
+
+	if len(options) > 0 {
+		// Optional selects without a default start empty so the input can be left unset
+		if *value == "" && !inputDef.Required {
+			options = append([]string{""}, options...)
+		}
+		field := huh.NewSelect[string]().
+			Title(fmt.Sprintf("Select value for '%s'", inputName)).
+			Options(huh.NewOptions(options...)...).
+			Value(value)
+		if inputDef.Description != "" {
+			field = field.Description(inputDef.Description)
+		}
+		return field
+	}
+
+	field := huh.NewInput().
+		Title(fmt.Sprintf("Enter value for '%s'", inputName)).
+		Value(value).
+		Validate(inputValueValidator(inputDef))
+	if inputDef.Description != "" {
+		field = field.Description(inputDef.Description)
+	}
+	return field
+}
+
+// inputValueValidator checks free-text input values: required inputs must be set,
+// and values must match the input type.
+func inputValueValidator(inputDef *workflow.InputDefinition) func(string) error {
+	return func(s string) error {
+		if s == "" {
+			if inputDef.Required {
+				return errors.New("this input is required")
+			}
+			return nil
+		}
+		return inputDef.ValidateValue(s)
+	}
+}
+
 // confirmExecution asks the user to confirm workflow execution
 func confirmExecution(ctx context.Context, wf *WorkflowOption, inputs []string) bool {
 	runInteractiveLog.Print("Requesting execution confirmation")
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"testing"
 
+	"charm.land/huh/v2"
 	"github.com/github/gh-aw/pkg/workflow"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -194,6 +195,29 @@ func TestBuildWorkflowDescription(t *testing.T) {
 	}
 }
 
+func TestBuildInputField(t *testing.T) {
+	value := ""
+	choice := buildInputField("depth", &workflow.InputDefinition{Type: "choice", Options: []string{"quick", "deep"}}, &value)
+	assert.IsType(t, &huh.Select[string]{}, choice, "choice inputs should be prompted with a select")
+
+	boolean := buildInputField("dry_run", &workflow.InputDefinition{Type: "boolean"}, &value)
+	assert.IsType(t, &huh.Select[string]{}, boolean, "boolean inputs should be prompted with a true/false select")
+
+	number := buildInputField("limit", &workflow.InputDefinition{Type: "number"}, &value)
+	assert.IsType(t, &huh.Input{}, number, "number inputs should be prompted with free text")
+}
+
+func TestInputValueValidator(t *testing.T) {
+	required := inputValueValidator(&workflow.InputDefinition{Type: "string", Required: true})
+	require.Error(t, required(""), "required inputs should reject empty values")
+	require.NoError(t, required("topic"), "required inputs should accept any text")
+
+	number := inputValueValidator(&workflow.InputDefinition{Type: "number"})
+	require.NoError(t, number(""), "optional inputs may be left empty")
+	require.NoError(t, number("5"), "number inputs should accept numbers")
+	assert.Error(t, number("five"), "number inputs should reject non-numeric text")
+}
+
 func TestBuildCommandString(t *testing.T) {
 	tests := []struct {
 		name           string
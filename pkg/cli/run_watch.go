@@ -0,0 +1,447 @@
+// This file provides command-line interface functionality for gh-aw.
+// This file (run_watch.go) implements `gh aw run --watch`, which follows a dispatched
+// workflow run and streams the agent's output to the terminal while the run is in progress.
+//
+// Key responsibilities:
+//   - Polling the run and its jobs, reporting job status transitions
+//   - Fetching the agent job log incrementally and printing only new lines
+//   - Isolating the agent execution step output from the rest of the job log
+//   - Decoding each engine's console output format into readable text
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/workflow"
+)
+
+var runWatchLog = logger.New("cli:run_watch")
+
+// runWatchPollInterval is how often the run, its jobs, and the agent log are polled.
+const runWatchPollInterval = 5 * time.Second
+
+// maxWatchToolDetailLength truncates tool arguments shown next to tool calls.
+const maxWatchToolDetailLength = 120
+
+// actionsLogTimestampPattern matches the timestamp GitHub Actions prefixes to every job log line.
+var actionsLogTimestampPattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?Z `)
+
+// watchedJob is the subset of the jobs API response used while watching a run
+type watchedJob struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Conclusion string `json:"conclusion"`
+}
+
+// runWatcher follows a single workflow run and streams the agent job output
+type runWatcher struct {
+	repo       string
+	runID      int64
+	out        io.Writer
+	decode     agentLogDecoder
+	jobStatus  map[int64]string // last reported status per job
+	agentLog   agentLogStream   // agent step isolation state
+	printedLen int              // number of agent job log lines already consumed
+	agentDone  bool             // the agent job completed and its log was fully consumed
+}
+
+// WatchWorkflowRun follows a workflow run until it completes, printing job status changes
+// to stderr and the agent's decoded output to stdout. engineID selects the log decoder;
+// when empty the decoder detects JSON event streams automatically.
+func WatchWorkflowRun(ctx context.Context, repo string, runID int64, engineID string, verbose bool) error {
+	runWatchLog.Printf("Watching workflow run: repo=%s, runID=%d, engine=%s", repo, runID, engineID)
+	watcher := &runWatcher{
+		repo:      repo,
+		runID:     runID,
+		out:       os.Stdout,
+		decode:    agentLogDecoderFor(engineID),
+		jobStatus: make(map[int64]string),
+	}
+
+	return PollWithSignalHandling(PollOptions{
+		Ctx:            ctx,
+		PollInterval:   runWatchPollInterval,
+		Timeout:        time.Duration(workflowCompletionWaitTimeoutMinutes) * time.Minute,
+		PollFunc:       watcher.poll,
+		StartMessage:   fmt.Sprintf("Watching workflow run %d (press Ctrl-C to stop watching; the run keeps going)", runID),
+		SuccessMessage: "Workflow completed successfully",
+		Verbose:        verbose,
+	})
+}
+
+// poll runs one watch iteration: report job transitions, stream new agent output,
+// and decide whether the run has finished.
+func (w *runWatcher) poll(ctx context.Context) (PollResult, error) {
+	status, conclusion, err := w.fetchRunStatus(ctx)
+	if err != nil {
+		return PollFailure, fmt.Errorf("failed to check workflow status: %w", err)
+	}
+
+	jobs, err := w.fetchJobs(ctx)
+	if err != nil {
+		// Job listing is best-effort; keep following the run.
+		runWatchLog.Printf("Failed to list jobs: %v", err)
+	}
+	for _, job := range jobs {
+		w.reportJobStatus(job)
+		if job.Name == string(constants.AgentJobName) && job.Status != "queued" && !w.agentDone {
+			w.streamAgentLog(ctx, job)
+		}
+	}
+
+	if status != "completed" {
+		return PollContinue, nil
+	}
+	switch conclusion {
+	case "success":
+		return PollSuccess, nil
+	case "failure":
+		return PollFailure, errors.New("workflow failed")
+	case "cancelled":
+		return PollFailure, errors.New("workflow was cancelled")
+	default:
+		return PollFailure, fmt.Errorf("workflow completed with conclusion %q", conclusion)
+	}
+}
+
+// reportJobStatus prints a job's status when it changes
+func (w *runWatcher) reportJobStatus(job watchedJob) {
+	if w.jobStatus[job.ID] == job.Status {
+		return
+	}
+	w.jobStatus[job.ID] = job.Status
+	switch job.Status {
+	case "in_progress":
+		fmt.Fprintln(os.Stderr, console.FormatProgressMessage("Job started: "+job.Name))
+	case "completed":
+		if isFailureConclusion(job.Conclusion) {
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Job %s finished: %s", job.Name, job.Conclusion)))
+		} else {
+			fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Job %s finished: %s", job.Name, job.Conclusion)))
+		}
+	}
+}
+
+// streamAgentLog fetches the agent job log and prints the lines that have not been
+// printed yet. Logs that are not available yet are retried on the next poll.
+func (w *runWatcher) streamAgentLog(ctx context.Context, job watchedJob) {
+	output, err := workflow.ExecGHContext(ctx, watchAPIArgs(w.repo, fmt.Sprintf("actions/jobs/%d/logs", job.ID))...).Output()
+	if err != nil {
+		runWatchLog.Printf("Agent job log not available yet: %v", err)
+		return
+	}
+	if job.Status == "completed" {
+		w.agentDone = true
+	}
+
+	lines := strings.Split(string(output), "\n")
+	// Hold back the last line until the job completes: it may still be partially written.
+	if job.Status != "completed" && len(lines) > 0 {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) <= w.printedLen {
+		return
+	}
+	for _, line := range lines[w.printedLen:] {
+		if text, ok := w.agentLog.feed(line, w.decode); ok {
+			fmt.Fprintln(w.out, text)
+		}
+	}
+	w.printedLen = len(lines)
+}
+
+// fetchRunStatus returns the run status and conclusion
+func (w *runWatcher) fetchRunStatus(ctx context.Context) (string, string, error) {
+	args := append(watchAPIArgs(w.repo, "actions/runs/"+strconv.FormatInt(w.runID, 10)),
+		"--jq", `{status: .status, conclusion: (.conclusion // "")}`)
+	output, err := workflow.ExecGHContext(ctx, args...).Output()
+	if err != nil {
+		return "", "", err
+	}
+	var run struct {
+		Status     string `json:"status"`
+		Conclusion string `json:"conclusion"`
+	}
+	if err := json.Unmarshal(output, &run); err != nil {
+		return "", "", fmt.Errorf("failed to parse run status: %w", err)
+	}
+	return run.Status, run.Conclusion, nil
+}
+
+// fetchJobs lists the run's jobs
+func (w *runWatcher) fetchJobs(ctx context.Context) ([]watchedJob, error) {
+	args := append(watchAPIArgs(w.repo, fmt.Sprintf("actions/runs/%d/jobs", w.runID)),
+		"--paginate", "--jq", `.jobs[] | {id: .id, name: .name, status: .status, conclusion: (.conclusion // "")}`)
+	output, err := workflow.ExecGHContext(ctx, args...).Output()
+	if err != nil {
+		return nil, err
+	}
+	var jobs []watchedJob
+	for line := range strings.SplitSeq(strings.TrimSpace(string(output)), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		var job watchedJob
+		if err := json.Unmarshal([]byte(line), &job); err != nil {
+			runWatchLog.Printf("Failed to parse job: %s", line)
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+// watchAPIArgs builds `gh api` arguments for a repository path. repo may be
+// owner/repo or HOST/owner/repo.
+func watchAPIArgs(repo, path string) []string {
+	parts := strings.Split(repo, "/")
+	if len(parts) == 3 {
+		return []string{"api", "--hostname", parts[0], fmt.Sprintf("repos/%s/%s/%s", parts[1], parts[2], path)}
+	}
+	return []string{"api", fmt.Sprintf("repos/%s/%s", repo, path)}
+}
+
+// agentLogStream isolates the agent execution step inside the agent job log.
+// Every engine's execution step records its start time to workflow.AgentCLIStartMsPath,
+// so the step group that mentions that path is the agent step; its output runs from the
+// end of that group to the start of the next step.
+type agentLogStream struct {
+	inRunGroup     bool // inside the collapsed "Run ..." header of a step
+	sawStartMarker bool // the current header is the agent execution step
+	inAgentStep    bool // inside the agent step's output
+}
+
+// feed consumes one raw job log line and returns the decoded text to print, if any
+func (s *agentLogStream) feed(rawLine string, decode agentLogDecoder) (string, bool) {
+	line := strings.TrimRight(actionsLogTimestampPattern.ReplaceAllString(rawLine, ""), "\r")
+	switch {
+	case strings.HasPrefix(line, "##[group]"):
+		s.inAgentStep = false
+		s.inRunGroup = strings.HasPrefix(line, "##[group]Run ")
+		s.sawStartMarker = false
+		return "", false
+	case strings.HasPrefix(line, "##[endgroup]"):
+		if s.inRunGroup && s.sawStartMarker {
+			s.inAgentStep = true
+		}
+		s.inRunGroup = false
+		s.sawStartMarker = false
+		return "", false
+	}
+
+	if s.inRunGroup {
+		if strings.Contains(line, workflow.AgentCLIStartMsPath) {
+			s.sawStartMarker = true
+		}
+		return "", false
+	}
+	if !s.inAgentStep {
+		return "", false
+	}
+	if strings.HasPrefix(line, "##[error]") {
+		return console.FormatErrorMessage(strings.TrimPrefix(line, "##[error]")), true
+	}
+	if strings.HasPrefix(line, "##[") {
+		return "", false
+	}
+	return decode(line)
+}
+
+// agentLogDecoder renders one line of an engine's console output for the terminal.
+// It returns false when the line carries nothing worth showing.
+type agentLogDecoder func(line string) (string, bool)
+
+// agentLogDecoders maps engine IDs to the decoder for their console output format.
+// Engines that are not listed here print plain text.
+var agentLogDecoders = map[string]agentLogDecoder{
+	"claude":  decodeAgentEventLine,
+	"gemini":  decodeAgentEventLine,
+	"pi":      decodeAgentEventLine,
+	"codex":   decodeCodexLine,
+	"copilot": decodeAgentTextLine,
+}
+
+// agentLogDecoderFor returns the decoder for an engine. Unknown engines (including an
+// empty engine ID when the workflow source is not available locally) use event decoding,
+// which falls back to plain text for lines that are not JSON events.
+func agentLogDecoderFor(engineID string) agentLogDecoder {
+	if decoder, ok := agentLogDecoders[engineID]; ok {
+		return decoder
+	}
+	return decodeAgentEventLine
+}
+
+// decodeAgentTextLine passes plain-text engine output through unchanged
+func decodeAgentTextLine(line string) (string, bool) {
+	if strings.TrimSpace(line) == "" {
+		return "", false
+	}
+	return line, true
+}
+
+// codexLogPrefixPattern matches the bracketed timestamp prefix of older Codex CLI output.
+var codexLogPrefixPattern = regexp.MustCompile(`^\[[^\]]+\]\s*`)
+
+// decodeCodexLine renders Codex CLI text output: section markers become headings and
+// command/tool lines are highlighted; everything else is printed as-is.
+func decodeCodexLine(line string) (string, bool) {
+	trimmed := strings.TrimSpace(codexLogPrefixPattern.ReplaceAllString(line, ""))
+	switch {
+	case trimmed == "":
+		return "", false
+	case trimmed == "thinking" || trimmed == "codex":
+		return "", false
+	case strings.HasPrefix(trimmed, "exec "), strings.HasPrefix(trimmed, "tool "):
+		return console.FormatProgressMessage(stringutil.Truncate(trimmed, maxWatchToolDetailLength)), true
+	case strings.HasPrefix(trimmed, "tokens used"):
+		return console.FormatVerboseMessage(trimmed), true
+	}
+	return line, true
+}
+
+// agentLogEvent covers the JSON event shapes emitted by the stream-json engines:
+// Claude (assistant/user/result with a nested message), Gemini and Pi (flat
+// message/tool_use/tool_result/result events), and Copilot SDK events (dotted types
+// with a data payload).
+type agentLogEvent struct {
+	Type    string `json:"type"`
+	Subtype string `json:"subtype"`
+	Role    string `json:"role"`
+	Model   string `json:"model"`
+	Content any    `json:"content"`
+	Message *struct {
+		Content []struct {
+			Type    string         `json:"type"`
+			Text    string         `json:"text"`
+			Name    string         `json:"name"`
+			Input   map[string]any `json:"input"`
+			IsError bool           `json:"is_error"`
+		} `json:"content"`
+	} `json:"message"`
+	ToolName   string         `json:"tool_name"`
+	Parameters map[string]any `json:"parameters"`
+	Status     string         `json:"status"`
+	NumTurns   int            `json:"num_turns"`
+	TotalCost  float64        `json:"total_cost_usd"`
+	IsError    bool           `json:"is_error"`
+	Data       map[string]any `json:"data"`
+}
+
+// decodeAgentEventLine renders a JSON event line; lines that are not JSON events
+// are printed as plain text.
+func decodeAgentEventLine(line string) (string, bool) {
+	trimmed := strings.TrimSpace(line)
+	if !strings.HasPrefix(trimmed, "{") {
+		return decodeAgentTextLine(line)
+	}
+	var event agentLogEvent
+	if err := json.Unmarshal([]byte(trimmed), &event); err != nil || event.Type == "" {
+		return decodeAgentTextLine(line)
+	}
+
+	var parts []string
+	switch event.Type {
+	case "system", "init", "session.init":
+		if event.Type == "system" && event.Subtype != "init" {
+			return "", false
+		}
+		model := event.Model
+		if model == "" {
+			model, _ = event.Data["model"].(string)
+		}
+		if model == "" {
+			return console.FormatInfoMessage("Agent session started"), true
+		}
+		return console.FormatInfoMessage("Agent session started (model: " + model + ")"), true
+	case "assistant", "message":
+		if event.Role != "" && event.Role != "assistant" {
+			return "", false
+		}
+		if text, ok := event.Content.(string); ok {
+			parts = append(parts, text)
+		}
+		if event.Message != nil {
+			for _, block := range event.Message.Content {
+				switch block.Type {
+				case "text":
+					parts = append(parts, block.Text)
+				case "tool_use":
+					parts = append(parts, formatWatchToolCall(block.Name, block.Input))
+				}
+			}
+		}
+	case "user":
+		if event.Message != nil {
+			for _, block := range event.Message.Content {
+				if block.Type == "tool_result" && block.IsError {
+					parts = append(parts, console.FormatWarningMessage("Tool call failed"))
+				}
+			}
+		}
+	case "tool_use":
+		parts = append(parts, formatWatchToolCall(event.ToolName, event.Parameters))
+	case "tool_result":
+		if event.Status != "" && event.Status != "success" {
+			parts = append(parts, console.FormatWarningMessage("Tool call failed: "+event.Status))
+		}
+	case "assistant.message":
+		if text, ok := event.Data["content"].(string); ok {
+			parts = append(parts, text)
+		}
+	case "tool.execution_start":
+		toolName, _ := event.Data["toolName"].(string)
+		args, _ := event.Data["arguments"].(map[string]any)
+		parts = append(parts, formatWatchToolCall(toolName, args))
+	case "tool.execution_complete":
+		if success, ok := event.Data["success"].(bool); ok && !success {
+			toolName, _ := event.Data["toolName"].(string)
+			parts = append(parts, console.FormatWarningMessage("Tool call failed: "+toolName))
+		}
+	case "result", "session.result":
+		summary := "Agent finished"
+		if event.NumTurns > 0 {
+			summary += fmt.Sprintf(" after %d turn(s)", event.NumTurns)
+		}
+		if event.TotalCost > 0 {
+			summary += fmt.Sprintf(", cost $%.4f", event.TotalCost)
+		}
+		if event.IsError {
+			return console.FormatWarningMessage(summary + " with an error"), true
+		}
+		return console.FormatSuccessMessage(summary), true
+	}
+
+	text := strings.TrimSpace(strings.Join(parts, "\n"))
+	if text == "" {
+		return "", false
+	}
+	return text, true
+}
+
+// formatWatchToolCall renders a tool call with its most descriptive argument
+func formatWatchToolCall(name string, input map[string]any) string {
+	if name == "" {
+		name = "tool"
+	}
+	for _, key := range []string{"command", "file_path", "path", "pattern", "url", "query"} {
+		if value, ok := input[key].(string); ok && value != "" {
+			return console.FormatProgressMessage(name + ": " + stringutil.Truncate(strings.ReplaceAll(value, "\n", " "), maxWatchToolDetailLength))
+		}
+	}
+	return console.FormatProgressMessage(name)
+}
@@ -0,0 +1,145 @@
+//go:build !integration
+
+package cli
+
+import (
+	"testing"
+
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAgentLogStreamIsolatesAgentStep(t *testing.T) {
+	logLines := []string{
+		"2026-01-01T00:00:00.0000000Z ##[group]Run actions/checkout@v5",
+		"2026-01-01T00:00:01.0000000Z ##[endgroup]",
+		"2026-01-01T00:00:02.0000000Z Syncing repository",
+		"2026-01-01T00:00:03.0000000Z ##[group]Run set -o pipefail",
+		"2026-01-01T00:00:03.0000000Z set -o pipefail",
+		"2026-01-01T00:00:03.0000000Z printf '%s' \"$(date +%s%3N)\" > " + workflow.AgentCLIStartMsPath,
+		"2026-01-01T00:00:03.0000000Z ##[endgroup]",
+		"2026-01-01T00:00:04.0000000Z Analyzing the repository",
+		"2026-01-01T00:00:05.0000000Z ##[error]Process completed with exit code 1.",
+		"2026-01-01T00:00:06.0000000Z ##[group]Run actions/upload-artifact@v4",
+		"2026-01-01T00:00:07.0000000Z ##[endgroup]",
+		"2026-01-01T00:00:08.0000000Z Uploading artifact",
+	}
+
+	var stream agentLogStream
+	var printed []string
+	for _, line := range logLines {
+		if text, ok := stream.feed(line, decodeAgentTextLine); ok {
+			printed = append(printed, text)
+		}
+	}
+
+	assert.Len(t, printed, 2, "only the agent step output should be printed")
+	assert.Equal(t, "Analyzing the repository", printed[0], "agent output should be printed without the timestamp")
+	assert.Contains(t, printed[1], "Process completed with exit code 1.", "errors in the agent step should be printed")
+}
+
+func TestDecodeAgentEventLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     string
+		expected string
+		skipped  bool
+	}{
+		{
+			name:     "claude assistant text",
+			line:     `{"type":"assistant","message":{"content":[{"type":"text","text":"Looking at the failing test."}]}}`,
+			expected: "Looking at the failing test.",
+		},
+		{
+			name:     "claude tool use",
+			line:     `{"type":"assistant","message":{"content":[{"type":"tool_use","name":"Bash","input":{"command":"go test ./..."}}]}}`,
+			expected: "Bash: go test ./...",
+		},
+		{
+			name:    "claude successful tool result",
+			line:    `{"type":"user","message":{"content":[{"type":"tool_result","content":"ok"}]}}`,
+			skipped: true,
+		},
+		{
+			name:     "claude result",
+			line:     `{"type":"result","num_turns":7,"total_cost_usd":0.1234}`,
+			expected: "Agent finished after 7 turn(s), cost $0.1234",
+		},
+		{
+			name:     "gemini message",
+			line:     `{"type":"message","role":"assistant","content":"Reviewing the docs."}`,
+			expected: "Reviewing the docs.",
+		},
+		{
+			name:    "gemini user message",
+			line:    `{"type":"message","role":"user","content":"prompt"}`,
+			skipped: true,
+		},
+		{
+			name:     "gemini tool use",
+			line:     `{"type":"tool_use","tool_name":"read_file","parameters":{"file_path":"README.md"}}`,
+			expected: "read_file: README.md",
+		},
+		{
+			name:     "copilot tool execution",
+			line:     `{"type":"tool.execution_start","data":{"toolName":"bash","arguments":{"command":"ls"}}}`,
+			expected: "bash: ls",
+		},
+		{
+			name:     "plain text fallback",
+			line:     "npm warn deprecated",
+			expected: "npm warn deprecated",
+		},
+		{
+			name:    "blank line",
+			line:    "   ",
+			skipped: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, ok := decodeAgentEventLine(tt.line)
+			if tt.skipped {
+				assert.False(t, ok, "line should not be printed")
+				return
+			}
+			assert.True(t, ok, "line should be printed")
+			assert.Contains(t, text, tt.expected, "unexpected decoded text")
+		})
+	}
+}
+
+func TestDecodeCodexLine(t *testing.T) {
+	_, ok := decodeCodexLine("[2026-01-01T00:00:00] thinking")
+	assert.False(t, ok, "thinking markers should be dropped")
+
+	text, ok := decodeCodexLine("exec bash -lc 'go test ./...'")
+	assert.True(t, ok, "exec lines should be printed")
+	assert.Contains(t, text, "exec bash -lc 'go test ./...'", "exec line should be kept")
+
+	text, ok = decodeCodexLine("The tests pass now.")
+	assert.True(t, ok, "plain output should be printed")
+	assert.Equal(t, "The tests pass now.", text, "plain output should be unchanged")
+}
+
+func TestAgentLogDecoderFor(t *testing.T) {
+	text, ok := agentLogDecoderFor("")(`{"type":"message","role":"assistant","content":"hi"}`)
+	assert.True(t, ok, "unknown engines should decode JSON events")
+	assert.Equal(t, "hi", text, "unexpected decoded text")
+
+	text, ok = agentLogDecoderFor("copilot")(`{"type":"message"}`)
+	assert.True(t, ok, "text engines should pass lines through")
+	assert.JSONEq(t, `{"type":"message"}`, text, "text engines should not decode JSON")
+}
+
+func TestWatchAPIArgs(t *testing.T) {
+	assert.Equal(t, []string{"api", "repos/octo/repo/actions/runs/1"}, watchAPIArgs("octo/repo", "actions/runs/1"), "unexpected args for owner/repo")
+	assert.Equal(t, []string{"api", "--hostname", "ghe.example.com", "repos/octo/repo/actions/runs/1"}, watchAPIArgs("ghe.example.com/octo/repo", "actions/runs/1"), "unexpected args for HOST/owner/repo")
+}
+
+func TestResolveWatchEngineID(t *testing.T) {
+	assert.Empty(t, resolveWatchEngineID(RunOptions{EngineOverride: "claude"}, ""), "engine is only resolved when watching")
+	assert.Equal(t, "claude", resolveWatchEngineID(RunOptions{Watch: true, EngineOverride: "claude"}, ""), "engine override should win")
+	assert.Empty(t, resolveWatchEngineID(RunOptions{Watch: true, RepoOverride: "octo/repo"}, ".github/workflows/x.lock.yml"), "remote workflows should auto-detect")
+}
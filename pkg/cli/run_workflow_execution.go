@@ -37,12 +37,14 @@ type RunOptions struct {
 	AutoMergePRs      bool     // Auto-merge PRs created during execution
 	Push              bool     // Commit and push workflow files before running
 	WaitForCompletion bool     // Wait for workflow completion
+	Watch             bool     // Stream live agent output until the run completes (implies waiting)
 	RepeatCount       int      // Number of times to repeat (0 = run once)
 	Inputs            []string // Workflow inputs in key=value format
 	Verbose           bool     // Enable verbose output
 	DryRun            bool     // Validate without actually triggering
 	JSON              bool     // Output results in JSON format
 	Approve           bool     // Approve safe update changes during compilation
+	Fixture           string   // Simulate job conditions locally for this fixture event file instead of dispatching
 }
 
 // WorkflowRunResult contains the result of a single workflow run trigger for JSON output
@@ -74,7 +76,7 @@ type workflowRunExecutionResult struct {
 
 // RunWorkflowOnGitHub runs an agentic workflow on GitHub Actions
 func RunWorkflowOnGitHub(ctx context.Context, workflowIdOrName string, opts RunOptions) error {
-	executionLog.Printf("Starting workflow run: workflow=%s, enable=%v, engineOverride=%s, repo=%s, ref=%s, push=%v, wait=%v, inputs=%v", workflowIdOrName, opts.Enable, opts.EngineOverride, opts.RepoOverride, opts.RefOverride, opts.Push, opts.WaitForCompletion, opts.Inputs)
+	executionLog.Printf("Starting workflow run: workflow=%s, enable=%v, engineOverride=%s, repo=%s, ref=%s, push=%v, wait=%v, watch=%v, inputs=%v", workflowIdOrName, opts.Enable, opts.EngineOverride, opts.RepoOverride, opts.RefOverride, opts.Push, opts.WaitForCompletion, opts.Watch, opts.Inputs)
 	if err := checkWorkflowRunContext(ctx, workflowIdOrName); err != nil {
 		return err
 	}
@@ -92,7 +94,7 @@ func RunWorkflowOnGitHub(ctx context.Context, workflowIdOrName string, opts RunO
 		return err
 	}
 	defer restoreEnabledWorkflow(workflowIdOrName, opts, prep.enableState)
-	args, ref := buildWorkflowRunArgs(prep.lockFileName, opts)
+	args, _ := buildWorkflowRunArgs(prep.lockFileName, opts)
 	workflowStartTime := time.Now()
 	if opts.DryRun {
 		return handleWorkflowDryRun(prep.lockFileName, args, opts)
@@ -102,7 +104,7 @@ func RunWorkflowOnGitHub(ctx context.Context, workflowIdOrName string, opts RunO
 		return err
 	}
 	handleWorkflowRunInfo(runResult.runInfo, runResult.runInfoErr, opts)
-	return waitForWorkflowRunCompletion(ctx, opts, runResult.runInfo, runResult.runInfoErr, workflowStartTime, ref)
+	return waitForWorkflowRunCompletion(ctx, opts, runResult.runInfo, runResult.runInfoErr, workflowStartTime, resolveWatchEngineID(opts, prep.lockFilePath))
 }
 
 func checkWorkflowRunContext(ctx context.Context, workflowIdOrName string) error {
@@ -453,8 +455,8 @@ func handleWorkflowRunInfo(runInfo *WorkflowRunInfo, runErr error, opts RunOptio
 	}
 }
 
-func waitForWorkflowRunCompletion(ctx context.Context, opts RunOptions, runInfo *WorkflowRunInfo, runErr error, workflowStartTime time.Time, _ string) error {
-	if !opts.WaitForCompletion && !opts.AutoMergePRs {
+func waitForWorkflowRunCompletion(ctx context.Context, opts RunOptions, runInfo *WorkflowRunInfo, runErr error, workflowStartTime time.Time, engineID string) error {
+	if !opts.WaitForCompletion && !opts.AutoMergePRs && !opts.Watch {
 		return nil
 	}
 	if runErr != nil {
@@ -465,9 +467,15 @@ func waitForWorkflowRunCompletion(ctx context.Context, opts RunOptions, runInfo
 	if targetRepo == "" || runInfo == nil {
 		return nil
 	}
-	printWorkflowWaitMessage(opts.AutoMergePRs)
-	runIDStr := strconv.FormatInt(runInfo.DatabaseID, 10)
-	if err := WaitForWorkflowCompletion(ctx, targetRepo, runIDStr, workflowCompletionWaitTimeoutMinutes, opts.Verbose); err != nil {
+	var err error
+	if opts.Watch {
+		err = WatchWorkflowRun(ctx, targetRepo, runInfo.DatabaseID, engineID, opts.Verbose)
+	} else {
+		printWorkflowWaitMessage(opts.AutoMergePRs)
+		runIDStr := strconv.FormatInt(runInfo.DatabaseID, 10)
+		err = WaitForWorkflowCompletion(ctx, targetRepo, runIDStr, workflowCompletionWaitTimeoutMinutes, opts.Verbose)
+	}
+	if err != nil {
 		if ctx.Err() != nil || errors.Is(err, ErrInterrupted) {
 			return err
 		}
@@ -482,10 +490,26 @@ func waitForWorkflowRunCompletion(ctx context.Context, opts RunOptions, runInfo
 	return nil
 }
 
+// resolveWatchEngineID returns the engine whose log format --watch should decode: the
+// --engine override, else the engine declared by the local workflow source. Returns ""
+// when the source is not available locally (e.g. --repo), which selects auto-detection.
+func resolveWatchEngineID(opts RunOptions, lockFilePath string) string {
+	if !opts.Watch {
+		return ""
+	}
+	if opts.EngineOverride != "" {
+		return opts.EngineOverride
+	}
+	if opts.RepoOverride != "" || lockFilePath == "" {
+		return ""
+	}
+	return extractEngineIDFromFile(stringutil.LockFileToMarkdown(lockFilePath))
+}
+
 func printWorkflowRunInfoWarning(opts RunOptions, runErr error) {
 	if opts.AutoMergePRs {
 		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Could not get workflow run information for auto-merge: %v", runErr)))
-	} else if opts.WaitForCompletion {
+	} else if opts.WaitForCompletion || opts.Watch {
 		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Could not get workflow run information: %v", runErr)))
 	}
 }
@@ -627,6 +651,9 @@ func RunWorkflowsOnGitHub(ctx context.Context, workflowNames []string, opts RunO
 		return ctx.Err()
 	default:
 	}
+	if opts.Fixture != "" {
+		return simulateWorkflowsWithFixture(workflowNames, opts)
+	}
 	if err := validateWorkflowsForRun(workflowNames, opts); err != nil {
 		return err
 	}
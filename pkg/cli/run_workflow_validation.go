@@ -168,6 +168,7 @@ func getWorkflowInputs(markdownPath string) (map[string]*workflow.InputDefinitio
 //   - All required inputs are provided
 //   - Provided input names match defined inputs (typo detection)
 //   - Suggestions for misspelled input names
+//   - Provided values match the input type (choice options, booleans, numbers)
 //
 // This follows the principle that domain-specific validation belongs in domain files.
 func validateWorkflowInputs(markdownPath string, providedInputs []string) error {
@@ -223,8 +224,20 @@ func validateWorkflowInputs(markdownPath string, providedInputs []string) error
 		}
 	}
 
+	// Check that provided values match the declared input types
+	var invalidValues []string
+	for _, providedName := range sliceutil.SortedKeys(providedInputsMap) {
+		inputDef, exists := workflowInputs[providedName]
+		if !exists {
+			continue
+		}
+		if err := inputDef.ValidateValue(providedInputsMap[providedName]); err != nil {
+			invalidValues = append(invalidValues, fmt.Sprintf("'%s' %s", providedName, err))
+		}
+	}
+
 	// Build error message if there are validation errors
-	if len(missingInputs) > 0 || len(typos) > 0 {
+	if len(missingInputs) > 0 || len(typos) > 0 || len(invalidValues) > 0 {
 		var errorParts []string
 
 		if len(missingInputs) > 0 {
@@ -235,6 +248,10 @@ func validateWorkflowInputs(markdownPath string, providedInputs []string) error
 			errorParts = append(errorParts, "Invalid input name(s):\n  "+strings.Join(suggestions, "\n  "))
 		}
 
+		if len(invalidValues) > 0 {
+			errorParts = append(errorParts, "Invalid input value(s):\n  "+strings.Join(invalidValues, "\n  "))
+		}
+
 		// Add helpful information about valid inputs
 		if len(workflowInputs) > 0 {
 			var inputDescriptions []string
@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/spf13/cobra"
+)
+
+var schemaCommandLog = logger.New("cli:schema_command")
+
+// schemaNames maps each schema name accepted by the schema command to its JSON.
+var schemaNames = map[string]func() string{
+	"workflow":    parser.MainWorkflowSchemaJSON,
+	"mcp-config":  parser.MCPConfigSchemaJSON,
+	"repo-config": func() string { return parser.RepoConfigSchema },
+}
+
+// NewSchemaCommand creates the schema command
+func NewSchemaCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schema [workflow|mcp-config|repo-config]",
+		Short: "Print the JSON schema used to validate workflow frontmatter",
+		Long: `Print the JSON schema that the compiler uses to validate configuration.
+
+Schemas:
+- workflow     Workflow frontmatter (default)
+- mcp-config   MCP server configurations
+- repo-config  Repository configuration (.github/aw/config)
+
+The schema matches this version of the compiler, so editors and linters that load it
+report the same unknown keys and invalid values as compile.`,
+		Example: `  ` + string(constants.CLIExtensionPrefix) + ` schema                                  # Print the workflow frontmatter schema
+  ` + string(constants.CLIExtensionPrefix) + ` schema -o .github/aw/workflow.schema.json # Write it to a file for editor integration
+  ` + string(constants.CLIExtensionPrefix) + ` schema mcp-config                       # Print the MCP server configuration schema`,
+		Args:      cobra.MaximumNArgs(1),
+		ValidArgs: []string{"workflow", "mcp-config", "repo-config"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := "workflow"
+			if len(args) == 1 {
+				name = args[0]
+			}
+			outputFile, _ := cmd.Flags().GetString("output")
+			return RunSchema(name, outputFile)
+		},
+	}
+
+	cmd.Flags().StringP("output", "o", "", "Write the schema to this file instead of stdout")
+
+	return cmd
+}
+
+// RunSchema prints the named schema, or writes it to outputFile when set.
+func RunSchema(name, outputFile string) error {
+	schemaCommandLog.Printf("Emitting schema: name=%s, output=%s", name, outputFile)
+
+	schemaJSON, ok := schemaNames[name]
+	if !ok {
+		return fmt.Errorf("unknown schema %q: expected one of workflow, mcp-config, repo-config", name)
+	}
+	content := strings.TrimRight(schemaJSON(), "\n") + "\n"
+
+	if outputFile == "" {
+		fmt.Fprint(os.Stdout, content)
+		return nil
+	}
+	if err := os.WriteFile(outputFile, []byte(content), constants.FilePermPublic); err != nil {
+		return fmt.Errorf("failed to write schema to %s: %w", outputFile, err)
+	}
+	fmt.Fprintln(os.Stderr, console.FormatSuccessMessage("Wrote "+name+" schema to "+outputFile))
+	return nil
+}
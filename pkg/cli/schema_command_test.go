@@ -0,0 +1,44 @@
+//go:build !integration
+
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunSchema_WritesFile(t *testing.T) {
+	for name := range schemaNames {
+		t.Run(name, func(t *testing.T) {
+			outputFile := filepath.Join(testutil.TempDir(t, "schema-command"), name+".schema.json")
+			require.NoError(t, RunSchema(name, outputFile), "schema should be written")
+
+			content, err := os.ReadFile(outputFile)
+			require.NoError(t, err, "failed to read schema")
+			var schema map[string]any
+			require.NoError(t, json.Unmarshal(content, &schema), "schema should be valid JSON")
+			assert.Contains(t, schema, "$schema", "should be a JSON schema document")
+		})
+	}
+}
+
+func TestRunSchema_WorkflowSchemaDescribesFrontmatter(t *testing.T) {
+	var schema struct {
+		Properties map[string]any `json:"properties"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(schemaNames["workflow"]()), &schema), "schema should be valid JSON")
+	assert.Contains(t, schema.Properties, "on", "workflow schema should describe triggers")
+	assert.Contains(t, schema.Properties, "tools", "workflow schema should describe tools")
+}
+
+func TestRunSchema_UnknownName(t *testing.T) {
+	err := RunSchema("lock-file", "")
+	require.Error(t, err, "unknown schema names should be rejected")
+	assert.Contains(t, err.Error(), "expected one of workflow, mcp-config, repo-config", "error should list the valid names")
+}
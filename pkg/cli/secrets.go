@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -62,6 +63,36 @@ func checkSecretExists(secretName string) (bool, error) {
 	return false, nil
 }
 
+// listRepositorySecretNames lists the Actions secrets available to the current repository:
+// its own secrets plus the organization secrets shared with it, when the caller may read them.
+// Used by compile to resolve engine: auto.
+func listRepositorySecretNames(ctx context.Context) ([]string, error) {
+	output, err := workflow.RunGHContext(ctx, "Listing secrets...", "secret", "list", "--json", "name")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list secrets: %w", err)
+	}
+	var secrets []struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(output, &secrets); err != nil {
+		return nil, fmt.Errorf("failed to parse secrets list: %w", err)
+	}
+	names := make([]string, 0, len(secrets))
+	for _, secret := range secrets {
+		names = append(names, secret.Name)
+	}
+
+	orgOutput, err := workflow.RunGHContext(ctx, "Listing organization secrets...", "api", "repos/{owner}/{repo}/actions/organization-secrets", "--paginate", "--jq", ".secrets[].name")
+	if err != nil {
+		secretsLog.Printf("Skipping organization secrets: %v", err)
+		return names, nil
+	}
+	for name := range strings.FieldsSeq(string(orgOutput)) {
+		names = append(names, name)
+	}
+	return names, nil
+}
+
 // extractSecretsFromConfig extracts all required secrets from an MCP server config
 func extractSecretsFromConfig(config parser.RegistryMCPServerConfig) []SecretInfo {
 	secretsLog.Printf("Extracting secrets from MCP config: command=%s", config.Command)
@@ -6,6 +6,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -30,11 +31,20 @@ func TestSetupActionCopiesAntigravityLogParser(t *testing.T) {
 	destination := filepath.Join(runnerTemp, "gh-aw", "actions")
 	githubOutput := filepath.Join(runnerTemp, "github-output.txt")
 
+	// setup.sh creates the scratch directory /tmp/gh-aw/<run-id>-<run-attempt>/<job>-<suffix>;
+	// a run ID derived from the PID keeps it away from the directories of real jobs.
+	runID := strconv.Itoa(900000000 + os.Getpid())
+	t.Cleanup(func() { os.RemoveAll(filepath.Join("/tmp/gh-aw", runID+"-1")) })
+
 	cmd := exec.Command("bash", setupScript)
 	cmd.Env = append(os.Environ(),
 		"RUNNER_TEMP="+runnerTemp,
 		"INPUT_DESTINATION="+destination,
 		"GITHUB_OUTPUT="+githubOutput,
+		"GITHUB_RUN_ID="+runID,
+		"GITHUB_RUN_ATTEMPT=1",
+		"GITHUB_JOB=agent",
+		"GITHUB_ENV="+filepath.Join(runnerTemp, "github-env.txt"),
 	)
 
 	output, err := cmd.CombinedOutput()
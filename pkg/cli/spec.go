@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"path"
 	"path/filepath"
 	"slices"
 	"strings"
@@ -405,8 +406,10 @@ func parseWorkflowSpec(spec string) (*WorkflowSpec, error) {
 		workflowPath = "workflows/" + workflowPath + ".md"
 	} else {
 		// Four or more parts: owner/repo/workflows/workflow-name or owner/repo/path/to/workflow-name
-		// Require .md extension to be explicit
-		if !strings.HasSuffix(workflowPath, ".md") {
+		// A path without extension names a markdown workflow; any other extension is rejected
+		if path.Ext(workflowPath) == "" {
+			workflowPath += ".md"
+		} else if !strings.HasSuffix(workflowPath, ".md") {
 			return nil, fmt.Errorf("workflow specification with path must end with '.md' extension: %s", workflowPath)
 		}
 	}
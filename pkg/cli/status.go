@@ -1,6 +1,8 @@
 package cli
 
 import (
+	"errors"
+
 	"github.com/github/gh-aw/pkg/constants"
 	"github.com/spf13/cobra"
 )
@@ -16,13 +18,19 @@ Displays a table with workflow name, AI engine, compilation status, enabled/disa
 and time remaining until expiration (if stop-after is configured).
 
 The optional pattern argument filters workflows by name (case-insensitive substring match).
-It accepts workflow IDs (basename without .md) or full filenames.`,
+It accepts workflow IDs (basename without .md) or full filenames.
+
+With --org, lists the agentic workflows installed across every repository of the
+organization the token can see, with their engine, compiler version, enabled state and
+last run, and whether the source they were added from has a newer release or commit
+upstream (stale).`,
 		Example: `  ` + string(constants.CLIExtensionPrefix) + ` status                           # Show all workflow status
   ` + string(constants.CLIExtensionPrefix) + ` status ci-                       # Show workflows with 'ci-' in name
   ` + string(constants.CLIExtensionPrefix) + ` status --json                    # Output in JSON format
   ` + string(constants.CLIExtensionPrefix) + ` status --ref main                # Show latest run status for main branch
   ` + string(constants.CLIExtensionPrefix) + ` status --label automation        # Show workflows with 'automation' label
-  ` + string(constants.CLIExtensionPrefix) + ` status --repo owner/other-repo   # Check status in different repository`,
+  ` + string(constants.CLIExtensionPrefix) + ` status --repo owner/other-repo   # Check status in different repository
+  ` + string(constants.CLIExtensionPrefix) + ` status --org myorg               # Inventory agentic workflows across an organization`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			var pattern string
 			if len(args) > 0 {
@@ -33,7 +41,14 @@ It accepts workflow IDs (basename without .md) or full filenames.`,
 			ref, _ := cmd.Flags().GetString("ref")
 			labelFilter, _ := cmd.Flags().GetString("label")
 			repoOverride, _ := cmd.Flags().GetString("repo")
-			statusLog.Printf("Status command invoked: pattern=%q, json=%v, ref=%q, label=%q, repo=%q", pattern, jsonFlag, ref, labelFilter, repoOverride)
+			org, _ := cmd.Flags().GetString("org")
+			statusLog.Printf("Status command invoked: pattern=%q, json=%v, ref=%q, label=%q, repo=%q, org=%q", pattern, jsonFlag, ref, labelFilter, repoOverride, org)
+			if org != "" {
+				if repoOverride != "" || ref != "" || labelFilter != "" {
+					return errors.New("--org cannot be combined with --repo, --ref or --label")
+				}
+				return StatusOrgWorkflows(cmd.Context(), org, pattern, verbose, jsonFlag)
+			}
 			return StatusWorkflows(pattern, verbose, jsonFlag, ref, labelFilter, repoOverride)
 		},
 	}
@@ -42,6 +57,7 @@ It accepts workflow IDs (basename without .md) or full filenames.`,
 	cmd.Flags().StringP("repo", "r", "", "Target repository ([HOST/]owner/repo format). Defaults to current repository")
 	cmd.Flags().String("ref", "", "Filter runs by branch or tag name (e.g., main, v1.0.0)")
 	cmd.Flags().String("label", "", "Filter workflows by label")
+	cmd.Flags().String("org", "", "Inventory agentic workflows across the repositories of an organization")
 
 	// Register completions for status command
 	cmd.ValidArgsFunction = CompleteWorkflowNames
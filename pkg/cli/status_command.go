@@ -35,6 +35,9 @@ type WorkflowStatus struct {
 	RunID         int64    `json:"run_id,omitempty" console:"header:run id,omitempty"`
 	RunStatus     string   `json:"run_status,omitempty" console:"header:status,omitempty"`
 	RunConclusion string   `json:"run_conclusion,omitempty" console:"header:conclusion,omitempty"`
+	// Reason is the reason recorded by `disable --reason` or `enable --reason`,
+	// shown while the workflow is still in the state it explains.
+	Reason string `json:"reason,omitempty" console:"header:reason,omitempty"`
 }
 
 // GetWorkflowStatuses retrieves workflow status information and returns it as a slice.
@@ -75,7 +78,11 @@ func GetWorkflowStatuses(pattern string, ref string, labelFilter string, repoOve
 		if labelFilter != "" {
 			return nil, errors.New("--label filter is not supported with --repo: label information is not available from the GitHub Actions API")
 		}
-		return buildRemoteWorkflowStatuses(pattern, githubWorkflows, latestRunsByWorkflow), nil
+		statuses := buildRemoteWorkflowStatuses(pattern, githubWorkflows, latestRunsByWorkflow)
+		if len(githubWorkflows) > 0 {
+			attachWorkflowStateReasons(statuses, repoOverride)
+		}
+		return statuses, nil
 	}
 
 	// Local path: discover markdown workflow files from the local filesystem.
@@ -196,9 +203,28 @@ func GetWorkflowStatuses(pattern string, ref string, labelFilter string, repoOve
 		})
 	}
 
+	if len(githubWorkflows) > 0 {
+		attachWorkflowStateReasons(statuses, repoOverride)
+	}
+
 	return statuses, nil
 }
 
+// attachWorkflowStateReasons fills in the reasons recorded by enable/disable --reason.
+// Reasons are best effort: a token that cannot read repository variables simply shows none.
+func attachWorkflowStateReasons(statuses []WorkflowStatus, repoOverride string) {
+	reasons, err := getWorkflowStateReasonsFn(repoOverride)
+	if err != nil {
+		statusLog.Printf("Failed to read workflow state reasons: %v", err)
+		return
+	}
+	for i := range statuses {
+		if reason, ok := reasons[statuses[i].Workflow]; ok && reason.appliesTo(statuses[i].Status) {
+			statuses[i].Reason = reason.String()
+		}
+	}
+}
+
 // buildRemoteWorkflowStatuses constructs workflow statuses from GitHub API data when
 // --repo is specified. Local-only fields (EngineID, Compiled, TimeRemaining, Labels,
 // On) are not available for remote repositories and are omitted from results.
@@ -304,8 +330,8 @@ func calculateTimeRemaining(stopTimeStr string) string {
 		return "N/A"
 	}
 
-	// Parse the stop time in local timezone
-	stopTime, err := time.ParseInLocation("2006-01-02 15:04:05", stopTimeStr, time.Local)
+	// Stop times are resolved and stored in UTC (see workflow.resolveStopTime)
+	stopTime, err := time.ParseInLocation("2006-01-02 15:04:05", stopTimeStr, time.UTC)
 	if err != nil {
 		return "Invalid"
 	}
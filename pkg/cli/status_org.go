@@ -0,0 +1,284 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/github/gh-aw/pkg/workflow"
+)
+
+var statusOrgLog = logger.New("cli:status_org")
+
+var listOrgReposFn = listOrgRepos
+var scanOrgRepoStatusesFn = scanOrgRepoStatuses
+
+// OrgWorkflowStatus represents the status of one agentic workflow in an organization-wide
+// inventory produced by `status --org`.
+type OrgWorkflowStatus struct {
+	Repository      string `json:"repository" console:"header:repository"`
+	Workflow        string `json:"workflow" console:"header:workflow"`
+	EngineID        string `json:"engine_id,omitempty" console:"header:engine,omitempty"`
+	CompilerVersion string `json:"compiler_version,omitempty" console:"header:compiler,omitempty"`
+	State           string `json:"state" console:"header:state"`
+	LastRun         string `json:"last_run,omitempty" console:"header:last run,omitempty"`
+	// Stale is "yes" when the source the workflow was added from has a newer
+	// version upstream, "no" when it is current, and empty when the workflow has
+	// no pinned source or the upstream version could not be resolved.
+	Stale     string `json:"stale,omitempty" console:"header:stale,omitempty"`
+	Source    string `json:"source,omitempty" console:"-"`
+	LatestRef string `json:"latest_ref,omitempty" console:"-"`
+}
+
+// orgRepoWorkflow is a workflow entry from the GitHub Actions workflows API.
+type orgRepoWorkflow struct {
+	ID    int64  `json:"id"`
+	Name  string `json:"name"`
+	Path  string `json:"path"`
+	State string `json:"state"`
+}
+
+// orgWorkflowRun is the subset of a workflow run used to report the last run.
+type orgWorkflowRun struct {
+	Status     string `json:"status"`
+	Conclusion string `json:"conclusion"`
+}
+
+// failingRunConclusions are the run conclusions reported as failing.
+var failingRunConclusions = []string{"failure", "timed_out", "startup_failure"}
+
+// isFailing reports whether the last run of the workflow failed.
+func (s OrgWorkflowStatus) isFailing() bool {
+	return slices.Contains(failingRunConclusions, s.LastRun)
+}
+
+// StatusOrgWorkflows prints an inventory of the agentic workflows installed across the
+// repositories of an organization that the token can see, with their engines, compiler
+// versions, enabled state, last run and whether their upstream source has changed.
+func StatusOrgWorkflows(ctx context.Context, org string, pattern string, verbose bool, jsonOutput bool) error {
+	statusOrgLog.Printf("Checking org workflow status: org=%s, pattern=%s, json=%v", org, pattern, jsonOutput)
+	if !isValidOrgSlug(org) {
+		return invalidOrgSlugError(org)
+	}
+
+	fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Discovering repositories in "+org+"..."))
+	repos, err := listOrgReposFn(ctx, org)
+	if err != nil {
+		return err
+	}
+	statusOrgLog.Printf("Found %d repositories in %s", len(repos), org)
+
+	statuses := []OrgWorkflowStatus{}
+	for i, repo := range repos {
+		if ctx.Err() != nil {
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Cancellation requested; stopping after %d/%d repositories", i, len(repos))))
+			break
+		}
+		if err := waitForOrgRateLimitFn(ctx, "core", verbose); err != nil {
+			if errors.Is(err, errOrgRateLimitCritical) {
+				fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("GitHub API budget critical; stopping after %d/%d repositories and reporting what was found", i, len(repos))))
+				break
+			}
+			if verbose {
+				fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Continuing after rate limit check failure for %s: %v", repo, err)))
+			}
+		}
+		if verbose {
+			fmt.Fprintln(os.Stderr, console.FormatProgressMessage(fmt.Sprintf("[%d/%d] Inspecting %s", i+1, len(repos), repo)))
+		}
+		repoStatuses, err := scanOrgRepoStatusesFn(ctx, repo, verbose)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Skipping %s: %v", repo, err)))
+			statusOrgLog.Printf("Failed to scan %s: %v", repo, err)
+			continue
+		}
+		for _, status := range repoStatuses {
+			if pattern != "" && !strings.Contains(strings.ToLower(status.Workflow), strings.ToLower(pattern)) {
+				continue
+			}
+			statuses = append(statuses, status)
+		}
+	}
+
+	slices.SortFunc(statuses, func(a, b OrgWorkflowStatus) int {
+		if c := strings.Compare(a.Repository, b.Repository); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Workflow, b.Workflow)
+	})
+
+	if jsonOutput {
+		jsonBytes, err := json.MarshalIndent(statuses, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Fprintln(os.Stdout, string(jsonBytes))
+		return nil
+	}
+
+	if len(statuses) == 0 {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage("No agentic workflows found in "+org))
+		return nil
+	}
+
+	fmt.Print(console.RenderStruct(statuses))
+	fmt.Fprintln(os.Stderr, console.FormatInfoMessage(summarizeOrgWorkflowStatuses(statuses)))
+	return nil
+}
+
+// summarizeOrgWorkflowStatuses returns a one-line summary of the inventory.
+func summarizeOrgWorkflowStatuses(statuses []OrgWorkflowStatus) string {
+	repos := make(map[string]struct{})
+	var stale, disabled, failing int
+	for _, status := range statuses {
+		repos[status.Repository] = struct{}{}
+		if status.Stale == "yes" {
+			stale++
+		}
+		if status.State != "active" {
+			disabled++
+		}
+		if status.isFailing() {
+			failing++
+		}
+	}
+	return fmt.Sprintf("%d agentic workflow(s) in %d repositories: %d stale, %d disabled, %d failing", len(statuses), len(repos), stale, disabled, failing)
+}
+
+// listOrgRepos returns the non-archived repositories of org visible to the token,
+// sorted by full name.
+func listOrgRepos(ctx context.Context, org string) ([]string, error) {
+	endpoint := fmt.Sprintf("orgs/%s/repos?type=all&per_page=100", org)
+	output, err := workflow.RunGHContext(ctx, "Listing repositories...", "api", "--paginate", endpoint, "--jq", ".[] | select(.archived | not) | .full_name")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repositories in %s: %w", org, err)
+	}
+	var repos []string
+	for line := range strings.SplitSeq(string(output), "\n") {
+		if repo := strings.TrimSpace(line); repo != "" {
+			repos = append(repos, repo)
+		}
+	}
+	slices.Sort(repos)
+	return repos, nil
+}
+
+// scanOrgRepoStatuses returns the status of each compiled agentic workflow in repo.
+// Agentic workflows are recognized by their .lock.yml path in the Actions workflows API,
+// so repositories without them cost a single API call.
+func scanOrgRepoStatuses(ctx context.Context, repo string, verbose bool) ([]OrgWorkflowStatus, error) {
+	output, err := workflow.RunGHContext(ctx, "Listing workflows...", "api", "--paginate", fmt.Sprintf("repos/%s/actions/workflows?per_page=100", repo), "--jq", ".workflows[]")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workflows: %w", err)
+	}
+	workflows, err := parseOrgRepoWorkflows(output)
+	if err != nil {
+		return nil, err
+	}
+
+	owner, name, _ := strings.Cut(repo, "/")
+	var statuses []OrgWorkflowStatus
+	for _, wf := range workflows {
+		if !strings.HasSuffix(wf.Path, ".lock.yml") {
+			continue
+		}
+		status := OrgWorkflowStatus{
+			Repository: repo,
+			Workflow:   strings.TrimSuffix(path.Base(wf.Path), ".lock.yml"),
+			State:      wf.State,
+		}
+		if wf.State == "disabled_manually" {
+			status.State = "disabled"
+		}
+
+		if lockContent, err := parser.DownloadFileFromGitHub(ctx, owner, name, wf.Path, ""); err == nil {
+			if meta, _, err := workflow.ExtractMetadataFromLockFile(string(lockContent)); err == nil && meta != nil {
+				status.EngineID = meta.AgentID
+				status.CompilerVersion = normalizeDisplayVersion(meta.CompilerVersion)
+			}
+		} else {
+			statusOrgLog.Printf("Failed to read %s/%s: %v", repo, wf.Path, err)
+		}
+
+		mdPath := strings.TrimSuffix(wf.Path, ".lock.yml") + ".md"
+		if mdContent, err := parser.DownloadFileFromGitHub(ctx, owner, name, mdPath, ""); err == nil {
+			status.Source = extractSourceFromContent(string(mdContent))
+		}
+		if status.Source != "" {
+			status.Stale, status.LatestRef = checkOrgWorkflowSourceStale(ctx, status.Workflow, status.Source, verbose)
+		}
+
+		runOutput, err := workflow.RunGHContext(ctx, "Fetching last run...", "api", fmt.Sprintf("repos/%s/actions/workflows/%d/runs?per_page=1", repo, wf.ID), "--jq", ".workflow_runs[0] // {}")
+		if err == nil {
+			var run orgWorkflowRun
+			if json.Unmarshal(runOutput, &run) == nil {
+				status.LastRun = run.Conclusion
+				if status.LastRun == "" {
+					status.LastRun = run.Status
+				}
+			}
+		}
+
+		statuses = append(statuses, status)
+	}
+	statusOrgLog.Printf("Scanned %s: %d agentic workflow(s)", repo, len(statuses))
+	return statuses, nil
+}
+
+// parseOrgRepoWorkflows decodes the stream of workflow objects printed by
+// `gh api --jq .workflows[]`.
+func parseOrgRepoWorkflows(output []byte) ([]orgRepoWorkflow, error) {
+	decoder := json.NewDecoder(bytes.NewReader(output))
+	var workflows []orgRepoWorkflow
+	for {
+		var wf orgRepoWorkflow
+		if err := decoder.Decode(&wf); err != nil {
+			if errors.Is(err, io.EOF) {
+				return workflows, nil
+			}
+			return nil, fmt.Errorf("failed to parse workflows: %w", err)
+		}
+		workflows = append(workflows, wf)
+	}
+}
+
+// extractSourceFromContent returns the source frontmatter field of a workflow, or an
+// empty string when it is not set.
+func extractSourceFromContent(content string) string {
+	result, err := parser.ExtractFrontmatterFromContent(content)
+	if err != nil || result.Frontmatter == nil {
+		return ""
+	}
+	source, _ := result.Frontmatter["source"].(string)
+	return strings.TrimSpace(source)
+}
+
+// checkOrgWorkflowSourceStale resolves the latest version of a workflow source pinned
+// to a release tag or commit SHA and reports whether the installed version is behind
+// ("yes"/"no") along with the latest ref. Sources that track a branch, and sources
+// that cannot be resolved, return empty strings.
+func checkOrgWorkflowSourceStale(ctx context.Context, workflowName, source string, verbose bool) (string, string) {
+	spec, err := parseSourceSpec(source)
+	if err != nil || spec.Ref == "" || isBranchRef(spec.Ref) {
+		return "", ""
+	}
+	latestRef, err := resolveLatestRefFn(ctx, spec.Repo, spec.Ref, false, verbose, 0)
+	if err != nil {
+		statusOrgLog.Printf("Failed to resolve latest ref for %s (%s): %v", workflowName, source, err)
+		return "", ""
+	}
+	if latestRef == spec.Ref {
+		return "no", latestRef
+	}
+	return "yes", latestRef
+}
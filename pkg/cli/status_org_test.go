@@ -0,0 +1,117 @@
+//go:build !integration
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOrgRepoWorkflows(t *testing.T) {
+	output := []byte(`{"id":1,"name":"Triage","path":".github/workflows/triage.lock.yml","state":"active"}
+{"id":2,"name":"CI","path":".github/workflows/ci.yml","state":"disabled_manually"}
+`)
+	workflows, err := parseOrgRepoWorkflows(output)
+	require.NoError(t, err, "workflow stream should parse")
+	require.Len(t, workflows, 2, "both workflows should be decoded")
+	assert.Equal(t, ".github/workflows/triage.lock.yml", workflows[0].Path, "unexpected path")
+	assert.Equal(t, int64(2), workflows[1].ID, "unexpected id")
+
+	_, err = parseOrgRepoWorkflows([]byte("not json"))
+	require.Error(t, err, "invalid output should fail")
+}
+
+func TestCheckOrgWorkflowSourceStale(t *testing.T) {
+	original := resolveLatestRefFn
+	t.Cleanup(func() { resolveLatestRefFn = original })
+	resolveLatestRefFn = func(_ context.Context, _ string, currentRef string, _ bool, _ bool, _ time.Duration) (string, error) {
+		if currentRef == "v0.9.0" {
+			return "", errors.New("not found")
+		}
+		return "v1.2.0", nil
+	}
+
+	tests := []struct {
+		name      string
+		source    string
+		stale     string
+		latestRef string
+	}{
+		{name: "behind latest release", source: "githubnext/agentics/workflows/triage.md@v1.0.0", stale: "yes", latestRef: "v1.2.0"},
+		{name: "at latest release", source: "githubnext/agentics/workflows/triage.md@v1.2.0", stale: "no", latestRef: "v1.2.0"},
+		{name: "tracks a branch", source: "githubnext/agentics/workflows/triage.md@main"},
+		{name: "unresolvable", source: "githubnext/agentics/workflows/triage.md@v0.9.0"},
+		{name: "invalid source", source: "not-a-source"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stale, latestRef := checkOrgWorkflowSourceStale(context.Background(), "triage", tt.source, false)
+			assert.Equal(t, tt.stale, stale, "unexpected stale value")
+			assert.Equal(t, tt.latestRef, latestRef, "unexpected latest ref")
+		})
+	}
+}
+
+func TestSummarizeOrgWorkflowStatuses(t *testing.T) {
+	statuses := []OrgWorkflowStatus{
+		{Repository: "acme/api", Workflow: "triage", State: "active", LastRun: "failure", Stale: "yes"},
+		{Repository: "acme/api", Workflow: "docs", State: "disabled", LastRun: "success"},
+		{Repository: "acme/web", Workflow: "triage", State: "active", LastRun: "timed_out", Stale: "no"},
+	}
+	assert.Equal(t, "3 agentic workflow(s) in 2 repositories: 1 stale, 1 disabled, 2 failing", summarizeOrgWorkflowStatuses(statuses), "unexpected summary")
+}
+
+func TestStatusOrgWorkflows(t *testing.T) {
+	origList := listOrgReposFn
+	origScan := scanOrgRepoStatusesFn
+	origWait := waitForOrgRateLimitFn
+	t.Cleanup(func() {
+		listOrgReposFn = origList
+		scanOrgRepoStatusesFn = origScan
+		waitForOrgRateLimitFn = origWait
+	})
+	waitForOrgRateLimitFn = func(context.Context, string, bool) error { return nil }
+	listOrgReposFn = func(context.Context, string) ([]string, error) {
+		return []string{"acme/api", "acme/broken", "acme/web"}, nil
+	}
+	scanOrgRepoStatusesFn = func(_ context.Context, repo string, _ bool) ([]OrgWorkflowStatus, error) {
+		switch repo {
+		case "acme/api":
+			return []OrgWorkflowStatus{
+				{Repository: repo, Workflow: "triage", State: "active"},
+				{Repository: repo, Workflow: "docs-updater", State: "disabled"},
+			}, nil
+		case "acme/broken":
+			return nil, errors.New("forbidden")
+		}
+		return []OrgWorkflowStatus{{Repository: repo, Workflow: "triage", State: "active"}}, nil
+	}
+
+	stdout, _ := captureOutput(t, func() error {
+		return StatusOrgWorkflows(context.Background(), "acme", "triage", false, true)
+	})
+	var statuses []OrgWorkflowStatus
+	require.NoError(t, json.Unmarshal([]byte(stdout), &statuses), "JSON output should parse")
+	require.Len(t, statuses, 2, "pattern should filter workflows and failing repos should be skipped")
+	assert.Equal(t, "acme/api", statuses[0].Repository, "results should be sorted by repository")
+	assert.Equal(t, "acme/web", statuses[1].Repository, "results should be sorted by repository")
+
+	err := StatusOrgWorkflows(context.Background(), "-bad-", "", false, true)
+	require.Error(t, err, "invalid org should be rejected")
+}
+
+func TestNewStatusCommand_OrgRejectsRepoFilters(t *testing.T) {
+	cmd := NewStatusCommand()
+	cmd.SetArgs([]string{"--org", "acme", "--repo", "acme/api"})
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	err := cmd.Execute()
+	require.Error(t, err, "--org with --repo should fail")
+	assert.Contains(t, err.Error(), "--org cannot be combined", "unexpected error message")
+}
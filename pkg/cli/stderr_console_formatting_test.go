@@ -58,7 +58,7 @@ tools:
 	require.NoError(t, os.Chdir(tmpDir))
 
 	_, stderr := captureOutput(t, func() error {
-		return findWorkflowsWithMCPServer(workflowsDir, "github", false)
+		return findWorkflowsWithMCPServer(workflowsDir, "github", false, false)
 	})
 
 	lines := strings.Split(strings.TrimSuffix(stderr, "\n"), "\n")
@@ -0,0 +1,291 @@
+package cli
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/sliceutil"
+	"github.com/github/gh-aw/pkg/workflow"
+)
+
+var telemetryLog = logger.New("cli:telemetry")
+
+// Compiler usage telemetry is opt-in and local. When enabled with "gh aw telemetry enable",
+// each compilation records which frontmatter features the workflow uses in a file in the
+// user configuration directory. Only feature names from the workflow schema (top-level
+// fields, triggers, tools, safe output types and the engine) are recorded, under a salted
+// hash of the repository and workflow ID; nothing is sent anywhere. "gh aw telemetry
+// export" writes the per-feature workflow counts to a file that platform teams collect
+// and sum with "gh aw telemetry aggregate".
+
+const (
+	// telemetryFileEnvVar overrides the location of the telemetry file.
+	telemetryFileEnvVar = "GH_AW_TELEMETRY_FILE"
+	// telemetryDisableEnvVar turns recording off for a process (e.g. in CI) even when enabled.
+	telemetryDisableEnvVar = "GH_AW_TELEMETRY"
+	// telemetryExportFormat identifies export files.
+	telemetryExportFormat = "gh-aw-telemetry/v1"
+)
+
+// telemetryTriggerPattern matches the string form of on: that names a single event.
+var telemetryTriggerPattern = regexp.MustCompile(`^[a-z_]+$`)
+
+// telemetryStore is the local telemetry file.
+type telemetryStore struct {
+	Enabled   bool                                `json:"enabled"`
+	EnabledAt string                              `json:"enabled_at,omitempty"`
+	Salt      string                              `json:"salt,omitempty"`
+	Workflows map[string]*telemetryWorkflowRecord `json:"workflows,omitempty"`
+}
+
+// telemetryWorkflowRecord holds the features of the last compilation of a workflow.
+type telemetryWorkflowRecord struct {
+	Features     []string `json:"features"`
+	Compilations int      `json:"compilations"`
+	LastCompiled string   `json:"last_compiled"`
+}
+
+// TelemetryExport is the anonymous usage summary written by "gh aw telemetry export".
+type TelemetryExport struct {
+	Format       string         `json:"format"`
+	Version      string         `json:"version"`
+	ExportedAt   string         `json:"exported_at"`
+	Workflows    int            `json:"workflows"`
+	Compilations int            `json:"compilations"`
+	Features     map[string]int `json:"features"`
+}
+
+// telemetryFilePath returns the path of the telemetry file.
+func telemetryFilePath() (string, error) {
+	if path := os.Getenv(telemetryFileEnvVar); path != "" { //nolint:osgetenvlibrary
+		return path, nil
+	}
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate the user configuration directory: %w", err)
+	}
+	return filepath.Join(configDir, "gh-aw", "telemetry.json"), nil
+}
+
+// loadTelemetryStore reads the telemetry file. A missing file is a disabled store.
+func loadTelemetryStore(path string) (*telemetryStore, error) {
+	store := &telemetryStore{}
+	content, err := os.ReadFile(path) // #nosec G304 -- path is the telemetry file in the user configuration directory
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read telemetry file: %w", err)
+	}
+	if err := json.Unmarshal(content, store); err != nil {
+		return nil, fmt.Errorf("failed to parse telemetry file %s: %w", path, err)
+	}
+	return store, nil
+}
+
+// saveTelemetryStore writes the telemetry file, readable only by the user.
+func saveTelemetryStore(path string, store *telemetryStore) error {
+	if err := os.MkdirAll(filepath.Dir(path), constants.DirPermSensitive); err != nil {
+		return fmt.Errorf("failed to create telemetry directory: %w", err)
+	}
+	content, err := json.MarshalIndent(store, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode telemetry file: %w", err)
+	}
+	if err := os.WriteFile(path, append(content, '\n'), constants.FilePermSensitive); err != nil {
+		return fmt.Errorf("failed to write telemetry file: %w", err)
+	}
+	return nil
+}
+
+// newTelemetrySalt returns the random salt of the workflow keys of a store.
+func newTelemetrySalt() (string, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate telemetry salt: %w", err)
+	}
+	return hex.EncodeToString(salt), nil
+}
+
+// telemetryWorkflowKey identifies a workflow in the store without recording its name.
+func telemetryWorkflowKey(salt, repoSlug, workflowID string) string {
+	sum := sha256.Sum256([]byte(salt + "\x00" + repoSlug + "\x00" + workflowID))
+	return hex.EncodeToString(sum[:8])
+}
+
+// collectTelemetryFeatures returns the sorted feature names a workflow uses. The names
+// come from the frontmatter keys, which the workflow schema restricts to known fields.
+func collectTelemetryFeatures(data *workflow.WorkflowData) []string {
+	features := map[string]struct{}{}
+	add := func(feature string) { features[feature] = struct{}{} }
+
+	if engine := workflowEngineID(data); engine != "" {
+		add("engine:" + engine)
+	}
+	for key, value := range data.RawFrontmatter {
+		add("frontmatter:" + key)
+		switch key {
+		case "on":
+			switch on := value.(type) {
+			case map[string]any:
+				for trigger := range on {
+					add("on:" + trigger)
+				}
+			case string:
+				if telemetryTriggerPattern.MatchString(on) {
+					add("on:" + on)
+				}
+			}
+		case "tools", "safe-outputs":
+			if section, ok := value.(map[string]any); ok {
+				for name := range section {
+					add(key + ":" + name)
+				}
+			}
+		}
+	}
+	return sliceutil.SortedKeys(features)
+}
+
+// telemetryDisabledByEnv reports whether GH_AW_TELEMETRY turns recording off.
+func telemetryDisabledByEnv() bool {
+	value := strings.TrimSpace(os.Getenv(telemetryDisableEnvVar)) //nolint:osgetenvlibrary
+	return value == "0" || strings.EqualFold(value, "off") || strings.EqualFold(value, "false")
+}
+
+// workflowEngineID returns the engine of a compiled workflow.
+func workflowEngineID(data *workflow.WorkflowData) string {
+	if data.EngineConfig != nil && data.EngineConfig.ID != "" {
+		return data.EngineConfig.ID
+	}
+	return data.AI
+}
+
+// recordCompilerUsage records the features of the compiled workflows when telemetry is
+// enabled. Failures are logged and never fail the compilation.
+func recordCompilerUsage(compiler *workflow.Compiler, workflows []*workflow.WorkflowData) {
+	if len(workflows) == 0 || telemetryDisabledByEnv() {
+		return
+	}
+	path, err := telemetryFilePath()
+	if err != nil {
+		telemetryLog.Printf("Skipping telemetry: %v", err)
+		return
+	}
+	store, err := loadTelemetryStore(path)
+	if err != nil || !store.Enabled {
+		if err != nil {
+			telemetryLog.Printf("Skipping telemetry: %v", err)
+		}
+		return
+	}
+	if store.Workflows == nil {
+		store.Workflows = map[string]*telemetryWorkflowRecord{}
+	}
+	today := time.Now().UTC().Format(time.DateOnly)
+	repoSlug := compiler.GetRepositorySlug()
+	for _, data := range workflows {
+		key := telemetryWorkflowKey(store.Salt, repoSlug, data.WorkflowID)
+		record := store.Workflows[key]
+		if record == nil {
+			record = &telemetryWorkflowRecord{}
+			store.Workflows[key] = record
+		}
+		record.Features = collectTelemetryFeatures(data)
+		record.Compilations++
+		record.LastCompiled = today
+	}
+	if err := saveTelemetryStore(path, store); err != nil {
+		telemetryLog.Printf("Failed to record telemetry: %v", err)
+		return
+	}
+	telemetryLog.Printf("Recorded compiler usage of %d workflow(s)", len(workflows))
+}
+
+// buildTelemetryExport summarizes a store into per-feature workflow counts.
+func buildTelemetryExport(store *telemetryStore) TelemetryExport {
+	export := TelemetryExport{
+		Format:     telemetryExportFormat,
+		Version:    GetVersion(),
+		ExportedAt: time.Now().UTC().Format(time.DateOnly),
+		Features:   map[string]int{},
+	}
+	for _, record := range store.Workflows {
+		export.Workflows++
+		export.Compilations += record.Compilations
+		for _, feature := range record.Features {
+			export.Features[feature]++
+		}
+	}
+	return export
+}
+
+// TelemetryFeatureUsage is one row of an aggregated telemetry report.
+type TelemetryFeatureUsage struct {
+	Feature   string  `json:"feature"`
+	Workflows int     `json:"workflows"`
+	Share     float64 `json:"share"`
+}
+
+// TelemetryAggregate sums the exports collected from several users.
+type TelemetryAggregate struct {
+	Exports      int                     `json:"exports"`
+	Workflows    int                     `json:"workflows"`
+	Compilations int                     `json:"compilations"`
+	Features     []TelemetryFeatureUsage `json:"features"`
+}
+
+// aggregateTelemetryExports sums exports into feature usage, most used first.
+func aggregateTelemetryExports(exports []TelemetryExport) TelemetryAggregate {
+	aggregate := TelemetryAggregate{Exports: len(exports)}
+	counts := map[string]int{}
+	for _, export := range exports {
+		aggregate.Workflows += export.Workflows
+		aggregate.Compilations += export.Compilations
+		for feature, count := range export.Features {
+			counts[feature] += count
+		}
+	}
+	for feature, count := range counts {
+		usage := TelemetryFeatureUsage{Feature: feature, Workflows: count}
+		if aggregate.Workflows > 0 {
+			usage.Share = float64(count) / float64(aggregate.Workflows)
+		}
+		aggregate.Features = append(aggregate.Features, usage)
+	}
+	slices.SortFunc(aggregate.Features, func(a, b TelemetryFeatureUsage) int {
+		if a.Workflows != b.Workflows {
+			return b.Workflows - a.Workflows
+		}
+		return strings.Compare(a.Feature, b.Feature)
+	})
+	return aggregate
+}
+
+// readTelemetryExport reads an export file written by "gh aw telemetry export".
+func readTelemetryExport(path string) (TelemetryExport, error) {
+	var export TelemetryExport
+	content, err := os.ReadFile(path) // #nosec G304 -- path is an export file given on the command line
+	if err != nil {
+		return export, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if err := json.Unmarshal(content, &export); err != nil {
+		return export, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if export.Format != telemetryExportFormat {
+		return export, fmt.Errorf("%s is not a gh aw telemetry export (format %q)", path, export.Format)
+	}
+	return export, nil
+}
@@ -0,0 +1,221 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/tty"
+	"github.com/spf13/cobra"
+)
+
+// NewTelemetryCommand creates the telemetry command
+func NewTelemetryCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "telemetry",
+		Short: "Record and export anonymous compiler feature usage (opt-in)",
+		Long: `Record which workflow features you use when compiling, and export the counts for your platform team.
+
+Telemetry is disabled by default. When enabled, each compilation records the frontmatter
+features its workflows use (top-level fields, triggers, tools, safe output types and the
+engine) in a file in your user configuration directory. Workflow and repository names are
+not recorded and nothing is sent anywhere: share usage by exporting it to a file.
+
+Set GH_AW_TELEMETRY=off to skip recording in a process (for example in CI) while enabled.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(newTelemetryStatusCommand())
+	cmd.AddCommand(newTelemetryEnableCommand())
+	cmd.AddCommand(newTelemetryDisableCommand())
+	cmd.AddCommand(newTelemetryExportCommand())
+	cmd.AddCommand(newTelemetryAggregateCommand())
+	return cmd
+}
+
+func newTelemetryStatusCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show whether telemetry is enabled and how much usage is recorded",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := telemetryFilePath()
+			if err != nil {
+				return err
+			}
+			store, err := loadTelemetryStore(path)
+			if err != nil {
+				return err
+			}
+			if !store.Enabled {
+				fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Telemetry is disabled. Enable it with: gh aw telemetry enable"))
+				return nil
+			}
+			fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Telemetry is enabled since %s: %d workflow(s) recorded in %s", store.EnabledAt, len(store.Workflows), path)))
+			if telemetryDisabledByEnv() {
+				fmt.Fprintln(os.Stderr, console.FormatWarningMessage(telemetryDisableEnvVar+" turns recording off in this environment"))
+			}
+			return nil
+		},
+	}
+}
+
+func newTelemetryEnableCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "enable",
+		Short: "Start recording compiler feature usage",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := telemetryFilePath()
+			if err != nil {
+				return err
+			}
+			store, err := loadTelemetryStore(path)
+			if err != nil {
+				return err
+			}
+			if store.Enabled {
+				fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Telemetry is already enabled"))
+				return nil
+			}
+			salt, err := newTelemetrySalt()
+			if err != nil {
+				return err
+			}
+			store = &telemetryStore{Enabled: true, EnabledAt: time.Now().UTC().Format(time.DateOnly), Salt: salt}
+			if err := saveTelemetryStore(path, store); err != nil {
+				return err
+			}
+			fmt.Fprintln(os.Stderr, console.FormatSuccessMessage("Telemetry enabled: compilations now record feature usage in "+path))
+			return nil
+		},
+	}
+}
+
+func newTelemetryDisableCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "disable",
+		Short: "Stop recording and delete the recorded usage",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := telemetryFilePath()
+			if err != nil {
+				return err
+			}
+			if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return fmt.Errorf("failed to delete telemetry file: %w", err)
+			}
+			fmt.Fprintln(os.Stderr, console.FormatSuccessMessage("Telemetry disabled and recorded usage deleted"))
+			return nil
+		},
+	}
+}
+
+func newTelemetryExportCommand() *cobra.Command {
+	var output string
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the recorded usage as anonymous per-feature counts",
+		Long: `Export the recorded usage as the number of workflows using each feature.
+
+The export holds no workflow, repository or user names, so it can be shared with your
+platform team, who combine the exports of several users with "gh aw telemetry aggregate".`,
+		Example: `  gh aw telemetry export                      # Print the export
+  gh aw telemetry export -o usage-alice.json  # Write it to a file`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunTelemetryExport(output)
+		},
+	}
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Write the export to a file instead of stdout")
+	return cmd
+}
+
+// RunTelemetryExport writes the anonymous usage export to a file or stdout.
+func RunTelemetryExport(output string) error {
+	path, err := telemetryFilePath()
+	if err != nil {
+		return err
+	}
+	store, err := loadTelemetryStore(path)
+	if err != nil {
+		return err
+	}
+	if !store.Enabled {
+		return errors.New("telemetry is disabled; enable it with 'gh aw telemetry enable' and compile workflows to record usage")
+	}
+	content, err := json.MarshalIndent(buildTelemetryExport(store), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode telemetry export: %w", err)
+	}
+	content = append(content, '\n')
+	if output == "" {
+		_, err := os.Stdout.Write(content)
+		return err
+	}
+	if err := os.WriteFile(output, content, constants.FilePermPublic); err != nil {
+		return fmt.Errorf("failed to write telemetry export: %w", err)
+	}
+	fmt.Fprintln(os.Stderr, console.FormatSuccessMessage("Telemetry export written to "+output))
+	return nil
+}
+
+func newTelemetryAggregateCommand() *cobra.Command {
+	var jsonOutput bool
+	cmd := &cobra.Command{
+		Use:   "aggregate <export-file>...",
+		Short: "Combine telemetry exports into feature usage across users",
+		Long: `Combine the exports collected from several users into the number of workflows using
+each feature, most used first. A workflow compiled by several users counts once per user.`,
+		Example: `  gh aw telemetry aggregate exports/*.json
+  gh aw telemetry aggregate exports/*.json --json`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunTelemetryAggregate(args, jsonOutput)
+		},
+	}
+	cmd.Flags().BoolVarP(&jsonOutput, "json", "j", false, "Output results in JSON format")
+	return cmd
+}
+
+// RunTelemetryAggregate combines telemetry export files and prints the feature usage.
+func RunTelemetryAggregate(files []string, jsonOutput bool) error {
+	exports := make([]TelemetryExport, 0, len(files))
+	for _, file := range files {
+		export, err := readTelemetryExport(file)
+		if err != nil {
+			return err
+		}
+		exports = append(exports, export)
+	}
+	aggregate := aggregateTelemetryExports(exports)
+	telemetryLog.Printf("Aggregated %d export(s): %d workflow(s), %d feature(s)", aggregate.Exports, aggregate.Workflows, len(aggregate.Features))
+
+	if jsonOutput {
+		content, err := json.MarshalIndent(aggregate, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode telemetry aggregate: %w", err)
+		}
+		fmt.Fprintln(os.Stdout, string(content))
+		return nil
+	}
+
+	config := console.TableConfig{
+		Title:   fmt.Sprintf("Feature usage across %d workflow(s) from %d export(s)", aggregate.Workflows, aggregate.Exports),
+		Headers: []string{"Feature", "Workflows", "Share"},
+		Rows:    make([][]string, 0, len(aggregate.Features)),
+		TTYFunc: tty.IsStderrTerminal,
+	}
+	for _, usage := range aggregate.Features {
+		config.Rows = append(config.Rows, []string{usage.Feature, strconv.Itoa(usage.Workflows), fmt.Sprintf("%.0f%%", usage.Share*100)})
+	}
+	fmt.Fprint(os.Stderr, console.RenderTable(config))
+	return nil
+}
@@ -0,0 +1,92 @@
+//go:build !integration
+
+package cli
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectTelemetryFeatures(t *testing.T) {
+	data := &workflow.WorkflowData{
+		AI: "copilot",
+		RawFrontmatter: map[string]any{
+			"on":           map[string]any{"issues": map[string]any{"types": []any{"opened"}}, "workflow_dispatch": nil},
+			"engine":       "copilot",
+			"tools":        map[string]any{"github": nil, "cache-memory": true},
+			"safe-outputs": map[string]any{"add-comment": nil},
+		},
+	}
+
+	assert.Equal(t, []string{
+		"engine:copilot",
+		"frontmatter:engine",
+		"frontmatter:on",
+		"frontmatter:safe-outputs",
+		"frontmatter:tools",
+		"on:issues",
+		"on:workflow_dispatch",
+		"safe-outputs:add-comment",
+		"tools:cache-memory",
+		"tools:github",
+	}, collectTelemetryFeatures(data))
+
+	shorthand := collectTelemetryFeatures(&workflow.WorkflowData{RawFrontmatter: map[string]any{"on": "issues"}})
+	assert.Contains(t, shorthand, "on:issues", "a single event name should be recorded")
+	freeform := collectTelemetryFeatures(&workflow.WorkflowData{RawFrontmatter: map[string]any{"on": "daily around 9am"}})
+	assert.Equal(t, []string{"frontmatter:on"}, freeform, "free-form trigger text should not be recorded")
+}
+
+func TestRecordCompilerUsage(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "telemetry.json")
+	t.Setenv(telemetryFileEnvVar, path)
+	t.Setenv(telemetryDisableEnvVar, "")
+
+	compiler := workflow.NewCompiler()
+	triage := &workflow.WorkflowData{WorkflowID: "triage", AI: "claude", RawFrontmatter: map[string]any{"on": "issues"}}
+
+	recordCompilerUsage(compiler, []*workflow.WorkflowData{triage})
+	assert.NoFileExists(t, path, "nothing should be recorded while telemetry is disabled")
+
+	require.NoError(t, saveTelemetryStore(path, &telemetryStore{Enabled: true, Salt: "salt"}))
+	recordCompilerUsage(compiler, []*workflow.WorkflowData{triage})
+	recordCompilerUsage(compiler, []*workflow.WorkflowData{triage})
+
+	store, err := loadTelemetryStore(path)
+	require.NoError(t, err)
+	require.Len(t, store.Workflows, 1, "recompiling a workflow should update its record")
+	for key, record := range store.Workflows {
+		assert.NotContains(t, key, "triage", "the workflow name should not be recorded")
+		assert.Equal(t, 2, record.Compilations)
+		assert.Contains(t, record.Features, "engine:claude")
+	}
+
+	t.Setenv(telemetryDisableEnvVar, "off")
+	recordCompilerUsage(compiler, []*workflow.WorkflowData{{WorkflowID: "other"}})
+	store, err = loadTelemetryStore(path)
+	require.NoError(t, err)
+	assert.Len(t, store.Workflows, 1, "GH_AW_TELEMETRY=off should skip recording")
+}
+
+func TestAggregateTelemetryExports(t *testing.T) {
+	alice := buildTelemetryExport(&telemetryStore{Workflows: map[string]*telemetryWorkflowRecord{
+		"a": {Features: []string{"engine:copilot", "tools:github"}, Compilations: 3},
+		"b": {Features: []string{"engine:claude"}, Compilations: 1},
+	}})
+	assert.Equal(t, 2, alice.Workflows)
+	assert.Equal(t, 4, alice.Compilations)
+	assert.Equal(t, telemetryExportFormat, alice.Format)
+
+	bob := TelemetryExport{Format: telemetryExportFormat, Workflows: 2, Features: map[string]int{"engine:copilot": 2}}
+	aggregate := aggregateTelemetryExports([]TelemetryExport{alice, bob})
+
+	assert.Equal(t, 2, aggregate.Exports)
+	assert.Equal(t, 4, aggregate.Workflows)
+	require.Len(t, aggregate.Features, 3)
+	assert.Equal(t, TelemetryFeatureUsage{Feature: "engine:copilot", Workflows: 3, Share: 0.75}, aggregate.Features[0], "the most used feature should come first")
+	assert.Equal(t, "engine:claude", aggregate.Features[1].Feature, "ties should be sorted by name")
+}
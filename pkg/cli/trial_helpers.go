@@ -124,6 +124,7 @@ func executeTrialRun(ctx context.Context, parsedSpecs []*WorkflowSpec, hostRepoS
 			WorkflowName: parsedSpec.WorkflowName,
 			RunID:        runID,
 			SafeOutputs:  artifacts.SafeOutputs,
+			WouldProduce: summarizeTrialSafeOutputs(artifacts.SafeOutputs),
 			//AgentStdioLogs:      artifacts.AgentStdioLogs,
 			AgenticRunInfo:      artifacts.AgenticRunInfo,
 			AdditionalArtifacts: artifacts.AdditionalArtifacts,
@@ -144,6 +145,7 @@ func executeTrialRun(ctx context.Context, parsedSpecs []*WorkflowSpec, hostRepoS
 			fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("=== Safe Outputs from %s ===", parsedSpec.WorkflowName)))
 			fmt.Fprintln(os.Stdout, string(outputBytes))
 			fmt.Fprintln(os.Stderr, console.FormatSuccessMessage("=== End of Safe Outputs ==="))
+			displayTrialSafeOutputs(parsedSpec.WorkflowName, targetRepoForFilename, result.WouldProduce)
 		} else {
 			fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("=== No Safe Outputs Generated by %s ===", parsedSpec.WorkflowName)))
 		}
@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/stringutil"
+)
+
+// trialSafeOutputSummaryMaxLen bounds the summary column so long bodies do not wrap the table.
+const trialSafeOutputSummaryMaxLen = 80
+
+// TrialSafeOutput describes one safe output the trial would have applied to the target
+// repository if the workflow had not run in staged mode.
+type TrialSafeOutput struct {
+	Type    string `json:"type"`
+	Target  string `json:"target,omitempty"`
+	Summary string `json:"summary,omitempty"`
+}
+
+// summarizeTrialSafeOutputs lists the items of the agent output artifact in the order
+// the agent emitted them.
+func summarizeTrialSafeOutputs(safeOutputs map[string]any) []TrialSafeOutput {
+	items, _ := safeOutputs["items"].([]any)
+	var summary []TrialSafeOutput
+	for _, raw := range items {
+		item, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		outputType, _ := item["type"].(string)
+		if outputType == "" {
+			continue
+		}
+		output := TrialSafeOutput{Type: outputType}
+		for _, key := range []string{"item_number", "issue_number", "pull_request_number", "discussion_number", "branch"} {
+			if value, ok := item[key]; ok && value != nil {
+				output.Target = fmt.Sprint(value)
+				if key != "branch" {
+					output.Target = "#" + output.Target
+				}
+				break
+			}
+		}
+		for _, key := range []string{"title", "body", "message", "reason", "labels"} {
+			value, ok := item[key]
+			if !ok || value == nil {
+				continue
+			}
+			text := fmt.Sprint(value)
+			if labels, ok := value.([]any); ok {
+				text = strings.Trim(fmt.Sprint(labels), "[]")
+			}
+			text = strings.Join(strings.Fields(text), " ")
+			if text != "" {
+				output.Summary = stringutil.Truncate(text, trialSafeOutputSummaryMaxLen)
+				break
+			}
+		}
+		summary = append(summary, output)
+	}
+	return summary
+}
+
+// displayTrialSafeOutputs renders the safe outputs a trial would have produced against targetRepo.
+func displayTrialSafeOutputs(workflowName, targetRepo string, outputs []TrialSafeOutput) {
+	if len(outputs) == 0 {
+		return
+	}
+	fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("%s would have produced %d safe output(s) in %s (staged, nothing was written):", workflowName, len(outputs), targetRepo)))
+	config := console.TableConfig{
+		Headers: []string{"Type", "Target", "Summary"},
+		Rows:    make([][]string, 0, len(outputs)),
+	}
+	for _, output := range outputs {
+		target := output.Target
+		if target == "" {
+			target = "-"
+		}
+		config.Rows = append(config.Rows, []string{output.Type, target, output.Summary})
+	}
+	fmt.Fprint(os.Stderr, console.RenderTable(config))
+}
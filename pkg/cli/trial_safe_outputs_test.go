@@ -0,0 +1,34 @@
+//go:build !integration
+
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummarizeTrialSafeOutputs(t *testing.T) {
+	safeOutputs := map[string]any{
+		"items": []any{
+			map[string]any{"type": "create_issue", "title": "Flaky test in\n  CI", "body": "details"},
+			map[string]any{"type": "add_comment", "item_number": float64(42), "body": strings.Repeat("x", 200)},
+			map[string]any{"type": "add_labels", "labels": []any{"bug", "triage"}},
+			map[string]any{"type": "push_to_pull_request_branch", "branch": "fix/flaky"},
+			map[string]any{"title": "missing type"},
+			"not an item",
+		},
+	}
+
+	summary := summarizeTrialSafeOutputs(safeOutputs)
+	require.Len(t, summary, 4, "items without a type should be skipped")
+	assert.Equal(t, TrialSafeOutput{Type: "create_issue", Summary: "Flaky test in CI"}, summary[0], "title should be the summary")
+	assert.Equal(t, "#42", summary[1].Target, "item number should be the target")
+	assert.LessOrEqual(t, len(summary[1].Summary), trialSafeOutputSummaryMaxLen, "long bodies should be truncated")
+	assert.Equal(t, "bug triage", summary[2].Summary, "labels should be listed")
+	assert.Equal(t, "fix/flaky", summary[3].Target, "branch should be the target")
+
+	assert.Empty(t, summarizeTrialSafeOutputs(nil), "missing agent output should yield no summary")
+}
@@ -7,6 +7,9 @@ type WorkflowTrialResult struct {
 	WorkflowName string         `json:"workflow_name"`
 	RunID        string         `json:"run_id"`
 	SafeOutputs  map[string]any `json:"safe_outputs"`
+	// WouldProduce summarizes SafeOutputs: the changes the workflow would have made to
+	// the target repository outside of trial mode.
+	WouldProduce []TrialSafeOutput `json:"would_produce,omitempty"`
 	//AgentStdioLogs      []string               `json:"agent_stdio_logs,omitempty"`
 	AgenticRunInfo      map[string]any `json:"agentic_run_info,omitempty"`
 	AdditionalArtifacts map[string]any `json:"additional_artifacts,omitempty"`
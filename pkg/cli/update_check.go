@@ -36,6 +36,7 @@ type Release struct {
 	HTMLURL    string `json:"html_url"`
 	Prerelease bool   `json:"prerelease"`
 	Draft      bool   `json:"draft"`
+	Body       string `json:"body"`
 }
 
 // shouldCheckForUpdate determines if we should check for updates based on:
@@ -32,6 +32,9 @@ repository, merges upstream changes with any local modifications, and recompiles
 
 If no workflow names are specified, all workflows with a 'source' field are updated.
 
+Use --dry-run to print the changes each update would make as a unified diff,
+without writing or compiling any files.
+
 By default, the update performs a 3-way merge to preserve your local changes.
 Use --no-merge to override local changes with the upstream version.
 
@@ -58,6 +61,7 @@ Note: In GitHub Enterprise repos, shorthand source specs resolve on your enterpr
   ` + string(constants.CLIExtensionPrefix) + ` update --org my-org --create-issue  # Open issues in repos with pending updates
   ` + string(constants.CLIExtensionPrefix) + ` update --org my-org --create-issue --yes  # Auto-accept per-repo confirmations (required in CI)
   ` + string(constants.CLIExtensionPrefix) + ` update --org my-org --create-pull-request --yes  # Auto-accept per-repo confirmations for PR creation (required in CI)
+  ` + string(constants.CLIExtensionPrefix) + ` update --dry-run          # Preview the changes as a diff without applying them
   ` + string(constants.CLIExtensionPrefix) + ` update --no-merge         # Override local changes with upstream
   ` + string(constants.CLIExtensionPrefix) + ` update repo-assist --major # Allow major version updates
   ` + string(constants.CLIExtensionPrefix) + ` update --force            # Force update even if no changes
@@ -96,6 +100,7 @@ Note: In GitHub Enterprise repos, shorthand source specs resolve on your enterpr
 			targetRepo, _ := cmd.Flags().GetString("repo")
 			targetOrg, _ := cmd.Flags().GetString("org")
 			repoGlobs, _ := cmd.Flags().GetStringSlice("repos")
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
 
 			if err := validateEngine(engineOverride); err != nil {
 				return err
@@ -118,6 +123,10 @@ Note: In GitHub Enterprise repos, shorthand source specs resolve on your enterpr
 				return errors.New("cannot specify both --create-pull-request and --create-issue")
 			}
 
+			if dryRun && (createPR || createIssue || targetRepo != "" || targetOrg != "") {
+				return errors.New("--dry-run cannot be combined with --create-pull-request, --create-issue, --repo or --org")
+			}
+
 			if createPR && targetRepo == "" && targetOrg == "" {
 				if err := PreflightCheckForCreatePR(verbose); err != nil {
 					return err
@@ -141,6 +150,7 @@ Note: In GitHub Enterprise repos, shorthand source specs resolve on your enterpr
 				DisableSecurityScanner: disableSecurityScanner,
 				CoolDown:               coolDown,
 				Approve:                approveFlag,
+				DryRun:                 dryRun,
 			}
 
 			if targetRepo != "" {
@@ -179,6 +189,7 @@ Note: In GitHub Enterprise repos, shorthand source specs resolve on your enterpr
 	cmd.Flags().Bool("disable-security-scanner", false, "Skip security scanning of workflow markdown content")
 	_ = cmd.Flags().MarkDeprecated("disable-security-scanner", "use --no-security-scanner instead")
 	cmd.Flags().Bool("approve", false, "Approve all safe update changes. When strict mode is active (the default), the compiler emits warnings for new restricted secrets or unapproved action additions/removals not present in the existing gh-aw-manifest. Use this flag to approve and skip safe update enforcement")
+	cmd.Flags().Bool("dry-run", false, "Print the changes each workflow update would make as a diff without writing or compiling files")
 	cmd.Flags().Bool("no-compile", false, "Skip recompiling workflows during update (do not modify lock files)")
 	cmd.Flags().Bool("no-redirect", false, "Refuse updates when redirect frontmatter is present")
 	cmd.Flags().String("org", "", "Preview or create workflow update pull requests across an organization")
@@ -210,6 +221,12 @@ func RunUpdateWorkflows(ctx context.Context, opts UpdateWorkflowsOptions) error
 		firstErr = fmt.Errorf("workflow update failed: %w", err)
 	}
 
+	// A dry run only previews workflow changes; action, container pin and
+	// recompile steps all write files.
+	if opts.DryRun {
+		return firstErr
+	}
+
 	// Update GitHub Actions versions in actions-lock.json.
 	// By default all actions are updated to the latest major version.
 	// Pass --no-release-bump to revert to only forcing updates for core (actions/*) actions.
@@ -132,6 +132,19 @@ func TestNewUpdateCommand_HasApproveFlag(t *testing.T) {
 	assert.Contains(t, flag.Usage, "When strict mode is active", "--approve description should match compile/upgrade semantics")
 }
 
+func TestNewUpdateCommand_DryRunRejectsRemoteModes(t *testing.T) {
+	cmd := NewUpdateCommand(func(string) error { return nil })
+	require.NotNil(t, cmd, "update command should be created")
+	require.NotNil(t, cmd.Flags().Lookup("dry-run"), "update command should register --dry-run")
+
+	cmd.SetArgs([]string{"--dry-run", "--org", "my-org"})
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	err := cmd.Execute()
+	require.Error(t, err, "--dry-run with --org should be rejected")
+	assert.Contains(t, err.Error(), "--dry-run cannot be combined", "error should explain the conflicting flags")
+}
+
 // TestMergeWorkflowContent_WithConflicts tests a merge with conflicts
 func TestMergeWorkflowContent_WithConflicts(t *testing.T) {
 	base := `---
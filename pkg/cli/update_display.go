@@ -33,3 +33,26 @@ func showUpdateSummary(successfulUpdates []string, failedUpdates []updateFailure
 		fmt.Fprintln(os.Stderr, "")
 	}
 }
+
+// showUpdatePreviewSummary displays the summary of a dry-run update, where no
+// workflow was written or compiled.
+func showUpdatePreviewSummary(previewedUpdates []string, failedUpdates []updateFailure) {
+	updateDisplayLog.Printf("Rendering update preview summary: %d previewed, %d failed", len(previewedUpdates), len(failedUpdates))
+	fmt.Fprintln(os.Stderr, "")
+
+	if len(previewedUpdates) > 0 {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Previewed %d workflow(s); no files were changed:", len(previewedUpdates))))
+		for _, name := range previewedUpdates {
+			fmt.Fprintln(os.Stderr, console.FormatListItem(name))
+		}
+		fmt.Fprintln(os.Stderr, "")
+	}
+
+	if len(failedUpdates) > 0 {
+		fmt.Fprintln(os.Stderr, console.FormatErrorMessage(fmt.Sprintf("Failed to preview %d workflow(s):", len(failedUpdates))))
+		for _, failure := range failedUpdates {
+			fmt.Fprintf(os.Stderr, "  %s: %s\n", failure.Name, failure.Error)
+		}
+		fmt.Fprintln(os.Stderr, "")
+	}
+}
@@ -134,6 +134,14 @@ func updateManifestWorkflowGroup(ctx context.Context, source string, grouped []*
 	for name, wf := range existingByName {
 		latestPath, exists := latestByName[name]
 		if !exists {
+			if opts.DryRun {
+				if err := previewManifestManagedWorkflowRemoval(wf); err != nil {
+					failures = append(failures, updateFailure{Name: wf.Name, Error: err.Error()})
+					continue
+				}
+				successes = append(successes, wf.Name)
+				continue
+			}
 			if err := removeManifestManagedWorkflow(wf.Path); err != nil {
 				failures = append(failures, updateFailure{Name: wf.Name, Error: err.Error()})
 				continue
@@ -177,6 +185,14 @@ func updateManifestWorkflowGroup(ctx context.Context, source string, grouped []*
 	return successes, failures
 }
 
+func previewManifestManagedWorkflowRemoval(wf *workflowWithSource) error {
+	currentContent, err := os.ReadFile(wf.Path)
+	if err != nil {
+		return fmt.Errorf("failed to read current workflow: %w", err)
+	}
+	return previewWorkflowUpdate(wf.Path, string(currentContent), "", "Would remove workflow no longer listed in manifest: "+filepath.Base(wf.Path))
+}
+
 func removeManifestManagedWorkflow(workflowPath string) error {
 	updateManifestLog.Printf("Removing manifest-managed workflow no longer in manifest: %s", filepath.Base(workflowPath))
 	if err := os.Remove(workflowPath); err != nil && !os.IsNotExist(err) {
@@ -268,6 +284,17 @@ func updateManifestManagedWorkflow(ctx context.Context, update manifestManagedWo
 		}
 	}
 
+	if opts.DryRun {
+		currentContent, err := os.ReadFile(update.wf.Path)
+		if err != nil {
+			return fmt.Errorf("failed to read current workflow: %w", err)
+		}
+		summary := fmt.Sprintf("Would update %s from %s to %s", update.wf.Name, shortRef(update.currentRef), shortRef(update.latestRef))
+		if hasConflicts {
+			summary += " with CONFLICTS"
+		}
+		return previewWorkflowUpdate(update.wf.Path, string(currentContent), finalContent, summary)
+	}
 	if err := os.WriteFile(update.wf.Path, []byte(finalContent), constants.FilePermPublic); err != nil {
 		return fmt.Errorf("failed to write updated workflow: %w", err)
 	}
@@ -313,6 +340,9 @@ func addManifestManagedWorkflow(ctx context.Context, targetDir, name, repo, late
 	}
 
 	destPath := filepath.Join(targetDir, name+".md")
+	if opts.DryRun {
+		return previewWorkflowUpdate(destPath, "", content, "Would add new workflow from manifest: "+filepath.Base(destPath))
+	}
 	if err := os.WriteFile(destPath, []byte(content), constants.FilePermPublic); err != nil {
 		return fmt.Errorf("failed to write new manifest workflow %s: %w", destPath, err)
 	}
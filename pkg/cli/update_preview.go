@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var updatePreviewLog = logger.New("cli:update_preview")
+
+// unifiedWorkflowDiff returns a unified diff between the old and new content of a
+// workflow file, with path as the file name in the diff headers. Returns an empty
+// string when the contents are identical.
+func unifiedWorkflowDiff(path, oldContent, newContent string) (string, error) {
+	tmpDir, err := os.MkdirTemp("", "gh-aw-diff-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "old.md"), []byte(oldContent), constants.FilePermPublic); err != nil {
+		return "", fmt.Errorf("failed to write old content: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "new.md"), []byte(newContent), constants.FilePermPublic); err != nil {
+		return "", fmt.Errorf("failed to write new content: %w", err)
+	}
+
+	// git diff --no-index exits with 1 when the files differ.
+	cmd := exec.Command("git", "diff", "--no-index", "--no-color", "--no-prefix", "old.md", "new.md")
+	cmd.Dir = tmpDir
+	output, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) || exitErr.ExitCode() != 1 {
+			return "", fmt.Errorf("git diff failed: %w", err)
+		}
+	}
+
+	name := filepath.ToSlash(path)
+	var diff strings.Builder
+	for line := range strings.SplitSeq(string(output), "\n") {
+		switch {
+		case strings.HasPrefix(line, "diff --git "), strings.HasPrefix(line, "index "):
+			continue
+		case line == "--- old.md":
+			line = "--- a/" + name
+		case line == "+++ new.md":
+			line = "+++ b/" + name
+		}
+		diff.WriteString(line + "\n")
+	}
+	return strings.TrimRight(diff.String(), "\n"), nil
+}
+
+// previewWorkflowUpdate prints the diff a dry-run update would apply to a workflow
+// file. oldContent is empty for workflows that would be added and newContent is
+// empty for workflows that would be removed.
+func previewWorkflowUpdate(path, oldContent, newContent, summary string) error {
+	updatePreviewLog.Printf("Previewing update of %s", path)
+	diff, err := unifiedWorkflowDiff(path, oldContent, newContent)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, console.FormatInfoMessage("[dry run] "+summary))
+	if diff == "" {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage("No content changes"))
+		return nil
+	}
+	fmt.Println(diff)
+	return nil
+}
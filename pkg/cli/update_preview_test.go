@@ -0,0 +1,58 @@
+//go:build !integration
+
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnifiedWorkflowDiff(t *testing.T) {
+	tests := []struct {
+		name       string
+		oldContent string
+		newContent string
+		expected   []string
+	}{
+		{
+			name:       "identical content",
+			oldContent: "---\non: push\n---\n\n# Triage\n",
+			newContent: "---\non: push\n---\n\n# Triage\n",
+		},
+		{
+			name:       "changed source ref and body",
+			oldContent: "---\nsource: owner/repo/workflows/triage.md@v1.0.0\n---\n\n# Triage\n",
+			newContent: "---\nsource: owner/repo/workflows/triage.md@v1.2.0\n---\n\n# Triage issues\n",
+			expected: []string{
+				"--- a/.github/workflows/triage.md",
+				"+++ b/.github/workflows/triage.md",
+				"-source: owner/repo/workflows/triage.md@v1.0.0",
+				"+source: owner/repo/workflows/triage.md@v1.2.0",
+				"-# Triage",
+				"+# Triage issues",
+			},
+		},
+		{
+			name:       "added workflow",
+			newContent: "# New\n",
+			expected:   []string{"+++ b/.github/workflows/triage.md", "+# New"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff, err := unifiedWorkflowDiff(".github/workflows/triage.md", tt.oldContent, tt.newContent)
+			require.NoError(t, err, "diff should succeed")
+			if len(tt.expected) == 0 {
+				assert.Empty(t, diff, "identical content should produce no diff")
+				return
+			}
+			for _, line := range tt.expected {
+				assert.Contains(t, diff, line, "diff should contain %q", line)
+			}
+			assert.NotContains(t, diff, "old.md", "diff should not mention temp file names")
+		})
+	}
+}
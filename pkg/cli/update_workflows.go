@@ -69,6 +69,7 @@ type UpdateWorkflowsOptions struct {
 	NoRedirect             bool
 	CoolDown               time.Duration
 	Approve                bool
+	DryRun                 bool // print the changes as a diff instead of writing and compiling
 }
 
 // UpdateWorkflows updates workflows from their source repositories
@@ -141,7 +142,11 @@ func UpdateWorkflows(ctx context.Context, opts UpdateWorkflowsOptions) error {
 	}
 
 	// Show summary
-	showUpdateSummary(successfulUpdates, failedUpdates)
+	if opts.DryRun {
+		showUpdatePreviewSummary(successfulUpdates, failedUpdates)
+	} else {
+		showUpdateSummary(successfulUpdates, failedUpdates)
+	}
 
 	if len(successfulUpdates) == 0 {
 		// If all failures were due to GitHub API rate limiting, treat as non-fatal.
@@ -803,6 +808,18 @@ func updateWorkflow(ctx context.Context, wf *workflowWithSource, opts UpdateWork
 		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("Security scanning disabled"))
 	}
 
+	if opts.DryRun {
+		currentContent, err := os.ReadFile(wf.Path)
+		if err != nil {
+			return fmt.Errorf("failed to read current workflow: %w", err)
+		}
+		summary := fmt.Sprintf("Would update %s from %s to %s", wf.Name, shortRef(currentRef), shortRef(latestRef))
+		if hasConflicts {
+			summary += " with CONFLICTS"
+		}
+		return previewWorkflowUpdate(wf.Path, string(currentContent), finalContent, summary)
+	}
+
 	// Write updated content
 	if err := os.WriteFile(wf.Path, []byte(finalContent), constants.FilePermPublic); err != nil {
 		return fmt.Errorf("failed to write updated workflow: %w", err)
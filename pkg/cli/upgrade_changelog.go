@@ -0,0 +1,157 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/semverutil"
+)
+
+var upgradeChangelogLog = logger.New("cli:upgrade_changelog")
+
+// maxChangelogEntries caps the number of changelog entries printed after an upgrade.
+const maxChangelogEntries = 20
+
+var (
+	// changelogAlwaysRelevantSection matches release-note sections whose entries
+	// always affect compiled workflows.
+	changelogAlwaysRelevantSection = regexp.MustCompile(`(?i)breaking|migration`)
+	// changelogCompilerKeywords matches release-note entries that change what the
+	// compiler accepts or generates.
+	changelogCompilerKeywords = regexp.MustCompile(`(?i)\b(compil\w*|lock[ -]?files?|\.lock\.yml|frontmatter|codemods?|safe[- ]outputs?|schema)\b`)
+	// changelogEntryPrefix matches the start of a release-note entry: a "####"
+	// heading (changeset notes) or a top-level list item (generated notes).
+	changelogEntryPrefix = regexp.MustCompile(`^(####\s+|[*-]\s+)`)
+)
+
+// changelogRelease holds the compiler-relevant entries of one release.
+type changelogRelease struct {
+	Tag     string
+	URL     string
+	Entries []string
+}
+
+// showUpgradeChangelog prints the compiler-relevant changes between fromVersion
+// and toVersion. It is best-effort: failures are logged and never block the upgrade.
+func showUpgradeChangelog(ctx context.Context, fromVersion, toVersion string) {
+	client, err := api.NewRESTClient(gitHubDotComRESTClientOptions())
+	if err != nil {
+		upgradeChangelogLog.Printf("Failed to create GitHub client (skipping changelog): %v", err)
+		return
+	}
+	releases, err := fetchReleasesBetween(ctx, client, fromVersion, toVersion)
+	if err != nil {
+		upgradeChangelogLog.Printf("Failed to fetch releases (skipping changelog): %v", err)
+		return
+	}
+	printUpgradeChangelog(buildUpgradeChangelog(releases), fromVersion, toVersion)
+}
+
+// fetchReleasesBetween returns the published releases newer than fromVersion and
+// no newer than toVersion, oldest first.
+func fetchReleasesBetween(ctx context.Context, client releaseRESTClient, fromVersion, toVersion string) ([]Release, error) {
+	var releases []Release
+	if err := client.DoWithContext(ctx, http.MethodGet, fmt.Sprintf("repos/github/gh-aw/releases?per_page=%d", maxReleasesToQuery), nil, &releases); err != nil {
+		return nil, fmt.Errorf("failed to query releases: %w", err)
+	}
+
+	var between []Release
+	for _, release := range releases {
+		if release.Draft || !semverutil.IsValid(release.TagName) {
+			continue
+		}
+		if semverutil.Compare(release.TagName, fromVersion) > 0 && semverutil.Compare(release.TagName, toVersion) <= 0 {
+			between = append(between, release)
+		}
+	}
+	slices.SortFunc(between, func(a, b Release) int {
+		return semverutil.Compare(a.TagName, b.TagName)
+	})
+	upgradeChangelogLog.Printf("Found %d release(s) between %s and %s", len(between), fromVersion, toVersion)
+	return between, nil
+}
+
+// buildUpgradeChangelog extracts the compiler-relevant entries of each release,
+// dropping releases without any.
+func buildUpgradeChangelog(releases []Release) []changelogRelease {
+	var changelog []changelogRelease
+	for _, release := range releases {
+		entries := compilerRelevantChanges(release.Body)
+		if len(entries) == 0 {
+			continue
+		}
+		changelog = append(changelog, changelogRelease{Tag: release.TagName, URL: release.HTMLURL, Entries: entries})
+	}
+	return changelog
+}
+
+// compilerRelevantChanges returns the one-line titles of the release-note entries
+// that affect compiled workflows: every entry of a breaking-change or migration
+// section, plus entries that mention the compiler, lock files, frontmatter,
+// codemods, safe outputs, or the schema.
+func compilerRelevantChanges(body string) []string {
+	var entries []string
+	alwaysRelevant := false
+	for line := range strings.SplitSeq(body, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.HasPrefix(line, "## ") || strings.HasPrefix(line, "### ") {
+			alwaysRelevant = changelogAlwaysRelevantSection.MatchString(line)
+			continue
+		}
+		prefix := changelogEntryPrefix.FindString(line)
+		if prefix == "" {
+			continue
+		}
+		title := strings.TrimSpace(strings.TrimPrefix(line, prefix))
+		if title == "" {
+			continue
+		}
+		if alwaysRelevant || changelogCompilerKeywords.MatchString(title) {
+			entries = append(entries, title)
+		}
+	}
+	return entries
+}
+
+// printUpgradeChangelog prints the changelog to stderr, capped at maxChangelogEntries.
+func printUpgradeChangelog(changelog []changelogRelease, fromVersion, toVersion string) {
+	if len(changelog) == 0 {
+		upgradeChangelogLog.Print("No compiler-relevant changes to show")
+		return
+	}
+
+	fmt.Fprintln(os.Stderr, "")
+	fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Compiler-relevant changes from %s to %s:", fromVersion, toVersion)))
+	printed := 0
+	omitted := 0
+	for _, release := range changelog {
+		if printed >= maxChangelogEntries {
+			omitted += len(release.Entries)
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "\n  %s\n", release.Tag)
+		for _, entry := range release.Entries {
+			if printed >= maxChangelogEntries {
+				omitted++
+				continue
+			}
+			fmt.Fprintf(os.Stderr, "    • %s\n", entry)
+			printed++
+		}
+		if release.URL != "" {
+			fmt.Fprintf(os.Stderr, "    %s\n", release.URL)
+		}
+	}
+	if omitted > 0 {
+		fmt.Fprintf(os.Stderr, "\n  …and %d more; see the release notes above.\n", omitted)
+	}
+	fmt.Fprintln(os.Stderr, "")
+}
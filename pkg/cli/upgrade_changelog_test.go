@@ -0,0 +1,90 @@
+//go:build !integration
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompilerRelevantChanges(t *testing.T) {
+	body := `## v1.5.0
+
+### Breaking Changes
+
+#### Remove the deprecated network.firewall field
+
+### Features
+
+#### Add observability.slo to the frontmatter
+#### Add a dark theme to the docs site
+
+### Bug Fixes
+
+#### Fix lock file hash drift on Windows
+#### Fix typo in the README
+
+## What's Changed
+* Regenerate the JSON schema by @octocat in https://github.com/github/gh-aw/pull/1
+* Bump actions/checkout by @dependabot in https://github.com/github/gh-aw/pull/2
+`
+
+	assert.Equal(t, []string{
+		"Remove the deprecated network.firewall field",
+		"Add observability.slo to the frontmatter",
+		"Fix lock file hash drift on Windows",
+		"Regenerate the JSON schema by @octocat in https://github.com/github/gh-aw/pull/1",
+	}, compilerRelevantChanges(body), "only breaking changes and compiler-related entries should be kept")
+	assert.Empty(t, compilerRelevantChanges(""), "empty release notes have no changes")
+}
+
+func TestFetchReleasesBetween(t *testing.T) {
+	client := fakeReleaseClient{do: func(_ context.Context, _ string, path string, _ io.Reader, response any) error {
+		assert.Contains(t, path, "repos/github/gh-aw/releases", "should list releases")
+		releases, ok := response.(*[]Release)
+		require.True(t, ok, "response should be a release list")
+		*releases = []Release{
+			{TagName: "v1.6.0"},
+			{TagName: "v1.5.0"},
+			{TagName: "v1.4.1", Draft: true},
+			{TagName: "v1.4.0"},
+			{TagName: "nightly"},
+			{TagName: "v1.3.0"},
+		}
+		return nil
+	}}
+
+	releases, err := fetchReleasesBetween(context.Background(), client, "v1.3.0", "v1.5.0")
+	require.NoError(t, err, "listing releases should succeed")
+	tags := make([]string, 0, len(releases))
+	for _, release := range releases {
+		tags = append(tags, release.TagName)
+	}
+	assert.Equal(t, []string{"v1.4.0", "v1.5.0"}, tags, "should return published releases after from up to to, oldest first")
+}
+
+func TestFetchReleasesBetween_Error(t *testing.T) {
+	client := fakeReleaseClient{do: func(context.Context, string, string, io.Reader, any) error {
+		return errors.New("rate limited")
+	}}
+
+	_, err := fetchReleasesBetween(context.Background(), client, "v1.3.0", "v1.5.0")
+	require.Error(t, err, "API errors should be returned")
+	assert.Contains(t, err.Error(), "failed to query releases", "error should explain what failed")
+}
+
+func TestBuildUpgradeChangelog(t *testing.T) {
+	changelog := buildUpgradeChangelog([]Release{
+		{TagName: "v1.4.0", Body: "### Bug Fixes\n\n#### Fix typo in the README\n"},
+		{TagName: "v1.5.0", HTMLURL: "https://github.com/github/gh-aw/releases/tag/v1.5.0", Body: "### Features\n\n#### Compile workflows in parallel\n"},
+	})
+
+	require.Len(t, changelog, 1, "releases without compiler-relevant changes should be dropped")
+	assert.Equal(t, "v1.5.0", changelog[0].Tag, "unexpected release")
+	assert.Equal(t, []string{"Compile workflows in parallel"}, changelog[0].Entries, "unexpected entries")
+}
@@ -44,6 +44,15 @@ This command:
   3. Updates GitHub Actions versions in .github/aw/actions-lock.json (unless --no-actions is set)
   4. Compiles all workflows to generate lock files (like 'compile' command)
 
+When a newer gh-aw release is available, the extension is upgraded first and the
+compiler-relevant changes from the release notes (breaking changes, migrations,
+and changes to compilation, lock files, frontmatter, and safe outputs) are shown.
+
+Lock files record the compiler version that generated them. When compilation is
+skipped (--no-compile or --no-fix), workflows whose lock files were generated by an
+older gh-aw version are listed and you are offered to recompile just those
+(--yes recompiles them without asking).
+
 Flag behavior:
 - Upgrade runs codemods, action version updates, and workflow compilation by default; use --no-fix to skip all three steps
 - --no-actions and --no-compile are only applied when --no-fix is not set
@@ -62,6 +71,7 @@ This command always upgrades all Markdown files in .github/workflows.`,
   ` + string(constants.CLIExtensionPrefix) + ` upgrade --no-fix                    # Update agent files only (skip codemods, actions, and compilation)
   ` + string(constants.CLIExtensionPrefix) + ` upgrade --no-actions                # Skip updating GitHub Actions versions
   ` + string(constants.CLIExtensionPrefix) + ` upgrade --no-compile                # Skip recompiling workflows (do not modify lock files)
+  ` + string(constants.CLIExtensionPrefix) + ` upgrade --no-compile --yes          # Recompile only workflows compiled by an older gh-aw version
   ` + string(constants.CLIExtensionPrefix) + ` upgrade --create-pull-request       # Upgrade and open a pull request
   ` + string(constants.CLIExtensionPrefix) + ` upgrade --dir custom/workflows      # Upgrade workflows in custom directory
   ` + string(constants.CLIExtensionPrefix) + ` upgrade --engine claude             # Override AI engine for compilation
@@ -178,7 +188,7 @@ This command always upgrades all Markdown files in .github/workflows.`,
 	cmd.Flags().Bool("pr", false, "Alias for --create-pull-request")
 	_ = cmd.Flags().MarkHidden("pr") // Hide the short alias from help output
 	cmd.Flags().Bool("create-issue", false, "Open a GitHub issue in each org repository with agentic workflows (requires --org)")
-	cmd.Flags().BoolP("yes", "y", false, "Auto-accept org-mode upgrade confirmations (required in CI)")
+	cmd.Flags().BoolP("yes", "y", false, "Auto-accept confirmations: org-mode upgrades and recompiling outdated lock files (required in CI)")
 	cmd.Flags().Bool("audit", false, "Check dependency health without performing upgrades")
 	cmd.Flags().Bool("pre-releases", false, "Include pre-release versions when checking for extension upgrades; pre-releases are installed by exact tag")
 	cmd.Flags().Bool("approve", false, "Approve all safe update changes. When strict mode is active (the default), the compiler emits warnings for new restricted secrets or unapproved action additions/removals not present in the existing gh-aw-manifest. Use this flag to approve and skip safe update enforcement")
@@ -248,6 +258,12 @@ func runUpgradeCommand(opts upgradeOptions) error {
 			return err
 		}
 		if upgraded {
+			// Show what changed for compiled workflows before the new binary recompiles them.
+			if installedVersion, err := installedExtensionVersion(); err == nil {
+				showUpgradeChangelog(opts.ctx, GetVersion(), installedVersion)
+			} else {
+				upgradeLog.Printf("Could not determine installed version for changelog: %v", err)
+			}
 			upgradeLog.Print("Extension was upgraded; re-launching with new binary")
 			fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Continuing upgrade with newly installed version..."))
 			// Pass installPath so relaunchWithSameArgs uses the pre-rename path;
@@ -384,6 +400,13 @@ func runUpgradeCommand(opts upgradeOptions) error {
 				fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Skipping compilation (--no-compile specified)"))
 			}
 		}
+
+		// Lock files stamped by an older compiler are not refreshed; offer to recompile them.
+		workflowsDir := opts.workflowDir
+		if workflowsDir == "" {
+			workflowsDir = constants.GetWorkflowDir()
+		}
+		offerStaleLockRecompile(opts, workflowsDir)
 	}
 
 	// Step 4b: Update container image digest pins (unless --no-fix or --no-actions is specified)
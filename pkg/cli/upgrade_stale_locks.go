@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/semverutil"
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/tty"
+	"github.com/github/gh-aw/pkg/workflow"
+)
+
+var upgradeStaleLocksLog = logger.New("cli:upgrade_stale_locks")
+
+// staleLockFile is a workflow whose lock file was generated by an older compiler.
+type staleLockFile struct {
+	MarkdownPath    string
+	CompilerVersion string // Version stamped in the gh-aw-metadata header; empty for legacy lock files
+}
+
+// upgradeConfirmActionFn is the confirmation prompt used before recompiling stale
+// lock files. Overridable in tests.
+var upgradeConfirmActionFn = console.ConfirmAction
+
+// findStaleLockFiles returns the workflows in workflowsDir whose lock files were
+// generated by a compiler older than currentVersion, as recorded by the
+// compiler_version field of the gh-aw-metadata header. Lock files from legacy
+// compilers that predate the metadata header are reported as stale too; lock files
+// without a stamped version (development builds) are not.
+// Returns nil when currentVersion is not a released version.
+func findStaleLockFiles(workflowsDir, currentVersion string) ([]staleLockFile, error) {
+	if !workflow.IsReleasedVersion(currentVersion) || !semverutil.IsValid(currentVersion) {
+		upgradeStaleLocksLog.Printf("Skipping stale lock file detection for non-release version %s", currentVersion)
+		return nil, nil
+	}
+
+	entries, err := os.ReadDir(workflowsDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read workflows directory: %w", err)
+	}
+
+	var stale []staleLockFile
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
+			continue
+		}
+		markdownPath := filepath.Join(workflowsDir, entry.Name())
+		content, err := os.ReadFile(stringutil.MarkdownToLockFile(markdownPath))
+		if err != nil {
+			// Not compiled yet; nothing to compare
+			continue
+		}
+		metadata, legacy, err := workflow.ExtractMetadataFromLockFile(string(content))
+		if err != nil || metadata == nil {
+			upgradeStaleLocksLog.Printf("No readable metadata in lock file for %s: %v", entry.Name(), err)
+			continue
+		}
+		switch {
+		case legacy:
+			stale = append(stale, staleLockFile{MarkdownPath: markdownPath})
+		case semverutil.IsValid(metadata.CompilerVersion) && semverutil.Compare(metadata.CompilerVersion, currentVersion) < 0:
+			stale = append(stale, staleLockFile{MarkdownPath: markdownPath, CompilerVersion: metadata.CompilerVersion})
+		}
+	}
+	upgradeStaleLocksLog.Printf("Found %d stale lock file(s) in %s", len(stale), workflowsDir)
+	return stale, nil
+}
+
+// offerStaleLockRecompile lists the workflows compiled by an older gh-aw version and
+// offers to recompile them. It is used when upgrade does not recompile every workflow
+// (--no-compile or --no-fix). With --yes the workflows are recompiled without asking;
+// in a non-interactive session the list is printed with a hint instead.
+func offerStaleLockRecompile(opts upgradeOptions, workflowsDir string) {
+	stale, err := findStaleLockFiles(workflowsDir, GetVersion())
+	if err != nil {
+		upgradeStaleLocksLog.Printf("Failed to detect stale lock files: %v", err)
+		return
+	}
+	if len(stale) == 0 {
+		return
+	}
+
+	fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("%d workflow(s) were compiled by an older gh-aw version:", len(stale))))
+	for _, s := range stale {
+		version := s.CompilerVersion
+		if version == "" {
+			version = "legacy"
+		}
+		fmt.Fprintf(os.Stderr, "  %s (%s)\n", filepath.Base(s.MarkdownPath), version)
+	}
+
+	if !opts.yes {
+		if !tty.IsStderrTerminal() {
+			fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Run '"+string(constants.CLIExtensionPrefix)+" compile' to recompile them with "+GetVersion()+"."))
+			return
+		}
+		confirmed, err := upgradeConfirmActionFn(
+			fmt.Sprintf("Recompile %d workflow(s) with %s?", len(stale), GetVersion()),
+			"Yes, recompile",
+			"No, skip",
+		)
+		if err != nil || !confirmed {
+			upgradeStaleLocksLog.Printf("Stale lock recompile declined: confirmed=%v, err=%v", confirmed, err)
+			return
+		}
+	}
+
+	recompileStaleLockFiles(opts, stale)
+}
+
+// recompileStaleLockFiles recompiles the given workflows, reporting failures as warnings.
+func recompileStaleLockFiles(opts upgradeOptions, stale []staleLockFile) {
+	recompiled := 0
+	for _, s := range stale {
+		if err := compileWorkflowWithRefresh(opts.ctx, s.MarkdownPath, opts.verbose, true, opts.engineOverride, false, opts.approve); err != nil {
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Failed to recompile %s: %v", filepath.Base(s.MarkdownPath), err)))
+			continue
+		}
+		recompiled++
+	}
+	fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Recompiled %d of %d workflow(s)", recompiled, len(stale))))
+}
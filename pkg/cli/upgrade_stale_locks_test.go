@@ -0,0 +1,58 @@
+//go:build !integration
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindStaleLockFiles(t *testing.T) {
+	originalRelease := workflow.IsRelease()
+	t.Cleanup(func() { workflow.SetIsRelease(originalRelease) })
+
+	dir := testutil.TempDir(t, "stale-locks")
+	writeWorkflow := func(name, lockHeader string) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name+".md"), []byte("---\non: push\n---\n# "+name+"\n"), 0644), "failed to write workflow")
+		if lockHeader != "" {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, name+".lock.yml"), []byte(lockHeader+"\nname: "+name+"\n"), 0644), "failed to write lock file")
+		}
+	}
+	writeWorkflow("older", `# gh-aw-metadata: {"schema_version":"v4","frontmatter_hash":"abc","compiler_version":"v1.4.0"}`)
+	writeWorkflow("current", `# gh-aw-metadata: {"schema_version":"v4","frontmatter_hash":"abc","compiler_version":"v1.5.0"}`)
+	writeWorkflow("dev-build", `# gh-aw-metadata: {"schema_version":"v4","frontmatter_hash":"abc"}`)
+	writeWorkflow("legacy", "# frontmatter-hash: "+"0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef")
+	writeWorkflow("uncompiled", "")
+
+	t.Run("release build", func(t *testing.T) {
+		workflow.SetIsRelease(true)
+
+		stale, err := findStaleLockFiles(dir, "v1.5.0")
+		require.NoError(t, err, "detection should succeed")
+		assert.Equal(t, []staleLockFile{
+			{MarkdownPath: filepath.Join(dir, "legacy.md")},
+			{MarkdownPath: filepath.Join(dir, "older.md"), CompilerVersion: "v1.4.0"},
+		}, stale, "only lock files from older or legacy compilers should be stale")
+	})
+
+	t.Run("development build", func(t *testing.T) {
+		workflow.SetIsRelease(false)
+
+		stale, err := findStaleLockFiles(dir, "v1.5.0")
+		require.NoError(t, err, "detection should succeed")
+		assert.Empty(t, stale, "development builds cannot compare versions")
+	})
+
+	t.Run("missing directory", func(t *testing.T) {
+		workflow.SetIsRelease(true)
+
+		_, err := findStaleLockFiles(filepath.Join(dir, "missing"), "v1.5.0")
+		require.Error(t, err, "a missing workflows directory should be reported")
+	})
+}
@@ -0,0 +1,534 @@
+// This file provides command-line interface functionality for gh-aw.
+// This file (workflow_graph.go) builds the dependency graph of the agentic
+// workflows in a repository for the graph command.
+//
+// Key responsibilities:
+//   - Reading the triggers and safe outputs of each workflow from its frontmatter
+//   - Linking each safe output to the workflows whose triggers it can fire
+//   - Detecting chains of workflows that trigger each other in a cycle
+//   - Rendering the graph as Mermaid or Graphviz DOT
+
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/github/gh-aw/pkg/sliceutil"
+	"github.com/github/gh-aw/pkg/workflow"
+)
+
+var workflowGraphLog = logger.New("cli:workflow_graph")
+
+// safeOutputEvents maps each safe output to the GitHub events it emits.
+var safeOutputEvents = map[string][]string{
+	"create-issue":                          {"issues"},
+	"update-issue":                          {"issues"},
+	"close-issue":                           {"issues"},
+	"assign-milestone":                      {"issues"},
+	"assign-to-user":                        {"issues"},
+	"unassign-from-user":                    {"issues"},
+	"add-comment":                           {"issue_comment", "discussion_comment"},
+	"add-labels":                            {"issues", "pull_request", "discussion"},
+	"remove-labels":                         {"issues", "pull_request", "discussion"},
+	"replace-label":                         {"issues", "pull_request", "discussion"},
+	"create-pull-request":                   {"pull_request", "push", "create"},
+	"update-pull-request":                   {"pull_request"},
+	"close-pull-request":                    {"pull_request"},
+	"mark-pull-request-as-ready-for-review": {"pull_request"},
+	"add-reviewer":                          {"pull_request"},
+	"push-to-pull-request-branch":           {"pull_request", "push"},
+	"merge-pull-request":                    {"pull_request", "push"},
+	"create-pull-request-review-comment":    {"pull_request_review_comment"},
+	"create-pull-request-review-suggestion": {"pull_request_review_comment"},
+	"reply-to-pull-request-review-comment":  {"pull_request_review_comment"},
+	"submit-pull-request-review":            {"pull_request_review"},
+	"dismiss-pull-request-review":           {"pull_request_review"},
+	"create-discussion":                     {"discussion"},
+	"update-discussion":                     {"discussion"},
+	"close-discussion":                      {"discussion"},
+	"update-release":                        {"release"},
+	"create-check-run":                      {"check_run"},
+	"create-code-scanning-alert":            {"code_scanning_alert"},
+	"dispatch-repository":                   {"repository_dispatch"},
+	"dispatch_repository":                   {"repository_dispatch"},
+}
+
+// safeOutputActivityTypes maps safe outputs to the activity type of the events they emit,
+// matched against the types: filter of the workflows they could trigger.
+var safeOutputActivityTypes = map[string]string{
+	"create-issue":                          "opened",
+	"update-issue":                          "edited",
+	"close-issue":                           "closed",
+	"assign-milestone":                      "milestoned",
+	"assign-to-user":                        "assigned",
+	"unassign-from-user":                    "unassigned",
+	"add-comment":                           "created",
+	"add-labels":                            "labeled",
+	"remove-labels":                         "unlabeled",
+	"create-pull-request":                   "opened",
+	"update-pull-request":                   "edited",
+	"close-pull-request":                    "closed",
+	"mark-pull-request-as-ready-for-review": "ready_for_review",
+	"add-reviewer":                          "review_requested",
+	"push-to-pull-request-branch":           "synchronize",
+	"merge-pull-request":                    "closed",
+	"create-pull-request-review-comment":    "created",
+	"create-pull-request-review-suggestion": "created",
+	"reply-to-pull-request-review-comment":  "created",
+	"submit-pull-request-review":            "submitted",
+	"dismiss-pull-request-review":           "dismissed",
+	"create-discussion":                     "created",
+	"update-discussion":                     "edited",
+	"close-discussion":                      "closed",
+	"update-release":                        "edited",
+}
+
+// commandTriggerEvents maps gh-aw command triggers to the GitHub events they listen to.
+var commandTriggerEvents = map[string][]string{
+	"slash_command": {"issues", "issue_comment", "pull_request", "pull_request_review_comment", "discussion", "discussion_comment"},
+	"command":       {"issues", "issue_comment", "pull_request", "pull_request_review_comment", "discussion", "discussion_comment"},
+	"label_command": {"issues", "pull_request", "discussion"},
+}
+
+// nonEventOnKeys are keys of the on: section that configure triggers rather than name events.
+var nonEventOnKeys = map[string]bool{
+	"bots":        true,
+	"filters":     true,
+	"labels":      true,
+	"needs":       true,
+	"permissions": true,
+	"reaction":    true,
+	"roles":       true,
+	"steps":       true,
+}
+
+// eventsNotBlockedByGitHubToken are the events that start workflow runs even when the
+// GITHUB_TOKEN caused them.
+var eventsNotBlockedByGitHubToken = map[string]bool{
+	"repository_dispatch": true,
+	"workflow_dispatch":   true,
+	"workflow_call":       true,
+	"workflow_run":        true,
+}
+
+// WorkflowGraphNode is an agentic workflow in the graph.
+type WorkflowGraphNode struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	File        string   `json:"file"`
+	Triggers    []string `json:"triggers"`
+	SafeOutputs []string `json:"safe_outputs"`
+	// CustomToken is true when safe outputs use a github-token or GitHub App, so the
+	// events they cause can start other workflows.
+	CustomToken bool `json:"custom_token,omitempty"`
+
+	listens         []string
+	listenTypes     map[string][]string
+	dispatches      []string
+	calls           []string
+	outputTokens    map[string]bool
+	workflowRunFrom []string
+}
+
+// WorkflowGraphEdge is a safe output of one workflow that can start another workflow.
+type WorkflowGraphEdge struct {
+	From       string `json:"from"`
+	To         string `json:"to"`
+	SafeOutput string `json:"safe_output"`
+	Event      string `json:"event"`
+	// Blocked is true when the event is caused by the GITHUB_TOKEN, which GitHub does
+	// not allow to start new workflow runs.
+	Blocked bool `json:"blocked,omitempty"`
+}
+
+// WorkflowGraph is the dependency graph of the agentic workflows in a repository.
+type WorkflowGraph struct {
+	Workflows []*WorkflowGraphNode `json:"workflows"`
+	Edges     []WorkflowGraphEdge  `json:"edges"`
+	// Cycles lists chains of workflows that can start each other in a loop.
+	Cycles [][]string `json:"cycles,omitempty"`
+}
+
+// buildWorkflowGraph reads the workflows in workflowsDir and links them together.
+func buildWorkflowGraph(workflowsDir string) (*WorkflowGraph, error) {
+	files, err := getMarkdownWorkflowFiles(workflowsDir)
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(files)
+
+	graph := &WorkflowGraph{Workflows: []*WorkflowGraphNode{}, Edges: []WorkflowGraphEdge{}}
+	for _, file := range files {
+		node, err := readWorkflowGraphNode(file)
+		if err != nil {
+			workflowGraphLog.Printf("Skipping %s: %v", file, err)
+			continue
+		}
+		graph.Workflows = append(graph.Workflows, node)
+	}
+
+	linkWorkflowGraph(graph)
+	graph.Cycles = findWorkflowGraphCycles(graph)
+
+	workflowGraphLog.Printf("Built workflow graph: workflows=%d, edges=%d, cycles=%d", len(graph.Workflows), len(graph.Edges), len(graph.Cycles))
+	return graph, nil
+}
+
+// readWorkflowGraphNode extracts the triggers and safe outputs of a workflow file.
+func readWorkflowGraphNode(file string) (*WorkflowGraphNode, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	result, err := parser.ExtractFrontmatterFromContent(string(content))
+	if err != nil {
+		return nil, err
+	}
+	return newWorkflowGraphNode(file, result), nil
+}
+
+// newWorkflowGraphNode builds a node from the parsed frontmatter of a workflow file.
+func newWorkflowGraphNode(file string, result *parser.FrontmatterResult) *WorkflowGraphNode {
+	node := &WorkflowGraphNode{
+		ID:           extractWorkflowNameFromPath(file),
+		File:         filepath.ToSlash(file),
+		Triggers:     []string{},
+		SafeOutputs:  []string{},
+		outputTokens: make(map[string]bool),
+		listenTypes:  make(map[string][]string),
+	}
+	if name, ok := result.Frontmatter["name"].(string); ok && strings.TrimSpace(name) != "" {
+		node.Name = strings.TrimSpace(name)
+	} else if name, err := parser.ExtractWorkflowNameFromMarkdownBody(result.Markdown, file); err == nil {
+		node.Name = name
+	}
+	if node.Name == "" {
+		node.Name = node.ID
+	}
+
+	addWorkflowGraphTriggers(node, result.Frontmatter["on"])
+	if safeOutputs, ok := result.Frontmatter["safe-outputs"].(map[string]any); ok {
+		addWorkflowGraphSafeOutputs(node, safeOutputs)
+	}
+	return node
+}
+
+// addWorkflowGraphTriggers records the trigger names of the on: section and the events
+// each trigger listens to.
+func addWorkflowGraphTriggers(node *WorkflowGraphNode, on any) {
+	var triggers []string
+	switch value := on.(type) {
+	case string:
+		triggers = append(triggers, value)
+	case []any:
+		for _, item := range value {
+			if name, ok := item.(string); ok {
+				triggers = append(triggers, name)
+			}
+		}
+	case map[string]any:
+		for key, config := range value {
+			if nonEventOnKeys[key] || strings.Contains(key, "-") {
+				continue
+			}
+			triggers = append(triggers, key)
+			if configMap, ok := config.(map[string]any); ok {
+				if types, ok := configMap["types"].([]any); ok {
+					node.listenTypes[key] = appendDispatchWorkflowNames(nil, types)
+				}
+			}
+			if key == "workflow_run" {
+				if configMap, ok := config.(map[string]any); ok {
+					if workflows, ok := configMap["workflows"].([]any); ok {
+						node.workflowRunFrom = appendDispatchWorkflowNames(node.workflowRunFrom, workflows)
+					}
+				}
+			}
+		}
+	}
+	slices.Sort(triggers)
+
+	var listens []string
+	for _, trigger := range triggers {
+		trigger = strings.TrimSpace(trigger)
+		if trigger == "" {
+			continue
+		}
+		node.Triggers = append(node.Triggers, trigger)
+		if events, ok := commandTriggerEvents[trigger]; ok {
+			listens = append(listens, events...)
+		} else {
+			listens = append(listens, trigger)
+		}
+	}
+	// Commands only match new or edited text, or an added label, unless the workflow also
+	// listens to the event directly.
+	for _, trigger := range node.Triggers {
+		types := []string{"opened", "created", "edited"}
+		if trigger == "label_command" {
+			types = []string{"labeled"}
+		}
+		for _, event := range commandTriggerEvents[trigger] {
+			if !slices.Contains(node.Triggers, event) {
+				node.listenTypes[event] = sliceutil.MergeUnique(node.listenTypes[event], types...)
+			}
+		}
+	}
+	node.listens = sliceutil.Deduplicate(listens)
+}
+
+// addWorkflowGraphSafeOutputs records the safe outputs a workflow can produce, the
+// workflows it can dispatch or call, and whether each output uses a custom token.
+func addWorkflowGraphSafeOutputs(node *WorkflowGraphNode, safeOutputs map[string]any) {
+	node.CustomToken = hasCustomSafeOutputToken(safeOutputs)
+
+	knownOutputs := make(map[string]bool)
+	for _, option := range workflow.GetSafeOutputToolOptions() {
+		knownOutputs[option.Key] = true
+	}
+
+	for _, key := range sliceutil.SortedKeys(safeOutputs) {
+		_, emitsEvents := safeOutputEvents[key]
+		if !knownOutputs[key] && !emitsEvents && key != "dispatch-workflow" && key != "call-workflow" {
+			continue
+		}
+		node.SafeOutputs = append(node.SafeOutputs, key)
+
+		config, _ := safeOutputs[key].(map[string]any)
+		node.outputTokens[key] = node.CustomToken || hasCustomSafeOutputToken(config)
+
+		var workflows []string
+		switch value := safeOutputs[key].(type) {
+		case []any:
+			workflows = appendDispatchWorkflowNames(nil, value)
+		case map[string]any:
+			if list, ok := value["workflows"].([]any); ok {
+				workflows = appendDispatchWorkflowNames(nil, list)
+			}
+		}
+		switch key {
+		case "dispatch-workflow":
+			node.dispatches = dedupeDispatchWorkflowNames(workflows)
+		case "call-workflow":
+			node.calls = dedupeDispatchWorkflowNames(workflows)
+		}
+	}
+}
+
+// hasCustomSafeOutputToken reports whether a safe-outputs config sets a github-token or
+// a GitHub App.
+func hasCustomSafeOutputToken(config map[string]any) bool {
+	if config == nil {
+		return false
+	}
+	if token, ok := config["github-token"].(string); ok && strings.TrimSpace(token) != "" {
+		return true
+	}
+	_, hasApp := config["github-app"]
+	return hasApp
+}
+
+// linkWorkflowGraph adds an edge for every safe output that can start another workflow.
+func linkWorkflowGraph(graph *WorkflowGraph) {
+	byID := make(map[string]*WorkflowGraphNode, len(graph.Workflows))
+	for _, node := range graph.Workflows {
+		byID[node.ID] = node
+	}
+
+	for _, from := range graph.Workflows {
+		for _, output := range from.SafeOutputs {
+			switch output {
+			case "dispatch-workflow":
+				for _, target := range from.dispatches {
+					if to, ok := byID[strings.TrimSuffix(target, ".md")]; ok {
+						graph.Edges = append(graph.Edges, WorkflowGraphEdge{From: from.ID, To: to.ID, SafeOutput: output, Event: "workflow_dispatch"})
+					}
+				}
+			case "call-workflow":
+				for _, target := range from.calls {
+					if to, ok := byID[strings.TrimSuffix(target, ".md")]; ok {
+						graph.Edges = append(graph.Edges, WorkflowGraphEdge{From: from.ID, To: to.ID, SafeOutput: output, Event: "workflow_call"})
+					}
+				}
+			default:
+				for _, event := range safeOutputEvents[output] {
+					for _, to := range graph.Workflows {
+						if workflowGraphListensTo(to, event, safeOutputActivityTypes[output]) {
+							graph.Edges = append(graph.Edges, WorkflowGraphEdge{
+								From:       from.ID,
+								To:         to.ID,
+								SafeOutput: output,
+								Event:      event,
+								Blocked:    !from.outputTokens[output] && !eventsNotBlockedByGitHubToken[event],
+							})
+						}
+					}
+				}
+			}
+		}
+
+		// A workflow_run trigger starts a workflow when the named workflow completes.
+		for _, to := range graph.Workflows {
+			if slices.Contains(to.workflowRunFrom, from.Name) {
+				graph.Edges = append(graph.Edges, WorkflowGraphEdge{From: from.ID, To: to.ID, Event: "workflow_run"})
+			}
+		}
+	}
+}
+
+// workflowGraphListensTo reports whether a workflow is triggered by an event with the given
+// activity type, honoring the types: filter of its trigger.
+func workflowGraphListensTo(node *WorkflowGraphNode, event, activityType string) bool {
+	if !slices.Contains(node.listens, event) {
+		return false
+	}
+	types := node.listenTypes[event]
+	return activityType == "" || len(types) == 0 || slices.Contains(types, activityType)
+}
+
+// findWorkflowGraphCycles returns the chains of workflows that can start each other in a
+// loop through edges that are not blocked. Each cycle is reported once, starting from its
+// smallest workflow ID.
+func findWorkflowGraphCycles(graph *WorkflowGraph) [][]string {
+	next := make(map[string][]string)
+	for _, edge := range graph.Edges {
+		if !edge.Blocked && !slices.Contains(next[edge.From], edge.To) {
+			next[edge.From] = append(next[edge.From], edge.To)
+		}
+	}
+
+	var cycles [][]string
+	seen := make(map[string]bool)
+	var visit func(path []string)
+	visit = func(path []string) {
+		start, current := path[0], path[len(path)-1]
+		for _, to := range next[current] {
+			switch {
+			case to == start:
+				key := strings.Join(path, ">")
+				if !seen[key] {
+					seen[key] = true
+					cycles = append(cycles, slices.Clone(path))
+				}
+			case to > start && !slices.Contains(path, to):
+				visit(append(path, to))
+			}
+		}
+	}
+	for _, node := range graph.Workflows {
+		visit([]string{node.ID})
+	}
+	return cycles
+}
+
+// renderWorkflowGraphMermaid renders the graph as a Mermaid flowchart. Edges that GitHub
+// blocks because the GITHUB_TOKEN caused the event are dotted.
+func renderWorkflowGraphMermaid(graph *WorkflowGraph) string {
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+
+	triggerIDs := make(map[string]string)
+	for _, node := range graph.Workflows {
+		for _, trigger := range node.Triggers {
+			triggerIDs[trigger] = "on_" + workflowGraphIdentifier(trigger)
+		}
+	}
+	for _, trigger := range sliceutil.SortedKeys(triggerIDs) {
+		fmt.Fprintf(&b, "    %s([%s])\n", triggerIDs[trigger], mermaidGraphLabel(trigger))
+	}
+
+	for _, node := range graph.Workflows {
+		nodeID := "wf_" + workflowGraphIdentifier(node.ID)
+		fmt.Fprintf(&b, "    %s[%s]\n", nodeID, mermaidGraphLabel(node.Name))
+		for _, trigger := range node.Triggers {
+			fmt.Fprintf(&b, "    %s --> %s\n", triggerIDs[trigger], nodeID)
+		}
+		for _, output := range node.SafeOutputs {
+			fmt.Fprintf(&b, "    %s[/%s/]\n", workflowGraphOutputID(node.ID, output), mermaidGraphLabel(output))
+			fmt.Fprintf(&b, "    %s --> %s\n", nodeID, workflowGraphOutputID(node.ID, output))
+		}
+	}
+
+	for _, edge := range graph.Edges {
+		from := "wf_" + workflowGraphIdentifier(edge.From)
+		if edge.SafeOutput != "" {
+			from = workflowGraphOutputID(edge.From, edge.SafeOutput)
+		}
+		to := "wf_" + workflowGraphIdentifier(edge.To)
+		if edge.Blocked {
+			fmt.Fprintf(&b, "    %s -. %s .-> %s\n", from, mermaidGraphLabel(edge.Event), to)
+		} else {
+			fmt.Fprintf(&b, "    %s -- %s --> %s\n", from, mermaidGraphLabel(edge.Event), to)
+		}
+	}
+	return b.String()
+}
+
+// renderWorkflowGraphDOT renders the graph in the Graphviz DOT language. Edges that
+// GitHub blocks because the GITHUB_TOKEN caused the event are dotted.
+func renderWorkflowGraphDOT(graph *WorkflowGraph) string {
+	var b strings.Builder
+	b.WriteString("digraph workflows {\n")
+	b.WriteString("    rankdir=LR;\n")
+
+	triggers := make(map[string]bool)
+	for _, node := range graph.Workflows {
+		for _, trigger := range node.Triggers {
+			triggers[trigger] = true
+		}
+	}
+	for _, trigger := range sliceutil.SortedKeys(triggers) {
+		fmt.Fprintf(&b, "    %q [label=%q, shape=ellipse];\n", "on:"+trigger, trigger)
+	}
+
+	for _, node := range graph.Workflows {
+		fmt.Fprintf(&b, "    %q [label=%q, shape=box];\n", node.ID, node.Name)
+		for _, trigger := range node.Triggers {
+			fmt.Fprintf(&b, "    %q -> %q;\n", "on:"+trigger, node.ID)
+		}
+		for _, output := range node.SafeOutputs {
+			outputID := node.ID + "/" + output
+			fmt.Fprintf(&b, "    %q [label=%q, shape=parallelogram];\n", outputID, output)
+			fmt.Fprintf(&b, "    %q -> %q;\n", node.ID, outputID)
+		}
+	}
+
+	for _, edge := range graph.Edges {
+		from := edge.From
+		if edge.SafeOutput != "" {
+			from = edge.From + "/" + edge.SafeOutput
+		}
+		style := ""
+		if edge.Blocked {
+			style = ", style=dotted"
+		}
+		fmt.Fprintf(&b, "    %q -> %q [label=%q%s];\n", from, edge.To, edge.Event, style)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func workflowGraphOutputID(workflowID, output string) string {
+	return "out_" + workflowGraphIdentifier(workflowID) + "__" + workflowGraphIdentifier(output)
+}
+
+// mermaidGraphLabel quotes a Mermaid label, escaping the double quotes it contains.
+func mermaidGraphLabel(label string) string {
+	return `"` + strings.ReplaceAll(label, `"`, "#quot;") + `"`
+}
+
+// workflowGraphIdentifier turns a name into a Mermaid node identifier.
+func workflowGraphIdentifier(name string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
+}
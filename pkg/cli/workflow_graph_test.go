@@ -0,0 +1,125 @@
+//go:build !integration
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeGraphTestWorkflows(t *testing.T, workflows map[string]string) string {
+	t.Helper()
+	dir := testutil.TempDir(t, "graph-*")
+	for name, content := range workflows {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644), "should write %s", name)
+	}
+	return dir
+}
+
+func TestBuildWorkflowGraph(t *testing.T) {
+	dir := writeGraphTestWorkflows(t, map[string]string{
+		"triage.md": `---
+on:
+  issues:
+    types: [opened]
+  roles: [admin]
+safe-outputs:
+  add-labels:
+  add-comment:
+  dispatch-workflow: [fixer]
+---
+# Issue Triage
+`,
+		"fixer.md": `---
+on: workflow_dispatch
+safe-outputs:
+  github-token: ${{ secrets.PAT }}
+  create-issue:
+  create-pull-request:
+---
+# Fixer
+`,
+		"review.md": `---
+on:
+  slash_command: review
+safe-outputs:
+  submit-pull-request-review:
+---
+# Reviewer
+`,
+		"README.md": "# Workflows\n",
+	})
+
+	graph, err := buildWorkflowGraph(dir)
+	require.NoError(t, err, "should build the graph")
+	require.Len(t, graph.Workflows, 3, "README.md is not a workflow")
+
+	triage := graph.Workflows[2]
+	assert.Equal(t, "Issue Triage", triage.Name, "name should come from the H1 header")
+	assert.Equal(t, []string{"issues"}, triage.Triggers, "trigger settings such as roles are not triggers")
+	assert.Equal(t, []string{"add-comment", "add-labels", "dispatch-workflow"}, triage.SafeOutputs, "should list the safe outputs")
+	assert.False(t, triage.CustomToken, "triage uses the GITHUB_TOKEN")
+	assert.True(t, graph.Workflows[0].CustomToken, "fixer sets safe-outputs.github-token")
+
+	assert.Equal(t, []WorkflowGraphEdge{
+		{From: "fixer", To: "review", SafeOutput: "create-issue", Event: "issues"},
+		{From: "fixer", To: "triage", SafeOutput: "create-issue", Event: "issues"},
+		{From: "fixer", To: "review", SafeOutput: "create-pull-request", Event: "pull_request"},
+		{From: "triage", To: "review", SafeOutput: "add-comment", Event: "issue_comment", Blocked: true},
+		{From: "triage", To: "review", SafeOutput: "add-comment", Event: "discussion_comment", Blocked: true},
+		{From: "triage", To: "fixer", SafeOutput: "dispatch-workflow", Event: "workflow_dispatch"},
+	}, graph.Edges, "labels do not match the opened filter or slash commands; GITHUB_TOKEN events are blocked")
+	assert.Equal(t, [][]string{{"fixer", "triage"}}, graph.Cycles, "fixer and triage start each other")
+}
+
+func TestBuildWorkflowGraph_WorkflowRun(t *testing.T) {
+	dir := writeGraphTestWorkflows(t, map[string]string{
+		"build.md": "---\non: push\n---\n# Nightly Build\n",
+		"report.md": `---
+on:
+  workflow_run:
+    workflows: ["Nightly Build"]
+    types: [completed]
+---
+# Report
+`,
+	})
+
+	graph, err := buildWorkflowGraph(dir)
+	require.NoError(t, err, "should build the graph")
+	assert.Equal(t, []WorkflowGraphEdge{{From: "build", To: "report", Event: "workflow_run"}}, graph.Edges, "workflow_run should link to the named workflow")
+	assert.Empty(t, graph.Cycles, "there is no cycle")
+}
+
+func TestRenderWorkflowGraph(t *testing.T) {
+	graph := &WorkflowGraph{
+		Workflows: []*WorkflowGraphNode{
+			{ID: "a", Name: `Say "hi"`, Triggers: []string{"issues"}, SafeOutputs: []string{"add-comment"}},
+			{ID: "b", Name: "B", Triggers: []string{"issue_comment"}},
+		},
+		Edges: []WorkflowGraphEdge{{From: "a", To: "b", SafeOutput: "add-comment", Event: "issue_comment", Blocked: true}},
+	}
+
+	mermaid := renderWorkflowGraphMermaid(graph)
+	assert.Contains(t, mermaid, "flowchart LR\n", "should be a Mermaid flowchart")
+	assert.Contains(t, mermaid, `on_issues(["issues"])`, "should declare trigger nodes")
+	assert.Contains(t, mermaid, `wf_a["Say #quot;hi#quot;"]`, "should escape quotes in names")
+	assert.Contains(t, mermaid, "wf_a --> out_a__add_comment", "should link workflows to their safe outputs")
+	assert.Contains(t, mermaid, `out_a__add_comment -. "issue_comment" .-> wf_b`, "blocked links should be dotted")
+
+	dot := renderWorkflowGraphDOT(graph)
+	assert.Contains(t, dot, "digraph workflows {", "should be a DOT digraph")
+	assert.Contains(t, dot, `"on:issues" -> "a";`, "should link triggers to workflows")
+	assert.Contains(t, dot, `"a/add-comment" -> "b" [label="issue_comment", style=dotted];`, "blocked links should be dotted")
+}
+
+func TestRunGraph_UnknownFormat(t *testing.T) {
+	err := RunGraph(t.TempDir(), "svg")
+	require.Error(t, err, "unknown formats should be rejected")
+	assert.Contains(t, err.Error(), "expected mermaid, dot or json", "should list the formats")
+}
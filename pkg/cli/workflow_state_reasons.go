@@ -0,0 +1,196 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/workflow"
+)
+
+var workflowStateReasonsLog = logger.New("cli:workflow_state_reasons")
+
+// workflowStateReasonsVariable is the repository Actions variable that records why
+// workflows were disabled or enabled with `disable --reason` / `enable --reason`.
+// It holds a JSON object keyed by workflow ID so `status` can read every reason with a
+// single API call.
+const workflowStateReasonsVariable = "GH_AW_WORKFLOW_STATE_REASONS"
+
+var getWorkflowStateReasonsFn = getWorkflowStateReasons
+var setWorkflowStateReasonsFn = setWorkflowStateReasons
+
+// WorkflowStateReason records the reason given for the last enable or disable of a workflow.
+type WorkflowStateReason struct {
+	Action string `json:"action"` // "enable" or "disable"
+	Reason string `json:"reason"`
+	Actor  string `json:"actor,omitempty"`
+	At     string `json:"at"` // RFC 3339 timestamp
+}
+
+// String formats the reason for the status table, e.g. "runaway costs (by octocat, 2026-10-15)".
+func (r WorkflowStateReason) String() string {
+	var details []string
+	if r.Actor != "" {
+		details = append(details, "by "+r.Actor)
+	}
+	if at, err := time.Parse(time.RFC3339, r.At); err == nil {
+		details = append(details, at.Format(time.DateOnly))
+	}
+	if len(details) == 0 {
+		return r.Reason
+	}
+	return fmt.Sprintf("%s (%s)", r.Reason, strings.Join(details, ", "))
+}
+
+// appliesTo reports whether the recorded reason still explains the workflow state, so
+// a disable reason is not shown for a workflow that was re-enabled from the GitHub UI.
+func (r WorkflowStateReason) appliesTo(state string) bool {
+	switch r.Action {
+	case "disable":
+		return state == "disabled"
+	case "enable":
+		return state == "active"
+	}
+	return false
+}
+
+// getWorkflowStateReasons reads the recorded reasons of a repository. A missing
+// variable yields an empty map.
+func getWorkflowStateReasons(repoOverride string) (map[string]WorkflowStateReason, error) {
+	args := []string{"variable", "list", "--json", "name,value"}
+	if repoOverride != "" {
+		args = append(args, "--repo", repoOverride)
+	}
+	output, err := workflow.RunGH("Reading workflow state reasons...", args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", workflowStateReasonsVariable, err)
+	}
+	var variables []struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	}
+	if err := json.Unmarshal(output, &variables); err != nil {
+		return nil, fmt.Errorf("failed to parse repository variables: %w", err)
+	}
+	for _, variable := range variables {
+		if variable.Name == workflowStateReasonsVariable {
+			return parseWorkflowStateReasons(variable.Value)
+		}
+	}
+	return map[string]WorkflowStateReason{}, nil
+}
+
+// parseWorkflowStateReasons decodes the value of the workflow state reasons variable.
+func parseWorkflowStateReasons(value string) (map[string]WorkflowStateReason, error) {
+	reasons := map[string]WorkflowStateReason{}
+	if strings.TrimSpace(value) == "" {
+		return reasons, nil
+	}
+	if err := json.Unmarshal([]byte(value), &reasons); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", workflowStateReasonsVariable, err)
+	}
+	return reasons, nil
+}
+
+// setWorkflowStateReasons writes the recorded reasons of a repository.
+func setWorkflowStateReasons(repoOverride string, reasons map[string]WorkflowStateReason) error {
+	value, err := json.Marshal(reasons)
+	if err != nil {
+		return fmt.Errorf("failed to marshal workflow state reasons: %w", err)
+	}
+	args := []string{"variable", "set", workflowStateReasonsVariable, "--body", string(value)}
+	if repoOverride != "" {
+		args = append(args, "--repo", repoOverride)
+	}
+	if output, err := workflow.RunGHCombined("Recording workflow state reasons...", args...); err != nil {
+		return fmt.Errorf("failed to write %s: %w: %s", workflowStateReasonsVariable, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// applyWorkflowStateReasons records reason for each workflow after action and reports
+// whether the map changed. An empty reason clears earlier entries so a stale reason is
+// not left behind by a later toggle without one.
+func applyWorkflowStateReasons(reasons map[string]WorkflowStateReason, names []string, action, reason, actor string, now time.Time) bool {
+	changed := false
+	for _, name := range names {
+		if reason == "" {
+			if _, ok := reasons[name]; ok {
+				delete(reasons, name)
+				changed = true
+			}
+			continue
+		}
+		reasons[name] = WorkflowStateReason{Action: action, Reason: reason, Actor: actor, At: now.UTC().Format(time.RFC3339)}
+		changed = true
+	}
+	return changed
+}
+
+// recordWorkflowStateReasons updates the recorded reasons for the workflows that were
+// toggled. Without a reason it only writes when an earlier entry needs clearing, so
+// plain enable/disable keeps working for users who cannot manage repository variables.
+func recordWorkflowStateReasons(repoOverride string, names []string, action, reason string) error {
+	reasons, err := getWorkflowStateReasonsFn(repoOverride)
+	if err != nil {
+		if reason == "" {
+			workflowStateReasonsLog.Printf("Skipping reason cleanup: %v", err)
+			return nil
+		}
+		return err
+	}
+	actor := ""
+	if reason != "" {
+		if output, err := workflow.RunGH("Fetching GitHub username...", "api", "user", "--jq", ".login"); err == nil {
+			actor = strings.TrimSpace(string(output))
+		}
+	}
+	if !applyWorkflowStateReasons(reasons, names, action, reason, actor, time.Now()) {
+		return nil
+	}
+	workflowStateReasonsLog.Printf("Recording %s reason for %d workflow(s)", action, len(names))
+	if err := setWorkflowStateReasonsFn(repoOverride, reasons); err != nil {
+		if reason == "" {
+			workflowStateReasonsLog.Printf("Skipping reason cleanup: %v", err)
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// expandWorkflowNamePatterns replaces glob patterns such as "triage-*" with the matching
+// workflow IDs from available. Plain names, and patterns that match nothing, are kept
+// as-is so they are reported as not found.
+func expandWorkflowNamePatterns(names []string, available []string) []string {
+	var expanded []string
+	for _, name := range names {
+		if !strings.ContainsAny(name, "*?[") {
+			if !slices.Contains(expanded, name) {
+				expanded = append(expanded, name)
+			}
+			continue
+		}
+		var matches []string
+		for _, candidate := range available {
+			if matched, err := path.Match(name, candidate); err == nil && matched {
+				matches = append(matches, candidate)
+			}
+		}
+		if len(matches) == 0 {
+			expanded = append(expanded, name)
+			continue
+		}
+		slices.Sort(matches)
+		for _, match := range matches {
+			if !slices.Contains(expanded, match) {
+				expanded = append(expanded, match)
+			}
+		}
+	}
+	return expanded
+}
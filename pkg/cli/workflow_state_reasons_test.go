@@ -0,0 +1,93 @@
+//go:build !integration
+
+package cli
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandWorkflowNamePatterns(t *testing.T) {
+	available := []string{"triage-issues", "ci-doctor", "triage-prs", "daily-plan"}
+	tests := []struct {
+		name     string
+		names    []string
+		expected []string
+	}{
+		{name: "plain names", names: []string{"ci-doctor", "daily-plan"}, expected: []string{"ci-doctor", "daily-plan"}},
+		{name: "glob", names: []string{"triage-*"}, expected: []string{"triage-issues", "triage-prs"}},
+		{name: "glob and overlapping name", names: []string{"triage-prs", "triage-*"}, expected: []string{"triage-prs", "triage-issues"}},
+		{name: "unmatched glob is kept", names: []string{"deploy-*"}, expected: []string{"deploy-*"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, expandWorkflowNamePatterns(tt.names, available), "unexpected expansion")
+		})
+	}
+}
+
+func TestApplyWorkflowStateReasons(t *testing.T) {
+	now := time.Date(2026, 10, 15, 9, 30, 0, 0, time.UTC)
+	reasons := map[string]WorkflowStateReason{
+		"ci-doctor": {Action: "disable", Reason: "flaky", At: "2026-10-01T00:00:00Z"},
+	}
+
+	changed := applyWorkflowStateReasons(reasons, []string{"triage"}, "disable", "posting duplicate comments", "octocat", now)
+	assert.True(t, changed, "a new reason should change the map")
+	assert.Equal(t, WorkflowStateReason{Action: "disable", Reason: "posting duplicate comments", Actor: "octocat", At: "2026-10-15T09:30:00Z"}, reasons["triage"], "unexpected recorded reason")
+	assert.Equal(t, "posting duplicate comments (by octocat, 2026-10-15)", reasons["triage"].String(), "unexpected formatted reason")
+
+	assert.False(t, applyWorkflowStateReasons(reasons, []string{"daily-plan"}, "enable", "", "", now), "clearing a missing entry should not change the map")
+	assert.True(t, applyWorkflowStateReasons(reasons, []string{"ci-doctor"}, "enable", "", "", now), "toggling without a reason should clear the old entry")
+	assert.NotContains(t, reasons, "ci-doctor", "stale reason should be removed")
+}
+
+func TestWorkflowStateReasonAppliesTo(t *testing.T) {
+	disabled := WorkflowStateReason{Action: "disable", Reason: "incident"}
+	assert.True(t, disabled.appliesTo("disabled"), "disable reason should apply to a disabled workflow")
+	assert.False(t, disabled.appliesTo("active"), "disable reason should not apply after re-enabling elsewhere")
+	assert.True(t, WorkflowStateReason{Action: "enable"}.appliesTo("active"), "enable reason should apply to an active workflow")
+}
+
+func TestParseWorkflowStateReasons(t *testing.T) {
+	reasons, err := parseWorkflowStateReasons(`{"triage":{"action":"disable","reason":"incident","at":"2026-10-15T09:30:00Z"}}`)
+	require.NoError(t, err, "valid value should parse")
+	assert.Equal(t, "incident", reasons["triage"].Reason, "unexpected reason")
+
+	reasons, err = parseWorkflowStateReasons("")
+	require.NoError(t, err, "empty value should parse")
+	assert.Empty(t, reasons, "empty value should yield no reasons")
+
+	_, err = parseWorkflowStateReasons("not json")
+	require.Error(t, err, "invalid value should fail")
+}
+
+func TestAttachWorkflowStateReasons(t *testing.T) {
+	original := getWorkflowStateReasonsFn
+	t.Cleanup(func() { getWorkflowStateReasonsFn = original })
+	getWorkflowStateReasonsFn = func(string) (map[string]WorkflowStateReason, error) {
+		return map[string]WorkflowStateReason{
+			"triage":    {Action: "disable", Reason: "incident"},
+			"ci-doctor": {Action: "disable", Reason: "flaky"},
+		}, nil
+	}
+
+	statuses := []WorkflowStatus{
+		{WorkflowListItem: WorkflowListItem{Workflow: "triage"}, Status: "disabled"},
+		{WorkflowListItem: WorkflowListItem{Workflow: "ci-doctor"}, Status: "active"},
+	}
+	attachWorkflowStateReasons(statuses, "")
+	assert.Equal(t, "incident", statuses[0].Reason, "reason should be shown for the disabled workflow")
+	assert.Empty(t, statuses[1].Reason, "reason should be hidden once the workflow was re-enabled")
+
+	getWorkflowStateReasonsFn = func(string) (map[string]WorkflowStateReason, error) {
+		return nil, errors.New("HTTP 403")
+	}
+	statuses[0].Reason = ""
+	attachWorkflowStateReasons(statuses, "")
+	assert.Empty(t, statuses[0].Reason, "unreadable reasons should be skipped")
+}
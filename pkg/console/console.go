@@ -62,6 +62,9 @@ func applyStyleWithTTY(style lipgloss.Style, text string, ttyCheck func() bool)
 // FormatError formats a CompilerError with Rust-like rendering
 func FormatError(err CompilerError) string {
 	consoleLog.Printf("Formatting error: type=%s, file=%s, line=%d", err.Type, err.Position.File, err.Position.Line)
+	if GitHubAnnotationsEnabled() {
+		return FormatGitHubAnnotation(err)
+	}
 	var output strings.Builder
 
 	// Get style based on error type
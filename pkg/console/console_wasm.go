@@ -14,6 +14,9 @@ func isTTY() bool {
 }
 
 func FormatError(err CompilerError) string {
+	if GitHubAnnotationsEnabled() {
+		return FormatGitHubAnnotation(err)
+	}
 	var output strings.Builder
 
 	var prefix string
@@ -0,0 +1,73 @@
+package console
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+// githubAnnotations switches FormatError to GitHub Actions workflow commands.
+var githubAnnotations atomic.Bool
+
+// SetGitHubAnnotations configures FormatError to emit GitHub Actions workflow commands
+// (::error file=...,line=...::message) instead of IDE-style diagnostics, so that running
+// a command in a pull request check annotates the files in the diff.
+func SetGitHubAnnotations(enabled bool) {
+	githubAnnotations.Store(enabled)
+}
+
+// GitHubAnnotationsEnabled reports whether FormatError emits GitHub Actions workflow commands.
+func GitHubAnnotationsEnabled() bool {
+	return githubAnnotations.Load()
+}
+
+// FormatGitHubAnnotation formats a compiler error as a single-line GitHub Actions workflow
+// command. Warnings map to ::warning, info messages to ::notice, and everything else to ::error.
+// The line and column are only included when the position is known.
+func FormatGitHubAnnotation(err CompilerError) string {
+	command := "error"
+	switch err.Type {
+	case "warning":
+		command = "warning"
+	case "info":
+		command = "notice"
+	}
+
+	var properties []string
+	if err.Position.File != "" {
+		properties = append(properties, "file="+escapeAnnotationProperty(ToRelativePath(err.Position.File)))
+		if err.Position.Line > 0 {
+			properties = append(properties, fmt.Sprintf("line=%d", err.Position.Line))
+			if err.Position.Column > 0 {
+				properties = append(properties, fmt.Sprintf("col=%d", err.Position.Column))
+			}
+		}
+	}
+
+	message := strings.TrimRight(err.Message, "\n")
+	if err.Hint != "" {
+		message += "\n" + err.Hint
+	}
+
+	var output strings.Builder
+	output.WriteString("::" + command)
+	if len(properties) > 0 {
+		output.WriteString(" " + strings.Join(properties, ","))
+	}
+	output.WriteString("::" + escapeAnnotationData(message) + "\n")
+	return output.String()
+}
+
+// escapeAnnotationData escapes a workflow command message so that it stays on one line.
+func escapeAnnotationData(value string) string {
+	value = strings.ReplaceAll(value, "%", "%25")
+	value = strings.ReplaceAll(value, "\r", "%0D")
+	return strings.ReplaceAll(value, "\n", "%0A")
+}
+
+// escapeAnnotationProperty escapes a workflow command property value.
+func escapeAnnotationProperty(value string) string {
+	value = escapeAnnotationData(value)
+	value = strings.ReplaceAll(value, ":", "%3A")
+	return strings.ReplaceAll(value, ",", "%2C")
+}
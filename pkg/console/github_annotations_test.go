@@ -0,0 +1,86 @@
+//go:build !integration
+
+package console
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatGitHubAnnotation(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      CompilerError
+		expected string
+	}{
+		{
+			name: "error with line and column",
+			err: CompilerError{
+				Position: ErrorPosition{File: "workflow.md", Line: 12, Column: 3},
+				Type:     "error",
+				Message:  "unknown property 'foo'",
+			},
+			expected: "::error file=workflow.md,line=12,col=3::unknown property 'foo'\n",
+		},
+		{
+			name: "warning maps to warning command",
+			err: CompilerError{
+				Position: ErrorPosition{File: "workflow.md", Line: 4},
+				Type:     "warning",
+				Message:  "uses /tmp",
+			},
+			expected: "::warning file=workflow.md,line=4::uses /tmp\n",
+		},
+		{
+			name: "info maps to notice command",
+			err: CompilerError{
+				Position: ErrorPosition{File: "workflow.md", Line: 1, Column: 1},
+				Type:     "info",
+				Message:  "note",
+			},
+			expected: "::notice file=workflow.md,line=1,col=1::note\n",
+		},
+		{
+			name: "unknown line is omitted",
+			err: CompilerError{
+				Position: ErrorPosition{File: "workflow.md"},
+				Type:     "error",
+				Message:  "failed",
+			},
+			expected: "::error file=workflow.md::failed\n",
+		},
+		{
+			name: "multi-line message and hint are escaped",
+			err: CompilerError{
+				Position: ErrorPosition{File: "a,b:c.md", Line: 2},
+				Type:     "error",
+				Message:  "100% wrong\nsecond line\n",
+				Hint:     "try again",
+			},
+			expected: "::error file=a%2Cb%3Ac.md,line=2::100%25 wrong%0Asecond line%0Atry again\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, FormatGitHubAnnotation(tt.err), "annotation should match")
+		})
+	}
+}
+
+func TestFormatErrorWithGitHubAnnotations(t *testing.T) {
+	err := CompilerError{
+		Position: ErrorPosition{File: "workflow.md", Line: 5, Column: 7},
+		Type:     "error",
+		Message:  "invalid syntax",
+	}
+
+	SetGitHubAnnotations(true)
+	t.Cleanup(func() { SetGitHubAnnotations(false) })
+	assert.True(t, GitHubAnnotationsEnabled(), "annotations should be enabled")
+	assert.Equal(t, "::error file=workflow.md,line=5,col=7::invalid syntax\n", FormatError(err), "FormatError should emit a workflow command")
+
+	SetGitHubAnnotations(false)
+	assert.NotContains(t, FormatError(err), "::error", "FormatError should use the default format when disabled")
+}
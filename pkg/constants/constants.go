@@ -180,7 +180,7 @@ const AWFDefaultCommand = "awf"
 const AWFLegacySecurityCommand = "sudo -E awf"
 
 // AWFProxyLogsDir is the default directory for AWF proxy logs
-const AWFProxyLogsDir = "/tmp/gh-aw/sandbox/firewall/logs"
+const AWFProxyLogsDir = TmpGhAwDirExpr + "/sandbox/firewall/logs"
 
 // AWFProxyLogsDirExpr is the host-side AWF proxy logs path resolved by Actions expression.
 const AWFProxyLogsDirExpr = GhAwRootDir + "/sandbox/firewall/logs"
@@ -191,7 +191,7 @@ const AWFProxyLogsDirShell = GhAwRootDirShell + "/sandbox/firewall/logs"
 // AWFAuditDir is the directory for AWF audit files (policy-manifest.json, squid.conf, docker-compose.redacted.yml).
 // These files are written by AWF when --audit-dir is specified and provide structured policy/configuration data
 // needed by the `awf logs audit` command for enriching log entries with policy rule matching.
-const AWFAuditDir = "/tmp/gh-aw/sandbox/firewall/audit"
+const AWFAuditDir = TmpGhAwDirExpr + "/sandbox/firewall/audit"
 
 // AWFAuditDirExpr is the host-side AWF audit dir path resolved by Actions expression.
 const AWFAuditDirExpr = GhAwRootDir + "/sandbox/firewall/audit"
@@ -203,7 +203,7 @@ const AWFAuditDirShell = GhAwRootDirShell + "/sandbox/firewall/audit"
 // The audit step runs after all pre-agent preparation (skills, agents, MCP servers) is
 // complete, capturing a file listing of agent-related directories before the AI engine
 // starts. This file is included in the agent artifact for post-run inspection.
-const PreAgentAuditFilePath = "/tmp/gh-aw/pre-agent-audit.txt"
+const PreAgentAuditFilePath = TmpGhAwDirExpr + "/pre-agent-audit.txt"
 
 // AWFConfigFilePath is the path inside the /tmp/gh-aw tree where the AWF config file
 // is copied so it can be included in the unified agent artifact.
@@ -211,7 +211,7 @@ const PreAgentAuditFilePath = "/tmp/gh-aw/pre-agent-audit.txt"
 // but that path is outside the /tmp/gh-aw/ root used by all other artifact paths.
 // A copy at this path is created before artifact upload so the config is available
 // for post-run analysis without mixing path roots in the artifact.
-const AWFConfigFilePath = "/tmp/gh-aw/awf-config.json"
+const AWFConfigFilePath = TmpGhAwDirExpr + "/awf-config.json"
 
 // AWFConfigFilePathExpr is the host-side AWF config path resolved by Actions expression.
 const AWFConfigFilePathExpr = GhAwRootDir + "/awf-config.json"
@@ -220,11 +220,19 @@ const AWFConfigFilePathExpr = GhAwRootDir + "/awf-config.json"
 // by the agent harness before exiting. It is co-located with other firewall observability
 // data under /tmp/gh-aw/sandbox/firewall/ so the existing chmod and artifact-upload steps
 // pick it up automatically.
-const AWFReflectFilePath = "/tmp/gh-aw/sandbox/firewall/awf-reflect.json"
+const AWFReflectFilePath = TmpGhAwDirExpr + "/sandbox/firewall/awf-reflect.json"
 
 // AWFReflectFilePathExpr is the host-side AWF /reflect output path resolved by Actions expression.
 const AWFReflectFilePathExpr = GhAwRootDir + "/sandbox/firewall/awf-reflect.json"
 
+// AWFBlockedRequestsFilePath is the structured record of the egress attempts denied by the
+// firewall proxy, written after the agent runs next to the proxy logs and read by
+// `gh aw logs` and `gh aw audit` to list the blocked domains of a run.
+const AWFBlockedRequestsFilePath = TmpGhAwDirExpr + "/sandbox/firewall/blocked-requests.json"
+
+// AWFBlockedRequestsFilePathExpr is the host-side blocked requests path resolved by Actions expression.
+const AWFBlockedRequestsFilePathExpr = GhAwRootDir + "/sandbox/firewall/blocked-requests.json"
+
 // FirewallAuditArtifactName is the legacy artifact name that was previously used for dedicated
 // firewall audit log uploads. Firewall audit/observability logs are now included in the unified
 // agent artifact. This constant is retained for backward compatibility when downloading artifacts
@@ -239,7 +247,7 @@ const DefaultMCPGatewayContainer = "ghcr.io/github/gh-aw-mcpg"
 
 // DefaultMCPGatewayPayloadDir is the default directory for MCP gateway payload files
 // This directory is shared between the agent container and MCP gateway for large payload exchange
-const DefaultMCPGatewayPayloadDir = "/tmp/gh-aw/mcp-payloads"
+const DefaultMCPGatewayPayloadDir = TmpGhAwDirExpr + "/mcp-payloads"
 
 // DefaultMCPGatewayPayloadSizeThreshold is the default size threshold (in bytes) for storing payloads to disk.
 // Payloads larger than this threshold are stored to disk, smaller ones are returned inline.
@@ -286,6 +294,14 @@ const GhAwRootDir = "${{ runner.temp }}/gh-aw"
 // This is shorter than the Actions expression and avoids expression-length issues.
 const GhAwRootDirShell = "${RUNNER_TEMP}/gh-aw"
 
+// CacheStagingDir is the directory in which actions/cache steps restore and save gh-aw
+// state. actions/cache versions each entry by its path list, so the path must be the same
+// in every run and job, which the scratch directory of the job is not; generated steps
+// copy the state between this directory and the scratch directory around the cache steps.
+// runner.temp belongs to one runner instance and is emptied before each job, so concurrent
+// runners on one host never share it.
+const CacheStagingDir = "${{ runner.temp }}/gh-aw-cache"
+
 // DefaultGhAwMount is the mount path for the gh-aw directory in containerized MCP servers
 // The gh-aw binary and supporting files are mounted read-only from the runner temp directory.
 // Uses the shell env var form since mounts are resolved in a shell context.
@@ -297,7 +313,7 @@ const DefaultGhBinaryMount = "/usr/bin/gh:/usr/bin/gh:ro"
 
 // DefaultTmpGhAwMount is the mount path for temporary gh-aw files in containerized MCP servers
 // Used for logs, cache, and other runtime data that needs read-write access
-const DefaultTmpGhAwMount = "/tmp/gh-aw:/tmp/gh-aw:rw"
+const DefaultTmpGhAwMount = TmpGhAwDirExpr + ":" + TmpGhAwDirExpr + ":rw"
 
 // DefaultWorkspaceMount is the mount path for the GitHub workspace directory in containerized MCP servers
 // Security: Uses GITHUB_WORKSPACE environment variable instead of template expansion to prevent template injection
@@ -440,39 +456,87 @@ const WorkflowsLockYmlGitAttributesEntry = WorkflowsLockYmlGlob + " linguist-gen
 // and engine harnesses during workflow execution. Paths here are always
 // in the /tmp/gh-aw tree regardless of whether the runner uses RUNNER_TEMP.
 // See also GhAwRootDir / GhAwRootDirShell for the host-side RUNNER_TEMP paths.
+//
+// Several self-hosted runner instances on one host share /tmp, so each job works
+// in its own scratch directory below /tmp/gh-aw, which setup.sh creates and
+// exports as TmpGhAwDirEnvVar. The path constants below are rooted at that
+// directory (TmpGhAwDirExpr) for use in generated steps; prompt text refers to it
+// through TmpGhAwDirPlaceholder and TmpGhAwDir is the logical root used in
+// artifact layouts.
 
 // TmpGhAwDir is the root /tmp/gh-aw directory (without trailing slash).
 const TmpGhAwDir = "/tmp/gh-aw"
 
+// TmpGhAwDirEnvVar is the environment variable through which setup.sh exports
+// the scratch directory of the current job,
+// /tmp/gh-aw/<run-id>-<run-attempt>/<job>-<random suffix>, to later steps.
+const TmpGhAwDirEnvVar = "GH_AW_TMP_DIR"
+
+// TmpGhAwDirExpr is the scratch directory of the current job in Actions expression
+// form. It is only defined after the setup step has run.
+const TmpGhAwDirExpr = "${{ env." + TmpGhAwDirEnvVar + " }}"
+
+// TmpGhAwDirShell is the scratch directory of the current job in shell
+// environment variable form.
+const TmpGhAwDirShell = "${" + TmpGhAwDirEnvVar + "}"
+
+// JobScopeEnvVar is the environment variable through which setup.sh exports an
+// identifier of the current job derived from its scratch directory,
+// <run-id>-<run-attempt>-<job>-<random suffix>. Like the directory it is unique on the
+// host, so it names host-wide resources of the job such as Docker networks.
+const JobScopeEnvVar = "GH_AW_JOB_SCOPE"
+
+// JobScopeExpr is the identifier of the current job in Actions expression form. It is
+// only defined after the setup step has run.
+const JobScopeExpr = "${{ env." + JobScopeEnvVar + " }}"
+
+// TmpGhAwDirPlaceholder stands for the scratch directory of the agent job in
+// prompt text. The prompt is built in the activation job, whose scratch directory
+// differs from the one of the agent job, so the agent job replaces the placeholder
+// with its own GH_AW_TMP_DIR before the engine reads the prompt.
+const TmpGhAwDirPlaceholder = "__GH_AW_TMP_DIR__"
+
 // TmpGhAwDirSlash is TmpGhAwDir with a trailing slash.
 // Use for path prefix comparisons (e.g. strings.HasPrefix).
 const TmpGhAwDirSlash = TmpGhAwDir + "/"
 
+// TmpGhAwDirExprSlash is TmpGhAwDirExpr with a trailing slash.
+const TmpGhAwDirExprSlash = TmpGhAwDirExpr + "/"
+
 // TmpGhAwAgentDir is the agent working directory in the /tmp/gh-aw tree.
-const TmpGhAwAgentDir = TmpGhAwDir + "/agent/"
+const TmpGhAwAgentDir = TmpGhAwDirExpr + "/agent/"
 
 // TmpGhAwAssetsDir is the directory the upload_assets job downloads the
 // safe-outputs assets artifact into. It is the single source of truth shared by
 // the download step (path:) and the upload_assets.cjs consumer script, so the
 // two never disagree on where staged assets live.
-const TmpGhAwAssetsDir = TmpGhAwDir + "/safeoutputs/assets"
+const TmpGhAwAssetsDir = TmpGhAwDirExpr + "/safeoutputs/assets"
 
 // TmpGhAwAssetsDirSlash is TmpGhAwAssetsDir with a trailing slash.
 const TmpGhAwAssetsDirSlash = TmpGhAwAssetsDir + "/"
 
 // AgentStdioLogPath is the path for capturing agent standard I/O log output.
-const AgentStdioLogPath = TmpGhAwDir + "/agent-stdio.log"
+// It holds both streams, interleaved line by line, with ANSI control sequences stripped.
+const AgentStdioLogPath = TmpGhAwDirExpr + "/agent-stdio.log"
+
+// AgentStdoutLogPath is the path for capturing the agent's standard output (agent content,
+// such as the JSON stream of stream-json engines) with ANSI control sequences stripped.
+const AgentStdoutLogPath = TmpGhAwDirExpr + "/agent-stdout.log"
+
+// AgentStderrLogPath is the path for capturing the agent's standard error (engine
+// diagnostics) with ANSI control sequences stripped.
+const AgentStderrLogPath = TmpGhAwDirExpr + "/agent-stderr.log"
 
 // AwPromptsFile is the runtime prompt file path populated by the setup action.
 // Engine harnesses read this file to pass the compiled prompt to the AI engine.
-const AwPromptsFile = TmpGhAwDir + "/aw-prompts/prompt.txt"
+const AwPromptsFile = TmpGhAwDirExpr + "/aw-prompts/prompt.txt"
 
 // AwPromptsFileShell is the runtime prompt file path in shell env-var form for host-side paths.
 const AwPromptsFileShell = GhAwRootDirShell + "/aw-prompts/prompt.txt"
 
 // TmpMcpConfigDir is the mcp-config directory in the /tmp/gh-aw tree.
 // Engines that require a writable MCP config directory (e.g. Codex) use this path.
-const TmpMcpConfigDir = TmpGhAwDir + "/mcp-config"
+const TmpMcpConfigDir = TmpGhAwDirExpr + "/mcp-config"
 
 // TmpMcpServersJsonPath is the MCP servers JSON config file in the /tmp tree.
 // Used by engines that resolve the config through the writable /tmp path.
@@ -482,69 +546,70 @@ const TmpMcpServersJsonPath = TmpMcpConfigDir + "/mcp-servers.json"
 const TmpMcpConfigLogsDir = TmpMcpConfigDir + "/logs/"
 
 // TmpMcpLogsDir is the MCP server logs root directory (with trailing slash).
-const TmpMcpLogsDir = TmpGhAwDir + "/mcp-logs/"
+const TmpMcpLogsDir = TmpGhAwDirExpr + "/mcp-logs/"
 
 // TmpMcpLogsSafeOutputsDir is the safe-outputs MCP server log directory.
-const TmpMcpLogsSafeOutputsDir = TmpGhAwDir + "/mcp-logs/safeoutputs"
+const TmpMcpLogsSafeOutputsDir = TmpGhAwDirExpr + "/mcp-logs/safeoutputs"
 
 // TmpMcpLogsPlaywrightDir is the Playwright MCP server log directory.
-const TmpMcpLogsPlaywrightDir = TmpGhAwDir + "/mcp-logs/playwright"
+const TmpMcpLogsPlaywrightDir = TmpGhAwDirExpr + "/mcp-logs/playwright"
 
 // TmpMcpLogsMount is the Docker volume mount spec for the MCP logs directory.
-const TmpMcpLogsMount = TmpGhAwDir + "/mcp-logs:" + TmpGhAwDir + "/mcp-logs"
+const TmpMcpLogsMount = TmpGhAwDirExpr + "/mcp-logs:" + TmpGhAwDirExpr + "/mcp-logs"
 
 // TmpMcpScriptsLogsDir is the mcp-scripts server log directory (with trailing slash).
-const TmpMcpScriptsLogsDir = TmpGhAwDir + "/mcp-scripts/logs/"
+const TmpMcpScriptsLogsDir = TmpGhAwDirExpr + "/mcp-scripts/logs/"
 
 // TmpRepoMemoryDir is the repo-memory data directory (with trailing slash).
-const TmpRepoMemoryDir = TmpGhAwDir + "/repo-memory/"
+const TmpRepoMemoryDir = TmpGhAwDirExpr + "/repo-memory/"
+
+// TmpContextReposDir is the directory additional repositories from the repos
+// frontmatter field are fetched into (with trailing slash).
+const TmpContextReposDir = TmpGhAwDirExpr + "/repos/"
 
 // TmpCommentMemoryDir is the comment-memory data directory (with trailing slash).
-const TmpCommentMemoryDir = TmpGhAwDir + "/comment-memory/"
+const TmpCommentMemoryDir = TmpGhAwDirExpr + "/comment-memory/"
 
 // TmpAwBundleGlob is the glob pattern for bundle files produced by the agent.
-const TmpAwBundleGlob = TmpGhAwDir + "/aw-*.bundle"
+const TmpAwBundleGlob = TmpGhAwDirExpr + "/aw-*.bundle"
 
 // TmpAwPatchGlob is the glob pattern for patch files produced by the agent.
-const TmpAwPatchGlob = TmpGhAwDir + "/aw-*.patch"
+const TmpAwPatchGlob = TmpGhAwDirExpr + "/aw-*.patch"
 
 // TmpGeminiClientErrorGlob is the glob for Gemini client error JSON diagnostic files.
-const TmpGeminiClientErrorGlob = TmpGhAwDir + "/gemini-client-error-*.json"
+const TmpGeminiClientErrorGlob = TmpGhAwDirExpr + "/gemini-client-error-*.json"
 
 // TmpAntigravityClientErrorGlob is the glob for Antigravity client error JSON diagnostic files.
-const TmpAntigravityClientErrorGlob = TmpGhAwDir + "/antigravity-client-error-*.json"
+const TmpAntigravityClientErrorGlob = TmpGhAwDirExpr + "/antigravity-client-error-*.json"
 
 // TmpPiAgentDir is the Pi engine agent working directory.
-const TmpPiAgentDir = TmpGhAwDir + "/pi-agent-dir"
+const TmpPiAgentDir = TmpGhAwDirExpr + "/pi-agent-dir"
 
 // ThreatDetectionLogPath is the threat detection engine log file path.
-const ThreatDetectionLogPath = TmpGhAwDir + "/threat-detection/detection.log"
+const ThreatDetectionLogPath = TmpGhAwDirExpr + "/threat-detection/detection.log"
 
 // ThreatDetectionDir is the threat detection working directory.
-const ThreatDetectionDir = TmpGhAwDir + "/threat-detection"
+const ThreatDetectionDir = TmpGhAwDirExpr + "/threat-detection"
 
 // ThreatDetectionResultPath is the structured verdict output file written by the
 // external threat-detect binary (features: gh-aw-detection: true). The binary writes
 // a four-field JSON verdict to this path via --output; threat-detect conclude reads it.
-const ThreatDetectionResultPath = TmpGhAwDir + "/threat-detection/detection_result.json"
+const ThreatDetectionResultPath = TmpGhAwDirExpr + "/threat-detection/detection_result.json"
 
 // TmpProxyLogsDir is the DIFC proxy logs directory (with trailing slash).
-const TmpProxyLogsDir = TmpGhAwDir + "/proxy-logs/"
+const TmpProxyLogsDir = TmpGhAwDirExpr + "/proxy-logs/"
 
 // TmpProxyTLSDir is the proxy TLS certificates sub-directory (with trailing slash).
-const TmpProxyTLSDir = TmpGhAwDir + "/proxy-logs/proxy-tls/"
+const TmpProxyTLSDir = TmpGhAwDirExpr + "/proxy-logs/proxy-tls/"
 
 // TmpProxyTLSCACert is the proxy TLS CA certificate file path.
-const TmpProxyTLSCACert = TmpGhAwDir + "/proxy-logs/proxy-tls/ca.crt"
+const TmpProxyTLSCACert = TmpGhAwDirExpr + "/proxy-logs/proxy-tls/ca.crt"
 
 // TmpDIFCProxyTLSCACert is the DIFC proxy TLS CA certificate file path.
-const TmpDIFCProxyTLSCACert = TmpGhAwDir + "/difc-proxy-tls/ca.crt"
-
-// TmpAwMcpLogsDir is the aw-mcp server logs directory.
-const TmpAwMcpLogsDir = TmpGhAwDir + "/aw-mcp/logs"
+const TmpDIFCProxyTLSCACert = TmpGhAwDirExpr + "/difc-proxy-tls/ca.crt"
 
 // TmpSandboxAgentLogsDir is the sandbox agent logs directory (with trailing slash).
-const TmpSandboxAgentLogsDir = TmpGhAwDir + "/sandbox/agent/logs/"
+const TmpSandboxAgentLogsDir = TmpGhAwDirExpr + "/sandbox/agent/logs/"
 
 // Shell and Actions expression form path constants
 //
@@ -119,10 +119,10 @@ func TestConstantValues(t *testing.T) {
 		{"DefaultMCPRegistryURL", string(DefaultMCPRegistryURL), "https://api.mcp.github.com/v0.1"},
 		{"OTELSentryEndpointSecretName", OTELSentryEndpointSecretName, "GH_AW_OTEL_SENTRY_ENDPOINT"},
 		{"AWFDefaultCommand", AWFDefaultCommand, "awf"},
-		{"AWFProxyLogsDir", AWFProxyLogsDir, "/tmp/gh-aw/sandbox/firewall/logs"},
-		{"AWFAuditDir", AWFAuditDir, "/tmp/gh-aw/sandbox/firewall/audit"},
-		{"PreAgentAuditFilePath", PreAgentAuditFilePath, "/tmp/gh-aw/pre-agent-audit.txt"},
-		{"AWFConfigFilePath", AWFConfigFilePath, "/tmp/gh-aw/awf-config.json"},
+		{"AWFProxyLogsDir", AWFProxyLogsDir, "${{ env.GH_AW_TMP_DIR }}/sandbox/firewall/logs"},
+		{"AWFAuditDir", AWFAuditDir, "${{ env.GH_AW_TMP_DIR }}/sandbox/firewall/audit"},
+		{"PreAgentAuditFilePath", PreAgentAuditFilePath, "${{ env.GH_AW_TMP_DIR }}/pre-agent-audit.txt"},
+		{"AWFConfigFilePath", AWFConfigFilePath, "${{ env.GH_AW_TMP_DIR }}/awf-config.json"},
 		{"AgentJobName", string(AgentJobName), "agent"},
 		{"ActivationJobName", string(ActivationJobName), "activation"},
 		{"PreActivationJobName", string(PreActivationJobName), "pre_activation"},
@@ -250,6 +250,7 @@ func TestFeatureFlagConstants(t *testing.T) {
 		{"DIFCProxyFeatureFlag", DIFCProxyFeatureFlag, "difc-proxy"},
 		{"AwfDiagnosticLogsFeatureFlag", AwfDiagnosticLogsFeatureFlag, "awf-diagnostic-logs"},
 		{"GroupConcurrencyQueueFeatureFlag", GroupConcurrencyQueueFeatureFlag, "group-concurrency-queue"},
+		{"CapabilitiesPromptFeatureFlag", CapabilitiesPromptFeatureFlag, "capabilities-prompt"},
 	}
 
 	for _, tt := range tests {
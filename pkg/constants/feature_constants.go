@@ -97,4 +97,13 @@ const (
 	//	features:
 	//	  gh-aw-detection: true
 	GHAWDetectionFeatureFlag FeatureFlag = "gh-aw-detection"
+	// CapabilitiesPromptFeatureFlag adds a <capabilities> section to the prompt that
+	// summarizes the engine, tools, safe outputs and restrictions of the compiled
+	// workflow, so the agent is told exactly what it can and cannot do.
+	//
+	// Workflow frontmatter usage:
+	//
+	//	features:
+	//	  capabilities-prompt: true
+	CapabilitiesPromptFeatureFlag FeatureFlag = "capabilities-prompt"
 )
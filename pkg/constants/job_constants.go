@@ -68,6 +68,7 @@ const UploadAssetsJobName JobName = "upload_assets"
 const UploadCodeScanningJobName JobName = "upload_code_scanning_sarif"
 const ConclusionJobName JobName = "conclusion"
 const UnlockJobName JobName = "unlock"
+const DisableExpiredJobName JobName = "disable_expired"
 
 // KnownBuiltInJobNames contains all known built-in workflow job names (including aliases).
 // It is used for O(1) membership checks when validating or filtering user-defined job
@@ -86,6 +87,7 @@ var KnownBuiltInJobNames = map[string]struct{}{
 	string(UploadCodeScanningJobName):  {},
 	string(ConclusionJobName):          {},
 	string(UnlockJobName):              {},
+	string(DisableExpiredJobName):      {},
 }
 
 // Artifact name constants
@@ -96,6 +98,15 @@ const AgentOutputArtifactName = "agent-output"
 // including safe outputs, agent output, engine logs, and other agent-related files.
 const AgentArtifactName = "agent"
 
+// AgentTranscriptsArtifactName is the artifact name for the encrypted agent transcripts
+// (agent stdio log, engine session logs and MCP gateway logs). It is only uploaded when
+// artifact-encryption covers transcripts; the files are then left out of the agent artifact.
+const AgentTranscriptsArtifactName = "agent-transcripts"
+
+// EncryptedArtifactSuffix is the file name suffix of artifacts encrypted with age:
+// a gzip-compressed tar archive of the files, encrypted to the configured recipients.
+const EncryptedArtifactSuffix = ".tar.gz.age"
+
 // DetectionArtifactName is the artifact name for the threat detection log.
 const DetectionArtifactName = "detection"
 
@@ -178,7 +189,9 @@ const SarifArtifactName = "code-scanning-sarif"
 // SarifArtifactDownloadPath is the local path where the upload_code_scanning_sarif job
 // downloads the SARIF artifact.  The file will be available at this path + the SARIF
 // filename ("code-scanning-alert.sarif") after actions/download-artifact completes.
-const SarifArtifactDownloadPath = "/tmp/gh-aw/sarif/"
+// The job does not run the setup step, so the path is in RUNNER_TEMP rather than in the
+// job scratch directory.
+const SarifArtifactDownloadPath = GhAwRootDir + "/sarif/"
 
 // SarifFileName is the name of the SARIF file generated by create_code_scanning_alert.cjs
 // and uploaded / downloaded as part of the code-scanning-sarif artifact.
@@ -214,6 +227,10 @@ const CheckRateLimitStepID StepID = "check_rate_limit"
 const CheckSkipRolesStepID StepID = "check_skip_roles"
 const CheckSkipBotsStepID StepID = "check_skip_bots"
 const CheckSkipIfCheckFailingStepID StepID = "check_skip_if_check_failing"
+const CheckReactionCommandStepID StepID = "check_reaction_command"
+const CheckScheduleTimezoneStepID StepID = "check_schedule_timezone"
+const CheckChangedPathsStepID StepID = "check_changed_paths"
+const CheckFollowUpsStepID StepID = "check_follow_ups"
 
 // PreActivationAppTokenStepID is the step ID for the unified GitHub App token mint step
 // emitted in the pre-activation job when on.github-app is configured alongside skip-if checks.
@@ -237,10 +254,19 @@ const SkipCheckOkOutput = "skip_check_ok"
 const SkipNoMatchCheckOkOutput = "skip_no_match_check_ok"
 const CommandPositionOkOutput = "command_position_ok"
 const MatchedCommandOutput = "matched_command"
+const SubcommandOutput = "subcommand"
+const CommandArgsOutput = "command_args"
 const RateLimitOkOutput = "rate_limit_ok"
 const SkipRolesOkOutput = "skip_roles_ok"
 const SkipBotsOkOutput = "skip_bots_ok"
 const SkipIfCheckFailingOkOutput = "skip_if_check_failing_ok"
+const ReactionCommandOkOutput = "reaction_command_ok"
+const ReactionItemNumberOutput = "reaction_item_number"
+const ReactionCommentIDOutput = "reaction_comment_id"
+const ReactionUserOutput = "reaction_user"
+const ScheduleTimezoneOkOutput = "schedule_timezone_ok"
+const ChangedPathsOkOutput = "changed_paths_ok"
+const FollowUpsOkOutput = "follow_ups_ok"
 const ActivatedOutput = "activated"
 
 // Rate limit defaults
@@ -494,8 +494,8 @@ func TestSpec_AWFConstants_Values(t *testing.T) {
 	assert.Equal(t, "awf", constants.AWFDefaultCommand,
 		"AWFDefaultCommand should be 'awf' for strict security mode")
 
-	// From spec: AWFProxyLogsDir // "/tmp/gh-aw/sandbox/firewall/logs"
-	assert.Equal(t, "/tmp/gh-aw/sandbox/firewall/logs", constants.AWFProxyLogsDir,
+	// From spec: AWFProxyLogsDir // "${{ env.GH_AW_TMP_DIR }}/sandbox/firewall/logs"
+	assert.Equal(t, "${{ env.GH_AW_TMP_DIR }}/sandbox/firewall/logs", constants.AWFProxyLogsDir,
 		"AWFProxyLogsDir should match the documented value")
 
 	// From spec: AWFProxyLogsDirExpr // "${{ runner.temp }}/gh-aw/sandbox/firewall/logs"
@@ -506,8 +506,8 @@ func TestSpec_AWFConstants_Values(t *testing.T) {
 	assert.Equal(t, "${RUNNER_TEMP}/gh-aw/sandbox/firewall/logs", constants.AWFProxyLogsDirShell,
 		"AWFProxyLogsDirShell should match the documented value")
 
-	// From spec: AWFAuditDir // "/tmp/gh-aw/sandbox/firewall/audit"
-	assert.Equal(t, "/tmp/gh-aw/sandbox/firewall/audit", constants.AWFAuditDir,
+	// From spec: AWFAuditDir // "${{ env.GH_AW_TMP_DIR }}/sandbox/firewall/audit"
+	assert.Equal(t, "${{ env.GH_AW_TMP_DIR }}/sandbox/firewall/audit", constants.AWFAuditDir,
 		"AWFAuditDir should match the documented value")
 
 	// From spec: AWFAuditDirExpr // "${{ runner.temp }}/gh-aw/sandbox/firewall/audit"
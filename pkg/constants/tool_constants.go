@@ -49,6 +49,8 @@ var AllowedExpressions = []string{
 	"github.event.pull_request.title",
 	"github.event.discussion.title",
 	"github.event.discussion.category.name",
+	"github.event.discussion.category.slug",
+	"github.event.discussion.state",
 	"github.event.release.name",
 	"github.event.workflow_job.id",
 	"github.event.deployment.environment",
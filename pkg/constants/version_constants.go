@@ -116,6 +116,10 @@ const AWFContainerRuntimeMinVersion Version = "v0.27.30"
 // Workflows pinning an older AWF version must use the old --security-mode compat behavior.
 const AWFLegacySecurityMinVersion Version = "v0.27.32"
 
+// DefaultDevcontainerCLIVersion is the default version of the @devcontainers/cli package
+// used to build the agent image for sandbox.agent.devcontainer.
+const DefaultDevcontainerCLIVersion Version = "0.80.0"
+
 // DefaultGVisorVersion is the pinned gVisor release used by the compiler-generated
 // install step. A specific dated release name is used instead of "latest" to ensure
 // reproducible, verifiable installs. Each release provides SHA-512 files for
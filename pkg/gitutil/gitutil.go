@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/github/gh-aw/pkg/fileutil"
 	"github.com/github/gh-aw/pkg/logger"
@@ -153,8 +154,44 @@ func FindGitRootFrom(startDir string) (string, error) {
 // and then reading the blob with git cat-file.
 // Use this when the caller already knows the git root (e.g. from a cached value).
 func ReadFileFromHEAD(filePath, gitRoot string) (string, error) {
+	return readFileFromRevision(filePath, gitRoot, "HEAD")
+}
+
+// ReadFileFromCommit reads a file as it was at the given commit, using the same path
+// validation as ReadFileFromHEAD. commit must be a full 40-character commit SHA.
+func ReadFileFromCommit(filePath, gitRoot, commit string) (string, error) {
+	if !IsValidFullSHA(commit) {
+		return "", fmt.Errorf("invalid commit SHA %q: expected a full 40-character SHA", commit)
+	}
+	return readFileFromRevision(filePath, gitRoot, commit)
+}
+
+// LastCommitOfFile returns the SHA and committer date of the most recent commit that
+// changed the file at relPath, given relative to gitRoot with forward slashes. The SHA is
+// empty when the file has never been committed.
+func LastCommitOfFile(relPath, gitRoot string) (string, time.Time, error) {
+	cmd := exec.Command("git", "-C", gitRoot, "log", "-1", "--format=%H %cI", "--", ":(literal)"+relPath)
+	output, err := cmd.Output()
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to read git history of %q: %w", relPath, err)
+	}
+	sha, date, found := strings.Cut(strings.TrimSpace(string(output)), " ")
+	if !found {
+		return "", time.Time{}, nil
+	}
+	if !IsValidFullSHA(sha) {
+		return "", time.Time{}, fmt.Errorf("unexpected git log output for %q: %q", relPath, output)
+	}
+	committedAt, err := time.Parse(time.RFC3339, date)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("unexpected commit date for %q: %w", relPath, err)
+	}
+	return sha, committedAt, nil
+}
+
+func readFileFromRevision(filePath, gitRoot, revision string) (string, error) {
 	if gitRoot == "" {
-		return "", fmt.Errorf("gitRoot must not be empty when reading %q from HEAD", filePath)
+		return "", fmt.Errorf("gitRoot must not be empty when reading %q from %s", filePath, revision)
 	}
 
 	cleanGitRoot, err := fileutil.ValidateAbsolutePath(gitRoot)
@@ -184,32 +221,32 @@ func ReadFileFromHEAD(filePath, gitRoot string) (string, error) {
 
 	relPath = filepath.ToSlash(relPath)
 
-	gitutilLog.Printf("Reading %q from git HEAD (relative path: %s)", filePath, relPath)
+	gitutilLog.Printf("Reading %q from git %s (relative path: %s)", filePath, revision, relPath)
 
-	blobID, err := resolveHEADBlobID(cleanGitRoot, relPath)
+	blobID, err := resolveBlobID(cleanGitRoot, revision, relPath)
 	if err != nil {
-		gitutilLog.Printf("File %q not found in HEAD commit: %v", filePath, err)
-		return "", fmt.Errorf("file %q not found in HEAD commit: %w", filePath, err)
+		gitutilLog.Printf("File %q not found in %s: %v", filePath, revision, err)
+		return "", fmt.Errorf("file %q not found in %s commit: %w", filePath, revision, err)
 	}
 
 	cmd := exec.Command("git", "-C", cleanGitRoot, "cat-file", "blob", blobID)
 	output, err := cmd.Output()
 	if err != nil {
-		gitutilLog.Printf("File %q not found in HEAD commit: %v", filePath, err)
-		return "", fmt.Errorf("file %q not found in HEAD commit: %w", filePath, err)
+		gitutilLog.Printf("File %q not found in %s: %v", filePath, revision, err)
+		return "", fmt.Errorf("file %q not found in %s commit: %w", filePath, revision, err)
 	}
 	return string(output), nil
 }
 
-func resolveHEADBlobID(gitRoot, relPath string) (string, error) {
+func resolveBlobID(gitRoot, revision, relPath string) (string, error) {
 	pathspec := ":(literal)" + relPath
-	cmd := exec.Command("git", "-C", gitRoot, "ls-tree", "-z", "--full-tree", "HEAD", "--", pathspec)
+	cmd := exec.Command("git", "-C", gitRoot, "ls-tree", "-z", "--full-tree", revision, "--", pathspec)
 	output, err := cmd.Output()
 	if err != nil {
 		return "", err
 	}
 	if len(output) == 0 {
-		return "", fmt.Errorf("path %q not found in HEAD", relPath)
+		return "", fmt.Errorf("path %q not found in %s", relPath, revision)
 	}
 
 	entry := strings.TrimSuffix(string(output), "\x00")
@@ -227,10 +264,10 @@ func resolveHEADBlobID(gitRoot, relPath string) (string, error) {
 		return "", fmt.Errorf("path %q resolved to unexpected entry %q", relPath, entryPath)
 	}
 	if fields[1] != "blob" {
-		return "", fmt.Errorf("path %q in HEAD is not a file", relPath)
+		return "", fmt.Errorf("path %q in %s is not a file", relPath, revision)
 	}
 	if !isGitObjectID(fields[2]) {
-		return "", fmt.Errorf("path %q in HEAD resolved to invalid object ID %q", relPath, fields[2])
+		return "", fmt.Errorf("path %q in %s resolved to invalid object ID %q", relPath, revision, fields[2])
 	}
 
 	return fields[2], nil
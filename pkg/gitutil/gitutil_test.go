@@ -440,3 +440,24 @@ func TestReadFileFromHEAD(t *testing.T) {
 		require.ErrorContains(t, err, "gitRoot must not be empty", "error should mention empty gitRoot")
 	})
 }
+
+func TestLastCommitOfFile(t *testing.T) {
+	t.Run("returns the last commit of a committed file", func(t *testing.T) {
+		gitRoot, err := FindGitRoot()
+		require.NoError(t, err, "must be inside a git repository")
+
+		sha, committedAt, err := LastCommitOfFile("go.mod", gitRoot)
+		require.NoError(t, err, "history of go.mod should be readable")
+		assert.True(t, IsValidFullSHA(sha), "should return a full commit SHA, got %q", sha)
+		assert.False(t, committedAt.IsZero(), "should return the commit date")
+	})
+
+	t.Run("returns an empty SHA for a file that was never committed", func(t *testing.T) {
+		gitRoot, err := FindGitRoot()
+		require.NoError(t, err, "must be inside a git repository")
+
+		sha, _, err := LastCommitOfFile("does/not/exist.lock.yml", gitRoot)
+		require.NoError(t, err, "a missing file should not be an error")
+		assert.Empty(t, sha, "a file without history should have no commit")
+	})
+}
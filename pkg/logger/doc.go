@@ -36,6 +36,16 @@
 //
 //	DEBUG_COLORS=0       # Disable colors (auto-disabled when piping)
 //
+// GH_AW_LOG - Per-namespace levels; replaces DEBUG when set:
+//
+//	GH_AW_LOG=workflow:gemini_tools=debug   # One namespace at debug
+//	GH_AW_LOG=*=info,workflow:*=trace       # Later entries win
+//
+// Levels are off, error, warn, info, debug (Printf/Print), and trace
+// (Tracef). Infof, Warnf, and Errorf log at their own level.
+//
+// GH_AW_LOG_FORMAT - Set to json for one JSON object per line.
+//
 // # Namespace Convention
 //
 // Follow the pattern: pkg:filename or pkg:component
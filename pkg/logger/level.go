@@ -0,0 +1,114 @@
+package logger
+
+import (
+	"os"
+	"strings"
+)
+
+// Level is the verbosity of a log message. A logger emits a message when the
+// message level is at or below the level configured for its namespace.
+type Level int
+
+const (
+	// LevelOff disables all output for a namespace.
+	LevelOff Level = iota
+	// LevelError is used for failures the compiler recovers from.
+	LevelError
+	// LevelWarn is used for fallbacks and skipped configuration.
+	LevelWarn
+	// LevelInfo is used for high-level progress (one line per phase).
+	LevelInfo
+	// LevelDebug is the level of Printf and Print, and the level enabled by DEBUG.
+	LevelDebug
+	// LevelTrace is used for per-item detail that is too noisy for DEBUG=*.
+	LevelTrace
+)
+
+var levelNames = map[Level]string{
+	LevelOff:   "off",
+	LevelError: "error",
+	LevelWarn:  "warn",
+	LevelInfo:  "info",
+	LevelDebug: "debug",
+	LevelTrace: "trace",
+}
+
+// String returns the lower-case level name used by GH_AW_LOG and JSON output.
+func (l Level) String() string {
+	if name, ok := levelNames[l]; ok {
+		return name
+	}
+	return "debug"
+}
+
+// parseLevel converts a GH_AW_LOG level name to a Level. Unknown names fall back
+// to LevelDebug so that a typo still produces output rather than silence.
+func parseLevel(name string) Level {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "off", "none":
+		return LevelOff
+	case "error":
+		return LevelError
+	case "warn", "warning":
+		return LevelWarn
+	case "info":
+		return LevelInfo
+	case "trace":
+		return LevelTrace
+	default:
+		return LevelDebug
+	}
+}
+
+var (
+	// GH_AW_LOG environment variable value, read once at initialization.
+	// When set, it takes precedence over DEBUG.
+	logEnv = os.Getenv("GH_AW_LOG") //nolint:osgetenvlibrary
+
+	// GH_AW_LOG_FORMAT=json switches output to one JSON object per line.
+	jsonOutput = os.Getenv("GH_AW_LOG_FORMAT") == "json" //nolint:osgetenvlibrary
+)
+
+// computeLevel resolves the level for a namespace. GH_AW_LOG takes precedence;
+// otherwise a namespace enabled by DEBUG logs at LevelDebug.
+func computeLevel(namespace string) Level {
+	if strings.TrimSpace(logEnv) != "" {
+		return computeLevelFromLogEnv(namespace, logEnv)
+	}
+	if computeEnabled(namespace) {
+		return LevelDebug
+	}
+	return LevelOff
+}
+
+// computeLevelFromLogEnv evaluates GH_AW_LOG entries for a namespace. Entries are
+// comma-separated "pattern=level" pairs; a bare pattern means debug and a
+// "-pattern" entry disables matching namespaces. Later matching entries win, so
+// broad defaults go first:
+//
+//	GH_AW_LOG=*=info,workflow:gemini_tools=trace,-workflow:expression*
+func computeLevelFromLogEnv(namespace, env string) Level {
+	level := LevelOff
+	for entry := range strings.SplitSeq(env, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if excludePattern, ok := strings.CutPrefix(entry, "-"); ok {
+			if matchPattern(namespace, excludePattern) {
+				return LevelOff // Exclusions take precedence
+			}
+			continue
+		}
+		pattern, levelName, hasLevel := strings.Cut(entry, "=")
+		if !matchPattern(namespace, strings.TrimSpace(pattern)) {
+			continue
+		}
+		if hasLevel {
+			level = parseLevel(levelName)
+		} else {
+			level = LevelDebug
+		}
+	}
+	return level
+}
@@ -0,0 +1,127 @@
+//go:build !integration
+
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupLogEnv(t *testing.T, value string, jsonFormat bool) {
+	t.Helper()
+	originalLogEnv, originalJSON, originalDebugEnv := logEnv, jsonOutput, debugEnv
+	logEnv, jsonOutput, debugEnv = value, jsonFormat, ""
+	t.Cleanup(func() {
+		logEnv, jsonOutput, debugEnv = originalLogEnv, originalJSON, originalDebugEnv
+	})
+}
+
+func TestComputeLevelFromLogEnv(t *testing.T) {
+	tests := []struct {
+		name      string
+		env       string
+		namespace string
+		expected  Level
+	}{
+		{name: "exact namespace with level", env: "workflow:gemini_tools=debug", namespace: "workflow:gemini_tools", expected: LevelDebug},
+		{name: "other namespace is off", env: "workflow:gemini_tools=debug", namespace: "workflow:compiler", expected: LevelOff},
+		{name: "bare pattern means debug", env: "workflow:*", namespace: "workflow:compiler", expected: LevelDebug},
+		{name: "later entries win", env: "*=info,workflow:*=trace", namespace: "workflow:compiler", expected: LevelTrace},
+		{name: "broad default applies to others", env: "*=info,workflow:*=trace", namespace: "cli:audit", expected: LevelInfo},
+		{name: "exclusion takes precedence", env: "*=trace,-workflow:expression*", namespace: "workflow:expression_parser", expected: LevelOff},
+		{name: "off level", env: "*=debug,parser:*=off", namespace: "parser:frontmatter", expected: LevelOff},
+		{name: "unknown level falls back to debug", env: "cli:*=verbose", namespace: "cli:audit", expected: LevelDebug},
+		{name: "spaces are trimmed", env: " cli:* = warn , ", namespace: "cli:audit", expected: LevelWarn},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, computeLevelFromLogEnv(tt.namespace, tt.env), "level should match for %q", tt.env)
+		})
+	}
+}
+
+func TestGHAWLogOverridesDebug(t *testing.T) {
+	setupLogEnv(t, "test:*=info", false)
+	debugEnv = "*"
+
+	log := New("test:levels")
+	assert.False(t, log.Enabled(), "GH_AW_LOG=info should not enable Printf")
+	assert.True(t, log.EnabledAt(LevelInfo), "info should be enabled")
+	assert.True(t, log.EnabledAt(LevelError), "error should be enabled below info")
+	assert.False(t, log.EnabledAt(LevelTrace), "trace should be disabled")
+	assert.False(t, log.EnabledAt(LevelOff), "off is never a message level")
+
+	logEnv = ""
+	assert.Equal(t, LevelDebug, New("test:levels").level, "DEBUG should enable debug when GH_AW_LOG is unset")
+}
+
+func TestLogger_LevelMethods(t *testing.T) {
+	setupLogEnv(t, "test:*=warn", false)
+	log := New("test:methods")
+
+	output := captureStderr(func() {
+		log.Printf("debug message")
+		log.Tracef("trace message")
+		log.Infof("info message")
+		log.Warnf("warn %s", "message")
+		log.Errorf("error message")
+	})
+
+	assert.NotContains(t, output, "debug message", "debug should be filtered at warn")
+	assert.NotContains(t, output, "trace message", "trace should be filtered at warn")
+	assert.NotContains(t, output, "info message", "info should be filtered at warn")
+	assert.Contains(t, output, "⚠ warn message", "warn should be printed with its glyph")
+	assert.Contains(t, output, "✗ error message", "error should be printed with its glyph")
+}
+
+func TestLogger_JSONOutput(t *testing.T) {
+	setupLogEnv(t, "test:*=trace", true)
+	log := New("test:json")
+
+	output := captureStderr(func() {
+		log.Tracef("resolved %d items", 3)
+		log.Printf("plain")
+	})
+
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	require.Len(t, lines, 2, "each message should be one line")
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &record), "output should be JSON")
+	assert.Equal(t, "trace", record["level"], "level should be recorded")
+	assert.Equal(t, "test:json", record["namespace"], "namespace should not be colored")
+	assert.Equal(t, "resolved 3 items", record["msg"], "message should not include a glyph")
+	assert.Contains(t, record, "time", "timestamp should be recorded")
+	assert.Contains(t, record, "elapsed_ms", "time diff should be recorded")
+
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &record), "output should be JSON")
+	assert.Equal(t, "debug", record["level"], "Printf should log at debug")
+}
+
+func TestSlogHandler_Levels(t *testing.T) {
+	setupLogEnv(t, "test:*=warn", true)
+	handler := NewSlogHandler(New("test:slog"))
+
+	assert.False(t, handler.Enabled(context.Background(), slog.LevelInfo), "info should be filtered at warn")
+	assert.True(t, handler.Enabled(context.Background(), slog.LevelError), "error should be enabled at warn")
+
+	record := slog.NewRecord(time.Now(), slog.LevelWarn, "slow response", 0)
+	record.AddAttrs(slog.Int("attempt", 2))
+	output := captureStderr(func() {
+		require.NoError(t, handler.Handle(context.Background(), record), "Handle should succeed")
+	})
+
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimSpace(output)), &decoded), "output should be JSON")
+	assert.Equal(t, "warn", decoded["level"], "slog level should map to warn")
+	assert.Equal(t, "slow response", decoded["msg"], "message should be kept")
+	assert.Equal(t, map[string]any{"attempt": float64(2)}, decoded["attrs"], "attributes should stay structured")
+}
@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"encoding/json"
 	"fmt"
 	"hash/fnv"
 	"image/color"
@@ -19,6 +20,7 @@ import (
 type Logger struct {
 	namespace string
 	enabled   bool
+	level     Level
 	lastLog   time.Time
 	mu        sync.Mutex
 	label     string
@@ -80,12 +82,19 @@ func initDebugEnv() string {
 //	DEBUG=ns:*,-ns:skip  - enables namespace but excludes specific patterns
 //
 // Colors are automatically assigned to each namespace if DEBUG_COLORS != "0".
+//
+// GH_AW_LOG, when set, replaces DEBUG and assigns a level per namespace pattern
+// (see computeLevelFromLogEnv):
+//
+//	GH_AW_LOG=workflow:gemini_tools=debug
+//	GH_AW_LOG=*=info,workflow:*=trace
 func New(namespace string) *Logger {
-	enabled := computeEnabled(namespace)
+	level := computeLevel(namespace)
 	label := selectNamespaceLabel(namespace)
 	return &Logger{
 		namespace: namespace,
-		enabled:   enabled,
+		enabled:   level >= LevelDebug,
+		level:     level,
 		lastLog:   time.Now(),
 		label:     label,
 	}
@@ -93,7 +102,7 @@ func New(namespace string) *Logger {
 
 // selectNamespaceLabel renders the namespace label with a hash-selected style.
 func selectNamespaceLabel(namespace string) string {
-	if !debugColors {
+	if !debugColors || jsonOutput {
 		return namespace
 	}
 
@@ -110,11 +119,17 @@ func selectNamespaceLabel(namespace string) string {
 	return colorPalette[hash%uint32(len(colorPalette))].Render(namespace)
 }
 
-// Enabled returns whether this logger is enabled
+// Enabled returns whether this logger is enabled at LevelDebug, the level of
+// Printf and Print.
 func (l *Logger) Enabled() bool {
 	return l.enabled
 }
 
+// EnabledAt returns whether messages at the given level are emitted.
+func (l *Logger) EnabledAt(level Level) bool {
+	return level != LevelOff && level <= l.level
+}
+
 // Printf prints a formatted message if the logger is enabled.
 // A newline is always added at the end.
 // Time diff since last log is displayed like the debug npm package.
@@ -122,10 +137,7 @@ func (l *Logger) Printf(format string, args ...any) {
 	if !l.enabled {
 		return
 	}
-	diff := l.tickTime()
-
-	message := fmt.Sprintf(format, args...)
-	lipgloss.Fprintf(stderrWriter(), "%s %s +%s\n", l.label, message, timeutil.FormatDuration(diff))
+	l.emit(LevelDebug, "", fmt.Sprintf(format, args...), nil)
 }
 
 // Print prints a message if the logger is enabled.
@@ -135,10 +147,67 @@ func (l *Logger) Print(args ...any) {
 	if !l.enabled {
 		return
 	}
-	diff := l.tickTime()
+	l.emit(LevelDebug, "", fmt.Sprint(args...), nil)
+}
+
+// Tracef prints a formatted message at LevelTrace. Use it for per-item detail
+// that would drown out the rest of DEBUG=* output.
+func (l *Logger) Tracef(format string, args ...any) {
+	l.logf(LevelTrace, "» ", format, args...)
+}
+
+// Infof prints a formatted message at LevelInfo.
+func (l *Logger) Infof(format string, args ...any) {
+	l.logf(LevelInfo, "· ", format, args...)
+}
+
+// Warnf prints a formatted message at LevelWarn.
+func (l *Logger) Warnf(format string, args ...any) {
+	l.logf(LevelWarn, "⚠ ", format, args...)
+}
+
+// Errorf prints a formatted message at LevelError.
+func (l *Logger) Errorf(format string, args ...any) {
+	l.logf(LevelError, "✗ ", format, args...)
+}
+
+func (l *Logger) logf(level Level, glyph, format string, args ...any) {
+	if !l.EnabledAt(level) {
+		return
+	}
+	l.emit(level, glyph, fmt.Sprintf(format, args...), nil)
+}
 
-	message := fmt.Sprint(args...)
-	lipgloss.Fprintf(stderrWriter(), "%s %s +%s\n", l.label, message, timeutil.FormatDuration(diff))
+// jsonRecord is one line of GH_AW_LOG_FORMAT=json output.
+type jsonRecord struct {
+	Time      string         `json:"time"`
+	Level     string         `json:"level"`
+	Namespace string         `json:"namespace"`
+	Message   string         `json:"msg"`
+	ElapsedMs float64        `json:"elapsed_ms"`
+	Attrs     map[string]any `json:"attrs,omitempty"`
+}
+
+// emit writes a message that has already passed the level check. The glyph is
+// only used for text output; attrs are only used for JSON output.
+func (l *Logger) emit(level Level, glyph, message string, attrs map[string]any) {
+	diff := l.tickTime()
+	if jsonOutput {
+		line, err := json.Marshal(jsonRecord{
+			Time:      time.Now().UTC().Format(time.RFC3339Nano),
+			Level:     level.String(),
+			Namespace: l.namespace,
+			Message:   message,
+			ElapsedMs: float64(diff.Microseconds()) / 1000,
+			Attrs:     attrs,
+		})
+		if err != nil {
+			return
+		}
+		fmt.Fprintf(stderrWriter(), "%s\n", line)
+		return
+	}
+	lipgloss.Fprintf(stderrWriter(), "%s %s%s +%s\n", l.label, glyph, message, timeutil.FormatDuration(diff))
 }
 
 func (l *Logger) tickTime() time.Duration {
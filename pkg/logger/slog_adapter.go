@@ -19,15 +19,31 @@ func NewSlogHandler(logger *Logger) *SlogHandler {
 }
 
 // Enabled reports whether the handler handles records at the given level.
-// We enable all levels when our logger is enabled.
+// slog levels are mapped onto the logger's levels, so DEBUG enables every
+// level from slog.LevelDebug up and GH_AW_LOG can raise or lower the bar.
 func (h *SlogHandler) Enabled(_ context.Context, level slog.Level) bool {
-	return h.logger.Enabled()
+	return h.logger.EnabledAt(levelFromSlog(level))
 }
 
 // Handle handles the Record.
 // It will only be called when Enabled returns true.
 func (h *SlogHandler) Handle(_ context.Context, r slog.Record) error {
-	if !h.logger.Enabled() {
+	level := levelFromSlog(r.Level)
+	if !h.logger.EnabledAt(level) {
+		return nil
+	}
+
+	// JSON output keeps attributes structured instead of flattening them into the message
+	if jsonOutput {
+		var attrs map[string]any
+		if r.NumAttrs() > 0 {
+			attrs = make(map[string]any, r.NumAttrs())
+			r.Attrs(func(a slog.Attr) bool {
+				attrs[a.Key] = a.Value.Resolve().Any()
+				return true
+			})
+		}
+		h.logger.emit(level, "", r.Message, attrs)
 		return nil
 	}
 
@@ -64,10 +80,26 @@ func (h *SlogHandler) Handle(_ context.Context, r slog.Record) error {
 		levelPrefix = "✗ "
 	}
 
-	h.logger.Print(levelPrefix + msg.String())
+	h.logger.emit(level, levelPrefix, msg.String(), nil)
 	return nil
 }
 
+// levelFromSlog maps an slog level onto the closest logger level.
+func levelFromSlog(level slog.Level) Level {
+	switch {
+	case level >= slog.LevelError:
+		return LevelError
+	case level >= slog.LevelWarn:
+		return LevelWarn
+	case level >= slog.LevelInfo:
+		return LevelInfo
+	case level >= slog.LevelDebug:
+		return LevelDebug
+	default:
+		return LevelTrace
+	}
+}
+
 // WithAttrs returns a new Handler whose attributes consist of
 // both the receiver's attributes and the arguments.
 // This implementation does not persist attributes.
@@ -23,6 +23,7 @@ type FrontmatterResult struct {
 	FrontmatterLines []string       // Original frontmatter lines for error context
 	FrontmatterStart int            // Line number where frontmatter starts (1-based)
 	FieldLines       map[string]int // Absolute line numbers (1-based) of top-level frontmatter keys in the file
+	MarkdownStart    int            // Line number where the trimmed Markdown body starts (1-based, 0 when empty)
 }
 
 // ExtractFrontmatterFromContent parses YAML frontmatter from markdown content string
@@ -55,6 +56,7 @@ func ExtractFrontmatterFromContent(content string) (*FrontmatterResult, error) {
 		FrontmatterLines: frontmatterLines,
 		FrontmatterStart: frontmatterStartLine,
 		FieldLines:       fieldLines,
+		MarkdownStart:    markdownStartLine(content, markdownStart),
 	}, nil
 }
 
@@ -74,6 +76,7 @@ func noFrontmatterResult(content string) *FrontmatterResult {
 		Markdown:         content,
 		FrontmatterLines: []string{},
 		FrontmatterStart: 0,
+		MarkdownStart:    1,
 	}
 }
 
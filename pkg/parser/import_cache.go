@@ -91,6 +91,20 @@ func (c *ImportCache) Get(owner, repo, path, sha string) (string, bool) {
 	return fullCachePath, true
 }
 
+// GetAnyRevision returns the cached file for owner/repo/path when exactly one cached
+// commit SHA contains it. It is used in offline mode, where a branch or tag ref cannot
+// be resolved to a SHA; ambiguous matches are reported as misses.
+func (c *ImportCache) GetAnyRevision(owner, repo, path string) (string, bool) {
+	pattern := filepath.Join(c.baseDir, ImportCacheDir, owner, repo, "*", sanitizePath(path))
+	matches, err := filepath.Glob(pattern)
+	if err != nil || len(matches) != 1 {
+		importCacheLog.Printf("No unique cached revision for %s/%s/%s: matches=%d", owner, repo, path, len(matches))
+		return "", false
+	}
+	importCacheLog.Printf("Cache hit (any revision): %s/%s/%s -> %s", owner, repo, path, matches[0])
+	return matches[0], true
+}
+
 // Set stores a new cache entry by saving the content to the cache directory
 // sha parameter should be the resolved commit SHA
 func (c *ImportCache) Set(owner, repo, path, sha string, content []byte) (string, error) {
@@ -20,6 +20,7 @@ import (
 type importAccumulator struct {
 	toolsBuilder             strings.Builder
 	mcpServersBuilder        strings.Builder
+	mcpServerSources         []string        // File path of each mcp-servers line in mcpServersBuilder
 	markdownBuilder          strings.Builder // imports with substituted inputs or schema defaults (compile-time substitution)
 	importPaths              []string        // Import paths for runtime-import macro generation
 	promptImports            []PromptImportEntry
@@ -377,7 +378,7 @@ func (acc *importAccumulator) extractEngineMCPSettings(mcpVal any, fullPath stri
 // frontmatter map and writes them into the appropriate accumulator builders and slices.
 //
 // Side effects: acc.mergedMaxTurns, acc.mergedMaxToolDenials, acc.mergedMaxRuns, acc.mergedMaxAICredits,
-// acc.mergedMaxDailyAICredits, acc.mcpServersBuilder,
+// acc.mergedMaxDailyAICredits, acc.mcpServersBuilder, acc.mcpServerSources,
 // acc.safeOutputs, acc.mcpScripts, acc.stepsBuilder, acc.runtimesBuilder,
 // acc.servicesBuilder, acc.networkBuilder, acc.permissionsBuilder,
 // acc.secretMaskingBuilder.
@@ -389,7 +390,7 @@ func (acc *importAccumulator) extractConfigFields(fm map[string]any, fullPath st
 	acc.extractFirstWinsJSONField(fm, fullPath, "max-ai-credits", &acc.mergedMaxAICredits)
 	acc.extractFirstWinsJSONField(fm, fullPath, "max-daily-ai-credits", &acc.mergedMaxDailyAICredits)
 
-	acc.appendJSONBuilderField(fm, "mcp-servers", "{}", &acc.mcpServersBuilder)
+	acc.extractMCPServers(fm, fullPath)
 	acc.appendJSONSliceField(fm, "safe-outputs", "{}", &acc.safeOutputs)
 	acc.appendJSONSliceField(fm, "mcp-scripts", "{}", &acc.mcpScripts)
 	acc.appendYAMLBuilderField(fm, "steps", &acc.stepsBuilder)
@@ -401,6 +402,17 @@ func (acc *importAccumulator) extractConfigFields(fm map[string]any, fullPath st
 	acc.appendJSONBuilderField(fm, "secret-masking", "{}", &acc.secretMaskingBuilder)
 }
 
+// extractMCPServers appends the import's mcp-servers as one JSON line and records the
+// file it came from, so that conflicting definitions can name both imports.
+func (acc *importAccumulator) extractMCPServers(fm map[string]any, fullPath string) {
+	content, err := extractFieldJSONFromMap(fm, "mcp-servers", "{}")
+	if err != nil || content == "" || content == "{}" {
+		return
+	}
+	acc.mcpServersBuilder.WriteString(content + "\n")
+	acc.mcpServerSources = append(acc.mcpServerSources, fullPath)
+}
+
 func (acc *importAccumulator) mergeSandboxAgentMounts(fm map[string]any) {
 	sandboxVal, hasSandbox := fm["sandbox"]
 	if !hasSandbox {
@@ -881,6 +893,7 @@ func (acc *importAccumulator) toImportsResult(topologicalOrder []string) *Import
 	return &ImportsResult{
 		MergedTools:                   acc.toolsBuilder.String(),
 		MergedMCPServers:              acc.mcpServersBuilder.String(),
+		MergedMCPServerSources:        acc.mcpServerSources,
 		MergedEngines:                 acc.engines,
 		MergedSafeOutputs:             acc.safeOutputs,
 		MergedMCPScripts:              acc.mcpScripts,
@@ -26,6 +26,7 @@ type PromptImportEntry struct {
 type ImportsResult struct {
 	MergedTools                   string                // Merged tools configuration from all imports
 	MergedMCPServers              string                // Merged mcp-servers configuration from all imports
+	MergedMCPServerSources        []string              // File path of the import behind each line in MergedMCPServers
 	MergedEngines                 []string              // Merged engine configurations from all imports
 	MergedSafeOutputs             []string              // Merged safe-outputs configurations from all imports
 	MergedMCPScripts              []string              // Merged mcp-scripts configurations from all imports
@@ -32,6 +32,27 @@ func IsMCPType(typeStr string) bool {
 	}
 }
 
+// HTTP MCP transports supported by the gateway when connecting to an upstream server.
+const (
+	// MCPTransportStreamableHTTP is the MCP streamable-HTTP transport (the default).
+	MCPTransportStreamableHTTP = "streamable-http"
+	// MCPTransportSSE is the legacy MCP HTTP+SSE transport.
+	MCPTransportSSE = "sse"
+)
+
+// ValidMCPTransports contains the transports accepted in the "transport" field of HTTP MCP servers.
+var ValidMCPTransports = []string{MCPTransportStreamableHTTP, MCPTransportSSE}
+
+// IsMCPTransport checks if a transport string is a valid HTTP MCP transport.
+func IsMCPTransport(transport string) bool {
+	switch transport {
+	case MCPTransportStreamableHTTP, MCPTransportSSE:
+		return true
+	default:
+		return false
+	}
+}
+
 // RegistryMCPServerConfig represents a parser-layer MCP server configuration.
 // It is intentionally distinct from workflow.MCPServerConfig, which models
 // workflow-facing YAML tool configuration.
@@ -44,10 +65,18 @@ type RegistryMCPServerConfig struct {
 	Registry  string   `json:"registry"`   // URI to installation location from registry
 	ProxyArgs []string `json:"proxy-args"` // custom proxy arguments for container-based tools
 	Allowed   []string `json:"allowed"`    // allowed tools
+	Blocked   []string `json:"blocked"`    // blocked tools (removed from the allowed set)
 
 	// Gateway startup behavior: when Required is explicitly false the server is optional
 	// and startup failures degrade to warnings. nil means the default (required).
 	Required *bool `json:"required,omitempty"`
+
+	// Per-server lifecycle limits enforced by the gateway. Zero values fall back to the
+	// gateway-level startupTimeout/toolTimeout and to never restarting the server.
+	StartupTimeout int    `json:"startup-timeout,omitempty"` // seconds to wait for the server to initialize
+	ToolTimeout    int    `json:"timeout,omitempty"`         // seconds to wait for a single tool call
+	Restart        string `json:"restart,omitempty"`         // restart policy for stdio servers: never or on-failure
+	MaxRestarts    int    `json:"max-restarts,omitempty"`    // restart attempts allowed with restart: on-failure
 }
 
 // MCPServerInfo contains the inspection results for an MCP server
@@ -662,5 +691,11 @@ func parseMCPHTTPTypeConfig(mcpConfig map[string]any, toolName string, config *R
 	mcpLog.Printf("Tool %s uses HTTP transport with URL: %s", toolName, urlStr)
 	config.URL = urlStr
 	copyStringMapFromAny(mcpConfig["headers"], config.Headers)
+	if transport, ok := mcpConfig["transport"].(string); ok {
+		if !IsMCPTransport(transport) {
+			return fmt.Errorf("unsupported transport '%s' for http MCP tool '%s'. Valid transports are: %s", transport, toolName, strings.Join(ValidMCPTransports, ", "))
+		}
+		config.Transport = transport
+	}
 	return nil
 }
@@ -0,0 +1,369 @@
+package parser
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var mcpServerDiagnosticsLog = logger.New("parser:mcp_server_diagnostics")
+
+// MCPServerIssue is a problem found in one mcp-servers entry, with a fix-it when the
+// correction is mechanical. Issues are produced by DiagnoseMCPServers so authors get a
+// targeted message instead of the generic oneOf failure of the stdio/http schema branches.
+type MCPServerIssue struct {
+	Server  string // Server name under mcp-servers
+	Field   string // Offending key, or empty when the whole entry is wrong
+	Message string // Human-readable explanation including a did-you-mean or example
+	// FixRename is the key Field should be renamed to, set only when the correction is
+	// unambiguous (a known alias or a single close match).
+	FixRename string
+	// FixList is the list that should replace a scalar Field value, set when a single
+	// string was given for a list field.
+	FixList []string
+}
+
+// JSONPath returns the JSON path of the issue, e.g. /mcp-servers/notion/args.
+func (i MCPServerIssue) JSONPath() string {
+	if i.Field == "" {
+		return "/mcp-servers/" + i.Server
+	}
+	return "/mcp-servers/" + i.Server + "/" + i.Field
+}
+
+// mcpServerKeyAliases maps keys commonly used in other MCP clients (Claude Desktop,
+// VS Code, Cursor) or older gh-aw versions to their mcp-servers equivalent.
+var mcpServerKeyAliases = map[string]string{
+	"arguments":             "args",
+	"cmd":                   "command",
+	"endpoint":              "url",
+	"environment":           "env",
+	"environment-variables": "env",
+	"envs":                  "env",
+	"header":                "headers",
+	"image":                 "container",
+	"mode":                  "type",
+	"serverUrl":             "url",
+	"tools":                 "allowed",
+	"allowed-tools":         "allowed",
+	"allowedTools":          "allowed",
+	"uri":                   "url",
+}
+
+// Single-line examples appended to messages about a server entry. They use YAML flow
+// style so they survive the one-line-per-failure error summary.
+const (
+	mcpStdioServerExample = `%s: {command: npx, args: ["-y", "@example/mcp-server"], env: {API_KEY: "${{ secrets.API_KEY }}"}, allowed: ["*"]}`
+	mcpHTTPServerExample  = `%s: {type: http, url: "https://api.example.com/mcp", headers: {Authorization: "Bearer ${{ secrets.API_TOKEN }}"}, allowed: ["*"]}`
+)
+
+// mcpServerSchemaProperties returns the properties of the stdio and http server
+// definitions in the main workflow schema, so the diagnostics follow the schema.
+func mcpServerSchemaProperties() (stdio map[string]any, http map[string]any) {
+	doc, err := getParsedSchemaDoc(mainWorkflowSchema)
+	if err != nil {
+		return nil, nil
+	}
+	defs, _ := doc.(map[string]any)["$defs"].(map[string]any)
+	properties := func(name string) map[string]any {
+		def, _ := defs[name].(map[string]any)
+		props, _ := def["properties"].(map[string]any)
+		return props
+	}
+	return properties("stdio_mcp_tool"), properties("http_mcp_tool")
+}
+
+// DiagnoseMCPServers checks the mcp-servers frontmatter value for unknown keys and values
+// of the wrong type and returns one issue per problem, sorted by server and key.
+func DiagnoseMCPServers(mcpServers any) []MCPServerIssue {
+	servers, ok := mcpServers.(map[string]any)
+	if !ok {
+		return nil
+	}
+	stdioProps, httpProps := mcpServerSchemaProperties()
+	if stdioProps == nil || httpProps == nil {
+		return nil
+	}
+
+	var issues []MCPServerIssue
+	for _, name := range slices.Sorted(maps.Keys(servers)) {
+		config, ok := servers[name].(map[string]any)
+		if !ok {
+			issues = append(issues, MCPServerIssue{
+				Server:  name,
+				Message: fmt.Sprintf("MCP server '%s' must be an object with a command, container or url. Example: %s", name, fmt.Sprintf(mcpStdioServerExample, name)),
+			})
+			continue
+		}
+		issues = append(issues, diagnoseMCPServer(name, config, stdioProps, httpProps)...)
+	}
+	mcpServerDiagnosticsLog.Printf("Diagnosed %d mcp-servers entries: %d issue(s)", len(servers), len(issues))
+	return issues
+}
+
+// diagnoseMCPServer checks one server entry against the schema branch it is meant for.
+func diagnoseMCPServer(name string, config map[string]any, stdioProps, httpProps map[string]any) []MCPServerIssue {
+	isHTTP := config["type"] == "http" || config["url"] != nil || config["endpoint"] != nil || config["uri"] != nil
+	props, otherProps, kind, otherKind, example := stdioProps, httpProps, "stdio", "http", mcpStdioServerExample
+	if isHTTP {
+		props, otherProps, kind, otherKind, example = httpProps, stdioProps, "http", "stdio", mcpHTTPServerExample
+	}
+	allKeys := slices.Sorted(maps.Keys(props))
+
+	var issues []MCPServerIssue
+	for _, key := range slices.Sorted(maps.Keys(config)) {
+		prop, known := props[key].(map[string]any)
+		if !known {
+			issues = append(issues, unknownMCPServerKeyIssue(name, key, kind, otherKind, allKeys, otherProps, example))
+			continue
+		}
+		if issue, bad := mcpServerTypeIssue(name, key, config[key], prop); bad {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+// unknownMCPServerKeyIssue explains an unknown key, suggesting the intended key when it
+// is a known alias, a close misspelling, or a key of the other server kind.
+func unknownMCPServerKeyIssue(name, key, kind, otherKind string, allKeys []string, otherProps map[string]any, example string) MCPServerIssue {
+	issue := MCPServerIssue{Server: name, Field: key}
+	field := fmt.Sprintf("'%s' in MCP server '%s'", key, name)
+
+	if alias, ok := mcpServerKeyAliases[key]; ok && slices.Contains(allKeys, alias) {
+		issue.FixRename = alias
+		issue.Message = fmt.Sprintf("Unknown property %s. Did you mean '%s'? Run 'gh aw fix --write' to rename it", field, alias)
+		return issue
+	}
+	if _, ok := otherProps[key]; ok {
+		issue.Message = fmt.Sprintf("Unknown property %s: '%s' only applies to %s MCP servers, but this server is configured as %s. Example: %s", field, key, otherKind, kind, fmt.Sprintf(example, name))
+		return issue
+	}
+	matches := closestMCPServerKeys(key, allKeys)
+	switch len(matches) {
+	case 0:
+		issue.Message = fmt.Sprintf("Unknown property %s. Valid properties for %s MCP servers: %s", field, kind, strings.Join(allKeys, ", "))
+	case 1:
+		issue.FixRename = matches[0]
+		issue.Message = fmt.Sprintf("Unknown property %s. Did you mean '%s'? Run 'gh aw fix --write' to rename it", field, matches[0])
+	default:
+		issue.Message = fmt.Sprintf("Unknown property %s. Did you mean one of: %s?", field, strings.Join(matches, ", "))
+	}
+	return issue
+}
+
+// closestMCPServerKeys returns the valid keys closest to key, also matching misspellings
+// of the known aliases (e.g. "enviroment" -> "environment" -> "env").
+func closestMCPServerKeys(key string, allKeys []string) []string {
+	candidates := slices.Clone(allKeys)
+	for alias, target := range mcpServerKeyAliases {
+		if slices.Contains(allKeys, target) {
+			candidates = append(candidates, alias)
+		}
+	}
+	var matches []string
+	for _, match := range FindClosestMatches(key, candidates, maxClosestMatches) {
+		if target, ok := mcpServerKeyAliases[match]; ok {
+			match = target
+		}
+		if !slices.Contains(matches, match) {
+			matches = append(matches, match)
+		}
+	}
+	return matches
+}
+
+// mcpServerTypeIssue reports a value whose type does not match the schema property.
+func mcpServerTypeIssue(name, key string, value any, prop map[string]any) (MCPServerIssue, bool) {
+	field := fmt.Sprintf("'%s' of MCP server '%s'", key, name)
+	issue := MCPServerIssue{Server: name, Field: key}
+
+	switch schemaType := prop["type"].(type) {
+	case string:
+		if mcpValueHasType(value, schemaType) {
+			return mcpServerEnumIssue(issue, field, value, prop)
+		}
+		if schemaType == "array" {
+			if s, ok := value.(string); ok {
+				issue.FixList = splitMCPListValue(key, s)
+				issue.Message = fmt.Sprintf("%s must be a list, not a string: %s: %s. Run 'gh aw fix --write' to convert it", field, key, formatMCPFlowList(issue.FixList))
+				return issue, true
+			}
+		}
+		issue.Message = fmt.Sprintf("%s must be %s, got %s. Example: %s", field, describeMCPSchemaType(schemaType), describeMCPValueType(value), mcpFieldExample(key, schemaType))
+		return issue, true
+	case []any:
+		for _, t := range schemaType {
+			if s, ok := t.(string); ok && mcpValueHasType(value, s) {
+				return issue, false
+			}
+		}
+		issue.Message = fmt.Sprintf("%s has an unsupported value of type %s", field, describeMCPValueType(value))
+		return issue, true
+	}
+	return issue, false
+}
+
+// mcpServerEnumIssue reports a value that is not one of the values allowed by the schema.
+func mcpServerEnumIssue(issue MCPServerIssue, field string, value any, prop map[string]any) (MCPServerIssue, bool) {
+	enum, ok := prop["enum"].([]any)
+	if !ok || slices.Contains(enum, value) {
+		return issue, false
+	}
+	var allowed []string
+	for _, v := range enum {
+		allowed = append(allowed, fmt.Sprint(v))
+	}
+	issue.Message = fmt.Sprintf("%s must be one of: %s", field, strings.Join(allowed, ", "))
+	if s, ok := value.(string); ok {
+		if matches := FindClosestMatches(s, allowed, 1); len(matches) == 1 {
+			issue.Message += fmt.Sprintf(". Did you mean '%s'?", matches[0])
+		}
+	}
+	return issue, true
+}
+
+// mcpValueHasType reports whether a YAML value matches a JSON schema type.
+func mcpValueHasType(value any, schemaType string) bool {
+	switch schemaType {
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "integer", "number":
+		switch v := value.(type) {
+		case int, int64, uint64, uint32, int32:
+			return true
+		case float64:
+			return schemaType == "number" || v == float64(int64(v))
+		}
+		return false
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "array":
+		_, ok := value.([]any)
+		return ok
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok
+	}
+	return true
+}
+
+// splitMCPListValue converts a string given for a list field into list items: command
+// lines for args are split on whitespace, tool lists on commas.
+func splitMCPListValue(key, value string) []string {
+	var parts []string
+	if key == "args" || key == "entrypointArgs" || key == "proxy-args" {
+		parts = strings.Fields(value)
+	} else {
+		for part := range strings.SplitSeq(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				parts = append(parts, part)
+			}
+		}
+	}
+	if len(parts) == 0 {
+		return []string{value}
+	}
+	return parts
+}
+
+// formatMCPFlowList formats list items as a YAML flow sequence of double-quoted strings.
+func formatMCPFlowList(items []string) string {
+	quoted := make([]string, len(items))
+	for i, item := range items {
+		quoted[i] = fmt.Sprintf("%q", item)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+func describeMCPSchemaType(schemaType string) string {
+	switch schemaType {
+	case "array":
+		return "a list"
+	case "object":
+		return "a mapping of names to values"
+	case "integer":
+		return "a whole number"
+	}
+	return "a " + schemaType
+}
+
+func describeMCPValueType(value any) string {
+	switch value.(type) {
+	case nil:
+		return "an empty value"
+	case string:
+		return "a string"
+	case bool:
+		return "a boolean"
+	case []any:
+		return "a list"
+	case map[string]any:
+		return "a mapping"
+	}
+	return "a number"
+}
+
+// mcpFieldExample returns a one-line example of a correctly typed field.
+func mcpFieldExample(key, schemaType string) string {
+	switch key {
+	case "env":
+		return `env: {API_KEY: "${{ secrets.API_KEY }}"}`
+	case "headers":
+		return `headers: {Authorization: "Bearer ${{ secrets.API_TOKEN }}"}`
+	case "timeout", "startup-timeout":
+		return key + ": 120 (seconds)"
+	}
+	switch schemaType {
+	case "array":
+		return key + ": [\"item\"]"
+	case "object":
+		return key + ": {name: value}"
+	case "integer", "number":
+		return key + ": 3"
+	case "boolean":
+		return key + ": true"
+	}
+	return key + ": value"
+}
+
+// formatMCPServerIssuesWithLocation returns a located compiler error describing the
+// problems in the mcp-servers section of frontmatter, or nil when there are none.
+func formatMCPServerIssuesWithLocation(frontmatter map[string]any, filePath string) error {
+	issues := DiagnoseMCPServers(frontmatter["mcp-servers"])
+	if len(issues) == 0 {
+		return nil
+	}
+	ctx := readFrontmatterContext(filePath)
+	if ctx.frontmatterContent == "" {
+		return nil
+	}
+
+	// Report issues in file order so the error header points at the first one
+	locations := make([]JSONPathLocation, len(issues))
+	for i, issue := range issues {
+		locations[i] = LocateJSONPathInYAML(ctx.frontmatterContent, issue.JSONPath())
+		if !locations[i].Found {
+			return nil
+		}
+	}
+	order := make([]int, len(issues))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int { return locations[a].Line - locations[b].Line })
+
+	detailLines := make([]string, 0, len(issues))
+	for _, i := range order {
+		line := locations[i].Line + ctx.frontmatterStart - 1
+		detailLines = append(detailLines, fmt.Sprintf("'%s' (line %d, col %d): %s", strings.TrimPrefix(issues[i].JSONPath(), "/"), line, locations[i].Column, issues[i].Message))
+	}
+	first := locations[order[0]]
+	adjustedLine := first.Line + ctx.frontmatterStart - 1
+	return formatCompilerErrorWithLocation(filePath, adjustedLine, first.Column, formatSchemaDetailMessage(detailLines), buildAdjustedContextLines(ctx, adjustedLine))
+}
@@ -0,0 +1,124 @@
+//go:build !integration
+
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func findMCPServerIssue(issues []MCPServerIssue, server, field string) (MCPServerIssue, bool) {
+	for _, issue := range issues {
+		if issue.Server == server && issue.Field == field {
+			return issue, true
+		}
+	}
+	return MCPServerIssue{}, false
+}
+
+func TestDiagnoseMCPServers(t *testing.T) {
+	t.Run("misspelled key suggests rename", func(t *testing.T) {
+		issues := DiagnoseMCPServers(map[string]any{
+			"notion": map[string]any{"comand": "npx"},
+		})
+		issue, ok := findMCPServerIssue(issues, "notion", "comand")
+		require.True(t, ok, "misspelled key should be reported")
+		assert.Equal(t, "command", issue.FixRename, "misspelling should be renamed to command")
+		assert.Contains(t, issue.Message, "Did you mean 'command'?", "message should include the suggestion")
+		assert.Equal(t, "/mcp-servers/notion/comand", issue.JSONPath(), "unexpected JSON path")
+	})
+
+	t.Run("aliases and misspelled aliases map to env", func(t *testing.T) {
+		issues := DiagnoseMCPServers(map[string]any{
+			"a": map[string]any{"command": "npx", "environment": map[string]any{}},
+			"b": map[string]any{"command": "npx", "enviroment": map[string]any{}},
+		})
+		issue, ok := findMCPServerIssue(issues, "a", "environment")
+		require.True(t, ok, "alias should be reported")
+		assert.Equal(t, "env", issue.FixRename, "alias should be renamed to env")
+		issue, ok = findMCPServerIssue(issues, "b", "enviroment")
+		require.True(t, ok, "misspelled alias should be reported")
+		assert.Equal(t, "env", issue.FixRename, "misspelled alias should be renamed to env")
+	})
+
+	t.Run("string given for a list is converted", func(t *testing.T) {
+		issues := DiagnoseMCPServers(map[string]any{
+			"notion": map[string]any{"command": "npx", "args": "-y @notion/server", "allowed": "search, fetch"},
+		})
+		issue, ok := findMCPServerIssue(issues, "notion", "args")
+		require.True(t, ok, "string args should be reported")
+		assert.Equal(t, []string{"-y", "@notion/server"}, issue.FixList, "args should be split on whitespace")
+		assert.Contains(t, issue.Message, `args: ["-y", "@notion/server"]`, "message should include the list form")
+		issue, ok = findMCPServerIssue(issues, "notion", "allowed")
+		require.True(t, ok, "string allowed should be reported")
+		assert.Equal(t, []string{"search", "fetch"}, issue.FixList, "allowed should be split on commas")
+	})
+
+	t.Run("enum value suggests closest value", func(t *testing.T) {
+		issues := DiagnoseMCPServers(map[string]any{
+			"notion": map[string]any{"url": "https://example.com/mcp", "type": "htp"},
+		})
+		issue, ok := findMCPServerIssue(issues, "notion", "type")
+		require.True(t, ok, "invalid type should be reported")
+		assert.Contains(t, issue.Message, "Did you mean 'http'?", "message should suggest the closest value")
+	})
+
+	t.Run("http-only key on a stdio server is explained", func(t *testing.T) {
+		issues := DiagnoseMCPServers(map[string]any{
+			"notion": map[string]any{"command": "npx", "headers": map[string]any{}},
+		})
+		issue, ok := findMCPServerIssue(issues, "notion", "headers")
+		require.True(t, ok, "http-only key should be reported")
+		assert.Empty(t, issue.FixRename, "no mechanical fix should be offered")
+		assert.Contains(t, issue.Message, "only applies to http MCP servers", "message should explain the server kind")
+	})
+
+	t.Run("non-object server entry", func(t *testing.T) {
+		issues := DiagnoseMCPServers(map[string]any{"notion": "npx"})
+		require.Len(t, issues, 1, "non-object entry should be reported once")
+		assert.Equal(t, "/mcp-servers/notion", issues[0].JSONPath(), "issue should point at the server")
+		assert.Contains(t, issues[0].Message, "must be an object", "unexpected message")
+	})
+
+	t.Run("valid servers have no issues", func(t *testing.T) {
+		issues := DiagnoseMCPServers(map[string]any{
+			"notion": map[string]any{"command": "npx", "args": []any{"-y", "@notion/server"}, "env": map[string]any{"TOKEN": "x"}},
+			"remote": map[string]any{"url": "https://example.com/mcp", "headers": map[string]any{"Authorization": "Bearer x"}},
+		})
+		assert.Empty(t, issues, "valid servers should not be reported")
+		assert.Nil(t, DiagnoseMCPServers("not-a-map"), "non-map input should be ignored")
+	})
+}
+
+func TestFormatMCPServerIssuesWithLocation(t *testing.T) {
+	content := `---
+on: push
+mcp-servers:
+  notion:
+    comand: npx
+    args: -y @notion/server
+---
+
+# Test
+`
+	filePath := filepath.Join(t.TempDir(), "test.md")
+	require.NoError(t, os.WriteFile(filePath, []byte(content), 0644), "failed to write test file")
+
+	frontmatter := map[string]any{
+		"on": "push",
+		"mcp-servers": map[string]any{
+			"notion": map[string]any{"comand": "npx", "args": "-y @notion/server"},
+		},
+	}
+	err := ValidateMainWorkflowFrontmatterWithSchemaAndLocation(frontmatter, filePath)
+	require.Error(t, err, "invalid mcp-servers should fail validation")
+	assert.Contains(t, err.Error(), "Did you mean 'command'?", "error should include the rename suggestion")
+	assert.Contains(t, err.Error(), "must be a list, not a string", "error should include the list conversion")
+	assert.Contains(t, err.Error(), ":5:", "error should point at the first issue line")
+
+	assert.NoError(t, formatMCPServerIssuesWithLocation(map[string]any{"on": "push"}, filePath), "no mcp-servers means no targeted error")
+}
@@ -256,7 +256,7 @@ func TestExtractMCPConfigurations(t *testing.T) {
 					Command: "docker",
 					Args: []string{
 						"run", "-i", "--rm", "--shm-size=2gb", "--cap-add=SYS_ADMIN",
-						"-v", "/tmp/gh-aw/mcp-logs:/tmp/gh-aw/mcp-logs",
+						"-v", "${{ env.GH_AW_TMP_DIR }}/mcp-logs:${{ env.GH_AW_TMP_DIR }}/mcp-logs",
 						"mcr.microsoft.com/playwright:" + string(constants.DefaultPlaywrightBrowserVersion),
 					},
 					Env: map[string]string{}}, Name: "playwright",
@@ -277,7 +277,7 @@ func TestExtractMCPConfigurations(t *testing.T) {
 					Command: "docker",
 					Args: []string{
 						"run", "-i", "--rm", "--shm-size=2gb", "--cap-add=SYS_ADMIN",
-						"-v", "/tmp/gh-aw/mcp-logs:/tmp/gh-aw/mcp-logs",
+						"-v", "${{ env.GH_AW_TMP_DIR }}/mcp-logs:${{ env.GH_AW_TMP_DIR }}/mcp-logs",
 						"mcr.microsoft.com/playwright:v1.41.0",
 					},
 					Env: map[string]string{}}, Name: "playwright",
@@ -298,7 +298,7 @@ func TestExtractMCPConfigurations(t *testing.T) {
 					Command: "docker",
 					Args: []string{
 						"run", "-i", "--rm", "--shm-size=2gb", "--cap-add=SYS_ADMIN",
-						"-v", "/tmp/gh-aw/mcp-logs:/tmp/gh-aw/mcp-logs",
+						"-v", "${{ env.GH_AW_TMP_DIR }}/mcp-logs:${{ env.GH_AW_TMP_DIR }}/mcp-logs",
 						"mcr.microsoft.com/playwright:20",
 					},
 					Env: map[string]string{}}, Name: "playwright",
@@ -319,7 +319,7 @@ func TestExtractMCPConfigurations(t *testing.T) {
 					Command: "docker",
 					Args: []string{
 						"run", "-i", "--rm", "--shm-size=2gb", "--cap-add=SYS_ADMIN",
-						"-v", "/tmp/gh-aw/mcp-logs:/tmp/gh-aw/mcp-logs",
+						"-v", "${{ env.GH_AW_TMP_DIR }}/mcp-logs:${{ env.GH_AW_TMP_DIR }}/mcp-logs",
 						"mcr.microsoft.com/playwright:1.41",
 					},
 					Env: map[string]string{}}, Name: "playwright",
@@ -340,7 +340,7 @@ func TestExtractMCPConfigurations(t *testing.T) {
 					Command: "docker",
 					Args: []string{
 						"run", "-i", "--rm", "--shm-size=2gb", "--cap-add=SYS_ADMIN",
-						"-v", "/tmp/gh-aw/mcp-logs:/tmp/gh-aw/mcp-logs",
+						"-v", "${{ env.GH_AW_TMP_DIR }}/mcp-logs:${{ env.GH_AW_TMP_DIR }}/mcp-logs",
 						"mcr.microsoft.com/playwright:142",
 					},
 					Env: map[string]string{}}, Name: "playwright",
@@ -541,6 +541,35 @@ func TestParseMCPConfig(t *testing.T) {
 				Allowed: []string{},
 			},
 		},
+		{
+			name:     "HTTP server with sse transport",
+			toolName: "sse-server",
+			mcpSection: map[string]any{
+				"type":      "http",
+				"url":       "https://mcp.example.com/sse",
+				"transport": "sse",
+			},
+			toolConfig: map[string]any{},
+			expected: RegistryMCPServerConfig{BaseMCPServerConfig: types.BaseMCPServerConfig{Type: "http",
+				URL:       "https://mcp.example.com/sse",
+				Transport: "sse",
+				Headers:   map[string]string{},
+				Env:       map[string]string{}}, Name: "sse-server",
+
+				Allowed: []string{},
+			},
+		},
+		{
+			name:     "HTTP server with unsupported transport",
+			toolName: "ws-server",
+			mcpSection: map[string]any{
+				"type":      "http",
+				"url":       "https://mcp.example.com/ws",
+				"transport": "websocket",
+			},
+			toolConfig:  map[string]any{},
+			expectError: true,
+		},
 		{
 			name:     "HTTP server with underscored headers",
 			toolName: "datadog-server",
@@ -831,6 +860,9 @@ func TestParseMCPConfig(t *testing.T) {
 			if result.URL != tt.expected.URL {
 				t.Errorf("Expected URL %q, got %q", tt.expected.URL, result.URL)
 			}
+			if result.Transport != tt.expected.Transport {
+				t.Errorf("Expected transport %q, got %q", tt.expected.Transport, result.Transport)
+			}
 			// For Docker containers, the environment variable order in args may vary
 			// due to map iteration order, so check for presence rather than exact order
 			if result.Container != "" {
@@ -1006,3 +1038,17 @@ func TestValidMCPTypes(t *testing.T) {
 		}
 	}
 }
+
+// TestIsMCPTransport tests the IsMCPTransport function
+func TestIsMCPTransport(t *testing.T) {
+	for _, transport := range ValidMCPTransports {
+		if !IsMCPTransport(transport) {
+			t.Errorf("IsMCPTransport(%q) should return true for transport in ValidMCPTransports", transport)
+		}
+	}
+	for _, transport := range []string{"", "http", "websocket", "SSE"} {
+		if IsMCPTransport(transport) {
+			t.Errorf("IsMCPTransport(%q) = true, want false", transport)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package parser
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// offlineMode disables every remote lookup performed while resolving imports.
+// When set, remote imports are served exclusively from the import cache in .github/aw/imports.
+var offlineMode atomic.Bool
+
+// SetOfflineMode enables or disables offline import resolution.
+func SetOfflineMode(offline bool) {
+	offlineMode.Store(offline)
+}
+
+// IsOfflineMode reports whether offline import resolution is enabled.
+func IsOfflineMode() bool {
+	return offlineMode.Load()
+}
+
+// NewOfflineError returns the error reported when an operation needs the network
+// while offline mode is enabled.
+func NewOfflineError(operation string) error {
+	return fmt.Errorf("%s requires network access, which is disabled by --offline", operation)
+}
@@ -0,0 +1,75 @@
+//go:build !integration
+
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownloadIncludeFromWorkflowSpecOffline(t *testing.T) {
+	SetOfflineMode(true)
+	t.Cleanup(func() { SetOfflineMode(false) })
+
+	const sha = "0123456789abcdef0123456789abcdef01234567"
+	cache := NewImportCache(t.TempDir())
+	cachedPath, err := cache.Set("octo", "shared", "workflows/shared/tools.md", sha, []byte("# Tools\n"))
+	require.NoError(t, err, "should seed the import cache")
+
+	path, err := downloadIncludeFromWorkflowSpec("octo/shared/workflows/shared/tools.md@"+sha, cache)
+	require.NoError(t, err, "pinned SHA should be served from the cache")
+	assert.Equal(t, cachedPath, path, "cached file should be returned")
+
+	path, err = downloadIncludeFromWorkflowSpec("octo/shared/workflows/shared/tools.md@main", cache)
+	require.NoError(t, err, "branch ref should use the only cached revision")
+	assert.Equal(t, cachedPath, path, "cached file should be returned for a branch ref")
+
+	_, err = cache.Set("octo", "shared", "workflows/shared/tools.md", strings.Repeat("f", 40), []byte("# Tools v2\n"))
+	require.NoError(t, err, "should seed a second revision")
+	_, err = downloadIncludeFromWorkflowSpec("octo/shared/workflows/shared/tools.md@main", cache)
+	require.Error(t, err, "ambiguous cached revisions should not be guessed")
+	assert.Contains(t, err.Error(), "disabled by --offline", "error should explain offline mode")
+
+	_, err = downloadIncludeFromWorkflowSpec("octo/shared/workflows/shared/missing.md@"+sha, cache)
+	require.Error(t, err, "uncached import should fail")
+	assert.Contains(t, err.Error(), ImportCacheDir, "error should name the import cache")
+}
+
+func TestRemoteLookupsFailOffline(t *testing.T) {
+	SetOfflineMode(true)
+	t.Cleanup(func() { SetOfflineMode(false) })
+
+	_, err := resolveRefToSHA(t.Context(), "octo", "shared", "main", "")
+	require.Error(t, err, "ref resolution should not reach the network")
+	assert.Contains(t, err.Error(), "resolving octo/shared@main requires network access", "error should name the lookup")
+
+	sha := strings.Repeat("a", 40)
+	resolved, err := resolveRefToSHA(t.Context(), "octo", "shared", sha, "")
+	require.NoError(t, err, "full SHAs need no lookup")
+	assert.Equal(t, sha, resolved, "full SHA should be returned unchanged")
+
+	_, err = downloadFileFromGitHub(t.Context(), "octo", "shared", "README.md", "main")
+	require.Error(t, err, "file download should not reach the network")
+	assert.Contains(t, err.Error(), "disabled by --offline", "error should explain offline mode")
+}
+
+func TestImportCacheGetAnyRevision(t *testing.T) {
+	dir := t.TempDir()
+	cache := NewImportCache(dir)
+
+	_, found := cache.GetAnyRevision("octo", "shared", "tools.md")
+	assert.False(t, found, "empty cache should miss")
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ImportCacheDir, "octo", "shared", "abc"), 0o755), "should create revision dir")
+	expected := filepath.Join(dir, ImportCacheDir, "octo", "shared", "abc", "tools.md")
+	require.NoError(t, os.WriteFile(expected, []byte("x"), 0o644), "should write cached file")
+
+	path, found := cache.GetAnyRevision("octo", "shared", "tools.md")
+	assert.True(t, found, "single revision should hit")
+	assert.Equal(t, expected, path, "cached path should match")
+}
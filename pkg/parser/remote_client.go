@@ -23,6 +23,9 @@ var remoteLog = logger.New("parser:remote_fetch")
 var publicAPIClient = &http.Client{Timeout: constants.DefaultHTTPClientTimeout}
 
 func createRESTClientForHost(host string) (*api.RESTClient, error) {
+	if IsOfflineMode() {
+		return nil, NewOfflineError("the GitHub API")
+	}
 	opts := api.ClientOptions{Timeout: constants.DefaultHTTPClientTimeout}
 	if host != "" {
 		opts.Host = host
@@ -48,6 +48,9 @@ func downloadFileFromGitHub(ctx context.Context, owner, repo, path, ref string)
 }
 
 func downloadFileFromGitHubWithDepth(ctx context.Context, owner, repo, path, ref string, symlinkDepth int, host string) ([]byte, error) {
+	if IsOfflineMode() {
+		return nil, NewOfflineError(fmt.Sprintf("downloading %s/%s/%s@%s", owner, repo, path, ref))
+	}
 	client, err := createRESTClientForHost(host)
 	if err != nil {
 		if gitutil.IsAuthError(err.Error()) {
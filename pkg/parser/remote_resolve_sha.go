@@ -84,6 +84,9 @@ func resolveRefToSHA(ctx context.Context, owner, repo, ref, host string) (string
 	if len(ref) == 40 && gitutil.IsHexString(ref) {
 		return ref, nil
 	}
+	if IsOfflineMode() {
+		return "", NewOfflineError(fmt.Sprintf("resolving %s/%s@%s", owner, repo, ref))
+	}
 
 	client, err := createRESTClientForHostFunc(host)
 	if err != nil {
@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"os"
 	"strings"
+
+	"github.com/github/gh-aw/pkg/gitutil"
 )
 
 // IsWorkflowSpec checks if a path looks like a workflowspec (owner/repo/path[@ref]).
@@ -75,6 +77,10 @@ func downloadIncludeFromWorkflowSpec(spec string, cache *ImportCache) (string, e
 	}
 	remoteLog.Printf("Parsed workflowspec: host=%s, owner=%s, repo=%s, file=%s, ref=%s", host, owner, repo, filePath, ref)
 
+	if IsOfflineMode() {
+		return resolveWorkflowSpecFromCacheOffline(spec, owner, repo, filePath, ref, cache)
+	}
+
 	sha := resolveWorkflowSpecSHAForCache(owner, repo, ref, host, cache)
 	if cache != nil && sha != "" {
 		if cachedPath, found := cache.Get(owner, repo, filePath, sha); found {
@@ -107,6 +113,22 @@ func downloadIncludeFromWorkflowSpec(spec string, cache *ImportCache) (string, e
 	return writeDownloadedIncludeToTempFile(content)
 }
 
+// resolveWorkflowSpecFromCacheOffline serves a remote import from the import cache without
+// touching the network. Pinned SHAs are looked up directly; branch and tag refs are accepted
+// only when exactly one cached revision of the file exists.
+func resolveWorkflowSpecFromCacheOffline(spec, owner, repo, filePath, ref string, cache *ImportCache) (string, error) {
+	if cache != nil {
+		if len(ref) == 40 && gitutil.IsHexString(ref) {
+			if cachedPath, found := cache.Get(owner, repo, filePath, ref); found {
+				return cachedPath, nil
+			}
+		} else if cachedPath, found := cache.GetAnyRevision(owner, repo, filePath); found {
+			return cachedPath, nil
+		}
+	}
+	return "", fmt.Errorf("import %s is not in the import cache (%s): %w", spec, ImportCacheDir, NewOfflineError("downloading it"))
+}
+
 func parseWorkflowSpecParts(spec string) (string, string, string, string, string, error) {
 	cleanSpec := spec
 	if before, _, ok := strings.Cut(spec, "#"); ok {
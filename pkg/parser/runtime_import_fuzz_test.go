@@ -137,6 +137,8 @@ try {
 		tmpFile.Close()
 
 		cmd := exec.Command(nodePath, tmpFile.Name(), expression)
+		// runtime_import.cjs requires the scratch directory that setup.sh exports.
+		cmd.Env = append(os.Environ(), "GH_AW_TMP_DIR="+t.TempDir())
 		output, err := cmd.CombinedOutput()
 		if err != nil {
 			// Command execution failure is acceptable for fuzz testing
@@ -291,6 +293,8 @@ try {
 		tmpFile.Close()
 
 		cmd := exec.Command(nodePath, tmpFile.Name(), content)
+		// runtime_import.cjs requires the scratch directory that setup.sh exports.
+		cmd.Env = append(os.Environ(), "GH_AW_TMP_DIR="+t.TempDir())
 		output, err := cmd.CombinedOutput()
 		if err != nil {
 			// Command execution failure is acceptable
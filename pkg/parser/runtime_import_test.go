@@ -217,6 +217,8 @@ console.log(JSON.stringify({ safe: result }));
 
 			// Run the test script
 			cmd := exec.Command(nodePath, tmpFile.Name(), tt.expression)
+			// runtime_import.cjs requires the scratch directory that setup.sh exports.
+			cmd.Env = append(os.Environ(), "GH_AW_TMP_DIR="+t.TempDir())
 			// Use Output() to only capture stdout, avoiding stderr like [one-shot-token]
 			output, err := cmd.Output()
 			if err != nil {
@@ -339,6 +341,8 @@ try {
 			tmpFile.Close()
 
 			cmd := exec.Command(nodePath, tmpFile.Name(), tt.content)
+			// runtime_import.cjs requires the scratch directory that setup.sh exports.
+			cmd.Env = append(os.Environ(), "GH_AW_TMP_DIR="+t.TempDir())
 			// Use Output() to only capture stdout, avoiding stderr like [one-shot-token]
 			output, err := cmd.Output()
 			if err != nil {
@@ -505,6 +509,8 @@ const { processRuntimeImport } = require('` + runtimeImportPath + `');
 			tmpFile.Close()
 
 			cmd := exec.Command(nodePath, tmpFile.Name())
+			// runtime_import.cjs requires the scratch directory that setup.sh exports.
+			cmd.Env = append(os.Environ(), "GH_AW_TMP_DIR="+t.TempDir())
 			// Use Output() to only capture stdout, avoiding stderr like [one-shot-token]
 			output, err := cmd.Output()
 			if err != nil {
@@ -32,6 +32,16 @@ var RepoConfigSchema string
 //go:embed schemas/aw_manifest_schema.json
 var awManifestSchema string
 
+// MainWorkflowSchemaJSON returns the JSON schema used to validate workflow frontmatter.
+func MainWorkflowSchemaJSON() string {
+	return mainWorkflowSchema
+}
+
+// MCPConfigSchemaJSON returns the JSON schema used to validate MCP server configurations.
+func MCPConfigSchemaJSON() string {
+	return mcpConfigSchema
+}
+
 // validateWithSchema validates frontmatter against a JSON schema
 // Cached compiled schemas to avoid recompiling on every validation
 var (
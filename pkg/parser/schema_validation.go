@@ -124,6 +124,10 @@ func ValidateMainWorkflowFrontmatterWithSchemaAndLocation(frontmatter map[string
 
 	// Then run the standard schema validation with location
 	if err := validateWithSchemaAndLocation(filtered, mainWorkflowSchema, "main workflow file", filePath); err != nil {
+		// Replace the generic oneOf failure of mcp-servers entries with targeted fix-its
+		if mcpErr := formatMCPServerIssuesWithLocation(filtered, filePath); mcpErr != nil {
+			return mcpErr
+		}
 		return err
 	}
 
@@ -158,6 +162,9 @@ func ValidateIncludedFileFrontmatterWithSchemaAndLocation(frontmatter map[string
 
 	// Validate with the main schema (which will catch unknown fields)
 	if err := validateWithSchemaAndLocation(tempFrontmatter, mainWorkflowSchema, "included file", filePath); err != nil {
+		if mcpErr := formatMCPServerIssuesWithLocation(tempFrontmatter, filePath); mcpErr != nil {
+			return mcpErr
+		}
 		return err
 	}
 
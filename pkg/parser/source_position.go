@@ -0,0 +1,190 @@
+package parser
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// fieldPathIndexPattern matches bracketed array indexes in a dotted field path ("steps[2]").
+var fieldPathIndexPattern = regexp.MustCompile(`\[(\d+)\]`)
+
+// LocateFrontmatterPath returns the absolute 1-based line and column of a dotted frontmatter
+// field path such as "safe-outputs.create-issue.max" or "jobs.build.steps[1]".
+// When the full path cannot be found, the deepest enclosing key that can be found is used,
+// so errors on values synthesized from defaults still point at their section.
+// It returns 0, 0 when not even the top-level key is present.
+//
+// frontmatterLines are the frontmatter lines without delimiters, and frontmatterStart is the
+// absolute line number of the first of them (see FrontmatterResult).
+func LocateFrontmatterPath(frontmatterLines []string, frontmatterStart int, fieldPath string) (int, int) {
+	if len(frontmatterLines) == 0 || fieldPath == "" {
+		return 0, 0
+	}
+	segments := strings.Split(fieldPathIndexPattern.ReplaceAllString(fieldPath, ".$1"), ".")
+
+	line, column, matched := -1, 0, 0
+	blockStart, blockEnd := 0, len(frontmatterLines)
+	for _, segment := range segments {
+		childIndent := firstYAMLContentIndent(frontmatterLines, blockStart, blockEnd)
+		if childIndent < 0 {
+			break
+		}
+		found := -1
+		if index, err := strconv.Atoi(segment); err == nil {
+			found = findYAMLListItem(frontmatterLines, blockStart, blockEnd, index)
+			if found >= 0 {
+				dashIndent := yamlIndent(frontmatterLines[found])
+				line, column = found, dashIndent+1
+				blockStart, blockEnd = found, yamlListItemEnd(frontmatterLines, found+1, blockEnd, dashIndent)
+			}
+		}
+		if found < 0 {
+			found = findYAMLKey(frontmatterLines, blockStart, blockEnd, childIndent, segment)
+			if found < 0 {
+				break
+			}
+			line, column = found, childIndent+1
+			blockStart, blockEnd = found+1, yamlBlockEnd(frontmatterLines, found+1, blockEnd, childIndent)
+		}
+		matched++
+	}
+	if line < 0 {
+		return 0, 0
+	}
+	jsonPathLog.Printf("Located frontmatter path %s at relative line %d (matched %d/%d segments)", fieldPath, line+1, matched, len(segments))
+	return line + frontmatterStart, column
+}
+
+// yamlIndent returns the number of leading spaces of a line.
+func yamlIndent(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// yamlKeyIndent returns the column (0-based) at which the content of a line starts once
+// list item markers are skipped, so "  - name: x" has its key at indent 4.
+func yamlKeyIndent(line string) int {
+	indent := yamlIndent(line)
+	for strings.HasPrefix(line[indent:], "- ") {
+		indent += 2
+		indent += yamlIndent(line[indent:])
+	}
+	return indent
+}
+
+func isYAMLContentLine(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return trimmed != "" && !strings.HasPrefix(trimmed, "#")
+}
+
+// firstYAMLContentIndent returns the key indent of the first content line in [start, end), or -1.
+func firstYAMLContentIndent(lines []string, start, end int) int {
+	for i := start; i < end; i++ {
+		if isYAMLContentLine(lines[i]) {
+			return yamlKeyIndent(lines[i])
+		}
+	}
+	return -1
+}
+
+// yamlBlockEnd returns the end (exclusive) of the block nested under a line whose key or list
+// marker is at parentIndent. Sequences may be indented at the same level as their parent key.
+func yamlBlockEnd(lines []string, start, end, parentIndent int) int {
+	for i := start; i < end; i++ {
+		if !isYAMLContentLine(lines[i]) {
+			continue
+		}
+		indent := yamlIndent(lines[i])
+		if indent < parentIndent || (indent == parentIndent && !strings.HasPrefix(lines[i][indent:], "- ") && lines[i][indent:] != "-") {
+			return i
+		}
+	}
+	return end
+}
+
+// yamlListItemEnd returns the end (exclusive) of a list item whose marker is at dashIndent.
+func yamlListItemEnd(lines []string, start, end, dashIndent int) int {
+	for i := start; i < end; i++ {
+		if isYAMLContentLine(lines[i]) && yamlIndent(lines[i]) <= dashIndent {
+			return i
+		}
+	}
+	return end
+}
+
+// findYAMLKey returns the line in [start, end) holding key at the given key indent, or -1.
+func findYAMLKey(lines []string, start, end, indent int, key string) int {
+	for i := start; i < end; i++ {
+		if !isYAMLContentLine(lines[i]) || yamlKeyIndent(lines[i]) != indent {
+			continue
+		}
+		rest := strings.Trim(lines[i][indent:], " ")
+		for _, quote := range []string{"", `"`, "'"} {
+			if candidate, ok := strings.CutPrefix(rest, quote+key+quote); ok && strings.HasPrefix(strings.TrimLeft(candidate, " "), ":") {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// findYAMLListItem returns the line of the index-th (0-based) list item in [start, end), or -1.
+func findYAMLListItem(lines []string, start, end, index int) int {
+	itemIndent := -1
+	count := 0
+	for i := start; i < end; i++ {
+		if !isYAMLContentLine(lines[i]) {
+			continue
+		}
+		indent := yamlIndent(lines[i])
+		if itemIndent < 0 {
+			itemIndent = indent
+		}
+		if indent != itemIndent || !strings.HasPrefix(strings.TrimLeft(lines[i], " "), "-") {
+			continue
+		}
+		if count == index {
+			return i
+		}
+		count++
+	}
+	return -1
+}
+
+// LocateMarkdownText returns the absolute 1-based line and column of the first occurrence of
+// text in the markdown body, or 0, 0 when it does not occur.
+//
+// markdownStart is the absolute line number of the first markdown line (see FrontmatterResult).
+func LocateMarkdownText(markdown string, markdownStart int, text string) (int, int) {
+	if text == "" {
+		return 0, 0
+	}
+	return LocateMarkdownOffset(markdown, markdownStart, strings.Index(markdown, text))
+}
+
+// LocateMarkdownOffset converts a byte offset in the markdown body to an absolute 1-based
+// line and column. It returns 0, 0 for a negative offset or an unknown markdownStart.
+func LocateMarkdownOffset(markdown string, markdownStart int, offset int) (int, int) {
+	if offset < 0 || offset > len(markdown) || markdownStart <= 0 {
+		return 0, 0
+	}
+	before := markdown[:offset]
+	line := markdownStart + strings.Count(before, "\n")
+	column := offset - strings.LastIndexByte(before, '\n')
+	return line, column
+}
+
+// markdownStartLine returns the absolute 1-based line of the first non-blank line of the markdown
+// body, given the content and the byte offset at which the body begins.
+func markdownStartLine(content string, bodyOffset int) int {
+	if bodyOffset > len(content) {
+		return 0
+	}
+	body := content[bodyOffset:]
+	trimmed := strings.TrimLeft(body, " \t\r\n")
+	if trimmed == "" {
+		return 0
+	}
+	leading := body[:len(body)-len(trimmed)]
+	return strings.Count(content[:bodyOffset], "\n") + strings.Count(leading, "\n") + 1
+}
@@ -0,0 +1,79 @@
+//go:build !integration
+
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocateFrontmatterPath(t *testing.T) {
+	frontmatter := strings.Split(`on:
+  issues:
+    types: [opened]
+safe-outputs:
+  add-comment:
+    max: 2
+  create-issue:
+    # comment
+    max: 0
+steps:
+- name: First
+  run: echo one
+- name: Second
+  run: echo two
+jobs:
+  build:
+    steps:
+      - uses: actions/checkout@v4
+      - name: Test
+        run: make test`, "\n")
+
+	tests := []struct {
+		name           string
+		path           string
+		expectedLine   int
+		expectedColumn int
+	}{
+		{name: "top-level key", path: "safe-outputs", expectedLine: 5, expectedColumn: 1},
+		{name: "nested key under the right parent", path: "safe-outputs.create-issue.max", expectedLine: 10, expectedColumn: 5},
+		{name: "missing key falls back to parent", path: "safe-outputs.create-issue.labels", expectedLine: 8, expectedColumn: 3},
+		{name: "list item at parent indent", path: "steps[1]", expectedLine: 14, expectedColumn: 1},
+		{name: "key in list item", path: "steps[1].run", expectedLine: 15, expectedColumn: 3},
+		{name: "indented list item key", path: "jobs.build.steps[1].run", expectedLine: 21, expectedColumn: 9},
+		{name: "first key on the item line", path: "jobs.build.steps[0].uses", expectedLine: 19, expectedColumn: 9},
+		{name: "missing top-level key", path: "network.allowed", expectedLine: 0, expectedColumn: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			line, column := LocateFrontmatterPath(frontmatter, 2, tt.path)
+			assert.Equal(t, tt.expectedLine, line, "line should match for %s", tt.path)
+			assert.Equal(t, tt.expectedColumn, column, "column should match for %s", tt.path)
+		})
+	}
+}
+
+func TestLocateMarkdownText(t *testing.T) {
+	markdown := "# Title\n\nUse ${{ secrets.X }} here"
+	line, column := LocateMarkdownText(markdown, 10, "${{ secrets.X }}")
+	assert.Equal(t, 12, line, "line should be offset by the markdown start")
+	assert.Equal(t, 5, column, "column should be 1-based")
+
+	line, column = LocateMarkdownText(markdown, 10, "missing")
+	assert.Zero(t, line, "missing text should not be located")
+	assert.Zero(t, column, "missing text should not be located")
+}
+
+func TestExtractFrontmatterFromContent_MarkdownStart(t *testing.T) {
+	result, err := ExtractFrontmatterFromContent("---\non: issues\n---\n\n\n# Task\nDo it\n")
+	require.NoError(t, err, "frontmatter should parse")
+	assert.Equal(t, 6, result.MarkdownStart, "markdown should start at the first non-blank line")
+
+	result, err = ExtractFrontmatterFromContent("# Task\n")
+	require.NoError(t, err, "content without frontmatter should parse")
+	assert.Equal(t, 1, result.MarkdownStart, "markdown without frontmatter starts at line 1")
+}
@@ -218,6 +218,12 @@ func mergeAllowedArrays(existing, new any) []any {
 	return result
 }
 
+// MergeMCPServerConfigs merges two definitions of the same MCP server, e.g. from two imports.
+// 'allowed' arrays are combined; any other differing value is a conflict.
+func MergeMCPServerConfigs(existing, additional map[string]any) (map[string]any, error) {
+	return mergeMCPTools(existing, additional)
+}
+
 // mergeMCPTools merges two MCP tool configurations, detecting conflicts except for 'allowed' arrays
 func mergeMCPTools(existing, new map[string]any) (map[string]any, error) {
 	toolsMergerLog.Printf("Merging MCP tool configs: existing_keys=%d, new_keys=%d", len(existing), len(new))
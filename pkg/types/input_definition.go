@@ -2,7 +2,9 @@ package types
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/github/gh-aw/pkg/logger"
 )
@@ -49,3 +51,24 @@ func (i *InputDefinition) GetDefaultAsString() string {
 		return fmt.Sprintf("%v", v)
 	}
 }
+
+// ValidateValue reports whether value is acceptable for the input's type: one of Options
+// for choice inputs, true or false for boolean inputs, and a number for number inputs.
+// String and environment inputs accept any value.
+func (i *InputDefinition) ValidateValue(value string) error {
+	switch i.Type {
+	case "choice":
+		if !slices.Contains(i.Options, value) {
+			return fmt.Errorf("must be one of %s, got %q", strings.Join(i.Options, ", "), value)
+		}
+	case "boolean":
+		if value != "true" && value != "false" {
+			return fmt.Errorf("must be true or false, got %q", value)
+		}
+	case "number":
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("must be a number, got %q", value)
+		}
+	}
+	return nil
+}
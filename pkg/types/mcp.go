@@ -17,6 +17,8 @@ type BaseMCPServerConfig struct {
 	URL     string            `json:"url,omitempty" yaml:"url,omitempty"`         // URL for HTTP mode MCP servers
 	Headers map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"` // HTTP headers for HTTP mode
 	Auth    *MCPAuthConfig    `json:"auth,omitempty" yaml:"auth,omitempty"`       // Upstream authentication config (HTTP mode only)
+	// Transport selects the upstream HTTP wire protocol: "streamable-http" (default) or "sse".
+	Transport string `json:"transport,omitempty" yaml:"transport,omitempty"`
 
 	// Container-specific fields
 	Container      string   `json:"container,omitempty" yaml:"container,omitempty"`           // Container image for the MCP server
@@ -27,11 +29,20 @@ type BaseMCPServerConfig struct {
 
 // MCPAuthConfig represents upstream authentication configuration for an HTTP MCP server.
 // When configured, the gateway dynamically acquires tokens and injects them as Authorization
-// headers on every outgoing request. Currently only GitHub Actions OIDC is supported.
+// headers on every outgoing request. Two types are supported: "github-oidc", where the gateway
+// mints a GitHub Actions OIDC token, and "oauth", where a workflow step exchanges a refresh
+// token for an access token before the agent starts.
 type MCPAuthConfig struct {
-	// Type is the authentication type. Currently only "github-oidc" is supported.
+	// Type is the authentication type: "github-oidc" or "oauth".
 	Type string `json:"type" yaml:"type"`
 	// Audience is the intended audience (aud claim) for the OIDC token.
 	// If omitted, defaults to the server's url field.
 	Audience string `json:"audience,omitempty" yaml:"audience,omitempty"`
+
+	// OAuth-specific fields (type: oauth)
+	TokenURL     string   `json:"token-url,omitempty" yaml:"token-url,omitempty"`         // OAuth token endpoint (https)
+	ClientID     string   `json:"client-id,omitempty" yaml:"client-id,omitempty"`         // OAuth client ID
+	ClientSecret string   `json:"client-secret,omitempty" yaml:"client-secret,omitempty"` // Optional client secret (secrets expression)
+	RefreshToken string   `json:"refresh-token,omitempty" yaml:"refresh-token,omitempty"` // Refresh token (secrets expression)
+	Scopes       []string `json:"scopes,omitempty" yaml:"scopes,omitempty"`               // Optional scopes requested for the access token
 }
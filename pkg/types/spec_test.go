@@ -291,3 +291,36 @@ func TestSpec_PublicAPI_GetDefaultAsString(t *testing.T) {
 		})
 	}
 }
+
+// TestSpec_PublicAPI_ValidateValue validates the documented behavior of the
+// InputDefinition.ValidateValue method as described in the types package README.md.
+// Spec: "choice inputs accept one of Options, boolean inputs accept true or false, and
+// number inputs accept any number. string and environment inputs accept any value."
+func TestSpec_PublicAPI_ValidateValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   types.InputDefinition
+		value   string
+		wantErr bool
+	}{
+		{name: "choice option accepted", input: types.InputDefinition{Type: "choice", Options: []string{"quick", "deep"}}, value: "deep"},
+		{name: "choice non-option rejected", input: types.InputDefinition{Type: "choice", Options: []string{"quick", "deep"}}, value: "thorough", wantErr: true},
+		{name: "boolean true accepted", input: types.InputDefinition{Type: "boolean"}, value: "true"},
+		{name: "boolean yes rejected", input: types.InputDefinition{Type: "boolean"}, value: "yes", wantErr: true},
+		{name: "number accepted", input: types.InputDefinition{Type: "number"}, value: "2.5"},
+		{name: "number text rejected", input: types.InputDefinition{Type: "number"}, value: "two", wantErr: true},
+		{name: "string accepts anything", input: types.InputDefinition{Type: "string"}, value: "anything"},
+		{name: "untyped accepts anything", input: types.InputDefinition{}, value: "anything"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.input.ValidateValue(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err, "ValidateValue(%q) should reject the value for type %q", tt.value, tt.input.Type)
+			} else {
+				assert.NoError(t, err, "ValidateValue(%q) should accept the value for type %q", tt.value, tt.input.Type)
+			}
+		})
+	}
+}
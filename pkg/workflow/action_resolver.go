@@ -10,6 +10,7 @@ import (
 	"github.com/github/gh-aw/pkg/actionpins"
 	"github.com/github/gh-aw/pkg/gitutil"
 	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
 	"github.com/github/gh-aw/pkg/semverutil"
 )
 
@@ -121,6 +122,11 @@ func (r *ActionResolver) ResolveSHA(ctx context.Context, repo, version string) (
 		return sha, nil
 	}
 
+	if IsOfflineMode() {
+		r.failedResolutions[cacheKey] = struct{}{}
+		return "", fmt.Errorf("action %s@%s is not pinned in .github/aw/%s or the embedded action pins: %w", repo, version, CacheFileName, parser.NewOfflineError("resolving its SHA"))
+	}
+
 	resolverLog.Printf("No embedded pin for %s@%s, querying GitHub API", repo, version)
 	resolverLog.Printf("This may take a moment as we query GitHub API at /repos/%s/git/ref/tags/%s", gitutil.ExtractBaseRepo(repo), version)
 
@@ -131,8 +131,8 @@ func TestBuildActivationJob_UsesLegacyAIEngineForSkillArtifactPath(t *testing.T)
 	require.NotNil(t, job)
 
 	steps := strings.Join(job.Steps, "")
-	assert.Contains(t, steps, "/tmp/gh-aw/.claude/skills", "expected activation artifact upload to include the legacy ai engine skill directory")
-	assert.NotContains(t, steps, "/tmp/gh-aw/.github/skills", "expected legacy ai workflows to avoid the default copilot skill directory")
+	assert.Contains(t, steps, "${{ env.GH_AW_TMP_DIR }}/.claude/skills", "expected activation artifact upload to include the legacy ai engine skill directory")
+	assert.NotContains(t, steps, "${{ env.GH_AW_TMP_DIR }}/.github/skills", "expected legacy ai workflows to avoid the default copilot skill directory")
 }
 
 func TestBuildActivationJob_AppIgnoreIfMissingFallsBackToActivationToken(t *testing.T) {
@@ -25,6 +25,7 @@ type AddCommentsConfig struct {
 	PullRequests           *bool    `yaml:"pull-requests,omitempty"`             // When false, excludes pull-requests:write permission and PRs from event condition. Default (nil or true) includes pull-requests:write.
 	Discussions            *bool    `yaml:"discussions,omitempty"`               // When true, includes discussions:write permission. Default (nil or false) excludes discussions:write.
 	Footer                 *string  `yaml:"footer,omitempty"`                    // Controls whether AI-generated footer is added. When false, visible footer is omitted but XML markers are kept.
+	DedupeKey              string   `yaml:"dedupe-key,omitempty"`                // Optional key embedded as a gh-aw-dedupe-key marker. When a comment with the same key exists on the target, it is updated in place instead of posting a new comment.
 }
 
 // parseCommentsConfig handles add-comment configuration
@@ -5,7 +5,7 @@
 // This file validates agent-specific configuration and feature compatibility
 // for agentic workflows. It ensures that:
 //   - Custom agent files exist when specified
-//   - Engine features are supported (HTTP transport, max-turns, web-search, bare mode)
+//   - Engine features are supported (HTTP transport, max-turns, web-search, bare mode, settings-scope)
 //   - Workflow triggers have appropriate security constraints
 //
 // # Validation Functions
@@ -16,6 +16,7 @@
 //   - validateMaxToolDenialsSupport() - Validates max-tool-denials support for Copilot SDK mode
 //   - validateWebSearchSupport() - Validates web-search feature support (warning)
 //   - validateBareModeSupport() - Validates bare mode feature support (warning)
+//   - validateSettingsScopeSupport() - Validates settings-scope feature support (warning)
 //   - validateWorkflowRunBranches() - Validates workflow_run has branch restrictions
 //
 // # Validation Patterns
@@ -251,6 +252,25 @@ func (c *Compiler) validateBareModeSupport(frontmatter map[string]any, engine Co
 	}
 }
 
+// validateSettingsScopeSupport validates that engine.settings-scope is only used with engines
+// that write a settings file. Emits a warning and has no effect on other engines.
+func (c *Compiler) validateSettingsScopeSupport(frontmatter map[string]any, engine CodingAgentEngine) {
+	_, engineConfig, _ := c.ExtractEngineConfig(frontmatter)
+
+	if engineConfig == nil || engineConfig.SettingsScope == "" {
+		// settings-scope not requested, no validation needed
+		return
+	}
+
+	agentValidationLog.Printf("Validating settings-scope support for engine: %s", engine.GetID())
+
+	if !engine.GetCapabilities().SettingsScope {
+		agentValidationLog.Printf("Engine %s does not support settings-scope, emitting warning", engine.GetID())
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Engine '%s' does not support engine.settings-scope. Only the 'gemini' engine writes a settings file. The setting will be ignored.", engine.GetID())))
+		c.IncrementWarningCount()
+	}
+}
+
 // validateWorkflowRunBranches validates workflow_run trigger requirements.
 // It enforces required workflows and branch restrictions guidance.
 func (c *Compiler) validateWorkflowRunBranches(workflowData *WorkflowData, markdownPath string) error {
@@ -133,6 +133,11 @@ type EngineCapabilities struct {
 	// which suppresses automatic loading of context and custom instructions. When false,
 	// specifying bare: true emits a warning and has no effect.
 	BareMode bool
+
+	// SettingsScope reports whether the engine writes a settings file whose location can be
+	// selected with engine.settings-scope. When false, specifying settings-scope emits a
+	// warning and has no effect.
+	SettingsScope bool
 }
 
 // CapabilityProvider detects what capabilities an engine supports.
@@ -0,0 +1,181 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const agenticJobsWorkflow = `---
+on:
+  issues:
+    types: [opened]
+permissions:
+  contents: read
+  issues: read
+engine: copilot
+safe-outputs:
+  add-comment:
+---
+
+# Triage
+
+Summarize the research and post a comment.
+
+## job: ` + "`research`" + `
+---
+engine: claude
+safe-outputs:
+  create-issue:
+---
+
+Research the issue.
+
+## Sources
+
+Look at the docs.
+
+## job: ` + "`implement`" + `
+---
+needs: research
+---
+
+Implement the research findings.
+`
+
+func TestExtractAgenticJobs(t *testing.T) {
+	markdown, jobs, err := extractAgenticJobs("# Triage\n\nMain prompt.\n\n## job: `research`\n---\nengine: claude\n---\n\nResearch.\n\n## Sources\n\nDocs.\n\n## job: `implement`\n---\nneeds: [research]\n---\nImplement.\n")
+	require.NoError(t, err, "job blocks should parse")
+
+	assert.Equal(t, "# Triage\n\nMain prompt.\n", markdown, "job blocks should be removed from the main prompt")
+	require.Len(t, jobs, 2, "both job blocks should be extracted")
+	assert.Equal(t, "research", jobs[0].Name)
+	assert.Equal(t, map[string]any{"engine": "claude"}, jobs[0].Frontmatter, "block frontmatter should be kept")
+	assert.Equal(t, "Research.\n\n## Sources\n\nDocs.", jobs[0].Prompt, "a block should run until the next job heading")
+	assert.Equal(t, "implement", jobs[1].Name)
+	assert.Equal(t, []string{"research"}, jobs[1].Needs, "needs should be parsed")
+	assert.Empty(t, jobs[1].Frontmatter, "needs should not be passed to the companion frontmatter")
+
+	unchanged, jobs, err := extractAgenticJobs("# Plain\n\n## Steps\n")
+	require.NoError(t, err)
+	assert.Equal(t, "# Plain\n\n## Steps\n", unchanged, "markdown without job blocks should be unchanged")
+	assert.Empty(t, jobs)
+}
+
+func TestExtractAgenticJobsErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		block   string
+		wantErr string
+	}{
+		{name: "unsupported field", block: "---\non: push\n---\nWork.", wantErr: `unsupported field "on"`},
+		{name: "invalid needs", block: "---\nneeds: 3\n---\nWork.", wantErr: "needs must be a job name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := extractAgenticJobs("Main.\n\n## job: `work`\n" + tt.block)
+			require.Error(t, err, "block should be rejected")
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestValidateAgenticJobs(t *testing.T) {
+	job := func(name string, needs ...string) *AgenticJob {
+		return &AgenticJob{Name: name, Needs: needs, Prompt: "Work."}
+	}
+	tests := []struct {
+		name       string
+		jobs       []*AgenticJob
+		customJobs map[string]any
+		wantErr    string
+	}{
+		{name: "valid chain", jobs: []*AgenticJob{job("a"), job("b", "a"), job("c", "a", "b")}},
+		{name: "duplicate name", jobs: []*AgenticJob{job("a"), job("a")}, wantErr: "defined more than once"},
+		{name: "built-in name", jobs: []*AgenticJob{job("agent")}, wantErr: "built-in job"},
+		{name: "custom job name", jobs: []*AgenticJob{job("lint")}, customJobs: map[string]any{"lint": map[string]any{}}, wantErr: "jobs.lint"},
+		{name: "unknown need", jobs: []*AgenticJob{job("a", "b")}, wantErr: `needs "b"`},
+		{name: "cycle", jobs: []*AgenticJob{job("a", "c"), job("b", "a"), job("c", "b")}, wantErr: "a → c → b → a"},
+		{name: "no instructions", jobs: []*AgenticJob{{Name: "a"}}, wantErr: "no instructions"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAgenticJobs(tt.jobs, tt.customJobs)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestBuildAgenticJobMarkdown(t *testing.T) {
+	data := &WorkflowData{
+		Name: "Triage",
+		RawFrontmatter: map[string]any{
+			"engine":      "copilot",
+			"permissions": map[string]any{"contents": "read"},
+			"tools":       map[string]any{"bash": nil},
+		},
+	}
+	job := &AgenticJob{Name: "implement", Needs: []string{"research"}, Frontmatter: map[string]any{"engine": "claude"}, Prompt: "Implement."}
+
+	content, err := buildAgenticJobMarkdown(data, job)
+	require.NoError(t, err)
+
+	assert.Contains(t, content, "name: Triage / implement")
+	assert.Contains(t, content, "workflow_call:")
+	assert.Contains(t, content, "artifact-prefix-research:", "upstream artifact prefixes should be inputs")
+	assert.Contains(t, content, "value: ${{ jobs.activation.outputs.artifact_prefix }}", "the run's artifact prefix should be an output")
+	assert.Contains(t, content, "engine: claude", "block settings should override inherited ones")
+	assert.NotContains(t, content, "engine: copilot")
+	assert.Contains(t, content, "contents: read", "permissions should be inherited")
+	assert.NotContains(t, content, "bash", "tools should not be inherited")
+	assert.True(t, strings.HasSuffix(content, "---\n\nImplement.\n"), "the job prompt should follow the frontmatter")
+}
+
+func TestCompileAgenticJobs(t *testing.T) {
+	dir := testutil.TempDir(t, "agentic-jobs-*")
+	markdownPath := filepath.Join(dir, "triage.md")
+	require.NoError(t, os.WriteFile(markdownPath, []byte(agenticJobsWorkflow), 0o644))
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(markdownPath), "workflow with agentic jobs should compile")
+
+	lock, err := os.ReadFile(filepath.Join(dir, "triage.lock.yml"))
+	require.NoError(t, err, "main lock file should be written")
+	lockContent := string(lock)
+
+	assert.Contains(t, lockContent, "uses: ./.github/workflows/triage.research.job.lock.yml", "main workflow should call the research companion")
+	assert.Contains(t, lockContent, "uses: ./.github/workflows/triage.implement.job.lock.yml", "main workflow should call the implement companion")
+	assert.Contains(t, lockContent, "artifact-prefix-research: ${{ needs.research.outputs.artifact_prefix }}", "research output should be passed to implement")
+	assert.Contains(t, lockContent, "name: ${{ needs.implement.outputs.artifact_prefix }}agent", "agent job should download the job outputs")
+	assert.Contains(t, lockContent, "<agentic-jobs>", "main prompt should describe the job outputs")
+
+	researchJob := lockContent[strings.Index(lockContent, "\n  research:"):]
+	researchJob = researchJob[:strings.Index(researchJob, "secrets: inherit")]
+	assert.Contains(t, researchJob, "issues: write", "caller job should grant the permissions of the companion's safe outputs")
+
+	agentJob := lockContent[strings.Index(lockContent, "\n  agent:"):]
+	agentJob = agentJob[:strings.Index(agentJob, "steps:")]
+	assert.Contains(t, agentJob, "- research", "agent job should run after the agentic jobs")
+
+	research, err := os.ReadFile(filepath.Join(dir, "triage.research.job.lock.yml"))
+	require.NoError(t, err, "research companion lock file should be written")
+	assert.Contains(t, string(research), "workflow_call:")
+	assert.Contains(t, string(research), "Research the issue.", "companion prompt should be inlined")
+	assert.NotContains(t, string(research), "Summarize the research", "companion prompt should not include the main prompt")
+
+	implement, err := os.ReadFile(filepath.Join(dir, "triage.implement.job.lock.yml"))
+	require.NoError(t, err, "implement companion lock file should be written")
+	assert.Contains(t, string(implement), "name: ${{ inputs.artifact-prefix-research }}agent", "companion should download its upstream output")
+}
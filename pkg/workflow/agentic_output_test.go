@@ -278,8 +278,8 @@ func TestEngineOutputFileDeclarations(t *testing.T) {
 		t.Errorf("Codex engine should declare output files for log collection, got: %v", codexOutputFiles)
 	}
 
-	if len(codexOutputFiles) > 0 && codexOutputFiles[0] != "/tmp/gh-aw/mcp-config/logs/" {
-		t.Errorf("Codex engine should declare /tmp/gh-aw/mcp-config/logs/, got: %v", codexOutputFiles[0])
+	if len(codexOutputFiles) > 0 && codexOutputFiles[0] != "${{ env.GH_AW_TMP_DIR }}/mcp-config/logs/" {
+		t.Errorf("Codex engine should declare ${{ env.GH_AW_TMP_DIR }}/mcp-config/logs/, got: %v", codexOutputFiles[0])
 	}
 
 	// Test Gemini engine declares output files for error log collection
@@ -290,8 +290,8 @@ func TestEngineOutputFileDeclarations(t *testing.T) {
 		t.Error("Gemini engine should declare output files for error log collection")
 	}
 
-	if len(geminiOutputFiles) > 0 && geminiOutputFiles[0] != "/tmp/gh-aw/gemini-client-error-*.json" {
-		t.Errorf("Gemini engine should declare /tmp/gh-aw/gemini-client-error-*.json, got: %v", geminiOutputFiles[0])
+	if len(geminiOutputFiles) > 0 && geminiOutputFiles[0] != "${{ env.GH_AW_TMP_DIR }}/gemini-client-error-*.json" {
+		t.Errorf("Gemini engine should declare ${{ env.GH_AW_TMP_DIR }}/gemini-client-error-*.json, got: %v", geminiOutputFiles[0])
 	}
 
 	t.Logf("Claude engine declares: %v", claudeOutputFiles)
@@ -342,12 +342,12 @@ This workflow tests that /tmp/gh-aw/ files are excluded from cleanup.
 	lockStr := string(lockContent)
 
 	// Verify that the upload step includes the /tmp/gh-aw/ path (artifact should still be uploaded)
-	if !strings.Contains(lockStr, "/tmp/gh-aw/sandbox/agent/logs/") {
+	if !strings.Contains(lockStr, "${{ env.GH_AW_TMP_DIR }}/sandbox/agent/logs/") {
 		t.Error("Expected upload artifact path to include '/tmp/gh-aw/sandbox/agent/logs/' in generated workflow")
 	}
 
 	// Verify that the cleanup step does NOT include rm commands for /tmp/gh-aw/ paths
-	if strings.Contains(lockStr, "rm -fr /tmp/gh-aw/sandbox/agent/logs/") {
+	if strings.Contains(lockStr, "rm -fr ${{ env.GH_AW_TMP_DIR }}/sandbox/agent/logs/") {
 		t.Error("Cleanup step should NOT include 'rm -fr /tmp/gh-aw/sandbox/agent/logs/' command")
 	}
 
@@ -498,11 +498,11 @@ func TestEngineOutputCleanupWithMixedPaths(t *testing.T) {
 	// Test the cleanup logic directly with mixed paths to ensure proper filtering
 	var yaml strings.Builder
 
-	// Simulate mixed output files: some in /tmp/gh-aw/, some in workspace
+	// Simulate mixed output files: some in ${{ env.GH_AW_TMP_DIR }}/, some in workspace
 	mockOutputFiles := []string{
-		"/tmp/gh-aw/logs/debug.log",
+		"${{ env.GH_AW_TMP_DIR }}/logs/debug.log",
 		"workspace-output/results.txt",
-		"/tmp/gh-aw/.cache/data.json",
+		"${{ env.GH_AW_TMP_DIR }}/.cache/data.json",
 		"build/artifacts.zip",
 	}
 
@@ -526,25 +526,25 @@ func TestEngineOutputCleanupWithMixedPaths(t *testing.T) {
 	result := yaml.String()
 
 	// Verify that all files are included in the upload step
-	if !strings.Contains(result, "/tmp/gh-aw/logs/debug.log") {
-		t.Error("Expected /tmp/gh-aw/logs/debug.log to be included in upload step")
+	if !strings.Contains(result, "${{ env.GH_AW_TMP_DIR }}/logs/debug.log") {
+		t.Error("Expected ${{ env.GH_AW_TMP_DIR }}/logs/debug.log to be included in upload step")
 	}
 	if !strings.Contains(result, "workspace-output/results.txt") {
 		t.Error("Expected workspace-output/results.txt to be included in upload step")
 	}
-	if !strings.Contains(result, "/tmp/gh-aw/.cache/data.json") {
-		t.Error("Expected /tmp/gh-aw/.cache/data.json to be included in upload step")
+	if !strings.Contains(result, "${{ env.GH_AW_TMP_DIR }}/.cache/data.json") {
+		t.Error("Expected ${{ env.GH_AW_TMP_DIR }}/.cache/data.json to be included in upload step")
 	}
 	if !strings.Contains(result, "build/artifacts.zip") {
 		t.Error("Expected build/artifacts.zip to be included in upload step")
 	}
 
 	// Verify that only workspace files are included in cleanup step
-	if strings.Contains(result, "rm -fr /tmp/gh-aw/logs/debug.log") {
-		t.Error("Cleanup step should NOT include 'rm -fr /tmp/gh-aw/logs/debug.log' command")
+	if strings.Contains(result, "rm -fr ${{ env.GH_AW_TMP_DIR }}/logs/debug.log") {
+		t.Error("Cleanup step should NOT include 'rm -fr ${{ env.GH_AW_TMP_DIR }}/logs/debug.log' command")
 	}
-	if strings.Contains(result, "rm -fr /tmp/gh-aw/.cache/data.json") {
-		t.Error("Cleanup step should NOT include 'rm -fr /tmp/gh-aw/.cache/data.json' command")
+	if strings.Contains(result, "rm -fr ${{ env.GH_AW_TMP_DIR }}/.cache/data.json") {
+		t.Error("Cleanup step should NOT include 'rm -fr ${{ env.GH_AW_TMP_DIR }}/.cache/data.json' command")
 	}
 	if !strings.Contains(result, "rm -fr workspace-output/results.txt") {
 		t.Error("Cleanup step should include 'rm -fr workspace-output/results.txt' command")
@@ -553,21 +553,21 @@ func TestEngineOutputCleanupWithMixedPaths(t *testing.T) {
 		t.Error("Cleanup step should include 'rm -fr build/artifacts.zip' command")
 	}
 
-	t.Log("Successfully verified that mixed path cleanup properly filters /tmp/gh-aw/ files")
+	t.Log("Successfully verified that mixed path cleanup properly filters ${{ env.GH_AW_TMP_DIR }}/ files")
 }
 
 func TestGenerateCleanupStep(t *testing.T) {
 	// Test the generateCleanupStep function directly to demonstrate its testability
 
-	// Test case 1: Only /tmp/gh-aw/ files - should not generate cleanup step
-	tmpOnlyFiles := []string{"/tmp/gh-aw/logs/debug.log", "/tmp/gh-aw/.cache/data.json"}
+	// Test case 1: Only ${{ env.GH_AW_TMP_DIR }}/ files - should not generate cleanup step
+	tmpOnlyFiles := []string{"${{ env.GH_AW_TMP_DIR }}/logs/debug.log", "${{ env.GH_AW_TMP_DIR }}/.cache/data.json"}
 	cleanupYaml, hasCleanup := generateCleanupStep(tmpOnlyFiles)
 
 	if hasCleanup {
-		t.Error("Expected no cleanup step for /tmp/gh-aw/ only files")
+		t.Error("Expected no cleanup step for ${{ env.GH_AW_TMP_DIR }}/ only files")
 	}
 	if cleanupYaml != "" {
-		t.Error("Expected empty cleanup YAML for /tmp/gh-aw/ only files")
+		t.Error("Expected empty cleanup YAML for ${{ env.GH_AW_TMP_DIR }}/ only files")
 	}
 
 	// Test case 2: Only workspace files - should generate cleanup step
@@ -585,17 +585,17 @@ func TestGenerateCleanupStep(t *testing.T) {
 	}
 
 	// Test case 3: Mixed files - should generate cleanup step only for workspace files
-	mixedFiles := []string{"/tmp/gh-aw/debug.log", "workspace/output.txt", "/tmp/gh-aw/.cache/data.json"}
+	mixedFiles := []string{"${{ env.GH_AW_TMP_DIR }}/debug.log", "workspace/output.txt", "${{ env.GH_AW_TMP_DIR }}/.cache/data.json"}
 	cleanupYaml, hasCleanup = generateCleanupStep(mixedFiles)
 
 	if !hasCleanup {
 		t.Error("Expected cleanup step for mixed files containing workspace files")
 	}
-	if strings.Contains(cleanupYaml, "rm -fr /tmp/gh-aw/debug.log") {
-		t.Error("Cleanup YAML should NOT contain /tmp/gh-aw/ files")
+	if strings.Contains(cleanupYaml, "rm -fr ${{ env.GH_AW_TMP_DIR }}/debug.log") {
+		t.Error("Cleanup YAML should NOT contain ${{ env.GH_AW_TMP_DIR }}/ files")
 	}
-	if strings.Contains(cleanupYaml, "rm -fr /tmp/gh-aw/.cache/data.json") {
-		t.Error("Cleanup YAML should NOT contain /tmp/gh-aw/ files")
+	if strings.Contains(cleanupYaml, "rm -fr ${{ env.GH_AW_TMP_DIR }}/.cache/data.json") {
+		t.Error("Cleanup YAML should NOT contain ${{ env.GH_AW_TMP_DIR }}/ files")
 	}
 	if !strings.Contains(cleanupYaml, "rm -fr workspace/output.txt") {
 		t.Error("Expected cleanup YAML to contain workspace files")
@@ -663,7 +663,7 @@ This workflow tests that the redacted URLs log file is included in artifact uplo
 
 	// Verify that the engine output path is present in the unified artifact upload paths.
 	// Copilot engine declares /tmp/gh-aw/sandbox/agent/logs/ as its output path.
-	if !strings.Contains(lockStr, "/tmp/gh-aw/sandbox/agent/logs/") {
+	if !strings.Contains(lockStr, "${{ env.GH_AW_TMP_DIR }}/sandbox/agent/logs/") {
 		t.Error("Expected Copilot engine output path '/tmp/gh-aw/sandbox/agent/logs/' to be in unified agent artifact upload paths")
 	}
 
@@ -726,7 +726,7 @@ This workflow tests that the Gemini engine error log wildcard is uploaded as an
 
 	// Verify that the Gemini error log wildcard path is included in the unified artifact upload
 	// under /tmp/gh-aw/ (not /tmp/) so the artifact upload LCA stays at /tmp/gh-aw/
-	if !strings.Contains(lockStr, "/tmp/gh-aw/gemini-client-error-*.json") {
+	if !strings.Contains(lockStr, "${{ env.GH_AW_TMP_DIR }}/gemini-client-error-*.json") {
 		t.Error("Gemini workflow should include '/tmp/gh-aw/gemini-client-error-*.json' in unified agent artifact upload")
 	}
 	if strings.Contains(lockStr, "path: /tmp/gemini-client-error") {
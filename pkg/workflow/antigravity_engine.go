@@ -146,7 +146,7 @@ func (e *AntigravityEngine) GetPreBundleSteps(workflowData *WorkflowData) []GitH
 		{
 			"      - name: Move Antigravity error files to artifact directory",
 			"        if: always()",
-			"        run: mv /tmp/antigravity-client-error-*.json /tmp/gh-aw/ 2>/dev/null || true",
+			"        run: mv /tmp/antigravity-client-error-*.json " + constants.TmpGhAwDirExpr + "/ 2>/dev/null || true",
 		},
 	}
 }
@@ -192,7 +192,7 @@ func (e *AntigravityEngine) GetExecutionSteps(workflowData *WorkflowData, logFil
 	}
 
 	// Append the prompt arg raw (not through shellJoinArgs) to preserve shell expansion
-	agyCommand := fmt.Sprintf(`%s %s --prompt "$(cat /tmp/gh-aw/aw-prompts/prompt.txt)"`, commandName, shellJoinArgs(agyArgs))
+	agyCommand := fmt.Sprintf(`%s %s --prompt "$(cat `+constants.TmpGhAwDirExpr+`/aw-prompts/prompt.txt)"`, commandName, shellJoinArgs(agyArgs))
 	agyCommand = getWorkspaceCommandPrefixFor(workflowData.EngineConfig) + agyCommand
 
 	// Build the full command with AWF wrapping if enabled
@@ -242,8 +242,8 @@ func (e *AntigravityEngine) GetExecutionSteps(workflowData *WorkflowData, logFil
 		command = fmt.Sprintf(`set -o pipefail
 printf '%%s' "$(date +%%s%%3N)" > %s
 touch %s
-(umask 177 && touch %s)
-%s 2>&1 | tee -a %s`, AgentCLIStartMsPath, AgentStepSummaryPath, logFile, agyCommand, logFile)
+%s
+%s`, AgentCLIStartMsPath, AgentStepSummaryPath, precreateEngineLogsCommand(logFile), captureEngineOutput(agyCommand, logFile))
 	}
 
 	// Build environment variables
@@ -6,6 +6,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/github/gh-aw/pkg/constants"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -56,17 +58,17 @@ func TestAntigravityEngine(t *testing.T) {
 	t.Run("declared output files", func(t *testing.T) {
 		outputFiles := engine.GetDeclaredOutputFiles()
 		require.Len(t, outputFiles, 1, "Should declare one output file path")
-		assert.Equal(t, "/tmp/gh-aw/antigravity-client-error-*.json", outputFiles[0], "Should declare Antigravity error log wildcard path under /tmp/gh-aw/")
+		assert.Equal(t, "${{ env.GH_AW_TMP_DIR }}/antigravity-client-error-*.json", outputFiles[0], "Should declare Antigravity error log wildcard path under ${{ env.GH_AW_TMP_DIR }}/")
 	})
 
-	t.Run("pre-bundle steps move files to /tmp/gh-aw/", func(t *testing.T) {
+	t.Run("pre-bundle steps move files to ${{ env.GH_AW_TMP_DIR }}/", func(t *testing.T) {
 		workflowData := &WorkflowData{Name: "test-workflow"}
 		steps := engine.GetPreBundleSteps(workflowData)
 		require.Len(t, steps, 1, "Should return exactly one pre-bundle step")
 
 		stepContent := strings.Join(steps[0], "\n")
 		assert.Contains(t, stepContent, "Move Antigravity error files", "Step name should describe move operation")
-		assert.Contains(t, stepContent, "mv /tmp/antigravity-client-error-*.json /tmp/gh-aw/", "Step should move files to /tmp/gh-aw/")
+		assert.Contains(t, stepContent, "mv /tmp/antigravity-client-error-*.json ${{ env.GH_AW_TMP_DIR }}/", "Step should move files to ${{ env.GH_AW_TMP_DIR }}/")
 		assert.Contains(t, stepContent, "if: always()", "Step should run always so files are captured on failure")
 	})
 }
@@ -156,7 +158,7 @@ func TestAntigravityEngineExecution(t *testing.T) {
 		assert.NotContains(t, stepContent, "--yolo", "Should not include unsupported --yolo flag")
 		assert.NotContains(t, stepContent, "--skip-trust", "Should not include unsupported --skip-trust flag")
 		assert.NotContains(t, stepContent, "--output-format stream-json", "Should not include unsupported output format flag")
-		assert.Contains(t, stepContent, `--prompt "$(cat /tmp/gh-aw/aw-prompts/prompt.txt)"`, "Should include prompt argument with correct shell quoting")
+		assert.Contains(t, stepContent, `--prompt "$(cat ${{ env.GH_AW_TMP_DIR }}/aw-prompts/prompt.txt)"`, "Should include prompt argument with correct shell quoting")
 		assert.Contains(t, stepContent, "/tmp/test.log", "Should include log file")
 		assert.Contains(t, stepContent, "ANTIGRAVITY_API_KEY: ${{ secrets.ANTIGRAVITY_API_KEY }}", "Should set ANTIGRAVITY_API_KEY env var")
 		assert.Contains(t, stepContent, "GEMINI_API_KEY: ${{ secrets.ANTIGRAVITY_API_KEY }}", "Should map GEMINI_API_KEY to the Antigravity secret for proxy auth")
@@ -323,7 +325,7 @@ func TestAntigravityEngineExecution(t *testing.T) {
 
 		assert.Contains(t, settingsContent, "Write Antigravity Config", "First step should be Write Antigravity Config")
 		assert.Contains(t, settingsContent, "includeDirectories", "Settings step should set includeDirectories")
-		assert.Contains(t, settingsContent, "/tmp/", "Settings step should include /tmp/ in include directories")
+		assert.Contains(t, settingsContent, constants.TmpGhAwDirExprSlash, "Settings step should include the job scratch directory in include directories")
 		assert.Contains(t, execContent, "Execute Antigravity CLI", "Second step should be Execute Antigravity CLI")
 	})
 }
@@ -499,7 +501,7 @@ func TestGenerateAntigravitySettingsStep(t *testing.T) {
 		content := strings.Join(step, "\n")
 
 		assert.Contains(t, content, "Write Antigravity Config", "Should have correct step name")
-		assert.Contains(t, content, "/tmp/", "Should include /tmp/ in include directories")
+		assert.Contains(t, content, constants.TmpGhAwDirExprSlash, "Should include the job scratch directory in include directories")
 		assert.Contains(t, content, "includeDirectories", "Should set includeDirectories")
 		assert.Contains(t, content, ".antigravity", "Should reference .antigravity directory")
 		assert.Contains(t, content, "GITHUB_WORKSPACE", "Should use GITHUB_WORKSPACE")
@@ -13,7 +13,8 @@ package workflow
 //  2. Settings Step Generation (generateAntigravitySettingsStep):
 //     Generates a GitHub Actions step that writes or merges .antigravity/settings.json
 //     before the Antigravity CLI execution. This step always sets:
-//     - context.includeDirectories: ["/tmp/"] so file tools can access /tmp/
+//     - context.includeDirectories: the job's /tmp/gh-aw scratch directory, so file
+//       tools can access the agent working directories but no other job's files
 //     - tools.core: derived from neutral tool configuration
 //     The merge approach ensures MCP server config (written by convert_gateway_config_antigravity.sh)
 //     is preserved while adding the context and tool settings.
@@ -23,6 +24,7 @@ import (
 	"fmt"
 	"sort"
 
+	"github.com/github/gh-aw/pkg/constants"
 	"github.com/github/gh-aw/pkg/logger"
 )
 
@@ -118,9 +120,9 @@ func appendBashTools(toolsCore []string, bashConfig any) []string {
 // Antigravity CLI project settings file (.antigravity/settings.json) before execution.
 //
 // This step:
-//  1. Sets context.includeDirectories to ["/tmp/"] so that Antigravity CLI file system
-//     tools (write_file, replace) can access files in /tmp/ including
-//     /tmp/gh-aw/cache-memory/ and other agent working directories.
+//  1. Sets context.includeDirectories to the job's scratch directory so that Antigravity CLI
+//     file system tools (write_file, replace) can access cache-memory and the other
+//     agent working directories, while files of concurrent jobs on the host stay out of reach.
 //  2. Sets tools.core to the list of built-in tools derived from the workflow's
 //     neutral tool configuration (bash → run_shell_command, edit → write_file/replace).
 //  3. Merges the above settings with any existing .antigravity/settings.json, which
@@ -145,7 +147,7 @@ func (e *AntigravityEngine) generateAntigravitySettingsStep(workflowData *Workfl
 	// Build the settings JSON object
 	config := map[string]any{
 		"context": map[string]any{
-			"includeDirectories": []string{"/tmp/"},
+			"includeDirectories": []string{constants.TmpGhAwDirExprSlash},
 		},
 		"tools": map[string]any{
 			"core": toolsCore,
@@ -155,7 +157,7 @@ func (e *AntigravityEngine) generateAntigravitySettingsStep(workflowData *Workfl
 	configJSON, err := json.Marshal(config)
 	if err != nil {
 		antigravityToolsLog.Printf("ERROR: Failed to marshal Antigravity settings: %v", err)
-		configJSON = []byte(`{"context":{"includeDirectories":["/tmp/"]},"tools":{"core":[]}}`)
+		configJSON = []byte(`{"context":{"includeDirectories":["` + constants.TmpGhAwDirExprSlash + `"]},"tools":{"core":[]}}`)
 	}
 
 	// Generate a shell script that:
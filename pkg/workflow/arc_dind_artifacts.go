@@ -10,35 +10,36 @@ import (
 
 var arcDindLog = logger.New("workflow:arc_dind_artifacts")
 
-// rewriteTmpGhAwPathsForArcDind rewrites artifact paths that use /tmp/gh-aw/ to
+// rewriteTmpGhAwPathsForArcDind rewrites artifact paths under the job's scratch
+// directory (${{ env.GH_AW_TMP_DIR }}, a per-job subdirectory of /tmp/gh-aw) to
 // use ${{ runner.temp }}/gh-aw/ instead, so all paths share a single root for the
 // artifact upload. This prevents upload-artifact from computing "/" as the common
-// ancestor (which happens when paths span both /tmp/gh-aw/ and the runner.temp tree),
+// ancestor (which happens when paths span both /tmp/gh-aw and the runner.temp tree),
 // causing a nested directory layout that breaks downstream artifact downloads.
 func rewriteTmpGhAwPathsForArcDind(paths []string) []string {
 	arcDindLog.Printf("Rewriting %d artifact paths for ARC/DinD single-root layout", len(paths))
 	result := make([]string, len(paths))
 	rewritten := 0
 	for i, p := range paths {
-		if after, ok := strings.CutPrefix(p, constants.TmpGhAwDirSlash); ok {
-			// /tmp/gh-aw/foo → ${{ runner.temp }}/gh-aw/foo
+		if after, ok := strings.CutPrefix(p, constants.TmpGhAwDirExprSlash); ok {
+			// ${{ env.GH_AW_TMP_DIR }}/foo → ${{ runner.temp }}/gh-aw/foo
 			result[i] = constants.GhAwRootDir + "/" + after
 			rewritten++
-		} else if p == constants.TmpGhAwDir {
+		} else if p == constants.TmpGhAwDirExpr {
 			result[i] = constants.GhAwRootDir
 			rewritten++
 		} else {
 			result[i] = p
 		}
 	}
-	arcDindLog.Printf("Rewrote %d of %d artifact paths from /tmp/gh-aw to runner.temp", rewritten, len(paths))
+	arcDindLog.Printf("Rewrote %d of %d artifact paths from the job scratch directory to runner.temp", rewritten, len(paths))
 	return result
 }
 
 // generateArcDindArtifactConsolidationStep emits a workflow step that copies files
-// from /tmp/gh-aw/ to ${{ runner.temp }}/gh-aw/ so the artifact upload has a single
+// from the job's scratch directory to ${{ runner.temp }}/gh-aw/ so the artifact upload has a single
 // root directory. On ARC/DinD, agent output files (agent_output.json, safe_outputs.ndjson,
-// aw-prompts/, patches, MCP logs) are written to /tmp/gh-aw/ during execution, but
+// aw-prompts/, patches, MCP logs) are written to the scratch directory during execution, but
 // firewall logs are under ${{ runner.temp }}/gh-aw/. This step consolidates them.
 func (c *Compiler) generateArcDindArtifactConsolidationStep(yaml *strings.Builder) {
 	arcDindLog.Print("Generating ARC/DinD artifact consolidation step")
@@ -46,13 +47,13 @@ func (c *Compiler) generateArcDindArtifactConsolidationStep(yaml *strings.Builde
 	yaml.WriteString("        if: always()\n")
 	yaml.WriteString("        shell: bash\n")
 	yaml.WriteString("        run: |\n")
-	// Use rsync-like cp to merge /tmp/gh-aw/ into ${RUNNER_TEMP}/gh-aw/ without
+	// Use rsync-like cp to merge the scratch directory into ${RUNNER_TEMP}/gh-aw/ without
 	// clobbering existing files (firewall logs already there). The -a flag preserves
 	// permissions/timestamps, --no-clobber skips existing files.
-	yaml.WriteString("          # Consolidate /tmp/gh-aw/ into ${RUNNER_TEMP}/gh-aw/ for single-root artifact upload\n")
-	yaml.WriteString("          if [ -d /tmp/gh-aw ]; then\n")
+	yaml.WriteString("          # Consolidate ${GH_AW_TMP_DIR}/ into ${RUNNER_TEMP}/gh-aw/ for single-root artifact upload\n")
+	yaml.WriteString("          if [ -d " + constants.TmpGhAwDirExpr + " ]; then\n")
 	yaml.WriteString("            mkdir -p \"${RUNNER_TEMP}/gh-aw\"\n")
-	fmt.Fprintf(yaml, "            cp -a --no-clobber /tmp/gh-aw/. \"${RUNNER_TEMP}/gh-aw/\" 2>/dev/null || \\\n")
-	fmt.Fprintf(yaml, "              cp -a /tmp/gh-aw/. \"${RUNNER_TEMP}/gh-aw/\" 2>/dev/null || true\n")
+	fmt.Fprintf(yaml, "            cp -a --no-clobber "+constants.TmpGhAwDirExpr+"/. \"${RUNNER_TEMP}/gh-aw/\" 2>/dev/null || \\\n")
+	fmt.Fprintf(yaml, "              cp -a "+constants.TmpGhAwDirExpr+"/. \"${RUNNER_TEMP}/gh-aw/\" 2>/dev/null || true\n")
 	yaml.WriteString("          fi\n")
 }
@@ -7,13 +7,13 @@ import (
 )
 
 func TestRewriteTmpGhAwPathsForArcDind(t *testing.T) {
-	t.Run("rewrites /tmp/gh-aw/ prefixed paths to runner.temp expression", func(t *testing.T) {
+	t.Run("rewrites scratch directory paths to runner.temp expression", func(t *testing.T) {
 		input := []string{
-			"/tmp/gh-aw/agent_output.json",
-			"/tmp/gh-aw/safe_outputs.ndjson",
-			"/tmp/gh-aw/aw-prompts/prompt.txt",
-			"/tmp/gh-aw/mcp-logs/",
-			"/tmp/gh-aw/aw-*.patch",
+			"${{ env.GH_AW_TMP_DIR }}/agent_output.json",
+			"${{ env.GH_AW_TMP_DIR }}/safe_outputs.ndjson",
+			"${{ env.GH_AW_TMP_DIR }}/aw-prompts/prompt.txt",
+			"${{ env.GH_AW_TMP_DIR }}/mcp-logs/",
+			"${{ env.GH_AW_TMP_DIR }}/aw-*.patch",
 		}
 		result := rewriteTmpGhAwPathsForArcDind(input)
 		assert.Equal(t, "${{ runner.temp }}/gh-aw/agent_output.json", result[0])
@@ -36,6 +36,7 @@ func TestRewriteTmpGhAwPathsForArcDind(t *testing.T) {
 		input := []string{
 			"/some/other/path",
 			"relative/path",
+			"/tmp/gh-aw/agent_output.json",
 		}
 		result := rewriteTmpGhAwPathsForArcDind(input)
 		assert.Equal(t, input, result)
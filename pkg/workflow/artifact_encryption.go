@@ -0,0 +1,187 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var artifactEncryptionLog = logger.New("workflow:artifact_encryption")
+
+// Artifact groups that artifact-encryption can cover.
+const (
+	encryptedArtifactTranscripts     = "transcripts"
+	encryptedArtifactSafeOutputItems = "safe-output-items"
+)
+
+var encryptableArtifacts = []string{encryptedArtifactTranscripts, encryptedArtifactSafeOutputItems}
+
+// ArtifactEncryptionConfig holds the artifact-encryption frontmatter configuration.
+type ArtifactEncryptionConfig struct {
+	// Recipients are the age public keys (age1... or ssh-ed25519/ssh-rsa keys) the
+	// artifacts are encrypted to.
+	Recipients []string
+	// Artifacts are the artifact groups to encrypt (transcripts, safe-output-items).
+	Artifacts []string
+}
+
+// encrypts reports whether the given artifact group is encrypted.
+func (c *ArtifactEncryptionConfig) encrypts(artifact string) bool {
+	return c != nil && slices.Contains(c.Artifacts, artifact)
+}
+
+// extractArtifactEncryptionConfig reads the artifact-encryption frontmatter field.
+// Returns nil when the field is not set.
+func extractArtifactEncryptionConfig(frontmatter map[string]any) (*ArtifactEncryptionConfig, error) {
+	raw, ok := frontmatter["artifact-encryption"]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	encryptionMap, ok := raw.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("artifact-encryption must be an object, got %T", raw)
+	}
+
+	config := &ArtifactEncryptionConfig{}
+	switch recipients := encryptionMap["recipients"].(type) {
+	case string:
+		config.Recipients = append(config.Recipients, strings.TrimSpace(recipients))
+	case []any:
+		for _, item := range recipients {
+			recipient, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("artifact-encryption.recipients entries must be strings, got %T", item)
+			}
+			config.Recipients = append(config.Recipients, strings.TrimSpace(recipient))
+		}
+	case nil:
+	default:
+		return nil, fmt.Errorf("artifact-encryption.recipients must be a string or a list of strings, got %T", recipients)
+	}
+	if len(config.Recipients) == 0 {
+		return nil, errors.New("artifact-encryption.recipients must list at least one age public key")
+	}
+	for _, recipient := range config.Recipients {
+		if !isAgeRecipient(recipient) {
+			return nil, fmt.Errorf("artifact-encryption.recipients entry %q is not an age public key; use an age1... key or an ssh-ed25519/ssh-rsa public key", recipient)
+		}
+	}
+
+	if rawArtifacts, ok := encryptionMap["artifacts"]; ok {
+		list, ok := rawArtifacts.([]any)
+		if !ok {
+			return nil, fmt.Errorf("artifact-encryption.artifacts must be a list, got %T", rawArtifacts)
+		}
+		for _, item := range list {
+			artifact, ok := item.(string)
+			if !ok || !slices.Contains(encryptableArtifacts, artifact) {
+				return nil, fmt.Errorf("artifact-encryption.artifacts entry %v is not supported; use one of: %s", item, strings.Join(encryptableArtifacts, ", "))
+			}
+			if !slices.Contains(config.Artifacts, artifact) {
+				config.Artifacts = append(config.Artifacts, artifact)
+			}
+		}
+	} else {
+		config.Artifacts = slices.Clone(encryptableArtifacts)
+	}
+
+	artifactEncryptionLog.Printf("Artifact encryption: recipients=%d, artifacts=%v", len(config.Recipients), config.Artifacts)
+	return config, nil
+}
+
+// isAgeRecipient reports whether s looks like a public key age can encrypt to.
+func isAgeRecipient(s string) bool {
+	if strings.ContainsAny(s, "\n\r'$`\\") {
+		return false
+	}
+	return strings.HasPrefix(s, "age1") || strings.HasPrefix(s, "ssh-ed25519 ") || strings.HasPrefix(s, "ssh-rsa ")
+}
+
+// transcriptArtifactPaths returns the paths of the agent artifact that hold agent
+// transcripts: the agent stdio log and its stdout and stderr logs, engine session logs
+// and MCP gateway and mcp-scripts logs, and files the agent wrote to its scratch directory.
+func transcriptArtifactPaths(data *WorkflowData, engine CodingAgentEngine, logFileFull string) []string {
+	paths := append(engineLogFiles(logFileFull), constants.TmpMcpLogsDir, constants.TmpGhAwAgentDir)
+	paths = append(paths, getEngineArtifactPaths(engine)...)
+	if IsMCPScriptsEnabled(data.MCPScripts) {
+		paths = append(paths, constants.TmpMcpScriptsLogsDir)
+	}
+	return paths
+}
+
+// splitEncryptedArtifactPaths removes the transcript paths from the agent artifact
+// paths when transcripts are encrypted, returning the remaining plaintext paths and
+// the paths in the scratch directory of the job to encrypt. On ARC/DinD the artifact
+// paths were rewritten to the runner.temp root; the transcripts are still encrypted
+// from the scratch directory.
+func splitEncryptedArtifactPaths(data *WorkflowData, engine CodingAgentEngine, logFileFull string, paths []string) ([]string, []string) {
+	if !data.ArtifactEncryption.encrypts(encryptedArtifactTranscripts) {
+		return paths, nil
+	}
+	transcripts := transcriptArtifactPaths(data, engine, logFileFull)
+	uploaded := transcripts
+	if isArcDindTopology(data) {
+		uploaded = rewriteTmpGhAwPathsForArcDind(transcripts)
+	}
+	var plaintext, encrypted []string
+	for _, path := range paths {
+		if i := slices.Index(uploaded, path); i >= 0 {
+			encrypted = append(encrypted, transcripts[i])
+		} else {
+			plaintext = append(plaintext, path)
+		}
+	}
+	return plaintext, encrypted
+}
+
+// buildEncryptArtifactStep returns the step that encrypts paths into outputFile with
+// encrypt_artifact.sh. Paths are single-quoted so glob patterns reach the script
+// unexpanded.
+func buildEncryptArtifactStep(stepName string, config *ArtifactEncryptionConfig, outputFile string, paths []string) []string {
+	args := make([]string, 0, len(paths)+1)
+	args = append(args, outputFile)
+	for _, path := range paths {
+		args = append(args, "'"+path+"'")
+	}
+	return []string{
+		fmt.Sprintf("      - name: %s\n", stepName),
+		"        if: always()\n",
+		"        env:\n",
+		"          GH_AW_AGE_RECIPIENTS: |\n",
+		"            " + strings.Join(config.Recipients, "\n            ") + "\n",
+		fmt.Sprintf("        run: bash \"${RUNNER_TEMP}/gh-aw/actions/encrypt_artifact.sh\" %s\n", strings.Join(args, " ")),
+	}
+}
+
+// generateEncryptedTranscriptsUpload emits the steps that encrypt the agent transcripts
+// and upload them as the agent-transcripts artifact.
+func (c *Compiler) generateEncryptedTranscriptsUpload(yaml *strings.Builder, data *WorkflowData, paths []string, prefix string) {
+	if len(paths) == 0 {
+		return
+	}
+	artifactEncryptionLog.Printf("Generating encrypted transcripts upload with %d paths", len(paths))
+	outputFile := constants.TmpGhAwDirExprSlash + constants.AgentTranscriptsArtifactName + constants.EncryptedArtifactSuffix
+
+	// The transcripts were redacted before encryption; record the source paths so the
+	// step-order validator checks them like the plaintext agent artifact.
+	c.stepOrderTracker.RecordArtifactUpload("Upload encrypted agent transcripts", paths)
+
+	for _, line := range buildEncryptArtifactStep("Encrypt agent transcripts", data.ArtifactEncryption, outputFile, paths) {
+		yaml.WriteString(line)
+	}
+	yaml.WriteString("      - name: Upload encrypted agent transcripts\n")
+	yaml.WriteString("        if: always()\n")
+	yaml.WriteString("        continue-on-error: true\n")
+	fmt.Fprintf(yaml, "        uses: %s\n", c.getActionPin("actions/upload-artifact"))
+	yaml.WriteString("        with:\n")
+	fmt.Fprintf(yaml, "          name: %s%s\n", prefix, constants.AgentTranscriptsArtifactName)
+	fmt.Fprintf(yaml, "          path: %s\n", outputFile)
+	yaml.WriteString("          if-no-files-found: ignore\n")
+	if line := artifactRetentionDaysLine(data, "          "); line != "" {
+		yaml.WriteString(line + "\n")
+	}
+}
@@ -0,0 +1,170 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAgeRecipient = "age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p"
+
+func TestExtractArtifactEncryptionConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		frontmatter map[string]any
+		expected    *ArtifactEncryptionConfig
+		wantErr     string
+	}{
+		{
+			name:        "not set",
+			frontmatter: map[string]any{},
+		},
+		{
+			name:        "single recipient defaults to all artifacts",
+			frontmatter: map[string]any{"artifact-encryption": map[string]any{"recipients": testAgeRecipient}},
+			expected: &ArtifactEncryptionConfig{
+				Recipients: []string{testAgeRecipient},
+				Artifacts:  []string{"transcripts", "safe-output-items"},
+			},
+		},
+		{
+			name: "recipient list and selected artifacts",
+			frontmatter: map[string]any{"artifact-encryption": map[string]any{
+				"recipients": []any{testAgeRecipient, "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIExample ops"},
+				"artifacts":  []any{"transcripts", "transcripts"},
+			}},
+			expected: &ArtifactEncryptionConfig{
+				Recipients: []string{testAgeRecipient, "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIExample ops"},
+				Artifacts:  []string{"transcripts"},
+			},
+		},
+		{
+			name:        "not an object",
+			frontmatter: map[string]any{"artifact-encryption": testAgeRecipient},
+			wantErr:     "artifact-encryption must be an object",
+		},
+		{
+			name:        "missing recipients",
+			frontmatter: map[string]any{"artifact-encryption": map[string]any{"artifacts": []any{"transcripts"}}},
+			wantErr:     "must list at least one age public key",
+		},
+		{
+			name:        "not an age key",
+			frontmatter: map[string]any{"artifact-encryption": map[string]any{"recipients": "-----BEGIN PGP PUBLIC KEY BLOCK-----"}},
+			wantErr:     "is not an age public key",
+		},
+		{
+			name:        "expression in recipient",
+			frontmatter: map[string]any{"artifact-encryption": map[string]any{"recipients": "age1${{ secrets.KEY }}"}},
+			wantErr:     "is not an age public key",
+		},
+		{
+			name: "unsupported artifact",
+			frontmatter: map[string]any{"artifact-encryption": map[string]any{
+				"recipients": testAgeRecipient,
+				"artifacts":  []any{"agent-output"},
+			}},
+			wantErr: "artifact-encryption.artifacts entry agent-output is not supported",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := extractArtifactEncryptionConfig(tt.frontmatter)
+			if tt.wantErr != "" {
+				require.Error(t, err, "expected an error")
+				assert.Contains(t, err.Error(), tt.wantErr, "unexpected error message")
+				return
+			}
+			require.NoError(t, err, "unexpected error")
+			assert.Equal(t, tt.expected, config, "unexpected artifact encryption config")
+		})
+	}
+}
+
+func TestSplitEncryptedArtifactPaths(t *testing.T) {
+	engine := NewClaudeEngine()
+	logFile := "${{ env.GH_AW_TMP_DIR }}/agent-stdio.log"
+	stdoutLog, stderrLog := engineStreamLogPaths(logFile)
+	paths := []string{"${{ env.GH_AW_TMP_DIR }}/agent_output.json", logFile, stdoutLog, stderrLog, "${{ env.GH_AW_TMP_DIR }}/mcp-logs/", "${{ env.GH_AW_TMP_DIR }}/aw-*.patch"}
+
+	plaintext, encrypted := splitEncryptedArtifactPaths(&WorkflowData{}, engine, logFile, paths)
+	assert.Equal(t, paths, plaintext, "paths should be unchanged without artifact-encryption")
+	assert.Empty(t, encrypted, "nothing should be encrypted without artifact-encryption")
+
+	data := &WorkflowData{ArtifactEncryption: &ArtifactEncryptionConfig{Recipients: []string{testAgeRecipient}, Artifacts: []string{"transcripts"}}}
+	plaintext, encrypted = splitEncryptedArtifactPaths(data, engine, logFile, paths)
+	assert.Equal(t, []string{"${{ env.GH_AW_TMP_DIR }}/agent_output.json", "${{ env.GH_AW_TMP_DIR }}/aw-*.patch"}, plaintext, "agent output and patches should stay plaintext")
+	assert.Equal(t, []string{logFile, stdoutLog, stderrLog, "${{ env.GH_AW_TMP_DIR }}/mcp-logs/"}, encrypted, "transcripts and the engine stream logs should be encrypted")
+}
+
+func TestArtifactEncryptionUploadSteps(t *testing.T) {
+	compiler := NewCompiler()
+	data := &WorkflowData{
+		ArtifactEncryption: &ArtifactEncryptionConfig{Recipients: []string{testAgeRecipient}, Artifacts: []string{"transcripts", "safe-output-items"}},
+		Retention:          &RetentionConfig{Days: 3},
+	}
+
+	var yaml strings.Builder
+	compiler.generateEncryptedTranscriptsUpload(&yaml, data, []string{"${{ env.GH_AW_TMP_DIR }}/agent-stdio.log", "${{ env.GH_AW_TMP_DIR }}/mcp-logs/"}, "")
+	transcripts := yaml.String()
+	assert.Contains(t, transcripts, "GH_AW_AGE_RECIPIENTS: |\n            "+testAgeRecipient+"\n", "recipients should be passed to the script")
+	assert.Contains(t, transcripts, `encrypt_artifact.sh" ${{ env.GH_AW_TMP_DIR }}/agent-transcripts.tar.gz.age '${{ env.GH_AW_TMP_DIR }}/agent-stdio.log' '${{ env.GH_AW_TMP_DIR }}/mcp-logs/'`, "transcripts should be encrypted into one archive")
+	assert.Contains(t, transcripts, "name: agent-transcripts\n", "transcripts should be uploaded as their own artifact")
+	assert.Contains(t, transcripts, "retention-days: 3", "retention should apply to the encrypted transcripts")
+
+	items := strings.Join(buildSafeOutputItemsManifestUploadStep(data, "", compiler.getActionPin), "")
+	assert.Contains(t, items, "- name: Encrypt Safe Outputs Items", "safe output items should be encrypted")
+	assert.Contains(t, items, "path: |\n            ${{ env.GH_AW_TMP_DIR }}/safe-outputs-items.tar.gz.age\n", "only the encrypted archive should be uploaded")
+	assert.NotContains(t, items, "            ${{ env.GH_AW_TMP_DIR }}/safe-output-items.jsonl\n", "plaintext items should not be uploaded")
+
+	yaml.Reset()
+	compiler.generateEncryptedTranscriptsUpload(&yaml, data, nil, "")
+	assert.Empty(t, yaml.String(), "no steps should be emitted without transcript paths")
+}
+
+func TestCompileArtifactEncryptionKeepsEngineLogsOutOfPlaintextUpload(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "artifact-encryption-*")
+	markdownPath := filepath.Join(tmpDir, "test.md")
+	content := `---
+on: issues
+permissions:
+  contents: read
+engine: copilot
+artifact-encryption:
+  recipients: ` + testAgeRecipient + `
+---
+
+# Test
+`
+	require.NoError(t, os.WriteFile(markdownPath, []byte(content), 0o644))
+	require.NoError(t, NewCompiler().CompileWorkflow(markdownPath), "workflow should compile")
+
+	lock, err := os.ReadFile(filepath.Join(tmpDir, "test.lock.yml"))
+	require.NoError(t, err)
+	lockStr := string(lock)
+
+	uploadIdx := strings.Index(lockStr, "- name: Upload agent artifacts")
+	require.NotEqual(t, -1, uploadIdx, "agent artifacts should be uploaded")
+	upload := lockStr[uploadIdx:]
+	upload = upload[:strings.Index(upload, "if-no-files-found")]
+
+	encryptIdx := strings.Index(lockStr, "encrypt_artifact.sh")
+	require.NotEqual(t, -1, encryptIdx, "transcripts should be encrypted")
+	encrypt := lockStr[encryptIdx:]
+	encrypt = encrypt[:strings.Index(encrypt, "\n")]
+
+	stdoutLog, stderrLog := engineStreamLogPaths(constants.TmpGhAwDirExpr + "/agent-stdio.log")
+	for _, logFile := range []string{stdoutLog, stderrLog} {
+		assert.NotContains(t, upload, logFile, "engine stream log should not be uploaded in plaintext")
+		assert.Contains(t, encrypt, logFile, "engine stream log should be encrypted")
+	}
+}
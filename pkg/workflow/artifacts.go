@@ -18,6 +18,8 @@ type ArtifactDownloadConfig struct {
 	StepName         string // Optional custom step name (defaults to "Download {artifact} artifact")
 	IfCondition      string // Optional conditional expression for the step (e.g., "needs.agent.outputs.has_patch == 'true'")
 	StepID           string // Optional step ID; when set, the env-setup step is gated on this step's success
+	RunID            string // Optional workflow run ID to download from another run (requires GitHubToken)
+	GitHubToken      string // Optional token used to download artifacts from another run
 }
 
 // buildArtifactDownloadSteps creates steps to download a GitHub Actions artifact.
@@ -53,6 +55,10 @@ func buildArtifactDownloadSteps(config ArtifactDownloadConfig, pinAction func(st
 	steps = append(steps, "        with:\n")
 	steps = append(steps, fmt.Sprintf("          name: %s\n", config.ArtifactName))
 	steps = append(steps, fmt.Sprintf("          path: %s\n", config.DownloadPath))
+	if config.RunID != "" {
+		steps = append(steps, fmt.Sprintf("          run-id: %s\n", config.RunID))
+		steps = append(steps, fmt.Sprintf("          github-token: %s\n", config.GitHubToken))
+	}
 
 	// Add environment variable setup if requested
 	if config.SetupEnvStep {
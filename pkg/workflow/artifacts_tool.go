@@ -0,0 +1,210 @@
+// This file implements the artifacts: tool.
+//
+// # Artifacts Tool
+//
+// The artifacts tool gives the agent a sanctioned way to publish files (reports, charts,
+// exports) without edit or commit rights:
+//
+//	tools:
+//	  artifacts:
+//	    max-file-size: 5242880
+//	    blocked-extensions: [.zip]
+//	    retention-days: 14
+//
+// The agent writes files to /tmp/gh-aw/artifacts/. After the agent finishes, a trusted step
+// copies the regular files that pass the limits (file size, file count, total size and an
+// extension denylist) to a staging directory, skipping symbolic links, and uploads the staging
+// directory as the agent-artifacts run artifact. Skipped files are listed in the step summary.
+// The denylist always contains executables, scripts and key material; blocked-extensions adds
+// to it.
+
+package workflow
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var artifactsToolLog = logger.New("workflow:artifacts_tool")
+
+const (
+	// artifactsToolDir is the directory the agent writes files to publish.
+	artifactsToolDir = constants.TmpGhAwDirExpr + "/artifacts"
+	// artifactsToolStagingDir receives the files that pass the limits before upload.
+	artifactsToolStagingDir = "${{ runner.temp }}/gh-aw/agent-artifacts"
+	// AgentArtifactsArtifactName is the name of the run artifact holding the agent's files.
+	AgentArtifactsArtifactName = "agent-artifacts"
+
+	defaultArtifactsMaxFileSize  = 10485760  // 10MB
+	defaultArtifactsMaxFileCount = 50        // files
+	defaultArtifactsMaxTotalSize = 104857600 // 100MB
+)
+
+// defaultArtifactsBlockedExtensions are never uploaded: executables, scripts and key material.
+var defaultArtifactsBlockedExtensions = []string{
+	".apk", ".bat", ".cmd", ".com", ".deb", ".dll", ".dylib", ".env", ".exe", ".jar", ".key",
+	".msi", ".p12", ".pem", ".pfx", ".ps1", ".rpm", ".scr", ".sh", ".so", ".vbs",
+}
+
+// ArtifactsToolConfig holds the configuration of the artifacts: tool.
+type ArtifactsToolConfig struct {
+	MaxFileSize       int      // maximum size per file in bytes
+	MaxFileCount      int      // maximum number of uploaded files
+	MaxTotalSize      int      // maximum size of all uploaded files in bytes
+	BlockedExtensions []string // lowercase extensions that are never uploaded (defaults included)
+	RetentionDays     int      // artifact retention in days (0 uses the repository default)
+}
+
+// extractArtifactsToolConfig parses tools.artifacts and removes it from the tools map, since
+// the tool is implemented by workflow steps rather than by an MCP server.
+func extractArtifactsToolConfig(tools map[string]any) (*ArtifactsToolConfig, error) {
+	raw, ok := tools["artifacts"]
+	if !ok {
+		return nil, nil
+	}
+	delete(tools, "artifacts")
+
+	config := &ArtifactsToolConfig{
+		MaxFileSize:       defaultArtifactsMaxFileSize,
+		MaxFileCount:      defaultArtifactsMaxFileCount,
+		MaxTotalSize:      defaultArtifactsMaxTotalSize,
+		BlockedExtensions: slices.Clone(defaultArtifactsBlockedExtensions),
+	}
+	switch v := raw.(type) {
+	case nil:
+		// tools.artifacts: with no value enables the tool with the defaults
+	case bool:
+		if !v {
+			return nil, nil
+		}
+	case map[string]any:
+		limits := []struct {
+			key   string
+			min   int
+			max   int
+			field *int
+		}{
+			{key: "max-file-size", min: 1, max: 524288000, field: &config.MaxFileSize},
+			{key: "max-file-count", min: 1, max: 1000, field: &config.MaxFileCount},
+			{key: "max-total-size", min: 1, max: 1073741824, field: &config.MaxTotalSize},
+			{key: "retention-days", min: 1, max: 90, field: &config.RetentionDays},
+		}
+		for _, limit := range limits {
+			value, exists := v[limit.key]
+			if !exists {
+				continue
+			}
+			n, ok := parseIntValue(value)
+			if !ok {
+				return nil, fmt.Errorf("tools.artifacts.%s must be an integer", limit.key)
+			}
+			if err := validateIntRange(n, limit.min, limit.max, "tools.artifacts."+limit.key); err != nil {
+				return nil, err
+			}
+			*limit.field = n
+		}
+		if blocked, exists := v["blocked-extensions"]; exists {
+			extensions, ok := blocked.([]any)
+			if !ok {
+				return nil, errors.New("tools.artifacts.blocked-extensions must be a list of file extensions")
+			}
+			for _, item := range extensions {
+				ext, ok := item.(string)
+				ext = strings.ToLower(strings.TrimSpace(ext))
+				if !ok || len(ext) < 2 || !strings.HasPrefix(ext, ".") || strings.ContainsAny(ext, "/\\") {
+					return nil, fmt.Errorf("tools.artifacts.blocked-extensions entries must be file extensions such as .zip, got '%v'", item)
+				}
+				if !slices.Contains(config.BlockedExtensions, ext) {
+					config.BlockedExtensions = append(config.BlockedExtensions, ext)
+				}
+			}
+			slices.Sort(config.BlockedExtensions)
+		}
+	default:
+		return nil, fmt.Errorf("tools.artifacts must be true, false or an object, got %T", raw)
+	}
+	if config.MaxTotalSize < config.MaxFileSize {
+		return nil, fmt.Errorf("tools.artifacts.max-total-size (%d) must not be less than max-file-size (%d)", config.MaxTotalSize, config.MaxFileSize)
+	}
+	artifactsToolLog.Printf("Artifacts tool enabled: max-file-size=%d, max-file-count=%d, max-total-size=%d, blocked=%d",
+		config.MaxFileSize, config.MaxFileCount, config.MaxTotalSize, len(config.BlockedExtensions))
+	return config, nil
+}
+
+// generateArtifactsToolDirStep creates the directory the agent writes files to publish.
+func generateArtifactsToolDirStep(builder *strings.Builder, data *WorkflowData) {
+	if data.ArtifactsTool == nil {
+		return
+	}
+	builder.WriteString("      - name: Create agent artifacts directory\n")
+	fmt.Fprintf(builder, "        run: mkdir -p %s\n", artifactsToolDir)
+}
+
+// generateArtifactsToolUploadSteps collects the files the agent published and uploads them
+// as a run artifact. It runs after the agent, so the collection is outside the agent's control.
+func generateArtifactsToolUploadSteps(builder *strings.Builder, data *WorkflowData, pinAction func(string) string) {
+	if data.ArtifactsTool == nil {
+		return
+	}
+	artifactsToolLog.Print("Generating agent artifacts collection and upload steps")
+
+	config, _ := json.Marshal(map[string]any{ //nolint:jsonmarshalignoredeerror // marshaling strings, ints and a string slice cannot fail
+		"sourceDir":         artifactsToolDir,
+		"stagingDir":        artifactsToolStagingDir,
+		"maxFileSize":       data.ArtifactsTool.MaxFileSize,
+		"maxFileCount":      data.ArtifactsTool.MaxFileCount,
+		"maxTotalSize":      data.ArtifactsTool.MaxTotalSize,
+		"blockedExtensions": data.ArtifactsTool.BlockedExtensions,
+	})
+
+	var script strings.Builder
+	script.WriteString("            const { setupGlobals } = require('${{ runner.temp }}/gh-aw/actions/setup_globals.cjs');\n")
+	script.WriteString("            setupGlobals(core, github, context, exec, io, getOctokit);\n")
+	script.WriteString("            const { collectAgentArtifacts } = require('${{ runner.temp }}/gh-aw/actions/collect_agent_artifacts.cjs');\n")
+	fmt.Fprintf(&script, "            await collectAgentArtifacts(%s);\n", config)
+	builder.WriteString(generateInlineGitHubScriptStep("Collect files published by the agent", script.String(), "always()", data))
+
+	builder.WriteString("      - name: Upload files published by the agent\n")
+	builder.WriteString("        if: always()\n")
+	fmt.Fprintf(builder, "        uses: %s\n", pinAction("actions/upload-artifact"))
+	builder.WriteString("        with:\n")
+	fmt.Fprintf(builder, "          name: %s%s\n", artifactPrefixExprForDownstreamJob(data), AgentArtifactsArtifactName)
+	fmt.Fprintf(builder, "          path: %s\n", artifactsToolStagingDir)
+	if data.ArtifactsTool.RetentionDays > 0 {
+		fmt.Fprintf(builder, "          retention-days: %d\n", data.ArtifactsTool.RetentionDays)
+	}
+	builder.WriteString("          if-no-files-found: ignore\n")
+}
+
+// buildArtifactsToolPromptSection tells the agent where to write files to publish.
+func buildArtifactsToolPromptSection(data *WorkflowData) *PromptSection {
+	if data.ArtifactsTool == nil {
+		return nil
+	}
+	config := data.ArtifactsTool
+	var content strings.Builder
+	content.WriteString("<artifacts>\n")
+	fmt.Fprintf(&content, "To publish files (reports, charts, exports) from this run, write them to %s/. They are uploaded as the %q workflow run artifact after you finish.\n", promptScratchPath(artifactsToolDir), AgentArtifactsArtifactName)
+	fmt.Fprintf(&content, "Limits: at most %d files, %s per file and %s in total. Files with these extensions are not uploaded: %s. Symbolic links are not uploaded.\n",
+		config.MaxFileCount, formatArtifactsSize(config.MaxFileSize), formatArtifactsSize(config.MaxTotalSize), strings.Join(config.BlockedExtensions, ", "))
+	content.WriteString("</artifacts>")
+	return &PromptSection{Content: content.String()}
+}
+
+// formatArtifactsSize renders a byte count for the prompt.
+func formatArtifactsSize(bytes int) string {
+	switch {
+	case bytes >= 1048576 && bytes%1048576 == 0:
+		return fmt.Sprintf("%dMB", bytes/1048576)
+	case bytes >= 1024 && bytes%1024 == 0:
+		return fmt.Sprintf("%dKB", bytes/1024)
+	default:
+		return fmt.Sprintf("%d bytes", bytes)
+	}
+}
@@ -0,0 +1,95 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractArtifactsToolConfig(t *testing.T) {
+	defaults := &ArtifactsToolConfig{
+		MaxFileSize:       defaultArtifactsMaxFileSize,
+		MaxFileCount:      defaultArtifactsMaxFileCount,
+		MaxTotalSize:      defaultArtifactsMaxTotalSize,
+		BlockedExtensions: defaultArtifactsBlockedExtensions,
+	}
+	tests := []struct {
+		name    string
+		raw     any
+		want    *ArtifactsToolConfig
+		wantErr string
+	}{
+		{name: "true uses the defaults", raw: true, want: defaults},
+		{name: "null uses the defaults", raw: nil, want: defaults},
+		{name: "false disables", raw: false, want: nil},
+		{
+			name: "custom limits",
+			raw:  map[string]any{"max-file-size": 2048, "max-file-count": 5, "max-total-size": 4096, "retention-days": 14},
+			want: &ArtifactsToolConfig{MaxFileSize: 2048, MaxFileCount: 5, MaxTotalSize: 4096, BlockedExtensions: defaultArtifactsBlockedExtensions, RetentionDays: 14},
+		},
+		{name: "retention out of range", raw: map[string]any{"retention-days": 120}, wantErr: "tools.artifacts.retention-days must be between 1 and 90"},
+		{name: "total below file size", raw: map[string]any{"max-file-size": 4096, "max-total-size": 1024}, wantErr: "must not be less than max-file-size"},
+		{name: "invalid extension", raw: map[string]any{"blocked-extensions": []any{"zip"}}, wantErr: "must be file extensions"},
+		{name: "wrong type", raw: "yes", wantErr: "must be true, false or an object"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tools := map[string]any{"artifacts": tt.raw, "edit": nil}
+			config, err := extractArtifactsToolConfig(tools)
+			if tt.wantErr != "" {
+				require.Error(t, err, "tools.artifacts should be rejected")
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, config)
+			assert.NotContains(t, tools, "artifacts", "the tool should be removed from the tools map")
+			assert.Contains(t, tools, "edit", "other tools should be kept")
+		})
+	}
+
+	config, err := extractArtifactsToolConfig(map[string]any{"artifacts": map[string]any{"blocked-extensions": []any{".ZIP", ".exe"}}})
+	require.NoError(t, err)
+	assert.Contains(t, config.BlockedExtensions, ".zip", "extra extensions should be added in lowercase")
+	assert.Len(t, config.BlockedExtensions, len(defaultArtifactsBlockedExtensions)+1, "default extensions should not be duplicated")
+}
+
+func TestCompileArtifactsTool(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "artifacts-tool-*")
+	markdownPath := filepath.Join(tmpDir, "report.md")
+	content := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+tools:
+  artifacts:
+    max-file-size: 5242880
+    retention-days: 14
+---
+
+# Report
+
+Write a report.
+`
+	require.NoError(t, os.WriteFile(markdownPath, []byte(content), 0o644))
+	require.NoError(t, NewCompiler().CompileWorkflow(markdownPath), "workflow with the artifacts tool should compile")
+
+	lockContent, err := os.ReadFile(filepath.Join(tmpDir, "report.lock.yml"))
+	require.NoError(t, err)
+	lock := string(lockContent)
+
+	assert.Contains(t, lock, "run: mkdir -p ${{ env.GH_AW_TMP_DIR }}/artifacts", "the artifacts directory should be created before the agent runs")
+	assert.Contains(t, lock, "To publish files (reports, charts, exports) from this run, write them to __GH_AW_TMP_DIR__/artifacts/", "the agent should be told where to write files")
+	assert.Contains(t, lock, "at most 50 files, 5MB per file and 100MB in total")
+	assert.Contains(t, lock, "require('${{ runner.temp }}/gh-aw/actions/collect_agent_artifacts.cjs')")
+	assert.Contains(t, lock, `"maxFileSize":5242880`)
+	assert.Contains(t, lock, "name: agent-artifacts\n          path: ${{ runner.temp }}/gh-aw/agent-artifacts\n          retention-days: 14\n", "the collected files should be uploaded")
+	assert.NotContains(t, lock, "\"artifacts\":", "the tool should not be configured as an MCP server")
+}
@@ -95,7 +95,7 @@ This workflow tests that aw_info.json is generated in /tmp directory.
 	}
 
 	// Test 3: Verify upload artifact still includes /tmp/gh-aw/aw_info.json path
-	if !strings.Contains(lockStr, "/tmp/gh-aw/aw_info.json") {
+	if !strings.Contains(lockStr, "${{ env.GH_AW_TMP_DIR }}/aw_info.json") {
 		t.Error("Expected artifact upload to include '/tmp/gh-aw/aw_info.json' path in generated workflow")
 	}
 
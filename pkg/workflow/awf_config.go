@@ -169,6 +169,9 @@ type AWFConfigFile struct {
 	// APIProxy contains API proxy (LLM gateway) configuration.
 	APIProxy *AWFAPIProxyConfig `json:"apiProxy,omitempty"`
 
+	// Security contains host access configuration.
+	Security *AWFSecurityConfig `json:"security,omitempty"`
+
 	// Container contains container execution configuration.
 	Container *AWFContainerConfig `json:"container,omitempty"`
 
@@ -204,6 +207,10 @@ type AWFNetworkConfig struct {
 	// Maps to: --block-domains <comma-separated>
 	BlockDomains []string `json:"blockDomains,omitempty"`
 
+	// UpstreamProxy is the corporate proxy (network.proxy) that the AWF Squid proxy
+	// forwards allowed traffic through.
+	UpstreamProxy string `json:"upstreamProxy,omitempty"`
+
 	// Isolation enables topology-based egress isolation mode.
 	// Maps to: --network-isolation
 	Isolation bool `json:"isolation,omitempty"`
@@ -318,6 +325,21 @@ type AWFContainerConfig struct {
 	// "gvisor" enables gVisor's runsc runtime for additional kernel-level isolation.
 	// AWF translates "gvisor" → "runsc" internally.
 	ContainerRuntime string `json:"containerRuntime,omitempty"`
+
+	// AgentImage overrides the agent container's base image.
+	// Set to the locally built devcontainer image for sandbox.agent.devcontainer.
+	AgentImage string `json:"agentImage,omitempty"`
+
+	// BuildLocal builds the agent container locally on top of AgentImage instead of
+	// pulling the prebuilt agent image.
+	BuildLocal bool `json:"buildLocal,omitempty"`
+}
+
+// AWFSecurityConfig is the "security" section of the AWF config file.
+type AWFSecurityConfig struct {
+	// EnableHostAccess controls whether the agent chroots into the runner filesystem.
+	// Set to false for sandbox.agent.devcontainer so the agent uses the image's toolchain.
+	EnableHostAccess *bool `json:"enableHostAccess,omitempty"`
 }
 
 // AWFLoggingConfig is the "logging" section of the AWF config file.
@@ -430,6 +452,16 @@ func BuildAWFConfigJSON(config AWFCommandConfig) (string, error) {
 		}
 	}
 
+	// Runners behind a corporate proxy cannot reach the internet directly, so the
+	// Squid proxy must chain to it (network.proxy).
+	if proxy := getNetworkProxyConfig(config.WorkflowData); proxy != nil {
+		if awfConfig.Network == nil {
+			awfConfig.Network = &AWFNetworkConfig{}
+		}
+		awfConfig.Network.UpstreamProxy = proxy.URL
+		awfConfigLog.Print("Network section: upstream proxy configured")
+	}
+
 	if isAWFNetworkIsolationEnabled(config.WorkflowData) {
 		if awfConfig.Network == nil {
 			awfConfig.Network = &AWFNetworkConfig{}
@@ -591,12 +623,18 @@ func BuildAWFConfigJSON(config AWFCommandConfig) (string, error) {
 		}
 		agentRuntime = ""
 	}
-	if awfImageTag != "" || isArcDindTopology(config.WorkflowData) || agentRuntime != "" || agentTimeout > 0 {
+	devcontainer := isDevcontainerRuntime(config.WorkflowData)
+	if awfImageTag != "" || isArcDindTopology(config.WorkflowData) || agentRuntime != "" || agentTimeout > 0 || devcontainer {
 		container := &AWFContainerConfig{
 			ImageTag:         awfImageTag,
 			AgentTimeout:     agentTimeout,
 			ContainerRuntime: agentRuntime,
 		}
+		if devcontainer {
+			container.AgentImage = devcontainerImageName
+			container.BuildLocal = true
+			awfConfigLog.Printf("Container section: agentImage=%s (devcontainer)", devcontainerImageName)
+		}
 		// NOTE: dockerHostPathPrefix is intentionally NOT set for arc-dind topology.
 		// With sysroot-stage active, the Docker daemon can access all needed paths:
 		//  - Workspace & RUNNER_TEMP: on the shared work volume (/home/runner/_work/)
@@ -617,6 +655,15 @@ func BuildAWFConfigJSON(config AWFCommandConfig) (string, error) {
 		}
 	}
 
+	// ── Security section ──────────────────────────────────────────────────────
+	// The devcontainer provides the toolchain, so the agent must not chroot into the
+	// runner filesystem. Runner paths the engine needs are mounted by BuildAWFCommand.
+	if devcontainer {
+		enableHostAccess := false
+		awfConfig.Security = &AWFSecurityConfig{EnableHostAccess: &enableHostAccess}
+		awfConfigLog.Print("Security section: enableHostAccess=false (devcontainer)")
+	}
+
 	// ── Logging section ──────────────────────────────────────────────────────
 	// Logging paths are set in config. For ARC/DinD, the config file is written at runtime,
 	// so ${RUNNER_TEMP} can be preserved for shell expansion before AWF reads the JSON.
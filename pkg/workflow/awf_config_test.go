@@ -106,8 +106,8 @@ func TestBuildAWFConfigJSON(t *testing.T) {
 
 		// logging section
 		assert.Contains(t, jsonStr, `"logging"`, "should include logging section")
-		assert.Contains(t, jsonStr, `"proxyLogsDir":"/tmp/gh-aw/sandbox/firewall/logs"`, "should include proxyLogsDir")
-		assert.Contains(t, jsonStr, `"auditDir":"/tmp/gh-aw/sandbox/firewall/audit"`, "should include auditDir")
+		assert.Contains(t, jsonStr, `"proxyLogsDir":"${{ env.GH_AW_TMP_DIR }}/sandbox/firewall/logs"`, "should include proxyLogsDir")
+		assert.Contains(t, jsonStr, `"auditDir":"${{ env.GH_AW_TMP_DIR }}/sandbox/firewall/audit"`, "should include auditDir")
 	})
 
 	t.Run("platform config is omitted when sandbox agent is disabled", func(t *testing.T) {
@@ -154,6 +154,41 @@ func TestBuildAWFConfigJSON(t *testing.T) {
 		assert.Contains(t, jsonStr, "ads.example.com", "should include the blocked domain")
 	})
 
+	t.Run("network proxy is set as the upstream proxy", func(t *testing.T) {
+		config := AWFCommandConfig{
+			EngineName:     "copilot",
+			AllowedDomains: "github.com",
+			WorkflowData: &WorkflowData{
+				EngineConfig: &EngineConfig{ID: "copilot"},
+				NetworkPermissions: &NetworkPermissions{
+					Firewall: &FirewallConfig{Enabled: true},
+					Proxy:    &NetworkProxyConfig{URL: "http://proxy.corp.example.com:3128"},
+				},
+			},
+		}
+
+		jsonStr, err := BuildAWFConfigJSON(config)
+		require.NoError(t, err, "BuildAWFConfigJSON should not return an error")
+		require.NoError(t, validateAWFConfigJSON(jsonStr), "config with an upstream proxy should match the AWF schema")
+
+		assert.Contains(t, jsonStr, `"upstreamProxy":"http://proxy.corp.example.com:3128"`, "should chain the Squid proxy to network.proxy")
+	})
+
+	t.Run("upstream proxy is omitted without network proxy", func(t *testing.T) {
+		config := AWFCommandConfig{
+			EngineName:     "copilot",
+			AllowedDomains: "github.com",
+			WorkflowData: &WorkflowData{
+				EngineConfig:       &EngineConfig{ID: "copilot"},
+				NetworkPermissions: &NetworkPermissions{Firewall: &FirewallConfig{Enabled: true}},
+			},
+		}
+
+		jsonStr, err := BuildAWFConfigJSON(config)
+		require.NoError(t, err, "BuildAWFConfigJSON should not return an error")
+		assert.NotContains(t, jsonStr, `"upstreamProxy"`, "should not set an upstream proxy")
+	})
+
 	t.Run("network isolation emits isolation and topologyAttach", func(t *testing.T) {
 		config := AWFCommandConfig{
 			EngineName:     "copilot",
@@ -1397,7 +1432,7 @@ func TestBuildAWFCommand_UsesConfigFile(t *testing.T) {
 	config := AWFCommandConfig{
 		EngineName:     "copilot",
 		EngineCommand:  "copilot --prompt-file /tmp/prompt.txt",
-		LogFile:        "/tmp/gh-aw/agent-stdio.log",
+		LogFile:        "${{ env.GH_AW_TMP_DIR }}/agent-stdio.log",
 		AllowedDomains: "github.com,api.github.com",
 		WorkflowData: &WorkflowData{
 			EngineConfig: &EngineConfig{ID: "copilot"},
@@ -1413,14 +1448,14 @@ func TestBuildAWFCommand_UsesConfigFile(t *testing.T) {
 	assert.Contains(t, command, "printf", "expected printf command to write the config file")
 	assert.Contains(t, command, "awf-config.json", "expected awf-config.json reference")
 
-	// Should copy the config file to /tmp/gh-aw/awf-config.json for artifact upload
-	assert.Contains(t, command, constants.AWFConfigFilePath, "expected awf-config.json to be copied to /tmp/gh-aw/")
+	// Should copy the config file to ${{ env.GH_AW_TMP_DIR }}/awf-config.json for artifact upload
+	assert.Contains(t, command, constants.AWFConfigFilePath, "expected awf-config.json to be copied to ${{ env.GH_AW_TMP_DIR }}/")
 
 	// Should reference the config file via --config
 	assert.Contains(t, command, "--config", "expected --config flag in AWF invocation")
 
 	// Should pass the merged models.json path (written by generate_aw_info.cjs in the activation job).
-	assert.Contains(t, command, `export GH_AW_MODELS_JSON_PATH="/tmp/gh-aw/models.json"`, "expected AWF command to export the merged models.json path from /tmp/gh-aw")
+	assert.Contains(t, command, `export GH_AW_MODELS_JSON_PATH="${{ env.GH_AW_TMP_DIR }}/models.json"`, "expected AWF command to export the merged models.json path from ${{ env.GH_AW_TMP_DIR }}")
 
 	// Should NOT have --allow-domains as a CLI flag (moved to config file)
 	assert.NotContains(t, command, "--allow-domains", "expected --allow-domains to be absent from CLI args")
@@ -42,7 +42,7 @@ const (
 	awfToolCacheMountVarName   = "GH_AW_TOOL_CACHE_MOUNT"
 	awfMaxAICreditsVarName     = "GH_AW_MAX_AI_CREDITS"
 	awfConfigRuntimePathExpr   = "${RUNNER_TEMP}/gh-aw/awf-config.json"
-	awfModelsJSONPathExpr      = "/tmp/gh-aw/models.json"
+	awfModelsJSONPathExpr      = constants.TmpGhAwDirExpr + "/models.json"
 	awfArcDindRootPathExpr     = "${RUNNER_TEMP}/gh-aw"
 	awfArcDindHomePathExpr     = "${RUNNER_TEMP}/gh-aw/home"
 	awfArcDindProxyLogsDirExpr = "${RUNNER_TEMP}/gh-aw/sandbox/firewall/logs"
@@ -136,7 +136,7 @@ func buildModelsJSONPathExportScript(isArcDind bool) string {
 }
 
 func rewriteArcDindPath(path string) string {
-	return strings.ReplaceAll(path, constants.TmpGhAwDir, awfArcDindRootPathExpr)
+	return strings.ReplaceAll(path, constants.TmpGhAwDirExpr, awfArcDindRootPathExpr)
 }
 
 func rewriteArcDindEngineCommand(command string) string {
@@ -317,11 +317,17 @@ fi`,
 		// Copy prompt files to daemon-visible path. On ARC/DinD, /tmp/gh-aw/ is NOT
 		// accessible to the Docker daemon. The activation job writes prompts to
 		// /tmp/gh-aw/aw-prompts/, so we copy them to ${RUNNER_TEMP}/gh-aw/aw-prompts/.
-		arcDindDockerHostProbe += fmt.Sprintf("\nif [ -d /tmp/gh-aw/aw-prompts ]; then cp -a /tmp/gh-aw/aw-prompts \"%s/aw-prompts\"; fi",
+		arcDindDockerHostProbe += fmt.Sprintf("\nif [ -d "+constants.TmpGhAwDirExpr+"/aw-prompts ]; then cp -a "+constants.TmpGhAwDirExpr+"/aw-prompts \"%s/aw-prompts\"; fi",
 			awfArcDindRootPathExpr,
 		)
 	}
 
+	// devcontainer: the agent does not chroot into the runner, so mount the workspace and
+	// $HOME (engine settings and MCP configuration) at their runner paths.
+	if isDevcontainerRuntime(config.WorkflowData) {
+		expandableArgs += ` --mount "${GITHUB_WORKSPACE}:${GITHUB_WORKSPACE}:rw" --mount "${HOME}:${HOME}:rw"`
+	}
+
 	// Generate a JSON config file and reference it via --config "${RUNNER_TEMP}/gh-aw/awf-config.json".
 	// This replaces several verbose CLI flags (--allow-domains, --enable-api-proxy, --image-tag,
 	// API targets) with a structured JSON file that is easier to audit and extend.
@@ -451,6 +457,10 @@ fi`,
 	}
 
 	engineCommand := config.EngineCommand
+	// sandbox.mcp.chaos/record/replay: start the MCP tool proxy next to the agent.
+	if proxySetup := getMCPToolProxySetup(config.WorkflowData); proxySetup != "" {
+		engineCommand = fmt.Sprintf("%s && %s", proxySetup, engineCommand)
+	}
 	if isArcDind {
 		engineCommand = rewriteArcDindEngineCommand(engineCommand)
 	}
@@ -458,11 +468,11 @@ fi`,
 	// Wrap engine command in shell (command already includes any internal setup like npm PATH)
 	shellWrappedCommand := WrapCommandInShell(engineCommand)
 
-	// Pre-create the agent stdio log file with restrictive permissions (0600) before
-	// starting the AWF container.  tee would otherwise create it with the default
+	// Pre-create the agent log files with restrictive permissions (0600) before
+	// starting the AWF container.  tee would otherwise create them with the default
 	// umask (0644), leaving secrets (e.g. MCP gateway tokens) world-readable on the
 	// runner host until the secret-redaction step runs.
-	preCreateLog := fmt.Sprintf("(umask 177 && touch %s)", shellEscapeArg(config.LogFile))
+	preCreateLog := precreateEngineLogsCommand(config.LogFile)
 
 	// Capture the epoch-millisecond timestamp at the very start of the Execute Agent CLI
 	// step on the host, before the AWF container launches.  sendJobConclusionSpan reads
@@ -485,6 +495,16 @@ fi`,
 	//
 	// We keep normal quoting for all user-controlled values via shellEscapeArg/shellJoinArgs
 	// and scope this suppression to the generated AWF invocation line only.
+	// The AWF invocation's output is captured by captureEngineOutput, which splits the
+	// engine's stdout and stderr into separate logs and strips ANSI control sequences.
+	awfInvocation := captureEngineOutput(fmt.Sprintf("%s %s %s %s %s \\\n  -- %s",
+		awfCommand,
+		expandableArgs,
+		toolCacheMountRef,
+		arcDindDockerHostRef,
+		shellJoinArgs(awfArgs),
+		shellWrappedCommand), config.LogFile)
+
 	var command string
 	if config.PathSetup != "" && configFileSetup != "" {
 		command = fmt.Sprintf(`set -o pipefail
@@ -497,8 +517,7 @@ fi`,
 %s
 %s
 %s
-%s %s %s %s %s \
-  -- %s 2>&1 | tee -a %s`,
+%s`,
 			writeAgentCLIStartMs,
 			config.PathSetup,
 			preCreateLog,
@@ -508,13 +527,7 @@ fi`,
 			arcDindPrefixProbe,
 			toolCacheMountProbe,
 			awfShellcheckDirective,
-			awfCommand,
-			expandableArgs,
-			toolCacheMountRef,
-			arcDindDockerHostRef,
-			shellJoinArgs(awfArgs),
-			shellWrappedCommand,
-			shellEscapeArg(config.LogFile))
+			awfInvocation)
 	} else if config.PathSetup != "" {
 		// Include path setup before AWF command (runs on host before AWF)
 		command = fmt.Sprintf(`set -o pipefail
@@ -526,8 +539,7 @@ fi`,
 %s
 %s
 %s
-%s %s %s %s %s \
-  -- %s 2>&1 | tee -a %s`,
+%s`,
 			writeAgentCLIStartMs,
 			config.PathSetup,
 			preCreateLog,
@@ -536,13 +548,7 @@ fi`,
 			arcDindPrefixProbe,
 			toolCacheMountProbe,
 			awfShellcheckDirective,
-			awfCommand,
-			expandableArgs,
-			toolCacheMountRef,
-			arcDindDockerHostRef,
-			shellJoinArgs(awfArgs),
-			shellWrappedCommand,
-			shellEscapeArg(config.LogFile))
+			awfInvocation)
 	} else if configFileSetup != "" {
 		command = fmt.Sprintf(`set -o pipefail
 %s
@@ -553,8 +559,7 @@ fi`,
 %s
 %s
 %s
-%s %s %s %s %s \
-  -- %s 2>&1 | tee -a %s`,
+%s`,
 			writeAgentCLIStartMs,
 			preCreateLog,
 			configFileSetup,
@@ -563,13 +568,7 @@ fi`,
 			arcDindPrefixProbe,
 			toolCacheMountProbe,
 			awfShellcheckDirective,
-			awfCommand,
-			expandableArgs,
-			toolCacheMountRef,
-			arcDindDockerHostRef,
-			shellJoinArgs(awfArgs),
-			shellWrappedCommand,
-			shellEscapeArg(config.LogFile))
+			awfInvocation)
 	} else {
 		command = fmt.Sprintf(`set -o pipefail
 %s
@@ -579,8 +578,7 @@ fi`,
 %s
 %s
 %s
-%s %s %s %s %s \
-  -- %s 2>&1 | tee -a %s`,
+%s`,
 			writeAgentCLIStartMs,
 			preCreateLog,
 			modelsJSONPathExport,
@@ -588,13 +586,7 @@ fi`,
 			arcDindPrefixProbe,
 			toolCacheMountProbe,
 			awfShellcheckDirective,
-			awfCommand,
-			expandableArgs,
-			toolCacheMountRef,
-			arcDindDockerHostRef,
-			shellJoinArgs(awfArgs),
-			shellWrappedCommand,
-			shellEscapeArg(config.LogFile))
+			awfInvocation)
 	}
 
 	awfHelpersLog.Print("Successfully built AWF command")
@@ -1399,7 +1399,7 @@ func TestBuildAWFArgsCliProxy(t *testing.T) {
 		assert.Contains(t, argsStr, "--difc-proxy-host", "Should include --difc-proxy-host when cli-proxy is enabled")
 		assert.Contains(t, argsStr, "host.docker.internal:18443", "Should use host.docker.internal:18443 as proxy host")
 		assert.Contains(t, argsStr, "--difc-proxy-ca-cert", "Should include --difc-proxy-ca-cert")
-		assert.Contains(t, argsStr, "/tmp/gh-aw/difc-proxy-tls/ca.crt", "Should use the correct CA cert path")
+		assert.Contains(t, argsStr, "${{ env.GH_AW_TMP_DIR }}/difc-proxy-tls/ca.crt", "Should use the correct CA cert path")
 		assert.NotContains(t, argsStr, "--enable-cli-proxy", "Should not include deprecated --enable-cli-proxy")
 		assert.NotContains(t, argsStr, "--cli-proxy-policy", "Should not include deprecated --cli-proxy-policy")
 	})
@@ -1880,7 +1880,7 @@ func TestBuildAWFCommand_IncludesChrootInjectScript(t *testing.T) {
 
 func TestBuildModelsJSONPathExportScript(t *testing.T) {
 	t.Run("uses tmp path by default", func(t *testing.T) {
-		assert.Equal(t, `export GH_AW_MODELS_JSON_PATH="/tmp/gh-aw/models.json"`, buildModelsJSONPathExportScript(false))
+		assert.Equal(t, `export GH_AW_MODELS_JSON_PATH="${{ env.GH_AW_TMP_DIR }}/models.json"`, buildModelsJSONPathExportScript(false))
 	})
 
 	t.Run("uses runner temp path for arc-dind", func(t *testing.T) {
@@ -1890,11 +1890,11 @@ func TestBuildModelsJSONPathExportScript(t *testing.T) {
 
 func TestRewriteArcDindPath(t *testing.T) {
 	t.Run("rewrites tmp gh-aw prefix", func(t *testing.T) {
-		assert.Equal(t, "${RUNNER_TEMP}/gh-aw/aw-prompts/prompt.txt", rewriteArcDindPath("/tmp/gh-aw/aw-prompts/prompt.txt"))
+		assert.Equal(t, "${RUNNER_TEMP}/gh-aw/aw-prompts/prompt.txt", rewriteArcDindPath("${{ env.GH_AW_TMP_DIR }}/aw-prompts/prompt.txt"))
 	})
 
 	t.Run("rewrites multiple occurrences", func(t *testing.T) {
-		input := "/tmp/gh-aw/a /tmp/gh-aw/b"
+		input := "${{ env.GH_AW_TMP_DIR }}/a ${{ env.GH_AW_TMP_DIR }}/b"
 		expected := "${RUNNER_TEMP}/gh-aw/a ${RUNNER_TEMP}/gh-aw/b"
 		assert.Equal(t, expected, rewriteArcDindPath(input))
 	})
@@ -1905,7 +1905,7 @@ func TestRewriteArcDindPath(t *testing.T) {
 }
 
 func TestRewriteArcDindEngineCommand(t *testing.T) {
-	command := "copilot --prompt-file /tmp/gh-aw/aw-prompts/prompt.txt"
+	command := "copilot --prompt-file ${{ env.GH_AW_TMP_DIR }}/aw-prompts/prompt.txt"
 	rewritten := rewriteArcDindEngineCommand(command)
 
 	assert.Contains(t, rewritten, "export HOME=${RUNNER_TEMP}/gh-aw/home")
@@ -0,0 +1,115 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var backportLog = logger.New("workflow:backport")
+
+// defaultBackportLabelPrefix is the label prefix that selects a backport target,
+// e.g. the label "backport release/1.2" selects release/1.2.
+const defaultBackportLabelPrefix = "backport "
+
+// backportHelperPath is where the agent job installs the guarded cherry-pick helper.
+const backportHelperPath = constants.TmpGhAwDirExpr + "/backport/cherry-pick.sh"
+
+// BackportConfig enables the backport primitives of create-pull-request: target-branch
+// selection from pull request labels and a cherry-pick helper that records conflicts.
+// Backport pull requests reuse the create-pull-request push path with an agent-provided
+// base, so allowed-base-branches bounds which branches can be targeted.
+type BackportConfig struct {
+	LabelPrefix string // Label prefix that selects a target branch (default "backport ")
+}
+
+// parseBackportConfig parses safe-outputs.create-pull-request.backport.
+// Accepts true (defaults), false/absent (disabled) or an object with label-prefix.
+func parseBackportConfig(configData map[string]any) *BackportConfig {
+	raw, exists := configData["backport"]
+	if !exists {
+		return nil
+	}
+	switch v := raw.(type) {
+	case bool:
+		if !v {
+			return nil
+		}
+		return &BackportConfig{LabelPrefix: defaultBackportLabelPrefix}
+	case map[string]any:
+		cfg := &BackportConfig{LabelPrefix: defaultBackportLabelPrefix}
+		if prefix, ok := v["label-prefix"].(string); ok && prefix != "" {
+			cfg.LabelPrefix = prefix
+		}
+		return cfg
+	case nil:
+		return &BackportConfig{LabelPrefix: defaultBackportLabelPrefix}
+	default:
+		backportLog.Printf("Ignoring invalid backport value of type %T", raw)
+		return nil
+	}
+}
+
+// isBackportEnabled reports whether create-pull-request has backport configured.
+func isBackportEnabled(safeOutputs *SafeOutputsConfig) bool {
+	return safeOutputs != nil && safeOutputs.CreatePullRequests != nil && safeOutputs.CreatePullRequests.Backport != nil
+}
+
+// validateSafeOutputsBackport requires allowed-base-branches when backport is enabled,
+// since every backport pull request overrides the default base branch.
+func validateSafeOutputsBackport(config *SafeOutputsConfig) error {
+	if !isBackportEnabled(config) {
+		return nil
+	}
+	if len(config.CreatePullRequests.AllowedBaseBranches) == 0 {
+		return errors.New("safe-outputs.create-pull-request.backport requires allowed-base-branches (e.g. [\"release/*\"]) to bound which branches can be targeted")
+	}
+	if strings.TrimSpace(config.CreatePullRequests.Backport.LabelPrefix) == "" {
+		return errors.New("safe-outputs.create-pull-request.backport.label-prefix cannot be empty")
+	}
+	return nil
+}
+
+// addActivationBackportTargetsStep adds the activation step that selects backport target
+// branches from the labels of the triggering pull request.
+func (c *Compiler) addActivationBackportTargetsStep(ctx *activationJobBuildContext) {
+	if !isBackportEnabled(ctx.data.SafeOutputs) {
+		return
+	}
+	prConfig := ctx.data.SafeOutputs.CreatePullRequests
+	backportLog.Printf("Adding backport target selection step: label_prefix=%q", prConfig.Backport.LabelPrefix)
+	ctx.steps = append(ctx.steps, "      - name: Select backport targets\n")
+	ctx.steps = append(ctx.steps, "        id: backport_targets\n")
+	ctx.steps = append(ctx.steps, fmt.Sprintf("        uses: %s\n", getCachedActionPin("actions/github-script", ctx.data)))
+	ctx.steps = append(ctx.steps, "        env:\n")
+	ctx.steps = append(ctx.steps, formatYAMLEnv("          ", "GH_AW_BACKPORT_LABEL_PREFIX", prConfig.Backport.LabelPrefix))
+	ctx.steps = append(ctx.steps, formatYAMLEnv("          ", "GH_AW_BACKPORT_ALLOWED_BRANCHES", strings.Join(prConfig.AllowedBaseBranches, ",")))
+	ctx.steps = append(ctx.steps, "        with:\n")
+	ctx.steps = append(ctx.steps, "          script: |\n")
+	ctx.steps = append(ctx.steps, generateGitHubScriptWithRequire("select_backport_targets.cjs"))
+	ctx.outputs["backport_targets"] = "${{ steps.backport_targets.outputs.targets }}"
+}
+
+// generateBackportHelperSteps installs the cherry-pick helper into the agent job and writes
+// the allowed target patterns and the selected targets next to it.
+func generateBackportHelperSteps(builder *strings.Builder, data *WorkflowData) {
+	if !isBackportEnabled(data.SafeOutputs) {
+		return
+	}
+	backportLog.Print("Generating backport helper install step")
+	prConfig := data.SafeOutputs.CreatePullRequests
+	builder.WriteString("      - name: Install backport helper\n")
+	builder.WriteString("        env:\n")
+	builder.WriteString(formatYAMLEnv("          ", "GH_AW_BACKPORT_ALLOWED_BRANCHES", strings.Join(prConfig.AllowedBaseBranches, ",")))
+	builder.WriteString("          GH_AW_BACKPORT_TARGETS: ${{ needs.activation.outputs.backport_targets }}\n")
+	builder.WriteString("        run: |\n")
+	builder.WriteString("          mkdir -p " + constants.TmpGhAwDirExpr + "/backport\n")
+	fmt.Fprintf(builder, "          install -m 0755 \"${RUNNER_TEMP}/gh-aw/actions/backport_cherry_pick.sh\" %s\n", backportHelperPath)
+	builder.WriteString("          printf '%s\\n' \"$GH_AW_BACKPORT_ALLOWED_BRANCHES\" | tr ',' '\\n' | sed '/^[[:space:]]*$/d' > " + constants.TmpGhAwDirExpr + "/backport/allowed-branches.txt\n")
+	builder.WriteString("          printf '%s\\n' \"$GH_AW_BACKPORT_TARGETS\" | tr ',' '\\n' | sed '/^[[:space:]]*$/d' > " + constants.TmpGhAwDirExpr + "/backport/targets.txt\n")
+	builder.WriteString("          echo \"Backport targets:\"\n")
+	builder.WriteString("          cat " + constants.TmpGhAwDirExpr + "/backport/targets.txt\n")
+}
@@ -0,0 +1,162 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBackportConfig(t *testing.T) {
+	tests := []struct {
+		name       string
+		configData map[string]any
+		want       *BackportConfig
+	}{
+		{
+			name:       "absent",
+			configData: map[string]any{},
+			want:       nil,
+		},
+		{
+			name:       "true uses default prefix",
+			configData: map[string]any{"backport": true},
+			want:       &BackportConfig{LabelPrefix: "backport "},
+		},
+		{
+			name:       "false disables",
+			configData: map[string]any{"backport": false},
+			want:       nil,
+		},
+		{
+			name:       "object with label-prefix",
+			configData: map[string]any{"backport": map[string]any{"label-prefix": "backport-to:"}},
+			want:       &BackportConfig{LabelPrefix: "backport-to:"},
+		},
+		{
+			name:       "empty object uses default prefix",
+			configData: map[string]any{"backport": map[string]any{}},
+			want:       &BackportConfig{LabelPrefix: "backport "},
+		},
+		{
+			name:       "invalid type is ignored",
+			configData: map[string]any{"backport": 42},
+			want:       nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseBackportConfig(tt.configData), "unexpected backport config")
+		})
+	}
+}
+
+func TestValidateSafeOutputsBackport(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *SafeOutputsConfig
+		wantErr string
+	}{
+		{
+			name:   "backport not configured passes",
+			config: &SafeOutputsConfig{CreatePullRequests: &CreatePullRequestsConfig{}},
+		},
+		{
+			name: "missing allowed-base-branches fails",
+			config: &SafeOutputsConfig{CreatePullRequests: &CreatePullRequestsConfig{
+				Backport: &BackportConfig{LabelPrefix: "backport "},
+			}},
+			wantErr: "backport requires allowed-base-branches",
+		},
+		{
+			name: "allowed-base-branches passes",
+			config: &SafeOutputsConfig{CreatePullRequests: &CreatePullRequestsConfig{
+				AllowedBaseBranches: []string{"release/*"},
+				Backport:            &BackportConfig{LabelPrefix: "backport "},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSafeOutputsBackport(tt.config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err, "expected backport validation to pass")
+				return
+			}
+			require.Error(t, err, "expected backport validation to fail")
+			assert.ErrorContains(t, err, tt.wantErr, "expected validation error to name the missing field")
+		})
+	}
+}
+
+func TestBackportCompilesSelectionAndHelperSteps(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "test-*")
+
+	testMarkdown := `---
+on:
+  pull_request:
+    types: [closed, labeled]
+permissions:
+  contents: read
+checkout:
+  fetch-depth: 0
+  fetch: ["release/*"]
+safe-outputs:
+  create-pull-request:
+    max: 3
+    allowed-base-branches: ["release/*"]
+    backport:
+      label-prefix: "backport-to:"
+---
+
+# Backporter
+
+Backport the merged pull request to each target branch.
+`
+
+	mdFile := filepath.Join(tmpDir, "backporter.md")
+	require.NoError(t, os.WriteFile(mdFile, []byte(testMarkdown), 0644), "failed to write test workflow")
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(mdFile), "backport workflow should compile")
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(mdFile))
+	require.NoError(t, err, "failed to read lock file")
+	lock := string(lockContent)
+
+	assert.Contains(t, lock, "id: backport_targets", "activation job should select backport targets")
+	assert.Contains(t, lock, `GH_AW_BACKPORT_LABEL_PREFIX: "backport-to:"`, "selection step should receive the label prefix")
+	assert.Contains(t, lock, `GH_AW_BACKPORT_ALLOWED_BRANCHES: "release/*"`, "selection step should receive allowed-base-branches")
+	assert.Contains(t, lock, "backport_targets: ${{ steps.backport_targets.outputs.targets }}", "activation job should expose the selected targets")
+	assert.Contains(t, lock, "GH_AW_BACKPORT_TARGETS: ${{ needs.activation.outputs.backport_targets }}", "agent job should receive the selected targets")
+	assert.Contains(t, lock, "backport_cherry_pick.sh\" "+backportHelperPath, "agent job should install the cherry-pick helper")
+	assert.Contains(t, lock, "safe_outputs_backport.md", "prompt should include the backport instructions")
+
+	selectIdx := strings.Index(lock, "id: backport_targets")
+	promptIdx := strings.Index(lock, "safe_outputs_backport.md")
+	assert.Less(t, selectIdx, promptIdx, "targets should be selected before the prompt is generated")
+}
+
+func TestBackportAddsHelperToGitCommands(t *testing.T) {
+	compiler := NewCompiler()
+	safeOutputs := &SafeOutputsConfig{CreatePullRequests: &CreatePullRequestsConfig{
+		AllowedBaseBranches: []string{"release/*"},
+		Backport:            &BackportConfig{LabelPrefix: defaultBackportLabelPrefix},
+	}}
+
+	tools := compiler.applyDefaultTools(map[string]any{"bash": []any{"echo"}}, safeOutputs, nil, nil)
+
+	bash, ok := tools["bash"].([]any)
+	require.True(t, ok, "bash tool should be a command list")
+	assert.Contains(t, bash, backportHelperPath+":*", "backport helper should be allowed")
+	assert.NotContains(t, bash, "git cherry-pick:*", "raw git cherry-pick should not be allowed")
+}
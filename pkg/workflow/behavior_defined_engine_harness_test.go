@@ -156,7 +156,7 @@ func TestBehaviorDefinedEngineNoHarnessScript(t *testing.T) {
 
 	execStepContent := strings.Join(steps[0], "\n")
 	assert.Contains(t, execStepContent, "noharness-cli run", "should invoke the command directly")
-	assert.Contains(t, execStepContent, `"$(cat /tmp/gh-aw/aw-prompts/prompt.txt)"`, "direct execution must include inline prompt substitution")
+	assert.Contains(t, execStepContent, `"$(cat ${{ env.GH_AW_TMP_DIR }}/aw-prompts/prompt.txt)"`, "direct execution must include inline prompt substitution")
 	assert.NotContains(t, execStepContent, "GH_AW_NODE_EXEC", "should not use node harness when harness-script is absent")
 	assert.NotContains(t, execStepContent, "GHAW_HARNESS_SCRIPT_EOF", "no harness write step should be present")
 }
@@ -8,10 +8,12 @@ import (
 	"os"
 	"slices"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/github/gh-aw/pkg/constants"
 	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/sliceutil"
 	"github.com/goccy/go-yaml"
 )
 
@@ -20,15 +22,15 @@ var cacheLog = logger.New("workflow:cache")
 // defaultCacheMemoryDir is the canonical runtime path for the default cache-memory.
 // Backward-compatible: workflows that were compiled before multi-cache support was added
 // continue to use this exact path.
-const defaultCacheMemoryDir = "/tmp/gh-aw/cache-memory"
+const defaultCacheMemoryDir = constants.TmpGhAwDirExpr + "/cache-memory"
 
 // cacheMemoryDirPrefix is the path prefix for non-default cache-memory directories.
 // The full path is formed by appending the cache ID: cacheMemoryDirPrefix + cacheID.
-const cacheMemoryDirPrefix = "/tmp/gh-aw/cache-memory-"
+const cacheMemoryDirPrefix = constants.TmpGhAwDirExpr + "/cache-memory-"
 
 // cacheMemoryDirFor returns the canonical runtime directory for the given cache ID.
-// Default cache → /tmp/gh-aw/cache-memory
-// Named cache   → /tmp/gh-aw/cache-memory-{id}
+// Default cache → ${{ env.GH_AW_TMP_DIR }}/cache-memory
+// Named cache   → ${{ env.GH_AW_TMP_DIR }}/cache-memory-{id}
 //
 // The returned path has no trailing slash. Callers that display the path as a directory
 // (e.g. in LLM prompt context) should append "/" explicitly.
@@ -52,6 +54,13 @@ func cacheMemoryDirFor(cacheID string) string {
 	return cacheMemoryDirPrefix + cacheID
 }
 
+// maxCacheMemoryRetentionDays is the largest retention accepted for a cache-memory entry.
+const maxCacheMemoryRetentionDays = 365
+
+// cacheMemoryEntryFields are the keys of the single cache-memory object form. An object
+// without any of these keys is read as a map of namespace names to retentions.
+var cacheMemoryEntryFields = []string{"id", "key", "description", "retention-days", "retention", "restore-only", "scope", "allowed-extensions"}
+
 // validCacheMemoryScopes defines the allowed values for cache-memory scope
 var validCacheMemoryScopes = []string{"workflow", "repo"}
 
@@ -104,6 +113,7 @@ type CacheMemoryEntry struct {
 	Key               string   `yaml:"key,omitempty"`                // custom cache key
 	Description       string   `yaml:"description,omitempty"`        // optional description for this cache
 	RetentionDays     *int     `yaml:"retention-days,omitempty"`     // retention days for upload-artifact action
+	Retention         int      `yaml:"retention,omitempty"`          // days after which unchanged files are removed (0 = keep forever)
 	RestoreOnly       bool     `yaml:"restore-only,omitempty"`       // if true, only restore cache without saving
 	Scope             string   `yaml:"scope,omitempty"`              // scope for restore keys: "workflow" (default) or "repo"
 	AllowedExtensions []string `yaml:"allowed-extensions,omitempty"` // allowed file extensions (default: [".json", ".jsonl", ".txt", ".md", ".csv"])
@@ -134,6 +144,9 @@ func parseCacheMemoryEntry(cacheMap map[string]any, defaultID string) (CacheMemo
 	if err := parseCacheMemoryRetentionDays(cacheMap, &entry); err != nil {
 		return entry, err
 	}
+	if err := parseCacheMemoryRetention(cacheMap, &entry); err != nil {
+		return entry, err
+	}
 	parseCacheMemoryRestoreOnly(cacheMap, &entry)
 	if err := parseCacheMemoryScope(cacheMap, &entry); err != nil {
 		return entry, err
@@ -142,7 +155,7 @@ func parseCacheMemoryEntry(cacheMap map[string]any, defaultID string) (CacheMemo
 		return entry, err
 	}
 	applyDefaultAllowedExtensions(&entry)
-	cacheLog.Printf("Parsed cache-memory entry: id=%s, scope=%s, restore-only=%v, retention-days=%v", entry.ID, entry.Scope, entry.RestoreOnly, entry.RetentionDays)
+	cacheLog.Printf("Parsed cache-memory entry: id=%s, scope=%s, restore-only=%v, retention-days=%v, retention=%d", entry.ID, entry.Scope, entry.RestoreOnly, entry.RetentionDays, entry.Retention)
 	return entry, nil
 }
 
@@ -193,6 +206,37 @@ func parseCacheMemoryRetentionDays(cacheMap map[string]any, entry *CacheMemoryEn
 	return validateIntRange(*entry.RetentionDays, 1, 90, "retention-days")
 }
 
+func parseCacheMemoryRetention(cacheMap map[string]any, entry *CacheMemoryEntry) error {
+	retention, exists := cacheMap["retention"]
+	if !exists || retention == nil {
+		return nil
+	}
+	days, err := parseCacheMemoryRetentionValue(retention)
+	if err != nil {
+		return fmt.Errorf("invalid cache-memory retention for %q: %w", entry.ID, err)
+	}
+	entry.Retention = days
+	return nil
+}
+
+// parseCacheMemoryRetentionValue parses a retention given as a number of days or as a
+// string such as "30d".
+func parseCacheMemoryRetentionValue(value any) (int, error) {
+	days := parseOptionalInt(value)
+	if str, ok := value.(string); ok {
+		if n, err := strconv.Atoi(strings.TrimSuffix(strings.TrimSpace(str), "d")); err == nil {
+			days = &n
+		}
+	}
+	if days == nil {
+		return 0, fmt.Errorf("expected a number of days such as 30 or \"30d\", got %v", value)
+	}
+	if *days < 1 || *days > maxCacheMemoryRetentionDays {
+		return 0, fmt.Errorf("retention must be between 1 and %d days, got %d", maxCacheMemoryRetentionDays, *days)
+	}
+	return *days, nil
+}
+
 // parseOptionalInt safely converts YAML numeric values (int, float64, uint64) to *int.
 //
 // It returns nil when the input cannot be represented as an integer for the current
@@ -313,6 +357,14 @@ func (c *Compiler) extractCacheMemoryConfig(toolsConfig *ToolsConfig) (*CacheMem
 		return config, nil
 	}
 	if configMap, ok := cacheMemoryValue.(map[string]any); ok {
+		if isCacheMemoryNamespaceMap(configMap) {
+			entries, err := parseCacheMemoryNamespaces(configMap)
+			if err != nil {
+				return nil, err
+			}
+			config.Caches = entries
+			return config, nil
+		}
 		entry, err := parseCacheMemoryEntry(configMap, "default")
 		if err != nil {
 			return nil, err
@@ -354,6 +406,37 @@ func parseCacheMemoryEntries(cacheArray []any) ([]CacheMemoryEntry, error) {
 	return entries, nil
 }
 
+// isCacheMemoryNamespaceMap reports whether a cache-memory object uses the namespace
+// shorthand, e.g. { triage-notes: 30d, embeddings: 90d }, rather than the fields of a
+// single cache.
+func isCacheMemoryNamespaceMap(configMap map[string]any) bool {
+	if len(configMap) == 0 {
+		return false
+	}
+	for key := range configMap {
+		if slices.Contains(cacheMemoryEntryFields, key) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseCacheMemoryNamespaces converts the namespace shorthand into one cache per
+// namespace, each with its own cache key, directory and retention. Namespaces are
+// sorted by name so the generated steps are stable.
+func parseCacheMemoryNamespaces(namespaces map[string]any) ([]CacheMemoryEntry, error) {
+	cacheLog.Printf("Processing cache-memory namespace map with %d namespaces", len(namespaces))
+	entries := make([]CacheMemoryEntry, 0, len(namespaces))
+	for _, name := range sliceutil.SortedKeys(namespaces) {
+		entry, err := parseCacheMemoryEntry(map[string]any{"id": name, "retention": namespaces[name]}, name)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
 // extractCacheMemoryConfigFromMap is a backward compatibility wrapper for extractCacheMemoryConfig
 // extractCacheMemoryConfigFromMap is a backward compatibility wrapper for extractCacheMemoryConfig
 // that accepts map[string]any instead of *ToolsConfig. This allows gradual migration of calling code.
@@ -382,6 +465,28 @@ func generateCacheSteps(builder *strings.Builder, data *WorkflowData, verbose bo
 	}
 	for i, cache := range caches {
 		writeCacheStep(builder, cache, i, len(caches))
+		for _, scratchPath := range cacheScratchPaths(cache["path"]) {
+			name := "Copy restored cache to " + strings.TrimPrefix(scratchPath, constants.TmpGhAwDirExprSlash)
+			builder.WriteString(strings.Join(buildCacheStagingRestoreCopySteps(name, cacheStagingPath(scratchPath), scratchPath), ""))
+		}
+	}
+}
+
+// generateCacheStagingForSave copies the paths of frontmatter caches that live in the
+// scratch directory of the job to the fixed path the actions/cache post step saves.
+func generateCacheStagingForSave(builder *strings.Builder, data *WorkflowData) {
+	if data.Cache == "" {
+		return
+	}
+	caches, err := parseCacheStepConfigs(data.Cache)
+	if err != nil {
+		return
+	}
+	for _, cache := range caches {
+		for _, scratchPath := range cacheScratchPaths(cache["path"]) {
+			name := "Copy " + strings.TrimPrefix(scratchPath, constants.TmpGhAwDirExprSlash) + " to the cache path"
+			builder.WriteString(strings.Join(buildCacheStagingSaveCopySteps(name, "always()", scratchPath, cacheStagingPath(scratchPath)), ""))
+		}
 	}
 }
 
@@ -442,6 +547,9 @@ func resolveCacheStepName(cache map[string]any, idx int, total int) string {
 	return stepName
 }
 
+// writeCachePath writes the path input of a frontmatter cache step. Paths in the scratch
+// directory of the job are cached at their fixed staging path instead, so that the cache
+// version, which actions/cache derives from the paths, is the same in every run.
 func writeCachePath(builder *strings.Builder, path any) {
 	if path == nil {
 		return
@@ -449,11 +557,43 @@ func writeCachePath(builder *strings.Builder, path any) {
 	if pathArray, isArray := path.([]any); isArray {
 		builder.WriteString("          path: |\n")
 		for _, p := range pathArray {
-			fmt.Fprintf(builder, "            %v\n", p)
+			fmt.Fprintf(builder, "            %v\n", stagedCachePath(p))
 		}
 		return
 	}
-	fmt.Fprintf(builder, "          path: %v\n", path)
+	fmt.Fprintf(builder, "          path: %v\n", stagedCachePath(path))
+}
+
+// stagedCachePath returns the path at which a frontmatter cache path is cached.
+func stagedCachePath(path any) any {
+	if pathStr, ok := path.(string); ok && isStageableCachePath(pathStr) {
+		return cacheStagingPath(pathStr)
+	}
+	return path
+}
+
+// cacheScratchPaths returns the paths, without trailing slash, of a frontmatter cache entry
+// that are cached at a staging path and copied to and from the scratch directory of the job.
+func cacheScratchPaths(path any) []string {
+	var paths []any
+	if pathArray, isArray := path.([]any); isArray {
+		paths = pathArray
+	} else {
+		paths = []any{path}
+	}
+	var scratchPaths []string
+	for _, p := range paths {
+		if pathStr, ok := p.(string); ok && isStageableCachePath(pathStr) {
+			scratchPaths = append(scratchPaths, strings.TrimSuffix(pathStr, "/"))
+		}
+	}
+	return scratchPaths
+}
+
+// isStageableCachePath reports whether a frontmatter cache path lies in the scratch
+// directory of the job. Glob patterns are left as they are: they cannot be copied.
+func isStageableCachePath(path string) bool {
+	return strings.HasPrefix(path, constants.TmpGhAwDirExprSlash) && !strings.ContainsAny(path, "*?[!")
 }
 
 // buildCacheRestoreKeys derives the ordered list of restore-keys for a cache entry.
@@ -587,14 +727,21 @@ func generateCacheMemorySteps(builder *strings.Builder, data *WorkflowData) {
 		builder.WriteString("        with:\n")
 		fmt.Fprintf(builder, "          key: %s\n", cacheKey)
 
-		// Path - always use the new cache directory format
-		fmt.Fprintf(builder, "          path: %s\n", cacheDir)
+		// The cache entry lives at a fixed path; the scratch directory changes with every job.
+		stagingDir := cacheStagingPath(cacheDir)
+		fmt.Fprintf(builder, "          path: %s\n", stagingDir)
 
 		builder.WriteString("          restore-keys: |\n")
 		for _, key := range restoreKeys {
 			fmt.Fprintf(builder, "            %s\n", key)
 		}
 
+		copyStepName := "Copy restored cache-memory into the scratch directory"
+		if !useBackwardCompatiblePaths {
+			copyStepName = fmt.Sprintf("%s (%s)", copyStepName, cache.ID)
+		}
+		builder.WriteString(strings.Join(buildCacheStagingDirCopySteps(copyStepName, "", stagingDir, cacheDir), ""))
+
 		// Add git setup step after cache restore.
 		// This initialises (or migrates) the git repository used for integrity branching,
 		// checks out the current integrity branch, and merges down from higher-integrity branches.
@@ -626,6 +773,11 @@ func generateCacheMemoryGitSetupStep(builder *strings.Builder, cache CacheMemory
 		escaped := strings.ReplaceAll(strings.Join(cache.AllowedExtensions, ":"), "'", "''")
 		fmt.Fprintf(builder, "          GH_AW_ALLOWED_EXTENSIONS: '%s'\n", escaped)
 	}
+	// Files that were not changed within the retention period are removed before the
+	// agent runs; the deletion is committed with the agent's changes after the run.
+	if cache.Retention > 0 {
+		fmt.Fprintf(builder, "          GH_AW_CACHE_RETENTION_DAYS: \"%d\"\n", cache.Retention)
+	}
 	builder.WriteString("        run: bash \"${RUNNER_TEMP}/gh-aw/actions/setup_cache_memory_git.sh\"\n")
 }
 
@@ -711,6 +863,36 @@ func generateCacheMemoryValidation(builder *strings.Builder, data *WorkflowData)
 	}
 }
 
+// generateCacheMemoryStagingForSave copies cache-memory back to the fixed cache path for
+// the caches that the post step of actions/cache saves at the end of the agent job. It must
+// run after the commit and validation steps so that the saved entry matches the artifact.
+func generateCacheMemoryStagingForSave(builder *strings.Builder, data *WorkflowData) {
+	if data.CacheMemoryConfig == nil || len(data.CacheMemoryConfig.Caches) == 0 {
+		return
+	}
+
+	// With threat detection the update_cache_memory job saves the cache after detection.
+	if IsDetectionJobEnabled(data.SafeOutputs) {
+		return
+	}
+
+	useBackwardCompatiblePaths := len(data.CacheMemoryConfig.Caches) == 1 && data.CacheMemoryConfig.Caches[0].ID == "default"
+
+	for _, cache := range data.CacheMemoryConfig.Caches {
+		// Restore-only caches are never saved
+		if cache.RestoreOnly {
+			continue
+		}
+
+		cacheDir := cacheMemoryDirFor(cache.ID)
+		stepName := "Copy cache-memory to the cache path"
+		if !useBackwardCompatiblePaths {
+			stepName = fmt.Sprintf("%s (%s)", stepName, cache.ID)
+		}
+		builder.WriteString(strings.Join(buildCacheStagingDirCopySteps(stepName, "always()", cacheDir, cacheStagingPath(cacheDir)), ""))
+	}
+}
+
 // generateCacheMemoryArtifactUpload generates artifact upload steps for cache-memory.
 // This should be called after agent execution steps to ensure cache is uploaded after the agent has finished.
 // pinAction resolves the upload-artifact action reference; pass c.getActionPin from Compiler methods.
@@ -791,13 +973,16 @@ func buildCacheMemoryPromptSection(config *CacheMemoryConfig) *PromptSection {
 	if len(config.Caches) == 1 && config.Caches[0].ID == "default" {
 		cache := config.Caches[0]
 		// Trailing slash makes the path look like a directory in prompt context.
-		cacheDir := cacheMemoryDirFor(cache.ID) + "/"
+		cacheDir := promptScratchPath(cacheMemoryDirFor(cache.ID)) + "/"
 
 		// Build description text
 		descriptionText := ""
 		if cache.Description != "" {
 			descriptionText = cache.Description
 		}
+		if cache.Retention > 0 {
+			descriptionText = strings.TrimSpace(descriptionText + " " + cacheMemoryRetentionNote(cache))
+		}
 
 		// Build allowed extensions text.
 		// When non-empty, add a compact plain-text restriction line.
@@ -828,11 +1013,15 @@ func buildCacheMemoryPromptSection(config *CacheMemoryConfig) *PromptSection {
 	var cacheList strings.Builder
 	for _, cache := range config.Caches {
 		// Trailing slash makes the path look like a directory in prompt context.
-		cacheDir := cacheMemoryDirFor(cache.ID) + "/"
+		cacheDir := promptScratchPath(cacheMemoryDirFor(cache.ID)) + "/"
+		retentionText := ""
+		if cache.Retention > 0 {
+			retentionText = " " + cacheMemoryRetentionNote(cache)
+		}
 		if cache.Description != "" {
-			fmt.Fprintf(&cacheList, "- **%s**: `%s` - %s\n", cache.ID, cacheDir, cache.Description)
+			fmt.Fprintf(&cacheList, "- **%s**: `%s`%s - %s\n", cache.ID, cacheDir, retentionText, cache.Description)
 		} else {
-			fmt.Fprintf(&cacheList, "- **%s**: `%s`\n", cache.ID, cacheDir)
+			fmt.Fprintf(&cacheList, "- **%s**: `%s`%s\n", cache.ID, cacheDir, retentionText)
 		}
 	}
 
@@ -883,7 +1072,7 @@ func buildCacheMemoryPromptSection(config *CacheMemoryConfig) *PromptSection {
 	// Build cache examples
 	var cacheExamples strings.Builder
 	for _, cache := range config.Caches {
-		cacheDir := cacheMemoryDirFor(cache.ID)
+		cacheDir := promptScratchPath(cacheMemoryDirFor(cache.ID))
 		fmt.Fprintf(&cacheExamples, "- `%s/notes.txt` - general notes and observations\n", cacheDir)
 		fmt.Fprintf(&cacheExamples, "- `%s/notes.md` - markdown formatted notes\n", cacheDir)
 		fmt.Fprintf(&cacheExamples, "- `%s/preferences.json` - user preferences and settings\n", cacheDir)
@@ -903,6 +1092,11 @@ func buildCacheMemoryPromptSection(config *CacheMemoryConfig) *PromptSection {
 	}
 }
 
+// cacheMemoryRetentionNote tells the agent that old files of a cache are removed.
+func cacheMemoryRetentionNote(cache CacheMemoryEntry) string {
+	return fmt.Sprintf("(files not updated for %d days are removed)", cache.Retention)
+}
+
 // buildUpdateCacheMemoryJob builds a job that updates cache-memory after detection passes
 // This job downloads cache-memory artifacts and saves them to GitHub Actions cache
 func (c *Compiler) buildUpdateCacheMemoryJob(data *WorkflowData, threatDetectionEnabled bool) (*Job, error) {
@@ -933,7 +1127,8 @@ func (c *Compiler) buildUpdateCacheMemoryJob(data *WorkflowData, threatDetection
 
 		// Determine artifact name and cache directory.
 		// Apply the workflow_call prefix to ensure we download the correct invocation's artifact.
-		cacheDir := cacheMemoryDirFor(cache.ID)
+		// The artifact is downloaded straight to the fixed cache path that the save step uses.
+		cacheDir := cacheStagingPath(cacheMemoryDirFor(cache.ID))
 		var artifactName string
 		if cache.ID == "default" {
 			artifactName = cacheArtifactPrefix + "cache-memory"
@@ -79,10 +79,10 @@ func TestParseCacheMemoryEntry_InvalidID(t *testing.T) {
 }
 
 func TestCacheMemoryDirFor_ValidIDs(t *testing.T) {
-	assert.Equal(t, "/tmp/gh-aw/cache-memory", cacheMemoryDirFor("default"))
-	assert.Equal(t, "/tmp/gh-aw/cache-memory", cacheMemoryDirFor(""))
-	assert.Equal(t, "/tmp/gh-aw/cache-memory-session", cacheMemoryDirFor("session"))
-	assert.Equal(t, "/tmp/gh-aw/cache-memory-my-cache", cacheMemoryDirFor("my-cache"))
+	assert.Equal(t, "${{ env.GH_AW_TMP_DIR }}/cache-memory", cacheMemoryDirFor("default"))
+	assert.Equal(t, "${{ env.GH_AW_TMP_DIR }}/cache-memory", cacheMemoryDirFor(""))
+	assert.Equal(t, "${{ env.GH_AW_TMP_DIR }}/cache-memory-session", cacheMemoryDirFor("session"))
+	assert.Equal(t, "${{ env.GH_AW_TMP_DIR }}/cache-memory-my-cache", cacheMemoryDirFor("my-cache"))
 }
 
 func TestCacheMemoryDirFor_InvalidIDPanics(t *testing.T) {
@@ -413,7 +413,7 @@ func TestCacheMemoryStepsIncludeGitSetup(t *testing.T) {
 		"Should reference the git setup script")
 	assert.Contains(t, output, "GH_AW_MIN_INTEGRITY: unapproved",
 		"Should set the integrity level env var")
-	assert.Contains(t, output, "GH_AW_CACHE_DIR: /tmp/gh-aw/cache-memory",
+	assert.Contains(t, output, "GH_AW_CACHE_DIR: ${{ env.GH_AW_TMP_DIR }}/cache-memory",
 		"Should set the cache dir env var")
 }
 
@@ -514,7 +514,7 @@ func TestCacheMemoryGitCommitSteps(t *testing.T) {
 		"Should reference the git commit script")
 	assert.Contains(t, output, "if: always()",
 		"Git commit step should always run")
-	assert.Contains(t, output, "GH_AW_CACHE_DIR: /tmp/gh-aw/cache-memory",
+	assert.Contains(t, output, "GH_AW_CACHE_DIR: ${{ env.GH_AW_TMP_DIR }}/cache-memory",
 		"Should set the cache dir env var")
 }
 
@@ -556,7 +556,7 @@ func TestCacheMemoryArtifactUploadAddsGitIntegrityCheck(t *testing.T) {
 
 	assert.Contains(t, output, "- name: Check cache-memory git integrity",
 		"Should include pre-upload git integrity check step")
-	assert.Contains(t, output, "GH_AW_CACHE_DIR: /tmp/gh-aw/cache-memory",
+	assert.Contains(t, output, "GH_AW_CACHE_DIR: ${{ env.GH_AW_TMP_DIR }}/cache-memory",
 		"Should set cache dir env var for integrity script")
 	assert.Contains(t, output, "run: bash \"${RUNNER_TEMP}/gh-aw/actions/check_cache_memory_git_integrity.sh\"",
 		"Should run shared integrity script before upload")
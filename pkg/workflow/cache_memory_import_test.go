@@ -83,14 +83,14 @@ Test cache-memory import without local definition.
 	// Custom keys now get the integrity/policy prefix to prevent cross-integrity cache sharing
 	expectedStrings := []string{
 		"- name: Create cache-memory directory (session)",
-		"path: /tmp/gh-aw/cache-memory-session",
+		"path: ${{ runner.temp }}/gh-aw-cache/cache-memory-session",
 		"key: memory-none-nopolicy-shared-session-${{ github.run_id }}",
 		"- name: Create cache-memory directory (logs)",
-		"path: /tmp/gh-aw/cache-memory-logs",
+		"path: ${{ runner.temp }}/gh-aw-cache/cache-memory-logs",
 		"key: memory-none-nopolicy-shared-logs-${{ github.run_id }}",
 		"cache_memory_prompt_multi.md", // Template file reference instead of literal content
-		"- **session**: `/tmp/gh-aw/cache-memory-session/`",
-		"- **logs**: `/tmp/gh-aw/cache-memory-logs/`",
+		"- **session**: `__GH_AW_TMP_DIR__/cache-memory-session/`",
+		"- **logs**: `__GH_AW_TMP_DIR__/cache-memory-logs/`",
 	}
 
 	for _, expected := range expectedStrings {
@@ -0,0 +1,93 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheMemoryNamespaceMap(t *testing.T) {
+	compiler := NewCompiler()
+	config, err := compiler.extractCacheMemoryConfigFromMap(map[string]any{
+		"cache-memory": map[string]any{"triage-notes": "30d", "embeddings": 90, "scratch": nil},
+	})
+	require.NoError(t, err, "namespace map should parse")
+	require.Len(t, config.Caches, 3)
+
+	assert.Equal(t, "embeddings", config.Caches[0].ID, "namespaces should be sorted by name")
+	assert.Equal(t, 90, config.Caches[0].Retention)
+	assert.Equal(t, "scratch", config.Caches[1].ID)
+	assert.Zero(t, config.Caches[1].Retention, "null should keep files forever")
+	assert.Equal(t, "triage-notes", config.Caches[2].ID)
+	assert.Equal(t, 30, config.Caches[2].Retention)
+	assert.Equal(t, generateDefaultCacheKey("triage-notes"), config.Caches[2].Key, "each namespace should have its own cache key")
+	assert.Equal(t, "workflow", config.Caches[2].Scope)
+}
+
+func TestCacheMemoryRetention(t *testing.T) {
+	compiler := NewCompiler()
+	config, err := compiler.extractCacheMemoryConfigFromMap(map[string]any{
+		"cache-memory": map[string]any{"key": "memory-notes", "retention": "14d"},
+	})
+	require.NoError(t, err, "an object with cache fields should not be read as namespaces")
+	require.Len(t, config.Caches, 1)
+	assert.Equal(t, "default", config.Caches[0].ID)
+	assert.Equal(t, 14, config.Caches[0].Retention)
+
+	tests := []struct {
+		name        string
+		cacheMemory any
+		wantErr     string
+	}{
+		{name: "invalid namespace name", cacheMemory: map[string]any{"../notes": "30d"}, wantErr: "invalid cache-memory id"},
+		{name: "invalid retention string", cacheMemory: map[string]any{"notes": "a month"}, wantErr: `expected a number of days such as 30 or "30d"`},
+		{name: "retention too long", cacheMemory: map[string]any{"notes": "400d"}, wantErr: "retention must be between 1 and 365 days"},
+		{name: "zero retention", cacheMemory: []any{map[string]any{"id": "notes", "key": "memory-notes", "retention": 0}}, wantErr: "retention must be between 1 and 365 days"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := compiler.extractCacheMemoryConfigFromMap(map[string]any{"cache-memory": tt.cacheMemory})
+			require.Error(t, err, "cache-memory should be rejected")
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestCompileCacheMemoryNamespaces(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "cache-memory-namespaces-*")
+	markdownPath := filepath.Join(tmpDir, "test.md")
+	content := `---
+on: issues
+permissions:
+  contents: read
+engine: claude
+tools:
+  cache-memory:
+    triage-notes: 30d
+    embeddings: 90d
+---
+
+# Test
+`
+	require.NoError(t, os.WriteFile(markdownPath, []byte(content), 0o644))
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(markdownPath), "workflow with memory namespaces should compile")
+
+	lockContent, err := os.ReadFile(filepath.Join(tmpDir, "test.lock.yml"))
+	require.NoError(t, err)
+	lock := string(lockContent)
+	assert.Contains(t, lock, "path: ${{ runner.temp }}/gh-aw-cache/cache-memory-triage-notes", "each namespace should have its own cache path")
+	assert.Contains(t, lock, "path: ${{ runner.temp }}/gh-aw-cache/cache-memory-embeddings")
+	assert.Contains(t, lock, "GH_AW_CACHE_DIR: ${{ env.GH_AW_TMP_DIR }}/cache-memory-triage-notes", "each namespace should have its own directory")
+	assert.Contains(t, lock, "-triage-notes-${{ env.GH_AW_WORKFLOW_ID_SANITIZED }}", "each namespace should have its own cache key")
+	assert.Contains(t, lock, `GH_AW_CACHE_RETENTION_DAYS: "30"`, "retention should be passed to the setup script")
+	assert.Contains(t, lock, `GH_AW_CACHE_RETENTION_DAYS: "90"`)
+	assert.Contains(t, lock, "(files not updated for 30 days are removed)", "the prompt should mention the retention")
+}
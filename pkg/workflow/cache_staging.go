@@ -0,0 +1,84 @@
+package workflow
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
+)
+
+// cacheStagingPath returns the fixed path at which actions/cache steps restore and save the
+// state that a job keeps at scratchPath, a path below the scratch directory of the job.
+//
+//	${{ env.GH_AW_TMP_DIR }}/cache-memory → ${{ runner.temp }}/gh-aw-cache/cache-memory
+func cacheStagingPath(scratchPath string) string {
+	return constants.CacheStagingDir + strings.TrimPrefix(scratchPath, constants.TmpGhAwDirExpr)
+}
+
+// buildCacheStagingDirCopySteps returns a step that replaces the directory to with a copy of
+// the directory from. When from does not exist (e.g. after a cache miss) to is left empty.
+// An empty condition emits no if: line.
+func buildCacheStagingDirCopySteps(name, condition, from, to string) []string {
+	steps := []string{fmt.Sprintf("      - name: %s\n", name)}
+	if condition != "" {
+		steps = append(steps, fmt.Sprintf("        if: %s\n", condition))
+	}
+	return append(steps,
+		"        run: |\n",
+		fmt.Sprintf("          rm -rf \"%s\"\n", to),
+		fmt.Sprintf("          mkdir -p \"%s\"\n", to),
+		fmt.Sprintf("          if [ -d \"%s\" ]; then cp -a \"%s/.\" \"%s/\"; fi\n", from, from, to),
+	)
+}
+
+// buildCacheStagingFileCopySteps returns a step that copies the file from to the path to when
+// from exists. An empty condition emits no if: line.
+func buildCacheStagingFileCopySteps(name, condition, from, to string) []string {
+	steps := []string{fmt.Sprintf("      - name: %s\n", name)}
+	if condition != "" {
+		steps = append(steps, fmt.Sprintf("        if: %s\n", condition))
+	}
+	return append(steps,
+		"        run: |\n",
+		fmt.Sprintf("          if [ -f \"%s\" ]; then\n", from),
+		fmt.Sprintf("            mkdir -p \"%s\"\n", path.Dir(to)),
+		fmt.Sprintf("            cp \"%s\" \"%s\"\n", from, to),
+		"          fi\n",
+	)
+}
+
+// buildCacheStagingRestoreCopySteps returns a step that copies the file or directory from,
+// restored by a frontmatter cache, to the path to. A directory is merged into to, which
+// custom steps may have filled before the cache step; nothing is copied after a cache miss.
+func buildCacheStagingRestoreCopySteps(name, from, to string) []string {
+	return []string{
+		fmt.Sprintf("      - name: %s\n", name),
+		"        run: |\n",
+		fmt.Sprintf("          if [ -d \"%s\" ]; then\n", from),
+		fmt.Sprintf("            mkdir -p \"%s\"\n", to),
+		fmt.Sprintf("            cp -a \"%s/.\" \"%s/\"\n", from, to),
+		fmt.Sprintf("          elif [ -e \"%s\" ]; then\n", from),
+		fmt.Sprintf("            mkdir -p \"%s\"\n", path.Dir(to)),
+		fmt.Sprintf("            cp -a \"%s\" \"%s\"\n", from, to),
+		"          fi\n",
+	}
+}
+
+// buildCacheStagingSaveCopySteps returns a step that replaces the path to with a copy of
+// the file or directory from. When from does not exist to is removed, so that the cache
+// step does not save the entry it restored again. An empty condition emits no if: line.
+func buildCacheStagingSaveCopySteps(name, condition, from, to string) []string {
+	steps := []string{fmt.Sprintf("      - name: %s\n", name)}
+	if condition != "" {
+		steps = append(steps, fmt.Sprintf("        if: %s\n", condition))
+	}
+	return append(steps,
+		"        run: |\n",
+		fmt.Sprintf("          rm -rf \"%s\"\n", to),
+		fmt.Sprintf("          if [ -e \"%s\" ]; then\n", from),
+		fmt.Sprintf("            mkdir -p \"%s\"\n", path.Dir(to)),
+		fmt.Sprintf("            cp -a \"%s\" \"%s\"\n", from, to),
+		"          fi\n",
+	)
+}
@@ -0,0 +1,149 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/goccy/go-yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheStagingPath(t *testing.T) {
+	assert.Equal(t, "${{ runner.temp }}/gh-aw-cache/cache-memory", cacheStagingPath(constants.TmpGhAwDirExpr+"/cache-memory"))
+	assert.Equal(t, "${{ runner.temp }}/gh-aw-cache/agentic-workflow-usage-cache.jsonl", cacheStagingPath(dailyAICUsageCacheFile))
+}
+
+func TestCacheScratchPaths(t *testing.T) {
+	assert.Equal(t, []string{"${{ env.GH_AW_TMP_DIR }}/agent/data"}, cacheScratchPaths("${{ env.GH_AW_TMP_DIR }}/agent/data/"))
+	assert.Equal(t, []string{"${{ env.GH_AW_TMP_DIR }}/a"}, cacheScratchPaths([]any{"~/.npm", "${{ env.GH_AW_TMP_DIR }}/a", "${{ env.GH_AW_TMP_DIR }}/*.json"}), "paths outside the scratch directory and globs are not staged")
+	assert.Nil(t, cacheScratchPaths(nil))
+}
+
+// lockCacheStepPaths returns the path inputs of the actions/cache restore and save steps
+// in a compiled lock file. actions/cache steps count as both.
+func lockCacheStepPaths(t *testing.T, lock []byte) (restorePaths, savePaths []string) {
+	t.Helper()
+	var workflow struct {
+		Jobs map[string]struct {
+			Steps []struct {
+				Uses string         `yaml:"uses"`
+				With map[string]any `yaml:"with"`
+			} `yaml:"steps"`
+		} `yaml:"jobs"`
+	}
+	require.NoError(t, yaml.Unmarshal(lock, &workflow), "lock file should be valid YAML")
+	for _, job := range workflow.Jobs {
+		for _, step := range job.Steps {
+			if !strings.HasPrefix(step.Uses, "actions/cache") {
+				continue
+			}
+			path := strings.TrimSpace(step.With["path"].(string))
+			switch {
+			case strings.HasPrefix(step.Uses, "actions/cache/restore@"):
+				restorePaths = append(restorePaths, path)
+			case strings.HasPrefix(step.Uses, "actions/cache/save@"):
+				savePaths = append(savePaths, path)
+			default:
+				restorePaths = append(restorePaths, path)
+				savePaths = append(savePaths, path)
+			}
+		}
+	}
+	return restorePaths, savePaths
+}
+
+func TestCompileCachePathsAreStable(t *testing.T) {
+	tests := []struct {
+		name        string
+		frontmatter string
+	}{
+		{
+			name: "cache-memory saved by the agent job",
+			frontmatter: `on: issues
+permissions:
+  contents: read
+engine: copilot
+tools:
+  cache-memory: true`,
+		},
+		{
+			name: "cache-memory saved after threat detection",
+			frontmatter: `on: issues
+permissions:
+  contents: read
+engine: copilot
+tools:
+  cache-memory:
+    triage-notes: 30d
+safe-outputs:
+  add-comment:`,
+		},
+		{
+			name: "experiments stored in the cache and daily AI credit cap",
+			frontmatter: `on: issues
+permissions:
+  contents: read
+engine: copilot
+max-daily-ai-credits: 5000
+experiments:
+  storage: cache
+  tone: [formal, casual]
+safe-outputs:
+  add-comment:`,
+		},
+		{
+			name: "frontmatter cache in the scratch directory",
+			frontmatter: `on: issues
+permissions:
+  contents: read
+engine: copilot
+cache:
+  - key: prompt-cache-${{ github.run_id }}
+    path: ${{ env.GH_AW_TMP_DIR }}/agent/prompt-cache
+    restore-keys: prompt-cache-`,
+		},
+		{
+			name: "sticky slash command context",
+			frontmatter: `on:
+  slash_command:
+    name: mybot
+    sticky: 2h
+permissions:
+  contents: read
+engine: copilot
+safe-outputs:
+  add-comment:`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := testutil.TempDir(t, "cache-staging-*")
+			markdownPath := filepath.Join(tmpDir, "test.md")
+			content := "---\n" + tt.frontmatter + "\n---\n\n# Test\n"
+			require.NoError(t, os.WriteFile(markdownPath, []byte(content), 0o644))
+
+			compiler := NewCompiler()
+			require.NoError(t, compiler.CompileWorkflow(markdownPath), "workflow should compile")
+
+			lock, err := os.ReadFile(filepath.Join(tmpDir, "test.lock.yml"))
+			require.NoError(t, err)
+			restorePaths, savePaths := lockCacheStepPaths(t, lock)
+			require.NotEmpty(t, restorePaths, "workflow should restore a cache")
+			for _, path := range append(restorePaths, savePaths...) {
+				assert.NotContains(t, path, constants.TmpGhAwDirEnvVar, "cache path must not depend on the scratch directory of the job")
+				assert.True(t, strings.HasPrefix(path, constants.CacheStagingDir+"/"), "cache path %q should be below the fixed cache directory", path)
+			}
+			for _, path := range savePaths {
+				assert.Contains(t, restorePaths, path, "a saved cache path should be restored by a later run")
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+// This file builds the <capabilities> prompt section enabled by the
+// capabilities-prompt feature flag.
+//
+// The section is generated from the compiled configuration rather than written
+// by hand, so it always matches what the run actually provides: the engine, the
+// tools it can call, the safe outputs with their budgets, and the restrictions it
+// runs under (network, turns, time). Expressions in configured values (for example
+// a max: budget or timeout-minutes taken from an input) are replaced with
+// placeholders and resolved by the substitution step.
+
+package workflow
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/sliceutil"
+)
+
+var capabilitiesPromptLog = logger.New("workflow:capabilities_prompt")
+
+// buildCapabilitiesPromptSection returns the <capabilities> prompt section, or nil
+// when the capabilities-prompt feature flag is not enabled.
+func (c *Compiler) buildCapabilitiesPromptSection(data *WorkflowData) *PromptSection {
+	if !isFeatureEnabled(constants.CapabilitiesPromptFeatureFlag, data) {
+		return nil
+	}
+
+	engine, err := c.getAgenticEngine(data.AI)
+	if err != nil {
+		capabilitiesPromptLog.Printf("Skipping capabilities section: %v", err)
+		return nil
+	}
+	content := buildCapabilitiesPromptText(data, engine)
+
+	section := &PromptSection{Content: content}
+	extractor := NewExpressionExtractor()
+	expressionMappings, err := extractor.ExtractExpressions(content)
+	if err == nil && len(expressionMappings) > 0 {
+		capabilitiesPromptLog.Printf("Extracted %d expression(s) from capabilities section", len(expressionMappings))
+		section.Content = extractor.ReplaceExpressionsWithEnvVars(content)
+		section.EnvVars = make(map[string]string, len(expressionMappings))
+		for _, mapping := range expressionMappings {
+			section.EnvVars[mapping.EnvVar] = fmt.Sprintf("${{ %s }}", mapping.Content)
+		}
+	}
+	return section
+}
+
+// buildCapabilitiesPromptText renders the <capabilities> block for the given engine.
+func buildCapabilitiesPromptText(data *WorkflowData, engine CodingAgentEngine) string {
+	capabilities := engine.GetCapabilities()
+
+	var b strings.Builder
+	b.WriteString("<capabilities>\n")
+	b.WriteString("This run provides exactly the capabilities below. Do not try to use tools, network access or permissions that are not listed.\n")
+	fmt.Fprintf(&b, "Engine: %s\n", engine.GetDisplayName())
+
+	b.WriteString("Tools:\n")
+	tools := capabilityToolLines(data, capabilities)
+	if len(tools) == 0 {
+		b.WriteString("- none\n")
+	}
+	for _, line := range tools {
+		fmt.Fprintf(&b, "- %s\n", line)
+	}
+
+	if safeOutputs := safeOutputToolBudgets(data.SafeOutputs); len(safeOutputs) > 0 {
+		fmt.Fprintf(&b, "Safe outputs: %s\n", strings.Join(safeOutputs, ", "))
+	} else {
+		b.WriteString("Safe outputs: none\n")
+	}
+
+	b.WriteString("Restrictions:\n")
+	fmt.Fprintf(&b, "- Network: %s\n", capabilityNetworkSummary(data))
+	if HasSafeOutputsEnabled(data.SafeOutputs) {
+		b.WriteString("- GitHub: changes to issues, pull requests, discussions and other GitHub resources are made only through the safe outputs above; a budget such as (max:3) is the number of calls allowed\n")
+	} else {
+		b.WriteString("- GitHub: no safe outputs are configured, so this run cannot create or change GitHub resources\n")
+	}
+	if capabilities.MaxTurns && data.EngineConfig != nil && data.EngineConfig.MaxTurns != "" {
+		fmt.Fprintf(&b, "- Turns: at most %s\n", data.EngineConfig.MaxTurns)
+	}
+	if timeout := strings.TrimSpace(strings.TrimPrefix(data.TimeoutMinutes, "timeout-minutes:")); timeout != "" {
+		fmt.Fprintf(&b, "- Time: the run is cancelled after %s minutes\n", timeout)
+	}
+	if data.SafeOutputs != nil && data.SafeOutputs.MissingTool != nil {
+		b.WriteString("If the task needs a capability that is not listed, report it with missing_tool instead of working around the restriction.\n")
+	}
+	b.WriteString("</capabilities>")
+	return b.String()
+}
+
+// capabilityToolLines describes each tool available to the agent, one line per tool.
+// web-search is only listed for engines with built-in support; other engines ignore it.
+func capabilityToolLines(data *WorkflowData, capabilities EngineCapabilities) []string {
+	tools := data.ParsedTools
+	if tools == nil {
+		return nil
+	}
+
+	var lines []string
+	if tools.GitHub != nil {
+		toolsets := "default"
+		if len(tools.GitHub.Toolset) > 0 {
+			toolsets = strings.Join(tools.GitHub.Toolset.ToStringSlice(), ", ")
+		}
+		via := "GitHub MCP server"
+		if isGitHubCLIModeEnabled(data) {
+			via = "pre-authenticated gh CLI"
+		}
+		lines = append(lines, fmt.Sprintf("github: read-only GitHub access through the %s (toolsets: %s)", via, toolsets))
+	}
+	if tools.Bash != nil {
+		switch {
+		case tools.Bash.AllowedCommands == nil || slices.Contains(tools.Bash.AllowedCommands, "*") || slices.Contains(tools.Bash.AllowedCommands, ":*"):
+			lines = append(lines, "bash: any shell command")
+		case len(tools.Bash.AllowedCommands) > 0:
+			lines = append(lines, "bash: only these commands: "+strings.Join(tools.Bash.AllowedCommands, ", "))
+		}
+	}
+	if tools.Edit != nil {
+		lines = append(lines, "edit: create and modify files in the workspace")
+	}
+	if tools.WebFetch != nil {
+		lines = append(lines, "web-fetch: fetch web pages from allowed domains")
+	}
+	if tools.WebSearch != nil && capabilities.WebSearch {
+		lines = append(lines, "web-search: search the web")
+	}
+	if tools.Playwright != nil {
+		lines = append(lines, "playwright: browser automation")
+	}
+	if tools.AgenticWorkflows != nil {
+		lines = append(lines, "agentic-workflows: inspect agentic workflow runs and logs")
+	}
+	if tools.CacheMemory != nil {
+		lines = append(lines, "cache-memory: files persisted between runs")
+	}
+	if tools.RepoMemory != nil {
+		lines = append(lines, "repo-memory: files persisted in a git branch")
+	}
+	for _, name := range sliceutil.SortedKeys(tools.Custom) {
+		if name == constants.SafeOutputsMCPServerID.String() {
+			continue
+		}
+		lines = append(lines, name+": MCP server")
+	}
+	return lines
+}
+
+// capabilityNetworkSummary describes the network access of the agent.
+func capabilityNetworkSummary(data *WorkflowData) string {
+	if !isFirewallEnabled(data) {
+		return "not restricted by a firewall"
+	}
+	network := data.NetworkPermissions
+	if network == nil {
+		return "only the default allowed domains are reachable"
+	}
+	if len(network.Allowed) == 0 {
+		return "no network access"
+	}
+	summary := "only these domains and ecosystems are reachable: " + strings.Join(network.Allowed, ", ")
+	if len(network.Blocked) > 0 {
+		summary += "; blocked: " + strings.Join(network.Blocked, ", ")
+	}
+	return summary
+}
@@ -0,0 +1,94 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildCapabilitiesPromptText(t *testing.T) {
+	max := "3"
+	data := &WorkflowData{
+		ParsedTools: NewTools(map[string]any{
+			"github":    map[string]any{"toolsets": []any{"issues"}},
+			"bash":      []any{"git", "ls"},
+			"edit":      nil,
+			"my-server": map[string]any{"command": "my-server"},
+		}),
+		SafeOutputs: &SafeOutputsConfig{
+			AddComments: &AddCommentsConfig{BaseSafeOutputConfig: BaseSafeOutputConfig{Max: &max}},
+			MissingTool: &MissingToolConfig{},
+		},
+		NetworkPermissions: &NetworkPermissions{Allowed: []string{"defaults", "python"}, Firewall: &FirewallConfig{Enabled: true}},
+		EngineConfig:       &EngineConfig{MaxTurns: "25"},
+		TimeoutMinutes:     "timeout-minutes: 15",
+	}
+
+	text := buildCapabilitiesPromptText(data, NewClaudeEngine())
+
+	assert.Contains(t, text, "Engine: Claude Code", "should name the engine")
+	assert.Contains(t, text, "- github: read-only GitHub access through the GitHub MCP server (toolsets: issues)", "should describe the GitHub tool")
+	assert.Contains(t, text, "- bash: only these commands: git, ls", "should list allowed bash commands")
+	assert.Contains(t, text, "- edit: create and modify files in the workspace", "should describe the edit tool")
+	assert.Contains(t, text, "- my-server: MCP server", "should list custom MCP servers")
+	assert.Contains(t, text, "Safe outputs: add_comment(max:3), missing_tool", "should list safe outputs with budgets")
+	assert.Contains(t, text, "- Network: only these domains and ecosystems are reachable: defaults, python", "should describe the network allow-list")
+	assert.Contains(t, text, "- Turns: at most 25", "Claude supports max-turns")
+	assert.Contains(t, text, "- Time: the run is cancelled after 15 minutes", "should state the timeout")
+	assert.Contains(t, text, "report it with missing_tool", "should point at missing_tool when enabled")
+}
+
+func TestBuildCapabilitiesPromptText_PerEngine(t *testing.T) {
+	data := &WorkflowData{
+		ParsedTools: NewTools(map[string]any{"bash": true, "web-search": nil}),
+	}
+
+	copilot := buildCapabilitiesPromptText(data, NewCopilotEngine())
+	assert.Contains(t, copilot, "Engine: GitHub Copilot CLI", "should name the engine")
+	assert.Contains(t, copilot, "- bash: any shell command", "bash: true allows every command")
+	assert.NotContains(t, copilot, "web-search", "Copilot has no built-in web search")
+	assert.Contains(t, copilot, "Safe outputs: none", "no safe outputs are configured")
+	assert.Contains(t, copilot, "cannot create or change GitHub resources", "should state that GitHub is read-only")
+	assert.NotContains(t, copilot, "Turns:", "max-turns is not configured")
+	assert.NotContains(t, copilot, "missing_tool", "missing_tool is not configured")
+
+	claude := buildCapabilitiesPromptText(data, NewClaudeEngine())
+	assert.Contains(t, claude, "- web-search: search the web", "Claude has built-in web search")
+}
+
+func TestCompileWorkflowWithCapabilitiesPrompt(t *testing.T) {
+	tests := []struct {
+		name     string
+		features string
+		want     bool
+	}{
+		{name: "enabled", features: "features:\n  capabilities-prompt: true\n", want: true},
+		{name: "disabled by default"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := testutil.TempDir(t, "capabilities-prompt-test")
+			testFile := filepath.Join(tmpDir, "capabilities.md")
+			content := "---\non: workflow_dispatch\npermissions:\n  contents: read\nengine: copilot\n" + tt.features + "safe-outputs:\n  create-issue:\n    max: ${{ inputs.max }}\n---\n\n# Capabilities\n\nDo the work.\n"
+			require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "failed to write workflow")
+
+			require.NoError(t, NewCompiler().CompileWorkflow(testFile), "workflow should compile")
+			lockContent, err := os.ReadFile(filepath.Join(tmpDir, "capabilities.lock.yml"))
+			require.NoError(t, err, "failed to read lock file")
+
+			lock := string(lockContent)
+			assert.Equal(t, tt.want, strings.Contains(lock, "<capabilities>"), "capabilities section should only be added with the feature flag")
+			if tt.want {
+				assert.Contains(t, lock, "Safe outputs: create_issue(max:__GH_AW_INPUTS_MAX__)", "expression budgets should use placeholders")
+			}
+		})
+	}
+}
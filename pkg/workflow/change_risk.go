@@ -0,0 +1,480 @@
+package workflow
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/gitutil"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+)
+
+var changeRiskLog = logger.New("workflow:change_risk")
+
+// ChangeRiskLevel summarizes the score of a ChangeRiskReport
+type ChangeRiskLevel string
+
+const (
+	ChangeRiskLow    ChangeRiskLevel = "low"
+	ChangeRiskMedium ChangeRiskLevel = "medium"
+	ChangeRiskHigh   ChangeRiskLevel = "high"
+)
+
+// Change risk finding categories
+const (
+	ChangeRiskCategoryWrite     = "write-capability"
+	ChangeRiskCategoryGuardrail = "guardrail"
+	ChangeRiskCategoryPrompt    = "prompt"
+)
+
+// Score thresholds for the risk levels
+const (
+	changeRiskMediumThreshold = 4
+	changeRiskHighThreshold   = 8
+)
+
+// highImpactSafeOutputs are safe outputs that change code, trigger other automation,
+// or hand work to other agents; enabling them scores higher than other write outputs.
+var highImpactSafeOutputs = []string{
+	"create-pull-request",
+	"push-to-pull-request-branch",
+	"merge-pull-request",
+	"dispatch-workflow",
+	"dispatch_repository",
+	"call-workflow",
+	"create-agent-session",
+	"handoff-to-copilot",
+	"assign-to-agent",
+	"update-release",
+	"upload-asset",
+}
+
+// defaultChangeRiskRoles are the roles allowed to trigger a workflow without a roles field
+var defaultChangeRiskRoles = []string{"admin", "maintainer", "write"}
+
+// ChangeRiskFinding is a single reason a workflow change is risky
+type ChangeRiskFinding struct {
+	Category string `json:"category"`
+	Message  string `json:"message"`
+	Points   int    `json:"points"`
+}
+
+// ChangeRiskReport scores the risk of the changes made to a workflow since the last
+// commit so reviewers can focus on changes that widen what the agent can do.
+type ChangeRiskReport struct {
+	Workflow string              `json:"workflow"`
+	Score    int                 `json:"score"`
+	Level    ChangeRiskLevel     `json:"level"`
+	Findings []ChangeRiskFinding `json:"findings"`
+}
+
+func (r *ChangeRiskReport) add(category string, points int, format string, args ...any) {
+	r.Findings = append(r.Findings, ChangeRiskFinding{Category: category, Message: fmt.Sprintf(format, args...), Points: points})
+	r.Score += points
+}
+
+// AssessChangeRisk compares the previous and current frontmatter and markdown body of a
+// workflow and scores the risk of the change. The analysis is heuristic: it flags newly
+// granted write capabilities, weakened guardrails, and large prompt rewrites.
+// Returns nil when nothing risk-relevant changed.
+func AssessChangeRisk(workflowID string, oldFrontmatter, newFrontmatter map[string]any, oldBody, newBody string) *ChangeRiskReport {
+	report := &ChangeRiskReport{Workflow: workflowID}
+
+	assessSafeOutputChanges(report, mapField(oldFrontmatter, "safe-outputs"), mapField(newFrontmatter, "safe-outputs"))
+	assessPermissionChanges(report, oldFrontmatter["permissions"], newFrontmatter["permissions"])
+	assessToolChanges(report, oldFrontmatter, newFrontmatter)
+	assessGuardrailChanges(report, oldFrontmatter, newFrontmatter)
+	assessPromptChanges(report, oldFrontmatter, newFrontmatter, oldBody, newBody)
+
+	if len(report.Findings) == 0 {
+		return nil
+	}
+	switch {
+	case report.Score >= changeRiskHighThreshold:
+		report.Level = ChangeRiskHigh
+	case report.Score >= changeRiskMediumThreshold:
+		report.Level = ChangeRiskMedium
+	default:
+		report.Level = ChangeRiskLow
+	}
+	changeRiskLog.Printf("Change risk for %s: score=%d, level=%s, findings=%d", workflowID, report.Score, report.Level, len(report.Findings))
+	return report
+}
+
+func assessSafeOutputChanges(report *ChangeRiskReport, oldOutputs, newOutputs map[string]any) {
+	for _, key := range addedKeys(oldOutputs, newOutputs) {
+		handler, ok := safeOutputHandlersByKey[key]
+		if !ok || handler.Builtin || handler.Key == "threat-detection" || newOutputs[key] == false {
+			continue
+		}
+		if slices.Contains(highImpactSafeOutputs, key) {
+			report.add(ChangeRiskCategoryWrite, 5, "new safe output '%s'", key)
+		} else {
+			report.add(ChangeRiskCategoryWrite, 3, "new safe output '%s'", key)
+		}
+	}
+	for _, job := range addedKeys(mapField(oldOutputs, "jobs"), mapField(newOutputs, "jobs")) {
+		report.add(ChangeRiskCategoryWrite, 4, "new custom safe output job '%s'", job)
+	}
+
+	if isEnabled(oldOutputs["staged"]) && !isEnabled(newOutputs["staged"]) && newOutputs != nil {
+		report.add(ChangeRiskCategoryGuardrail, 3, "safe outputs are no longer staged")
+	}
+	if oldOutputs["threat-detection"] != false && newOutputs["threat-detection"] == false {
+		report.add(ChangeRiskCategoryGuardrail, 5, "threat detection was disabled")
+	}
+}
+
+func assessPermissionChanges(report *ChangeRiskReport, oldPermissions, newPermissions any) {
+	if newPermissions == "write-all" && oldPermissions != "write-all" {
+		report.add(ChangeRiskCategoryWrite, 8, "permissions changed to write-all")
+		return
+	}
+	oldScopes := mapFromAny(oldPermissions)
+	newScopes := mapFromAny(newPermissions)
+	scopes := sortedKeys(newScopes)
+	for _, scope := range scopes {
+		if newScopes[scope] == "write" && oldScopes[scope] != "write" && oldPermissions != "write-all" {
+			report.add(ChangeRiskCategoryWrite, 5, "new write permission '%s'", scope)
+		}
+	}
+}
+
+func assessToolChanges(report *ChangeRiskReport, oldFrontmatter, newFrontmatter map[string]any) {
+	oldTools := mapField(oldFrontmatter, "tools")
+	newTools := mapField(newFrontmatter, "tools")
+	for _, tool := range addedKeys(oldTools, newTools) {
+		if newTools[tool] == false {
+			continue
+		}
+		report.add(ChangeRiskCategoryWrite, 2, "new tool '%s'", tool)
+	}
+	if !isUnrestrictedBash(oldTools["bash"]) && isUnrestrictedBash(newTools["bash"]) {
+		report.add(ChangeRiskCategoryWrite, 4, "bash is no longer restricted to an allowlist")
+	}
+
+	oldGitHub := mapField(oldTools, "github")
+	newGitHub := mapField(newTools, "github")
+	if _, ok := newTools["github"]; ok {
+		oldToolsets := stringSet(oldGitHub["toolsets"])
+		var added []string
+		for toolset := range stringSet(newGitHub["toolsets"]) {
+			if _, ok := oldToolsets[toolset]; !ok {
+				added = append(added, toolset)
+			}
+		}
+		if len(added) > 0 {
+			sort.Strings(added)
+			report.add(ChangeRiskCategoryWrite, 2, "new GitHub toolsets: %s", strings.Join(added, ", "))
+		}
+		if oldGitHub["read-only"] == true && newGitHub["read-only"] == false {
+			report.add(ChangeRiskCategoryWrite, 4, "GitHub tools are no longer read-only")
+		}
+		if oldGitHub["lockdown"] == true && newGitHub["lockdown"] != true {
+			report.add(ChangeRiskCategoryGuardrail, 3, "GitHub lockdown mode was turned off")
+		}
+	}
+
+	for _, server := range addedKeys(mapField(oldFrontmatter, "mcp-servers"), mapField(newFrontmatter, "mcp-servers")) {
+		report.add(ChangeRiskCategoryWrite, 3, "new MCP server '%s'", server)
+	}
+}
+
+func assessGuardrailChanges(report *ChangeRiskReport, oldFrontmatter, newFrontmatter map[string]any) {
+	if oldFrontmatter["strict"] != false && newFrontmatter["strict"] == false {
+		report.add(ChangeRiskCategoryGuardrail, 5, "strict mode was disabled")
+	}
+
+	oldDomains := networkDomains(oldFrontmatter["network"])
+	newDomains := networkDomains(newFrontmatter["network"])
+	var addedDomains []string
+	for domain := range newDomains {
+		if _, ok := oldDomains[domain]; !ok {
+			addedDomains = append(addedDomains, domain)
+		}
+	}
+	sort.Strings(addedDomains)
+	if slices.Contains(addedDomains, "*") {
+		report.add(ChangeRiskCategoryGuardrail, 6, "network access opened to all domains")
+	} else if len(addedDomains) > 0 {
+		report.add(ChangeRiskCategoryGuardrail, 2, "network access widened: %s", strings.Join(addedDomains, ", "))
+	}
+
+	oldSandbox := mapField(oldFrontmatter, "sandbox")
+	newSandbox := mapField(newFrontmatter, "sandbox")
+	if oldSandbox["agent"] != false && newSandbox["agent"] == false {
+		report.add(ChangeRiskCategoryGuardrail, 6, "the agent sandbox was disabled")
+	}
+
+	oldRoles := stringSet(oldFrontmatter["roles"])
+	if len(oldRoles) == 0 {
+		oldRoles = stringSet(defaultChangeRiskRoles)
+	}
+	newRoles := stringSet(newFrontmatter["roles"])
+	if _, ok := newRoles["all"]; ok {
+		if _, had := oldRoles["all"]; !had {
+			report.add(ChangeRiskCategoryGuardrail, 5, "workflow can be triggered by any user (roles: all)")
+		}
+	} else {
+		var addedRoles []string
+		for role := range newRoles {
+			if _, ok := oldRoles[role]; !ok {
+				addedRoles = append(addedRoles, role)
+			}
+		}
+		if len(addedRoles) > 0 {
+			sort.Strings(addedRoles)
+			report.add(ChangeRiskCategoryGuardrail, 3, "new trigger roles: %s", strings.Join(addedRoles, ", "))
+		}
+	}
+	if added := addedStrings(oldFrontmatter["bots"], newFrontmatter["bots"]); len(added) > 0 {
+		report.add(ChangeRiskCategoryGuardrail, 2, "new trigger bots: %s", strings.Join(added, ", "))
+	}
+
+	if _, had := mapField(oldFrontmatter, "on")["pull_request_target"]; !had {
+		if _, has := mapField(newFrontmatter, "on")["pull_request_target"]; has {
+			report.add(ChangeRiskCategoryGuardrail, 4, "new pull_request_target trigger (runs with secrets on fork pull requests)")
+		}
+	}
+}
+
+func assessPromptChanges(report *ChangeRiskReport, oldFrontmatter, newFrontmatter map[string]any, oldBody, newBody string) {
+	ratio := promptChangeRatio(oldBody, newBody)
+	switch {
+	case ratio >= 0.5:
+		report.add(ChangeRiskCategoryPrompt, 4, "major prompt rewrite (%d%% of lines changed)", int(ratio*100))
+	case ratio >= 0.2:
+		report.add(ChangeRiskCategoryPrompt, 2, "substantial prompt edit (%d%% of lines changed)", int(ratio*100))
+	case ratio > 0:
+		report.add(ChangeRiskCategoryPrompt, 1, "prompt edited (%d%% of lines changed)", max(int(ratio*100), 1))
+	}
+
+	if added := addedStrings(oldFrontmatter["imports"], newFrontmatter["imports"]); len(added) > 0 {
+		report.add(ChangeRiskCategoryPrompt, 2, "new imports: %s", strings.Join(added, ", "))
+	}
+	if oldEngine, newEngine := engineName(oldFrontmatter["engine"]), engineName(newFrontmatter["engine"]); oldEngine != newEngine {
+		report.add(ChangeRiskCategoryPrompt, 2, "engine changed from '%s' to '%s'", displayOrDefault(oldEngine), displayOrDefault(newEngine))
+	}
+}
+
+// promptChangeRatio returns the share of non-blank lines added or removed between two
+// prompt bodies, from 0 (identical) to 1 (completely rewritten).
+func promptChangeRatio(oldBody, newBody string) float64 {
+	oldLines := promptLines(oldBody)
+	newLines := promptLines(newBody)
+	total := len(oldLines) + len(newLines)
+	if total == 0 {
+		return 0
+	}
+	remaining := make(map[string]int, len(oldLines))
+	for _, line := range oldLines {
+		remaining[line]++
+	}
+	changed := 0
+	for _, line := range newLines {
+		if remaining[line] > 0 {
+			remaining[line]--
+			continue
+		}
+		changed++
+	}
+	for _, count := range remaining {
+		changed += count
+	}
+	return float64(changed) / float64(total)
+}
+
+func promptLines(body string) []string {
+	var lines []string
+	for line := range strings.SplitSeq(body, "\n") {
+		if trimmed := strings.TrimSpace(line); trimmed != "" {
+			lines = append(lines, trimmed)
+		}
+	}
+	return lines
+}
+
+func mapField(m map[string]any, key string) map[string]any {
+	if m == nil {
+		return nil
+	}
+	return mapFromAny(m[key])
+}
+
+func mapFromAny(value any) map[string]any {
+	if m, ok := value.(map[string]any); ok {
+		return m
+	}
+	return nil
+}
+
+func addedKeys(oldMap, newMap map[string]any) []string {
+	var added []string
+	for key := range newMap {
+		if _, ok := oldMap[key]; !ok {
+			added = append(added, key)
+		}
+	}
+	sort.Strings(added)
+	return added
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// stringSet converts a string or list of strings into a set
+func stringSet(value any) map[string]struct{} {
+	set := make(map[string]struct{})
+	switch v := value.(type) {
+	case string:
+		set[v] = struct{}{}
+	case []any:
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				set[s] = struct{}{}
+			}
+		}
+	case []string:
+		for _, s := range v {
+			set[s] = struct{}{}
+		}
+	}
+	return set
+}
+
+func addedStrings(oldValue, newValue any) []string {
+	oldSet := stringSet(oldValue)
+	var added []string
+	for value := range stringSet(newValue) {
+		if _, ok := oldSet[value]; !ok {
+			added = append(added, value)
+		}
+	}
+	sort.Strings(added)
+	return added
+}
+
+func isEnabled(value any) bool {
+	return value == true
+}
+
+// isUnrestrictedBash reports whether a bash tool configuration allows any command
+func isUnrestrictedBash(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v == "*" || v == ":*"
+	case []any:
+		for _, item := range v {
+			if item == "*" || item == ":*" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// networkDomains returns the allowed domains of a network configuration. The
+// "defaults" shorthand and a missing network field both map to {"defaults"}.
+func networkDomains(value any) map[string]struct{} {
+	switch v := value.(type) {
+	case nil:
+		return map[string]struct{}{"defaults": {}}
+	case string:
+		return stringSet(v)
+	case map[string]any:
+		return stringSet(v["allowed"])
+	}
+	return map[string]struct{}{}
+}
+
+func engineName(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case map[string]any:
+		if id, ok := v["id"].(string); ok {
+			return id
+		}
+	}
+	return ""
+}
+
+func displayOrDefault(value string) string {
+	if value == "" {
+		return "default"
+	}
+	return value
+}
+
+// assessChangeRisk scores the changes made to the workflow at markdownPath since HEAD
+// and stores the report for the compile summary. New workflows and workflows outside
+// the git repository are not scored.
+func (c *Compiler) assessChangeRisk(markdownPath string) {
+	delete(c.changeRiskReports, markdownPath)
+	if c.gitRoot == "" {
+		return
+	}
+	committed, err := gitutil.ReadFileFromHEAD(markdownPath, c.gitRoot)
+	if err != nil {
+		changeRiskLog.Printf("Skipping change risk for %s: %v", markdownPath, err)
+		return
+	}
+	current, err := os.ReadFile(markdownPath)
+	if err != nil || string(current) == committed {
+		return
+	}
+
+	oldResult, err := parser.ExtractFrontmatterFromContent(committed)
+	if err != nil {
+		changeRiskLog.Printf("Skipping change risk for %s: committed version does not parse: %v", markdownPath, err)
+		return
+	}
+	newResult, err := parser.ExtractFrontmatterFromContent(string(current))
+	if err != nil {
+		return
+	}
+
+	report := AssessChangeRisk(GetWorkflowIDFromPath(markdownPath), oldResult.Frontmatter, newResult.Frontmatter, oldResult.Markdown, newResult.Markdown)
+	if report == nil {
+		return
+	}
+	if c.changeRiskReports == nil {
+		c.changeRiskReports = make(map[string]*ChangeRiskReport)
+	}
+	c.changeRiskReports[markdownPath] = report
+}
+
+// GetChangeRiskReport returns the change risk report of the workflow at markdownPath,
+// or nil when the workflow is new, unchanged, or its change carries no risk findings.
+func (c *Compiler) GetChangeRiskReport(markdownPath string) *ChangeRiskReport {
+	return c.changeRiskReports[markdownPath]
+}
+
+// GetChangeRiskReports returns the change risk reports of all workflows compiled by
+// this compiler, highest score first.
+func (c *Compiler) GetChangeRiskReports() []*ChangeRiskReport {
+	reports := make([]*ChangeRiskReport, 0, len(c.changeRiskReports))
+	for _, report := range c.changeRiskReports {
+		reports = append(reports, report)
+	}
+	sort.Slice(reports, func(i, j int) bool {
+		if reports[i].Score != reports[j].Score {
+			return reports[i].Score > reports[j].Score
+		}
+		return reports[i].Workflow < reports[j].Workflow
+	})
+	return reports
+}
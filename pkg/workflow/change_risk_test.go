@@ -0,0 +1,220 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func riskMessages(report *ChangeRiskReport) []string {
+	if report == nil {
+		return nil
+	}
+	messages := make([]string, 0, len(report.Findings))
+	for _, finding := range report.Findings {
+		messages = append(messages, finding.Message)
+	}
+	return messages
+}
+
+func TestAssessChangeRisk(t *testing.T) {
+	base := func() map[string]any {
+		return map[string]any{
+			"on":           "issues",
+			"permissions":  map[string]any{"contents": "read"},
+			"safe-outputs": map[string]any{"add-comment": nil},
+			"tools":        map[string]any{"github": map[string]any{"toolsets": []any{"issues"}, "lockdown": true}},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		mutate    func(fm map[string]any)
+		wantLevel ChangeRiskLevel
+		wantMsg   string
+	}{
+		{
+			name: "new high impact safe output",
+			mutate: func(fm map[string]any) {
+				fm["safe-outputs"].(map[string]any)["create-pull-request"] = nil
+			},
+			wantLevel: ChangeRiskMedium,
+			wantMsg:   "new safe output 'create-pull-request'",
+		},
+		{
+			name: "new write permission",
+			mutate: func(fm map[string]any) {
+				fm["permissions"].(map[string]any)["issues"] = "write"
+			},
+			wantLevel: ChangeRiskMedium,
+			wantMsg:   "new write permission 'issues'",
+		},
+		{
+			name: "write-all permissions",
+			mutate: func(fm map[string]any) {
+				fm["permissions"] = "write-all"
+			},
+			wantLevel: ChangeRiskHigh,
+			wantMsg:   "permissions changed to write-all",
+		},
+		{
+			name: "strict disabled",
+			mutate: func(fm map[string]any) {
+				fm["strict"] = false
+			},
+			wantLevel: ChangeRiskMedium,
+			wantMsg:   "strict mode was disabled",
+		},
+		{
+			name: "network opened to all domains",
+			mutate: func(fm map[string]any) {
+				fm["network"] = map[string]any{"allowed": []any{"*"}}
+			},
+			wantLevel: ChangeRiskMedium,
+			wantMsg:   "network access opened to all domains",
+		},
+		{
+			name: "threat detection disabled",
+			mutate: func(fm map[string]any) {
+				fm["safe-outputs"].(map[string]any)["threat-detection"] = false
+			},
+			wantLevel: ChangeRiskMedium,
+			wantMsg:   "threat detection was disabled",
+		},
+		{
+			name: "lockdown turned off",
+			mutate: func(fm map[string]any) {
+				fm["tools"].(map[string]any)["github"].(map[string]any)["lockdown"] = false
+			},
+			wantLevel: ChangeRiskLow,
+			wantMsg:   "GitHub lockdown mode was turned off",
+		},
+		{
+			name: "unrestricted bash",
+			mutate: func(fm map[string]any) {
+				fm["tools"].(map[string]any)["bash"] = []any{"*"}
+			},
+			wantLevel: ChangeRiskMedium,
+			wantMsg:   "bash is no longer restricted to an allowlist",
+		},
+		{
+			name: "roles opened to all users",
+			mutate: func(fm map[string]any) {
+				fm["roles"] = "all"
+			},
+			wantLevel: ChangeRiskMedium,
+			wantMsg:   "workflow can be triggered by any user (roles: all)",
+		},
+		{
+			name: "new triage role",
+			mutate: func(fm map[string]any) {
+				fm["roles"] = []any{"admin", "maintainer", "write", "triage"}
+			},
+			wantLevel: ChangeRiskLow,
+			wantMsg:   "new trigger roles: triage",
+		},
+		{
+			name: "pull_request_target trigger",
+			mutate: func(fm map[string]any) {
+				fm["on"] = map[string]any{"pull_request_target": nil}
+			},
+			wantLevel: ChangeRiskMedium,
+			wantMsg:   "new pull_request_target trigger (runs with secrets on fork pull requests)",
+		},
+		{
+			name: "new mcp server",
+			mutate: func(fm map[string]any) {
+				fm["mcp-servers"] = map[string]any{"notion": map[string]any{"url": "https://mcp.notion.com/mcp"}}
+			},
+			wantLevel: ChangeRiskLow,
+			wantMsg:   "new MCP server 'notion'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newFrontmatter := base()
+			tt.mutate(newFrontmatter)
+			report := AssessChangeRisk("triage", base(), newFrontmatter, "Prompt", "Prompt")
+			require.NotNil(t, report, "change should be scored")
+			assert.Contains(t, riskMessages(report), tt.wantMsg, "finding should describe the change")
+			assert.Equal(t, tt.wantLevel, report.Level, "risk level should match the score")
+		})
+	}
+}
+
+func TestAssessChangeRiskNoFindings(t *testing.T) {
+	frontmatter := map[string]any{
+		"on":           "issues",
+		"safe-outputs": map[string]any{"add-comment": nil, "create-issue": nil},
+		"network":      map[string]any{"allowed": []any{"defaults", "example.com"}},
+	}
+	narrowed := map[string]any{
+		"on":           "issues",
+		"safe-outputs": map[string]any{"add-comment": nil, "noop": nil},
+		"network":      map[string]any{"allowed": []any{"defaults"}},
+		"strict":       true,
+	}
+	assert.Nil(t, AssessChangeRisk("triage", frontmatter, narrowed, "Same prompt", "Same prompt"), "narrowing changes should not be flagged")
+}
+
+func TestAssessChangeRiskPrompt(t *testing.T) {
+	oldBody := "# Triage\n\nRead the issue.\nAdd a label.\nBe concise.\nDo not close issues.\n"
+
+	report := AssessChangeRisk("triage", nil, nil, oldBody, oldBody+"Mention the author.\n")
+	require.NotNil(t, report, "prompt edit should be reported")
+	assert.Equal(t, ChangeRiskLow, report.Level, "small edit should be low risk")
+	assert.Contains(t, riskMessages(report)[0], "prompt edited", "small edit should be reported as an edit")
+
+	report = AssessChangeRisk("triage", nil, nil, oldBody, "# Fixer\n\nRewrite the code.\nOpen a pull request.\n")
+	require.NotNil(t, report, "rewrite should be reported")
+	assert.Contains(t, riskMessages(report)[0], "major prompt rewrite", "rewrite should be reported as major")
+
+	report = AssessChangeRisk("triage", map[string]any{"engine": "copilot"}, map[string]any{"engine": map[string]any{"id": "claude"}, "imports": []any{"shared/tools.md"}}, oldBody, oldBody)
+	require.NotNil(t, report, "engine and import changes should be reported")
+	assert.Contains(t, riskMessages(report), "engine changed from 'copilot' to 'claude'", "engine change should be reported")
+	assert.Contains(t, riskMessages(report), "new imports: shared/tools.md", "new imports should be reported")
+}
+
+func TestPromptChangeRatio(t *testing.T) {
+	assert.InDelta(t, 0.0, promptChangeRatio("a\nb\n", "a\n\nb\n"), 0.001, "blank lines should be ignored")
+	assert.InDelta(t, 1.0, promptChangeRatio("a\nb\n", "c\nd\n"), 0.001, "disjoint prompts should be fully changed")
+	assert.InDelta(t, 0.2, promptChangeRatio("a\nb\n", "a\nb\nc\n"), 0.001, "one added line of five total should be 20%")
+	assert.InDelta(t, 0.0, promptChangeRatio("", ""), 0.001, "empty prompts should be unchanged")
+}
+
+func TestCompilerAssessesChangeRisk(t *testing.T) {
+	root := t.TempDir()
+	t.Chdir(root)
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, "git %v failed: %s", args, output)
+	}
+	git("init", "-q")
+
+	workflowsDir := filepath.Join(root, ".github", "workflows")
+	require.NoError(t, os.MkdirAll(workflowsDir, 0o755), "workflows dir should be created")
+	markdownPath := filepath.Join(workflowsDir, "triage.md")
+	require.NoError(t, os.WriteFile(markdownPath, []byte("---\non: issues\nengine: copilot\nsafe-outputs:\n  add-comment:\n---\n\nTriage the issue.\n"), 0o644), "workflow should be written")
+	git("add", ".")
+	git("commit", "-q", "-m", "init")
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(markdownPath), "unchanged workflow should compile")
+	assert.Nil(t, compiler.GetChangeRiskReport(markdownPath), "unchanged workflow should not be scored")
+
+	require.NoError(t, os.WriteFile(markdownPath, []byte("---\non: issues\nengine: copilot\nsafe-outputs:\n  add-comment:\n  create-pull-request:\n---\n\nTriage the issue.\n"), 0o644), "workflow should be updated")
+	require.NoError(t, compiler.CompileWorkflow(markdownPath), "changed workflow should compile")
+	report := compiler.GetChangeRiskReport(markdownPath)
+	require.NotNil(t, report, "changed workflow should be scored")
+	assert.Equal(t, "triage", report.Workflow, "report should name the workflow")
+	assert.Contains(t, riskMessages(report), "new safe output 'create-pull-request'", "new safe output should be reported")
+	assert.Len(t, compiler.GetChangeRiskReports(), 1, "reports should be collected for the summary")
+}
@@ -121,8 +121,8 @@ steps:
 
 	t.Logf("Found %d steps: %v", len(stepNames), stepNames)
 
-	if len(stepNames) < 9 {
-		t.Fatalf("Expected at least 9 steps, got %d: %v", len(stepNames), stepNames)
+	if len(stepNames) < 10 {
+		t.Fatalf("Expected at least 10 steps, got %d: %v", len(stepNames), stepNames)
 	}
 
 	// Verify the order in dev mode (when local actions are used):
@@ -161,16 +161,20 @@ steps:
 		t.Errorf("Sixth step should be 'Download activation artifact', got '%s'", stepNames[5])
 	}
 
-	if stepNames[6] != "Checkout code" {
-		t.Errorf("Seventh step should be 'Checkout code', got '%s'", stepNames[6])
+	if stepNames[6] != "Resolve scratch directory in prompt" {
+		t.Errorf("Seventh step should be 'Resolve scratch directory in prompt', got '%s'", stepNames[6])
 	}
 
-	if stepNames[7] != "Setup Node.js" {
-		t.Errorf("Eighth step should be 'Setup Node.js' (runtime setup after checkout), got '%s'", stepNames[7])
+	if stepNames[7] != "Checkout code" {
+		t.Errorf("Eighth step should be 'Checkout code', got '%s'", stepNames[7])
 	}
 
-	if stepNames[8] != "Use Node" {
-		t.Errorf("Ninth step should be 'Use Node', got '%s'", stepNames[8])
+	if stepNames[8] != "Setup Node.js" {
+		t.Errorf("Ninth step should be 'Setup Node.js' (runtime setup after checkout), got '%s'", stepNames[8])
+	}
+
+	if stepNames[9] != "Use Node" {
+		t.Errorf("Tenth step should be 'Use Node', got '%s'", stepNames[9])
 	}
 
 	// Verify that .github checkout is NOT present (redundant with full checkout in custom steps)
@@ -141,6 +141,15 @@ func (e *ClaudeEngine) GetInstallationSteps(workflowData *WorkflowData) []GitHub
 			true,
 			false,
 		))
+	} else if isDevcontainerRuntime(workflowData) {
+		npmSteps = append(npmSteps, GenerateDockerSbxNpmCLIInstallStep(
+			"@anthropic-ai/claude-code",
+			version,
+			"Install Claude Code CLI for devcontainer",
+			"claude",
+			true,
+			false,
+		))
 	}
 	return BuildNpmEngineInstallStepsWithAWF(npmSteps, workflowData)
 }
@@ -150,6 +159,12 @@ func (e *ClaudeEngine) GetDeclaredOutputFiles() []string {
 	return []string{}
 }
 
+// GetLogFileForParsing returns the stdout log, which holds the stream-json output
+// without the debug log and stderr diagnostics that agent-stdio.log interleaves.
+func (e *ClaudeEngine) GetLogFileForParsing() string {
+	return constants.AgentStdoutLogPath
+}
+
 // GetAgentManifestFiles returns Claude-specific instruction files that should be
 // treated as security-sensitive manifests.  Modifying these files can change the
 // agent's instructions, guidelines, or permissions on the next run.
@@ -246,6 +261,11 @@ func (e *ClaudeEngine) buildClaudeCliArgs(workflowData *WorkflowData, toolsWithM
 	if allowedTools != "" {
 		claudeArgs = append(claudeArgs, "--allowed-tools", allowedTools)
 	}
+	// Tools blocked on an MCP server are denied explicitly so they stay unavailable
+	// even when the server itself is allowed with a wildcard.
+	if disallowedTools := formatMCPBlockedTools(toolsWithMountedCLIs, "mcp__%s__%s"); len(disallowedTools) > 0 {
+		claudeArgs = append(claudeArgs, "--disallowed-tools", strings.Join(disallowedTools, ","))
+	}
 
 	// --debug-file implicitly enables debug mode and captures logs more reliably than 2>&1 | tee.
 	claudeArgs = append(claudeArgs, "--debug-file", logFile, "--verbose")
@@ -327,14 +347,14 @@ func (e *ClaudeEngine) buildClaudeCommandString(workflowData *WorkflowData, clau
 		// The harness sets cwd=GITHUB_WORKSPACE when spawning the claude process, so no
 		// shell-level cd prefix is needed.
 		execPrefix := fmt.Sprintf(`%s %s/%s %s`, nodeRuntimeResolutionCommand, SetupActionDestinationShell, harnessScriptName, commandName)
-		claudeCommand = fmt.Sprintf("%s %s%s --prompt-file /tmp/gh-aw/aw-prompts/prompt.txt", execPrefix, shellJoinArgs(claudeArgs), mcpConfigArg)
+		claudeCommand = fmt.Sprintf("%s %s%s --prompt-file "+constants.TmpGhAwDirExpr+"/aw-prompts/prompt.txt", execPrefix, shellJoinArgs(claudeArgs), mcpConfigArg)
 	} else {
 		// Without harness: use shell expansion for the prompt (no retry logic).
 		// Apply workspace prefix here since there is no JS harness to set the cwd.
 		// The prompt command is appended raw after shellJoinArgs because it contains
 		// shell variable references ("$(cat ...)") that must NOT be escaped —
 		// single-quoting them would prevent shell expansion at runtime.
-		promptCommand := `"$(cat /tmp/gh-aw/aw-prompts/prompt.txt)"`
+		promptCommand := `"$(cat ` + constants.TmpGhAwDirExpr + `/aw-prompts/prompt.txt)"`
 		claudeCommand = getWorkspaceCommandPrefixFor(workflowData.EngineConfig) + fmt.Sprintf("%s%s %s", shellJoinArgs(append([]string{commandName}, claudeArgs...)), mcpConfigArg, promptCommand)
 	}
 
@@ -408,15 +428,15 @@ func (e *ClaudeEngine) buildClaudeFullCommand(workflowData *WorkflowData, claude
 
 	// Run Claude command without AWF wrapper.
 	// Note: Claude Code CLI writes debug logs to --debug-file and JSON output to stdout.
-	// Use tee to capture stdout (stream-json output) to the log file while also displaying on console.
-	// The combined output (debug logs + JSON) will be in the log file for parsing.
+	// captureEngineOutput keeps stdout (the stream-json output parsed for the step summary)
+	// apart from stderr diagnostics while the combined log keeps both.
 	// PATH is already set correctly by actions/setup-* steps which prepend to PATH.
 	return fmt.Sprintf(`set -o pipefail
           printf '%%s' "$(date +%%s%%3N)" > %s
           touch %s
-          (umask 177 && touch %s)
+          %s
           # Execute Claude Code CLI with prompt from file
-          %s 2>&1 | tee -a %s`, AgentCLIStartMsPath, AgentStepSummaryPath, logFile, claudeCommand, logFile)
+          %s`, AgentCLIStartMsPath, AgentStepSummaryPath, precreateEngineLogsCommand(logFile), captureEngineOutput(claudeCommand, logFile))
 }
 
 // buildClaudeCommandEnv builds the environment variable map for the Claude execution step.
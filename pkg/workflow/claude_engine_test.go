@@ -123,7 +123,7 @@ func TestClaudeEngine(t *testing.T) {
 		t.Errorf("Expected ANTHROPIC_API_KEY environment variable in step: %s", stepContent)
 	}
 
-	if !strings.Contains(stepContent, "GH_AW_PROMPT: /tmp/gh-aw/aw-prompts/prompt.txt") {
+	if !strings.Contains(stepContent, "GH_AW_PROMPT: ${{ env.GH_AW_TMP_DIR }}/aw-prompts/prompt.txt") {
 		t.Errorf("Expected GH_AW_PROMPT environment variable in step: %s", stepContent)
 	}
 
@@ -695,16 +695,16 @@ func TestClaudeEngineNoDoubleEscapePrompt(t *testing.T) {
 			},
 		}
 
-		steps := engine.GetExecutionSteps(workflowData, "/tmp/gh-aw/test.log")
+		steps := engine.GetExecutionSteps(workflowData, "${{ env.GH_AW_TMP_DIR }}/test.log")
 		stepContent := strings.Join([]string(steps[0]), "\n")
 
 		// With harness: prompt is passed via --prompt-file, not via shell expansion.
-		if strings.Contains(stepContent, `"$(cat /tmp/gh-aw/aw-prompts/prompt.txt)"`) {
+		if strings.Contains(stepContent, `"$(cat ${{ env.GH_AW_TMP_DIR }}/aw-prompts/prompt.txt)"`) {
 			t.Errorf("Found old shell-expansion prompt argument (harness should use --prompt-file instead):\n%s", stepContent)
 		}
 
 		// The harness reads the prompt file via --prompt-file.
-		if !strings.Contains(stepContent, "--prompt-file /tmp/gh-aw/aw-prompts/prompt.txt") {
+		if !strings.Contains(stepContent, "--prompt-file ${{ env.GH_AW_TMP_DIR }}/aw-prompts/prompt.txt") {
 			t.Errorf("Expected --prompt-file argument for harness execution, got:\n%s", stepContent)
 		}
 	})
@@ -720,11 +720,11 @@ func TestClaudeEngineNoDoubleEscapePrompt(t *testing.T) {
 			AgentFile: ".github/agents/test-agent.md",
 		}
 
-		steps := engine.GetExecutionSteps(workflowData, "/tmp/gh-aw/test.log")
+		steps := engine.GetExecutionSteps(workflowData, "${{ env.GH_AW_TMP_DIR }}/test.log")
 		stepContent := strings.Join([]string(steps[0]), "\n")
 
 		// Must still read from prompt.txt via --prompt-file — not from a PROMPT_TEXT shell variable
-		if !strings.Contains(stepContent, "--prompt-file /tmp/gh-aw/aw-prompts/prompt.txt") {
+		if !strings.Contains(stepContent, "--prompt-file ${{ env.GH_AW_TMP_DIR }}/aw-prompts/prompt.txt") {
 			t.Errorf("Expected claude to read from prompt.txt even with agent file set, got:\n%s", stepContent)
 		}
 		if strings.Contains(stepContent, "PROMPT_TEXT") {
@@ -765,17 +765,17 @@ func TestClaudeEngineHarnessUsesPromptFile(t *testing.T) {
 		},
 	}
 
-	steps := engine.GetExecutionSteps(workflowData, "/tmp/gh-aw/test.log")
+	steps := engine.GetExecutionSteps(workflowData, "${{ env.GH_AW_TMP_DIR }}/test.log")
 	if len(steps) != 1 {
 		t.Fatalf("Expected 1 execution step, got %d", len(steps))
 	}
 	stepContent := strings.Join([]string(steps[0]), "\n")
 
 	// Harness-based execution uses --prompt-file, not "$(cat ...)".
-	if strings.Contains(stepContent, `"$(cat /tmp/gh-aw/aw-prompts/prompt.txt)"`) {
+	if strings.Contains(stepContent, `"$(cat ${{ env.GH_AW_TMP_DIR }}/aw-prompts/prompt.txt)"`) {
 		t.Errorf("Found old shell-expansion prompt: harness should use --prompt-file:\n%s", stepContent)
 	}
-	if !strings.Contains(stepContent, "--prompt-file /tmp/gh-aw/aw-prompts/prompt.txt") {
+	if !strings.Contains(stepContent, "--prompt-file ${{ env.GH_AW_TMP_DIR }}/aw-prompts/prompt.txt") {
 		t.Errorf("Expected --prompt-file in harness-wrapped execution:\n%s", stepContent)
 	}
 
@@ -830,7 +830,7 @@ func TestClaudeEngineAWFWithAgentFileReadsPromptTxt(t *testing.T) {
 		},
 	}
 
-	steps := engine.GetExecutionSteps(workflowData, "/tmp/gh-aw/agent-stdio.log")
+	steps := engine.GetExecutionSteps(workflowData, "${{ env.GH_AW_TMP_DIR }}/agent-stdio.log")
 	if len(steps) == 0 {
 		t.Fatal("Expected at least one step")
 	}
@@ -846,7 +846,7 @@ func TestClaudeEngineAWFWithAgentFileReadsPromptTxt(t *testing.T) {
 	}
 
 	// The container command must still read from prompt.txt via --prompt-file (harness resolves it).
-	if !strings.Contains(stepContent, "--prompt-file /tmp/gh-aw/aw-prompts/prompt.txt") {
+	if !strings.Contains(stepContent, "--prompt-file ${{ env.GH_AW_TMP_DIR }}/aw-prompts/prompt.txt") {
 		t.Errorf("Expected claude to read from prompt.txt in AWF mode, got:\n%s", stepContent)
 	}
 }
@@ -79,21 +79,21 @@ func TestClaudeEngineComputeAllowedTools(t *testing.T) {
 					"key": "test-memory-key",
 				},
 			},
-			expected: "Bash(cat /tmp/gh-aw/cache-memory/),Bash(cat > /tmp/gh-aw/cache-memory/),Bash(mkdir -p /tmp/gh-aw/cache-memory/),Bash(mv /tmp/gh-aw/cache-memory/),BashOutput,Edit(/tmp/gh-aw/cache-memory/*),ExitPlanMode,Glob,Grep,KillBash,LS,MultiEdit(/tmp/gh-aw/cache-memory/*),NotebookRead,Read,Read(/tmp/gh-aw/cache-memory/*),Task,TodoWrite,Write(/tmp/gh-aw/cache-memory/*)",
+			expected: "Bash(cat ${{ env.GH_AW_TMP_DIR }}/cache-memory/),Bash(cat > ${{ env.GH_AW_TMP_DIR }}/cache-memory/),Bash(mkdir -p ${{ env.GH_AW_TMP_DIR }}/cache-memory/),Bash(mv ${{ env.GH_AW_TMP_DIR }}/cache-memory/),BashOutput,Edit(${{ env.GH_AW_TMP_DIR }}/cache-memory/*),ExitPlanMode,Glob,Grep,KillBash,LS,MultiEdit(${{ env.GH_AW_TMP_DIR }}/cache-memory/*),NotebookRead,Read,Read(${{ env.GH_AW_TMP_DIR }}/cache-memory/*),Task,TodoWrite,Write(${{ env.GH_AW_TMP_DIR }}/cache-memory/*)",
 		},
 		{
 			name: "cache-memory with boolean true",
 			tools: map[string]any{
 				"cache-memory": true,
 			},
-			expected: "Bash(cat /tmp/gh-aw/cache-memory/),Bash(cat > /tmp/gh-aw/cache-memory/),Bash(mkdir -p /tmp/gh-aw/cache-memory/),Bash(mv /tmp/gh-aw/cache-memory/),BashOutput,Edit(/tmp/gh-aw/cache-memory/*),ExitPlanMode,Glob,Grep,KillBash,LS,MultiEdit(/tmp/gh-aw/cache-memory/*),NotebookRead,Read,Read(/tmp/gh-aw/cache-memory/*),Task,TodoWrite,Write(/tmp/gh-aw/cache-memory/*)",
+			expected: "Bash(cat ${{ env.GH_AW_TMP_DIR }}/cache-memory/),Bash(cat > ${{ env.GH_AW_TMP_DIR }}/cache-memory/),Bash(mkdir -p ${{ env.GH_AW_TMP_DIR }}/cache-memory/),Bash(mv ${{ env.GH_AW_TMP_DIR }}/cache-memory/),BashOutput,Edit(${{ env.GH_AW_TMP_DIR }}/cache-memory/*),ExitPlanMode,Glob,Grep,KillBash,LS,MultiEdit(${{ env.GH_AW_TMP_DIR }}/cache-memory/*),NotebookRead,Read,Read(${{ env.GH_AW_TMP_DIR }}/cache-memory/*),Task,TodoWrite,Write(${{ env.GH_AW_TMP_DIR }}/cache-memory/*)",
 		},
 		{
 			name: "cache-memory with nil value (no value specified)",
 			tools: map[string]any{
 				"cache-memory": nil,
 			},
-			expected: "Bash(cat /tmp/gh-aw/cache-memory/),Bash(cat > /tmp/gh-aw/cache-memory/),Bash(mkdir -p /tmp/gh-aw/cache-memory/),Bash(mv /tmp/gh-aw/cache-memory/),BashOutput,Edit(/tmp/gh-aw/cache-memory/*),ExitPlanMode,Glob,Grep,KillBash,LS,MultiEdit(/tmp/gh-aw/cache-memory/*),NotebookRead,Read,Read(/tmp/gh-aw/cache-memory/*),Task,TodoWrite,Write(/tmp/gh-aw/cache-memory/*)",
+			expected: "Bash(cat ${{ env.GH_AW_TMP_DIR }}/cache-memory/),Bash(cat > ${{ env.GH_AW_TMP_DIR }}/cache-memory/),Bash(mkdir -p ${{ env.GH_AW_TMP_DIR }}/cache-memory/),Bash(mv ${{ env.GH_AW_TMP_DIR }}/cache-memory/),BashOutput,Edit(${{ env.GH_AW_TMP_DIR }}/cache-memory/*),ExitPlanMode,Glob,Grep,KillBash,LS,MultiEdit(${{ env.GH_AW_TMP_DIR }}/cache-memory/*),NotebookRead,Read,Read(${{ env.GH_AW_TMP_DIR }}/cache-memory/*),Task,TodoWrite,Write(${{ env.GH_AW_TMP_DIR }}/cache-memory/*)",
 		},
 		{
 			name: "cache-memory with github tools",
@@ -103,7 +103,7 @@ func TestClaudeEngineComputeAllowedTools(t *testing.T) {
 					"allowed": []any{"get_repository"},
 				},
 			},
-			expected: "Bash(cat /tmp/gh-aw/cache-memory/),Bash(cat > /tmp/gh-aw/cache-memory/),Bash(mkdir -p /tmp/gh-aw/cache-memory/),Bash(mv /tmp/gh-aw/cache-memory/),BashOutput,Edit(/tmp/gh-aw/cache-memory/*),ExitPlanMode,Glob,Grep,KillBash,LS,MultiEdit(/tmp/gh-aw/cache-memory/*),NotebookRead,Read,Read(/tmp/gh-aw/cache-memory/*),Task,TodoWrite,Write(/tmp/gh-aw/cache-memory/*),mcp__github__get_repository",
+			expected: "Bash(cat ${{ env.GH_AW_TMP_DIR }}/cache-memory/),Bash(cat > ${{ env.GH_AW_TMP_DIR }}/cache-memory/),Bash(mkdir -p ${{ env.GH_AW_TMP_DIR }}/cache-memory/),Bash(mv ${{ env.GH_AW_TMP_DIR }}/cache-memory/),BashOutput,Edit(${{ env.GH_AW_TMP_DIR }}/cache-memory/*),ExitPlanMode,Glob,Grep,KillBash,LS,MultiEdit(${{ env.GH_AW_TMP_DIR }}/cache-memory/*),NotebookRead,Read,Read(${{ env.GH_AW_TMP_DIR }}/cache-memory/*),Task,TodoWrite,Write(${{ env.GH_AW_TMP_DIR }}/cache-memory/*),mcp__github__get_repository",
 		},
 		{
 			name: "cache-memory with unrestricted bash (no extra cache bash commands injected)",
@@ -111,7 +111,7 @@ func TestClaudeEngineComputeAllowedTools(t *testing.T) {
 				"cache-memory": true,
 				"bash":         []any{"*"},
 			},
-			expected: "Bash,BashOutput,Edit(/tmp/gh-aw/cache-memory/*),ExitPlanMode,Glob,Grep,KillBash,LS,MultiEdit(/tmp/gh-aw/cache-memory/*),NotebookRead,Read,Read(/tmp/gh-aw/cache-memory/*),Task,TodoWrite,Write(/tmp/gh-aw/cache-memory/*)",
+			expected: "Bash,BashOutput,Edit(${{ env.GH_AW_TMP_DIR }}/cache-memory/*),ExitPlanMode,Glob,Grep,KillBash,LS,MultiEdit(${{ env.GH_AW_TMP_DIR }}/cache-memory/*),NotebookRead,Read,Read(${{ env.GH_AW_TMP_DIR }}/cache-memory/*),Task,TodoWrite,Write(${{ env.GH_AW_TMP_DIR }}/cache-memory/*)",
 		},
 		{
 			name: "mixed neutral and mcp tools",
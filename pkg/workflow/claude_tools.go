@@ -25,7 +25,7 @@ func (e *ClaudeEngine) expandNeutralToolsToClaudeTools(tools map[string]any) map
 	// Count neutral tools
 	for key := range tools {
 		switch key {
-		case "bash", "web-fetch", "web-search", "edit", "playwright":
+		case "bash", "web-fetch", "web-search", "edit", "playwright", "filesystem":
 			neutralToolCount++
 		}
 	}
@@ -37,7 +37,7 @@ func (e *ClaudeEngine) expandNeutralToolsToClaudeTools(tools map[string]any) map
 	// Copy existing tools that are not neutral tools
 	for key, value := range tools {
 		switch key {
-		case "bash", "web-fetch", "web-search", "edit", "playwright":
+		case "bash", "web-fetch", "web-search", "edit", "playwright", "filesystem":
 			// These are neutral tools that need conversion - skip copying, will be converted below
 			continue
 		default:
@@ -82,6 +82,15 @@ func (e *ClaudeEngine) expandNeutralToolsToClaudeTools(tools map[string]any) map
 		_ = editTool
 	}
 
+	if filesystemTool, hasFilesystem := tools["filesystem"]; hasFilesystem {
+		// filesystem -> Read/Write/Edit/MultiEdit scoped to the configured folders
+		if config, err := parseFilesystemTool(filesystemTool); err == nil {
+			for _, tool := range config.claudeAllowedTools() {
+				claudeAllowed[tool] = nil
+			}
+		}
+	}
+
 	// Handle playwright tool by converting it to an MCP tool configuration
 	if _, hasPlaywright := tools["playwright"]; hasPlaywright {
 		// Create playwright as an MCP tool with the same tools available as copilot agent
@@ -106,7 +115,7 @@ func isExplicitlyDisabledTool(tool any) bool {
 // While --tools is simpler (e.g., "Bash,Edit,Read"), it lacks the fine-grained control gh-aw requires:
 // - Specific bash commands: Bash(git:*), Bash(ls)
 // - MCP tool prefixes: mcp__github__issue_read, mcp__github__*
-// - Path-specific access: Read(/tmp/gh-aw/cache-memory/*)
+// - Path-specific access: Read(${{ env.GH_AW_TMP_DIR }}/cache-memory/*)
 //
 // This function:
 // 1. validates that only neutral tools are provided (no claude section)
@@ -345,8 +354,9 @@ func appendGenericMCPTools(allowedTools []string, toolName string, mcpConfig map
 			return append(allowedTools, "mcp__"+toolName)
 		}
 	}
+	blocked := getMCPBlockedTools(mcpConfig)
 	for _, item := range allowedSlice {
-		if str, ok := item.(string); ok {
+		if str, ok := item.(string); ok && !slices.Contains(blocked, str) {
 			allowedTools = append(allowedTools, fmt.Sprintf("mcp__%s__%s", toolName, str))
 		}
 	}
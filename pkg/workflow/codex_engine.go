@@ -23,13 +23,13 @@ const detectionResponseSchema = `{"type":"object","properties":{"prompt_injectio
 
 // detectionSchemaFilePath is the path where the detection JSON schema is written
 // before Codex runs. It is referenced by --output-schema.
-const detectionSchemaFilePath = "/tmp/gh-aw/threat-detection/detection_schema.json"
+const detectionSchemaFilePath = constants.TmpGhAwDirExpr + "/threat-detection/detection_schema.json"
 
 // detectionResultFilePath is the path where Codex writes the final structured
 // verdict via --output-last-message. The parser reads this file directly instead
 // of scraping the log stream, eliminating false parse_error warnings from noisy
 // SSE/tracing output.
-const detectionResultFilePath = "/tmp/gh-aw/threat-detection/detection_result.json"
+const detectionResultFilePath = constants.TmpGhAwDirExpr + "/threat-detection/detection_result.json"
 
 // Pre-compiled regexes for Codex log parsing (performance optimization)
 var (
@@ -124,15 +124,19 @@ func (e *CodexEngine) GetInstallationSteps(workflowData *WorkflowData) []GitHubA
 		"codex",
 		workflowData,
 	)
-	if isDockerSbxRuntime(workflowData) {
+	if isDockerSbxRuntime(workflowData) || isDevcontainerRuntime(workflowData) {
 		version := string(constants.DefaultCodexVersion)
 		if workflowData.EngineConfig != nil && workflowData.EngineConfig.Version != "" {
 			version = workflowData.EngineConfig.Version
 		}
+		stepName := "Install Codex CLI in docker-sbx path"
+		if isDevcontainerRuntime(workflowData) {
+			stepName = "Install Codex CLI for devcontainer"
+		}
 		steps = append(steps, GenerateDockerSbxNpmCLIInstallStep(
 			"@openai/codex",
 			version,
-			"Install Codex CLI in docker-sbx path",
+			stepName,
 			"codex",
 			false,
 			false,
@@ -285,7 +289,7 @@ func (e *CodexEngine) GetExecutionSteps(workflowData *WorkflowData, logFile stri
 		structuredOutputParam = fmt.Sprintf(` --output-schema %s -o %s`, detectionSchemaFilePath, detectionResultFilePath)
 		// Shell command to write the schema file before Codex runs.
 		// printf '%s' avoids the need to escape the JSON (no single quotes in schema).
-		detectionSchemaWriteCmd = fmt.Sprintf("mkdir -p /tmp/gh-aw/threat-detection && printf '%%s' '%s' > %s", detectionResponseSchema, detectionSchemaFilePath)
+		detectionSchemaWriteCmd = fmt.Sprintf("mkdir -p "+constants.TmpGhAwDirExpr+"/threat-detection && printf '%%s' '%s' > %s", detectionResponseSchema, detectionSchemaFilePath)
 		codexEngineLog.Printf("Enabling structured outputs for Codex detection run")
 	}
 
@@ -320,7 +324,7 @@ func (e *CodexEngine) GetExecutionSteps(workflowData *WorkflowData, logFile stri
 		// The harness sets cwd=GITHUB_WORKSPACE when spawning the codex process, so no
 		// shell-level cd prefix is needed.
 		execPrefix := fmt.Sprintf(`%s %s/%s %s`, nodeRuntimeResolutionCommand, SetupActionDestinationShell, harnessScriptName, commandName)
-		codexCommand = fmt.Sprintf("%s exec%s%s%s%s%s%s --prompt-file /tmp/gh-aw/aw-prompts/prompt.txt",
+		codexCommand = fmt.Sprintf("%s exec%s%s%s%s%s%s --prompt-file "+constants.TmpGhAwDirExpr+"/aw-prompts/prompt.txt",
 			execPrefix, modelParam, webSearchParam, webFetchParam, executionPolicyParam, structuredOutputParam, customArgsParam)
 	} else {
 		// Without harness: use shell expansion for the prompt (no retry logic).
@@ -361,7 +365,7 @@ func (e *CodexEngine) GetExecutionSteps(workflowData *WorkflowData, logFile stri
 			// Harness handles prompt reading via --prompt-file; no INSTRUCTION variable needed.
 			codexCommandWithSetup = fmt.Sprintf(`%s && %s`, npmPathSetup, codexCommand)
 		} else {
-			codexCommandWithSetup = fmt.Sprintf(`%s && INSTRUCTION="$(cat /tmp/gh-aw/aw-prompts/prompt.txt)" && %s`, npmPathSetup, codexCommand)
+			codexCommandWithSetup = fmt.Sprintf(`%s && INSTRUCTION="$(cat `+constants.TmpGhAwDirExpr+`/aw-prompts/prompt.txt)" && %s`, npmPathSetup, codexCommand)
 		}
 		if dockerSbxCLIPath := GetDockerSbxNpmCLIPathSetup(workflowData); dockerSbxCLIPath != "" {
 			codexCommandWithSetup = fmt.Sprintf("%s && %s", dockerSbxCLIPath, codexCommandWithSetup)
@@ -413,17 +417,17 @@ func (e *CodexEngine) GetExecutionSteps(workflowData *WorkflowData, logFile stri
 			command = fmt.Sprintf(`set -o pipefail
 printf '%%s' "$(date +%%s%%3N)" > %s
 touch %s
-(umask 177 && touch %s)
+%s
 mkdir -p "$CODEX_HOME/logs"
-%s%s 2>&1 | tee %s`, AgentCLIStartMsPath, AgentStepSummaryPath, logFile, schemaWritePrefix, codexCommand, logFile)
+%s`, AgentCLIStartMsPath, AgentStepSummaryPath, precreateEngineLogsCommand(logFile), captureEngineOutput(schemaWritePrefix+codexCommand, logFile))
 		} else {
 			command = fmt.Sprintf(`set -o pipefail
 printf '%%s' "$(date +%%s%%3N)" > %s
 touch %s
-(umask 177 && touch %s)
+%s
 INSTRUCTION="$(cat "$GH_AW_PROMPT")"
 mkdir -p "$CODEX_HOME/logs"
-%s%s 2>&1 | tee %s`, AgentCLIStartMsPath, AgentStepSummaryPath, logFile, schemaWritePrefix, codexCommand, logFile)
+%s`, AgentCLIStartMsPath, AgentStepSummaryPath, precreateEngineLogsCommand(logFile), captureEngineOutput(schemaWritePrefix+codexCommand, logFile))
 		}
 	}
 
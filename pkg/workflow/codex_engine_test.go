@@ -172,7 +172,7 @@ func TestCodexEngineExecutionIncludesGitHubAWPrompt(t *testing.T) {
 		Name: "test-workflow",
 	}
 
-	steps := engine.GetExecutionSteps(workflowData, "/tmp/gh-aw/test.log")
+	steps := engine.GetExecutionSteps(workflowData, "${{ env.GH_AW_TMP_DIR }}/test.log")
 
 	// Should have at least one step
 	if len(steps) == 0 {
@@ -185,7 +185,7 @@ func TestCodexEngineExecutionIncludesGitHubAWPrompt(t *testing.T) {
 	foundMCPConfigEnv := false
 	for _, step := range steps {
 		stepContent := strings.Join([]string(step), "\n")
-		if strings.Contains(stepContent, "GH_AW_PROMPT: /tmp/gh-aw/aw-prompts/prompt.txt") {
+		if strings.Contains(stepContent, "GH_AW_PROMPT: ${{ env.GH_AW_TMP_DIR }}/aw-prompts/prompt.txt") {
 			foundPromptEnv = true
 		}
 		if strings.Contains(stepContent, "GH_AW_MCP_CONFIG: ${{ runner.temp }}/gh-aw/mcp-config/config.toml") {
@@ -205,13 +205,13 @@ func TestCodexEngineExecutionIncludesGitHubAWPrompt(t *testing.T) {
 func TestCodexEngineExecutionUsesWritableCodexHome(t *testing.T) {
 	engine := NewCodexEngine()
 
-	steps := engine.GetExecutionSteps(&WorkflowData{Name: "test-workflow"}, "/tmp/gh-aw/test.log")
+	steps := engine.GetExecutionSteps(&WorkflowData{Name: "test-workflow"}, "${{ env.GH_AW_TMP_DIR }}/test.log")
 	if len(steps) == 0 {
 		t.Fatal("Expected at least one execution step")
 	}
 
 	stepContent := strings.Join([]string(steps[0]), "\n")
-	if !strings.Contains(stepContent, "CODEX_HOME: /tmp/gh-aw/mcp-config") {
+	if !strings.Contains(stepContent, "CODEX_HOME: ${{ env.GH_AW_TMP_DIR }}/mcp-config") {
 		t.Errorf("Expected CODEX_HOME to use writable /tmp path, got:\n%s", stepContent)
 	}
 }
@@ -282,16 +282,16 @@ func TestCodexEngineRenderMCPConfig(t *testing.T) {
 				"GH_AW_MCP_CONFIG_NORM_EOF",
 				"",
 				"# Sync converter output to writable CODEX_HOME for Codex",
-				"mkdir -p /tmp/gh-aw/mcp-config",
-				"cat > \"/tmp/gh-aw/mcp-config/config.toml\" << GH_AW_CODEX_SHELL_POLICY_NORM_EOF",
+				"mkdir -p ${{ env.GH_AW_TMP_DIR }}/mcp-config",
+				"cat > \"${{ env.GH_AW_TMP_DIR }}/mcp-config/config.toml\" << GH_AW_CODEX_SHELL_POLICY_NORM_EOF",
 				"[shell_environment_policy]",
 				"inherit = \"core\"",
 				"include_only = [\"^CODEX_API_KEY$\", \"^GITHUB_PERSONAL_ACCESS_TOKEN$\", \"^HOME$\", \"^OPENAI_API_KEY$\", \"^PATH$\"]",
 				"GH_AW_CODEX_SHELL_POLICY_NORM_EOF",
-				"cat \"${RUNNER_TEMP}/gh-aw/mcp-config/config.toml\" >> \"/tmp/gh-aw/mcp-config/config.toml\"",
-				"chmod 600 \"/tmp/gh-aw/mcp-config/config.toml\"",
+				"cat \"${RUNNER_TEMP}/gh-aw/mcp-config/config.toml\" >> \"${{ env.GH_AW_TMP_DIR }}/mcp-config/config.toml\"",
+				"chmod 600 \"${{ env.GH_AW_TMP_DIR }}/mcp-config/config.toml\"",
 				"mkdir -p \"${CODEX_HOME}\"",
-				"if [ \"/tmp/gh-aw/mcp-config/config.toml\" != \"${CODEX_HOME}/config.toml\" ]; then cp \"/tmp/gh-aw/mcp-config/config.toml\" \"${CODEX_HOME}/config.toml\"; fi",
+				"if [ \"${{ env.GH_AW_TMP_DIR }}/mcp-config/config.toml\" != \"${CODEX_HOME}/config.toml\" ]; then cp \"${{ env.GH_AW_TMP_DIR }}/mcp-config/config.toml\" \"${CODEX_HOME}/config.toml\"; fi",
 				"chmod 600 \"${CODEX_HOME}/config.toml\"",
 			},
 		},
@@ -378,7 +378,7 @@ func TestCodexEngineRenderMCPConfigOpenAIProxyProvider(t *testing.T) {
 		}
 
 		normalizedResult := normalizeHeredocDelimiters(result)
-		syncStart := strings.Index(normalizedResult, "cat > \"/tmp/gh-aw/mcp-config/config.toml\" << GH_AW_CODEX_SHELL_POLICY_NORM_EOF")
+		syncStart := strings.Index(normalizedResult, "cat > \"${{ env.GH_AW_TMP_DIR }}/mcp-config/config.toml\" << GH_AW_CODEX_SHELL_POLICY_NORM_EOF")
 		if syncStart == -1 {
 			t.Fatalf("Expected config sync heredoc start in generated config, got:\n%s", normalizedResult)
 		}
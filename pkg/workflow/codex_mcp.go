@@ -132,7 +132,7 @@ func (e *CodexEngine) RenderMCPConfig(yaml *strings.Builder, tools map[string]an
 	// and prepend shell policy (converter output does not include this section).
 	yaml.WriteString("          \n")
 	yaml.WriteString("          # Sync converter output to writable CODEX_HOME for Codex\n")
-	yaml.WriteString("          mkdir -p /tmp/gh-aw/mcp-config\n")
+	yaml.WriteString("          mkdir -p " + constants.TmpGhAwDirExpr + "/mcp-config\n")
 
 	// Build the shell-policy heredoc content into a temp buffer so the delimiter
 	// can be derived from a SHA-256 hash of the content for build stability.
@@ -142,28 +142,28 @@ func (e *CodexEngine) RenderMCPConfig(yaml *strings.Builder, tools map[string]an
 	}
 	e.renderShellEnvironmentPolicyToml(&shellPolicyContent, tools, mcpTools, "          ")
 	shellPolicyDelimiter := GenerateHeredocDelimiterFromContent("CODEX_SHELL_POLICY", shellPolicyContent.String())
-	yaml.WriteString("          cat > \"/tmp/gh-aw/mcp-config/config.toml\" << " + shellPolicyDelimiter + "\n")
+	yaml.WriteString("          cat > \"" + constants.TmpGhAwDirExpr + "/mcp-config/config.toml\" << " + shellPolicyDelimiter + "\n")
 	yaml.WriteString(shellPolicyContent.String())
 	yaml.WriteString("          " + shellPolicyDelimiter + "\n")
 	if isFirewallEnabled(workflowData) {
 		e.renderAppendConvertedConfigWithoutOpenAIProxy(yaml)
 	} else {
-		yaml.WriteString("          cat \"${RUNNER_TEMP}/gh-aw/mcp-config/config.toml\" >> \"/tmp/gh-aw/mcp-config/config.toml\"\n")
+		yaml.WriteString("          cat \"${RUNNER_TEMP}/gh-aw/mcp-config/config.toml\" >> \"" + constants.TmpGhAwDirExpr + "/mcp-config/config.toml\"\n")
 	}
 	if workflowData.EngineConfig != nil && strings.TrimSpace(workflowData.EngineConfig.Config) != "" {
 		customConfigDelimiter := GenerateHeredocDelimiterFromContent("CODEX_CUSTOM_CONFIG", workflowData.EngineConfig.Config)
 		yaml.WriteString("          \n")
 		yaml.WriteString("          # Append engine-level custom Codex config\n")
-		yaml.WriteString("          cat >> \"/tmp/gh-aw/mcp-config/config.toml\" << " + customConfigDelimiter + "\n")
+		yaml.WriteString("          cat >> \"" + constants.TmpGhAwDirExpr + "/mcp-config/config.toml\" << " + customConfigDelimiter + "\n")
 		yaml.WriteString(workflowData.EngineConfig.Config)
 		if !strings.HasSuffix(workflowData.EngineConfig.Config, "\n") {
 			yaml.WriteString("\n")
 		}
 		yaml.WriteString("          " + customConfigDelimiter + "\n")
 	}
-	yaml.WriteString("          chmod 600 \"/tmp/gh-aw/mcp-config/config.toml\"\n")
+	yaml.WriteString("          chmod 600 \"" + constants.TmpGhAwDirExpr + "/mcp-config/config.toml\"\n")
 	yaml.WriteString("          mkdir -p \"${CODEX_HOME}\"\n")
-	yaml.WriteString("          if [ \"/tmp/gh-aw/mcp-config/config.toml\" != \"${CODEX_HOME}/config.toml\" ]; then cp \"/tmp/gh-aw/mcp-config/config.toml\" \"${CODEX_HOME}/config.toml\"; fi\n")
+	yaml.WriteString("          if [ \"" + constants.TmpGhAwDirExpr + "/mcp-config/config.toml\" != \"${CODEX_HOME}/config.toml\" ]; then cp \"" + constants.TmpGhAwDirExpr + "/mcp-config/config.toml\" \"${CODEX_HOME}/config.toml\"; fi\n")
 	yaml.WriteString("          chmod 600 \"${CODEX_HOME}/config.toml\"\n")
 
 	return nil
@@ -193,7 +193,7 @@ func (e *CodexEngine) renderAppendConvertedConfigWithoutOpenAIProxy(yaml *string
 	yaml.WriteString("            /^\\[model_providers\\.openai-proxy\\][[:space:]]*$/ { skip_openai_proxy = 1; next }\n")
 	yaml.WriteString("            /^\\[/ { skip_openai_proxy = 0 }\n")
 	yaml.WriteString("            !skip_openai_proxy { print }\n")
-	yaml.WriteString("          ' \"${RUNNER_TEMP}/gh-aw/mcp-config/config.toml\" >> \"/tmp/gh-aw/mcp-config/config.toml\"\n")
+	yaml.WriteString("          ' \"${RUNNER_TEMP}/gh-aw/mcp-config/config.toml\" >> \"" + constants.TmpGhAwDirExpr + "/mcp-config/config.toml\"\n")
 }
 
 // renderCodexMCPConfigWithContext generates custom MCP server configuration for a single tool in codex workflow config.toml
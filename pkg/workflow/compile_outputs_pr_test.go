@@ -568,8 +568,8 @@ This test verifies that the aw-*.patch artifact is downloaded in the safe_output
 		t.Errorf("Expected patch artifact to be downloaded from unified 'agent' artifact")
 	}
 
-	if !strings.Contains(lockContentStr, "path: /tmp/gh-aw/") {
-		t.Errorf("Expected patch artifact to be downloaded to '/tmp/gh-aw/'")
+	if !strings.Contains(lockContentStr, "path: ${{ env.GH_AW_TMP_DIR }}/") {
+		t.Errorf("Expected patch artifact to be downloaded to '${{ env.GH_AW_TMP_DIR }}/'")
 	}
 
 	// Verify that the handler manager step exists (processes create_pull_request)
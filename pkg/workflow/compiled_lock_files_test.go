@@ -3,13 +3,17 @@
 package workflow
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/goccy/go-yaml"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/github/gh-aw/pkg/constants"
 )
 
 // workflowsDir is the path to the .github/workflows directory relative to this test file.
@@ -404,9 +408,9 @@ func TestCompiledLockFiles_SmokeWorkflowCallHasExpectedOutputs(t *testing.T) {
 	})
 
 	t.Run("LockUploadsOTELMirrorInAgentArtifact", func(t *testing.T) {
-		assert.Contains(t, lockContent, "/tmp/gh-aw/otel.jsonl",
+		assert.Contains(t, lockContent, "${{ env.GH_AW_TMP_DIR }}/otel.jsonl",
 			"smoke-workflow-call agent artifact should include the OTEL JSONL mirror")
-		assert.Contains(t, lockContent, "/tmp/gh-aw/otlp-export-errors.jsonl",
+		assert.Contains(t, lockContent, "${{ env.GH_AW_TMP_DIR }}/otlp-export-errors.jsonl",
 			"smoke-workflow-call agent artifact should include OTLP export failure details")
 	})
 
@@ -449,3 +453,44 @@ func TestCompiledLockFiles_SmokeCallWorkflowForwardsPayload(t *testing.T) {
 		assert.Contains(t, lockContent, "${{ fromJSON(needs.safe_outputs.outputs.call_workflow_payload)['task-description'] }}", "call-workflow job should forward task-description from the handler payload")
 	})
 }
+
+// TestCompiledLockFiles_TmpDirOnlyUsedAfterSetup validates that the job scratch directory is
+// only referenced once the setup step has exported GH_AW_TMP_DIR. The env context is not
+// available in job-level settings, and steps before the setup step (or in jobs without one)
+// would see an empty GH_AW_TMP_DIR and fall back to paths outside of /tmp/gh-aw.
+func TestCompiledLockFiles_TmpDirOnlyUsedAfterSetup(t *testing.T) {
+	lockFiles, err := filepath.Glob(filepath.Join(workflowsDir, "*.lock.yml"))
+	require.NoError(t, err, "should glob lock files")
+	require.NotEmpty(t, lockFiles, "should find at least one lock file")
+
+	for _, lockPath := range lockFiles {
+		lockBytes, err := os.ReadFile(lockPath)
+		require.NoError(t, err, "should read %s", lockPath)
+
+		var workflow struct {
+			Jobs map[string]map[string]any `yaml:"jobs"`
+		}
+		require.NoError(t, yaml.Unmarshal(lockBytes, &workflow), "should parse %s", lockPath)
+
+		baseName := filepath.Base(lockPath)
+		for jobName, job := range workflow.Jobs {
+			for key, value := range job {
+				if key == "steps" || key == "outputs" {
+					continue
+				}
+				assert.NotContains(t, fmt.Sprint(value), constants.TmpGhAwDirEnvVar,
+					"lock file %s: job %s must not use the job scratch directory in %s", baseName, jobName, key)
+			}
+
+			steps, _ := job["steps"].([]any)
+			for _, rawStep := range steps {
+				step, _ := rawStep.(map[string]any)
+				if step["id"] == "setup" {
+					break
+				}
+				assert.NotContains(t, fmt.Sprint(step), constants.TmpGhAwDirEnvVar,
+					"lock file %s: job %s uses the job scratch directory before the setup step in step %v", baseName, jobName, step["name"])
+			}
+		}
+	}
+}
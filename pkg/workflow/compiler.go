@@ -4,6 +4,7 @@ import (
 	_ "embed"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -12,6 +13,7 @@ import (
 	"github.com/github/gh-aw/pkg/constants"
 
 	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/fileutil"
 	"github.com/github/gh-aw/pkg/gitutil"
 	"github.com/github/gh-aw/pkg/logger"
 	"github.com/github/gh-aw/pkg/stringutil"
@@ -193,17 +195,16 @@ func (c *Compiler) generateAndValidateYAML(workflowData *WorkflowData, markdownP
 		if schemaErr != nil {
 			// Try to point at the exact line of the failing field in the source markdown.
 			// extractSchemaErrorField unwraps the error chain to find the top-level field
-			// name (e.g. "timeout-minutes"), which findFrontmatterFieldLine then locates in
+			// name (e.g. "timeout-minutes"), which locateSourceField then locates in
 			// the source frontmatter so the error is IDE-navigable.
-			fieldLine := 1
+			fieldLine, fieldColumn := 1, 1
 			if fieldName := extractSchemaErrorField(schemaErr); fieldName != "" {
-				frontmatterLines := strings.Split(workflowData.FrontmatterYAML, "\n")
-				if line := findFrontmatterFieldLine(frontmatterLines, 2, fieldName); line > 0 {
-					fieldLine = line
+				if line, column := locateSourceField(workflowData, fieldName); line > 0 {
+					fieldLine, fieldColumn = line, column
 				}
 			}
 			// Store error first so we can write invalid YAML before returning
-			formattedErr := formatCompilerErrorWithPosition(markdownPath, fieldLine, 1, "error",
+			formattedErr := formatCompilerErrorWithPosition(markdownPath, fieldLine, fieldColumn, "error",
 				fmt.Sprintf("invalid workflow: %v", schemaErr), schemaErr)
 			// Write the invalid YAML to a .invalid.yml file for inspection
 			invalidFile := strings.TrimSuffix(lockFile, ".lock.yml") + ".invalid.yml"
@@ -250,11 +251,28 @@ func (c *Compiler) generateAndValidateYAML(workflowData *WorkflowData, markdownP
 // writeWorkflowOutput writes the compiled workflow to the lock file
 // and handles console output formatting.
 func (c *Compiler) writeWorkflowOutput(lockFile, yamlContent string, markdownPath string) error {
+	// Write to stdout instead of the lock file (--stdout)
+	if c.outputWriter != nil && !c.noEmit {
+		return c.writeWorkflowToOutputWriter(yamlContent, markdownPath)
+	}
+
+	// Relocate the lock file to the output directory (--output-dir). The prompt registry
+	// only tracks lock files next to their source.
+	recordPrompt := c.customOutput == ""
+	if !recordPrompt {
+		lockFile = c.OutputLockFile(markdownPath)
+	}
+
 	// Write to lock file (unless noEmit is enabled)
 	if c.noEmit {
+		if c.checkLockFiles {
+			if err := checkLockFileUpToDate(lockFile, yamlContent, markdownPath); err != nil {
+				return err
+			}
+		}
 		workflowLog.Print("Validation completed - no lock file generated (--no-emit enabled)")
 	} else {
-		workflowLog.Printf("Writing output to: %s", lockFile)
+		workflowLog.Infof("Writing output to: %s", lockFile)
 
 		// Check if content has actually changed
 		contentUnchanged := false
@@ -268,12 +286,19 @@ func (c *Compiler) writeWorkflowOutput(lockFile, yamlContent string, markdownPat
 
 		// Only write if content has changed
 		if !contentUnchanged {
+			if err := fileutil.EnsureParentDir(lockFile, constants.DirPermPublic); err != nil {
+				return formatCompilerError(lockFile, "error", fmt.Sprintf("failed to create output directory: %v", err), err)
+			}
 			if err := os.WriteFile(lockFile, []byte(yamlContent), constants.FilePermPublic); err != nil {
 				return formatCompilerError(lockFile, "error", fmt.Sprintf("failed to write lock file: %v", err), err)
 			}
 			workflowLog.Print("Lock file written successfully")
 		}
 
+		if recordPrompt {
+			c.recordPromptVersion(markdownPath, lockFile, yamlContent)
+		}
+
 		// Validate file size after writing
 		if lockFileInfo, err := os.Stat(lockFile); err == nil {
 			if lockFileInfo.Size() > MaxLockFileSize {
@@ -303,6 +328,44 @@ func (c *Compiler) writeWorkflowOutput(lockFile, yamlContent string, markdownPat
 	return nil
 }
 
+// checkLockFileUpToDate returns an error when the lock file is missing or its content is not
+// byte-for-byte identical to the compiled workflow (compile --check).
+func checkLockFileUpToDate(lockFile, yamlContent, markdownPath string) error {
+	existingContent, err := os.ReadFile(lockFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return formatCompilerError(markdownPath, "error", fmt.Sprintf("lock file %s is missing; run 'gh aw compile' and commit the result", console.ToRelativePath(lockFile)), err)
+	}
+	if err != nil {
+		return formatCompilerError(markdownPath, "error", fmt.Sprintf("failed to read lock file: %v", err), err)
+	}
+	if string(existingContent) != yamlContent {
+		workflowLog.Printf("Lock file %s differs from the compiled workflow", lockFile)
+		return formatCompilerError(markdownPath, "error", fmt.Sprintf("lock file %s is out of date; run 'gh aw compile' and commit the result", console.ToRelativePath(lockFile)), nil)
+	}
+	workflowLog.Printf("Lock file %s is up to date", lockFile)
+	return nil
+}
+
+// writeWorkflowToOutputWriter writes the compiled workflow to the configured output writer,
+// separating consecutive workflows with a YAML document marker.
+func (c *Compiler) writeWorkflowToOutputWriter(yamlContent string, markdownPath string) error {
+	workflowLog.Printf("Writing output of %s to the output writer", markdownPath)
+	var content strings.Builder
+	if c.outputDocuments > 0 {
+		content.WriteString("---\n")
+	}
+	content.WriteString(yamlContent)
+	if _, err := io.WriteString(c.outputWriter, content.String()); err != nil {
+		return formatCompilerError(markdownPath, "error", fmt.Sprintf("failed to write compiled workflow: %v", err), err)
+	}
+	c.outputDocuments++
+
+	if !c.quiet {
+		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(console.ToRelativePath(markdownPath)))
+	}
+	return nil
+}
+
 // validateTemplateInjection checks compiled YAML for template injection vulnerabilities
 // (unsafe GitHub Actions expressions used directly in run: blocks).
 //
@@ -357,7 +420,7 @@ func (c *Compiler) validateTemplateInjection(yamlContent, lockFile, markdownPath
 			var reparsed map[string]any
 			if err := yaml.Unmarshal([]byte(yamlContent), &reparsed); err != nil {
 				// Malformed YAML: skip validation (compilation would have surfaced this elsewhere).
-				templateInjectionValidationLog.Printf("Failed to parse YAML for template injection check: %v", err)
+				templateInjectionValidationLog.Warnf("Failed to parse YAML for template injection check: %v", err)
 				reparsed = nil
 			}
 			if reparsed != nil {
@@ -416,14 +479,28 @@ func (c *Compiler) CompileWorkflowData(workflowData *WorkflowData, markdownPath
 	// Track compilation time for performance monitoring
 	startTime := time.Now()
 	defer func() {
-		workflowLog.Printf("Compilation completed in %v", time.Since(startTime))
+		workflowLog.Infof("Compilation completed in %v", time.Since(startTime))
 	}()
 
+	// Compile the companion workflows of agentic jobs and fan-out first, so the jobs
+	// calling them can be granted the permissions their compiled jobs require
+	if err := c.compileAgenticJobs(workflowData, markdownPath); err != nil {
+		return err
+	}
+	if err := c.compileFanOut(workflowData, markdownPath); err != nil {
+		return err
+	}
+	if err := c.compilePreview(workflowData, markdownPath); err != nil {
+		return err
+	}
+
 	// Reset the step order tracker for this compilation
 	c.stepOrderTracker = NewStepOrderTracker()
 
 	// Reset schedule friendly formats for this compilation
 	c.scheduleFriendlyFormats = nil
+	c.scheduleOptions = nil
+	c.followUpPolling = false
 
 	// Reset the artifact manager for this compilation
 	if c.artifactManager == nil {
@@ -451,7 +528,7 @@ func (c *Compiler) CompileWorkflowData(workflowData *WorkflowData, markdownPath
 	// Sanitize the lock file path to prevent path traversal attacks
 	lockFile = filepath.Clean(lockFile)
 
-	workflowLog.Printf("Starting compilation: %s -> %s", markdownPath, lockFile)
+	workflowLog.Infof("Starting compilation: %s -> %s", markdownPath, lockFile)
 
 	// Resolve and cache the baseline manifest only when safe update mode is active.
 	// This avoids unnecessary git/filesystem reads on compile paths that skip safe update
@@ -488,7 +565,7 @@ func (c *Compiler) CompileWorkflowData(workflowData *WorkflowData, markdownPath
 					workflowLog.Printf("Loaded committed gh-aw-manifest from HEAD: %d secret(s)", len(oldManifest.Secrets))
 				}
 			} else {
-				workflowLog.Printf("Failed to parse committed gh-aw-manifest: %v. Safe update enforcement will proceed without baseline comparison (all secrets will be considered new).", parseErr)
+				workflowLog.Warnf("Failed to parse committed gh-aw-manifest: %v. Safe update enforcement will proceed without baseline comparison (all secrets will be considered new).", parseErr)
 			}
 		} else {
 			workflowLog.Printf("Lock file %s not found in HEAD commit (%v); falling back to filesystem read.", lockFile, readErr)
@@ -500,7 +577,7 @@ func (c *Compiler) CompileWorkflowData(workflowData *WorkflowData, markdownPath
 						workflowLog.Printf("Loaded gh-aw-manifest from filesystem: %d secret(s)", len(oldManifest.Secrets))
 					}
 				} else {
-					workflowLog.Printf("Failed to parse filesystem gh-aw-manifest: %v. Safe update enforcement will treat as empty manifest.", parseErr)
+					workflowLog.Warnf("Failed to parse filesystem gh-aw-manifest: %v. Safe update enforcement will treat as empty manifest.", parseErr)
 				}
 			} else {
 				// No lock file anywhere — this is a brand-new workflow.  Use an empty
@@ -539,6 +616,9 @@ func (c *Compiler) CompileWorkflowData(workflowData *WorkflowData, markdownPath
 	// Note: compute-text functionality is now inlined directly in the task job
 	// instead of using a shared action file
 
+	// Refresh MCP server image pins before images are collected (--update-mcp)
+	c.refreshMCPImagePins(workflowData, markdownPath)
+
 	// Generate and validate YAML (also embeds the new gh-aw-manifest in the header).
 	// Returns the collected body secrets and action refs to avoid duplicate scans for
 	// safe update enforcement.
@@ -552,6 +632,17 @@ func (c *Compiler) CompileWorkflowData(workflowData *WorkflowData, markdownPath
 		return formatCompilerError(markdownPath, "error", "YAML generation: "+err.Error(), err)
 	}
 
+	// Report MCP server image digests that changed since the lock file was last compiled
+	c.checkMCPServerPins(workflowData, markdownPath, lockFile)
+
+	// Score the risk of the changes made since the last commit for the compile summary
+	c.assessChangeRisk(markdownPath)
+
+	// Fail on risky configurations that are not accepted in the baseline (compile --strict)
+	if err := c.checkSecurityLints(workflowData, markdownPath, yamlContent); err != nil {
+		return err
+	}
+
 	// Enforce safe update mode: emit a warning prompt (not a hard error) when unapproved
 	// secrets or action changes are detected.  body* vars contain data collected from the
 	// workflow body only (not the header) to avoid matching the gh-aw-manifest JSON comment.
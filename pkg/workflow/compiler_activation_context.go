@@ -68,7 +68,7 @@ func (c *Compiler) newActivationJobBuildContext(
 	compilerActivationJobLog.Printf("Initializing activation job build context: pre_activation=%t, lock=%s", preActivationJobCreated, lockFilename)
 	setupActionRef := c.resolveActionReference("./actions/setup", data)
 	if setupActionRef == "" {
-		compilerActivationJobLog.Print("Failed to resolve setup action reference for activation job")
+		compilerActivationJobLog.Warnf("Failed to resolve setup action reference for activation job")
 		return nil, errors.New("failed to resolve setup action reference; ensure ./actions/setup exists and is accessible")
 	}
 
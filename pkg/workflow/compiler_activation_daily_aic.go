@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/github/gh-aw/pkg/constants"
 	"github.com/github/gh-aw/pkg/sliceutil"
 )
 
@@ -96,6 +97,10 @@ func (c *Compiler) resolveDailyAICToken(data *WorkflowData) string {
 	return c.resolveActivationToken(data)
 }
 
+// dailyAICUsageCacheFile is the per-workflow JSONL snapshot of AIC usage that the daily
+// guardrail reads and the conclusion job appends to.
+const dailyAICUsageCacheFile = constants.TmpGhAwDirExpr + "/agentic-workflow-usage-cache.jsonl"
+
 func (c *Compiler) buildActivationDailyAICGuardrailStep(data *WorkflowData) []string {
 	compilerActivationJobLog.Printf("Building daily AIC guardrail step: dedicated_app=%t, cache_enabled=%t", data.MaxDailyAICreditsGitHubApp != nil, data.WorkflowID != "")
 	var steps []string
@@ -118,7 +123,8 @@ func (c *Compiler) buildActivationDailyAICGuardrailStep(data *WorkflowData) []st
 		steps = append(steps, "        with:\n")
 		steps = append(steps, fmt.Sprintf("          key: %s${{ github.run_id }}\n", cacheKeyPrefix))
 		steps = append(steps, fmt.Sprintf("          restore-keys: %s\n", cacheKeyPrefix))
-		steps = append(steps, "          path: /tmp/gh-aw/agentic-workflow-usage-cache.jsonl\n")
+		steps = append(steps, "          path: "+cacheStagingPath(dailyAICUsageCacheFile)+"\n")
+		steps = append(steps, buildCacheStagingFileCopySteps("Copy daily AIC usage cache into the scratch directory", maxDailyAICreditsConfiguredIfExpr, cacheStagingPath(dailyAICUsageCacheFile), dailyAICUsageCacheFile)...)
 		// Artifact-based fallback for cross-branch cache misses.
 		// GitHub Actions actions/cache is branch-scoped: caches written by the conclusion job
 		// on one PR branch are invisible to the activation job running on a different PR branch.
@@ -73,9 +73,28 @@ func (c *Compiler) buildActivationJob(data *WorkflowData, preActivationJobCreate
 		}
 	}
 
+	// Assign the run to a concurrency pool slot; the agent job's concurrency group
+	// reads the slot through needs.activation.outputs.pool_slot.
+	if poolSlotStep := buildSelectPoolSlotStep(data); len(poolSlotStep) > 0 {
+		ctx.steps = append(ctx.steps, poolSlotStep...)
+		ctx.outputs[poolSlotOutput] = fmt.Sprintf("${{ steps.%s.outputs.%s }}", selectPoolSlotStepID, poolSlotOutput)
+	}
+
+	// Restore the context of the previous invocation of a sticky slash command
+	// before the prompt is built so the follow-up section can include it.
+	if stickySteps := buildStickyContextRestoreSteps(data); len(stickySteps) > 0 {
+		ctx.steps = append(ctx.steps, stickySteps...)
+	}
+
+	// Take the due follow-ups out of the queue on scheduled runs so the prompt can describe them.
+	if followUpSteps := buildFollowUpTakeSteps(data); len(followUpSteps) > 0 {
+		ctx.steps = append(ctx.steps, followUpSteps...)
+	}
+
 	c.configureActivationNeedsAndCondition(ctx)
 	compilerActivationJobLog.Print("Generating prompt in activation job")
 	c.generatePromptInActivationJob(&ctx.steps, data, preActivationJobCreated, ctx.customJobsBeforeActivation)
+	ctx.outputs[correlationIDOutput] = fmt.Sprintf("${{ steps.%s.outputs.%s }}", runContextStepID, correlationIDOutput)
 	c.addActivationArtifactUploadStep(ctx)
 	if len(ctx.steps) == 0 {
 		ctx.steps = append(ctx.steps, "      - run: echo \"Activation success\"\n")
@@ -97,7 +116,7 @@ func (c *Compiler) buildActivationJob(data *WorkflowData, preActivationJobCreate
 		RunsOn:                     c.formatFrameworkJobRunsOn(data),
 		Permissions:                permissions,
 		Environment:                c.buildActivationEnvironment(ctx),
-		Env:                        buildDailyAICActivationJobEnv(data),
+		Env:                        withNetworkProxyEnv(buildDailyAICActivationJobEnv(data), data),
 		Steps:                      ctx.steps,
 		Outputs:                    ctx.outputs,
 		Needs:                      ctx.activationNeeds,
@@ -264,7 +283,7 @@ func activationEventSet(onSection string) (map[string]struct {
 	})
 	var onData map[string]any
 	if err := yaml.Unmarshal([]byte(onSection), &onData); err != nil {
-		compilerActivationJobLog.Printf("Failed to parse on section for activation permission scoping: %v", err)
+		compilerActivationJobLog.Warnf("Failed to parse on section for activation permission scoping: %v", err)
 		return events, false
 	}
 
@@ -515,7 +534,7 @@ func injectIfConditionAfterName(step, condition string) string {
 		}
 	}
 	if nameLineIdx < 0 {
-		compilerActivationJobLog.Printf("Warning: could not inject if-condition %q — step has no '- name:' line: %q", condition, step)
+		compilerActivationJobLog.Warnf("could not inject if-condition %q — step has no '- name:' line: %q", condition, step)
 		return step
 	}
 
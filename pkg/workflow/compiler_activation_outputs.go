@@ -25,6 +25,7 @@ func (c *Compiler) addActivationFeedbackAndValidationSteps(ctx *activationJobBui
 	}
 	c.addActivationReactionStep(ctx)
 	c.addActivationSecretValidationStep(ctx)
+	c.addActivationRequiredSecretsStep(ctx)
 	c.addActivationOAuthTokenCheckStep(ctx)
 	c.addActivationCrossRepoGuidanceStep(ctx)
 	return nil
@@ -41,6 +42,11 @@ func (c *Compiler) addActivationCommandAndLabelOutputs(ctx *activationJobBuildCo
 			ctx.outputs["slash_command"] = fmt.Sprintf("${{ steps.%s.outputs.%s }}", constants.CheckCommandPositionStepID, constants.MatchedCommandOutput)
 		}
 	}
+	if len(data.CommandSubcommands) > 0 && ctx.preActivationJob {
+		for output, preActivationOutput := range slashCommandActivationOutputs {
+			ctx.outputs[output] = fmt.Sprintf("${{ needs.%s.outputs.%s }}", string(constants.PreActivationJobName), preActivationOutput)
+		}
+	}
 
 	if ctx.shouldRemoveLabel {
 		compilerActivationJobLog.Print("Adding remove-trigger-label step for label-command workflow")
@@ -147,24 +153,24 @@ func (c *Compiler) addActivationArtifactUploadStep(ctx *activationJobBuildContex
 	ctx.steps = append(ctx.steps, fmt.Sprintf("          name: %s\n", activationArtifactName))
 	ctx.steps = append(ctx.steps, "          include-hidden-files: true\n")
 	ctx.steps = append(ctx.steps, "          path: |\n")
-	ctx.steps = append(ctx.steps, "            /tmp/gh-aw/aw_info.json\n")
-	ctx.steps = append(ctx.steps, "            /tmp/gh-aw/models.json\n")
-	ctx.steps = append(ctx.steps, "            /tmp/gh-aw/aw-prompts/prompt.txt\n")
-	ctx.steps = append(ctx.steps, "            /tmp/gh-aw/aw-prompts/prompt-template.txt\n")
-	ctx.steps = append(ctx.steps, "            /tmp/gh-aw/aw-prompts/prompt-import-tree.json\n")
-	ctx.steps = append(ctx.steps, "            /tmp/gh-aw/"+constants.GithubRateLimitsFilename+"\n")
-	ctx.steps = append(ctx.steps, "            /tmp/gh-aw/base\n")
+	ctx.steps = append(ctx.steps, "            "+constants.TmpGhAwDirExpr+"/aw_info.json\n")
+	ctx.steps = append(ctx.steps, "            "+constants.TmpGhAwDirExpr+"/models.json\n")
+	ctx.steps = append(ctx.steps, "            "+constants.TmpGhAwDirExpr+"/aw-prompts/prompt.txt\n")
+	ctx.steps = append(ctx.steps, "            "+constants.TmpGhAwDirExpr+"/aw-prompts/prompt-template.txt\n")
+	ctx.steps = append(ctx.steps, "            "+constants.TmpGhAwDirExpr+"/aw-prompts/prompt-import-tree.json\n")
+	ctx.steps = append(ctx.steps, "            "+constants.TmpGhAwDirExpr+"/"+constants.GithubRateLimitsFilename+"\n")
+	ctx.steps = append(ctx.steps, "            "+constants.TmpGhAwDirExpr+"/base\n")
 	engineID := resolveActivationEngineID(ctx.data)
 	// Include the engine-specific sub-agent staging directory only when inline agents are enabled.
 	if isFeatureEnabled(constants.FeatureFlag("inline-agents"), ctx.data) {
 		subAgentDir := GetEngineSubAgentDir(engineID)
-		ctx.steps = append(ctx.steps, fmt.Sprintf("            /tmp/gh-aw/%s\n", subAgentDir))
+		ctx.steps = append(ctx.steps, fmt.Sprintf("            "+constants.TmpGhAwDirExpr+"/%s\n", subAgentDir))
 	}
 	// Always include the engine-specific skill directory when either inline skills are enabled
 	// or frontmatter skills are configured (via Skills or SkillReferences).
 	if isFeatureEnabled(constants.FeatureFlag("inline-agents"), ctx.data) || len(ctx.data.Skills) > 0 || len(ctx.data.SkillReferences) > 0 {
 		skillDir := GetEngineSkillDir(engineID)
-		ctx.steps = append(ctx.steps, fmt.Sprintf("            /tmp/gh-aw/%s\n", skillDir))
+		ctx.steps = append(ctx.steps, fmt.Sprintf("            "+constants.TmpGhAwDirExpr+"/%s\n", skillDir))
 	}
 	ctx.steps = append(ctx.steps, "          if-no-files-found: ignore\n")
 	ctx.steps = append(ctx.steps, "          retention-days: 1\n")
@@ -45,6 +45,19 @@ func (c *Compiler) addActivationSecretValidationStep(ctx *activationJobBuildCont
 	compilerActivationJobLog.Printf("Added validate-secret step to activation job")
 }
 
+// addActivationRequiredSecretsStep adds a step that fails the activation job with a list of
+// missing secrets when any secret declared in required-secrets is not set.
+func (c *Compiler) addActivationRequiredSecretsStep(ctx *activationJobBuildContext) {
+	requiredSecretsStep := buildRequiredSecretsValidationStep(ctx.data)
+	if len(requiredSecretsStep) == 0 {
+		return
+	}
+	for _, line := range requiredSecretsStep {
+		ctx.steps = append(ctx.steps, line+"\n")
+	}
+	compilerActivationJobLog.Printf("Added validate-required-secrets step to activation job")
+}
+
 // addActivationOAuthTokenCheckStep adds a step to the activation job that checks
 // COPILOT_GITHUB_TOKEN, GH_AW_GITHUB_TOKEN, and GH_AW_GITHUB_MCP_SERVER_TOKEN are not
 // OAuth tokens. OAuth tokens (gho_...) are not suitable for automation as they are
@@ -113,6 +126,7 @@ func (c *Compiler) addActivationRepositoryAndOutputSteps(ctx *activationJobBuild
 	if err := c.addActivationTextOutputStep(ctx); err != nil {
 		return err
 	}
+	c.addActivationBackportTargetsStep(ctx)
 	if err := c.addActivationStatusCommentStep(ctx); err != nil {
 		return err
 	}
@@ -269,6 +283,12 @@ func (c *Compiler) addActivationTextOutputStep(ctx *activationJobBuildContext) e
 	ctx.outputs["text"] = "${{ steps.sanitized.outputs.text }}"
 	ctx.outputs["title"] = "${{ steps.sanitized.outputs.title }}"
 	ctx.outputs["body"] = "${{ steps.sanitized.outputs.body }}"
+	ctx.outputs["discussion_number"] = "${{ steps.sanitized.outputs.discussion_number }}"
+	ctx.outputs["discussion_category"] = "${{ steps.sanitized.outputs.discussion_category }}"
+	ctx.outputs["discussion_body"] = "${{ steps.sanitized.outputs.discussion_body }}"
+	if ctx.data.Privacy.pseudonymizes() {
+		ctx.outputs[privacySaltOutput] = "${{ steps.sanitized.outputs." + privacySaltOutput + " }}"
+	}
 	return nil
 }
 
@@ -287,6 +307,9 @@ func buildActivationTextOutputEnvLines(data *WorkflowData, domainsStr string) []
 	if domainsStr != "" {
 		envLines = append(envLines, formatYAMLEnv("          ", "GH_AW_ALLOWED_DOMAINS", domainsStr))
 	}
+	if data.Privacy.pseudonymizes() {
+		envLines = append(envLines, "          GH_AW_PRIVACY_PSEUDONYMIZE: \"true\"\n")
+	}
 	return envLines
 }
 
@@ -282,7 +282,7 @@ This workflow should generate a unified artifact upload step that includes the p
 	}
 
 	// Verify the prompt path is included in the unified upload
-	if !strings.Contains(lockYAML, "/tmp/gh-aw/aw-prompts/prompt.txt") {
+	if !strings.Contains(lockYAML, "${{ env.GH_AW_TMP_DIR }}/aw-prompts/prompt.txt") {
 		t.Error("Expected prompt path '/tmp/gh-aw/aw-prompts/prompt.txt' to be in unified upload")
 	}
 
@@ -373,10 +373,10 @@ Push some changes.
 	}
 	uploadSection := afterUpload[:30+nextStep]
 
-	if !strings.Contains(uploadSection, "/tmp/gh-aw/aw-*.patch") {
+	if !strings.Contains(uploadSection, "${{ env.GH_AW_TMP_DIR }}/aw-*.patch") {
 		t.Error("Expected '/tmp/gh-aw/aw-*.patch' in unified artifact upload when threat detection is enabled with staged push-to-pull-request-branch")
 	}
-	if !strings.Contains(uploadSection, "/tmp/gh-aw/aw-*.bundle") {
+	if !strings.Contains(uploadSection, "${{ env.GH_AW_TMP_DIR }}/aw-*.bundle") {
 		t.Error("Expected '/tmp/gh-aw/aw-*.bundle' in unified artifact upload when threat detection is enabled with staged push-to-pull-request-branch")
 	}
 }
@@ -232,9 +232,9 @@ Test workflow with script mode.
 		t.Error("Expected 'repository: github/gh-aw' in checkout step for script mode")
 	}
 
-	// 2. Checkout should target path: /tmp/gh-aw/actions-source
-	if !strings.Contains(lockStr, "path: /tmp/gh-aw/actions-source") {
-		t.Error("Expected 'path: /tmp/gh-aw/actions-source' in checkout step for script mode")
+	// 2. Checkout should target path: ${{ runner.temp }}/gh-aw/actions-source
+	if !strings.Contains(lockStr, "path: ${{ runner.temp }}/gh-aw/actions-source") {
+		t.Error("Expected 'path: ${{ runner.temp }}/gh-aw/actions-source' in checkout step for script mode")
 	}
 
 	// 3. Checkout should use shallow clone (fetch-depth: 1)
@@ -243,7 +243,7 @@ Test workflow with script mode.
 	}
 
 	// 4. Setup step should run bash script instead of using "uses:"
-	if !strings.Contains(lockStr, "bash /tmp/gh-aw/actions-source/actions/setup/setup.sh") {
+	if !strings.Contains(lockStr, `bash "${RUNNER_TEMP}/gh-aw/actions-source/actions/setup/setup.sh"`) {
 		t.Error("Expected setup script to run bash directly in script mode")
 	}
 
@@ -269,7 +269,7 @@ Test workflow with script mode.
 	}
 
 	// 9. Cleanup step should be generated for script mode (mirrors post.js)
-	if !strings.Contains(lockStr, "bash /tmp/gh-aw/actions-source/actions/setup/clean.sh") {
+	if !strings.Contains(lockStr, `bash "${RUNNER_TEMP}/gh-aw/actions-source/actions/setup/clean.sh"`) {
 		t.Error("Expected 'Clean Scripts' step with clean.sh in script mode")
 	}
 
@@ -50,31 +50,19 @@ func extractRestoreMemoryConfig(configMap map[string]any, jobName string, data *
 	return cfg, nil
 }
 
-// buildRestoreMemorySteps returns two slices:
-//   - setupLines: gh-aw checkout + setup steps (only when repo-memory or comment-memory
-//     is requested, since those need scripts from the setup action)
-//   - memoryLines: the actual memory restore/clone/prepare steps
+// buildRestoreMemorySteps returns the memory restore/clone/prepare steps of a custom job.
+// The memory directories live in the scratch directory of the job, so the caller injects
+// the gh-aw setup step (see buildCustomJobSetupSteps) before these steps.
 //
 // No write-back steps are ever emitted; all injected steps are read-only.
-func (c *Compiler) buildRestoreMemorySteps(cfg *restoreMemoryConfig, jobName string, data *WorkflowData) (setupLines []string, memoryLines []string, err error) {
+func (c *Compiler) buildRestoreMemorySteps(cfg *restoreMemoryConfig, jobName string, data *WorkflowData) []string {
 	if cfg == nil {
-		return nil, nil, nil
+		return nil
 	}
 
 	customJobMemoryLog.Printf("Building restore-memory steps for job %s", jobName)
 
-	// repo-memory and comment-memory rely on scripts installed by the gh-aw setup action.
-	// Inject the setup step before any memory steps so those scripts are available.
-	if cfg.RepoMemory || cfg.CommentMemory {
-		setupActionRef := c.resolveActionReference("./actions/setup", data)
-		if setupActionRef == "" && !c.actionMode.IsScript() {
-			return nil, nil, fmt.Errorf("jobs.%s.restore-memory: repo-memory/comment-memory require the setup action but no action ref was found", jobName)
-		}
-		setupLines = append(setupLines, c.generateCheckoutActionsFolder(data)...)
-		// Pass empty trace IDs — custom jobs do not inherit the activation span.
-		setupLines = append(setupLines, c.generateSetupStep(data, setupActionRef, SetupActionDestination, false, "", "")...)
-	}
-
+	var memoryLines []string
 	if cfg.CacheMemory {
 		memoryLines = append(memoryLines, generateCacheMemoryRestoreLines(data)...)
 	}
@@ -88,7 +76,7 @@ func (c *Compiler) buildRestoreMemorySteps(cfg *restoreMemoryConfig, jobName str
 		}
 	}
 
-	return setupLines, memoryLines, nil
+	return memoryLines
 }
 
 // generateCacheMemoryRestoreLines produces read-only cache-memory restore steps for a
@@ -127,7 +115,7 @@ func generateCacheMemoryRestoreLines(data *WorkflowData) []string {
 		lines = append(lines, fmt.Sprintf("        uses: %s\n", getActionPin("actions/cache/restore")))
 		lines = append(lines, "        with:\n")
 		lines = append(lines, fmt.Sprintf("          key: %s\n", cacheKey))
-		lines = append(lines, fmt.Sprintf("          path: %s\n", cacheDir))
+		lines = append(lines, fmt.Sprintf("          path: %s\n", cacheStagingPath(cacheDir)))
 
 		// Build restore keys using the shared helper (same semantics as the agent job).
 		// Only emit the restore-keys block when there is at least one key; an empty
@@ -138,6 +126,10 @@ func generateCacheMemoryRestoreLines(data *WorkflowData) []string {
 				lines = append(lines, fmt.Sprintf("            %s\n", key))
 			}
 		}
+
+		// Copy the restored entry from the fixed cache path into the scratch directory of the job.
+		copyStepName := fmt.Sprintf("Copy restored cache-memory into the scratch directory (%s)", cache.ID)
+		lines = append(lines, buildCacheStagingDirCopySteps(copyStepName, "", cacheStagingPath(cacheDir), cacheDir)...)
 	}
 
 	return lines
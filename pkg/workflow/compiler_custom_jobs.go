@@ -417,7 +417,7 @@ func extractCustomJobOutputs(job *Job, jobName string, configMap map[string]any)
 		if valStr, ok := val.(string); ok {
 			job.Outputs[key] = valStr
 		} else {
-			compilerJobsLog.Printf("Warning: output '%s' in job '%s' has non-string value (type: %T), ignoring", key, jobName, val)
+			compilerJobsLog.Warnf("output '%s' in job '%s' has non-string value (type: %T), ignoring", key, jobName, val)
 		}
 	}
 }
@@ -541,17 +541,18 @@ func (c *Compiler) configureCustomJobSteps(job *Job, jobName string, configMap m
 		// know which host to target without this step.
 		job.Steps = append(job.Steps, generateGHESHostConfigurationStep())
 
-		// Inject gh-aw setup + memory restore steps when restore-memory is requested.
-		// Setup lines come first (they install scripts needed by repo/comment memory).
+		// Inject the gh-aw setup step when the job restores memory or its own steps use
+		// the scratch directory of the job: setup.sh creates the directory and exports it
+		// as GH_AW_TMP_DIR, and installs the scripts needed by repo/comment memory.
 		// Memory lines follow immediately after (restore/clone/prepare steps).
-		if hasRestoreMemory {
-			memorySetupLines, memoryRestoreLines, memErr := c.buildRestoreMemorySteps(restoreMemCfg, jobName, data)
-			if memErr != nil {
-				return memErr
+		if hasRestoreMemory || customJobStepsUseTmpDir(setupSteps, preSteps, regularSteps) {
+			setupActionLines, setupErr := c.buildCustomJobSetupSteps(jobName, data)
+			if setupErr != nil {
+				return setupErr
 			}
-			job.Steps = append(job.Steps, memorySetupLines...)
-			job.Steps = append(job.Steps, memoryRestoreLines...)
+			job.Steps = append(job.Steps, setupActionLines...)
 		}
+		job.Steps = append(job.Steps, c.buildRestoreMemorySteps(restoreMemCfg, jobName, data)...)
 
 		job.Steps = append(job.Steps, preSteps...)
 		job.Steps = append(job.Steps, regularSteps...)
@@ -560,6 +561,32 @@ func (c *Compiler) configureCustomJobSteps(job *Job, jobName string, configMap m
 	return nil
 }
 
+// buildCustomJobSetupSteps returns the gh-aw checkout and setup steps for a custom job.
+func (c *Compiler) buildCustomJobSetupSteps(jobName string, data *WorkflowData) ([]string, error) {
+	setupActionRef := c.resolveActionReference("./actions/setup", data)
+	if setupActionRef == "" && !c.actionMode.IsScript() {
+		return nil, fmt.Errorf("jobs.%s: the scratch directory of the job requires the setup action but no action ref was found", jobName)
+	}
+	var lines []string
+	lines = append(lines, c.generateCheckoutActionsFolder(data)...)
+	// Pass empty trace IDs — custom jobs do not inherit the activation span.
+	lines = append(lines, c.generateSetupStep(data, setupActionRef, SetupActionDestination, false, "", "")...)
+	return lines, nil
+}
+
+// customJobStepsUseTmpDir reports whether any of the given custom job steps refers to the
+// scratch directory of the job through GH_AW_TMP_DIR.
+func customJobStepsUseTmpDir(stepLists ...[]string) bool {
+	for _, steps := range stepLists {
+		for _, step := range steps {
+			if strings.Contains(step, constants.TmpGhAwDirEnvVar) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func formatIndentedYAMLField(fieldName string, value any, trimTrailingNewline bool) (string, error) {
 	yamlBytes, err := yaml.Marshal(value)
 	if err != nil {
@@ -322,6 +322,51 @@ func TestConfigureCustomJobSteps_AllThreeStepTypes(t *testing.T) {
 	assert.Contains(t, all, "echo regular")
 }
 
+func TestConfigureCustomJobSteps_ScratchDirInjectsSetup(t *testing.T) {
+	tests := []struct {
+		name      string
+		step      map[string]any
+		wantSetup bool
+	}{
+		{
+			name:      "shell reference to the scratch directory",
+			step:      map[string]any{"name": "R", "run": "cat \"${GH_AW_TMP_DIR}/agent_usage.json\""},
+			wantSetup: true,
+		},
+		{
+			name:      "expression reference to the scratch directory",
+			step:      map[string]any{"name": "D", "uses": "actions/download-artifact@v8", "with": map[string]any{"path": "${{ env.GH_AW_TMP_DIR }}/"}},
+			wantSetup: true,
+		},
+		{
+			name:      "no reference to the scratch directory",
+			step:      map[string]any{"name": "R", "run": "echo regular"},
+			wantSetup: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compiler := NewCompiler()
+			data := &WorkflowData{Name: "Test"}
+			job := &Job{Name: "my-job"}
+			configMap := map[string]any{"steps": []any{tt.step}}
+
+			err := compiler.configureCustomJobSteps(job, "my-job", configMap, data)
+
+			require.NoError(t, err)
+			all := strings.Join(job.Steps, "")
+			if tt.wantSetup {
+				assert.Contains(t, all, "id: setup", "setup step should export GH_AW_TMP_DIR for the job")
+				assert.Less(t, strings.Index(all, "id: setup"), strings.Index(all, "name: "+tt.step["name"].(string)+"\n"),
+					"setup step should run before the steps of the job")
+			} else {
+				assert.NotContains(t, all, "id: setup", "setup step should not be injected")
+			}
+		})
+	}
+}
+
 func TestConfigureCustomJobSteps_InvalidStepsType(t *testing.T) {
 	compiler := NewCompiler()
 	data := &WorkflowData{Name: "Test"}
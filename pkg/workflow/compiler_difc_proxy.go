@@ -202,7 +202,7 @@ func getDIFCProxyPolicyJSON(githubTool map[string]any, data *WorkflowData, gatew
 
 	jsonBytes, err := json.Marshal(guardPolicy)
 	if err != nil {
-		difcProxyLog.Printf("Failed to marshal DIFC proxy policy: %v", err)
+		difcProxyLog.Warnf("Failed to marshal DIFC proxy policy: %v", err)
 		return ""
 	}
 
@@ -529,7 +529,7 @@ func buildCLIProxyPolicyJSON(githubToolConfig map[string]any, data *WorkflowData
 
 	var policy map[string]any
 	if err := json.Unmarshal([]byte(policyJSON), &policy); err != nil {
-		difcProxyLog.Printf("Failed to unmarshal CLI proxy policy JSON for runtime defaults: %v", err)
+		difcProxyLog.Warnf("Failed to unmarshal CLI proxy policy JSON for runtime defaults: %v", err)
 		return policyJSON
 	}
 	allowOnly, ok := policy["allow-only"].(map[string]any)
@@ -548,7 +548,7 @@ func buildCLIProxyPolicyJSON(githubToolConfig map[string]any, data *WorkflowData
 
 	jsonBytes, err := json.Marshal(policy)
 	if err != nil {
-		difcProxyLog.Printf("Failed to marshal CLI proxy policy JSON with runtime defaults: %v", err)
+		difcProxyLog.Warnf("Failed to marshal CLI proxy policy JSON with runtime defaults: %v", err)
 		return policyJSON
 	}
 	return string(jsonBytes)
@@ -490,7 +490,7 @@ Test that DIFC proxy is injected by default when min-integrity is set with custo
 		"custom step should have GITHUB_API_URL in step-level env")
 	assert.Contains(t, result, "GITHUB_GRAPHQL_URL: https://localhost:18443/api/graphql",
 		"custom step should have GITHUB_GRAPHQL_URL in step-level env")
-	assert.Contains(t, result, "NODE_EXTRA_CA_CERTS: /tmp/gh-aw/proxy-logs/proxy-tls/ca.crt",
+	assert.Contains(t, result, "NODE_EXTRA_CA_CERTS: ${{ env.GH_AW_TMP_DIR }}/proxy-logs/proxy-tls/ca.crt",
 		"custom step should have NODE_EXTRA_CA_CERTS in step-level env")
 
 	// Verify step ordering: Start proxy must come before Stop proxy
@@ -690,7 +690,7 @@ func TestProxyEnvVars(t *testing.T) {
 	assert.Equal(t, "${{ github.repository }}", vars["GH_REPO"], "GH_REPO should reference github.repository")
 	assert.Equal(t, "https://localhost:18443/api/v3", vars["GITHUB_API_URL"], "GITHUB_API_URL should point to proxy")
 	assert.Equal(t, "https://localhost:18443/api/graphql", vars["GITHUB_GRAPHQL_URL"], "GITHUB_GRAPHQL_URL should point to proxy")
-	assert.Equal(t, "/tmp/gh-aw/proxy-logs/proxy-tls/ca.crt", vars["NODE_EXTRA_CA_CERTS"], "NODE_EXTRA_CA_CERTS should be the proxy CA cert")
+	assert.Equal(t, "${{ env.GH_AW_TMP_DIR }}/proxy-logs/proxy-tls/ca.crt", vars["NODE_EXTRA_CA_CERTS"], "NODE_EXTRA_CA_CERTS should be the proxy CA cert")
 	assert.Len(t, vars, 5, "proxyEnvVars should return exactly 5 vars")
 }
 
@@ -717,7 +717,7 @@ func TestInjectProxyEnvIntoCustomSteps(t *testing.T) {
 				"GH_REPO: ${{ github.repository }}",
 				"GITHUB_API_URL: https://localhost:18443/api/v3",
 				"GITHUB_GRAPHQL_URL: https://localhost:18443/api/graphql",
-				"NODE_EXTRA_CA_CERTS: /tmp/gh-aw/proxy-logs/proxy-tls/ca.crt",
+				"NODE_EXTRA_CA_CERTS: ${{ env.GH_AW_TMP_DIR }}/proxy-logs/proxy-tls/ca.crt",
 			},
 			desc: "step without env should get proxy env block added",
 		},
@@ -730,7 +730,7 @@ func TestInjectProxyEnvIntoCustomSteps(t *testing.T) {
 				"GH_REPO: ${{ github.repository }}",
 				"GITHUB_API_URL: https://localhost:18443/api/v3",
 				"GITHUB_GRAPHQL_URL: https://localhost:18443/api/graphql",
-				"NODE_EXTRA_CA_CERTS: /tmp/gh-aw/proxy-logs/proxy-tls/ca.crt",
+				"NODE_EXTRA_CA_CERTS: ${{ env.GH_AW_TMP_DIR }}/proxy-logs/proxy-tls/ca.crt",
 			},
 			desc: "existing env var GH_TOKEN should be preserved alongside proxy vars",
 		},
@@ -826,7 +826,7 @@ func TestInjectProxyEnvIntoCustomSteps(t *testing.T) {
 				"GITHUB_API_URL: https://localhost:18443/api/v3",
 				"GH_REPO: ${{ github.repository }}",
 				"GITHUB_GRAPHQL_URL: https://localhost:18443/api/graphql",
-				"NODE_EXTRA_CA_CERTS: /tmp/gh-aw/proxy-logs/proxy-tls/ca.crt",
+				"NODE_EXTRA_CA_CERTS: ${{ env.GH_AW_TMP_DIR }}/proxy-logs/proxy-tls/ca.crt",
 			},
 			expectedAbsent: []string{
 				"GH_HOST: example.com",
@@ -0,0 +1,65 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/stringutil"
+)
+
+var compilerDisableExpiredJobLog = logger.New("workflow:compiler_disable_expired_job")
+
+// buildDisableExpiredJob creates the guard job that disables the workflow once its
+// on.stop-after time has passed. The pre-activation stop-time check only skips runs,
+// so without this job an expired workflow would keep being scheduled (and skipped) forever.
+// The job runs only when the pre-activation job reports stop_time_ok == 'false'.
+func (c *Compiler) buildDisableExpiredJob(data *WorkflowData, lockFilename string) (*Job, error) {
+	compilerDisableExpiredJobLog.Printf("Building disable_expired job: stop_time=%s, lock_file=%s", data.StopTime, lockFilename)
+
+	setupActionRef := c.resolveActionReference("./actions/setup", data)
+	if setupActionRef == "" && !c.actionMode.IsScript() {
+		return nil, errors.New("setup action reference is required but could not be resolved")
+	}
+
+	var steps []string
+	steps = append(steps, c.generateCheckoutActionsFolder(data)...)
+	traceID := fmt.Sprintf("${{ needs.%s.outputs.setup-trace-id }}", constants.PreActivationJobName)
+	steps = append(steps, c.generateSetupStep(data, setupActionRef, SetupActionDestination, false, traceID, setupParentSpanNeedsExpr(constants.PreActivationJobName))...)
+
+	steps = append(steps, "      - name: Disable expired workflow\n")
+	steps = append(steps, fmt.Sprintf("        uses: %s\n", getCachedActionPin("actions/github-script", data)))
+	steps = append(steps, "        env:\n")
+	steps = append(steps, fmt.Sprintf("          GH_AW_WORKFLOW_FILE: %q\n", lockFilename))
+	steps = append(steps, fmt.Sprintf("          GH_AW_STOP_TIME: %q\n", stringutil.StripANSI(data.StopTime)))
+	steps = append(steps, "        with:\n")
+	steps = append(steps, "          script: |\n")
+	steps = append(steps, generateGitHubScriptWithRequire("disable_expired_workflow.cjs"))
+
+	if c.actionMode.IsScript() {
+		steps = append(steps, c.generateScriptModeCleanupStep())
+	}
+
+	stopTimeReached := BuildEquals(
+		BuildPropertyAccess(fmt.Sprintf("needs.%s.outputs.%s", constants.PreActivationJobName, constants.StopTimeOkOutput)),
+		BuildStringLiteral("false"),
+	)
+
+	// actions: write is required to disable the workflow; contents: read only for dev-mode checkout.
+	perms := NewPermissions()
+	if (c.actionMode.IsDev() || c.actionMode.IsScript()) && len(c.generateCheckoutActionsFolder(data)) > 0 {
+		perms = NewPermissionsContentsRead()
+	}
+	perms.Set(PermissionActions, PermissionWrite)
+
+	return &Job{
+		Name:           string(constants.DisableExpiredJobName),
+		Needs:          []string{string(constants.PreActivationJobName)},
+		If:             RenderCondition(stopTimeReached),
+		RunsOn:         c.formatFrameworkJobRunsOn(data),
+		Permissions:    perms.RenderToYAML(),
+		Steps:          steps,
+		TimeoutMinutes: 5,
+	}, nil
+}
@@ -0,0 +1,69 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDisableExpiredJob(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "disable-expired-job-test")
+
+	t.Run("created with stop-after", func(t *testing.T) {
+		workflowFile := filepath.Join(tmpDir, "trial.md")
+		require.NoError(t, os.WriteFile(workflowFile, []byte(`---
+on:
+  workflow_dispatch:
+  stop-after: "+7d"
+engine: claude
+---
+
+# Trial
+`), 0644), "failed to write workflow")
+
+		require.NoError(t, NewCompiler().CompileWorkflow(workflowFile), "workflow should compile")
+		lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(workflowFile))
+		require.NoError(t, err, "failed to read lock file")
+		lock := string(lockContent)
+
+		jobStart := strings.Index(lock, "\n  disable_expired:\n")
+		require.NotEqual(t, -1, jobStart, "disable_expired job should be generated")
+		job := lock[jobStart:]
+		if next := strings.Index(job[1:], "\n  pre_activation:\n"); next != -1 {
+			job = job[:next+1]
+		}
+
+		assert.Contains(t, job, "needs: pre_activation", "job should depend on the stop-time check")
+		assert.Contains(t, job, "if: needs.pre_activation.outputs.stop_time_ok == 'false'", "job should only run once the stop time is reached")
+		assert.Contains(t, job, "actions: write", "job needs actions: write to disable the workflow")
+		assert.Contains(t, job, `GH_AW_WORKFLOW_FILE: "trial.lock.yml"`, "job should disable this workflow's lock file")
+		assert.Contains(t, job, "disable_expired_workflow.cjs", "job should run the disable script")
+		assert.Contains(t, lock, "stop_time_ok: ${{ steps.check_stop_time.outputs.stop_time_ok }}", "pre_activation should expose the stop-time result")
+	})
+
+	t.Run("not created without stop-after", func(t *testing.T) {
+		workflowFile := filepath.Join(tmpDir, "no-stop.md")
+		require.NoError(t, os.WriteFile(workflowFile, []byte(`---
+on:
+  workflow_dispatch:
+engine: claude
+---
+
+# No stop
+`), 0644), "failed to write workflow")
+
+		require.NoError(t, NewCompiler().CompileWorkflow(workflowFile), "workflow should compile")
+		lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(workflowFile))
+		require.NoError(t, err, "failed to read lock file")
+
+		assert.NotContains(t, string(lockContent), "disable_expired:", "disable_expired job should only exist with stop-after")
+	})
+}
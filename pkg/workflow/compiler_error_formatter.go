@@ -2,6 +2,7 @@ package workflow
 
 import (
 	"errors"
+	"strings"
 
 	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/logger"
@@ -49,6 +50,67 @@ func formatCompilerError(filePath string, errType string, message string, cause
 	return formatCompilerErrorWithPosition(filePath, line, column, errType, message, cause)
 }
 
+// locateFrontmatterField fills in the source position of a *WorkflowValidationError from its
+// field path (e.g. "safe-outputs.create-issue.max"), so that the "file:line:col: error:" prefix
+// points at the offending key instead of line 1.
+// Errors that already carry a position, or whose field is not in the frontmatter, are returned unchanged.
+func locateFrontmatterField(err error, workflowData *WorkflowData, markdownPath string) error {
+	var vErr *WorkflowValidationError
+	if !errors.As(err, &vErr) || vErr.Line > 0 || vErr.Field == "" {
+		return err
+	}
+	if line, column := locateSourceField(workflowData, vErr.Field); line > 0 {
+		compilerErrorLog.Printf("Located validation error for field %s at %d:%d", vErr.Field, line, column)
+		vErr.File = markdownPath
+		vErr.Line = line
+		vErr.Column = column
+	}
+	return err
+}
+
+// locateSourceField returns the position of a dotted frontmatter field path in the workflow source.
+// The nested key is located in the raw frontmatter when possible; otherwise the line of the
+// top-level key is used. It returns 0, 0 when the field is not in the source frontmatter
+// (for example, when it comes from an import).
+func locateSourceField(workflowData *WorkflowData, field string) (int, int) {
+	if workflowData == nil || field == "" {
+		return 0, 0
+	}
+	if workflowData.FrontmatterStartLine > 0 && workflowData.FrontmatterYAML != "" {
+		frontmatterLines := strings.Split(workflowData.FrontmatterYAML, "\n")
+		if line, column := parser.LocateFrontmatterPath(frontmatterLines, workflowData.FrontmatterStartLine, field); line > 0 {
+			return line, column
+		}
+	}
+	key := field
+	if idx := strings.IndexAny(key, ".["); idx >= 0 {
+		key = key[:idx]
+	}
+	if line, ok := workflowData.FrontmatterFieldLines[key]; ok && line > 0 {
+		return line, 1
+	}
+	return 0, 0
+}
+
+// locateSourceMarkdown returns the position of the first occurrence of text in the workflow's
+// own markdown body, or 0, 0 when the text is not there (for example, when it comes from an import).
+func locateSourceMarkdown(workflowData *WorkflowData, text string) (int, int) {
+	if workflowData == nil {
+		return 0, 0
+	}
+	return parser.LocateMarkdownText(workflowData.RawMarkdown, workflowData.MarkdownStartLine, text)
+}
+
+// formatCompilerErrorForField creates a formatted compiler error positioned at a frontmatter
+// field (e.g. "on.pull_request_target"). When the field is not in the source frontmatter,
+// it behaves like formatCompilerError.
+func formatCompilerErrorForField(workflowData *WorkflowData, filePath string, field string, errType string, message string, cause error) error {
+	if line, column := locateSourceField(workflowData, field); line > 0 {
+		return formatCompilerErrorWithPosition(filePath, line, column, errType, message, cause)
+	}
+	return formatCompilerError(filePath, errType, message, cause)
+}
+
 // isFormattedCompilerError reports whether err is already a console-formatted compiler error
 // produced by formatCompilerError, formatCompilerErrorWithPosition, or parser.FormatImportError.
 // Use this instead of fragile string-contains checks to avoid double-wrapping.
@@ -115,18 +177,31 @@ func formatCompilerErrorWithContext(filePath string, line int, column int, errTy
 	return &wrappedCompilerError{formatted: formattedErr, cause: cause}
 }
 
-// formatCompilerMessage creates a formatted compiler message string (for warnings printed to stderr)
-// filePath: the file path to include in the message (typically markdownPath or lockFile)
-// msgType: the message type ("error" or "warning")
-// message: the message text
-func formatCompilerMessage(filePath string, msgType string, message string) string {
+// formatCompilerMessageAt creates a formatted compiler message string at a specific line/column.
+// A line of 0 omits the position, like formatCompilerMessage.
+func formatCompilerMessageAt(filePath string, line int, column int, msgType string, message string) string {
 	return console.FormatError(console.CompilerError{
 		Position: console.ErrorPosition{
 			File:   filePath,
-			Line:   0,
-			Column: 0,
+			Line:   line,
+			Column: column,
 		},
 		Type:    msgType,
 		Message: message,
 	})
 }
+
+// formatCompilerMessageForField creates a formatted compiler message positioned at a frontmatter
+// field (e.g. "sandbox.agent"), falling back to the file alone when the field is not in the source.
+func formatCompilerMessageForField(workflowData *WorkflowData, filePath string, field string, msgType string, message string) string {
+	line, column := locateSourceField(workflowData, field)
+	return formatCompilerMessageAt(filePath, line, column, msgType, message)
+}
+
+// formatCompilerMessage creates a formatted compiler message string (for warnings printed to stderr)
+// filePath: the file path to include in the message (typically markdownPath or lockFile)
+// msgType: the message type ("error" or "warning")
+// message: the message text
+func formatCompilerMessage(filePath string, msgType string, message string) string {
+	return formatCompilerMessageAt(filePath, 0, 0, msgType, message)
+}
@@ -16,7 +16,7 @@ import (
 var experimentsLog = logger.New("workflow:compiler_experiments")
 
 // experimentsCacheDir is the runtime directory where the experiment state JSON is stored.
-const experimentsCacheDir = "/tmp/gh-aw/experiments"
+const experimentsCacheDir = constants.TmpGhAwDirExpr + "/experiments"
 
 // experimentStateFile is the path to the experiment state JSON written by pick_experiment.cjs.
 const experimentStateFile = experimentsCacheDir + "/state.json"
@@ -433,10 +433,12 @@ func (c *Compiler) generateExperimentCacheSteps(data *WorkflowData, experimentNa
 		"        with:\n",
 		fmt.Sprintf("          key: %s\n", cacheKey),
 		fmt.Sprintf("          restore-keys: %s\n", restoreKey),
-		fmt.Sprintf("          path: %s\n", experimentsCacheDir),
+		fmt.Sprintf("          path: %s\n", cacheStagingPath(experimentsCacheDir)),
 	)
+	steps = append(steps, buildCacheStagingDirCopySteps("Copy experiment state into the scratch directory", "", cacheStagingPath(experimentsCacheDir), experimentsCacheDir)...)
 
 	steps = append(steps, c.generatePickExperimentStep(data, experimentNames)...)
+	steps = append(steps, buildCacheStagingDirCopySteps("Copy experiment state to the cache path", "always()", experimentsCacheDir, cacheStagingPath(experimentsCacheDir))...)
 
 	// ── Step 3: Save experiment cache ─────────────────────────────────────────
 	steps = append(steps,
@@ -445,7 +447,7 @@ func (c *Compiler) generateExperimentCacheSteps(data *WorkflowData, experimentNa
 		fmt.Sprintf("        uses: %s\n", getActionPin("actions/cache/save")),
 		"        with:\n",
 		fmt.Sprintf("          key: %s\n", cacheKey),
-		fmt.Sprintf("          path: %s\n", experimentsCacheDir),
+		fmt.Sprintf("          path: %s\n", cacheStagingPath(experimentsCacheDir)),
 	)
 
 	steps = append(steps, c.generateExperimentArtifactUploadStep(data, sanitizedID)...)
@@ -41,7 +41,7 @@ func (c *Compiler) generateGitHubMCPLockdownDetectionStep(yaml *strings.Builder,
 	actionVersion := string(constants.DefaultGitHubScriptVersion)
 	pinnedAction, err := getActionPinWithData(actionRepo, actionVersion, data)
 	if err != nil {
-		githubConfigLog.Printf("Failed to resolve %s@%s: %v", actionRepo, actionVersion, err)
+		githubConfigLog.Warnf("Failed to resolve %s@%s: %v", actionRepo, actionVersion, err)
 		// In strict mode, this error would have been returned by getActionPinWithData
 		// In normal mode, we fall back to using the version tag without pinning
 		pinnedAction = fmt.Sprintf("%s@%s", actionRepo, actionVersion)
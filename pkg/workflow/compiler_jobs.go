@@ -197,11 +197,16 @@ func (c *Compiler) buildJobs(data *WorkflowData, markdownPath string) error {
 	}
 
 	// Build pre-activation and activation jobs
-	_, activationJobCreated, err := c.buildPreActivationAndActivationJobs(data, frontmatter, lockFilename)
+	preActivationJobCreated, activationJobCreated, err := c.buildPreActivationAndActivationJobs(data, frontmatter, lockFilename)
 	if err != nil {
 		return err
 	}
 
+	// A fan-out workflow only dispatches its items; the agent runs in the companion workflow
+	if data.FanOut != nil {
+		return c.buildFanOutJobs(data, preActivationJobCreated)
+	}
+
 	// Build main workflow job
 	if err := c.buildMainJobWrapper(data, activationJobCreated); err != nil {
 		return err
@@ -221,6 +226,15 @@ func (c *Compiler) buildJobs(data *WorkflowData, markdownPath string) error {
 		}
 	}
 
+	// Build the preview job of a safe-output preview companion
+	if previewJob, err := c.buildSafeOutputPreviewJob(data); err != nil {
+		return fmt.Errorf("failed to build preview job: %w", err)
+	} else if previewJob != nil {
+		if err := c.jobManager.AddJob(previewJob); err != nil {
+			return fmt.Errorf("failed to add preview job: %w", err)
+		}
+	}
+
 	// Apply jobs.<builtin-job>.pre-steps customizations to already-created built-in jobs
 	// before processing non-built-in custom jobs.
 	if err := c.applyBuiltinJobPreSteps(data); err != nil {
@@ -273,13 +287,17 @@ func (c *Compiler) buildPreActivationAndActivationJobs(data *WorkflowData, front
 	hasOnSteps := len(data.OnSteps) > 0
 	hasOnNeeds := len(data.OnNeeds) > 0
 	hasLabelNames := len(data.LabelNames) > 0
-	compilerJobsLog.Printf("Job configuration: needsPermissionCheck=%v, hasStopTime=%v, hasSkipIfMatch=%v, hasSkipIfNoMatch=%v, hasSkipRoles=%v, hasSkipBots=%v, hasSkipAuthorAssociations=%v, hasCommand=%v, hasRateLimit=%v, hasOnSteps=%v, hasOnNeeds=%v, hasLabelNames=%v", needsPermissionCheck, hasStopTime, hasSkipIfMatch, hasSkipIfNoMatch, hasSkipRoles, hasSkipBots, hasSkipAuthorAssociations, hasCommandTrigger, hasRateLimit, hasOnSteps, hasOnNeeds, hasLabelNames)
+	hasReactionCommand := data.ReactionCommand != nil
+	hasScheduleOptions := data.ScheduleJitterSeconds > 0 || len(scheduleTimezoneGuards(data.ScheduleTimezoneChecks, data.ScheduleUnguardedCrons)) > 0
+	hasTriggerFilters := data.TriggerFilters.needsPreActivation()
+	hasFollowUpPolling := data.FollowUpPolling
+	compilerJobsLog.Printf("Job configuration: needsPermissionCheck=%v, hasStopTime=%v, hasSkipIfMatch=%v, hasSkipIfNoMatch=%v, hasSkipRoles=%v, hasSkipBots=%v, hasSkipAuthorAssociations=%v, hasCommand=%v, hasRateLimit=%v, hasOnSteps=%v, hasOnNeeds=%v, hasLabelNames=%v, hasReactionCommand=%v, hasScheduleOptions=%v, hasTriggerFilters=%v, hasFollowUpPolling=%v", needsPermissionCheck, hasStopTime, hasSkipIfMatch, hasSkipIfNoMatch, hasSkipRoles, hasSkipBots, hasSkipAuthorAssociations, hasCommandTrigger, hasRateLimit, hasOnSteps, hasOnNeeds, hasLabelNames, hasReactionCommand, hasScheduleOptions, hasTriggerFilters, hasFollowUpPolling)
 
 	// Build pre-activation job if needed. The job combines:
 	//   - membership checks, stop-time validation, skip-if-match/no-match checks
 	//   - skip-roles/bots checks, rate limit check, command position check
-	//   - on.steps injection, label-names filter
-	if needsPermissionCheck || hasStopTime || hasSkipIfMatch || hasSkipIfNoMatch || hasSkipRoles || hasSkipBots || hasSkipAuthorAssociations || hasCommandTrigger || hasRateLimit || hasOnSteps || hasOnNeeds || hasLabelNames {
+	//   - on.steps injection, label-names filter, reaction command and follow-up polling
+	if needsPermissionCheck || hasStopTime || hasSkipIfMatch || hasSkipIfNoMatch || hasSkipRoles || hasSkipBots || hasSkipAuthorAssociations || hasCommandTrigger || hasRateLimit || hasOnSteps || hasOnNeeds || hasLabelNames || hasReactionCommand || hasScheduleOptions || hasTriggerFilters || hasFollowUpPolling {
 		compilerJobsLog.Print("Building pre-activation job")
 		preActivationJob, err := c.buildPreActivationJob(data, needsPermissionCheck)
 		if err != nil {
@@ -292,6 +310,17 @@ func (c *Compiler) buildPreActivationAndActivationJobs(data *WorkflowData, front
 		preActivationJobCreated = true
 	}
 
+	// Build the guard job that disables the workflow once its stop-after time has passed.
+	if hasStopTime {
+		disableExpiredJob, err := c.buildDisableExpiredJob(data, lockFilename)
+		if err != nil {
+			return preActivationJobCreated, false, fmt.Errorf("failed to build %s job: %w", constants.DisableExpiredJobName, err)
+		}
+		if err := c.jobManager.AddJob(disableExpiredJob); err != nil {
+			return preActivationJobCreated, false, fmt.Errorf("failed to add %s job: %w", constants.DisableExpiredJobName, err)
+		}
+	}
+
 	// Determine if we need to add workflow_run repository safety check
 	var workflowRunRepoSafety string
 	if c.hasWorkflowRunTrigger(frontmatter) {
@@ -300,7 +329,7 @@ func (c *Compiler) buildPreActivationAndActivationJobs(data *WorkflowData, front
 	}
 
 	// Build activation job if needed (preamble job that handles runtime conditions)
-	if c.isActivationJobNeeded() {
+	if c.isActivationJobNeeded() && data.FanOut == nil {
 		compilerJobsLog.Print("Building activation job")
 		activationJob, err := c.buildActivationJob(data, preActivationJobCreated, workflowRunRepoSafety, lockFilename)
 		if err != nil {
@@ -255,13 +255,11 @@ func (c *Compiler) buildMainJobEnv(data *WorkflowData) map[string]string {
 		compilerMainJobLog.Printf("Configuring safe-outputs job env for main job (uploadAssets=%v)", data.SafeOutputs.UploadAssets != nil)
 		env = make(map[string]string)
 
-		// Set GH_AW_MCP_LOG_DIR for safe outputs MCP server logging
-		// Store in mcp-logs directory so it's included in mcp-logs artifact
-		env["GH_AW_MCP_LOG_DIR"] = constants.TmpMcpLogsSafeOutputsDir
-
 		// Note: GH_AW_SAFE_OUTPUTS, GH_AW_SAFE_OUTPUTS_CONFIG_PATH, and
 		// GH_AW_SAFE_OUTPUTS_TOOLS_PATH are set via a run step (see generateSetRuntimePathsStep)
 		// because the runner context is not available in job-level env: blocks.
+		// GH_AW_MCP_LOG_DIR is set on the MCP gateway step (see collectMCPEnvironmentVariables)
+		// because the job scratch directory is only known once the setup step has run.
 
 		// Add asset-related environment variables
 		// These must always be set (even to empty) because awmg v0.0.12+ validates ${VAR} references
@@ -300,7 +298,9 @@ func (c *Compiler) buildMainJobEnv(data *WorkflowData) map[string]string {
 		env["GH_AW_PROJECT_UTC"] = fmt.Sprintf("%q", utcOffset)
 	}
 
-	return env
+	// Export the corporate proxy (network.proxy) so setup, install, and MCP gateway
+	// steps reach the internet through it.
+	return withNetworkProxyEnv(env, data)
 }
 
 // buildMainJobPermissions builds the final permissions string for the main agent job.
@@ -309,6 +309,11 @@ func (c *Compiler) buildMainJobEnv(data *WorkflowData) map[string]string {
 func (c *Compiler) buildMainJobPermissions(data *WorkflowData) (string, error) {
 	permissions := augmentPermissionsForDevMode(c, data, filterJobLevelPermissions(data.Permissions, data.CachedPermissions))
 
+	// Downloading the upstream run's agent artifact for workflow_run chaining requires actions: read
+	if len(data.WorkflowRunUpstreams) > 0 && data.Permissions != "permissions: {}" {
+		permissions = mergeInferredIntoPermissionsYAML(permissions, map[PermissionScope]PermissionLevel{PermissionActions: PermissionRead})
+	}
+
 	agentAllScripts := collectAgentJobScripts(data)
 	if len(agentAllScripts) == 0 {
 		return permissions, nil
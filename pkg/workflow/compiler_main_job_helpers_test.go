@@ -211,7 +211,7 @@ func TestBuildMainJobEnv(t *testing.T) {
 		assert.Nil(t, env)
 	})
 
-	t.Run("safe outputs triggers env initialisation with MCP_LOG_DIR", func(t *testing.T) {
+	t.Run("safe outputs triggers env initialisation", func(t *testing.T) {
 		c := NewCompiler()
 		c.repoConfigLoaded = true
 		c.repoConfig = &RepoConfig{}
@@ -220,7 +220,7 @@ func TestBuildMainJobEnv(t *testing.T) {
 		}
 		env := c.buildMainJobEnv(data)
 		require.NotNil(t, env)
-		assert.Contains(t, env, "GH_AW_MCP_LOG_DIR")
+		assert.NotContains(t, env, "GH_AW_MCP_LOG_DIR", "the log directory depends on the job scratch directory and is set on the MCP gateway step")
 		assert.Contains(t, env, "DEFAULT_BRANCH")
 	})
 
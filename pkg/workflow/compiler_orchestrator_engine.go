@@ -37,6 +37,7 @@ type engineSetupResult struct {
 func (c *Compiler) setupEngineAndImports(result *parser.FrontmatterResult, cleanPath string, content []byte, markdownDir string) (*engineSetupResult, error) {
 	orchestratorEngineLog.Printf("Setting up engine and processing imports")
 	engineSetting, engineConfig, model := c.ExtractEngineConfig(result.Frontmatter)
+	c.reportAutoEngineSelection(engineConfig, cleanPath)
 	preservedMaxTurns, preservedMaxAICredits, preservedMaxRuns, preservedMaxTurnCacheMisses := extractEngineBudgetLimits(engineConfig)
 	if err := c.validateAndRegisterInlineEngineConfig(engineConfig); err != nil {
 		return nil, err
@@ -261,7 +262,7 @@ func (c *Compiler) resolveEngineFromIncludesAndImports(
 	orchestratorEngineLog.Printf("Expanding includes for engine configurations")
 	includedEngines, err := parser.ExpandIncludesForEngines(result.Markdown, markdownDir)
 	if err != nil {
-		orchestratorEngineLog.Printf("Failed to expand includes for engines: %v", err)
+		orchestratorEngineLog.Warnf("Failed to expand includes for engines: %v", err)
 		return "", nil, "", fmt.Errorf("failed to expand includes for engines: %w", err)
 	}
 	allEngines := append(importsResult.MergedEngines, includedEngines...)
@@ -284,7 +285,7 @@ func (c *Compiler) resolveEngineFromIncludesAndImports(
 		var extractedModel string
 		engineConfig, extractedModel, err = c.extractEngineConfigFromJSON(allEngines[0])
 		if err != nil {
-			orchestratorEngineLog.Printf("Failed to extract engine config: %v", err)
+			orchestratorEngineLog.Warnf("Failed to extract engine config: %v", err)
 			return "", nil, "", fmt.Errorf("failed to extract engine config from included file: %w", err)
 		}
 		// Preserve the model from the main workflow frontmatter if already set;
@@ -435,9 +436,7 @@ func (c *Compiler) runPostEngineValidations(
 	agenticEngine CodingAgentEngine,
 	importsResult *parser.ImportsResult,
 ) error {
-	enableFirewallByDefaultForCopilot(engineSetting, networkPermissions, sandboxConfig)
-	enableFirewallByDefaultForClaude(engineSetting, networkPermissions, sandboxConfig)
-	enableFirewallByDefaultForPi(engineSetting, networkPermissions, sandboxConfig)
+	enableFirewallByDefaultForEngine(engineSetting, networkPermissions, sandboxConfig)
 	return c.withEffectiveStrictMode(frontmatter, func() error {
 		orchestratorEngineLog.Printf("Validating strict firewall (strict=%v)", c.strictMode)
 		if err := c.validateStrictFirewall(engineSetting, networkPermissions, sandboxConfig); err != nil {
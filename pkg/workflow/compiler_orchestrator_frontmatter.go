@@ -46,7 +46,8 @@ func (c *Compiler) validateEngineBeforeSchema(
 	engineValue, ok := frontmatterForValidation["engine"].(string)
 	// Keep the empty-string default-engine behavior, but let whitespace-only values
 	// fall through to getAgenticEngine so they surface as invalid engine typos.
-	if !ok || engineValue == "" {
+	// engine: auto is resolved to a concrete engine later, in ExtractEngineConfig.
+	if !ok || engineValue == "" || engineValue == AutoEngineID {
 		return nil
 	}
 
@@ -88,12 +89,16 @@ func (c *Compiler) parseFrontmatterSection(markdownPath string) (*frontmatterPar
 	// filepath.Clean removes ".." and other problematic path elements
 	cleanPath := filepath.Clean(markdownPath)
 
-	// Read the file
-	content, err := os.ReadFile(cleanPath)
-	if err != nil {
-		orchestratorFrontmatterLog.Printf("Failed to read file: %s, error: %v", cleanPath, err)
-		// Keep the user-facing message while avoiding exposure of os.PathError internals.
-		return nil, fmt.Errorf("failed to read file: %w", frontmatterReadError{message: err.Error()})
+	// Read the file, unless the content was supplied in memory
+	content := []byte(c.contentOverride)
+	if c.contentOverride == "" {
+		var err error
+		content, err = os.ReadFile(cleanPath)
+		if err != nil {
+			orchestratorFrontmatterLog.Warnf("Failed to read file: %s, error: %v", cleanPath, err)
+			// Keep the user-facing message while avoiding exposure of os.PathError internals.
+			return nil, fmt.Errorf("failed to read file: %w", frontmatterReadError{message: err.Error()})
+		}
 	}
 	contentString := string(content)
 
@@ -122,6 +127,9 @@ func (c *Compiler) parseFrontmatterSection(markdownPath string) (*frontmatterPar
 		orchestratorFrontmatterLog.Printf("Schedule preprocessing failed: %v", err)
 		return nil, err
 	}
+	if err := c.expandFollowUpSchedule(result.Frontmatter); err != nil {
+		return nil, err
+	}
 
 	// Create a copy of frontmatter without internal markers for schema validation
 	// Keep the original frontmatter with markers for YAML generation
@@ -245,6 +253,12 @@ func (c *Compiler) parseFrontmatterSection(markdownPath string) (*frontmatterPar
 		return nil, fmt.Errorf("template condition validation failed: %w", err)
 	}
 
+	// Validate that template conditions do not reference secrets
+	if err := validateNoSecretsInTemplateConditions(result.Markdown); err != nil {
+		orchestratorFrontmatterLog.Printf("Template condition secret validation failed: %v", err)
+		return nil, fmt.Errorf("template condition validation failed: %w", err)
+	}
+
 	// Warn when experiment comparison expressions use double-quoted string literals.
 	// GitHub Actions expression syntax only supports single-quoted string literals, so
 	// the compiler converts double quotes to single quotes automatically — but authors
@@ -22,6 +22,7 @@ type toolsProcessingResult struct {
 	runInstallScripts     bool // true when runtimes.node.run-install-scripts: true is set (from main + imports)
 	toolsTimeout          string
 	toolsStartupTimeout   string
+	artifactsTool         *ArtifactsToolConfig
 	markdownContent       string
 	importedMarkdown      string   // Only imports WITH inputs (for compile-time substitution)
 	importPaths           []string // Import paths for runtime-import macro generation (imports without inputs)
@@ -88,6 +89,7 @@ func (c *Compiler) processToolsAndMarkdown(result *parser.FrontmatterResult, cle
 		runInstallScripts:     runInstallScripts,
 		toolsTimeout:          toolsData.toolsTimeout,
 		toolsStartupTimeout:   toolsData.toolsStartupTimeout,
+		artifactsTool:         toolsData.artifactsTool,
 		markdownContent:       markdownData.markdownContent,
 		importedMarkdown:      markdownData.importedMarkdown,
 		importPaths:           markdownData.importPaths,
@@ -116,6 +118,7 @@ type mergedToolsData struct {
 	includedToolFiles     []string
 	toolsTimeout          string
 	toolsStartupTimeout   string
+	artifactsTool         *ArtifactsToolConfig
 	hasExplicitGitHubTool bool
 }
 
@@ -181,12 +184,12 @@ func (c *Compiler) resolveToolsConfiguration(
 	}
 	includedTools, includedToolFiles, err := parser.ExpandIncludesWithManifest(effectiveMarkdown, markdownDir, true)
 	if err != nil {
-		orchestratorToolsLog.Printf("Failed to expand includes for tools: %v", err)
+		orchestratorToolsLog.Warnf("Failed to expand includes for tools: %v", err)
 		return nil, fmt.Errorf("failed to expand includes for tools: %w", err)
 	}
 	allIncludedTools := strings.Join(nonEmptyStrings(importsResult.MergedTools, includedTools), "\n")
 	mcpServers := extractMCPServersMapFromFrontmatter(result.Frontmatter)
-	resolvedMCPServers, err := c.mergeImportedMCPServers(mcpServers, importsResult.MergedMCPServers)
+	resolvedMCPServers, err := c.mergeImportedMCPServers(mcpServers, importsResult)
 	if err != nil {
 		return nil, err
 	}
@@ -196,11 +199,18 @@ func (c *Compiler) resolveToolsConfiguration(
 		orchestratorToolsLog.Printf("Tools merge failed: %v", err)
 		return nil, fmt.Errorf("failed to merge tools: %w", err)
 	}
+	if err := expandGitHubOperations(tools); err != nil {
+		return nil, err
+	}
 	githubToolExplicit := hasExplicitGitHubTool(tools, topTools)
 	toolsTimeout, toolsStartupTimeout, err := c.extractToolTimeouts(tools)
 	if err != nil {
 		return nil, err
 	}
+	artifactsTool, err := extractArtifactsToolConfig(tools)
+	if err != nil {
+		return nil, err
+	}
 	c.warnDeprecatedAPMImports(result.Frontmatter)
 	if err := ValidateMCPConfigs(tools); err != nil {
 		orchestratorToolsLog.Printf("MCP configuration validation failed: %v", err)
@@ -216,6 +226,7 @@ func (c *Compiler) resolveToolsConfiguration(
 		includedToolFiles:     includedToolFiles,
 		toolsTimeout:          toolsTimeout,
 		toolsStartupTimeout:   toolsStartupTimeout,
+		artifactsTool:         artifactsTool,
 		hasExplicitGitHubTool: githubToolExplicit,
 	}, nil
 }
@@ -230,12 +241,12 @@ func nonEmptyStrings(values ...string) []string {
 	return out
 }
 
-func (c *Compiler) mergeImportedMCPServers(mcpServers map[string]any, mergedImportMCPServers string) (map[string]any, error) {
-	if mergedImportMCPServers == "" {
+func (c *Compiler) mergeImportedMCPServers(mcpServers map[string]any, importsResult *parser.ImportsResult) (map[string]any, error) {
+	if importsResult.MergedMCPServers == "" {
 		return mcpServers, nil
 	}
 	orchestratorToolsLog.Printf("Merging imported mcp-servers")
-	mergedMCPServers, err := c.MergeMCPServers(mcpServers, mergedImportMCPServers)
+	mergedMCPServers, err := c.MergeMCPServers(mcpServers, importsResult.MergedMCPServers, importsResult.MergedMCPServerSources)
 	if err != nil {
 		orchestratorToolsLog.Printf("MCP servers merge failed: %v", err)
 		return nil, fmt.Errorf("failed to merge imported mcp-servers: %w", err)
@@ -318,6 +329,7 @@ func (c *Compiler) validateEngineToolRequirements(frontmatter map[string]any, ag
 	}
 	c.validateWebSearchSupport(tools, agenticEngine)
 	c.validateBareModeSupport(frontmatter, agenticEngine)
+	c.validateSettingsScopeSupport(frontmatter, agenticEngine)
 	return nil
 }
 
@@ -421,14 +433,15 @@ func (c *Compiler) logAndDetectTextOutput(markdownContent string, frontmatter ma
 func (c *Compiler) tryParseFrontmatterConfig(frontmatter map[string]any) *FrontmatterConfig {
 	parsedFrontmatter, err := ParseFrontmatterConfig(frontmatter)
 	if err != nil {
-		orchestratorToolsLog.Printf("Failed to parse frontmatter config: %v", err)
+		orchestratorToolsLog.Warnf("Failed to parse frontmatter config: %v", err)
 		return nil
 	}
 	return parsedFrontmatter
 }
 
 // detectTextOutputUsage checks if the markdown content uses ${{ steps.sanitized.outputs.text }},
-// ${{ steps.sanitized.outputs.title }}, or ${{ steps.sanitized.outputs.body }}.
+// ${{ steps.sanitized.outputs.title }}, ${{ steps.sanitized.outputs.body }}, or one of the
+// ${{ steps.sanitized.outputs.discussion_* }} outputs.
 // It also recognises the deprecated ${{ needs.activation.outputs.{text,title,body} }} forms so
 // that workflows that have not yet been migrated still compile correctly.
 func (c *Compiler) detectTextOutputUsage(markdownContent string) bool {
@@ -449,9 +462,12 @@ func (c *Compiler) detectTextOutputUsage(markdownContent string) bool {
 		hasBodyUsage = strings.Contains(markdownContent, "${{ needs.activation.outputs.body }}")
 	}
 
-	hasUsage := hasTextUsage || hasTitleUsage || hasBodyUsage
-	detectionLog.Printf("Detected usage of sanitized outputs - text: %v, title: %v, body: %v, any: %v",
-		hasTextUsage, hasTitleUsage, hasBodyUsage, hasUsage)
+	hasDiscussionUsage := strings.Contains(markdownContent, "${{ steps.sanitized.outputs.discussion_") ||
+		strings.Contains(markdownContent, "${{ needs.activation.outputs.discussion_")
+
+	hasUsage := hasTextUsage || hasTitleUsage || hasBodyUsage || hasDiscussionUsage
+	detectionLog.Printf("Detected usage of sanitized outputs - text: %v, title: %v, body: %v, discussion: %v, any: %v",
+		hasTextUsage, hasTitleUsage, hasBodyUsage, hasDiscussionUsage, hasUsage)
 	return hasUsage
 }
 
@@ -511,7 +527,7 @@ func (c *Compiler) hasContentContext(frontmatter map[string]any) bool {
 func (c *Compiler) warnDeprecatedFrontmatterFields(frontmatter map[string]any) {
 	deprecatedFields, err := parser.GetMainWorkflowDeprecatedFieldsDeep()
 	if err != nil {
-		orchestratorToolsLog.Printf("Failed to load deprecated fields from schema: %v", err)
+		orchestratorToolsLog.Warnf("Failed to load deprecated fields from schema: %v", err)
 		return
 	}
 
@@ -187,6 +187,12 @@ func (c *Compiler) validateWorkflowToolConfigurations(ctx *workflowBuildContext)
 	if err := validateIntegrityReactions(ctx.workflowData.ParsedTools, ctx.workflowData.Name, ctx.workflowData, gatewayConfig); err != nil {
 		return fmt.Errorf("%s: %w", ctx.cleanPath, err)
 	}
+	if err := c.validateMCPChaosConfig(ctx.workflowData, ctx.cleanPath); err != nil {
+		return fmt.Errorf("%s: %w", ctx.cleanPath, err)
+	}
+	if err := c.validateMCPRecordingConfig(ctx.workflowData, ctx.cleanPath); err != nil {
+		return fmt.Errorf("%s: %w", ctx.cleanPath, err)
+	}
 	return nil
 }
 
@@ -216,10 +222,22 @@ func (c *Compiler) populateWorkflowBuildContext(ctx *workflowBuildContext) error
 	if err := c.extractAdditionalConfigurations(ctx.frontmatter.Frontmatter, ctx.toolsResult.tools, ctx.markdownDir, ctx.workflowData, ctx.engineSetup.importsResult, ctx.toolsResult.rawMainMarkdown, ctx.toolsResult.safeOutputs); err != nil {
 		return err
 	}
+	if err := c.applyAgenticJobs(ctx.workflowData, ctx.cleanPath); err != nil {
+		return formatCompilerError(ctx.cleanPath, "error", err.Error(), err)
+	}
 	if err := c.mergeImportedOnFields(ctx.frontmatter.Frontmatter, ctx.workflowData, ctx.engineSetup.importsResult); err != nil {
 		return err
 	}
-	return c.processOnSectionAndFilters(ctx.frontmatter.Frontmatter, ctx.workflowData, ctx.cleanPath)
+	if err := c.processOnSectionAndFilters(ctx.frontmatter.Frontmatter, ctx.workflowData, ctx.cleanPath); err != nil {
+		return err
+	}
+	if err := c.applyFanOut(ctx.workflowData, ctx.frontmatter.Frontmatter, ctx.cleanPath); err != nil {
+		return formatCompilerError(ctx.cleanPath, "error", err.Error(), err)
+	}
+	if err := c.applyPreview(ctx.workflowData, ctx.frontmatter.Frontmatter); err != nil {
+		return formatCompilerError(ctx.cleanPath, "error", err.Error(), err)
+	}
+	return nil
 }
 
 func (c *Compiler) attachSharedActionResolver(workflowData *WorkflowData) {
@@ -420,8 +438,29 @@ func (c *Compiler) extractAdditionalConfigurations(
 	}
 	workflowData.RepoMemoryConfig = repoMemoryConfig
 
+	// Extract the additional repositories the agent reads from
+	contextRepos, err := extractContextRepos(frontmatter)
+	if err != nil {
+		return err
+	}
+	workflowData.ContextRepos = contextRepos
+
 	// Extract and process mcp-scripts and safe-outputs
 	workflowData.Command, workflowData.CommandEvents, workflowData.CommandCentralized, workflowData.CommandPlaceholder = c.extractCommandConfig(frontmatter)
+	commandSubcommands, err := c.extractCommandSubcommands(frontmatter)
+	if err != nil {
+		return err
+	}
+	workflowData.CommandSubcommands = commandSubcommands
+	commandSticky, err := c.extractCommandSticky(frontmatter)
+	if err != nil {
+		return err
+	}
+	workflowData.CommandSticky = commandSticky
+	if commandSticky > 0 {
+		// The sanitized request text is saved for the next invocation.
+		workflowData.NeedsTextOutput = true
+	}
 	workflowData.LabelCommand, workflowData.LabelCommandEvents, workflowData.LabelCommandDecentralized, workflowData.LabelCommandRemoveLabel = c.extractLabelCommandConfig(frontmatter)
 	workflowData.Jobs = c.extractJobsFromFrontmatter(frontmatter)
 
@@ -536,6 +575,12 @@ func (c *Compiler) extractAdditionalConfigurations(
 	// This ensures every workflow with safe-outputs has at least one meaningful action handler.
 	applyDefaultCreateIssue(workflowData)
 
+	// Privacy mode restricts safe-output mentions, so it is applied once safe-outputs
+	// have been fully merged.
+	if err := applyPrivacyConfig(frontmatter, workflowData); err != nil {
+		return err
+	}
+
 	// Apply the top-level github-app as a fallback for all nested github-app token minting operations.
 	// This runs last so that all section-specific configurations have been resolved first.
 	applyTopLevelGitHubAppFallbacks(workflowData)
@@ -545,6 +590,16 @@ func (c *Compiler) extractAdditionalConfigurations(
 	workflowData.Experiments = experimentVariantsFromConfigs(workflowData.ExperimentConfigs)
 	workflowData.ExperimentsStorage = extractExperimentsStorageFromFrontmatter(frontmatter)
 
+	// Extract the context: values referenced in the body as ${{ context.<name> }}.
+	workflowData.PromptContext = extractPromptContextFromFrontmatter(frontmatter)
+
+	// Extract the prompt-budget: token budgets enforced while assembling the prompt.
+	promptBudget, err := extractPromptBudgetFromFrontmatter(frontmatter)
+	if err != nil {
+		return fmt.Errorf("invalid prompt-budget configuration: %w", err)
+	}
+	workflowData.PromptBudget = promptBudget
+
 	// Extract BinEval evals configuration.
 	evalsConfig, err := c.parseEvalsFromFrontmatter(frontmatter)
 	if err != nil {
@@ -646,6 +701,20 @@ func (c *Compiler) processOnSectionAndFilters(
 		return err
 	}
 
+	// Process reaction_command trigger configuration from the on: section
+	if err := c.processReactionCommandConfiguration(frontmatter, workflowData); err != nil {
+		return err
+	}
+
+	// Process on.filters (paths, labels, author-associations) from the on: section
+	if err := c.processTriggerFiltersConfiguration(frontmatter, workflowData); err != nil {
+		return err
+	}
+
+	// Carry over timezone checks and jitter collected during schedule preprocessing
+	c.processScheduleOptions(workflowData)
+	workflowData.FollowUpPolling = c.followUpPolling
+
 	// Process manual-approval configuration from the on: section
 	if err := c.processManualApprovalConfiguration(frontmatter, workflowData); err != nil {
 		return err
@@ -692,7 +761,7 @@ func (c *Compiler) processOnSectionAndFilters(
 				onSteps[i] = s.ToMap()
 			}
 		} else {
-			orchestratorWorkflowLog.Printf("Failed to convert on.steps to typed steps for action pinning: %v", convErr)
+			orchestratorWorkflowLog.Warnf("Failed to convert on.steps to typed steps for action pinning: %v", convErr)
 		}
 	}
 
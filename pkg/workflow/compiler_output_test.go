@@ -0,0 +1,119 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const compilerOutputTestWorkflow = `---
+on: push
+permissions:
+  contents: read
+engine: copilot
+strict: false
+---
+
+# Test Workflow
+
+This is a test workflow.`
+
+func writeCompilerOutputTestWorkflows(t *testing.T, names ...string) (string, []string) {
+	t.Helper()
+	tmpDir := testutil.TempDir(t, "compiler-output-test")
+	paths := make([]string, 0, len(names))
+	for _, name := range names {
+		path := filepath.Join(tmpDir, name+".md")
+		require.NoError(t, os.WriteFile(path, []byte(compilerOutputTestWorkflow), 0644), "failed to write workflow")
+		paths = append(paths, path)
+	}
+	return tmpDir, paths
+}
+
+func TestCompileWorkflowToOutputWriter(t *testing.T) {
+	tmpDir, paths := writeCompilerOutputTestWorkflows(t, "first", "second")
+
+	var out bytes.Buffer
+	compiler := NewCompiler()
+	compiler.SetQuiet(true)
+	compiler.SetOutputWriter(&out)
+	for _, path := range paths {
+		require.NoError(t, compiler.CompileWorkflow(path), "workflow should compile")
+		assert.Empty(t, compiler.OutputLockFile(path), "no lock file should be reported in stdout mode")
+	}
+
+	assert.Equal(t, 1, strings.Count(out.String(), "\n---\n"), "workflows should be separated by one document marker")
+	assert.Equal(t, 2, strings.Count(out.String(), "# This file was automatically generated by gh-aw."), "both workflows should be written")
+	lockFiles, err := filepath.Glob(filepath.Join(tmpDir, "*.lock.yml"))
+	require.NoError(t, err, "failed to list lock files")
+	assert.Empty(t, lockFiles, "no lock file should be written next to the source")
+}
+
+func TestCompileWorkflowToOutputDir(t *testing.T) {
+	tmpDir, paths := writeCompilerOutputTestWorkflows(t, "triage")
+	outputDir := filepath.Join(tmpDir, "build", "workflows")
+
+	compiler := NewCompiler()
+	compiler.SetQuiet(true)
+	compiler.SetOutputDir(outputDir)
+	require.NoError(t, compiler.CompileWorkflow(paths[0]), "workflow should compile")
+
+	lockFile := filepath.Join(outputDir, "triage.lock.yml")
+	assert.Equal(t, lockFile, compiler.OutputLockFile(paths[0]), "lock file should be relocated")
+	assert.FileExists(t, lockFile, "lock file should be written to the output directory")
+	assert.NoFileExists(t, filepath.Join(tmpDir, "triage.lock.yml"), "lock file should not be written next to the source")
+}
+
+func TestCompileWorkflowCheckLockFiles(t *testing.T) {
+	_, paths := writeCompilerOutputTestWorkflows(t, "triage")
+	lockFile := filepath.Join(filepath.Dir(paths[0]), "triage.lock.yml")
+
+	newCheckCompiler := func() *Compiler {
+		compiler := NewCompiler()
+		compiler.SetQuiet(true)
+		compiler.SetNoEmit(true)
+		compiler.SetCheckLockFiles(true)
+		return compiler
+	}
+
+	err := newCheckCompiler().CompileWorkflow(paths[0])
+	require.Error(t, err, "a missing lock file should fail the check")
+	assert.Contains(t, err.Error(), "triage.lock.yml is missing", "unexpected error message")
+	assert.NoFileExists(t, lockFile, "check mode should not write the lock file")
+
+	compiler := NewCompiler()
+	compiler.SetQuiet(true)
+	require.NoError(t, compiler.CompileWorkflow(paths[0]), "workflow should compile")
+	require.NoError(t, newCheckCompiler().CompileWorkflow(paths[0]), "a freshly compiled lock file should pass the check")
+
+	content, err := os.ReadFile(lockFile)
+	require.NoError(t, err, "failed to read lock file")
+	require.NoError(t, os.WriteFile(lockFile, append(content, '\n'), 0644), "failed to edit lock file")
+	err = newCheckCompiler().CompileWorkflow(paths[0])
+	require.Error(t, err, "any byte difference should fail the check")
+	assert.Contains(t, err.Error(), "triage.lock.yml is out of date", "unexpected error message")
+}
+
+func TestCompileWorkflowIsDeterministic(t *testing.T) {
+	_, paths := writeCompilerOutputTestWorkflows(t, "triage")
+
+	var outputs []string
+	for range 3 {
+		var out bytes.Buffer
+		compiler := NewCompiler()
+		compiler.SetQuiet(true)
+		compiler.SetOutputWriter(&out)
+		require.NoError(t, compiler.CompileWorkflow(paths[0]), "workflow should compile")
+		outputs = append(outputs, out.String())
+	}
+	assert.Equal(t, outputs[0], outputs[1], "compiling twice should produce identical output")
+	assert.Equal(t, outputs[0], outputs[2], "compiling twice should produce identical output")
+}
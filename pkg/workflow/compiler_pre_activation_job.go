@@ -47,6 +47,21 @@ func (c *Compiler) buildPreActivationJob(data *WorkflowData, needsPermissionChec
 	skipIfToken := c.resolvePreActivationSkipIfToken(data)
 	steps = c.buildPreActivationSkipIfQuerySteps(data, steps, skipIfToken)
 	steps = c.buildPreActivationSkipIfCheckFailingStep(data, steps)
+	if data.ReactionCommand != nil {
+		steps = c.appendPreActivationReactionCommandStep(data, steps)
+	}
+	if len(scheduleTimezoneGuards(data.ScheduleTimezoneChecks, data.ScheduleUnguardedCrons)) > 0 {
+		steps = c.appendPreActivationScheduleTimezoneStep(data, steps)
+	}
+	if data.ScheduleJitterSeconds > 0 {
+		steps = c.appendPreActivationScheduleJitterStep(data, steps)
+	}
+	if data.TriggerFilters != nil && len(data.TriggerFilters.PathCheckEvents) > 0 {
+		steps = c.appendPreActivationChangedPathsStep(data, steps)
+	}
+	if data.FollowUpPolling {
+		steps = c.appendPreActivationFollowUpsStep(data, steps)
+	}
 	steps = c.buildPreActivationRolesBotsCmdSteps(data, steps)
 	steps = c.buildPreActivationMemoryRestoreSteps(data, steps)
 	steps, onStepIDs, err := c.injectPreActivationOnSteps(data, steps, customSteps)
@@ -99,6 +114,25 @@ func (c *Compiler) buildPreActivationPermissions(data *WorkflowData, setupAction
 		}
 		perms.Set(PermissionActions, PermissionRead)
 	}
+	// The reaction command check reads issues, comments, and their reactions, and lists
+	// previous scheduled runs to determine where the last poll left off.
+	if data.ReactionCommand != nil {
+		if perms == nil {
+			perms = NewPermissions()
+		}
+		perms.Set(PermissionActions, PermissionRead)
+		perms.Set(PermissionIssues, PermissionRead)
+		if slices.Contains(data.ReactionCommand.Events, "pull_request") {
+			perms.Set(PermissionPullRequests, PermissionRead)
+		}
+	}
+	// The changed paths check lists the files of the triggering pull request.
+	if data.TriggerFilters != nil && len(data.TriggerFilters.PathCheckEvents) > 0 {
+		if perms == nil {
+			perms = NewPermissions()
+		}
+		perms.Set(PermissionPullRequests, PermissionRead)
+	}
 	// Auto-grant pull-requests: read when label_command uses decentralized strategy
 	// with pull_request events. The check_membership.cjs script calls the pulls API
 	// to verify PR provenance, which requires pull-requests: read.
@@ -351,6 +385,10 @@ func (c *Compiler) appendPreActivationCommandPositionStep(data *WorkflowData, st
 	if data.CommandPlaceholder != "" {
 		steps = append(steps, fmt.Sprintf("          GH_AW_COMMAND_PLACEHOLDER: %q\n", data.CommandPlaceholder))
 	}
+	if len(data.CommandSubcommands) > 0 {
+		steps = append(steps, fmt.Sprintf("          GH_AW_COMMAND_SUBCOMMANDS: %q\n", slashCommandSubcommandsJSON(data.CommandSubcommands)))
+		steps = append(steps, formatYAMLEnv("          ", "GH_AW_COMMAND_HELP", buildSlashCommandHelpText(data.Command, data.CommandSubcommands)))
+	}
 	steps = append(steps, "        with:\n")
 	steps = append(steps, "          script: |\n")
 	return append(steps, generateGitHubScriptWithRequire("check_command_position.cjs"))
@@ -398,6 +436,10 @@ func buildPreActivationSkipAndCommandConditions(data *WorkflowData) []ConditionN
 	conditions := appendPreActivationCondition(nil, data.SkipIfMatch != nil, constants.CheckSkipIfMatchStepID, constants.SkipCheckOkOutput)
 	conditions = appendPreActivationCondition(conditions, data.SkipIfNoMatch != nil, constants.CheckSkipIfNoMatchStepID, constants.SkipNoMatchCheckOkOutput)
 	conditions = appendPreActivationCondition(conditions, data.SkipIfCheckFailing != nil, constants.CheckSkipIfCheckFailingStepID, constants.SkipIfCheckFailingOkOutput)
+	conditions = appendPreActivationCondition(conditions, data.ReactionCommand != nil, constants.CheckReactionCommandStepID, constants.ReactionCommandOkOutput)
+	conditions = appendPreActivationCondition(conditions, len(scheduleTimezoneGuards(data.ScheduleTimezoneChecks, data.ScheduleUnguardedCrons)) > 0, constants.CheckScheduleTimezoneStepID, constants.ScheduleTimezoneOkOutput)
+	conditions = appendPreActivationCondition(conditions, data.TriggerFilters != nil && len(data.TriggerFilters.PathCheckEvents) > 0, constants.CheckChangedPathsStepID, constants.ChangedPathsOkOutput)
+	conditions = appendPreActivationCondition(conditions, data.FollowUpPolling, constants.CheckFollowUpsStepID, constants.FollowUpsOkOutput)
 	conditions = appendPreActivationCondition(conditions, len(data.SkipRoles) > 0, constants.CheckSkipRolesStepID, constants.SkipRolesOkOutput)
 	conditions = appendPreActivationCondition(conditions, len(data.SkipBots) > 0, constants.CheckSkipBotsStepID, constants.SkipBotsOkOutput)
 	return appendPreActivationCondition(conditions, len(data.Command) > 0, constants.CheckCommandPositionStepID, constants.CommandPositionOkOutput)
@@ -425,14 +467,14 @@ func buildPreActivationActivatedExpression(data *WorkflowData, conditions []Cond
 func buildPreActivationActivatedNode(data *WorkflowData, conditions []ConditionNode) (ConditionNode, error) {
 	// Build the final expression.
 	if len(conditions) == 0 {
-		// Pre-activation was created solely for on.steps injection.
+		// Pre-activation was created solely for on.steps injection or schedule jitter.
 		// The activated output is unconditionally true; the user controls
 		// agent execution through their own if: condition referencing the
 		// on.steps outputs (e.g., needs.pre_activation.outputs.gate_result).
-		if len(data.OnSteps) > 0 || len(data.OnNeeds) > 0 || len(data.SkipAuthorAssociations) > 0 {
+		if len(data.OnSteps) > 0 || len(data.OnNeeds) > 0 || len(data.SkipAuthorAssociations) > 0 || data.ScheduleJitterSeconds > 0 || data.TriggerFilters.needsPreActivation() {
 			compilerActivationJobsLog.Printf(
-				"Pre-activation created with no output checks (on.steps=%d, on.needs=%d, skip-author-associations=%d); activated output is unconditionally true",
-				len(data.OnSteps), len(data.OnNeeds), len(data.SkipAuthorAssociations),
+				"Pre-activation created with no output checks (on.steps=%d, on.needs=%d, skip-author-associations=%d, schedule-jitter=%ds, filters=%v); activated output is unconditionally true",
+				len(data.OnSteps), len(data.OnNeeds), len(data.SkipAuthorAssociations), data.ScheduleJitterSeconds, data.TriggerFilters.needsPreActivation(),
 			)
 			return BuildStringLiteral("true"), nil
 		}
@@ -464,6 +506,21 @@ func buildPreActivationJobOutputs(data *WorkflowData, activatedExpression string
 	} else {
 		outputs[constants.MatchedCommandOutput] = "''"
 	}
+	// Expose the stop-time check result so the disable_expired job can disable an expired workflow.
+	if data.StopTime != "" {
+		outputs[constants.StopTimeOkOutput] = fmt.Sprintf("${{ steps.%s.outputs.%s }}", constants.CheckStopTimeStepID, constants.StopTimeOkOutput)
+	}
+	// Subcommand outputs are only declared when subcommands are configured.
+	if len(data.CommandSubcommands) > 0 {
+		outputs[constants.SubcommandOutput] = fmt.Sprintf("${{ steps.%s.outputs.%s }}", constants.CheckCommandPositionStepID, constants.SubcommandOutput)
+		outputs[constants.CommandArgsOutput] = fmt.Sprintf("${{ steps.%s.outputs.%s }}", constants.CheckCommandPositionStepID, constants.CommandArgsOutput)
+	}
+	// Reaction command outputs identify the item the reaction was added to.
+	if data.ReactionCommand != nil {
+		for _, output := range []string{constants.ReactionItemNumberOutput, constants.ReactionCommentIDOutput, constants.ReactionUserOutput} {
+			outputs[output] = fmt.Sprintf("${{ steps.%s.outputs.%s }}", constants.CheckReactionCommandStepID, output)
+		}
+	}
 	// Wire on.steps step outcomes as pre-activation outputs.
 	// For each step with an id, emit output "<id>_result: ${{ steps.<id>.outcome }}"
 	// so users can reference them with: needs.pre_activation.outputs.<id>_result
@@ -527,6 +584,14 @@ func (c *Compiler) applyPreActivationIfConditionGuards(data *WorkflowData, needs
 	if len(data.SkipAuthorAssociations) > 0 {
 		jobIfCondition = combinePreActivationIfCondition(RenderCondition(buildSkipAuthorAssociationsCondition(data.SkipAuthorAssociations)), jobIfCondition)
 	}
+	// on.filters labels and author-associations are static payload checks, so they also
+	// compile into the job-level if condition instead of a pre-activation step.
+	if data.TriggerFilters != nil && len(data.TriggerFilters.Labels) > 0 {
+		jobIfCondition = combinePreActivationIfCondition(RenderCondition(buildTriggerFilterLabelsCondition(data.TriggerFilters.Labels)), jobIfCondition)
+	}
+	if data.TriggerFilters != nil && len(data.TriggerFilters.AuthorAssociationEvents) > 0 {
+		jobIfCondition = combinePreActivationIfCondition(RenderCondition(buildTriggerFilterAuthorAssociationsCondition(data.TriggerFilters.AuthorAssociations, data.TriggerFilters.AuthorAssociationEvents)), jobIfCondition)
+	}
 	return jobIfCondition
 }
 
@@ -418,6 +418,19 @@ This workflow has no comment-memory configured.
 		t.Error("Expected 'Download activation artifact' step even without comment-memory")
 	}
 
+	// The prompt placeholder for the scratch directory is resolved right after the download
+	downloadIdx := indexInNonCommentLines(agentSection, "- name: Download activation artifact")
+	resolveIdx := indexInNonCommentLines(agentSection, "- name: Resolve scratch directory in prompt")
+	if resolveIdx == -1 {
+		t.Fatal("Expected 'Resolve scratch directory in prompt' step in agent job")
+	}
+	if resolveIdx < downloadIdx {
+		t.Errorf("'Resolve scratch directory in prompt' (%d) must come after 'Download activation artifact' (%d)", resolveIdx, downloadIdx)
+	}
+	if !strings.Contains(agentSection, `bash "${RUNNER_TEMP}/gh-aw/actions/resolve_prompt_tmp_dir.sh"`) {
+		t.Error("Expected the resolve step to run resolve_prompt_tmp_dir.sh")
+	}
+
 	// Comment-memory-specific steps must NOT be present
 	if strings.Contains(agentSection, "name: Write comment-memory configuration") {
 		t.Error("Did not expect 'Write comment-memory configuration' step when comment-memory is not configured")
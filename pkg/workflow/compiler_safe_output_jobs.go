@@ -214,7 +214,7 @@ func (c *Compiler) buildCallWorkflowJobs(data *WorkflowData, markdownPath string
 		if markdownPath != "" {
 			fileResult, findErr := findWorkflowFile(workflowName, markdownPath)
 			if findErr != nil {
-				compilerSafeOutputJobsLog.Printf("Warning: could not find worker workflow file for '%s': %v. "+
+				compilerSafeOutputJobsLog.Warnf("could not find worker workflow file for '%s': %v. "+
 					"Typed inputs will not be forwarded in the with: block.", workflowName, findErr)
 			} else {
 				var workflowInputs map[string]any
@@ -227,11 +227,11 @@ func (c *Compiler) buildCallWorkflowJobs(data *WorkflowData, markdownPath string
 				case fileResult.mdExists:
 					workflowInputs, inputErr = extractMDWorkflowCallInputs(fileResult.mdPath)
 				default:
-					compilerSafeOutputJobsLog.Printf("Warning: no worker file found for '%s'; "+
+					compilerSafeOutputJobsLog.Warnf("no worker file found for '%s'; "+
 						"typed inputs will not be forwarded in the with: block.", workflowName)
 				}
 				if inputErr != nil {
-					compilerSafeOutputJobsLog.Printf("Warning: could not extract workflow_call inputs for '%s': %v. "+
+					compilerSafeOutputJobsLog.Warnf("could not extract workflow_call inputs for '%s': %v. "+
 						"Typed inputs will not be forwarded in the with: block.", workflowName, inputErr)
 				} else if workflowInputs != nil {
 					typedInputCount := 0
@@ -268,7 +268,7 @@ func (c *Compiler) buildCallWorkflowJobs(data *WorkflowData, markdownPath string
 		if markdownPath != "" {
 			workerSecrets, secretsErr := extractCallWorkflowSecrets(workflowName, markdownPath)
 			if secretsErr != nil {
-				compilerSafeOutputJobsLog.Printf("Warning: could not extract secrets for call-workflow job '%s': %v. "+
+				compilerSafeOutputJobsLog.Warnf("could not extract secrets for call-workflow job '%s': %v. "+
 					"Falling back to secrets: inherit.", jobName, secretsErr)
 				callJob.SecretsInherit = true
 			} else if len(workerSecrets) == 0 {
@@ -1324,6 +1324,15 @@ func TestHandlerConfigUpdateFields(t *testing.T) {
 			},
 			expectedKeys: []string{"allow_title", "allow_body"},
 		},
+		{
+			name: "type and parent",
+			config: &UpdateIssuesConfig{
+				Type:         testBoolPtr(true),
+				AllowedTypes: []string{"Bug", "Task"},
+				Parent:       testBoolPtr(true),
+			},
+			expectedKeys: []string{"allow_type", "allowed_types", "allow_parent"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1486,6 +1495,49 @@ func TestHandlerConfigTargetRepo(t *testing.T) {
 	}
 }
 
+func TestHandlerConfigDedupeKey(t *testing.T) {
+	compiler := NewCompiler()
+
+	workflowData := &WorkflowData{
+		Name: "Test Workflow",
+		SafeOutputs: &SafeOutputsConfig{
+			CreateIssues: &CreateIssuesConfig{
+				DedupeKey: "daily-report",
+			},
+			AddComments: &AddCommentsConfig{
+				DedupeKey: "status-summary",
+			},
+		},
+	}
+
+	var steps []string
+	compiler.addHandlerManagerConfigEnvVar(&steps, workflowData)
+
+	found := false
+	for _, step := range steps {
+		if strings.Contains(step, "GH_AW_SAFE_OUTPUTS_HANDLER_CONFIG") {
+			parts := strings.Split(step, "GH_AW_SAFE_OUTPUTS_HANDLER_CONFIG: ")
+			if len(parts) == 2 {
+				found = true
+				jsonStr := strings.TrimSpace(parts[1])
+				jsonStr = strings.Trim(jsonStr, "\"")
+				jsonStr = strings.ReplaceAll(jsonStr, "\\\"", "\"")
+
+				var config map[string]map[string]any
+				err := json.Unmarshal([]byte(jsonStr), &config)
+				require.NoError(t, err)
+
+				require.Contains(t, config, "create_issue")
+				assert.Equal(t, "daily-report", config["create_issue"]["dedupe_key"])
+
+				require.Contains(t, config, "add_comment")
+				assert.Equal(t, "status-summary", config["add_comment"]["dedupe_key"])
+			}
+		}
+	}
+	require.True(t, found, "expected GH_AW_SAFE_OUTPUTS_HANDLER_CONFIG in steps")
+}
+
 func TestHandlerConfigClosePullRequestTargetRepo(t *testing.T) {
 	compiler := NewCompiler()
 
@@ -1578,6 +1630,53 @@ func TestHandlerConfigCreateCheckRunTarget(t *testing.T) {
 	require.True(t, foundHandlerConfig, "Expected GH_AW_SAFE_OUTPUTS_HANDLER_CONFIG in generated steps")
 }
 
+func TestHandlerConfigCreateCheckRunAnnotations(t *testing.T) {
+	compiler := NewCompiler()
+
+	checkRunConfig := compiler.parseCreateCheckRunConfig(map[string]any{
+		"create-check-run": map[string]any{
+			"annotations": map[string]any{
+				"rules":    []any{"alt-text", "heading-order"},
+				"required": true,
+			},
+		},
+	})
+	require.NotNil(t, checkRunConfig.Annotations, "annotations should be parsed")
+	assert.Equal(t, defaultCheckRunAnnotationsMax, checkRunConfig.Annotations.Max, "annotations max should default to 50")
+
+	workflowData := &WorkflowData{
+		Name:        "Test Workflow",
+		SafeOutputs: &SafeOutputsConfig{CreateCheckRun: checkRunConfig},
+	}
+
+	var steps []string
+	compiler.addHandlerManagerConfigEnvVar(&steps, workflowData)
+
+	foundHandlerConfig := false
+	for _, step := range steps {
+		if strings.Contains(step, "GH_AW_SAFE_OUTPUTS_HANDLER_CONFIG") {
+			foundHandlerConfig = true
+			parts := strings.Split(step, "GH_AW_SAFE_OUTPUTS_HANDLER_CONFIG: ")
+			if len(parts) == 2 {
+				jsonStr := strings.TrimSpace(parts[1])
+				jsonStr = strings.Trim(jsonStr, "\"")
+				jsonStr = strings.ReplaceAll(jsonStr, "\\\"", "\"")
+
+				var config map[string]map[string]any
+				err := json.Unmarshal([]byte(jsonStr), &config)
+				require.NoError(t, err)
+
+				handlerConfig, ok := config["create_check_run"]
+				require.True(t, ok)
+				assert.InDelta(t, float64(50), handlerConfig["annotations_max"], 0.0001, "annotations_max should be passed to the handler")
+				assert.Equal(t, []any{"alt-text", "heading-order"}, handlerConfig["annotations_rules"], "annotations_rules should be passed to the handler")
+				assert.Equal(t, true, handlerConfig["annotations_required"], "annotations_required should be passed to the handler")
+			}
+		}
+	}
+	require.True(t, foundHandlerConfig, "Expected GH_AW_SAFE_OUTPUTS_HANDLER_CONFIG in generated steps")
+}
+
 // TestHandlerConfigPatchSize tests max patch size configuration
 func TestHandlerConfigPatchSize(t *testing.T) {
 	tests := []struct {
@@ -3690,3 +3789,33 @@ func TestReportFailureAsIssueWithCategoriesFilter(t *testing.T) {
 		})
 	}
 }
+
+// TestReportFailureAsCommentParsing tests that report-failure-as-comment is parsed
+// as a templatable bool
+func TestReportFailureAsCommentParsing(t *testing.T) {
+	tests := []struct {
+		name        string
+		reportValue any
+		expected    *string
+	}{
+		{name: "boolean true", reportValue: true, expected: strPtr("true")},
+		{name: "boolean false", reportValue: false, expected: strPtr("false")},
+		{name: "templatable expression", reportValue: "${{ inputs.report-failure-as-comment }}", expected: strPtr("${{ inputs.report-failure-as-comment }}")},
+		{name: "invalid string is ignored", reportValue: "sometimes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frontmatter := map[string]any{
+				"safe-outputs": map[string]any{
+					"report-failure-as-comment": tt.reportValue,
+					"add-comment":               nil,
+				},
+			}
+
+			config := NewCompiler().extractSafeOutputsConfig(frontmatter)
+			require.NotNil(t, config, "SafeOutputsConfig should be created")
+			assert.Equal(t, tt.expected, config.ReportFailureAsComment, "report-failure-as-comment should match")
+		})
+	}
+}
@@ -213,7 +213,7 @@ func (c *Compiler) buildSafeOutputsSetupAndDownloadSteps(data *WorkflowData, age
 		consolidatedSafeOutputsJobLog.Print("Adding patch artifact download for create-pull-request or push-to-pull-request-branch")
 		patchDownloadSteps := buildArtifactDownloadSteps(ArtifactDownloadConfig{
 			ArtifactName: agentArtifactPrefix + constants.AgentArtifactName,
-			DownloadPath: constants.TmpGhAwDirSlash,
+			DownloadPath: constants.TmpGhAwDirExprSlash,
 			SetupEnvStep: false, // No environment variable needed, the script checks the file directly
 			StepName:     "Download patch artifact",
 		}, c.getActionPin)
@@ -240,7 +240,7 @@ func (c *Compiler) buildSafeOutputsSetupAndDownloadSteps(data *WorkflowData, age
 		for i, step := range data.SafeOutputs.Steps {
 			stepMap, ok := step.(map[string]any)
 			if !ok {
-				consolidatedSafeOutputsJobLog.Printf("Warning: safe-outputs step at index %d is not a valid step object (must be a map with properties like name, run, uses). Skipping this step.", i)
+				consolidatedSafeOutputsJobLog.Warnf("safe-outputs step at index %d is not a valid step object (must be a map with properties like name, run, uses). Skipping this step.", i)
 				continue
 			}
 			typedStep, err := MapToStep(stepMap)
@@ -299,6 +299,7 @@ func (c *Compiler) buildSafeOutputsHandlerOutputsAndActionSteps(data *WorkflowDa
 		data.SafeOutputs.LinkSubIssue != nil ||
 		data.SafeOutputs.UpdateRelease != nil ||
 		data.SafeOutputs.CreatePullRequestReviewComments != nil ||
+		data.SafeOutputs.CreatePullRequestReviewSuggestions != nil ||
 		data.SafeOutputs.SubmitPullRequestReview != nil ||
 		data.SafeOutputs.ReplyToPullRequestReviewComment != nil ||
 		data.SafeOutputs.ResolvePullRequestReviewThread != nil ||
@@ -319,7 +320,9 @@ func (c *Compiler) buildSafeOutputsHandlerOutputsAndActionSteps(data *WorkflowDa
 		data.SafeOutputs.MissingData != nil ||
 		data.SafeOutputs.AssignToAgent != nil || // assign_to_agent is now handled by the handler manager
 		data.SafeOutputs.CreateAgentSessions != nil || // create_agent_session is now handled by the handler manager
+		data.SafeOutputs.HandoffToCopilot != nil ||
 		data.SafeOutputs.UploadArtifact != nil || // upload_artifact is handled inline in the handler loop
+		data.SafeOutputs.ScheduleFollowUp != nil ||
 		len(data.SafeOutputs.Scripts) > 0 || // Custom scripts run in the handler loop
 		len(data.SafeOutputs.Actions) > 0 // Custom actions need handler to export their payloads
 
@@ -352,6 +355,10 @@ func (c *Compiler) buildSafeOutputsHandlerOutputsAndActionSteps(data *WorkflowDa
 		)
 	}
 
+	// Restore the follow-up queue so the schedule_follow_up handler appends to the latest version.
+	followUpRestoreSteps, followUpSaveSteps := buildFollowUpSafeOutputsSteps(data)
+	steps = append(steps, followUpRestoreSteps...)
+
 	// 1. Handler Manager step (processes create_issue, update_issue, add_comment, assign_to_agent,
 	// upload_artifact, etc.)
 	// This processes all safe output types that are handled by the unified handler
@@ -363,6 +370,7 @@ func (c *Compiler) buildSafeOutputsHandlerOutputsAndActionSteps(data *WorkflowDa
 			return nil, nil, nil, err
 		}
 		steps = append(steps, handlerManagerSteps...)
+		steps = append(steps, followUpSaveSteps...)
 		safeOutputStepNames = append(safeOutputStepNames, "process_safe_outputs")
 
 		// Add outputs from handler manager
@@ -528,7 +536,7 @@ func (c *Compiler) buildSafeOutputsJobFromParts(
 	jobCondition := buildSafeOutputsJobCondition(data, threatDetectionEnabled)
 	needs := c.buildSafeOutputsJobNeeds(data, mainJobName, threatDetectionEnabled)
 	workflowID := GetWorkflowIDFromPath(markdownPath)
-	jobEnv := c.buildJobLevelSafeOutputEnvVars(data, workflowID)
+	jobEnv := withNetworkProxyEnv(c.buildJobLevelSafeOutputEnvVars(data, workflowID), data)
 
 	var concurrency string
 	if data.SafeOutputs.ConcurrencyGroup != "" {
@@ -641,7 +649,7 @@ func (c *Compiler) calculatePreambleInsertIndex(steps []string, data *WorkflowDa
 	if usesPatchesAndCheckouts(data.SafeOutputs) {
 		patchDownloadSteps := buildArtifactDownloadSteps(ArtifactDownloadConfig{
 			ArtifactName: agentArtifactPrefix + constants.AgentArtifactName,
-			DownloadPath: constants.TmpGhAwDirSlash,
+			DownloadPath: constants.TmpGhAwDirExprSlash,
 			SetupEnvStep: false,
 			StepName:     "Download patch artifact",
 		}, c.getActionPin)
@@ -655,7 +663,7 @@ func (c *Compiler) calculatePreambleInsertIndex(steps []string, data *WorkflowDa
 func (c *Compiler) appendFinalSafeOutputSteps(data *WorkflowData, steps []string, agentArtifactPrefix string) []string {
 	isStaged := c.trialMode || templatableBoolIsTrue(data.SafeOutputs.Staged)
 	if !isStaged {
-		steps = append(steps, buildSafeOutputItemsManifestUploadStep(agentArtifactPrefix, c.getActionPin)...)
+		steps = append(steps, buildSafeOutputItemsManifestUploadStep(data, agentArtifactPrefix, c.getActionPin)...)
 	}
 	if c.actionMode.IsDev() && usesPatchesAndCheckouts(data.SafeOutputs) {
 		steps = append(steps, c.generateRestoreActionsSetupStep())
@@ -783,6 +791,9 @@ func (c *Compiler) buildJobLevelSafeOutputEnvVars(data *WorkflowData, workflowID
 		envVars["GH_AW_TRACKER_ID"] = fmt.Sprintf("%q", data.TrackerID)
 	}
 
+	// Thread the run correlation ID resolved by the activation job into safe output markers and footers.
+	envVars["GH_AW_CORRELATION_ID"] = correlationIDNeedsExpr()
+
 	// Bake the repository project UTC offset (from aw.json) into safe-outputs job env
 	// so runtime JavaScript helpers do not need to read aw.json on the runner.
 	if utcOffset := c.getCompiledProjectUTCOffset(); utcOffset != "" {
@@ -851,7 +862,7 @@ func (c *Compiler) buildJobLevelSafeOutputEnvVars(data *WorkflowData, workflowID
 	if data.SafeOutputs != nil && data.SafeOutputs.Messages != nil {
 		messagesJSON, err := serializeMessagesConfig(data.SafeOutputs.Messages)
 		if err != nil {
-			consolidatedSafeOutputsJobLog.Printf("Warning: failed to serialize messages config: %v", err)
+			consolidatedSafeOutputsJobLog.Warnf("failed to serialize messages config: %v", err)
 		} else if messagesJSON != "" {
 			envVars["GH_AW_SAFE_OUTPUT_MESSAGES"] = fmt.Sprintf("%q", messagesJSON)
 		}
@@ -895,18 +906,34 @@ func resolveSafeOutputsEnvironment(data *WorkflowData) string {
 // try to upload an artifact with the same name in the same workflow run.
 // prefix is prepended to the artifact name; use empty string for non-workflow_call workflows.
 // pinAction resolves the upload-artifact action reference; pass c.getActionPin from Compiler methods.
-func buildSafeOutputItemsManifestUploadStep(prefix string, pinAction func(string) string) []string {
-	return []string{
+func buildSafeOutputItemsManifestUploadStep(data *WorkflowData, prefix string, pinAction func(string) string) []string {
+	paths := []string{
+		constants.TmpGhAwDirExpr + "/safe-output-items.jsonl",
+		constants.TmpGhAwDirExpr + "/" + constants.TemporaryIdMapFilename,
+	}
+	var steps []string
+	if data.ArtifactEncryption.encrypts(encryptedArtifactSafeOutputItems) {
+		// Upload a single age-encrypted archive of the items in place of the plaintext files.
+		outputFile := constants.TmpGhAwDirExprSlash + constants.SafeOutputItemsArtifactName + constants.EncryptedArtifactSuffix
+		steps = buildEncryptArtifactStep("Encrypt Safe Outputs Items", data.ArtifactEncryption, outputFile, paths)
+		paths = []string{outputFile}
+	}
+	steps = append(steps,
 		"      - name: Upload Safe Outputs Items\n",
 		"        if: always()\n",
 		fmt.Sprintf("        uses: %s\n", pinAction("actions/upload-artifact")),
 		"        with:\n",
 		fmt.Sprintf("          name: %s%s\n", prefix, constants.SafeOutputItemsArtifactName),
 		"          path: |\n",
-		"            /tmp/gh-aw/safe-output-items.jsonl\n",
-		fmt.Sprintf("            /tmp/gh-aw/%s\n", constants.TemporaryIdMapFilename),
-		"          if-no-files-found: ignore\n",
+	)
+	for _, path := range paths {
+		steps = append(steps, "            "+path+"\n")
 	}
+	steps = append(steps, "          if-no-files-found: ignore\n")
+	if line := artifactRetentionDaysLine(data, "          "); line != "" {
+		steps = append(steps, line+"\n")
+	}
+	return steps
 }
 
 // buildSarifArtifactUploadStep builds the step that uploads the SARIF file generated by
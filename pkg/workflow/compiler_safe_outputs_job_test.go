@@ -1118,7 +1118,7 @@ func TestGitHubAppTokenStepWithOTLPHeaders(t *testing.T) {
 	stepsContent := strings.Join(job.Steps, "")
 	maskIndex := strings.Index(stepsContent, "Mask OTLP telemetry headers")
 	downloadIndex := strings.Index(stepsContent, "Download agent output artifact")
-	setupEnvEchoIndex := strings.Index(stepsContent, "GH_AW_AGENT_OUTPUT=/tmp/gh-aw/agent_output.json")
+	setupEnvEchoIndex := strings.Index(stepsContent, "GH_AW_AGENT_OUTPUT=${{ env.GH_AW_TMP_DIR }}/agent_output.json")
 	tokenIndex := strings.Index(stepsContent, "Generate GitHub App token")
 
 	require.NotEqual(t, -1, maskIndex, "OTLP headers mask step should be present")
@@ -1130,7 +1130,7 @@ func TestGitHubAppTokenStepWithOTLPHeaders(t *testing.T) {
 	assert.Less(t, setupEnvEchoIndex, tokenIndex, "app token step must be inserted after setup-agent-output-env run block")
 	// Regression guard: app token insertion must not split setup-agent-output-env and leave
 	// an `echo ...` run line nested under a previous `with:` block.
-	assert.NotContains(t, stepsContent, "with:\n          echo \"GH_AW_AGENT_OUTPUT=/tmp/gh-aw/agent_output.json\" >> \"$GITHUB_OUTPUT\"",
+	assert.NotContains(t, stepsContent, "with:\n          echo \"GH_AW_AGENT_OUTPUT=${{ env.GH_AW_TMP_DIR }}/agent_output.json\" >> \"$GITHUB_OUTPUT\"",
 		"app token step must not split setup-agent-output-env into a nested echo under with:")
 }
 
@@ -1165,7 +1165,7 @@ func TestGitHubAppTokenStepWithOTLPAttributes(t *testing.T) {
 	stepsContent := strings.Join(job.Steps, "")
 	attrMaskIndex := strings.Index(stepsContent, "Mask OTLP custom attribute values")
 	downloadIndex := strings.Index(stepsContent, "Download agent output artifact")
-	setupEnvEchoIndex := strings.Index(stepsContent, "GH_AW_AGENT_OUTPUT=/tmp/gh-aw/agent_output.json")
+	setupEnvEchoIndex := strings.Index(stepsContent, "GH_AW_AGENT_OUTPUT=${{ env.GH_AW_TMP_DIR }}/agent_output.json")
 	tokenIndex := strings.Index(stepsContent, "Generate GitHub App token")
 
 	require.NotEqual(t, -1, attrMaskIndex, "OTLP attributes mask step should be present")
@@ -1175,7 +1175,7 @@ func TestGitHubAppTokenStepWithOTLPAttributes(t *testing.T) {
 
 	assert.Less(t, attrMaskIndex, downloadIndex, "attributes masking should happen before artifact download")
 	assert.Less(t, setupEnvEchoIndex, tokenIndex, "app token step must be inserted after setup-agent-output-env run block")
-	assert.NotContains(t, stepsContent, "with:\n          echo \"GH_AW_AGENT_OUTPUT=/tmp/gh-aw/agent_output.json\" >> \"$GITHUB_OUTPUT\"",
+	assert.NotContains(t, stepsContent, "with:\n          echo \"GH_AW_AGENT_OUTPUT=${{ env.GH_AW_TMP_DIR }}/agent_output.json\" >> \"$GITHUB_OUTPUT\"",
 		"app token step must not split setup-agent-output-env into a nested echo under with:")
 }
 
@@ -1213,7 +1213,7 @@ func TestGitHubAppTokenStepWithOTLPHeadersAndAttributes(t *testing.T) {
 	headerMaskIndex := strings.Index(stepsContent, "Mask OTLP telemetry headers")
 	attrMaskIndex := strings.Index(stepsContent, "Mask OTLP custom attribute values")
 	downloadIndex := strings.Index(stepsContent, "Download agent output artifact")
-	setupEnvEchoIndex := strings.Index(stepsContent, "GH_AW_AGENT_OUTPUT=/tmp/gh-aw/agent_output.json")
+	setupEnvEchoIndex := strings.Index(stepsContent, "GH_AW_AGENT_OUTPUT=${{ env.GH_AW_TMP_DIR }}/agent_output.json")
 	tokenIndex := strings.Index(stepsContent, "Generate GitHub App token")
 
 	require.NotEqual(t, -1, headerMaskIndex, "OTLP headers mask step should be present")
@@ -1225,7 +1225,7 @@ func TestGitHubAppTokenStepWithOTLPHeadersAndAttributes(t *testing.T) {
 	assert.Less(t, headerMaskIndex, downloadIndex, "header masking should happen before artifact download")
 	assert.Less(t, attrMaskIndex, downloadIndex, "attributes masking should happen before artifact download")
 	assert.Less(t, setupEnvEchoIndex, tokenIndex, "app token step must be inserted after setup-agent-output-env run block")
-	assert.NotContains(t, stepsContent, "with:\n          echo \"GH_AW_AGENT_OUTPUT=/tmp/gh-aw/agent_output.json\" >> \"$GITHUB_OUTPUT\"",
+	assert.NotContains(t, stepsContent, "with:\n          echo \"GH_AW_AGENT_OUTPUT=${{ env.GH_AW_TMP_DIR }}/agent_output.json\" >> \"$GITHUB_OUTPUT\"",
 		"app token step must not split setup-agent-output-env into a nested echo under with:")
 }
 
@@ -219,8 +219,8 @@ func (c *Compiler) buildHandlerManagerStep(data *WorkflowData) ([]string, error)
 		steps = append(steps, fmt.Sprintf("          GH_AW_PROJECT_GITHUB_TOKEN: %s\n", projectToken))
 	}
 
-	// Add GH_AW_ASSIGN_TO_AGENT_TOKEN when assign-to-agent is configured OR when create-issue
-	// or create-pull-request is configured with copilot in assignees. All handlers create a
+	// Add GH_AW_ASSIGN_TO_AGENT_TOKEN when assign-to-agent or handoff-to-copilot is configured OR
+	// when create-issue or create-pull-request is configured with copilot in assignees. All handlers create a
 	// dedicated Octokit using this token (agent token preference chain), which is required
 	// because the Copilot assignment API only accepts PATs (not GitHub App tokens). This env
 	// var is evaluated as a GitHub Actions expression, so it resolves to the actual token value
@@ -240,6 +240,11 @@ func (c *Compiler) buildHandlerManagerStep(data *WorkflowData) ([]string, error)
 		//nolint:gosec // G101: False positive - this is a GitHub Actions expression template, not a hardcoded credential
 		steps = append(steps, fmt.Sprintf("          GH_AW_ASSIGN_TO_AGENT_TOKEN: %s\n", agentTokenStr))
 		consolidatedSafeOutputsStepsLog.Print("Added GH_AW_ASSIGN_TO_AGENT_TOKEN env var for create-pull-request copilot assignment handler")
+	} else if data.SafeOutputs != nil && data.SafeOutputs.HandoffToCopilot != nil {
+		agentTokenStr := getEffectiveCopilotCodingAgentGitHubToken(data.SafeOutputs.HandoffToCopilot.GitHubToken)
+		//nolint:gosec // G101: False positive - this is a GitHub Actions expression template, not a hardcoded credential
+		steps = append(steps, fmt.Sprintf("          GH_AW_ASSIGN_TO_AGENT_TOKEN: %s\n", agentTokenStr))
+		consolidatedSafeOutputsStepsLog.Print("Added GH_AW_ASSIGN_TO_AGENT_TOKEN env var for handoff-to-copilot handler")
 	}
 
 	// Add GH_AW_AGENT_SESSION_TOKEN when create-agent-session is configured.
@@ -30,6 +30,8 @@ func (c *Compiler) CompileToYAML(workflowData *WorkflowData, markdownPath string
 
 	c.stepOrderTracker = NewStepOrderTracker()
 	c.scheduleFriendlyFormats = nil
+	c.scheduleOptions = nil
+	c.followUpPolling = false
 
 	if c.artifactManager == nil {
 		c.artifactManager = NewArtifactManager()
@@ -88,6 +90,9 @@ func (c *Compiler) ParseWorkflowString(content string, virtualPath string) (*Wor
 	if err := c.preprocessScheduleFields(result.Frontmatter, cleanPath, content); err != nil {
 		return nil, err
 	}
+	if err := c.expandFollowUpSchedule(result.Frontmatter); err != nil {
+		return nil, err
+	}
 
 	frontmatterForValidation := c.copyFrontmatterWithoutInternalMarkers(result.Frontmatter)
 
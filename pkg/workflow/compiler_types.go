@@ -2,10 +2,13 @@ package workflow
 
 import (
 	"context"
+	"io"
 	"os"
+	"path/filepath"
 
 	"github.com/github/gh-aw/pkg/logger"
 	"github.com/github/gh-aw/pkg/parser"
+	"github.com/github/gh-aw/pkg/stringutil"
 )
 
 var logTypes = logger.New("workflow:compiler_types")
@@ -59,61 +62,83 @@ type Compiler struct {
 	verbose                 bool
 	quiet                   bool // If true, suppress success messages (for interactive mode)
 	engineOverride          string
-	customOutput            string                   // If set, output will be written to this path instead of default location
-	version                 string                   // Version of the extension
-	skipValidation          bool                     // If true, skip schema validation
-	noEmit                  bool                     // If true, validate without generating lock files
-	strictMode              bool                     // If true, enforce strict validation requirements
-	allowActionRefs         bool                     // If true, unresolved action refs are warnings instead of errors
-	approve                 bool                     // If true, approve safe update changes (skip safe update enforcement)
-	forceStaged             bool                     // If true, force all safe-outputs into staged mode
-	trialMode               bool                     // If true, suppress safe outputs for trial mode execution
-	trialLogicalRepoSlug    string                   // If set in trial mode, the logical repository to checkout
-	useSamples              bool                     // If true, replace the agentic step with a deterministic samples replay driver (hidden feature)
-	refreshStopTime         bool                     // If true, regenerate stop-after times instead of preserving existing ones
-	forceRefreshActionPins  bool                     // If true, clear action cache and resolve all actions from GitHub API
-	failFast                bool                     // If true, stop at first validation error instead of collecting all errors
-	actionCacheCleared      bool                     // Tracks if action cache has already been cleared (for forceRefreshActionPins)
-	markdownPath            string                   // Path to the markdown file being compiled (for context in dynamic tool generation)
-	actionMode              ActionMode               // Mode for generating JavaScript steps (inline vs custom actions)
-	actionTag               string                   // Override action SHA or tag for actions/setup (when set, overrides actionMode to release)
-	actionsRepo             string                   // Override the external actions repository (default: github/gh-aw-actions)
-	jobManager              *JobManager              // Manages jobs and dependencies
-	engineRegistry          *EngineRegistry          // Registry of available agentic engines
-	engineCatalog           *EngineCatalog           // Catalog of engine definitions backed by the registry
-	fileTracker             FileCreationTracker      // Optional file tracker for tracking created files
-	warningCount            int                      // Number of warnings encountered during compilation
-	stepOrderTracker        *StepOrderTracker        // Tracks step ordering for validation
-	actionCache             *ActionCache             // Shared cache for action pin resolutions across all workflows
-	actionResolver          *ActionResolver          // Shared resolver for action pins across all workflows
-	actionPinWarnings       map[string]bool          // Shared cache of already-warned action pin failures (key: "repo@version")
-	importCache             *parser.ImportCache      // Shared cache for imported workflow files
-	workflowIdentifier      string                   // Identifier for the current workflow being compiled (for schedule scattering)
-	scheduleWarnings        []string                 // Accumulated schedule warnings for this compiler instance
-	safeUpdateWarnings      []string                 // Accumulated safe update warnings (new secrets/actions requiring review)
-	repositorySlug          string                   // Repository slug (owner/repo) used as seed for scattering
-	repositorySlugLocked    bool                     // If true, repositorySlug was set via --schedule-seed and must not be overridden by per-file detection
-	artifactManager         *ArtifactManager         // Tracks artifact uploads/downloads for validation
-	scheduleFriendlyFormats map[int]string           // Maps schedule item index to friendly format string for current workflow
-	gitRoot                 string                   // Git repository root directory (if set, used for action cache path)
-	repoConfig              *RepoConfig              // Cached repository-level aw.json config
-	repoConfigErr           error                    // Cached repo config load error
-	repoConfigLoaded        bool                     // True once repo config has been loaded (success or failure)
-	contentOverride         string                   // If set, use this content instead of reading from disk (for Wasm/in-memory compilation)
-	skipHeader              bool                     // If true, skip ASCII art header in generated YAML (for Wasm/editor mode)
-	inlinePrompt            bool                     // If true, inline markdown content in YAML instead of using runtime-import macros (for Wasm builds)
-	priorManifests          map[string]*GHAWManifest // Pre-cached manifests keyed by lock file path; takes precedence over git HEAD / filesystem reads
-	requireDocker           bool                     // If true, fail validation when Docker is not available instead of silently skipping
-	ghesCompatFromCLI       bool                     // If true, GHES compat was requested via --ghes CLI flag (takes precedence over aw.json)
-	ghesArtifactCompat      bool                     // If true, GHES compatibility mode is enabled; artifact actions still use latest non-v3 pins
-	ownerTypeCache          map[string]string        // Cached GitHub owner type ("User"/"Organization"/"") keyed by owner login; not goroutine-safe (Compiler is used sequentially)
-	copilotRequestsTipShown map[string]bool          // Tracks markdown paths that already emitted the copilot-requests enable tip in this compiler instance
+	customOutput            string                       // If set, lock files are written to this directory instead of next to the markdown source
+	outputWriter            io.Writer                    // If set, compiled workflows are written to this writer instead of lock files
+	outputDocuments         int                          // Number of compiled workflows written to outputWriter
+	version                 string                       // Version of the extension
+	skipValidation          bool                         // If true, skip schema validation
+	noEmit                  bool                         // If true, validate without generating lock files
+	checkLockFiles          bool                         // If true, fail when the existing lock file differs from the compiled workflow (requires noEmit)
+	strictMode              bool                         // If true, enforce strict validation requirements
+	allowActionRefs         bool                         // If true, unresolved action refs are warnings instead of errors
+	approve                 bool                         // If true, approve safe update changes (skip safe update enforcement)
+	forceStaged             bool                         // If true, force all safe-outputs into staged mode
+	trialMode               bool                         // If true, suppress safe outputs for trial mode execution
+	trialLogicalRepoSlug    string                       // If set in trial mode, the logical repository to checkout
+	useSamples              bool                         // If true, replace the agentic step with a deterministic samples replay driver (hidden feature)
+	refreshStopTime         bool                         // If true, regenerate stop-after times instead of preserving existing ones
+	forceRefreshActionPins  bool                         // If true, clear action cache and resolve all actions from GitHub API
+	failFast                bool                         // If true, stop at first validation error instead of collecting all errors
+	actionCacheCleared      bool                         // Tracks if action cache has already been cleared (for forceRefreshActionPins)
+	markdownPath            string                       // Path to the markdown file being compiled (for context in dynamic tool generation)
+	actionMode              ActionMode                   // Mode for generating JavaScript steps (inline vs custom actions)
+	actionTag               string                       // Override action SHA or tag for actions/setup (when set, overrides actionMode to release)
+	actionsRepo             string                       // Override the external actions repository (default: github/gh-aw-actions)
+	jobManager              *JobManager                  // Manages jobs and dependencies
+	engineRegistry          *EngineRegistry              // Registry of available agentic engines
+	engineCatalog           *EngineCatalog               // Catalog of engine definitions backed by the registry
+	fileTracker             FileCreationTracker          // Optional file tracker for tracking created files
+	warningCount            int                          // Number of warnings encountered during compilation
+	stepOrderTracker        *StepOrderTracker            // Tracks step ordering for validation
+	actionCache             *ActionCache                 // Shared cache for action pin resolutions across all workflows
+	actionResolver          *ActionResolver              // Shared resolver for action pins across all workflows
+	promptRegistry          *PromptRegistry              // Shared prompt version registry, loaded when the first lock file is written
+	actionPinWarnings       map[string]bool              // Shared cache of already-warned action pin failures (key: "repo@version")
+	importCache             *parser.ImportCache          // Shared cache for imported workflow files
+	workflowIdentifier      string                       // Identifier for the current workflow being compiled (for schedule scattering)
+	scheduleWarnings        []string                     // Accumulated schedule warnings for this compiler instance
+	safeUpdateWarnings      []string                     // Accumulated safe update warnings (new secrets/actions requiring review)
+	changeRiskReports       map[string]*ChangeRiskReport // Change risk reports keyed by markdown path, for the compile summary
+	repositorySlug          string                       // Repository slug (owner/repo) used as seed for scattering
+	repositorySlugLocked    bool                         // If true, repositorySlug was set via --schedule-seed and must not be overridden by per-file detection
+	artifactManager         *ArtifactManager             // Tracks artifact uploads/downloads for validation
+	scheduleFriendlyFormats map[int]string               // Maps schedule item index to friendly format string for current workflow
+	scheduleOptions         *compiledScheduleOptions     // Timezone checks and jitter collected from schedule items for current workflow
+	followUpPolling         bool                         // True when a polling schedule was added for safe-outputs.schedule-follow-up in the current workflow
+	gitRoot                 string                       // Git repository root directory (if set, used for action cache path)
+	repoConfig              *RepoConfig                  // Cached repository-level aw.json config
+	repoConfigErr           error                        // Cached repo config load error
+	repoConfigLoaded        bool                         // True once repo config has been loaded (success or failure)
+	contentOverride         string                       // If set, use this content instead of reading from disk (for Wasm/in-memory compilation)
+	skipHeader              bool                         // If true, skip ASCII art header in generated YAML (for Wasm/editor mode)
+	inlinePrompt            bool                         // If true, inline markdown content in YAML instead of using runtime-import macros (for Wasm builds)
+	priorManifests          map[string]*GHAWManifest     // Pre-cached manifests keyed by lock file path; takes precedence over git HEAD / filesystem reads
+	requireDocker           bool                         // If true, fail validation when Docker is not available instead of silently skipping
+	ghesCompatFromCLI       bool                         // If true, GHES compat was requested via --ghes CLI flag (takes precedence over aw.json)
+	ghesArtifactCompat      bool                         // If true, GHES compatibility mode is enabled; artifact actions still use latest non-v3 pins
+	ownerTypeCache          map[string]string            // Cached GitHub owner type ("User"/"Organization"/"") keyed by owner login; not goroutine-safe (Compiler is used sequentially)
+	copilotRequestsTipShown map[string]bool              // Tracks markdown paths that already emitted the copilot-requests enable tip in this compiler instance
 	// modelPricingResolver is an optional callback for resolving per-token pricing of models that
 	// are absent from the embedded models.json catalog. When non-nil it is called during
 	// buildInitialWorkflowData for the workflow's configured model; any returned pricing is merged
 	// into WorkflowData.ModelCosts so it is embedded in GH_AW_INFO_MODEL_COSTS in the lock.yml.
 	// Injected by the cli package (which has access to the embedded catalog and models.dev download).
 	modelPricingResolver func(ctx context.Context, provider, model string) (map[string]float64, bool)
+	// containerDigestResolver re-resolves MCP server image digests for --update-mcp (see SetContainerDigestResolver).
+	containerDigestResolver func(ctx context.Context, image string) (string, error)
+	refreshedMCPImages      map[string]bool // MCP server images already re-resolved in this run
+	// repositorySecretsResolver lists the repository secrets for engine: auto (see SetRepositorySecretsResolver).
+	repositorySecretsResolver func(ctx context.Context) ([]string, error)
+	repositorySecrets         map[string]bool // Cached result of repositorySecretsResolver
+	repositorySecretsErr      error           // Cached error of repositorySecretsResolver
+	repositorySecretsLoaded   bool            // True once repositorySecretsResolver has been called
+	// Security lints (see security_lints.go)
+	securityLints         bool                      // If true, run the security lints and fail on findings that are not baselined
+	updateLintBaseline    bool                      // If true, record security lint findings instead of failing (--update-lint-baseline)
+	securityLintReports   map[string][]SecurityLint // Security lint findings keyed by markdown path
+	securityLintBaseline  *SecurityLintBaseline     // Cached baseline of accepted security lint findings
+	securityLintBaseErr   error                     // Cached baseline load error
+	securityLintBaseReady bool                      // True once the baseline has been loaded (success or failure)
 }
 
 // NewCompiler creates a new workflow compiler with functional options.
@@ -195,6 +220,37 @@ func (c *Compiler) SetNoEmit(noEmit bool) {
 	c.noEmit = noEmit
 }
 
+// SetCheckLockFiles configures whether to compare each compiled workflow with its existing
+// lock file and fail when they differ (compile --check). Used together with SetNoEmit.
+func (c *Compiler) SetCheckLockFiles(check bool) {
+	c.checkLockFiles = check
+}
+
+// SetOutputDir configures the directory lock files are written to instead of next to
+// their markdown source (compile --output-dir).
+func (c *Compiler) SetOutputDir(dir string) {
+	c.customOutput = dir
+}
+
+// SetOutputWriter configures a writer that receives the compiled workflows instead of
+// lock files (compile --stdout). Workflows are separated by YAML document markers.
+func (c *Compiler) SetOutputWriter(w io.Writer) {
+	c.outputWriter = w
+}
+
+// OutputLockFile returns the path the compiled workflow for markdownPath is written to,
+// honoring SetOutputDir, or an empty string when compiled workflows go to the output writer.
+func (c *Compiler) OutputLockFile(markdownPath string) string {
+	if c.outputWriter != nil {
+		return ""
+	}
+	lockFile := stringutil.MarkdownToLockFile(markdownPath)
+	if c.customOutput != "" {
+		lockFile = filepath.Join(c.customOutput, filepath.Base(lockFile))
+	}
+	return filepath.Clean(lockFile)
+}
+
 // SetApprove configures whether to skip safe update enforcement via the CLI --approve flag.
 // When true, safe update enforcement is disabled regardless of strict mode setting,
 // approving all changes.
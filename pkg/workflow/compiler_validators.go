@@ -5,15 +5,17 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"slices"
 	"strings"
 
 	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/parser"
 )
 
-// validateExpressions checks expression safety and runtime-import file references
-// embedded in the workflow's markdown content. It is the first validator called in
+// validateExpressions checks expression safety, runtime-import file references, and
+// workflow_dispatch inputs used by the workflow's markdown content. It is the first validator called in
 // validateWorkflowData and guards against unsafe GitHub Actions expressions.
 func (c *Compiler) validateExpressions(workflowData *WorkflowData, markdownPath string) error {
 	// Check for secrets serialization expressions FIRST — before the general allowlist —
@@ -24,16 +26,28 @@ func (c *Compiler) validateExpressions(workflowData *WorkflowData, markdownPath
 		return formatCompilerError(markdownPath, "error", err.Error(), err)
 	}
 
+	// Validate context: values and the ${{ context.<name> }} references to them before the
+	// allowlist, so undefined references get a specific error.
+	if err := validatePromptContext(workflowData); err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+
+	if err := validatePromptBudget(workflowData); err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+
 	// Validate expression safety - check that all GitHub Actions expressions are in the allowed list.
 	// In non-strict mode, ${{ toJSON(secrets) }} occurrences were already warned about above;
 	// neutralize them so the allowlist does not re-surface them as errors.
 	if strings.Contains(workflowData.MarkdownContent, "${{") {
 		workflowLog.Printf("Validating expression safety")
-		markdownForAllowlist := workflowData.MarkdownContent
+		// ${{ context.<name> }} references are validated as the values they stand for.
+		markdownForAllowlist := expandPromptContextReferences(workflowData.MarkdownContent, workflowData.PromptContext)
 		if !c.effectiveStrictMode(workflowData.RawFrontmatter) {
 			markdownForAllowlist = neutralizeSecretsSerializationExpressions(markdownForAllowlist)
 		}
 		if err := validateExpressionSafety(markdownForAllowlist); err != nil {
+			locateUnauthorizedExpression(err, workflowData, markdownPath)
 			return formatCompilerError(markdownPath, "error", err.Error(), err)
 		}
 	}
@@ -58,10 +72,20 @@ func (c *Compiler) validateExpressions(workflowData *WorkflowData, markdownPath
 		}
 	}
 
-	// Warn when the prompt explicitly references /tmp/ or /tmp/gh-aw/ directly instead
-	// of the recommended /tmp/gh-aw/agent/ subtree.
+	// Warn when the prompt references /tmp/ directly instead of the scratch directory
+	// of the job.
 	c.validatePromptTmpPaths(workflowData, markdownPath)
 
+	// Check workflow_dispatch input definitions, and warn when the prompt references
+	// inputs that no trigger declares (they would silently render empty).
+	if err := validateWorkflowDispatchInputs(workflowData.RawFrontmatter); err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+	if undeclared := undeclaredPromptInputReferences(workflowData.MarkdownContent, workflowData.RawFrontmatter); len(undeclared) > 0 {
+		fmt.Fprintln(os.Stderr, formatCompilerMessage(markdownPath, "warning", formatUndeclaredInputsWarning(undeclared)))
+		c.IncrementWarningCount()
+	}
+
 	return nil
 }
 
@@ -77,32 +101,71 @@ const tmpSafePrefix = "/tmp/gh-aw/"
 // /tmp/ references that are not under /tmp/gh-aw/.
 // Returns an empty string when no problematic patterns are found.
 func warnPromptTmpPaths(content string) string {
-	if !strings.Contains(content, tmpNeedle) {
+	if findUnsafeTmpPath(content) < 0 {
 		return ""
 	}
-	// Walk every /tmp/ occurrence; warn as soon as one is not under /tmp/gh-aw/.
-	rest := content
+	return "Prompt references /tmp/ directly. " +
+		"Use " + constants.TmpGhAwDirPlaceholder + "/agent/ as the root for all temporary files " +
+		"generated by the agent — its contents are uploaded as a run artifact."
+}
+
+// findUnsafeTmpPath returns the byte offset of the first /tmp/ reference in content
+// that is not under /tmp/gh-aw/, or -1 when there is none.
+func findUnsafeTmpPath(content string) int {
+	offset := 0
 	for {
-		pos := strings.Index(rest, tmpNeedle)
+		pos := strings.Index(content[offset:], tmpNeedle)
 		if pos < 0 {
-			break
+			return -1
 		}
-		segment := rest[pos:]
-		if !strings.HasPrefix(segment, tmpSafePrefix) {
-			return "Prompt references /tmp/ directly. " +
-				"Use /tmp/gh-aw/agent/ as the root for all temporary files " +
-				"generated by the agent — its contents are uploaded as a run artifact."
+		if !strings.HasPrefix(content[offset+pos:], tmpSafePrefix) {
+			return offset + pos
 		}
-		rest = rest[pos+len(tmpNeedle):]
+		offset += pos + len(tmpNeedle)
 	}
-	return ""
 }
 
-// validatePromptTmpPaths emits an advisory warning when the workflow markdown body
-// references /tmp/ or /tmp/gh-aw/ instead of the recommended /tmp/gh-aw/agent/ root.
+// jobScratchTmpDirs are the directories the framework keeps in the scratch directory of
+// the job rather than directly below /tmp/gh-aw/. Prompts must reach them through
+// TmpGhAwDirPlaceholder.
+var jobScratchTmpDirs = []string{
+	"agent", "aw-mcp", "aw-prompts", "cache-memory", "comment-memory", "experiments",
+	"mcp-logs", "repo-memory", "safeoutputs", "sandbox",
+}
+
+// jobScratchTmpPathPattern matches /tmp/gh-aw/ paths of directories in jobScratchTmpDirs,
+// including named caches such as /tmp/gh-aw/cache-memory-notes/.
+var jobScratchTmpPathPattern = regexp.MustCompile(`/tmp/gh-aw/(` + strings.Join(jobScratchTmpDirs, "|") + `)(-[A-Za-z0-9_-]+)?\b`)
+
+// warnPromptJobScratchPaths returns a non-empty advisory message when content refers to a
+// directory of the job scratch directory through /tmp/gh-aw/, where the agent no longer
+// finds it. Returns an empty string when no such reference is found.
+func warnPromptJobScratchPaths(content string) string {
+	match := jobScratchTmpPathPattern.FindStringSubmatch(content)
+	if match == nil {
+		return ""
+	}
+	dir := strings.TrimPrefix(match[0], constants.TmpGhAwDir+"/")
+	return fmt.Sprintf("Prompt references %s/, which is kept in the scratch directory of the job. Use %s/%s/ instead.",
+		match[0], constants.TmpGhAwDirPlaceholder, dir)
+}
+
+// validatePromptTmpPaths emits advisory warnings when the workflow markdown body
+// references /tmp/ instead of the scratch directory of the job, or reaches a directory
+// of the scratch directory through /tmp/gh-aw/.
 func (c *Compiler) validatePromptTmpPaths(workflowData *WorkflowData, markdownPath string) {
 	if msg := warnPromptTmpPaths(workflowData.MarkdownContent); msg != "" {
-		fmt.Fprintln(os.Stderr, formatCompilerMessage(markdownPath, "warning", msg))
+		line, column := parser.LocateMarkdownOffset(workflowData.RawMarkdown, workflowData.MarkdownStartLine, findUnsafeTmpPath(workflowData.RawMarkdown))
+		fmt.Fprintln(os.Stderr, formatCompilerMessageAt(markdownPath, line, column, "warning", msg))
+		c.IncrementWarningCount()
+	}
+	if msg := warnPromptJobScratchPaths(workflowData.MarkdownContent); msg != "" {
+		offset := -1
+		if loc := jobScratchTmpPathPattern.FindStringIndex(workflowData.RawMarkdown); loc != nil {
+			offset = loc[0]
+		}
+		line, column := parser.LocateMarkdownOffset(workflowData.RawMarkdown, workflowData.MarkdownStartLine, offset)
+		fmt.Fprintln(os.Stderr, formatCompilerMessageAt(markdownPath, line, column, "warning", msg))
 		c.IncrementWarningCount()
 	}
 }
@@ -171,6 +234,7 @@ func (c *Compiler) validateCoreToolConfiguration(workflowData *WorkflowData, mar
 		{logMessage: "Validating safe-outputs urls policy", validateFn: func() error { return validateSafeOutputsURLs(workflowData.SafeOutputs) }},
 		{logMessage: "Validating safe-outputs allowed-domains", validateFn: func() error { return c.validateSafeOutputsAllowedDomains(workflowData.SafeOutputs) }},
 		{logMessage: "Validating safe-outputs merge-pull-request", validateFn: func() error { return validateSafeOutputsMergePullRequest(workflowData.SafeOutputs) }},
+		{logMessage: "Validating safe-outputs create-pull-request backport", validateFn: func() error { return validateSafeOutputsBackport(workflowData.SafeOutputs) }},
 		{logMessage: "Validating safe-outputs needs declarations", validateFn: func() error { return validateSafeOutputsNeeds(workflowData) }},
 		{logMessage: "Validating on.needs declarations", validateFn: func() error { return c.validateOnNeeds(workflowData) }},
 		{logMessage: "Validating safe-job needs declarations", validateFn: func() error { return validateSafeJobNeeds(workflowData) }},
@@ -179,7 +243,10 @@ func (c *Compiler) validateCoreToolConfiguration(workflowData *WorkflowData, mar
 		{logMessage: "Validating network firewall configuration", validateFn: func() error { return validateNetworkFirewallConfig(workflowData.NetworkPermissions) }},
 		{logMessage: "Validating safe-outputs allow-workflows", validateFn: func() error { return validateSafeOutputsAllowWorkflows(workflowData.SafeOutputs) }},
 		{logMessage: "Validating OTLP resource attributes", validateFn: func() error { return validateOTLPResourceAttributes(workflowData) }},
+		{logMessage: "Validating latency SLOs", validateFn: func() error { return validateLatencySLOConfig(workflowData) }},
 		{logMessage: "Validating labels", validateFn: func() error { return validateLabels(workflowData) }},
+		{logMessage: "Validating runners and images against aw.json", validateFn: func() error { return c.validateRunnerAllowlist(workflowData) }},
+		{logMessage: "Validating required-secrets against MCP server secrets", validateFn: func() error { return validateRequiredSecrets(workflowData) }},
 		{logMessage: "Validating workflow_dispatch input requirements for command triggers", validateFn: func() error { return validateCommandWorkflowDispatchInputs(workflowData) }},
 		{logMessage: "Validating max-daily-ai-credits frontmatter", validateFn: func() error { return validateMaxDailyAICFrontmatter(workflowData) }},
 		{logMessage: "Validating private-to-public-flows string value", validateFn: func() error { return validatePrivateToPublicFlowsStringValue(workflowData) }},
@@ -192,20 +259,26 @@ func (c *Compiler) validateCoreToolConfiguration(workflowData *WorkflowData, mar
 		if c.strictMode {
 			return formatCompilerError(markdownPath, "error", err.Error(), err)
 		}
-		fmt.Fprintln(os.Stderr, formatCompilerMessage(markdownPath, "warning", err.Error()))
+		fmt.Fprintln(os.Stderr, formatCompilerMessageForField(workflowData, markdownPath, "safe-outputs.steps", "warning", err.Error()))
 		c.IncrementWarningCount()
 	}
 	workflowLog.Printf("Validating cross-repo checkout paths")
 	c.deriveAndWarnCrossRepoCheckoutPaths(workflowData.CheckoutConfigs, markdownPath)
 	workflowLog.Printf("Validating push-to-pull-request-branch configuration")
 	c.validatePushToPullRequestBranchWarnings(workflowData.SafeOutputs, workflowData.CheckoutConfigs)
+	// The table entries are independent, so run all of them and report every failure
+	// at once unless --fail-fast is set.
+	collector := NewErrorCollector(c.failFast)
 	for _, validation := range validations {
 		workflowLog.Printf("%s", validation.logMessage)
 		if err := validation.validateFn(); err != nil {
-			return formatCompilerError(markdownPath, "error", err.Error(), err)
+			err = locateFrontmatterField(err, workflowData, markdownPath)
+			if collectErr := collector.Add(formatCompilerError(markdownPath, "error", err.Error(), err)); collectErr != nil {
+				return collectErr
+			}
 		}
 	}
-	return nil
+	return collector.Error()
 }
 
 func (c *Compiler) validateThreatDetectionSandboxRequirement(workflowData *WorkflowData, markdownPath string) error {
@@ -225,6 +298,10 @@ func (c *Compiler) validateConcurrencyConfiguration(workflowData *WorkflowData,
 			return formatCompilerError(markdownPath, "error", "concurrency.job-discriminator validation failed: "+err.Error(), err)
 		}
 	}
+	if workflowData.ConcurrencyPool != nil && workflowData.EngineConfig != nil && workflowData.EngineConfig.Concurrency != "" {
+		err := errors.New("concurrency.pool and engine.concurrency both configure the agent job concurrency group")
+		return formatCompilerError(markdownPath, "error", err.Error()+". Remove engine.concurrency to use the pool, or remove concurrency.pool.", err)
+	}
 	workflowLog.Printf("Validating engine-level concurrency configuration")
 	if workflowData.EngineConfig != nil && workflowData.EngineConfig.Concurrency != "" {
 		if err := validateConcurrencyQueueConfiguration(workflowData.EngineConfig.Concurrency); err != nil {
@@ -270,7 +347,7 @@ func validateWorkflowConcurrency(workflowData *WorkflowData, markdownPath string
 
 func (c *Compiler) emitGeneralToolWarnings(workflowData *WorkflowData, markdownPath string) {
 	if workflowData.Concurrency != "" && strings.Contains(workflowData.Concurrency, "cancel-in-progress: true") && hasBotSelfCancelRisk(workflowData) {
-		fmt.Fprintln(os.Stderr, formatCompilerMessage(markdownPath, "warning",
+		fmt.Fprintln(os.Stderr, formatCompilerMessageForField(workflowData, markdownPath, "concurrency.cancel-in-progress", "warning",
 			"Custom workflow-level concurrency with cancel-in-progress: true may cause self-cancellation.\n"+
 				"safe-outputs.github-app can post comments that re-trigger this workflow via issue_comment,\n"+
 				"and those passive bot-authored runs can collide with the primary run's concurrency group.\n"+
@@ -280,7 +357,7 @@ func (c *Compiler) emitGeneralToolWarnings(workflowData *WorkflowData, markdownP
 		c.IncrementWarningCount()
 	}
 	if isAgentSandboxDisabled(workflowData) {
-		fmt.Fprintln(os.Stderr, formatCompilerMessage(markdownPath, "warning",
+		fmt.Fprintln(os.Stderr, formatCompilerMessageForField(workflowData, markdownPath, "sandbox.agent", "warning",
 			"Agent sandbox disabled (sandbox.agent: false). This removes firewall protection. "+
 				"The AI agent will have direct network access without firewall filtering. "+
 				"The MCP gateway remains enabled. Only use this for testing or in controlled "+
@@ -298,7 +375,7 @@ func (c *Compiler) emitGeneralToolWarnings(workflowData *WorkflowData, markdownP
 	}
 	c.emitExperimentalFeatureWarnings(workflowData)
 	if len(workflowData.Command) > 0 && len(workflowData.Bots) > 0 {
-		fmt.Fprintln(os.Stderr, formatCompilerMessage(markdownPath, "warning",
+		fmt.Fprintln(os.Stderr, formatCompilerMessageForField(workflowData, markdownPath, "on.bots", "warning",
 			"Both slash_command and bots triggers are configured. If a bot listed in bots: "+
 				"posts a comment that starts with the slash command text (e.g., /command-name), "+
 				"it will trigger the workflow and occupy the concurrency slot, potentially "+
@@ -307,7 +384,7 @@ func (c *Compiler) emitGeneralToolWarnings(workflowData *WorkflowData, markdownP
 		c.IncrementWarningCount()
 	}
 	if workflowData.Redirect != "" {
-		fmt.Fprintln(os.Stderr, formatCompilerMessage(markdownPath, "info", "workflow redirect configured: updates move to "+workflowData.Redirect))
+		fmt.Fprintln(os.Stderr, formatCompilerMessageForField(workflowData, markdownPath, "redirect", "info", "workflow redirect configured: updates move to "+workflowData.Redirect))
 	}
 }
 
@@ -609,35 +609,75 @@ func TestWarnPromptTmpPaths(t *testing.T) {
 			expectWarning: true,
 		},
 		{
-			name:          "cache-memory path is safe",
-			content:       "Read state from /tmp/gh-aw/cache-memory/my-workflow/state.json",
+			name:          "job scratch directory placeholder is safe",
+			content:       "Read state from __GH_AW_TMP_DIR__/cache-memory/my-workflow/state.json",
 			expectWarning: false,
 		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := warnPromptTmpPaths(tt.content)
+			if tt.expectWarning {
+				assert.NotEmpty(t, msg, "expected a warning message")
+				assert.Contains(t, msg, "__GH_AW_TMP_DIR__/agent/", "warning should suggest the agent directory of the job")
+			} else {
+				assert.Empty(t, msg, "expected no warning message")
+			}
+		})
+	}
+}
+
+// TestWarnPromptJobScratchPaths tests that prompts reaching a directory of the job scratch
+// directory through /tmp/gh-aw/ are pointed at the placeholder.
+func TestWarnPromptJobScratchPaths(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		expectedMsg string
+	}{
 		{
-			name:          "named cache-memory path is safe",
-			content:       "Use /tmp/gh-aw/cache-memory-focus-areas/ for storage.",
-			expectWarning: false,
+			name:    "placeholder path",
+			content: "Read state from __GH_AW_TMP_DIR__/cache-memory/state.json",
 		},
 		{
-			name:          "repo-memory path is safe",
-			content:       "Access shared memory at /tmp/gh-aw/repo-memory/default/metrics.json",
-			expectWarning: false,
+			name:    "user directory below /tmp/gh-aw",
+			content: "Store output in /tmp/gh-aw/data/result.txt",
 		},
 		{
-			name:          "comment-memory path is safe",
-			content:       "Append haiku to /tmp/gh-aw/comment-memory/notes.md",
-			expectWarning: false,
+			name:        "agent path",
+			content:     "Store output in /tmp/gh-aw/agent/result.txt",
+			expectedMsg: "Use __GH_AW_TMP_DIR__/agent/ instead.",
+		},
+		{
+			name:        "cache-memory path",
+			content:     "Read state from /tmp/gh-aw/cache-memory/my-workflow/state.json",
+			expectedMsg: "Use __GH_AW_TMP_DIR__/cache-memory/ instead.",
+		},
+		{
+			name:        "named cache-memory path",
+			content:     "Use /tmp/gh-aw/cache-memory-focus-areas/ for storage.",
+			expectedMsg: "Use __GH_AW_TMP_DIR__/cache-memory-focus-areas/ instead.",
+		},
+		{
+			name:        "repo-memory path",
+			content:     "Access shared memory at /tmp/gh-aw/repo-memory/default/metrics.json",
+			expectedMsg: "Use __GH_AW_TMP_DIR__/repo-memory/ instead.",
+		},
+		{
+			name:        "comment-memory path",
+			content:     "Append haiku to /tmp/gh-aw/comment-memory/notes.md",
+			expectedMsg: "Use __GH_AW_TMP_DIR__/comment-memory/ instead.",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			msg := warnPromptTmpPaths(tt.content)
-			if tt.expectWarning {
-				assert.NotEmpty(t, msg, "expected a warning message")
-				assert.Contains(t, msg, "/tmp/gh-aw/agent/", "warning should suggest /tmp/gh-aw/agent/")
-			} else {
+			msg := warnPromptJobScratchPaths(tt.content)
+			if tt.expectedMsg == "" {
 				assert.Empty(t, msg, "expected no warning message")
+			} else {
+				assert.Contains(t, msg, tt.expectedMsg, "warning should suggest the placeholder path")
 			}
 		})
 	}
@@ -657,10 +697,15 @@ func TestValidatePromptTmpPaths(t *testing.T) {
 			expectWarn: false,
 		},
 		{
-			name:       "correct /tmp/gh-aw/agent/ — no warning",
-			markdown:   "Store results in /tmp/gh-aw/agent/output.txt",
+			name:       "correct __GH_AW_TMP_DIR__/agent/ — no warning",
+			markdown:   "Store results in __GH_AW_TMP_DIR__/agent/output.txt",
 			expectWarn: false,
 		},
+		{
+			name:       "/tmp/gh-aw/agent/ — warning",
+			markdown:   "Store results in /tmp/gh-aw/agent/output.txt",
+			expectWarn: true,
+		},
 		{
 			name:       "bare /tmp/ reference — warning",
 			markdown:   "Save artifacts to /tmp/data.json",
@@ -671,6 +716,11 @@ func TestValidatePromptTmpPaths(t *testing.T) {
 			markdown:   "Write summary to /tmp/gh-aw/summary.txt",
 			expectWarn: false,
 		},
+		{
+			name:       "/tmp/gh-aw/cache-memory/ — warning",
+			markdown:   "Read notes from /tmp/gh-aw/cache-memory/notes.md",
+			expectWarn: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -697,3 +747,83 @@ func TestValidatePromptTmpPaths(t *testing.T) {
 		})
 	}
 }
+
+// TestValidateCoreToolConfiguration_CollectsAllErrors tests that independent validations
+// are all reported and that validation errors point at their frontmatter key.
+func TestValidateCoreToolConfiguration_CollectsAllErrors(t *testing.T) {
+	workflowData := &WorkflowData{
+		Name:                  "Test",
+		ParsedFrontmatter:     &FrontmatterConfig{Labels: []string{" padded "}},
+		RequiredSecrets:       []string{"NOTION_TOKEN"},
+		FrontmatterFieldLines: map[string]int{"labels": 4, "required-secrets": 7},
+		Tools: map[string]any{
+			"datadog": map[string]any{
+				"url":     "https://mcp.datadoghq.com/mcp",
+				"headers": map[string]any{"DD-API-KEY": "${{ secrets.DD_API_KEY }}"},
+			},
+		},
+	}
+
+	err := NewCompiler().validateCoreToolConfiguration(workflowData, "test.md")
+	require.Error(t, err, "both validations should fail")
+	messages := ExpandErrorMessages(err)
+	require.Len(t, messages, 2, "every failing validation should be reported")
+	assert.Contains(t, messages[0], "labels[0] has leading or trailing whitespace", "labels error should be reported")
+	assert.Contains(t, messages[1], "test.md:7:1: error:", "validation error should point at its frontmatter key")
+	assert.Contains(t, messages[1], "DD_API_KEY", "required-secrets error should be reported")
+
+	err = NewCompiler(WithFailFast(true)).validateCoreToolConfiguration(workflowData, "test.md")
+	require.Error(t, err, "fail-fast should still fail")
+	assert.Len(t, ExpandErrorMessages(err), 1, "fail-fast should stop at the first error")
+}
+
+func TestLocateFrontmatterField(t *testing.T) {
+	workflowData := &WorkflowData{FrontmatterFieldLines: map[string]int{"tools": 5}}
+
+	err := locateFrontmatterField(NewValidationError("tools.cache-memory.key", "k", "bad key", ""), workflowData, "wf.md")
+	var vErr *WorkflowValidationError
+	require.ErrorAs(t, err, &vErr, "error type should be preserved")
+	assert.Equal(t, 5, vErr.Line, "nested field should fall back to the top-level key line")
+	assert.Equal(t, "wf.md", vErr.File, "file should be set")
+
+	unknown := NewValidationError("sandbox", "", "bad", "")
+	require.ErrorAs(t, locateFrontmatterField(unknown, workflowData, "wf.md"), &vErr, "error type should be preserved")
+	assert.Zero(t, vErr.Line, "keys missing from the frontmatter should stay unlocated")
+
+	plain := errors.New("plain")
+	assert.Equal(t, plain, locateFrontmatterField(plain, workflowData, "wf.md"), "other errors should pass through")
+}
+
+func TestLocateFrontmatterField_NestedPath(t *testing.T) {
+	workflowData := &WorkflowData{
+		FrontmatterYAML:       "on: issues\nsafe-outputs:\n  create-issue:\n    max: 0\n  add-comment:",
+		FrontmatterStartLine:  2,
+		FrontmatterFieldLines: map[string]int{"on": 2, "safe-outputs": 3},
+	}
+
+	var vErr *WorkflowValidationError
+	err := locateFrontmatterField(NewValidationError("safe-outputs.create-issue.max", "0", "max must be positive", ""), workflowData, "wf.md")
+	require.ErrorAs(t, err, &vErr, "error type should be preserved")
+	assert.Equal(t, 5, vErr.Line, "nested key should be located")
+	assert.Equal(t, 5, vErr.Column, "column should point at the nested key")
+
+	err = locateFrontmatterField(NewValidationError("safe-outputs.add-comment.target", "", "bad target", ""), workflowData, "wf.md")
+	require.ErrorAs(t, err, &vErr, "error type should be preserved")
+	assert.Equal(t, 6, vErr.Line, "missing keys should point at the deepest enclosing key")
+}
+
+func TestValidateExpressions_PointsAtUnauthorizedExpression(t *testing.T) {
+	workflowData := &WorkflowData{
+		MarkdownContent:   "# Task\n\nIssue ${{ github.event.issue.number }} by ${{ secrets.TOKEN }}",
+		RawMarkdown:       "# Task\n\nIssue ${{ github.event.issue.number }} by ${{ secrets.TOKEN }}",
+		MarkdownStartLine: 6,
+	}
+	err := NewCompiler().validateExpressions(workflowData, "wf.md")
+	require.Error(t, err, "unauthorized expression should be rejected")
+	assert.Contains(t, err.Error(), "wf.md:8:43: error:", "error should point at the unauthorized expression")
+}
+
+func TestFindUnsafeTmpPath(t *testing.T) {
+	assert.Equal(t, -1, findUnsafeTmpPath("write to /tmp/gh-aw/agent/out.txt"), "framework paths should be allowed")
+	assert.Equal(t, 34, findUnsafeTmpPath("write to /tmp/gh-aw/agent/ not to /tmp/x"), "first unsafe path should be found")
+}
@@ -112,7 +112,7 @@ func (c *Compiler) injectWorkflowCallOutputs(onSection string, safeOutputs *Safe
 	// Parse the on section YAML
 	var onData map[string]any
 	if err := yaml.Unmarshal([]byte(onSection), &onData); err != nil {
-		workflowCallLog.Printf("Warning: failed to parse on section for workflow_call outputs injection: %v", err)
+		workflowCallLog.Warnf("failed to parse on section for workflow_call outputs injection: %v", err)
 		return onSection
 	}
 
@@ -166,7 +166,7 @@ func (c *Compiler) injectWorkflowCallOutputs(onSection string, safeOutputs *Safe
 	newOnData := map[string]any{"on": onMap}
 	newYAML, err := yaml.Marshal(newOnData)
 	if err != nil {
-		workflowCallLog.Printf("Warning: failed to marshal on section with workflow_call outputs: %v", err)
+		workflowCallLog.Warnf("failed to marshal on section with workflow_call outputs: %v", err)
 		return onSection
 	}
 
@@ -267,7 +267,7 @@ func injectWorkflowCallSecretsSection(onSection string, secrets []string) string
 	// Parse the on section YAML.
 	var onData map[string]any
 	if err := yaml.Unmarshal([]byte(onSection), &onData); err != nil {
-		workflowCallLog.Printf("Warning: failed to parse on section for workflow_call secrets injection: %v", err)
+		workflowCallLog.Warnf("failed to parse on section for workflow_call secrets injection: %v", err)
 		return onSection
 	}
 
@@ -345,7 +345,7 @@ func injectWorkflowCallSecretsSection(onSection string, secrets []string) string
 	newOnData := map[string]any{"on": onMap}
 	newYAML, err := yaml.Marshal(newOnData)
 	if err != nil {
-		workflowCallLog.Printf("Warning: failed to marshal on section with workflow_call secrets: %v", err)
+		workflowCallLog.Warnf("failed to marshal on section with workflow_call secrets: %v", err)
 		return onSection
 	}
 
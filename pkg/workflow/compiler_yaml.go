@@ -22,7 +22,7 @@ func (c *Compiler) buildJobsAndValidate(data *WorkflowData, markdownPath string)
 
 	// Build all jobs
 	if err := c.buildJobs(data, markdownPath); err != nil {
-		compilerYamlLog.Printf("Failed to build jobs: %v", err)
+		compilerYamlLog.Warnf("Failed to build jobs: %v", err)
 		return fmt.Errorf("failed to build jobs: %w", err)
 	}
 
@@ -49,7 +49,7 @@ func (c *Compiler) generateWorkflowBody(yaml *strings.Builder, data *WorkflowDat
 
 	// Inject on.workflow_call.outputs when workflow_call is configured and safe-outputs are present
 	onSection := data.On
-	if data.SafeOutputs != nil {
+	if data.SafeOutputs != nil && data.FanOut == nil {
 		onSection = c.injectWorkflowCallOutputs(onSection, data.SafeOutputs)
 	}
 	// Inject aw_context input into workflow_dispatch triggers so dispatched workflows
@@ -140,7 +140,7 @@ func (c *Compiler) generateYAML(data *WorkflowData, markdownPath string) (string
 			},
 		)
 		if bErr != nil {
-			compilerYamlLog.Printf("Warning: could not compute body hash for %q: %v", markdownPath, bErr)
+			compilerYamlLog.Warnf("could not compute body hash for %q: %v", markdownPath, bErr)
 			// Non-fatal: continue without body hash
 		} else {
 			bodyHash = bHash
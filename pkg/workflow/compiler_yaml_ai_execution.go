@@ -43,7 +43,7 @@ func (c *Compiler) generateLogParsing(yaml *strings.Builder, data *WorkflowData,
 	logParserScript := GetLogParserScript(parserScriptName)
 	if logParserScript == "" {
 		// Skip if parser script not found
-		compilerYamlLog.Printf("Warning: parser script %s not found, skipping log parsing", parserScriptName)
+		compilerYamlLog.Warnf("parser script %s not found, skipping log parsing", parserScriptName)
 		return
 	}
 
@@ -175,8 +175,8 @@ func (c *Compiler) generateAgentOutputPlaceholderStep(yaml *strings.Builder) {
 	yaml.WriteString("      - name: Write agent output placeholder if missing\n")
 	yaml.WriteString("        if: always()\n")
 	yaml.WriteString("        run: |\n")
-	yaml.WriteString("          if [ ! -f /tmp/gh-aw/agent_output.json ]; then\n")
-	yaml.WriteString("            echo '{\"items\":[]}' > /tmp/gh-aw/agent_output.json\n")
+	yaml.WriteString("          if [ ! -f " + constants.TmpGhAwDirExpr + "/agent_output.json ]; then\n")
+	yaml.WriteString("            echo '{\"items\":[]}' > " + constants.TmpGhAwDirExpr + "/agent_output.json\n")
 	yaml.WriteString("          fi\n")
 }
 
@@ -211,6 +211,32 @@ func (c *Compiler) generateTokenUsageSummary(yaml *strings.Builder, data *Workfl
 	yaml.WriteString("            await main();\n")
 }
 
+// generateFirewallBlockedRequests generates a step that extracts the requests denied by
+// the firewall proxy into blocked-requests.json, a per-domain record of the blocked egress
+// attempts that is bundled in the agent artifact for `gh aw logs` and `gh aw audit`.
+func (c *Compiler) generateFirewallBlockedRequests(yaml *strings.Builder, data *WorkflowData) {
+	compilerYamlLog.Print("Generating firewall blocked requests step")
+
+	// with/env blocks expand Actions expressions, not shell vars, so use runner.temp on ARC/DinD
+	firewallLogsDir := constants.AWFProxyLogsDir
+	if isArcDindTopology(data) {
+		firewallLogsDir = constants.AWFProxyLogsDirExpr
+	}
+
+	yaml.WriteString("      - name: Record blocked egress attempts\n")
+	yaml.WriteString("        if: always()\n")
+	yaml.WriteString("        continue-on-error: true\n")
+	fmt.Fprintf(yaml, "        uses: %s\n", getCachedActionPin("actions/github-script", data))
+	yaml.WriteString("        env:\n")
+	fmt.Fprintf(yaml, "          AWF_LOGS_DIR: %s\n", firewallLogsDir)
+	yaml.WriteString("        with:\n")
+	yaml.WriteString("          script: |\n")
+	yaml.WriteString("            const { setupGlobals } = require('" + SetupActionDestination + "/setup_globals.cjs');\n")
+	yaml.WriteString("            setupGlobals(core, github, context, exec, io, getOctokit);\n")
+	yaml.WriteString("            const { main } = require('" + SetupActionDestination + "/record_firewall_blocked_requests.cjs');\n")
+	yaml.WriteString("            await main();\n")
+}
+
 // generateAWFReflectSummary generates a step that reads the AWF /reflect payload
 // persisted by copilot_harness.cjs and appends a provider/model table to $GITHUB_STEP_SUMMARY.
 //
@@ -278,6 +304,12 @@ func (c *Compiler) generateEngineInstallAndPreAgentSteps(yaml *strings.Builder,
 	// Add step to checkout PR branch if the event is pull_request
 	c.generatePRReadyForReviewCheckout(yaml, data)
 
+	// Download the agent output of the upstream agentic workflow run for workflow_run chaining
+	c.generateWorkflowRunUpstreamDownloadStep(yaml, data)
+
+	// Download the agent output of the agentic jobs this job depends on
+	c.generateAgenticJobUpstreamDownloadSteps(yaml, data)
+
 	// Add Node.js setup if the engine requires it and it's not already set up in custom steps
 	engine, err := c.getAgenticEngine(data.AI)
 	if err != nil {
@@ -426,6 +458,14 @@ func (c *Compiler) generateAgentRunSteps(yaml *strings.Builder, data *WorkflowDa
 	// connects to via host.docker.internal:18443.
 	c.generateStartCliProxyStep(yaml, data)
 
+	// Build the devcontainer image that AWF uses as the agent container's base image.
+	for _, step := range generateDevcontainerBuildSteps(data) {
+		for _, line := range step {
+			yaml.WriteString(line)
+			yaml.WriteString("\n")
+		}
+	}
+
 	// Refresh sbx credentials immediately before AWF execution. Docker Hub OAuth
 	// tokens obtained during the daemon-setup step can expire between workflow steps,
 	// causing "user is not authenticated to Docker" errors when AWF calls `sbx create`.
@@ -487,6 +527,9 @@ func (c *Compiler) generateAgentRunSteps(yaml *strings.Builder, data *WorkflowDa
 	// The MCP gateway is always enabled, even when agent sandbox is disabled
 	c.generateStopMCPGateway(yaml, data)
 
+	// Remove the MCP egress proxies once the gateway has stopped the servers that use them
+	generateMCPEgressProxyCleanupStep(yaml, data.Tools)
+
 	// Add secret redaction step BEFORE any artifact uploads
 	// This ensures all artifacts are scanned for secrets before being uploaded
 	c.generateSecretRedactionStep(yaml, yaml.String(), data)
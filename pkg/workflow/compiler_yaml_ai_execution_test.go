@@ -0,0 +1,24 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateFirewallBlockedRequests(t *testing.T) {
+	compiler := NewCompiler()
+	var yaml strings.Builder
+	compiler.generateFirewallBlockedRequests(&yaml, &WorkflowData{Name: "test-workflow"})
+	step := yaml.String()
+
+	assert.Contains(t, step, "- name: Record blocked egress attempts", "step name")
+	assert.Contains(t, step, "if: always()", "step should run after agent failures")
+	assert.Contains(t, step, "continue-on-error: true", "step should not fail the job")
+	assert.Contains(t, step, "AWF_LOGS_DIR: "+constants.AWFProxyLogsDir, "step should read the proxy logs")
+	assert.Contains(t, step, "/record_firewall_blocked_requests.cjs');", "step should run the recorder script")
+}
@@ -25,7 +25,8 @@ func (c *Compiler) generateUploadAccessLogs(yaml *strings.Builder, tools map[str
 // This consolidates multiple individual upload steps into one, improving workflow readability
 // and reliability. The step always runs (even on cancellation) and ignores missing files.
 // prefix is prepended to the artifact name to avoid clashes in workflow_call context.
-func (c *Compiler) generateUnifiedArtifactUpload(yaml *strings.Builder, paths []string, prefix string) {
+// The artifact holds the agent transcripts and gateway logs, so retention applies to it.
+func (c *Compiler) generateUnifiedArtifactUpload(yaml *strings.Builder, data *WorkflowData, paths []string, prefix string) {
 	if len(paths) == 0 {
 		compilerYamlArtifactsLog.Print("No paths to upload, skipping unified artifact upload")
 		return
@@ -53,6 +54,9 @@ func (c *Compiler) generateUnifiedArtifactUpload(yaml *strings.Builder, paths []
 	}
 
 	yaml.WriteString("          if-no-files-found: ignore\n")
+	if line := artifactRetentionDaysLine(data, "          "); line != "" {
+		yaml.WriteString(line + "\n")
+	}
 
 	compilerYamlArtifactsLog.Printf("Generated unified artifact upload step with %d paths", len(paths))
 }
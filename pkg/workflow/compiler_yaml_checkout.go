@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
 )
 
 // generateInitialAndCheckoutSteps emits the OTLP mask step, pre-steps, all checkout steps
@@ -159,7 +161,7 @@ func (c *Compiler) generateRepositoryImportCheckouts(yaml *strings.Builder, repo
 		// Format: owner/repo@ref or owner/repo
 		owner, repo, ref := parseRepositoryImportSpec(repoImport)
 		if owner == "" || repo == "" {
-			compilerYamlLog.Printf("Warning: failed to parse repository import: %s", repoImport)
+			compilerYamlLog.Warnf("failed to parse repository import: %s", repoImport)
 			continue
 		}
 
@@ -224,13 +226,13 @@ func (c *Compiler) generateLegacyAgentImportCheckout(yaml *strings.Builder, agen
 	// Parse the import spec to extract owner, repo, and ref
 	owner, repo, ref := parseRepositoryImportSpec(agentImportSpec)
 	if owner == "" || repo == "" {
-		compilerYamlLog.Printf("Warning: failed to parse legacy agent import spec: %s", agentImportSpec)
+		compilerYamlLog.Warnf("failed to parse legacy agent import spec: %s", agentImportSpec)
 		return
 	}
 
 	// Generate a sanitized directory name for the checkout
 	sanitizedRef := sanitizeRefForPath(ref)
-	checkoutPath := fmt.Sprintf("/tmp/gh-aw/repo-imports/%s-%s-%s", owner, repo, sanitizedRef)
+	checkoutPath := fmt.Sprintf(constants.TmpGhAwDirExpr+"/repo-imports/%s-%s-%s", owner, repo, sanitizedRef)
 
 	// Generate the checkout step
 	fmt.Fprintf(yaml, "      - name: Checkout agent import %s/%s@%s\n", owner, repo, ref)
@@ -417,15 +417,15 @@ func TestGeneratePlaceholderSubstitutionStep(t *testing.T) {
 			name: "static quoted values not wrapped",
 			mappings: []*ExpressionMapping{
 				{EnvVar: "GH_AW_CACHE_DESCRIPTION", Content: "''"},
-				{EnvVar: "GH_AW_CACHE_DIR", Content: "'/tmp/gh-aw/cache-memory/'"},
+				{EnvVar: "GH_AW_CACHE_DIR", Content: "'__GH_AW_TMP_DIR__/cache-memory/'"},
 			},
 			expectInStr: []string{
 				"GH_AW_CACHE_DESCRIPTION: ''",
-				"GH_AW_CACHE_DIR: '/tmp/gh-aw/cache-memory/'",
+				"GH_AW_CACHE_DIR: '__GH_AW_TMP_DIR__/cache-memory/'",
 			},
 			notInStr: []string{
 				"${{ '' }}",
-				"${{ '/tmp/gh-aw/cache-memory/' }}",
+				"${{ '__GH_AW_TMP_DIR__/cache-memory/' }}",
 			},
 		},
 		{
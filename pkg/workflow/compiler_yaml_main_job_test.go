@@ -218,7 +218,7 @@ func TestGenerateLegacyAgentImportCheckout(t *testing.T) {
 				"- name: Checkout agent import github/example@main",
 				"repository: github/example",
 				"ref: main",
-				"path: /tmp/gh-aw/repo-imports/github-example-main",
+				"path: ${{ env.GH_AW_TMP_DIR }}/repo-imports/github-example-main",
 				"sparse-checkout: |",
 				".github/",
 				"persist-credentials: false",
@@ -232,7 +232,7 @@ func TestGenerateLegacyAgentImportCheckout(t *testing.T) {
 				"Checkout agent import org/repo@feature/branch",
 				"repository: org/repo",
 				"ref: feature/branch",
-				"path: /tmp/gh-aw/repo-imports/org-repo-feature-branch",
+				"path: ${{ env.GH_AW_TMP_DIR }}/repo-imports/org-repo-feature-branch",
 			},
 			shouldGenerate: true,
 		},
@@ -632,7 +632,7 @@ func TestGenerateMainJobSteps(t *testing.T) {
 			},
 			expectInOutput: []string{
 				"- name: Checkout repository",
-				"/tmp/gh-aw/aw-*.patch", // Git patch glob path should be collected
+				"${{ env.GH_AW_TMP_DIR }}/aw-*.patch", // Git patch glob path should be collected
 			},
 			shouldError: false,
 		},
@@ -653,7 +653,7 @@ func TestGenerateMainJobSteps(t *testing.T) {
 			},
 			expectInOutput: []string{
 				"- name: Checkout agent import github/example@main",
-				"path: /tmp/gh-aw/repo-imports/github-example-main",
+				"path: ${{ env.GH_AW_TMP_DIR }}/repo-imports/github-example-main",
 				"- name: Merge remote .github folder",
 				"GH_AW_AGENT_FILE:",
 				"GH_AW_AGENT_IMPORT_SPEC:",
@@ -33,11 +33,11 @@ func (c *Compiler) collectArtifactPaths(data *WorkflowData, engine CodingAgentEn
 	// tools can consume structured token data without parsing the step summary.
 	// Requires AWF v0.25.8+
 	if isFirewallEnabled(data) {
-		paths = append(paths, constants.TmpGhAwDirSlash+constants.TokenUsageFilename)
+		paths = append(paths, constants.TmpGhAwDirExprSlash+constants.TokenUsageFilename)
 	}
 
-	// Collect agent stdio logs path for unified upload
-	paths = append(paths, logFileFull)
+	// Collect the agent stdio log and its separate stdout and stderr logs for unified upload
+	paths = append(paths, engineLogFiles(logFileFull)...)
 
 	// Include the pre-agent audit file (file listing of agent-related directories captured
 	// before agent execution) so it is available in the agent artifact for post-run inspection.
@@ -50,23 +50,23 @@ func (c *Compiler) collectArtifactPaths(data *WorkflowData, engine CodingAgentEn
 
 	// Collect GitHub API rate-limit log for observability.
 	// Written by github_rate_limit_logger.cjs during REST API calls.
-	paths = append(paths, constants.TmpGhAwDirSlash+constants.GithubRateLimitsFilename)
+	paths = append(paths, constants.TmpGhAwDirExprSlash+constants.GithubRateLimitsFilename)
 
 	// Collect OTLP span mirror — enables post-hoc trace debugging without a live collector.
 	// Written by send_otlp_span.cjs; each line is a full OTLP/HTTP JSON traces payload.
 	// Only included when OTLP is configured for this workflow.
 	if isOTLPEnabled(data) {
-		paths = append(paths, constants.TmpGhAwDirSlash+constants.OtelJsonlFilename)
-		paths = append(paths, constants.TmpGhAwDirSlash+constants.OtlpExportErrorsFilename)
+		paths = append(paths, constants.TmpGhAwDirExprSlash+constants.OtelJsonlFilename)
+		paths = append(paths, constants.TmpGhAwDirExprSlash+constants.OtlpExportErrorsFilename)
 	}
 
 	// Collect safe outputs and agent output paths for the unified artifact.
 	// These were previously uploaded as separate safe-output and agent-output artifacts.
 	if data.SafeOutputs != nil {
 		// Raw safe-output NDJSON (copied to /tmp/gh-aw/ by generateOutputCollectionStep)
-		paths = append(paths, constants.TmpGhAwDirSlash+constants.SafeOutputsFilename)
+		paths = append(paths, constants.TmpGhAwDirExprSlash+constants.SafeOutputsFilename)
 		// Processed agent output JSON produced by collect_ndjson_output.cjs
-		paths = append(paths, constants.TmpGhAwDirSlash+constants.AgentOutputFilename)
+		paths = append(paths, constants.TmpGhAwDirExprSlash+constants.AgentOutputFilename)
 		if data.SafeOutputs.CommentMemory != nil {
 			paths = append(paths, constants.TmpCommentMemoryDir)
 		}
@@ -101,6 +101,7 @@ func (c *Compiler) collectArtifactPaths(data *WorkflowData, engine CodingAgentEn
 			paths = append(paths, constants.AWFProxyLogsDirExpr+"/")
 			paths = append(paths, constants.AWFAuditDirExpr+"/")
 			paths = append(paths, constants.AWFReflectFilePathExpr)
+			paths = append(paths, constants.AWFBlockedRequestsFilePathExpr)
 		} else {
 			paths = append(paths, constants.AWFConfigFilePath)
 			paths = append(paths, constants.AWFProxyLogsDir+"/")
@@ -109,6 +110,8 @@ func (c *Compiler) collectArtifactPaths(data *WorkflowData, engine CodingAgentEn
 			// Co-located under /tmp/gh-aw/sandbox/firewall/ so the existing
 			// chmod -R a+rX step covers its permissions before upload.
 			paths = append(paths, constants.AWFReflectFilePath)
+			// Include the denied egress attempts recorded from the proxy logs.
+			paths = append(paths, constants.AWFBlockedRequestsFilePath)
 		}
 	}
 
@@ -148,6 +151,9 @@ func (c *Compiler) generateSummarySteps(yaml *strings.Builder, data *WorkflowDat
 			yaml.WriteString(line)
 			yaml.WriteByte('\n')
 		}
+		// Record the denied egress attempts for gh aw logs and audit. This runs after
+		// the firewall log step, which reclaims ownership of the firewall directory.
+		c.generateFirewallBlockedRequests(yaml, data)
 	}
 
 	// Parse token-usage.jsonl and append to step summary (requires AWF v0.25.8+)
@@ -197,6 +203,9 @@ func (c *Compiler) generatePostAgentCollectionAndUpload(yaml *strings.Builder, d
 		c.generateAgentOutputPlaceholderStep(yaml)
 	}
 
+	// Save the request and agent output for the next invocation of a sticky slash command
+	generateStickyContextSaveSteps(yaml, data)
+
 	// Add post-execution cleanup step for Copilot engine
 	if copilotEngine, ok := engine.(*CopilotEngine); ok {
 		cleanupStep := copilotEngine.GetCleanupStep(data)
@@ -217,6 +226,11 @@ func (c *Compiler) generatePostAgentCollectionAndUpload(yaml *strings.Builder, d
 	// This validates file types before cache is saved or uploaded
 	generateCacheMemoryValidation(yaml, data)
 
+	// Copy cache-memory and the scratch paths of frontmatter caches to the fixed paths
+	// saved by the actions/cache post steps
+	generateCacheMemoryStagingForSave(yaml, data)
+	generateCacheStagingForSave(yaml, data)
+
 	// Add cache-memory artifact upload (after agent execution)
 	// This ensures artifacts are uploaded after the agent has finished modifying the cache
 	generateCacheMemoryArtifactUpload(yaml, data, c.getActionPin)
@@ -230,6 +244,10 @@ func (c *Compiler) generatePostAgentCollectionAndUpload(yaml *strings.Builder, d
 	// to be downloaded and processed by the upload_artifact job
 	generateSafeOutputsArtifactStagingUpload(yaml, data, c.getActionPin)
 
+	// Add the artifacts tool collection and upload (after agent execution)
+	// This uploads the files the agent placed in the artifacts directory that pass the limits
+	generateArtifactsToolUploadSteps(yaml, data, c.getActionPin)
+
 	// Add post-steps (if any) after AI execution
 	c.generatePostSteps(yaml, data)
 
@@ -242,11 +260,25 @@ func (c *Compiler) generatePostAgentCollectionAndUpload(yaml *strings.Builder, d
 		c.generateArcDindArtifactConsolidationStep(yaml)
 	}
 
+	// Redact the artifact files once more right before upload. The summary steps and
+	// post-steps above write files (firewall logs, blocked-requests.json, token usage)
+	// after "Redact secrets in logs" ran. This pass is not recorded with the step-order
+	// tracker: it would move the redaction point past the earlier memory uploads.
+	if len(artifactPaths) > 0 {
+		for _, line := range buildArtifactSecretRedactionStep("Redact secrets in agent artifacts", "always()", textArtifactPaths(artifactPaths), CollectSecretReferences(yaml.String()), data) {
+			yaml.WriteString(line)
+		}
+	}
+
 	// Generate single unified artifact upload with all collected paths.
 	// In workflow_call context, apply the per-invocation prefix to avoid name clashes.
 	agentArtifactPrefix := artifactPrefixExprForDownstreamJob(data)
+	// With artifact-encryption, transcripts are uploaded encrypted in their own artifact
+	// because downstream jobs read the plaintext agent artifact.
+	artifactPaths, transcriptPaths := splitEncryptedArtifactPaths(data, engine, logFileFull, artifactPaths)
+	c.generateEncryptedTranscriptsUpload(yaml, data, transcriptPaths, agentArtifactPrefix)
 	compilerYamlLog.Printf("Emitting unified agent artifact upload with %d path(s)", len(artifactPaths))
-	c.generateUnifiedArtifactUpload(yaml, artifactPaths, agentArtifactPrefix)
+	c.generateUnifiedArtifactUpload(yaml, data, artifactPaths, agentArtifactPrefix)
 
 	// In dev mode the setup action is referenced via a local path (./actions/setup), so its files
 	// live in the workspace. When a checkout: entry targets an external repository without a path
@@ -59,11 +59,16 @@ func (c *Compiler) generatePrompt(yaml *strings.Builder, data *WorkflowData, pre
 	// Build main workflow content chunks (inline embed or runtime-import macro) and
 	// collect any additional expression mappings from inlined markdown.
 	userPromptChunks, expressionMappings = c.buildMainWorkflowPromptChunks(data, userPromptChunks, expressionMappings)
+	rewriteSlashCommandActivationOutputs(expressionMappings, data)
+	resolvePromptContextMappings(expressionMappings, data.PromptContext)
 
 	// Enhance entity number expressions with || inputs.item_number fallback when the
 	// workflow has a workflow_dispatch trigger with item_number.
 	applyWorkflowDispatchFallbacks(expressionMappings, data.HasDispatchItemNumber)
 
+	// Resolve runtime-only values (current time) referenced by the run context section.
+	c.generateRunContextStep(yaml, data)
+
 	allExpressionMappings := c.generateUnifiedPromptCreationStep(yaml, builtinSections, userPromptChunks, expressionMappings, data)
 
 	// Merge all known needs.* expressions for the substitution step.
@@ -212,7 +217,7 @@ func (c *Compiler) processOrderedPromptImports(data *WorkflowData) (userPromptCh
 		if workspaceRoot != "" {
 			rawContent, err := os.ReadFile(filepath.Join(workspaceRoot, importPath))
 			if err != nil {
-				compilerYamlPromptLog.Printf("Warning: failed to read import file %s (%v), falling back to runtime-import", importPath, err)
+				compilerYamlPromptLog.Warnf("failed to read import file %s (%v), falling back to runtime-import", importPath, err)
 				userPromptChunks = append(userPromptChunks, fmt.Sprintf("{{#runtime-import %s}}", importPath))
 				continue
 			}
@@ -262,7 +267,7 @@ func (c *Compiler) processLegacyPromptImports(data *WorkflowData) (userPromptChu
 			rawContent, err := os.ReadFile(filepath.Join(workspaceRoot, importPath))
 			if err != nil {
 				// Fall back to runtime-import macro if file cannot be read
-				compilerYamlPromptLog.Printf("Warning: failed to read import file %s (%v), falling back to runtime-import", importPath, err)
+				compilerYamlPromptLog.Warnf("failed to read import file %s (%v), falling back to runtime-import", importPath, err)
 				userPromptChunks = append(userPromptChunks, fmt.Sprintf("{{#runtime-import %s}}", importPath))
 				continue
 			}
@@ -293,7 +298,7 @@ func (c *Compiler) processLegacyPromptImports(data *WorkflowData) (userPromptChu
 func writePromptBashStep(yaml *strings.Builder, name, script string) {
 	fmt.Fprintf(yaml, "      - name: %s\n", name)
 	yaml.WriteString("        env:\n")
-	yaml.WriteString("          GH_AW_PROMPT: /tmp/gh-aw/aw-prompts/prompt.txt\n")
+	yaml.WriteString("          GH_AW_PROMPT: " + constants.TmpGhAwDirExpr + "/aw-prompts/prompt.txt\n")
 	yaml.WriteString("        # poutine:ignore untrusted_checkout_exec\n")
 	fmt.Fprintf(yaml, "        run: bash \"${RUNNER_TEMP}/gh-aw/actions/%s\"\n", script)
 }
@@ -56,15 +56,25 @@ func (c *Compiler) generateRuntimeAndWorkspaceSetupSteps(yaml *strings.Builder,
 	c.generateActivationArtifactAndCommentMemorySteps(yaml, data)
 
 	// Add cache-memory steps before custom steps so that user steps: code can read
-	// /tmp/gh-aw/cache-memory/<key>/ without an LLM turn.
+	// ${GH_AW_TMP_DIR}/cache-memory/<key>/ without an LLM turn.
 	compilerYamlLog.Printf("Generating cache-memory steps for workflow")
 	generateCacheMemorySteps(yaml, data)
 
 	// Add repo-memory clone steps before custom steps so that user steps: code can read
-	// /tmp/gh-aw/repo-memory/<name>/ without an LLM turn.
+	// ${GH_AW_TMP_DIR}/repo-memory/<name>/ without an LLM turn.
 	compilerYamlLog.Printf("Generating repo-memory steps for workflow")
 	generateRepoMemorySteps(yaml, data)
 
+	// Create the directory the agent writes files to publish with the artifacts tool.
+	generateArtifactsToolDirStep(yaml, data)
+
+	// Fetch files and issues of the repos: context repositories before custom steps so
+	// that user steps: code can read /tmp/gh-aw/repos/<name>/ as well.
+	c.generateContextRepoSteps(yaml, data)
+
+	// Install the backport cherry-pick helper before custom steps so user steps can use it too.
+	generateBackportHelperSteps(yaml, data)
+
 	c.emitCustomSteps(yaml, data, customStepsContainCheckout, runtimeSetupSteps)
 
 	// Add cache steps if cache configuration is present. Keep workspace caches after user
@@ -87,7 +97,7 @@ func (c *Compiler) prepareRuntimeSetupAndCheckoutInfo(data *WorkflowData) ([]Git
 	if len(runtimeRequirements) > 0 && data.CustomSteps != "" {
 		deduplicatedCustomSteps, filteredRequirements, err := DeduplicateRuntimeSetupStepsFromCustomSteps(data.CustomSteps, runtimeRequirements)
 		if err != nil {
-			compilerYamlLog.Printf("Warning: failed to deduplicate runtime setup steps: %v", err)
+			compilerYamlLog.Warnf("failed to deduplicate runtime setup steps: %v", err)
 		} else {
 			data.CustomSteps = deduplicatedCustomSteps
 			runtimeRequirements = filteredRequirements
@@ -227,10 +237,14 @@ func (c *Compiler) generateActivationArtifactAndCommentMemorySteps(yaml *strings
 	fmt.Fprintf(yaml, "        uses: %s\n", c.getActionPin("actions/download-artifact"))
 	yaml.WriteString("        with:\n")
 	fmt.Fprintf(yaml, "          name: %s\n", activationArtifactName)
-	yaml.WriteString("          path: /tmp/gh-aw\n")
+	yaml.WriteString("          path: " + constants.TmpGhAwDirExpr + "\n")
+
+	// The prompt was built in the activation job, whose scratch directory differs from
+	// this job's; point the framework prompt text at this job's scratch directory.
+	writePromptBashStep(yaml, "Resolve scratch directory in prompt", "resolve_prompt_tmp_dir.sh")
 
 	// Materialize comment-memory safe outputs as editable markdown files BEFORE user steps.
-	// This prepares /tmp/gh-aw/comment-memory/*.md from prior comment history and injects
+	// This prepares ${GH_AW_TMP_DIR}/comment-memory/*.md from prior comment history and injects
 	// prompt guidance so the agent can update files directly and persist them via the
 	// comment_memory safe output.
 	if data.SafeOutputs == nil || data.SafeOutputs.CommentMemory == nil {
@@ -279,7 +293,7 @@ func (c *Compiler) generateCommentMemoryEarlyConfigStep(yaml *strings.Builder, d
 func (c *Compiler) generateCommentMemoryEarlyConfigLines(data *WorkflowData) ([]string, bool) {
 	builder := handlerRegistry[commentMemoryHandlerKey]
 	if builder == nil {
-		compilerYamlLog.Printf("Warning: %s handler not found in registry; skipping early config write", commentMemoryHandlerKey)
+		compilerYamlLog.Warnf("%s handler not found in registry; skipping early config write", commentMemoryHandlerKey)
 		return nil, false
 	}
 	cfg := builder(data.SafeOutputs)
@@ -298,13 +312,13 @@ func (c *Compiler) generateCommentMemoryEarlyConfigLines(data *WorkflowData) ([]
 	configMap := map[string]any{commentMemoryHandlerKey: cfg}
 	jsonBytes, err := json.Marshal(configMap)
 	if err != nil {
-		compilerYamlLog.Printf("Warning: failed to marshal comment-memory config: %v", err)
+		compilerYamlLog.Warnf("failed to marshal comment-memory config: %v", err)
 		return nil, false
 	}
 	configJSON := string(jsonBytes)
 	delimiter := GenerateHeredocDelimiterFromContent("COMMENT_MEMORY_CONFIG", configJSON)
 	if err := ValidateHeredocContent(configJSON, delimiter); err != nil {
-		compilerYamlLog.Printf("Warning: comment-memory config contains heredoc delimiter; skipping early config write: %v", err)
+		compilerYamlLog.Warnf("comment-memory config contains heredoc delimiter; skipping early config write: %v", err)
 		return nil, false
 	}
 	var lines []string
@@ -423,7 +437,7 @@ func (c *Compiler) addCustomStepsWithRuntimeInsertion(yaml *strings.Builder, cus
 func (c *Compiler) sanitizeAndWarnCustomSteps(customSteps string) string {
 	sanitized, warnings, err := sanitizeCustomStepsYAML(customSteps)
 	if err != nil {
-		compilerYamlLog.Printf("Failed to sanitize custom steps YAML: %v", err)
+		compilerYamlLog.Warnf("Failed to sanitize custom steps YAML: %v", err)
 		return customSteps
 	}
 	for _, w := range warnings {
@@ -37,7 +37,8 @@ func (c *Compiler) generateCheckoutActionsFolder(data *WorkflowData) []string {
 	// that were added after the latest tag.
 	ref := versionToGitRef(c.version)
 
-	// Script mode: checkout .github folder from github/gh-aw to /tmp/gh-aw/actions-source/
+	// Script mode: checkout .github folder from github/gh-aw to ${RUNNER_TEMP}/gh-aw/actions-source/.
+	// The checkout runs before setup.sh creates the job scratch directory, so it lives in RUNNER_TEMP.
 	if c.actionMode.IsScript() {
 		lines := []string{
 			"      - name: Checkout actions folder\n",
@@ -51,7 +52,7 @@ func (c *Compiler) generateCheckoutActionsFolder(data *WorkflowData) []string {
 		lines = append(lines,
 			"          sparse-checkout: |\n",
 			"            actions\n",
-			"          path: /tmp/gh-aw/actions-source\n",
+			"          path: "+constants.GhAwRootDir+"/actions-source\n",
 			"          fetch-depth: 1\n",
 			"          clean: false\n",
 			"          persist-credentials: false\n",
@@ -191,7 +192,7 @@ func (c *Compiler) generateSetupStepWithArtifactClientCondition(data *WorkflowDa
 			"      - name: Setup Scripts\n",
 			"        id: setup\n",
 			"        run: |\n",
-			"          bash /tmp/gh-aw/actions-source/actions/setup/setup.sh\n",
+			"          bash \"" + constants.GhAwRootDirShell + "/actions-source/actions/setup/setup.sh\"\n",
 			"        env:\n",
 			fmt.Sprintf("          INPUT_DESTINATION: %s\n", destination),
 			"          INPUT_JOB_NAME: ${{ github.job }}\n",
@@ -303,7 +304,7 @@ func (c *Compiler) generateSetRuntimePathsStep() []string {
 }
 
 // generateScriptModeCleanupStep generates a cleanup step for script mode that sends an OTLP
-// conclusion span and removes /tmp/gh-aw/. This mirrors the post.js post step that runs
+// conclusion span and removes the job scratch directory. This mirrors the post.js post step that runs
 // automatically when using a `uses:` action in dev/release/action mode.
 //
 // The step is guarded by `if: always()` so it runs even if prior steps fail, ensuring
@@ -316,7 +317,7 @@ func (c *Compiler) generateScriptModeCleanupStep() string {
 	step.WriteString("      - name: Clean Scripts\n")
 	step.WriteString("        if: always()\n")
 	step.WriteString("        run: |\n")
-	step.WriteString("          bash /tmp/gh-aw/actions-source/actions/setup/clean.sh\n")
+	step.WriteString("          bash \"" + constants.GhAwRootDirShell + "/actions-source/actions/setup/clean.sh\"\n")
 	step.WriteString("        env:\n")
 	fmt.Fprintf(&step, "          INPUT_DESTINATION: %s\n", SetupActionDestination)
 	step.WriteString("          INPUT_JOB_NAME: ${{ github.job }}\n")
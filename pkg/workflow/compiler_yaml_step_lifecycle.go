@@ -226,7 +226,7 @@ func (c *Compiler) generateCreateAwInfo(yaml *strings.Builder, data *WorkflowDat
 			escapedSkillsJSON := strings.ReplaceAll(string(skillsJSON), "'", "''")
 			fmt.Fprintf(yaml, "          GH_AW_INFO_SKILLS: '%s'\n", escapedSkillsJSON)
 		} else {
-			compilerYamlStepLifecycleLog.Printf("Failed to marshal skills for GH_AW_INFO_SKILLS, engine will not receive skill list: %v", err)
+			compilerYamlStepLifecycleLog.Warnf("Failed to marshal skills for GH_AW_INFO_SKILLS, engine will not receive skill list: %v", err)
 		}
 	}
 	fmt.Fprintf(yaml, "        uses: %s\n", getCachedActionPin("actions/github-script", data))
@@ -246,8 +246,8 @@ func (c *Compiler) generateOutputCollectionStep(yaml *strings.Builder, data *Wor
 	yaml.WriteString("        env:\n")
 	yaml.WriteString("          GH_AW_SAFE_OUTPUTS: ${{ steps.set-runtime-paths.outputs.GH_AW_SAFE_OUTPUTS }}\n")
 	yaml.WriteString("        run: |\n")
-	fmt.Fprintf(yaml, "          mkdir -p /tmp/gh-aw\n")
-	fmt.Fprintf(yaml, "          cp \"$GH_AW_SAFE_OUTPUTS\" /tmp/gh-aw/%s 2>/dev/null || true\n", constants.SafeOutputsFilename)
+	fmt.Fprintf(yaml, "          mkdir -p "+constants.TmpGhAwDirExpr+"\n")
+	fmt.Fprintf(yaml, "          cp \"$GH_AW_SAFE_OUTPUTS\" "+constants.TmpGhAwDirExpr+"/%s 2>/dev/null || true\n", constants.SafeOutputsFilename)
 
 	yaml.WriteString("      - name: Ingest agent output\n")
 	yaml.WriteString("        id: collect_output\n")
@@ -296,6 +296,12 @@ func (c *Compiler) generateOutputCollectionStep(yaml *strings.Builder, data *Wor
 	yaml.WriteString("          GITHUB_SERVER_URL: ${{ github.server_url }}\n")
 	yaml.WriteString("          GITHUB_API_URL: ${{ github.api_url }}\n")
 
+	// Privacy mode: restore mentions of allowlisted maintainers from their pseudonyms
+	if data.Privacy.pseudonymizes() && len(data.Privacy.Maintainers) > 0 {
+		fmt.Fprintf(yaml, "          GH_AW_PRIVACY_SALT: ${{ needs.%s.outputs.%s }}\n", constants.ActivationJobName, privacySaltOutput)
+		fmt.Fprintf(yaml, "          GH_AW_PRIVACY_MAINTAINERS: %q\n", strings.Join(data.Privacy.Maintainers, ","))
+	}
+
 	// Add command names for command trigger prevention in safe outputs
 	if len(data.Command) > 0 {
 		if commandsJSON, err := json.Marshal(data.Command); err == nil {
@@ -191,6 +191,11 @@ func TestDetectTextOutputUsage(t *testing.T) {
 			content:       "Body: \"${{ steps.sanitized.outputs.body }}\"",
 			expectedUsage: true,
 		},
+		{
+			name:          "with_discussion_body_usage",
+			content:       "Original post: \"${{ steps.sanitized.outputs.discussion_body }}\"",
+			expectedUsage: true,
+		},
 		// Deprecated needs.activation.outputs.* forms must also be detected so that
 		// workflows not yet migrated still compile correctly.
 		{
@@ -48,6 +48,12 @@ func GenerateJobConcurrencyConfig(workflowData *WorkflowData) string {
 		return workflowData.EngineConfig.Concurrency
 	}
 
+	// Pool members share the pool's groups regardless of trigger type.
+	if workflowData.ConcurrencyPool != nil {
+		concurrencyLog.Printf("Using concurrency pool: %s", workflowData.ConcurrencyPool.Name)
+		return generatePoolJobConcurrencyConfig(workflowData)
+	}
+
 	// Check if this workflow has special trigger handling (issues, PRs, discussions, push, command,
 	// or workflow_dispatch-only). For these cases, no default concurrency should be applied at agent level
 	if hasSpecialTriggers(workflowData) {
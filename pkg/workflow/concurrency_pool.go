@@ -0,0 +1,137 @@
+package workflow
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/typeutil"
+)
+
+var concurrencyPoolLog = logger.New("workflow:concurrency_pool")
+
+// concurrencyPoolNamePattern restricts pool names to characters that are safe to
+// embed verbatim in a concurrency group name.
+var concurrencyPoolNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+// maxConcurrencyPoolSize caps concurrency.pool.max so a pool cannot be used to
+// bypass runner quota entirely.
+const maxConcurrencyPoolSize = 20
+
+// selectPoolSlotStepID is the activation step that assigns a run to a pool slot.
+const selectPoolSlotStepID = "select-pool-slot"
+
+// poolSlotOutput is the activation job output holding the selected pool slot.
+const poolSlotOutput = "pool_slot"
+
+// claimPoolSlotStepNamePrefix starts the name of the activation step that records the slot
+// a run holds. select_pool_slot.cjs matches the same prefix.
+const claimPoolSlotStepNamePrefix = "Claim concurrency pool slot "
+
+// ConcurrencyPoolConfig describes a named concurrency pool shared by several
+// agentic workflows in the same repository (from concurrency.pool).
+type ConcurrencyPoolConfig struct {
+	Name string // pool name shared by all member workflows
+	Max  int    // maximum number of agent jobs in the pool running at once
+}
+
+// extractConcurrencyPool reads concurrency.pool from the frontmatter. The pool may be
+// given as a bare name (max 1) or as an object with name and max fields.
+// Returns nil when no pool is configured.
+func extractConcurrencyPool(frontmatter map[string]any) (*ConcurrencyPoolConfig, error) {
+	concurrencyMap, ok := frontmatter["concurrency"].(map[string]any)
+	if !ok {
+		return nil, nil
+	}
+	poolRaw, ok := concurrencyMap["pool"]
+	if !ok {
+		return nil, nil
+	}
+
+	pool := &ConcurrencyPoolConfig{Max: 1}
+	switch v := poolRaw.(type) {
+	case string:
+		pool.Name = v
+	case map[string]any:
+		if name, ok := v["name"].(string); ok {
+			pool.Name = name
+		}
+		if maxRaw, ok := v["max"]; ok {
+			maxVal, ok := typeutil.ParseIntValue(maxRaw)
+			if !ok {
+				return nil, fmt.Errorf("concurrency.pool.max must be an integer, got %v", maxRaw)
+			}
+			pool.Max = maxVal
+		}
+	default:
+		return nil, fmt.Errorf("concurrency.pool must be a pool name or an object with name and max, got %T", poolRaw)
+	}
+
+	pool.Name = strings.TrimSpace(pool.Name)
+	if !concurrencyPoolNamePattern.MatchString(pool.Name) {
+		return nil, fmt.Errorf("concurrency.pool name %q is invalid: use letters, digits, '-' and '_'", pool.Name)
+	}
+	if pool.Max < 1 || pool.Max > maxConcurrencyPoolSize {
+		return nil, fmt.Errorf("concurrency.pool.max must be between 1 and %d, got %d", maxConcurrencyPoolSize, pool.Max)
+	}
+
+	concurrencyPoolLog.Printf("Extracted concurrency pool: name=%s, max=%d", pool.Name, pool.Max)
+	return pool, nil
+}
+
+// generatePoolJobConcurrencyConfig generates the agent job concurrency block for a
+// workflow that belongs to a concurrency pool. A pool of size 1 maps to a single group;
+// larger pools are split into one group per slot, and the activation job picks the slot
+// for each run, so at most Max agent jobs in the pool run at the same time.
+func generatePoolJobConcurrencyConfig(workflowData *WorkflowData) string {
+	pool := workflowData.ConcurrencyPool
+	groupValue := "gh-aw-pool-" + pool.Name
+	if pool.Max > 1 {
+		groupValue = fmt.Sprintf("%s-${{ needs.activation.outputs.%s }}", groupValue, poolSlotOutput)
+	}
+	concurrencyPoolLog.Printf("Built pool concurrency group: %s", groupValue)
+
+	// Pool members never cancel each other: pending runs wait for a free slot.
+	concurrencyConfig := fmt.Sprintf("concurrency:\n  group: \"%s\"\n  cancel-in-progress: false", groupValue)
+	if isGroupConcurrencyQueueEnabled(workflowData) {
+		concurrencyConfig += "\n  queue: max"
+	}
+	return concurrencyConfig
+}
+
+// buildSelectPoolSlotStep generates the activation steps that assign the run to one of
+// the pool's slots. The selection step lists in-progress runs of the pool and waits until
+// a slot is free; the claim step records the chosen slot in its name so other pool members
+// can see which slots are taken. Each slot's concurrency group still admits one agent job
+// at a time, so two runs that pick the same slot at once queue instead of exceeding Max.
+func buildSelectPoolSlotStep(data *WorkflowData) []string {
+	pool := data.ConcurrencyPool
+	if pool == nil || pool.Max <= 1 {
+		return nil
+	}
+	return []string{
+		fmt.Sprintf("      - name: Select concurrency pool slot (%s)\n", pool.Name),
+		fmt.Sprintf("        id: %s\n", selectPoolSlotStepID),
+		fmt.Sprintf("        uses: %s\n", getCachedActionPin("actions/github-script", data)),
+		"        env:\n",
+		fmt.Sprintf("          GH_AW_POOL_NAME: %s\n", pool.Name),
+		fmt.Sprintf("          GH_AW_POOL_MAX: \"%d\"\n", pool.Max),
+		"        with:\n",
+		"          script: |\n",
+		"            const { setupGlobals } = require('" + SetupActionDestination + "/setup_globals.cjs');\n",
+		"            setupGlobals(core, github, context, exec, io, getOctokit);\n",
+		"            const { main } = require('" + SetupActionDestination + "/select_pool_slot.cjs');\n",
+		"            await main();\n",
+		fmt.Sprintf("      - name: %s%s/${{ steps.%s.outputs.%s }}\n", claimPoolSlotStepNamePrefix, pool.Name, selectPoolSlotStepID, poolSlotOutput),
+		"        env:\n",
+		fmt.Sprintf("          GH_AW_POOL_SLOT: ${{ steps.%s.outputs.%s }}\n", selectPoolSlotStepID, poolSlotOutput),
+		fmt.Sprintf("        run: echo \"Holding slot ${GH_AW_POOL_SLOT} of concurrency pool %s\"\n", pool.Name),
+	}
+}
+
+// hasConcurrencyPoolSlots reports whether the workflow joins a pool with more than one slot,
+// which needs actions: read in the activation job to list the pool's runs.
+func hasConcurrencyPoolSlots(data *WorkflowData) bool {
+	return data.ConcurrencyPool != nil && data.ConcurrencyPool.Max > 1
+}
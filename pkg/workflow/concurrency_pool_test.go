@@ -0,0 +1,141 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractConcurrencyPool(t *testing.T) {
+	tests := []struct {
+		name        string
+		frontmatter map[string]any
+		expected    *ConcurrencyPoolConfig
+		wantErr     string
+	}{
+		{
+			name:        "no concurrency",
+			frontmatter: map[string]any{},
+		},
+		{
+			name:        "concurrency without pool",
+			frontmatter: map[string]any{"concurrency": map[string]any{"group": "my-group"}},
+		},
+		{
+			name:        "pool name shorthand",
+			frontmatter: map[string]any{"concurrency": map[string]any{"pool": "nightly-agents"}},
+			expected:    &ConcurrencyPoolConfig{Name: "nightly-agents", Max: 1},
+		},
+		{
+			name: "pool object with max",
+			frontmatter: map[string]any{"concurrency": map[string]any{
+				"pool": map[string]any{"name": "nightly-agents", "max": uint64(3)},
+			}},
+			expected: &ConcurrencyPoolConfig{Name: "nightly-agents", Max: 3},
+		},
+		{
+			name:        "invalid pool name",
+			frontmatter: map[string]any{"concurrency": map[string]any{"pool": "nightly agents"}},
+			wantErr:     "concurrency.pool name",
+		},
+		{
+			name: "max out of range",
+			frontmatter: map[string]any{"concurrency": map[string]any{
+				"pool": map[string]any{"name": "nightly-agents", "max": 0},
+			}},
+			wantErr: "between 1 and 20",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool, err := extractConcurrencyPool(tt.frontmatter)
+			if tt.wantErr != "" {
+				require.Error(t, err, "expected an error")
+				assert.Contains(t, err.Error(), tt.wantErr, "unexpected error message")
+				return
+			}
+			require.NoError(t, err, "unexpected error")
+			assert.Equal(t, tt.expected, pool, "unexpected pool config")
+		})
+	}
+}
+
+func TestConcurrencyPoolCompilation(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "concurrency-pool-test")
+
+	compile := func(t *testing.T, name, frontmatter string) string {
+		t.Helper()
+		workflowFile := filepath.Join(tmpDir, name)
+		require.NoError(t, os.WriteFile(workflowFile, []byte(frontmatter+"\n\n# Pool\n"), 0644), "failed to write workflow")
+		require.NoError(t, NewCompiler().CompileWorkflow(workflowFile), "workflow should compile")
+		lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(workflowFile))
+		require.NoError(t, err, "failed to read lock file")
+		return string(lockContent)
+	}
+
+	t.Run("single slot pool", func(t *testing.T) {
+		lock := compile(t, "single.md", `---
+on:
+  schedule:
+    - cron: "0 3 * * *"
+concurrency:
+  pool: nightly-agents
+engine: claude
+---`)
+
+		assert.Contains(t, lock, `group: "gh-aw-pool-nightly-agents"`, "agent job should use the pool group")
+		assert.NotContains(t, lock, "pool_slot", "single slot pools need no slot selection")
+		assert.NotContains(t, lock, "pool: nightly-agents", "pool should be stripped from the lock file")
+	})
+
+	t.Run("multi slot pool", func(t *testing.T) {
+		lock := compile(t, "multi.md", `---
+on:
+  issues:
+    types: [opened]
+concurrency:
+  pool:
+    name: nightly-agents
+    max: 3
+engine: claude
+---`)
+
+		assert.Contains(t, lock, `group: "gh-aw-pool-nightly-agents-${{ needs.activation.outputs.pool_slot }}"`, "agent job should use a per-slot pool group")
+		assert.Contains(t, lock, "pool_slot: ${{ steps.select-pool-slot.outputs.pool_slot }}", "activation should expose the selected slot")
+		assert.Contains(t, lock, "require('"+SetupActionDestination+"/select_pool_slot.cjs')", "slot should be selected by the pool guard script")
+		assert.Contains(t, lock, `GH_AW_POOL_MAX: "3"`, "guard should know the pool size")
+		assert.Contains(t, lock, "- name: Claim concurrency pool slot nightly-agents/${{ steps.select-pool-slot.outputs.pool_slot }}", "activation should record the claimed slot in a step name")
+		assert.Contains(t, lock, "actions: read", "guard needs to list the pool's runs")
+		assert.Equal(t, 1, strings.Count(lock, "gh-aw-pool-nightly-agents"), "only the agent job should join the pool")
+	})
+
+	t.Run("pool conflicts with engine concurrency", func(t *testing.T) {
+		workflowFile := filepath.Join(tmpDir, "conflict.md")
+		require.NoError(t, os.WriteFile(workflowFile, []byte(`---
+on:
+  workflow_dispatch:
+concurrency:
+  pool: nightly-agents
+engine:
+  id: claude
+  concurrency:
+    group: custom
+---
+
+# Conflict
+`), 0644), "failed to write workflow")
+
+		err := NewCompiler().CompileWorkflow(workflowFile)
+		require.Error(t, err, "pool and engine.concurrency should not be combined")
+		assert.Contains(t, err.Error(), "concurrency.pool and engine.concurrency", "unexpected error message")
+	})
+}
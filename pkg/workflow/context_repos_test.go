@@ -0,0 +1,158 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractContextRepos(t *testing.T) {
+	repos, err := extractContextRepos(map[string]any{
+		"repos": map[string]any{
+			"handbook": map[string]any{
+				"repository": "acme/handbook",
+				"issues":     true,
+			},
+			"docs": map[string]any{
+				"repository":   "acme/shared-docs",
+				"ref":          "main",
+				"files":        []any{"docs/**", "README.md"},
+				"issues":       map[string]any{"labels": []any{"faq"}, "state": "all", "max": 50},
+				"github-token": "${{ secrets.DOCS_TOKEN }}",
+			},
+		},
+	})
+	require.NoError(t, err, "valid repos should parse")
+	require.Len(t, repos, 2)
+
+	assert.Equal(t, "docs", repos[0].Name, "repos should be sorted by name")
+	assert.Equal(t, "acme/shared-docs", repos[0].Repository)
+	assert.Equal(t, "main", repos[0].Ref)
+	assert.Equal(t, []string{"docs/**", "README.md"}, repos[0].Files)
+	assert.Equal(t, &ContextRepoIssuesConfig{Labels: []string{"faq"}, State: "all", Max: 50}, repos[0].Issues)
+	assert.Equal(t, "${{ secrets.DOCS_TOKEN }}", repos[0].token())
+
+	assert.Equal(t, "handbook", repos[1].Name)
+	assert.Equal(t, &ContextRepoIssuesConfig{State: "open", Max: defaultContextRepoIssuesMax}, repos[1].Issues, "issues: true should use the defaults")
+	assert.Equal(t, getEffectiveGitHubToken(""), repos[1].token(), "the default token chain should be used without github-token")
+
+	none, err := extractContextRepos(map[string]any{})
+	require.NoError(t, err)
+	assert.Nil(t, none)
+}
+
+func TestExtractContextReposErrors(t *testing.T) {
+	app := map[string]any{"client-id": "${{ vars.APP_ID }}", "private-key": "${{ secrets.APP_KEY }}"}
+	tests := []struct {
+		name    string
+		repos   any
+		wantErr string
+	}{
+		{name: "not an object", repos: []any{"acme/docs"}, wantErr: "repos must be an object"},
+		{name: "invalid name", repos: map[string]any{"Docs": map[string]any{"repository": "acme/docs", "issues": true}}, wantErr: "name must start with a lowercase letter"},
+		{name: "invalid repository", repos: map[string]any{"docs": map[string]any{"repository": "docs", "issues": true}}, wantErr: "owner/repo slug"},
+		{name: "nothing selected", repos: map[string]any{"docs": map[string]any{"repository": "acme/docs"}}, wantErr: "must select files, issues, or both"},
+		{name: "empty files", repos: map[string]any{"docs": map[string]any{"repository": "acme/docs", "files": []any{}}}, wantErr: "non-empty list of file patterns"},
+		{name: "invalid state", repos: map[string]any{"docs": map[string]any{"repository": "acme/docs", "issues": map[string]any{"state": "merged"}}}, wantErr: "open, closed, all"},
+		{name: "max out of range", repos: map[string]any{"docs": map[string]any{"repository": "acme/docs", "issues": map[string]any{"max": 1000}}}, wantErr: "between 1 and 500"},
+		{name: "label with comma", repos: map[string]any{"docs": map[string]any{"repository": "acme/docs", "issues": map[string]any{"labels": []any{"a,b"}}}}, wantErr: "must not contain a comma"},
+		{name: "token and app", repos: map[string]any{"docs": map[string]any{"repository": "acme/docs", "issues": true, "github-token": "${{ secrets.T }}", "github-app": app}}, wantErr: "cannot both be set"},
+		{name: "duplicate repository", repos: map[string]any{
+			"a": map[string]any{"repository": "acme/docs", "issues": true},
+			"b": map[string]any{"repository": "Acme/Docs", "issues": true},
+		}, wantErr: "already declared by repos.a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := extractContextRepos(map[string]any{"repos": tt.repos})
+			require.Error(t, err, "repos should be rejected")
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestApplyContextReposToGitHubTool(t *testing.T) {
+	repos := []*ContextRepoConfig{{Name: "docs", Repository: "acme/shared-docs"}, {Name: "other", Repository: "other/wiki"}, {Name: "api", Repository: "Acme/API"}}
+
+	data := &WorkflowData{
+		ContextRepos: repos,
+		Tools:        map[string]any{"github": map[string]any{"allowed-repos": []any{"acme/app", "other/*"}}},
+	}
+	applyContextReposToGitHubTool(data)
+	assert.Equal(t, []any{"acme/app", "other/*", "acme/shared-docs", "acme/api"}, data.Tools["github"].(map[string]any)["allowed-repos"],
+		"repositories not covered by an explicit list should be appended in lowercase")
+
+	data = &WorkflowData{
+		ContextRepos: repos,
+		Tools:        map[string]any{"github": map[string]any{"allowed-repos": "all"}},
+	}
+	applyContextReposToGitHubTool(data)
+	assert.Equal(t, "all", data.Tools["github"].(map[string]any)["allowed-repos"], "scope keywords should be left unchanged")
+}
+
+func TestCompileContextRepos(t *testing.T) {
+	dir := testutil.TempDir(t, "context-repos-*")
+	markdownPath := filepath.Join(dir, "triage.md")
+	workflow := `---
+on:
+  issues:
+    types: [opened]
+permissions:
+  contents: read
+  issues: read
+engine: copilot
+repos:
+  docs:
+    repository: acme/shared-docs
+    files: ["docs/**"]
+    issues:
+      labels: [faq]
+  handbook:
+    repository: acme/handbook
+    issues: true
+    github-app:
+      client-id: ${{ vars.APP_ID }}
+      private-key: ${{ secrets.APP_KEY }}
+safe-outputs:
+  add-comment:
+---
+
+# Triage
+
+Answer the question using the shared docs.
+`
+	require.NoError(t, os.WriteFile(markdownPath, []byte(workflow), 0o644))
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(markdownPath), "workflow with repos should compile")
+
+	lock, err := os.ReadFile(filepath.Join(dir, "triage.lock.yml"))
+	require.NoError(t, err)
+	lockContent := string(lock)
+
+	assert.Contains(t, lockContent, "- name: Checkout repos.docs files")
+	assert.Contains(t, lockContent, "repository: acme/shared-docs")
+	assert.Contains(t, lockContent, "sparse-checkout-cone-mode: false")
+	assert.Contains(t, lockContent, `CONTEXT_REPO_DIR: "${{ env.GH_AW_TMP_DIR }}/repos/docs/files"`, "files should be moved out of the workspace")
+	assert.Contains(t, lockContent, `chmod -R a-w "$CONTEXT_REPO_DIR"`, "files should be made read-only")
+	assert.Contains(t, lockContent, `CONTEXT_REPO_ISSUES_LABELS: "faq"`)
+	assert.NotContains(t, lockContent, "Checkout repos.handbook", "issues-only repos should not be checked out")
+
+	assert.Contains(t, lockContent, "id: context-repo-app-token-handbook")
+	assert.Contains(t, lockContent, "repositories: handbook", "the app token should be scoped to the repository")
+	assert.Contains(t, lockContent, "permission-issues: read")
+	assert.Contains(t, lockContent, "GH_TOKEN: ${{ steps.context-repo-app-token-handbook.outputs.token }}")
+
+	assert.Contains(t, lockContent, "<context-repos>", "the prompt should point the agent at the repositories")
+	assert.Contains(t, lockContent, "- issues: __GH_AW_TMP_DIR__/repos/handbook/issues.json (open issues, up to 30)")
+
+	assert.Greater(t, strings.Index(lockContent, "- name: Fetch repos.docs issues"), strings.Index(lockContent, "\n  agent:"),
+		"repositories should be fetched in the agent job")
+}
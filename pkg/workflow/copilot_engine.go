@@ -21,7 +21,7 @@ import (
 
 var copilotLog = logger.New("workflow:copilot_engine")
 
-const logsFolder = "/tmp/gh-aw/sandbox/agent/logs/"
+const logsFolder = constants.TmpGhAwDirExpr + "/sandbox/agent/logs/"
 
 // CopilotEngine represents the GitHub Copilot CLI agentic engine.
 // It provides integration with GitHub Copilot CLI for agentic workflows,
@@ -37,8 +37,8 @@ import (
 
 var copilotExecLog = logger.New("workflow:copilot_engine_execution")
 
-const customEngineCommandScriptPath = "/tmp/gh-aw/engine-command.sh"
-const agentExecutionExitCodePath = "/tmp/gh-aw/agent_execution_exit_code.txt"
+const customEngineCommandScriptPath = constants.TmpGhAwDirExpr + "/engine-command.sh"
+const agentExecutionExitCodePath = constants.TmpGhAwDirExpr + "/agent_execution_exit_code.txt"
 
 // copilotSettingsPath is the shell expression that resolves to the Copilot CLI settings
 // file at runtime. The Copilot CLI resolves its config directory as ~/.copilot, which is
@@ -94,7 +94,7 @@ func buildCopilotSettingsSetup(settingsContent string, fixOwnershipForCustomComm
 // time (matching buildCopilotSettingsCleanupTrap behavior).
 func buildCopilotSettingsCleanupAndExitCodeTrap() string {
 	return fmt.Sprintf(
-		"trap 'gh_aw_exit_code=$?; mkdir -p /tmp/gh-aw >/dev/null 2>&1 || true; printf \"%%s\" \"$gh_aw_exit_code\" > %s || true; rm -f \"%s\"' EXIT\n",
+		"trap 'gh_aw_exit_code=$?; mkdir -p "+constants.TmpGhAwDirExpr+" >/dev/null 2>&1 || true; printf \"%%s\" \"$gh_aw_exit_code\" > %s || true; rm -f \"%s\"' EXIT\n",
 		agentExecutionExitCodePath,
 		copilotSettingsPath,
 	)
@@ -253,11 +253,11 @@ func (e *CopilotEngine) buildCopilotBaseArgs(sandboxEnabled bool) []string {
 		copilotExecLog.Print("Using firewall mode with simplified arguments")
 		// Note: --add-dir "${GITHUB_WORKSPACE}" is appended raw after shellJoinArgs below
 		// to allow shell variable expansion (cannot go through shellEscapeArg).
-		return []string{"--add-dir", constants.TmpGhAwDirSlash, "--log-level", "all", "--log-dir", logsFolder}
+		return []string{"--add-dir", constants.TmpGhAwDirExprSlash, "--log-level", "all", "--log-dir", logsFolder}
 	}
 	// Original args for non-sandbox mode
 	copilotExecLog.Print("Using standard mode with full arguments")
-	return []string{"--add-dir", "/tmp/", "--add-dir", constants.TmpGhAwDirSlash, "--add-dir", constants.TmpGhAwAgentDir, "--log-level", "all", "--log-dir", logsFolder}
+	return []string{"--add-dir", "/tmp/", "--add-dir", constants.TmpGhAwDirExprSlash, "--add-dir", constants.TmpGhAwAgentDir, "--log-level", "all", "--log-dir", logsFolder}
 }
 
 func (e *CopilotEngine) buildCopilotFeatureArgs(workflowData *WorkflowData, copilotArgs []string) []string {
@@ -335,7 +335,7 @@ func (e *CopilotEngine) resolveCopilotCommand(workflowData *WorkflowData, sandbo
 		return customEngineCommandScriptPath, buildEngineCommandScriptSetup(workflowData.EngineConfig.Command)
 	}
 	if sandboxEnabled {
-		if isArcDindTopology(workflowData) {
+		if isArcDindTopology(workflowData) || isDevcontainerRuntime(workflowData) {
 			return constants.GhAwRootDirShell + "/bin/copilot", ""
 		}
 		// AWF - use the installed binary directly
@@ -501,8 +501,8 @@ func (e *CopilotEngine) buildCopilotDirectCommand(workflowData *WorkflowData, co
 	return fmt.Sprintf(`set -o pipefail
 printf '%%s' "$(date +%%s%%3N)" > %s
 touch %s
-(umask 177 && touch %s)
-%s%s 2>&1 | tee %s`, AgentCLIStartMsPath, AgentStepSummaryPath, logFile, preCommandSetup, copilotCommand, logFile)
+%s
+%s%s`, AgentCLIStartMsPath, AgentStepSummaryPath, precreateEngineLogsCommand(logFile), preCommandSetup, captureEngineOutput(copilotCommand, logFile))
 }
 
 type copilotStepEnvFlags struct {
@@ -736,7 +736,7 @@ func buildEngineCommandScriptSetup(command string) string {
 		heredocDelimiter += "_X"
 	}
 
-	return fmt.Sprintf(`mkdir -p /tmp/gh-aw
+	return fmt.Sprintf(`mkdir -p `+constants.TmpGhAwDirExpr+`
 GH_AW_PREV_UMASK="$(umask)"
 umask 0177
 cat > %s <<'%s'
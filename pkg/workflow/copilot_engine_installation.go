@@ -137,6 +137,11 @@ func (e *CopilotEngine) GetInstallationSteps(workflowData *WorkflowData) []GitHu
 		npmSteps = append(npmSteps, sdkInstallStep)
 	}
 	steps := BuildNpmEngineInstallStepsWithAWF(npmSteps, workflowData)
+	// The devcontainer does not see the runner's /usr/local/bin, so copy the CLI to
+	// ${RUNNER_TEMP}/gh-aw/bin/copilot, which is mounted into the agent container.
+	if isDevcontainerRuntime(workflowData) {
+		steps = append(steps, generateCopilotCLICopyStep("Copy Copilot CLI for devcontainer"))
+	}
 
 	return appendCopilotLSPInstallSteps(steps, workflowData)
 }
@@ -54,7 +54,7 @@ func TestCopilotEngine(t *testing.T) {
 		t.Errorf("Expected 1 declared output file, got %d", len(outputFiles))
 	}
 
-	if outputFiles[0] != "/tmp/gh-aw/sandbox/agent/logs/" {
+	if outputFiles[0] != "${{ env.GH_AW_TMP_DIR }}/sandbox/agent/logs/" {
 		t.Errorf("Expected declared output file to be logs folder, got %s", outputFiles[0])
 	}
 }
@@ -129,7 +129,7 @@ func TestCopilotEngineExecutionSteps(t *testing.T) {
 	workflowData := &WorkflowData{
 		Name: "test-workflow",
 	}
-	steps := engine.GetExecutionSteps(workflowData, "/tmp/gh-aw/test.log")
+	steps := engine.GetExecutionSteps(workflowData, "${{ env.GH_AW_TMP_DIR }}/test.log")
 
 	// GetExecutionSteps returns 1 step: copilot execution
 	if len(steps) != 1 {
@@ -144,15 +144,15 @@ func TestCopilotEngineExecutionSteps(t *testing.T) {
 	}
 
 	// When firewall is disabled, should use 'copilot' command (not npx)
-	if !strings.Contains(stepContent, "copilot") || !strings.Contains(stepContent, "--add-dir /tmp/ --add-dir /tmp/gh-aw/ --add-dir /tmp/gh-aw/agent/ --log-level all --log-dir") {
-		t.Errorf("Expected command to contain 'copilot' and '--add-dir /tmp/ --add-dir /tmp/gh-aw/ --add-dir /tmp/gh-aw/agent/ --log-level all --log-dir' in step content:\n%s", stepContent)
+	if !strings.Contains(stepContent, "copilot") || !strings.Contains(stepContent, "--add-dir /tmp/ --add-dir \"${{ env.GH_AW_TMP_DIR }}/\" --add-dir \"${{ env.GH_AW_TMP_DIR }}/agent/\" --log-level all --log-dir") {
+		t.Errorf("Expected command to contain 'copilot' and '--add-dir /tmp/ --add-dir ${{ env.GH_AW_TMP_DIR }}/ --add-dir ${{ env.GH_AW_TMP_DIR }}/agent/ --log-level all --log-dir' in step content:\n%s", stepContent)
 	}
 
-	if !strings.Contains(stepContent, "/tmp/gh-aw/test.log") {
+	if !strings.Contains(stepContent, "${{ env.GH_AW_TMP_DIR }}/test.log") {
 		t.Errorf("Expected command to contain log file name in step content:\n%s", stepContent)
 	}
 
-	if !strings.Contains(stepContent, "--prompt-file /tmp/gh-aw/aw-prompts/prompt.txt") {
+	if !strings.Contains(stepContent, "--prompt-file ${{ env.GH_AW_TMP_DIR }}/aw-prompts/prompt.txt") {
 		t.Errorf("Expected command to pass prompt file path directly, got:\n%s", stepContent)
 	}
 	if strings.Contains(stepContent, `cd "${GITHUB_WORKSPACE}" &&`) {
@@ -216,14 +216,14 @@ func TestCopilotEngineExecutionSteps(t *testing.T) {
 	if !strings.Contains(stepContent, "mkdir -p /tmp/") {
 		t.Errorf("Expected 'mkdir -p /tmp/' command in step content:\n%s", stepContent)
 	}
-	if !strings.Contains(stepContent, "mkdir -p /tmp/gh-aw/") {
-		t.Errorf("Expected 'mkdir -p /tmp/gh-aw/' command in step content:\n%s", stepContent)
+	if !strings.Contains(stepContent, "mkdir -p ${{ env.GH_AW_TMP_DIR }}/") {
+		t.Errorf("Expected 'mkdir -p ${{ env.GH_AW_TMP_DIR }}/' command in step content:\n%s", stepContent)
 	}
-	if !strings.Contains(stepContent, "mkdir -p /tmp/gh-aw/agent/") {
-		t.Errorf("Expected 'mkdir -p /tmp/gh-aw/agent/' command in step content:\n%s", stepContent)
+	if !strings.Contains(stepContent, "mkdir -p ${{ env.GH_AW_TMP_DIR }}/agent/") {
+		t.Errorf("Expected 'mkdir -p ${{ env.GH_AW_TMP_DIR }}/agent/' command in step content:\n%s", stepContent)
 	}
-	if !strings.Contains(stepContent, "mkdir -p /tmp/gh-aw/sandbox/agent/logs/") {
-		t.Errorf("Expected 'mkdir -p /tmp/gh-aw/sandbox/agent/logs/' command in step content:\n%s", stepContent)
+	if !strings.Contains(stepContent, "mkdir -p ${{ env.GH_AW_TMP_DIR }}/sandbox/agent/logs/") {
+		t.Errorf("Expected 'mkdir -p ${{ env.GH_AW_TMP_DIR }}/sandbox/agent/logs/' command in step content:\n%s", stepContent)
 	}
 }
 
@@ -680,7 +680,7 @@ func TestCopilotEngineGetLogFileForParsing(t *testing.T) {
 	engine := NewCopilotEngine()
 	logFile := engine.GetLogFileForParsing()
 
-	expected := "/tmp/gh-aw/sandbox/agent/logs/"
+	expected := "${{ env.GH_AW_TMP_DIR }}/sandbox/agent/logs/"
 	if logFile != expected {
 		t.Errorf("Expected '%s', got '%s'", expected, logFile)
 	}
@@ -1470,7 +1470,7 @@ func TestCopilotEnginePromptFilePath(t *testing.T) {
 			"bash": []any{"git status"},
 		},
 	}
-	steps := engine.GetExecutionSteps(workflowData, "/tmp/gh-aw/test.log")
+	steps := engine.GetExecutionSteps(workflowData, "${{ env.GH_AW_TMP_DIR }}/test.log")
 
 	if len(steps) != 1 {
 		t.Fatalf("Expected 1 step, got %d", len(steps))
@@ -1494,7 +1494,7 @@ func TestCopilotEnginePromptFilePath(t *testing.T) {
 		t.Fatalf("Could not find copilot command in step content:\n%s", stepContent)
 	}
 
-	if !strings.Contains(copilotCommand, "--prompt-file /tmp/gh-aw/aw-prompts/prompt.txt") {
+	if !strings.Contains(copilotCommand, "--prompt-file ${{ env.GH_AW_TMP_DIR }}/aw-prompts/prompt.txt") {
 		t.Errorf("Expected prompt to be passed via --prompt-file, got: %s", copilotCommand)
 	}
 
@@ -1677,12 +1677,12 @@ This workflow tests that Copilot log parsing uses the correct log file path.
 	lockStr := string(lockContent)
 
 	// Verify that the log parsing step uses /tmp/gh-aw/sandbox/agent/logs/ instead of agent-stdio.log
-	if !strings.Contains(lockStr, "GH_AW_AGENT_OUTPUT: /tmp/gh-aw/sandbox/agent/logs/") {
+	if !strings.Contains(lockStr, "GH_AW_AGENT_OUTPUT: ${{ env.GH_AW_TMP_DIR }}/sandbox/agent/logs/") {
 		t.Error("Expected GH_AW_AGENT_OUTPUT to be set to '/tmp/gh-aw/sandbox/agent/logs/' for Copilot engine")
 	}
 
 	// Verify that it's NOT using the agent-stdio.log path for parsing
-	if strings.Contains(lockStr, "GH_AW_AGENT_OUTPUT: /tmp/gh-aw/agent-stdio.log") {
+	if strings.Contains(lockStr, "GH_AW_AGENT_OUTPUT: ${{ env.GH_AW_TMP_DIR }}/agent-stdio.log") {
 		t.Error("Expected GH_AW_AGENT_OUTPUT to NOT use '/tmp/gh-aw/agent-stdio.log' for Copilot engine")
 	}
 
@@ -1757,7 +1757,7 @@ func TestCopilotEngineExecutionStepsWithCacheMemory(t *testing.T) {
 			},
 		},
 	}
-	steps := engine.GetExecutionSteps(workflowData, "/tmp/gh-aw/test.log")
+	steps := engine.GetExecutionSteps(workflowData, "${{ env.GH_AW_TMP_DIR }}/test.log")
 
 	if len(steps) != 1 {
 		t.Fatalf("Expected 1 step, got %d", len(steps))
@@ -1766,25 +1766,25 @@ func TestCopilotEngineExecutionStepsWithCacheMemory(t *testing.T) {
 	stepContent := strings.Join([]string(steps[0]), "\n")
 
 	// Test that mkdir commands are present for cache-memory directories
-	if !strings.Contains(stepContent, "mkdir -p /tmp/gh-aw/cache-memory/") {
-		t.Errorf("Expected 'mkdir -p /tmp/gh-aw/cache-memory/' command for default cache in step content:\n%s", stepContent)
+	if !strings.Contains(stepContent, "mkdir -p ${{ env.GH_AW_TMP_DIR }}/cache-memory/") {
+		t.Errorf("Expected 'mkdir -p ${{ env.GH_AW_TMP_DIR }}/cache-memory/' command for default cache in step content:\n%s", stepContent)
 	}
-	if !strings.Contains(stepContent, "mkdir -p /tmp/gh-aw/cache-memory-session/") {
-		t.Errorf("Expected 'mkdir -p /tmp/gh-aw/cache-memory-session/' command for session cache in step content:\n%s", stepContent)
+	if !strings.Contains(stepContent, "mkdir -p ${{ env.GH_AW_TMP_DIR }}/cache-memory-session/") {
+		t.Errorf("Expected 'mkdir -p ${{ env.GH_AW_TMP_DIR }}/cache-memory-session/' command for session cache in step content:\n%s", stepContent)
 	}
-	if !strings.Contains(stepContent, "mkdir -p /tmp/gh-aw/cache-memory-logs/") {
-		t.Errorf("Expected 'mkdir -p /tmp/gh-aw/cache-memory-logs/' command for logs cache in step content:\n%s", stepContent)
+	if !strings.Contains(stepContent, "mkdir -p ${{ env.GH_AW_TMP_DIR }}/cache-memory-logs/") {
+		t.Errorf("Expected 'mkdir -p ${{ env.GH_AW_TMP_DIR }}/cache-memory-logs/' command for logs cache in step content:\n%s", stepContent)
 	}
 
 	// Verify --add-dir flags are present for cache directories
-	if !strings.Contains(stepContent, "--add-dir /tmp/gh-aw/cache-memory/") {
-		t.Errorf("Expected '--add-dir /tmp/gh-aw/cache-memory/' in copilot args")
+	if !strings.Contains(stepContent, "--add-dir \"${{ env.GH_AW_TMP_DIR }}/cache-memory/\"") {
+		t.Errorf("Expected '--add-dir ${{ env.GH_AW_TMP_DIR }}/cache-memory/' in copilot args")
 	}
-	if !strings.Contains(stepContent, "--add-dir /tmp/gh-aw/cache-memory-session/") {
-		t.Errorf("Expected '--add-dir /tmp/gh-aw/cache-memory-session/' in copilot args")
+	if !strings.Contains(stepContent, "--add-dir \"${{ env.GH_AW_TMP_DIR }}/cache-memory-session/\"") {
+		t.Errorf("Expected '--add-dir ${{ env.GH_AW_TMP_DIR }}/cache-memory-session/' in copilot args")
 	}
-	if !strings.Contains(stepContent, "--add-dir /tmp/gh-aw/cache-memory-logs/") {
-		t.Errorf("Expected '--add-dir /tmp/gh-aw/cache-memory-logs/' in copilot args")
+	if !strings.Contains(stepContent, "--add-dir \"${{ env.GH_AW_TMP_DIR }}/cache-memory-logs/\"") {
+		t.Errorf("Expected '--add-dir ${{ env.GH_AW_TMP_DIR }}/cache-memory-logs/' in copilot args")
 	}
 }
 
@@ -2657,7 +2657,7 @@ func TestCopilotEngineHarnessScript(t *testing.T) {
 			SafeOutputs:  nil,
 		}
 
-		steps := engine.GetExecutionSteps(workflowData, "/tmp/gh-aw/agent-stdio.log")
+		steps := engine.GetExecutionSteps(workflowData, "${{ env.GH_AW_TMP_DIR }}/agent-stdio.log")
 		if len(steps) == 0 {
 			t.Fatal("Expected at least one step")
 		}
@@ -2693,7 +2693,7 @@ func TestCopilotEngineHarnessScript(t *testing.T) {
 			Tools: make(map[string]any),
 		}
 
-		steps := engine.GetExecutionSteps(workflowData, "/tmp/gh-aw/agent-stdio.log")
+		steps := engine.GetExecutionSteps(workflowData, "${{ env.GH_AW_TMP_DIR }}/agent-stdio.log")
 		if len(steps) == 0 {
 			t.Fatal("Expected at least one step")
 		}
@@ -2722,17 +2722,17 @@ func TestCopilotEngineHarnessScript(t *testing.T) {
 			Tools: make(map[string]any),
 		}
 
-		steps := engine.GetExecutionSteps(workflowData, "/tmp/gh-aw/agent-stdio.log")
+		steps := engine.GetExecutionSteps(workflowData, "${{ env.GH_AW_TMP_DIR }}/agent-stdio.log")
 		if len(steps) == 0 {
 			t.Fatal("Expected at least one step")
 		}
 
 		stepContent := strings.Join([]string(steps[0]), "\n")
 
-		if !strings.Contains(stepContent, "copilot_harness.cjs /tmp/gh-aw/engine-command.sh") {
+		if !strings.Contains(stepContent, "copilot_harness.cjs ${{ env.GH_AW_TMP_DIR }}/engine-command.sh") {
 			t.Errorf("Expected driver to run serialized engine command script, got:\n%s", stepContent)
 		}
-		if !strings.Contains(stepContent, "cat > /tmp/gh-aw/engine-command.sh <<'GH_AW_ENGINE_COMMAND_EOF'") {
+		if !strings.Contains(stepContent, "cat > ${{ env.GH_AW_TMP_DIR }}/engine-command.sh <<'GH_AW_ENGINE_COMMAND_EOF'") {
 			t.Errorf("Expected step to serialize engine.command into script via heredoc, got:\n%s", stepContent)
 		}
 		if !strings.Contains(stepContent, "GH_AW_ENGINE_COMMAND_EOF") {
@@ -2850,10 +2850,10 @@ func TestBuildEngineCommandScriptSetup(t *testing.T) {
 	if !strings.Contains(setup, `umask "$GH_AW_PREV_UMASK"`) {
 		t.Fatalf("Expected script setup to restore original umask, got:\n%s", setup)
 	}
-	if !strings.Contains(setup, "chmod 700 /tmp/gh-aw/engine-command.sh") {
+	if !strings.Contains(setup, "chmod 700 ${{ env.GH_AW_TMP_DIR }}/engine-command.sh") {
 		t.Fatalf("Expected owner-only execute permissions, got:\n%s", setup)
 	}
-	if !strings.Contains(setup, "cat > /tmp/gh-aw/engine-command.sh <<'GH_AW_ENGINE_COMMAND_EOF'") {
+	if !strings.Contains(setup, "cat > ${{ env.GH_AW_TMP_DIR }}/engine-command.sh <<'GH_AW_ENGINE_COMMAND_EOF'") {
 		t.Fatalf("Expected heredoc-based script materialization, got:\n%s", setup)
 	}
 	if !strings.Contains(setup, "set -eo pipefail") {
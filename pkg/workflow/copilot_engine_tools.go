@@ -6,6 +6,7 @@
 //     Converts workflow tool configurations into --allow-tool flags for Copilot CLI.
 //     Handles bash/shell tools, edit tools, safe outputs, mcp-scripts, and MCP servers.
 //     Supports granular permissions (e.g., "github(get_file)") and server-level wildcards.
+//     Tools listed under an MCP server's "blocked" field become --deny-tool flags.
 //
 //  2. Tool Argument Comments (generateCopilotToolArgumentsComment):
 //     Generates human-readable comments documenting which tool permissions are granted.
@@ -24,6 +25,7 @@ package workflow
 import (
 	"fmt"
 	"os"
+	"slices"
 	"sort"
 	"strings"
 
@@ -75,7 +77,7 @@ func (e *CopilotEngine) computeCopilotToolArguments(tools map[string]any, safeOu
 					if cmdStr == ":*" || cmdStr == "*" {
 						// Use --allow-all-tools flag instead of individual tool permissions
 						copilotEngineToolsLog.Print("Bash wildcard detected, using --allow-all-tools")
-						return []string{"--allow-all-tools"}
+						return append([]string{"--allow-all-tools"}, computeCopilotDenyToolArguments(tools)...)
 					}
 				}
 			}
@@ -230,8 +232,9 @@ func (e *CopilotEngine) computeCopilotToolArguments(tools map[string]any, safeOu
 				// If it has specific allowed tools, add them individually
 				if allowed, hasAllowed := toolConfigMap["allowed"]; hasAllowed {
 					if allowedList, ok := allowed.([]any); ok {
+						blocked := getMCPBlockedTools(toolConfigMap)
 						for _, allowedTool := range allowedList {
-							if toolStr, ok := allowedTool.(string); ok {
+							if toolStr, ok := allowedTool.(string); ok && !slices.Contains(blocked, toolStr) {
 								args = append(args, "--allow-tool", fmt.Sprintf("%s(%s)", toolName, toolStr))
 							}
 						}
@@ -265,10 +268,22 @@ func (e *CopilotEngine) computeCopilotToolArguments(tools map[string]any, safeOu
 		args = newArgs
 	}
 
+	args = append(args, computeCopilotDenyToolArguments(tools)...)
+
 	copilotEngineToolsLog.Printf("Computed %d tool arguments", len(args)/2)
 	return args
 }
 
+// computeCopilotDenyToolArguments returns --deny-tool arguments for the tools blocked on
+// MCP servers. Deny rules take precedence over --allow-tool and --allow-all-tools.
+func computeCopilotDenyToolArguments(tools map[string]any) []string {
+	var args []string
+	for _, deniedTool := range formatMCPBlockedTools(tools, "%s(%s)") {
+		args = append(args, "--deny-tool", deniedTool)
+	}
+	return args
+}
+
 // generateCopilotToolArgumentsComment generates a multi-line comment showing each tool argument.
 // This is used to document which tool permissions are being granted in the compiled workflow.
 func (e *CopilotEngine) generateCopilotToolArgumentsComment(tools map[string]any, safeOutputs *SafeOutputsConfig, mcpScripts *MCPScriptsConfig, workflowData *WorkflowData, indent string) string {
@@ -280,8 +295,13 @@ func (e *CopilotEngine) generateCopilotToolArgumentsComment(tools map[string]any
 	var comment strings.Builder
 	comment.WriteString(indent + "# Copilot CLI tool arguments (sorted):\n")
 
-	// Group flag-value pairs for better readability
+	// Group flag-value pairs for better readability. --allow-all-tools takes no value
+	// and is skipped so that any --deny-tool pairs following it stay aligned.
 	for i := 0; i < len(toolArgs); i += 2 {
+		if toolArgs[i] == "--allow-all-tools" {
+			i--
+			continue
+		}
 		if i+1 < len(toolArgs) {
 			fmt.Fprintf(&comment, "%s# %s %s\n", indent, toolArgs[i], toolArgs[i+1])
 		}
@@ -1,6 +1,9 @@
 package workflow
 
-import "github.com/github/gh-aw/pkg/logger"
+import (
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/typeutil"
+)
 
 var createCheckRunLog = logger.New("workflow:create_check_run")
 
@@ -10,12 +13,24 @@ type CreateCheckRunOutputConfig struct {
 	Summary string `yaml:"summary,omitempty"` // Optional fallback summary (max 65535 chars)
 }
 
+// defaultCheckRunAnnotationsMax is the default cap on annotations per check run.
+// The Checks API accepts at most 50 annotations per request; larger sets are sent in batches.
+const defaultCheckRunAnnotationsMax = 50
+
+// CreateCheckRunAnnotationsConfig controls file/line annotations attached to the check run
+type CreateCheckRunAnnotationsConfig struct {
+	Max      int      `yaml:"max,omitempty"`      // Maximum annotations per check run (default: 50)
+	Rules    []string `yaml:"rules,omitempty"`    // Allowed rule IDs; when set, every annotation must cite one
+	Required bool     `yaml:"required,omitempty"` // Require at least one annotation unless the conclusion is success
+}
+
 // CreateCheckRunConfig holds configuration for creating GitHub Check Runs from agent output
 type CreateCheckRunConfig struct {
 	BaseSafeOutputConfig `yaml:",inline"`
-	Target               string                      `yaml:"target,omitempty"` // Target pull request for check run attachment: "triggering", "*", or explicit PR number
-	Name                 string                      `yaml:"name,omitempty"`   // Check run name shown in the GitHub Checks UI
-	Output               *CreateCheckRunOutputConfig `yaml:"output,omitempty"` // Optional static output defaults
+	Target               string                           `yaml:"target,omitempty"`      // Target pull request for check run attachment: "triggering", "*", or explicit PR number
+	Name                 string                           `yaml:"name,omitempty"`        // Check run name shown in the GitHub Checks UI
+	Output               *CreateCheckRunOutputConfig      `yaml:"output,omitempty"`      // Optional static output defaults
+	Annotations          *CreateCheckRunAnnotationsConfig `yaml:"annotations,omitempty"` // Optional file/line annotation settings
 }
 
 // parseCreateCheckRunConfig handles create-check-run configuration
@@ -63,6 +78,11 @@ func (c *Compiler) parseCreateCheckRunConfig(outputMap map[string]any) *CreateCh
 			}
 		}
 
+		// Parse optional annotations block
+		if annotationsVal, exists := configMap["annotations"]; exists {
+			checkRunConfig.Annotations = parseCreateCheckRunAnnotationsConfig(annotationsVal)
+		}
+
 		// Parse common base fields with default max of 1
 		c.parseBaseSafeOutputConfig(configMap, &checkRunConfig.BaseSafeOutputConfig, 1)
 	} else {
@@ -75,3 +95,25 @@ func (c *Compiler) parseCreateCheckRunConfig(outputMap map[string]any) *CreateCh
 	createCheckRunLog.Printf("Parsed create-check-run config: name=%q", checkRunConfig.Name)
 	return checkRunConfig
 }
+
+// parseCreateCheckRunAnnotationsConfig parses the create-check-run annotations block.
+// A bare "annotations: true" enables annotations with defaults.
+func parseCreateCheckRunAnnotationsConfig(value any) *CreateCheckRunAnnotationsConfig {
+	annotationsCfg := &CreateCheckRunAnnotationsConfig{Max: defaultCheckRunAnnotationsMax}
+	switch v := value.(type) {
+	case bool:
+		if !v {
+			return nil
+		}
+	case map[string]any:
+		if maxVal, ok := typeutil.ParseIntValue(v["max"]); ok && maxVal > 0 {
+			annotationsCfg.Max = maxVal
+		}
+		annotationsCfg.Rules = ParseStringArrayFromConfig(v, "rules", createCheckRunLog)
+		if required, ok := v["required"].(bool); ok {
+			annotationsCfg.Required = required
+		}
+	}
+	createCheckRunLog.Printf("Parsed annotations config: max=%d, rules=%d, required=%t", annotationsCfg.Max, len(annotationsCfg.Rules), annotationsCfg.Required)
+	return annotationsCfg
+}
@@ -16,6 +16,8 @@ type CreateIssuesConfig struct {
 	Labels               []string              `yaml:"labels,omitempty"`
 	AllowedLabels        []string              `yaml:"allowed-labels,omitempty"`       // Optional list of allowed labels. If omitted, any labels are allowed (including creating new ones).
 	AllowedFields        []string              `yaml:"allowed-fields,omitempty"`       // Optional list of allowed issue field names. If omitted or empty, any issue fields are allowed. Use ["*"] to explicitly allow all.
+	AllowedTypes         []string              `yaml:"allowed-types,omitempty"`        // Optional list of issue type names the agent can set. If omitted, any issue type is allowed.
+	MaxSubIssues         int                   `yaml:"max-sub-issues,omitempty"`       // Maximum number of issues created in a run that can be linked under the same parent (0 = no limit)
 	Assignees            []string              `yaml:"assignees,omitempty"`            // List of users/bots to assign the issue to
 	DeduplicateByTitle   *TemplatableBoolOrInt `yaml:"deduplicate-by-title,omitempty"` // When true or 0, deduplicate by exact title match. When set to a positive integer N, also allow fuzzy matches up to edit distance N. When false or omitted, disable title-based deduplication. Accepts GitHub Actions expressions.
 	TargetRepoSlug       string                `yaml:"target-repo,omitempty"`          // Target repository in format "owner/repo" for cross-repository issues
@@ -23,6 +25,7 @@ type CreateIssuesConfig struct {
 	CloseOlderIssues     *string               `yaml:"close-older-issues,omitempty"`   // When true, close older issues with same title prefix or labels as "not planned"
 	CloseOlderKey        string                `yaml:"close-older-key,omitempty"`      // Optional explicit deduplication key for close-older matching. When set, uses gh-aw-close-key marker instead of workflow-id markers.
 	GroupByDay           *string               `yaml:"group-by-day,omitempty"`         // When true, if an open issue was already created today (UTC), post new content as a comment on it instead of creating a duplicate. Works best with close-older-issues: true.
+	DedupeKey            string                `yaml:"dedupe-key,omitempty"`           // Optional key embedded as a gh-aw-dedupe-key marker. When an open issue with the same key exists, it is updated in place instead of creating a new issue.
 	Expires              int                   `yaml:"expires,omitempty"`              // Hours until the issue expires and should be automatically closed
 	Group                *string               `yaml:"group,omitempty"`                // If true, group issues as sub-issues under a parent issue (workflow ID is used as group identifier)
 	Footer               *string               `yaml:"footer,omitempty"`               // Controls whether AI-generated footer is added. When false, visible footer is omitted but XML markers are kept.
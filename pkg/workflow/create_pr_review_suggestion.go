@@ -0,0 +1,55 @@
+// This file provides the create-pull-request-review-suggestion safe output.
+//
+// # Review Suggestions
+//
+// The create_pull_request_review_suggestion tool lets the agent propose a replacement
+// for lines of a pull request as a GitHub suggestion block, which maintainers can apply
+// with one click:
+//
+//	safe-outputs:
+//	  create-pull-request-review-suggestion:
+//	    max: 5
+//	    target: triggering
+//
+// Suggestions are buffered together with create-pull-request-review-comment comments and
+// submitted as part of the same review. Before buffering, the handler fetches the current
+// pull request diff and rejects suggestions whose lines are not inside a single hunk on the
+// RIGHT side, and suggestions made against a head commit that is no longer the pull
+// request head.
+
+package workflow
+
+import (
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var createPRReviewSuggestionLog = logger.New("workflow:create_pr_review_suggestion")
+
+// CreatePRReviewSuggestionsConfig holds configuration for creating suggestion
+// blocks on pull request diffs from agent output
+type CreatePRReviewSuggestionsConfig struct {
+	BaseSafeOutputConfig   `yaml:",inline"`
+	SafeOutputTargetConfig `yaml:",inline"`
+	SafeOutputFilterConfig `yaml:",inline"`
+}
+
+// parsePullRequestReviewSuggestionsConfig handles create-pull-request-review-suggestion configuration
+func (c *Compiler) parsePullRequestReviewSuggestionsConfig(outputMap map[string]any) *CreatePRReviewSuggestionsConfig {
+	config := parseConfigScaffold(outputMap, "create-pull-request-review-suggestion", createPRReviewSuggestionLog, func(err error) *CreatePRReviewSuggestionsConfig {
+		createPRReviewSuggestionLog.Printf("Failed to unmarshal config: %v", err)
+		return nil
+	})
+	if config == nil {
+		return nil
+	}
+	// Suggestions are anchored to a single repository, so a wildcard target-repo is invalid
+	if config.TargetRepoSlug == "*" {
+		createPRReviewSuggestionLog.Print("Invalid target-repo: wildcard '*' is not allowed")
+		return nil
+	}
+	if config.Max == nil {
+		config.Max = defaultIntStr(10)
+	}
+	createPRReviewSuggestionLog.Printf("Parsed create-pull-request-review-suggestion config: target=%s", config.Target)
+	return config
+}
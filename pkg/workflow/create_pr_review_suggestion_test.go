@@ -0,0 +1,107 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePullRequestReviewSuggestionsConfig(t *testing.T) {
+	tests := []struct {
+		name       string
+		outputMap  map[string]any
+		wantConfig bool
+		wantMax    string
+		wantTarget string
+		wantLabels []string
+	}{
+		{
+			name:       "null config uses defaults",
+			outputMap:  map[string]any{"create-pull-request-review-suggestion": nil},
+			wantConfig: true,
+			wantMax:    "10",
+		},
+		{
+			name: "all fields",
+			outputMap: map[string]any{
+				"create-pull-request-review-suggestion": map[string]any{
+					"max":             3,
+					"target":          "*",
+					"target-repo":     "octo/app",
+					"required-labels": []any{"automated"},
+				},
+			},
+			wantConfig: true,
+			wantMax:    "3",
+			wantTarget: "*",
+			wantLabels: []string{"automated"},
+		},
+		{
+			name: "wildcard target-repo is rejected",
+			outputMap: map[string]any{
+				"create-pull-request-review-suggestion": map[string]any{"target-repo": "*"},
+			},
+			wantConfig: false,
+		},
+		{
+			name:       "not configured",
+			outputMap:  map[string]any{},
+			wantConfig: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := NewCompiler().parsePullRequestReviewSuggestionsConfig(tt.outputMap)
+			if !tt.wantConfig {
+				assert.Nil(t, config, "config should not be parsed")
+				return
+			}
+			require.NotNil(t, config, "config should be parsed")
+			require.NotNil(t, config.Max, "max should default")
+			assert.Equal(t, tt.wantMax, *config.Max, "max should match")
+			assert.Equal(t, tt.wantTarget, config.Target, "target should match")
+			assert.Equal(t, tt.wantLabels, config.RequiredLabels, "required labels should match")
+		})
+	}
+}
+
+func TestCompileCreatePullRequestReviewSuggestion(t *testing.T) {
+	dir := t.TempDir()
+	markdownPath := filepath.Join(dir, "suggest.md")
+	content := `---
+on:
+  pull_request:
+    types: [opened, synchronize]
+permissions:
+  contents: read
+  pull-requests: read
+engine: copilot
+safe-outputs:
+  create-pull-request-review-comment:
+  create-pull-request-review-suggestion:
+    max: 5
+---
+
+# Suggest fixes
+
+Suggest one-click fixes for typos in the changed lines.
+`
+	require.NoError(t, os.WriteFile(markdownPath, []byte(content), 0o644), "workflow should be written")
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(markdownPath), "workflow should compile")
+
+	lock, err := os.ReadFile(filepath.Join(dir, "suggest.lock.yml"))
+	require.NoError(t, err, "lock file should be written")
+	lockContent := string(lock)
+
+	assert.Contains(t, lockContent, `\"create_pull_request_review_suggestion\":{\"max\":5}`, "handler config should include the suggestion handler")
+	assert.Contains(t, lockContent, "pull-requests: write", "safe outputs job should be able to submit the review")
+	assert.Contains(t, lockContent, "create_pull_request_review_suggestion", "the tool should be enabled")
+}
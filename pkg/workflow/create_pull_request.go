@@ -64,6 +64,7 @@ type CreatePullRequestsConfig struct {
 	ProtectedFilesExclude          []string         `yaml:"-"`                                             // Files/prefixes to exclude from the default protected list (from object-form protected-files.exclude). Not sourced from YAML directly; populated during pre-processing.
 	AllowedFiles                   []string         `yaml:"allowed-files,omitempty"`                       // Strict allowlist of glob patterns for files eligible for create. Checked independently of protected-files; both checks must pass.
 	ExcludedFiles                  []string         `yaml:"excluded-files,omitempty"`                      // List of glob patterns for files to exclude from the patch using git :(exclude) pathspecs. Matching files are stripped by git at generation time and will not appear in the commit or be subject to allowed-files or protected-files checks.
+	GroupByDirectory               string           `yaml:"group-by-directory,omitempty"`                  // Glob pattern for grouping directories (e.g. "docs/*"). Every file in the patch must be inside a single directory matching the pattern, so each pull request covers one group.
 	PreserveBranchName             bool             `yaml:"preserve-branch-name,omitempty"`                // When true, skips the random salt suffix on agent-specified branch names. Invalid characters are still replaced for security; casing is always preserved. Useful when CI enforces branch naming conventions (e.g. Jira keys in uppercase).
 	RecreateRef                    bool             `yaml:"recreate-ref,omitempty"`                        // When true (and preserve-branch-name is true), allows the handler to force-delete an existing remote branch ref and recreate it from the agent's local HEAD. When false (default), an existing remote branch causes a fallback to issue (or push_failed). Useful for long-lived reusable branches whose previous PR was merged.
 	PatchFormat                    string           `yaml:"patch-format,omitempty"`                        // Transport format for packaging changes: "bundle" (default, uses git bundle and preserves merge topology/per-commit metadata) or "am" (uses git format-patch).
@@ -71,6 +72,7 @@ type CreatePullRequestsConfig struct {
 	AllowWorkflows                 bool             `yaml:"allow-workflows,omitempty"`                     // When true, adds workflows: write to the GitHub App token. Requires safe-outputs.github-app to be configured.
 	CloseOlderPullRequests         *string          `yaml:"close-older-pull-requests,omitempty"`           // When true, close older open pull requests with the same workflow-id marker when a new one is created. Capped at 10 closures per run.
 	CloseOlderKey                  string           `yaml:"close-older-key,omitempty"`                     // Optional explicit deduplication key for close-older matching. When set, uses gh-aw-close-key marker instead of workflow-id markers.
+	Backport                       *BackportConfig  `yaml:"-"`                                             // Backport primitives (label-selected target branches and a guarded cherry-pick helper). Parsed manually to support the boolean shorthand.
 }
 
 // parseCreatePullRequestsConfig handles only create-pull-request (singular) configuration
@@ -153,6 +155,9 @@ func (c *Compiler) parseCreatePullRequestsConfig(outputMap map[string]any) *Crea
 					config.HeadGitHubApp = parseAppConfig(headAppMap)
 				}
 			}
+
+			// Parse backport manually so that the boolean shorthand (backport: true) is supported.
+			config.Backport = parseBackportConfig(configData)
 		},
 	)
 	if config == nil {
@@ -44,13 +44,13 @@ func TestDailySafeOutputIntegratorIncludesTempCoverageScriptAllowlist(t *testing
 	if !strings.Contains(bashSection, "  - \"*\"") {
 		t.Fatal("expected bash allowlist to include wildcard access")
 	}
-	if !strings.Contains(bashSection, "  - cat > /tmp/gh-aw/agent/*.py") {
+	if !strings.Contains(bashSection, "  - cat > ${{ env.GH_AW_TMP_DIR }}/agent/*.py") {
 		t.Fatal("expected bash allowlist to include temporary coverage script creation")
 	}
 
 	for _, expected := range []string{
-		"- Create that temporary script with the edit tool or the allowed `cat > /tmp/gh-aw/agent/*.py` bash command, then run it with `python3`.",
-		"- Do not retry alternate shell-redirection paths; use only the allowed `/tmp/gh-aw/agent/` path.",
+		"- Create that temporary script with the edit tool or the allowed `cat > __GH_AW_TMP_DIR__/agent/*.py` bash command, then run it with `python3`.",
+		"- Do not retry alternate shell-redirection paths; use only the allowed `__GH_AW_TMP_DIR__/agent/` path.",
 	} {
 		if !strings.Contains(sourceContentStr, expected) {
 			t.Fatalf("expected workflow source guidance to contain %q", expected)
@@ -0,0 +1,105 @@
+// This file generates the steps that run the agent inside the repository's devcontainer
+// for sandbox.agent.devcontainer.
+//
+// The devcontainer image is built on the runner with the devcontainer CLI (the same
+// tool devcontainers/ci uses) and tagged gh-aw-devcontainer. AWF then uses it as the
+// base image of the agent container instead of chrooting into the runner filesystem,
+// so the agent's shell tool runs the project's canonical toolchain. Runner paths the
+// engine depends on are mounted at the same location:
+//   - the workspace (read-write),
+//   - $HOME, where engines keep their settings and MCP configuration,
+//   - ${RUNNER_TEMP}/gh-aw, where the engine CLI is staged (already mounted read-only).
+
+package workflow
+
+import (
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var devcontainerLog = logger.New("workflow:devcontainer")
+
+const (
+	// devcontainerImageName is the local image tag of the built devcontainer.
+	devcontainerImageName = "gh-aw-devcontainer"
+
+	// defaultDevcontainerConfigPath is the devcontainer configuration built when
+	// sandbox.agent.devcontainer does not name one.
+	defaultDevcontainerConfigPath = ".devcontainer/devcontainer.json"
+)
+
+// devcontainerSupportedEngines lists the engines whose CLI can be staged into the
+// devcontainer: npm CLIs staged under engine-cli and the Copilot CLI binary.
+var devcontainerSupportedEngines = []string{"copilot", "claude", "codex"}
+
+var devcontainerConfigPathPattern = regexp.MustCompile(`^[A-Za-z0-9._/-]+\.json$`)
+
+// getDevcontainerConfigPath returns the devcontainer configuration path relative to
+// the repository root.
+func getDevcontainerConfigPath(workflowData *WorkflowData) string {
+	agentConfig := getAgentConfig(workflowData)
+	if agentConfig == nil || agentConfig.Devcontainer == nil || agentConfig.Devcontainer.Config == "" {
+		return defaultDevcontainerConfigPath
+	}
+	return agentConfig.Devcontainer.Config
+}
+
+// isValidDevcontainerConfigPath reports whether path is a relative JSON file path
+// that stays inside the repository.
+func isValidDevcontainerConfigPath(configPath string) bool {
+	if !devcontainerConfigPathPattern.MatchString(configPath) || strings.HasPrefix(configPath, "/") {
+		return false
+	}
+	cleaned := path.Clean(configPath)
+	return cleaned != ".." && !strings.HasPrefix(cleaned, "../")
+}
+
+// generateDevcontainerBuildSteps returns the steps that install the devcontainer CLI
+// and build the agent image. They run after checkout and before the engine executes.
+func generateDevcontainerBuildSteps(workflowData *WorkflowData) []GitHubActionStep {
+	if !isDevcontainerRuntime(workflowData) {
+		return nil
+	}
+	configPath := getDevcontainerConfigPath(workflowData)
+	devcontainerLog.Printf("Generating devcontainer build steps: config=%s", configPath)
+
+	steps := GenerateNpmInstallStepsWithScope(
+		"@devcontainers/cli",
+		string(constants.DefaultDevcontainerCLIVersion),
+		"Install devcontainer CLI",
+		"devcontainer",
+		NPMInstallOptions{
+			IsGlobal:          true,
+			RunInstallScripts: false,
+			CooldownEnabled:   resolveRuntimeCooldown(workflowData, "node"),
+		},
+	)
+	steps = append(steps, GitHubActionStep([]string{
+		"      - name: Build devcontainer image",
+		"        run: |",
+		"          set -euo pipefail",
+		`          devcontainer build --workspace-folder "${GITHUB_WORKSPACE}" --config "${GITHUB_WORKSPACE}/${GH_AW_DEVCONTAINER_CONFIG}" --image-name "${GH_AW_DEVCONTAINER_IMAGE}"`,
+		"        env:",
+		"          GH_AW_DEVCONTAINER_CONFIG: " + configPath,
+		"          GH_AW_DEVCONTAINER_IMAGE: " + devcontainerImageName,
+	}))
+	return steps
+}
+
+// generateCopilotCLICopyStep returns a step that copies the installed Copilot CLI to
+// ${RUNNER_TEMP}/gh-aw/bin/copilot, a path visible to agent containers that do not
+// see the runner's /usr/local/bin (ARC/DinD runners and devcontainers).
+func generateCopilotCLICopyStep(stepName string) GitHubActionStep {
+	return GitHubActionStep([]string{
+		"      - name: " + stepName,
+		"        run: |",
+		"          mkdir -p \"${RUNNER_TEMP}/gh-aw/bin\"",
+		`          COPILOT_SRC="$(command -v copilot)"`,
+		"          cp \"$COPILOT_SRC\" \"${RUNNER_TEMP}/gh-aw/bin/copilot\"",
+		"          chmod +x \"${RUNNER_TEMP}/gh-aw/bin/copilot\"",
+	})
+}
@@ -0,0 +1,122 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsValidDevcontainerConfigPath(t *testing.T) {
+	tests := []struct {
+		path  string
+		valid bool
+	}{
+		{path: ".devcontainer/devcontainer.json", valid: true},
+		{path: ".devcontainer/ci/devcontainer.json", valid: true},
+		{path: ".devcontainer.json", valid: true},
+		{path: "/etc/devcontainer.json", valid: false},
+		{path: "../other/devcontainer.json", valid: false},
+		{path: ".devcontainer/../../devcontainer.json", valid: false},
+		{path: ".devcontainer/Dockerfile", valid: false},
+		{path: ".devcontainer/$(id).json", valid: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.valid, isValidDevcontainerConfigPath(tt.path))
+		})
+	}
+}
+
+func TestDevcontainerValidation(t *testing.T) {
+	newWorkflowData := func(engineID string, agent *AgentSandboxConfig) *WorkflowData {
+		return &WorkflowData{
+			EngineConfig:  &EngineConfig{ID: engineID},
+			SandboxConfig: &SandboxConfig{Agent: agent},
+			Tools:         map[string]any{"github": map[string]any{"mode": "remote"}},
+		}
+	}
+
+	err := validateSandboxConfig(newWorkflowData("claude", &AgentSandboxConfig{ID: "awf", Devcontainer: &DevcontainerConfig{}}))
+	require.NoError(t, err, "devcontainer with the default config should be valid")
+
+	err = validateSandboxConfig(newWorkflowData("copilot", &AgentSandboxConfig{ID: "awf", Devcontainer: &DevcontainerConfig{Config: "../devcontainer.json"}}))
+	require.ErrorContains(t, err, "relative path", "config outside the repository must be rejected")
+
+	err = validateSandboxConfig(newWorkflowData("gemini", &AgentSandboxConfig{ID: "awf", Devcontainer: &DevcontainerConfig{}}))
+	require.ErrorContains(t, err, "not supported with the gemini engine")
+
+	err = validateSandboxConfig(newWorkflowData("copilot", &AgentSandboxConfig{
+		ID:                    "awf",
+		Runtime:               AgentRuntimeDockerSbx,
+		SudoExplicitlyEnabled: true,
+		Devcontainer:          &DevcontainerConfig{},
+	}))
+	require.ErrorContains(t, err, "incompatible with runtime: docker-sbx")
+}
+
+func TestCompileDevcontainer(t *testing.T) {
+	dir := testutil.TempDir(t, "devcontainer-*")
+	markdownPath := filepath.Join(dir, "build.md")
+	workflow := `---
+on:
+  workflow_dispatch:
+engine: claude
+sandbox:
+  agent:
+    devcontainer:
+      config: .devcontainer/ci/devcontainer.json
+---
+
+# Build
+
+Run the tests and report failures.
+`
+	require.NoError(t, os.WriteFile(markdownPath, []byte(workflow), 0o644))
+	require.NoError(t, NewCompiler().CompileWorkflow(markdownPath), "devcontainer workflow should compile")
+
+	lock, err := os.ReadFile(filepath.Join(dir, "build.lock.yml"))
+	require.NoError(t, err)
+	lockContent := string(lock)
+
+	assert.Contains(t, lockContent, "- name: Build devcontainer image")
+	assert.Contains(t, lockContent, "GH_AW_DEVCONTAINER_CONFIG: .devcontainer/ci/devcontainer.json")
+	assert.Contains(t, lockContent, "- name: Install Claude Code CLI for devcontainer", "the engine CLI should be staged for the container")
+	assert.Contains(t, lockContent, `export PATH="${RUNNER_TEMP}/gh-aw/engine-cli/bin:$PATH"`)
+	assert.Contains(t, lockContent, `--mount "${GITHUB_WORKSPACE}:${GITHUB_WORKSPACE}:rw" --mount "${HOME}:${HOME}:rw"`)
+	assert.Contains(t, lockContent, `\"agentImage\":\"gh-aw-devcontainer\",\"buildLocal\":true`)
+	assert.Contains(t, lockContent, `\"security\":{\"enableHostAccess\":false}`, "the agent should not chroot into the runner")
+}
+
+func TestCompileDevcontainerCopilot(t *testing.T) {
+	dir := testutil.TempDir(t, "devcontainer-*")
+	markdownPath := filepath.Join(dir, "build.md")
+	workflow := `---
+on:
+  workflow_dispatch:
+engine: copilot
+sandbox:
+  agent:
+    devcontainer: true
+---
+
+# Build
+
+Run the tests and report failures.
+`
+	require.NoError(t, os.WriteFile(markdownPath, []byte(workflow), 0o644))
+	require.NoError(t, NewCompiler().CompileWorkflow(markdownPath), "devcontainer workflow should compile")
+
+	lock, err := os.ReadFile(filepath.Join(dir, "build.lock.yml"))
+	require.NoError(t, err)
+	lockContent := string(lock)
+
+	assert.Contains(t, lockContent, "GH_AW_DEVCONTAINER_CONFIG: .devcontainer/devcontainer.json", "the default config should be built")
+	assert.Contains(t, lockContent, "- name: Copy Copilot CLI for devcontainer")
+	assert.Contains(t, lockContent, "${RUNNER_TEMP}/gh-aw/bin/copilot", "the copied CLI should be invoked")
+}
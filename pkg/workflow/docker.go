@@ -176,32 +176,21 @@ func collectDockerImages(tools map[string]any, workflowData *WorkflowData, actio
 
 	// Collect images from custom MCP tools with container configurations
 	for toolName, toolValue := range tools {
-		if mcpConfig, ok := toolValue.(map[string]any); ok {
-			if hasMcp, _ := hasMCPConfig(mcpConfig); hasMcp {
-				// Check if this tool uses a container
-				if mcpConf, err := getMCPConfig(mcpConfig, toolName); err == nil {
-					// Check for direct container field
-					if mcpConf.Container != "" {
-						image := mcpConf.Container
-						if !setutil.Contains(imageSet, image) {
-							images = append(images, image)
-							imageSet[image] = struct {
-							}{}
-						}
-					} else if mcpConf.Command == "docker" && len(mcpConf.Args) > 0 {
-						// Extract container image from docker args
-						// Args format: ["run", "--rm", "-i", ... , "container-image"]
-						// The container image is the last arg
-						image := mcpConf.Args[len(mcpConf.Args)-1]
-						// Skip if it's a docker flag (starts with -)
-						if !strings.HasPrefix(image, "-") && !setutil.Contains(imageSet, image) {
-							images = append(images, image)
-							imageSet[image] = struct {
-							}{}
-						}
-					}
-				}
-			}
+		if image, ok := customMCPServerImage(toolName, toolValue); ok && !setutil.Contains(imageSet, image) {
+			images = append(images, image)
+			imageSet[image] = struct {
+			}{}
+		}
+	}
+
+	// Collect the Squid image used by per-server MCP egress proxies
+	if len(collectMCPEgressConfigs(tools)) > 0 {
+		squidImage := getMCPEgressProxyImage(workflowData)
+		if !setutil.Contains(imageSet, squidImage) {
+			images = append(images, squidImage)
+			imageSet[squidImage] = struct {
+			}{}
+			dockerLog.Printf("Added MCP egress proxy container: %s", squidImage)
 		}
 	}
 
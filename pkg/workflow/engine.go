@@ -96,6 +96,12 @@ type EngineConfig struct {
 	// Defaults to the repository workspace (GITHUB_WORKSPACE) when empty.
 	Cwd string
 
+	// SettingsScope selects where engines that read a settings file (e.g. Gemini's
+	// settings.json) get their generated settings written: "project" (default, the
+	// workspace), "user" (under $HOME), or "both". The user scope keeps settings
+	// available when the agent changes directories away from the workspace.
+	SettingsScope string
+
 	// Harness retry policy fields — templatable integers (literal value or ${{ expr }}).
 	// When set, the value is injected as the corresponding GH_AW_HARNESS_* env var so
 	// that all harness scripts (copilot, claude, codex) can read it from the environment.
@@ -105,8 +111,21 @@ type EngineConfig struct {
 	HarnessInitialDelayMs    string // engine.harness.initial-delay-ms   → GH_AW_HARNESS_INITIAL_DELAY_MS
 	HarnessBackoffMultiplier string // engine.harness.backoff-multiplier → GH_AW_HARNESS_BACKOFF_MULTIPLIER
 	HarnessMaxDelayMs        string // engine.harness.max-delay-ms       → GH_AW_HARNESS_MAX_DELAY_MS
+
+	// engine: auto fields. AutoPreference is engine.prefer; AutoSelection describes the engine
+	// that was selected and AutoFallback is set when no preferred engine had a secret.
+	AutoPreference []string
+	AutoSelection  string
+	AutoFallback   bool
 }
 
+// Engine settings scopes accepted by engine.settings-scope.
+const (
+	EngineSettingsScopeProject = "project"
+	EngineSettingsScopeUser    = "user"
+	EngineSettingsScopeBoth    = "both"
+)
+
 // EngineAuthConfig represents engine.auth frontmatter settings that map to
 // AWF_AUTH_* environment variables consumed by the AWF API proxy sidecar.
 type EngineAuthConfig struct {
@@ -156,11 +175,12 @@ type EngineAuthConfig struct {
 // Ecosystem identifiers in the Allowed list are expanded to their corresponding domain lists.
 // See GetAllowedDomains() for the list of supported ecosystem identifiers.
 type NetworkPermissions struct {
-	Allowed           []string        `yaml:"allowed,omitempty"` // List of allowed domains or ecosystem identifiers (e.g., "defaults", "github", "python")
-	AllowedInput      bool            `yaml:"allowed-input,omitempty"`
-	Blocked           []string        `yaml:"blocked,omitempty"`  // List of blocked domains (takes precedence over allowed)
-	Firewall          *FirewallConfig `yaml:"firewall,omitempty"` // AWF firewall configuration (see firewall.go)
-	ExplicitlyDefined bool            `yaml:"-"`                  // Internal flag: true if network field was explicitly set in frontmatter
+	Allowed           []string            `yaml:"allowed,omitempty"` // List of allowed domains or ecosystem identifiers (e.g., "defaults", "github", "python")
+	AllowedInput      bool                `yaml:"allowed-input,omitempty"`
+	Blocked           []string            `yaml:"blocked,omitempty"`  // List of blocked domains (takes precedence over allowed)
+	Firewall          *FirewallConfig     `yaml:"firewall,omitempty"` // AWF firewall configuration (see firewall.go)
+	Proxy             *NetworkProxyConfig `yaml:"proxy,omitempty"`    // Corporate proxy for runner-side traffic (see network_proxy.go)
+	ExplicitlyDefined bool                `yaml:"-"`                  // Internal flag: true if network field was explicitly set in frontmatter
 }
 
 // EngineNetworkConfig combines engine configuration with top-level network permissions
@@ -205,7 +225,16 @@ func (e *EngineConfig) GetMaxTurnCacheMisses() int {
 
 // ExtractEngineConfig extracts engine configuration from frontmatter, supporting both string and object formats.
 // It returns the resolved engine setting, the parsed engine configuration, and the resolved model string.
+// engine: auto is resolved here to the first preferred engine with a configured secret.
 func (c *Compiler) ExtractEngineConfig(frontmatter map[string]any) (string, *EngineConfig, string) {
+	engineSetting, engineConfig, model := c.extractEngineConfig(frontmatter)
+	if engineSetting == AutoEngineID && engineConfig != nil && !engineConfig.IsInlineDefinition {
+		engineSetting = c.resolveAutoEngine(engineConfig)
+	}
+	return engineSetting, engineConfig, model
+}
+
+func (c *Compiler) extractEngineConfig(frontmatter map[string]any) (string, *EngineConfig, string) {
 	topLevel := parseTopLevelEngineConfig(frontmatter)
 	engine, exists := frontmatter["engine"]
 	if !exists {
@@ -345,6 +374,7 @@ func applyReferencedEngineFields(config *EngineConfig, engineObj map[string]any,
 	applyEngineMCPField(config, engineObj)
 	applyEngineExtensionsField(config, engineObj)
 	applyEngineBooleanFields(config, engineObj)
+	applyEnginePreferField(config, engineObj)
 	applyEngineTopLevelOverrides(config, topLevel)
 }
 
@@ -447,6 +477,10 @@ func applyEngineStringFields(config *EngineConfig, engineObj map[string]any) {
 		config.Cwd = cwd
 		engineLog.Printf("Extracted engine.cwd: %s", config.Cwd)
 	}
+	if scope, ok := engineObj["settings-scope"].(string); ok && scope != "" {
+		config.SettingsScope = scope
+		engineLog.Printf("Extracted engine.settings-scope: %s", config.SettingsScope)
+	}
 }
 
 func applyEngineHarnessField(config *EngineConfig, engineObj map[string]any) {
@@ -128,7 +128,7 @@ func TestClaudeEngineWithAgentFromImports(t *testing.T) {
 		AgentFile: ".github/agents/test-agent.md",
 	}
 
-	steps := engine.GetExecutionSteps(workflowData, "/tmp/gh-aw/test.log")
+	steps := engine.GetExecutionSteps(workflowData, "${{ env.GH_AW_TMP_DIR }}/test.log")
 
 	if len(steps) != 1 {
 		t.Fatalf("Expected 1 execution step, got %d", len(steps))
@@ -150,7 +150,7 @@ func TestClaudeEngineWithAgentFromImports(t *testing.T) {
 
 	// The engine still reads the standard prompt.txt (which has agent content prepended by the compiler).
 	// With harness: --prompt-file is used instead of shell expansion.
-	if !strings.Contains(stepContent, "--prompt-file /tmp/gh-aw/aw-prompts/prompt.txt") {
+	if !strings.Contains(stepContent, "--prompt-file ${{ env.GH_AW_TMP_DIR }}/aw-prompts/prompt.txt") {
 		t.Errorf("Expected standard prompt.txt reading in claude command, got:\n%s", stepContent)
 	}
 
@@ -170,7 +170,7 @@ func TestClaudeEngineWithoutAgentFile(t *testing.T) {
 		},
 	}
 
-	steps := engine.GetExecutionSteps(workflowData, "/tmp/gh-aw/test.log")
+	steps := engine.GetExecutionSteps(workflowData, "${{ env.GH_AW_TMP_DIR }}/test.log")
 
 	if len(steps) != 1 {
 		t.Fatalf("Expected 1 execution step, got %d", len(steps))
@@ -184,7 +184,7 @@ func TestClaudeEngineWithoutAgentFile(t *testing.T) {
 	}
 
 	// Should still have the standard prompt (via --prompt-file with harness).
-	if !strings.Contains(stepContent, "--prompt-file /tmp/gh-aw/aw-prompts/prompt.txt") {
+	if !strings.Contains(stepContent, "--prompt-file ${{ env.GH_AW_TMP_DIR }}/aw-prompts/prompt.txt") {
 		t.Errorf("Expected standard prompt reading in claude command, got:\n%s", stepContent)
 	}
 }
@@ -201,7 +201,7 @@ func TestCodexEngineWithAgentFromImports(t *testing.T) {
 		AgentFile: ".github/agents/test-agent.md",
 	}
 
-	steps := engine.GetExecutionSteps(workflowData, "/tmp/gh-aw/test.log")
+	steps := engine.GetExecutionSteps(workflowData, "${{ env.GH_AW_TMP_DIR }}/test.log")
 
 	if len(steps) != 1 {
 		t.Fatalf("Expected 1 execution step, got %d", len(steps))
@@ -220,7 +220,7 @@ func TestCodexEngineWithAgentFromImports(t *testing.T) {
 
 	// The engine still reads the standard prompt.txt (which has agent content prepended by the compiler)
 	// via --prompt-file when using the default harness.
-	if !strings.Contains(stepContent, "--prompt-file /tmp/gh-aw/aw-prompts/prompt.txt") {
+	if !strings.Contains(stepContent, "--prompt-file ${{ env.GH_AW_TMP_DIR }}/aw-prompts/prompt.txt") {
 		t.Errorf("Expected --prompt-file reading in codex command, got:\n%s", stepContent)
 	}
 
@@ -240,7 +240,7 @@ func TestCodexEngineWithoutAgentFile(t *testing.T) {
 		},
 	}
 
-	steps := engine.GetExecutionSteps(workflowData, "/tmp/gh-aw/test.log")
+	steps := engine.GetExecutionSteps(workflowData, "${{ env.GH_AW_TMP_DIR }}/test.log")
 
 	if len(steps) != 1 {
 		t.Fatalf("Expected 1 execution step, got %d", len(steps))
@@ -254,7 +254,7 @@ func TestCodexEngineWithoutAgentFile(t *testing.T) {
 	}
 
 	// Should have the standard instruction reading via --prompt-file (harness handles prompt reading)
-	if !strings.Contains(stepContent, "--prompt-file /tmp/gh-aw/aw-prompts/prompt.txt") {
+	if !strings.Contains(stepContent, "--prompt-file ${{ env.GH_AW_TMP_DIR }}/aw-prompts/prompt.txt") {
 		t.Errorf("Expected --prompt-file reading in codex command, got:\n%s", stepContent)
 	}
 }
@@ -288,7 +288,7 @@ func TestCodexEngineAWFWithAgentFileReadsPromptTxt(t *testing.T) {
 		},
 	}
 
-	steps := engine.GetExecutionSteps(workflowData, "/tmp/gh-aw/agent-stdio.log")
+	steps := engine.GetExecutionSteps(workflowData, "${{ env.GH_AW_TMP_DIR }}/agent-stdio.log")
 	if len(steps) == 0 {
 		t.Fatal("Expected at least one step")
 	}
@@ -304,7 +304,7 @@ func TestCodexEngineAWFWithAgentFileReadsPromptTxt(t *testing.T) {
 	}
 
 	// The container command must still pass prompt.txt via --prompt-file (harness handles reading).
-	if !strings.Contains(stepContent, "--prompt-file /tmp/gh-aw/aw-prompts/prompt.txt") {
+	if !strings.Contains(stepContent, "--prompt-file ${{ env.GH_AW_TMP_DIR }}/aw-prompts/prompt.txt") {
 		t.Errorf("Expected codex to use --prompt-file in AWF mode, got:\n%s", stepContent)
 	}
 }
@@ -332,7 +332,7 @@ func TestGeminiEngineWithAgentFromImports(t *testing.T) {
 		Tools:     map[string]any{},
 	}
 
-	steps := engine.GetExecutionSteps(workflowData, "/tmp/gh-aw/test.log")
+	steps := engine.GetExecutionSteps(workflowData, "${{ env.GH_AW_TMP_DIR }}/test.log")
 
 	// GetExecutionSteps returns a settings step + execution step for Gemini
 	if len(steps) == 0 {
@@ -356,7 +356,7 @@ func TestGeminiEngineWithAgentFromImports(t *testing.T) {
 	}
 
 	// The execution step must read the prompt from prompt.txt
-	if !strings.Contains(combined, `"$(cat /tmp/gh-aw/aw-prompts/prompt.txt)"`) {
+	if !strings.Contains(combined, `"$(cat ${{ env.GH_AW_TMP_DIR }}/aw-prompts/prompt.txt)"`) {
 		t.Errorf("Expected Gemini to read from prompt.txt, got:\n%s", combined)
 	}
 }
@@ -381,7 +381,7 @@ func TestGeminiEngineAWFWithAgentFileReadsPromptTxt(t *testing.T) {
 		Tools: map[string]any{},
 	}
 
-	steps := engine.GetExecutionSteps(workflowData, "/tmp/gh-aw/agent-stdio.log")
+	steps := engine.GetExecutionSteps(workflowData, "${{ env.GH_AW_TMP_DIR }}/agent-stdio.log")
 	if len(steps) == 0 {
 		t.Fatal("Expected at least one step")
 	}
@@ -402,7 +402,7 @@ func TestGeminiEngineAWFWithAgentFileReadsPromptTxt(t *testing.T) {
 	}
 
 	// The command must still read from prompt.txt.
-	if !strings.Contains(combined, `"$(cat /tmp/gh-aw/aw-prompts/prompt.txt)"`) {
+	if !strings.Contains(combined, `"$(cat ${{ env.GH_AW_TMP_DIR }}/aw-prompts/prompt.txt)"`) {
 		t.Errorf("Expected gemini to read from prompt.txt in AWF mode, got:\n%s", combined)
 	}
 }
@@ -400,3 +400,65 @@ engine:
 		})
 	}
 }
+
+func TestSettingsScope_UnsupportedEngineWarningIntegration(t *testing.T) {
+	tests := []struct {
+		name        string
+		engineID    string
+		wantWarning bool
+	}{
+		{
+			name:        "gemini honors settings-scope without warning",
+			engineID:    "gemini",
+			wantWarning: false,
+		},
+		{
+			name:        "claude emits warning",
+			engineID:    "claude",
+			wantWarning: true,
+		},
+		{
+			name:        "codex emits warning",
+			engineID:    "codex",
+			wantWarning: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := testutil.TempDir(t, "test-*")
+
+			workflowContent := fmt.Sprintf(`---
+on: workflow_dispatch
+engine:
+  id: %s
+  settings-scope: user
+---
+
+# Test Settings Scope
+`, tt.engineID)
+
+			workflowPath := filepath.Join(tmpDir, "test-workflow.md")
+			require.NoError(t, os.WriteFile(workflowPath, []byte(workflowContent), 0644),
+				"should write workflow file")
+
+			compiler := NewCompiler()
+			require.NoError(t, compiler.CompileWorkflow(workflowPath),
+				"should compile without error (unsupported settings-scope is a warning, not an error)")
+
+			baseline := NewCompiler()
+			baselinePath := filepath.Join(tmpDir, "baseline.md")
+			require.NoError(t, os.WriteFile(baselinePath, []byte(strings.Replace(workflowContent, "  settings-scope: user\n", "", 1)), 0644),
+				"should write baseline workflow file")
+			require.NoError(t, baseline.CompileWorkflow(baselinePath), "should compile baseline workflow")
+
+			if tt.wantWarning {
+				assert.Greater(t, compiler.GetWarningCount(), baseline.GetWarningCount(),
+					"should emit a warning when settings-scope is specified for an engine without a settings file")
+			} else {
+				assert.Equal(t, baseline.GetWarningCount(), compiler.GetWarningCount(),
+					"should not emit a settings-scope warning for gemini")
+			}
+		})
+	}
+}
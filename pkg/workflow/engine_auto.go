@@ -0,0 +1,141 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var engineAutoLog = logger.New("workflow:engine_auto")
+
+// AutoEngineID is the engine value that selects, at compile time, the first engine of the
+// preference order whose credentials are configured as secrets in the repository.
+const AutoEngineID = "auto"
+
+// DefaultAutoEnginePreference is the preference order used by engine: auto when
+// engine.prefer is not set.
+var DefaultAutoEnginePreference = []string{
+	string(constants.CopilotEngine),
+	string(constants.ClaudeEngine),
+	string(constants.CodexEngine),
+	string(constants.GeminiEngine),
+}
+
+// SetRepositorySecretsResolver registers the callback engine: auto uses to list the secrets
+// available to the repository. The list is fetched once per compiler run.
+// Injected by the cli package, which owns the gh integration.
+func (c *Compiler) SetRepositorySecretsResolver(fn func(ctx context.Context) ([]string, error)) {
+	c.repositorySecretsResolver = fn
+}
+
+// loadRepositorySecrets returns the secrets available to the repository, or an error when
+// no resolver is registered or the secrets cannot be listed.
+func (c *Compiler) loadRepositorySecrets() (map[string]bool, error) {
+	if c.repositorySecretsLoaded {
+		return c.repositorySecrets, c.repositorySecretsErr
+	}
+	c.repositorySecretsLoaded = true
+	if c.repositorySecretsResolver == nil {
+		c.repositorySecretsErr = errors.New("repository secrets are not available in this compilation mode")
+		return nil, c.repositorySecretsErr
+	}
+	ctx := c.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	names, err := c.repositorySecretsResolver(ctx)
+	if err != nil {
+		c.repositorySecretsErr = err
+		return nil, err
+	}
+	c.repositorySecrets = make(map[string]bool, len(names))
+	for _, name := range names {
+		c.repositorySecrets[name] = true
+	}
+	engineAutoLog.Printf("Loaded %d repository secret name(s)", len(names))
+	return c.repositorySecrets, nil
+}
+
+// autoEngineSecretNames returns the secrets any of which enables engine, or nil for engines
+// without known credentials.
+func autoEngineSecretNames(engine string) []string {
+	opt := constants.GetEngineOption(engine)
+	if opt == nil {
+		return nil
+	}
+	return append([]string{opt.SecretName}, opt.AlternativeSecrets...)
+}
+
+// resolveAutoEngine replaces engine: auto in config with the first preferred engine whose
+// secret is configured and returns the selected engine ID. When no preferred engine has a
+// secret, or the secrets cannot be listed, the first preferred engine is used and the
+// fallback is recorded for reportAutoEngineSelection.
+func (c *Compiler) resolveAutoEngine(config *EngineConfig) string {
+	preference := config.AutoPreference
+	if len(preference) == 0 {
+		preference = DefaultAutoEnginePreference
+	}
+
+	secrets, err := c.loadRepositorySecrets()
+	if err == nil {
+		for _, engine := range preference {
+			for _, secret := range autoEngineSecretNames(engine) {
+				if secrets[secret] {
+					config.ID = engine
+					config.AutoSelection = fmt.Sprintf("engine: auto selected '%s' (%s is configured)", engine, secret)
+					engineAutoLog.Print(config.AutoSelection)
+					return engine
+				}
+			}
+		}
+	}
+
+	config.ID = preference[0]
+	config.AutoFallback = true
+	if err != nil {
+		config.AutoSelection = fmt.Sprintf("engine: auto could not list repository secrets (%v); using '%s', the first preferred engine. Use --engine to choose explicitly", err, config.ID)
+	} else {
+		config.AutoSelection = fmt.Sprintf("engine: auto found no secret for %s; using '%s', the first preferred engine", strings.Join(preference, ", "), config.ID)
+		if names := autoEngineSecretNames(config.ID); len(names) > 0 {
+			config.AutoSelection += fmt.Sprintf(". Configure %s to run it", names[0])
+		}
+	}
+	engineAutoLog.Print(config.AutoSelection)
+	return config.ID
+}
+
+// reportAutoEngineSelection tells the user which engine engine: auto selected, unless --engine
+// overrides it. A fallback is reported as a warning because the compiled workflow will fail
+// without the secret.
+func (c *Compiler) reportAutoEngineSelection(config *EngineConfig, markdownPath string) {
+	if config == nil || config.AutoSelection == "" || c.engineOverride != "" {
+		return
+	}
+	if config.AutoFallback {
+		fmt.Fprintln(os.Stderr, formatCompilerMessage(markdownPath, "warning", config.AutoSelection))
+		c.IncrementWarningCount()
+		return
+	}
+	if c.verbose {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage(config.AutoSelection))
+	}
+}
+
+// applyEnginePreferField reads engine.prefer, the preference order of engine: auto.
+func applyEnginePreferField(config *EngineConfig, engineObj map[string]any) {
+	prefer, ok := engineObj["prefer"].([]any)
+	if !ok {
+		return
+	}
+	for _, item := range prefer {
+		if engine, ok := item.(string); ok && engine != "" {
+			config.AutoPreference = append(config.AutoPreference, engine)
+		}
+	}
+}
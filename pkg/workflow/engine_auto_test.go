@@ -0,0 +1,128 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractEngineConfig_Auto(t *testing.T) {
+	tests := []struct {
+		name         string
+		engine       any
+		secrets      []string
+		resolverErr  error
+		noResolver   bool
+		expected     string
+		wantFallback bool
+	}{
+		{
+			name:     "default preference selects first engine with a secret",
+			engine:   "auto",
+			secrets:  []string{"OPENAI_API_KEY", "ANTHROPIC_API_KEY"},
+			expected: "claude",
+		},
+		{
+			name:     "declared preference order wins",
+			engine:   map[string]any{"id": "auto", "prefer": []any{"codex", "claude"}},
+			secrets:  []string{"ANTHROPIC_API_KEY", "OPENAI_API_KEY"},
+			expected: "codex",
+		},
+		{
+			name:     "alternative secret names are accepted",
+			engine:   map[string]any{"id": "auto", "prefer": []any{"codex"}},
+			secrets:  []string{"CODEX_API_KEY"},
+			expected: "codex",
+		},
+		{
+			name:         "no matching secret falls back to the first preferred engine",
+			engine:       map[string]any{"id": "auto", "prefer": []any{"gemini", "claude"}},
+			secrets:      []string{"UNRELATED"},
+			expected:     "gemini",
+			wantFallback: true,
+		},
+		{
+			name:         "resolver failure falls back",
+			engine:       "auto",
+			resolverErr:  errors.New("HTTP 403"),
+			expected:     "copilot",
+			wantFallback: true,
+		},
+		{
+			name:         "no resolver falls back",
+			engine:       "auto",
+			noResolver:   true,
+			expected:     "copilot",
+			wantFallback: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compiler := NewCompiler()
+			calls := 0
+			if !tt.noResolver {
+				compiler.SetRepositorySecretsResolver(func(context.Context) ([]string, error) {
+					calls++
+					return tt.secrets, tt.resolverErr
+				})
+			}
+
+			engineSetting, config, _ := compiler.ExtractEngineConfig(map[string]any{"engine": tt.engine})
+			require.NotNil(t, config, "engine config should be extracted")
+			assert.Equal(t, tt.expected, engineSetting, "unexpected engine setting")
+			assert.Equal(t, tt.expected, config.ID, "unexpected engine ID")
+			assert.Equal(t, tt.wantFallback, config.AutoFallback, "unexpected fallback state")
+			assert.NotEmpty(t, config.AutoSelection, "selection should be described")
+
+			compiler.ExtractEngineConfig(map[string]any{"engine": tt.engine})
+			if !tt.noResolver {
+				assert.Equal(t, 1, calls, "secrets should be listed once per compiler")
+			}
+		})
+	}
+}
+
+func TestExtractEngineConfig_NotAuto(t *testing.T) {
+	compiler := NewCompiler()
+	compiler.SetRepositorySecretsResolver(func(context.Context) ([]string, error) {
+		t.Fatal("secrets should not be listed for an explicit engine")
+		return nil, nil
+	})
+	engineSetting, config, _ := compiler.ExtractEngineConfig(map[string]any{"engine": "claude"})
+	assert.Equal(t, "claude", engineSetting, "explicit engine should be kept")
+	assert.Empty(t, config.AutoSelection, "explicit engine should not be described as auto-selected")
+}
+
+func TestCompileWorkflowWithAutoEngine(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "auto-engine-test")
+	testFile := filepath.Join(tmpDir, "auto.md")
+	content := `---
+on: push
+permissions:
+  contents: read
+engine: auto
+strict: false
+---
+
+# Test Workflow
+
+This is a test workflow.`
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "failed to write workflow")
+
+	compiler := NewCompiler()
+	compiler.SetRepositorySecretsResolver(func(context.Context) ([]string, error) {
+		return []string{"ANTHROPIC_API_KEY"}, nil
+	})
+	workflowData, err := compiler.ParseWorkflowFile(testFile)
+	require.NoError(t, err, "engine: auto should be accepted")
+	assert.Equal(t, "claude", workflowData.AI, "engine with a configured secret should be selected")
+}
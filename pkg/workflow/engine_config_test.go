@@ -514,6 +514,17 @@ func TestExtractEngineConfig(t *testing.T) {
 			expectedEngineSetting: "claude",
 			expectedConfig:        &EngineConfig{ID: "claude", Cwd: "${{ github.workspace }}/subdir"},
 		},
+		{
+			name: "object format - settings-scope",
+			frontmatter: map[string]any{
+				"engine": map[string]any{
+					"id":             "gemini",
+					"settings-scope": "both",
+				},
+			},
+			expectedEngineSetting: "gemini",
+			expectedConfig:        &EngineConfig{ID: "gemini", SettingsScope: "both"},
+		},
 		{
 			name: "object format - complete with user-agent",
 			frontmatter: map[string]any{
@@ -653,6 +664,10 @@ func TestExtractEngineConfig(t *testing.T) {
 					t.Errorf("Expected config.Cwd '%s', got '%s'", test.expectedConfig.Cwd, config.Cwd)
 				}
 
+				if config.SettingsScope != test.expectedConfig.SettingsScope {
+					t.Errorf("Expected config.SettingsScope '%s', got '%s'", test.expectedConfig.SettingsScope, config.SettingsScope)
+				}
+
 				if config.HarnessMaxRetries != test.expectedConfig.HarnessMaxRetries {
 					t.Errorf("Expected config.HarnessMaxRetries '%s', got '%s'", test.expectedConfig.HarnessMaxRetries, config.HarnessMaxRetries)
 				}
@@ -1303,6 +1318,42 @@ func TestSupportsBareMode(t *testing.T) {
 	}
 }
 
+func TestSupportsSettingsScope(t *testing.T) {
+	tests := []struct {
+		name     string
+		engine   CodingAgentEngine
+		expected bool
+	}{
+		{
+			name:     "gemini supports settings-scope",
+			engine:   NewGeminiEngine(),
+			expected: true,
+		},
+		{
+			name:     "claude does not support settings-scope",
+			engine:   NewClaudeEngine(),
+			expected: false,
+		},
+		{
+			name:     "codex does not support settings-scope",
+			engine:   NewCodexEngine(),
+			expected: false,
+		},
+		{
+			name:     "copilot does not support settings-scope",
+			engine:   NewCopilotEngine(),
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.engine.GetCapabilities().SettingsScope,
+				"SettingsScope capability should be %v for %s", tt.expected, tt.engine.GetID())
+		})
+	}
+}
+
 // TestBareMode_UnsupportedEngineNoFlag verifies that engines not supporting bare mode
 // do not inject any bare-mode flags in their execution steps.
 func TestBareMode_UnsupportedEngineNoFlag(t *testing.T) {
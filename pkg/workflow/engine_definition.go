@@ -130,6 +130,7 @@ type EngineCapabilitiesDefinition struct {
 	MaxContinuations bool `yaml:"max-continuations,omitempty"`
 	NativeAgentFile  bool `yaml:"native-agent-file,omitempty"`
 	BareMode         bool `yaml:"bare-mode,omitempty"`
+	SettingsScope    bool `yaml:"settings-scope,omitempty"`
 }
 
 // ToRuntimeCapabilities converts the declarative capabilities definition into the
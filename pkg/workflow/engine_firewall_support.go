@@ -116,6 +116,9 @@ func generateSquidLogsUploadStep(workflowName string, workflowData *WorkflowData
 		"          path: " + firewallLogsDir,
 		"          if-no-files-found: ignore",
 	}
+	if line := artifactRetentionDaysLine(workflowData, "          "); line != "" {
+		stepLines = append(stepLines, line)
+	}
 
 	return GitHubActionStep(stepLines)
 }
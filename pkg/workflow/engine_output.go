@@ -11,19 +11,19 @@ import (
 var engineOutputLog = logger.New("workflow:engine_output")
 
 // RedactedURLsLogPath is the path where redacted URL domains are logged during sanitization
-const RedactedURLsLogPath = "/tmp/gh-aw/redacted-urls.log"
+const RedactedURLsLogPath = constants.TmpGhAwDirExpr + "/redacted-urls.log"
 
 // AgentStepSummaryPath is the path used as GITHUB_STEP_SUMMARY inside the agent sandbox.
 // The agent writes its step summary content to this file, which is then appended to the
 // real $GITHUB_STEP_SUMMARY after secret redaction.
-const AgentStepSummaryPath = "/tmp/gh-aw/agent-step-summary.md"
+const AgentStepSummaryPath = constants.TmpGhAwDirExpr + "/agent-step-summary.md"
 
 // AgentCLIStartMsPath is the path where the epoch-millisecond timestamp of the
 // "Execute Agent CLI" step start is written on the host (before the AWF container
 // launches). It is read by sendJobConclusionSpan to set the start time of the
 // dedicated gh-aw.<job>.agent span, excluding pre-agent overhead such as the
 // workspace audit and CLI proxy startup.
-const AgentCLIStartMsPath = "/tmp/gh-aw/agent_cli_start_ms.txt"
+const AgentCLIStartMsPath = constants.TmpGhAwDirExpr + "/agent_cli_start_ms.txt"
 
 // generateCleanupStep generates the cleanup step YAML for workspace files, excluding /tmp/gh-aw/ files
 // Returns the YAML string and whether a cleanup step was generated
@@ -34,7 +34,7 @@ func generateCleanupStep(outputFiles []string) (string, bool) {
 	// Filter to get only workspace files (exclude /tmp/gh-aw/ files)
 	var workspaceFiles []string
 	for _, file := range outputFiles {
-		if !strings.HasPrefix(file, constants.TmpGhAwDirSlash) {
+		if !strings.HasPrefix(file, constants.TmpGhAwDirExprSlash) {
 			workspaceFiles = append(workspaceFiles, file)
 		}
 	}
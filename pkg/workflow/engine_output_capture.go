@@ -0,0 +1,75 @@
+// This file captures the output of engine execution steps.
+//
+// # Engine Output Capture
+//
+// Engines write agent content (for example the JSON stream of stream-json engines)
+// to stdout and diagnostics (debug logs, progress, warnings) to stderr, often with
+// ANSI colors. Capturing both with `2>&1 | tee` interleaves them and leaves color
+// codes in the log, which breaks the transcript parsers and the step summary.
+//
+// captureEngineOutput keeps the streams apart: each one has its ANSI control
+// sequences stripped and is written to its own log file next to the combined log,
+// which still receives both streams (line by line) for existing consumers:
+//
+//	agent-stdio.log   both streams
+//	agent-stdout.log  agent content
+//	agent-stderr.log  engine diagnostics
+//
+// The console keeps stdout and stderr separate as well, and the engine's exit
+// status is preserved through the pipeline (the steps run with pipefail).
+
+package workflow
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var engineOutputCaptureLog = logger.New("workflow:engine_output_capture")
+
+// stripANSICommand removes ANSI control sequences from each line as it arrives:
+// CSI sequences (colors, cursor movement, erase), OSC sequences (window titles,
+// hyperlinks) and the remaining two-character escapes. -u keeps sed unbuffered so
+// output streams to the console and logs without delay.
+const stripANSICommand = `sed -u -E 's/\x1B\[[0-?]*[ -/]*[@-~]//g; s/\x1B\][^\x07\x1B]*(\x07|\x1B\\)//g; s/\x1B[@-Z\\-_]//g'`
+
+// engineStreamLogPaths returns the stdout and stderr logs that accompany an engine's
+// combined log file, e.g. agent-stdio.log → agent-stdout.log and agent-stderr.log,
+// detection.log → detection-stdout.log and detection-stderr.log.
+func engineStreamLogPaths(logFile string) (string, string) {
+	stem := strings.TrimSuffix(strings.TrimSuffix(logFile, ".log"), "-stdio")
+	return stem + "-stdout.log", stem + "-stderr.log"
+}
+
+// engineLogFiles returns the combined log file followed by its stream logs.
+func engineLogFiles(logFile string) []string {
+	stdoutLog, stderrLog := engineStreamLogPaths(logFile)
+	return []string{logFile, stdoutLog, stderrLog}
+}
+
+// precreateEngineLogsCommand pre-creates the engine log files with restrictive
+// permissions (0600). tee would otherwise create them with the default umask (0644),
+// leaving secrets world-readable on the runner until the secret-redaction step runs.
+func precreateEngineLogsCommand(logFile string) string {
+	return fmt.Sprintf("(umask 177 && touch %s)", shellJoinArgs(engineLogFiles(logFile)))
+}
+
+// captureEngineOutput wraps an engine command so its stdout and stderr are stripped of
+// ANSI control sequences and appended to their own logs and to the combined log.
+//
+// stderr is moved to fd 3 and fed through its own pipeline, while fd 4 keeps the step's
+// original stdout:
+//
+//	{ { { CMD; } 2>&3 | strip | tee -a OUT LOG; } 3>&1 1>&4 | strip | tee -a ERR LOG >&2; } 4>&1
+//
+// With pipefail the pipeline exits with the engine's status.
+func captureEngineOutput(command, logFile string) string {
+	stdoutLog, stderrLog := engineStreamLogPaths(logFile)
+	engineOutputCaptureLog.Printf("Capturing engine output: log=%s, stdout=%s, stderr=%s", logFile, stdoutLog, stderrLog)
+	return fmt.Sprintf("{ { { %s; } 2>&3 | %s | tee -a %s %s; } 3>&1 1>&4 | %s | tee -a %s %s >&2; } 4>&1",
+		command,
+		stripANSICommand, shellEscapeArg(stdoutLog), shellEscapeArg(logFile),
+		stripANSICommand, shellEscapeArg(stderrLog), shellEscapeArg(logFile))
+}
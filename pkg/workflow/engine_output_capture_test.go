@@ -0,0 +1,63 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEngineStreamLogPaths(t *testing.T) {
+	tests := []struct {
+		logFile    string
+		wantStdout string
+		wantStderr string
+	}{
+		{"/tmp/gh-aw/agent-stdio.log", "/tmp/gh-aw/agent-stdout.log", "/tmp/gh-aw/agent-stderr.log"},
+		{"/tmp/gh-aw/threat-detection/detection.log", "/tmp/gh-aw/threat-detection/detection-stdout.log", "/tmp/gh-aw/threat-detection/detection-stderr.log"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.logFile, func(t *testing.T) {
+			stdoutLog, stderrLog := engineStreamLogPaths(tt.logFile)
+			assert.Equal(t, tt.wantStdout, stdoutLog, "stdout log path")
+			assert.Equal(t, tt.wantStderr, stderrLog, "stderr log path")
+		})
+	}
+}
+
+func TestCaptureEngineOutput(t *testing.T) {
+	if _, err := exec.LookPath("bash"); err != nil {
+		t.Skip("bash not available")
+	}
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "agent-stdio.log")
+	command := `printf '\033[32mout\033[0m\n'; printf '\033]0;title\007err\n' >&2; exit 3`
+	script := "set -o pipefail\n" + precreateEngineLogsCommand(logFile) + "\n" + captureEngineOutput(command, logFile)
+
+	cmd := exec.Command("bash", "-c", script)
+	var stdout, stderr strings.Builder
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+
+	var exitErr *exec.ExitError
+	require.ErrorAs(t, err, &exitErr, "engine failure should fail the pipeline")
+	assert.Equal(t, 3, exitErr.ExitCode(), "engine exit status should be preserved")
+	assert.Equal(t, "out\n", stdout.String(), "stdout should stay on stdout without ANSI codes")
+	assert.Equal(t, "err\n", stderr.String(), "stderr should stay on stderr without ANSI codes")
+
+	read := func(name string) string {
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		require.NoError(t, err, "log %s should exist", name)
+		return string(content)
+	}
+	assert.Equal(t, "out\n", read("agent-stdout.log"), "stdout log")
+	assert.Equal(t, "err\n", read("agent-stderr.log"), "stderr log")
+	assert.ElementsMatch(t, []string{"out", "err"}, strings.Fields(read("agent-stdio.log")), "combined log")
+}
@@ -84,7 +84,7 @@ func TestGenerateEngineOutputCleanup_WithWorkspaceFiles(t *testing.T) {
 	compiler := NewCompiler()
 	var yaml strings.Builder
 
-	// Codex declares /tmp/gh-aw/ files; those shouldn't get a rm -fr cleanup
+	// Codex declares ${{ env.GH_AW_TMP_DIR }}/ files; those shouldn't get a rm -fr cleanup
 	codexEngine := NewCodexEngine()
 	declaredFiles := codexEngine.GetDeclaredOutputFiles()
 	require.NotEmpty(t, declaredFiles, "Codex must declare files for this test")
@@ -92,17 +92,17 @@ func TestGenerateEngineOutputCleanup_WithWorkspaceFiles(t *testing.T) {
 	compiler.generateEngineOutputCleanup(&yaml, codexEngine)
 	result := yaml.String()
 
-	// If all Codex output files are under /tmp/gh-aw/, no cleanup step should be emitted
+	// If all Codex output files are under ${{ env.GH_AW_TMP_DIR }}/, no cleanup step should be emitted
 	allUnderTmpGhAw := true
 	for _, f := range declaredFiles {
-		if !strings.HasPrefix(f, "/tmp/gh-aw/") {
+		if !strings.HasPrefix(f, "${{ env.GH_AW_TMP_DIR }}/") {
 			allUnderTmpGhAw = false
 			break
 		}
 	}
 
 	if allUnderTmpGhAw {
-		assert.Empty(t, result, "no cleanup step should be emitted when all files are under /tmp/gh-aw/")
+		assert.Empty(t, result, "no cleanup step should be emitted when all files are under ${{ env.GH_AW_TMP_DIR }}/")
 	} else {
 		assert.Contains(t, result, "Clean up engine output files", "cleanup step should be present for workspace files")
 	}
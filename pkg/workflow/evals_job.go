@@ -10,7 +10,7 @@ import (
 
 var evalsJobLog = logger.New("workflow:evals_job")
 
-const evalsStateDir = "/tmp/gh-aw/evals-state"
+const evalsStateDir = constants.TmpGhAwDirExpr + "/evals-state"
 
 func evalsBranchName(workflowID string) string {
 	return WorkflowStateBranchName(constants.EvalsBranchPrefix, workflowID)
@@ -15,13 +15,13 @@ var evalsStepsLog = logger.New("workflow:evals_steps")
 
 const (
 	// evalsDir is the BinEval working directory on the runner.
-	evalsDir = "/tmp/gh-aw/evals"
+	evalsDir = constants.TmpGhAwDirExpr + "/evals"
 
 	// evalsLogPath is the engine output log written during the evals engine execution step.
-	evalsLogPath = "/tmp/gh-aw/evals/evals.log"
+	evalsLogPath = constants.TmpGhAwDirExpr + "/evals/evals.log"
 
 	// evalsResultsPath is the parsed JSONL results file produced by the parse step.
-	evalsResultsPath = "/tmp/gh-aw/" + constants.EvalsResultFilename
+	evalsResultsPath = constants.TmpGhAwDirExpr + "/" + constants.EvalsResultFilename
 )
 
 // buildEvalsJobSteps builds all steps that run inside the evals job.
@@ -101,8 +101,8 @@ func buildPrepareEvalsFilesStep() []string {
 		"      - name: Prepare evals files\n",
 		"        run: |\n",
 		fmt.Sprintf("          mkdir -p %s\n", evalsDir),
-		"          cp /tmp/gh-aw/agent_output.json " + evalsDir + "/agent_output.json 2>/dev/null || true\n",
-		"          cp /tmp/gh-aw/aw-prompts/prompt.txt " + evalsDir + "/prompt.txt 2>/dev/null || true\n",
+		"          cp " + constants.TmpGhAwDirExpr + "/agent_output.json " + evalsDir + "/agent_output.json 2>/dev/null || true\n",
+		"          cp " + constants.TmpGhAwDirExpr + "/aw-prompts/prompt.txt " + evalsDir + "/prompt.txt 2>/dev/null || true\n",
 		fmt.Sprintf("          ls -la %s/ 2>/dev/null || true\n", evalsDir),
 	}
 }
@@ -329,15 +329,7 @@ await main();`
 	}
 	if len(secretReferences) > 0 {
 		steps = append(steps, "        env:\n")
-		escapedRefs := make([]string, len(secretReferences))
-		for i, ref := range secretReferences {
-			escapedRefs[i] = escapeSingleQuoteBackslash(ref)
-		}
-		steps = append(steps, fmt.Sprintf("          GH_AW_SECRET_NAMES: '%s'\n", strings.Join(escapedRefs, ",")))
-		for _, secretName := range secretReferences {
-			escapedSecretName := escapeSingleQuoteBackslash(secretName)
-			steps = append(steps, fmt.Sprintf("          SECRET_%s: ${{ secrets.%s }}\n", escapedSecretName, secretName))
-		}
+		steps = append(steps, secretRedactionEnvLines(secretReferences)...)
 	}
 	steps = append(steps,
 		"        with:\n",
@@ -84,12 +84,14 @@ var ghAwOnSectionKeys = map[string]bool{
 	"allow-bot-authored-trigger-comment": true,
 	"bots":                               true,
 	"command":                            true,
+	"filters":                            true,
 	"github-app":                         true,
 	"github-token":                       true,
 	"label_command":                      true,
 	"labels":                             true,
 	"needs":                              true,
 	"reaction":                           true,
+	"reaction_command":                   true,
 	"roles":                              true,
 	"skip-author-associations":           true,
 	"skip-if-match":                      true,
@@ -0,0 +1,1006 @@
+// This file implements an evaluator for GitHub Actions expressions.
+//
+// # Expression Evaluation
+//
+// The compiler generates many `${{ }}` guard conditions (job and step `if:` values)
+// that are otherwise only exercised in production runs. EvaluateExpression and
+// EvaluateCondition evaluate them locally against an ExpressionContext so the
+// guards can be unit-tested, and `gh aw run --fixture` uses them to show which
+// jobs a fixture event would run.
+//
+// The evaluator follows the documented GitHub Actions semantics:
+//   - literals: null, booleans, numbers (including hex and exponents) and
+//     single-quoted strings ('' escapes a quote)
+//   - property access with `.`, `[...]` and the `*` object filter; context and
+//     property names are case-insensitive and missing properties evaluate to null
+//   - operators: ! < <= > >= == != && || with loose equality: values of different
+//     types are coerced to numbers and strings compare case-insensitively
+//   - && and || return one of their operands, like JavaScript
+//   - functions: contains, startsWith, endsWith, format, join, toJSON, fromJSON,
+//     success, always, cancelled and failure (hashFiles needs a workspace and is
+//     reported as unsupported)
+
+package workflow
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var expressionEvaluatorLog = logger.New("workflow:expression_evaluator")
+
+// Job statuses seen by the status functions (success(), failure(), cancelled()).
+const (
+	ExpressionJobStatusSuccess   = "success"
+	ExpressionJobStatusFailure   = "failure"
+	ExpressionJobStatusCancelled = "cancelled"
+	// ExpressionJobStatusSkipped means a job this one needs was skipped: success(),
+	// failure() and cancelled() are all false, so only always() and !cancelled() pass.
+	ExpressionJobStatusSkipped = "skipped"
+)
+
+// ExpressionContext holds the values an expression can read.
+type ExpressionContext struct {
+	// Contexts maps context names (github, inputs, needs, steps, env, vars, ...) to
+	// their values. Values use the JSON data model: nil, bool, numbers, string,
+	// map[string]any and []any.
+	Contexts map[string]any
+	// JobStatus is the status seen by the status functions. Empty means success.
+	JobStatus string
+}
+
+// expressionFilterResult is the result of an object filter (`*`). Property access
+// on it applies to each element.
+type expressionFilterResult []any
+
+// EvaluateExpression evaluates a GitHub Actions expression, with or without its
+// ${{ }} wrapper, and returns its value.
+func EvaluateExpression(expression string, ctx *ExpressionContext) (any, error) {
+	expr := stripExpressionWrapper(expression)
+	if expr == "" {
+		return nil, errors.New("empty expression")
+	}
+	node, err := parseEvaluatorExpression(expr)
+	if err != nil {
+		return nil, err
+	}
+	if ctx == nil {
+		ctx = &ExpressionContext{}
+	}
+	value, err := node.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return finalizeExpressionValue(value), nil
+}
+
+// EvaluateCondition evaluates an `if:` condition the way the runner does: an empty
+// condition means success(), and a condition that calls none of the status
+// functions is implicitly combined with success().
+func EvaluateCondition(condition string, ctx *ExpressionContext) (bool, error) {
+	if ctx == nil {
+		ctx = &ExpressionContext{}
+	}
+	expr := stripExpressionWrapper(condition)
+	if expr == "" {
+		return expressionStatusMatches(ctx, ExpressionJobStatusSuccess), nil
+	}
+	node, err := parseEvaluatorExpression(expr)
+	if err != nil {
+		return false, err
+	}
+	if !callsStatusFunction(node) && !expressionStatusMatches(ctx, ExpressionJobStatusSuccess) {
+		expressionEvaluatorLog.Printf("Condition has no status function and job status is %s: skipping", ctx.JobStatus)
+		return false, nil
+	}
+	value, err := node.eval(ctx)
+	if err != nil {
+		return false, err
+	}
+	return expressionTruthy(finalizeExpressionValue(value)), nil
+}
+
+func expressionStatusMatches(ctx *ExpressionContext, status string) bool {
+	current := ctx.JobStatus
+	if current == "" {
+		current = ExpressionJobStatusSuccess
+	}
+	return current == status
+}
+
+// finalizeExpressionValue turns internal values into plain JSON data model values.
+func finalizeExpressionValue(value any) any {
+	if filtered, ok := value.(expressionFilterResult); ok {
+		return []any(filtered)
+	}
+	return value
+}
+
+// ===== Tokenizer =====
+
+type evalTokenKind int
+
+const (
+	evalTokenEOF evalTokenKind = iota
+	evalTokenString
+	evalTokenNumber
+	evalTokenIdentifier
+	evalTokenOperator
+)
+
+type evalToken struct {
+	kind  evalTokenKind
+	text  string
+	num   float64
+	pos   int
+	space bool // preceded by whitespace
+}
+
+// evalOperators lists the punctuation tokens, longest first.
+var evalOperators = []string{"&&", "||", "==", "!=", "<=", ">=", "!", "<", ">", "(", ")", "[", "]", ".", ",", "*"}
+
+func tokenizeEvaluatorExpression(expr string) ([]evalToken, error) {
+	var tokens []evalToken
+	space := false
+	for i := 0; i < len(expr); {
+		ch := expr[i]
+		if ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r' {
+			space = true
+			i++
+			continue
+		}
+		start := i
+		switch {
+		case ch == '\'':
+			var sb strings.Builder
+			i++
+			closed := false
+			for i < len(expr) {
+				if expr[i] == '\'' {
+					if i+1 < len(expr) && expr[i+1] == '\'' {
+						sb.WriteByte('\'')
+						i += 2
+						continue
+					}
+					i++
+					closed = true
+					break
+				}
+				sb.WriteByte(expr[i])
+				i++
+			}
+			if !closed {
+				return nil, fmt.Errorf("unterminated string starting at position %d", start)
+			}
+			tokens = append(tokens, evalToken{kind: evalTokenString, text: sb.String(), pos: start, space: space})
+		case isEvalDigit(ch) || (ch == '-' && i+1 < len(expr) && (isEvalDigit(expr[i+1]) || expr[i+1] == '.')) || (ch == '.' && i+1 < len(expr) && isEvalDigit(expr[i+1]) && !afterPropertyOperand(tokens)):
+			i++
+			for i < len(expr) && (isEvalIdentChar(expr[i]) || expr[i] == '.' || ((expr[i] == '+' || expr[i] == '-') && (expr[i-1] == 'e' || expr[i-1] == 'E'))) {
+				i++
+			}
+			text := expr[start:i]
+			num, ok := parseExpressionNumber(text)
+			if !ok {
+				return nil, fmt.Errorf("invalid number '%s' at position %d", text, start)
+			}
+			tokens = append(tokens, evalToken{kind: evalTokenNumber, text: text, num: num, pos: start, space: space})
+		case isEvalIdentStart(ch):
+			for i < len(expr) && isEvalIdentChar(expr[i]) {
+				i++
+			}
+			tokens = append(tokens, evalToken{kind: evalTokenIdentifier, text: expr[start:i], pos: start, space: space})
+		default:
+			matched := ""
+			for _, op := range evalOperators {
+				if strings.HasPrefix(expr[i:], op) {
+					matched = op
+					break
+				}
+			}
+			if matched == "" {
+				return nil, fmt.Errorf("unexpected character '%c' at position %d", ch, start)
+			}
+			i += len(matched)
+			tokens = append(tokens, evalToken{kind: evalTokenOperator, text: matched, pos: start, space: space})
+		}
+		space = false
+	}
+	return append(tokens, evalToken{kind: evalTokenEOF, pos: len(expr)}), nil
+}
+
+// afterPropertyOperand reports whether a '.' at this point is a property dereference
+// (after an identifier, ']' or ')') rather than the start of a number like .5.
+func afterPropertyOperand(tokens []evalToken) bool {
+	if len(tokens) == 0 {
+		return false
+	}
+	last := tokens[len(tokens)-1]
+	return last.kind == evalTokenIdentifier || (last.kind == evalTokenOperator && (last.text == "]" || last.text == ")" || last.text == "*"))
+}
+
+func isEvalDigit(ch byte) bool { return ch >= '0' && ch <= '9' }
+
+func isEvalIdentStart(ch byte) bool {
+	return (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') || ch == '_'
+}
+
+func isEvalIdentChar(ch byte) bool { return isEvalIdentStart(ch) || isEvalDigit(ch) || ch == '-' }
+
+// parseExpressionNumber parses a number literal or a string coerced to a number.
+func parseExpressionNumber(text string) (float64, bool) {
+	text = strings.TrimSpace(text)
+	sign := 1.0
+	unsigned := text
+	if strings.HasPrefix(unsigned, "-") {
+		sign = -1
+		unsigned = unsigned[1:]
+	} else if strings.HasPrefix(unsigned, "+") {
+		unsigned = unsigned[1:]
+	}
+	if strings.HasPrefix(unsigned, "0x") || strings.HasPrefix(unsigned, "0X") {
+		n, err := strconv.ParseInt(unsigned[2:], 16, 64)
+		if err != nil {
+			return 0, false
+		}
+		return sign * float64(n), true
+	}
+	switch unsigned {
+	case "Infinity":
+		return sign * math.Inf(1), true
+	case "NaN":
+		return math.NaN(), true
+	}
+	if unsigned == "" || strings.ContainsAny(unsigned, "_xXpP") {
+		return 0, false
+	}
+	n, err := strconv.ParseFloat(unsigned, 64)
+	if err != nil {
+		return 0, false
+	}
+	return sign * n, true
+}
+
+// ===== Parser =====
+
+type evalNode interface {
+	eval(ctx *ExpressionContext) (any, error)
+}
+
+type evalLiteral struct{ value any }
+
+type evalContextRef struct{ name string }
+
+type evalProperty struct {
+	target evalNode
+	name   string
+}
+
+type evalIndex struct {
+	target evalNode
+	index  evalNode
+}
+
+type evalFilter struct{ target evalNode }
+
+type evalNot struct{ operand evalNode }
+
+type evalBinary struct {
+	op          string
+	left, right evalNode
+}
+
+type evalCall struct {
+	name string
+	args []evalNode
+}
+
+type evaluatorParser struct {
+	tokens []evalToken
+	pos    int
+	depth  int
+}
+
+// maxEvaluatorDepth bounds nesting so a hostile expression cannot exhaust the stack.
+const maxEvaluatorDepth = 50
+
+func parseEvaluatorExpression(expr string) (evalNode, error) {
+	tokens, err := tokenizeEvaluatorExpression(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &evaluatorParser{tokens: tokens}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != evalTokenEOF {
+		return nil, fmt.Errorf("unexpected token '%s' at position %d", tok.text, tok.pos)
+	}
+	return node, nil
+}
+
+func (p *evaluatorParser) peek() evalToken { return p.tokens[p.pos] }
+
+func (p *evaluatorParser) next() evalToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != evalTokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *evaluatorParser) isOperator(ops ...string) bool {
+	tok := p.peek()
+	return tok.kind == evalTokenOperator && slices.Contains(ops, tok.text)
+}
+
+func (p *evaluatorParser) expect(op string) error {
+	if !p.isOperator(op) {
+		tok := p.peek()
+		if tok.kind == evalTokenEOF {
+			return fmt.Errorf("expected '%s' at end of expression", op)
+		}
+		return fmt.Errorf("expected '%s' at position %d, got '%s'", op, tok.pos, tok.text)
+	}
+	p.next()
+	return nil
+}
+
+func (p *evaluatorParser) parseBinaryLevel(ops []string, operand func() (evalNode, error)) (evalNode, error) {
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator(ops...) {
+		op := p.next().text
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		left = &evalBinary{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *evaluatorParser) parseOr() (evalNode, error) {
+	p.depth++
+	defer func() { p.depth-- }()
+	if p.depth > maxEvaluatorDepth {
+		return nil, errors.New("expression is nested too deeply")
+	}
+	return p.parseBinaryLevel([]string{"||"}, p.parseAnd)
+}
+
+func (p *evaluatorParser) parseAnd() (evalNode, error) {
+	return p.parseBinaryLevel([]string{"&&"}, p.parseEquality)
+}
+
+func (p *evaluatorParser) parseEquality() (evalNode, error) {
+	return p.parseBinaryLevel([]string{"==", "!="}, p.parseComparison)
+}
+
+func (p *evaluatorParser) parseComparison() (evalNode, error) {
+	return p.parseBinaryLevel([]string{"<", "<=", ">", ">="}, p.parseUnary)
+}
+
+func (p *evaluatorParser) parseUnary() (evalNode, error) {
+	if p.isOperator("!") {
+		p.next()
+		p.depth++
+		defer func() { p.depth-- }()
+		if p.depth > maxEvaluatorDepth {
+			return nil, errors.New("expression is nested too deeply")
+		}
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &evalNot{operand: operand}, nil
+	}
+	return p.parsePostfix()
+}
+
+func (p *evaluatorParser) parsePostfix() (evalNode, error) {
+	node, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch {
+		case p.isOperator(".") && !p.peek().space:
+			p.next()
+			tok := p.next()
+			switch {
+			case tok.kind == evalTokenIdentifier:
+				node = &evalProperty{target: node, name: tok.text}
+			case tok.kind == evalTokenOperator && tok.text == "*":
+				node = &evalFilter{target: node}
+			default:
+				return nil, fmt.Errorf("expected property name at position %d", tok.pos)
+			}
+		case p.isOperator("["):
+			p.next()
+			if p.isOperator("*") {
+				p.next()
+				node = &evalFilter{target: node}
+			} else {
+				index, err := p.parseOr()
+				if err != nil {
+					return nil, err
+				}
+				node = &evalIndex{target: node, index: index}
+			}
+			if err := p.expect("]"); err != nil {
+				return nil, err
+			}
+		default:
+			return node, nil
+		}
+	}
+}
+
+func (p *evaluatorParser) parsePrimary() (evalNode, error) {
+	tok := p.next()
+	switch tok.kind {
+	case evalTokenString:
+		return &evalLiteral{value: tok.text}, nil
+	case evalTokenNumber:
+		return &evalLiteral{value: tok.num}, nil
+	case evalTokenIdentifier:
+		switch tok.text {
+		case "true":
+			return &evalLiteral{value: true}, nil
+		case "false":
+			return &evalLiteral{value: false}, nil
+		case "null":
+			return &evalLiteral{value: nil}, nil
+		case "NaN":
+			return &evalLiteral{value: math.NaN()}, nil
+		case "Infinity":
+			return &evalLiteral{value: math.Inf(1)}, nil
+		}
+		if p.isOperator("(") {
+			p.next()
+			var args []evalNode
+			if !p.isOperator(")") {
+				for {
+					arg, err := p.parseOr()
+					if err != nil {
+						return nil, err
+					}
+					args = append(args, arg)
+					if !p.isOperator(",") {
+						break
+					}
+					p.next()
+				}
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			if err := checkExpressionFunctionArity(tok.text, len(args)); err != nil {
+				return nil, err
+			}
+			return &evalCall{name: strings.ToLower(tok.text), args: args}, nil
+		}
+		return &evalContextRef{name: tok.text}, nil
+	case evalTokenOperator:
+		if tok.text == "(" {
+			node, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return node, nil
+		}
+		return nil, fmt.Errorf("unexpected '%s' at position %d", tok.text, tok.pos)
+	default:
+		return nil, errors.New("unexpected end of expression")
+	}
+}
+
+// expressionFunctionArity lists the supported functions with their minimum and
+// maximum argument counts (-1 means unbounded).
+var expressionFunctionArity = map[string][2]int{
+	"contains":   {2, 2},
+	"startswith": {2, 2},
+	"endswith":   {2, 2},
+	"format":     {1, -1},
+	"join":       {1, 2},
+	"tojson":     {1, 1},
+	"fromjson":   {1, 1},
+	"hashfiles":  {1, -1},
+	"success":    {0, 0},
+	"always":     {0, 0},
+	"cancelled":  {0, 0},
+	"failure":    {0, 0},
+}
+
+func checkExpressionFunctionArity(name string, count int) error {
+	arity, ok := expressionFunctionArity[strings.ToLower(name)]
+	if !ok {
+		return fmt.Errorf("unknown function '%s'", name)
+	}
+	if count < arity[0] || (arity[1] >= 0 && count > arity[1]) {
+		return fmt.Errorf("function '%s' called with %d argument(s)", name, count)
+	}
+	return nil
+}
+
+// callsStatusFunction reports whether the expression calls success(), always(),
+// cancelled() or failure().
+func callsStatusFunction(node evalNode) bool {
+	switch n := node.(type) {
+	case *evalCall:
+		switch n.name {
+		case "success", "always", "cancelled", "failure":
+			return true
+		}
+		return slices.ContainsFunc(n.args, callsStatusFunction)
+	case *evalBinary:
+		return callsStatusFunction(n.left) || callsStatusFunction(n.right)
+	case *evalNot:
+		return callsStatusFunction(n.operand)
+	case *evalProperty:
+		return callsStatusFunction(n.target)
+	case *evalIndex:
+		return callsStatusFunction(n.target) || callsStatusFunction(n.index)
+	case *evalFilter:
+		return callsStatusFunction(n.target)
+	}
+	return false
+}
+
+// ===== Evaluation =====
+
+func (n *evalLiteral) eval(_ *ExpressionContext) (any, error) { return n.value, nil }
+
+func (n *evalContextRef) eval(ctx *ExpressionContext) (any, error) {
+	if value, ok := lookupExpressionKey(ctx.Contexts, n.name); ok {
+		return normalizeExpressionValue(value), nil
+	}
+	return nil, nil
+}
+
+func (n *evalProperty) eval(ctx *ExpressionContext) (any, error) {
+	target, err := n.target.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return accessExpressionProperty(target, n.name), nil
+}
+
+func (n *evalIndex) eval(ctx *ExpressionContext) (any, error) {
+	target, err := n.target.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+	index, err := n.index.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if filtered, ok := target.(expressionFilterResult); ok {
+		var result expressionFilterResult
+		for _, item := range filtered {
+			if value := indexExpressionValue(item, index); value != nil {
+				result = append(result, value)
+			}
+		}
+		return result, nil
+	}
+	return indexExpressionValue(target, index), nil
+}
+
+func (n *evalFilter) eval(ctx *ExpressionContext) (any, error) {
+	target, err := n.target.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := expressionFilterResult{}
+	appendItems := func(value any) {
+		switch v := value.(type) {
+		case []any:
+			result = append(result, v...)
+		case map[string]any:
+			keys := make([]string, 0, len(v))
+			for key := range v {
+				keys = append(keys, key)
+			}
+			slices.Sort(keys)
+			for _, key := range keys {
+				result = append(result, normalizeExpressionValue(v[key]))
+			}
+		}
+	}
+	if filtered, ok := target.(expressionFilterResult); ok {
+		for _, item := range filtered {
+			appendItems(item)
+		}
+	} else {
+		appendItems(target)
+	}
+	return result, nil
+}
+
+func (n *evalNot) eval(ctx *ExpressionContext) (any, error) {
+	value, err := n.operand.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return !expressionTruthy(finalizeExpressionValue(value)), nil
+}
+
+func (n *evalBinary) eval(ctx *ExpressionContext) (any, error) {
+	left, err := n.left.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+	left = finalizeExpressionValue(left)
+	switch n.op {
+	case "&&":
+		if !expressionTruthy(left) {
+			return left, nil
+		}
+		right, err := n.right.eval(ctx)
+		return finalizeExpressionValue(right), err
+	case "||":
+		if expressionTruthy(left) {
+			return left, nil
+		}
+		right, err := n.right.eval(ctx)
+		return finalizeExpressionValue(right), err
+	}
+	right, err := n.right.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+	right = finalizeExpressionValue(right)
+	switch n.op {
+	case "==":
+		return expressionEquals(left, right), nil
+	case "!=":
+		return !expressionEquals(left, right), nil
+	default:
+		return expressionCompare(n.op, left, right), nil
+	}
+}
+
+func (n *evalCall) eval(ctx *ExpressionContext) (any, error) {
+	switch n.name {
+	case "success":
+		return expressionStatusMatches(ctx, ExpressionJobStatusSuccess), nil
+	case "failure":
+		return expressionStatusMatches(ctx, ExpressionJobStatusFailure), nil
+	case "cancelled":
+		return expressionStatusMatches(ctx, ExpressionJobStatusCancelled), nil
+	case "always":
+		return true, nil
+	case "hashfiles":
+		return nil, errors.New("hashFiles() needs the repository workspace and cannot be evaluated here")
+	}
+
+	args := make([]any, len(n.args))
+	for i, arg := range n.args {
+		value, err := arg.eval(ctx)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = finalizeExpressionValue(value)
+	}
+
+	switch n.name {
+	case "contains":
+		if items, ok := args[0].([]any); ok {
+			return slices.ContainsFunc(items, func(item any) bool { return expressionEquals(item, args[1]) }), nil
+		}
+		return strings.Contains(strings.ToLower(expressionString(args[0])), strings.ToLower(expressionString(args[1]))), nil
+	case "startswith":
+		return strings.HasPrefix(strings.ToLower(expressionString(args[0])), strings.ToLower(expressionString(args[1]))), nil
+	case "endswith":
+		return strings.HasSuffix(strings.ToLower(expressionString(args[0])), strings.ToLower(expressionString(args[1]))), nil
+	case "format":
+		return formatExpressionString(expressionString(args[0]), args[1:])
+	case "join":
+		separator := ","
+		if len(args) == 2 {
+			separator = expressionString(args[1])
+		}
+		items, ok := args[0].([]any)
+		if !ok {
+			return expressionString(args[0]), nil
+		}
+		parts := make([]string, len(items))
+		for i, item := range items {
+			parts[i] = expressionString(item)
+		}
+		return strings.Join(parts, separator), nil
+	case "tojson":
+		data, err := json.MarshalIndent(args[0], "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("toJSON: %w", err)
+		}
+		return string(data), nil
+	case "fromjson":
+		var value any
+		if err := json.Unmarshal([]byte(expressionString(args[0])), &value); err != nil {
+			return nil, fmt.Errorf("fromJSON: invalid JSON: %w", err)
+		}
+		return value, nil
+	}
+	return nil, fmt.Errorf("unknown function '%s'", n.name)
+}
+
+// lookupExpressionKey finds a key in a map, falling back to a case-insensitive match.
+func lookupExpressionKey(values map[string]any, name string) (any, bool) {
+	if value, ok := values[name]; ok {
+		return value, true
+	}
+	for key, value := range values {
+		if strings.EqualFold(key, name) {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+func accessExpressionProperty(target any, name string) any {
+	switch v := target.(type) {
+	case map[string]any:
+		if value, ok := lookupExpressionKey(v, name); ok {
+			return normalizeExpressionValue(value)
+		}
+	case expressionFilterResult:
+		var result expressionFilterResult
+		for _, item := range v {
+			if value := accessExpressionProperty(item, name); value != nil {
+				result = append(result, value)
+			}
+		}
+		return result
+	}
+	return nil
+}
+
+func indexExpressionValue(target any, index any) any {
+	switch v := target.(type) {
+	case map[string]any:
+		return accessExpressionProperty(v, expressionString(index))
+	case []any:
+		n := expressionNumber(index)
+		if math.IsNaN(n) || n < 0 || n != math.Trunc(n) || int(n) >= len(v) {
+			return nil
+		}
+		return normalizeExpressionValue(v[int(n)])
+	}
+	return nil
+}
+
+// normalizeExpressionValue converts Go values that are not part of the JSON data
+// model (integers, typed maps and slices) so contexts built in Go code evaluate
+// like contexts loaded from JSON.
+func normalizeExpressionValue(value any) any {
+	switch v := value.(type) {
+	case nil, bool, float64, string, map[string]any, []any, expressionFilterResult:
+		return v
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	case []string:
+		items := make([]any, len(v))
+		for i, item := range v {
+			items[i] = item
+		}
+		return items
+	case map[string]string:
+		items := make(map[string]any, len(v))
+		for key, item := range v {
+			items[key] = item
+		}
+		return items
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return rv.Convert(reflect.TypeFor[float64]()).Float()
+	}
+	return value
+}
+
+// expressionTruthy reports whether a value is truthy: false, 0, -0, NaN, "" and
+// null are falsy; everything else, including empty arrays and objects, is truthy.
+func expressionTruthy(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case float64:
+		return v != 0 && !math.IsNaN(v)
+	case string:
+		return v != ""
+	}
+	return true
+}
+
+// expressionNumber coerces a value to a number for comparisons between types.
+func expressionNumber(value any) float64 {
+	switch v := value.(type) {
+	case nil:
+		return 0
+	case bool:
+		if v {
+			return 1
+		}
+		return 0
+	case float64:
+		return v
+	case string:
+		if strings.TrimSpace(v) == "" {
+			return 0
+		}
+		if n, ok := parseExpressionNumber(v); ok {
+			return n
+		}
+	}
+	return math.NaN()
+}
+
+// expressionString converts a value to a string for string functions.
+func expressionString(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		switch {
+		case math.IsNaN(v):
+			return "NaN"
+		case math.IsInf(v, 1):
+			return "Infinity"
+		case math.IsInf(v, -1):
+			return "-Infinity"
+		}
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case string:
+		return v
+	case []any:
+		return "Array"
+	case map[string]any:
+		return "Object"
+	}
+	return fmt.Sprint(value)
+}
+
+func expressionKind(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	}
+	return "object"
+}
+
+// expressionEquals implements loose equality: strings compare case-insensitively,
+// values of different types are compared as numbers and objects and arrays are
+// only equal to themselves.
+func expressionEquals(left, right any) bool {
+	leftKind, rightKind := expressionKind(left), expressionKind(right)
+	if leftKind == rightKind {
+		switch l := left.(type) {
+		case nil:
+			return true
+		case bool:
+			return l == right.(bool)
+		case float64:
+			return l == right.(float64)
+		case string:
+			return strings.EqualFold(l, right.(string))
+		}
+		return sameExpressionObject(left, right)
+	}
+	if leftKind == "object" || rightKind == "object" {
+		return false
+	}
+	l, r := expressionNumber(left), expressionNumber(right)
+	return l == r
+}
+
+func sameExpressionObject(left, right any) bool {
+	lv, rv := reflect.ValueOf(left), reflect.ValueOf(right)
+	if lv.Kind() != rv.Kind() {
+		return false
+	}
+	switch lv.Kind() {
+	case reflect.Map, reflect.Slice:
+		return lv.Pointer() == rv.Pointer() && lv.Len() == rv.Len()
+	}
+	return false
+}
+
+// expressionCompare implements <, <=, > and >=. Two strings compare
+// case-insensitively; otherwise both sides are coerced to numbers and any NaN
+// makes the comparison false.
+func expressionCompare(op string, left, right any) bool {
+	var cmp int
+	ls, lok := left.(string)
+	rs, rok := right.(string)
+	if lok && rok {
+		cmp = strings.Compare(strings.ToUpper(ls), strings.ToUpper(rs))
+	} else {
+		l, r := expressionNumber(left), expressionNumber(right)
+		if math.IsNaN(l) || math.IsNaN(r) {
+			return false
+		}
+		switch {
+		case l < r:
+			cmp = -1
+		case l > r:
+			cmp = 1
+		}
+	}
+	switch op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	default:
+		return cmp >= 0
+	}
+}
+
+// formatExpressionString implements format(): {N} is replaced by argument N and
+// {{ and }} produce literal braces.
+func formatExpressionString(format string, args []any) (string, error) {
+	var sb strings.Builder
+	for i := 0; i < len(format); i++ {
+		ch := format[i]
+		switch {
+		case ch == '{' && i+1 < len(format) && format[i+1] == '{':
+			sb.WriteByte('{')
+			i++
+		case ch == '}' && i+1 < len(format) && format[i+1] == '}':
+			sb.WriteByte('}')
+			i++
+		case ch == '{':
+			end := strings.IndexByte(format[i:], '}')
+			if end < 0 {
+				return "", fmt.Errorf("format: unclosed '{' in %q", format)
+			}
+			index, err := strconv.Atoi(format[i+1 : i+end])
+			if err != nil || index < 0 {
+				return "", fmt.Errorf("format: invalid placeholder %q", format[i:i+end+1])
+			}
+			if index >= len(args) {
+				return "", fmt.Errorf("format: placeholder {%d} has no argument", index)
+			}
+			sb.WriteString(expressionString(args[index]))
+			i += end
+		case ch == '}':
+			return "", fmt.Errorf("format: unexpected '}' in %q", format)
+		default:
+			sb.WriteByte(ch)
+		}
+	}
+	return sb.String(), nil
+}
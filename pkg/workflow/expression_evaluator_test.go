@@ -0,0 +1,149 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestExpressionContext() *ExpressionContext {
+	return &ExpressionContext{Contexts: map[string]any{
+		"github": map[string]any{
+			"event_name": "issue_comment",
+			"actor":      "octocat",
+			"event": map[string]any{
+				"comment": map[string]any{"body": "/Triage please", "author_association": "MEMBER"},
+				"issue": map[string]any{
+					"number": 42,
+					"labels": []any{
+						map[string]any{"name": "bug"},
+						map[string]any{"name": "needs-triage"},
+					},
+				},
+			},
+		},
+		"inputs": map[string]any{"count": "3", "enabled": true},
+		"needs": map[string]any{
+			"pre_activation": map[string]any{"result": "success", "outputs": map[string]any{"activated": "true"}},
+		},
+	}}
+}
+
+func TestEvaluateExpression(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		expected   any
+	}{
+		{name: "null literal", expression: "null", expected: nil},
+		{name: "boolean literal", expression: "true", expected: true},
+		{name: "number literal", expression: "0x10", expected: float64(16)},
+		{name: "exponent literal", expression: "1.5e2", expected: float64(150)},
+		{name: "string literal with escaped quote", expression: "'it''s'", expected: "it's"},
+		{name: "wrapped expression", expression: "${{ github.actor }}", expected: "octocat"},
+		{name: "case-insensitive context and property", expression: "GitHub.Event_Name", expected: "issue_comment"},
+		{name: "missing property is null", expression: "github.event.pull_request.number", expected: nil},
+		{name: "index access", expression: "github.event['issue']['number']", expected: float64(42)},
+		{name: "array index", expression: "github.event.issue.labels[1].name", expected: "needs-triage"},
+		{name: "object filter", expression: "github.event.issue.labels.*.name", expected: []any{"bug", "needs-triage"}},
+		{name: "strings compare case-insensitively", expression: "github.event_name == 'ISSUE_COMMENT'", expected: true},
+		{name: "loose equality coerces to number", expression: "inputs.count == 3", expected: true},
+		{name: "null equals zero", expression: "github.missing == 0", expected: true},
+		{name: "relational comparison", expression: "github.event.issue.number >= 42", expected: true},
+		{name: "and returns last operand", expression: "github.actor && inputs.count", expected: "3"},
+		{name: "or returns first truthy operand", expression: "github.missing || 'fallback'", expected: "fallback"},
+		{name: "not", expression: "!github.missing", expected: true},
+		{name: "contains on string", expression: "contains(github.event.comment.body, '/triage')", expected: true},
+		{name: "contains on array", expression: "contains(fromJSON('[\"OWNER\",\"MEMBER\"]'), github.event.comment.author_association)", expected: true},
+		{name: "contains on filter", expression: "contains(github.event.issue.labels.*.name, 'bug')", expected: true},
+		{name: "startsWith", expression: "startsWith(github.event.comment.body, '/TRIAGE')", expected: true},
+		{name: "endsWith", expression: "endsWith(github.actor, 'cat')", expected: true},
+		{name: "format", expression: "format('{0}-{1} {{literal}}', github.actor, 7)", expected: "octocat-7 {literal}"},
+		{name: "join", expression: "join(github.event.issue.labels.*.name, ', ')", expected: "bug, needs-triage"},
+		{name: "fromJSON object", expression: "fromJSON('{\"a\":{\"b\":1}}').a.b", expected: float64(1)},
+		{name: "toJSON", expression: "toJSON(inputs.enabled)", expected: "true"},
+		{name: "needs outputs", expression: "needs.pre_activation.outputs.activated == 'true'", expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := EvaluateExpression(tt.expression, newTestExpressionContext())
+			require.NoError(t, err, "expression %q should evaluate", tt.expression)
+			assert.Equal(t, tt.expected, value, "unexpected value for %q", tt.expression)
+		})
+	}
+}
+
+func TestEvaluateExpressionErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+	}{
+		{name: "empty", expression: "${{ }}"},
+		{name: "unterminated string", expression: "'abc"},
+		{name: "unknown function", expression: "lower('A')"},
+		{name: "wrong arity", expression: "contains('a')"},
+		{name: "trailing tokens", expression: "github.actor github.actor"},
+		{name: "hashFiles is unsupported", expression: "hashFiles('**/go.sum')"},
+		{name: "invalid JSON", expression: "fromJSON('{')"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := EvaluateExpression(tt.expression, newTestExpressionContext())
+			assert.Error(t, err, "expression %q should fail", tt.expression)
+		})
+	}
+}
+
+func TestEvaluateCondition(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition string
+		jobStatus string
+		expected  bool
+	}{
+		{name: "empty condition runs on success", condition: "", expected: true},
+		{name: "empty condition skips on failure", condition: "", jobStatus: ExpressionJobStatusFailure, expected: false},
+		{name: "plain condition", condition: "github.event_name == 'issue_comment'", expected: true},
+		{name: "plain condition is implicitly guarded by success", condition: "github.event_name == 'issue_comment'", jobStatus: ExpressionJobStatusSkipped, expected: false},
+		{name: "always runs after skipped needs", condition: "always()", jobStatus: ExpressionJobStatusSkipped, expected: true},
+		{name: "not cancelled runs after failure", condition: "!cancelled()", jobStatus: ExpressionJobStatusFailure, expected: true},
+		{name: "not cancelled skips when cancelled", condition: "!cancelled()", jobStatus: ExpressionJobStatusCancelled, expected: false},
+		{name: "failure", condition: "failure()", jobStatus: ExpressionJobStatusFailure, expected: true},
+		{name: "success is false after skipped needs", condition: "success() || failure()", jobStatus: ExpressionJobStatusSkipped, expected: false},
+		{name: "truthy string", condition: "${{ github.actor }}", expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := newTestExpressionContext()
+			ctx.JobStatus = tt.jobStatus
+			result, err := EvaluateCondition(tt.condition, ctx)
+			require.NoError(t, err, "condition %q should evaluate", tt.condition)
+			assert.Equal(t, tt.expected, result, "unexpected result for %q with status %q", tt.condition, tt.jobStatus)
+		})
+	}
+}
+
+// TestEvaluateConditionGeneratedGuards evaluates conditions built with the
+// expression builders, so the rendered guards are checked against their intent.
+func TestEvaluateConditionGeneratedGuards(t *testing.T) {
+	condition := BuildFromAllowedForks([]string{}).Render()
+
+	ctx := &ExpressionContext{Contexts: map[string]any{"github": map[string]any{
+		"repository_id": 1,
+		"event":         map[string]any{"pull_request": map[string]any{"head": map[string]any{"repo": map[string]any{"id": 1}}}},
+	}}}
+	result, err := EvaluateCondition(condition, ctx)
+	require.NoError(t, err, "generated guard should evaluate")
+	assert.True(t, result, "same-repository pull request should pass the fork guard")
+
+	ctx.Contexts["github"].(map[string]any)["repository_id"] = 2
+	result, err = EvaluateCondition(condition, ctx)
+	require.NoError(t, err, "generated guard should evaluate")
+	assert.False(t, result, "fork pull request should fail the fork guard")
+}
@@ -183,6 +183,7 @@ func (e *ExpressionExtractor) ExtractExpressions(markdown string) ([]*Expression
 //	needs.activation.outputs.text -> steps.sanitized.outputs.text
 //	needs.activation.outputs.title -> steps.sanitized.outputs.title
 //	needs.activation.outputs.body -> steps.sanitized.outputs.body
+//	needs.activation.outputs.discussion_{number,category,body} -> steps.sanitized.outputs.discussion_{number,category,body}
 //
 // Other activation outputs (e.g., comment_id, comment_repo) are not transformed.
 //
@@ -194,7 +195,7 @@ func (e *ExpressionExtractor) ExtractExpressions(markdown string) ([]*Expression
 func transformActivationOutputs(expr string) string {
 	// Define the activation outputs that should be transformed
 	// These are the outputs generated by the sanitized step (formerly compute-text)
-	activationOutputs := []string{"text", "title", "body"}
+	activationOutputs := []string{"text", "title", "body", "discussion_number", "discussion_category", "discussion_body"}
 
 	for _, output := range activationOutputs {
 		// Build the old and new expressions
@@ -386,6 +386,11 @@ func TestTransformActivationOutputs(t *testing.T) {
 			input:    "needs.activation.outputs.body",
 			expected: "steps.sanitized.outputs.body",
 		},
+		{
+			name:     "transform discussion body output",
+			input:    "needs.activation.outputs.discussion_body",
+			expected: "steps.sanitized.outputs.discussion_body",
+		},
 		{
 			name:     "no transformation for other outputs",
 			input:    "needs.activation.outputs.comment_id",
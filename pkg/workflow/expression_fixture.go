@@ -0,0 +1,259 @@
+// This file implements fixture events for expression evaluation.
+//
+// # Expression Fixtures
+//
+// A fixture describes a simulated run in JSON: the triggering event and the
+// values of the other contexts the compiled conditions read, for example:
+//
+//	{
+//	  "event_name": "issue_comment",
+//	  "event": {"action": "created", "comment": {"body": "/triage"}},
+//	  "actor": "octocat",
+//	  "jobs": {"pre_activation": {"outputs": {"activated": "true"}}}
+//	}
+//
+// SimulateWorkflowJobs evaluates the `if:` condition of every job in a compiled
+// workflow against a fixture, in dependency order. Steps are not run, so a job
+// that would run is assumed to succeed with no outputs unless the fixture's jobs
+// entry supplies its result and outputs.
+
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+)
+
+// ExpressionFixture describes a simulated workflow run.
+type ExpressionFixture struct {
+	EventName  string         `json:"event_name"`           // github.event_name
+	Event      map[string]any `json:"event,omitempty"`      // github.event (the webhook payload)
+	Actor      string         `json:"actor,omitempty"`      // github.actor (defaults to event.sender.login)
+	Repository string         `json:"repository,omitempty"` // github.repository in owner/repo form
+	Ref        string         `json:"ref,omitempty"`        // github.ref
+	Inputs     map[string]any `json:"inputs,omitempty"`     // inputs (defaults to event.inputs)
+	Vars       map[string]any `json:"vars,omitempty"`       // vars
+	Env        map[string]any `json:"env,omitempty"`        // env
+	// Jobs sets the simulated result ("success", "failure", ...) and outputs of jobs by ID.
+	Jobs map[string]ExpressionFixtureJob `json:"jobs,omitempty"`
+}
+
+// ExpressionFixtureJob sets the simulated result and outputs of a job.
+type ExpressionFixtureJob struct {
+	Result  string         `json:"result,omitempty"`
+	Outputs map[string]any `json:"outputs,omitempty"`
+}
+
+// JobSimulation is the simulated outcome of a job.
+type JobSimulation struct {
+	Job       string   `json:"job"`
+	Needs     []string `json:"needs,omitempty"`
+	Condition string   `json:"condition,omitempty"`
+	Result    string   `json:"result"` // success, failure, cancelled or skipped
+	Error     string   `json:"error,omitempty"`
+}
+
+// LoadExpressionFixture reads a fixture from a JSON file.
+func LoadExpressionFixture(path string) (*ExpressionFixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture: %w", err)
+	}
+	var fixture ExpressionFixture
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		return nil, fmt.Errorf("failed to parse fixture %s: %w", path, err)
+	}
+	if fixture.EventName == "" {
+		return nil, fmt.Errorf("fixture %s must set event_name", path)
+	}
+	return &fixture, nil
+}
+
+// Context builds the expression context (github, inputs, vars and env) of the fixture.
+func (f *ExpressionFixture) Context() *ExpressionContext {
+	event := f.Event
+	if event == nil {
+		event = map[string]any{}
+	}
+	actor := f.Actor
+	if actor == "" {
+		if sender, ok := event["sender"].(map[string]any); ok {
+			actor, _ = sender["login"].(string)
+		}
+	}
+	owner, _, _ := strings.Cut(f.Repository, "/")
+	github := map[string]any{
+		"event_name":       f.EventName,
+		"event":            event,
+		"actor":            actor,
+		"triggering_actor": actor,
+		"repository":       f.Repository,
+		"repository_owner": owner,
+		"ref":              f.Ref,
+		"ref_name":         fixtureRefName(f.Ref),
+	}
+	inputs := f.Inputs
+	if inputs == nil {
+		inputs, _ = event["inputs"].(map[string]any)
+	}
+	return &ExpressionContext{Contexts: map[string]any{
+		"github": github,
+		"inputs": orEmptyExpressionMap(inputs),
+		"vars":   orEmptyExpressionMap(f.Vars),
+		"env":    orEmptyExpressionMap(f.Env),
+	}}
+}
+
+func fixtureRefName(ref string) string {
+	for _, prefix := range []string{"refs/heads/", "refs/tags/"} {
+		if name, ok := strings.CutPrefix(ref, prefix); ok {
+			return name
+		}
+	}
+	return ref
+}
+
+func orEmptyExpressionMap(values map[string]any) map[string]any {
+	if values == nil {
+		return map[string]any{}
+	}
+	return values
+}
+
+// SimulateWorkflowJobs evaluates the job conditions of a compiled workflow
+// (.lock.yml content) against a fixture and returns the jobs in the order they
+// would be considered.
+func SimulateWorkflowJobs(lockContent []byte, fixture *ExpressionFixture) ([]JobSimulation, error) {
+	var workflow struct {
+		Jobs map[string]struct {
+			If    any `yaml:"if"`
+			Needs any `yaml:"needs"`
+		} `yaml:"jobs"`
+	}
+	if err := yaml.Unmarshal(lockContent, &workflow); err != nil {
+		return nil, fmt.Errorf("failed to parse workflow: %w", err)
+	}
+	if len(workflow.Jobs) == 0 {
+		return nil, fmt.Errorf("workflow has no jobs")
+	}
+
+	needsByJob := make(map[string][]string, len(workflow.Jobs))
+	for id, job := range workflow.Jobs {
+		needsByJob[id] = nil
+		switch needs := job.Needs.(type) {
+		case string:
+			needsByJob[id] = []string{needs}
+		case []any:
+			for _, need := range needs {
+				needsByJob[id] = append(needsByJob[id], fmt.Sprint(need))
+			}
+		}
+		for _, need := range needsByJob[id] {
+			if _, ok := workflow.Jobs[need]; !ok {
+				return nil, fmt.Errorf("job %s needs unknown job %s", id, need)
+			}
+		}
+	}
+
+	order, err := sortJobsByNeeds(needsByJob)
+	if err != nil {
+		return nil, err
+	}
+
+	base := fixture.Context()
+	results := make(map[string]string, len(order))
+	simulations := make([]JobSimulation, 0, len(order))
+	for _, id := range order {
+		condition := ""
+		if raw := workflow.Jobs[id].If; raw != nil {
+			condition = strings.TrimSpace(fmt.Sprint(raw))
+		}
+		needs := needsByJob[id]
+		needsContext := make(map[string]any, len(needs))
+		for _, need := range needs {
+			needsContext[need] = map[string]any{
+				"result":  results[need],
+				"outputs": orEmptyExpressionMap(fixture.Jobs[need].Outputs),
+			}
+		}
+		contexts := maps.Clone(base.Contexts)
+		contexts["needs"] = needsContext
+		ctx := &ExpressionContext{Contexts: contexts, JobStatus: jobStatusFromNeeds(needs, results)}
+
+		simulation := JobSimulation{Job: id, Needs: needs, Condition: condition, Result: ExpressionJobStatusSkipped}
+		run, err := EvaluateCondition(condition, ctx)
+		switch {
+		case err != nil:
+			// The runner fails a job whose condition cannot be evaluated
+			simulation.Result = ExpressionJobStatusFailure
+			simulation.Error = err.Error()
+		case run:
+			simulation.Result = ExpressionJobStatusSuccess
+			if override := fixture.Jobs[id].Result; override != "" {
+				simulation.Result = override
+			}
+		}
+		expressionEvaluatorLog.Printf("Simulated job %s: %s", id, simulation.Result)
+		results[id] = simulation.Result
+		simulations = append(simulations, simulation)
+	}
+	return simulations, nil
+}
+
+// jobStatusFromNeeds derives the status seen by the status functions of a job
+// from the results of the jobs it needs.
+func jobStatusFromNeeds(needs []string, results map[string]string) string {
+	status := ExpressionJobStatusSuccess
+	for _, need := range needs {
+		switch results[need] {
+		case ExpressionJobStatusFailure:
+			return ExpressionJobStatusFailure
+		case ExpressionJobStatusCancelled:
+			status = ExpressionJobStatusCancelled
+		case ExpressionJobStatusSkipped:
+			if status == ExpressionJobStatusSuccess {
+				status = ExpressionJobStatusSkipped
+			}
+		}
+	}
+	return status
+}
+
+// sortJobsByNeeds orders jobs so every job comes after the jobs it needs, breaking
+// ties by job ID.
+func sortJobsByNeeds(needsByJob map[string][]string) ([]string, error) {
+	remaining := slices.Sorted(maps.Keys(needsByJob))
+	done := make(map[string]bool, len(remaining))
+	order := make([]string, 0, len(remaining))
+	for len(remaining) > 0 {
+		progressed := false
+		next := remaining[:0]
+		for _, id := range remaining {
+			ready := true
+			for _, need := range needsByJob[id] {
+				if !done[need] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				order = append(order, id)
+				done[id] = true
+				progressed = true
+			} else {
+				next = append(next, id)
+			}
+		}
+		if !progressed {
+			return nil, fmt.Errorf("jobs have circular needs: %s", strings.Join(next, ", "))
+		}
+		remaining = next
+	}
+	return order, nil
+}
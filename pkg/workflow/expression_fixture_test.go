@@ -0,0 +1,136 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const simulationTestLockFile = `name: test
+on: issue_comment
+jobs:
+  conclusion:
+    needs: [activation, agent]
+    if: always() && needs.agent.result != 'skipped'
+    runs-on: ubuntu-latest
+  agent:
+    needs: activation
+    runs-on: ubuntu-latest
+  activation:
+    needs: pre_activation
+    if: needs.pre_activation.outputs.activated == 'true'
+    runs-on: ubuntu-latest
+  pre_activation:
+    if: ${{ github.event_name == 'issue_comment' && startsWith(github.event.comment.body, '/triage') }}
+    runs-on: ubuntu-latest
+`
+
+func simulatedResults(simulations []JobSimulation) map[string]string {
+	results := make(map[string]string, len(simulations))
+	for _, simulation := range simulations {
+		results[simulation.Job] = simulation.Result
+	}
+	return results
+}
+
+func TestSimulateWorkflowJobs(t *testing.T) {
+	tests := []struct {
+		name     string
+		fixture  *ExpressionFixture
+		expected map[string]string
+	}{
+		{
+			name: "matching command runs every job",
+			fixture: &ExpressionFixture{
+				EventName: "issue_comment",
+				Event:     map[string]any{"comment": map[string]any{"body": "/triage"}},
+				Jobs:      map[string]ExpressionFixtureJob{"pre_activation": {Outputs: map[string]any{"activated": "true"}}},
+			},
+			expected: map[string]string{"pre_activation": "success", "activation": "success", "agent": "success", "conclusion": "success"},
+		},
+		{
+			name: "other comment skips the whole chain",
+			fixture: &ExpressionFixture{
+				EventName: "issue_comment",
+				Event:     map[string]any{"comment": map[string]any{"body": "thanks"}},
+			},
+			expected: map[string]string{"pre_activation": "skipped", "activation": "skipped", "agent": "skipped", "conclusion": "skipped"},
+		},
+		{
+			name: "failed agent still runs always() conclusion",
+			fixture: &ExpressionFixture{
+				EventName: "issue_comment",
+				Event:     map[string]any{"comment": map[string]any{"body": "/triage"}},
+				Jobs: map[string]ExpressionFixtureJob{
+					"pre_activation": {Outputs: map[string]any{"activated": "true"}},
+					"agent":          {Result: "failure"},
+				},
+			},
+			expected: map[string]string{"pre_activation": "success", "activation": "success", "agent": "failure", "conclusion": "success"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			simulations, err := SimulateWorkflowJobs([]byte(simulationTestLockFile), tt.fixture)
+			require.NoError(t, err, "simulation should succeed")
+			assert.Equal(t, tt.expected, simulatedResults(simulations), "unexpected job results")
+
+			jobs := make([]string, 0, len(simulations))
+			for _, simulation := range simulations {
+				jobs = append(jobs, simulation.Job)
+			}
+			assert.Equal(t, []string{"pre_activation", "activation", "agent", "conclusion"}, jobs, "jobs should be simulated in dependency order")
+		})
+	}
+}
+
+func TestSimulateWorkflowJobsErrors(t *testing.T) {
+	fixture := &ExpressionFixture{EventName: "push"}
+
+	_, err := SimulateWorkflowJobs([]byte("jobs:\n  a:\n    needs: missing\n"), fixture)
+	require.Error(t, err, "unknown needs should fail")
+	assert.Contains(t, err.Error(), "unknown job missing", "error should name the missing job")
+
+	_, err = SimulateWorkflowJobs([]byte("jobs:\n  a:\n    needs: b\n  b:\n    needs: a\n"), fixture)
+	require.Error(t, err, "circular needs should fail")
+	assert.Contains(t, err.Error(), "circular", "error should report the cycle")
+
+	simulations, err := SimulateWorkflowJobs([]byte("jobs:\n  a:\n    if: hashFiles('x')\n"), fixture)
+	require.NoError(t, err, "condition errors are reported per job")
+	require.Len(t, simulations, 1, "expected one job")
+	assert.Equal(t, ExpressionJobStatusFailure, simulations[0].Result, "job with invalid condition should fail")
+	assert.NotEmpty(t, simulations[0].Error, "job error should be reported")
+}
+
+func TestLoadExpressionFixture(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "event.json")
+	content := `{"event_name": "workflow_dispatch", "repository": "octo/repo", "ref": "refs/heads/main",
+		"event": {"sender": {"login": "octocat"}, "inputs": {"mode": "fast"}}}`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644), "failed to write fixture")
+
+	fixture, err := LoadExpressionFixture(path)
+	require.NoError(t, err, "fixture should load")
+
+	ctx := fixture.Context()
+	for expression, expected := range map[string]any{
+		"github.actor":            "octocat",
+		"github.repository_owner": "octo",
+		"github.ref_name":         "main",
+		"inputs.mode":             "fast",
+	} {
+		value, err := EvaluateExpression(expression, ctx)
+		require.NoError(t, err, "expression %q should evaluate", expression)
+		assert.Equal(t, expected, value, "unexpected value for %q", expression)
+	}
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"event": {}}`), 0o644), "failed to write fixture")
+	_, err = LoadExpressionFixture(path)
+	assert.Error(t, err, "fixture without event_name should fail")
+}
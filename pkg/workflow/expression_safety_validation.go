@@ -6,6 +6,7 @@
 package workflow
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
 	"slices"
@@ -136,6 +137,28 @@ func validateExpressionSafety(markdownContent string) error {
 	return nil
 }
 
+// locateUnauthorizedExpression points an expression safety error at the first unauthorized
+// expression in the workflow's own markdown body. Expressions that come from imports are not
+// in the source file, so the error is left without a position.
+func locateUnauthorizedExpression(err error, workflowData *WorkflowData, markdownPath string) {
+	var vErr *WorkflowValidationError
+	if !errors.As(err, &vErr) || vErr.Line > 0 {
+		return
+	}
+	for _, loc := range ExpressionPatternDotAll.FindAllStringIndex(workflowData.RawMarkdown, -1) {
+		if validateExpressionSafety(workflowData.RawMarkdown[loc[0]:loc[1]]) == nil {
+			continue
+		}
+		line, column := parser.LocateMarkdownOffset(workflowData.RawMarkdown, workflowData.MarkdownStartLine, loc[0])
+		if line > 0 {
+			vErr.File = markdownPath
+			vErr.Line = line
+			vErr.Column = column
+		}
+		return
+	}
+}
+
 // ExpressionValidationOptions contains the options for validating a single expression
 type ExpressionValidationOptions struct {
 	NeedsStepsRe            *regexp.Regexp
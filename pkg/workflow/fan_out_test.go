@@ -0,0 +1,142 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractFanOutConfig(t *testing.T) {
+	config, err := extractFanOutConfig(map[string]any{
+		"fan-out": map[string]any{
+			"issues":       map[string]any{"labels": []any{"needs-triage"}, "state": "all"},
+			"max-items":    50,
+			"max-parallel": 2,
+		},
+	})
+	require.NoError(t, err, "valid fan-out should parse")
+	assert.Equal(t, &FanOutConfig{
+		Issues:      &FanOutIssuesConfig{Labels: []string{"needs-triage"}, State: "all"},
+		MaxItems:    50,
+		MaxParallel: 2,
+	}, config)
+
+	config, err = extractFanOutConfig(map[string]any{
+		"fan-out": map[string]any{"items": []any{"docs", map[string]any{"area": "cli"}}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []any{"docs", map[string]any{"area": "cli"}}, config.Items)
+	assert.Equal(t, defaultFanOutMaxItems, config.MaxItems, "max-items should default")
+	assert.Equal(t, defaultFanOutMaxParallel, config.MaxParallel, "max-parallel should default")
+
+	config, err = extractFanOutConfig(map[string]any{})
+	require.NoError(t, err)
+	assert.Nil(t, config)
+}
+
+func TestExtractFanOutConfigErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		fanOut  any
+		wantErr string
+	}{
+		{name: "not an object", fanOut: []any{"a"}, wantErr: "fan-out must be an object"},
+		{name: "no source", fanOut: map[string]any{"max-parallel": 2}, wantErr: "must set issues or items"},
+		{name: "both sources", fanOut: map[string]any{"issues": true, "items": []any{"a"}}, wantErr: "cannot both be set"},
+		{name: "empty items", fanOut: map[string]any{"items": []any{}}, wantErr: "non-empty list"},
+		{name: "too many items", fanOut: map[string]any{"items": []any{"a", "b", "c"}, "max-items": 2}, wantErr: "more than max-items (2)"},
+		{name: "max-parallel out of range", fanOut: map[string]any{"issues": true, "max-parallel": 0}, wantErr: "max-parallel must be an integer between 1 and 256"},
+		{name: "invalid state", fanOut: map[string]any{"issues": map[string]any{"state": "merged"}}, wantErr: "open, closed, all"},
+		{name: "label with comma", fanOut: map[string]any{"issues": map[string]any{"labels": []any{"a,b"}}}, wantErr: "must not contain a comma"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := extractFanOutConfig(map[string]any{"fan-out": tt.fanOut})
+			require.Error(t, err, "fan-out should be rejected")
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestCompileFanOut(t *testing.T) {
+	dir := testutil.TempDir(t, "fan-out-*")
+	markdownPath := filepath.Join(dir, "sweep.md")
+	workflow := `---
+on:
+  workflow_dispatch:
+permissions:
+  contents: read
+  issues: read
+engine: copilot
+fan-out:
+  items: [docs, cli]
+  max-parallel: 1
+safe-outputs:
+  create-issue:
+---
+
+# Sweep
+
+Review the area of the repository named in the item.
+`
+	require.NoError(t, os.WriteFile(markdownPath, []byte(workflow), 0o644))
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(markdownPath), "fan-out workflow should compile")
+
+	lock, err := os.ReadFile(filepath.Join(dir, "sweep.lock.yml"))
+	require.NoError(t, err)
+	lockContent := string(lock)
+
+	assert.Contains(t, lockContent, "  fan_out:\n")
+	assert.Contains(t, lockContent, `FAN_OUT_ITEMS: "[\"docs\",\"cli\"]"`, "the explicit list should be embedded as JSON")
+	assert.Contains(t, lockContent, "entry: ${{ fromJSON(needs.fan_out.outputs.items) }}")
+	assert.Contains(t, lockContent, "max-parallel: 1")
+	assert.Contains(t, lockContent, "uses: ./.github/workflows/sweep.fan-out.job.lock.yml")
+	assert.Contains(t, lockContent, "fan-out-item: ${{ toJSON(matrix.entry.item) }}")
+	assert.Contains(t, lockContent, "issues: write", "the call should be granted the permissions of the companion jobs")
+	assert.NotContains(t, lockContent, "\n  agent:", "the agent should only run in the companion workflow")
+
+	companion, err := os.ReadFile(filepath.Join(dir, "sweep.fan-out.job.lock.yml"))
+	require.NoError(t, err, "the companion workflow should be written")
+	companionContent := string(companion)
+
+	assert.Contains(t, companionContent, "workflow_call:")
+	assert.Contains(t, companionContent, "group: gh-aw-sweep.fan-out.job-${{ github.run_id }}-${{ inputs.fan-out-index }}",
+		"items should not share the workflow-level concurrency group")
+	assert.Contains(t, companionContent, "<fan-out-item>")
+	assert.Contains(t, companionContent, ": ${{ inputs.fan-out-item }}", "the item should reach the prompt through an environment variable")
+	assert.Contains(t, companionContent, "Review the area of the repository named in the item.")
+}
+
+func TestCompileFanOutWithAgenticJobs(t *testing.T) {
+	dir := testutil.TempDir(t, "fan-out-*")
+	markdownPath := filepath.Join(dir, "sweep.md")
+	workflow := `---
+on:
+  workflow_dispatch:
+engine: copilot
+fan-out:
+  issues: true
+---
+
+# Sweep
+
+Triage the issue.
+
+## job: ` + "`research`" + `
+
+Research the issue.
+`
+	require.NoError(t, os.WriteFile(markdownPath, []byte(workflow), 0o644))
+
+	err := NewCompiler().CompileWorkflow(markdownPath)
+	require.Error(t, err, "fan-out and agentic jobs should not be combined")
+	assert.Contains(t, err.Error(), "fan-out cannot be combined with agentic jobs")
+}
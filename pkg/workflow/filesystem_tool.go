@@ -0,0 +1,213 @@
+package workflow
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var filesystemToolLog = logger.New("workflow:filesystem_tool")
+
+// filesystemMCPImage is the container image of the filesystem MCP server used for
+// engines without path-scoped native file tools.
+const filesystemMCPImage = "mcp/filesystem"
+
+// filesystemMCPReadOnlyTools are the filesystem MCP server tools that do not modify files.
+// They are the only tools allowed when the configuration has no write roots.
+var filesystemMCPReadOnlyTools = []string{
+	"directory_tree",
+	"get_file_info",
+	"list_allowed_directories",
+	"list_directory",
+	"list_directory_with_sizes",
+	"read_file",
+	"read_media_file",
+	"read_multiple_files",
+	"read_text_file",
+	"search_files",
+}
+
+// FilesystemToolConfig is the neutral filesystem tool:
+//
+//	tools:
+//	  filesystem:
+//	    read: [docs]
+//	    write: [docs/generated]
+//
+// Roots are repository-relative folders. Write roots are also readable.
+type FilesystemToolConfig struct {
+	Read  []string
+	Write []string
+}
+
+// parseFilesystemTool parses and validates the tools.filesystem configuration.
+func parseFilesystemTool(value any) (*FilesystemToolConfig, error) {
+	configMap, ok := value.(map[string]any)
+	if !ok {
+		return nil, NewValidationError(
+			"tools.filesystem",
+			fmt.Sprintf("%v", value),
+			"filesystem must be an object with read and/or write folder lists",
+			fmt.Sprintf("Configure the folders the agent may access:\n\ntools:\n  filesystem:\n    read:\n      - docs\n\nSee: %s", constants.DocsToolsURL),
+		)
+	}
+
+	config := &FilesystemToolConfig{}
+	for _, field := range []string{"read", "write"} {
+		raw, exists := configMap[field]
+		if !exists {
+			continue
+		}
+		items, ok := raw.([]any)
+		if !ok {
+			return nil, NewValidationError(
+				"tools.filesystem."+field,
+				fmt.Sprintf("%v", raw),
+				field+" must be a list of repository-relative folders",
+				fmt.Sprintf("Example:\n\ntools:\n  filesystem:\n    %s:\n      - docs\n\nSee: %s", field, constants.DocsToolsURL),
+			)
+		}
+		for _, item := range items {
+			root, err := normalizeFilesystemRoot(field, item)
+			if err != nil {
+				return nil, err
+			}
+			if field == "read" {
+				config.Read = appendUniqueRoot(config.Read, root)
+			} else {
+				config.Write = appendUniqueRoot(config.Write, root)
+			}
+		}
+	}
+
+	// A folder listed under write is already readable
+	config.Read = slices.DeleteFunc(config.Read, func(root string) bool {
+		return slices.Contains(config.Write, root)
+	})
+
+	if len(config.Read) == 0 && len(config.Write) == 0 {
+		return nil, NewValidationError(
+			"tools.filesystem",
+			"{}",
+			"filesystem requires at least one read or write folder",
+			fmt.Sprintf("Example:\n\ntools:\n  filesystem:\n    read:\n      - docs\n\nSee: %s", constants.DocsToolsURL),
+		)
+	}
+	return config, nil
+}
+
+// normalizeFilesystemRoot validates a single root and returns its cleaned form.
+// Roots must stay inside the repository checkout.
+func normalizeFilesystemRoot(field string, item any) (string, error) {
+	root, ok := item.(string)
+	if !ok || strings.TrimSpace(root) == "" {
+		return "", NewValidationError(
+			"tools.filesystem."+field,
+			fmt.Sprintf("%v", item),
+			"folders must be non-empty strings",
+			"Use repository-relative folder names such as 'docs' or 'src/api'.",
+		)
+	}
+	cleaned := path.Clean(strings.TrimSpace(root))
+	if path.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, "../") || strings.ContainsAny(cleaned, "*?[$`") {
+		return "", NewValidationError(
+			"tools.filesystem."+field,
+			root,
+			"folders must be repository-relative paths without '..', globs, or expressions",
+			"Use a folder inside the repository checkout, for example 'docs' or 'docs/generated'.",
+		)
+	}
+	return cleaned, nil
+}
+
+func appendUniqueRoot(roots []string, root string) []string {
+	if slices.Contains(roots, root) {
+		return roots
+	}
+	return append(roots, root)
+}
+
+// claudeAllowedTools returns path-scoped Claude file tools for the configured roots.
+func (f *FilesystemToolConfig) claudeAllowedTools() []string {
+	var tools []string
+	for _, root := range f.Read {
+		tools = append(tools, fmt.Sprintf("Read(%s)", filesystemRootPattern(root)))
+	}
+	for _, root := range f.Write {
+		pattern := filesystemRootPattern(root)
+		for _, tool := range []string{"Read", "Write", "Edit", "MultiEdit"} {
+			tools = append(tools, fmt.Sprintf("%s(%s)", tool, pattern))
+		}
+	}
+	return tools
+}
+
+func filesystemRootPattern(root string) string {
+	if root == "." {
+		return "./**"
+	}
+	return "./" + root + "/**"
+}
+
+// mcpServerConfig returns the custom MCP server configuration that replaces the neutral
+// tool for engines without path-scoped native file tools. Each root is bind-mounted at
+// its workspace path, read-only for read roots, so the server cannot reach other files.
+func (f *FilesystemToolConfig) mcpServerConfig() map[string]any {
+	var mounts, dirs []any
+	for _, root := range f.Read {
+		dir := filesystemWorkspacePath(root)
+		mounts = append(mounts, dir+":"+dir+":ro")
+		dirs = append(dirs, dir)
+	}
+	for _, root := range f.Write {
+		dir := filesystemWorkspacePath(root)
+		mounts = append(mounts, dir+":"+dir+":rw")
+		dirs = append(dirs, dir)
+	}
+
+	config := map[string]any{
+		"container":      filesystemMCPImage,
+		"mounts":         mounts,
+		"entrypointArgs": dirs,
+	}
+	if len(f.Write) == 0 {
+		allowed := make([]any, 0, len(filesystemMCPReadOnlyTools))
+		for _, tool := range filesystemMCPReadOnlyTools {
+			allowed = append(allowed, tool)
+		}
+		config["allowed"] = allowed
+	}
+	return config
+}
+
+func filesystemWorkspacePath(root string) string {
+	if root == "." {
+		return "${GITHUB_WORKSPACE}"
+	}
+	return "${GITHUB_WORKSPACE}/" + root
+}
+
+// applyFilesystemTool validates tools.filesystem and, for engines other than Claude,
+// replaces it with the equivalent filesystem MCP server. Claude keeps the neutral tool
+// and maps it to path-scoped native tools in expandNeutralToolsToClaudeTools.
+func applyFilesystemTool(data *WorkflowData) error {
+	value, exists := data.Tools["filesystem"]
+	if !exists {
+		return nil
+	}
+	config, err := parseFilesystemTool(value)
+	if err != nil {
+		return err
+	}
+	if ResolveEngineID(data) == "claude" {
+		filesystemToolLog.Printf("Using native file tools for filesystem: read=%v, write=%v", config.Read, config.Write)
+		return nil
+	}
+	filesystemToolLog.Printf("Using filesystem MCP server: read=%v, write=%v", config.Read, config.Write)
+	data.Tools["filesystem"] = config.mcpServerConfig()
+	return nil
+}
@@ -0,0 +1,123 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFilesystemTool(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         any
+		expectedRead  []string
+		expectedWrite []string
+		errContains   string
+	}{
+		{
+			name:         "read roots are cleaned",
+			value:        map[string]any{"read": []any{"docs/", "./examples"}},
+			expectedRead: []string{"docs", "examples"},
+		},
+		{
+			name:          "write roots are not repeated as read roots",
+			value:         map[string]any{"read": []any{"docs", "src"}, "write": []any{"docs"}},
+			expectedRead:  []string{"src"},
+			expectedWrite: []string{"docs"},
+		},
+		{
+			name:        "parent traversal is rejected",
+			value:       map[string]any{"read": []any{"docs/../../etc"}},
+			errContains: "repository-relative",
+		},
+		{
+			name:        "absolute path is rejected",
+			value:       map[string]any{"write": []any{"/etc"}},
+			errContains: "repository-relative",
+		},
+		{
+			name:        "globs are rejected",
+			value:       map[string]any{"read": []any{"docs/*"}},
+			errContains: "globs",
+		},
+		{
+			name:        "at least one root is required",
+			value:       map[string]any{"read": []any{}},
+			errContains: "at least one read or write folder",
+		},
+		{
+			name:        "non-object value is rejected",
+			value:       true,
+			errContains: "must be an object",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := parseFilesystemTool(tt.value)
+			if tt.errContains != "" {
+				require.Error(t, err, "invalid configuration should be rejected")
+				assert.Contains(t, err.Error(), tt.errContains, "error should explain the problem")
+				return
+			}
+			require.NoError(t, err, "valid configuration should parse")
+			assert.Equal(t, tt.expectedRead, config.Read, "read roots should match")
+			assert.Equal(t, tt.expectedWrite, config.Write, "write roots should match")
+		})
+	}
+}
+
+func TestFilesystemToolConfig_MCPServerConfig(t *testing.T) {
+	t.Run("read-only roots", func(t *testing.T) {
+		config := (&FilesystemToolConfig{Read: []string{"docs"}}).mcpServerConfig()
+		assert.Equal(t, "mcp/filesystem", config["container"], "filesystem MCP image should be used")
+		assert.Equal(t, []any{"${GITHUB_WORKSPACE}/docs:${GITHUB_WORKSPACE}/docs:ro"}, config["mounts"], "read roots should be mounted read-only")
+		assert.Equal(t, []any{"${GITHUB_WORKSPACE}/docs"}, config["entrypointArgs"], "mounted roots should be passed to the server")
+		assert.Contains(t, config["allowed"], "read_text_file", "read tools should be allowed")
+		assert.NotContains(t, config["allowed"], "write_file", "write tools should not be allowed")
+	})
+
+	t.Run("write roots", func(t *testing.T) {
+		config := (&FilesystemToolConfig{Read: []string{"src"}, Write: []string{"."}}).mcpServerConfig()
+		assert.Equal(t, []any{
+			"${GITHUB_WORKSPACE}/src:${GITHUB_WORKSPACE}/src:ro",
+			"${GITHUB_WORKSPACE}:${GITHUB_WORKSPACE}:rw",
+		}, config["mounts"], "write roots should be mounted read-write")
+		assert.NotContains(t, config, "allowed", "all tools should be available with write roots")
+	})
+}
+
+func TestApplyFilesystemTool(t *testing.T) {
+	newData := func(engine string) *WorkflowData {
+		return &WorkflowData{
+			EngineConfig: &EngineConfig{ID: engine},
+			Tools:        map[string]any{"filesystem": map[string]any{"read": []any{"docs"}}},
+		}
+	}
+
+	data := newData("copilot")
+	require.NoError(t, applyFilesystemTool(data), "filesystem should be applied")
+	hasMCP, mcpType := hasMCPConfig(data.Tools["filesystem"].(map[string]any))
+	assert.True(t, hasMCP, "filesystem should become an MCP server")
+	assert.Equal(t, "stdio", mcpType, "filesystem MCP server should run as a container")
+
+	data = newData("claude")
+	require.NoError(t, applyFilesystemTool(data), "filesystem should be applied")
+	assert.Equal(t, map[string]any{"read": []any{"docs"}}, data.Tools["filesystem"], "Claude should keep the neutral tool")
+}
+
+func TestClaudeFilesystemTools(t *testing.T) {
+	engine := NewClaudeEngine()
+	tools := map[string]any{
+		"filesystem": map[string]any{"read": []any{"docs"}, "write": []any{"docs/generated"}},
+	}
+	allowed := engine.computeAllowedClaudeToolsString(tools, nil, nil, nil, nil)
+	assert.Contains(t, allowed, "Read(./docs/**)", "read root should be readable")
+	assert.Contains(t, allowed, "Write(./docs/generated/**)", "write root should be writable")
+	assert.Contains(t, allowed, "Edit(./docs/generated/**)", "write root should be editable")
+	assert.NotContains(t, allowed, "Write(./docs/**)", "read root should not be writable")
+	assert.NotContains(t, allowed, "mcp__filesystem", "no MCP server should be used")
+}
@@ -150,6 +150,16 @@ func isDockerSbxRuntime(workflowData *WorkflowData) bool {
 	return agentConfig.Runtime == AgentRuntimeDockerSbx
 }
 
+// isDevcontainerRuntime returns true when the agent should run inside the
+// repository's devcontainer image.
+func isDevcontainerRuntime(workflowData *WorkflowData) bool {
+	agentConfig := getAgentConfig(workflowData)
+	if agentConfig == nil || agentConfig.Disabled {
+		return false
+	}
+	return agentConfig.Devcontainer != nil
+}
+
 func isAWFNetworkIsolationEnabled(workflowData *WorkflowData) bool {
 	agentConfig := getAgentConfig(workflowData)
 	if agentConfig == nil || agentConfig.Disabled {
@@ -163,65 +173,15 @@ func isAWFNetworkIsolationEnabled(workflowData *WorkflowData) bool {
 	return agentConfig.NetworkIsolation
 }
 
-// enableFirewallByDefaultForCopilot enables firewall by default for copilot and codex engines
-// when network restrictions are present but no explicit firewall configuration exists
-// and no SRT sandbox is configured (SRT and AWF are mutually exclusive)
-// and sandbox.agent is not explicitly set to false
-//
-// The firewall is enabled by default for copilot and codex UNLESS:
-// - allowed contains "*" (unrestricted network access)
-// - sandbox.agent is explicitly set to false
-// - SRT sandbox is configured
-func enableFirewallByDefaultForCopilot(engineID string, networkPermissions *NetworkPermissions, sandboxConfig *SandboxConfig) {
-	// Only apply to copilot and codex engines
-	if engineID != string(constants.CopilotEngine) && engineID != string(constants.CodexEngine) {
-		return
-	}
-
-	enableFirewallByDefaultForEngine(engineID, networkPermissions, sandboxConfig)
-}
-
-// enableFirewallByDefaultForClaude enables firewall by default for Claude engine
-// when network restrictions are present but no explicit firewall configuration exists
-// and sandbox.agent is not explicitly set to false
-//
-// The firewall is enabled by default for Claude UNLESS:
-// - allowed contains "*" (unrestricted network access)
-// - sandbox.agent is explicitly set to false
-func enableFirewallByDefaultForClaude(engineID string, networkPermissions *NetworkPermissions, sandboxConfig *SandboxConfig) {
-	// Only apply to claude engine
-	if engineID != string(constants.ClaudeEngine) {
-		return
-	}
-
-	enableFirewallByDefaultForEngine(engineID, networkPermissions, sandboxConfig)
-}
-
-// enableFirewallByDefaultForPi enables firewall by default for Pi engine
-// when network restrictions are present but no explicit firewall configuration exists
-// and sandbox.agent is not explicitly set to false
-//
-// The firewall is enabled by default for Pi UNLESS:
-// - allowed contains "*" (unrestricted network access)
-// - sandbox.agent is explicitly set to false
-func enableFirewallByDefaultForPi(engineID string, networkPermissions *NetworkPermissions, sandboxConfig *SandboxConfig) {
-	// Only apply to pi engine
-	if engineID != string(constants.PiEngine) {
-		return
-	}
-
-	enableFirewallByDefaultForEngine(engineID, networkPermissions, sandboxConfig)
-}
-
-// enableFirewallByDefaultForEngine enables firewall by default for a given engine
-// when network restrictions are present but no explicit firewall configuration exists
-// and no SRT sandbox is configured (SRT and AWF are mutually exclusive)
-// and sandbox.agent is not explicitly set to false
+// enableFirewallByDefaultForEngine enables firewall by default for any engine
+// when no explicit firewall configuration exists and sandbox.agent is not
+// explicitly set to false. Every engine runs behind the AWF egress proxy, so
+// network.allowed is enforced the same way regardless of the engine's own
+// sandbox features.
 //
-// The firewall is enabled by default for the engine UNLESS:
+// The firewall is enabled by default UNLESS:
 // - allowed contains "*" (unrestricted network access)
 // - sandbox.agent is explicitly set to false
-// - SRT sandbox is configured (Copilot only)
 func enableFirewallByDefaultForEngine(engineID string, networkPermissions *NetworkPermissions, sandboxConfig *SandboxConfig) {
 	// Check if network permissions exist
 	if networkPermissions == nil {
@@ -251,7 +211,7 @@ func enableFirewallByDefaultForEngine(engineID string, networkPermissions *Netwo
 		return
 	}
 
-	// Enable firewall by default for the engine (copilot, claude, codex)
+	// Enable firewall by default for the engine
 	// This applies to all cases EXCEPT when allowed = "*"
 	networkPermissions.Firewall = &FirewallConfig{
 		Enabled: true,
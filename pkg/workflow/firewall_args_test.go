@@ -64,8 +64,8 @@ func TestFirewallArgsInCopilotEngine(t *testing.T) {
 		}
 
 		// Verify that --log-dir is included in copilot args for log collection
-		if !strings.Contains(stepContent, "--log-dir /tmp/gh-aw/sandbox/agent/logs/") {
-			t.Error("Expected copilot command to contain '--log-dir /tmp/gh-aw/sandbox/agent/logs/' for log collection in firewall mode")
+		if !strings.Contains(stepContent, `--log-dir "${{ env.GH_AW_TMP_DIR }}/sandbox/agent/logs/"`) {
+			t.Error("Expected copilot command to contain '--log-dir ${{ env.GH_AW_TMP_DIR }}/sandbox/agent/logs/' for log collection in firewall mode")
 		}
 	})
 
@@ -333,10 +333,10 @@ func TestFirewallArgsInCopilotEngine(t *testing.T) {
 		if !strings.Contains(stepContent, "--prompt-file ${RUNNER_TEMP}/gh-aw/aw-prompts/prompt.txt") {
 			t.Error("Expected prompt file path to be rewritten under ${RUNNER_TEMP}/gh-aw for arc-dind")
 		}
-		if !strings.Contains(stepContent, "--log-dir ${RUNNER_TEMP}/gh-aw/sandbox/agent/logs/") {
+		if !strings.Contains(stepContent, `--log-dir "${RUNNER_TEMP}/gh-aw/sandbox/agent/logs/"`) {
 			t.Error("Expected copilot log-dir to be rewritten under ${RUNNER_TEMP}/gh-aw for arc-dind")
 		}
-		if !strings.Contains(stepContent, "--add-dir ${RUNNER_TEMP}/gh-aw/") {
+		if !strings.Contains(stepContent, `--add-dir "${RUNNER_TEMP}/gh-aw/"`) {
 			t.Error("Expected copilot --add-dir path to be rewritten under ${RUNNER_TEMP}/gh-aw for arc-dind")
 		}
 		if !strings.Contains(stepContent, `${RUNNER_TEMP}/gh-aw/bin/copilot`) {
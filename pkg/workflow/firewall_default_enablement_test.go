@@ -7,15 +7,15 @@ import (
 	"testing"
 )
 
-// TestEnableFirewallByDefaultForCopilot tests the automatic firewall enablement for copilot engine
-func TestEnableFirewallByDefaultForCopilot(t *testing.T) {
+// TestEnableFirewallByDefaultForEngine tests the automatic firewall enablement for every engine
+func TestEnableFirewallByDefaultForEngine(t *testing.T) {
 	t.Run("copilot engine with network restrictions enables firewall by default", func(t *testing.T) {
 		networkPerms := &NetworkPermissions{
 			Allowed:           []string{"example.com", "api.github.com"},
 			ExplicitlyDefined: true,
 		}
 
-		enableFirewallByDefaultForCopilot("copilot", networkPerms, nil)
+		enableFirewallByDefaultForEngine("copilot", networkPerms, nil)
 
 		if networkPerms.Firewall == nil {
 			t.Error("Expected firewall to be enabled by default for copilot engine with network restrictions")
@@ -31,7 +31,7 @@ func TestEnableFirewallByDefaultForCopilot(t *testing.T) {
 			ExplicitlyDefined: true,
 		}
 
-		enableFirewallByDefaultForCopilot("copilot", networkPerms, nil)
+		enableFirewallByDefaultForEngine("copilot", networkPerms, nil)
 
 		if networkPerms.Firewall == nil {
 			t.Error("Expected firewall to be enabled by default for copilot engine with network:defaults")
@@ -48,7 +48,7 @@ func TestEnableFirewallByDefaultForCopilot(t *testing.T) {
 			Allowed:           []string{},
 		}
 
-		enableFirewallByDefaultForCopilot("copilot", networkPerms, nil)
+		enableFirewallByDefaultForEngine("copilot", networkPerms, nil)
 
 		if networkPerms.Firewall == nil {
 			t.Error("Expected firewall to be enabled by default for copilot engine with empty network object")
@@ -65,7 +65,7 @@ func TestEnableFirewallByDefaultForCopilot(t *testing.T) {
 			ExplicitlyDefined: true,
 		}
 
-		enableFirewallByDefaultForCopilot("copilot", networkPerms, nil)
+		enableFirewallByDefaultForEngine("copilot", networkPerms, nil)
 
 		if networkPerms.Firewall != nil {
 			t.Error("Expected firewall to NOT be enabled when allowed contains wildcard '*'")
@@ -81,23 +81,25 @@ func TestEnableFirewallByDefaultForCopilot(t *testing.T) {
 			},
 		}
 
-		enableFirewallByDefaultForCopilot("copilot", networkPerms, nil)
+		enableFirewallByDefaultForEngine("copilot", networkPerms, nil)
 
 		if networkPerms.Firewall.Enabled {
 			t.Error("Expected explicit firewall.Enabled=false to be preserved")
 		}
 	})
 
-	t.Run("non-copilot engine does not enable firewall", func(t *testing.T) {
-		networkPerms := &NetworkPermissions{
-			Allowed:           []string{"example.com"},
-			ExplicitlyDefined: true,
-		}
+	t.Run("engines without their own sandbox also enable firewall", func(t *testing.T) {
+		for _, engineID := range []string{"claude", "gemini", "antigravity", "opencode", "pi"} {
+			networkPerms := &NetworkPermissions{
+				Allowed:           []string{"example.com", "*.npmjs.org"},
+				ExplicitlyDefined: true,
+			}
 
-		enableFirewallByDefaultForCopilot("claude", networkPerms, nil)
+			enableFirewallByDefaultForEngine(engineID, networkPerms, nil)
 
-		if networkPerms.Firewall != nil {
-			t.Error("Expected firewall to remain nil for non-copilot engine")
+			if networkPerms.Firewall == nil || !networkPerms.Firewall.Enabled {
+				t.Errorf("Expected firewall to be enabled by default for %s engine", engineID)
+			}
 		}
 	})
 
@@ -107,7 +109,7 @@ func TestEnableFirewallByDefaultForCopilot(t *testing.T) {
 			ExplicitlyDefined: true,
 		}
 
-		enableFirewallByDefaultForCopilot("codex", networkPerms, nil)
+		enableFirewallByDefaultForEngine("codex", networkPerms, nil)
 
 		if networkPerms.Firewall == nil {
 			t.Error("Expected firewall to be enabled by default for codex engine with network restrictions")
@@ -118,14 +120,60 @@ func TestEnableFirewallByDefaultForCopilot(t *testing.T) {
 		}
 	})
 
+	t.Run("gemini engine with network:defaults enables firewall by default", func(t *testing.T) {
+		networkPerms := &NetworkPermissions{
+			ExplicitlyDefined: true,
+		}
+
+		enableFirewallByDefaultForEngine("gemini", networkPerms, nil)
+
+		if networkPerms.Firewall == nil {
+			t.Fatal("Expected firewall to be enabled by default for gemini engine with network:defaults")
+		}
+
+		if !networkPerms.Firewall.Enabled {
+			t.Error("Expected firewall.Enabled to be true")
+		}
+	})
+
+	t.Run("pi engine with network restrictions enables firewall by default", func(t *testing.T) {
+		networkPerms := &NetworkPermissions{
+			Allowed:           []string{"example.com"},
+			ExplicitlyDefined: true,
+		}
+
+		enableFirewallByDefaultForEngine("pi", networkPerms, nil)
+
+		if networkPerms.Firewall == nil {
+			t.Fatal("Expected firewall to be enabled by default for pi engine with network restrictions")
+		}
+
+		if !networkPerms.Firewall.Enabled {
+			t.Error("Expected firewall.Enabled to be true")
+		}
+	})
+
+	t.Run("pi engine with wildcard allowed does NOT enable firewall", func(t *testing.T) {
+		networkPerms := &NetworkPermissions{
+			Allowed:           []string{"*"},
+			ExplicitlyDefined: true,
+		}
+
+		enableFirewallByDefaultForEngine("pi", networkPerms, nil)
+
+		if networkPerms.Firewall != nil {
+			t.Error("Expected firewall to NOT be enabled for pi engine when allowed contains wildcard '*'")
+		}
+	})
+
 	t.Run("nil network permissions does not cause error", func(t *testing.T) {
 		// Should not panic
-		enableFirewallByDefaultForCopilot("copilot", nil, nil)
+		enableFirewallByDefaultForEngine("copilot", nil, nil)
 	})
 }
 
-// TestCopilotFirewallDefaultIntegration tests the integration with workflow compilation
-func TestCopilotFirewallDefaultIntegration(t *testing.T) {
+// TestFirewallDefaultIntegration tests the integration with workflow compilation
+func TestFirewallDefaultIntegration(t *testing.T) {
 	t.Run("copilot workflow with network restrictions includes AWF installation", func(t *testing.T) {
 		frontmatter := map[string]any{
 			"on": "workflow_dispatch",
@@ -155,7 +203,7 @@ func TestCopilotFirewallDefaultIntegration(t *testing.T) {
 		}
 
 		// Enable firewall by default
-		enableFirewallByDefaultForCopilot(engineConfig.ID, networkPerms, nil)
+		enableFirewallByDefaultForEngine(engineConfig.ID, networkPerms, nil)
 
 		// Verify firewall is enabled
 		if networkPerms.Firewall == nil {
@@ -232,7 +280,7 @@ func TestCopilotFirewallDefaultIntegration(t *testing.T) {
 		}
 
 		// Enable firewall by default (should not override explicit config)
-		enableFirewallByDefaultForCopilot(engineConfig.ID, networkPerms, sandboxConfig)
+		enableFirewallByDefaultForEngine(engineConfig.ID, networkPerms, sandboxConfig)
 
 		// Create workflow data
 		workflowData := &WorkflowData{
@@ -255,13 +303,13 @@ func TestCopilotFirewallDefaultIntegration(t *testing.T) {
 		}
 	})
 
-	t.Run("claude engine with network restrictions does not enable firewall", func(t *testing.T) {
+	t.Run("gemini engine with network restrictions enables firewall", func(t *testing.T) {
 		frontmatter := map[string]any{
 			"on": "workflow_dispatch",
 			"permissions": map[string]any{
 				"contents": "read",
 			},
-			"engine": "claude",
+			"engine": "gemini",
 			"network": map[string]any{
 				"allowed": []any{"example.com"},
 			},
@@ -273,8 +321,8 @@ func TestCopilotFirewallDefaultIntegration(t *testing.T) {
 
 		// Extract engine config
 		engineSetting, engineConfig, _ := c.ExtractEngineConfig(frontmatter)
-		if engineSetting != "claude" {
-			t.Fatalf("Expected engine 'claude', got '%s'", engineSetting)
+		if engineSetting != "gemini" {
+			t.Fatalf("Expected engine 'gemini', got '%s'", engineSetting)
 		}
 
 		// Extract network permissions
@@ -283,12 +331,44 @@ func TestCopilotFirewallDefaultIntegration(t *testing.T) {
 			t.Fatal("Expected network permissions to be extracted")
 		}
 
-		// Enable firewall by default (should not affect non-copilot engines)
-		enableFirewallByDefaultForCopilot(engineConfig.ID, networkPerms, nil)
+		// Enable firewall by default (applies to every engine)
+		enableFirewallByDefaultForEngine(engineConfig.ID, networkPerms, nil)
 
-		// Verify firewall is NOT enabled for claude
-		if networkPerms.Firewall != nil {
-			t.Error("Expected firewall to remain nil for claude engine")
+		// Verify firewall is enabled for gemini
+		if networkPerms.Firewall == nil || !networkPerms.Firewall.Enabled {
+			t.Error("Expected firewall to be enabled for gemini engine")
+		}
+	})
+
+	t.Run("pi engine with network restrictions enables firewall", func(t *testing.T) {
+		frontmatter := map[string]any{
+			"on": "workflow_dispatch",
+			"permissions": map[string]any{
+				"contents": "read",
+			},
+			"engine": "pi",
+			"network": map[string]any{
+				"allowed": []any{"example.com"},
+			},
+		}
+
+		c := NewCompiler()
+		c.SetSkipValidation(true)
+
+		engineSetting, engineConfig, _ := c.ExtractEngineConfig(frontmatter)
+		if engineSetting != "pi" {
+			t.Fatalf("Expected engine 'pi', got '%s'", engineSetting)
+		}
+
+		networkPerms := c.extractNetworkPermissions(frontmatter)
+		if networkPerms == nil {
+			t.Fatal("Expected network permissions to be extracted")
+		}
+
+		enableFirewallByDefaultForEngine(engineConfig.ID, networkPerms, nil)
+
+		if networkPerms.Firewall == nil || !networkPerms.Firewall.Enabled {
+			t.Error("Expected firewall to be enabled for pi engine")
 		}
 	})
 }
@@ -331,7 +411,7 @@ func TestDailyTeamStatusFirewallEnabled(t *testing.T) {
 		}
 
 		// Enable firewall by default
-		enableFirewallByDefaultForCopilot(engineID, networkPerms, nil)
+		enableFirewallByDefaultForEngine(engineID, networkPerms, nil)
 
 		// Verify firewall is enabled
 		if networkPerms.Firewall == nil {
@@ -60,6 +60,8 @@ func (c *Compiler) extractNetworkPermissions(frontmatter map[string]any) *Networ
 				}
 			}
 
+			permissions.Proxy = parseNetworkProxyConfig(networkObj)
+
 			// Empty object {} means no network access (empty allowed list)
 			return permissions
 		}
@@ -274,6 +276,24 @@ func (c *Compiler) extractAgentSandboxConfig(agentVal any) *AgentSandboxConfig {
 		}
 	}
 
+	// Extract devcontainer (run the agent in the repository's devcontainer image)
+	if devcontainerVal, hasDevcontainer := agentObj["devcontainer"]; hasDevcontainer {
+		switch v := devcontainerVal.(type) {
+		case bool:
+			if v {
+				agentConfig.Devcontainer = &DevcontainerConfig{}
+			}
+		case map[string]any:
+			agentConfig.Devcontainer = &DevcontainerConfig{}
+			if configStr, ok := v["config"].(string); ok {
+				agentConfig.Devcontainer.Config = configStr
+			}
+		}
+		if agentConfig.Devcontainer != nil {
+			frontmatterExtractionSecurityLog.Printf("Extracted sandbox.agent.devcontainer: config=%q", agentConfig.Devcontainer.Config)
+		}
+	}
+
 	// Extract legacy-security (opt-in to legacy sudo/iptables mode)
 	if legacyVal, hasLegacy := agentObj["legacy-security"]; hasLegacy {
 		if legacyStr, ok := legacyVal.(string); ok && legacyStr == "enable" {
@@ -499,6 +519,19 @@ func (c *Compiler) extractMCPGatewayConfig(mcpVal any) *MCPGatewayRuntimeConfig
 		}
 	}
 
+	// Extract chaos (seeded fault injection for MCP tool calls)
+	if chaosVal, hasChaos := mcpObj["chaos"]; hasChaos {
+		mcpConfig.Chaos = extractMCPChaosConfig(chaosVal)
+	}
+
+	// Extract record / replay (capture tool calls, or answer them from a recording)
+	if record, ok := mcpObj["record"].(bool); ok {
+		mcpConfig.Record = record
+	}
+	if replay, ok := mcpObj["replay"].(string); ok {
+		mcpConfig.Replay = replay
+	}
+
 	return mcpConfig
 }
 
@@ -64,6 +64,8 @@ type onSectionCleanupState struct {
 	inSkipIfNoMatch              bool
 	inSkipIfCheckFailing         bool
 	inSkipAuthorAssociations     bool
+	inReactionCommand            bool
+	inTriggerFilters             bool
 	inSkipRolesArray             bool
 	inSkipBotsArray              bool
 	inRolesArray                 bool
@@ -133,7 +135,7 @@ func (s *onSectionCleanupState) handleEventSectionEntry(info onSectionLine, resu
 }
 
 func (s *onSectionCleanupState) detectEventSection(info onSectionLine) (string, bool) {
-	if s.inOnPermissions || s.inOnSteps || s.inSkipAuthorAssociations {
+	if s.inOnPermissions || s.inOnSteps || s.inSkipAuthorAssociations || s.inTriggerFilters {
 		return "", false
 	}
 	if info.indent != 2 && info.indent != 4 {
@@ -184,6 +186,8 @@ func (s *onSectionCleanupState) resetTopLevelExtensionState() {
 	s.inSkipIfNoMatch = false
 	s.inSkipIfCheckFailing = false
 	s.inSkipAuthorAssociations = false
+	s.inReactionCommand = false
+	s.inTriggerFilters = false
 }
 
 func (s *onSectionCleanupState) leaveEventSections(info onSectionLine) {
@@ -283,6 +287,12 @@ func (s *onSectionCleanupState) enterObjectSections(info onSectionLine) {
 	if !s.inEventSection() && !s.inSkipAuthorAssociations && strings.HasPrefix(info.trimmed, "skip-author-associations:") && info.trimmed == "skip-author-associations:" {
 		s.inSkipAuthorAssociations = true
 	}
+	if !s.inEventSection() && !s.inReactionCommand && info.trimmed == "reaction_command:" {
+		s.inReactionCommand = true
+	}
+	if !s.inEventSection() && !s.inTriggerFilters && info.indent == 2 && info.trimmed == "filters:" {
+		s.inTriggerFilters = true
+	}
 	if !s.inEventSection() && !s.inGitHubApp && ((strings.HasPrefix(info.trimmed, "github-app:") && info.trimmed == "github-app:") ||
 		(strings.HasPrefix(info.trimmed, "# github-app:") && strings.Contains(info.trimmed, "pre-activation job"))) {
 		s.inGitHubApp = true
@@ -308,6 +318,12 @@ func (s *onSectionCleanupState) leaveObjectSections(info onSectionLine) {
 	if s.inSkipAuthorAssociations && isLeavingTopLevelObject(info, "skip-author-associations:", "# skip-author-associations:") {
 		s.inSkipAuthorAssociations = false
 	}
+	if s.inReactionCommand && isLeavingTopLevelObject(info, "reaction_command:", "# reaction_command:") {
+		s.inReactionCommand = false
+	}
+	if s.inTriggerFilters && isLeavingTopLevelObject(info, "filters:", "# filters:") {
+		s.inTriggerFilters = false
+	}
 	if s.inGitHubApp && isLeavingTopLevelObject(info, "github-app:", "# github-app:") {
 		s.inGitHubApp = false
 	}
@@ -421,6 +437,14 @@ func (s *onSectionCleanupState) commentConditionalObjectField(info onSectionLine
 		return true, " # Skip-if-check-failing processed as check status gate in pre-activation job"
 	case s.inSkipIfCheckFailing && (hasAnyPrefixInLine(info.trimmed, "include:", "exclude:", "branch:", "allow-pending:") || strings.HasPrefix(info.trimmed, "-")):
 		return true, ""
+	case strings.HasPrefix(info.trimmed, "reaction_command:"):
+		return true, " # Reaction command processed as polling schedule and reaction check in pre-activation job"
+	case s.inReactionCommand && info.indent > 2:
+		return true, ""
+	case info.indent == 2 && strings.HasPrefix(info.trimmed, "filters:"):
+		return true, " # Filters compiled into native paths filters and pre-activation checks"
+	case s.inTriggerFilters && info.indent > 2:
+		return true, ""
 	case strings.HasPrefix(info.trimmed, "skip-author-associations:"):
 		return true, " # Skip-author-associations compiled into pre-activation job if condition"
 	case s.inSkipAuthorAssociations && info.indent > 2:
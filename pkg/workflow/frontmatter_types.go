@@ -294,7 +294,16 @@ type OTLPConfig struct {
 
 // ObservabilityConfig represents workflow observability options.
 type ObservabilityConfig struct {
-	OTLP *OTLPConfig `json:"otlp,omitempty"`
+	OTLP *OTLPConfig       `json:"otlp,omitempty"`
+	SLO  *LatencySLOConfig `json:"slo,omitempty"`
+}
+
+// LatencySLOConfig holds the latency SLOs checked by the conclusion job (observability.slo).
+// Durations are Go duration strings measured from the start of the run.
+type LatencySLOConfig struct {
+	Start  string `json:"start,omitempty"`  // Maximum time until the agent job starts
+	Finish string `json:"finish,omitempty"` // Maximum time until the agent job finishes
+	Notify bool   `json:"notify,omitempty"` // Also comment on the "[aw] SLO Breaches" tracking issue
 }
 
 // FrontmatterConfig represents the structured configuration from workflow frontmatter
@@ -373,7 +382,9 @@ type FrontmatterConfig struct {
 	Resources      []string       `json:"resources,omitempty"`       // Additional workflow .md or action .yml files to fetch alongside this workflow
 
 	// Metadata
-	Metadata      map[string]string    `json:"metadata,omitempty"` // Custom metadata key-value pairs
+	Metadata      map[string]string    `json:"metadata,omitempty"`      // Custom metadata key-value pairs
+	Context       map[string]string    `json:"context,omitempty"`       // Named values interpolated into the body as ${{ context.<name> }}
+	PromptBudget  map[string]any       `json:"prompt-budget,omitempty"` // Token budgets per prompt section; see PromptBudget
 	SecretMasking *SecretMaskingConfig `json:"secret-masking,omitempty"`
 	Observability *ObservabilityConfig `json:"observability,omitempty"`
 
@@ -32,6 +32,7 @@ func NewGeminiEngine() *GeminiEngine {
 				MaxContinuations: false, // Gemini CLI does not support --max-autopilot-continues-style continuation mode
 				WebSearch:        false,
 				NativeAgentFile:  false, // Gemini does not support agent file natively; the compiler prepends the agent file content to prompt.txt
+				SettingsScope:    true,  // Gemini writes .gemini/settings.json and honors engine.settings-scope
 			},
 			dedicatedLLMGatewayPort: constants.GeminiLLMGatewayPort,
 		},
@@ -122,6 +123,12 @@ func (e *GeminiEngine) GetDeclaredOutputFiles() []string {
 	}
 }
 
+// GetLogFileForParsing returns the stdout log, which holds the stream-json output
+// without the stderr diagnostics that agent-stdio.log interleaves.
+func (e *GeminiEngine) GetLogFileForParsing() string {
+	return constants.AgentStdoutLogPath
+}
+
 // GetAgentManifestFiles returns Gemini-specific instruction files that should be
 // treated as security-sensitive manifests.  A fork PR that modifies these files
 // can redirect the agent's behaviour or expand which files it treats as instructions.
@@ -148,7 +155,7 @@ func (e *GeminiEngine) GetPreBundleSteps(workflowData *WorkflowData) []GitHubAct
 		{
 			"      - name: Move Gemini error files to artifact directory",
 			"        if: always()",
-			"        run: mv /tmp/gemini-client-error-*.json /tmp/gh-aw/ 2>/dev/null || true",
+			"        run: mv /tmp/gemini-client-error-*.json " + constants.TmpGhAwDirExpr + "/ 2>/dev/null || true",
 		},
 	}
 }
@@ -204,7 +211,7 @@ func (e *GeminiEngine) GetExecutionSteps(workflowData *WorkflowData, logFile str
 	}
 
 	// Append the prompt arg raw (not through shellJoinArgs) to preserve shell expansion
-	geminiCommand := fmt.Sprintf(`%s %s --prompt "$(cat /tmp/gh-aw/aw-prompts/prompt.txt)"`, commandName, shellJoinArgs(geminiArgs))
+	geminiCommand := fmt.Sprintf(`%s %s --prompt "$(cat `+constants.TmpGhAwDirExpr+`/aw-prompts/prompt.txt)"`, commandName, shellJoinArgs(geminiArgs))
 	geminiCommand = getWorkspaceCommandPrefixFor(workflowData.EngineConfig) + geminiCommand
 
 	// Build the full command with AWF wrapping if enabled
@@ -254,8 +261,8 @@ func (e *GeminiEngine) GetExecutionSteps(workflowData *WorkflowData, logFile str
 		command = fmt.Sprintf(`set -o pipefail
 printf '%%s' "$(date +%%s%%3N)" > %s
 touch %s
-(umask 177 && touch %s)
-%s 2>&1 | tee -a %s`, AgentCLIStartMsPath, AgentStepSummaryPath, logFile, geminiCommand, logFile)
+%s
+%s`, AgentCLIStartMsPath, AgentStepSummaryPath, precreateEngineLogsCommand(logFile), captureEngineOutput(geminiCommand, logFile))
 	}
 
 	// Build environment variables
@@ -291,8 +298,10 @@ touch %s
 		env["GH_AW_VERSION"] = "dev"
 	}
 
-	// Add MCP config env var if needed (points to .gemini/settings.json for Gemini)
-	if HasMCPServers(workflowData) {
+	// Add MCP config env var if needed (points to .gemini/settings.json for Gemini).
+	// With settings-scope "user" the workspace copy is moved to ~/.gemini, so the
+	// workspace path would be stale and is omitted.
+	if HasMCPServers(workflowData) && geminiSettingsScope(workflowData) != EngineSettingsScopeUser {
 		env["GH_AW_MCP_CONFIG"] = "${{ github.workspace }}/.gemini/settings.json"
 	}
 
@@ -6,6 +6,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/github/gh-aw/pkg/constants"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -56,17 +58,17 @@ func TestGeminiEngine(t *testing.T) {
 	t.Run("declared output files", func(t *testing.T) {
 		outputFiles := engine.GetDeclaredOutputFiles()
 		require.Len(t, outputFiles, 1, "Should declare one output file path")
-		assert.Equal(t, "/tmp/gh-aw/gemini-client-error-*.json", outputFiles[0], "Should declare Gemini error log wildcard path under /tmp/gh-aw/")
+		assert.Equal(t, "${{ env.GH_AW_TMP_DIR }}/gemini-client-error-*.json", outputFiles[0], "Should declare Gemini error log wildcard path under ${{ env.GH_AW_TMP_DIR }}/")
 	})
 
-	t.Run("pre-bundle steps move files to /tmp/gh-aw/", func(t *testing.T) {
+	t.Run("pre-bundle steps move files to ${{ env.GH_AW_TMP_DIR }}/", func(t *testing.T) {
 		workflowData := &WorkflowData{Name: "test-workflow"}
 		steps := engine.GetPreBundleSteps(workflowData)
 		require.Len(t, steps, 1, "Should return exactly one pre-bundle step")
 
 		stepContent := strings.Join(steps[0], "\n")
 		assert.Contains(t, stepContent, "Move Gemini error files", "Step name should describe move operation")
-		assert.Contains(t, stepContent, "mv /tmp/gemini-client-error-*.json /tmp/gh-aw/", "Step should move files to /tmp/gh-aw/")
+		assert.Contains(t, stepContent, "mv /tmp/gemini-client-error-*.json ${{ env.GH_AW_TMP_DIR }}/", "Step should move files to ${{ env.GH_AW_TMP_DIR }}/")
 		assert.Contains(t, stepContent, "if: always()", "Step should run always so files are captured on failure")
 	})
 }
@@ -160,7 +162,7 @@ func TestGeminiEngineExecution(t *testing.T) {
 		assert.Contains(t, stepContent, "--yolo", "Should include --yolo flag for auto-approving tool executions")
 		assert.Contains(t, stepContent, "--skip-trust", "Should include --skip-trust flag to prevent workspace trust check from overriding --yolo")
 		assert.Contains(t, stepContent, "--output-format stream-json", "Should use streaming JSON output format")
-		assert.Contains(t, stepContent, `--prompt "$(cat /tmp/gh-aw/aw-prompts/prompt.txt)"`, "Should include prompt argument with correct shell quoting")
+		assert.Contains(t, stepContent, `--prompt "$(cat ${{ env.GH_AW_TMP_DIR }}/aw-prompts/prompt.txt)"`, "Should include prompt argument with correct shell quoting")
 		assert.Contains(t, stepContent, "/tmp/test.log", "Should include log file")
 		assert.Contains(t, stepContent, "GEMINI_API_KEY: ${{ secrets.GEMINI_API_KEY }}", "Should set GEMINI_API_KEY env var")
 	})
@@ -314,7 +316,7 @@ func TestGeminiEngineExecution(t *testing.T) {
 
 		assert.Contains(t, settingsContent, "Write Gemini Config", "First step should be Write Gemini Config")
 		assert.Contains(t, settingsContent, "includeDirectories", "Settings step should set includeDirectories")
-		assert.Contains(t, settingsContent, "/tmp/", "Settings step should include /tmp/ in include directories")
+		assert.Contains(t, settingsContent, constants.TmpGhAwDirExprSlash, "Settings step should include the job scratch directory in include directories")
 		assert.Contains(t, execContent, "Execute Gemini CLI", "Second step should be Execute Gemini CLI")
 	})
 }
@@ -489,7 +491,7 @@ func TestGenerateGeminiSettingsStep(t *testing.T) {
 		content := strings.Join(step, "\n")
 
 		assert.Contains(t, content, "Write Gemini Config", "Should have correct step name")
-		assert.Contains(t, content, "/tmp/", "Should include /tmp/ in include directories")
+		assert.Contains(t, content, constants.TmpGhAwDirExprSlash, "Should include the job scratch directory in include directories")
 		assert.Contains(t, content, "includeDirectories", "Should set includeDirectories")
 		assert.Contains(t, content, ".gemini", "Should reference .gemini directory")
 		assert.Contains(t, content, "GITHUB_WORKSPACE", "Should use GITHUB_WORKSPACE")
@@ -597,6 +599,43 @@ func TestGenerateGeminiSettingsStep(t *testing.T) {
 		assert.Contains(t, content, "run_shell_command(playwright:*)", "Should include mounted playwright CLI command")
 		assert.Contains(t, content, "run_shell_command(safeoutputs:*)", "Should include mounted safeoutputs CLI command")
 	})
+
+	t.Run("default settings scope writes project settings only", func(t *testing.T) {
+		workflowData := &WorkflowData{
+			Name:  "test-workflow",
+			Tools: map[string]any{},
+		}
+		step := engine.generateGeminiSettingsStep(workflowData)
+		content := strings.Join(step, "\n")
+
+		assert.NotContains(t, content, "$HOME/.gemini", "Should not write user-scope settings by default")
+	})
+
+	t.Run("user settings scope moves settings to home directory", func(t *testing.T) {
+		workflowData := &WorkflowData{
+			Name:         "test-workflow",
+			Tools:        map[string]any{},
+			EngineConfig: &EngineConfig{ID: "gemini", SettingsScope: EngineSettingsScopeUser},
+		}
+		step := engine.generateGeminiSettingsStep(workflowData)
+		content := strings.Join(step, "\n")
+
+		assert.Contains(t, content, `mkdir -p "$HOME/.gemini"`, "Should create user-scope settings directory")
+		assert.Contains(t, content, `mv "$SETTINGS" "$HOME/.gemini/settings.json"`, "Should move settings to user scope")
+	})
+
+	t.Run("both settings scope copies settings to home directory", func(t *testing.T) {
+		workflowData := &WorkflowData{
+			Name:         "test-workflow",
+			Tools:        map[string]any{},
+			EngineConfig: &EngineConfig{ID: "gemini", SettingsScope: EngineSettingsScopeBoth},
+		}
+		step := engine.generateGeminiSettingsStep(workflowData)
+		content := strings.Join(step, "\n")
+
+		assert.Contains(t, content, `cp "$SETTINGS" "$HOME/.gemini/settings.json"`, "Should copy settings to user scope")
+		assert.NotContains(t, content, `mv "$SETTINGS"`, "Should keep project-scope settings")
+	})
 }
 
 func TestGeminiEngineWithExpressionVersion(t *testing.T) {
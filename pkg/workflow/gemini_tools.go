@@ -13,16 +13,20 @@ package workflow
 //  2. Settings Step Generation (generateGeminiSettingsStep):
 //     Generates a GitHub Actions step that writes or merges .gemini/settings.json
 //     before the Gemini CLI execution. This step always sets:
-//     - context.includeDirectories: ["/tmp/"] so file tools can access /tmp/
+//     - context.includeDirectories: the job's /tmp/gh-aw scratch directory, so file
+//       tools can access the agent working directories but no other job's files
 //     - tools.core: derived from neutral tool configuration
 //     The merge approach ensures MCP server config (written by convert_gateway_config_gemini.sh)
 //     is preserved while adding the context and tool settings.
+//     When engine.settings-scope is "user" or "both", the merged settings are also
+//     written to ~/.gemini/settings.json so they apply regardless of the working directory.
 
 import (
 	"encoding/json"
 	"fmt"
 	"sort"
 
+	"github.com/github/gh-aw/pkg/constants"
 	"github.com/github/gh-aw/pkg/logger"
 )
 
@@ -113,9 +117,9 @@ func computeGeminiToolsCore(tools map[string]any) []string {
 // Gemini CLI project settings file (.gemini/settings.json) before execution.
 //
 // This step:
-//  1. Sets context.includeDirectories to ["/tmp/"] so that Gemini CLI file system
-//     tools (write_file, replace) can access files in /tmp/ including
-//     /tmp/gh-aw/cache-memory/ and other agent working directories.
+//  1. Sets context.includeDirectories to the job's scratch directory so that Gemini CLI
+//     file system tools (write_file, replace) can access cache-memory and the other
+//     agent working directories, while files of concurrent jobs on the host stay out of reach.
 //  2. Sets tools.core to the list of built-in tools derived from the workflow's
 //     neutral tool configuration (bash → run_shell_command, edit → write_file/replace).
 //  3. Merges the above settings with any existing .gemini/settings.json, which
@@ -140,7 +144,7 @@ func (e *GeminiEngine) generateGeminiSettingsStep(workflowData *WorkflowData) Gi
 	// Build the settings JSON object
 	config := map[string]any{
 		"context": map[string]any{
-			"includeDirectories": []string{"/tmp/"},
+			"includeDirectories": []string{constants.TmpGhAwDirExprSlash},
 		},
 		"tools": map[string]any{
 			"core": toolsCore,
@@ -150,7 +154,7 @@ func (e *GeminiEngine) generateGeminiSettingsStep(workflowData *WorkflowData) Gi
 	configJSON, err := json.Marshal(config)
 	if err != nil {
 		geminiToolsLog.Printf("ERROR: Failed to marshal Gemini settings: %v", err)
-		configJSON = []byte(`{"context":{"includeDirectories":["/tmp/"]},"tools":{"core":[]}}`)
+		configJSON = []byte(`{"context":{"includeDirectories":["` + constants.TmpGhAwDirExprSlash + `"]},"tools":{"core":[]}}`)
 	}
 
 	// Generate a shell script that:
@@ -174,6 +178,20 @@ else
   echo "$BASE_CONFIG" > "$SETTINGS"
 fi`
 
+	// Write the same merged settings to user scope (~/.gemini/settings.json) when
+	// requested. "user" moves the file so only the user-scope copy remains; "both"
+	// copies it so project and user scope hold byte-identical settings.
+	switch geminiSettingsScope(workflowData) {
+	case EngineSettingsScopeUser:
+		command += `
+mkdir -p "$HOME/.gemini"
+mv "$SETTINGS" "$HOME/.gemini/settings.json"`
+	case EngineSettingsScopeBoth:
+		command += `
+mkdir -p "$HOME/.gemini"
+cp "$SETTINGS" "$HOME/.gemini/settings.json"`
+	}
+
 	stepLines := []string{
 		"      - name: Write Gemini Config",
 	}
@@ -183,3 +201,12 @@ fi`
 	stepLines = FormatStepWithCommandAndEnv(stepLines, command, env)
 	return GitHubActionStep(stepLines)
 }
+
+// geminiSettingsScope returns the effective engine.settings-scope for Gemini,
+// defaulting to the project (workspace) scope.
+func geminiSettingsScope(workflowData *WorkflowData) string {
+	if workflowData.EngineConfig != nil && workflowData.EngineConfig.SettingsScope != "" {
+		return workflowData.EngineConfig.SettingsScope
+	}
+	return EngineSettingsScopeProject
+}
@@ -102,20 +102,8 @@ func TestGitPatchFromHEADCommits(t *testing.T) {
 	}
 
 	// Now run the patch generation script
-	// The script creates the patch at /tmp/gh-aw/aw-{branch}.patch
-	// Ensure the /tmp/gh-aw directory exists
-	if err := os.MkdirAll("/tmp/gh-aw", 0755); err != nil {
-		t.Fatalf("Failed to create /tmp/gh-aw directory: %v", err)
-	}
-
-	// Remove any existing aw-*.patch files from previous runs
-	if entries, err := os.ReadDir("/tmp/gh-aw"); err == nil {
-		for _, entry := range entries {
-			if matched, _ := filepath.Match("aw-*.patch", entry.Name()); matched {
-				os.Remove(filepath.Join("/tmp/gh-aw", entry.Name()))
-			}
-		}
-	}
+	// The script writes patches to the job scratch directory that setup.sh exports.
+	scratchDir := t.TempDir()
 
 	// Create a minimal safe-outputs file (empty - no branch name)
 	safeOutputsFile := filepath.Join(tmpDir, "safe-outputs.jsonl")
@@ -138,6 +126,7 @@ func TestGitPatchFromHEADCommits(t *testing.T) {
 	cmd.Dir = tmpDir
 	cmd.Env = append(os.Environ(),
 		"GH_AW_SAFE_OUTPUTS="+safeOutputsFile,
+		"GH_AW_TMP_DIR="+scratchDir,
 		"GITHUB_SHA="+initialSHA,
 		"DEFAULT_BRANCH=main",
 		"GITHUB_STEP_SUMMARY=/dev/null",
@@ -153,10 +142,10 @@ func TestGitPatchFromHEADCommits(t *testing.T) {
 
 	// Find the generated patch file (aw-{branch}.patch)
 	var patchFile string
-	if entries, err := os.ReadDir("/tmp/gh-aw"); err == nil {
+	if entries, err := os.ReadDir(scratchDir); err == nil {
 		for _, entry := range entries {
 			if matched, _ := filepath.Match("aw-*.patch", entry.Name()); matched {
-				patchFile = filepath.Join("/tmp/gh-aw", entry.Name())
+				patchFile = filepath.Join(scratchDir, entry.Name())
 				break
 			}
 		}
@@ -314,23 +303,14 @@ func TestGitPatchPrefersBranchOverHEAD(t *testing.T) {
 		t.Fatalf("Failed to write script: %v", err)
 	}
 
-	// Ensure /tmp/gh-aw exists and is clean
-	if err := os.MkdirAll("/tmp/gh-aw", 0755); err != nil {
-		t.Fatalf("Failed to create /tmp/gh-aw: %v", err)
-	}
-	// Remove any existing aw-*.patch files from previous runs
-	if entries, err := os.ReadDir("/tmp/gh-aw"); err == nil {
-		for _, entry := range entries {
-			if matched, _ := filepath.Match("aw-*.patch", entry.Name()); matched {
-				os.Remove(filepath.Join("/tmp/gh-aw", entry.Name()))
-			}
-		}
-	}
+	// The script writes patches to the job scratch directory that setup.sh exports.
+	scratchDir := t.TempDir()
 
 	cmd = exec.Command("bash", scriptFile)
 	cmd.Dir = tmpDir
 	cmd.Env = append(os.Environ(),
 		"GH_AW_SAFE_OUTPUTS="+safeOutputsFile,
+		"GH_AW_TMP_DIR="+scratchDir,
 		"GITHUB_SHA="+initialSHA,
 		"DEFAULT_BRANCH=main",
 		"GITHUB_STEP_SUMMARY=/dev/null",
@@ -401,21 +381,14 @@ func TestGitPatchNoCommits(t *testing.T) {
 	scriptFile := filepath.Join(tmpDir, "generate_patch.sh")
 	os.WriteFile(scriptFile, scriptContent, 0755)
 
-	// Ensure /tmp/gh-aw exists and is clean
-	os.MkdirAll("/tmp/gh-aw", 0755)
-	// Remove any existing aw-*.patch files from previous runs
-	if entries, err := os.ReadDir("/tmp/gh-aw"); err == nil {
-		for _, entry := range entries {
-			if matched, _ := filepath.Match("aw-*.patch", entry.Name()); matched {
-				os.Remove(filepath.Join("/tmp/gh-aw", entry.Name()))
-			}
-		}
-	}
+	// The script writes patches to the job scratch directory that setup.sh exports.
+	scratchDir := t.TempDir()
 
 	cmd = exec.Command("bash", scriptFile)
 	cmd.Dir = tmpDir
 	cmd.Env = append(os.Environ(),
 		"GH_AW_SAFE_OUTPUTS="+safeOutputsFile,
+		"GH_AW_TMP_DIR="+scratchDir,
 		"GITHUB_SHA="+currentSHA,
 		"DEFAULT_BRANCH=main",
 		"GITHUB_STEP_SUMMARY=/dev/null",
@@ -425,7 +398,7 @@ func TestGitPatchNoCommits(t *testing.T) {
 	t.Logf("Script output:\n%s", scriptOutput)
 
 	// Verify no patch was generated (no aw-*.patch files should exist)
-	if entries, err := os.ReadDir("/tmp/gh-aw"); err == nil {
+	if entries, err := os.ReadDir(scratchDir); err == nil {
 		for _, entry := range entries {
 			if matched, _ := filepath.Match("aw-*.patch", entry.Name()); matched {
 				t.Errorf("Patch file should not be created when there are no commits, found: %s", entry.Name())
@@ -94,7 +94,7 @@ Please do the following tasks:
 	}
 
 	// Verify the patch path is included in the unified upload (glob pattern for multiple patches)
-	if !strings.Contains(lockContent, "/tmp/gh-aw/aw-*.patch") {
+	if !strings.Contains(lockContent, "${{ env.GH_AW_TMP_DIR }}/aw-*.patch") {
 		t.Error("Expected artifact path '/tmp/gh-aw/aw-*.patch' in unified upload")
 	}
 
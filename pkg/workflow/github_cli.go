@@ -14,6 +14,7 @@ import (
 
 	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
 	"github.com/github/gh-aw/pkg/tty"
 )
 
@@ -141,6 +142,9 @@ func enrichGHError(err error) error {
 // and returns the output. This is the core implementation for all RunGH* functions.
 // If stdin is non-nil it is attached to the command's standard input.
 func runGHWithSpinnerContext(ctx context.Context, spinnerMessage string, combined bool, stdin io.Reader, args ...string) ([]byte, error) {
+	if IsOfflineMode() {
+		return nil, parser.NewOfflineError("gh " + strings.Join(args, " "))
+	}
 	cmd := ExecGHContext(ctx, args...)
 	if stdin != nil {
 		cmd.Stdin = stdin
@@ -0,0 +1,128 @@
+// This file expands tools.github.operations into GitHub MCP toolsets and tool filters.
+//
+// # Operation Groups
+//
+// Instead of listing toolsets and individual tool names, a workflow can grant the
+// GitHub tool a set of named operation groups:
+//
+//	tools:
+//	  github:
+//	    operations: [issues-read, code-search]
+//
+// Each group names the read-only GitHub MCP tools it needs. The compiler replaces
+// operations with the union of those tools in allowed and of their toolsets in
+// toolsets, so every engine receives the same tool filter through its usual
+// allowed-tools rendering and the job permissions follow from the toolsets.
+// The GitHub MCP server is always read-only; writes go through safe-outputs.
+
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/github/gh-aw/pkg/sliceutil"
+)
+
+var githubOperationGroupsLog = logger.New("workflow:github_operation_groups")
+
+// githubOperationGroups maps each operation group to the GitHub MCP tools it allows.
+var githubOperationGroups = map[string][]string{
+	"issues-read": {
+		"issue_read", "list_issues", "search_issues", "list_issue_types", "issue_dependency_read",
+	},
+	"prs-read": {
+		"pull_request_read", "list_pull_requests", "search_pull_requests",
+	},
+	"actions-read": {
+		"actions_get", "actions_list", "get_job_logs",
+	},
+	"repos-read": {
+		"get_repository", "get_file_contents", "get_file_blame", "list_commits", "get_commit",
+		"list_branches", "list_tags", "get_tag", "list_releases", "get_latest_release", "get_release_by_tag",
+	},
+	"code-search": {
+		"search_code",
+	},
+	"discussions-read": {
+		"list_discussions", "list_discussion_categories", "get_discussion", "get_discussion_comments",
+	},
+	"labels-read": {
+		"get_label", "list_label", "list_labels",
+	},
+	"security-read": {
+		"list_code_scanning_alerts", "get_code_scanning_alert",
+		"list_secret_scanning_alerts", "get_secret_scanning_alert",
+		"list_dependabot_alerts", "get_dependabot_alert",
+	},
+}
+
+// expandGitHubOperations replaces tools.github.operations with the allowed tools and
+// toolsets of the listed operation groups. It returns an error for unknown groups and
+// when operations is combined with allowed or toolsets.
+func expandGitHubOperations(tools map[string]any) error {
+	githubConfig, ok := tools["github"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	rawOperations, ok := githubConfig["operations"]
+	if !ok {
+		return nil
+	}
+	if _, hasAllowed := githubConfig["allowed"]; hasAllowed {
+		return errors.New("invalid GitHub tool configuration: 'tools.github.operations' cannot be combined with 'tools.github.allowed'. Use operation groups or list the allowed tools, not both")
+	}
+	if _, hasToolsets := githubConfig["toolsets"]; hasToolsets {
+		return errors.New("invalid GitHub tool configuration: 'tools.github.operations' cannot be combined with 'tools.github.toolsets'. The toolsets are derived from the operation groups")
+	}
+
+	operations, ok := rawOperations.([]any)
+	if !ok || len(operations) == 0 {
+		return errors.New("invalid GitHub tool configuration: 'tools.github.operations' must be a non-empty list of operation groups")
+	}
+
+	toolToToolset, err := getGitHubToolToToolsetMap()
+	if err != nil {
+		return err
+	}
+
+	validGroups := sliceutil.SortedKeys(githubOperationGroups)
+	allowedSet := make(map[string]struct{})
+	toolsetSet := make(map[string]struct{})
+	for _, item := range operations {
+		group, _ := item.(string)
+		groupTools, known := githubOperationGroups[group]
+		if !known {
+			message := fmt.Sprintf("invalid GitHub tool configuration: unknown operation group %q in 'tools.github.operations'", group)
+			if matches := parser.FindClosestMatches(group, validGroups, 1); len(matches) > 0 {
+				message += fmt.Sprintf(". Did you mean %q?", matches[0])
+			}
+			return fmt.Errorf("%s. Valid groups: %s", message, strings.Join(validGroups, ", "))
+		}
+		for _, tool := range groupTools {
+			allowedSet[tool] = struct{}{}
+			if toolset, ok := toolToToolset[tool]; ok {
+				toolsetSet[toolset] = struct{}{}
+			}
+		}
+	}
+
+	allowed := make([]any, 0, len(allowedSet))
+	for _, tool := range sliceutil.SortedKeys(allowedSet) {
+		allowed = append(allowed, tool)
+	}
+	toolsetNames := sliceutil.SortedKeys(toolsetSet)
+	toolsets := make([]any, 0, len(toolsetNames))
+	for _, toolset := range toolsetNames {
+		toolsets = append(toolsets, toolset)
+	}
+
+	githubOperationGroupsLog.Printf("Expanded %d operation group(s) to %d tool(s) in toolsets %v", len(operations), len(allowed), toolsetNames)
+	delete(githubConfig, "operations")
+	githubConfig["allowed"] = allowed
+	githubConfig["toolsets"] = toolsets
+	return nil
+}
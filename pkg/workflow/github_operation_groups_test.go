@@ -0,0 +1,90 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandGitHubOperations(t *testing.T) {
+	tools := map[string]any{
+		"github": map[string]any{"operations": []any{"issues-read", "code-search"}, "lockdown": true},
+	}
+	require.NoError(t, expandGitHubOperations(tools), "valid operation groups should expand")
+
+	github := tools["github"].(map[string]any)
+	assert.NotContains(t, github, "operations", "operations should be replaced")
+	assert.Equal(t, []any{"issues", "repos"}, github["toolsets"], "toolsets should follow from the groups")
+	assert.Equal(t, []any{"issue_dependency_read", "issue_read", "list_issue_types", "list_issues", "search_code", "search_issues"}, github["allowed"])
+	assert.Equal(t, true, github["lockdown"], "other settings should be kept")
+
+	assert.NoError(t, expandGitHubOperations(map[string]any{"github": map[string]any{"toolsets": []any{"repos"}}}), "configurations without operations are unchanged")
+}
+
+func TestExpandGitHubOperationsErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		github  map[string]any
+		wantErr string
+	}{
+		{name: "unknown group", github: map[string]any{"operations": []any{"issue-read"}}, wantErr: `unknown operation group "issue-read" in 'tools.github.operations'. Did you mean "issues-read"?`},
+		{name: "empty list", github: map[string]any{"operations": []any{}}, wantErr: "must be a non-empty list"},
+		{name: "with allowed", github: map[string]any{"operations": []any{"prs-read"}, "allowed": []any{"issue_read"}}, wantErr: "cannot be combined with 'tools.github.allowed'"},
+		{name: "with toolsets", github: map[string]any{"operations": []any{"prs-read"}, "toolsets": []any{"all"}}, wantErr: "cannot be combined with 'tools.github.toolsets'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := expandGitHubOperations(map[string]any{"github": tt.github})
+			require.Error(t, err, "operations should be rejected")
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestGitHubOperationGroupsUseKnownTools(t *testing.T) {
+	toolToToolset, err := getGitHubToolToToolsetMap()
+	require.NoError(t, err)
+	for group, tools := range githubOperationGroups {
+		assert.True(t, strings.HasSuffix(group, "-read") || group == "code-search", "operation group %s should be read-only", group)
+		for _, tool := range tools {
+			assert.Contains(t, toolToToolset, tool, "tool %s of operation group %s should map to a toolset", tool, group)
+		}
+	}
+}
+
+func TestCompileGitHubOperations(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "github-operations-*")
+	markdownPath := filepath.Join(tmpDir, "test.md")
+	content := `---
+on: issues
+permissions:
+  contents: read
+  issues: read
+engine: claude
+tools:
+  github:
+    operations: [issues-read, code-search]
+---
+
+# Test
+`
+	require.NoError(t, os.WriteFile(markdownPath, []byte(content), 0o644))
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(markdownPath), "workflow with operation groups should compile")
+
+	lockContent, err := os.ReadFile(filepath.Join(tmpDir, "test.lock.yml"))
+	require.NoError(t, err)
+	lock := string(lockContent)
+	assert.Contains(t, lock, "issues,repos", "GitHub MCP server should enable the derived toolsets")
+	assert.Contains(t, lock, "mcp__github__search_code", "Claude should be allowed the code search tool")
+	assert.Contains(t, lock, "mcp__github__issue_read", "Claude should be allowed the issue read tool")
+	assert.NotContains(t, lock, "mcp__github__create_issue", "write tools should not be allowed")
+}
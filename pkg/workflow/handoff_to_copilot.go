@@ -0,0 +1,41 @@
+package workflow
+
+import (
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var handoffToCopilotLog = logger.New("workflow:handoff_to_copilot")
+
+// HandoffToCopilotConfig holds configuration for handing off implementation work to the
+// Copilot coding agent. Each handoff creates an issue with a structured task description
+// (problem, acceptance criteria, relevant files, out-of-scope notes) and assigns Copilot to it.
+type HandoffToCopilotConfig struct {
+	BaseSafeOutputConfig `yaml:",inline"`
+	TitlePrefix          string   `yaml:"title-prefix,omitempty"`
+	Labels               []string `yaml:"labels,omitempty"`        // Labels added to every handoff issue
+	TargetRepoSlug       string   `yaml:"target-repo,omitempty"`   // Target repository in format "owner/repo" for cross-repository handoffs
+	AllowedRepos         []string `yaml:"allowed-repos,omitempty"` // List of additional repositories that handoff issues can be created in
+}
+
+// parseHandoffToCopilotConfig handles handoff-to-copilot configuration
+func (c *Compiler) parseHandoffToCopilotConfig(outputMap map[string]any) *HandoffToCopilotConfig {
+	if configMap, ok := outputMap["handoff-to-copilot"].(map[string]any); ok {
+		if _, isInvalid := parseTargetRepoWithValidation(configMap); isInvalid {
+			return nil // Invalid configuration, return nil to cause validation error
+		}
+	}
+
+	config := parseConfigScaffold(outputMap, "handoff-to-copilot", handoffToCopilotLog, func(err error) *HandoffToCopilotConfig {
+		handoffToCopilotLog.Printf("Failed to unmarshal config: %v", err)
+		return &HandoffToCopilotConfig{}
+	})
+	if config == nil {
+		return nil
+	}
+	if config.Max == nil {
+		config.Max = defaultIntStr(1)
+	}
+	handoffToCopilotLog.Printf("Parsed handoff-to-copilot config: title_prefix=%q, labels=%d, target_repo=%s",
+		config.TitlePrefix, len(config.Labels), config.TargetRepoSlug)
+	return config
+}
@@ -0,0 +1,109 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHandoffToCopilotConfig(t *testing.T) {
+	tests := []struct {
+		name       string
+		outputMap  map[string]any
+		wantConfig bool
+		wantMax    string
+		wantPrefix string
+		wantLabels []string
+		wantRepo   string
+	}{
+		{
+			name:       "null config uses defaults",
+			outputMap:  map[string]any{"handoff-to-copilot": nil},
+			wantConfig: true,
+			wantMax:    "1",
+		},
+		{
+			name: "all fields",
+			outputMap: map[string]any{
+				"handoff-to-copilot": map[string]any{
+					"max":          3,
+					"title-prefix": "[copilot] ",
+					"labels":       []any{"copilot-task"},
+					"target-repo":  "owner/repo",
+				},
+			},
+			wantConfig: true,
+			wantMax:    "3",
+			wantPrefix: "[copilot] ",
+			wantLabels: []string{"copilot-task"},
+			wantRepo:   "owner/repo",
+		},
+		{
+			name:       "not configured",
+			outputMap:  map[string]any{},
+			wantConfig: false,
+		},
+		{
+			name: "wildcard target-repo is rejected",
+			outputMap: map[string]any{
+				"handoff-to-copilot": map[string]any{"target-repo": "*"},
+			},
+			wantConfig: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := NewCompiler().parseHandoffToCopilotConfig(tt.outputMap)
+			if !tt.wantConfig {
+				assert.Nil(t, config, "config should not be parsed")
+				return
+			}
+			require.NotNil(t, config, "config should be parsed")
+			require.NotNil(t, config.Max, "max should default")
+			assert.Equal(t, tt.wantMax, *config.Max, "max should match")
+			assert.Equal(t, tt.wantPrefix, config.TitlePrefix, "title prefix should match")
+			assert.Equal(t, tt.wantLabels, config.Labels, "labels should match")
+			assert.Equal(t, tt.wantRepo, config.TargetRepoSlug, "target repo should match")
+		})
+	}
+}
+
+func TestCompileHandoffToCopilot(t *testing.T) {
+	dir := t.TempDir()
+	markdownPath := filepath.Join(dir, "triage.md")
+	content := `---
+on: issues
+permissions:
+  contents: read
+engine: copilot
+safe-outputs:
+  handoff-to-copilot:
+    title-prefix: "[copilot] "
+    labels: [copilot-task]
+---
+
+# Triage
+
+Hand off actionable issues to Copilot.
+`
+	require.NoError(t, os.WriteFile(markdownPath, []byte(content), 0o644), "workflow should be written")
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(markdownPath), "workflow should compile")
+
+	lock, err := os.ReadFile(filepath.Join(dir, "triage.lock.yml"))
+	require.NoError(t, err, "lock file should be written")
+	lockContent := string(lock)
+
+	assert.Contains(t, lockContent, `\"handoff_to_copilot\":{`, "handler config should include handoff_to_copilot")
+	assert.Contains(t, lockContent, `\"title_prefix\":\"[copilot] \"`, "handler config should include the title prefix")
+	assert.Contains(t, lockContent, `GH_AW_ASSIGN_COPILOT: "true"`, "copilot assignment should be enabled")
+	assert.Contains(t, lockContent, "GH_AW_ASSIGN_TO_AGENT_TOKEN:", "an agent token should be provided for the copilot assignment")
+	assert.Contains(t, lockContent, "issues: write", "safe outputs job should be able to create issues")
+}
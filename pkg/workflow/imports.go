@@ -4,10 +4,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
 	"sort"
 	"strings"
 	"sync"
 
+	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/logger"
 	"github.com/github/gh-aw/pkg/parser"
 	"github.com/github/gh-aw/pkg/setutil"
@@ -59,9 +63,14 @@ func (c *Compiler) MergeTools(topTools map[string]any, includedToolsJSON string)
 	return result, nil
 }
 
-// MergeMCPServers merges mcp-servers from imports with top-level mcp-servers
-// Takes object maps and merges them directly
-func (c *Compiler) MergeMCPServers(topMCPServers map[string]any, importedMCPServersJSON string) (map[string]any, error) {
+// MergeMCPServers merges mcp-servers from imports with top-level mcp-servers.
+// importedMCPServersJSON holds one JSON object per import; sources holds the matching import
+// file paths and is used to name both imports when their definitions conflict.
+//
+// Imported servers override same-named top-level servers (with a warning when the definitions
+// differ). A server defined by several imports is merged: identical definitions are
+// deduplicated, 'allowed' arrays are combined, and any other difference is an error.
+func (c *Compiler) MergeMCPServers(topMCPServers map[string]any, importedMCPServersJSON string, sources []string) (map[string]any, error) {
 	importsLog.Print("Merging MCP servers from imports")
 
 	if importedMCPServersJSON == "" || importedMCPServersJSON == "{}" {
@@ -72,12 +81,13 @@ func (c *Compiler) MergeMCPServers(topMCPServers map[string]any, importedMCPServ
 	// Initialize result with top-level MCP servers
 	result := make(map[string]any)
 	maps.Copy(result, topMCPServers)
+	definedBy := make(map[string]string)
 
 	// Split by newlines to handle multiple JSON objects from different imports
-	lines := strings.Split(importedMCPServersJSON, "\n")
+	lines := strings.Split(strings.TrimRight(importedMCPServersJSON, "\n"), "\n")
 	importsLog.Printf("Processing %d MCP server definition lines", len(lines))
 
-	for _, line := range lines {
+	for i, line := range lines {
 		line = strings.TrimSpace(line)
 		if line == "" || line == "{}" {
 			continue
@@ -88,11 +98,31 @@ func (c *Compiler) MergeMCPServers(topMCPServers map[string]any, importedMCPServ
 		if err := json.Unmarshal([]byte(line), &importedMCPServers); err != nil {
 			continue // Skip invalid lines
 		}
+		source := "an imported workflow"
+		if i < len(sources) && sources[i] != "" {
+			source = "'" + c.importDisplayPath(sources[i]) + "'"
+		}
 
-		// Merge MCP servers - imported servers take precedence over top-level ones
-		for serverName, serverConfig := range importedMCPServers {
-			importsLog.Printf("Merging MCP server: %s", serverName)
-			result[serverName] = serverConfig
+		for _, serverName := range sliceutil.SortedKeys(importedMCPServers) {
+			serverConfig := importedMCPServers[serverName]
+			importsLog.Printf("Merging MCP server %s from %s", serverName, source)
+
+			previousSource, importedBefore := definedBy[serverName]
+			if !importedBefore {
+				if topConfig, defined := topMCPServers[serverName]; defined && !reflect.DeepEqual(topConfig, serverConfig) {
+					fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("MCP server '%s' in mcp-servers is replaced by the definition imported from %s. Remove it from the workflow or rename it to keep both.", serverName, source)))
+					c.IncrementWarningCount()
+				}
+				result[serverName] = serverConfig
+				definedBy[serverName] = source
+				continue
+			}
+
+			merged, err := mergeImportedMCPServer(result[serverName], serverConfig)
+			if err != nil {
+				return nil, fmt.Errorf("MCP server '%s' is defined differently by imports %s and %s: %w. Define the server in a single shared file and import it from both", serverName, previousSource, source, err)
+			}
+			result[serverName] = merged
 		}
 	}
 
@@ -100,6 +130,32 @@ func (c *Compiler) MergeMCPServers(topMCPServers map[string]any, importedMCPServ
 	return result, nil
 }
 
+// importDisplayPath shortens an imported file path for diagnostics: paths under the
+// directory of the workflow being compiled are shown relative to it.
+func (c *Compiler) importDisplayPath(path string) string {
+	if c.markdownPath == "" {
+		return path
+	}
+	rel, err := filepath.Rel(filepath.Dir(c.markdownPath), path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
+// mergeImportedMCPServer merges two imported definitions of the same MCP server.
+func mergeImportedMCPServer(existing, additional any) (any, error) {
+	if reflect.DeepEqual(existing, additional) {
+		return existing, nil
+	}
+	existingMap, existingIsMap := existing.(map[string]any)
+	additionalMap, additionalIsMap := additional.(map[string]any)
+	if !existingIsMap || !additionalIsMap {
+		return nil, fmt.Errorf("conflicting values: existing=%v, new=%v", existing, additional)
+	}
+	return parser.MergeMCPServerConfigs(existingMap, additionalMap)
+}
+
 // MergeNetworkPermissions merges network permissions from imports with top-level network permissions
 // Combines allowed domains from both sources into a single list
 func (c *Compiler) MergeNetworkPermissions(topNetwork *NetworkPermissions, importedNetworkJSON string) (*NetworkPermissions, error) {
@@ -116,6 +172,7 @@ func (c *Compiler) MergeNetworkPermissions(topNetwork *NetworkPermissions, impor
 	if topNetwork != nil {
 		result.Allowed = make([]string, len(topNetwork.Allowed))
 		copy(result.Allowed, topNetwork.Allowed)
+		result.Proxy = topNetwork.Proxy
 		importsLog.Printf("Starting with %d top-level allowed domains", len(topNetwork.Allowed))
 	}
 
@@ -481,6 +538,9 @@ func mergeSafeOutputConfig(result *SafeOutputsConfig, config map[string]any, c *
 		result.ReportFailureAsIssueCategories = importedConfig.ReportFailureAsIssueCategories
 		result.ReportFailureAsIssueExcludedCategories = importedConfig.ReportFailureAsIssueExcludedCategories
 	}
+	if result.ReportFailureAsComment == nil && importedConfig.ReportFailureAsComment != nil {
+		result.ReportFailureAsComment = importedConfig.ReportFailureAsComment
+	}
 	if result.FailureIssueRepo == "" && importedConfig.FailureIssueRepo != "" {
 		result.FailureIssueRepo = importedConfig.FailureIssueRepo
 	}
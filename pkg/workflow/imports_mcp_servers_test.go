@@ -0,0 +1,87 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeMCPServersFromImports(t *testing.T) {
+	imported := `{"notion":{"container":"mcp/notion","allowed":["search"]}}
+{"notion":{"container":"mcp/notion","allowed":["fetch","search"]},"fetch":{"container":"mcp/fetch"}}
+{"fetch":{"container":"mcp/fetch"}}
+`
+	compiler := NewCompiler()
+	merged, err := compiler.MergeMCPServers(map[string]any{}, imported, []string{"/repo/a.md", "/repo/b.md", "/repo/c.md"})
+	require.NoError(t, err, "compatible definitions should merge")
+	assert.Equal(t, map[string]any{
+		"notion": map[string]any{"container": "mcp/notion", "allowed": []any{"search", "fetch"}},
+		"fetch":  map[string]any{"container": "mcp/fetch"},
+	}, merged, "allowed arrays should be combined and identical definitions deduplicated")
+	assert.Zero(t, compiler.GetWarningCount(), "merging imports should not warn")
+}
+
+func TestMergeMCPServersConflictingImports(t *testing.T) {
+	imported := `{"notion":{"container":"mcp/notion:1"}}
+{"notion":{"container":"mcp/notion:2"}}
+`
+	compiler := NewCompiler()
+	compiler.markdownPath = "/repo/.github/workflows/triage.md"
+	_, err := compiler.MergeMCPServers(nil, imported, []string{
+		"/repo/.github/workflows/shared/mcp/notion.md",
+		"/repo/.github/workflows/shared/notion-v2.md",
+	})
+	require.Error(t, err, "conflicting definitions should fail")
+	assert.Contains(t, err.Error(), "MCP server 'notion' is defined differently by imports 'shared/mcp/notion.md' and 'shared/notion-v2.md'", "error should name both imports relative to the workflow")
+	assert.Contains(t, err.Error(), "conflicting values for 'container'", "error should name the conflicting field")
+}
+
+func TestMergeMCPServersOverridesTopLevel(t *testing.T) {
+	top := map[string]any{
+		"notion": map[string]any{"container": "mcp/notion:local"},
+		"local":  map[string]any{"container": "mcp/local"},
+	}
+	compiler := NewCompiler()
+	merged, err := compiler.MergeMCPServers(top, `{"notion":{"container":"mcp/notion:shared"}}`, []string{"/repo/shared.md"})
+	require.NoError(t, err, "imported servers should override top-level ones")
+	assert.Equal(t, map[string]any{"container": "mcp/notion:shared"}, merged["notion"], "imported definition should win")
+	assert.Contains(t, merged, "local", "top-level servers not imported should be kept")
+	assert.Equal(t, 1, compiler.GetWarningCount(), "replacing a different top-level definition should warn")
+
+	compiler = NewCompiler()
+	_, err = compiler.MergeMCPServers(map[string]any{"notion": map[string]any{"container": "mcp/notion:shared"}}, `{"notion":{"container":"mcp/notion:shared"}}`, nil)
+	require.NoError(t, err, "identical definitions should merge")
+	assert.Zero(t, compiler.GetWarningCount(), "identical top-level definitions should not warn")
+}
+
+func TestCompileWorkflowWithConflictingMCPServerImports(t *testing.T) {
+	dir := t.TempDir()
+	sharedDir := filepath.Join(dir, "shared", "mcp")
+	require.NoError(t, os.MkdirAll(sharedDir, 0755), "shared directory should be created")
+	for name, image := range map[string]string{"notion.md": "mcp/notion:1", "notion-next.md": "mcp/notion:2"} {
+		content := "---\nmcp-servers:\n  notion:\n    container: " + image + "\n    allowed: [\"*\"]\n---\n"
+		require.NoError(t, os.WriteFile(filepath.Join(sharedDir, name), []byte(content), 0644), "shared file should be written")
+	}
+	workflowPath := filepath.Join(dir, "workflow.md")
+	require.NoError(t, os.WriteFile(workflowPath, []byte(`---
+on: issues
+permissions:
+  contents: read
+engine: copilot
+imports:
+  - shared/mcp/notion.md
+  - shared/mcp/notion-next.md
+---
+
+# Test
+`), 0644), "workflow should be written")
+
+	err := NewCompiler().CompileWorkflow(workflowPath)
+	require.Error(t, err, "conflicting imports should fail compilation")
+	assert.Contains(t, err.Error(), "'shared/mcp/notion.md' and 'shared/mcp/notion-next.md'", "error should name both imports")
+}
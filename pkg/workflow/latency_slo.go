@@ -0,0 +1,122 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var latencySLOLog = logger.New("workflow:latency_slo")
+
+// getLatencySLOConfig returns the latency SLOs of the workflow, or nil when
+// observability.slo is not set. RawFrontmatter is read first because
+// ParsedFrontmatter is nil when the typed parse fails (e.g. for a string "on:").
+func getLatencySLOConfig(workflowData *WorkflowData) *LatencySLOConfig {
+	if workflowData == nil {
+		return nil
+	}
+	if sloMap, ok := extractRawObservabilityMap(workflowData.RawFrontmatter)["slo"].(map[string]any); ok {
+		slo := &LatencySLOConfig{}
+		slo.Start, _ = sloMap["start"].(string)
+		slo.Finish, _ = sloMap["finish"].(string)
+		slo.Notify, _ = sloMap["notify"].(bool)
+		return slo
+	}
+	if workflowData.ParsedFrontmatter == nil || workflowData.ParsedFrontmatter.Observability == nil {
+		return nil
+	}
+	return workflowData.ParsedFrontmatter.Observability.SLO
+}
+
+// parseLatencySLODuration parses an SLO duration, returning zero for an unset value.
+func parseLatencySLODuration(field, raw string) (time.Duration, error) {
+	if raw == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("observability.slo.%s: invalid duration %q. Must be a positive Go duration string (e.g. \"3m\", \"20m\", \"1h\")", field, raw)
+	}
+	return d, nil
+}
+
+// validateLatencySLOConfig checks observability.slo. The schema checks the duration
+// format; this checks that the SLOs can be met and can be measured.
+func validateLatencySLOConfig(workflowData *WorkflowData) error {
+	slo := getLatencySLOConfig(workflowData)
+	if slo == nil {
+		return nil
+	}
+	start, err := parseLatencySLODuration("start", slo.Start)
+	if err != nil {
+		return err
+	}
+	finish, err := parseLatencySLODuration("finish", slo.Finish)
+	if err != nil {
+		return err
+	}
+	if start == 0 && finish == 0 {
+		return errors.New("observability.slo: set at least one of start or finish")
+	}
+	if start > 0 && finish > 0 && finish <= start {
+		return fmt.Errorf("observability.slo.finish (%s) must be longer than observability.slo.start (%s): the agent job cannot finish before it starts", slo.Finish, slo.Start)
+	}
+	if workflowData.SafeOutputs == nil {
+		return errors.New("observability.slo requires safe-outputs: the SLOs are checked by the conclusion job, which is only generated when safe-outputs are enabled")
+	}
+	latencySLOLog.Printf("Latency SLOs validated: start=%s finish=%s notify=%v", slo.Start, slo.Finish, slo.Notify)
+	return nil
+}
+
+// buildConclusionLatencySLOStep builds the conclusion job step that compares the timings
+// of the run with observability.slo, annotates breaches, and optionally reports them
+// on the "[aw] SLO Breaches" tracking issue.
+func (c *Compiler) buildConclusionLatencySLOStep(data *WorkflowData, mainJobName string) []string {
+	slo := getLatencySLOConfig(data)
+	if slo == nil {
+		return nil
+	}
+	// Invalid durations are rejected by validateLatencySLOConfig
+	start, _ := parseLatencySLODuration("start", slo.Start)
+	finish, _ := parseLatencySLODuration("finish", slo.Finish)
+
+	envVars := buildWorkflowMetadataEnvVarsWithTrackerID(data.Name, data.Source, data.TrackerID, buildLocalWorkflowSourceURL(c.markdownPath))
+	envVars = append(envVars, "          GH_AW_RUN_URL: ${{ github.server_url }}/${{ github.repository }}/actions/runs/${{ github.run_id }}\n")
+	envVars = append(envVars, fmt.Sprintf("          GH_AW_SLO_AGENT_JOB: %q\n", mainJobName))
+	if start > 0 {
+		envVars = append(envVars, fmt.Sprintf("          GH_AW_SLO_START_SECONDS: \"%d\"\n", int64(start.Seconds())))
+	}
+	if finish > 0 {
+		envVars = append(envVars, fmt.Sprintf("          GH_AW_SLO_FINISH_SECONDS: \"%d\"\n", int64(finish.Seconds())))
+	}
+	if slo.Notify {
+		envVars = append(envVars, "          GH_AW_SLO_NOTIFY: \"true\"\n")
+	}
+	steps := c.buildGitHubScriptStepWithoutDownload(data, GitHubScriptStepConfig{
+		StepName:      "Check latency SLOs",
+		StepID:        "latency_slo",
+		MainJobName:   mainJobName,
+		CustomEnvVars: envVars,
+		ScriptFile:    "check_latency_slo.cjs",
+	})
+	latencySLOLog.Printf("Added latency SLO step to conclusion job: start=%s finish=%s", slo.Start, slo.Finish)
+	return steps
+}
+
+// applyLatencySLOPermissions grants the conclusion job what the latency SLO step needs:
+// actions: read to list the jobs of the run, and issues: write when breaches are reported
+// on the tracking issue.
+func applyLatencySLOPermissions(data *WorkflowData, permissions *Permissions) {
+	slo := getLatencySLOConfig(data)
+	if slo == nil {
+		return
+	}
+	if level, ok := permissions.Get(PermissionActions); !ok || level == PermissionNone {
+		permissions.Set(PermissionActions, PermissionRead)
+	}
+	if slo.Notify {
+		permissions.Set(PermissionIssues, PermissionWrite)
+	}
+}
@@ -0,0 +1,98 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateLatencySLOConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		slo     *LatencySLOConfig
+		noSafe  bool
+		wantErr string
+	}{
+		{name: "not configured"},
+		{name: "start and finish", slo: &LatencySLOConfig{Start: "3m", Finish: "20m"}},
+		{name: "finish only", slo: &LatencySLOConfig{Finish: "1h"}},
+		{name: "empty", slo: &LatencySLOConfig{Notify: true}, wantErr: "set at least one of start or finish"},
+		{name: "invalid duration", slo: &LatencySLOConfig{Start: "3 minutes"}, wantErr: "observability.slo.start: invalid duration"},
+		{name: "finish before start", slo: &LatencySLOConfig{Start: "20m", Finish: "3m"}, wantErr: "must be longer than observability.slo.start"},
+		{name: "without safe-outputs", slo: &LatencySLOConfig{Start: "3m"}, noSafe: true, wantErr: "requires safe-outputs"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := &WorkflowData{
+				ParsedFrontmatter: &FrontmatterConfig{Observability: &ObservabilityConfig{SLO: tt.slo}},
+				SafeOutputs:       &SafeOutputsConfig{},
+			}
+			if tt.noSafe {
+				data.SafeOutputs = nil
+			}
+			err := validateLatencySLOConfig(data)
+			if tt.wantErr == "" {
+				assert.NoError(t, err, "SLO config should be valid")
+				return
+			}
+			require.Error(t, err, "SLO config should be rejected")
+			assert.Contains(t, err.Error(), tt.wantErr, "unexpected error message")
+		})
+	}
+}
+
+func TestCompileWorkflowWithLatencySLO(t *testing.T) {
+	tests := []struct {
+		name       string
+		slo        string
+		wantEnv    []string
+		wantNotify bool
+	}{
+		{
+			name:    "annotations only",
+			slo:     "    start: 3m\n    finish: 20m\n",
+			wantEnv: []string{`GH_AW_SLO_START_SECONDS: "180"`, `GH_AW_SLO_FINISH_SECONDS: "1200"`},
+		},
+		{
+			name:       "notify",
+			slo:        "    finish: 1h\n    notify: true\n",
+			wantEnv:    []string{`GH_AW_SLO_FINISH_SECONDS: "3600"`, `GH_AW_SLO_NOTIFY: "true"`},
+			wantNotify: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := testutil.TempDir(t, "latency-slo-test")
+			testFile := filepath.Join(tmpDir, "slo.md")
+			content := "---\non: workflow_dispatch\npermissions:\n  contents: read\nengine: copilot\nobservability:\n  slo:\n" + tt.slo + "---\n\n# SLO\n\nDo the work.\n"
+			require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "failed to write workflow")
+
+			compiler := NewCompiler()
+			require.NoError(t, compiler.CompileWorkflow(testFile), "workflow should compile")
+			lockContent, err := os.ReadFile(filepath.Join(tmpDir, "slo.lock.yml"))
+			require.NoError(t, err, "failed to read lock file")
+
+			conclusion := extractJobSection(string(lockContent), "conclusion")
+			require.NotEmpty(t, conclusion, "conclusion job should be generated")
+			assert.Contains(t, conclusion, "- name: Check latency SLOs", "SLO step should be in the conclusion job")
+			assert.Contains(t, conclusion, "check_latency_slo.cjs", "SLO step should run the SLO script")
+			for _, env := range tt.wantEnv {
+				assert.Contains(t, conclusion, env, "SLO step should carry its limits")
+			}
+			assert.Contains(t, conclusion, "actions: read", "conclusion job should be able to list the run's jobs")
+			assert.Equal(t, tt.wantNotify, strings.Contains(conclusion, "GH_AW_SLO_NOTIFY"), "breaches should only be reported on an issue with notify")
+			if tt.wantNotify {
+				assert.Contains(t, conclusion, "issues: write", "notify should be able to comment on the tracking issue")
+			}
+		})
+	}
+}
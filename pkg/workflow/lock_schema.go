@@ -36,6 +36,7 @@ type LockMetadata struct {
 	SchemaVersion       LockSchemaVersion `json:"schema_version"`
 	FrontmatterHash     string            `json:"frontmatter_hash,omitempty"`
 	BodyHash            string            `json:"body_hash,omitempty"`
+	PromptHash          string            `json:"prompt_hash,omitempty"`
 	StopTime            string            `json:"stop_time,omitempty"`
 	CompilerVersion     string            `json:"compiler_version,omitempty"`
 	Strict              bool              `json:"strict,omitempty"`
@@ -124,6 +125,7 @@ func GenerateLockMetadata(hashInfo LockHashInfo, stopTime string, strict bool, a
 		SchemaVersion:       LockSchemaV4,
 		FrontmatterHash:     hashInfo.FrontmatterHash,
 		BodyHash:            hashInfo.BodyHash,
+		PromptHash:          ComputePromptHash(hashInfo.FrontmatterHash, hashInfo.BodyHash),
 		StopTime:            stopTime,
 		Strict:              strict,
 		AgentID:             agentInfo.AgentID,
@@ -49,10 +49,12 @@ This is a test workflow.`
 	// Log capture is now done using Claude's --debug-file flag
 	// This provides cleaner, more reliable log capture than shell redirection
 	expected := []string{
-		"--debug-file /tmp/gh-aw/agent-stdio.log",
-		// The log file must be pre-created with restrictive permissions before the
+		`--debug-file "${{ env.GH_AW_TMP_DIR }}/agent-stdio.log"`,
+		// The log files must be pre-created with restrictive permissions before the
 		// agent starts, so that tee/--debug-file cannot produce a world-readable file.
-		"(umask 177 && touch /tmp/gh-aw/agent-stdio.log)",
+		`(umask 177 && touch "${{ env.GH_AW_TMP_DIR }}/agent-stdio.log" "${{ env.GH_AW_TMP_DIR }}/agent-stdout.log" "${{ env.GH_AW_TMP_DIR }}/agent-stderr.log")`,
+		// stdout and stderr are captured to separate logs with ANSI sequences stripped
+		"2>&3 | " + stripANSICommand + ` | tee -a "${{ env.GH_AW_TMP_DIR }}/agent-stdout.log" "${{ env.GH_AW_TMP_DIR }}/agent-stdio.log"`,
 	}
 
 	for _, expected := range expected {
@@ -64,8 +66,8 @@ This is a test workflow.`
 	// Verify that the old standalone log-file touch step (pre-permissions-fix) is NOT present
 	// as a bare command (without the umask wrapper).
 	notExpected := []string{
-		"cat /tmp/gh-aw/agent-stdio.log >> $GITHUB_STEP_SUMMARY",
-		"cat /tmp/gh-aw/agent-stdio.log >> \"$GITHUB_STEP_SUMMARY\"",
+		"cat ${{ env.GH_AW_TMP_DIR }}/agent-stdio.log >> $GITHUB_STEP_SUMMARY",
+		"cat ${{ env.GH_AW_TMP_DIR }}/agent-stdio.log >> \"$GITHUB_STEP_SUMMARY\"",
 	}
 
 	for _, notExpected := range notExpected {
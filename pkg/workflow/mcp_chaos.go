@@ -0,0 +1,174 @@
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var mcpChaosLog = logger.New("workflow:mcp_chaos")
+
+// defaultMCPChaosTimeout is how long an injected timeout holds a tool call before
+// failing it, when sandbox.mcp.chaos.timeout is not set.
+const defaultMCPChaosTimeout = "30s"
+
+// MCPChaosConfig configures fault injection for MCP tool calls:
+//
+//	sandbox:
+//	  mcp:
+//	    chaos:
+//	      seed: 42
+//	      error-rate: 0.1
+//	      timeout-rate: 0.05
+//	      truncate-rate: 0.1
+//
+// A proxy between the agent and the MCP gateway decides the fate of each tools/call request from
+// the seed, the server name, and the call's position in that server's call sequence, so
+// a run with the same seed and the same call order sees the same faults.
+type MCPChaosConfig struct {
+	Seed         int64
+	ErrorRate    float64
+	TimeoutRate  float64
+	TruncateRate float64
+	Timeout      string   // Go duration the injected timeout holds a call (default 30s)
+	Servers      []string // MCP servers to inject faults into; empty means all servers
+}
+
+// timeoutDuration returns the configured injected timeout, falling back to the default
+// when it is unset or invalid (invalid values are rejected by validateMCPChaosConfig).
+func (c *MCPChaosConfig) timeoutDuration() time.Duration {
+	raw := c.Timeout
+	if raw == "" {
+		raw = defaultMCPChaosTimeout
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		d, _ = time.ParseDuration(defaultMCPChaosTimeout)
+	}
+	return d
+}
+
+// proxyConfigJSON returns the chaos configuration passed to mcp_tool_proxy.cjs.
+func (c *MCPChaosConfig) proxyConfigJSON() (string, error) {
+	servers := c.Servers
+	if servers == nil {
+		servers = []string{}
+	}
+	encoded, err := json.Marshal(map[string]any{
+		"seed":          c.Seed,
+		"error_rate":    c.ErrorRate,
+		"timeout_rate":  c.TimeoutRate,
+		"truncate_rate": c.TruncateRate,
+		"timeout_ms":    c.timeoutDuration().Milliseconds(),
+		"servers":       servers,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode MCP chaos configuration: %w", err)
+	}
+	return string(encoded), nil
+}
+
+// extractMCPChaosConfig parses sandbox.mcp.chaos. It returns nil when the key is absent
+// or not an object.
+func extractMCPChaosConfig(chaosVal any) *MCPChaosConfig {
+	chaosObj, ok := chaosVal.(map[string]any)
+	if !ok {
+		return nil
+	}
+	config := &MCPChaosConfig{}
+	switch seed := chaosObj["seed"].(type) {
+	case int:
+		config.Seed = int64(seed)
+	case int64:
+		config.Seed = seed
+	case uint64:
+		config.Seed = int64(seed)
+	case float64:
+		config.Seed = int64(seed)
+	}
+	config.ErrorRate = parseChaosRate(chaosObj["error-rate"])
+	config.TimeoutRate = parseChaosRate(chaosObj["timeout-rate"])
+	config.TruncateRate = parseChaosRate(chaosObj["truncate-rate"])
+	if timeout, ok := chaosObj["timeout"].(string); ok {
+		config.Timeout = timeout
+	}
+	if servers, ok := chaosObj["servers"].([]any); ok {
+		for _, server := range servers {
+			if name, ok := server.(string); ok {
+				config.Servers = append(config.Servers, name)
+			}
+		}
+	}
+	mcpChaosLog.Printf("Extracted MCP chaos config: seed=%d error=%v timeout=%v truncate=%v servers=%v",
+		config.Seed, config.ErrorRate, config.TimeoutRate, config.TruncateRate, config.Servers)
+	return config
+}
+
+// parseChaosRate converts a YAML number to a rate, returning zero for other types.
+func parseChaosRate(val any) float64 {
+	switch v := val.(type) {
+	case float64:
+		return v
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	}
+	return 0
+}
+
+// getMCPChaosConfig returns the chaos configuration of the workflow, or nil when chaos
+// mode is not enabled.
+func getMCPChaosConfig(workflowData *WorkflowData) *MCPChaosConfig {
+	if workflowData == nil || workflowData.SandboxConfig == nil || workflowData.SandboxConfig.MCP == nil {
+		return nil
+	}
+	return workflowData.SandboxConfig.MCP.Chaos
+}
+
+// validateMCPChaosConfig checks sandbox.mcp.chaos and warns that faults will be injected.
+// The schema bounds each rate; this checks what it cannot express: the combined rate,
+// the timeout duration, the server names, and the sandbox the proxy can run in.
+func (c *Compiler) validateMCPChaosConfig(workflowData *WorkflowData, markdownPath string) error {
+	chaos := getMCPChaosConfig(workflowData)
+	if chaos == nil {
+		return nil
+	}
+	if total := chaos.ErrorRate + chaos.TimeoutRate + chaos.TruncateRate; total > 1 {
+		return fmt.Errorf("sandbox.mcp.chaos: error-rate, timeout-rate, and truncate-rate add up to %s, which exceeds 1. Each rate is the probability of a fault for a single tool call.\n\nExample:\nsandbox:\n  mcp:\n    chaos:\n      seed: 42\n      error-rate: 0.1\n      timeout-rate: 0.05\n      truncate-rate: 0.1\n\nSee: %s", strconv.FormatFloat(total, 'f', -1, 64), constants.DocsSandboxURL)
+	}
+	if chaos.Timeout != "" {
+		if d, err := time.ParseDuration(chaos.Timeout); err != nil || d <= 0 {
+			return fmt.Errorf("sandbox.mcp.chaos.timeout: invalid duration %q. Must be a positive Go duration string (e.g. \"30s\", \"2m\").\n\nSee: %s", chaos.Timeout, constants.DocsSandboxURL)
+		}
+	}
+	if err := validateMCPToolProxySandbox(workflowData, "sandbox.mcp.chaos"); err != nil {
+		return err
+	}
+	known := mcpGatewayServerNames(workflowData)
+	for _, server := range chaos.Servers {
+		if !slices.Contains(known, server) {
+			return fmt.Errorf("sandbox.mcp.chaos.servers: unknown MCP server '%s'. Configured servers: %s\n\nSee: %s", server, strings.Join(known, ", "), constants.DocsSandboxURL)
+		}
+	}
+
+	targets := "all MCP servers"
+	if len(chaos.Servers) > 0 {
+		targets = strings.Join(chaos.Servers, ", ")
+	}
+	fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf(
+		"%s: MCP chaos mode is enabled for %s (seed %d: %.0f%% errors, %.0f%% timeouts, %.0f%% truncated results). Tool calls will fail on purpose; do not use this in production workflows.",
+		markdownPath, targets, chaos.Seed, chaos.ErrorRate*100, chaos.TimeoutRate*100, chaos.TruncateRate*100)))
+	c.IncrementWarningCount()
+	return nil
+}
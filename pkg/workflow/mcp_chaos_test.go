@@ -0,0 +1,121 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractMCPChaosConfig(t *testing.T) {
+	config := extractMCPChaosConfig(map[string]any{
+		"seed":          uint64(42),
+		"error-rate":    0.1,
+		"timeout-rate":  0.05,
+		"truncate-rate": uint64(0),
+		"timeout":       "5s",
+		"servers":       []any{"github", "notion"},
+	})
+	require.NotNil(t, config, "chaos object should be extracted")
+	assert.Equal(t, int64(42), config.Seed, "seed should be extracted")
+	assert.InDelta(t, 0.1, config.ErrorRate, 1e-9, "error rate should be extracted")
+	assert.InDelta(t, 0.05, config.TimeoutRate, 1e-9, "timeout rate should be extracted")
+	assert.Zero(t, config.TruncateRate, "integer rates should be accepted")
+	assert.Equal(t, []string{"github", "notion"}, config.Servers, "servers should be extracted")
+
+	proxyJSON, err := config.proxyConfigJSON()
+	require.NoError(t, err, "proxy config should encode")
+	assert.JSONEq(t, `{"seed":42,"error_rate":0.1,"timeout_rate":0.05,"truncate_rate":0,"timeout_ms":5000,"servers":["github","notion"]}`, proxyJSON, "proxy config should carry every field")
+
+	defaults, err := (&MCPChaosConfig{}).proxyConfigJSON()
+	require.NoError(t, err, "default proxy config should encode")
+	assert.Contains(t, defaults, `"timeout_ms":30000`, "timeout should default to 30s")
+	assert.Contains(t, defaults, `"servers":[]`, "servers should default to an empty list")
+
+	assert.Nil(t, extractMCPChaosConfig(true), "non-object chaos should be ignored")
+}
+
+func TestValidateMCPChaosConfig(t *testing.T) {
+	newData := func(chaos *MCPChaosConfig) *WorkflowData {
+		return &WorkflowData{
+			Tools:       map[string]any{"github": map[string]any{}, "notion": map[string]any{"container": "mcp/notion"}},
+			SafeOutputs: &SafeOutputsConfig{CreateIssues: &CreateIssuesConfig{}},
+			SandboxConfig: &SandboxConfig{
+				Agent: &AgentSandboxConfig{ID: "awf", NetworkIsolation: true},
+				MCP:   &MCPGatewayRuntimeConfig{Chaos: chaos},
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		chaos   *MCPChaosConfig
+		wantErr string
+	}{
+		{name: "valid", chaos: &MCPChaosConfig{Seed: 1, ErrorRate: 0.2, TimeoutRate: 0.3, Timeout: "2s", Servers: []string{"notion", "safeoutputs"}}},
+		{name: "rates above one", chaos: &MCPChaosConfig{ErrorRate: 0.6, TruncateRate: 0.5}, wantErr: "add up to 1.1"},
+		{name: "invalid timeout", chaos: &MCPChaosConfig{Timeout: "soon"}, wantErr: "invalid duration"},
+		{name: "unknown server", chaos: &MCPChaosConfig{Servers: []string{"slack"}}, wantErr: "unknown MCP server 'slack'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compiler := NewCompiler()
+			err := compiler.validateMCPChaosConfig(newData(tt.chaos), "workflow.md")
+			if tt.wantErr != "" {
+				require.Error(t, err, "invalid chaos config should be rejected")
+				assert.Contains(t, err.Error(), tt.wantErr, "error should explain the problem")
+				return
+			}
+			require.NoError(t, err, "valid chaos config should be accepted")
+			assert.Equal(t, 1, compiler.GetWarningCount(), "chaos mode should always warn")
+		})
+	}
+
+	unsandboxed := newData(&MCPChaosConfig{ErrorRate: 0.1})
+	unsandboxed.SandboxConfig.Agent = &AgentSandboxConfig{Disabled: true}
+	err := NewCompiler().validateMCPChaosConfig(unsandboxed, "workflow.md")
+	require.Error(t, err, "chaos without the agent sandbox should be rejected")
+	assert.Contains(t, err.Error(), "requires the AWF agent sandbox", "error should name the requirement")
+
+	require.NoError(t, NewCompiler().validateMCPChaosConfig(&WorkflowData{}, "workflow.md"), "workflows without chaos should be accepted")
+}
+
+func TestCompileWorkflowWithMCPChaos(t *testing.T) {
+	dir := t.TempDir()
+	workflowPath := filepath.Join(dir, "chaos.md")
+	require.NoError(t, os.WriteFile(workflowPath, []byte(`---
+on: workflow_dispatch
+permissions:
+  contents: read
+  issues: read
+  pull-requests: read
+engine: copilot
+sandbox:
+  mcp:
+    chaos:
+      seed: 42
+      error-rate: 0.1
+      truncate-rate: 0.1
+      servers: [github]
+tools:
+  github:
+---
+
+# Chaos
+`), 0644), "workflow should be written")
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(workflowPath), "chaos workflow should compile")
+	assert.Equal(t, 1, compiler.GetWarningCount(), "chaos mode should warn")
+
+	lock, err := os.ReadFile(filepath.Join(dir, "chaos.lock.yml"))
+	require.NoError(t, err, "lock file should be written")
+	content := string(lock)
+	assert.Contains(t, content, `export GH_AW_MCP_PROXY_PORT="8090"`, "converters should be pointed at the MCP tool proxy")
+	assert.Contains(t, content, "GH_AW_MCP_CHAOS=", "chaos configuration should be passed to the proxy")
+	assert.Contains(t, content, "start_mcp_tool_proxy.sh", "MCP tool proxy should start inside the agent container")
+}
@@ -311,6 +311,9 @@ func (c *Compiler) generateMCPCLIMountStep(yaml *strings.Builder, data *Workflow
 	yaml.WriteString("          MCP_GATEWAY_API_KEY: ${{ steps.start-mcp-gateway.outputs.gateway-api-key }}\n")
 	yaml.WriteString("          MCP_GATEWAY_DOMAIN: ${{ steps.start-mcp-gateway.outputs.gateway-domain }}\n")
 	yaml.WriteString("          MCP_GATEWAY_PORT: ${{ steps.start-mcp-gateway.outputs.gateway-port }}\n")
+	if isMCPToolProxyEnabled(data) {
+		fmt.Fprintf(yaml, "          GH_AW_MCP_PROXY_PORT: \"%d\"\n", mcpToolProxyPort)
+	}
 	fmt.Fprintf(yaml, "        uses: %s\n", getActionPin("actions/github-script"))
 	yaml.WriteString("        with:\n")
 	yaml.WriteString("          script: |\n")
@@ -115,6 +115,7 @@ func loadSharedMCPConfig(toolConfig map[string]any, toolName string) (*parser.Re
 	headerSecrets := map[string]string(nil)
 	if mcpConfig.Type == "http" {
 		headerSecrets = ExtractSecretsFromMap(mcpConfig.Headers)
+		maps.Copy(headerSecrets, mcpOAuthHeaderSecrets(toolName, mcpConfig))
 	}
 	return mcpConfig, headerSecrets, nil
 }
@@ -137,17 +138,17 @@ func determineMCPPropertyOrder(toolName string, mcpConfig *parser.RegistryMCPSer
 	switch mcpConfig.Type {
 	case "stdio":
 		if renderer.Format == "toml" {
-			return []string{"container", "entrypoint", "entrypointArgs", "mounts", "command", "args", "env", "proxy-args", "registry"}, true
+			return []string{"container", "entrypoint", "entrypointArgs", "mounts", "command", "args", "env", "proxy-args", "registry", "startup_timeout_sec", "tool_timeout_sec"}, true
 		}
-		return []string{"type", "container", "entrypoint", "entrypointArgs", "mounts", "command", "args", "tools", "env", "proxy-args", "registry", "required"}, true
+		return []string{"type", "container", "entrypoint", "entrypointArgs", "mounts", "command", "args", "tools", "env", "proxy-args", "registry", "startupTimeout", "toolTimeout", "restart", "maxRestarts", "required"}, true
 	case "http":
 		if renderer.Format == "toml" {
-			return []string{"url", "http_headers"}, true
+			return []string{"url", "http_headers", "startup_timeout_sec", "tool_timeout_sec"}, true
 		}
 		if len(headerSecrets) > 0 {
-			return []string{"type", "url", "headers", "auth", "tools", "env", "required"}, true
+			return []string{"type", "url", "transport", "headers", "auth", "tools", "env", "startupTimeout", "toolTimeout", "required"}, true
 		}
-		return []string{"type", "url", "headers", "auth", "tools", "required"}, true
+		return []string{"type", "url", "transport", "headers", "auth", "tools", "startupTimeout", "toolTimeout", "required"}, true
 	default:
 		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Custom MCP server '%s' has unsupported type '%s'. Supported types: stdio, http", toolName, mcpConfig.Type)))
 		return nil, false
@@ -186,14 +187,28 @@ func shouldRenderMCPProperty(prop string, mcpConfig *parser.RegistryMCPServerCon
 		return len(mcpConfig.Env) > 0 || len(headerSecrets) > 0
 	case "url":
 		return mcpConfig.URL != ""
+	case "transport":
+		// Streamable HTTP is the gateway default, so only the non-default transport is emitted.
+		return mcpConfig.Transport != "" && mcpConfig.Transport != parser.MCPTransportStreamableHTTP
 	case "headers", "http_headers":
 		return len(mcpConfig.Headers) > 0
 	case "auth":
-		return mcpConfig.Auth != nil
+		// OAuth tokens are exchanged by a workflow step and sent as a header; the gateway
+		// only handles the auth types it acquires tokens for itself.
+		return mcpConfig.Auth != nil && mcpConfig.Auth.Type != mcpAuthTypeOAuth
 	case "proxy-args":
 		return len(mcpConfig.ProxyArgs) > 0
 	case "registry":
 		return mcpConfig.Registry != ""
+	case "startupTimeout", "startup_timeout_sec":
+		return mcpConfig.StartupTimeout > 0
+	case "toolTimeout", "tool_timeout_sec":
+		return mcpConfig.ToolTimeout > 0
+	case "restart":
+		// Never restarting is the gateway default, so only on-failure is emitted.
+		return mcpConfig.Restart == mcpRestartOnFailure
+	case "maxRestarts":
+		return mcpConfig.Restart == mcpRestartOnFailure && mcpConfig.MaxRestarts > 0
 	case "required":
 		return mcpConfig.Required != nil && !*mcpConfig.Required
 	default:
@@ -211,8 +226,10 @@ func renderMCPProperties(yaml *strings.Builder, properties []string, mcpConfig *
 
 func renderMCPProperty(yaml *strings.Builder, property string, isLast bool, mcpConfig *parser.RegistryMCPServerConfig, renderer MCPConfigRenderer, headerSecrets map[string]string) {
 	switch property {
-	case "type", "container", "entrypoint", "command", "url", "registry", "required":
+	case "type", "container", "entrypoint", "command", "url", "transport", "registry", "restart", "required":
 		renderMCPScalarProperty(yaml, property, isLast, mcpConfig, renderer)
+	case "startupTimeout", "startup_timeout_sec", "toolTimeout", "tool_timeout_sec", "maxRestarts":
+		renderMCPIntProperty(yaml, property, isLast, mcpConfig, renderer)
 	case "tools", "entrypointArgs", "mounts", "args", "proxy-args":
 		renderMCPArrayProperty(yaml, property, isLast, mcpConfig, renderer)
 	case "env", "http_headers", "headers":
@@ -247,8 +264,12 @@ func renderMCPScalarProperty(yaml *strings.Builder, property string, isLast bool
 			urlValue = rewriteLocalhostToDockerHost(urlValue)
 		}
 		renderMCPStringScalar(yaml, renderer, "url", urlValue, isLast)
+	case "transport":
+		renderMCPJSONScalar(yaml, renderer, "transport", mcpConfig.Transport, isLast)
 	case "registry":
 		renderMCPStringScalar(yaml, renderer, "registry", mcpConfig.Registry, isLast)
+	case "restart":
+		renderMCPJSONScalar(yaml, renderer, "restart", mcpConfig.Restart, isLast)
 	case "required":
 		if renderer.Format == "json" && mcpConfig.Required != nil && !*mcpConfig.Required {
 			fmt.Fprintf(yaml, "%s\"required\": false%s\n", renderer.IndentLevel, renderMCPComma(isLast))
@@ -256,6 +277,25 @@ func renderMCPScalarProperty(yaml *strings.Builder, property string, isLast bool
 	}
 }
 
+// renderMCPIntProperty renders the per-server timeouts (seconds) and restart limit. TOML
+// output uses Codex's native startup_timeout_sec and tool_timeout_sec keys.
+func renderMCPIntProperty(yaml *strings.Builder, property string, isLast bool, mcpConfig *parser.RegistryMCPServerConfig, renderer MCPConfigRenderer) {
+	var value int
+	switch property {
+	case "startupTimeout", "startup_timeout_sec":
+		value = mcpConfig.StartupTimeout
+	case "toolTimeout", "tool_timeout_sec":
+		value = mcpConfig.ToolTimeout
+	case "maxRestarts":
+		value = mcpConfig.MaxRestarts
+	}
+	if renderer.Format == "toml" {
+		fmt.Fprintf(yaml, "%s%s = %d\n", renderer.IndentLevel, property, value)
+		return
+	}
+	fmt.Fprintf(yaml, "%s\"%s\": %d%s\n", renderer.IndentLevel, property, value, renderMCPComma(isLast))
+}
+
 func renderMCPStringScalar(yaml *strings.Builder, renderer MCPConfigRenderer, key, value string, isLast bool) {
 	if renderer.Format == "toml" {
 		fmt.Fprintf(yaml, "%s%s = \"%s\"\n", renderer.IndentLevel, key, value)
@@ -271,7 +311,7 @@ func renderMCPJSONScalar(yaml *strings.Builder, renderer MCPConfigRenderer, key,
 func renderMCPArrayProperty(yaml *strings.Builder, property string, isLast bool, mcpConfig *parser.RegistryMCPServerConfig, renderer MCPConfigRenderer) {
 	switch property {
 	case "tools":
-		values := mcpConfig.Allowed
+		values := filterBlockedMCPTools(mcpConfig.Allowed, mcpConfig.Blocked)
 		if len(values) == 0 {
 			values = []string{"*"}
 		}
@@ -396,6 +436,7 @@ func collectHTTPMCPHeaderSecrets(tools map[string]any) map[string]string {
 				if mcpConfig, err := getMCPConfig(toolConfig, toolName); err == nil {
 					secrets := ExtractSecretsFromMap(mcpConfig.Headers)
 					maps.Copy(allSecrets, secrets)
+					maps.Copy(allSecrets, mcpOAuthHeaderSecrets(toolName, mcpConfig))
 				}
 			}
 		}
@@ -407,24 +448,31 @@ func collectHTTPMCPHeaderSecrets(tools map[string]any) map[string]string {
 // validateMCPKnownProperties checks that all keys in toolConfig are in the known set.
 func validateMCPKnownProperties(toolConfig map[string]any, toolName string) error {
 	knownProperties := map[string]struct{}{
-		"type":           {},
-		"mode":           {},
-		"command":        {},
-		"container":      {},
-		"version":        {},
-		"args":           {},
-		"entrypoint":     {},
-		"entrypointArgs": {},
-		"mounts":         {},
-		"env":            {},
-		"proxy-args":     {},
-		"url":            {},
-		"headers":        {},
-		"auth":           {},
-		"registry":       {},
-		"allowed":        {},
-		"toolsets":       {},
-		"required":       {},
+		"type":            {},
+		"mode":            {},
+		"command":         {},
+		"container":       {},
+		"version":         {},
+		"args":            {},
+		"entrypoint":      {},
+		"entrypointArgs":  {},
+		"mounts":          {},
+		"env":             {},
+		"proxy-args":      {},
+		"network":         {},
+		"url":             {},
+		"headers":         {},
+		"auth":            {},
+		"transport":       {},
+		"registry":        {},
+		"allowed":         {},
+		"blocked":         {},
+		"toolsets":        {},
+		"required":        {},
+		"startup-timeout": {},
+		"timeout":         {},
+		"restart":         {},
+		"max-restarts":    {},
 	}
 	for key := range toolConfig {
 		if !setutil.Contains(knownProperties, key) {
@@ -514,6 +562,9 @@ func extractMCPHTTPFields(config MapToolConfig, toolName string, result *parser.
 	if headers, ok := config.GetStringMap("headers"); ok {
 		result.Headers = headers
 	}
+	if transport, ok := config.GetString("transport"); ok {
+		result.Transport = transport
+	}
 	if authVal, hasAuth := config.GetAny("auth"); hasAuth {
 		if authMap, ok := authVal.(map[string]any); ok {
 			authConfig := &types.MCPAuthConfig{}
@@ -523,6 +574,9 @@ func extractMCPHTTPFields(config MapToolConfig, toolName string, result *parser.
 			if audience, ok := authMap["audience"].(string); ok {
 				authConfig.Audience = audience
 			}
+			if authConfig.Type == mcpAuthTypeOAuth {
+				extractMCPOAuthFields(MapToolConfig(authMap), authConfig)
+			}
 			if authConfig.Type != "" {
 				result.Auth = authConfig
 			}
@@ -530,6 +584,9 @@ func extractMCPHTTPFields(config MapToolConfig, toolName string, result *parser.
 			result.Auth = authCfg
 		}
 	}
+	if result.Auth != nil && result.Auth.Type == mcpAuthTypeOAuth {
+		applyMCPOAuthHeader(toolName, result)
+	}
 	return nil
 }
 
@@ -600,6 +657,10 @@ func getMCPConfig(toolConfig map[string]any, toolName string) (*parser.RegistryM
 	if allowed, ok := config.GetStringArray("allowed"); ok {
 		result.Allowed = allowed
 	}
+	if blocked, ok := config.GetStringArray("blocked"); ok {
+		result.Blocked = blocked
+	}
+	extractMCPLifecycleFields(config, result)
 	if requiredVal, ok := config.GetAny("required"); ok {
 		if requiredBool, ok := requiredVal.(bool); ok {
 			result.Required = &requiredBool
@@ -607,6 +668,11 @@ func getMCPConfig(toolConfig map[string]any, toolName string) (*parser.RegistryM
 	}
 
 	postProcessMCPConfig(result)
+	if result.Type == "stdio" {
+		if err := applyMCPEgress(result, getMCPServerEgressAllowed(toolConfig)); err != nil {
+			return nil, err
+		}
+	}
 	return result, nil
 }
 
@@ -149,10 +149,10 @@ func renderPlaywrightMCPConfigWithOptions(yaml *strings.Builder, playwrightConfi
 	// When guard policies follow, mounts is not the last field (add trailing comma)
 	mcpPlaywrightLog.Printf("Adding volume mounts: guard_policies=%d", len(guardPolicies))
 	if len(guardPolicies) > 0 {
-		yaml.WriteString("                \"mounts\": [\"/tmp/gh-aw/mcp-logs:/tmp/gh-aw/mcp-logs:rw\"],\n")
+		yaml.WriteString("                \"mounts\": [\"" + constants.TmpGhAwDirExpr + "/mcp-logs:" + constants.TmpGhAwDirExpr + "/mcp-logs:rw\"],\n")
 		renderGuardPoliciesJSON(yaml, guardPolicies, "                ")
 	} else {
-		yaml.WriteString("                \"mounts\": [\"/tmp/gh-aw/mcp-logs:/tmp/gh-aw/mcp-logs:rw\"]\n")
+		yaml.WriteString("                \"mounts\": [\"" + constants.TmpGhAwDirExpr + "/mcp-logs:" + constants.TmpGhAwDirExpr + "/mcp-logs:rw\"]\n")
 	}
 
 	// Note: tools field is NOT included here - the converter script adds it back
@@ -30,7 +30,7 @@ func TestRenderSafeOutputsMCPConfigWithOptions(t *testing.T) {
 				`"type": "stdio"`,
 				`"container": "` + pinnedGhAwNodeImage + `"`,
 				`"${RUNNER_TEMP}/gh-aw/safeoutputs:${RUNNER_TEMP}/gh-aw/safeoutputs:rw"`,
-				`"/tmp/gh-aw:/tmp/gh-aw:rw"`,
+				`"${{ env.GH_AW_TMP_DIR }}:${{ env.GH_AW_TMP_DIR }}:rw"`,
 				`"entrypoint": "sh"`,
 				`"entrypointArgs": ["-c", "sh ${RUNNER_TEMP}/gh-aw/safeoutputs/start_safe_outputs_mcp.sh"]`,
 				`"env": {`,
@@ -50,7 +50,7 @@ func TestRenderSafeOutputsMCPConfigWithOptions(t *testing.T) {
 				`"safeoutputs": {`,
 				`"container": "` + pinnedGhAwNodeImage + `"`,
 				`"${RUNNER_TEMP}/gh-aw/safeoutputs:${RUNNER_TEMP}/gh-aw/safeoutputs:rw"`,
-				`"/tmp/gh-aw:/tmp/gh-aw:rw"`,
+				`"${{ env.GH_AW_TMP_DIR }}:${{ env.GH_AW_TMP_DIR }}:rw"`,
 				`"args": ["-w", "\${GITHUB_WORKSPACE}"]`,
 				`"entrypoint": "sh"`,
 				`"entrypointArgs": ["-c", "sh ${RUNNER_TEMP}/gh-aw/safeoutputs/start_safe_outputs_mcp.sh"]`,
@@ -114,9 +114,9 @@ func TestRenderAgenticWorkflowsMCPConfigWithOptions(t *testing.T) {
 				`"type": "stdio"`,
 				`"container": "localhost/gh-aw:dev"`,                          // Dev mode uses locally built image
 				`"\${GITHUB_WORKSPACE}:\${GITHUB_WORKSPACE}:rw"`,              // workspace mount (read-write)
-				`"/tmp/gh-aw:/tmp/gh-aw:rw"`,                                  // temp directory mount (read-write)
+				`"${{ env.GH_AW_TMP_DIR }}:${{ env.GH_AW_TMP_DIR }}:rw"`,      // temp directory mount (read-write)
 				`"args": ["--network", "host", "-w", "\${GITHUB_WORKSPACE}"]`, // Network access + working directory
-				`"DEBUG": "*"`,                                                // Literal value for debug logging
+				`"DEBUG": "*"`, // Literal value for debug logging
 				`"GITHUB_TOKEN": "\${GITHUB_TOKEN}"`,
 				`              },`,
 			},
@@ -144,7 +144,7 @@ func TestRenderAgenticWorkflowsMCPConfigWithOptions(t *testing.T) {
 				`"${RUNNER_TEMP}/gh-aw:${RUNNER_TEMP}/gh-aw:ro"`,              // gh-aw binary mount (read-only)
 				`"/usr/bin/gh:/usr/bin/gh:ro"`,                                // gh CLI binary mount (read-only)
 				`"\${GITHUB_WORKSPACE}:\${GITHUB_WORKSPACE}:rw"`,              // workspace mount (read-write)
-				`"/tmp/gh-aw:/tmp/gh-aw:rw"`,                                  // temp directory mount (read-write)
+				`"${{ env.GH_AW_TMP_DIR }}:${{ env.GH_AW_TMP_DIR }}:rw"`,      // temp directory mount (read-write)
 				`"args": ["--network", "host", "-w", "\${GITHUB_WORKSPACE}"]`, // Network access + working directory
 				`"DEBUG": "*"`,
 				`"GITHUB_TOKEN": "\${GITHUB_TOKEN}"`,
@@ -167,7 +167,7 @@ func TestRenderAgenticWorkflowsMCPConfigWithOptions(t *testing.T) {
 				`"agenticworkflows": {`,
 				`"container": "localhost/gh-aw:dev"`,                          // Dev mode uses locally built image
 				`"\${GITHUB_WORKSPACE}:\${GITHUB_WORKSPACE}:rw"`,              // workspace mount (read-write)
-				`"/tmp/gh-aw:/tmp/gh-aw:rw"`,                                  // temp directory mount (read-write)
+				`"${{ env.GH_AW_TMP_DIR }}:${{ env.GH_AW_TMP_DIR }}:rw"`,      // temp directory mount (read-write)
 				`"args": ["--network", "host", "-w", "\${GITHUB_WORKSPACE}"]`, // Network access + working directory
 				// Environment variables
 				`"DEBUG": "*"`, // Literal value for debug logging
@@ -230,11 +230,11 @@ func TestRenderSafeOutputsMCPConfigTOML(t *testing.T) {
 	expectedContent := []string{
 		`[mcp_servers.safeoutputs]`,
 		`container = "` + resolveMCPGatewayContainerImage(constants.DefaultGhAwNodeImage, nil) + `"`,
-		`mounts = ["\${GITHUB_WORKSPACE}:\${GITHUB_WORKSPACE}:rw", "${RUNNER_TEMP}/gh-aw/safeoutputs:${RUNNER_TEMP}/gh-aw/safeoutputs:rw", "/tmp/gh-aw:/tmp/gh-aw:rw"]`,
+		`mounts = ["\${GITHUB_WORKSPACE}:\${GITHUB_WORKSPACE}:rw", "${RUNNER_TEMP}/gh-aw/safeoutputs:${RUNNER_TEMP}/gh-aw/safeoutputs:rw", "${{ env.GH_AW_TMP_DIR }}:${{ env.GH_AW_TMP_DIR }}:rw"]`,
 		`args = ["-w", "$GITHUB_WORKSPACE"]`,
 		`entrypoint = "sh"`,
 		`entrypointArgs = ["-c", "sh ${RUNNER_TEMP}/gh-aw/safeoutputs/start_safe_outputs_mcp.sh"]`,
-		`env_vars = ["DEBUG", "DEFAULT_BRANCH", "GH_AW_ASSETS_ALLOWED_EXTS", "GH_AW_ASSETS_BRANCH", "GH_AW_ASSETS_MAX_SIZE_KB", "GH_AW_MCP_LOG_DIR", "GH_AW_SAFE_OUTPUTS", "GH_AW_SAFE_OUTPUTS_CONFIG_PATH", "GH_AW_SAFE_OUTPUTS_TOOLS_PATH", "GH_AW_POLICY_ALLOW_CREATE_PULL_REQUEST", "GITHUB_REPOSITORY", "GITHUB_SHA", "GITHUB_TOKEN", "GITHUB_WORKSPACE", "RUNNER_TEMP"]`,
+		`env_vars = ["DEBUG", "DEFAULT_BRANCH", "GH_AW_ASSETS_ALLOWED_EXTS", "GH_AW_ASSETS_BRANCH", "GH_AW_ASSETS_MAX_SIZE_KB", "GH_AW_MCP_LOG_DIR", "GH_AW_SAFE_OUTPUTS", "GH_AW_SAFE_OUTPUTS_CONFIG_PATH", "GH_AW_SAFE_OUTPUTS_TOOLS_PATH", "GH_AW_POLICY_ALLOW_CREATE_PULL_REQUEST", "GH_AW_TMP_DIR", "GITHUB_REPOSITORY", "GITHUB_SHA", "GITHUB_TOKEN", "GITHUB_WORKSPACE", "RUNNER_TEMP"]`,
 	}
 
 	unexpectedContent := []string{
@@ -300,7 +300,7 @@ func TestRenderSafeOutputsMCPConfigTOMLStableAcrossSandboxModes(t *testing.T) {
 			if !strings.Contains(result, `container = "`+resolveMCPGatewayContainerImage(constants.DefaultGhAwNodeImage, tt.workflowData)+`"`) {
 				t.Errorf("Expected gh-aw node container not found in output:\n%s", result)
 			}
-			if !strings.Contains(result, `mounts = ["\${GITHUB_WORKSPACE}:\${GITHUB_WORKSPACE}:rw", "${RUNNER_TEMP}/gh-aw/safeoutputs:${RUNNER_TEMP}/gh-aw/safeoutputs:rw", "/tmp/gh-aw:/tmp/gh-aw:rw"]`) {
+			if !strings.Contains(result, `mounts = ["\${GITHUB_WORKSPACE}:\${GITHUB_WORKSPACE}:rw", "${RUNNER_TEMP}/gh-aw/safeoutputs:${RUNNER_TEMP}/gh-aw/safeoutputs:rw", "${{ env.GH_AW_TMP_DIR }}:${{ env.GH_AW_TMP_DIR }}:rw"]`) {
 				t.Errorf("Expected stable safe-outputs mounts not found in output:\n%s", result)
 			}
 			if strings.Contains(result, "host.docker.internal") || strings.Contains(result, "localhost") {
@@ -328,8 +328,8 @@ func TestRenderAgenticWorkflowsMCPConfigTOML(t *testing.T) {
 			expectedContainer:    `container = "localhost/gh-aw:dev"`,
 			shouldHaveEntrypoint: false, // Dev mode uses container's default ENTRYPOINT
 			expectedMounts: []string{
-				`"\${GITHUB_WORKSPACE}:\${GITHUB_WORKSPACE}:rw"`, // workspace mount
-				`"/tmp/gh-aw:/tmp/gh-aw:rw"`,                     // temp directory mount
+				`"\${GITHUB_WORKSPACE}:\${GITHUB_WORKSPACE}:rw"`,         // workspace mount
+				`"${{ env.GH_AW_TMP_DIR }}:${{ env.GH_AW_TMP_DIR }}:rw"`, // temp directory mount
 			},
 			unexpectedContent: []string{
 				`--cmd`,
@@ -345,12 +345,12 @@ func TestRenderAgenticWorkflowsMCPConfigTOML(t *testing.T) {
 			expectedContainer:    `container = "alpine:latest"`,
 			shouldHaveEntrypoint: true,
 			expectedMounts: []string{
-				`entrypoint = "${RUNNER_TEMP}/gh-aw/gh-aw"`,           // Entrypoint needed in release mode
-				`entrypointArgs = ["mcp-server", "--validate-actor"]`, // EntrypointArgs needed in release mode with validate-actor flag
-				`"${RUNNER_TEMP}/gh-aw:${RUNNER_TEMP}/gh-aw:ro"`,      // gh-aw binary mount
-				`"/usr/bin/gh:/usr/bin/gh:ro"`,                        // gh CLI binary mount
-				`"\${GITHUB_WORKSPACE}:\${GITHUB_WORKSPACE}:rw"`,      // workspace mount
-				`"/tmp/gh-aw:/tmp/gh-aw:rw"`,                          // temp directory mount
+				`entrypoint = "${RUNNER_TEMP}/gh-aw/gh-aw"`,              // Entrypoint needed in release mode
+				`entrypointArgs = ["mcp-server", "--validate-actor"]`,    // EntrypointArgs needed in release mode with validate-actor flag
+				`"${RUNNER_TEMP}/gh-aw:${RUNNER_TEMP}/gh-aw:ro"`,         // gh-aw binary mount
+				`"/usr/bin/gh:/usr/bin/gh:ro"`,                           // gh CLI binary mount
+				`"\${GITHUB_WORKSPACE}:\${GITHUB_WORKSPACE}:rw"`,         // workspace mount
+				`"${{ env.GH_AW_TMP_DIR }}:${{ env.GH_AW_TMP_DIR }}:rw"`, // temp directory mount
 			},
 			unexpectedContent: []string{
 				`--cmd`,
@@ -373,7 +373,7 @@ func TestRenderAgenticWorkflowsMCPConfigTOML(t *testing.T) {
 			expectedContent := []string{
 				`[mcp_servers.agenticworkflows]`,
 				tt.expectedContainer,
-				`env_vars = ["DEBUG", "GH_TOKEN", "GITHUB_TOKEN", "GITHUB_ACTOR", "GITHUB_REPOSITORY"]`,
+				`env_vars = ["DEBUG", "GH_TOKEN", "GITHUB_TOKEN", "GITHUB_ACTOR", "GITHUB_REPOSITORY", "GH_AW_TMP_DIR"]`,
 			}
 			expectedContent = append(expectedContent, tt.expectedMounts...)
 
@@ -40,10 +40,12 @@ var builtInToolNames = map[string]bool{
 	"edit":              true,
 	"web-fetch":         true,
 	"web-search":        true,
+	"filesystem":        true,
 	"safety-prompt":     true,
 	"timeout":           true,
 	"startup-timeout":   true,
 	"cli-proxy":         true,
+	"artifacts":         true,
 }
 
 // builtInToolNamesForError is the sorted, comma-separated list of built-in tool names
@@ -97,6 +99,15 @@ func ValidateMCPConfigs(tools map[string]any) error {
 		if err := validateMCPRequirements(toolName, mcpConfig, config); err != nil {
 			return err
 		}
+		if err := validateMCPBlockedTools(toolName, config); err != nil {
+			return err
+		}
+		if err := validateMCPServerLifecycle(toolName, config); err != nil {
+			return err
+		}
+		if err := validateMCPServerNetwork(toolName, config); err != nil {
+			return err
+		}
 
 		// Run JSON schema validation as a catch-all after custom validation. Build a
 		// schema-compatible view of the config by extracting only the properties defined
@@ -154,14 +165,21 @@ func getRawMCPConfig(toolConfig map[string]any) (map[string]any, error) {
 		"env":             {},
 		"headers":         {},
 		"auth":            {}, // upstream OIDC authentication (HTTP servers only)
+		"transport":       {}, // upstream HTTP transport: streamable-http or sse (HTTP servers only)
 		"version":         {},
 		"args":            {},
 		"entrypoint":      {},
 		"entrypointArgs":  {},
 		"mounts":          {},
 		"proxy-args":      {},
+		"network":         {}, // per-server egress allowlist (container-based servers only)
 		"registry":        {},
 		"allowed":         {},
+		"blocked":         {}, // tools removed from the allowed set for custom MCP servers
+		"startup-timeout": {}, // per-server initialize timeout in seconds
+		"timeout":         {}, // per-server tool call timeout in seconds
+		"restart":         {}, // restart policy for stdio servers: never or on-failure
+		"max-restarts":    {}, // restart attempts allowed with restart: on-failure
 		"mode":            {}, // for github tool: prompt/runtime mode (cli) or legacy MCP transport (local/remote)
 		"github-token":    {}, // for github tool
 		"read-only":       {}, // for github tool
@@ -182,7 +200,7 @@ func getRawMCPConfig(toolConfig map[string]any) (map[string]any, error) {
 		}
 	}
 
-	// Check for unknown fields that might be typos or deprecated (like "network")
+	// Check for unknown fields that might be typos or deprecated
 	for field := range toolConfig {
 		if !setutil.Contains(knownToolFields, field) {
 			// Build list of valid fields for the error message
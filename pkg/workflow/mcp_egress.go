@@ -0,0 +1,194 @@
+package workflow
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/github/gh-aw/pkg/sliceutil"
+)
+
+var mcpEgressLog = logger.New("workflow:mcp_egress")
+
+// mcpEgressProxyPort is the port the per-server Squid proxy listens on.
+const mcpEgressProxyPort = 3128
+
+// mcpEgressNamePrefix prefixes the internal Docker network and proxy container created
+// for each MCP server that declares network.allowed.
+const mcpEgressNamePrefix = "gh-aw-mcp-egress-"
+
+// mcpEgressProxyAlias is the hostname of the proxy on the internal network of its MCP
+// server. Every network has a single proxy, so the alias is the same for all servers
+// and jobs. Keep in sync with actions/setup/sh/start_mcp_egress_proxy.sh.
+const mcpEgressProxyAlias = "gh-aw-mcp-egress-proxy"
+
+var mcpEgressNameSanitizer = regexp.MustCompile(`[^a-z0-9_.-]+`)
+
+// MCPEgressConfig is the egress policy of a single container-based MCP server:
+//
+//	mcp-servers:
+//	  notion:
+//	    container: mcp/notion
+//	    network:
+//	      allowed: [api.notion.com]
+//
+// The server container is attached to an internal Docker network whose only way out is
+// a dedicated Squid proxy that allows the listed domains. This is independent of the
+// top-level network configuration, which only applies to the agent.
+type MCPEgressConfig struct {
+	ServerName string
+	Allowed    []string
+}
+
+// networkName returns the internal Docker network the MCP server container joins. Docker
+// networks and containers are shared by all jobs on a host, so the name includes the job
+// scope exported by setup.sh, which differs between runs, attempts, jobs and matrix legs.
+func (e *MCPEgressConfig) networkName() string {
+	name := mcpEgressNameSanitizer.ReplaceAllString(strings.ToLower(e.ServerName), "-")
+	return mcpEgressNamePrefix + constants.JobScopeExpr + "-" + strings.Trim(name, "-")
+}
+
+// proxyName returns the name of the proxy container.
+func (e *MCPEgressConfig) proxyName() string {
+	return e.networkName() + "-proxy"
+}
+
+// proxyURL returns the proxy URL exported to the MCP server container. It uses the
+// network alias of the proxy rather than its container name, which is scoped to the job.
+func (e *MCPEgressConfig) proxyURL() string {
+	return fmt.Sprintf("http://%s:%d", mcpEgressProxyAlias, mcpEgressProxyPort)
+}
+
+// squidDomains returns the allowed domains in Squid dstdomain form. Ecosystem identifiers
+// are expanded, and every domain also matches its subdomains, like the top-level
+// network.allowed list.
+func (e *MCPEgressConfig) squidDomains() []string {
+	seen := make(map[string]struct{})
+	for _, domain := range expandAllowedDomains(e.Allowed) {
+		domain = strings.TrimPrefix(strings.TrimPrefix(domain, "*"), ".")
+		if domain != "" {
+			seen["."+domain] = struct{}{}
+		}
+	}
+	return sliceutil.SortedKeys(seen)
+}
+
+// getMCPServerEgressAllowed returns network.allowed from an MCP server config, or nil.
+func getMCPServerEgressAllowed(toolConfig map[string]any) []string {
+	networkObj, ok := toolConfig["network"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	allowed, _ := MapToolConfig(networkObj).GetStringArray("allowed")
+	return allowed
+}
+
+// validateMCPServerNetwork checks that network.allowed is only used on stdio servers,
+// which run as containers the compiler can attach to an egress-restricted network.
+func validateMCPServerNetwork(toolName string, toolConfig map[string]any) error {
+	if _, hasNetwork := toolConfig["network"]; !hasNetwork {
+		return nil
+	}
+	if _, mcpType := hasMCPConfig(toolConfig); mcpType != "http" {
+		return nil
+	}
+	return NewValidationError(
+		fmt.Sprintf("mcp-servers.%s.network", toolName),
+		"http",
+		"network.allowed restricts the egress of a containerized MCP server and is not supported for HTTP servers",
+		fmt.Sprintf("Run 'gh aw fix' to move the domains to the top-level network.allowed, or run the server as a container:\n\nmcp-servers:\n  %s:\n    container: ghcr.io/example/mcp-server:latest\n    network:\n      allowed:\n        - api.example.com\n\nSee: %s", toolName, constants.DocsToolsURL),
+	)
+}
+
+// applyMCPEgress routes a container-based MCP server through its egress proxy: the
+// container joins the internal network and receives the proxy environment. Servers that
+// do not run in a container are rejected because their egress cannot be constrained.
+func applyMCPEgress(result *parser.RegistryMCPServerConfig, allowed []string) error {
+	if len(allowed) == 0 {
+		return nil
+	}
+	if result.Container == "" {
+		return fmt.Errorf("mcp-servers.%s.network: network.allowed requires a container-based MCP server so that its egress can be restricted. Use 'container' instead of 'command', or run 'gh aw fix' to move the domains to the top-level network.allowed.\n\nExample:\nmcp-servers:\n  %s:\n    container: ghcr.io/example/mcp-server:latest\n    network:\n      allowed:\n        - api.example.com", result.Name, result.Name)
+	}
+	egress := &MCPEgressConfig{ServerName: result.Name, Allowed: allowed}
+	result.Args = append([]string{"--network", egress.networkName()}, result.Args...)
+	if result.Env == nil {
+		result.Env = make(map[string]string)
+	}
+	proxyURL := egress.proxyURL()
+	result.Env["HTTPS_PROXY"] = proxyURL
+	result.Env["HTTP_PROXY"] = proxyURL
+	result.Env["NO_PROXY"] = "localhost,127.0.0.1"
+	mcpEgressLog.Printf("Routing MCP server %s through %s (%d allowed entries)", result.Name, egress.proxyName(), len(allowed))
+	return nil
+}
+
+// collectMCPEgressConfigs returns the egress policies of all MCP servers that declare
+// network.allowed, sorted by server name.
+func collectMCPEgressConfigs(tools map[string]any) []MCPEgressConfig {
+	var configs []MCPEgressConfig
+	for _, toolName := range sliceutil.SortedKeys(tools) {
+		toolConfig, ok := tools[toolName].(map[string]any)
+		if !ok {
+			continue
+		}
+		if hasMcp, mcpType := hasMCPConfig(toolConfig); !hasMcp || mcpType != "stdio" {
+			continue
+		}
+		if allowed := getMCPServerEgressAllowed(toolConfig); len(allowed) > 0 {
+			configs = append(configs, MCPEgressConfig{ServerName: toolName, Allowed: allowed})
+		}
+	}
+	return configs
+}
+
+// getMCPEgressProxyImage returns the Squid image used for MCP egress proxies. It reuses
+// the firewall's Squid image so that no additional image needs to be trusted.
+func getMCPEgressProxyImage(workflowData *WorkflowData) string {
+	return constants.DefaultFirewallRegistry + "/squid:" + getAWFImageTag(getFirewallConfig(workflowData))
+}
+
+// generateMCPEgressProxySteps emits the step that starts one egress proxy per MCP server
+// with network.allowed. It must run before the MCP gateway starts the server containers.
+func generateMCPEgressProxySteps(yaml *strings.Builder, tools map[string]any, workflowData *WorkflowData) {
+	configs := collectMCPEgressConfigs(tools)
+	if len(configs) == 0 {
+		return
+	}
+	image := getMCPEgressProxyImage(workflowData)
+	if digest := lookupContainerDigest(image, workflowData); digest != "" {
+		image += "@" + digest
+	}
+	mcpEgressLog.Printf("Generating egress proxies for %d MCP servers", len(configs))
+
+	yaml.WriteString("      - name: Start MCP egress proxies\n")
+	yaml.WriteString("        run: |\n")
+	for _, config := range configs {
+		args := slices.Concat([]string{config.ServerName, config.networkName(), image}, config.squidDomains())
+		yaml.WriteString("          bash \"${RUNNER_TEMP}/gh-aw/actions/start_mcp_egress_proxy.sh\" " + shellJoinArgs(args) + "\n")
+	}
+}
+
+// generateMCPEgressProxyCleanupStep emits the step that removes the egress proxies and
+// their networks once the MCP gateway has stopped. It always runs, so a failed or
+// cancelled agent run does not leave containers and networks behind on the host.
+func generateMCPEgressProxyCleanupStep(yaml *strings.Builder, tools map[string]any) {
+	configs := collectMCPEgressConfigs(tools)
+	if len(configs) == 0 {
+		return
+	}
+	networks := make([]string, 0, len(configs))
+	for _, config := range configs {
+		networks = append(networks, config.networkName())
+	}
+
+	yaml.WriteString("      - name: Stop MCP egress proxies\n")
+	yaml.WriteString("        if: always()\n")
+	yaml.WriteString("        continue-on-error: true\n")
+	yaml.WriteString("        run: |\n")
+	yaml.WriteString("          bash \"${RUNNER_TEMP}/gh-aw/actions/stop_mcp_egress_proxy.sh\" " + shellJoinArgs(networks) + "\n")
+}
@@ -0,0 +1,113 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMCPEgressConfig_Names(t *testing.T) {
+	egress := &MCPEgressConfig{ServerName: "My Server_1", Allowed: []string{"api.example.com"}}
+	assert.Equal(t, "gh-aw-mcp-egress-${{ env.GH_AW_JOB_SCOPE }}-my-server_1", egress.networkName(), "network name should be docker-safe and scoped to the job")
+	assert.Equal(t, "gh-aw-mcp-egress-${{ env.GH_AW_JOB_SCOPE }}-my-server_1-proxy", egress.proxyName(), "proxy name should be scoped to the job")
+	assert.Equal(t, "http://gh-aw-mcp-egress-proxy:3128", egress.proxyURL(), "proxy URL should use the network alias of the proxy")
+}
+
+func TestMCPEgressConfig_SquidDomains(t *testing.T) {
+	egress := &MCPEgressConfig{ServerName: "notion", Allowed: []string{"api.notion.com", "*.notion.so", ".files.example.com"}}
+	assert.Equal(t, []string{".api.notion.com", ".files.example.com", ".notion.so"}, egress.squidDomains(), "domains should match subdomains in Squid form")
+}
+
+func TestGetMCPConfig_NetworkAllowed(t *testing.T) {
+	t.Run("container server joins the egress network", func(t *testing.T) {
+		config, err := getMCPConfig(map[string]any{
+			"container": "mcp/notion",
+			"args":      []any{"-v", "/data:/data"},
+			"env":       map[string]any{"NOTION_TOKEN": "${{ secrets.NOTION_TOKEN }}"},
+			"network":   map[string]any{"allowed": []any{"api.notion.com"}},
+		}, "notion")
+		require.NoError(t, err, "container server with network.allowed should be accepted")
+		assert.Equal(t, []string{"--network", "gh-aw-mcp-egress-${{ env.GH_AW_JOB_SCOPE }}-notion", "-v", "/data:/data"}, config.Args, "network flag should precede user args")
+		assert.Equal(t, "http://gh-aw-mcp-egress-proxy:3128", config.Env["HTTPS_PROXY"], "proxy should be exported")
+		assert.Equal(t, "${{ secrets.NOTION_TOKEN }}", config.Env["NOTION_TOKEN"], "user env should be kept")
+	})
+
+	t.Run("server without network is unchanged", func(t *testing.T) {
+		config, err := getMCPConfig(map[string]any{"container": "mcp/notion"}, "notion")
+		require.NoError(t, err, "container server should be accepted")
+		assert.Empty(t, config.Args, "no args should be added")
+		assert.NotContains(t, config.Env, "HTTPS_PROXY", "no proxy should be set")
+	})
+
+	t.Run("command server cannot be constrained", func(t *testing.T) {
+		_, err := getMCPConfig(map[string]any{
+			"command": "./server",
+			"network": map[string]any{"allowed": []any{"api.example.com"}},
+		}, "local")
+		require.Error(t, err, "non-container server should be rejected")
+		assert.Contains(t, err.Error(), "requires a container-based MCP server", "error should explain the requirement")
+	})
+}
+
+func TestGenerateMCPEgressProxySteps(t *testing.T) {
+	tools := map[string]any{
+		"notion": map[string]any{
+			"container": "mcp/notion",
+			"network":   map[string]any{"allowed": []any{"api.notion.com"}},
+		},
+		"fetch": map[string]any{"container": "mcp/fetch"},
+		"docs":  map[string]any{"url": "https://mcp.example.com/mcp"},
+	}
+
+	var yaml strings.Builder
+	generateMCPEgressProxySteps(&yaml, tools, &WorkflowData{})
+	step := yaml.String()
+	assert.Contains(t, step, "- name: Start MCP egress proxies", "step should be generated")
+	assert.Contains(t, step, `start_mcp_egress_proxy.sh" notion "gh-aw-mcp-egress-${{ env.GH_AW_JOB_SCOPE }}-notion" ghcr.io/github/gh-aw-firewall/squid:`, "proxy should be started for notion")
+	assert.Contains(t, step, " .api.notion.com\n", "allowed domains should be passed")
+	assert.NotContains(t, step, "fetch", "servers without network.allowed should not get a proxy")
+
+	yaml.Reset()
+	generateMCPEgressProxySteps(&yaml, map[string]any{"fetch": map[string]any{"container": "mcp/fetch"}}, &WorkflowData{})
+	assert.Empty(t, yaml.String(), "no step without network.allowed")
+}
+
+func TestGenerateMCPEgressProxyCleanupStep(t *testing.T) {
+	tools := map[string]any{
+		"notion": map[string]any{
+			"container": "mcp/notion",
+			"network":   map[string]any{"allowed": []any{"api.notion.com"}},
+		},
+		"jira": map[string]any{
+			"container": "mcp/jira",
+			"network":   map[string]any{"allowed": []any{"example.atlassian.net"}},
+		},
+		"fetch": map[string]any{"container": "mcp/fetch"},
+	}
+
+	var yaml strings.Builder
+	generateMCPEgressProxyCleanupStep(&yaml, tools)
+	step := yaml.String()
+	assert.Contains(t, step, "- name: Stop MCP egress proxies\n        if: always()\n", "cleanup should run even when the agent fails")
+	assert.Contains(t, step, `stop_mcp_egress_proxy.sh" "gh-aw-mcp-egress-${{ env.GH_AW_JOB_SCOPE }}-jira" "gh-aw-mcp-egress-${{ env.GH_AW_JOB_SCOPE }}-notion"`, "networks of all servers should be removed")
+	assert.NotContains(t, step, "fetch", "servers without network.allowed have no proxy")
+
+	yaml.Reset()
+	generateMCPEgressProxyCleanupStep(&yaml, map[string]any{"fetch": map[string]any{"container": "mcp/fetch"}})
+	assert.Empty(t, yaml.String(), "no step without network.allowed")
+}
+
+func TestCollectDockerImages_MCPEgressProxy(t *testing.T) {
+	tools := map[string]any{
+		"notion": map[string]any{
+			"container": "mcp/notion",
+			"network":   map[string]any{"allowed": []any{"api.notion.com"}},
+		},
+	}
+	images := collectDockerImages(tools, &WorkflowData{}, ActionModeDev)
+	assert.Contains(t, strings.Join(images, " "), "ghcr.io/github/gh-aw-firewall/squid:", "egress proxy image should be pre-pulled")
+}
@@ -112,6 +112,9 @@ func collectMCPEnvironmentVariables(tools map[string]any, mcpTools []string, wor
 		// by the gateway from process.env at startup time.
 		envVars["GH_AW_SAFE_OUTPUTS_CONFIG_PATH"] = "${{ steps.set-runtime-paths.outputs.GH_AW_SAFE_OUTPUTS_CONFIG_PATH }}"
 		envVars["GH_AW_SAFE_OUTPUTS_TOOLS_PATH"] = "${{ steps.set-runtime-paths.outputs.GH_AW_SAFE_OUTPUTS_TOOLS_PATH }}"
+		// The safe outputs MCP server logs to the mcp-logs directory so the logs are included
+		// in the mcp-logs artifact.
+		envVars["GH_AW_MCP_LOG_DIR"] = constants.TmpMcpLogsSafeOutputsDir
 		// PolicyAllowCreatePullRequest is sourced from vars.* (GitHub repository/environment
 		// configuration variables, not runner env) so that org admins can disable PR creation
 		// without modifying compiled workflow YAML. The || 'true' fallback preserves
@@ -186,6 +189,7 @@ func collectMCPEnvironmentVariables(tools map[string]any, mcpTools []string, wor
 			// Extract secrets from headers for HTTP MCP servers
 			if mcpConfig.Type == "http" && len(mcpConfig.Headers) > 0 {
 				headerSecrets := ExtractSecretsFromMap(mcpConfig.Headers)
+				maps.Copy(headerSecrets, mcpOAuthHeaderSecrets(toolName, mcpConfig))
 				mcpEnvironmentLog.Printf("Extracted %d secrets from HTTP MCP server '%s'", len(headerSecrets), toolName)
 				maps.Copy(envVars, headerSecrets)
 			}
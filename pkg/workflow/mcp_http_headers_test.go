@@ -492,3 +492,60 @@ func TestRenderSharedMCPConfig_WithAuth(t *testing.T) {
 		}
 	})
 }
+
+func TestRenderSharedMCPConfig_WithTransport(t *testing.T) {
+	renderer := MCPConfigRenderer{
+		IndentLevel:           "  ",
+		Format:                "json",
+		RequiresCopilotFields: true,
+	}
+
+	t.Run("renders sse transport after url", func(t *testing.T) {
+		toolConfig := map[string]any{
+			"type":      "http",
+			"url":       "https://my-server.example.com/sse",
+			"transport": "sse",
+			"headers": map[string]any{
+				"Authorization": "Bearer ${{ secrets.MY_TOKEN }}",
+			},
+		}
+
+		var output strings.Builder
+		err := renderSharedMCPConfig(&output, "my-server", toolConfig, renderer)
+		if err != nil {
+			t.Fatalf("renderSharedMCPConfig failed: %v", err)
+		}
+
+		result := output.String()
+		urlIdx := strings.Index(result, `"url":`)
+		transportIdx := strings.Index(result, `"transport": "sse"`)
+		headersIdx := strings.Index(result, `"headers":`)
+		if transportIdx == -1 {
+			t.Fatalf("Expected transport not found in output:\n%s", result)
+		}
+		if urlIdx >= transportIdx || transportIdx >= headersIdx {
+			t.Errorf("Properties not in expected order (url, transport, headers):\n%s", result)
+		}
+		if !strings.Contains(result, `"Authorization": "Bearer \${MY_TOKEN}"`) {
+			t.Errorf("Expected header secret to be replaced with env var reference:\n%s", result)
+		}
+	})
+
+	t.Run("omits default streamable-http transport", func(t *testing.T) {
+		toolConfig := map[string]any{
+			"type":      "http",
+			"url":       "https://my-server.example.com/mcp",
+			"transport": "streamable-http",
+		}
+
+		var output strings.Builder
+		err := renderSharedMCPConfig(&output, "my-server", toolConfig, renderer)
+		if err != nil {
+			t.Fatalf("renderSharedMCPConfig failed: %v", err)
+		}
+
+		if strings.Contains(output.String(), `"transport"`) {
+			t.Errorf("Expected default transport to be omitted:\n%s", output.String())
+		}
+	})
+}
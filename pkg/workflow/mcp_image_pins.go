@@ -0,0 +1,255 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var mcpImagePinsLog = logger.New("workflow:mcp_image_pins")
+
+// mcpServerImagesHeader starts the lock file header section that lists the container image of
+// each MCP server, so that lock file diffs show exactly which digest each server is bound to:
+//
+//	# MCP server images:
+//	#   - github: ghcr.io/github/github-mcp-server:v0.32.0@sha256:...
+//	#   - notion: mcp/notion (not pinned)
+const mcpServerImagesHeader = "# MCP server images:"
+
+// mcpServerImageLinePattern matches an entry of the MCP server images header section.
+var mcpServerImageLinePattern = regexp.MustCompile(`^#   - ([^:]+): (\S+)`)
+
+// mcpServerImage is the container image an MCP server runs in.
+type mcpServerImage struct {
+	Name        string // MCP server name, e.g. "github"
+	Image       string // Image tag, e.g. "ghcr.io/github/github-mcp-server:v0.32.0"
+	Digest      string // SHA-256 digest when pinned
+	PinnedImage string // Full pinned reference (image@digest)
+
+	explicit bool // digest written in the workflow itself rather than resolved from pins
+}
+
+// mcpPinChange is an MCP server image pin that differs from the previous lock file.
+type mcpPinChange struct {
+	name    string
+	message string
+}
+
+// customMCPServerImage returns the container image of a custom MCP server configured with
+// either a container field or a "docker run ... image" command.
+func customMCPServerImage(toolName string, toolValue any) (string, bool) {
+	mcpConfig, ok := toolValue.(map[string]any)
+	if !ok {
+		return "", false
+	}
+	if hasMcp, _ := hasMCPConfig(mcpConfig); !hasMcp {
+		return "", false
+	}
+	mcpConf, err := getMCPConfig(mcpConfig, toolName)
+	if err != nil {
+		return "", false
+	}
+	if mcpConf.Container != "" {
+		return mcpConf.Container, true
+	}
+	if mcpConf.Command == "docker" && len(mcpConf.Args) > 0 {
+		// Args format: ["run", "--rm", "-i", ... , "container-image"]
+		image := mcpConf.Args[len(mcpConf.Args)-1]
+		if !strings.HasPrefix(image, "-") {
+			return image, true
+		}
+	}
+	return "", false
+}
+
+// collectMCPServerImages returns the container image of every MCP server that runs in a
+// container, sorted by server name. Digest fields are filled from the container pins.
+func collectMCPServerImages(data *WorkflowData) []mcpServerImage {
+	if data == nil || len(data.Tools) == 0 {
+		return nil
+	}
+	var servers []mcpServerImage
+	for name, value := range data.Tools {
+		var image string
+		switch name {
+		case "github":
+			githubTool, ok := value.(map[string]any)
+			if !ok || getGitHubType(githubTool) != GitHubMCPModeLocal {
+				continue
+			}
+			image = "ghcr.io/github/github-mcp-server:" + getGitHubDockerImageVersion(githubTool)
+		case "playwright":
+			if isPlaywrightCLIMode(data.Tools) {
+				continue
+			}
+			image = "mcr.microsoft.com/playwright/mcp"
+		default:
+			var ok bool
+			if image, ok = customMCPServerImage(name, value); !ok {
+				continue
+			}
+		}
+		servers = append(servers, pinMCPServerImage(name, image, data))
+	}
+	slices.SortFunc(servers, func(a, b mcpServerImage) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return servers
+}
+
+// pinMCPServerImage resolves the digest pin for an MCP server image from the action cache
+// or the embedded pins. Images that already carry a digest are split into their parts.
+func pinMCPServerImage(name, image string, data *WorkflowData) mcpServerImage {
+	image = applyContainerPinMappingFromData(image, data)
+	if base, digest, found := strings.Cut(image, "@"); found {
+		return mcpServerImage{Name: name, Image: base, Digest: digest, PinnedImage: image, explicit: true}
+	}
+	if pin, ok := lookupContainerPin(image, data.ActionCache); ok && pin.PinnedImage != "" {
+		return mcpServerImage{Name: name, Image: image, Digest: pin.Digest, PinnedImage: pin.PinnedImage}
+	}
+	return mcpServerImage{Name: name, Image: image}
+}
+
+// SetContainerDigestResolver registers the callback used by --update-mcp to resolve the current
+// digest of MCP server images. When set, every MCP server image is re-resolved once per
+// compiler run and the resulting pin replaces the cached one in actions-lock.json.
+// Injected by the cli package, which owns the Docker and crane integrations.
+func (c *Compiler) SetContainerDigestResolver(fn func(ctx context.Context, image string) (string, error)) {
+	c.containerDigestResolver = fn
+}
+
+// refreshMCPImagePins re-resolves the digests of the workflow's MCP server images when a
+// container digest resolver is registered. Resolution failures are reported as warnings and
+// leave the existing pin in place.
+func (c *Compiler) refreshMCPImagePins(data *WorkflowData, markdownPath string) {
+	if c.containerDigestResolver == nil || data.ActionCache == nil {
+		return
+	}
+	if c.refreshedMCPImages == nil {
+		c.refreshedMCPImages = make(map[string]bool)
+	}
+	ctx := c.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	for _, server := range collectMCPServerImages(data) {
+		if server.explicit || c.refreshedMCPImages[server.Image] {
+			continue
+		}
+		c.refreshedMCPImages[server.Image] = true
+
+		digest, err := c.containerDigestResolver(ctx, server.Image)
+		if err != nil {
+			mcpImagePinsLog.Printf("Failed to resolve digest for %s: %v", server.Image, err)
+			fmt.Fprintln(os.Stderr, formatCompilerMessage(markdownPath, "warning",
+				fmt.Sprintf("could not refresh the pin for MCP server '%s' image %s: %v", server.Name, server.Image, err)))
+			c.IncrementWarningCount()
+			continue
+		}
+		if digest == server.Digest {
+			mcpImagePinsLog.Printf("MCP server image %s is up to date: %s", server.Image, digest)
+			continue
+		}
+		data.ActionCache.SetContainerPin(server.Image, digest, server.Image+"@"+digest)
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Pinned MCP server '%s' image %s → %s", server.Name, server.Image, digest)))
+	}
+}
+
+// writeMCPServerImagesHeader writes the MCP server images section of the lock file header.
+func writeMCPServerImagesHeader(yaml *strings.Builder, servers []mcpServerImage) {
+	if len(servers) == 0 {
+		return
+	}
+	yaml.WriteString("#\n")
+	yaml.WriteString(mcpServerImagesHeader + "\n")
+	for _, server := range servers {
+		image := server.Image + " (not pinned)"
+		if server.PinnedImage != "" {
+			image = server.PinnedImage
+		}
+		fmt.Fprintf(yaml, "#   - %s: %s\n", server.Name, image)
+	}
+}
+
+// parseMCPServerImagesHeader reads the MCP server images section back from a lock file.
+// It returns nil for lock files compiled before the section existed.
+func parseMCPServerImagesHeader(content string) []mcpServerImage {
+	_, section, found := strings.Cut(content, mcpServerImagesHeader+"\n")
+	if !found {
+		return nil
+	}
+	var servers []mcpServerImage
+	for line := range strings.SplitSeq(section, "\n") {
+		match := mcpServerImageLinePattern.FindStringSubmatch(line)
+		if match == nil {
+			break
+		}
+		server := mcpServerImage{Name: match[1], Image: match[2]}
+		if base, digest, pinned := strings.Cut(match[2], "@"); pinned {
+			server.Image, server.Digest, server.PinnedImage = base, digest, match[2]
+		}
+		servers = append(servers, server)
+	}
+	return servers
+}
+
+// verifyMCPServerPins compares the MCP server image pins of the previous lock file with the
+// ones being compiled and reports each pin that changed without an image change:
+// a different digest for the same tag, or a pin that is no longer available. Changed tags are
+// not reported since they are visible in the workflow source.
+func verifyMCPServerPins(previous, current []mcpServerImage) []mcpPinChange {
+	previousByName := make(map[string]mcpServerImage, len(previous))
+	for _, server := range previous {
+		previousByName[server.Name] = server
+	}
+	var changes []mcpPinChange
+	for _, server := range current {
+		old, ok := previousByName[server.Name]
+		if !ok || old.Image != server.Image || old.Digest == "" || old.Digest == server.Digest {
+			continue
+		}
+		if server.Digest == "" {
+			changes = append(changes, mcpPinChange{server.Name, fmt.Sprintf("MCP server '%s' image %s was pinned to %s in the lock file but has no pin in actions-lock.json. Run 'gh aw compile --update-mcp' to pin it again.", server.Name, server.Image, old.Digest)})
+			continue
+		}
+		changes = append(changes, mcpPinChange{server.Name, fmt.Sprintf("MCP server '%s' image %s changed digest from %s to %s. Review the new image, or run 'gh aw compile --update-mcp' to refresh pins intentionally.", server.Name, server.Image, old.Digest, server.Digest)})
+	}
+	return changes
+}
+
+// mcpServerField returns the frontmatter path that configures an MCP server, used to position
+// pin diagnostics.
+func mcpServerField(data *WorkflowData, name string) string {
+	if servers, ok := data.RawFrontmatter["mcp-servers"].(map[string]any); ok {
+		if _, exists := servers[name]; exists {
+			return "mcp-servers." + name
+		}
+	}
+	return "tools." + name
+}
+
+// checkMCPServerPins reports MCP server image pins that changed since the lock file was last
+// compiled. Pin changes made by --update-mcp in this run are expected and not reported.
+func (c *Compiler) checkMCPServerPins(data *WorkflowData, markdownPath, lockFile string) {
+	if c.containerDigestResolver != nil {
+		return
+	}
+	current := collectMCPServerImages(data)
+	if len(current) == 0 {
+		return
+	}
+	content, err := os.ReadFile(lockFile)
+	if err != nil {
+		return
+	}
+	for _, change := range verifyMCPServerPins(parseMCPServerImagesHeader(string(content)), current) {
+		fmt.Fprintln(os.Stderr, formatCompilerMessageForField(data, markdownPath, mcpServerField(data, change.name), "warning", change.message))
+		c.IncrementWarningCount()
+	}
+}
@@ -0,0 +1,115 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testDigestA = "sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	testDigestB = "sha256:bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+)
+
+func TestCollectMCPServerImages(t *testing.T) {
+	cache := NewActionCache(t.TempDir())
+	cache.SetContainerPin("mcp/notion:1.0", testDigestA, "mcp/notion:1.0@"+testDigestA)
+	data := &WorkflowData{
+		ActionCache: cache,
+		Tools: map[string]any{
+			"notion":  map[string]any{"container": "mcp/notion:1.0"},
+			"fetch":   map[string]any{"container": "mcp/fetch:2.0"},
+			"pinned":  map[string]any{"container": "mcp/pinned:1@" + testDigestB},
+			"bash":    []any{"echo"},
+			"remote":  map[string]any{"type": "http", "url": "https://example.com/mcp"},
+			"process": map[string]any{"command": "uvx", "args": []any{"server"}},
+		},
+	}
+
+	servers := collectMCPServerImages(data)
+	require.Len(t, servers, 4, "only containerized MCP servers should be listed")
+	assert.Equal(t, mcpServerImage{Name: "fetch", Image: "mcp/fetch:2.0"}, servers[0], "unpinned image should have no digest")
+	assert.Equal(t, mcpServerImage{Name: "notion", Image: "mcp/notion:1.0", Digest: testDigestA, PinnedImage: "mcp/notion:1.0@" + testDigestA}, servers[1], "cached pin should be applied")
+	assert.Equal(t, "mcp/pinned:1", servers[2].Image, "explicit digest should be split from the tag")
+	assert.Equal(t, testDigestB, servers[2].Digest, "explicit digest should be kept")
+	assert.True(t, servers[2].explicit, "explicit digest should be marked")
+	assert.Equal(t, "python:alpine", servers[3].Image, "auto-containerized uvx server should be listed")
+}
+
+func TestMCPServerImagesHeaderRoundTrip(t *testing.T) {
+	servers := []mcpServerImage{
+		{Name: "fetch", Image: "mcp/fetch:2.0"},
+		{Name: "notion", Image: "mcp/notion:1.0", Digest: testDigestA, PinnedImage: "mcp/notion:1.0@" + testDigestA},
+	}
+	var yaml strings.Builder
+	writeMCPServerImagesHeader(&yaml, servers)
+	assert.Equal(t, "#\n# MCP server images:\n#   - fetch: mcp/fetch:2.0 (not pinned)\n#   - notion: mcp/notion:1.0@"+testDigestA+"\n", yaml.String(), "header section should list each server")
+
+	parsed := parseMCPServerImagesHeader("# gh-aw-metadata: {}\n" + yaml.String() + "#\n# Effective stop-time: never\n")
+	assert.Equal(t, servers, parsed, "header section should parse back to the same pins")
+	assert.Nil(t, parseMCPServerImagesHeader("# no section\n"), "lock files without the section should yield no pins")
+}
+
+func TestVerifyMCPServerPins(t *testing.T) {
+	previous := []mcpServerImage{
+		{Name: "notion", Image: "mcp/notion:1.0", Digest: testDigestA},
+		{Name: "fetch", Image: "mcp/fetch:2.0", Digest: testDigestA},
+		{Name: "slack", Image: "mcp/slack:1.0", Digest: testDigestA},
+	}
+	current := []mcpServerImage{
+		{Name: "notion", Image: "mcp/notion:1.0", Digest: testDigestB},
+		{Name: "fetch", Image: "mcp/fetch:2.0"},
+		{Name: "slack", Image: "mcp/slack:2.0", Digest: testDigestB},
+		{Name: "new", Image: "mcp/new:1.0"},
+	}
+
+	changes := verifyMCPServerPins(previous, current)
+	require.Len(t, changes, 2, "only pins that changed for the same tag should be reported")
+	assert.Equal(t, "notion", changes[0].name, "digest change should be reported")
+	assert.Contains(t, changes[0].message, "changed digest", "digest change should be explained")
+	assert.Equal(t, "fetch", changes[1].name, "lost pin should be reported")
+	assert.Contains(t, changes[1].message, "--update-mcp", "lost pin should suggest refreshing pins")
+
+	assert.Empty(t, verifyMCPServerPins(previous, previous), "unchanged pins should not be reported")
+	assert.Empty(t, verifyMCPServerPins(nil, current), "lock files without pins should not be reported")
+}
+
+func TestRefreshMCPImagePins(t *testing.T) {
+	cache := NewActionCache(t.TempDir())
+	cache.SetContainerPin("mcp/notion:1.0", testDigestA, "mcp/notion:1.0@"+testDigestA)
+	data := &WorkflowData{
+		ActionCache: cache,
+		Tools: map[string]any{
+			"notion": map[string]any{"container": "mcp/notion:1.0"},
+			"broken": map[string]any{"container": "mcp/broken:1.0"},
+			"pinned": map[string]any{"container": "mcp/pinned:1@" + testDigestA},
+		},
+	}
+
+	var resolved []string
+	compiler := NewCompiler()
+	compiler.SetContainerDigestResolver(func(_ context.Context, image string) (string, error) {
+		resolved = append(resolved, image)
+		if image == "mcp/broken:1.0" {
+			return "", errors.New("registry unavailable")
+		}
+		return testDigestB, nil
+	})
+
+	compiler.refreshMCPImagePins(data, "workflow.md")
+	compiler.refreshMCPImagePins(data, "workflow.md")
+
+	assert.ElementsMatch(t, []string{"mcp/broken:1.0", "mcp/notion:1.0"}, resolved, "each image should be resolved once, explicit digests never")
+	pin, ok := cache.GetContainerPin("mcp/notion:1.0")
+	require.True(t, ok, "refreshed pin should be cached")
+	assert.Equal(t, testDigestB, pin.Digest, "refreshed pin should replace the old digest")
+	_, ok = cache.GetContainerPin("mcp/broken:1.0")
+	assert.False(t, ok, "failed resolution should not add a pin")
+	assert.Equal(t, 1, compiler.GetWarningCount(), "failed resolution should be a warning")
+}
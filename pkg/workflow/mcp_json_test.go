@@ -181,6 +181,22 @@ func TestGetMCPConfigWithAuth(t *testing.T) {
 	})
 }
 
+func TestGetMCPConfigWithTransport(t *testing.T) {
+	toolConfig := map[string]any{
+		"type":      "http",
+		"url":       "https://my-server.example.com/sse",
+		"transport": "sse",
+	}
+
+	result, err := getMCPConfig(toolConfig, "my-server")
+	if err != nil {
+		t.Fatalf("getMCPConfig() unexpected error: %v", err)
+	}
+	if result.Transport != "sse" {
+		t.Errorf("expected Transport = 'sse', got %q", result.Transport)
+	}
+}
+
 func TestHasMCPConfig(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -280,7 +296,7 @@ func TestValidateMCPConfigs(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "new format: stdio with container and network config should fail",
+			name: "new format: stdio with container and network config",
 			tools: map[string]any{
 				"network-server": map[string]any{
 					"type":      "stdio",
@@ -292,8 +308,7 @@ func TestValidateMCPConfigs(t *testing.T) {
 					"allowed": []any{"fetch", "post"},
 				},
 			},
-			wantErr: true,
-			errMsg:  "unknown property 'network'",
+			wantErr: false,
 		},
 		{
 			name: "new format: missing type and no inferrable fields",
@@ -479,19 +494,18 @@ func TestValidateMCPConfigs(t *testing.T) {
 			errMsg:  "missing required property 'url'",
 		},
 		{
-			name: "network field in tool config should fail (no longer supported)",
+			name: "network field on http server should fail",
 			tools: map[string]any{
 				"toolWithNetworkField": map[string]any{
-					"type":      "stdio",
-					"container": "mcp/fetch",
+					"type": "http",
+					"url":  "https://mcp.example.com/mcp",
 					"network": map[string]any{
 						"allowed": []any{"example.com"},
 					},
-					"allowed": []any{"tool1"},
 				},
 			},
 			wantErr: true,
-			errMsg:  "unknown property 'network'",
+			errMsg:  "not supported for HTTP servers",
 		},
 		{
 			name: "http server with valid auth config is accepted",
@@ -562,6 +576,40 @@ func TestValidateMCPConfigs(t *testing.T) {
 			wantErr: true,
 			errMsg:  "not supported",
 		},
+		{
+			name: "http with sse transport is accepted",
+			tools: map[string]any{
+				"sse-server": map[string]any{
+					"type":      "http",
+					"url":       "https://my-server.example.com/sse",
+					"transport": "sse",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "http with unsupported transport is rejected",
+			tools: map[string]any{
+				"bad-transport": map[string]any{
+					"type":      "http",
+					"url":       "https://my-server.example.com/mcp",
+					"transport": "websocket",
+				},
+			},
+			wantErr: true,
+			errMsg:  "'transport' must be one of: streamable-http, sse",
+		},
+		{
+			name: "transport on stdio server is rejected",
+			tools: map[string]any{
+				"stdio-transport": map[string]any{
+					"container": "my-registry/my-tool",
+					"transport": "sse",
+				},
+			},
+			wantErr: true,
+			errMsg:  "'transport' is only supported for HTTP servers",
+		},
 		{
 			name: "auth with empty type string is rejected",
 			tools: map[string]any{
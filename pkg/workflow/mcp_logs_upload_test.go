@@ -62,10 +62,10 @@ Please navigate to example.com and take a screenshot.
 
 	// Verify the playwright output directory is pre-created and made writable so the Docker container
 	// (which runs as a non-root user) can write screenshots to the mounted volume path
-	if !strings.Contains(lockContentStr, "mkdir -p /tmp/gh-aw/mcp-logs/playwright") {
+	if !strings.Contains(lockContentStr, "mkdir -p ${{ env.GH_AW_TMP_DIR }}/mcp-logs/playwright") {
 		t.Error("Expected 'mkdir -p /tmp/gh-aw/mcp-logs/playwright' in Start MCP Gateway step to pre-create screenshot directory")
 	}
-	if !strings.Contains(lockContentStr, "chmod 777 /tmp/gh-aw/mcp-logs/playwright") {
+	if !strings.Contains(lockContentStr, "chmod 777 ${{ env.GH_AW_TMP_DIR }}/mcp-logs/playwright") {
 		t.Error("Expected 'chmod 777 /tmp/gh-aw/mcp-logs/playwright' in Start MCP Gateway step so non-root Docker user can write screenshots")
 	}
 
@@ -80,7 +80,7 @@ Please navigate to example.com and take a screenshot.
 	}
 
 	// Verify the MCP logs path is included in the unified upload
-	if !strings.Contains(lockContentStr, "/tmp/gh-aw/mcp-logs/") {
+	if !strings.Contains(lockContentStr, "${{ env.GH_AW_TMP_DIR }}/mcp-logs/") {
 		t.Error("Expected artifact path '/tmp/gh-aw/mcp-logs/' in unified upload")
 	}
 
@@ -175,7 +175,7 @@ This workflow does not use Playwright but should still have MCP logs upload.
 		t.Error("Expected 'Upload agent artifacts' step to be present")
 	}
 
-	if !strings.Contains(lockContentStr, "/tmp/gh-aw/mcp-logs/") {
+	if !strings.Contains(lockContentStr, "${{ env.GH_AW_TMP_DIR }}/mcp-logs/") {
 		t.Error("Expected MCP logs path in unified artifact upload even when Playwright is not used")
 	}
 
@@ -190,7 +190,7 @@ This workflow does not use Playwright but should still have MCP logs upload.
 	}
 
 	// Verify the playwright output directory is NOT pre-created when playwright is not used
-	if strings.Contains(lockContentStr, "mkdir -p /tmp/gh-aw/mcp-logs/playwright") {
+	if strings.Contains(lockContentStr, "mkdir -p ${{ env.GH_AW_TMP_DIR }}/mcp-logs/playwright") {
 		t.Error("Did not expect 'mkdir -p /tmp/gh-aw/mcp-logs/playwright' in workflow without Playwright")
 	}
 }
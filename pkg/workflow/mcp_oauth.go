@@ -0,0 +1,243 @@
+package workflow
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/github/gh-aw/pkg/types"
+)
+
+var mcpOAuthLog = logger.New("workflow:mcp_oauth")
+
+// mcpAuthTypeOAuth is the auth.type of remote MCP servers that authenticate with an OAuth
+// access token obtained from a refresh token.
+const mcpAuthTypeOAuth = "oauth"
+
+// mcpOAuthStepIDPrefix prefixes the id of the token exchange step generated for each server.
+const mcpOAuthStepIDPrefix = "mcp-oauth-"
+
+var (
+	mcpOAuthStepIDSanitizer = regexp.MustCompile(`[^a-z0-9_-]+`)
+	mcpOAuthEnvVarSanitizer = regexp.MustCompile(`[^A-Z0-9_]+`)
+)
+
+// extractMCPOAuthFields populates the OAuth client configuration of an HTTP MCP server:
+//
+//	mcp-servers:
+//	  linear:
+//	    type: http
+//	    url: https://mcp.linear.app/mcp
+//	    auth:
+//	      type: oauth
+//	      token-url: https://linear.app/oauth/token
+//	      client-id: ${{ vars.LINEAR_CLIENT_ID }}
+//	      client-secret: ${{ secrets.LINEAR_CLIENT_SECRET }}  # optional
+//	      refresh-token: ${{ secrets.LINEAR_REFRESH_TOKEN }}
+//	      scopes: [read]                                      # optional
+func extractMCPOAuthFields(authConfig MapToolConfig, result *types.MCPAuthConfig) {
+	if tokenURL, ok := authConfig.GetString("token-url"); ok {
+		result.TokenURL = tokenURL
+	}
+	if clientID, ok := authConfig.GetString("client-id"); ok {
+		result.ClientID = clientID
+	}
+	if clientSecret, ok := authConfig.GetString("client-secret"); ok {
+		result.ClientSecret = clientSecret
+	}
+	if refreshToken, ok := authConfig.GetString("refresh-token"); ok {
+		result.RefreshToken = refreshToken
+	}
+	if scopes, ok := authConfig.GetStringArray("scopes"); ok {
+		result.Scopes = scopes
+	}
+}
+
+// mcpOAuthStepID returns the id of the step that exchanges the refresh token of an MCP server.
+func mcpOAuthStepID(serverName string) string {
+	name := mcpOAuthStepIDSanitizer.ReplaceAllString(strings.ToLower(serverName), "-")
+	return mcpOAuthStepIDPrefix + strings.Trim(name, "-")
+}
+
+// mcpOAuthTokenEnvVar returns the environment variable that carries the access token of an
+// MCP server into the gateway and agent steps.
+func mcpOAuthTokenEnvVar(serverName string) string {
+	name := mcpOAuthEnvVarSanitizer.ReplaceAllString(strings.ToUpper(serverName), "_")
+	return "GH_AW_MCP_OAUTH_TOKEN_" + strings.Trim(name, "_")
+}
+
+// mcpOAuthTokenExpression returns the step output expression holding the access token.
+func mcpOAuthTokenExpression(serverName string) string {
+	return fmt.Sprintf("${{ steps.%s.outputs.access_token }}", mcpOAuthStepID(serverName))
+}
+
+// applyMCPOAuthHeader sends the exchanged access token as the Authorization header of an
+// OAuth-authenticated server.
+func applyMCPOAuthHeader(toolName string, result *parser.RegistryMCPServerConfig) {
+	if result.Headers == nil {
+		result.Headers = make(map[string]string)
+	}
+	result.Headers["Authorization"] = "Bearer " + mcpOAuthTokenExpression(toolName)
+}
+
+// mcpOAuthHeaderSecrets returns the access token of an OAuth-authenticated server in the
+// same env var name -> expression form as ExtractSecretsFromMap, so the token is passed to
+// the gateway and agent through the environment like any other header secret.
+func mcpOAuthHeaderSecrets(toolName string, mcpConfig *parser.RegistryMCPServerConfig) map[string]string {
+	if mcpConfig.Auth == nil || mcpConfig.Auth.Type != mcpAuthTypeOAuth {
+		return nil
+	}
+	return map[string]string{mcpOAuthTokenEnvVar(toolName): mcpOAuthTokenExpression(toolName)}
+}
+
+// validateMCPOAuthConfig checks the auth block of a server with auth.type: oauth. The token
+// endpoint must use https, client-id and refresh-token are required, and the refresh token
+// and client secret must come from repository secrets so they never appear in the lock file.
+func validateMCPOAuthConfig(toolName string, toolConfig map[string]any, authMap map[string]any) error {
+	example := fmt.Sprintf("Example:\n\nmcp-servers:\n  %s:\n    type: http\n    url: \"https://mcp.example.com/mcp\"\n    auth:\n      type: oauth\n      token-url: \"https://auth.example.com/oauth/token\"\n      client-id: ${{ vars.EXAMPLE_CLIENT_ID }}\n      refresh-token: ${{ secrets.EXAMPLE_REFRESH_TOKEN }}\n\nSee: %s", toolName, constants.DocsToolsURL)
+	auth := MapToolConfig(authMap)
+
+	for _, field := range []string{"token-url", "client-id", "refresh-token"} {
+		if value, ok := auth.GetString(field); !ok || strings.TrimSpace(value) == "" {
+			return NewValidationError(
+				fmt.Sprintf("mcp-servers.%s.auth.%s", toolName, field),
+				fmt.Sprintf("%v", authMap[field]),
+				fmt.Sprintf("'auth.%s' is required when 'auth.type' is 'oauth'", field),
+				example,
+			)
+		}
+	}
+
+	tokenURL, _ := auth.GetString("token-url")
+	if parsed, err := url.Parse(tokenURL); err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+		return NewValidationError(
+			fmt.Sprintf("mcp-servers.%s.auth.token-url", toolName),
+			tokenURL,
+			"'auth.token-url' must be an https URL",
+			example,
+		)
+	}
+
+	for _, field := range []string{"refresh-token", "client-secret"} {
+		raw, ok := authMap[field]
+		if !ok {
+			continue
+		}
+		value, _ := raw.(string)
+		value = strings.TrimSpace(value)
+		if !strings.HasPrefix(value, "${{") || !strings.HasSuffix(value, "}}") || ExtractSecretName(value) == "" {
+			return NewValidationError(
+				fmt.Sprintf("mcp-servers.%s.auth.%s", toolName, field),
+				"(redacted)",
+				fmt.Sprintf("'auth.%s' must be a secrets expression such as ${{ secrets.NAME }}; credentials must not be written into the workflow", field),
+				example,
+			)
+		}
+	}
+
+	if scopesRaw, ok := authMap["scopes"]; ok {
+		if !isMCPOAuthScopeList(scopesRaw) {
+			return NewValidationError(
+				fmt.Sprintf("mcp-servers.%s.auth.scopes", toolName),
+				fmt.Sprintf("%v", scopesRaw),
+				"'auth.scopes' must be an array of strings",
+				example,
+			)
+		}
+	}
+
+	if headers, ok := toolConfig["headers"].(map[string]any); ok {
+		for name := range headers {
+			if strings.EqualFold(name, "Authorization") {
+				return NewValidationError(
+					fmt.Sprintf("mcp-servers.%s.headers.%s", toolName, name),
+					name,
+					"'headers.Authorization' cannot be combined with 'auth.type: oauth'; the exchanged access token is sent as the Authorization header",
+					example,
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// isMCPOAuthScopeList reports whether value is a list of non-empty strings.
+func isMCPOAuthScopeList(value any) bool {
+	if scopes, ok := value.([]string); ok {
+		return !slices.Contains(scopes, "")
+	}
+	items, ok := value.([]any)
+	if !ok {
+		return false
+	}
+	for _, item := range items {
+		if scope, isString := item.(string); !isString || scope == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// collectMCPOAuthServers returns the OAuth-authenticated HTTP MCP servers keyed by name.
+func collectMCPOAuthServers(tools map[string]any) map[string]*types.MCPAuthConfig {
+	servers := make(map[string]*types.MCPAuthConfig)
+	for toolName, toolValue := range tools {
+		toolConfig, ok := toolValue.(map[string]any)
+		if !ok {
+			continue
+		}
+		if hasMcp, mcpType := hasMCPConfig(toolConfig); !hasMcp || mcpType != "http" {
+			continue
+		}
+		mcpConfig, err := getMCPConfig(toolConfig, toolName)
+		if err != nil || mcpConfig.Auth == nil || mcpConfig.Auth.Type != mcpAuthTypeOAuth {
+			continue
+		}
+		servers[toolName] = mcpConfig.Auth
+	}
+	return servers
+}
+
+// generateMCPOAuthTokenSteps emits one step per OAuth-authenticated MCP server that exchanges
+// the refresh token for an access token. The token is exposed as a masked step output and
+// reaches the gateway through the server's Authorization header.
+func generateMCPOAuthTokenSteps(yaml *strings.Builder, tools map[string]any, workflowData *WorkflowData) {
+	servers := collectMCPOAuthServers(tools)
+	if len(servers) == 0 {
+		return
+	}
+	mcpOAuthLog.Printf("Generating OAuth token exchange steps for %d MCP servers", len(servers))
+
+	names := make([]string, 0, len(servers))
+	for name := range servers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		auth := servers[name]
+		fmt.Fprintf(yaml, "      - name: Exchange OAuth token for %s MCP server\n", name)
+		fmt.Fprintf(yaml, "        id: %s\n", mcpOAuthStepID(name))
+		fmt.Fprintf(yaml, "        uses: %s\n", getCachedActionPin("actions/github-script", workflowData))
+		yaml.WriteString("        env:\n")
+		fmt.Fprintf(yaml, "          GH_AW_MCP_OAUTH_SERVER: %s\n", quoteYAMLEnvValue(name))
+		fmt.Fprintf(yaml, "          GH_AW_MCP_OAUTH_TOKEN_URL: %s\n", quoteYAMLEnvValue(auth.TokenURL))
+		fmt.Fprintf(yaml, "          GH_AW_MCP_OAUTH_CLIENT_ID: %s\n", quoteYAMLEnvValue(auth.ClientID))
+		if auth.ClientSecret != "" {
+			fmt.Fprintf(yaml, "          GH_AW_MCP_OAUTH_CLIENT_SECRET: %s\n", auth.ClientSecret)
+		}
+		fmt.Fprintf(yaml, "          GH_AW_MCP_OAUTH_REFRESH_TOKEN: %s\n", auth.RefreshToken)
+		if len(auth.Scopes) > 0 {
+			fmt.Fprintf(yaml, "          GH_AW_MCP_OAUTH_SCOPES: %s\n", quoteYAMLEnvValue(strings.Join(auth.Scopes, " ")))
+		}
+		yaml.WriteString("        with:\n")
+		yaml.WriteString("          script: |\n")
+		yaml.WriteString(generateGitHubScriptWithRequire("mcp_oauth_token.cjs"))
+	}
+}
@@ -0,0 +1,158 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func oauthTestServer() map[string]any {
+	return map[string]any{
+		"type": "http",
+		"url":  "https://mcp.linear.app/mcp",
+		"auth": map[string]any{
+			"type":          "oauth",
+			"token-url":     "https://linear.app/oauth/token",
+			"client-id":     "${{ vars.LINEAR_CLIENT_ID }}",
+			"client-secret": "${{ secrets.LINEAR_CLIENT_SECRET }}",
+			"refresh-token": "${{ secrets.LINEAR_REFRESH_TOKEN }}",
+			"scopes":        []any{"read", "write"},
+		},
+	}
+}
+
+func TestMCPOAuthNames(t *testing.T) {
+	assert.Equal(t, "mcp-oauth-linear-app", mcpOAuthStepID("Linear.App"), "step id should be a lowercase identifier")
+	assert.Equal(t, "GH_AW_MCP_OAUTH_TOKEN_LINEAR_APP", mcpOAuthTokenEnvVar("linear-app"), "env var should be uppercase with underscores")
+	assert.Equal(t, "${{ steps.mcp-oauth-linear-app.outputs.access_token }}", mcpOAuthTokenExpression("linear-app"), "expression should reference the step output")
+}
+
+func TestGetMCPConfigOAuth(t *testing.T) {
+	mcpConfig, err := getMCPConfig(oauthTestServer(), "linear")
+	require.NoError(t, err, "oauth config should parse")
+	require.NotNil(t, mcpConfig.Auth, "auth should be extracted")
+	assert.Equal(t, "https://linear.app/oauth/token", mcpConfig.Auth.TokenURL, "token url should be extracted")
+	assert.Equal(t, "${{ vars.LINEAR_CLIENT_ID }}", mcpConfig.Auth.ClientID, "client id should be extracted")
+	assert.Equal(t, "${{ secrets.LINEAR_CLIENT_SECRET }}", mcpConfig.Auth.ClientSecret, "client secret should be extracted")
+	assert.Equal(t, "${{ secrets.LINEAR_REFRESH_TOKEN }}", mcpConfig.Auth.RefreshToken, "refresh token should be extracted")
+	assert.Equal(t, []string{"read", "write"}, mcpConfig.Auth.Scopes, "scopes should be extracted")
+	assert.Equal(t, "Bearer ${{ steps.mcp-oauth-linear.outputs.access_token }}", mcpConfig.Headers["Authorization"], "access token should be sent as the Authorization header")
+}
+
+func TestValidateMCPOAuthConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(config map[string]any)
+		wantErr string
+	}{
+		{
+			name:   "valid config",
+			mutate: func(config map[string]any) {},
+		},
+		{
+			name: "missing refresh token",
+			mutate: func(config map[string]any) {
+				delete(config["auth"].(map[string]any), "refresh-token")
+			},
+			wantErr: "'auth.refresh-token' is required",
+		},
+		{
+			name: "missing client id",
+			mutate: func(config map[string]any) {
+				delete(config["auth"].(map[string]any), "client-id")
+			},
+			wantErr: "'auth.client-id' is required",
+		},
+		{
+			name: "plain http token url",
+			mutate: func(config map[string]any) {
+				config["auth"].(map[string]any)["token-url"] = "http://linear.app/oauth/token"
+			},
+			wantErr: "must be an https URL",
+		},
+		{
+			name: "literal refresh token",
+			mutate: func(config map[string]any) {
+				config["auth"].(map[string]any)["refresh-token"] = "lin_oauth_abc123"
+			},
+			wantErr: "'auth.refresh-token' must be a secrets expression",
+		},
+		{
+			name: "client secret from vars",
+			mutate: func(config map[string]any) {
+				config["auth"].(map[string]any)["client-secret"] = "${{ vars.LINEAR_CLIENT_SECRET }}"
+			},
+			wantErr: "'auth.client-secret' must be a secrets expression",
+		},
+		{
+			name: "non-string scopes",
+			mutate: func(config map[string]any) {
+				config["auth"].(map[string]any)["scopes"] = "read write"
+			},
+			wantErr: "'auth.scopes' must be an array of strings",
+		},
+		{
+			name: "conflicting authorization header",
+			mutate: func(config map[string]any) {
+				config["headers"] = map[string]any{"authorization": "Bearer ${{ secrets.LINEAR_API_KEY }}"}
+			},
+			wantErr: "cannot be combined with 'auth.type: oauth'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := oauthTestServer()
+			tt.mutate(config)
+			err := validateMCPRequirements("linear", config, config)
+			if tt.wantErr == "" {
+				require.NoError(t, err, "valid oauth config should be accepted")
+				return
+			}
+			require.Error(t, err, "invalid oauth config should be rejected")
+			assert.Contains(t, err.Error(), tt.wantErr, "error should explain the problem")
+			assert.NotContains(t, err.Error(), "lin_oauth_abc123", "error should not echo credentials")
+		})
+	}
+}
+
+func TestGenerateMCPOAuthTokenSteps(t *testing.T) {
+	tools := map[string]any{
+		"linear": oauthTestServer(),
+		"search": map[string]any{"type": "http", "url": "https://mcp.example.com/mcp"},
+	}
+
+	var yaml strings.Builder
+	generateMCPOAuthTokenSteps(&yaml, tools, &WorkflowData{})
+	output := yaml.String()
+
+	assert.Equal(t, 1, strings.Count(output, "- name: Exchange OAuth token"), "only oauth servers should get a token exchange step")
+	assert.Contains(t, output, "id: mcp-oauth-linear\n", "step should have a stable id")
+	assert.Contains(t, output, "GH_AW_MCP_OAUTH_TOKEN_URL: 'https://linear.app/oauth/token'", "token url should be passed")
+	assert.Contains(t, output, "GH_AW_MCP_OAUTH_CLIENT_SECRET: ${{ secrets.LINEAR_CLIENT_SECRET }}", "client secret should be passed from secrets")
+	assert.Contains(t, output, "GH_AW_MCP_OAUTH_REFRESH_TOKEN: ${{ secrets.LINEAR_REFRESH_TOKEN }}", "refresh token should be passed from secrets")
+	assert.Contains(t, output, "GH_AW_MCP_OAUTH_SCOPES: 'read write'", "scopes should be space separated")
+	assert.Contains(t, output, "mcp_oauth_token.cjs", "step should run the token exchange script")
+
+	var empty strings.Builder
+	generateMCPOAuthTokenSteps(&empty, map[string]any{"search": tools["search"]}, &WorkflowData{})
+	assert.Empty(t, empty.String(), "no steps should be generated without oauth servers")
+}
+
+func TestRenderMCPOAuthServer(t *testing.T) {
+	var yaml strings.Builder
+	require.NoError(t, renderSharedMCPConfig(&yaml, "linear", oauthTestServer(), MCPConfigRenderer{Format: "json"}), "config should render")
+	output := yaml.String()
+
+	assert.Contains(t, output, `"Authorization": "Bearer \${GH_AW_MCP_OAUTH_TOKEN_LINEAR}"`, "access token should be read from the environment")
+	assert.Contains(t, output, `"GH_AW_MCP_OAUTH_TOKEN_LINEAR": "\${GH_AW_MCP_OAUTH_TOKEN_LINEAR}"`, "access token should be passed through env")
+	assert.NotContains(t, output, `"auth"`, "oauth is handled before the gateway starts")
+	assert.NotContains(t, output, "refresh", "the refresh token must not reach the gateway")
+
+	envVars := collectMCPEnvironmentVariables(map[string]any{"linear": oauthTestServer()}, []string{"linear"}, &WorkflowData{}, false)
+	assert.Equal(t, "${{ steps.mcp-oauth-linear.outputs.access_token }}", envVars["GH_AW_MCP_OAUTH_TOKEN_LINEAR"], "gateway step should receive the access token")
+}
@@ -152,16 +152,33 @@ func validateMCPRequirements(toolName string, mcpConfig map[string]any, toolConf
 					fmt.Sprintf("Example:\n\ntools:\n  %s:\n    type: http\n    url: \"https://api.example.com/mcp\"\n    auth:\n      type: github-oidc\n\nSee: %s", toolName, constants.DocsToolsURL),
 				)
 			}
-			if authTypeStr != "github-oidc" {
+			if authTypeStr == mcpAuthTypeOAuth {
+				if err := validateMCPOAuthConfig(toolName, toolConfig, authMap); err != nil {
+					return err
+				}
+			} else if authTypeStr != "github-oidc" {
 				return NewValidationError(
 					fmt.Sprintf("mcp-servers.%s.auth.type", toolName),
 					authTypeStr,
-					fmt.Sprintf("'auth.type' value %q is not supported; expected 'github-oidc'", authTypeStr),
+					fmt.Sprintf("'auth.type' value %q is not supported; expected 'github-oidc' or 'oauth'", authTypeStr),
 					fmt.Sprintf("Example:\n\ntools:\n  %s:\n    type: http\n    url: \"https://api.example.com/mcp\"\n    auth:\n      type: github-oidc\n\nSee: %s", toolName, constants.DocsToolsURL),
 				)
 			}
 		}
 
+		// Validate transport if present: must be one of the supported HTTP transports
+		if transportRaw, hasTransport := toolConfig["transport"]; hasTransport {
+			transportStr, ok := transportRaw.(string)
+			if !ok || !parser.IsMCPTransport(transportStr) {
+				return NewValidationError(
+					fmt.Sprintf("mcp-servers.%s.transport", toolName),
+					fmt.Sprintf("%v", transportRaw),
+					"'transport' must be one of: "+strings.Join(parser.ValidMCPTransports, ", "),
+					fmt.Sprintf("Example:\n\ntools:\n  %s:\n    type: http\n    url: \"https://api.example.com/sse\"\n    transport: sse\n\nSee: %s", toolName, constants.DocsToolsURL),
+				)
+			}
+		}
+
 		return validateStringProperty(toolName, "url", url, hasURL)
 
 	case "stdio":
@@ -175,6 +192,16 @@ func validateMCPRequirements(toolName string, mcpConfig map[string]any, toolConf
 			)
 		}
 
+		// stdio type does not support transport (transport selects the HTTP wire protocol)
+		if _, hasTransport := toolConfig["transport"]; hasTransport {
+			return NewValidationError(
+				fmt.Sprintf("mcp-servers.%s.transport", toolName),
+				"transport",
+				"'transport' is only supported for HTTP servers (type: 'http')",
+				fmt.Sprintf("Example:\n\ntools:\n  %s:\n    type: http\n    url: \"https://api.example.com/sse\"\n    transport: sse\n\nSee: %s", toolName, constants.DocsToolsURL),
+			)
+		}
+
 		// stdio type requires either 'command' or 'container' property (but not both)
 		command, hasCommand := mcpConfig["command"]
 		container, hasContainer := mcpConfig["container"]
@@ -225,20 +252,26 @@ func validateMCPRequirements(toolName string, mcpConfig map[string]any, toolConf
 // pkg/parser/schemas/mcp_config_schema.json. If you add or remove a property
 // from that schema, update this map accordingly.
 var mcpSchemaTopLevelFields = map[string]bool{
-	"type":           true,
-	"registry":       true,
-	"url":            true,
-	"command":        true,
-	"container":      true,
-	"args":           true,
-	"entrypoint":     true,
-	"entrypointArgs": true,
-	"mounts":         true,
-	"env":            true,
-	"headers":        true,
-	"network":        true,
-	"allowed":        true,
-	"version":        true,
+	"type":            true,
+	"registry":        true,
+	"url":             true,
+	"transport":       true,
+	"command":         true,
+	"container":       true,
+	"args":            true,
+	"entrypoint":      true,
+	"entrypointArgs":  true,
+	"mounts":          true,
+	"env":             true,
+	"headers":         true,
+	"network":         true,
+	"allowed":         true,
+	"blocked":         true,
+	"version":         true,
+	"startup-timeout": true,
+	"timeout":         true,
+	"restart":         true,
+	"max-restarts":    true,
 }
 
 // buildSchemaMCPConfig extracts only the fields defined in mcp_config_schema.json
@@ -0,0 +1,58 @@
+package workflow
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var mcpRecordingLog = logger.New("workflow:mcp_recording")
+
+// validateMCPRecordingConfig checks sandbox.mcp.record and sandbox.mcp.replay:
+//
+//	sandbox:
+//	  mcp:
+//	    record: true                                    # capture tool calls and results
+//	    replay: .github/aw/mcp-recordings/triage.jsonl  # answer tool calls from a recording
+//
+// Both are served by the MCP tool proxy inside the agent container. Recordings are written
+// to /tmp/gh-aw/mcp-logs/mcp-recording.jsonl and uploaded with the MCP logs, so they can be
+// downloaded with `gh aw audit` and committed. Replay warns because tool calls never
+// reach the real MCP servers.
+func (c *Compiler) validateMCPRecordingConfig(workflowData *WorkflowData, markdownPath string) error {
+	if workflowData == nil || workflowData.SandboxConfig == nil || workflowData.SandboxConfig.MCP == nil {
+		return nil
+	}
+	mcpConfig := workflowData.SandboxConfig.MCP
+	if !mcpConfig.Record && mcpConfig.Replay == "" {
+		return nil
+	}
+	mcpRecordingLog.Printf("Validating MCP recording config: record=%v replay=%q", mcpConfig.Record, mcpConfig.Replay)
+
+	if mcpConfig.Record && mcpConfig.Replay != "" {
+		return fmt.Errorf("sandbox.mcp.record and sandbox.mcp.replay cannot be used together: a replayed run never reaches the MCP servers, so there is nothing new to record. Record a run first, then replay the recording in later runs.\n\nSee: %s", constants.DocsSandboxURL)
+	}
+	if mcpConfig.Replay != "" && (filepath.IsAbs(mcpConfig.Replay) || !filepath.IsLocal(mcpConfig.Replay)) {
+		return fmt.Errorf("sandbox.mcp.replay: %q must be a path relative to the repository root that stays inside the repository (e.g. \".github/aw/mcp-recordings/triage.jsonl\").\n\nSee: %s", mcpConfig.Replay, constants.DocsSandboxURL)
+	}
+
+	field := "sandbox.mcp.record"
+	if mcpConfig.Replay != "" {
+		field = "sandbox.mcp.replay"
+	}
+	if err := validateMCPToolProxySandbox(workflowData, field); err != nil {
+		return err
+	}
+
+	if mcpConfig.Replay != "" {
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf(
+			"%s: MCP tool calls are replayed from %s and never reach the MCP servers; do not use this in production workflows.",
+			markdownPath, mcpConfig.Replay)))
+		c.IncrementWarningCount()
+	}
+	return nil
+}
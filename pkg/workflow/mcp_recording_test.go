@@ -0,0 +1,79 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateMCPRecordingConfig(t *testing.T) {
+	tests := []struct {
+		name         string
+		mcpConfig    *MCPGatewayRuntimeConfig
+		agent        *AgentSandboxConfig
+		wantErr      string
+		wantWarnings int
+	}{
+		{name: "record", mcpConfig: &MCPGatewayRuntimeConfig{Record: true}},
+		{name: "replay", mcpConfig: &MCPGatewayRuntimeConfig{Replay: ".github/aw/mcp-recordings/triage.jsonl"}, wantWarnings: 1},
+		{name: "record and replay", mcpConfig: &MCPGatewayRuntimeConfig{Record: true, Replay: "recording.jsonl"}, wantErr: "cannot be used together"},
+		{name: "absolute replay path", mcpConfig: &MCPGatewayRuntimeConfig{Replay: "/tmp/recording.jsonl"}, wantErr: "must be a path relative to the repository root"},
+		{name: "replay path outside repository", mcpConfig: &MCPGatewayRuntimeConfig{Replay: "../recording.jsonl"}, wantErr: "must be a path relative to the repository root"},
+		{name: "sandbox disabled", mcpConfig: &MCPGatewayRuntimeConfig{Record: true}, agent: &AgentSandboxConfig{Disabled: true}, wantErr: "sandbox.mcp.record requires the AWF agent sandbox"},
+		{name: "neither", mcpConfig: &MCPGatewayRuntimeConfig{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agent := tt.agent
+			if agent == nil {
+				agent = &AgentSandboxConfig{ID: "awf"}
+			}
+			compiler := NewCompiler()
+			err := compiler.validateMCPRecordingConfig(&WorkflowData{SandboxConfig: &SandboxConfig{Agent: agent, MCP: tt.mcpConfig}}, "workflow.md")
+			if tt.wantErr != "" {
+				require.Error(t, err, "invalid recording config should be rejected")
+				assert.Contains(t, err.Error(), tt.wantErr, "error should explain the problem")
+				return
+			}
+			require.NoError(t, err, "valid recording config should be accepted")
+			assert.Equal(t, tt.wantWarnings, compiler.GetWarningCount(), "only replay should warn")
+		})
+	}
+}
+
+func TestCompileWorkflowWithMCPReplay(t *testing.T) {
+	dir := t.TempDir()
+	workflowPath := filepath.Join(dir, "replay.md")
+	require.NoError(t, os.WriteFile(workflowPath, []byte(`---
+on: workflow_dispatch
+permissions:
+  contents: read
+  issues: read
+  pull-requests: read
+engine: copilot
+sandbox:
+  mcp:
+    replay: .github/aw/mcp-recordings/replay.jsonl
+tools:
+  github:
+---
+
+# Replay
+`), 0644), "workflow should be written")
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(workflowPath), "replay workflow should compile")
+	assert.Equal(t, 1, compiler.GetWarningCount(), "replay mode should warn")
+
+	lock, err := os.ReadFile(filepath.Join(dir, "replay.lock.yml"))
+	require.NoError(t, err, "lock file should be written")
+	content := string(lock)
+	assert.Contains(t, content, `export GH_AW_MCP_PROXY_PORT="8090"`, "converters should be pointed at the MCP tool proxy")
+	assert.Contains(t, content, "GH_AW_MCP_REPLAY=", "replay path should be passed to the proxy")
+	assert.NotContains(t, content, "GH_AW_MCP_CHAOS=", "chaos should stay off")
+}
@@ -74,7 +74,7 @@ func shouldRewriteLocalhostToDocker(workflowData *WorkflowData) bool {
 
 // noOpCacheMemoryRenderer is a no-op MCPToolRenderers.RenderCacheMemory function for engines
 // that do not need an MCP server entry for cache-memory. Cache-memory is a simple file share
-// accessible at /tmp/gh-aw/cache-memory/ and requires no MCP configuration.
+// in the job's scratch directory and requires no MCP configuration.
 func noOpCacheMemoryRenderer(_ *strings.Builder, _ bool, _ *WorkflowData) {}
 
 // renderStandardJSONMCPConfigOptions holds configuration for renderStandardJSONMCPConfig.
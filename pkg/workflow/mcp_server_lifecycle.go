@@ -0,0 +1,177 @@
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/github/gh-aw/pkg/typeutil"
+)
+
+var mcpServerLifecycleLog = logger.New("workflow:mcp_server_lifecycle")
+
+// Restart policies for stdio MCP servers, applied by the gateway when the server process exits.
+const (
+	// mcpRestartNever leaves a crashed server down; its tools fail for the rest of the run (the default).
+	mcpRestartNever = "never"
+	// mcpRestartOnFailure restarts a server that exits or stops responding, up to max-restarts times.
+	mcpRestartOnFailure = "on-failure"
+)
+
+// validMCPRestartPolicies lists the values accepted by mcp-servers.<name>.restart.
+var validMCPRestartPolicies = []string{mcpRestartNever, mcpRestartOnFailure}
+
+// defaultMCPMaxRestarts is the number of restarts allowed with restart: on-failure
+// when max-restarts is not set.
+const defaultMCPMaxRestarts = 3
+
+// extractMCPLifecycleFields populates the per-server timeouts and restart policy:
+//
+//	mcp-servers:
+//	  notion:
+//	    container: mcp/notion
+//	    startup-timeout: 20   # seconds to wait for initialize
+//	    timeout: 30           # seconds to wait for each tool call
+//	    restart: on-failure   # restart the server when it crashes or hangs
+//	    max-restarts: 2
+func extractMCPLifecycleFields(config MapToolConfig, result *parser.RegistryMCPServerConfig) {
+	if value, ok := config.GetAny("startup-timeout"); ok {
+		if seconds, ok := typeutil.ParseIntValue(value); ok {
+			result.StartupTimeout = seconds
+		}
+	}
+	if value, ok := config.GetAny("timeout"); ok {
+		if seconds, ok := typeutil.ParseIntValue(value); ok {
+			result.ToolTimeout = seconds
+		}
+	}
+	if restart, ok := config.GetString("restart"); ok {
+		result.Restart = restart
+	}
+	if value, ok := config.GetAny("max-restarts"); ok {
+		if maxRestarts, ok := typeutil.ParseIntValue(value); ok {
+			result.MaxRestarts = maxRestarts
+		}
+	}
+	if result.Restart == mcpRestartOnFailure && result.MaxRestarts == 0 {
+		result.MaxRestarts = defaultMCPMaxRestarts
+	}
+}
+
+// validateMCPServerLifecycle checks the timeouts and restart policy of an MCP server.
+// Timeouts must be positive whole seconds; restart and max-restarts only apply to stdio
+// servers, because the gateway cannot restart a remote HTTP server.
+func validateMCPServerLifecycle(toolName string, toolConfig map[string]any) error {
+	for _, field := range []string{"startup-timeout", "timeout"} {
+		value, ok := toolConfig[field]
+		if !ok {
+			continue
+		}
+		if seconds, isInt := typeutil.ParseIntValue(value); !isInt || seconds < 1 {
+			return NewValidationError(
+				fmt.Sprintf("mcp-servers.%s.%s", toolName, field),
+				fmt.Sprintf("%v", value),
+				fmt.Sprintf("'%s' must be a positive number of seconds", field),
+				fmt.Sprintf("Example:\n\nmcp-servers:\n  %s:\n    startup-timeout: 30\n    timeout: 60\n\nSee: %s", toolName, constants.DocsToolsURL),
+			)
+		}
+	}
+
+	restartRaw, hasRestart := toolConfig["restart"]
+	maxRestartsRaw, hasMaxRestarts := toolConfig["max-restarts"]
+	if !hasRestart && !hasMaxRestarts {
+		return nil
+	}
+	example := fmt.Sprintf("Example:\n\nmcp-servers:\n  %s:\n    container: \"mcp/example\"\n    restart: on-failure\n    max-restarts: 3\n\nSee: %s", toolName, constants.DocsToolsURL)
+
+	if _, mcpType := hasMCPConfig(toolConfig); mcpType == "http" {
+		field := "restart"
+		if !hasRestart {
+			field = "max-restarts"
+		}
+		return NewValidationError(
+			fmt.Sprintf("mcp-servers.%s.%s", toolName, field),
+			field,
+			fmt.Sprintf("'%s' is only supported for stdio servers; the gateway cannot restart a remote HTTP server", field),
+			example,
+		)
+	}
+
+	restart, _ := restartRaw.(string)
+	if hasRestart && !slices.Contains(validMCPRestartPolicies, restart) {
+		return NewValidationError(
+			fmt.Sprintf("mcp-servers.%s.restart", toolName),
+			fmt.Sprintf("%v", restartRaw),
+			"'restart' must be one of: "+strings.Join(validMCPRestartPolicies, ", "),
+			example,
+		)
+	}
+	if hasMaxRestarts {
+		if restart != mcpRestartOnFailure {
+			return NewValidationError(
+				fmt.Sprintf("mcp-servers.%s.max-restarts", toolName),
+				fmt.Sprintf("%v", maxRestartsRaw),
+				"'max-restarts' requires 'restart: on-failure'",
+				example,
+			)
+		}
+		if maxRestarts, isInt := typeutil.ParseIntValue(maxRestartsRaw); !isInt || maxRestarts < 1 {
+			return NewValidationError(
+				fmt.Sprintf("mcp-servers.%s.max-restarts", toolName),
+				fmt.Sprintf("%v", maxRestartsRaw),
+				"'max-restarts' must be a positive integer",
+				example,
+			)
+		}
+	}
+	return nil
+}
+
+// mcpServerTimeouts is the per-server entry of GH_AW_MCP_SERVER_TIMEOUTS.
+type mcpServerTimeouts struct {
+	Startup int `json:"startup,omitempty"`
+	Tool    int `json:"tool,omitempty"`
+}
+
+// collectMCPServerTimeouts returns the timeouts of every configured MCP server that sets
+// startup-timeout or timeout, keyed by server name.
+func collectMCPServerTimeouts(tools map[string]any) map[string]mcpServerTimeouts {
+	result := make(map[string]mcpServerTimeouts)
+	for toolName, toolValue := range tools {
+		toolConfig, ok := toolValue.(map[string]any)
+		if !ok {
+			continue
+		}
+		if hasMcp, _ := hasMCPConfig(toolConfig); !hasMcp {
+			continue
+		}
+		var lifecycle parser.RegistryMCPServerConfig
+		extractMCPLifecycleFields(MapToolConfig(toolConfig), &lifecycle)
+		if lifecycle.StartupTimeout > 0 || lifecycle.ToolTimeout > 0 {
+			result[toolName] = mcpServerTimeouts{Startup: lifecycle.StartupTimeout, Tool: lifecycle.ToolTimeout}
+		}
+	}
+	if len(result) > 0 {
+		mcpServerLifecycleLog.Printf("Collected timeouts for %d MCP server(s)", len(result))
+	}
+	return result
+}
+
+// marshalMCPServerTimeouts returns the per-server timeouts as JSON for the gateway config
+// converters (GH_AW_MCP_SERVER_TIMEOUTS), or "" when no server overrides a timeout.
+func marshalMCPServerTimeouts(tools map[string]any) string {
+	timeouts := collectMCPServerTimeouts(tools)
+	if len(timeouts) == 0 {
+		return ""
+	}
+	data, err := json.Marshal(timeouts)
+	if err != nil {
+		mcpServerLifecycleLog.Printf("Failed to marshal MCP server timeouts: %v", err)
+		return ""
+	}
+	return string(data)
+}
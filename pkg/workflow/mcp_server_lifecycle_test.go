@@ -0,0 +1,143 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractMCPLifecycleFields(t *testing.T) {
+	var config parser.RegistryMCPServerConfig
+	extractMCPLifecycleFields(MapToolConfig{
+		"startup-timeout": uint64(20),
+		"timeout":         45,
+		"restart":         "on-failure",
+	}, &config)
+	assert.Equal(t, 20, config.StartupTimeout, "startup timeout should be extracted")
+	assert.Equal(t, 45, config.ToolTimeout, "tool timeout should be extracted")
+	assert.Equal(t, "on-failure", config.Restart, "restart policy should be extracted")
+	assert.Equal(t, defaultMCPMaxRestarts, config.MaxRestarts, "max-restarts should default with restart: on-failure")
+
+	var explicit parser.RegistryMCPServerConfig
+	extractMCPLifecycleFields(MapToolConfig{"restart": "on-failure", "max-restarts": 1}, &explicit)
+	assert.Equal(t, 1, explicit.MaxRestarts, "explicit max-restarts should be kept")
+
+	var never parser.RegistryMCPServerConfig
+	extractMCPLifecycleFields(MapToolConfig{"restart": "never"}, &never)
+	assert.Zero(t, never.MaxRestarts, "max-restarts should not default without restart: on-failure")
+}
+
+func TestValidateMCPServerLifecycle(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  map[string]any
+		wantErr string
+	}{
+		{
+			name:   "no lifecycle fields",
+			config: map[string]any{"container": "mcp/notion"},
+		},
+		{
+			name:   "timeouts and restart on stdio server",
+			config: map[string]any{"container": "mcp/notion", "startup-timeout": 20, "timeout": 30, "restart": "on-failure", "max-restarts": 2},
+		},
+		{
+			name:   "timeouts on http server",
+			config: map[string]any{"type": "http", "url": "https://mcp.example.com/mcp", "timeout": 30},
+		},
+		{
+			name:    "zero timeout",
+			config:  map[string]any{"container": "mcp/notion", "timeout": 0},
+			wantErr: "'timeout' must be a positive number of seconds",
+		},
+		{
+			name:    "string startup timeout",
+			config:  map[string]any{"container": "mcp/notion", "startup-timeout": "30s"},
+			wantErr: "'startup-timeout' must be a positive number of seconds",
+		},
+		{
+			name:    "unknown restart policy",
+			config:  map[string]any{"container": "mcp/notion", "restart": "always"},
+			wantErr: "'restart' must be one of: never, on-failure",
+		},
+		{
+			name:    "restart on http server",
+			config:  map[string]any{"url": "https://mcp.example.com/mcp", "restart": "on-failure"},
+			wantErr: "only supported for stdio servers",
+		},
+		{
+			name:    "max-restarts without on-failure",
+			config:  map[string]any{"container": "mcp/notion", "restart": "never", "max-restarts": 2},
+			wantErr: "'max-restarts' requires 'restart: on-failure'",
+		},
+		{
+			name:    "non-positive max-restarts",
+			config:  map[string]any{"container": "mcp/notion", "restart": "on-failure", "max-restarts": 0},
+			wantErr: "'max-restarts' must be a positive integer",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateMCPServerLifecycle("notion", tt.config)
+			if tt.wantErr == "" {
+				require.NoError(t, err, "valid lifecycle config should be accepted")
+				return
+			}
+			require.Error(t, err, "invalid lifecycle config should be rejected")
+			assert.Contains(t, err.Error(), tt.wantErr, "error should explain the problem")
+		})
+	}
+}
+
+func TestMarshalMCPServerTimeouts(t *testing.T) {
+	tools := map[string]any{
+		"notion": map[string]any{"container": "mcp/notion", "startup-timeout": 20, "timeout": 45},
+		"search": map[string]any{"type": "http", "url": "https://mcp.example.com/mcp", "timeout": 10},
+		"fs":     map[string]any{"container": "mcp/filesystem", "restart": "on-failure"},
+		"bash":   []any{"ls"},
+	}
+	assert.JSONEq(t, `{"notion":{"startup":20,"tool":45},"search":{"tool":10}}`, marshalMCPServerTimeouts(tools), "only servers with timeouts should be collected")
+	assert.Empty(t, marshalMCPServerTimeouts(map[string]any{"fs": tools["fs"]}), "no timeouts should produce an empty string")
+}
+
+func TestRenderMCPServerLifecycle(t *testing.T) {
+	toolConfig := map[string]any{
+		"container":       "mcp/notion",
+		"startup-timeout": 20,
+		"timeout":         45,
+		"restart":         "on-failure",
+	}
+
+	t.Run("gateway json", func(t *testing.T) {
+		var yaml strings.Builder
+		require.NoError(t, renderSharedMCPConfig(&yaml, "notion", toolConfig, MCPConfigRenderer{Format: "json"}), "config should render")
+		output := yaml.String()
+		assert.Contains(t, output, `"startupTimeout": 20,`, "startup timeout should be rendered")
+		assert.Contains(t, output, `"toolTimeout": 45,`, "tool timeout should be rendered")
+		assert.Contains(t, output, `"restart": "on-failure",`, "restart policy should be rendered")
+		assert.Contains(t, output, `"maxRestarts": 3`, "default max-restarts should be rendered")
+	})
+
+	t.Run("codex toml", func(t *testing.T) {
+		var yaml strings.Builder
+		require.NoError(t, renderSharedMCPConfig(&yaml, "notion", toolConfig, MCPConfigRenderer{Format: "toml"}), "config should render")
+		output := yaml.String()
+		assert.Contains(t, output, "startup_timeout_sec = 20", "startup timeout should use the Codex key")
+		assert.Contains(t, output, "tool_timeout_sec = 45", "tool timeout should use the Codex key")
+		assert.NotContains(t, output, "restart", "restart is enforced by the gateway, not Codex")
+	})
+
+	t.Run("defaults are omitted", func(t *testing.T) {
+		var yaml strings.Builder
+		require.NoError(t, renderSharedMCPConfig(&yaml, "notion", map[string]any{"container": "mcp/notion", "restart": "never"}, MCPConfigRenderer{Format: "json"}), "config should render")
+		output := yaml.String()
+		assert.NotContains(t, output, "Timeout", "unset timeouts should not be rendered")
+		assert.NotContains(t, output, "restart", "the default restart policy should not be rendered")
+	})
+}
@@ -269,8 +269,8 @@ func generateSafeOutputsSetup(c *Compiler, yaml *strings.Builder, safeOutputConf
 	}
 	yaml.WriteString("        run: |\n")
 	yaml.WriteString("          mkdir -p \"${RUNNER_TEMP}/gh-aw/safeoutputs\"\n")
-	yaml.WriteString("          mkdir -p /tmp/gh-aw/safeoutputs\n")
-	yaml.WriteString("          mkdir -p /tmp/gh-aw/mcp-logs/safeoutputs\n")
+	yaml.WriteString("          mkdir -p " + constants.TmpGhAwDirExpr + "/safeoutputs\n")
+	yaml.WriteString("          mkdir -p " + constants.TmpGhAwDirExpr + "/mcp-logs/safeoutputs\n")
 	if workflowData.SafeOutputs != nil && workflowData.SafeOutputs.UploadArtifact != nil {
 		yaml.WriteString("          mkdir -p \"${RUNNER_TEMP}/gh-aw/safeoutputs/upload-artifacts\"\n")
 	}
@@ -518,6 +518,8 @@ func appendMCPScriptToolFile(yaml *strings.Builder, workflowData *WorkflowData,
 }
 
 func generateMCPGatewaySetup(yaml *strings.Builder, tools map[string]any, mcpTools []string, engine CodingAgentEngine, workflowData *WorkflowData, hasAgenticWorkflows bool) error {
+	generateMCPEgressProxySteps(yaml, tools, workflowData)
+	generateMCPOAuthTokenSteps(yaml, tools, workflowData)
 	yaml.WriteString("      - name: Start MCP Gateway\n")
 	yaml.WriteString("        id: start-mcp-gateway\n")
 	mcpEnvVars := collectMCPEnvironmentVariables(tools, mcpTools, workflowData, hasAgenticWorkflows)
@@ -526,8 +528,8 @@ func generateMCPGatewaySetup(yaml *strings.Builder, tools map[string]any, mcpToo
 	yaml.WriteString("          set -eo pipefail\n")
 	yaml.WriteString("          mkdir -p \"${RUNNER_TEMP}/gh-aw/mcp-config\"\n")
 	if slices.Contains(mcpTools, "playwright") {
-		yaml.WriteString("          mkdir -p /tmp/gh-aw/mcp-logs/playwright\n")
-		yaml.WriteString("          chmod 777 /tmp/gh-aw/mcp-logs/playwright\n")
+		yaml.WriteString("          mkdir -p " + constants.TmpGhAwDirExpr + "/mcp-logs/playwright\n")
+		yaml.WriteString("          chmod 777 " + constants.TmpGhAwDirExpr + "/mcp-logs/playwright\n")
 	}
 	ensureDefaultMCPGatewayConfig(workflowData)
 	gatewayConfig := workflowData.SandboxConfig.MCP
@@ -655,6 +657,10 @@ func writeMCPGatewayExports(yaml *strings.Builder, opts writeMCPGatewayExportsOp
 		hostDomain = "localhost"
 	}
 	yaml.WriteString("          export MCP_GATEWAY_HOST_DOMAIN=\"" + hostDomain + "\"\n")
+	if isMCPToolProxyEnabled(workflowData) {
+		// The config converters point agents at the in-container MCP tool proxy instead of the gateway.
+		yaml.WriteString("          export GH_AW_MCP_PROXY_PORT=\"" + strconv.Itoa(mcpToolProxyPort) + "\"\n")
+	}
 	if gatewayConfig.APIKey == "" {
 		yaml.WriteString("          MCP_GATEWAY_API_KEY=$(openssl rand -base64 45 | tr -d '/+=')\n")
 		yaml.WriteString("          echo \"::add-mask::${MCP_GATEWAY_API_KEY}\"\n")
@@ -679,6 +685,18 @@ func writeMCPGatewayExports(yaml *strings.Builder, opts writeMCPGatewayExportsOp
 			yaml.WriteString("          export GH_AW_MCP_CLI_SERVERS=" + escapedCLIServersJSON + "\n")
 		}
 	}
+	// Engines whose MCP settings support per-server tool exclusion (Gemini, Codex)
+	// read the blocked tools from GH_AW_MCP_BLOCKED_TOOLS in their config converter.
+	if workflowData != nil {
+		if blockedToolsJSON := marshalMCPBlockedTools(workflowData.Tools); blockedToolsJSON != "" {
+			yaml.WriteString("          export GH_AW_MCP_BLOCKED_TOOLS=" + shellEscapeArg(blockedToolsJSON) + "\n")
+		}
+		// Codex and Gemini also enforce per-server timeouts on the client side, read from
+		// GH_AW_MCP_SERVER_TIMEOUTS in their config converter.
+		if timeoutsJSON := marshalMCPServerTimeouts(workflowData.Tools); timeoutsJSON != "" {
+			yaml.WriteString("          export GH_AW_MCP_SERVER_TIMEOUTS=" + shellEscapeArg(timeoutsJSON) + "\n")
+		}
+	}
 	if hasGitHub && getGitHubType(githubTool) == GitHubMCPModeRemote && engine.GetID() == "copilot" {
 		yaml.WriteString("          export GITHUB_PERSONAL_ACCESS_TOKEN=\"$GITHUB_MCP_SERVER_TOKEN\"\n")
 	}
@@ -795,6 +813,7 @@ func appendMCPGatewayBaseEnvFlags(containerCmd *strings.Builder, payloadPathPref
 	containerCmd.WriteString(" -e DEBUG")
 	containerCmd.WriteString(" -e MCP_GATEWAY_LOG_DIR")
 	containerCmd.WriteString(" -e GH_AW_MCP_LOG_DIR")
+	containerCmd.WriteString(" -e GH_AW_TMP_DIR")
 	containerCmd.WriteString(" -e GH_AW_SAFE_OUTPUTS")
 	containerCmd.WriteString(" -e GH_AW_SAFE_OUTPUTS_CONFIG_PATH")
 	containerCmd.WriteString(" -e GH_AW_SAFE_OUTPUTS_TOOLS_PATH")
@@ -852,6 +871,11 @@ func appendMCPGatewayConditionalEnvFlags(containerCmd *strings.Builder, workflow
 		containerCmd.WriteString(" -e ACTIONS_ID_TOKEN_REQUEST_URL")
 		containerCmd.WriteString(" -e ACTIONS_ID_TOKEN_REQUEST_TOKEN")
 	}
+	if getNetworkProxyConfig(workflowData) != nil {
+		for _, envVar := range networkProxyEnvVars {
+			containerCmd.WriteString(" -e " + envVar)
+		}
+	}
 }
 
 func appendMCPGatewayCustomAndHTTPEnvFlags(containerCmd *strings.Builder, workflowData *WorkflowData, gatewayConfig *MCPGatewayRuntimeConfig, mcpEnvVars map[string]string, hasGitHub bool, githubTool map[string]any, tools map[string]any, engine CodingAgentEngine) {
@@ -927,6 +951,12 @@ func buildAddedGatewayEnvVarSet(workflowData *WorkflowData, gatewayConfig *MCPGa
 		addedEnvVars["ACTIONS_ID_TOKEN_REQUEST_TOKEN"] = struct {
 		}{}
 	}
+	if getNetworkProxyConfig(workflowData) != nil {
+		for _, envVar := range networkProxyEnvVars {
+			addedEnvVars[envVar] = struct {
+			}{}
+		}
+	}
 	for envVarName := range gatewayConfig.Env {
 		addedEnvVars[envVarName] = struct {
 		}{}
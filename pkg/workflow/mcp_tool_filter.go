@@ -0,0 +1,116 @@
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var mcpToolFilterLog = logger.New("workflow:mcp_tool_filter")
+
+// filterBlockedMCPTools returns the allowed tool list with every blocked tool removed.
+// A wildcard ("*") entry is kept as-is: blocked tools are then enforced by each engine's
+// deny mechanism instead of the gateway allowlist.
+func filterBlockedMCPTools(allowed []string, blocked []string) []string {
+	if len(blocked) == 0 {
+		return allowed
+	}
+	filtered := make([]string, 0, len(allowed))
+	for _, tool := range allowed {
+		if tool == "*" || !slices.Contains(blocked, tool) {
+			filtered = append(filtered, tool)
+		}
+	}
+	return filtered
+}
+
+// getMCPBlockedTools returns the tool names listed under "blocked" in an MCP server config.
+func getMCPBlockedTools(toolConfig map[string]any) []string {
+	blocked, ok := MapToolConfig(toolConfig).GetStringArray("blocked")
+	if !ok {
+		return nil
+	}
+	return blocked
+}
+
+// collectMCPBlockedTools returns the blocked tools of every configured MCP server, keyed by
+// server name. Servers without a blocked list are omitted.
+func collectMCPBlockedTools(tools map[string]any) map[string][]string {
+	result := make(map[string][]string)
+	for toolName, toolValue := range tools {
+		toolConfig, ok := toolValue.(map[string]any)
+		if !ok {
+			continue
+		}
+		if hasMcp, _ := hasMCPConfig(toolConfig); !hasMcp {
+			continue
+		}
+		if blocked := getMCPBlockedTools(toolConfig); len(blocked) > 0 {
+			result[toolName] = blocked
+		}
+	}
+	if len(result) > 0 {
+		mcpToolFilterLog.Printf("Collected blocked tools for %d MCP server(s)", len(result))
+	}
+	return result
+}
+
+// formatMCPBlockedTools formats the blocked tools of every MCP server with the given
+// engine-specific pattern (e.g. "mcp__%s__%s" for Claude), sorted for stable output.
+func formatMCPBlockedTools(tools map[string]any, pattern string) []string {
+	var formatted []string
+	for serverName, blocked := range collectMCPBlockedTools(tools) {
+		for _, tool := range blocked {
+			formatted = append(formatted, fmt.Sprintf(pattern, serverName, tool))
+		}
+	}
+	sort.Strings(formatted)
+	return formatted
+}
+
+// marshalMCPBlockedTools returns the blocked tools map as JSON for the gateway config
+// converters (GH_AW_MCP_BLOCKED_TOOLS), or "" when no server blocks any tool.
+func marshalMCPBlockedTools(tools map[string]any) string {
+	blocked := collectMCPBlockedTools(tools)
+	if len(blocked) == 0 {
+		return ""
+	}
+	data, err := json.Marshal(blocked)
+	if err != nil {
+		mcpToolFilterLog.Printf("Failed to marshal blocked tools: %v", err)
+		return ""
+	}
+	return string(data)
+}
+
+// validateMCPBlockedTools checks that the blocked list of an MCP server names concrete
+// tools and does not remove every tool from an explicit allowed list.
+func validateMCPBlockedTools(toolName string, toolConfig map[string]any) error {
+	blocked := getMCPBlockedTools(toolConfig)
+	if len(blocked) == 0 {
+		return nil
+	}
+	example := fmt.Sprintf("Example:\n\nmcp-servers:\n  %s:\n    allowed: [\"*\"]\n    blocked: [\"delete_repository\"]\n\nSee: %s", toolName, constants.DocsToolsURL)
+	if slices.Contains(blocked, "*") {
+		return NewValidationError(
+			fmt.Sprintf("mcp-servers.%s.blocked", toolName),
+			"*",
+			"'blocked' must list individual tool names; to disable the whole server, remove it from mcp-servers",
+			example,
+		)
+	}
+	allowed, hasAllowed := MapToolConfig(toolConfig).GetStringArray("allowed")
+	if hasAllowed && len(allowed) > 0 && len(filterBlockedMCPTools(allowed, blocked)) == 0 {
+		return NewValidationError(
+			fmt.Sprintf("mcp-servers.%s.blocked", toolName),
+			fmt.Sprintf("%v", blocked),
+			"'blocked' removes every tool listed in 'allowed', leaving the server with no tools",
+			example,
+		)
+	}
+	return nil
+}
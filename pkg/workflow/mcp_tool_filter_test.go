@@ -0,0 +1,127 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterBlockedMCPTools(t *testing.T) {
+	tests := []struct {
+		name     string
+		allowed  []string
+		blocked  []string
+		expected []string
+	}{
+		{
+			name:     "no blocked tools returns allowed unchanged",
+			allowed:  []string{"read_note", "write_note"},
+			expected: []string{"read_note", "write_note"},
+		},
+		{
+			name:     "blocked tool is removed",
+			allowed:  []string{"read_note", "write_note"},
+			blocked:  []string{"write_note"},
+			expected: []string{"read_note"},
+		},
+		{
+			name:     "wildcard is kept",
+			allowed:  []string{"*"},
+			blocked:  []string{"write_file"},
+			expected: []string{"*"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, filterBlockedMCPTools(tt.allowed, tt.blocked), "filtered allowed tools should match")
+		})
+	}
+}
+
+func TestCollectMCPBlockedTools(t *testing.T) {
+	tools := map[string]any{
+		"fs": map[string]any{
+			"container": "mcp/filesystem",
+			"blocked":   []any{"write_file"},
+		},
+		"notes": map[string]any{
+			"type": "http",
+			"url":  "https://mcp.example.com/mcp",
+		},
+		"bash": []any{"ls"},
+	}
+
+	assert.Equal(t, map[string][]string{"fs": {"write_file"}}, collectMCPBlockedTools(tools), "only servers with blocked tools should be collected")
+	assert.Equal(t, []string{"mcp__fs__write_file"}, formatMCPBlockedTools(tools, "mcp__%s__%s"), "blocked tools should be formatted with the engine pattern")
+	assert.JSONEq(t, `{"fs":["write_file"]}`, marshalMCPBlockedTools(tools), "blocked tools should marshal to JSON")
+	assert.Empty(t, marshalMCPBlockedTools(map[string]any{}), "no blocked tools should marshal to an empty string")
+}
+
+func TestValidateMCPBlockedTools(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  map[string]any
+		wantErr string
+	}{
+		{
+			name:   "blocked with wildcard allowed is valid",
+			config: map[string]any{"allowed": []any{"*"}, "blocked": []any{"write_file"}},
+		},
+		{
+			name:    "wildcard in blocked is rejected",
+			config:  map[string]any{"blocked": []any{"*"}},
+			wantErr: "'blocked' must list individual tool names",
+		},
+		{
+			name:    "blocking every allowed tool is rejected",
+			config:  map[string]any{"allowed": []any{"read_note"}, "blocked": []any{"read_note"}},
+			wantErr: "leaving the server with no tools",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateMCPBlockedTools("server", tt.config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err, "config should be valid")
+				return
+			}
+			require.Error(t, err, "config should be rejected")
+			assert.Contains(t, err.Error(), tt.wantErr, "error should explain the problem")
+		})
+	}
+}
+
+func TestBlockedMCPToolsEngineArguments(t *testing.T) {
+	tools := map[string]any{
+		"fs": map[string]any{
+			"container": "mcp/filesystem",
+			"allowed":   []any{"read_file", "write_file"},
+			"blocked":   []any{"write_file"},
+		},
+	}
+
+	t.Run("copilot denies blocked tools and skips them in allow list", func(t *testing.T) {
+		args := NewCopilotEngine().computeCopilotToolArguments(tools, nil, nil, nil)
+		joined := strings.Join(args, " ")
+		assert.Contains(t, joined, "--deny-tool fs(write_file)", "blocked tool should be denied")
+		assert.Contains(t, joined, "--allow-tool fs(read_file)", "allowed tool should still be allowed")
+		assert.NotContains(t, joined, "--allow-tool fs(write_file)", "blocked tool should not be allowed")
+	})
+
+	t.Run("copilot denies blocked tools with allow-all-tools", func(t *testing.T) {
+		withBashWildcard := map[string]any{"bash": []any{"*"}, "fs": tools["fs"]}
+		args := NewCopilotEngine().computeCopilotToolArguments(withBashWildcard, nil, nil, nil)
+		assert.Equal(t, []string{"--allow-all-tools", "--deny-tool", "fs(write_file)"}, args, "deny rules should follow --allow-all-tools")
+	})
+
+	t.Run("claude skips blocked tools in allowed list", func(t *testing.T) {
+		allowed := appendGenericMCPTools(nil, "fs", tools["fs"].(map[string]any))
+		assert.Equal(t, []string{"mcp__fs__read_file"}, allowed, "blocked tool should be removed from Claude allowed tools")
+	})
+}
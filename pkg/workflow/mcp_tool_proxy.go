@@ -0,0 +1,102 @@
+package workflow
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/sliceutil"
+)
+
+var mcpToolProxyLog = logger.New("workflow:mcp_tool_proxy")
+
+// mcpToolProxyPort is the port the MCP tool proxy listens on inside the agent container.
+// Agents are pointed at localhost on this port instead of the gateway while the proxy
+// is enabled.
+const mcpToolProxyPort = 8090
+
+// isMCPToolProxyEnabled reports whether the workflow needs the MCP tool proxy, which
+// sits between the agent and the MCP gateway to inject faults (sandbox.mcp.chaos) and
+// to record or replay tool calls (sandbox.mcp.record, sandbox.mcp.replay).
+func isMCPToolProxyEnabled(workflowData *WorkflowData) bool {
+	if workflowData == nil || workflowData.SandboxConfig == nil || workflowData.SandboxConfig.MCP == nil {
+		return false
+	}
+	mcpConfig := workflowData.SandboxConfig.MCP
+	return mcpConfig.Chaos != nil || mcpConfig.Record || mcpConfig.Replay != ""
+}
+
+// validateMCPToolProxySandbox rejects features that need the MCP tool proxy when the
+// agent does not run in the AWF container the proxy is started in.
+func validateMCPToolProxySandbox(workflowData *WorkflowData, field string) error {
+	if !isFirewallEnabled(workflowData) || isDockerSbxRuntime(workflowData) {
+		return fmt.Errorf("%s requires the AWF agent sandbox: the MCP tool proxy runs next to the agent inside the AWF container. Remove %s or use sandbox.agent: awf.\n\nSee: %s", field, field, constants.DocsSandboxURL)
+	}
+	return nil
+}
+
+// mcpGatewayServerNames returns the names the gateway routes MCP servers under
+// (/mcp/<name>), which are the names the MCP tool proxy sees.
+func mcpGatewayServerNames(workflowData *WorkflowData) []string {
+	names := make(map[string]struct{})
+	for _, tool := range collectMCPTools(workflowData) {
+		switch tool {
+		case "safe-outputs":
+			tool = constants.SafeOutputsMCPServerID.String()
+		case "mcp-scripts":
+			tool = constants.MCPScriptsMCPServerID.String()
+		}
+		names[tool] = struct{}{}
+	}
+	return sliceutil.SortedKeys(names)
+}
+
+// mcpToolProxyUpstreamHost returns the host under which the agent container reaches the
+// MCP gateway, which is where the MCP tool proxy forwards requests.
+func mcpToolProxyUpstreamHost(workflowData *WorkflowData) string {
+	if isAWFNetworkIsolationEnabled(workflowData) {
+		return "awmg-mcpg"
+	}
+	// The agent may run inside a chrooted host environment where host.docker.internal
+	// is not resolvable, so use the AWF network gateway IP (as mount_mcp_as_cli.cjs does).
+	return "172.30.0.1"
+}
+
+// getMCPToolProxySetup returns the shell command that starts the MCP tool proxy inside
+// the agent container, to be chained with && before the engine command. The proxy is
+// stopped by an EXIT trap when the engine command finishes. Returns an empty string
+// when no feature needs the proxy.
+func getMCPToolProxySetup(workflowData *WorkflowData) string {
+	if !isMCPToolProxyEnabled(workflowData) {
+		return ""
+	}
+	mcpConfig := workflowData.SandboxConfig.MCP
+
+	var exports []string
+	if mcpConfig.Chaos != nil {
+		configJSON, err := mcpConfig.Chaos.proxyConfigJSON()
+		if err != nil {
+			mcpToolProxyLog.Printf("Skipping MCP chaos configuration: %v", err)
+		} else {
+			exports = append(exports, "GH_AW_MCP_CHAOS="+shellEscapeArg(configJSON))
+		}
+	}
+	if mcpConfig.Record {
+		exports = append(exports, "GH_AW_MCP_RECORD=true")
+	}
+	if mcpConfig.Replay != "" {
+		exports = append(exports, "GH_AW_MCP_REPLAY="+shellEscapeArg(mcpConfig.Replay))
+	}
+
+	gatewayPort := mcpConfig.Port
+	if gatewayPort == 0 {
+		gatewayPort = int(DefaultMCPGatewayPort)
+	}
+	upstream := fmt.Sprintf("http://%s:%d", mcpToolProxyUpstreamHost(workflowData), gatewayPort)
+	exports = append(exports, fmt.Sprintf("GH_AW_MCP_PROXY_PORT=%d", mcpToolProxyPort), "GH_AW_MCP_PROXY_UPSTREAM="+upstream)
+
+	mcpToolProxyLog.Printf("Starting MCP tool proxy on port %d in front of %s", mcpToolProxyPort, upstream)
+	return fmt.Sprintf(`%s && export %s && source "${RUNNER_TEMP}/gh-aw/actions/start_mcp_tool_proxy.sh"`,
+		GetNpmBinPathSetup(), strings.Join(exports, " "))
+}
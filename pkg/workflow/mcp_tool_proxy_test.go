@@ -0,0 +1,37 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMCPToolProxySetup(t *testing.T) {
+	data := &WorkflowData{SandboxConfig: &SandboxConfig{
+		Agent: &AgentSandboxConfig{ID: "awf", NetworkIsolation: true},
+		MCP:   &MCPGatewayRuntimeConfig{Chaos: &MCPChaosConfig{Seed: 7, ErrorRate: 0.5}},
+	}}
+	setup := getMCPToolProxySetup(data)
+	assert.Contains(t, setup, `GH_AW_MCP_CHAOS='{"error_rate":0.5,"seed":7,"servers":[],"timeout_ms":30000,"timeout_rate":0,"truncate_rate":0}'`, "chaos config should be passed as quoted JSON")
+	assert.Contains(t, setup, "GH_AW_MCP_PROXY_PORT=8090", "proxy port should be passed")
+	assert.Contains(t, setup, "GH_AW_MCP_PROXY_UPSTREAM=http://awmg-mcpg:8080", "network isolation should reach the gateway by container name")
+	assert.Contains(t, setup, `source "${RUNNER_TEMP}/gh-aw/actions/start_mcp_tool_proxy.sh"`, "startup script should be sourced")
+	assert.NotContains(t, setup, "GH_AW_MCP_RECORD", "recording should be off unless requested")
+
+	data.SandboxConfig.Agent.NetworkIsolation = false
+	data.SandboxConfig.MCP.Port = 9000
+	assert.Contains(t, getMCPToolProxySetup(data), "GH_AW_MCP_PROXY_UPSTREAM=http://172.30.0.1:9000", "host gateway should be reached through the AWF gateway IP")
+
+	recordOnly := &WorkflowData{SandboxConfig: &SandboxConfig{MCP: &MCPGatewayRuntimeConfig{Record: true}}}
+	setup = getMCPToolProxySetup(recordOnly)
+	assert.Contains(t, setup, "GH_AW_MCP_RECORD=true", "record flag should be passed")
+	assert.NotContains(t, setup, "GH_AW_MCP_CHAOS=", "chaos should be off unless configured")
+
+	replay := &WorkflowData{SandboxConfig: &SandboxConfig{MCP: &MCPGatewayRuntimeConfig{Replay: ".github/aw/mcp-recordings/triage.jsonl"}}}
+	assert.Contains(t, getMCPToolProxySetup(replay), "GH_AW_MCP_REPLAY=.github/aw/mcp-recordings/triage.jsonl", "replay path should be passed")
+
+	assert.Empty(t, getMCPToolProxySetup(&WorkflowData{}), "no setup without proxy features")
+	assert.False(t, isMCPToolProxyEnabled(&WorkflowData{SandboxConfig: &SandboxConfig{MCP: &MCPGatewayRuntimeConfig{Port: 8080}}}), "plain gateway config should not enable the proxy")
+}
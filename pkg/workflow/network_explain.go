@@ -0,0 +1,89 @@
+// This file explains how the network allow-list of a workflow is built.
+//
+// network.allowed accepts domains and named ecosystem bundles (defaults, node,
+// python, go, ...) that expand to the curated domain lists in
+// data/ecosystem_domains.json. ExplainNetworkPermissions reports the expansion of
+// each entry together with the domains the engine, tools and runtimes add, so
+// `gh aw compile --explain-network` can print the final allow-list for review.
+
+package workflow
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/sliceutil"
+)
+
+var networkExplainLog = logger.New("workflow:network_explain")
+
+// NetworkAllowedEntry is the expansion of one network.allowed entry.
+type NetworkAllowedEntry struct {
+	Entry     string   `json:"entry"`
+	Ecosystem bool     `json:"ecosystem"`
+	Domains   []string `json:"domains"`
+}
+
+// NetworkExplanation describes how the network allow-list of a workflow was built.
+type NetworkExplanation struct {
+	Implicit  bool                  `json:"implicit"`          // network was not configured, so the defaults bundle applies
+	Allowed   []NetworkAllowedEntry `json:"allowed"`           // network.allowed entries in frontmatter order
+	Added     []string              `json:"added,omitempty"`   // domains added by the engine, tools and runtimes
+	Blocked   []string              `json:"blocked,omitempty"` // network.blocked domains, which take precedence
+	Effective []string              `json:"effective"`         // final allow-list passed to the firewall
+}
+
+// ExplainNetworkPermissions explains the network allow-list of a compiled workflow.
+func ExplainNetworkPermissions(data *WorkflowData) *NetworkExplanation {
+	network := data.NetworkPermissions
+	explanation := &NetworkExplanation{Allowed: []NetworkAllowedEntry{}}
+
+	entries := []string{"defaults"}
+	if network != nil {
+		entries = network.Allowed
+	}
+	explanation.Implicit = network == nil || !network.ExplicitlyDefined
+
+	requested := make(map[string]struct{})
+	for _, entry := range entries {
+		expansion := NetworkAllowedEntry{Entry: entry, Domains: getEcosystemDomains(entry)}
+		if len(expansion.Domains) > 0 {
+			expansion.Ecosystem = true
+		} else {
+			expansion.Domains = []string{entry}
+		}
+		for _, domain := range expansion.Domains {
+			requested[domain] = struct{}{}
+		}
+		explanation.Allowed = append(explanation.Allowed, expansion)
+	}
+
+	// The compiler caches the final allow-list it passes to the firewall, including
+	// engine, tool and runtime domains. Fall back to network.allowed alone otherwise.
+	if data.CachedAllowedDomainsComputed {
+		for domain := range strings.SplitSeq(data.CachedAllowedDomainsStr, ",") {
+			if domain = strings.TrimSpace(domain); domain != "" {
+				explanation.Effective = append(explanation.Effective, domain)
+			}
+		}
+		slices.Sort(explanation.Effective)
+		explanation.Effective = slices.Compact(explanation.Effective)
+	} else {
+		explanation.Effective = sliceutil.SortedKeys(requested)
+	}
+	if explanation.Effective == nil {
+		explanation.Effective = []string{}
+	}
+
+	for _, domain := range explanation.Effective {
+		if _, ok := requested[domain]; !ok {
+			explanation.Added = append(explanation.Added, domain)
+		}
+	}
+	explanation.Blocked = GetBlockedDomains(network)
+
+	networkExplainLog.Printf("Explained network for %s: %d entries, %d added, %d effective domains",
+		data.WorkflowID, len(explanation.Allowed), len(explanation.Added), len(explanation.Effective))
+	return explanation
+}
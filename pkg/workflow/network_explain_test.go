@@ -0,0 +1,62 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplainNetworkPermissions(t *testing.T) {
+	t.Run("ecosystem bundles expand to their curated domains", func(t *testing.T) {
+		data := &WorkflowData{NetworkPermissions: &NetworkPermissions{
+			Allowed:           []string{"go", "example.com"},
+			Blocked:           []string{"goproxy.io"},
+			ExplicitlyDefined: true,
+		}}
+
+		explanation := ExplainNetworkPermissions(data)
+
+		assert.False(t, explanation.Implicit, "explicit network should not be implicit")
+		require.Len(t, explanation.Allowed, 2, "each allowed entry should be explained")
+		assert.Equal(t, "go", explanation.Allowed[0].Entry, "entries should keep frontmatter order")
+		assert.True(t, explanation.Allowed[0].Ecosystem, "go should be recognized as an ecosystem")
+		assert.Contains(t, explanation.Allowed[0].Domains, "proxy.golang.org", "go bundle should include the module proxy")
+		assert.Equal(t, NetworkAllowedEntry{Entry: "example.com", Domains: []string{"example.com"}}, explanation.Allowed[1], "plain domains should be kept as-is")
+		assert.Equal(t, []string{"goproxy.io"}, explanation.Blocked, "blocked domains should be reported")
+		assert.Empty(t, explanation.Added, "nothing is added without a compiled allow-list")
+		assert.Contains(t, explanation.Effective, "example.com", "effective list should include plain domains")
+		assert.Contains(t, explanation.Effective, "sum.golang.org", "effective list should include bundle domains")
+	})
+
+	t.Run("compiled allow-list reports domains added by the engine", func(t *testing.T) {
+		data := &WorkflowData{
+			NetworkPermissions:           &NetworkPermissions{Allowed: []string{"example.com"}, ExplicitlyDefined: true},
+			CachedAllowedDomainsComputed: true,
+			CachedAllowedDomainsStr:      "example.com,api.githubcopilot.com,example.com",
+		}
+
+		explanation := ExplainNetworkPermissions(data)
+
+		assert.Equal(t, []string{"api.githubcopilot.com", "example.com"}, explanation.Effective, "effective list should be sorted and deduplicated")
+		assert.Equal(t, []string{"api.githubcopilot.com"}, explanation.Added, "engine domains should be reported as added")
+	})
+
+	t.Run("missing network uses the defaults bundle", func(t *testing.T) {
+		explanation := ExplainNetworkPermissions(&WorkflowData{})
+
+		assert.True(t, explanation.Implicit, "missing network should be implicit")
+		require.Len(t, explanation.Allowed, 1, "defaults should be the only entry")
+		assert.Equal(t, "defaults", explanation.Allowed[0].Entry, "defaults bundle should apply")
+		assert.True(t, explanation.Allowed[0].Ecosystem, "defaults should be an ecosystem")
+	})
+
+	t.Run("empty allowed list denies all access", func(t *testing.T) {
+		explanation := ExplainNetworkPermissions(&WorkflowData{NetworkPermissions: &NetworkPermissions{Allowed: []string{}, ExplicitlyDefined: true}})
+
+		assert.Empty(t, explanation.Allowed, "no entries should be explained")
+		assert.Empty(t, explanation.Effective, "no domains should be allowed")
+	})
+}
@@ -0,0 +1,105 @@
+package workflow
+
+import (
+	"maps"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var networkProxyLog = logger.New("workflow:network_proxy")
+
+// networkProxyLocalHosts are always appended to NO_PROXY so that traffic to the MCP
+// gateway, the mcp-scripts server, and other runner-local endpoints never leaves the host.
+var networkProxyLocalHosts = []string{"localhost", "127.0.0.1", "::1", "host.docker.internal"}
+
+// networkProxyEnvVars lists the environment variables exported for network.proxy, in the
+// order they are forwarded to the MCP gateway container.
+var networkProxyEnvVars = []string{"HTTPS_PROXY", "HTTP_PROXY", "NO_PROXY"}
+
+// NetworkProxyConfig is the corporate proxy configured via network.proxy.
+//
+//	network:
+//	  proxy:
+//	    url: http://proxy.corp.example.com:3128
+//	    no-proxy: [.corp.example.com]
+type NetworkProxyConfig struct {
+	URL     string   `yaml:"url" json:"url"`
+	NoProxy []string `yaml:"no-proxy,omitempty" json:"no-proxy,omitempty"`
+}
+
+// parseNetworkProxyConfig extracts network.proxy from the network frontmatter object.
+// Returns nil when the proxy is not configured or has no URL.
+func parseNetworkProxyConfig(networkObj map[string]any) *NetworkProxyConfig {
+	proxyObj, ok := networkObj["proxy"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	proxyURL, _ := proxyObj["url"].(string)
+	proxyURL = strings.TrimSpace(proxyURL)
+	if proxyURL == "" {
+		return nil
+	}
+	config := &NetworkProxyConfig{URL: proxyURL}
+	if noProxy, ok := proxyObj["no-proxy"].([]any); ok {
+		for _, host := range noProxy {
+			if hostStr, ok := host.(string); ok && strings.TrimSpace(hostStr) != "" {
+				config.NoProxy = append(config.NoProxy, strings.TrimSpace(hostStr))
+			}
+		}
+	}
+	networkProxyLog.Printf("Parsed network proxy: no-proxy entries=%d", len(config.NoProxy))
+	return config
+}
+
+// noProxyValue returns the NO_PROXY value: the configured bypass hosts followed by the
+// runner-local hosts, without duplicates.
+func (p *NetworkProxyConfig) noProxyValue() string {
+	seen := make(map[string]bool)
+	var hosts []string
+	for _, host := range append(append([]string{}, p.NoProxy...), networkProxyLocalHosts...) {
+		if !seen[host] {
+			seen[host] = true
+			hosts = append(hosts, host)
+		}
+	}
+	return strings.Join(hosts, ",")
+}
+
+// getNetworkProxyConfig returns the proxy configured for the workflow, or nil.
+func getNetworkProxyConfig(data *WorkflowData) *NetworkProxyConfig {
+	if data == nil || data.NetworkPermissions == nil {
+		return nil
+	}
+	return data.NetworkPermissions.Proxy
+}
+
+// buildNetworkProxyEnv returns the job-level proxy environment, or nil when no proxy is
+// configured. Only the upper-case variables are set because the GitHub
+// Actions parser rejects env keys that differ only by case; most tools honor both forms.
+func buildNetworkProxyEnv(data *WorkflowData) map[string]string {
+	proxy := getNetworkProxyConfig(data)
+	if proxy == nil {
+		return nil
+	}
+	return map[string]string{
+		"HTTPS_PROXY": proxy.URL,
+		"HTTP_PROXY":  proxy.URL,
+		"NO_PROXY":    proxy.noProxyValue(),
+	}
+}
+
+// withNetworkProxyEnv adds the proxy environment (network.proxy) to a job-level env map.
+// Every job that runs on the runner needs it: setup, artifact, and GitHub API traffic of
+// the activation, detection, safe-outputs, and conclusion jobs also goes through the proxy.
+func withNetworkProxyEnv(env map[string]string, data *WorkflowData) map[string]string {
+	proxyEnv := buildNetworkProxyEnv(data)
+	if proxyEnv == nil {
+		return env
+	}
+	if env == nil {
+		env = make(map[string]string, len(proxyEnv))
+	}
+	maps.Copy(env, proxyEnv)
+	return env
+}
@@ -0,0 +1,124 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/github/gh-aw/pkg/testutil"
+)
+
+func TestParseNetworkProxyConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		network  map[string]any
+		expected *NetworkProxyConfig
+	}{
+		{
+			name:    "no proxy",
+			network: map[string]any{"allowed": []any{"defaults"}},
+		},
+		{
+			name:    "empty url is ignored",
+			network: map[string]any{"proxy": map[string]any{"url": "  "}},
+		},
+		{
+			name: "url and no-proxy",
+			network: map[string]any{"proxy": map[string]any{
+				"url":      "http://proxy.corp.example.com:3128",
+				"no-proxy": []any{".corp.example.com", ""},
+			}},
+			expected: &NetworkProxyConfig{
+				URL:     "http://proxy.corp.example.com:3128",
+				NoProxy: []string{".corp.example.com"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parseNetworkProxyConfig(tt.network), "parsed proxy config should match")
+		})
+	}
+}
+
+func TestBuildNetworkProxyEnv(t *testing.T) {
+	assert.Nil(t, buildNetworkProxyEnv(&WorkflowData{}), "no env without network.proxy")
+
+	data := &WorkflowData{NetworkPermissions: &NetworkPermissions{Proxy: &NetworkProxyConfig{
+		URL:     "http://proxy:3128",
+		NoProxy: []string{".corp.example.com", "localhost"},
+	}}}
+	env := buildNetworkProxyEnv(data)
+	assert.Equal(t, "http://proxy:3128", env["HTTPS_PROXY"], "HTTPS_PROXY should be the proxy URL")
+	assert.Equal(t, "http://proxy:3128", env["HTTP_PROXY"], "HTTP_PROXY should be the proxy URL")
+	assert.Equal(t, ".corp.example.com,localhost,127.0.0.1,::1,host.docker.internal", env["NO_PROXY"], "NO_PROXY should add local hosts without duplicates")
+}
+
+func TestNetworkProxyCompiledWorkflow(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "network-proxy-test")
+	workflowPath := filepath.Join(tmpDir, "proxy.md")
+	content := `---
+on: issues
+permissions:
+  contents: read
+engine: copilot
+network:
+  allowed: [defaults]
+  proxy:
+    url: http://proxy.corp.example.com:3128
+    no-proxy: [.corp.example.com]
+safe-outputs:
+  add-comment:
+---
+
+# Proxy
+
+Summarize the issue.
+`
+	require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0o644), "should write workflow")
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(workflowPath), "workflow should compile")
+
+	lockContent, err := os.ReadFile(filepath.Join(tmpDir, "proxy.lock.yml"))
+	require.NoError(t, err, "should read lock file")
+	lock := string(lockContent)
+
+	assert.Contains(t, lock, "HTTPS_PROXY: http://proxy.corp.example.com:3128", "agent job should export HTTPS_PROXY")
+	assert.Contains(t, lock, "NO_PROXY: .corp.example.com,localhost,127.0.0.1,::1,host.docker.internal", "agent job should export NO_PROXY")
+	assert.True(t, strings.Contains(lock, "-e HTTPS_PROXY -e HTTP_PROXY -e NO_PROXY"), "gateway container should receive the proxy env")
+
+	var workflow map[string]any
+	require.NoError(t, yaml.Unmarshal(lockContent, &workflow), "lock file should be valid YAML")
+	jobs, ok := workflow["jobs"].(map[string]any)
+	require.True(t, ok, "jobs should be a map")
+	for _, jobName := range []string{"activation", "agent", "detection", "safe_outputs", "conclusion"} {
+		job, ok := jobs[jobName].(map[string]any)
+		require.True(t, ok, "%s job should exist", jobName)
+		env, ok := job["env"].(map[string]any)
+		require.True(t, ok, "%s job should have an env section", jobName)
+		assert.Equal(t, "http://proxy.corp.example.com:3128", env["HTTPS_PROXY"], "%s job should export HTTPS_PROXY", jobName)
+		assert.Equal(t, "http://proxy.corp.example.com:3128", env["HTTP_PROXY"], "%s job should export HTTP_PROXY", jobName)
+		assert.Contains(t, env["NO_PROXY"], "localhost", "%s job should export NO_PROXY", jobName)
+	}
+	assert.Contains(t, lock, `\"upstreamProxy\":\"http://proxy.corp.example.com:3128\"`, "AWF should chain its proxy to the corporate proxy")
+}
+
+func TestWithNetworkProxyEnv(t *testing.T) {
+	data := &WorkflowData{NetworkPermissions: &NetworkPermissions{Proxy: &NetworkProxyConfig{URL: "http://proxy:3128"}}}
+
+	env := withNetworkProxyEnv(map[string]string{"GH_AW_X": "1"}, data)
+	assert.Equal(t, "1", env["GH_AW_X"], "existing env should be kept")
+	assert.Equal(t, "http://proxy:3128", env["HTTPS_PROXY"], "proxy env should be added")
+
+	assert.Equal(t, "http://proxy:3128", withNetworkProxyEnv(nil, data)["HTTP_PROXY"], "nil env should be allocated")
+	assert.Nil(t, withNetworkProxyEnv(nil, &WorkflowData{}), "env should stay nil without network.proxy")
+}
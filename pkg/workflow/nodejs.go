@@ -178,15 +178,7 @@ func BuildNpmEngineInstallStepsWithAWF(npmSteps []GitHubActionStep, workflowData
 	// On ARC/DinD, the AWF command references ${RUNNER_TEMP}/gh-aw/bin/copilot which is
 	// daemon-visible, so we copy from wherever the install script placed it.
 	if isFirewallEnabled(workflowData) && isArcDindTopology(workflowData) {
-		copyStep := GitHubActionStep([]string{
-			"      - name: Copy Copilot CLI to daemon-visible path",
-			"        run: |",
-			"          mkdir -p \"${RUNNER_TEMP}/gh-aw/bin\"",
-			`          COPILOT_SRC="$(command -v copilot)"`,
-			"          cp \"$COPILOT_SRC\" \"${RUNNER_TEMP}/gh-aw/bin/copilot\"",
-			"          chmod +x \"${RUNNER_TEMP}/gh-aw/bin/copilot\"",
-		})
-		steps = append(steps, copyStep)
+		steps = append(steps, generateCopilotCLICopyStep("Copy Copilot CLI to daemon-visible path"))
 	}
 
 	return steps
@@ -227,7 +219,7 @@ func GetNpmBinPathSetup() string {
 }
 
 // GenerateDockerSbxNpmCLIInstallStep installs an npm CLI into a runner path that is
-// visible inside the docker-sbx microVM, then creates a stable bin/ symlink from
+// visible inside the docker-sbx microVM or devcontainer, then creates a stable bin/ symlink from
 // ${RUNNER_TEMP}/gh-aw/engine-cli/bin/<command> to the package's node_modules/.bin entry.
 func GenerateDockerSbxNpmCLIInstallStep(packageName, version, stepName, commandName string, runInstallScripts bool, cooldownEnabled bool) GitHubActionStep {
 	ignoreScriptsFlag := "--ignore-scripts "
@@ -269,9 +261,10 @@ func GenerateDockerSbxNpmCLIInstallStep(packageName, version, stepName, commandN
 }
 
 // GetDockerSbxNpmCLIPathSetup returns the PATH export needed for npm CLIs that were
-// staged into ${RUNNER_TEMP}/gh-aw/engine-cli/bin for docker-sbx microVM runs.
+// staged into ${RUNNER_TEMP}/gh-aw/engine-cli/bin for docker-sbx microVM and
+// devcontainer runs.
 func GetDockerSbxNpmCLIPathSetup(workflowData *WorkflowData) string {
-	if !isDockerSbxRuntime(workflowData) {
+	if !isDockerSbxRuntime(workflowData) && !isDevcontainerRuntime(workflowData) {
 		return ""
 	}
 	return `export PATH="${RUNNER_TEMP}/gh-aw/engine-cli/bin:$PATH"`
@@ -16,7 +16,9 @@ var notifyCommentLog = logger.New("workflow:notify_comment")
 // - Updating status comments (if status-comment: true)
 // - Processing noop messages
 // - Handling agent failures
+// - Posting failure comments on the triggering item (if report-failure-as-comment is enabled)
 // - Recording missing tools
+// - Checking latency SLOs (if observability.slo is set)
 // This job runs when:
 // 1. always() - runs even if agent fails
 // 2. Agent job was not skipped
@@ -35,6 +37,7 @@ func (c *Compiler) buildConclusionJob(data *WorkflowData, mainJobName string, sa
 	steps = append(steps, c.buildConclusionDetectionRunsStep(data, mainJobName)...)
 	steps = append(steps, c.buildConclusionMissingToolStep(data, mainJobName)...)
 	steps = append(steps, c.buildConclusionReportIncompleteStep(data, mainJobName)...)
+	steps = append(steps, c.buildConclusionLatencySLOStep(data, mainJobName)...)
 	messagesJSON := serializeConclusionMessagesJSON(data)
 	agentFailureSteps, err := c.buildAgentFailureStep(data, mainJobName, messagesJSON)
 	if err != nil {
@@ -58,6 +61,11 @@ func (c *Compiler) buildConclusionJob(data *WorkflowData, mainJobName string, sa
 			CustomToken:   token,
 		})...)
 	}
+	failureCommentSteps, err := c.buildConclusionFailureCommentStep(data, mainJobName, customEnvVars, token)
+	if err != nil {
+		return nil, err
+	}
+	steps = append(steps, failureCommentSteps...)
 	if c.actionMode.IsScript() {
 		steps = append(steps, c.generateScriptModeCleanupStep())
 	}
@@ -87,6 +95,9 @@ func (c *Compiler) buildConclusionJob(data *WorkflowData, mainJobName string, sa
 	if needsDailyAICCachePermission(data) && !conclusionPerms.HasAnyWriteScope() {
 		conclusionPerms.Set(PermissionActions, PermissionWrite)
 	}
+	// observability.slo lists the jobs of the run and may report breaches on an issue.
+	applyLatencySLOPermissions(data, conclusionPerms)
+	applyFailureCommentPermissions(data, conclusionPerms)
 	return &Job{
 		Name:        "conclusion",
 		If:          RenderCondition(buildConclusionJobCondition(data, mainJobName, safeOutputJobNames)),
@@ -94,6 +105,7 @@ func (c *Compiler) buildConclusionJob(data *WorkflowData, mainJobName string, sa
 		Environment: c.indentYAMLLines(resolveSafeOutputsEnvironment(data), "    "),
 		Permissions: conclusionPerms.RenderToYAML(),
 		Concurrency: c.buildConclusionJobConcurrency(data),
+		Env:         withNetworkProxyEnv(nil, data),
 		Steps:       steps,
 		Needs:       needs,
 		Outputs:     buildConclusionJobOutputs(data),
@@ -116,7 +128,7 @@ func buildUsageArtifactUploadSteps(prefix string, hasEvals bool, pinAction func(
 		fmt.Sprintf("        uses: %s\n", pinAction("actions/download-artifact")),
 		"        with:\n",
 		fmt.Sprintf("          name: %s\n", safeOutputsItemsArtifactName),
-		"          path: /tmp/gh-aw/\n",
+		"          path: " + constants.TmpGhAwDirExpr + "/\n",
 	}
 	if hasEvals {
 		evalsArtifactName := prefix + constants.EvalsArtifactName
@@ -128,7 +140,7 @@ func buildUsageArtifactUploadSteps(prefix string, hasEvals bool, pinAction func(
 			fmt.Sprintf("        uses: %s\n", pinAction("actions/download-artifact")),
 			"        with:\n",
 			fmt.Sprintf("          name: %s\n", evalsArtifactName),
-			"          path: /tmp/gh-aw/evals/\n",
+			"          path: "+constants.TmpGhAwDirExpr+"/evals/\n",
 		)
 	}
 	steps = append(steps,
@@ -136,34 +148,34 @@ func buildUsageArtifactUploadSteps(prefix string, hasEvals bool, pinAction func(
 		"        if: always()\n",
 		"        continue-on-error: true\n",
 		"        run: |\n",
-		"          mkdir -p /tmp/gh-aw/usage/agent /tmp/gh-aw/usage/detection\n",
+		"          mkdir -p "+constants.TmpGhAwDirExpr+"/usage/agent "+constants.TmpGhAwDirExpr+"/usage/detection\n",
 		"          echo \"Usage artifact source file status:\"\n",
-		"          for file in /tmp/gh-aw/aw_info.json /tmp/gh-aw/aw-info.jsonl /tmp/gh-aw/agent_usage.json /tmp/gh-aw/agent_usage.jsonl /tmp/gh-aw/detection_usage.jsonl /tmp/gh-aw/evals/evals.jsonl /tmp/gh-aw/github_rate_limits.jsonl /tmp/gh-aw/sandbox/firewall-audit-logs/api-proxy-logs/token-usage.jsonl /tmp/gh-aw/sandbox/firewall/logs/api-proxy-logs/token-usage.jsonl /tmp/gh-aw/sandbox/firewall/audit/api-proxy-logs/token-usage.jsonl /tmp/gh-aw/threat-detection/sandbox/firewall-audit-logs/api-proxy-logs/token-usage.jsonl /tmp/gh-aw/threat-detection/sandbox/firewall/logs/api-proxy-logs/token-usage.jsonl /tmp/gh-aw/threat-detection/sandbox/firewall/audit/api-proxy-logs/token-usage.jsonl; do\n",
+		"          for file in "+constants.TmpGhAwDirExpr+"/aw_info.json "+constants.TmpGhAwDirExpr+"/aw-info.jsonl "+constants.TmpGhAwDirExpr+"/agent_usage.json "+constants.TmpGhAwDirExpr+"/agent_usage.jsonl "+constants.TmpGhAwDirExpr+"/detection_usage.jsonl "+constants.TmpGhAwDirExpr+"/evals/evals.jsonl "+constants.TmpGhAwDirExpr+"/github_rate_limits.jsonl "+constants.TmpGhAwDirExpr+"/sandbox/firewall-audit-logs/api-proxy-logs/token-usage.jsonl "+constants.TmpGhAwDirExpr+"/sandbox/firewall/logs/api-proxy-logs/token-usage.jsonl "+constants.TmpGhAwDirExpr+"/sandbox/firewall/audit/api-proxy-logs/token-usage.jsonl "+constants.TmpGhAwDirExpr+"/threat-detection/sandbox/firewall-audit-logs/api-proxy-logs/token-usage.jsonl "+constants.TmpGhAwDirExpr+"/threat-detection/sandbox/firewall/logs/api-proxy-logs/token-usage.jsonl "+constants.TmpGhAwDirExpr+"/threat-detection/sandbox/firewall/audit/api-proxy-logs/token-usage.jsonl; do\n",
 		"            [ -f \"$file\" ] && echo \"FOUND: $file\" || echo \"MISSING: $file\"\n",
 		"          done\n",
-		"          [ -f /tmp/gh-aw/aw_info.json ] && cp /tmp/gh-aw/aw_info.json /tmp/gh-aw/usage/aw_info.json || true\n",
-		"          [ -f /tmp/gh-aw/aw-info.jsonl ] && cp /tmp/gh-aw/aw-info.jsonl /tmp/gh-aw/usage/aw-info.jsonl || true\n",
-		"          [ -f /tmp/gh-aw/agent_usage.json ] && cp /tmp/gh-aw/agent_usage.json /tmp/gh-aw/usage/agent_usage.json || true\n",
-		"          [ -f /tmp/gh-aw/agent_usage.jsonl ] && cp /tmp/gh-aw/agent_usage.jsonl /tmp/gh-aw/usage/agent_usage.jsonl || true\n",
-		"          [ -f /tmp/gh-aw/detection_usage.jsonl ] && cp /tmp/gh-aw/detection_usage.jsonl /tmp/gh-aw/usage/detection_usage.jsonl || true\n",
-		"          [ -f /tmp/gh-aw/evals/evals.jsonl ] && cp /tmp/gh-aw/evals/evals.jsonl /tmp/gh-aw/usage/evals.jsonl || true\n",
-		"          [ -f /tmp/gh-aw/github_rate_limits.jsonl ] && cp /tmp/gh-aw/github_rate_limits.jsonl /tmp/gh-aw/usage/github_rate_limits.jsonl || true\n",
+		"          [ -f "+constants.TmpGhAwDirExpr+"/aw_info.json ] && cp "+constants.TmpGhAwDirExpr+"/aw_info.json "+constants.TmpGhAwDirExpr+"/usage/aw_info.json || true\n",
+		"          [ -f "+constants.TmpGhAwDirExpr+"/aw-info.jsonl ] && cp "+constants.TmpGhAwDirExpr+"/aw-info.jsonl "+constants.TmpGhAwDirExpr+"/usage/aw-info.jsonl || true\n",
+		"          [ -f "+constants.TmpGhAwDirExpr+"/agent_usage.json ] && cp "+constants.TmpGhAwDirExpr+"/agent_usage.json "+constants.TmpGhAwDirExpr+"/usage/agent_usage.json || true\n",
+		"          [ -f "+constants.TmpGhAwDirExpr+"/agent_usage.jsonl ] && cp "+constants.TmpGhAwDirExpr+"/agent_usage.jsonl "+constants.TmpGhAwDirExpr+"/usage/agent_usage.jsonl || true\n",
+		"          [ -f "+constants.TmpGhAwDirExpr+"/detection_usage.jsonl ] && cp "+constants.TmpGhAwDirExpr+"/detection_usage.jsonl "+constants.TmpGhAwDirExpr+"/usage/detection_usage.jsonl || true\n",
+		"          [ -f "+constants.TmpGhAwDirExpr+"/evals/evals.jsonl ] && cp "+constants.TmpGhAwDirExpr+"/evals/evals.jsonl "+constants.TmpGhAwDirExpr+"/usage/evals.jsonl || true\n",
+		"          [ -f "+constants.TmpGhAwDirExpr+"/github_rate_limits.jsonl ] && cp "+constants.TmpGhAwDirExpr+"/github_rate_limits.jsonl "+constants.TmpGhAwDirExpr+"/usage/github_rate_limits.jsonl || true\n",
 		// Agent token usage: copy in ascending priority order (last non-empty source wins).
 		// firewall/logs/ is the authoritative proxy-logs dir and goes last so it always wins
 		// over the legacy firewall-audit-logs/ path and the AWF audit dir (firewall/audit/).
 		// Using [ -s ] (non-empty) prevents an empty stub file from zeroing out valid data.
-		"          [ -s /tmp/gh-aw/sandbox/firewall-audit-logs/api-proxy-logs/token-usage.jsonl ] && cp /tmp/gh-aw/sandbox/firewall-audit-logs/api-proxy-logs/token-usage.jsonl /tmp/gh-aw/usage/agent/token_usage.jsonl || true\n",
-		"          [ -s /tmp/gh-aw/sandbox/firewall/audit/api-proxy-logs/token-usage.jsonl ] && cp /tmp/gh-aw/sandbox/firewall/audit/api-proxy-logs/token-usage.jsonl /tmp/gh-aw/usage/agent/token_usage.jsonl || true\n",
-		"          [ -s /tmp/gh-aw/sandbox/firewall/logs/api-proxy-logs/token-usage.jsonl ] && cp /tmp/gh-aw/sandbox/firewall/logs/api-proxy-logs/token-usage.jsonl /tmp/gh-aw/usage/agent/token_usage.jsonl || true\n",
+		"          [ -s "+constants.TmpGhAwDirExpr+"/sandbox/firewall-audit-logs/api-proxy-logs/token-usage.jsonl ] && cp "+constants.TmpGhAwDirExpr+"/sandbox/firewall-audit-logs/api-proxy-logs/token-usage.jsonl "+constants.TmpGhAwDirExpr+"/usage/agent/token_usage.jsonl || true\n",
+		"          [ -s "+constants.TmpGhAwDirExpr+"/sandbox/firewall/audit/api-proxy-logs/token-usage.jsonl ] && cp "+constants.TmpGhAwDirExpr+"/sandbox/firewall/audit/api-proxy-logs/token-usage.jsonl "+constants.TmpGhAwDirExpr+"/usage/agent/token_usage.jsonl || true\n",
+		"          [ -s "+constants.TmpGhAwDirExpr+"/sandbox/firewall/logs/api-proxy-logs/token-usage.jsonl ] && cp "+constants.TmpGhAwDirExpr+"/sandbox/firewall/logs/api-proxy-logs/token-usage.jsonl "+constants.TmpGhAwDirExpr+"/usage/agent/token_usage.jsonl || true\n",
 		// Detection token usage: same priority ordering as agent.
-		"          [ -s /tmp/gh-aw/threat-detection/sandbox/firewall-audit-logs/api-proxy-logs/token-usage.jsonl ] && cp /tmp/gh-aw/threat-detection/sandbox/firewall-audit-logs/api-proxy-logs/token-usage.jsonl /tmp/gh-aw/usage/detection/token_usage.jsonl || true\n",
-		"          [ -s /tmp/gh-aw/threat-detection/sandbox/firewall/audit/api-proxy-logs/token-usage.jsonl ] && cp /tmp/gh-aw/threat-detection/sandbox/firewall/audit/api-proxy-logs/token-usage.jsonl /tmp/gh-aw/usage/detection/token_usage.jsonl || true\n",
-		"          [ -s /tmp/gh-aw/threat-detection/sandbox/firewall/logs/api-proxy-logs/token-usage.jsonl ] && cp /tmp/gh-aw/threat-detection/sandbox/firewall/logs/api-proxy-logs/token-usage.jsonl /tmp/gh-aw/usage/detection/token_usage.jsonl || true\n",
-		"          [ -f /tmp/gh-aw/usage/agent/token_usage.jsonl ] || : > /tmp/gh-aw/usage/agent/token_usage.jsonl\n",
-		"          [ -f /tmp/gh-aw/usage/detection/token_usage.jsonl ] || : > /tmp/gh-aw/usage/detection/token_usage.jsonl\n",
-		"          mkdir -p /tmp/gh-aw/usage/activity\n",
+		"          [ -s "+constants.TmpGhAwDirExpr+"/threat-detection/sandbox/firewall-audit-logs/api-proxy-logs/token-usage.jsonl ] && cp "+constants.TmpGhAwDirExpr+"/threat-detection/sandbox/firewall-audit-logs/api-proxy-logs/token-usage.jsonl "+constants.TmpGhAwDirExpr+"/usage/detection/token_usage.jsonl || true\n",
+		"          [ -s "+constants.TmpGhAwDirExpr+"/threat-detection/sandbox/firewall/audit/api-proxy-logs/token-usage.jsonl ] && cp "+constants.TmpGhAwDirExpr+"/threat-detection/sandbox/firewall/audit/api-proxy-logs/token-usage.jsonl "+constants.TmpGhAwDirExpr+"/usage/detection/token_usage.jsonl || true\n",
+		"          [ -s "+constants.TmpGhAwDirExpr+"/threat-detection/sandbox/firewall/logs/api-proxy-logs/token-usage.jsonl ] && cp "+constants.TmpGhAwDirExpr+"/threat-detection/sandbox/firewall/logs/api-proxy-logs/token-usage.jsonl "+constants.TmpGhAwDirExpr+"/usage/detection/token_usage.jsonl || true\n",
+		"          [ -f "+constants.TmpGhAwDirExpr+"/usage/agent/token_usage.jsonl ] || : > "+constants.TmpGhAwDirExpr+"/usage/agent/token_usage.jsonl\n",
+		"          [ -f "+constants.TmpGhAwDirExpr+"/usage/detection/token_usage.jsonl ] || : > "+constants.TmpGhAwDirExpr+"/usage/detection/token_usage.jsonl\n",
+		"          mkdir -p "+constants.TmpGhAwDirExpr+"/usage/activity\n",
 		fmt.Sprintf("          node \"%s/generate_usage_activity_summary.cjs\"\n", SetupActionDestinationShell),
-		"          find /tmp/gh-aw/usage -type f -print | sort\n",
+		"          find "+constants.TmpGhAwDirExpr+"/usage -type f -print | sort\n",
 		"      - name: Upload usage artifact\n",
 		"        if: always()\n",
 		"        continue-on-error: true\n",
@@ -171,16 +183,16 @@ func buildUsageArtifactUploadSteps(prefix string, hasEvals bool, pinAction func(
 		"        with:\n",
 		fmt.Sprintf("          name: %s\n", usageArtifactName),
 		"          path: |\n",
-		"            /tmp/gh-aw/usage/aw_info.json\n",
-		"            /tmp/gh-aw/usage/aw-info.jsonl\n",
-		"            /tmp/gh-aw/usage/agent_usage.json\n",
-		"            /tmp/gh-aw/usage/agent_usage.jsonl\n",
-		"            /tmp/gh-aw/usage/detection_usage.jsonl\n",
-		"            /tmp/gh-aw/usage/evals.jsonl\n",
-		"            /tmp/gh-aw/usage/github_rate_limits.jsonl\n",
-		"            /tmp/gh-aw/usage/agent/token_usage.jsonl\n",
-		"            /tmp/gh-aw/usage/detection/token_usage.jsonl\n",
-		"            /tmp/gh-aw/usage/activity/summary.json\n",
+		"            "+constants.TmpGhAwDirExpr+"/usage/aw_info.json\n",
+		"            "+constants.TmpGhAwDirExpr+"/usage/aw-info.jsonl\n",
+		"            "+constants.TmpGhAwDirExpr+"/usage/agent_usage.json\n",
+		"            "+constants.TmpGhAwDirExpr+"/usage/agent_usage.jsonl\n",
+		"            "+constants.TmpGhAwDirExpr+"/usage/detection_usage.jsonl\n",
+		"            "+constants.TmpGhAwDirExpr+"/usage/evals.jsonl\n",
+		"            "+constants.TmpGhAwDirExpr+"/usage/github_rate_limits.jsonl\n",
+		"            "+constants.TmpGhAwDirExpr+"/usage/agent/token_usage.jsonl\n",
+		"            "+constants.TmpGhAwDirExpr+"/usage/detection/token_usage.jsonl\n",
+		"            "+constants.TmpGhAwDirExpr+"/usage/activity/summary.json\n",
 		"          if-no-files-found: ignore\n",
 	)
 	return steps
@@ -191,13 +203,15 @@ func buildUsageArtifactUploadSteps(prefix string, hasEvals bool, pinAction func(
 // activation job so that subsequent guardrail checks can skip artifact downloads for known runs.
 //
 // The sequence is: restore latest snapshot → append current run entry → save updated snapshot.
+// The snapshot is restored and saved at a fixed path and copied to and from the scratch
+// directory of the job, where the write script reads and appends to it.
 // The restore step uses a prefix restore-key so it picks up the most recent snapshot even when
 // the exact key (which includes the current run ID) does not exist yet.
 func buildDailyAICUsageCacheSteps(data *WorkflowData, pinAction func(string) string) []string {
 	sanitized := SanitizeWorkflowIDForCacheKey(data.WorkflowID)
 	cacheKeyPrefix := fmt.Sprintf("agentic-workflow-usage-%s-", sanitized)
 	cacheKey := cacheKeyPrefix + "${{ github.run_id }}"
-	return []string{
+	steps := []string{
 		"      - name: Restore daily AIC usage cache\n",
 		"        id: restore-daily-aic-cache-conclusion\n",
 		"        if: always()\n",
@@ -206,7 +220,10 @@ func buildDailyAICUsageCacheSteps(data *WorkflowData, pinAction func(string) str
 		"        with:\n",
 		fmt.Sprintf("          key: %s\n", cacheKey),
 		fmt.Sprintf("          restore-keys: %s\n", cacheKeyPrefix),
-		"          path: /tmp/gh-aw/agentic-workflow-usage-cache.jsonl\n",
+		"          path: " + cacheStagingPath(dailyAICUsageCacheFile) + "\n",
+	}
+	steps = append(steps, buildCacheStagingFileCopySteps("Copy daily AIC usage cache into the scratch directory", "always()", cacheStagingPath(dailyAICUsageCacheFile), dailyAICUsageCacheFile)...)
+	steps = append(steps,
 		"      - name: Write daily AIC usage cache entry\n",
 		"        id: write-daily-aic-cache\n",
 		"        if: always()\n",
@@ -215,10 +232,13 @@ func buildDailyAICUsageCacheSteps(data *WorkflowData, pinAction func(string) str
 		"        with:\n",
 		"          github-token: ${{ github.token }}\n",
 		"          script: |\n",
-		"            const { setupGlobals } = require('" + SetupActionDestination + "/setup_globals.cjs');\n",
+		"            const { setupGlobals } = require('"+SetupActionDestination+"/setup_globals.cjs');\n",
 		"            setupGlobals(core, github, context);\n",
-		"            const { main } = require('" + SetupActionDestination + "/write_daily_aic_usage_cache.cjs');\n",
+		"            const { main } = require('"+SetupActionDestination+"/write_daily_aic_usage_cache.cjs');\n",
 		"            await main();\n",
+	)
+	steps = append(steps, buildCacheStagingFileCopySteps("Copy daily AIC usage cache to the cache path", "always()", dailyAICUsageCacheFile, cacheStagingPath(dailyAICUsageCacheFile))...)
+	return append(steps,
 		"      - name: Save daily AIC usage cache\n",
 		"        id: save-daily-aic-cache\n",
 		"        if: always()\n",
@@ -226,7 +246,7 @@ func buildDailyAICUsageCacheSteps(data *WorkflowData, pinAction func(string) str
 		fmt.Sprintf("        uses: %s\n", pinAction("actions/cache/save")),
 		"        with:\n",
 		fmt.Sprintf("          key: %s\n", cacheKey),
-		"          path: /tmp/gh-aw/agentic-workflow-usage-cache.jsonl\n",
+		"          path: "+cacheStagingPath(dailyAICUsageCacheFile)+"\n",
 		// Upload the cache file as an artifact so the activation job's artifact-based
 		// fallback can retrieve it on a different PR branch where actions/cache is
 		// branch-scoped and would otherwise always miss.
@@ -237,10 +257,10 @@ func buildDailyAICUsageCacheSteps(data *WorkflowData, pinAction func(string) str
 		fmt.Sprintf("        uses: %s\n", pinAction("actions/upload-artifact")),
 		"        with:\n",
 		"          name: aic-usage-cache\n",
-		"          path: /tmp/gh-aw/agentic-workflow-usage-cache.jsonl\n",
+		"          path: "+dailyAICUsageCacheFile+"\n",
 		"          if-no-files-found: ignore\n",
 		"          retention-days: 7\n",
-	}
+	)
 }
 
 // isGroupConcurrencyQueueEnabled reports whether compiler-generated concurrency groups
@@ -291,6 +291,7 @@ func buildAgentFailureActivationStatusVars(data *WorkflowData) []string {
 	envVars = append(envVars, fmt.Sprintf("          GH_AW_LOCKDOWN_CHECK_FAILED: ${{ needs.%s.outputs.lockdown_check_failed }}\n", constants.ActivationJobName))
 	envVars = append(envVars, fmt.Sprintf("          GH_AW_OAUTH_TOKEN_CHECK_FAILED: ${{ needs.%s.outputs.oauth_token_check_failed }}\n", constants.ActivationJobName))
 	envVars = append(envVars, fmt.Sprintf("          GH_AW_STALE_LOCK_FILE_FAILED: ${{ needs.%s.outputs.stale_lock_file_failed }}\n", constants.ActivationJobName))
+	envVars = append(envVars, fmt.Sprintf("          GH_AW_CORRELATION_ID: %s\n", correlationIDNeedsExpr()))
 	// SkillReferences holds structured skill refs (with github-token/github-app); Skills holds raw
 	// skill specs from simple frontmatter. Both result in activation-job skill install steps.
 	if len(data.SkillReferences) > 0 || len(data.Skills) > 0 {
@@ -432,6 +433,61 @@ func (c *Compiler) buildAgentFailureStep(data *WorkflowData, mainJobName, messag
 	}), nil
 }
 
+// isFailureCommentEnabled reports whether the conclusion job posts a failure comment on the
+// triggering issue or pull request. It defaults to enabled when add-comment is configured,
+// since those workflows already answer on the triggering item.
+func isFailureCommentEnabled(data *WorkflowData) bool {
+	if data.SafeOutputs == nil {
+		return false
+	}
+	if data.SafeOutputs.ReportFailureAsComment == nil {
+		return data.SafeOutputs.AddComments != nil
+	}
+	return *data.SafeOutputs.ReportFailureAsComment != "false"
+}
+
+// buildConclusionFailureCommentStep builds the step that explains a failed run on the
+// triggering issue or pull request, so a failure is not mistaken for the agent ignoring it.
+func (c *Compiler) buildConclusionFailureCommentStep(data *WorkflowData, mainJobName string, customEnvVars []string, token string) ([]string, error) {
+	if !isFailureCommentEnabled(data) {
+		return nil, nil
+	}
+	engine, err := c.getAgenticEngine(data.AI)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get agentic engine: %w", err)
+	}
+	envVars := slices.Clone(customEnvVars)
+	envVars = append(envVars, buildTemplatableBoolEnvVar("GH_AW_FAILURE_REPORT_AS_COMMENT", data.SafeOutputs.ReportFailureAsComment)...)
+	envVars = append(envVars, buildAgentFailureEngineDetectionVars(engine, data, mainJobName)...)
+	envVars = append(envVars, fmt.Sprintf("          GH_AW_AI_CREDITS_RATE_LIMIT_ERROR: ${{ needs.%s.outputs.ai_credits_rate_limit_error || 'false' }}\n", mainJobName))
+	envVars = append(envVars, fmt.Sprintf("          GH_AW_LOCKDOWN_CHECK_FAILED: ${{ needs.%s.outputs.lockdown_check_failed }}\n", constants.ActivationJobName))
+	envVars = append(envVars, fmt.Sprintf("          GH_AW_OAUTH_TOKEN_CHECK_FAILED: ${{ needs.%s.outputs.oauth_token_check_failed }}\n", constants.ActivationJobName))
+	envVars = append(envVars, fmt.Sprintf("          GH_AW_STALE_LOCK_FILE_FAILED: ${{ needs.%s.outputs.stale_lock_file_failed }}\n", constants.ActivationJobName))
+	if hasMaxDailyAICGuardrail(data) {
+		envVars = append(envVars, fmt.Sprintf("          GH_AW_DAILY_AI_CREDITS_EXCEEDED: ${{ needs.%s.outputs.daily_ai_credits_exceeded }}\n", constants.ActivationJobName))
+	}
+	return c.buildGitHubScriptStepWithoutDownload(data, GitHubScriptStepConfig{
+		StepName:      "Post failure comment",
+		StepID:        "failure_comment",
+		MainJobName:   mainJobName,
+		CustomEnvVars: envVars,
+		Script:        "const { main } = require('${{ runner.temp }}/gh-aw/actions/notify_failure_comment.cjs'); await main();",
+		ScriptFile:    "notify_failure_comment.cjs",
+		CustomToken:   token,
+		StepCondition: "always()",
+	}), nil
+}
+
+// applyFailureCommentPermissions grants the conclusion job write access to issues and pull
+// requests when failure comments are enabled without add-comment providing it.
+func applyFailureCommentPermissions(data *WorkflowData, perms *Permissions) {
+	if !isFailureCommentEnabled(data) || data.SafeOutputs.AddComments != nil {
+		return
+	}
+	perms.Set(PermissionIssues, PermissionWrite)
+	perms.Set(PermissionPullRequests, PermissionWrite)
+}
+
 // buildConclusionScriptEnvVars builds environment variables for the completion status script.
 func (c *Compiler) buildConclusionScriptEnvVars(data *WorkflowData, mainJobName string, safeOutputJobNames []string, messagesJSON string) []string {
 	// Build environment variables for the conclusion script
@@ -443,6 +499,7 @@ func (c *Compiler) buildConclusionScriptEnvVars(data *WorkflowData, mainJobName
 	if data.TrackerID != "" {
 		envVars = append(envVars, fmt.Sprintf("          GH_AW_TRACKER_ID: %q\n", data.TrackerID))
 	}
+	envVars = append(envVars, fmt.Sprintf("          GH_AW_CORRELATION_ID: %s\n", correlationIDNeedsExpr()))
 	envVars = append(envVars, fmt.Sprintf("          GH_AW_AGENT_CONCLUSION: ${{ needs.%s.result }}\n", mainJobName))
 	if slices.Contains(safeOutputJobNames, string(constants.SafeOutputsJobName)) {
 		envVars = append(envVars, fmt.Sprintf("          GH_AW_SAFE_OUTPUTS_RESULT: ${{ needs.%s.result }}\n", constants.SafeOutputsJobName))
@@ -1275,63 +1275,63 @@ func TestConclusionJobIncludesUsageArtifactSteps(t *testing.T) {
 	if !strings.Contains(allSteps, "Upload usage artifact") {
 		t.Errorf("Expected conclusion job to upload usage artifact.\nGenerated steps:\n%s", allSteps)
 	}
-	if !strings.Contains(allSteps, "/tmp/gh-aw/usage/aw_info.json") {
+	if !strings.Contains(allSteps, "${{ env.GH_AW_TMP_DIR }}/usage/aw_info.json") {
 		t.Errorf("Expected usage artifact to include aw_info.json path.\nGenerated steps:\n%s", allSteps)
 	}
-	if !strings.Contains(allSteps, "cp /tmp/gh-aw/aw_info.json /tmp/gh-aw/usage/aw_info.json") {
+	if !strings.Contains(allSteps, "cp ${{ env.GH_AW_TMP_DIR }}/aw_info.json ${{ env.GH_AW_TMP_DIR }}/usage/aw_info.json") {
 		t.Errorf("Expected usage artifact collection to include aw_info.json copy command.\nGenerated steps:\n%s", allSteps)
 	}
-	if !strings.Contains(allSteps, "/tmp/gh-aw/usage/aw-info.jsonl") {
+	if !strings.Contains(allSteps, "${{ env.GH_AW_TMP_DIR }}/usage/aw-info.jsonl") {
 		t.Errorf("Expected usage artifact to include aw-info.jsonl path.\nGenerated steps:\n%s", allSteps)
 	}
-	if !strings.Contains(allSteps, "/tmp/gh-aw/usage/agent_usage.jsonl") {
+	if !strings.Contains(allSteps, "${{ env.GH_AW_TMP_DIR }}/usage/agent_usage.jsonl") {
 		t.Errorf("Expected usage artifact to include agent_usage.jsonl path.\nGenerated steps:\n%s", allSteps)
 	}
-	if !strings.Contains(allSteps, "/tmp/gh-aw/usage/agent_usage.json") {
+	if !strings.Contains(allSteps, "${{ env.GH_AW_TMP_DIR }}/usage/agent_usage.json") {
 		t.Errorf("Expected usage artifact to include agent_usage.json path.\nGenerated steps:\n%s", allSteps)
 	}
-	if !strings.Contains(allSteps, "cp /tmp/gh-aw/agent_usage.json /tmp/gh-aw/usage/agent_usage.json") {
+	if !strings.Contains(allSteps, "cp ${{ env.GH_AW_TMP_DIR }}/agent_usage.json ${{ env.GH_AW_TMP_DIR }}/usage/agent_usage.json") {
 		t.Errorf("Expected usage artifact collection to copy agent_usage.json.\nGenerated steps:\n%s", allSteps)
 	}
-	if !strings.Contains(allSteps, "/tmp/gh-aw/usage/detection_usage.jsonl") {
+	if !strings.Contains(allSteps, "${{ env.GH_AW_TMP_DIR }}/usage/detection_usage.jsonl") {
 		t.Errorf("Expected usage artifact to include detection_usage.jsonl path.\nGenerated steps:\n%s", allSteps)
 	}
-	if !strings.Contains(allSteps, "/tmp/gh-aw/usage/github_rate_limits.jsonl") {
+	if !strings.Contains(allSteps, "${{ env.GH_AW_TMP_DIR }}/usage/github_rate_limits.jsonl") {
 		t.Errorf("Expected usage artifact to include GitHub API rate limit usage path.\nGenerated steps:\n%s", allSteps)
 	}
-	if !strings.Contains(allSteps, "/tmp/gh-aw/usage/agent/token_usage.jsonl") {
+	if !strings.Contains(allSteps, "${{ env.GH_AW_TMP_DIR }}/usage/agent/token_usage.jsonl") {
 		t.Errorf("Expected usage artifact to include agent token usage path.\nGenerated steps:\n%s", allSteps)
 	}
-	if !strings.Contains(allSteps, "/tmp/gh-aw/sandbox/firewall/audit/api-proxy-logs/token-usage.jsonl") {
+	if !strings.Contains(allSteps, "${{ env.GH_AW_TMP_DIR }}/sandbox/firewall/audit/api-proxy-logs/token-usage.jsonl") {
 		t.Errorf("Expected usage artifact collection to include firewall audit token usage path for agent.\nGenerated steps:\n%s", allSteps)
 	}
 	// Verify non-empty check (-s) is used for token-usage copies so empty stub files from
 	// AWF's audit dir cannot zero out valid data written by the primary proxy-logs dir.
-	if !strings.Contains(allSteps, "[ -s /tmp/gh-aw/sandbox/firewall/logs/api-proxy-logs/token-usage.jsonl ]") {
+	if !strings.Contains(allSteps, "[ -s ${{ env.GH_AW_TMP_DIR }}/sandbox/firewall/logs/api-proxy-logs/token-usage.jsonl ]") {
 		t.Errorf("Expected usage artifact collection to use non-empty (-s) check for firewall/logs token-usage copy.\nGenerated steps:\n%s", allSteps)
 	}
-	if !strings.Contains(allSteps, "[ -s /tmp/gh-aw/sandbox/firewall/audit/api-proxy-logs/token-usage.jsonl ]") {
+	if !strings.Contains(allSteps, "[ -s ${{ env.GH_AW_TMP_DIR }}/sandbox/firewall/audit/api-proxy-logs/token-usage.jsonl ]") {
 		t.Errorf("Expected usage artifact collection to use non-empty (-s) check for firewall/audit token-usage copy.\nGenerated steps:\n%s", allSteps)
 	}
 	// Verify firewall/logs/ copy appears after firewall/audit/ copy so it wins (last non-empty wins).
-	logsIdx := strings.Index(allSteps, "[ -s /tmp/gh-aw/sandbox/firewall/logs/api-proxy-logs/token-usage.jsonl ]")
-	auditIdx := strings.Index(allSteps, "[ -s /tmp/gh-aw/sandbox/firewall/audit/api-proxy-logs/token-usage.jsonl ]")
+	logsIdx := strings.Index(allSteps, "[ -s ${{ env.GH_AW_TMP_DIR }}/sandbox/firewall/logs/api-proxy-logs/token-usage.jsonl ]")
+	auditIdx := strings.Index(allSteps, "[ -s ${{ env.GH_AW_TMP_DIR }}/sandbox/firewall/audit/api-proxy-logs/token-usage.jsonl ]")
 	if logsIdx > 0 && auditIdx > 0 && logsIdx <= auditIdx {
 		t.Errorf("Expected firewall/logs token-usage copy to appear AFTER firewall/audit copy (logs = higher priority).\nGenerated steps:\n%s", allSteps)
 	}
-	if !strings.Contains(allSteps, "/tmp/gh-aw/usage/detection/token_usage.jsonl") {
+	if !strings.Contains(allSteps, "${{ env.GH_AW_TMP_DIR }}/usage/detection/token_usage.jsonl") {
 		t.Errorf("Expected usage artifact to include detection token usage path.\nGenerated steps:\n%s", allSteps)
 	}
-	if !strings.Contains(allSteps, "/tmp/gh-aw/threat-detection/sandbox/firewall/audit/api-proxy-logs/token-usage.jsonl") {
+	if !strings.Contains(allSteps, "${{ env.GH_AW_TMP_DIR }}/threat-detection/sandbox/firewall/audit/api-proxy-logs/token-usage.jsonl") {
 		t.Errorf("Expected usage artifact collection to include firewall audit token usage path for detection.\nGenerated steps:\n%s", allSteps)
 	}
 	if !strings.Contains(allSteps, "Usage artifact source file status:") {
 		t.Errorf("Expected usage artifact collection to log source file status for diagnostics.\nGenerated steps:\n%s", allSteps)
 	}
-	if !strings.Contains(allSteps, ": > /tmp/gh-aw/usage/agent/token_usage.jsonl") {
+	if !strings.Contains(allSteps, ": > ${{ env.GH_AW_TMP_DIR }}/usage/agent/token_usage.jsonl") {
 		t.Errorf("Expected usage artifact collection to ensure agent token usage file exists.\nGenerated steps:\n%s", allSteps)
 	}
-	if !strings.Contains(allSteps, ": > /tmp/gh-aw/usage/detection/token_usage.jsonl") {
+	if !strings.Contains(allSteps, ": > ${{ env.GH_AW_TMP_DIR }}/usage/detection/token_usage.jsonl") {
 		t.Errorf("Expected usage artifact collection to ensure detection token usage file exists.\nGenerated steps:\n%s", allSteps)
 	}
 	if !strings.Contains(allSteps, "generate_usage_activity_summary.cjs") {
@@ -1346,7 +1346,7 @@ func TestConclusionJobIncludesUsageArtifactSteps(t *testing.T) {
 	if strings.Contains(allSteps, "node ${{ runner.temp }}/gh-aw/actions/generate_usage_activity_summary.cjs") {
 		t.Errorf("Usage activity summary script path must not inline ${{ runner.temp }} in run block.\nGenerated steps:\n%s", allSteps)
 	}
-	if !strings.Contains(allSteps, "/tmp/gh-aw/usage/activity/summary.json") {
+	if !strings.Contains(allSteps, "${{ env.GH_AW_TMP_DIR }}/usage/activity/summary.json") {
 		t.Errorf("Expected usage artifact to include activity summary path.\nGenerated steps:\n%s", allSteps)
 	}
 	if !strings.Contains(allSteps, "Download safe outputs items manifest") {
@@ -1400,10 +1400,10 @@ func TestConclusionJobIncludesEvalsInUsageArtifact(t *testing.T) {
 	if !strings.Contains(allSteps, "name: evals") {
 		t.Errorf("Expected evals artifact download step to use the evals artifact name.\nGenerated steps:\n%s", allSteps)
 	}
-	if !strings.Contains(allSteps, "cp /tmp/gh-aw/evals/evals.jsonl /tmp/gh-aw/usage/evals.jsonl") {
+	if !strings.Contains(allSteps, "cp ${{ env.GH_AW_TMP_DIR }}/evals/evals.jsonl ${{ env.GH_AW_TMP_DIR }}/usage/evals.jsonl") {
 		t.Errorf("Expected usage artifact collection to copy evals results.\nGenerated steps:\n%s", allSteps)
 	}
-	if !strings.Contains(allSteps, "/tmp/gh-aw/usage/evals.jsonl") {
+	if !strings.Contains(allSteps, "${{ env.GH_AW_TMP_DIR }}/usage/evals.jsonl") {
 		t.Errorf("Expected usage artifact upload to include evals results.\nGenerated steps:\n%s", allSteps)
 	}
 }
@@ -1606,3 +1606,48 @@ func TestConclusionJobActionsWritePermissionForDailyAICCache(t *testing.T) {
 		}
 	})
 }
+
+func TestConclusionJobFailureComment(t *testing.T) {
+	compiler := NewCompiler()
+	addComment := &AddCommentsConfig{BaseSafeOutputConfig: BaseSafeOutputConfig{Max: strPtr("1")}}
+
+	tests := []struct {
+		name          string
+		safeOutputs   *SafeOutputsConfig
+		expectStep    bool
+		expectIssues  bool
+		expectRuntime bool
+	}{
+		{name: "enabled by default with add-comment", safeOutputs: &SafeOutputsConfig{AddComments: addComment}, expectStep: true, expectIssues: true},
+		{name: "disabled by default without add-comment", safeOutputs: &SafeOutputsConfig{NoOp: &NoOpConfig{}}},
+		{name: "explicitly disabled", safeOutputs: &SafeOutputsConfig{AddComments: addComment, ReportFailureAsComment: strPtr("false")}, expectIssues: true},
+		{name: "explicitly enabled without add-comment", safeOutputs: &SafeOutputsConfig{NoOp: &NoOpConfig{}, ReportFailureAsComment: strPtr("true")}, expectStep: true, expectIssues: true},
+		{name: "templatable expression", safeOutputs: &SafeOutputsConfig{NoOp: &NoOpConfig{}, ReportFailureAsComment: strPtr("${{ inputs.report-failure-as-comment }}")}, expectStep: true, expectIssues: true, expectRuntime: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job, err := compiler.buildConclusionJob(&WorkflowData{Name: "Test Workflow", SafeOutputs: tt.safeOutputs}, string(constants.AgentJobName), []string{})
+			if err != nil {
+				t.Fatalf("Failed to build conclusion job: %v", err)
+			}
+			jobYAML := strings.Join(job.Steps, "")
+
+			if got := strings.Contains(jobYAML, "name: Post failure comment"); got != tt.expectStep {
+				t.Errorf("Expected failure comment step present=%v, got %v", tt.expectStep, got)
+			}
+			if tt.expectStep && !strings.Contains(jobYAML, "notify_failure_comment.cjs") {
+				t.Error("Expected failure comment step to run notify_failure_comment.cjs")
+			}
+			if tt.expectStep && !strings.Contains(jobYAML, "GH_AW_AGENT_CONCLUSION: ${{ needs.agent.result }}") {
+				t.Error("Expected failure comment step to receive the agent conclusion")
+			}
+			if got := strings.Contains(jobYAML, "GH_AW_FAILURE_REPORT_AS_COMMENT: ${{ inputs.report-failure-as-comment }}"); got != tt.expectRuntime {
+				t.Errorf("Expected runtime toggle env var present=%v, got %v", tt.expectRuntime, got)
+			}
+			if got := strings.Contains(job.Permissions, "issues: write"); got != tt.expectIssues {
+				t.Errorf("Expected issues: write present=%v, got permissions: %q", tt.expectIssues, job.Permissions)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package workflow
+
+import (
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+)
+
+var offlineModeLog = logger.New("workflow:offline_mode")
+
+// SetOfflineMode enables or disables offline compilation (compile --offline).
+//
+// In offline mode the compiler never talks to GitHub: actions are pinned from
+// .github/aw/actions-lock.json and the embedded pins, remote imports are read from
+// .github/aw/imports, and best-effort repository checks are skipped. Anything that
+// cannot be satisfied locally fails with an error naming the missing input.
+func SetOfflineMode(offline bool) {
+	offlineModeLog.Printf("Setting offline mode: %v", offline)
+	parser.SetOfflineMode(offline)
+}
+
+// IsOfflineMode reports whether offline compilation is enabled.
+func IsOfflineMode() bool {
+	return parser.IsOfflineMode()
+}
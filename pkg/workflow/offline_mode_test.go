@@ -0,0 +1,40 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/github/gh-aw/pkg/testutil"
+)
+
+func TestActionResolverOffline(t *testing.T) {
+	SetOfflineMode(true)
+	t.Cleanup(func() { SetOfflineMode(false) })
+
+	cache := NewActionCache(testutil.TempDir(t, "offline-*"))
+	cache.Set("octo/custom-action", "v1", "cached-sha")
+	resolver := NewActionResolver(cache)
+
+	sha, err := resolver.ResolveSHA(context.Background(), "octo/custom-action", "v1")
+	require.NoError(t, err, "actions-lock.json entries should resolve offline")
+	assert.Equal(t, "cached-sha", sha, "cached SHA should be used")
+
+	_, err = resolver.ResolveSHA(context.Background(), "octo/unpinned-action", "v2")
+	require.Error(t, err, "unpinned action should fail offline")
+	assert.Contains(t, err.Error(), "octo/unpinned-action@v2 is not pinned in .github/aw/actions-lock.json", "error should name the missing pin")
+	assert.Contains(t, err.Error(), "disabled by --offline", "error should explain offline mode")
+}
+
+func TestRunGHOffline(t *testing.T) {
+	SetOfflineMode(true)
+	t.Cleanup(func() { SetOfflineMode(false) })
+
+	_, err := RunGH("Checking repository...", "api", "/repos/octo/repo")
+	require.Error(t, err, "gh should not be invoked offline")
+	assert.Contains(t, err.Error(), "gh api /repos/octo/repo requires network access", "error should name the command")
+}
@@ -0,0 +1,71 @@
+package workflow
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var outputLanguageLog = logger.New("workflow:output_language")
+
+// outputLanguageNames maps the ISO 639-1 codes accepted by output-language to the
+// language name used in the agent instructions. Only languages the post-run
+// detector in output_language.cjs can recognize are supported.
+var outputLanguageNames = map[string]string{
+	"de": "German",
+	"en": "English",
+	"es": "Spanish",
+	"fr": "French",
+	"it": "Italian",
+	"ja": "Japanese",
+	"ko": "Korean",
+	"nl": "Dutch",
+	"pl": "Polish",
+	"pt": "Portuguese",
+	"ru": "Russian",
+	"sv": "Swedish",
+	"uk": "Ukrainian",
+	"zh": "Chinese",
+}
+
+// extractOutputLanguage reads the output-language frontmatter field and returns the
+// normalized language code. Returns an empty string when the field is not set.
+func extractOutputLanguage(frontmatter map[string]any) (string, error) {
+	raw, ok := frontmatter["output-language"]
+	if !ok || raw == nil {
+		return "", nil
+	}
+	value, ok := raw.(string)
+	if !ok {
+		return "", fmt.Errorf("output-language must be a language code string, got %T", raw)
+	}
+	code := strings.ToLower(strings.TrimSpace(value))
+	if _, ok := outputLanguageNames[code]; !ok {
+		supported := make([]string, 0, len(outputLanguageNames))
+		for c := range outputLanguageNames {
+			supported = append(supported, c)
+		}
+		slices.Sort(supported)
+		return "", fmt.Errorf("output-language %q is not supported; use one of: %s", value, strings.Join(supported, ", "))
+	}
+	outputLanguageLog.Printf("Output language: %s", code)
+	return code, nil
+}
+
+// buildOutputLanguagePromptSection returns the instructions that pin the language
+// of all user-facing output, or nil when output-language is not configured.
+func buildOutputLanguagePromptSection(data *WorkflowData) *PromptSection {
+	if data.OutputLanguage == "" {
+		return nil
+	}
+	name := outputLanguageNames[data.OutputLanguage]
+	content := fmt.Sprintf(`<output-language>
+Write all user-facing output in %[1]s (%[2]s): issue, pull request and discussion titles and bodies, comments, review comments and any other text published through safe outputs.
+Do not switch languages, even when the triggering content or the repository is written in another language.
+Keep code, commands, file paths, identifiers and quoted text unchanged.
+Safe outputs that are not written in %[1]s are rejected and must be rewritten.
+</output-language>`, name, data.OutputLanguage)
+	return &PromptSection{Content: content}
+}
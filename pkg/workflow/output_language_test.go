@@ -0,0 +1,89 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractOutputLanguage(t *testing.T) {
+	tests := []struct {
+		name        string
+		frontmatter map[string]any
+		expected    string
+		wantErr     string
+	}{
+		{
+			name:        "not set",
+			frontmatter: map[string]any{},
+		},
+		{
+			name:        "supported code",
+			frontmatter: map[string]any{"output-language": "de"},
+			expected:    "de",
+		},
+		{
+			name:        "code is normalized",
+			frontmatter: map[string]any{"output-language": " JA "},
+			expected:    "ja",
+		},
+		{
+			name:        "unsupported code",
+			frontmatter: map[string]any{"output-language": "xx"},
+			wantErr:     "not supported",
+		},
+		{
+			name:        "not a string",
+			frontmatter: map[string]any{"output-language": 42},
+			wantErr:     "must be a language code string",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := extractOutputLanguage(tt.frontmatter)
+			if tt.wantErr != "" {
+				require.Error(t, err, "expected an error")
+				assert.Contains(t, err.Error(), tt.wantErr, "unexpected error message")
+				return
+			}
+			require.NoError(t, err, "unexpected error")
+			assert.Equal(t, tt.expected, code, "unexpected language code")
+		})
+	}
+}
+
+func TestBuildOutputLanguagePromptSection(t *testing.T) {
+	assert.Nil(t, buildOutputLanguagePromptSection(&WorkflowData{}), "no section without output-language")
+
+	section := buildOutputLanguagePromptSection(&WorkflowData{OutputLanguage: "de"})
+	require.NotNil(t, section, "section should be built")
+	assert.False(t, section.IsFile, "section should be inline")
+	assert.Contains(t, section.Content, "<output-language>", "section should be tagged")
+	assert.Contains(t, section.Content, "German (de)", "section should name the language")
+}
+
+func TestOutputLanguagePromptAndConfig(t *testing.T) {
+	compiler := NewCompiler()
+	data := &WorkflowData{
+		OutputLanguage: "fr",
+		SafeOutputs: &SafeOutputsConfig{
+			AddComments: &AddCommentsConfig{},
+		},
+	}
+
+	var found bool
+	for _, section := range compiler.collectPromptSections(data) {
+		if !section.IsFile && section.Content == buildOutputLanguagePromptSection(data).Content {
+			found = true
+		}
+	}
+	assert.True(t, found, "prompt should include the output language section")
+
+	configJSON, err := generateSafeOutputsConfig(data)
+	require.NoError(t, err, "generateSafeOutputsConfig should not return an error")
+	assert.Contains(t, configJSON, `"output_language":"fr"`, "config should carry the output language")
+}
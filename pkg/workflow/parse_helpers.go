@@ -10,13 +10,20 @@
 //     (e.g. `needs: job-name`, `state: failure`), handle the string case explicitly at the call site.
 //   - coerceStringOrArrayField() - Converts a single string scalar field into a one-element []string
 //     for fields that accept either a single value or an array in workflow YAML.
+//   - parseIntValue() - Converts a YAML number (int, uint64 or float64) to int.
+//   - parseNonEmptyStringList() - Parses a list of non-empty strings, rejecting any other item.
 //
 // Config normalization helpers such as preprocessProtectedFilesField now live in
 // config_preprocessing.go.
 
 package workflow
 
-import "github.com/github/gh-aw/pkg/logger"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
 
 var parseHelpersLog = logger.New("workflow:parse_helpers")
 
@@ -101,3 +108,37 @@ func parseStringSliceAny(raw any, debugLog *logger.Logger) []string {
 		return nil
 	}
 }
+
+// parseIntValue converts a YAML number to int. It accepts the int, uint64 and float64
+// values the YAML decoder produces and returns false for any other type.
+func parseIntValue(value any) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case float64:
+		return int(v), true
+	case uint64:
+		return int(v), true
+	default:
+		return 0, false
+	}
+}
+
+// parseNonEmptyStringList parses a list of non-empty strings, trimming surrounding
+// whitespace. It returns an error when value is not a list or an item is not a
+// non-empty string; callers wrap it in a field-specific message.
+func parseNonEmptyStringList(value any) ([]string, error) {
+	list, ok := value.([]any)
+	if !ok {
+		return nil, fmt.Errorf("expected a list, got %T", value)
+	}
+	result := make([]string, 0, len(list))
+	for _, item := range list {
+		s, ok := item.(string)
+		if !ok || strings.TrimSpace(s) == "" {
+			return nil, fmt.Errorf("expected non-empty strings, got %v", item)
+		}
+		result = append(result, strings.TrimSpace(s))
+	}
+	return result, nil
+}
@@ -77,8 +77,8 @@ in the consolidated safe_outputs job when create-pull-request is enabled.
 	}
 
 	// 4. Verify correct download path
-	if !strings.Contains(lockContentStr, "path: /tmp/gh-aw/") {
-		t.Error("Expected patch artifact to be downloaded to '/tmp/gh-aw/'")
+	if !strings.Contains(lockContentStr, "path: ${{ env.GH_AW_TMP_DIR }}/") {
+		t.Error("Expected patch artifact to be downloaded to '${{ env.GH_AW_TMP_DIR }}/'")
 	}
 
 	// 5. Verify the step has continue-on-error set (since patch might not exist)
@@ -164,8 +164,8 @@ push-to-pull-request-branch is enabled.
 	if !strings.Contains(lockContentStr, "name: agent\n") {
 		t.Error("Expected patch to be downloaded from unified 'agent' artifact")
 	}
-	if !strings.Contains(lockContentStr, "path: /tmp/gh-aw/") {
-		t.Error("Expected patch artifact to be downloaded to '/tmp/gh-aw/'")
+	if !strings.Contains(lockContentStr, "path: ${{ env.GH_AW_TMP_DIR }}/") {
+		t.Error("Expected patch artifact to be downloaded to '${{ env.GH_AW_TMP_DIR }}/'")
 	}
 }
 
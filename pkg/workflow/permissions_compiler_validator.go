@@ -98,6 +98,12 @@ func (c *Compiler) validatePermissions(workflowData *WorkflowData, markdownPath
 		return nil, err
 	}
 
+	// Validate workflow_run upstream names and record agentic upstreams for output passing
+	workflowLog.Printf("Resolving workflow_run upstream workflows")
+	if err := c.resolveWorkflowRunUpstreams(workflowData, markdownPath); err != nil {
+		return nil, err
+	}
+
 	// Validate pull_request_target trigger security
 	workflowLog.Printf("Validating pull_request_target trigger security")
 	if err := c.validatePullRequestTargetTrigger(workflowData, markdownPath); err != nil {
@@ -132,7 +138,7 @@ func (c *Compiler) validatePermissions(workflowData *WorkflowData, markdownPath
 					downgradeToWarning := c.strictMode && shouldDowngradeDefaultToolsetPermissionError(workflowData.ParsedTools.GitHub)
 					if c.strictMode && !downgradeToWarning {
 						// In strict mode, missing permissions are errors
-						return nil, formatCompilerError(markdownPath, "error", message, nil)
+						return nil, formatCompilerErrorForField(workflowData, markdownPath, "permissions", "error", message, nil)
 					}
 
 					if downgradeToWarning {
@@ -141,7 +147,7 @@ func (c *Compiler) validatePermissions(workflowData *WorkflowData, markdownPath
 
 					// In non-strict mode, missing permissions are warnings.
 					// In strict mode with default-only toolsets, this is intentionally downgraded to warning.
-					fmt.Fprintln(os.Stderr, formatCompilerMessage(markdownPath, "warning", message))
+					fmt.Fprintln(os.Stderr, formatCompilerMessageForField(workflowData, markdownPath, "permissions", "warning", message))
 					c.IncrementWarningCount()
 				}
 			}
@@ -150,7 +156,7 @@ func (c *Compiler) validatePermissions(workflowData *WorkflowData, markdownPath
 
 	// Enforce required id-token: write permission for OIDC auth users.
 	if err := validateOIDCPermissions(workflowData, workflowPermissions); err != nil {
-		return nil, formatCompilerError(markdownPath, "error", err.Error(), err)
+		return nil, formatCompilerErrorForField(workflowData, markdownPath, "permissions.id-token", "error", err.Error(), err)
 	}
 
 	// Emit warning if id-token: write permission is detected
@@ -159,7 +165,7 @@ func (c *Compiler) validatePermissions(workflowData *WorkflowData, markdownPath
 		warningMsg := `This workflow grants id-token: write permission
 OIDC tokens can authenticate to cloud providers (AWS, Azure, GCP).
 Ensure proper audience validation and trust policies are configured.`
-		fmt.Fprintln(os.Stderr, formatCompilerMessage(markdownPath, "warning", warningMsg))
+		fmt.Fprintln(os.Stderr, formatCompilerMessageForField(workflowData, markdownPath, "permissions.id-token", "warning", warningMsg))
 		c.IncrementWarningCount()
 	}
 	if shouldEmitCopilotRequestsEnableTip(workflowData, workflowPermissions) && !c.repositoryOwnerIsIndividualUser() {
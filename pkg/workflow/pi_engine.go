@@ -352,7 +352,7 @@ func (e *PiEngine) GetExecutionSteps(workflowData *WorkflowData, logFile string)
 			// further escaping in practice.
 			modelsJSON := buildPiModelsJSON(profile.gatewayPort, gatewaySecretEnvVar, modelID)
 			piModelsJSONSetup = fmt.Sprintf(
-				`mkdir -p /tmp/gh-aw/pi-agent-dir && printf '%%s\n' %s > /tmp/gh-aw/pi-agent-dir/models.json && `,
+				`mkdir -p `+constants.TmpGhAwDirExpr+`/pi-agent-dir && printf '%%s\n' %s > `+constants.TmpGhAwDirExpr+`/pi-agent-dir/models.json && `,
 				shellEscapeArg(modelsJSON))
 			if !driverConfigured {
 				piArgs = append(piArgs, "--model", "aw-gateway/"+modelID)
@@ -381,10 +381,11 @@ func (e *PiEngine) GetExecutionSteps(workflowData *WorkflowData, logFile string)
 		// user-specified extensions (via engine.args) so the built-in behaviour wins.
 		// ${RUNNER_TEMP} is a Linux shell variable expanded by bash at runtime; gh-aw
 		// container environments are Linux-only so this is safe across all runners.
-		// stdout (JSONL) and stderr are both piped through tee so that PiStreamingLogFile
-		// captures all structured events while agent-stdio.log captures the same output.
+		// stdout (JSONL) is piped through tee so that PiStreamingLogFile captures the
+		// structured events only; stderr diagnostics are captured separately by the
+		// execution step (agent-stderr.log) and both land in agent-stdio.log.
 		piCommand = fmt.Sprintf(
-			`cat /tmp/gh-aw/aw-prompts/prompt.txt | %s %s --extension "${RUNNER_TEMP}/gh-aw/actions/pi_provider.cjs" --extension "${RUNNER_TEMP}/gh-aw/actions/pi_steering_extension.cjs" 2>&1 | tee %s`,
+			`cat `+constants.TmpGhAwDirExpr+`/aw-prompts/prompt.txt | %s %s --extension "${RUNNER_TEMP}/gh-aw/actions/pi_provider.cjs" --extension "${RUNNER_TEMP}/gh-aw/actions/pi_steering_extension.cjs" | tee %s`,
 			commandName, shellJoinArgs(piArgs), PiStreamingLogFile)
 	}
 
@@ -437,8 +438,8 @@ func (e *PiEngine) GetExecutionSteps(workflowData *WorkflowData, logFile string)
 		command = fmt.Sprintf(`set -o pipefail
 printf '%%s' "$(date +%%s%%3N)" > %s
 touch %s
-(umask 177 && touch %s)
-%s 2>&1 | tee -a %s`, AgentCLIStartMsPath, AgentStepSummaryPath, logFile, piCommand, logFile)
+%s
+%s`, AgentCLIStartMsPath, AgentStepSummaryPath, precreateEngineLogsCommand(logFile), captureEngineOutput(piCommand, logFile))
 	}
 
 	// Build the environment map.  Provider-specific credentials are injected via
@@ -539,7 +540,7 @@ touch %s
 // PiStreamingLogFile is the path where Pi CLI writes its streaming JSONL event log.
 // All Pi tool calls, messages, and metrics are captured here for post-run analysis
 // and step summary rendering.
-const PiStreamingLogFile = "/tmp/gh-aw/pi-streaming.jsonl"
+const PiStreamingLogFile = constants.TmpGhAwDirExpr + "/pi-streaming.jsonl"
 
 // filterPiArgs removes redundant Pi CLI flags that gh-aw should not pass through.
 // Pi runs in yolo mode by default, so explicit --yolo flags are ignored while all
@@ -564,8 +565,8 @@ func filterPiArgs(args []string) []string {
 // (no '/'), it is resolved from the setup-action directory: "${RUNNER_TEMP}/gh-aw/actions/<driverName>".
 //
 // The driver reads GH_AW_PROMPT and GH_AW_PI_MODEL from the environment and emits
-// JSONL compatible with parse_pi_log.cjs to stdout; stderr and stdout are both
-// captured by tee to PiStreamingLogFile.
+// JSONL compatible with parse_pi_log.cjs to stdout, which tee captures to
+// PiStreamingLogFile; stderr is left to the execution step's output capture.
 func buildPiDriverCommand(driverName string) string {
 	var driverPath string
 	if strings.Contains(driverName, "/") {
@@ -576,5 +577,5 @@ func buildPiDriverCommand(driverName string) string {
 		driverPath = fmt.Sprintf(`"%s/%s"`, SetupActionDestinationShell, driverName)
 	}
 
-	return fmt.Sprintf(`%s %s 2>&1 | tee %s`, nodeRuntimeResolutionCommand, driverPath, PiStreamingLogFile)
+	return fmt.Sprintf(`%s %s | tee %s`, nodeRuntimeResolutionCommand, driverPath, PiStreamingLogFile)
 }
@@ -327,7 +327,7 @@ func TestPiEngine_GetExecutionSteps_FirewallCopilotProvider(t *testing.T) {
 			Firewall: &FirewallConfig{Enabled: true},
 		},
 	}
-	steps := engine.GetExecutionSteps(workflowData, "/tmp/gh-aw/agent-stdio.log")
+	steps := engine.GetExecutionSteps(workflowData, "${{ env.GH_AW_TMP_DIR }}/agent-stdio.log")
 	require.Len(t, steps, 1, "Should produce exactly one execution step")
 
 	stepText := strings.Join(steps[0], "\n")
@@ -335,7 +335,7 @@ func TestPiEngine_GetExecutionSteps_FirewallCopilotProvider(t *testing.T) {
 	assert.Contains(t, stepText, "PI_CODING_AGENT_DIR", "Firewall mode should set PI_CODING_AGENT_DIR for models.json config")
 	assert.Contains(t, stepText, "GH_AW_NODE_BIN=$(command -v node 2>/dev/null || true)", "Firewall mode should capture node path before AWF chroot execution")
 	assert.Contains(t, stepText, "export GH_AW_NODE_BIN", "Firewall mode should export GH_AW_NODE_BIN for AWF container")
-	assert.Contains(t, stepText, "PI_CODING_AGENT_DIR: /tmp/gh-aw/pi-agent-dir", "PI_CODING_AGENT_DIR should point to the models.json directory")
+	assert.Contains(t, stepText, "PI_CODING_AGENT_DIR: ${{ env.GH_AW_TMP_DIR }}/pi-agent-dir", "PI_CODING_AGENT_DIR should point to the models.json directory")
 	assert.Contains(t, stepText, "models.json", "Firewall mode should write a models.json gateway config")
 	assert.Contains(t, stepText, "aw-gateway", "Firewall mode should register the aw-gateway provider in models.json")
 	assert.Contains(t, stepText, "claude-sonnet-4-20250514", "Step should include the model ID in models.json")
@@ -0,0 +1,134 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var privacyLog = logger.New("workflow:privacy")
+
+// privacySaltOutput is the activation job output carrying the per-run salt that keys
+// pseudonyms. Only the output collector receives it; it is never passed to the agent.
+const privacySaltOutput = "privacy_salt"
+
+// PrivacyConfig holds the privacy frontmatter configuration.
+type PrivacyConfig struct {
+	// Pseudonymize replaces usernames and email addresses in the injected event
+	// context with per-run pseudonyms.
+	Pseudonymize bool
+	// Maintainers are the logins whose pseudonyms are turned back into @mentions
+	// in safe outputs. All other mentions are escaped.
+	Maintainers []string
+}
+
+// pseudonymizes reports whether privacy pseudonymization is enabled.
+func (p *PrivacyConfig) pseudonymizes() bool {
+	return p != nil && p.Pseudonymize
+}
+
+// extractPrivacyConfig reads the privacy frontmatter field. Returns nil when the
+// field is not set.
+func extractPrivacyConfig(frontmatter map[string]any) (*PrivacyConfig, error) {
+	raw, ok := frontmatter["privacy"]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	privacyMap, ok := raw.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("privacy must be an object, got %T", raw)
+	}
+
+	config := &PrivacyConfig{}
+	if v, ok := privacyMap["pseudonymize"]; ok {
+		b, ok := v.(bool)
+		if !ok {
+			return nil, fmt.Errorf("privacy.pseudonymize must be a boolean, got %T", v)
+		}
+		config.Pseudonymize = b
+	}
+	if v, ok := privacyMap["maintainers"]; ok {
+		list, ok := v.([]any)
+		if !ok {
+			return nil, fmt.Errorf("privacy.maintainers must be a list of GitHub usernames, got %T", v)
+		}
+		for _, item := range list {
+			login, ok := item.(string)
+			if !ok || strings.TrimSpace(login) == "" {
+				return nil, fmt.Errorf("privacy.maintainers entries must be non-empty strings, got %v", item)
+			}
+			config.Maintainers = append(config.Maintainers, strings.TrimPrefix(strings.TrimSpace(login), "@"))
+		}
+	}
+	if len(config.Maintainers) > 0 && !config.Pseudonymize {
+		return nil, errors.New("privacy.maintainers requires privacy.pseudonymize: true")
+	}
+	privacyLog.Printf("Privacy config: pseudonymize=%t, maintainers=%v", config.Pseudonymize, config.Maintainers)
+	return config, nil
+}
+
+// privacyIdentityExpressions are expressions that put a username or email address
+// into the prompt unchanged. They are rejected in privacy mode.
+var privacyIdentityExpressions = []string{"github.actor", "github.triggering_actor"}
+
+// validatePrivacyExpressions rejects markdown expressions that would bypass
+// pseudonymization by injecting usernames or email addresses directly.
+func validatePrivacyExpressions(markdown string) error {
+	extractor := NewExpressionExtractor()
+	mappings, err := extractor.ExtractExpressions(markdown)
+	if err != nil {
+		return nil // expression syntax errors are reported by expression validation
+	}
+	for _, mapping := range mappings {
+		expr := strings.TrimSpace(mapping.Content)
+		isIdentity := strings.HasPrefix(expr, "github.event.") && (strings.HasSuffix(expr, ".login") || strings.HasSuffix(expr, ".email"))
+		for _, identityExpr := range privacyIdentityExpressions {
+			if expr == identityExpr {
+				isIdentity = true
+			}
+		}
+		if isIdentity {
+			return fmt.Errorf("privacy.pseudonymize is enabled but the prompt uses ${{ %s }}, which injects a username or email address unchanged; use ${{ steps.sanitized.outputs.text }} (or .title/.body) for pseudonymized event content", expr)
+		}
+	}
+	return nil
+}
+
+// applyPrivacyConfig enables privacy mode on the workflow: the sanitized event
+// context is always computed (so it can be pseudonymized), and safe-output mentions
+// are restricted to the allowlisted maintainers.
+func applyPrivacyConfig(frontmatter map[string]any, data *WorkflowData) error {
+	config, err := extractPrivacyConfig(frontmatter)
+	if err != nil {
+		return err
+	}
+	data.Privacy = config
+	if !config.pseudonymizes() {
+		return nil
+	}
+	if err := validatePrivacyExpressions(data.MarkdownContent); err != nil {
+		return err
+	}
+	data.NeedsTextOutput = true
+
+	if data.SafeOutputs != nil {
+		if data.SafeOutputs.Mentions != nil {
+			return errors.New("safe-outputs.mentions cannot be combined with privacy.pseudonymize; list the users that may be mentioned in privacy.maintainers")
+		}
+		disabled := false
+		data.SafeOutputs.Mentions = &MentionsConfig{
+			AllowedCollaborators: &disabled,
+			AllowContext:         &disabled,
+			Allowed:              config.Maintainers,
+		}
+	}
+	return nil
+}
+
+// privacyGitHubContextPrompt replaces the actor in the GitHub context prompt with
+// its pseudonym, computed by the sanitized step in the activation job.
+func privacyGitHubContextPrompt(promptText string) string {
+	return strings.ReplaceAll(promptText, "${{ github.actor }}", "${{ steps.sanitized.outputs.actor }}")
+}
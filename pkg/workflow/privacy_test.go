@@ -0,0 +1,105 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractPrivacyConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		frontmatter map[string]any
+		expected    *PrivacyConfig
+		wantErr     string
+	}{
+		{
+			name:        "not set",
+			frontmatter: map[string]any{},
+		},
+		{
+			name:        "pseudonymize with maintainers",
+			frontmatter: map[string]any{"privacy": map[string]any{"pseudonymize": true, "maintainers": []any{"octocat", " @hubot "}}},
+			expected:    &PrivacyConfig{Pseudonymize: true, Maintainers: []string{"octocat", "hubot"}},
+		},
+		{
+			name:        "not an object",
+			frontmatter: map[string]any{"privacy": true},
+			wantErr:     "privacy must be an object",
+		},
+		{
+			name:        "maintainers without pseudonymize",
+			frontmatter: map[string]any{"privacy": map[string]any{"maintainers": []any{"octocat"}}},
+			wantErr:     "requires privacy.pseudonymize: true",
+		},
+		{
+			name:        "empty maintainer",
+			frontmatter: map[string]any{"privacy": map[string]any{"pseudonymize": true, "maintainers": []any{""}}},
+			wantErr:     "non-empty strings",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := extractPrivacyConfig(tt.frontmatter)
+			if tt.wantErr != "" {
+				require.Error(t, err, "expected an error")
+				assert.Contains(t, err.Error(), tt.wantErr, "unexpected error message")
+				return
+			}
+			require.NoError(t, err, "unexpected error")
+			assert.Equal(t, tt.expected, config, "unexpected privacy config")
+		})
+	}
+}
+
+func TestValidatePrivacyExpressions(t *testing.T) {
+	require.NoError(t, validatePrivacyExpressions("Triage: ${{ steps.sanitized.outputs.text }} in ${{ github.repository }}"), "sanitized outputs are allowed")
+
+	for _, expr := range []string{"github.actor", "github.triggering_actor", "github.event.issue.user.login", "github.event.head_commit.author.email"} {
+		err := validatePrivacyExpressions("Hello ${{ " + expr + " }}")
+		require.Error(t, err, "expression %s should be rejected", expr)
+		assert.Contains(t, err.Error(), expr, "error should name the expression")
+	}
+}
+
+func TestApplyPrivacyConfig(t *testing.T) {
+	t.Run("restricts mentions to maintainers", func(t *testing.T) {
+		data := &WorkflowData{SafeOutputs: &SafeOutputsConfig{AddComments: &AddCommentsConfig{}}}
+		frontmatter := map[string]any{"privacy": map[string]any{"pseudonymize": true, "maintainers": []any{"octocat"}}}
+
+		require.NoError(t, applyPrivacyConfig(frontmatter, data), "unexpected error")
+		assert.True(t, data.NeedsTextOutput, "privacy mode should always compute the sanitized context")
+		require.NotNil(t, data.SafeOutputs.Mentions, "mentions should be configured")
+		assert.Equal(t, []string{"octocat"}, data.SafeOutputs.Mentions.Allowed, "only maintainers may be mentioned")
+		assert.False(t, *data.SafeOutputs.Mentions.AllowContext, "context mentions should be disabled")
+		assert.False(t, *data.SafeOutputs.Mentions.AllowedCollaborators, "collaborator mentions should be disabled")
+	})
+
+	t.Run("conflicts with safe-outputs mentions", func(t *testing.T) {
+		data := &WorkflowData{SafeOutputs: &SafeOutputsConfig{Mentions: &MentionsConfig{}}}
+		frontmatter := map[string]any{"privacy": map[string]any{"pseudonymize": true}}
+
+		err := applyPrivacyConfig(frontmatter, data)
+		require.Error(t, err, "expected a conflict error")
+		assert.Contains(t, err.Error(), "safe-outputs.mentions", "error should name the conflicting field")
+	})
+
+	t.Run("disabled leaves workflow unchanged", func(t *testing.T) {
+		data := &WorkflowData{SafeOutputs: &SafeOutputsConfig{}}
+		frontmatter := map[string]any{"privacy": map[string]any{"pseudonymize": false}}
+
+		require.NoError(t, applyPrivacyConfig(frontmatter, data), "unexpected error")
+		assert.False(t, data.NeedsTextOutput, "sanitized context should not be forced")
+		assert.Nil(t, data.SafeOutputs.Mentions, "mentions should not be configured")
+	})
+}
+
+func TestPrivacyGitHubContextPrompt(t *testing.T) {
+	prompt := privacyGitHubContextPrompt(githubContextPromptText)
+	assert.NotContains(t, prompt, "${{ github.actor }}", "actor should not be injected unchanged")
+	assert.Contains(t, prompt, "${{ steps.sanitized.outputs.actor }}", "actor pseudonym should be injected")
+}
@@ -0,0 +1,144 @@
+// This file implements the prompt-budget: frontmatter field, which assigns token
+// budgets to the sections of the assembled prompt:
+//   - instructions: the workflow's own prompt (the markdown body after runtime imports,
+//     interpolation and template rendering), excluding the built-in system sections
+//   - context: the values injected into the prompt through ${{ }} expressions,
+//     such as issue bodies and comments
+//
+// Each section takes a number of tokens, or an object with tokens and a truncation
+// strategy (head, tail, middle or fail). The budget is passed to interpolate_prompt.cjs
+// as GH_AW_PROMPT_BUDGET, which enforces it while assembling the prompt and reports
+// the per-section usage in the run summary.
+
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/typeutil"
+)
+
+var promptBudgetLog = logger.New("workflow:prompt_budget")
+
+// promptBudgetCharsPerToken mirrors the token estimate of estimate_tokens.cjs.
+const promptBudgetCharsPerToken = 4
+
+// Truncation strategies for a prompt-budget section.
+const (
+	PromptBudgetTruncateHead   = "head"   // keep the beginning of the section
+	PromptBudgetTruncateTail   = "tail"   // keep the end of the section
+	PromptBudgetTruncateMiddle = "middle" // keep the beginning and the end, drop the middle
+	PromptBudgetTruncateFail   = "fail"   // fail the run instead of truncating
+)
+
+// PromptBudgetSection is the token budget of one prompt section.
+type PromptBudgetSection struct {
+	Tokens   int    `json:"tokens"`
+	Truncate string `json:"truncate"`
+}
+
+// PromptBudget holds the prompt-budget: configuration.
+type PromptBudget struct {
+	Instructions *PromptBudgetSection `json:"instructions,omitempty"`
+	Context      *PromptBudgetSection `json:"context,omitempty"`
+}
+
+// extractPromptBudgetFromFrontmatter reads the prompt-budget: field from raw frontmatter.
+// It returns nil when the field is absent or sets no budget.
+func extractPromptBudgetFromFrontmatter(frontmatter map[string]any) (*PromptBudget, error) {
+	raw, ok := frontmatter["prompt-budget"]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	sections, ok := raw.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("prompt-budget must be an object with instructions and/or context budgets, got %T", raw)
+	}
+
+	budget := &PromptBudget{}
+	for name, value := range sections {
+		section, err := parsePromptBudgetSection(name, value)
+		if err != nil {
+			return nil, err
+		}
+		switch name {
+		case "instructions":
+			budget.Instructions = section
+		case "context":
+			budget.Context = section
+		default:
+			return nil, fmt.Errorf("prompt-budget.%s: unknown section. Valid sections: instructions, context", name)
+		}
+	}
+	if budget.Instructions == nil && budget.Context == nil {
+		return nil, nil
+	}
+	promptBudgetLog.Printf("Extracted prompt budget: instructions=%v, context=%v", budget.Instructions != nil, budget.Context != nil)
+	return budget, nil
+}
+
+// parsePromptBudgetSection parses a section given as a token count or as
+// {tokens, truncate}. The truncation strategy defaults to head.
+func parsePromptBudgetSection(name string, value any) (*PromptBudgetSection, error) {
+	section := &PromptBudgetSection{Truncate: PromptBudgetTruncateHead}
+	switch v := value.(type) {
+	case map[string]any:
+		tokens, ok := typeutil.ParseIntValue(v["tokens"])
+		if !ok {
+			return nil, fmt.Errorf("prompt-budget.%s.tokens must be an integer", name)
+		}
+		section.Tokens = tokens
+		if truncate, ok := v["truncate"]; ok {
+			str, ok := truncate.(string)
+			if !ok {
+				return nil, fmt.Errorf("prompt-budget.%s.truncate must be a string", name)
+			}
+			section.Truncate = str
+		}
+	default:
+		tokens, ok := typeutil.ParseIntValue(value)
+		if !ok {
+			return nil, fmt.Errorf("prompt-budget.%s must be a number of tokens or an object with tokens and truncate", name)
+		}
+		section.Tokens = tokens
+	}
+
+	if section.Tokens < 1 {
+		return nil, fmt.Errorf("prompt-budget.%s: token budget must be at least 1, got %d", name, section.Tokens)
+	}
+	switch section.Truncate {
+	case PromptBudgetTruncateHead, PromptBudgetTruncateTail, PromptBudgetTruncateMiddle, PromptBudgetTruncateFail:
+	default:
+		return nil, fmt.Errorf("prompt-budget.%s.truncate: unknown strategy %q. Valid strategies: head, tail, middle, fail", name, section.Truncate)
+	}
+	return section, nil
+}
+
+// validatePromptBudget rejects an instructions budget that the markdown body already
+// exceeds at compile time when the section is configured to fail rather than truncate,
+// since every run of the workflow would fail.
+func validatePromptBudget(workflowData *WorkflowData) error {
+	budget := workflowData.PromptBudget
+	if budget == nil || budget.Instructions == nil || budget.Instructions.Truncate != PromptBudgetTruncateFail {
+		return nil
+	}
+	estimated := (len(workflowData.MarkdownContent) + promptBudgetCharsPerToken - 1) / promptBudgetCharsPerToken
+	if estimated > budget.Instructions.Tokens {
+		return NewValidationError(
+			"prompt-budget.instructions",
+			fmt.Sprintf("%d", budget.Instructions.Tokens),
+			fmt.Sprintf("the markdown body is about %d tokens, which exceeds the instructions budget of %d tokens with truncate: fail", estimated, budget.Instructions.Tokens),
+			"Raise prompt-budget.instructions.tokens, shorten the prompt, or choose a truncation strategy (head, tail or middle)",
+		)
+	}
+	return nil
+}
+
+// promptBudgetEnvValue returns the JSON passed to interpolate_prompt.cjs as GH_AW_PROMPT_BUDGET.
+func promptBudgetEnvValue(budget *PromptBudget) string {
+	// PromptBudget only holds ints and strings, so marshaling cannot fail.
+	data, _ := json.Marshal(budget)
+	return string(data)
+}
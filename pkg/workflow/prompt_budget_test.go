@@ -0,0 +1,110 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractPromptBudgetFromFrontmatter(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     any
+		want    *PromptBudget
+		wantErr string
+	}{
+		{name: "absent"},
+		{name: "empty", raw: map[string]any{}},
+		{
+			name: "token counts default to head",
+			raw:  map[string]any{"instructions": 4000, "context": uint64(8000)},
+			want: &PromptBudget{
+				Instructions: &PromptBudgetSection{Tokens: 4000, Truncate: PromptBudgetTruncateHead},
+				Context:      &PromptBudgetSection{Tokens: 8000, Truncate: PromptBudgetTruncateHead},
+			},
+		},
+		{
+			name: "object form",
+			raw:  map[string]any{"context": map[string]any{"tokens": 500, "truncate": "middle"}},
+			want: &PromptBudget{Context: &PromptBudgetSection{Tokens: 500, Truncate: PromptBudgetTruncateMiddle}},
+		},
+		{name: "not an object", raw: 100, wantErr: "must be an object"},
+		{name: "unknown section", raw: map[string]any{"history": 100}, wantErr: "unknown section"},
+		{name: "zero tokens", raw: map[string]any{"context": 0}, wantErr: "at least 1"},
+		{name: "missing tokens", raw: map[string]any{"context": map[string]any{"truncate": "tail"}}, wantErr: "tokens must be an integer"},
+		{name: "unknown strategy", raw: map[string]any{"context": map[string]any{"tokens": 10, "truncate": "summarize"}}, wantErr: "unknown strategy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frontmatter := map[string]any{}
+			if tt.raw != nil {
+				frontmatter["prompt-budget"] = tt.raw
+			}
+			got, err := extractPromptBudgetFromFrontmatter(frontmatter)
+			if tt.wantErr != "" {
+				require.Error(t, err, "prompt budget should be rejected")
+				assert.Contains(t, err.Error(), tt.wantErr, "unexpected error message")
+				return
+			}
+			require.NoError(t, err, "prompt budget should be valid")
+			assert.Equal(t, tt.want, got, "unexpected prompt budget")
+		})
+	}
+}
+
+func TestValidatePromptBudget(t *testing.T) {
+	body := strings.Repeat("word ", 100)
+
+	fail := &WorkflowData{
+		MarkdownContent: body,
+		PromptBudget:    &PromptBudget{Instructions: &PromptBudgetSection{Tokens: 10, Truncate: PromptBudgetTruncateFail}},
+	}
+	err := validatePromptBudget(fail)
+	require.Error(t, err, "a body over a failing budget should be rejected")
+	assert.Contains(t, err.Error(), "about 125 tokens", "error should report the estimate")
+
+	truncate := &WorkflowData{
+		MarkdownContent: body,
+		PromptBudget:    &PromptBudget{Instructions: &PromptBudgetSection{Tokens: 10, Truncate: PromptBudgetTruncateHead}},
+	}
+	assert.NoError(t, validatePromptBudget(truncate), "a truncating budget should be accepted")
+}
+
+func TestCompileWorkflowWithPromptBudget(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "prompt-budget-test")
+	testFile := filepath.Join(tmpDir, "budget.md")
+	content := `---
+on:
+  issues:
+    types: [opened]
+permissions:
+  contents: read
+engine: copilot
+prompt-budget:
+  instructions: 4000
+  context:
+    tokens: 1000
+    truncate: middle
+---
+
+# Triage
+
+Summarize the issue.
+`
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "failed to write workflow")
+
+	require.NoError(t, NewCompiler().CompileWorkflow(testFile), "workflow should compile")
+	lockContent, err := os.ReadFile(filepath.Join(tmpDir, "budget.lock.yml"))
+	require.NoError(t, err, "failed to read lock file")
+
+	assert.Contains(t, string(lockContent), `GH_AW_PROMPT_BUDGET: '{"instructions":{"tokens":4000,"truncate":"head"},"context":{"tokens":1000,"truncate":"middle"}}'`, "budget should be passed to the interpolation step")
+	assert.Contains(t, string(lockContent), "interpolate_prompt.cjs", "the interpolation step should run to enforce the budget")
+}
@@ -23,6 +23,7 @@ const (
 	safeOutputsCreatePRFile                 = "safe_outputs_create_pull_request.md"
 	safeOutputsPushToBranchFile             = "safe_outputs_push_to_pr_branch.md"
 	safeOutputsCommentMemoryFile            = "safe_outputs_comment_memory.md"
+	safeOutputsBackportFile                 = "safe_outputs_backport.md"
 	safeOutputsAutoCreateIssueFile          = "safe_outputs_auto_create_issue.md"
 	githubMCPToolsPromptFile                = "github_mcp_tools_prompt.md"
 	githubMCPToolsWithSafeOutputsPromptFile = "github_mcp_tools_with_safeoutputs_prompt.md"
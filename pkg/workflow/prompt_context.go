@@ -0,0 +1,178 @@
+// This file implements the context: frontmatter field, a map of named values that
+// the markdown body references as ${{ context.<name> }}.
+//
+// Each value is either a static string or a single allowed GitHub Actions expression.
+// References are rewritten at compile time:
+//   - the expression allowlist sees the underlying expression (or nothing, for static strings)
+//   - the prompt expression mapping for context.<name> resolves to the underlying expression,
+//     or to a quoted literal for static strings, in the GH_AW_CONTEXT_<NAME> env var
+//
+// The runtime evaluator (runtime_import.cjs) reads GH_AW_CONTEXT_<NAME> when the body is
+// loaded with runtime-import, so both inline and runtime-import prompts are supported.
+
+package workflow
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/sliceutil"
+)
+
+var promptContextLog = logger.New("workflow:prompt_context")
+
+// maxPromptContextValueLength mirrors the maxLength of context values in the schema.
+const maxPromptContextValueLength = 1024
+
+var (
+	// promptContextNamePattern matches a valid context: key.
+	promptContextNamePattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]{0,63}$`)
+	// promptContextReferencePattern matches ${{ context.<name> }} references in markdown.
+	promptContextReferencePattern = regexp.MustCompile(`\$\{\{\s*context\.([a-zA-Z0-9_]+)\s*\}\}`)
+	// promptContextExpressionValuePattern matches a context value that is a single expression.
+	promptContextExpressionValuePattern = regexp.MustCompile(`^\$\{\{\s*(.+?)\s*\}\}$`)
+)
+
+// extractPromptContextFromFrontmatter reads the context: map from raw frontmatter.
+// Non-string values are ignored; the schema rejects them.
+func extractPromptContextFromFrontmatter(frontmatter map[string]any) map[string]string {
+	raw, ok := frontmatter["context"].(map[string]any)
+	if !ok || len(raw) == 0 {
+		return nil
+	}
+	promptContext := make(map[string]string, len(raw))
+	for name, value := range raw {
+		if str, ok := value.(string); ok {
+			promptContext[name] = str
+		}
+	}
+	promptContextLog.Printf("Extracted %d context value(s) from frontmatter", len(promptContext))
+	return promptContext
+}
+
+// promptContextExpression returns the expression of a context value written as
+// "${{ <expression> }}", and false for static strings.
+func promptContextExpression(value string) (string, bool) {
+	m := promptContextExpressionValuePattern.FindStringSubmatch(strings.TrimSpace(value))
+	if m == nil {
+		return "", false
+	}
+	return m[1], true
+}
+
+// validatePromptContext checks the context: values and the ${{ context.<name> }}
+// references in the markdown body. Expression values must pass the expression
+// allowlist; static values must be single-line text without expression or template
+// syntax, so they can be inlined into the prompt as-is.
+func validatePromptContext(workflowData *WorkflowData) error {
+	for _, name := range sliceutil.SortedKeys(workflowData.PromptContext) {
+		if err := validatePromptContextValue(name, workflowData.PromptContext[name]); err != nil {
+			return err
+		}
+	}
+
+	var undefined []string
+	for _, m := range promptContextReferencePattern.FindAllStringSubmatch(workflowData.MarkdownContent, -1) {
+		if _, ok := workflowData.PromptContext[m[1]]; !ok && !slices.Contains(undefined, m[1]) {
+			undefined = append(undefined, m[1])
+		}
+	}
+	if len(undefined) > 0 {
+		available := "none"
+		if len(workflowData.PromptContext) > 0 {
+			available = strings.Join(sliceutil.SortedKeys(workflowData.PromptContext), ", ")
+		}
+		return NewValidationError(
+			"context",
+			strings.Join(undefined, ", "),
+			fmt.Sprintf("the markdown body references context values that are not defined: %s (defined: %s)", strings.Join(undefined, ", "), available),
+			"Declare each referenced value in the context: frontmatter field, for example:\ncontext:\n  "+undefined[0]+": \"...\"",
+		)
+	}
+	return nil
+}
+
+// validatePromptContextValue validates a single context: entry.
+func validatePromptContextValue(name, value string) error {
+	if !promptContextNamePattern.MatchString(name) {
+		return fmt.Errorf("context.%s: invalid name. Names must start with a letter or underscore and contain only letters, digits, and underscores (maximum 64 characters)", name)
+	}
+	if len(value) > maxPromptContextValueLength {
+		return fmt.Errorf("context.%s: value is %d characters long (maximum %d)", name, len(value), maxPromptContextValueLength)
+	}
+
+	if expression, ok := promptContextExpression(value); ok {
+		if promptContextReferencePattern.MatchString("${{ " + expression + " }}") {
+			return fmt.Errorf("context.%s: context values cannot reference other context values", name)
+		}
+		if err := validateExpressionSafety(value); err != nil {
+			return fmt.Errorf("context.%s: %w", name, err)
+		}
+		return nil
+	}
+
+	switch {
+	case strings.Contains(value, "{{#"):
+		return fmt.Errorf("context.%s: static values cannot contain template directives such as {{#if}} or {{#runtime-import}}", name)
+	case strings.Contains(value, "${{") || strings.Contains(value, "}}"):
+		return fmt.Errorf("context.%s: static values cannot contain expression syntax; use a single expression such as \"${{ github.event.issue.title }}\" as the whole value", name)
+	case strings.ContainsFunc(value, unicode.IsControl):
+		return fmt.Errorf("context.%s: static values must be a single line without control characters", name)
+	}
+	return nil
+}
+
+// expandPromptContextReferences replaces ${{ context.<name> }} references with the
+// underlying expression, and removes references to static values. Used so that the
+// expression allowlist validates what the reference actually evaluates.
+// Undefined references are left in place.
+func expandPromptContextReferences(markdown string, promptContext map[string]string) string {
+	if len(promptContext) == 0 || !strings.Contains(markdown, "context.") {
+		return markdown
+	}
+	return promptContextReferencePattern.ReplaceAllStringFunc(markdown, func(match string) string {
+		name := promptContextReferencePattern.FindStringSubmatch(match)[1]
+		value, ok := promptContext[name]
+		if !ok {
+			return match
+		}
+		if expression, ok := promptContextExpression(value); ok {
+			return "${{ " + expression + " }}"
+		}
+		return ""
+	})
+}
+
+// promptContextMappingContent returns the env var content for a context value:
+// the expression itself, or a single-quoted literal for static values.
+func promptContextMappingContent(value string) string {
+	if expression, ok := promptContextExpression(value); ok {
+		return expression
+	}
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// resolvePromptContextMappings points the expression mappings of ${{ context.<name> }}
+// references at their context: values. The env var name (GH_AW_CONTEXT_<NAME>) is kept
+// so the runtime evaluator can resolve references in runtime-imported markdown.
+func resolvePromptContextMappings(mappings []*ExpressionMapping, promptContext map[string]string) {
+	if len(promptContext) == 0 {
+		return
+	}
+	for _, mapping := range mappings {
+		name, ok := strings.CutPrefix(mapping.Content, "context.")
+		if !ok {
+			continue
+		}
+		value, ok := promptContext[name]
+		if !ok {
+			continue
+		}
+		mapping.Content = promptContextMappingContent(value)
+		promptContextLog.Printf("Resolved context.%s -> %s", name, mapping.Content)
+	}
+}
@@ -0,0 +1,114 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatePromptContextValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		value   string
+		wantErr string
+	}{
+		{name: "static value", key: "team", value: "platform"},
+		{name: "allowed expression", key: "issue_title", value: "${{ github.event.issue.title }}"},
+		{name: "invalid name", key: "1team", value: "platform", wantErr: "invalid name"},
+		{name: "too long", key: "team", value: string(make([]byte, maxPromptContextValueLength+1)), wantErr: "maximum 1024"},
+		{name: "disallowed expression", key: "token", value: "${{ secrets.GITHUB_TOKEN }}", wantErr: "context.token"},
+		{name: "references another value", key: "alias", value: "${{ context.team }}", wantErr: "cannot reference other context values"},
+		{name: "embedded expression", key: "greeting", value: "Hello ${{ github.actor }}", wantErr: "cannot contain expression syntax"},
+		{name: "template directive", key: "body", value: "{{#runtime-import secrets.md}}", wantErr: "template directives"},
+		{name: "multiline", key: "body", value: "line one\nline two", wantErr: "single line"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePromptContextValue(tt.key, tt.value)
+			if tt.wantErr == "" {
+				assert.NoError(t, err, "context value should be valid")
+				return
+			}
+			require.Error(t, err, "context value should be rejected")
+			assert.Contains(t, err.Error(), tt.wantErr, "unexpected error message")
+		})
+	}
+}
+
+func TestValidatePromptContext_UndefinedReference(t *testing.T) {
+	data := &WorkflowData{
+		PromptContext:   map[string]string{"team": "platform"},
+		MarkdownContent: "Team ${{ context.team }} owns ${{ context.repo_area }}.",
+	}
+
+	err := validatePromptContext(data)
+	require.Error(t, err, "undefined references should be rejected")
+	assert.Contains(t, err.Error(), "repo_area", "error should name the undefined value")
+	assert.Contains(t, err.Error(), "defined: team", "error should list the defined values")
+}
+
+func TestExpandPromptContextReferences(t *testing.T) {
+	promptContext := map[string]string{
+		"team":        "platform",
+		"issue_title": "${{ github.event.issue.title }}",
+	}
+
+	got := expandPromptContextReferences("Team: ${{ context.team }}\nTitle: ${{context.issue_title}}\nOther: ${{ context.other }}", promptContext)
+	assert.Equal(t, "Team: \nTitle: ${{ github.event.issue.title }}\nOther: ${{ context.other }}", got, "references should expand to what they evaluate")
+}
+
+func TestPromptContextMappingContent(t *testing.T) {
+	assert.Equal(t, "github.event.issue.title", promptContextMappingContent("${{ github.event.issue.title }}"), "expressions should be used as-is")
+	assert.Equal(t, "'platform'", promptContextMappingContent("platform"), "static values should be quoted")
+	assert.Equal(t, "'it''s'", promptContextMappingContent("it's"), "single quotes should be escaped")
+}
+
+func TestCompileWorkflowWithPromptContext(t *testing.T) {
+	const frontmatter = "---\non:\n  issues:\n    types: [opened]\npermissions:\n  contents: read\nengine: copilot\ncontext:\n  team: platform\n  issue_title: ${{ github.event.issue.title }}\n---\n\n"
+
+	tests := []struct {
+		name         string
+		inlinePrompt bool
+	}{
+		{name: "runtime import"},
+		{name: "inline prompt", inlinePrompt: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := testutil.TempDir(t, "prompt-context-test")
+			testFile := filepath.Join(tmpDir, "context.md")
+			content := frontmatter + "# Triage\n\nYou work for the ${{ context.team }} team. Triage \"${{ context.issue_title }}\".\n"
+			require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "failed to write workflow")
+
+			compiler := NewCompiler()
+			compiler.inlinePrompt = tt.inlinePrompt
+			require.NoError(t, compiler.CompileWorkflow(testFile), "workflow should compile")
+			lockContent, err := os.ReadFile(filepath.Join(tmpDir, "context.lock.yml"))
+			require.NoError(t, err, "failed to read lock file")
+
+			assert.Contains(t, string(lockContent), "GH_AW_CONTEXT_TEAM: ${{ 'platform' }}", "static values should be passed as quoted literals")
+			assert.Contains(t, string(lockContent), "GH_AW_CONTEXT_ISSUE_TITLE: ${{ github.event.issue.title }}", "expression values should be passed through")
+			assert.NotContains(t, string(lockContent), "${{ context.", "context references should not reach the workflow")
+		})
+	}
+
+	t.Run("undefined reference", func(t *testing.T) {
+		tmpDir := testutil.TempDir(t, "prompt-context-test")
+		testFile := filepath.Join(tmpDir, "context.md")
+		content := frontmatter + "# Triage\n\nArea: ${{ context.area }}\n"
+		require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "failed to write workflow")
+
+		err := NewCompiler().CompileWorkflow(testFile)
+		require.Error(t, err, "undefined context references should fail compilation")
+		assert.Contains(t, err.Error(), "not defined: area", "error should name the undefined reference")
+	})
+}
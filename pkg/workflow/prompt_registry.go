@@ -0,0 +1,302 @@
+package workflow
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/gitutil"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var promptRegistryLog = logger.New("workflow:prompt_registry")
+
+// PromptRegistryFileName is the name of the prompt version registry in .github/aw/.
+const PromptRegistryFileName = "prompt-registry.json"
+
+// promptHashShortLength is the number of hex characters shown for a prompt hash.
+const promptHashShortLength = 12
+
+// ComputePromptHash derives the prompt hash recorded in the lock metadata from the
+// frontmatter and body hashes. The compiled prompt depends on both: the body (including
+// imported markdown) is the user prompt, and the frontmatter selects the built-in
+// sections (safe outputs, tools, network) that are appended to it.
+// Returns "" when either hash is missing.
+func ComputePromptHash(frontmatterHash, bodyHash string) string {
+	if frontmatterHash == "" || bodyHash == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(frontmatterHash + "\n" + bodyHash))
+	return hex.EncodeToString(sum[:])
+}
+
+// ShortPromptHash returns the abbreviated form of a prompt hash used in CLI output.
+func ShortPromptHash(hash string) string {
+	if len(hash) > promptHashShortLength {
+		return hash[:promptHashShortLength]
+	}
+	return hash
+}
+
+// PromptVersion is one compiled prompt of a workflow recorded in the registry, together
+// with the commit whose lock file carries it.
+type PromptVersion struct {
+	Version         int    `json:"version"`
+	PromptHash      string `json:"prompt_hash"`
+	FrontmatterHash string `json:"frontmatter_hash"`
+	BodyHash        string `json:"body_hash"`
+	Commit          string `json:"commit"`
+	CommittedAt     string `json:"committed_at"`
+	CompilerVersion string `json:"compiler_version,omitempty"`
+}
+
+// Label returns the display name of the version, e.g. "v3".
+func (v PromptVersion) Label() string {
+	return fmt.Sprintf("v%d", v.Version)
+}
+
+// PromptRegistryEntry is the version history of one workflow.
+type PromptRegistryEntry struct {
+	Markdown string          `json:"markdown"`
+	Lock     string          `json:"lock"`
+	Versions []PromptVersion `json:"versions"`
+}
+
+// Latest returns the most recently recorded version, if any.
+func (e *PromptRegistryEntry) Latest() (PromptVersion, bool) {
+	if len(e.Versions) == 0 {
+		return PromptVersion{}, false
+	}
+	return e.Versions[len(e.Versions)-1], true
+}
+
+// ResolveVersion finds a version by number ("3" or "v3") or by prompt hash prefix.
+// When several versions share a prompt hash (for example after a rollback), the most
+// recent one is returned.
+func (e *PromptRegistryEntry) ResolveVersion(ref string) (PromptVersion, error) {
+	ref = strings.TrimSpace(ref)
+	if number, err := strconv.Atoi(strings.TrimPrefix(strings.ToLower(ref), "v")); err == nil {
+		for _, version := range e.Versions {
+			if version.Version == number {
+				return version, nil
+			}
+		}
+		return PromptVersion{}, fmt.Errorf("version %s is not recorded", ref)
+	}
+
+	if len(ref) < 7 {
+		return PromptVersion{}, fmt.Errorf("%q is not a version number (e.g. v3) or a prompt hash prefix of at least 7 characters", ref)
+	}
+	var match *PromptVersion
+	for i := len(e.Versions) - 1; i >= 0; i-- {
+		version := e.Versions[i]
+		if !strings.HasPrefix(version.PromptHash, strings.ToLower(ref)) {
+			continue
+		}
+		if match != nil && match.PromptHash != version.PromptHash {
+			return PromptVersion{}, fmt.Errorf("prompt hash prefix %q is ambiguous", ref)
+		}
+		if match == nil {
+			match = &version
+		}
+	}
+	if match == nil {
+		return PromptVersion{}, fmt.Errorf("no version with prompt hash %q is recorded", ref)
+	}
+	return *match, nil
+}
+
+// PromptRegistry tracks the committed prompt hashes of every workflow over time. It is
+// stored in .github/aw/prompt-registry.json and appended to by `gh aw compile` once a
+// changed prompt has been committed; `gh aw rollback` uses it to find earlier versions.
+// The file is a local index of the git history and is not meant to be committed.
+type PromptRegistry struct {
+	Workflows map[string]*PromptRegistryEntry `json:"workflows"`
+
+	root  string
+	path  string
+	dirty bool
+}
+
+// NewPromptRegistry creates an empty registry for the repository at repoRoot.
+func NewPromptRegistry(repoRoot string) *PromptRegistry {
+	return &PromptRegistry{
+		Workflows: make(map[string]*PromptRegistryEntry),
+		root:      repoRoot,
+		path:      filepath.Join(repoRoot, ".github", "aw", PromptRegistryFileName),
+	}
+}
+
+// LoadPromptRegistry reads the registry of the repository at repoRoot. A missing file
+// yields an empty registry.
+func LoadPromptRegistry(repoRoot string) (*PromptRegistry, error) {
+	registry := NewPromptRegistry(repoRoot)
+	data, err := os.ReadFile(registry.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return registry, nil
+		}
+		return nil, fmt.Errorf("failed to read prompt registry: %w", err)
+	}
+	if err := json.Unmarshal(data, registry); err != nil {
+		return nil, fmt.Errorf("failed to parse prompt registry %s: %w", registry.path, err)
+	}
+	if registry.Workflows == nil {
+		registry.Workflows = make(map[string]*PromptRegistryEntry)
+	}
+	promptRegistryLog.Printf("Loaded prompt registry with %d workflows from %s", len(registry.Workflows), registry.path)
+	return registry, nil
+}
+
+// Root returns the repository root the registry belongs to.
+func (r *PromptRegistry) Root() string {
+	return r.root
+}
+
+// GetPath returns the path of the registry file.
+func (r *PromptRegistry) GetPath() string {
+	return r.path
+}
+
+// Record appends a new version for workflowID when the prompt hash in metadata, read from
+// the lock file of commit, differs from the latest recorded one. markdownPath and lockPath
+// are stored relative to the repository root. Returns the current version and whether it
+// was newly recorded.
+func (r *PromptRegistry) Record(workflowID, markdownPath, lockPath string, metadata *LockMetadata, commit string, committedAt time.Time) (PromptVersion, bool) {
+	if metadata == nil || metadata.PromptHash == "" || commit == "" {
+		return PromptVersion{}, false
+	}
+	entry, ok := r.Workflows[workflowID]
+	if !ok {
+		entry = &PromptRegistryEntry{}
+		r.Workflows[workflowID] = entry
+	}
+	entry.Markdown = r.relativePath(markdownPath)
+	entry.Lock = r.relativePath(lockPath)
+
+	latest, hasLatest := entry.Latest()
+	if hasLatest && latest.PromptHash == metadata.PromptHash {
+		return latest, false
+	}
+	version := PromptVersion{
+		Version:         latest.Version + 1,
+		PromptHash:      metadata.PromptHash,
+		FrontmatterHash: metadata.FrontmatterHash,
+		BodyHash:        metadata.BodyHash,
+		Commit:          commit,
+		CommittedAt:     committedAt.UTC().Format(time.RFC3339),
+		CompilerVersion: metadata.CompilerVersion,
+	}
+	entry.Versions = append(entry.Versions, version)
+	r.dirty = true
+	promptRegistryLog.Printf("Recorded prompt %s for %s (%s)", version.Label(), workflowID, ShortPromptHash(version.PromptHash))
+	return version, true
+}
+
+// relativePath returns path relative to the repository root with forward slashes.
+func (r *PromptRegistry) relativePath(path string) string {
+	if rel, err := filepath.Rel(r.root, path); err == nil && !strings.HasPrefix(rel, "..") {
+		return filepath.ToSlash(rel)
+	}
+	return filepath.ToSlash(path)
+}
+
+// Save writes the registry when it was modified.
+func (r *PromptRegistry) Save() error {
+	if !r.dirty {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), constants.DirPermPublic); err != nil {
+		return fmt.Errorf("failed to create prompt registry directory: %w", err)
+	}
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal prompt registry: %w", err)
+	}
+	if err := os.WriteFile(r.path, append(data, '\n'), constants.FilePermPublic); err != nil {
+		return fmt.Errorf("failed to write prompt registry: %w", err)
+	}
+	r.dirty = false
+	promptRegistryLog.Printf("Saved prompt registry to %s", r.path)
+	return nil
+}
+
+// recordPromptVersion records the prompt version of a workflow in the prompt registry of
+// the repository containing it. Only committed versions are recorded, so every listed
+// version can be restored by `gh aw rollback`: when the freshly compiled prompt differs
+// from the latest recorded one, the lock file of the last commit that changed it is
+// recorded instead. A changed prompt is therefore recorded by the first compile after it
+// has been committed. Workflows outside a git repository, or in a different repository
+// than the first compiled workflow, are not recorded. The registry is saved by the caller
+// via GetPromptRegistry.
+func (c *Compiler) recordPromptVersion(markdownPath, lockFile, yamlContent string) {
+	root, err := gitutil.FindGitRootFrom(filepath.Dir(markdownPath))
+	if err != nil {
+		promptRegistryLog.Printf("Not recording prompt version for %s: %v", markdownPath, err)
+		return
+	}
+	if c.promptRegistry == nil {
+		registry, err := LoadPromptRegistry(root)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(err.Error()))
+			return
+		}
+		c.promptRegistry = registry
+	}
+	if c.promptRegistry.Root() != root {
+		promptRegistryLog.Printf("Not recording prompt version for %s: outside %s", markdownPath, c.promptRegistry.Root())
+		return
+	}
+
+	metadata, _, err := ExtractMetadataFromLockFile(yamlContent)
+	if err != nil || metadata == nil || metadata.PromptHash == "" {
+		promptRegistryLog.Printf("Not recording prompt version for %s: no lock metadata", markdownPath)
+		return
+	}
+	workflowID := GetWorkflowIDFromPath(markdownPath)
+	if entry, ok := c.promptRegistry.Workflows[workflowID]; ok {
+		if latest, ok := entry.Latest(); ok && latest.PromptHash == metadata.PromptHash {
+			return
+		}
+	}
+
+	markdownAbs, _ := filepath.Abs(markdownPath)
+	lockAbs, _ := filepath.Abs(lockFile)
+	lockRel := c.promptRegistry.relativePath(lockAbs)
+	commit, committedAt, err := gitutil.LastCommitOfFile(lockRel, root)
+	if err != nil || commit == "" {
+		promptRegistryLog.Printf("Not recording prompt version for %s: lock file is not committed", markdownPath)
+		return
+	}
+	committed, err := gitutil.ReadFileFromCommit(lockAbs, root, commit)
+	if err != nil {
+		promptRegistryLog.Printf("Not recording prompt version for %s: %v", markdownPath, err)
+		return
+	}
+	committedMetadata, _, err := ExtractMetadataFromLockFile(committed)
+	if err != nil || committedMetadata == nil {
+		promptRegistryLog.Printf("Not recording prompt version for %s: no lock metadata in %s", markdownPath, commit)
+		return
+	}
+	if committedMetadata.PromptHash == "" {
+		committedMetadata.PromptHash = ComputePromptHash(committedMetadata.FrontmatterHash, committedMetadata.BodyHash)
+	}
+	if version, recorded := c.promptRegistry.Record(workflowID, markdownAbs, lockAbs, committedMetadata, commit, committedAt); recorded && c.verbose {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Recorded prompt %s for %s (%s)", version.Label(), workflowID, ShortPromptHash(version.PromptHash))))
+	}
+}
+
+// GetPromptRegistry returns the prompt registry updated by this compiler instance, or
+// nil when no lock file has been written.
+func (c *Compiler) GetPromptRegistry() *PromptRegistry {
+	return c.promptRegistry
+}
@@ -0,0 +1,169 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputePromptHash(t *testing.T) {
+	hash := ComputePromptHash("fm", "body")
+	assert.Len(t, hash, 64, "prompt hash should be a sha256 hex digest")
+	assert.Equal(t, hash, ComputePromptHash("fm", "body"), "prompt hash should be deterministic")
+	assert.NotEqual(t, hash, ComputePromptHash("fm", "body2"), "body changes should change the prompt hash")
+	assert.NotEqual(t, hash, ComputePromptHash("fm2", "body"), "frontmatter changes should change the prompt hash")
+	assert.Empty(t, ComputePromptHash("", "body"), "missing frontmatter hash should yield no prompt hash")
+	assert.Empty(t, ComputePromptHash("fm", ""), "missing body hash should yield no prompt hash")
+}
+
+func TestGenerateLockMetadataPromptHash(t *testing.T) {
+	metadata := GenerateLockMetadata(LockHashInfo{FrontmatterHash: "fm", BodyHash: "body"}, "", false, AgentMetadataInfo{})
+	assert.Equal(t, ComputePromptHash("fm", "body"), metadata.PromptHash, "lock metadata should carry the prompt hash")
+}
+
+func TestPromptRegistryRecord(t *testing.T) {
+	root := t.TempDir()
+	registry := NewPromptRegistry(root)
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	markdown := filepath.Join(root, ".github", "workflows", "triage.md")
+	lock := filepath.Join(root, ".github", "workflows", "triage.lock.yml")
+	v1 := &LockMetadata{FrontmatterHash: "fm", BodyHash: "b1", PromptHash: ComputePromptHash("fm", "b1")}
+	v2 := &LockMetadata{FrontmatterHash: "fm", BodyHash: "b2", PromptHash: ComputePromptHash("fm", "b2")}
+
+	_, recorded := registry.Record("triage", markdown, lock, v1, "", now)
+	assert.False(t, recorded, "a prompt without a commit should not be recorded")
+
+	version, recorded := registry.Record("triage", markdown, lock, v1, "c1", now)
+	assert.True(t, recorded, "first prompt should be recorded")
+	assert.Equal(t, 1, version.Version, "first version should be 1")
+	assert.Equal(t, "c1", version.Commit, "version should carry its commit")
+	assert.Equal(t, "2026-10-01T12:00:00Z", version.CommittedAt, "version should carry the commit date")
+
+	_, recorded = registry.Record("triage", markdown, lock, v1, "c2", now)
+	assert.False(t, recorded, "unchanged prompt should not add a version")
+
+	version, recorded = registry.Record("triage", markdown, lock, v2, "c3", now)
+	assert.True(t, recorded, "changed prompt should be recorded")
+	assert.Equal(t, 2, version.Version, "second version should be 2")
+
+	version, recorded = registry.Record("triage", markdown, lock, v1, "c4", now)
+	assert.True(t, recorded, "returning to an earlier prompt should be recorded as a new version")
+	assert.Equal(t, 3, version.Version, "rollback should append a version")
+
+	entry := registry.Workflows["triage"]
+	require.NotNil(t, entry, "workflow entry should exist")
+	assert.Equal(t, ".github/workflows/triage.md", entry.Markdown, "markdown path should be relative to the repository")
+	assert.Equal(t, ".github/workflows/triage.lock.yml", entry.Lock, "lock path should be relative to the repository")
+
+	_, recorded = registry.Record("triage", markdown, lock, &LockMetadata{FrontmatterHash: "fm"}, "c5", now)
+	assert.False(t, recorded, "metadata without a prompt hash should not be recorded")
+}
+
+func TestPromptRegistrySaveLoad(t *testing.T) {
+	root := t.TempDir()
+	registry := NewPromptRegistry(root)
+	require.NoError(t, registry.Save(), "saving an unmodified registry should be a no-op")
+	assert.NoFileExists(t, registry.GetPath(), "unmodified registry should not be written")
+
+	metadata := &LockMetadata{FrontmatterHash: "fm", BodyHash: "b1", PromptHash: ComputePromptHash("fm", "b1"), CompilerVersion: "v1.2.3"}
+	registry.Record("triage", filepath.Join(root, "triage.md"), filepath.Join(root, "triage.lock.yml"), metadata, "c1", time.Now())
+	require.NoError(t, registry.Save(), "registry should save")
+
+	loaded, err := LoadPromptRegistry(root)
+	require.NoError(t, err, "registry should load")
+	require.Contains(t, loaded.Workflows, "triage", "workflow should round-trip")
+	assert.Equal(t, registry.Workflows["triage"].Versions, loaded.Workflows["triage"].Versions, "versions should round-trip")
+
+	require.NoError(t, os.WriteFile(registry.GetPath(), []byte("{"), 0o644), "corrupt registry should be written")
+	_, err = LoadPromptRegistry(root)
+	require.Error(t, err, "corrupt registry should fail to load")
+}
+
+func TestPromptRegistryResolveVersion(t *testing.T) {
+	entry := &PromptRegistryEntry{Versions: []PromptVersion{
+		{Version: 1, PromptHash: "aaaaaaa111"},
+		{Version: 2, PromptHash: "bbbbbbb222"},
+		{Version: 3, PromptHash: "aaaaaaa111"},
+		{Version: 4, PromptHash: "aaaaaaa999"},
+	}}
+
+	tests := []struct {
+		ref     string
+		want    int
+		wantErr string
+	}{
+		{ref: "2", want: 2},
+		{ref: "v2", want: 2},
+		{ref: "V1", want: 1},
+		{ref: "bbbbbbb", want: 2},
+		{ref: "aaaaaaa1", want: 3},
+		{ref: "v9", wantErr: "not recorded"},
+		{ref: "aaaaaaa", wantErr: "ambiguous"},
+		{ref: "abc", wantErr: "at least 7 characters"},
+		{ref: "ccccccc", wantErr: "no version"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			version, err := entry.ResolveVersion(tt.ref)
+			if tt.wantErr != "" {
+				require.Error(t, err, "reference should not resolve")
+				assert.Contains(t, err.Error(), tt.wantErr, "error should explain the problem")
+				return
+			}
+			require.NoError(t, err, "reference should resolve")
+			assert.Equal(t, tt.want, version.Version, "reference should resolve to the expected version")
+		})
+	}
+}
+
+func TestCompilerRecordsPromptVersion(t *testing.T) {
+	root := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = root
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, "git %v failed: %s", args, output)
+	}
+	git("init", "-q")
+	workflowsDir := filepath.Join(root, ".github", "workflows")
+	require.NoError(t, os.MkdirAll(workflowsDir, 0o755), "workflows dir should be created")
+	markdownPath := filepath.Join(workflowsDir, "triage.md")
+	lockPath := filepath.Join(workflowsDir, "triage.lock.yml")
+	writeWorkflow := func(body string) {
+		require.NoError(t, os.WriteFile(markdownPath, []byte("---\non: issues\nengine: copilot\n---\n\n"+body+"\n"), 0o644), "workflow should be written")
+	}
+
+	writeWorkflow("Triage the issue.")
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(markdownPath), "workflow should compile")
+	registry := compiler.GetPromptRegistry()
+	require.NotNil(t, registry, "compiler should load the prompt registry")
+	assert.Equal(t, root, registry.Root(), "registry should belong to the workflow's repository")
+	assert.NotContains(t, registry.Workflows, "triage", "an uncommitted prompt should not be recorded")
+
+	git("add", ".")
+	git("commit", "-q", "-m", "add triage")
+	committedLock, err := os.ReadFile(lockPath)
+	require.NoError(t, err, "lock file should be written")
+	committedMetadata, _, err := ExtractMetadataFromLockFile(string(committedLock))
+	require.NoError(t, err, "lock metadata should parse")
+
+	writeWorkflow("Triage the issue and label it.")
+	require.NoError(t, compiler.CompileWorkflow(markdownPath), "changed workflow should compile")
+	entry := registry.Workflows["triage"]
+	require.NotNil(t, entry, "the committed prompt should be recorded")
+	require.Len(t, entry.Versions, 1, "only the committed version should be recorded")
+	assert.Equal(t, committedMetadata.PromptHash, entry.Versions[0].PromptHash, "registry should record the committed prompt hash")
+	head, err := exec.Command("git", "-C", root, "rev-parse", "HEAD").Output()
+	require.NoError(t, err, "HEAD should resolve")
+	assert.Equal(t, strings.TrimSpace(string(head)), entry.Versions[0].Commit, "version should carry the commit of its lock file")
+	assert.NoFileExists(t, registry.GetPath(), "the compiler should leave saving to the caller")
+}
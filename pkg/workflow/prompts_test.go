@@ -76,7 +76,7 @@ This is a test workflow with cache-memory enabled.
 	if !strings.Contains(lockStr, "cache_memory_prompt.md") {
 		t.Error("Expected cache template file reference in generated workflow")
 	}
-	if !strings.Contains(lockStr, "GH_AW_CACHE_DIR: '/tmp/gh-aw/cache-memory/'") {
+	if !strings.Contains(lockStr, "GH_AW_CACHE_DIR: '__GH_AW_TMP_DIR__/cache-memory/'") {
 		t.Error("Expected GH_AW_CACHE_DIR environment variable in generated workflow")
 	}
 	if !strings.Contains(lockStr, "GH_AW_CACHE_DIR: process.env.GH_AW_CACHE_DIR") {
@@ -144,7 +144,7 @@ This is a test workflow without cache-memory.
 		t.Error("Did not expect cache template file reference in workflow without cache-memory")
 	}
 
-	if strings.Contains(lockStr, "/tmp/gh-aw/cache-memory/") {
+	if strings.Contains(lockStr, "/cache-memory/") {
 		t.Error("Did not expect '/tmp/gh-aw/cache-memory/' reference in workflow without cache-memory")
 	}
 
@@ -207,11 +207,11 @@ This is a test workflow with multiple cache-memory entries.
 	}
 
 	// Test 3: Verify both cache directories are mentioned in environment variables
-	if !strings.Contains(lockStr, "/tmp/gh-aw/cache-memory/") {
+	if !strings.Contains(lockStr, "__GH_AW_TMP_DIR__/cache-memory/") {
 		t.Error("Expected '/tmp/gh-aw/cache-memory/' reference for default cache")
 	}
 
-	if !strings.Contains(lockStr, "/tmp/gh-aw/cache-memory-session/") {
+	if !strings.Contains(lockStr, "__GH_AW_TMP_DIR__/cache-memory-session/") {
 		t.Error("Expected '/tmp/gh-aw/cache-memory-session/' reference for session cache")
 	}
 
@@ -231,7 +231,7 @@ func TestDailyFunctionNamerColdStartHandling(t *testing.T) {
 	}
 
 	workflow := string(content)
-	coldStartGuidance := "**On cold start** (`/tmp/gh-aw/cache-memory/function-namer-state.json` missing): treat this as expected initialization, not a failure. Do **not** call `missing_data` for a missing state file on first run or cold cache; run the Step 1 script as written, accept `LAST_INDEX=-1`, and continue."
+	coldStartGuidance := "**On cold start** (`__GH_AW_TMP_DIR__/cache-memory/function-namer-state.json` missing): treat this as expected initialization, not a failure. Do **not** call `missing_data` for a missing state file on first run or cold cache; run the Step 1 script as written, accept `LAST_INDEX=-1`, and continue."
 	if !strings.Contains(workflow, coldStartGuidance) {
 		t.Fatal("Expected daily-function-namer workflow to include explicit cold-start guidance")
 	}
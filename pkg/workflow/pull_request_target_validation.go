@@ -118,7 +118,7 @@ func (c *Compiler) validatePullRequestTargetTrigger(workflowData *WorkflowData,
 			"Even with checkout: false, consider whether pull_request_target is truly necessary.\n" +
 			"If you only need to react to PR events without write access, use pull_request instead.\n" +
 			"See: https://securitylab.github.com/resources/github-actions-preventing-pwn-requests/"
-		fmt.Fprintln(os.Stderr, formatCompilerMessage(markdownPath, "warning", warningMsg))
+		fmt.Fprintln(os.Stderr, formatCompilerMessageForField(workflowData, markdownPath, "on.pull_request_target", "warning", warningMsg))
 		c.IncrementWarningCount()
 	}
 
@@ -160,11 +160,11 @@ func (c *Compiler) validatePullRequestTargetTrigger(workflowData *WorkflowData,
 		"See: https://securitylab.github.com/resources/github-actions-preventing-pwn-requests/"
 
 	if effectiveStrictMode {
-		return formatCompilerError(markdownPath, "error", message, nil)
+		return formatCompilerErrorForField(workflowData, markdownPath, "on.pull_request_target", "error", message, nil)
 	}
 
 	// Non-strict mode: emit a warning so existing workflows continue to compile.
-	fmt.Fprintln(os.Stderr, formatCompilerMessage(markdownPath, "warning", message))
+	fmt.Fprintln(os.Stderr, formatCompilerMessageForField(workflowData, markdownPath, "on.pull_request_target", "warning", message))
 	c.IncrementWarningCount()
 
 	return nil
@@ -0,0 +1,207 @@
+// This file provides the reaction_command trigger.
+//
+// # Reaction Command Trigger
+//
+// A reaction command activates a workflow when a user adds a specific emoji
+// reaction to an issue, pull request, or issue comment:
+//
+//	on:
+//	  reaction_command:
+//	    emoji: eyes
+//	    events: [issues, issue_comment]
+//	    interval: 15m
+//
+// GitHub Actions does not emit an event for reactions, so the trigger compiles to
+// a fuzzy polling schedule ("every 15m" by default) plus workflow_dispatch. On
+// scheduled runs, the check_reaction_command step in the pre-activation job looks
+// for matching reactions added since the previous scheduled run and verifies that
+// the reacting user holds one of the roles in on.roles. The matched item is
+// exposed as pre-activation outputs and described to the agent in the prompt.
+// When no matching reaction is found, the workflow is skipped.
+
+package workflow
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var reactionCommandLog = logger.New("workflow:reaction_command")
+
+// reactionCommandSupportedEvents lists the item types whose reactions can trigger a workflow.
+var reactionCommandSupportedEvents = []string{"issues", "pull_request", "issue_comment"}
+
+// defaultReactionCommandInterval is the polling interval used when none is configured.
+const defaultReactionCommandInterval = "15m"
+
+var reactionCommandIntervalPattern = regexp.MustCompile(`^([0-9]+)([mh])$`)
+
+// ReactionCommandConfig holds the configuration for the reaction_command trigger.
+type ReactionCommandConfig struct {
+	Emoji    string   // reaction content that activates the workflow (e.g. "eyes", "+1")
+	Events   []string // item types whose reactions are considered
+	Interval string   // polling interval such as "15m" or "1h"
+}
+
+// parseReactionCommandConfig converts on.reaction_command into a ReactionCommandConfig.
+// The value is either an emoji (shorthand) or an object with emoji, events, and interval.
+func parseReactionCommandConfig(value any) (*ReactionCommandConfig, error) {
+	config := &ReactionCommandConfig{Interval: defaultReactionCommandInterval}
+
+	emojiValue := value
+	if configMap, ok := value.(map[string]any); ok {
+		var hasEmoji bool
+		if emojiValue, hasEmoji = configMap["emoji"]; !hasEmoji {
+			return nil, errors.New("reaction_command requires an 'emoji' field. Example:\n  reaction_command:\n    emoji: eyes")
+		}
+		if eventsValue, hasEvents := configMap["events"]; hasEvents {
+			events := normalizeStringOrStringSlice(eventsValue)
+			if len(events) == 0 {
+				return nil, errors.New("reaction_command 'events' must list at least one of: " + strings.Join(reactionCommandSupportedEvents, ", "))
+			}
+			for _, event := range events {
+				if !slices.Contains(reactionCommandSupportedEvents, event) {
+					return nil, fmt.Errorf("reaction_command event '%s' is not supported: must be one of %s", event, strings.Join(reactionCommandSupportedEvents, ", "))
+				}
+				if !slices.Contains(config.Events, event) {
+					config.Events = append(config.Events, event)
+				}
+			}
+		}
+		if intervalValue, hasInterval := configMap["interval"]; hasInterval {
+			interval, ok := intervalValue.(string)
+			if !ok || !reactionCommandIntervalPattern.MatchString(interval) {
+				return nil, fmt.Errorf("reaction_command 'interval' must be minutes or hours such as '15m' or '1h', got %v", intervalValue)
+			}
+			config.Interval = interval
+		}
+	}
+
+	emoji, err := parseReactionValue(emojiValue)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reaction_command emoji: %w", err)
+	}
+	if emoji == "none" || !isValidReaction(emoji) {
+		return nil, fmt.Errorf("invalid reaction_command emoji '%s': must be one of +1, -1, laugh, confused, heart, hooray, rocket, eyes", emoji)
+	}
+	config.Emoji = emoji
+
+	if len(config.Events) == 0 {
+		config.Events = slices.Clone(reactionCommandSupportedEvents)
+	}
+	return config, nil
+}
+
+// reactionCommandIntervalMinutes returns the polling interval in minutes.
+func reactionCommandIntervalMinutes(interval string) int {
+	match := reactionCommandIntervalPattern.FindStringSubmatch(interval)
+	if match == nil {
+		return 0
+	}
+	value, _ := strconv.Atoi(match[1])
+	if match[2] == "h" {
+		return value * 60
+	}
+	return value
+}
+
+// expandReactionCommandSchedule adds the polling schedule and workflow_dispatch trigger
+// for on.reaction_command before schedule expressions are normalized. An explicit
+// schedule in the on: section takes precedence over the reaction_command interval.
+func expandReactionCommandSchedule(onMap map[string]any) error {
+	value, hasReactionCommand := onMap["reaction_command"]
+	if !hasReactionCommand {
+		return nil
+	}
+	config, err := parseReactionCommandConfig(value)
+	if err != nil {
+		return err
+	}
+	if _, hasSchedule := onMap["schedule"]; !hasSchedule {
+		onMap["schedule"] = "every " + config.Interval
+		reactionCommandLog.Printf("Added polling schedule 'every %s' for reaction_command", config.Interval)
+	}
+	if _, hasDispatch := onMap["workflow_dispatch"]; !hasDispatch {
+		onMap["workflow_dispatch"] = nil
+	}
+	return nil
+}
+
+// processReactionCommandConfiguration extracts on.reaction_command into WorkflowData.
+func (c *Compiler) processReactionCommandConfiguration(frontmatter map[string]any, workflowData *WorkflowData) error {
+	onMap, ok := frontmatter["on"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	value, hasReactionCommand := onMap["reaction_command"]
+	if !hasReactionCommand {
+		return nil
+	}
+	config, err := parseReactionCommandConfig(value)
+	if err != nil {
+		return err
+	}
+	workflowData.ReactionCommand = config
+	reactionCommandLog.Printf("Reaction command configured: emoji=%s, events=%v, interval=%s", config.Emoji, config.Events, config.Interval)
+	return nil
+}
+
+// appendPreActivationReactionCommandStep adds the step that finds a matching reaction
+// by an authorized user on scheduled runs.
+func (c *Compiler) appendPreActivationReactionCommandStep(data *WorkflowData, steps []string) []string {
+	eventsJSON, _ := json.Marshal(data.ReactionCommand.Events) //nolint:jsonmarshalignoredeerror // marshaling a string slice cannot fail
+	steps = append(steps, "      - name: Check reaction command\n")
+	steps = append(steps, fmt.Sprintf("        id: %s\n", constants.CheckReactionCommandStepID))
+	steps = append(steps, fmt.Sprintf("        uses: %s\n", getCachedActionPin("actions/github-script", data)))
+	steps = append(steps, "        env:\n")
+	steps = append(steps, fmt.Sprintf("          GH_AW_REACTION_COMMAND_EMOJI: %q\n", data.ReactionCommand.Emoji))
+	steps = append(steps, fmt.Sprintf("          GH_AW_REACTION_COMMAND_EVENTS: %q\n", string(eventsJSON)))
+	steps = append(steps, fmt.Sprintf("          GH_AW_REACTION_COMMAND_INTERVAL_MINUTES: \"%d\"\n", reactionCommandIntervalMinutes(data.ReactionCommand.Interval)))
+	steps = append(steps, fmt.Sprintf("          GH_AW_REQUIRED_ROLES: %q\n", strings.Join(data.Roles, ",")))
+	steps = append(steps, "        with:\n")
+	steps = append(steps, "          script: |\n")
+	return append(steps, generateGitHubScriptWithRequire("check_reaction_command.cjs"))
+}
+
+// buildReactionCommandPromptSection tells the agent which item the reaction was added to.
+// The section is only emitted at runtime when the reaction check matched an item.
+func buildReactionCommandPromptSection(data *WorkflowData) *PromptSection {
+	if data.ReactionCommand == nil {
+		return nil
+	}
+	itemExpr := fmt.Sprintf("needs.%s.outputs.%s", constants.PreActivationJobName, constants.ReactionItemNumberOutput)
+	commentExpr := fmt.Sprintf("needs.%s.outputs.%s", constants.PreActivationJobName, constants.ReactionCommentIDOutput)
+	userExpr := fmt.Sprintf("needs.%s.outputs.%s", constants.PreActivationJobName, constants.ReactionUserOutput)
+	content := fmt.Sprintf(`<reaction-command>
+This run was triggered by @${{ %s }} adding a "%s" reaction to issue or pull request #${{ %s }} (comment ID, empty when the reaction is on the issue itself: ${{ %s }}).
+Work on that item. Because this run was started by a schedule, pass the item number explicitly to any safe output that targets an issue or pull request.
+</reaction-command>`, userExpr, data.ReactionCommand.Emoji, itemExpr, commentExpr)
+
+	extractor := NewExpressionExtractor()
+	mappings, err := extractor.ExtractExpressions(content)
+	if err != nil {
+		reactionCommandLog.Printf("Failed to extract reaction command prompt expressions: %v", err)
+		return nil
+	}
+	envVars := make(map[string]string, len(mappings))
+	var itemEnvVar string
+	for _, mapping := range mappings {
+		envVars[mapping.EnvVar] = fmt.Sprintf("${{ %s }}", mapping.Content)
+		if mapping.Content == itemExpr {
+			itemEnvVar = mapping.EnvVar
+		}
+	}
+	return &PromptSection{
+		Content:        extractor.ReplaceExpressionsWithEnvVars(content),
+		ShellCondition: fmt.Sprintf(`[ -n "$%s" ]`, itemEnvVar),
+		EnvVars:        envVars,
+	}
+}
@@ -0,0 +1,123 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReactionCommandConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		expected *ReactionCommandConfig
+	}{
+		{
+			name:     "shorthand",
+			value:    "rocket",
+			expected: &ReactionCommandConfig{Emoji: "rocket", Events: []string{"issues", "pull_request", "issue_comment"}, Interval: "15m"},
+		},
+		{
+			name:     "numeric +1 shorthand",
+			value:    1,
+			expected: &ReactionCommandConfig{Emoji: "+1", Events: []string{"issues", "pull_request", "issue_comment"}, Interval: "15m"},
+		},
+		{
+			name:     "object",
+			value:    map[string]any{"emoji": "eyes", "events": []any{"issue_comment", "issues", "issue_comment"}, "interval": "1h"},
+			expected: &ReactionCommandConfig{Emoji: "eyes", Events: []string{"issue_comment", "issues"}, Interval: "1h"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := parseReactionCommandConfig(tt.value)
+			require.NoError(t, err, "valid reaction_command should parse")
+			assert.Equal(t, tt.expected, config, "parsed config should match")
+		})
+	}
+}
+
+func TestParseReactionCommandConfigErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   any
+		wantErr string
+	}{
+		{name: "none emoji", value: "none", wantErr: "invalid reaction_command emoji 'none'"},
+		{name: "unknown emoji", value: "thumbsup", wantErr: "invalid reaction_command emoji"},
+		{name: "missing emoji", value: map[string]any{"interval": "15m"}, wantErr: "requires an 'emoji' field"},
+		{name: "unsupported event", value: map[string]any{"emoji": "eyes", "events": []any{"discussion"}}, wantErr: "event 'discussion' is not supported"},
+		{name: "invalid interval", value: map[string]any{"emoji": "eyes", "interval": "daily"}, wantErr: "'interval' must be minutes or hours"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseReactionCommandConfig(tt.value)
+			require.Error(t, err, "invalid reaction_command should be rejected")
+			assert.Contains(t, err.Error(), tt.wantErr, "error should explain the problem")
+		})
+	}
+}
+
+func TestReactionCommandIntervalMinutes(t *testing.T) {
+	assert.Equal(t, 15, reactionCommandIntervalMinutes("15m"), "minutes should be returned as-is")
+	assert.Equal(t, 120, reactionCommandIntervalMinutes("2h"), "hours should be converted to minutes")
+	assert.Equal(t, 0, reactionCommandIntervalMinutes("soon"), "invalid intervals should yield zero")
+}
+
+func TestCompileReactionCommand(t *testing.T) {
+	dir := t.TempDir()
+	markdownPath := filepath.Join(dir, "look.md")
+	content := `---
+on:
+  reaction_command:
+    emoji: eyes
+    events: [issues, pull_request]
+permissions:
+  contents: read
+  issues: read
+engine: copilot
+---
+
+# Look
+
+Take a look.
+`
+	require.NoError(t, os.WriteFile(markdownPath, []byte(content), 0o644), "workflow should be written")
+	compiler := NewCompiler()
+	compiler.SetWorkflowIdentifier("look.md")
+	require.NoError(t, compiler.CompileWorkflow(markdownPath), "workflow should compile")
+
+	lock, err := os.ReadFile(filepath.Join(dir, "look.lock.yml"))
+	require.NoError(t, err, "lock file should be written")
+	lockContent := string(lock)
+
+	assert.Contains(t, lockContent, "# reaction_command:", "reaction_command should be commented out of the on: section")
+	assert.Contains(t, lockContent, "Friendly format: every 15m", "reaction_command should poll on the default interval")
+	assert.Contains(t, lockContent, "workflow_dispatch:", "reaction_command should allow manual runs")
+	assert.Contains(t, lockContent, "id: check_reaction_command", "pre-activation should check for reactions")
+	assert.Contains(t, lockContent, `GH_AW_REACTION_COMMAND_EVENTS: "[\"issues\",\"pull_request\"]"`, "reaction check should receive the configured events")
+	assert.Contains(t, lockContent, "steps.check_reaction_command.outputs.reaction_command_ok == 'true'", "activation should depend on the reaction check")
+	assert.Contains(t, lockContent, "reaction_item_number: ${{ steps.check_reaction_command.outputs.reaction_item_number }}", "pre-activation should expose the matched item")
+	assert.Contains(t, lockContent, "pull-requests: read", "pull_request reactions require pull-requests: read")
+	assert.Contains(t, lockContent, "<reaction-command>", "prompt should describe the matched item")
+}
+
+func TestCompileReactionCommandExplicitSchedule(t *testing.T) {
+	dir := t.TempDir()
+	markdownPath := filepath.Join(dir, "look.md")
+	content := "---\non:\n  reaction_command: rocket\n  schedule: every 30m\nengine: copilot\n---\n\n# Look\n"
+	require.NoError(t, os.WriteFile(markdownPath, []byte(content), 0o644), "workflow should be written")
+	compiler := NewCompiler()
+	compiler.SetWorkflowIdentifier("look.md")
+	require.NoError(t, compiler.CompileWorkflow(markdownPath), "workflow should compile")
+
+	lock, err := os.ReadFile(filepath.Join(dir, "look.lock.yml"))
+	require.NoError(t, err, "lock file should be written")
+	assert.Contains(t, string(lock), "Friendly format: every 30m", "an explicit schedule should take precedence")
+	assert.NotContains(t, string(lock), "every 15m", "the default interval should not be added")
+}
@@ -174,23 +174,8 @@ func (c *Compiler) generateSecretRedactionStep(yaml *strings.Builder, yamlConten
 
 		// Add environment variables
 		yaml.WriteString("        env:\n")
-
-		// Pass the list of secret names as a comma-separated string
-		// Escape each secret reference to prevent injection when embedding in YAML
-		escapedRefs := make([]string, len(secretReferences))
-		for i, ref := range secretReferences {
-			escapedRefs[i] = escapeSingleQuoteBackslash(ref)
-		}
-		fmt.Fprintf(yaml, "          GH_AW_SECRET_NAMES: '%s'\n", strings.Join(escapedRefs, ","))
-
-		// Pass the actual secret values as environment variables so they can be redacted
-		// Each secret will be available as an environment variable
-		for _, secretName := range secretReferences {
-			// Escape secret name to prevent injection in YAML
-			escapedSecretName := escapeSingleQuoteBackslash(secretName)
-			// Use original secretName in GitHub Actions expression since it's already validated
-			// to only contain safe characters (uppercase letters, numbers, underscores)
-			fmt.Fprintf(yaml, "          SECRET_%s: ${{ secrets.%s }}\n", escapedSecretName, secretName)
+		for _, line := range secretRedactionEnvLines(secretReferences) {
+			yaml.WriteString(line)
 		}
 	}
 
@@ -203,6 +188,74 @@ func (c *Compiler) generateSecretRedactionStep(yaml *strings.Builder, yamlConten
 	}
 }
 
+// secretRedactionEnvLines returns the env entries that hand the referenced secrets to
+// redact_secrets.cjs: GH_AW_SECRET_NAMES lists the names and SECRET_<NAME> holds each value.
+func secretRedactionEnvLines(secretReferences []string) []string {
+	// Pass the list of secret names as a comma-separated string
+	// Escape each secret reference to prevent injection when embedding in YAML
+	escapedRefs := make([]string, len(secretReferences))
+	for i, ref := range secretReferences {
+		escapedRefs[i] = escapeSingleQuoteBackslash(ref)
+	}
+	lines := []string{fmt.Sprintf("          GH_AW_SECRET_NAMES: '%s'\n", strings.Join(escapedRefs, ","))}
+
+	// Pass the actual secret values as environment variables so they can be redacted
+	// Each secret will be available as an environment variable
+	for _, secretName := range secretReferences {
+		// Escape secret name to prevent injection in YAML
+		escapedSecretName := escapeSingleQuoteBackslash(secretName)
+		// Use original secretName in GitHub Actions expression since it's already validated
+		// to only contain safe characters (uppercase letters, numbers, underscores)
+		lines = append(lines, fmt.Sprintf("          SECRET_%s: ${{ secrets.%s }}\n", escapedSecretName, secretName))
+	}
+	return lines
+}
+
+// textArtifactPaths drops the git bundle globs from artifact upload paths. Bundles are
+// binary: rewriting a byte run that looks like a credential would corrupt the bundle
+// that create_pull_request applies later.
+func textArtifactPaths(paths []string) []string {
+	var result []string
+	for _, path := range paths {
+		if strings.HasSuffix(path, ".bundle") {
+			continue
+		}
+		result = append(result, path)
+	}
+	return result
+}
+
+// buildArtifactSecretRedactionStep creates a step that runs redact_secrets.cjs over the
+// given artifact paths only (GH_AW_REDACT_PATHS). It is emitted right before an upload so
+// files written after the main "Redact secrets in logs" step are redacted too. Unlike that
+// step it always runs the script: the built-in credential patterns apply without secrets.
+func buildArtifactSecretRedactionStep(name, condition string, paths, secretReferences []string, data *WorkflowData) []string {
+	secretMaskingLog.Printf("Generating %q for %d path(s) and %d secret(s)", name, len(paths), len(secretReferences))
+	steps := []string{
+		"      - name: " + name + "\n",
+		"        if: " + condition + "\n",
+		"        continue-on-error: true\n",
+		fmt.Sprintf("        uses: %s\n", getCachedActionPin("actions/github-script", data)),
+		"        env:\n",
+		"          GH_AW_REDACT_PATHS: |\n",
+	}
+	for _, path := range paths {
+		steps = append(steps, "            "+path+"\n")
+	}
+	if len(secretReferences) > 0 {
+		steps = append(steps, secretRedactionEnvLines(secretReferences)...)
+	}
+	steps = append(steps,
+		"        with:\n",
+		"          script: |\n",
+		"            const { setupGlobals } = require('"+SetupActionDestination+"/setup_globals.cjs');\n",
+		"            setupGlobals(core, github, context, exec, io, getOctokit);\n",
+		"            const { main } = require('"+SetupActionDestination+"/redact_secrets.cjs');\n",
+		"            await main();\n",
+	)
+	return steps
+}
+
 // generateCustomSecretMaskingStep generates a custom secret masking step from configuration
 func (c *Compiler) generateCustomSecretMaskingStep(yaml *strings.Builder, step map[string]any, data *WorkflowData) {
 	// Record the custom secret masking step for validation
@@ -325,3 +325,109 @@ Test workflow for secret redaction.
 		t.Error("Expected redaction step to have 'if: always()' condition")
 	}
 }
+
+func TestBuildArtifactSecretRedactionStep(t *testing.T) {
+	step := strings.Join(buildArtifactSecretRedactionStep("Redact secrets in agent artifacts", "always()",
+		[]string{"/tmp/gh-aw/agent-stdio.log", "/tmp/gh-aw/mcp-logs/"}, []string{"API_KEY"}, &WorkflowData{}), "")
+
+	for _, want := range []string{
+		"      - name: Redact secrets in agent artifacts\n        if: always()\n        continue-on-error: true\n",
+		"          GH_AW_REDACT_PATHS: |\n            /tmp/gh-aw/agent-stdio.log\n            /tmp/gh-aw/mcp-logs/\n",
+		"          GH_AW_SECRET_NAMES: 'API_KEY'\n          SECRET_API_KEY: ${{ secrets.API_KEY }}\n",
+		"require('" + SetupActionDestination + "/redact_secrets.cjs')",
+	} {
+		if !strings.Contains(step, want) {
+			t.Errorf("Expected step to contain %q, got:\n%s", want, step)
+		}
+	}
+
+	// Without secret references the step still runs for the built-in patterns
+	step = strings.Join(buildArtifactSecretRedactionStep("Redact", "always()", []string{"/tmp/gh-aw/a.log"}, nil, &WorkflowData{}), "")
+	if strings.Contains(step, "GH_AW_SECRET_NAMES") {
+		t.Errorf("Expected no GH_AW_SECRET_NAMES without secret references, got:\n%s", step)
+	}
+	if !strings.Contains(step, "redact_secrets.cjs") {
+		t.Errorf("Expected the step to run redact_secrets.cjs, got:\n%s", step)
+	}
+}
+
+func TestSecretRedactionBeforeArtifactUploads(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "artifact-redaction-test")
+
+	testWorkflow := `---
+on: push
+permissions:
+  contents: read
+  issues: read
+engine: copilot
+steps:
+  - name: Fetch data
+    run: ./fetch.sh
+    env:
+      DATA_API_KEY: ${{ secrets.DATA_API_KEY }}
+safe-outputs:
+  create-issue:
+  create-pull-request:
+    patch-format: bundle
+---
+
+# Test Workflow
+
+Test workflow for artifact secret redaction.
+`
+
+	testFile := filepath.Join(tmpDir, "test-workflow.md")
+	if err := os.WriteFile(testFile, []byte(testWorkflow), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	compiler := NewCompiler()
+	if err := compiler.CompileWorkflow(testFile); err != nil {
+		t.Fatalf("Failed to compile workflow: %v", err)
+	}
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	if err != nil {
+		t.Fatalf("Failed to read lock file: %v", err)
+	}
+	lockStr := string(lockContent)
+
+	// The final pass runs after the firewall summary steps and before the agent artifact upload
+	finalIdx := strings.Index(lockStr, "- name: Redact secrets in agent artifacts")
+	if finalIdx == -1 {
+		t.Fatal("Expected the agent artifact redaction step")
+	}
+	if idx := strings.Index(lockStr, "- name: Record blocked egress attempts"); idx == -1 || idx > finalIdx {
+		t.Error("Expected the agent artifact redaction step after the blocked egress step")
+	}
+	if idx := strings.Index(lockStr, "- name: Upload agent artifacts"); idx < finalIdx {
+		t.Error("Expected the agent artifact redaction step before the agent artifact upload")
+	}
+	if !strings.Contains(lockStr[finalIdx:], "SECRET_COPILOT_GITHUB_TOKEN: ${{ secrets.COPILOT_GITHUB_TOKEN }}") {
+		t.Error("Expected the agent artifact redaction step to receive the engine secret")
+	}
+	finalStep := lockStr[finalIdx:]
+	finalStep = finalStep[:strings.Index(finalStep, "- name: Upload agent artifacts")]
+	if !strings.Contains(finalStep, "aw-*.patch") {
+		t.Errorf("Expected the agent artifact redaction step to scan the patch files, got:\n%s", finalStep)
+	}
+	if strings.Contains(finalStep, ".bundle") {
+		t.Errorf("Expected the agent artifact redaction step to skip the binary git bundles, got:\n%s", finalStep)
+	}
+
+	// The detection job redacts its working directory before uploading the detection log
+	detectionIdx := strings.Index(lockStr, "- name: Redact secrets in threat detection files")
+	if detectionIdx == -1 {
+		t.Fatal("Expected the threat detection redaction step")
+	}
+	if idx := strings.Index(lockStr, "- name: Upload threat detection log"); idx < detectionIdx {
+		t.Error("Expected the threat detection redaction step before the detection log upload")
+	}
+	if !strings.Contains(lockStr[detectionIdx:], "            ${{ env.GH_AW_TMP_DIR }}/threat-detection/\n") {
+		t.Error("Expected the threat detection redaction step to scan the detection directory")
+	}
+	// The detection log can echo the agent output, so the agent job's secrets are redacted too
+	if !strings.Contains(lockStr[detectionIdx:], "SECRET_DATA_API_KEY: ${{ secrets.DATA_API_KEY }}") {
+		t.Error("Expected the threat detection redaction step to receive the secrets of the agent job")
+	}
+}
@@ -10,8 +10,17 @@
 //		  "ghes": true,               // enables GHES compatibility mode (artifact pins remain latest non-v3)
 //		  "help_command": false,      // disables builtin centralized /help comment handler
 //		  "utc": "-08:00", // project home UTC offset for rendered local times
+//		  "mcp_registry": "https://mcp.acme.com/v0.1", // MCP registry used by gh aw mcp add
 //		  "auto_upgrade": true, // set to true to generate agentic-auto-upgrade.yml with weekly schedule
 //		  "auto_upgrade": { "cron": "0 9 * * 1" }, // or object form: enable with custom cron (Monday 09:00 UTC)
+//		  "warm_runners": {           // generate agentic-warm-runners.yml to pre-provision self-hosted runners
+//		    "runs_on": ["self-hosted", "linux"], // runner label(s) to warm
+//		    "cron": "0 */2 * * *"     // optional custom schedule (default: every 6 hours, scattered)
+//		  },
+//		  "runners": {                // restrict the runners and images workflows may request
+//		    "allowed_runs_on": ["ubuntu-latest", "self-hosted", "gpu-*"], // runner labels and groups
+//		    "allowed_images": ["node:20", "ghcr.io/acme/*"] // container and services images
+//		  },
 //		  "action_pins": {            // redirect action references to internal mirrors
 //		    "actions/checkout@v4": "acme-corp/checkout@v4"
 //		  },
@@ -96,6 +105,28 @@ type MaintenanceCompileConfig struct {
 	CreatePullRequestGitHubToken string `json:"create_pull_request_github_token,omitempty"`
 }
 
+// WarmRunnersConfig holds the settings of the agentic-warm-runners workflow from aw.json.
+type WarmRunnersConfig struct {
+	// RunsOn is the self-hosted runner label or labels to warm.
+	RunsOn RunsOnValue `json:"runs_on"`
+
+	// Cron is an optional custom cron expression that overrides the default
+	// fuzzy schedule of every 6 hours.
+	Cron string `json:"cron,omitempty"`
+}
+
+// RunnersConfig holds the runner and image allowlists from aw.json. Entries may
+// use * to match any sequence of characters; an empty list allows any value.
+type RunnersConfig struct {
+	// AllowedRunsOn lists the runner labels and runner group names that
+	// workflows may request in runs-on fields.
+	AllowedRunsOn []string `json:"allowed_runs_on,omitempty"`
+
+	// AllowedImages lists the images that workflows may use for the job
+	// container and services.
+	AllowedImages []string `json:"allowed_images,omitempty"`
+}
+
 type MaintenanceConfig struct {
 	// RunsOn is the runner label or labels used for all jobs in agentics-maintenance.yml.
 	RunsOn RunsOnValue `json:"runs_on,omitempty"`
@@ -182,6 +213,15 @@ type RepoConfig struct {
 	// the default fuzzy weekly schedule. Requires AutoUpgrade to be true.
 	AutoUpgradeCron string
 
+	// WarmRunners enables generation of agentic-warm-runners.yml, which pre-installs
+	// engine CLIs and pulls container images onto self-hosted runners on a schedule.
+	// nil when warm_runners is not configured.
+	WarmRunners *WarmRunnersConfig
+
+	// Runners restricts the runner labels, container image, and service images
+	// that workflows may request. nil when runners is not configured.
+	Runners *RunnersConfig
+
 	// MaintenanceDisabled is true when maintenance has been explicitly set to false
 	// in aw.json, disabling agentic-maintenance generation and any features that
 	// depend on it (such as expires).
@@ -205,6 +245,10 @@ type RepoConfig struct {
 	// with separate image ref and SHA-256 digest fields so that each
 	// component can be validated independently.
 	ContainerPins map[string]ContainerPinTarget
+
+	// MCPRegistry is the base URL of the MCP registry searched by "gh aw mcp add"
+	// when --registry is not given. Empty means the default public registry.
+	MCPRegistry string
 }
 
 // ContainerPinTarget holds the replacement image reference for a container_pins
@@ -240,9 +284,12 @@ func (r *RepoConfig) UnmarshalJSON(data []byte) error {
 		HelpCommand   *bool                         `json:"help_command,omitempty"` // nil = use default (enabled)
 		UTC           string                        `json:"utc,omitempty"`
 		AutoUpgrade   json.RawMessage               `json:"auto_upgrade,omitempty"`
+		WarmRunners   *WarmRunnersConfig            `json:"warm_runners,omitempty"`
+		Runners       *RunnersConfig                `json:"runners,omitempty"`
 		Maintenance   json.RawMessage               `json:"maintenance,omitempty"`
 		ActionPins    map[string]string             `json:"action_pins,omitempty"`
 		ContainerPins map[string]ContainerPinTarget `json:"container_pins,omitempty"`
+		MCPRegistry   string                        `json:"mcp_registry,omitempty"`
 	}
 	if err := json.Unmarshal(data, &raw); err != nil {
 		return err
@@ -253,6 +300,12 @@ func (r *RepoConfig) UnmarshalJSON(data []byte) error {
 	r.UTC = strings.TrimSpace(raw.UTC)
 	r.ActionPins = raw.ActionPins
 	r.ContainerPins = raw.ContainerPins
+	r.MCPRegistry = strings.TrimRight(strings.TrimSpace(raw.MCPRegistry), "/")
+	r.WarmRunners = raw.WarmRunners
+	r.Runners = raw.Runners
+	if r.WarmRunners != nil {
+		r.WarmRunners.Cron = strings.TrimSpace(r.WarmRunners.Cron)
+	}
 
 	// Parse polymorphic auto_upgrade: boolean or { "cron": "..." } object.
 	if len(raw.AutoUpgrade) > 0 && string(raw.AutoUpgrade) != "null" {
@@ -377,6 +430,16 @@ func validateRepoConfigValues(cfg *RepoConfig) error {
 			return fmt.Errorf("invalid %s: auto_upgrade.cron %w", RepoConfigFileName, err)
 		}
 	}
+	if cfg.WarmRunners != nil {
+		if len(cfg.WarmRunners.RunsOn) == 0 {
+			return fmt.Errorf("invalid %s: warm_runners.runs_on is required", RepoConfigFileName)
+		}
+		if cfg.WarmRunners.Cron != "" {
+			if err := validateCronExpression(cfg.WarmRunners.Cron); err != nil {
+				return fmt.Errorf("invalid %s: warm_runners.cron %w", RepoConfigFileName, err)
+			}
+		}
+	}
 	if cfg.Maintenance != nil {
 		seenDisabledJobs := map[string]string{}
 		for _, jobName := range cfg.Maintenance.DisabledJobs {
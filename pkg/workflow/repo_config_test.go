@@ -413,6 +413,49 @@ func TestLoadRepoConfig_InvalidUTC(t *testing.T) {
 	require.ErrorContains(t, err, "utc must be a numeric UTC offset")
 }
 
+func TestLoadRepoConfig_MCPRegistry(t *testing.T) {
+	dir := t.TempDir()
+	writeAWJSON(t, dir, `{"mcp_registry": "https://mcp.example.com/v0.1/"}`)
+
+	cfg, err := LoadRepoConfig(dir)
+	require.NoError(t, err, "valid aw.json with mcp_registry should load without error")
+	assert.Equal(t, "https://mcp.example.com/v0.1", cfg.MCPRegistry, "trailing slash should be trimmed")
+
+	writeAWJSON(t, dir, `{"mcp_registry": "mcp.example.com"}`)
+	_, err = LoadRepoConfig(dir)
+	require.Error(t, err, "mcp_registry without a scheme should be rejected")
+}
+
+func TestLoadRepoConfig_WarmRunners(t *testing.T) {
+	dir := t.TempDir()
+	writeAWJSON(t, dir, `{"warm_runners": {"runs_on": ["self-hosted", "linux"], "cron": "0 */2 * * *"}}`)
+
+	cfg, err := LoadRepoConfig(dir)
+	require.NoError(t, err, "valid aw.json with warm_runners should load without error")
+	require.NotNil(t, cfg.WarmRunners, "warm_runners config should be set")
+	assert.Equal(t, RunsOnValue{"self-hosted", "linux"}, cfg.WarmRunners.RunsOn, "runs_on should be deserialised as RunsOnValue")
+	assert.Equal(t, "0 */2 * * *", cfg.WarmRunners.Cron, "cron should be set")
+
+	writeAWJSON(t, dir, `{"warm_runners": {"cron": "0 */2 * * *"}}`)
+	_, err = LoadRepoConfig(dir)
+	require.Error(t, err, "warm_runners without runs_on should be rejected")
+}
+
+func TestLoadRepoConfig_Runners(t *testing.T) {
+	dir := t.TempDir()
+	writeAWJSON(t, dir, `{"runners": {"allowed_runs_on": ["ubuntu-latest", "gpu-*"], "allowed_images": ["postgres:*"]}}`)
+
+	cfg, err := LoadRepoConfig(dir)
+	require.NoError(t, err, "valid aw.json with runners should load without error")
+	require.NotNil(t, cfg.Runners, "runners config should be set")
+	assert.Equal(t, []string{"ubuntu-latest", "gpu-*"}, cfg.Runners.AllowedRunsOn, "allowed_runs_on should be set")
+	assert.Equal(t, []string{"postgres:*"}, cfg.Runners.AllowedImages, "allowed_images should be set")
+
+	writeAWJSON(t, dir, `{"runners": {"allowed_labels": ["ubuntu-latest"]}}`)
+	_, err = LoadRepoConfig(dir)
+	require.Error(t, err, "unknown runners fields should be rejected")
+}
+
 // TestFormatRunsOn tests the YAML serialisation of runs-on values.
 func TestFormatRunsOn(t *testing.T) {
 	const def = "ubuntu-slim"
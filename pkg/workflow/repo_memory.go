@@ -264,7 +264,7 @@ func applyRepoMemoryLimits(entry *RepoMemoryEntry, memoryMap map[string]any) err
 		{key: "max-patch-size", min: 1, max: maxRepoMemoryPatchSize, field: &entry.MaxPatchSize},
 	}
 	for _, limit := range limits {
-		if value, ok := parseRepoMemoryInt(memoryMap[limit.key]); ok {
+		if value, ok := parseIntValue(memoryMap[limit.key]); ok {
 			*limit.field = value
 			if err := validateIntRange(value, limit.min, limit.max, limit.key); err != nil {
 				return err
@@ -316,19 +316,6 @@ func parseRepoMemoryStringList(value any) []string {
 	}
 }
 
-func parseRepoMemoryInt(value any) (int, bool) {
-	switch v := value.(type) {
-	case int:
-		return v, true
-	case float64:
-		return int(v), true
-	case uint64:
-		return int(v), true
-	default:
-		return 0, false
-	}
-}
-
 // generateRepoMemoryArtifactUpload generates steps to upload repo-memory directories as artifacts.
 // This runs at the end of the agent job (always condition) to save the state.
 // pinAction resolves the upload-artifact action reference; pass c.getActionPin from Compiler methods.
@@ -535,7 +522,7 @@ func (c *Compiler) buildPushRepoMemoryDownloadSteps(data *WorkflowData) []string
 		step.WriteString("        continue-on-error: true\n")
 		step.WriteString("        with:\n")
 		fmt.Fprintf(&step, "          name: %srepo-memory-%s\n", repoMemoryPrefix, sanitizedID)
-		fmt.Fprintf(&step, "          path: /tmp/gh-aw/repo-memory/%s\n", memory.ID)
+		fmt.Fprintf(&step, "          path: "+constants.TmpGhAwDirExpr+"/repo-memory/%s\n", memory.ID)
 		steps = append(steps, step.String())
 	}
 	return steps
@@ -27,32 +27,32 @@ func TestRepoMemoryPathConsistencyAcrossLayers(t *testing.T) {
 			name:                 "default memory",
 			memoryID:             "default",
 			branchName:           "memory/default",
-			expectedMemoryDir:    "/tmp/gh-aw/repo-memory/default",
-			expectedPromptPath:   "/tmp/gh-aw/repo-memory/default/",
+			expectedMemoryDir:    "${{ env.GH_AW_TMP_DIR }}/repo-memory/default",
+			expectedPromptPath:   "__GH_AW_TMP_DIR__/repo-memory/default/",
 			expectedArtifactName: "repo-memory-default",
 		},
 		{
 			name:                 "custom memory ID",
 			memoryID:             "session",
 			branchName:           "memory/session",
-			expectedMemoryDir:    "/tmp/gh-aw/repo-memory/session",
-			expectedPromptPath:   "/tmp/gh-aw/repo-memory/session/",
+			expectedMemoryDir:    "${{ env.GH_AW_TMP_DIR }}/repo-memory/session",
+			expectedPromptPath:   "__GH_AW_TMP_DIR__/repo-memory/session/",
 			expectedArtifactName: "repo-memory-session",
 		},
 		{
 			name:                 "campaigns memory",
 			memoryID:             "campaigns",
 			branchName:           "memory/campaigns",
-			expectedMemoryDir:    "/tmp/gh-aw/repo-memory/campaigns",
-			expectedPromptPath:   "/tmp/gh-aw/repo-memory/campaigns/",
+			expectedMemoryDir:    "${{ env.GH_AW_TMP_DIR }}/repo-memory/campaigns",
+			expectedPromptPath:   "__GH_AW_TMP_DIR__/repo-memory/campaigns/",
 			expectedArtifactName: "repo-memory-campaigns",
 		},
 		{
 			name:                 "hyphenated memory ID",
 			memoryID:             "code-metrics",
 			branchName:           "memory/code-metrics",
-			expectedMemoryDir:    "/tmp/gh-aw/repo-memory/code-metrics",
-			expectedPromptPath:   "/tmp/gh-aw/repo-memory/code-metrics/",
+			expectedMemoryDir:    "${{ env.GH_AW_TMP_DIR }}/repo-memory/code-metrics",
+			expectedPromptPath:   "__GH_AW_TMP_DIR__/repo-memory/code-metrics/",
 			expectedArtifactName: "repo-memory-codemetrics", // Sanitized: hyphens removed
 		},
 	}
@@ -198,7 +198,7 @@ func TestRepoMemoryPromptPathTrailingSlash(t *testing.T) {
 			require.NotNil(t, section, "Should have a prompt section")
 			assert.True(t, section.IsFile, "Should use template file")
 
-			expectedPath := fmt.Sprintf("/tmp/gh-aw/repo-memory/%s/", tt.memoryID)
+			expectedPath := fmt.Sprintf("__GH_AW_TMP_DIR__/repo-memory/%s/", tt.memoryID)
 
 			if tt.memoryID == "default" {
 				// Single default memory uses GH_AW_MEMORY_DIR
@@ -243,11 +243,11 @@ func TestRepoMemoryArtifactPathNoTrailingSlash(t *testing.T) {
 	uploadOutput := uploadBuilder.String()
 
 	// Should have path without trailing slash
-	assert.Contains(t, uploadOutput, "path: /tmp/gh-aw/repo-memory/test\n",
+	assert.Contains(t, uploadOutput, "path: ${{ env.GH_AW_TMP_DIR }}/repo-memory/test\n",
 		"Artifact upload path should not have trailing slash")
 
 	// Should not have path with trailing slash
-	assert.NotContains(t, uploadOutput, "path: /tmp/gh-aw/repo-memory/test/",
+	assert.NotContains(t, uploadOutput, "path: ${{ env.GH_AW_TMP_DIR }}/repo-memory/test/",
 		"Artifact upload path must not have trailing slash")
 
 	// Test artifact download in push job
@@ -258,7 +258,7 @@ func TestRepoMemoryArtifactPathNoTrailingSlash(t *testing.T) {
 
 	pushJobOutput := strings.Join(pushJob.Steps, "\n")
 
-	assert.Contains(t, pushJobOutput, "path: /tmp/gh-aw/repo-memory/test\n",
+	assert.Contains(t, pushJobOutput, "path: ${{ env.GH_AW_TMP_DIR }}/repo-memory/test\n",
 		"Artifact download path should not have trailing slash")
 }
 
@@ -396,9 +396,9 @@ func TestRepoMemoryMultipleMemoriesPathConsistency(t *testing.T) {
 	assert.Equal(t, repoMemoryPromptMultiFile, section.Content, "Should use multi memory template")
 
 	memoryList := section.EnvVars["GH_AW_MEMORY_LIST"]
-	assert.Contains(t, memoryList, "/tmp/gh-aw/repo-memory/session/",
+	assert.Contains(t, memoryList, "__GH_AW_TMP_DIR__/repo-memory/session/",
 		"Memory list should contain session memory path")
-	assert.Contains(t, memoryList, "/tmp/gh-aw/repo-memory/logs/",
+	assert.Contains(t, memoryList, "__GH_AW_TMP_DIR__/repo-memory/logs/",
 		"Memory list should contain logs memory path")
 
 	// Test artifact upload
@@ -408,11 +408,11 @@ func TestRepoMemoryMultipleMemoriesPathConsistency(t *testing.T) {
 
 	assert.Contains(t, uploadOutput, "name: repo-memory-session",
 		"Should upload session artifact")
-	assert.Contains(t, uploadOutput, "path: /tmp/gh-aw/repo-memory/session",
+	assert.Contains(t, uploadOutput, "path: ${{ env.GH_AW_TMP_DIR }}/repo-memory/session",
 		"Should use correct session path")
 	assert.Contains(t, uploadOutput, "name: repo-memory-logs",
 		"Should upload logs artifact")
-	assert.Contains(t, uploadOutput, "path: /tmp/gh-aw/repo-memory/logs",
+	assert.Contains(t, uploadOutput, "path: ${{ env.GH_AW_TMP_DIR }}/repo-memory/logs",
 		"Should use correct logs path")
 
 	// Test clone steps
@@ -422,11 +422,11 @@ func TestRepoMemoryMultipleMemoriesPathConsistency(t *testing.T) {
 
 	assert.Contains(t, cloneOutput, "Clone repo-memory branch (session)",
 		"Should have clone step for session")
-	assert.Contains(t, cloneOutput, "MEMORY_DIR: /tmp/gh-aw/repo-memory/session",
+	assert.Contains(t, cloneOutput, "MEMORY_DIR: ${{ env.GH_AW_TMP_DIR }}/repo-memory/session",
 		"Should use correct session directory")
 	assert.Contains(t, cloneOutput, "Clone repo-memory branch (logs)",
 		"Should have clone step for logs")
-	assert.Contains(t, cloneOutput, "MEMORY_DIR: /tmp/gh-aw/repo-memory/logs",
+	assert.Contains(t, cloneOutput, "MEMORY_DIR: ${{ env.GH_AW_TMP_DIR }}/repo-memory/logs",
 		"Should use correct logs directory")
 
 	// Test push job
@@ -439,11 +439,11 @@ func TestRepoMemoryMultipleMemoriesPathConsistency(t *testing.T) {
 
 	assert.Contains(t, pushJobOutput, "name: repo-memory-session",
 		"Should download session artifact")
-	assert.Contains(t, pushJobOutput, "ARTIFACT_DIR: /tmp/gh-aw/repo-memory/session",
+	assert.Contains(t, pushJobOutput, "ARTIFACT_DIR: ${{ env.GH_AW_TMP_DIR }}/repo-memory/session",
 		"Should use correct session artifact directory")
 	assert.Contains(t, pushJobOutput, "name: repo-memory-logs",
 		"Should download logs artifact")
-	assert.Contains(t, pushJobOutput, "ARTIFACT_DIR: /tmp/gh-aw/repo-memory/logs",
+	assert.Contains(t, pushJobOutput, "ARTIFACT_DIR: ${{ env.GH_AW_TMP_DIR }}/repo-memory/logs",
 		"Should use correct logs artifact directory")
 }
 
@@ -483,8 +483,8 @@ func TestRepoMemoryPathComponentIsolation(t *testing.T) {
 	// Combine all output (using memory list from prompt section EnvVars)
 	allOutput := memoryList + uploadBuilder.String() + cloneBuilder.String() + pushJobOutput
 
-	// Verify consistent use of /tmp/gh-aw/repo-memory/test
-	expectedMemoryDir := "/tmp/gh-aw/repo-memory/test"
+	// Verify consistent use of ${{ env.GH_AW_TMP_DIR }}/repo-memory/test
+	expectedMemoryDir := "${{ env.GH_AW_TMP_DIR }}/repo-memory/test"
 	assert.Contains(t, allOutput, expectedMemoryDir,
 		"All layers should use same memory directory base path")
 
@@ -497,10 +497,10 @@ func TestRepoMemoryPathComponentIsolation(t *testing.T) {
 		"Artifact name should follow naming convention")
 
 	// Verify no path leakage (e.g., branch name in memory path)
-	assert.NotContains(t, allOutput, "/tmp/gh-aw/repo-memory/memory/test",
+	assert.NotContains(t, allOutput, "${{ env.GH_AW_TMP_DIR }}/repo-memory/memory/test",
 		"Memory path should not include branch name prefix")
 
 	// Verify no artifact name leakage (e.g., artifact prefix in paths)
-	assert.NotContains(t, allOutput, "/tmp/gh-aw/repo-memory-test",
+	assert.NotContains(t, allOutput, "${{ env.GH_AW_TMP_DIR }}/repo-memory-test",
 		"Memory path should not use artifact naming convention")
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/github/gh-aw/pkg/constants"
 	"github.com/github/gh-aw/pkg/logger"
 	"github.com/github/gh-aw/pkg/sliceutil"
 )
@@ -25,7 +26,7 @@ func buildRepoMemoryPromptSection(config *RepoMemoryConfig) *PromptSection {
 	// Check if there's only one memory with ID "default" to use singular template
 	if len(config.Memories) == 1 && config.Memories[0].ID == "default" {
 		memory := config.Memories[0]
-		memoryDir := fmt.Sprintf("/tmp/gh-aw/repo-memory/%s/", memory.ID)
+		memoryDir := fmt.Sprintf(constants.TmpGhAwDirPlaceholder+"/repo-memory/%s/", memory.ID)
 
 		repoMemoryPromptLog.Printf("Building single default repo memory prompt section: branch=%s", memory.BranchName)
 
@@ -101,7 +102,7 @@ func buildRepoMemoryPromptSection(config *RepoMemoryConfig) *PromptSection {
 	// Build memory list
 	var memoryList strings.Builder
 	for _, memory := range config.Memories {
-		memoryDir := fmt.Sprintf("/tmp/gh-aw/repo-memory/%s/", memory.ID)
+		memoryDir := fmt.Sprintf(constants.TmpGhAwDirPlaceholder+"/repo-memory/%s/", memory.ID)
 		fmt.Fprintf(&memoryList, "- **%s**: `%s`", memory.ID, memoryDir)
 		if memory.Description != "" {
 			fmt.Fprintf(&memoryList, " - %s", memory.Description)
@@ -235,12 +235,12 @@ func TestRepoMemoryStepsGeneration(t *testing.T) {
 		t.Error("Expected CREATE_ORPHAN environment variable")
 	}
 
-	if !strings.Contains(output, "MEMORY_DIR: /tmp/gh-aw/repo-memory/default") {
+	if !strings.Contains(output, "MEMORY_DIR: ${{ env.GH_AW_TMP_DIR }}/repo-memory/default") {
 		t.Error("Expected MEMORY_DIR environment variable")
 	}
 
 	// Check for memory directory creation
-	if !strings.Contains(output, "/tmp/gh-aw/repo-memory/default") {
+	if !strings.Contains(output, "${{ env.GH_AW_TMP_DIR }}/repo-memory/default") {
 		t.Error("Expected memory directory path")
 	}
 }
@@ -312,7 +312,7 @@ func TestGenerateRepoMemoryArtifactUpload(t *testing.T) {
 				"continue-on-error should appear before env:")
 
 			// Sanitize step must set MEMORY_DIR and call the script
-			expectedDir := "/tmp/gh-aw/repo-memory/" + tt.memory.ID
+			expectedDir := "${{ env.GH_AW_TMP_DIR }}/repo-memory/" + tt.memory.ID
 			assert.Contains(t, sanitizeSection, "MEMORY_DIR: "+expectedDir,
 				"Sanitize step should set MEMORY_DIR env var")
 			assert.Contains(t, sanitizeSection, "sanitize_repo_memory_filenames.sh",
@@ -341,7 +341,7 @@ func TestRepoMemoryPromptGeneration(t *testing.T) {
 	require.NotNil(t, section.EnvVars, "Should have environment variables")
 
 	// Check for prompt header key
-	assert.Equal(t, "/tmp/gh-aw/repo-memory/default/", section.EnvVars["GH_AW_MEMORY_DIR"], "Should have correct memory directory")
+	assert.Equal(t, "__GH_AW_TMP_DIR__/repo-memory/default/", section.EnvVars["GH_AW_MEMORY_DIR"], "Should have correct memory directory")
 
 	// Check for description
 	assert.Equal(t, " Persistent memory for agent state", section.EnvVars["GH_AW_MEMORY_DESCRIPTION"], "Expected custom description with leading space")
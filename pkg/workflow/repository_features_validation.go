@@ -88,6 +88,10 @@ func (c *Compiler) validateRepositoryFeatures(workflowData *WorkflowData) error
 	if workflowData.SafeOutputs == nil {
 		return nil
 	}
+	if IsOfflineMode() {
+		repositoryFeaturesLog.Print("Skipping repository feature validation in offline mode")
+		return nil
+	}
 
 	repositoryFeaturesLog.Print("Validating repository features for safe-outputs")
 
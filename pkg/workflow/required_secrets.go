@@ -0,0 +1,116 @@
+package workflow
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var requiredSecretsLog = logger.New("workflow:required_secrets")
+
+// collectMCPServerSecretReferences returns every secret referenced by the env and headers of
+// custom MCP servers, mapped to the sorted names of the servers that reference it.
+// GITHUB_TOKEN is skipped because it is always available to the workflow.
+func collectMCPServerSecretReferences(tools map[string]any) map[string][]string {
+	references := make(map[string][]string)
+	for _, toolName := range slices.Sorted(maps.Keys(tools)) {
+		toolConfig, ok := tools[toolName].(map[string]any)
+		if !ok {
+			continue
+		}
+		if hasMcp, _ := hasMCPConfig(toolConfig); !hasMcp {
+			continue
+		}
+		mcpConfig, err := getMCPConfig(toolConfig, toolName)
+		if err != nil {
+			continue
+		}
+		secrets := ExtractSecretsFromMap(mcpConfig.Env)
+		maps.Copy(secrets, ExtractSecretsFromMap(mcpConfig.Headers))
+		for secretName := range secrets {
+			if secretName == "GITHUB_TOKEN" {
+				continue
+			}
+			references[secretName] = append(references[secretName], toolName)
+		}
+	}
+	requiredSecretsLog.Printf("Collected %d secrets referenced by MCP servers", len(references))
+	return references
+}
+
+// extractRequiredSecrets returns the secret names declared in the required-secrets frontmatter field.
+func extractRequiredSecrets(frontmatter map[string]any) []string {
+	return parseStringSliceAny(frontmatter["required-secrets"], requiredSecretsLog)
+}
+
+// validateRequiredSecrets checks that every secret referenced by MCP server env or headers is
+// listed in required-secrets. The check is opt-in: workflows that do not declare
+// required-secrets are not validated so existing workflows keep compiling.
+func validateRequiredSecrets(workflowData *WorkflowData) error {
+	declared := workflowData.RequiredSecrets
+	if len(declared) == 0 {
+		return nil
+	}
+
+	references := collectMCPServerSecretReferences(workflowData.Tools)
+	var missing []string
+	for _, secretName := range slices.Sorted(maps.Keys(references)) {
+		if !slices.Contains(declared, secretName) {
+			missing = append(missing, secretName)
+		}
+	}
+	if len(missing) == 0 {
+		requiredSecretsLog.Printf("All %d MCP server secrets are declared in required-secrets", len(references))
+		return nil
+	}
+
+	usages := make([]string, 0, len(missing))
+	for _, secretName := range missing {
+		usages = append(usages, fmt.Sprintf("%s (used by %s)", secretName, strings.Join(references[secretName], ", ")))
+	}
+	var example strings.Builder
+	example.WriteString("Add them to required-secrets so the workflow fails early with a clear message when they are not set. Example:\n\nrequired-secrets:")
+	for _, secretName := range slices.Concat(declared, missing) {
+		fmt.Fprintf(&example, "\n  - %s", secretName)
+	}
+	return NewValidationError(
+		"required-secrets",
+		strings.Join(missing, ", "),
+		"MCP servers reference secrets that are not listed in required-secrets: "+strings.Join(usages, "; "),
+		example.String(),
+	)
+}
+
+// buildRequiredSecretsValidationStep returns the activation step that fails the run with a
+// list of missing secrets when any secret in required-secrets is not set. It returns nil when
+// required-secrets is not declared or a top-level environment is configured, because
+// environment secrets are not available to the activation job.
+func buildRequiredSecretsValidationStep(workflowData *WorkflowData) []string {
+	var secretNames []string
+	for _, secretName := range workflowData.RequiredSecrets {
+		if secretName != "GITHUB_TOKEN" {
+			secretNames = append(secretNames, secretName)
+		}
+	}
+	if len(secretNames) == 0 {
+		return nil
+	}
+	if strings.TrimSpace(workflowData.Environment) != "" {
+		requiredSecretsLog.Print("Skipping required secrets validation step: top-level environment is configured")
+		return nil
+	}
+
+	lines := []string{
+		"      - name: Validate required secrets",
+		"        id: validate-required-secrets",
+		"        run: bash \"${RUNNER_TEMP}/gh-aw/actions/validate_required_secrets.sh\" " + shellJoinArgs(secretNames),
+		"        env:",
+	}
+	for _, secretName := range secretNames {
+		lines = appendEnvVarLine(lines, secretName, fmt.Sprintf("${{ secrets.%s }}", secretName))
+	}
+	return lines
+}
@@ -0,0 +1,129 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/github/gh-aw/pkg/testutil"
+)
+
+func TestCollectMCPServerSecretReferences(t *testing.T) {
+	tools := map[string]any{
+		"github": map[string]any{"toolsets": []any{"default"}},
+		"notion": map[string]any{
+			"container": "mcp/notion",
+			"env": map[string]any{
+				"NOTION_TOKEN": "${{ secrets.NOTION_TOKEN }}",
+				"GH_TOKEN":     "${{ secrets.GITHUB_TOKEN }}",
+			},
+		},
+		"datadog": map[string]any{
+			"url": "https://mcp.datadoghq.com/mcp",
+			"headers": map[string]any{
+				"DD-API-KEY": "${{ secrets.DD_API_KEY }}",
+			},
+		},
+		"notes": map[string]any{
+			"command": "npx",
+			"args":    []any{"-y", "notion-notes-mcp"},
+			"env": map[string]any{
+				"NOTION_TOKEN": "${{ secrets.NOTION_TOKEN }}",
+			},
+		},
+	}
+
+	references := collectMCPServerSecretReferences(tools)
+	assert.Equal(t, map[string][]string{
+		"NOTION_TOKEN": {"notes", "notion"},
+		"DD_API_KEY":   {"datadog"},
+	}, references, "env and header secrets should be collected per server, without GITHUB_TOKEN")
+}
+
+func TestValidateRequiredSecrets(t *testing.T) {
+	tools := map[string]any{
+		"notion": map[string]any{
+			"container": "mcp/notion",
+			"env":       map[string]any{"NOTION_TOKEN": "${{ secrets.NOTION_TOKEN }}"},
+		},
+		"datadog": map[string]any{
+			"url":     "https://mcp.datadoghq.com/mcp",
+			"headers": map[string]any{"DD-API-KEY": "${{ secrets.DD_API_KEY }}"},
+		},
+	}
+
+	t.Run("not declared is not validated", func(t *testing.T) {
+		data := &WorkflowData{Tools: tools}
+		assert.NoError(t, validateRequiredSecrets(data), "validation should be opt-in")
+	})
+
+	t.Run("all declared", func(t *testing.T) {
+		data := &WorkflowData{Tools: tools, RequiredSecrets: []string{"NOTION_TOKEN", "DD_API_KEY"}}
+		assert.NoError(t, validateRequiredSecrets(data), "declared secrets should pass")
+	})
+
+	t.Run("undeclared secret", func(t *testing.T) {
+		data := &WorkflowData{Tools: tools, RequiredSecrets: []string{"NOTION_TOKEN"}}
+		err := validateRequiredSecrets(data)
+		require.Error(t, err, "undeclared secret should be rejected")
+		assert.Contains(t, err.Error(), "DD_API_KEY (used by datadog)", "error should name the secret and server")
+		assert.Contains(t, err.Error(), "required-secrets:\n  - NOTION_TOKEN\n  - DD_API_KEY", "suggestion should show the full list")
+	})
+}
+
+func TestBuildRequiredSecretsValidationStep(t *testing.T) {
+	assert.Nil(t, buildRequiredSecretsValidationStep(&WorkflowData{}), "no step without required-secrets")
+
+	data := &WorkflowData{RequiredSecrets: []string{"NOTION_TOKEN", "GITHUB_TOKEN", "DD_API_KEY"}}
+	step := strings.Join(buildRequiredSecretsValidationStep(data), "\n")
+	assert.Contains(t, step, "id: validate-required-secrets", "step should have a stable id")
+	assert.Contains(t, step, `validate_required_secrets.sh" NOTION_TOKEN DD_API_KEY`, "script should receive the secret names")
+	assert.Contains(t, step, "NOTION_TOKEN: ${{ secrets.NOTION_TOKEN }}", "secrets should be passed through env")
+	assert.NotContains(t, step, "secrets.GITHUB_TOKEN", "GITHUB_TOKEN should not be validated")
+
+	data.Environment = "production"
+	assert.Nil(t, buildRequiredSecretsValidationStep(data), "environment secrets are not visible to the activation job")
+}
+
+func TestRequiredSecretsCompiledWorkflow(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "required-secrets-test")
+	frontmatter := `---
+on: issues
+permissions:
+  contents: read
+engine: copilot
+required-secrets: [NOTION_TOKEN]
+mcp-servers:
+  notion:
+    container: mcp/notion
+    env:
+      NOTION_TOKEN: ${{ secrets.NOTION_TOKEN }}
+%s---
+
+# Notion
+
+Summarize the issue into Notion.
+`
+
+	workflowPath := filepath.Join(tmpDir, "notion.md")
+	require.NoError(t, os.WriteFile(workflowPath, []byte(strings.Replace(frontmatter, "%s", "", 1)), 0o644), "should write workflow")
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(workflowPath), "workflow should compile")
+
+	lockContent, err := os.ReadFile(filepath.Join(tmpDir, "notion.lock.yml"))
+	require.NoError(t, err, "should read lock file")
+	assert.Contains(t, string(lockContent), "- name: Validate required secrets", "activation job should validate required secrets")
+
+	undeclaredPath := filepath.Join(tmpDir, "undeclared.md")
+	undeclared := strings.Replace(frontmatter, "%s", "  datadog:\n    url: https://mcp.datadoghq.com/mcp\n    headers:\n      DD-API-KEY: ${{ secrets.DD_API_KEY }}\n", 1)
+	require.NoError(t, os.WriteFile(undeclaredPath, []byte(undeclared), 0o644), "should write workflow")
+	err = NewCompiler().CompileWorkflow(undeclaredPath)
+	require.Error(t, err, "undeclared MCP secret should fail compilation")
+	assert.Contains(t, err.Error(), "DD_API_KEY (used by datadog)", "error should list the undeclared secret")
+}
@@ -0,0 +1,197 @@
+// This file selects the runner of the agent job from the resources: frontmatter field.
+//
+// # Resource Hints
+//
+// A workflow can declare the resources its agent needs instead of naming a runner:
+//
+//	resources:
+//	  cpu: 8
+//	  memory: 32GB
+//	  disk: 200GB
+//	  fallback: ubuntu-latest
+//
+// When runs-on is not set, the compiler picks the smallest GitHub-hosted Linux runner
+// that satisfies every requirement: ubuntu-latest, or one of the larger runners labeled
+// ubuntu-latest-<N>-cores. The fallback label is used instead when no runner is large
+// enough, or when the selected label is not permitted by runners.allowed_runs_on in
+// aw.json. An explicit runs-on always wins; resources is then ignored with a warning.
+
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var resourcesLog = logger.New("workflow:resources")
+
+// ResourcesConfig holds the resource requirements from the resources: frontmatter field.
+type ResourcesConfig struct {
+	CPU      int    // Minimum number of CPU cores
+	MemoryGB int    // Minimum memory in GB
+	DiskGB   int    // Minimum disk space in GB
+	Fallback string // Runner label used when no sized runner fits or is permitted
+}
+
+// runnerSize describes the hardware of a GitHub-hosted Linux runner label.
+type runnerSize struct {
+	label    string
+	cpu      int
+	memoryGB int
+	diskGB   int
+}
+
+// runnerSizes lists the GitHub-hosted Linux runners from smallest to largest. The
+// ubuntu-latest entry uses the specification of private repositories, the smaller one.
+var runnerSizes = []runnerSize{
+	{label: "ubuntu-latest", cpu: 2, memoryGB: 7, diskGB: 14},
+	{label: "ubuntu-latest-4-cores", cpu: 4, memoryGB: 16, diskGB: 150},
+	{label: "ubuntu-latest-8-cores", cpu: 8, memoryGB: 32, diskGB: 300},
+	{label: "ubuntu-latest-16-cores", cpu: 16, memoryGB: 64, diskGB: 600},
+	{label: "ubuntu-latest-32-cores", cpu: 32, memoryGB: 128, diskGB: 1200},
+	{label: "ubuntu-latest-64-cores", cpu: 64, memoryGB: 256, diskGB: 2040},
+}
+
+// extractResourcesConfig parses the resources: frontmatter field.
+func extractResourcesConfig(frontmatter map[string]any) (*ResourcesConfig, error) {
+	raw, ok := frontmatter["resources"]
+	if !ok {
+		return nil, nil
+	}
+	resourcesMap, ok := raw.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("resources must be an object, got %T", raw)
+	}
+
+	config := &ResourcesConfig{}
+	if value, ok := resourcesMap["cpu"]; ok {
+		n, ok := parseIntValue(value)
+		if !ok || n < 1 {
+			return nil, errors.New("resources.cpu must be a positive integer")
+		}
+		config.CPU = n
+	}
+	if value, ok := resourcesMap["memory"]; ok {
+		n, err := parseResourceGigabytes(value)
+		if err != nil {
+			return nil, fmt.Errorf("resources.memory %w", err)
+		}
+		config.MemoryGB = n
+	}
+	if value, ok := resourcesMap["disk"]; ok {
+		n, err := parseResourceGigabytes(value)
+		if err != nil {
+			return nil, fmt.Errorf("resources.disk %w", err)
+		}
+		config.DiskGB = n
+	}
+	if value, ok := resourcesMap["fallback"]; ok {
+		fallback, ok := value.(string)
+		if !ok || strings.TrimSpace(fallback) == "" {
+			return nil, errors.New("resources.fallback must be a runner label")
+		}
+		config.Fallback = strings.TrimSpace(fallback)
+	}
+	if config.CPU == 0 && config.MemoryGB == 0 && config.DiskGB == 0 {
+		return nil, errors.New("resources must set at least one of cpu, memory or disk")
+	}
+	return config, nil
+}
+
+// parseResourceGigabytes accepts a number of gigabytes as an integer or as a string
+// such as "32GB" or "32G".
+func parseResourceGigabytes(value any) (int, error) {
+	if n, ok := parseIntValue(value); ok {
+		if n < 1 {
+			return 0, errors.New("must be a positive number of gigabytes")
+		}
+		return n, nil
+	}
+	s, ok := value.(string)
+	if !ok {
+		return 0, errors.New("must be a number of gigabytes such as 16 or \"16GB\"")
+	}
+	s = strings.ToUpper(strings.TrimSpace(s))
+	s = strings.TrimSpace(strings.TrimSuffix(strings.TrimSuffix(s, "B"), "G"))
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 1 {
+		return 0, errors.New("must be a number of gigabytes such as 16 or \"16GB\"")
+	}
+	return n, nil
+}
+
+// selectRunnerForResources returns the smallest runner label that satisfies the
+// requirements, or an empty string when none does.
+func selectRunnerForResources(config *ResourcesConfig) string {
+	for _, size := range runnerSizes {
+		if size.cpu >= config.CPU && size.memoryGB >= config.MemoryGB && size.diskGB >= config.DiskGB {
+			return size.label
+		}
+	}
+	return ""
+}
+
+// resolveResourcesRunsOn returns the runs-on snippet of the agent job for the
+// resources: field, applying the fallback when the selected runner is too small
+// or not permitted by aw.json.
+func (c *Compiler) resolveResourcesRunsOn(config *ResourcesConfig) (string, error) {
+	label := selectRunnerForResources(config)
+	resourcesLog.Printf("Resources cpu=%d memory=%dGB disk=%dGB selected runner %q", config.CPU, config.MemoryGB, config.DiskGB, label)
+
+	if label != "" {
+		allowed := true
+		if repoConfig, err := c.loadRepoConfig(); err == nil && repoConfig != nil && repoConfig.Runners != nil && len(repoConfig.Runners.AllowedRunsOn) > 0 {
+			allowed = matchesRunnerAllowlist(label, repoConfig.Runners.AllowedRunsOn)
+		}
+		if allowed {
+			return "runs-on: " + label, nil
+		}
+		if config.Fallback == "" {
+			return "", NewValidationError(
+				"resources",
+				label,
+				fmt.Sprintf("the selected runner %q is not permitted by runners.allowed_runs_on in %s", label, RepoConfigFileName),
+				"Set resources.fallback to a permitted runner label, or ask a repository maintainer to add the larger runner to runners.allowed_runs_on",
+			)
+		}
+		resourcesLog.Printf("Runner %q is not permitted by aw.json, using fallback %q", label, config.Fallback)
+		return "runs-on: " + config.Fallback, nil
+	}
+
+	if config.Fallback == "" {
+		largest := runnerSizes[len(runnerSizes)-1]
+		return "", NewValidationError(
+			"resources",
+			fmt.Sprintf("cpu=%d memory=%dGB disk=%dGB", config.CPU, config.MemoryGB, config.DiskGB),
+			"no GitHub-hosted runner satisfies these requirements",
+			fmt.Sprintf("The largest runner (%s) has %d cores, %dGB memory and %dGB disk. Lower the requirements, set resources.fallback, or set runs-on to a self-hosted runner.", largest.label, largest.cpu, largest.memoryGB, largest.diskGB),
+		)
+	}
+	resourcesLog.Printf("No runner satisfies the requirements, using fallback %q", config.Fallback)
+	return "runs-on: " + config.Fallback, nil
+}
+
+// applyResourcesRunsOn sets the runner of the agent job from the resources: field
+// when runs-on is not set, and warns that resources is ignored when it is.
+func (c *Compiler) applyResourcesRunsOn(data *WorkflowData) error {
+	if data.Resources == nil {
+		return nil
+	}
+	if data.RunsOn != "" {
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("resources is ignored because runs-on is set. Remove runs-on to let the compiler select a runner from resources."))
+		c.IncrementWarningCount()
+		return nil
+	}
+	runsOn, err := c.resolveResourcesRunsOn(data.Resources)
+	if err != nil {
+		return err
+	}
+	data.RunsOn = runsOn
+	return nil
+}
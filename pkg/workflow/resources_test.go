@@ -0,0 +1,123 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractResourcesConfig(t *testing.T) {
+	config, err := extractResourcesConfig(map[string]any{
+		"resources": map[string]any{"cpu": 8, "memory": "32GB", "disk": 200, "fallback": "self-hosted"},
+	})
+	require.NoError(t, err, "valid resources should parse")
+	assert.Equal(t, &ResourcesConfig{CPU: 8, MemoryGB: 32, DiskGB: 200, Fallback: "self-hosted"}, config)
+
+	config, err = extractResourcesConfig(map[string]any{})
+	require.NoError(t, err)
+	assert.Nil(t, config)
+
+	tests := []struct {
+		name      string
+		resources any
+		wantErr   string
+	}{
+		{name: "not an object", resources: "large", wantErr: "resources must be an object"},
+		{name: "empty", resources: map[string]any{"fallback": "ubuntu-latest"}, wantErr: "at least one of cpu, memory or disk"},
+		{name: "zero cpu", resources: map[string]any{"cpu": 0}, wantErr: "resources.cpu must be a positive integer"},
+		{name: "invalid memory", resources: map[string]any{"memory": "lots"}, wantErr: "resources.memory must be a number of gigabytes"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := extractResourcesConfig(map[string]any{"resources": tt.resources})
+			require.Error(t, err, "resources should be rejected")
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestSelectRunnerForResources(t *testing.T) {
+	assert.Equal(t, "ubuntu-latest", selectRunnerForResources(&ResourcesConfig{CPU: 2}))
+	assert.Equal(t, "ubuntu-latest-4-cores", selectRunnerForResources(&ResourcesConfig{MemoryGB: 12}), "memory should select a larger runner")
+	assert.Equal(t, "ubuntu-latest-16-cores", selectRunnerForResources(&ResourcesConfig{CPU: 8, MemoryGB: 48}), "the largest requirement should win")
+	assert.Equal(t, "ubuntu-latest-4-cores", selectRunnerForResources(&ResourcesConfig{DiskGB: 100}))
+	assert.Empty(t, selectRunnerForResources(&ResourcesConfig{CPU: 128}), "no runner should fit")
+}
+
+func TestCompileResourcesRunsOn(t *testing.T) {
+	tests := []struct {
+		name        string
+		awJSON      string
+		frontmatter string
+		wantRunsOn  string
+		wantErr     string
+	}{
+		{
+			name:        "larger runner selected",
+			frontmatter: "resources:\n  cpu: 8\n  memory: 32GB\n",
+			wantRunsOn:  "runs-on: ubuntu-latest-8-cores",
+		},
+		{
+			name:        "fallback when too large",
+			frontmatter: "resources:\n  cpu: 128\n  fallback: gpu-runner\n",
+			wantRunsOn:  "runs-on: gpu-runner",
+		},
+		{
+			name:        "too large without fallback",
+			frontmatter: "resources:\n  memory: 512GB\n",
+			wantErr:     "no GitHub-hosted runner satisfies these requirements",
+		},
+		{
+			name:        "fallback when not permitted by aw.json",
+			awJSON:      `{"runners": {"allowed_runs_on": ["ubuntu-latest"]}}`,
+			frontmatter: "resources:\n  cpu: 8\n  fallback: ubuntu-latest\n",
+			wantRunsOn:  "runs-on: ubuntu-latest\n",
+		},
+		{
+			name:        "not permitted by aw.json without fallback",
+			awJSON:      `{"runners": {"allowed_runs_on": ["ubuntu-latest"]}}`,
+			frontmatter: "resources:\n  cpu: 8\n",
+			wantErr:     `"ubuntu-latest-8-cores" is not permitted by runners.allowed_runs_on`,
+		},
+		{
+			name:        "explicit runs-on wins",
+			frontmatter: "runs-on: ubuntu-24.04\nresources:\n  cpu: 8\n",
+			wantRunsOn:  "runs-on: ubuntu-24.04",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gitRoot := testutil.TempDir(t, "resources-*")
+			workflowsDir := filepath.Join(gitRoot, ".github", "workflows")
+			require.NoError(t, os.MkdirAll(workflowsDir, 0o755))
+			if tt.awJSON != "" {
+				writeAWJSON(t, gitRoot, tt.awJSON)
+			}
+			markdownPath := filepath.Join(workflowsDir, "test.md")
+			content := "---\non: issues\npermissions:\n  contents: read\nengine: copilot\n" + tt.frontmatter + "---\n\n# Test\n"
+			require.NoError(t, os.WriteFile(markdownPath, []byte(content), 0o644))
+
+			compiler := NewCompiler()
+			compiler.gitRoot = gitRoot
+			err := compiler.CompileWorkflow(markdownPath)
+			if tt.wantErr != "" {
+				require.Error(t, err, "resources should be rejected")
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err, "resources should compile")
+
+			lockContent, err := os.ReadFile(filepath.Join(workflowsDir, "test.lock.yml"))
+			require.NoError(t, err)
+			assert.Contains(t, string(lockContent), "  agent:\n", "lock file should have an agent job")
+			assert.Contains(t, string(lockContent), tt.wantRunsOn, "agent job should run on the selected runner")
+		})
+	}
+}
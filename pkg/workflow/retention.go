@@ -0,0 +1,52 @@
+package workflow
+
+import (
+	"fmt"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var retentionLog = logger.New("workflow:retention")
+
+// RetentionConfig holds the retention frontmatter configuration.
+type RetentionConfig struct {
+	// Days is the number of days the artifacts holding agent transcripts, gateway
+	// and firewall logs, threat detection logs and safe output items are kept.
+	Days int
+}
+
+// extractRetentionConfig reads the retention frontmatter field. Returns nil when the
+// field is not set.
+func extractRetentionConfig(frontmatter map[string]any) (*RetentionConfig, error) {
+	raw, ok := frontmatter["retention"]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	retentionMap, ok := raw.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("retention must be an object, got %T", raw)
+	}
+	rawDays, ok := retentionMap["days"]
+	if !ok {
+		return nil, nil
+	}
+	days := parseOptionalInt(rawDays)
+	if days == nil {
+		return nil, fmt.Errorf("retention.days must be an integer, got %v", rawDays)
+	}
+	if err := validateIntRange(*days, 1, 90, "retention.days"); err != nil {
+		return nil, err
+	}
+	retentionLog.Printf("Artifact retention: %d days", *days)
+	return &RetentionConfig{Days: *days}, nil
+}
+
+// artifactRetentionDaysLine returns the retention-days input for an upload-artifact
+// step that stores run data covered by retention, or an empty string when the
+// repository default applies. indent is the indentation of the with: inputs.
+func artifactRetentionDaysLine(data *WorkflowData, indent string) string {
+	if data == nil || data.Retention == nil {
+		return ""
+	}
+	return fmt.Sprintf("%sretention-days: %d", indent, data.Retention.Days)
+}
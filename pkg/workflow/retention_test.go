@@ -0,0 +1,85 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractRetentionConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		frontmatter map[string]any
+		expected    *RetentionConfig
+		wantErr     string
+	}{
+		{
+			name:        "not set",
+			frontmatter: map[string]any{},
+		},
+		{
+			name:        "days",
+			frontmatter: map[string]any{"retention": map[string]any{"days": 7}},
+			expected:    &RetentionConfig{Days: 7},
+		},
+		{
+			name:        "days parsed as float",
+			frontmatter: map[string]any{"retention": map[string]any{"days": float64(14)}},
+			expected:    &RetentionConfig{Days: 14},
+		},
+		{
+			name:        "not an object",
+			frontmatter: map[string]any{"retention": 7},
+			wantErr:     "retention must be an object",
+		},
+		{
+			name:        "not an integer",
+			frontmatter: map[string]any{"retention": map[string]any{"days": "7"}},
+			wantErr:     "retention.days must be an integer",
+		},
+		{
+			name:        "out of range",
+			frontmatter: map[string]any{"retention": map[string]any{"days": 91}},
+			wantErr:     "retention.days must be between 1 and 90",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := extractRetentionConfig(tt.frontmatter)
+			if tt.wantErr != "" {
+				require.Error(t, err, "expected an error")
+				assert.Contains(t, err.Error(), tt.wantErr, "unexpected error message")
+				return
+			}
+			require.NoError(t, err, "unexpected error")
+			assert.Equal(t, tt.expected, config, "unexpected retention config")
+		})
+	}
+}
+
+func TestRetentionAppliedToUploadSteps(t *testing.T) {
+	compiler := NewCompiler()
+	data := &WorkflowData{Retention: &RetentionConfig{Days: 5}}
+
+	var yaml strings.Builder
+	compiler.generateUnifiedArtifactUpload(&yaml, data, []string{"/tmp/gh-aw/agent-stdio.log"}, "")
+	assert.Contains(t, yaml.String(), "          retention-days: 5\n", "agent artifact should use the configured retention")
+
+	firewall := strings.Join(generateSquidLogsUploadStep("test", data), "\n")
+	assert.Contains(t, firewall, "retention-days: 5", "firewall logs should use the configured retention")
+
+	items := strings.Join(buildSafeOutputItemsManifestUploadStep(data, "", compiler.getActionPin), "")
+	assert.Contains(t, items, "retention-days: 5", "safe output items should use the configured retention")
+
+	detection := strings.Join(compiler.buildUploadDetectionLogStep(data), "")
+	assert.Contains(t, detection, "retention-days: 5", "detection log should use the configured retention")
+
+	yaml.Reset()
+	compiler.generateUnifiedArtifactUpload(&yaml, &WorkflowData{}, []string{"/tmp/gh-aw/agent-stdio.log"}, "")
+	assert.NotContains(t, yaml.String(), "retention-days", "repository default retention should apply when retention is not set")
+}
@@ -0,0 +1,85 @@
+// This file builds the run context block that is injected into every prompt.
+//
+// Agents have no reliable notion of "now" and frequently state the wrong date.
+// The activation job resolves the current time at runtime (in the project
+// timezone configured by the `utc` field of aw.json, or UTC) and the prompt
+// carries it together with the triggering event, the run URL, and the run's
+// correlation ID.
+//
+// The correlation ID is a short numeric ID derived from the repository and run
+// ID (see actions/setup/js/correlation_id.cjs). The activation job exposes it as
+// an output and downstream jobs receive it as GH_AW_CORRELATION_ID, so safe
+// output markers, footers, and the prompt all quote the same value.
+
+package workflow
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/workflow/compilerenv"
+)
+
+var runContextLog = logger.New("workflow:run_context")
+
+//go:embed prompts/run_context_prompt.md
+var runContextPromptText string
+
+// runContextStepID is the id of the activation step that resolves runtime
+// context values; run_context_prompt.md references its outputs.
+const runContextStepID = "run-context"
+
+// correlationIDOutput is the activation job output carrying the run correlation ID.
+const correlationIDOutput = "correlation_id"
+
+// correlationIDNeedsExpr returns the expression that reads the run correlation ID
+// in jobs that depend on the activation job.
+func correlationIDNeedsExpr() string {
+	return fmt.Sprintf("${{ needs.%s.outputs.%s }}", constants.ActivationJobName, correlationIDOutput)
+}
+
+// buildRunContextPromptSection returns the inline <run-context> prompt section with
+// its expressions replaced by placeholders for the substitution step.
+func buildRunContextPromptSection() *PromptSection {
+	extractor := NewExpressionExtractor()
+	expressionMappings, err := extractor.ExtractExpressions(runContextPromptText)
+	if err != nil || len(expressionMappings) == 0 {
+		runContextLog.Printf("Skipping run context section: no expressions extracted (err=%v)", err)
+		return nil
+	}
+
+	envVars := make(map[string]string, len(expressionMappings))
+	for _, mapping := range expressionMappings {
+		envVars[mapping.EnvVar] = fmt.Sprintf("${{ %s }}", mapping.Content)
+	}
+	return &PromptSection{
+		Content: extractor.ReplaceExpressionsWithEnvVars(runContextPromptText),
+		EnvVars: envVars,
+	}
+}
+
+// generateRunContextStep writes the step that resolves the current time and the
+// correlation ID for the <run-context> prompt section. It must run before the prompt is created.
+func (c *Compiler) generateRunContextStep(yaml *strings.Builder, data *WorkflowData) {
+	utcOffset := c.getCompiledProjectUTCOffset()
+	runContextLog.Printf("Generating run context step: project_utc=%q", utcOffset)
+
+	yaml.WriteString("      - name: Resolve run context\n")
+	fmt.Fprintf(yaml, "        id: %s\n", runContextStepID)
+	fmt.Fprintf(yaml, "        uses: %s\n", getCachedActionPin("actions/github-script", data))
+	yaml.WriteString("        env:\n")
+	// The enterprise default (managed by `gh aw env`) applies when aw.json sets no offset.
+	fmt.Fprintf(yaml, "          %s: ${{ vars.%s }}\n", compilerenv.DefaultUTC, compilerenv.DefaultUTC)
+	if utcOffset != "" {
+		fmt.Fprintf(yaml, "          GH_AW_PROJECT_UTC: %q\n", utcOffset)
+	}
+	yaml.WriteString("        with:\n")
+	yaml.WriteString("          script: |\n")
+	yaml.WriteString("            const { setupGlobals } = require('" + SetupActionDestination + "/setup_globals.cjs');\n")
+	yaml.WriteString("            setupGlobals(core, github, context, exec, io, getOctokit);\n")
+	yaml.WriteString("            const { main } = require('" + SetupActionDestination + "/run_context.cjs');\n")
+	yaml.WriteString("            await main();\n")
+}
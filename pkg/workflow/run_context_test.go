@@ -0,0 +1,57 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildRunContextPromptSection(t *testing.T) {
+	section := buildRunContextPromptSection()
+	require.NotNil(t, section, "run context section should always be built")
+
+	assert.False(t, section.IsFile, "run context should be inline content")
+	assert.Contains(t, section.Content, "<run-context>", "section should be wrapped in run-context tags")
+	assert.NotContains(t, section.Content, "${{", "expressions should be replaced with placeholders")
+
+	values := make([]string, 0, len(section.EnvVars))
+	for _, value := range section.EnvVars {
+		values = append(values, value)
+	}
+	assert.Contains(t, values, "${{ steps.run-context.outputs.current_time }}", "current time should come from the run context step")
+	assert.Contains(t, values, "${{ steps.run-context.outputs.correlation_id }}", "correlation ID should come from the run context step")
+	assert.Contains(t, values, "${{ github.event_name }}", "event name should be exposed")
+	assert.Contains(t, values, "${{ github.run_id }}", "run URL should be built from the run id")
+}
+
+func TestCollectPromptSectionsIncludesRunContext(t *testing.T) {
+	compiler := &Compiler{}
+	data := &WorkflowData{ParsedTools: NewTools(map[string]any{})}
+
+	sections := compiler.collectPromptSections(data)
+	require.NotEmpty(t, sections, "expected built-in sections")
+	assert.Contains(t, sections[len(sections)-1].Content, "<run-context>", "run context should be the last built-in section")
+}
+
+func TestGenerateRunContextStep(t *testing.T) {
+	compiler := &Compiler{repoConfigLoaded: true, repoConfig: &RepoConfig{UTC: "+05:30"}}
+	var yaml strings.Builder
+
+	compiler.generateRunContextStep(&yaml, &WorkflowData{})
+
+	output := yaml.String()
+	assert.Contains(t, output, "id: run-context", "step id should match the prompt expressions")
+	assert.Contains(t, output, "GH_AW_DEFAULT_UTC: ${{ vars.GH_AW_DEFAULT_UTC }}", "enterprise default should be passed through")
+	assert.Contains(t, output, `GH_AW_PROJECT_UTC: "+05:30"`, "aw.json offset should be baked into the step")
+	assert.Contains(t, output, "/run_context.cjs');", "step should run run_context.cjs")
+}
+
+func TestBuildJobLevelSafeOutputEnvVarsThreadsCorrelationID(t *testing.T) {
+	compiler := &Compiler{}
+	envVars := compiler.buildJobLevelSafeOutputEnvVars(&WorkflowData{Name: "Test", SafeOutputs: &SafeOutputsConfig{}}, "test")
+	assert.Equal(t, "${{ needs.activation.outputs.correlation_id }}", envVars["GH_AW_CORRELATION_ID"], "safe outputs should receive the activation correlation ID")
+}
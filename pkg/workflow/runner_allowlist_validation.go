@@ -0,0 +1,194 @@
+// This file validates the runner labels, job container image, and service images
+// requested by a workflow against the runners section of aw.json.
+//
+// # Runner Allowlists
+//
+// Repositories that run agents on self-hosted or larger runners can restrict
+// which runners and images workflow authors may request:
+//
+//	{
+//	  "runners": {
+//	    "allowed_runs_on": ["ubuntu-latest", "self-hosted", "linux", "gpu-*"],
+//	    "allowed_images": ["node:20", "postgres:*", "ghcr.io/acme/*"]
+//	  }
+//	}
+//
+// allowed_runs_on applies to every runner label and runner group name in runs-on,
+// runs-on-slim, safe-outputs.runs-on, and safe-outputs.threat-detection.runs-on.
+// allowed_images applies to the container image and every services image.
+// Entries may use * to match any sequence of characters. An omitted list allows
+// any value. Values that are GitHub Actions expressions cannot be checked at
+// compile time and are rejected while the matching list is set.
+//
+// # Validation Functions
+//
+//   - validateRunnerAllowlist() - Validates runners and images against aw.json
+//   - matchesRunnerAllowlist() - Matches a value against allowlist patterns
+
+package workflow
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var runnerAllowlistValidationLog = logger.New("workflow:runner_allowlist_validation")
+
+// validateRunnerAllowlist checks the runners and images requested by the workflow
+// against runners.allowed_runs_on and runners.allowed_images in aw.json.
+// It returns nil when aw.json is absent, fails to load, or has no runners section.
+func (c *Compiler) validateRunnerAllowlist(workflowData *WorkflowData) error {
+	repoConfig, err := c.loadRepoConfig()
+	if err != nil || repoConfig == nil || repoConfig.Runners == nil {
+		return nil
+	}
+	runners := repoConfig.Runners
+
+	if len(runners.AllowedRunsOn) > 0 {
+		runnerAllowlistValidationLog.Printf("Validating runner labels against %d allowed pattern(s)", len(runners.AllowedRunsOn))
+		for _, field := range collectRunnerFields(workflowData.RawFrontmatter) {
+			for _, label := range field.values {
+				if err := checkRunnerAllowlist(field.name, label, "runners.allowed_runs_on", runners.AllowedRunsOn); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if len(runners.AllowedImages) > 0 {
+		runnerAllowlistValidationLog.Printf("Validating container and service images against %d allowed pattern(s)", len(runners.AllowedImages))
+		for _, field := range collectImageFields(workflowData) {
+			for _, image := range field.values {
+				if err := checkRunnerAllowlist(field.name, image, "runners.allowed_images", runners.AllowedImages); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// allowlistField is a frontmatter field and the values in it that must be allowed.
+type allowlistField struct {
+	name   string
+	values []string
+}
+
+// checkRunnerAllowlist returns a validation error when value does not match any
+// of the allowlist patterns configured under setting in aw.json.
+func checkRunnerAllowlist(field, value, setting string, patterns []string) error {
+	if strings.Contains(value, "${{") {
+		return NewValidationError(
+			field,
+			value,
+			fmt.Sprintf("expressions cannot be checked against %s in %s", setting, RepoConfigFileName),
+			fmt.Sprintf("Use a literal value from %s: %s", setting, strings.Join(patterns, ", ")),
+		)
+	}
+	if matchesRunnerAllowlist(value, patterns) {
+		return nil
+	}
+	return NewValidationError(
+		field,
+		value,
+		fmt.Sprintf("%q is not permitted by %s in %s", value, setting, RepoConfigFileName),
+		fmt.Sprintf("Use one of the permitted values (%s), or ask a repository maintainer to add it to %s", strings.Join(patterns, ", "), setting),
+	)
+}
+
+// matchesRunnerAllowlist reports whether value matches one of the patterns.
+// A * in a pattern matches any sequence of characters, including '/' and ':'.
+func matchesRunnerAllowlist(value string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if !strings.Contains(pattern, "*") {
+			if value == pattern {
+				return true
+			}
+			continue
+		}
+		expr := "^" + strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*") + "$"
+		if matched, err := regexp.MatchString(expr, value); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// collectRunnerFields returns the runner labels and runner group names of every
+// runs-on field in the frontmatter.
+func collectRunnerFields(frontmatter map[string]any) []allowlistField {
+	fields := []allowlistField{
+		{name: "runs-on", values: runnerLabelsAndGroup(frontmatter["runs-on"])},
+		{name: "runs-on-slim", values: runnerLabelsAndGroup(frontmatter["runs-on-slim"])},
+	}
+	if safeOutputs, ok := frontmatter["safe-outputs"].(map[string]any); ok {
+		fields = append(fields, allowlistField{name: "safe-outputs.runs-on", values: runnerLabelsAndGroup(safeOutputs["runs-on"])})
+		if threatDetection, ok := safeOutputs["threat-detection"].(map[string]any); ok {
+			fields = append(fields, allowlistField{name: "safe-outputs.threat-detection.runs-on", values: runnerLabelsAndGroup(threatDetection["runs-on"])})
+		}
+	}
+	return fields
+}
+
+// runnerLabelsAndGroup returns the runner labels of a runs-on value followed by
+// its runner group name, if any.
+func runnerLabelsAndGroup(runsOn any) []string {
+	values := extractRunnerLabels(runsOn)
+	if runsOnMap, ok := runsOn.(map[string]any); ok {
+		if group, ok := runsOnMap["group"].(string); ok && group != "" {
+			values = append(values, group)
+		}
+	}
+	return values
+}
+
+// collectImageFields returns the job container image and the image of every
+// service, including services merged from imports.
+func collectImageFields(workflowData *WorkflowData) []allowlistField {
+	var fields []allowlistField
+	if image := containerImage(workflowData.RawFrontmatter["container"]); image != "" {
+		fields = append(fields, allowlistField{name: "container", values: []string{image}})
+	}
+
+	if workflowData.Services == "" {
+		return fields
+	}
+	var servicesWrapper map[string]map[string]any
+	if err := yaml.Unmarshal([]byte(workflowData.Services), &servicesWrapper); err != nil {
+		runnerAllowlistValidationLog.Printf("Failed to parse services for allowlist validation: %v", err)
+		return fields
+	}
+	services := servicesWrapper["services"]
+	names := make([]string, 0, len(services))
+	for name := range services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if image := containerImage(services[name]); image != "" {
+			fields = append(fields, allowlistField{name: "services." + name + ".image", values: []string{image}})
+		}
+	}
+	return fields
+}
+
+// containerImage returns the image of a container or service value, which is
+// either an image string or an object with an image field.
+func containerImage(value any) string {
+	switch v := value.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case map[string]any:
+		if image, ok := v["image"].(string); ok {
+			return strings.TrimSpace(image)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,83 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchesRunnerAllowlist(t *testing.T) {
+	patterns := []string{"ubuntu-latest", "gpu-*", "ghcr.io/acme/*"}
+
+	assert.True(t, matchesRunnerAllowlist("ubuntu-latest", patterns), "exact entries should match")
+	assert.True(t, matchesRunnerAllowlist("gpu-large", patterns), "* should match a suffix")
+	assert.True(t, matchesRunnerAllowlist("ghcr.io/acme/tools/node:20", patterns), "* should match across / and :")
+	assert.False(t, matchesRunnerAllowlist("ubuntu-24.04", patterns), "unlisted labels should not match")
+	assert.False(t, matchesRunnerAllowlist("my-gpu-large", patterns), "patterns should be anchored")
+}
+
+func TestValidateRunnerAllowlist(t *testing.T) {
+	const awJSON = `{"runners": {"allowed_runs_on": ["self-hosted", "linux", "gpu-*"], "allowed_images": ["node:20", "postgres:*"]}}`
+
+	tests := []struct {
+		name        string
+		frontmatter string
+		wantErr     string
+	}{
+		{
+			name:        "permitted runner, container and service",
+			frontmatter: "runs-on: [self-hosted, linux, gpu-large]\ncontainer: node:20\nservices:\n  postgres:\n    image: postgres:16\n",
+		},
+		{
+			name:        "runner label not permitted",
+			frontmatter: "runs-on: [self-hosted, windows]\n",
+			wantErr:     `"windows" is not permitted by runners.allowed_runs_on`,
+		},
+		{
+			name:        "runner group not permitted",
+			frontmatter: "runs-on:\n  group: big-runners\n  labels: [linux]\n",
+			wantErr:     `"big-runners" is not permitted by runners.allowed_runs_on`,
+		},
+		{
+			name:        "runner expression",
+			frontmatter: "runs-on: ${{ vars.RUNNER }}\n",
+			wantErr:     "expressions cannot be checked against runners.allowed_runs_on",
+		},
+		{
+			name:        "container image not permitted",
+			frontmatter: "runs-on: self-hosted\ncontainer:\n  image: python:3.12\n",
+			wantErr:     `"python:3.12" is not permitted by runners.allowed_images`,
+		},
+		{
+			name:        "service image not permitted",
+			frontmatter: "runs-on: self-hosted\nservices:\n  redis:\n    image: redis:7\n",
+			wantErr:     "services.redis.image",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gitRoot := testutil.TempDir(t, "runner-allowlist-*")
+			writeAWJSON(t, gitRoot, awJSON)
+			markdownPath := filepath.Join(gitRoot, ".github", "workflows", "test.md")
+			content := "---\non: issues\npermissions:\n  contents: read\nengine: copilot\n" + tt.frontmatter + "---\n\n# Test\n"
+			require.NoError(t, os.WriteFile(markdownPath, []byte(content), 0o644))
+
+			compiler := NewCompiler()
+			compiler.gitRoot = gitRoot
+			err := compiler.CompileWorkflow(markdownPath)
+			if tt.wantErr == "" {
+				require.NoError(t, err, "permitted runners and images should compile")
+				return
+			}
+			require.Error(t, err, "disallowed runners and images should be rejected")
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -44,6 +44,18 @@ var safeOutputHandlers = []safeOutputHandlerDescriptor{
 			return NewPermissionsContentsReadIssuesWrite()
 		},
 	},
+	{
+		Key:         "handoff-to-copilot",
+		StructField: "HandoffToCopilot",
+		ToolName:    "handoff_to_copilot",
+		NewConfig:   func() any { return &HandoffToCopilotConfig{} },
+		PermissionBuilder: func(safeOutputs *SafeOutputsConfig) *Permissions {
+			if !isSafeOutputHandlerEnabledAndUnstaged(safeOutputs, "HandoffToCopilot") {
+				return nil
+			}
+			return NewPermissionsContentsReadIssuesWrite()
+		},
+	},
 	{
 		Key:         "create-discussion",
 		StructField: "CreateDiscussions",
@@ -191,6 +203,18 @@ var safeOutputHandlers = []safeOutputHandlerDescriptor{
 			return NewPermissionsContentsReadPRWrite()
 		},
 	},
+	{
+		Key:         "create-pull-request-review-suggestion",
+		StructField: "CreatePullRequestReviewSuggestions",
+		ToolName:    "create_pull_request_review_suggestion",
+		NewConfig:   func() any { return &CreatePRReviewSuggestionsConfig{} },
+		PermissionBuilder: func(safeOutputs *SafeOutputsConfig) *Permissions {
+			if !isSafeOutputHandlerEnabledAndUnstaged(safeOutputs, "CreatePullRequestReviewSuggestions") {
+				return nil
+			}
+			return NewPermissionsContentsReadPRWrite()
+		},
+	},
 	{
 		Key:         "submit-pull-request-review",
 		StructField: "SubmitPullRequestReview",
@@ -442,6 +466,12 @@ var safeOutputHandlers = []safeOutputHandlerDescriptor{
 		StructField: "UploadArtifact",
 		ToolName:    "upload_artifact",
 	},
+	{
+		Key:         "schedule-follow-up",
+		StructField: "ScheduleFollowUp",
+		ToolName:    "schedule_follow_up",
+		NewConfig:   func() any { return &ScheduleFollowUpConfig{} },
+	},
 	{
 		Key:         "update-release",
 		StructField: "UpdateRelease",
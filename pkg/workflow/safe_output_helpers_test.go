@@ -703,14 +703,14 @@ func TestBuildAgentOutputDownloadSteps(t *testing.T) {
 		"continue-on-error: true",
 		"uses: actions/download-artifact@3e5f45b2cfb9172054b4087a40e8e0b5a5461e7c",
 		"name: agent",
-		"path: /tmp/gh-aw/",
+		"path: ${{ env.GH_AW_TMP_DIR }}/",
 		"- name: Setup agent output environment variable",
 		"id: setup-agent-output-env",
 		"if: steps.download-agent-output.outcome == 'success'",
-		"mkdir -p /tmp/gh-aw/",
-		`find "/tmp/gh-aw/" -type f -print`,
-		// Hardcoded path is correct because GetPreBundleSteps ensures LCA is /tmp/gh-aw/
-		`echo "GH_AW_AGENT_OUTPUT=/tmp/gh-aw/agent_output.json" >> "$GITHUB_OUTPUT"`,
+		"mkdir -p ${{ env.GH_AW_TMP_DIR }}/",
+		`find "${{ env.GH_AW_TMP_DIR }}/" -type f -print`,
+		// Hardcoded path is correct because GetPreBundleSteps ensures LCA is ${{ env.GH_AW_TMP_DIR }}/
+		`echo "GH_AW_AGENT_OUTPUT=${{ env.GH_AW_TMP_DIR }}/agent_output.json" >> "$GITHUB_OUTPUT"`,
 	}
 
 	for _, expected := range expectedComponents {
@@ -720,14 +720,14 @@ func TestBuildAgentOutputDownloadSteps(t *testing.T) {
 	}
 
 	// Verify no dynamic find-based lookup is used (regression guard: the Gemini engine
-	// moves files to /tmp/gh-aw/ via GetPreBundleSteps so the hardcoded path is always valid)
+	// moves files to ${{ env.GH_AW_TMP_DIR }}/ via GetPreBundleSteps so the hardcoded path is always valid)
 	if strings.Contains(stepsStr, "FOUND_FILE=$(find") {
 		t.Error("Step must not use dynamic find resolution; hardcoded path should be used instead")
 	}
 
 	// Verify mkdir comes before find to ensure directory exists
-	mkdirIdx := strings.Index(stepsStr, "mkdir -p /tmp/gh-aw/")
-	findIdx := strings.Index(stepsStr, `find "/tmp/gh-aw/"`)
+	mkdirIdx := strings.Index(stepsStr, "mkdir -p ${{ env.GH_AW_TMP_DIR }}/")
+	findIdx := strings.Index(stepsStr, `find "${{ env.GH_AW_TMP_DIR }}/"`)
 
 	if mkdirIdx == -1 {
 		t.Fatal("mkdir command not found in steps")
@@ -0,0 +1,236 @@
+// This file implements safe-output previews in pull requests.
+//
+// # Safe-Output Preview
+//
+// A workflow can preview what it would do when a pull request changes it:
+//
+//	preview:
+//	  fixtures: .github/aw/mcp-recordings/issue-triage.jsonl
+//
+// The workflow compiles to an additional companion workflow, <name>.preview.job.lock.yml,
+// triggered by pull requests that change the workflow source or its fixtures. The companion
+// is the workflow as changed by the pull request: it runs the agent with safe outputs in
+// staged mode, so nothing is written to the repository, and answers MCP tool calls from the
+// fixtures recording when one is given (see sandbox.mcp.replay). A preview job then posts
+// the safe outputs the agent produced as a comment on the pull request, updated on each
+// push, so reviewers see the behavioral impact of the change and not only the YAML diff.
+// preview: true runs the preview against the live MCP servers.
+
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"path"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/goccy/go-yaml"
+)
+
+var safeOutputPreviewLog = logger.New("workflow:safe_output_preview")
+
+const (
+	// safeOutputPreviewCompanionName names the companion workflow, <name>.preview.job.lock.yml.
+	safeOutputPreviewCompanionName = "preview"
+	// safeOutputPreviewJobName is the companion job that posts the preview comment.
+	safeOutputPreviewJobName = "preview"
+)
+
+// PreviewConfig holds the configuration from the preview: frontmatter field.
+type PreviewConfig struct {
+	Fixtures string // MCP recording replayed by the preview run (empty for live MCP servers)
+}
+
+// extractPreviewConfig parses the preview: frontmatter field.
+func extractPreviewConfig(frontmatter map[string]any) (*PreviewConfig, error) {
+	raw, ok := frontmatter["preview"]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	switch v := raw.(type) {
+	case bool:
+		if !v {
+			return nil, nil
+		}
+		return &PreviewConfig{}, nil
+	case map[string]any:
+		config := &PreviewConfig{}
+		if fixtures, ok := v["fixtures"]; ok {
+			s, ok := fixtures.(string)
+			if !ok || strings.TrimSpace(s) == "" {
+				return nil, errors.New("preview.fixtures must be the path of an MCP recording")
+			}
+			s = strings.TrimSpace(s)
+			if path.IsAbs(s) || strings.Contains(s, "\\") || strings.HasPrefix(path.Clean(s), "..") {
+				return nil, fmt.Errorf("preview.fixtures must be a path relative to the repository root, got '%s'", s)
+			}
+			config.Fixtures = path.Clean(s)
+		}
+		return config, nil
+	default:
+		return nil, fmt.Errorf("preview must be true, false or an object, got %T", raw)
+	}
+}
+
+// applyPreview validates the preview: field and records it on the workflow data. The
+// companion workflow is compiled by compilePreview.
+func (c *Compiler) applyPreview(workflowData *WorkflowData, frontmatter map[string]any) error {
+	config, err := extractPreviewConfig(frontmatter)
+	if err != nil || config == nil {
+		return err
+	}
+	if _, ok := frontmatter["safe-outputs"]; !ok || workflowData.SafeOutputs == nil {
+		return errors.New("preview requires safe-outputs: the preview shows the safe outputs the agent would produce")
+	}
+	if workflowData.FanOut != nil {
+		return errors.New("preview cannot be combined with fan-out")
+	}
+	if len(workflowData.AgenticJobs) > 0 {
+		return errors.New("preview cannot be combined with agentic jobs (\"## job:\" blocks)")
+	}
+	safeOutputPreviewLog.Printf("Safe-output preview enabled (fixtures=%q)", config.Fixtures)
+	workflowData.Preview = config
+	return nil
+}
+
+// buildPreviewCompanionMarkdown returns the source of the preview companion: the workflow's
+// frontmatter and body, triggered by pull requests changing the workflow, with safe outputs
+// staged and MCP tool calls replayed from the fixtures.
+func buildPreviewCompanionMarkdown(workflowData *WorkflowData, markdownPath, companionPath string) (string, error) {
+	frontmatter := map[string]any{}
+	if err := yaml.Unmarshal([]byte(workflowData.FrontmatterYAML), &frontmatter); err != nil {
+		return "", fmt.Errorf("preview: failed to read workflow frontmatter: %w", err)
+	}
+	delete(frontmatter, "preview")
+
+	paths := []any{strings.TrimPrefix(renderWorkflowReviewPath(markdownPath), "./")}
+	if workflowData.Preview.Fixtures != "" {
+		paths = append(paths, workflowData.Preview.Fixtures)
+	}
+	frontmatter["name"] = workflowData.Name + " / " + safeOutputPreviewCompanionName
+	frontmatter["on"] = map[string]any{
+		"pull_request": map[string]any{
+			"types": []any{"opened", "synchronize", "reopened"},
+			"paths": paths,
+		},
+	}
+	frontmatter["concurrency"] = map[string]any{
+		"group":              fmt.Sprintf("gh-aw-%s-${{ github.event.pull_request.number }}", GetWorkflowIDFromPath(companionPath)),
+		"cancel-in-progress": true,
+	}
+
+	safeOutputs := map[string]any{}
+	if existing, ok := frontmatter["safe-outputs"].(map[string]any); ok {
+		maps.Copy(safeOutputs, existing)
+	}
+	safeOutputs["staged"] = true
+	frontmatter["safe-outputs"] = safeOutputs
+
+	if workflowData.Preview.Fixtures != "" {
+		sandbox := map[string]any{}
+		if existing, ok := frontmatter["sandbox"].(map[string]any); ok {
+			maps.Copy(sandbox, existing)
+		}
+		mcp := map[string]any{}
+		if existing, ok := sandbox["mcp"].(map[string]any); ok {
+			maps.Copy(mcp, existing)
+		}
+		delete(mcp, "record")
+		mcp["replay"] = workflowData.Preview.Fixtures
+		sandbox["mcp"] = mcp
+		frontmatter["sandbox"] = sandbox
+	}
+
+	frontmatterYAML, err := yaml.Marshal(frontmatter)
+	if err != nil {
+		return "", fmt.Errorf("preview: failed to generate workflow frontmatter: %w", err)
+	}
+	return "---\n" + string(frontmatterYAML) + "---\n\n" + strings.TrimSpace(workflowData.RawMarkdown) + "\n", nil
+}
+
+// compilePreview compiles the preview companion of a workflow with preview: enabled.
+func (c *Compiler) compilePreview(workflowData *WorkflowData, markdownPath string) error {
+	if workflowData.Preview == nil {
+		return nil
+	}
+	companionPath := agenticJobMarkdownPath(markdownPath, safeOutputPreviewCompanionName)
+	content, err := buildPreviewCompanionMarkdown(workflowData, markdownPath, companionPath)
+	if err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+	safeOutputPreviewLog.Printf("Compiling preview companion to %s", companionPath)
+
+	companionCompiler := c.newAgenticJobCompiler(content)
+	companionData, err := companionCompiler.ParseWorkflowFile(companionPath)
+	if err != nil {
+		return formatCompilerError(markdownPath, "error", "preview: "+err.Error(), err)
+	}
+	companionData.PreviewRun = true
+	if err := companionCompiler.CompileWorkflowData(companionData, companionPath); err != nil {
+		return err
+	}
+	c.warningCount += companionCompiler.warningCount
+	return nil
+}
+
+// buildSafeOutputPreviewJob builds the job of a preview companion that posts the safe
+// outputs of the staged run as a comment on the pull request.
+func (c *Compiler) buildSafeOutputPreviewJob(data *WorkflowData) (*Job, error) {
+	if !data.PreviewRun {
+		return nil, nil
+	}
+	safeOutputPreviewLog.Print("Building preview job")
+
+	var steps []string
+
+	setupActionRef := c.resolveActionReference("./actions/setup", data)
+	if setupActionRef != "" || c.actionMode.IsScript() {
+		steps = append(steps, c.generateCheckoutActionsFolder(data)...)
+		traceID := fmt.Sprintf("${{ needs.%s.outputs.setup-trace-id }}", constants.ActivationJobName)
+		parentSpanID := setupParentSpanNeedsExpr(constants.ActivationJobName)
+		steps = append(steps, c.generateSetupStep(data, setupActionRef, SetupActionDestination, false, traceID, parentSpanID)...)
+	}
+
+	steps = append(steps, buildAgentOutputDownloadSteps(artifactPrefixExprForDownstreamJob(data), c.getActionPin)...)
+
+	steps = append(steps,
+		"      - name: Post safe-output preview\n",
+		"        id: post_preview\n",
+		fmt.Sprintf("        uses: %s\n", getCachedActionPin("actions/github-script", data)),
+		"        env:\n",
+		"          GH_AW_AGENT_OUTPUT: ${{ steps.setup-agent-output-env.outputs.GH_AW_AGENT_OUTPUT }}\n",
+		fmt.Sprintf("          GH_AW_WORKFLOW_ID: %q\n", strings.TrimSuffix(data.WorkflowID, "."+safeOutputPreviewCompanionName+".job")),
+		fmt.Sprintf("          GH_AW_WORKFLOW_NAME: %q\n", strings.TrimSuffix(data.Name, " / "+safeOutputPreviewCompanionName)),
+		fmt.Sprintf("          GH_AW_AGENT_CONCLUSION: ${{ needs.%s.result }}\n", constants.AgentJobName),
+		"          GH_AW_PULL_REQUEST_NUMBER: ${{ github.event.pull_request.number }}\n",
+		"        with:\n",
+		"          script: |\n",
+		"            const { setupGlobals } = require('"+SetupActionDestination+"/setup_globals.cjs');\n",
+		"            setupGlobals(core, github, context, exec, io, getOctokit);\n",
+		"            const { main } = require('"+SetupActionDestination+"/post_safe_output_preview.cjs');\n",
+		"            await main();\n",
+	)
+
+	if c.actionMode.IsDev() {
+		steps = append(steps, c.generateRestoreActionsSetupStep())
+	}
+
+	agentFinished := BuildNotEquals(
+		BuildPropertyAccess(fmt.Sprintf("needs.%s.result", constants.AgentJobName)),
+		BuildStringLiteral("skipped"),
+	)
+	notCancelled := &NotNode{Child: BuildFunctionCall("cancelled")}
+	jobCondition := RenderCondition(BuildAnd(BuildAnd(BuildFunctionCall("always"), notCancelled), agentFinished))
+
+	return &Job{
+		Name:        safeOutputPreviewJobName,
+		RunsOn:      c.formatFrameworkJobRunsOn(data),
+		If:          jobCondition,
+		Permissions: "permissions:\n      pull-requests: write",
+		Needs:       []string{string(constants.AgentJobName), string(constants.ActivationJobName)},
+		Steps:       steps,
+	}, nil
+}
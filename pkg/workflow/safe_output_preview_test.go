@@ -0,0 +1,104 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractPreviewConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     any
+		want    *PreviewConfig
+		wantErr string
+	}{
+		{name: "true previews against live servers", raw: true, want: &PreviewConfig{}},
+		{name: "false disables", raw: false, want: nil},
+		{name: "fixtures", raw: map[string]any{"fixtures": "./.github/aw/rec.jsonl"}, want: &PreviewConfig{Fixtures: ".github/aw/rec.jsonl"}},
+		{name: "absolute fixtures", raw: map[string]any{"fixtures": "/tmp/rec.jsonl"}, wantErr: "relative to the repository root"},
+		{name: "fixtures outside the repository", raw: map[string]any{"fixtures": "../rec.jsonl"}, wantErr: "relative to the repository root"},
+		{name: "empty fixtures", raw: map[string]any{"fixtures": ""}, wantErr: "path of an MCP recording"},
+		{name: "wrong type", raw: "yes", wantErr: "must be true, false or an object"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := extractPreviewConfig(map[string]any{"preview": tt.raw})
+			if tt.wantErr != "" {
+				require.Error(t, err, "preview should be rejected")
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, config)
+		})
+	}
+}
+
+func TestCompileSafeOutputPreview(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "safe-output-preview-*")
+	markdownPath := filepath.Join(tmpDir, "triage.md")
+	content := `---
+on:
+  issues:
+    types: [opened]
+permissions:
+  contents: read
+engine: copilot
+preview:
+  fixtures: .github/aw/mcp-recordings/triage.jsonl
+safe-outputs:
+  add-comment:
+---
+
+# Triage
+
+Triage the issue.
+`
+	require.NoError(t, os.WriteFile(markdownPath, []byte(content), 0o644))
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(markdownPath), "workflow with preview should compile")
+
+	mainLock, err := os.ReadFile(filepath.Join(tmpDir, "triage.lock.yml"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(mainLock), "post_safe_output_preview.cjs", "the workflow itself should not post previews")
+
+	previewLock, err := os.ReadFile(filepath.Join(tmpDir, "triage.preview.job.lock.yml"))
+	require.NoError(t, err, "the preview companion should be compiled")
+	lock := string(previewLock)
+	assert.Contains(t, lock, "  pull_request:\n    paths:\n      - .github/workflows/triage.md\n      - .github/aw/mcp-recordings/triage.jsonl\n", "pull requests changing the workflow or its fixtures should trigger the preview")
+	assert.Contains(t, lock, "GH_AW_SAFE_OUTPUTS_STAGED: true", "safe outputs should be staged")
+	assert.Contains(t, lock, "GH_AW_MCP_REPLAY=.github/aw/mcp-recordings/triage.jsonl", "tool calls should be replayed from the fixtures")
+	assert.Contains(t, lock, "group: gh-aw-triage.preview.job-${{ github.event.pull_request.number }}", "previews of the same pull request should cancel each other")
+	assert.Contains(t, lock, "  preview:\n    needs:\n      - activation\n      - agent\n", "a preview job should follow the agent")
+	assert.Contains(t, lock, "      pull-requests: write", "the preview job should be able to comment")
+	assert.Contains(t, lock, `GH_AW_WORKFLOW_ID: "triage"`, "the comment should be marked with the workflow ID")
+	assert.Contains(t, lock, "require('${{ runner.temp }}/gh-aw/actions/post_safe_output_preview.cjs')")
+}
+
+func TestSafeOutputPreviewRequiresSafeOutputs(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "safe-output-preview-invalid-*")
+	markdownPath := filepath.Join(tmpDir, "plain.md")
+	content := `---
+on: issues
+permissions:
+  contents: read
+engine: copilot
+preview: true
+---
+
+# Plain
+`
+	require.NoError(t, os.WriteFile(markdownPath, []byte(content), 0o644))
+
+	err := NewCompiler().CompileWorkflow(markdownPath)
+	require.Error(t, err, "preview without safe outputs should be rejected")
+	assert.Contains(t, err.Error(), "preview requires safe-outputs")
+}
@@ -278,8 +278,8 @@ func TestUpdateIssueValidationConfig(t *testing.T) {
 		t.Fatal("update_issue not found in ValidationConfig")
 	}
 
-	if config.CustomValidation != "requiresOneOf:status,title,body,labels,assignees,milestone" {
-		t.Errorf("update_issue customValidation = %q, want %q", config.CustomValidation, "requiresOneOf:status,title,body,labels,assignees,milestone")
+	if config.CustomValidation != "requiresOneOf:status,title,body,labels,assignees,milestone,issue_type,parent" {
+		t.Errorf("update_issue customValidation = %q, want %q", config.CustomValidation, "requiresOneOf:status,title,body,labels,assignees,milestone,issue_type,parent")
 	}
 
 	if _, ok := config.Fields["labels"]; !ok {
@@ -349,16 +349,16 @@ func TestStripOnErrorOnlyOnOptionalFields(t *testing.T) {
 func TestValidationConfigConsistency(t *testing.T) {
 	// Verify that all types with customValidation have valid validation rules
 	validCustomValidations := map[string]bool{
-		"requiresOneOf:status,title,body,labels,assignees,milestone": true,
-		"requiresOneOf:title,body":                                   true,
-		"requiresOneOf:title,body,update_branch":                     true,
-		"requiresOneOf:title,body,labels":                            true,
-		"requiresOneOf:issue_number,pull_number":                     true,
-		"requiresOneOf:milestone_number,milestone_title":             true,
-		"requiresOneOf:field_name,field_node_id":                     true,
-		"requiresOneOf:reviewers,team_reviewers":                     true,
-		"startLineLessOrEqualLine":                                   true,
-		"parentAndSubDifferent":                                      true,
+		"requiresOneOf:status,title,body,labels,assignees,milestone,issue_type,parent": true,
+		"requiresOneOf:title,body":                       true,
+		"requiresOneOf:title,body,update_branch":         true,
+		"requiresOneOf:title,body,labels":                true,
+		"requiresOneOf:issue_number,pull_number":         true,
+		"requiresOneOf:milestone_number,milestone_title": true,
+		"requiresOneOf:field_name,field_node_id":         true,
+		"requiresOneOf:reviewers,team_reviewers":         true,
+		"startLineLessOrEqualLine":                       true,
+		"parentAndSubDifferent":                          true,
 	}
 
 	for typeName, config := range ValidationConfig {
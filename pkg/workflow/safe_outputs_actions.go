@@ -12,6 +12,7 @@ import (
 
 	"github.com/github/gh-aw/pkg/gitutil"
 	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
 	"github.com/github/gh-aw/pkg/sliceutil"
 	"github.com/github/gh-aw/pkg/stringutil"
 	"github.com/goccy/go-yaml"
@@ -277,6 +278,9 @@ func extractSHAFromPinnedRef(pinned string) string {
 // fetchRemoteActionYAML fetches and parses action.yml from a GitHub repository.
 // It tries both action.yml and action.yaml filenames.
 func fetchRemoteActionYAML(repo, subdir, ref string) (*actionYAMLFile, error) {
+	if IsOfflineMode() {
+		return nil, parser.NewOfflineError(fmt.Sprintf("fetching action.yml for %s@%s", repo, ref))
+	}
 	for _, filename := range []string{"action.yml", "action.yaml"} {
 		var contentPath string
 		if subdir != "" {
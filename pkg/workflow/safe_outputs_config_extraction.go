@@ -65,6 +65,12 @@ func (c *Compiler) extractSafeOutputsConfig(frontmatter map[string]any) *SafeOut
 				config.CreateAgentSessions = agentSessionConfig
 			}
 
+			// Handle handoff-to-copilot
+			handoffConfig := c.parseHandoffToCopilotConfig(outputMap)
+			if handoffConfig != nil {
+				config.HandoffToCopilot = handoffConfig
+			}
+
 			// Handle update-project (smart project board management)
 			updateProjectConfig := c.parseUpdateProjectConfig(outputMap)
 			if updateProjectConfig != nil {
@@ -138,6 +144,12 @@ func (c *Compiler) extractSafeOutputsConfig(frontmatter map[string]any) *SafeOut
 				config.CreatePullRequestReviewComments = prReviewCommentsConfig
 			}
 
+			// Handle create-pull-request-review-suggestion
+			prReviewSuggestionsConfig := c.parsePullRequestReviewSuggestionsConfig(outputMap)
+			if prReviewSuggestionsConfig != nil {
+				config.CreatePullRequestReviewSuggestions = prReviewSuggestionsConfig
+			}
+
 			// Handle submit-pull-request-review
 			submitPRReviewConfig := c.parseSubmitPullRequestReviewConfig(outputMap)
 			if submitPRReviewConfig != nil {
@@ -264,6 +276,12 @@ func (c *Compiler) extractSafeOutputsConfig(frontmatter map[string]any) *SafeOut
 				config.UploadArtifact = uploadArtifactConfig
 			}
 
+			// Handle schedule-follow-up
+			scheduleFollowUpConfig := c.parseScheduleFollowUpConfig(outputMap)
+			if scheduleFollowUpConfig != nil {
+				config.ScheduleFollowUp = scheduleFollowUpConfig
+			}
+
 			// Handle update-release
 			updateReleaseConfig := c.parseUpdateReleaseConfig(outputMap)
 			if updateReleaseConfig != nil {
@@ -178,6 +178,12 @@ func generateSafeOutputsConfig(data *WorkflowData) (string, error) {
 		}
 	}
 
+	// Output language: consumed by the MCP server (reject-and-retry on mismatch) and
+	// by the ingestion step (post-run language validation).
+	if data.OutputLanguage != "" {
+		safeOutputsConfig["output_language"] = data.OutputLanguage
+	}
+
 	// Push-repo-memory configuration: enables the push_repo_memory MCP tool for early
 	// size validation during the agent session.
 	if data.RepoMemoryConfig != nil && len(data.RepoMemoryConfig.Memories) > 0 {
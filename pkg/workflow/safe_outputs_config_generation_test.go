@@ -850,7 +850,7 @@ func TestGenerateSafeOutputsConfigRepoMemory(t *testing.T) {
 	mem0, ok := memories[0].(map[string]any)
 	require.True(t, ok, "First memory entry should be a map")
 	assert.Equal(t, "default", mem0["id"], "First memory id should match")
-	assert.Equal(t, "/tmp/gh-aw/repo-memory/default", mem0["dir"], "First memory dir should be correct")
+	assert.Equal(t, "${{ env.GH_AW_TMP_DIR }}/repo-memory/default", mem0["dir"], "First memory dir should be correct")
 	assert.InDelta(t, float64(5120), mem0["max_file_size"], 0.0001, "First memory max_file_size should match")
 	assert.InDelta(t, float64(20480), mem0["max_patch_size"], 0.0001, "First memory max_patch_size should match")
 	assert.InDelta(t, float64(50), mem0["max_file_count"], 0.0001, "First memory max_file_count should match")
@@ -859,7 +859,7 @@ func TestGenerateSafeOutputsConfigRepoMemory(t *testing.T) {
 	mem1, ok := memories[1].(map[string]any)
 	require.True(t, ok, "Second memory entry should be a map")
 	assert.Equal(t, "notes", mem1["id"], "Second memory id should match")
-	assert.Equal(t, "/tmp/gh-aw/repo-memory/notes", mem1["dir"], "Second memory dir should be correct")
+	assert.Equal(t, "${{ env.GH_AW_TMP_DIR }}/repo-memory/notes", mem1["dir"], "Second memory dir should be correct")
 	assert.InDelta(t, float64(2048), mem1["max_file_size"], 0.0001, "Second memory max_file_size should match")
 }
 
@@ -196,6 +196,16 @@ func (c *Compiler) extractGlobalConfigFields(outputMap map[string]any, config *S
 		}
 	}
 
+	// Handle report-failure-as-comment (templatable bool)
+	if err := preprocessBoolFieldAsString(outputMap, "report-failure-as-comment", safeOutputsConfigLog); err != nil {
+		safeOutputsConfigLog.Printf("report-failure-as-comment: %v", err)
+	} else if reportFailureAsComment, exists := outputMap["report-failure-as-comment"]; exists {
+		if reportFailureAsCommentStr, ok := reportFailureAsComment.(string); ok {
+			config.ReportFailureAsComment = &reportFailureAsCommentStr
+			safeOutputsConfigLog.Printf("Report failure as comment: %s", reportFailureAsCommentStr)
+		}
+	}
+
 	// Handle failure-issue-repo (repository for failure issues, format: "owner/repo")
 	if failureIssueRepo, exists := outputMap["failure-issue-repo"]; exists {
 		if failureIssueRepoStr, ok := failureIssueRepo.(string); ok && failureIssueRepoStr != "" {
@@ -290,10 +290,12 @@ func (c *Compiler) addAllSafeOutputConfigEnvVars(steps *[]string, data *Workflow
 	}
 
 	// Check if copilot is in create-issue or create-pull-request assignees - enables inline copilot assignment
+	// handoff-to-copilot always assigns copilot to the issues it creates.
 	if (data.SafeOutputs.CreateIssues != nil && hasCopilotAssignee(data.SafeOutputs.CreateIssues.Assignees)) ||
-		(data.SafeOutputs.CreatePullRequests != nil && hasCopilotAssignee(data.SafeOutputs.CreatePullRequests.Assignees)) {
+		(data.SafeOutputs.CreatePullRequests != nil && hasCopilotAssignee(data.SafeOutputs.CreatePullRequests.Assignees)) ||
+		data.SafeOutputs.HandoffToCopilot != nil {
 		*steps = append(*steps, "          GH_AW_ASSIGN_COPILOT: \"true\"\n")
-		safeOutputsEnvLog.Print("Copilot assignment requested - enabled for create-issue or create-pull-request fallback issues")
+		safeOutputsEnvLog.Print("Copilot assignment requested - enabled for create-issue, create-pull-request fallback issues, or handoff-to-copilot")
 	}
 
 	// Note: All handler configuration is read from the config.json file at runtime.
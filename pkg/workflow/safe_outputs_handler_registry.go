@@ -18,6 +18,8 @@ var handlerRegistry = map[string]handlerBuilder{
 			AddIfTrue("require_temporary_id", c.RequireTemporaryID).
 			AddStringSlice("allowed_labels", c.AllowedLabels).
 			AddStringSlice("allowed_fields", c.AllowedFields).
+			AddStringSlice("allowed_types", c.AllowedTypes).
+			AddIfPositive("max_sub_issues", c.MaxSubIssues).
 			AddStringSlice("allowed_repos", c.AllowedRepos).
 			AddIfPositive("expires", c.Expires).
 			AddStringSlice("labels", c.Labels).
@@ -28,6 +30,7 @@ var handlerRegistry = map[string]handlerBuilder{
 			AddTemplatableBool("close_older_issues", c.CloseOlderIssues).
 			AddIfNotEmpty("close_older_key", c.CloseOlderKey).
 			AddTemplatableBool("group_by_day", c.GroupByDay).
+			AddIfNotEmpty("dedupe_key", c.DedupeKey).
 			AddTemplatableBool("footer", getEffectiveFooterForTemplatable(c.Footer, cfg.Footer)).
 			AddIfNotEmpty("github-token", c.GitHubToken).
 			AddBoolPtr("normalize_closing_keywords", c.NormalizeClosingKeywords).
@@ -53,6 +56,7 @@ var handlerRegistry = map[string]handlerBuilder{
 			AddBoolPtr("normalize_closing_keywords", c.NormalizeClosingKeywords).
 			AddStringSlice("required_labels", c.RequiredLabels).
 			AddIfNotEmpty("required_title_prefix", c.RequiredTitlePrefix).
+			AddIfNotEmpty("dedupe_key", c.DedupeKey).
 			AddTemplatableBool("staged", templatableBoolPtrToStringPtr(c.Staged)).
 			Build()
 	},
@@ -300,6 +304,12 @@ var handlerRegistry = map[string]handlerBuilder{
 				AddIfNotEmpty("output_title", c.Output.Title).
 				AddIfNotEmpty("output_summary", c.Output.Summary)
 		}
+		if c.Annotations != nil {
+			builder.
+				AddIfPositive("annotations_max", c.Annotations.Max).
+				AddStringSlice("annotations_rules", c.Annotations.Rules).
+				AddIfTrue("annotations_required", c.Annotations.Required)
+		}
 		// When a per-handler github-app is configured, the compiler mints a token in a
 		// separate step (create-check-run-app-token) and passes it as github-token so the
 		// JS handler can use it via createAuthenticatedGitHubClient.
@@ -326,6 +336,34 @@ var handlerRegistry = map[string]handlerBuilder{
 			AddTemplatableBool("staged", templatableBoolPtrToStringPtr(c.Staged)).
 			Build()
 	},
+	"handoff_to_copilot": func(cfg *SafeOutputsConfig) map[string]any {
+		if cfg.HandoffToCopilot == nil {
+			return nil
+		}
+		c := cfg.HandoffToCopilot
+		return newHandlerConfigBuilder().
+			AddTemplatableInt("max", c.Max).
+			AddIfNotEmpty("title_prefix", c.TitlePrefix).
+			AddStringSlice("labels", c.Labels).
+			AddIfNotEmpty("target-repo", c.TargetRepoSlug).
+			AddStringSlice("allowed_repos", c.AllowedRepos).
+			AddTemplatableBool("footer", getEffectiveFooterForTemplatable(nil, cfg.Footer)).
+			AddIfNotEmpty("github-token", c.GitHubToken).
+			AddTemplatableBool("staged", templatableBoolPtrToStringPtr(c.Staged)).
+			Build()
+	},
+	"schedule_follow_up": func(cfg *SafeOutputsConfig) map[string]any {
+		if cfg.ScheduleFollowUp == nil {
+			return nil
+		}
+		c := cfg.ScheduleFollowUp
+		return newHandlerConfigBuilder().
+			AddTemplatableInt("max", c.Max).
+			AddIfPositive("max_horizon_hours", c.maxHorizonHours()).
+			AddIfPositive("max_pending", c.MaxPending).
+			AddTemplatableBool("staged", templatableBoolPtrToStringPtr(c.Staged)).
+			Build()
+	},
 	"update_issue": func(cfg *SafeOutputsConfig) map[string]any {
 		if cfg.UpdateIssues == nil {
 			return nil
@@ -344,6 +382,13 @@ var handlerRegistry = map[string]handlerBuilder{
 		if c.Title != nil {
 			builder.AddDefault("allow_title", true)
 		}
+		if c.Type != nil {
+			builder.AddDefault("allow_type", true).
+				AddStringSlice("allowed_types", c.AllowedTypes)
+		}
+		if c.Parent != nil {
+			builder.AddDefault("allow_parent", true)
+		}
 		// Body uses boolean value mode - add the actual boolean value
 		builder.AddBoolPtrOrDefault("allow_body", c.Body, true)
 		return builder.
@@ -427,6 +472,22 @@ var handlerRegistry = map[string]handlerBuilder{
 			AddTemplatableBool("staged", templatableBoolPtrToStringPtr(c.Staged)).
 			Build()
 	},
+	"create_pull_request_review_suggestion": func(cfg *SafeOutputsConfig) map[string]any {
+		if cfg.CreatePullRequestReviewSuggestions == nil {
+			return nil
+		}
+		c := cfg.CreatePullRequestReviewSuggestions
+		return newHandlerConfigBuilder().
+			AddTemplatableInt("max", c.Max).
+			AddIfNotEmpty("target", c.Target).
+			AddStringSlice("required_labels", c.RequiredLabels).
+			AddIfNotEmpty("required_title_prefix", c.RequiredTitlePrefix).
+			AddIfNotEmpty("target-repo", c.TargetRepoSlug).
+			AddStringSlice("allowed_repos", c.AllowedRepos).
+			AddIfNotEmpty("github-token", c.GitHubToken).
+			AddTemplatableBool("staged", templatableBoolPtrToStringPtr(c.Staged)).
+			Build()
+	},
 	"submit_pull_request_review": func(cfg *SafeOutputsConfig) map[string]any {
 		if cfg.SubmitPullRequestReview == nil {
 			return nil
@@ -535,6 +596,7 @@ var handlerRegistry = map[string]handlerBuilder{
 			AddStringSlice("_protected_files_exclude", c.ProtectedFilesExclude).
 			AddStringSlice("allowed_files", c.AllowedFiles).
 			AddStringSlice("excluded_files", c.ExcludedFiles).
+			AddIfNotEmpty("group_by_directory", c.GroupByDirectory).
 			AddIfTrue("preserve_branch_name", c.PreserveBranchName).
 			AddIfTrue("recreate_ref", c.RecreateRef).
 			AddIfNotEmpty("patch_format", c.PatchFormat).
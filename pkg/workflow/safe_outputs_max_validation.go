@@ -126,6 +126,16 @@ func validateSafeOutputsMax(config *SafeOutputsConfig) error {
 			return err
 		}
 	}
+	if config.HandoffToCopilot != nil {
+		if err := checkMaxField("handoff_to_copilot", config.HandoffToCopilot.Max); err != nil {
+			return err
+		}
+	}
+	if config.ScheduleFollowUp != nil {
+		if err := checkMaxField("schedule_follow_up", config.ScheduleFollowUp.Max); err != nil {
+			return err
+		}
+	}
 	if config.CreateCheckRun != nil {
 		if err := checkMaxField("create_check_run", config.CreateCheckRun.Max); err != nil {
 			return err
@@ -161,6 +171,11 @@ func validateSafeOutputsMax(config *SafeOutputsConfig) error {
 			return err
 		}
 	}
+	if config.CreatePullRequestReviewSuggestions != nil {
+		if err := checkMaxField("create_pull_request_review_suggestion", config.CreatePullRequestReviewSuggestions.Max); err != nil {
+			return err
+		}
+	}
 	if config.CreatePullRequests != nil {
 		if err := checkMaxField("create_pull_request", config.CreatePullRequests.Max); err != nil {
 			return err
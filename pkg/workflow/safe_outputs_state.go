@@ -43,6 +43,8 @@ func hasAnySafeOutputEnabled(safeOutputs *SafeOutputsConfig) bool {
 	// plus CommentMemory which is attached via tools.comment-memory and not in safeOutputFieldMapping).
 	return safeOutputs.CreateIssues != nil ||
 		safeOutputs.CreateAgentSessions != nil ||
+		safeOutputs.HandoffToCopilot != nil ||
+		safeOutputs.ScheduleFollowUp != nil ||
 		safeOutputs.CreateDiscussions != nil ||
 		safeOutputs.UpdateDiscussions != nil ||
 		safeOutputs.CloseDiscussions != nil ||
@@ -54,6 +56,7 @@ func hasAnySafeOutputEnabled(safeOutputs *SafeOutputsConfig) bool {
 		safeOutputs.CommentMemory != nil ||
 		safeOutputs.CreatePullRequests != nil ||
 		safeOutputs.CreatePullRequestReviewComments != nil ||
+		safeOutputs.CreatePullRequestReviewSuggestions != nil ||
 		safeOutputs.SubmitPullRequestReview != nil ||
 		safeOutputs.ReplyToPullRequestReviewComment != nil ||
 		safeOutputs.ResolvePullRequestReviewThread != nil ||
@@ -110,6 +113,8 @@ func hasNonBuiltinSafeOutputsEnabled(safeOutputs *SafeOutputsConfig) bool {
 	// tools.comment-memory and is not in safeOutputFieldMapping.
 	return safeOutputs.CreateIssues != nil ||
 		safeOutputs.CreateAgentSessions != nil ||
+		safeOutputs.HandoffToCopilot != nil ||
+		safeOutputs.ScheduleFollowUp != nil ||
 		safeOutputs.CreateDiscussions != nil ||
 		safeOutputs.UpdateDiscussions != nil ||
 		safeOutputs.CloseDiscussions != nil ||
@@ -121,6 +126,7 @@ func hasNonBuiltinSafeOutputsEnabled(safeOutputs *SafeOutputsConfig) bool {
 		safeOutputs.CommentMemory != nil ||
 		safeOutputs.CreatePullRequests != nil ||
 		safeOutputs.CreatePullRequestReviewComments != nil ||
+		safeOutputs.CreatePullRequestReviewSuggestions != nil ||
 		safeOutputs.SubmitPullRequestReview != nil ||
 		safeOutputs.ReplyToPullRequestReviewComment != nil ||
 		safeOutputs.ResolvePullRequestReviewThread != nil ||
@@ -24,6 +24,14 @@ func computeEnabledToolNames(data *WorkflowData) map[string]struct {
 		enabledTools["create_agent_session"] = struct {
 		}{}
 	}
+	if data.SafeOutputs.HandoffToCopilot != nil {
+		enabledTools["handoff_to_copilot"] = struct {
+		}{}
+	}
+	if data.SafeOutputs.ScheduleFollowUp != nil {
+		enabledTools["schedule_follow_up"] = struct {
+		}{}
+	}
 	if data.SafeOutputs.CreateDiscussions != nil {
 		enabledTools["create_discussion"] = struct {
 		}{}
@@ -64,6 +72,10 @@ func computeEnabledToolNames(data *WorkflowData) map[string]struct {
 		enabledTools["create_pull_request_review_comment"] = struct {
 		}{}
 	}
+	if data.SafeOutputs.CreatePullRequestReviewSuggestions != nil {
+		enabledTools["create_pull_request_review_suggestion"] = struct {
+		}{}
+	}
 	if data.SafeOutputs.SubmitPullRequestReview != nil {
 		enabledTools["submit_pull_request_review"] = struct {
 		}{}
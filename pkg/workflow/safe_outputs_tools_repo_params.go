@@ -40,6 +40,11 @@ func addRepoParameterIfNeeded(tool map[string]any, toolName string, safeOutputs
 			hasAllowedRepos = len(config.AllowedRepos) > 0
 			targetRepoSlug = config.TargetRepoSlug
 		}
+	case "create_pull_request_review_suggestion":
+		if config := safeOutputs.CreatePullRequestReviewSuggestions; config != nil {
+			hasAllowedRepos = len(config.AllowedRepos) > 0
+			targetRepoSlug = config.TargetRepoSlug
+		}
 	case "reply_to_pull_request_review_comment":
 		if config := safeOutputs.ReplyToPullRequestReviewComment; config != nil {
 			hasAllowedRepos = len(config.AllowedRepos) > 0
@@ -55,6 +60,11 @@ func addRepoParameterIfNeeded(tool map[string]any, toolName string, safeOutputs
 			hasAllowedRepos = len(config.AllowedRepos) > 0
 			targetRepoSlug = config.TargetRepoSlug
 		}
+	case "handoff_to_copilot":
+		if config := safeOutputs.HandoffToCopilot; config != nil {
+			hasAllowedRepos = len(config.AllowedRepos) > 0
+			targetRepoSlug = config.TargetRepoSlug
+		}
 	case "close_issue", "update_issue":
 		if config := safeOutputs.CloseIssues; config != nil && toolName == "close_issue" {
 			hasAllowedRepos = len(config.AllowedRepos) > 0
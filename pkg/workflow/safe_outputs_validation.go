@@ -151,6 +151,9 @@ func validateSafeOutputsTarget(config *SafeOutputsConfig) error {
 	if config.CreatePullRequestReviewComments != nil {
 		configs = append(configs, targetConfig{"create-pull-request-review-comment", config.CreatePullRequestReviewComments.Target})
 	}
+	if config.CreatePullRequestReviewSuggestions != nil {
+		configs = append(configs, targetConfig{"create-pull-request-review-suggestion", config.CreatePullRequestReviewSuggestions.Target})
+	}
 	if config.SubmitPullRequestReview != nil {
 		configs = append(configs, targetConfig{"submit-pull-request-review", config.SubmitPullRequestReview.Target})
 	}
@@ -64,6 +64,7 @@ var ValidationConfig = map[string]TypeValidationConfig{
 			"body":         {Required: true, Type: "string", Sanitize: true, MaxLength: MaxBodyLength, MinLength: MinIssueBodyLength},
 			"labels":       {Type: "array", ItemType: "string", ItemSanitize: true, ItemMaxLength: 128},
 			"fields":       {Type: "array"},
+			"issue_type":   {Type: "string", Sanitize: true, MaxLength: 128},
 			"parent":       {IssueOrPRNumber: true},
 			"temporary_id": {Type: "string"},
 			"repo":         {Type: "string", MaxLength: 256}, // Optional: target repository in format "owner/repo"
@@ -76,6 +77,25 @@ var ValidationConfig = map[string]TypeValidationConfig{
 			"repo": {Type: "string", MaxLength: 256}, // Optional: target repository in format "owner/repo"
 		},
 	},
+	"handoff_to_copilot": {
+		DefaultMax: 1,
+		Fields: map[string]FieldValidation{
+			"title":               {Required: true, Type: "string", Sanitize: true, MaxLength: 128},
+			"description":         {Required: true, Type: "string", Sanitize: true, MaxLength: MaxBodyLength, MinLength: MinIssueBodyLength},
+			"acceptance_criteria": {Required: true, Type: "array", ItemType: "string", ItemSanitize: true, ItemMaxLength: 1024},
+			"relevant_files":      {Type: "array", ItemType: "string", ItemSanitize: true, ItemMaxLength: 512},
+			"out_of_scope":        {Type: "array", ItemType: "string", ItemSanitize: true, ItemMaxLength: 1024},
+			"repo":                {Type: "string", MaxLength: 256}, // Optional: target repository in format "owner/repo"
+		},
+	},
+	"schedule_follow_up": {
+		DefaultMax: 1,
+		Fields: map[string]FieldValidation{
+			"delay":       {Required: true, Type: "string", MaxLength: 32},
+			"reason":      {Required: true, Type: "string", Sanitize: true, MaxLength: 2048},
+			"item_number": {IssueOrPRNumber: true},
+		},
+	},
 	"add_comment": {
 		DefaultMax: 1,
 		Fields: map[string]FieldValidation{
@@ -187,7 +207,7 @@ var ValidationConfig = map[string]TypeValidationConfig{
 	},
 	"update_issue": {
 		DefaultMax:       1,
-		CustomValidation: "requiresOneOf:status,title,body,labels,assignees,milestone",
+		CustomValidation: "requiresOneOf:status,title,body,labels,assignees,milestone,issue_type,parent",
 		Fields: map[string]FieldValidation{
 			"status":       {Type: "string", Enum: []string{"open", "closed"}},
 			"title":        {Type: "string", Sanitize: true, MaxLength: 128},
@@ -196,6 +216,8 @@ var ValidationConfig = map[string]TypeValidationConfig{
 			"labels":       {Type: "array"},
 			"assignees":    {Type: "array", ItemType: "string", ItemSanitize: true, ItemMaxLength: MaxGitHubUsernameLength},
 			"milestone":    {OptionalPositiveInteger: true},
+			"issue_type":   {Type: "string", Sanitize: true, MaxLength: 128}, // Empty string clears the type
+			"parent":       {IssueOrPRNumber: true},
 			"issue_number": {IssueOrPRNumber: true},
 			"repo":         {Type: "string", MaxLength: 256}, // Optional: target repository in format "owner/repo"
 		},
@@ -244,6 +266,19 @@ var ValidationConfig = map[string]TypeValidationConfig{
 			"repo":                {Type: "string", MaxLength: 256}, // Optional: target repository in format "owner/repo"
 		},
 	},
+	"create_pull_request_review_suggestion": {
+		DefaultMax:       1,
+		CustomValidation: "startLineLessOrEqualLine",
+		Fields: map[string]FieldValidation{
+			"path":                {Required: true, Type: "string"},
+			"line":                {Required: true, PositiveInteger: true},
+			"start_line":          {OptionalPositiveInteger: true},
+			"suggestion":          {Type: "string", MaxLength: MaxBodyLength}, // Not sanitized: applied verbatim when committed, rendered inside a suggestion block
+			"body":                {Type: "string", Sanitize: true, MaxLength: MaxBodyLength},
+			"pull_request_number": {OptionalPositiveInteger: true},
+			"repo":                {Type: "string", MaxLength: 256}, // Optional: target repository in format "owner/repo"
+		},
+	},
 	"submit_pull_request_review": {
 		DefaultMax: 1,
 		Fields: map[string]FieldValidation{
@@ -16,6 +16,7 @@ package workflow
 import (
 	"slices"
 
+	"github.com/github/gh-aw/pkg/constants"
 	"github.com/github/gh-aw/pkg/logger"
 	"github.com/github/gh-aw/pkg/sliceutil"
 )
@@ -30,7 +31,7 @@ const (
 	SandboxTypeDefault SandboxType = "default" // Alias for AWF (backward compat)
 )
 
-const defaultAgentWorkspaceWritePath = "/tmp/gh-aw/agent"
+const defaultAgentWorkspaceWritePath = constants.TmpGhAwDirExpr + "/agent"
 
 // SandboxConfig represents the top-level sandbox configuration from front matter
 // New format: { agent: "awf"|"srt"|{type, config}, mcp: {port, command, ...} }
@@ -67,6 +68,7 @@ type AgentSandboxConfig struct {
 	Version               string                                `yaml:"version,omitempty"`        // AWF version override used to install and run the matching firewall version
 	Platform              string                                `yaml:"platform,omitempty"`       // AWF platform.type override (github.com, ghes, ghec, ghec-self-hosted)
 	Runtime               AgentRuntime                          `yaml:"runtime,omitempty"`        // Container runtime for the agent container (e.g., "gvisor")
+	Devcontainer          *DevcontainerConfig                   `yaml:"devcontainer,omitempty"`   // Run the agent in the repository's devcontainer image
 	NetworkIsolation      bool                                  `yaml:"sudo,omitempty"`           // Internal: true = isolation mode (AWF --network-isolation). Frontmatter sudo: false (or omitted) maps to NetworkIsolation=true; sudo: true maps to NetworkIsolation=false.
 	SudoExplicitlyEnabled bool                                  `yaml:"-"`                        // True when sudo: true was explicitly set in frontmatter. Used to emit an error (strict) or warning (non-strict) at compile time.
 	LegacySecurity        bool                                  `yaml:"-"`                        // True when legacy-security: enable was set in frontmatter. Enables sudo, host-access, and iptables-based mode.
@@ -82,6 +84,13 @@ type AgentSandboxConfig struct {
 	Targets               map[string]*AgentAPIProxyTargetConfig `yaml:"targets,omitempty"`        // Per-provider API proxy target overrides keyed by provider name (e.g. "openai", "anthropic")
 }
 
+// DevcontainerConfig configures running the agent inside the repository's devcontainer.
+// The image is built from the devcontainer configuration before the agent runs and
+// replaces the runner filesystem as the agent container's toolchain.
+type DevcontainerConfig struct {
+	Config string `yaml:"config,omitempty"` // Path to devcontainer.json, relative to the repository root
+}
+
 // AiCreditsPricingConfig holds per-token pricing rates ($/1M tokens) used as a fallback
 // for models not in the AWF built-in pricing table. Maps to apiProxy.defaultAiCreditsPricing
 // in the AWF config file. Required when maxAiCredits is active and the model is unrecognized.
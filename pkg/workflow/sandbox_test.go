@@ -342,7 +342,7 @@ func TestMergeImportedSandboxAgentMounts(t *testing.T) {
 }
 
 func TestDefaultAgentWorkspaceWritePath(t *testing.T) {
-	assert.Equal(t, "/tmp/gh-aw/agent", defaultAgentWorkspaceWritePath)
+	assert.Equal(t, "${{ env.GH_AW_TMP_DIR }}/agent", defaultAgentWorkspaceWritePath)
 }
 
 func TestWorkflowHashWithSandbox(t *testing.T) {
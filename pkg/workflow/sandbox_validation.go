@@ -14,6 +14,7 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/github/gh-aw/pkg/constants"
@@ -173,6 +174,37 @@ func validateSandboxConfig(workflowData *WorkflowData) error {
 		sandboxValidationLog.Print("docker-sbx runtime configured -- topology, sudo, and AWF version checks passed")
 	}
 
+	// Validate devcontainer compatibility
+	if isDevcontainerRuntime(workflowData) {
+		configPath := getDevcontainerConfigPath(workflowData)
+		if !isValidDevcontainerConfigPath(configPath) {
+			return NewValidationError(
+				"sandbox.agent.devcontainer.config",
+				configPath,
+				"devcontainer config must be a relative path to a .json file inside the repository",
+				"Use a path relative to the repository root:\n\nsandbox:\n  agent:\n    devcontainer:\n      config: .devcontainer/devcontainer.json",
+			)
+		}
+		if agentConfig.Runtime == AgentRuntimeDockerSbx || isArcDindTopology(workflowData) {
+			return NewValidationError(
+				"sandbox.agent.devcontainer",
+				configPath,
+				"devcontainer is incompatible with runtime: docker-sbx and runner.topology: arc-dind",
+				"The devcontainer image is run as a local Docker container on the runner. Remove sandbox.agent.devcontainer or the conflicting setting.",
+			)
+		}
+		if engineID := ResolveEngineID(workflowData); engineID != "" && !slices.Contains(devcontainerSupportedEngines, engineID) {
+			return NewValidationError(
+				"sandbox.agent.devcontainer",
+				configPath,
+				fmt.Sprintf("devcontainer is not supported with the %s engine", engineID),
+				fmt.Sprintf("Use one of the engines that can be staged into the devcontainer: %s.", strings.Join(devcontainerSupportedEngines, ", ")),
+			)
+		}
+
+		sandboxValidationLog.Printf("devcontainer configured -- config=%s", configPath)
+	}
+
 	// Validate config structure if provided (deprecated - was only for SRT)
 	if sandboxConfig.Config != nil {
 		// Config is no longer used - SRT removed
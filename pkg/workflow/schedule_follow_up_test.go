@@ -0,0 +1,216 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseScheduleFollowUpConfig(t *testing.T) {
+	tests := []struct {
+		name             string
+		outputMap        map[string]any
+		wantConfig       bool
+		wantMax          string
+		wantHorizonHours int
+		wantMaxPending   int
+	}{
+		{
+			name:             "null config uses defaults",
+			outputMap:        map[string]any{"schedule-follow-up": nil},
+			wantConfig:       true,
+			wantMax:          "1",
+			wantHorizonHours: 168,
+		},
+		{
+			name: "all fields",
+			outputMap: map[string]any{
+				"schedule-follow-up": map[string]any{
+					"max":         2,
+					"max-horizon": "2w",
+					"max-pending": 10,
+				},
+			},
+			wantConfig:       true,
+			wantMax:          "2",
+			wantHorizonHours: 336,
+			wantMaxPending:   10,
+		},
+		{
+			name: "max-horizon is capped at 30 days",
+			outputMap: map[string]any{
+				"schedule-follow-up": map[string]any{"max-horizon": "90d"},
+			},
+			wantConfig:       true,
+			wantMax:          "1",
+			wantHorizonHours: 720,
+		},
+		{
+			name:       "not configured",
+			outputMap:  map[string]any{},
+			wantConfig: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := NewCompiler().parseScheduleFollowUpConfig(tt.outputMap)
+			if !tt.wantConfig {
+				assert.Nil(t, config, "config should not be parsed")
+				return
+			}
+			require.NotNil(t, config, "config should be parsed")
+			require.NotNil(t, config.Max, "max should default")
+			assert.Equal(t, tt.wantMax, *config.Max, "max should match")
+			assert.Equal(t, tt.wantHorizonHours, config.maxHorizonHours(), "max horizon should match")
+			assert.Equal(t, tt.wantMaxPending, config.MaxPending, "max pending should match")
+		})
+	}
+}
+
+func TestExpandFollowUpSchedule(t *testing.T) {
+	tests := []struct {
+		name        string
+		frontmatter map[string]any
+		wantPolling bool
+		wantEvents  []string
+	}{
+		{
+			name: "string trigger gets a polling schedule",
+			frontmatter: map[string]any{
+				"on":           "issues",
+				"safe-outputs": map[string]any{"schedule-follow-up": nil},
+			},
+			wantPolling: true,
+			wantEvents:  []string{"issues", "schedule", "workflow_dispatch"},
+		},
+		{
+			name: "map trigger gets a polling schedule",
+			frontmatter: map[string]any{
+				"on":           map[string]any{"issue_comment": map[string]any{"types": []any{"created"}}},
+				"safe-outputs": map[string]any{"schedule-follow-up": nil},
+			},
+			wantPolling: true,
+			wantEvents:  []string{"issue_comment", "schedule", "workflow_dispatch"},
+		},
+		{
+			name: "existing schedule is kept",
+			frontmatter: map[string]any{
+				"on":           map[string]any{"schedule": []any{map[string]any{"cron": "0 9 * * 1"}}},
+				"safe-outputs": map[string]any{"schedule-follow-up": nil},
+			},
+			wantPolling: false,
+			wantEvents:  []string{"schedule"},
+		},
+		{
+			name: "without schedule-follow-up nothing changes",
+			frontmatter: map[string]any{
+				"on":           map[string]any{"issues": nil},
+				"safe-outputs": map[string]any{"add-comment": nil},
+			},
+			wantPolling: false,
+			wantEvents:  []string{"issues"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compiler := NewCompiler()
+			compiler.SetWorkflowIdentifier("triage.md")
+			require.NoError(t, compiler.expandFollowUpSchedule(tt.frontmatter), "expansion should succeed")
+			assert.Equal(t, tt.wantPolling, compiler.followUpPolling, "polling flag should match")
+
+			onMap, ok := tt.frontmatter["on"].(map[string]any)
+			require.True(t, ok, "on should be a map")
+			var events []string
+			for event := range onMap {
+				events = append(events, event)
+			}
+			assert.ElementsMatch(t, tt.wantEvents, events, "trigger events should match")
+		})
+	}
+}
+
+func TestCompileScheduleFollowUp(t *testing.T) {
+	dir := t.TempDir()
+	markdownPath := filepath.Join(dir, "triage.md")
+	content := `---
+on: issues
+permissions:
+  contents: read
+engine: copilot
+safe-outputs:
+  add-comment:
+  schedule-follow-up:
+    max-horizon: 14d
+    max-pending: 3
+---
+
+# Triage
+
+Ask the reporter for a reproduction and check back in three days.
+`
+	require.NoError(t, os.WriteFile(markdownPath, []byte(content), 0o644), "workflow should be written")
+
+	compiler := NewCompiler()
+	compiler.SetWorkflowIdentifier("triage.md")
+	require.NoError(t, compiler.CompileWorkflow(markdownPath), "workflow should compile")
+
+	lock, err := os.ReadFile(filepath.Join(dir, "triage.lock.yml"))
+	require.NoError(t, err, "lock file should be written")
+	lockContent := string(lock)
+
+	onSection := lockContent[strings.Index(lockContent, "\non:"):strings.Index(lockContent, "\npermissions:")]
+	assert.Contains(t, onSection, "schedule:", "a polling schedule should be added")
+	assert.Contains(t, onSection, "workflow_dispatch:", "workflow_dispatch should be added")
+
+	assert.Contains(t, lockContent, "id: check_follow_ups", "pre-activation should check for due follow-ups")
+	assert.Contains(t, lockContent, "steps.check_follow_ups.outputs.follow_ups_ok == 'true'", "activation should depend on a due follow-up")
+	assert.Contains(t, lockContent, "take_due_follow_ups.cjs", "activation should take the due follow-ups")
+	assert.Contains(t, lockContent, "<scheduled-follow-up>", "the prompt should describe due follow-ups")
+	assert.Contains(t, lockContent, "gh-aw-follow-ups-triage-${{ github.run_id }}-${{ github.run_attempt }}-schedule", "safe outputs should save the queue")
+	assert.Contains(t, lockContent, "steps.process_safe_outputs.outputs.follow_ups_scheduled != ''", "the queue should only be saved when a follow-up was scheduled")
+	assert.Contains(t, lockContent, `\"schedule_follow_up\":{`, "handler config should include schedule_follow_up")
+	assert.Contains(t, lockContent, `\"max_horizon_hours\":336`, "handler config should include the horizon in hours")
+	assert.Contains(t, lockContent, `\"max_pending\":3`, "handler config should include max pending")
+}
+
+func TestCompileScheduleFollowUpQueueCachePath(t *testing.T) {
+	dir := t.TempDir()
+	markdownPath := filepath.Join(dir, "triage.md")
+	content := `---
+on: issues
+permissions:
+  contents: read
+engine: copilot
+safe-outputs:
+  schedule-follow-up:
+---
+
+# Triage
+`
+	require.NoError(t, os.WriteFile(markdownPath, []byte(content), 0o644), "workflow should be written")
+
+	compiler := NewCompiler()
+	compiler.SetWorkflowIdentifier("triage.md")
+	require.NoError(t, compiler.CompileWorkflow(markdownPath), "workflow should compile")
+
+	lock, err := os.ReadFile(filepath.Join(dir, "triage.lock.yml"))
+	require.NoError(t, err, "lock file should be written")
+	restorePaths, savePaths := lockCacheStepPaths(t, lock)
+
+	queuePath := "${{ runner.temp }}/gh-aw-cache/follow-ups"
+	assert.Contains(t, savePaths, queuePath, "the safe outputs job should save the queue")
+	assert.Contains(t, restorePaths, queuePath, "scheduled runs should restore the queue")
+	for _, path := range append(restorePaths, savePaths...) {
+		if strings.Contains(path, "follow-ups") {
+			assert.Equal(t, queuePath, path, "the queue should be saved at the path it is restored from")
+		}
+	}
+}
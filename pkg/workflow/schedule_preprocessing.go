@@ -234,6 +234,16 @@ func (c *Compiler) preprocessScheduleFields(frontmatter map[string]any, markdown
 		return nil
 	}
 
+	// reaction_command polls for reactions, so it contributes a schedule of its own
+	if err := expandReactionCommandSchedule(onMap); err != nil {
+		return err
+	}
+
+	// on.filters.paths becomes a native paths filter wherever GitHub Actions supports one
+	if err := expandTriggerFilterPaths(onMap); err != nil {
+		return err
+	}
+
 	// Check if schedule field exists in the "on" map
 	scheduleValue, hasSchedule := onMap["schedule"]
 	if !hasSchedule {
@@ -274,10 +284,15 @@ func (c *Compiler) preprocessScheduleFields(frontmatter map[string]any, markdown
 		return nil
 	}
 
+	// Handle single object format: schedule: { cron: "...", timezone: "...", jitter: "..." }
+	if scheduleObject, ok := scheduleValue.(map[string]any); ok {
+		scheduleValue = []any{scheduleObject}
+	}
+
 	// Schedule should be an array of schedule items
 	scheduleArray, ok := scheduleValue.([]any)
 	if !ok {
-		return errors.New("schedule field must be a string or an array")
+		return errors.New("schedule field must be a string, an object, or an array")
 	}
 
 	// Initialize friendly formats map for this compilation
@@ -285,8 +300,12 @@ func (c *Compiler) preprocessScheduleFields(frontmatter map[string]any, markdown
 		c.scheduleFriendlyFormats = make(map[int]string)
 	}
 
-	// Process each schedule item
+	// Process each schedule item. Items with a timezone may expand to several UTC
+	// items, so the friendly formats are re-keyed by the position in the output.
 	schedulePreprocessingLog.Printf("Processing %d schedule items", len(scheduleArray))
+	outputArray := make([]any, 0, len(scheduleArray))
+	outputFriendlyFormats := make(map[int]string)
+	c.scheduleOptions = &compiledScheduleOptions{}
 	for i, item := range scheduleArray {
 		itemMap, ok := item.(map[string]any)
 		if !ok {
@@ -304,10 +323,22 @@ func (c *Compiler) preprocessScheduleFields(frontmatter map[string]any, markdown
 		}
 
 		// Validate optional timezone field (IANA timezone string)
+		timezone := ""
 		if tzValue, hasTimezone := itemMap["timezone"]; hasTimezone {
-			if _, ok := tzValue.(string); !ok {
+			tzStr, ok := tzValue.(string)
+			if !ok {
 				return fmt.Errorf("schedule item %d 'timezone' field must be a string (IANA timezone, e.g. \"America/New_York\")", i)
 			}
+			timezone = tzStr
+		}
+
+		// Jitter applies to every scheduled run; the largest value wins
+		if jitterValue, hasJitter := itemMap["jitter"]; hasJitter {
+			jitter, err := parseScheduleJitter(jitterValue)
+			if err != nil {
+				return fmt.Errorf("schedule item %d: %w", i, err)
+			}
+			c.scheduleOptions.JitterSeconds = max(c.scheduleOptions.JitterSeconds, int(jitter.Seconds()))
 		}
 
 		// Try to parse as human-friendly schedule
@@ -317,15 +348,43 @@ func (c *Compiler) preprocessScheduleFields(frontmatter map[string]any, markdown
 			return err
 		}
 
-		// Update the cron field with the parsed cron expression
-		itemMap["cron"] = parsedCron
+		if timezone == "" {
+			// GitHub Actions evaluates cron expressions in UTC already
+			outputFriendlyFormats[len(outputArray)] = original
+			outputArray = append(outputArray, map[string]any{"cron": parsedCron})
+			c.scheduleOptions.UnguardedCrons = append(c.scheduleOptions.UnguardedCrons, parsedCron)
+			continue
+		}
 
-		// If there was an original friendly format, store it for later use
-		if original != "" {
-			c.scheduleFriendlyFormats[i] = original
+		checks, err := convertScheduleTimezone(parsedCron, timezone)
+		if err != nil {
+			return fmt.Errorf("schedule item %d: %w", i, err)
+		}
+		local := original
+		if local == "" {
+			local = parsedCron
+		}
+		for _, check := range checks {
+			outputFriendlyFormats[len(outputArray)] = fmt.Sprintf("%s in %s (UTC%s)", local, timezone, check.Offset)
+			outputArray = append(outputArray, map[string]any{"cron": check.Cron})
+		}
+		if len(checks) == 1 {
+			c.scheduleOptions.UnguardedCrons = append(c.scheduleOptions.UnguardedCrons, checks[0].Cron)
+		} else {
+			c.scheduleOptions.TimezoneChecks = append(c.scheduleOptions.TimezoneChecks, checks...)
 		}
 	}
 
+	onMap["schedule"] = outputArray
+	for index, friendly := range outputFriendlyFormats {
+		if friendly != "" {
+			c.scheduleFriendlyFormats[index] = friendly
+		}
+	}
+	if len(outputArray) > 10 {
+		return fmt.Errorf("schedule has %d cron expressions after converting timezones to UTC, but GitHub Actions allows at most 10", len(outputArray))
+	}
+
 	// Add workflow_dispatch if not already present
 	if _, hasWorkflowDispatch := onMap["workflow_dispatch"]; !hasWorkflowDispatch {
 		schedulePreprocessingLog.Printf("Adding workflow_dispatch to scheduled workflow")
@@ -4,6 +4,7 @@ package workflow
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"testing"
@@ -1793,15 +1794,14 @@ func TestLabelTriggerShorthandPreprocessingErrors(t *testing.T) {
 }
 
 // TestScheduleTimezoneField verifies that the optional timezone field in schedule items
-// is validated and preserved in the output, supporting the GitHub Actions on.schedule timezone syntax.
+// is validated and converted to UTC cron expressions, one per UTC offset of the timezone.
 func TestScheduleTimezoneField(t *testing.T) {
 	tests := []struct {
-		name             string
-		frontmatter      map[string]any
-		expectedCron     string
-		expectedTimezone string
-		expectedError    bool
-		errorSubstring   string
+		name           string
+		frontmatter    map[string]any
+		expectedCrons  []string
+		expectedError  bool
+		errorSubstring string
 	}{
 		{
 			name: "schedule with valid IANA timezone",
@@ -1815,8 +1815,7 @@ func TestScheduleTimezoneField(t *testing.T) {
 					},
 				},
 			},
-			expectedCron:     "30 5 * * 1-5",
-			expectedTimezone: "America/New_York",
+			expectedCrons: []string{"30 10 * * 1-5", "30 9 * * 1-5"},
 		},
 		{
 			name: "schedule with UTC timezone",
@@ -1830,8 +1829,7 @@ func TestScheduleTimezoneField(t *testing.T) {
 					},
 				},
 			},
-			expectedCron:     "0 2 * * *",
-			expectedTimezone: "UTC",
+			expectedCrons: []string{"0 2 * * *"},
 		},
 		{
 			name: "schedule without timezone (backward compatible)",
@@ -1844,8 +1842,7 @@ func TestScheduleTimezoneField(t *testing.T) {
 					},
 				},
 			},
-			expectedCron:     "0 9 * * 1",
-			expectedTimezone: "",
+			expectedCrons: []string{"0 9 * * 1"},
 		},
 		{
 			name: "multiple schedules with mixed timezone usage",
@@ -1863,9 +1860,7 @@ func TestScheduleTimezoneField(t *testing.T) {
 					},
 				},
 			},
-			// Second schedule item checked separately in the test body below
-			expectedCron:     "30 5 * * 1-4",
-			expectedTimezone: "America/New_York",
+			expectedCrons: []string{"30 10 * * 1-4", "30 9 * * 1-4", "30 17 * * 2,4", "30 16 * * 2,4"},
 		},
 		{
 			name: "timezone field must be a string - non-string rejected",
@@ -1909,45 +1904,16 @@ func TestScheduleTimezoneField(t *testing.T) {
 
 			onMap := tt.frontmatter["on"].(map[string]any)
 			scheduleArray := onMap["schedule"].([]any)
-			firstSchedule := scheduleArray[0].(map[string]any)
-
-			actualCron, ok := firstSchedule["cron"].(string)
-			if !ok {
-				t.Errorf("expected cron to be string, got %T", firstSchedule["cron"])
-				return
-			}
-			if actualCron != tt.expectedCron {
-				t.Errorf("expected cron '%s', got '%s'", tt.expectedCron, actualCron)
-			}
-
-			if tt.expectedTimezone != "" {
-				actualTimezone, ok := firstSchedule["timezone"].(string)
-				if !ok {
-					t.Errorf("expected timezone field to be preserved as string, got %T", firstSchedule["timezone"])
-					return
-				}
-				if actualTimezone != tt.expectedTimezone {
-					t.Errorf("expected timezone '%s', got '%s'", tt.expectedTimezone, actualTimezone)
-				}
-			} else {
-				if _, hasTimezone := firstSchedule["timezone"]; hasTimezone {
-					t.Errorf("expected no timezone field, but it was present: %v", firstSchedule["timezone"])
+			var actualCrons []string
+			for _, item := range scheduleArray {
+				itemMap := item.(map[string]any)
+				if _, hasTimezone := itemMap["timezone"]; hasTimezone {
+					t.Errorf("expected timezone to be removed after conversion, got %v", itemMap["timezone"])
 				}
+				actualCrons = append(actualCrons, itemMap["cron"].(string))
 			}
-
-			// Verify second schedule item for mixed timezone test
-			if tt.name == "multiple schedules with mixed timezone usage" && len(scheduleArray) > 1 {
-				secondSchedule, ok := scheduleArray[1].(map[string]any)
-				if !ok {
-					t.Errorf("expected second schedule to be map, got %T", scheduleArray[1])
-					return
-				}
-				if cron, ok := secondSchedule["cron"].(string); !ok || cron != "30 17 * * 2,4" {
-					t.Errorf("expected second cron '30 17 * * 2,4', got '%v'", secondSchedule["cron"])
-				}
-				if tz, ok := secondSchedule["timezone"].(string); !ok || tz != "Europe/London" {
-					t.Errorf("expected second timezone 'Europe/London', got '%v'", secondSchedule["timezone"])
-				}
+			if !slices.Equal(actualCrons, tt.expectedCrons) {
+				t.Errorf("expected crons %v, got %v", tt.expectedCrons, actualCrons)
 			}
 		})
 	}
@@ -0,0 +1,358 @@
+// This file converts timezone-aware schedule items to UTC cron expressions and
+// implements schedule jitter.
+//
+// GitHub Actions evaluates cron expressions in UTC, so an item such as
+//
+//	schedule:
+//	  cron: "0 9 * * 1-5"
+//	  timezone: Europe/Berlin
+//	  jitter: 30m
+//
+// is compiled to one UTC cron per UTC offset the timezone uses during the year.
+// For timezones with daylight saving time this yields two crons, and a
+// pre-activation step only lets the cron that matches the offset currently in
+// effect through. Jitter delays scheduled runs by a random amount so that many
+// workflows sharing a schedule do not all start at the same moment.
+
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata" // embedded so conversions do not depend on the host's timezone database
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var scheduleTimezoneLog = logger.New("workflow:schedule_timezone")
+
+// scheduleTimezoneReferenceYear fixes the dates used to sample a timezone's UTC
+// offsets, so compiled schedules do not change when the compiler runs in another year.
+const scheduleTimezoneReferenceYear = 2026
+
+// maxScheduleJitter bounds how long a scheduled run may be delayed.
+const maxScheduleJitter = time.Hour
+
+var scheduleJitterPattern = regexp.MustCompile(`^([0-9]+)([smh])$`)
+
+// ScheduleTimezoneCheck ties a compiled UTC cron expression to the timezone offset
+// it was computed for.
+type ScheduleTimezoneCheck struct {
+	Cron     string // UTC cron expression emitted in the on: section
+	Timezone string // IANA timezone of the schedule item
+	Offset   string // UTC offset the cron is valid for, formatted like `date +%:z` (e.g. "+02:00")
+}
+
+// compiledScheduleOptions collects what schedule preprocessing learned about the
+// schedule items, for use when building the pre-activation job.
+type compiledScheduleOptions struct {
+	TimezoneChecks []ScheduleTimezoneCheck // UTC crons that are only valid for one offset of their timezone
+	UnguardedCrons []string                // UTC crons that always run
+	JitterSeconds  int                     // Upper bound of the random delay added to scheduled runs
+}
+
+// processScheduleOptions copies the timezone checks and jitter collected during
+// schedule preprocessing into the workflow data.
+func (c *Compiler) processScheduleOptions(workflowData *WorkflowData) {
+	if c.scheduleOptions == nil {
+		return
+	}
+	workflowData.ScheduleTimezoneChecks = c.scheduleOptions.TimezoneChecks
+	workflowData.ScheduleUnguardedCrons = c.scheduleOptions.UnguardedCrons
+	workflowData.ScheduleJitterSeconds = c.scheduleOptions.JitterSeconds
+	scheduleTimezoneLog.Printf("Schedule options: %d timezone check(s), jitter=%ds", len(workflowData.ScheduleTimezoneChecks), workflowData.ScheduleJitterSeconds)
+}
+
+// parseScheduleJitter parses a jitter value such as "30m", "90s", or "1h".
+func parseScheduleJitter(value any) (time.Duration, error) {
+	str, ok := value.(string)
+	if !ok {
+		return 0, errors.New("schedule 'jitter' must be a duration string such as \"30m\"")
+	}
+	match := scheduleJitterPattern.FindStringSubmatch(strings.TrimSpace(str))
+	if match == nil {
+		return 0, fmt.Errorf("invalid schedule jitter '%s': must be a number of seconds, minutes, or hours such as \"90s\", \"30m\", or \"1h\"", str)
+	}
+	n, _ := strconv.Atoi(match[1])
+	unit := map[string]time.Duration{"s": time.Second, "m": time.Minute, "h": time.Hour}[match[2]]
+	jitter := time.Duration(n) * unit
+	if jitter <= 0 || jitter > maxScheduleJitter {
+		return 0, fmt.Errorf("invalid schedule jitter '%s': must be greater than zero and at most %s", str, "1h")
+	}
+	return jitter, nil
+}
+
+// convertScheduleTimezone converts a cron expression written in the given IANA
+// timezone to UTC. It returns one check per distinct UTC cron; a timezone with
+// daylight saving time usually yields two.
+func convertScheduleTimezone(cron string, timezone string) ([]ScheduleTimezoneCheck, error) {
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule timezone '%s': must be an IANA timezone such as \"Europe/Berlin\" or \"America/New_York\"", timezone)
+	}
+
+	var checks []ScheduleTimezoneCheck
+	for _, offset := range scheduleTimezoneOffsets(loc) {
+		utcCron, err := shiftCronToUTC(cron, offset)
+		if err != nil {
+			return nil, fmt.Errorf("cannot convert schedule '%s' from %s to UTC: %w", cron, timezone, err)
+		}
+		if slices.ContainsFunc(checks, func(c ScheduleTimezoneCheck) bool { return c.Cron == utcCron }) {
+			continue
+		}
+		checks = append(checks, ScheduleTimezoneCheck{Cron: utcCron, Timezone: timezone, Offset: formatUTCOffset(offset)})
+	}
+	scheduleTimezoneLog.Printf("Converted %q in %s to %d UTC cron(s)", cron, timezone, len(checks))
+	return checks, nil
+}
+
+// scheduleTimezoneOffsets returns the distinct UTC offsets, in seconds, that loc
+// uses in winter and summer of the reference year.
+func scheduleTimezoneOffsets(loc *time.Location) []int {
+	var offsets []int
+	for _, month := range []time.Month{time.January, time.July} {
+		_, offset := time.Date(scheduleTimezoneReferenceYear, month, 15, 12, 0, 0, 0, time.UTC).In(loc).Zone()
+		if !slices.Contains(offsets, offset) {
+			offsets = append(offsets, offset)
+		}
+	}
+	sort.Ints(offsets)
+	return offsets
+}
+
+func formatUTCOffset(offsetSeconds int) string {
+	sign := "+"
+	if offsetSeconds < 0 {
+		sign = "-"
+		offsetSeconds = -offsetSeconds
+	}
+	return fmt.Sprintf("%s%02d:%02d", sign, offsetSeconds/3600, offsetSeconds%3600/60)
+}
+
+// shiftCronToUTC rewrites a local cron expression for a timezone with the given UTC
+// offset. The minute and hour fields are shifted, and when the UTC time falls on a
+// different day the day-of-week field is shifted too.
+func shiftCronToUTC(cron string, offsetSeconds int) (string, error) {
+	fields := strings.Fields(cron)
+	if len(fields) != 5 {
+		return "", errors.New("cron expression must have exactly 5 fields")
+	}
+	minuteField, hourField, domField, monthField, dowField := fields[0], fields[1], fields[2], fields[3], fields[4]
+	offsetMinutes := offsetSeconds / 60
+	if offsetMinutes == 0 {
+		return cron, nil
+	}
+
+	minute := -1
+	if m, err := strconv.Atoi(minuteField); err == nil {
+		minute = m
+	} else if offsetMinutes%60 != 0 {
+		return "", fmt.Errorf("the UTC offset %s is not a whole number of hours, so the minute field must be a single number", formatUTCOffset(offsetSeconds))
+	}
+
+	hours, err := expandCronField(hourField, 0, 23, nil)
+	if err != nil {
+		return "", fmt.Errorf("hour field: %w", err)
+	}
+	if len(hours) == 24 {
+		// Runs every hour: only a partial-hour offset changes anything.
+		if domField != "*" || dowField != "*" {
+			return "", errors.New("schedules that run every hour on specific days cannot be converted; list the hours explicitly")
+		}
+		if minute >= 0 {
+			minuteField = strconv.Itoa(floorMod(minute-offsetMinutes, 60))
+		}
+		return strings.Join([]string{minuteField, hourField, domField, monthField, dowField}, " "), nil
+	}
+
+	localMinute := max(minute, 0)
+	utcHours := make([]int, 0, len(hours))
+	var dayShifts []int
+	utcMinute := 0
+	for _, hour := range hours {
+		total := hour*60 + localMinute - offsetMinutes
+		if shift := floorDiv(total, 24*60); !slices.Contains(dayShifts, shift) {
+			dayShifts = append(dayShifts, shift)
+		}
+		utcMinute = floorMod(total, 60)
+		utcHours = append(utcHours, floorMod(total, 24*60)/60)
+	}
+	sort.Ints(utcHours)
+	if minute >= 0 {
+		minuteField = strconv.Itoa(utcMinute)
+	}
+
+	// Without day restrictions it does not matter which UTC day an hour falls on
+	if domField == "*" && dowField == "*" {
+		return strings.Join([]string{minuteField, joinInts(utcHours), domField, monthField, dowField}, " "), nil
+	}
+	if len(dayShifts) > 1 {
+		return "", errors.New("the hours fall on different days in UTC; split them into separate schedule items")
+	}
+	if dayShift := dayShifts[0]; dayShift != 0 {
+		if domField != "*" {
+			return "", errors.New("the UTC time falls on a different day, which cannot be expressed with a day-of-month field")
+		}
+		if dowField != "*" {
+			days, err := expandCronField(dowField, 0, 7, cronWeekdayNames)
+			if err != nil {
+				return "", fmt.Errorf("day-of-week field: %w", err)
+			}
+			shifted := make([]int, 0, len(days))
+			for _, day := range days {
+				if d := floorMod(day+dayShift, 7); !slices.Contains(shifted, d) {
+					shifted = append(shifted, d)
+				}
+			}
+			sort.Ints(shifted)
+			dowField = joinInts(shifted)
+		}
+	}
+
+	return strings.Join([]string{minuteField, joinInts(utcHours), domField, monthField, dowField}, " "), nil
+}
+
+var cronWeekdayNames = map[string]int{"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6}
+
+// expandCronField expands a cron field made of values, ranges, steps, and lists
+// into the sorted values it matches.
+func expandCronField(field string, minValue int, maxValue int, names map[string]int) ([]int, error) {
+	parseValue := func(s string) (int, error) {
+		if v, ok := names[strings.ToLower(s)]; ok {
+			return v, nil
+		}
+		v, err := strconv.Atoi(s)
+		if err != nil || v < minValue || v > maxValue {
+			return 0, fmt.Errorf("unsupported value '%s'", s)
+		}
+		return v, nil
+	}
+
+	var values []int
+	for part := range strings.SplitSeq(field, ",") {
+		base, stepStr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			s, err := strconv.Atoi(stepStr)
+			if err != nil || s < 1 {
+				return nil, fmt.Errorf("unsupported step '%s'", part)
+			}
+			step = s
+		}
+		start, end := minValue, maxValue
+		switch {
+		case base == "*":
+		case strings.Contains(base, "-"):
+			from, to, _ := strings.Cut(base, "-")
+			var err error
+			if start, err = parseValue(from); err != nil {
+				return nil, err
+			}
+			if end, err = parseValue(to); err != nil {
+				return nil, err
+			}
+		default:
+			v, err := parseValue(base)
+			if err != nil {
+				return nil, err
+			}
+			start = v
+			if !hasStep {
+				end = v
+			}
+		}
+		for v := start; v <= end; v += step {
+			if !slices.Contains(values, v) {
+				values = append(values, v)
+			}
+		}
+	}
+	sort.Ints(values)
+	return values, nil
+}
+
+func joinInts(values []int) string {
+	parts := make([]string, 0, len(values))
+	for _, v := range values {
+		parts = append(parts, strconv.Itoa(v))
+	}
+	return strings.Join(parts, ",")
+}
+
+func floorDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && a < 0 {
+		q--
+	}
+	return q
+}
+
+func floorMod(a, b int) int {
+	return ((a % b) + b) % b
+}
+
+// scheduleTimezoneGuards returns the checks whose cron is only valid while the
+// timezone uses a particular offset. Crons that are also emitted unconditionally,
+// or for every offset of an item, always pass.
+func scheduleTimezoneGuards(checks []ScheduleTimezoneCheck, unguardedCrons []string) map[string][]ScheduleTimezoneCheck {
+	guards := make(map[string][]ScheduleTimezoneCheck)
+	for _, check := range checks {
+		if slices.Contains(unguardedCrons, check.Cron) {
+			continue
+		}
+		guards[check.Cron] = append(guards[check.Cron], check)
+	}
+	return guards
+}
+
+// appendPreActivationScheduleTimezoneStep adds the step that skips scheduled runs
+// whose cron was compiled for a UTC offset the timezone is not currently using.
+func (c *Compiler) appendPreActivationScheduleTimezoneStep(data *WorkflowData, steps []string) []string {
+	guards := scheduleTimezoneGuards(data.ScheduleTimezoneChecks, data.ScheduleUnguardedCrons)
+	crons := make([]string, 0, len(guards))
+	for cron := range guards {
+		crons = append(crons, cron)
+	}
+	sort.Strings(crons)
+
+	steps = append(steps, "      - name: Check schedule timezone\n")
+	steps = append(steps, fmt.Sprintf("        id: %s\n", constants.CheckScheduleTimezoneStepID))
+	steps = append(steps, "        env:\n")
+	steps = append(steps, "          GH_AW_SCHEDULE: ${{ github.event.schedule }}\n")
+	steps = append(steps, "        run: |\n")
+	steps = append(steps, "          ok=true\n")
+	steps = append(steps, "          case \"$GH_AW_SCHEDULE\" in\n")
+	for _, cron := range crons {
+		var tests []string
+		for _, check := range guards[cron] {
+			tests = append(tests, fmt.Sprintf("[ \"$(TZ=%s date +%%:z)\" = \"%s\" ]", check.Timezone, check.Offset))
+		}
+		steps = append(steps, fmt.Sprintf("            %q) %s || ok=false ;;\n", cron, strings.Join(tests, " || ")))
+	}
+	steps = append(steps, "          esac\n")
+	steps = append(steps, "          if [ \"$ok\" = false ]; then echo \"Schedule '$GH_AW_SCHEDULE' does not match the current daylight saving time offset, skipping\"; fi\n")
+	steps = append(steps, fmt.Sprintf("          echo \"%s=$ok\" >> \"$GITHUB_OUTPUT\"\n", constants.ScheduleTimezoneOkOutput))
+	return steps
+}
+
+// appendPreActivationScheduleJitterStep adds a randomized sleep to scheduled runs.
+func (c *Compiler) appendPreActivationScheduleJitterStep(data *WorkflowData, steps []string) []string {
+	condition := "github.event_name == 'schedule'"
+	if len(scheduleTimezoneGuards(data.ScheduleTimezoneChecks, data.ScheduleUnguardedCrons)) > 0 {
+		condition += fmt.Sprintf(" && steps.%s.outputs.%s == 'true'", constants.CheckScheduleTimezoneStepID, constants.ScheduleTimezoneOkOutput)
+	}
+	steps = append(steps, "      - name: Apply schedule jitter\n")
+	steps = append(steps, fmt.Sprintf("        if: %s\n", condition))
+	steps = append(steps, "        run: |\n")
+	steps = append(steps, fmt.Sprintf("          delay=$((RANDOM %% %d))\n", data.ScheduleJitterSeconds))
+	steps = append(steps, "          echo \"Delaying scheduled run by ${delay}s to spread load\"\n")
+	steps = append(steps, "          sleep \"$delay\"\n")
+	return steps
+}
@@ -0,0 +1,139 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShiftCronToUTC(t *testing.T) {
+	tests := []struct {
+		name     string
+		cron     string
+		offset   int
+		expected string
+	}{
+		{name: "no offset", cron: "0 9 * * 1-5", offset: 0, expected: "0 9 * * 1-5"},
+		{name: "positive offset same day", cron: "0 9 * * 1-5", offset: 2 * 3600, expected: "0 7 * * 1-5"},
+		{name: "negative offset same day", cron: "30 5 * * *", offset: -5 * 3600, expected: "30 10 * * *"},
+		{name: "previous day shifts weekdays", cron: "0 1 * * 1-5", offset: 2 * 3600, expected: "0 23 * * 0,1,2,3,4"},
+		{name: "next day shifts weekdays", cron: "0 22 * * fri", offset: -5 * 3600, expected: "0 3 * * 6"},
+		{name: "partial hour offset", cron: "15 9 * * *", offset: 5*3600 + 30*60, expected: "45 3 * * *"},
+		{name: "hour list without day restrictions", cron: "0 */6 * * *", offset: 3600, expected: "0 5,11,17,23 * * *"},
+		{name: "every hour with partial offset", cron: "0 * * * *", offset: 5*3600 + 30*60, expected: "30 * * * *"},
+		{name: "minute wildcard with whole hour offset", cron: "*/15 9 * * *", offset: 3600, expected: "*/15 8 * * *"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := shiftCronToUTC(tt.cron, tt.offset)
+			require.NoError(t, err, "cron should convert")
+			assert.Equal(t, tt.expected, actual, "converted cron should match")
+		})
+	}
+}
+
+func TestShiftCronToUTCErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		cron    string
+		offset  int
+		wantErr string
+	}{
+		{name: "day of month crosses midnight", cron: "0 1 15 * *", offset: 2 * 3600, wantErr: "day-of-month"},
+		{name: "hours on different UTC days", cron: "0 1,12 * * 1", offset: 2 * 3600, wantErr: "split them into separate schedule items"},
+		{name: "minute range with partial offset", cron: "0-30 9 * * *", offset: 5*3600 + 30*60, wantErr: "minute field must be a single number"},
+		{name: "every hour on specific days", cron: "0 * * * 1", offset: 3600, wantErr: "list the hours explicitly"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := shiftCronToUTC(tt.cron, tt.offset)
+			require.Error(t, err, "unsupported conversion should be rejected")
+			assert.Contains(t, err.Error(), tt.wantErr, "error should explain the problem")
+		})
+	}
+}
+
+func TestConvertScheduleTimezone(t *testing.T) {
+	checks, err := convertScheduleTimezone("0 9 * * 1-5", "Europe/Berlin")
+	require.NoError(t, err, "Europe/Berlin should convert")
+	assert.Equal(t, []ScheduleTimezoneCheck{
+		{Cron: "0 8 * * 1-5", Timezone: "Europe/Berlin", Offset: "+01:00"},
+		{Cron: "0 7 * * 1-5", Timezone: "Europe/Berlin", Offset: "+02:00"},
+	}, checks, "a timezone with daylight saving time should yield one cron per offset")
+
+	checks, err = convertScheduleTimezone("0 9 * * *", "Asia/Kolkata")
+	require.NoError(t, err, "Asia/Kolkata should convert")
+	assert.Equal(t, []ScheduleTimezoneCheck{{Cron: "30 3 * * *", Timezone: "Asia/Kolkata", Offset: "+05:30"}}, checks, "a timezone without daylight saving time should yield one cron")
+
+	_, err = convertScheduleTimezone("0 9 * * *", "Mars/Olympus")
+	require.Error(t, err, "unknown timezones should be rejected")
+	assert.Contains(t, err.Error(), "must be an IANA timezone", "error should explain the expected format")
+}
+
+func TestParseScheduleJitter(t *testing.T) {
+	jitter, err := parseScheduleJitter("30m")
+	require.NoError(t, err, "minutes should parse")
+	assert.Equal(t, 30*time.Minute, jitter, "jitter should match")
+
+	jitter, err = parseScheduleJitter("90s")
+	require.NoError(t, err, "seconds should parse")
+	assert.Equal(t, 90*time.Second, jitter, "jitter should match")
+
+	for _, value := range []any{"2h", "0m", "soon", 30} {
+		_, err := parseScheduleJitter(value)
+		assert.Error(t, err, "jitter %v should be rejected", value)
+	}
+}
+
+func TestCompileScheduleTimezoneAndJitter(t *testing.T) {
+	dir := t.TempDir()
+	markdownPath := filepath.Join(dir, "standup.md")
+	content := `---
+on:
+  schedule:
+    cron: "0 9 * * 1-5"
+    timezone: Europe/Berlin
+    jitter: 30m
+engine: copilot
+---
+
+# Standup
+
+Summarize yesterday's activity.
+`
+	require.NoError(t, os.WriteFile(markdownPath, []byte(content), 0o644), "workflow should be written")
+	compiler := NewCompiler()
+	compiler.SetWorkflowIdentifier("standup.md")
+	require.NoError(t, compiler.CompileWorkflow(markdownPath), "workflow should compile")
+
+	lock, err := os.ReadFile(filepath.Join(dir, "standup.lock.yml"))
+	require.NoError(t, err, "lock file should be written")
+	lockContent := string(lock)
+
+	assert.Contains(t, lockContent, `- cron: "0 8 * * 1-5"  # Friendly format: 0 9 * * 1-5 in Europe/Berlin (UTC+01:00)`, "winter cron should be emitted")
+	assert.Contains(t, lockContent, `- cron: "0 7 * * 1-5"  # Friendly format: 0 9 * * 1-5 in Europe/Berlin (UTC+02:00)`, "summer cron should be emitted")
+	assert.NotContains(t, lockContent, "timezone: Europe/Berlin", "timezone should not be passed to GitHub Actions")
+	assert.Contains(t, lockContent, `"0 7 * * 1-5") [ "$(TZ=Europe/Berlin date +%:z)" = "+02:00" ] || ok=false ;;`, "summer cron should only run during daylight saving time")
+	assert.Contains(t, lockContent, "steps.check_schedule_timezone.outputs.schedule_timezone_ok == 'true'", "activation should depend on the timezone check")
+	assert.Contains(t, lockContent, "delay=$((RANDOM % 1800))", "jitter should add a randomized sleep")
+}
+
+func TestCompileScheduleJitterOnly(t *testing.T) {
+	dir := t.TempDir()
+	markdownPath := filepath.Join(dir, "nightly.md")
+	content := "---\non:\n  schedule:\n    - cron: \"0 2 * * *\"\n      jitter: 5m\nengine: copilot\n---\n\n# Nightly\n"
+	require.NoError(t, os.WriteFile(markdownPath, []byte(content), 0o644), "workflow should be written")
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(markdownPath), "workflow should compile")
+
+	lock, err := os.ReadFile(filepath.Join(dir, "nightly.lock.yml"))
+	require.NoError(t, err, "lock file should be written")
+	assert.Contains(t, string(lock), "delay=$((RANDOM % 300))", "jitter should add a randomized sleep")
+	assert.NotContains(t, string(lock), "check_schedule_timezone", "no timezone check is needed without a timezone")
+}
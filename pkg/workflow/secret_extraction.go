@@ -72,7 +72,7 @@ func ExtractSecretsFromValue(value string) map[string]string {
 				varName := match[1]
 				// Store the full expression that contains this secret
 				secrets[varName] = expr
-				secretLog.Printf("Extracted secret: %s from expression: %s", varName, expr)
+				secretLog.Tracef("Extracted secret: %s from expression: %s", varName, expr)
 			}
 		}
 	}
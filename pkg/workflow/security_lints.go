@@ -0,0 +1,329 @@
+package workflow
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var securityLintsLog = logger.New("workflow:security_lints")
+
+// Security lint IDs. The IDs are stable so that CI can baseline accepted findings.
+const (
+	SecurityLintWritePermissionsOpenNetwork = "write-permissions-open-network"
+	SecurityLintBashWildcardWithSecrets     = "bash-wildcard-with-secrets"
+	SecurityLintMissingTimeout              = "missing-timeout"
+	SecurityLintUnpinnedAction              = "unpinned-action"
+	SecurityLintMCPServerWithoutDigest      = "mcp-server-without-digest"
+)
+
+// SecurityLintBaselineFileName is the path of the security lint baseline relative to the git root.
+const SecurityLintBaselineFileName = ".github/aw/security-lints-baseline.json"
+
+// SecurityLint is a risky configuration found by the security lints run by compile --strict.
+type SecurityLint struct {
+	ID        string `json:"id"`
+	Message   string `json:"message"`
+	Baselined bool   `json:"baselined,omitempty"` // Accepted in the baseline file; does not fail the compile
+}
+
+// SecurityLintException accepts one security lint finding for one workflow.
+type SecurityLintException struct {
+	Workflow string `json:"workflow"`
+	ID       string `json:"id"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// SecurityLintBaseline is the content of the security lint baseline file.
+type SecurityLintBaseline struct {
+	Version    int                     `json:"version"`
+	Exceptions []SecurityLintException `json:"exceptions"`
+}
+
+// has reports whether the baseline accepts lint id for workflowID.
+func (b *SecurityLintBaseline) has(workflowID, id string) bool {
+	if b == nil {
+		return false
+	}
+	for _, exception := range b.Exceptions {
+		if exception.Workflow == workflowID && exception.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// usesLinePattern matches the action reference of a `uses:` line in the generated YAML.
+var usesLinePattern = regexp.MustCompile(`(?m)^\s*(?:-\s+)?uses:\s*['"]?([^\s'"#]+)`)
+
+// pinnedActionRefPattern matches an action ref pinned to a full commit SHA.
+var pinnedActionRefPattern = regexp.MustCompile(`@[0-9a-f]{40}$`)
+
+// RunSecurityLints checks the compiled workflow for risky configurations and returns the
+// findings sorted by lint ID.
+func RunSecurityLints(data *WorkflowData, yamlContent string) []SecurityLint {
+	var lints []SecurityLint
+	add := func(id, format string, args ...any) {
+		lints = append(lints, SecurityLint{ID: id, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if writeScopes := securityLintWriteScopes(data); len(writeScopes) > 0 && !hasNetworkRestrictions(data.NetworkPermissions) {
+		add(SecurityLintWritePermissionsOpenNetwork, "write permissions (%s) are granted while network access is not restricted beyond the defaults; add a network.allowed list or drop the write scopes", strings.Join(writeScopes, ", "))
+	}
+
+	if hasSecurityLintBashWildcard(data) {
+		if secrets := securityLintUserSecrets(data); len(secrets) > 0 {
+			add(SecurityLintBashWildcardWithSecrets, "bash allows any command while secrets are used in the agent job (%s); list the allowed bash commands or move the secrets to a separate job", strings.Join(secrets, ", "))
+		}
+	}
+
+	if _, ok := data.RawFrontmatter["timeout-minutes"]; !ok {
+		add(SecurityLintMissingTimeout, "timeout-minutes is not set; set an explicit limit for the agent job")
+	}
+
+	seen := make(map[string]bool)
+	for _, match := range usesLinePattern.FindAllStringSubmatch(yamlContent, -1) {
+		ref := match[1]
+		if seen[ref] || strings.HasPrefix(ref, "./") || strings.HasPrefix(ref, "docker://") || pinnedActionRefPattern.MatchString(ref) {
+			continue
+		}
+		seen[ref] = true
+		add(SecurityLintUnpinnedAction, "action %s is not pinned to a commit SHA", ref)
+	}
+
+	for _, server := range collectMCPServerImages(data) {
+		if server.PinnedImage == "" {
+			add(SecurityLintMCPServerWithoutDigest, "MCP server '%s' uses image %s without a digest; run 'gh aw compile --update-mcp' to pin it", server.Name, server.Image)
+		}
+	}
+
+	sort.SliceStable(lints, func(i, j int) bool { return lints[i].ID < lints[j].ID })
+	return lints
+}
+
+// securityLintWriteScopes returns the write scopes of the workflow and custom job permissions,
+// ignoring copilot-requests which only authenticates the engine. Custom job scopes are
+// prefixed with the job name.
+func securityLintWriteScopes(data *WorkflowData) []string {
+	var scopes []string
+	collect := func(prefix string, permissions *Permissions) {
+		for _, scope := range findWritePermissions(permissions) {
+			if scope != PermissionCopilotRequests {
+				scopes = append(scopes, prefix+string(scope))
+			}
+		}
+	}
+	if data.Permissions != "" {
+		collect("", NewPermissionsParser(data.Permissions).ToPermissions())
+	}
+	jobNames := make([]string, 0, len(data.Jobs))
+	for name := range data.Jobs {
+		jobNames = append(jobNames, name)
+	}
+	sort.Strings(jobNames)
+	for _, name := range jobNames {
+		job, ok := data.Jobs[name].(map[string]any)
+		if !ok || job["permissions"] == nil {
+			continue
+		}
+		collect("jobs."+name+": ", NewPermissionsParserFromValue(job["permissions"]).ToPermissions())
+	}
+	return scopes
+}
+
+// hasSecurityLintBashWildcard reports whether the bash tool allows any command.
+func hasSecurityLintBashWildcard(data *WorkflowData) bool {
+	if data.ParsedTools == nil || data.ParsedTools.Bash == nil {
+		return false
+	}
+	commands := data.ParsedTools.Bash.AllowedCommands
+	if commands == nil {
+		return true // bash: true
+	}
+	for _, cmd := range commands {
+		if cmd == ":*" || cmd == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// securityLintUserSecrets returns the secrets the workflow uses in the agent job through the
+// top-level env, engine.env, or custom steps.
+func securityLintUserSecrets(data *WorkflowData) []string {
+	found := make(map[string]bool)
+	collect := func(text string) {
+		for _, match := range SecretExpressionPattern.FindAllStringSubmatch(text, -1) {
+			found[match[1]] = true
+		}
+	}
+	collect(data.Env)
+	collect(data.CustomSteps)
+	collect(data.PreAgentSteps)
+	if data.EngineConfig != nil {
+		for _, value := range data.EngineConfig.Env {
+			collect(value)
+		}
+	}
+	secrets := make([]string, 0, len(found))
+	for name := range found {
+		secrets = append(secrets, name)
+	}
+	sort.Strings(secrets)
+	return secrets
+}
+
+// SetSecurityLints enables the security lints run by compile --strict.
+func (c *Compiler) SetSecurityLints(enabled bool) {
+	c.securityLints = enabled
+}
+
+// SetUpdateLintBaseline records security lint findings without failing the compile so that
+// WriteSecurityLintBaseline can accept them (--update-lint-baseline).
+func (c *Compiler) SetUpdateLintBaseline(update bool) {
+	c.updateLintBaseline = update
+}
+
+// loadSecurityLintBaseline reads the baseline file from the git root. A missing file is an
+// empty baseline.
+func (c *Compiler) loadSecurityLintBaseline() (*SecurityLintBaseline, error) {
+	if c.securityLintBaseReady {
+		return c.securityLintBaseline, c.securityLintBaseErr
+	}
+	c.securityLintBaseReady = true
+	if c.gitRoot == "" {
+		return nil, nil
+	}
+	path := filepath.Join(c.gitRoot, SecurityLintBaselineFileName)
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		c.securityLintBaseErr = fmt.Errorf("failed to read %s: %w", SecurityLintBaselineFileName, err)
+		return nil, c.securityLintBaseErr
+	}
+	var baseline SecurityLintBaseline
+	if err := json.Unmarshal(content, &baseline); err != nil {
+		c.securityLintBaseErr = fmt.Errorf("failed to parse %s: %w", SecurityLintBaselineFileName, err)
+		return nil, c.securityLintBaseErr
+	}
+	securityLintsLog.Printf("Loaded %d security lint exception(s)", len(baseline.Exceptions))
+	c.securityLintBaseline = &baseline
+	return c.securityLintBaseline, nil
+}
+
+// checkSecurityLints runs the security lints when enabled and fails on findings that are
+// not accepted in the baseline.
+func (c *Compiler) checkSecurityLints(data *WorkflowData, markdownPath, yamlContent string) error {
+	delete(c.securityLintReports, markdownPath)
+	if !c.securityLints {
+		return nil
+	}
+	lints := RunSecurityLints(data, yamlContent)
+	if c.securityLintReports == nil {
+		c.securityLintReports = make(map[string][]SecurityLint)
+	}
+	c.securityLintReports[markdownPath] = lints
+	if len(lints) == 0 || c.updateLintBaseline {
+		return nil
+	}
+
+	baseline, err := c.loadSecurityLintBaseline()
+	if err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+	workflowID := GetWorkflowIDFromPath(markdownPath)
+	collector := NewErrorCollector(c.failFast)
+	for i := range lints {
+		if baseline.has(workflowID, lints[i].ID) {
+			lints[i].Baselined = true
+			continue
+		}
+		message := fmt.Sprintf("[%s] %s. Accept it with 'gh aw compile --strict --update-lint-baseline'", lints[i].ID, lints[i].Message)
+		if err := collector.Add(formatCompilerError(markdownPath, "error", message, nil)); err != nil {
+			return err
+		}
+	}
+	securityLintsLog.Printf("%s: %d security lint finding(s), %d not baselined", workflowID, len(lints), collector.Count())
+	return collector.FormattedError("security lint")
+}
+
+// GetSecurityLints returns the security lint findings of the workflow at markdownPath.
+func (c *Compiler) GetSecurityLints(markdownPath string) []SecurityLint {
+	return c.securityLintReports[markdownPath]
+}
+
+// WriteSecurityLintBaseline writes the baseline file accepting the security lint findings of
+// the workflows compiled by this compiler. Exceptions of other workflows are kept, as are the
+// reasons of exceptions that are still needed. Returns the number of exceptions written.
+func (c *Compiler) WriteSecurityLintBaseline() (int, error) {
+	if c.gitRoot == "" {
+		return 0, errors.New("--update-lint-baseline requires a git repository")
+	}
+	c.securityLintBaseReady = false
+	existing, err := c.loadSecurityLintBaseline()
+	if err != nil {
+		return 0, err
+	}
+
+	compiled := make(map[string]bool)
+	for markdownPath := range c.securityLintReports {
+		compiled[GetWorkflowIDFromPath(markdownPath)] = true
+	}
+	reasons := make(map[string]string)
+	baseline := &SecurityLintBaseline{Version: 1, Exceptions: []SecurityLintException{}}
+	if existing != nil {
+		for _, exception := range existing.Exceptions {
+			if compiled[exception.Workflow] {
+				reasons[exception.Workflow+"/"+exception.ID] = exception.Reason
+				continue
+			}
+			baseline.Exceptions = append(baseline.Exceptions, exception)
+		}
+	}
+	for markdownPath, lints := range c.securityLintReports {
+		workflowID := GetWorkflowIDFromPath(markdownPath)
+		seen := make(map[string]bool)
+		for _, lint := range lints {
+			if seen[lint.ID] {
+				continue
+			}
+			seen[lint.ID] = true
+			baseline.Exceptions = append(baseline.Exceptions, SecurityLintException{
+				Workflow: workflowID,
+				ID:       lint.ID,
+				Reason:   reasons[workflowID+"/"+lint.ID],
+			})
+		}
+	}
+	sort.Slice(baseline.Exceptions, func(i, j int) bool {
+		a, b := baseline.Exceptions[i], baseline.Exceptions[j]
+		if a.Workflow != b.Workflow {
+			return a.Workflow < b.Workflow
+		}
+		return a.ID < b.ID
+	})
+
+	content, err := json.MarshalIndent(baseline, "", "  ")
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode %s: %w", SecurityLintBaselineFileName, err)
+	}
+	path := filepath.Join(c.gitRoot, SecurityLintBaselineFileName)
+	if err := os.MkdirAll(filepath.Dir(path), constants.DirPermPublic); err != nil {
+		return 0, fmt.Errorf("failed to create %s: %w", filepath.Dir(SecurityLintBaselineFileName), err)
+	}
+	if err := os.WriteFile(path, append(content, '\n'), constants.FilePermPublic); err != nil {
+		return 0, fmt.Errorf("failed to write %s: %w", SecurityLintBaselineFileName, err)
+	}
+	c.securityLintBaseline = baseline
+	return len(baseline.Exceptions), nil
+}
@@ -0,0 +1,180 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func securityLintIDs(lints []SecurityLint) []string {
+	ids := make([]string, 0, len(lints))
+	for _, lint := range lints {
+		ids = append(ids, lint.ID)
+	}
+	return ids
+}
+
+func TestRunSecurityLints(t *testing.T) {
+	pinned := "uses: actions/checkout@0123456789abcdef0123456789abcdef01234567 # v4\n      - uses: ./actions/setup\n"
+
+	tests := []struct {
+		name     string
+		data     *WorkflowData
+		yaml     string
+		expected []string
+	}{
+		{
+			name: "safe workflow has no findings",
+			data: &WorkflowData{
+				RawFrontmatter: map[string]any{"timeout-minutes": 10},
+				Permissions:    "permissions:\n  contents: read\n",
+			},
+			yaml:     pinned,
+			expected: []string{},
+		},
+		{
+			name:     "missing timeout",
+			data:     &WorkflowData{RawFrontmatter: map[string]any{}},
+			yaml:     pinned,
+			expected: []string{SecurityLintMissingTimeout},
+		},
+		{
+			name: "custom job write permissions with default network",
+			data: &WorkflowData{
+				RawFrontmatter:     map[string]any{"timeout-minutes": 10},
+				NetworkPermissions: &NetworkPermissions{Allowed: []string{"defaults"}},
+				Jobs: map[string]any{
+					"release": map[string]any{"permissions": map[string]any{"contents": "write"}},
+				},
+			},
+			yaml:     pinned,
+			expected: []string{SecurityLintWritePermissionsOpenNetwork},
+		},
+		{
+			name: "write permissions with a network allowlist",
+			data: &WorkflowData{
+				RawFrontmatter:     map[string]any{"timeout-minutes": 10},
+				NetworkPermissions: &NetworkPermissions{Allowed: []string{"defaults", "python"}},
+				Jobs: map[string]any{
+					"release": map[string]any{"permissions": map[string]any{"contents": "write"}},
+				},
+			},
+			yaml:     pinned,
+			expected: []string{},
+		},
+		{
+			name: "bash wildcard with secrets in custom steps",
+			data: &WorkflowData{
+				RawFrontmatter: map[string]any{"timeout-minutes": 10},
+				ParsedTools:    &Tools{Bash: &BashToolConfig{AllowedCommands: []string{":*"}}},
+				CustomSteps:    "steps:\n  - run: deploy\n    env:\n      TOKEN: ${{ secrets.DEPLOY_TOKEN }}\n",
+			},
+			yaml:     pinned,
+			expected: []string{SecurityLintBashWildcardWithSecrets},
+		},
+		{
+			name: "bash allowlist with secrets",
+			data: &WorkflowData{
+				RawFrontmatter: map[string]any{"timeout-minutes": 10},
+				ParsedTools:    &Tools{Bash: &BashToolConfig{AllowedCommands: []string{"ls"}}},
+				CustomSteps:    "steps:\n  - run: deploy\n    env:\n      TOKEN: ${{ secrets.DEPLOY_TOKEN }}\n",
+			},
+			yaml:     pinned,
+			expected: []string{},
+		},
+		{
+			name:     "unpinned actions are reported once",
+			data:     &WorkflowData{RawFrontmatter: map[string]any{"timeout-minutes": 10}},
+			yaml:     pinned + "      - uses: some/action@v4\n      - uses: 'some/action@v4'\n      - uses: docker://alpine:3\n",
+			expected: []string{SecurityLintUnpinnedAction},
+		},
+		{
+			name: "MCP server without digest",
+			data: &WorkflowData{
+				RawFrontmatter: map[string]any{"timeout-minutes": 10},
+				Tools:          map[string]any{"foo": map[string]any{"container": "ghcr.io/foo/bar:v1"}},
+			},
+			yaml:     pinned,
+			expected: []string{SecurityLintMCPServerWithoutDigest},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lints := RunSecurityLints(tt.data, tt.yaml)
+			assert.Equal(t, tt.expected, securityLintIDs(lints), "unexpected security lints")
+		})
+	}
+}
+
+func TestCheckSecurityLints(t *testing.T) {
+	data := &WorkflowData{RawFrontmatter: map[string]any{}}
+	markdownPath := filepath.Join("workflows", "triage.md")
+
+	t.Run("disabled by default", func(t *testing.T) {
+		compiler := NewCompiler()
+		require.NoError(t, compiler.checkSecurityLints(data, markdownPath, ""), "lints should not run without --strict")
+		assert.Empty(t, compiler.GetSecurityLints(markdownPath), "no findings should be recorded")
+	})
+
+	t.Run("findings fail with their lint ID", func(t *testing.T) {
+		compiler := NewCompiler()
+		compiler.gitRoot = testutil.TempDir(t, "security-lints")
+		compiler.SetSecurityLints(true)
+		err := compiler.checkSecurityLints(data, markdownPath, "")
+		require.Error(t, err, "missing timeout should fail")
+		assert.Contains(t, err.Error(), "[missing-timeout]", "error should carry the lint ID")
+	})
+
+	t.Run("baselined findings pass", func(t *testing.T) {
+		gitRoot := testutil.TempDir(t, "security-lints")
+		baselinePath := filepath.Join(gitRoot, SecurityLintBaselineFileName)
+		require.NoError(t, os.MkdirAll(filepath.Dir(baselinePath), 0755), "failed to create baseline dir")
+		require.NoError(t, os.WriteFile(baselinePath, []byte(`{"version":1,"exceptions":[{"workflow":"triage","id":"missing-timeout","reason":"bounded by the runner"}]}`), 0644), "failed to write baseline")
+
+		compiler := NewCompiler()
+		compiler.gitRoot = gitRoot
+		compiler.SetSecurityLints(true)
+		require.NoError(t, compiler.checkSecurityLints(data, markdownPath, ""), "baselined finding should not fail")
+		lints := compiler.GetSecurityLints(markdownPath)
+		require.Len(t, lints, 1, "finding should still be reported")
+		assert.True(t, lints[0].Baselined, "finding should be marked as baselined")
+	})
+}
+
+func TestWriteSecurityLintBaseline(t *testing.T) {
+	gitRoot := testutil.TempDir(t, "security-lints")
+	baselinePath := filepath.Join(gitRoot, SecurityLintBaselineFileName)
+	require.NoError(t, os.MkdirAll(filepath.Dir(baselinePath), 0755), "failed to create baseline dir")
+	require.NoError(t, os.WriteFile(baselinePath, []byte(`{"version":1,"exceptions":[
+		{"workflow":"other","id":"unpinned-action","reason":"vendored"},
+		{"workflow":"triage","id":"missing-timeout","reason":"bounded by the runner"},
+		{"workflow":"triage","id":"unpinned-action"}
+	]}`), 0644), "failed to write baseline")
+
+	compiler := NewCompiler()
+	compiler.gitRoot = gitRoot
+	compiler.SetSecurityLints(true)
+	compiler.SetUpdateLintBaseline(true)
+	require.NoError(t, compiler.checkSecurityLints(&WorkflowData{RawFrontmatter: map[string]any{}}, filepath.Join("workflows", "triage.md"), ""), "findings should not fail while updating the baseline")
+
+	count, err := compiler.WriteSecurityLintBaseline()
+	require.NoError(t, err, "baseline should be written")
+	assert.Equal(t, 2, count, "unexpected number of exceptions")
+
+	content, err := os.ReadFile(baselinePath)
+	require.NoError(t, err, "failed to read baseline")
+	var baseline SecurityLintBaseline
+	require.NoError(t, json.Unmarshal(content, &baseline), "baseline should be valid JSON")
+	assert.Equal(t, []SecurityLintException{
+		{Workflow: "other", ID: SecurityLintUnpinnedAction, Reason: "vendored"},
+		{Workflow: "triage", ID: SecurityLintMissingTimeout, Reason: "bounded by the runner"},
+	}, baseline.Exceptions, "stale exceptions of compiled workflows should be dropped and reasons kept")
+}
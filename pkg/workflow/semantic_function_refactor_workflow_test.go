@@ -69,11 +69,11 @@ func TestSemanticFunctionRefactorWorkflowCostGuardrails(t *testing.T) {
 	for _, snippet := range []string{
 		`17 2 * * *`,
 		`GH_AW_MAX_DAILY_AI_CREDITS: "300"`,
-		`"maxAiCredits":300`,
+		`\"maxAiCredits\":300`,
 		`claude-sonnet-4.6`,
 		`name: Precompute semantic refactor slice`,
-		`/tmp/gh-aw/agent/semantic-function-refactor/targets.txt`,
-		`/tmp/gh-aw/agent/semantic-function-refactor/go-files.txt`,
+		`${GH_AW_TMP_DIR}/agent/semantic-function-refactor/targets.txt`,
+		`${GH_AW_TMP_DIR}/agent/semantic-function-refactor/go-files.txt`,
 	} {
 		if !strings.Contains(lockStr, snippet) {
 			t.Fatalf("expected compiled workflow to contain %q", snippet)
@@ -13,13 +13,13 @@ var shellLog = logger.New("workflow:shell")
 // Arguments containing ${{ }} GitHub Actions expressions are double-quoted;
 // other arguments with special shell characters are single-quoted.
 func shellJoinArgs(args []string) string {
-	shellLog.Printf("Joining %d shell arguments with escaping", len(args))
+	shellLog.Tracef("Joining %d shell arguments with escaping", len(args))
 	var escapedArgs []string
 	for _, arg := range args {
 		escapedArgs = append(escapedArgs, shellEscapeArg(arg))
 	}
 	result := strings.Join(escapedArgs, " ")
-	shellLog.Print("Shell arguments joined successfully")
+	shellLog.Tracef("Shell arguments joined successfully")
 	return result
 }
 
@@ -33,7 +33,7 @@ func shellEscapeArg(arg string) string {
 	// ${{ env.X == 'staging' }} becomes '\''staging'\'' which GA cannot parse).
 	// Double-quoting preserves the expression for GA evaluation.
 	if containsExpression(arg) {
-		shellLog.Print("Argument contains GitHub Actions expression, using double-quote wrapping")
+		shellLog.Tracef("Argument contains GitHub Actions expression, using double-quote wrapping")
 		escaped := strings.ReplaceAll(arg, `"`, `\"`)
 		// Escape bare $ signs (those not part of a ${{ }} expression) so that bash
 		// does not perform variable expansion inside the double-quoted string.
@@ -47,7 +47,7 @@ func shellEscapeArg(arg string) string {
 
 	// Check if the argument contains special shell characters that need escaping
 	if strings.ContainsAny(arg, "()[]{}*?$`\"'\\|&;<> \t\n") {
-		shellLog.Print("Argument contains special characters, applying escaping")
+		shellLog.Tracef("Argument contains special characters, applying escaping")
 		// Handle single quotes in the argument by escaping them
 		// Use '\'' instead of '\"'\"' to avoid creating double-quoted contexts
 		// that would interpret backslash escape sequences
@@ -139,7 +139,7 @@ func hasAnyPrefix(s string, prefixes []string) bool {
 
 // that allows ${VAR_NAME} variables to be expanded at runtime.
 func buildDockerCommandWithExpandableVars(cmd string) string {
-	shellLog.Printf("Building docker command with expandable vars (length: %d)", len(cmd))
+	shellLog.Tracef("Building docker command with expandable vars (length: %d)", len(cmd))
 	// Find all ${VAR_NAME} patterns that need expansion outside of single quotes.
 	// We want: 'docker run ... -v '"${GITHUB_WORKSPACE}"':'"${GITHUB_WORKSPACE}"':rw ...'
 	// This closes the single quote, adds the variable in double quotes, then reopens single quote.
@@ -148,11 +148,11 @@ func buildDockerCommandWithExpandableVars(cmd string) string {
 	expandableVars := findExpandableVars(cmd)
 
 	if len(expandableVars) == 0 {
-		shellLog.Print("No expandable variables found, using normal escaping")
+		shellLog.Tracef("No expandable variables found, using normal escaping")
 		return shellEscapeArg(cmd)
 	}
 
-	shellLog.Printf("Docker command built with expandable variables: %v", expandableVars)
+	shellLog.Tracef("Docker command built with expandable variables: %v", expandableVars)
 
 	// Process the command: wrap in single quotes, break out for each variable
 	var result strings.Builder
@@ -0,0 +1,109 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCommandStickyWindow(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     any
+		want    time.Duration
+		wantErr string
+	}{
+		{name: "true uses default window", raw: true, want: 24 * time.Hour},
+		{name: "false disables", raw: false, want: 0},
+		{name: "duration", raw: "90m", want: 90 * time.Minute},
+		{name: "invalid duration", raw: "two hours", wantErr: "is not a duration"},
+		{name: "too short", raw: "30s", wantErr: "at least 1m"},
+		{name: "wrong type", raw: 3, wantErr: "expected true, false or a duration string"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			window, err := parseCommandStickyWindow(tt.raw)
+			if tt.wantErr != "" {
+				require.Error(t, err, "sticky value should be rejected")
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, window)
+		})
+	}
+}
+
+func TestFormatCommandStickyWindow(t *testing.T) {
+	assert.Equal(t, "24h", formatCommandStickyWindow(24*time.Hour))
+	assert.Equal(t, "1h30m", formatCommandStickyWindow(90*time.Minute))
+	assert.Equal(t, "45m", formatCommandStickyWindow(45*time.Minute))
+}
+
+func TestCompileSlashCommandSticky(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "slash-command-sticky-*")
+	markdownPath := filepath.Join(tmpDir, "my-bot.md")
+	content := `---
+on:
+  slash_command:
+    name: mybot
+    sticky: 2h
+permissions:
+  contents: read
+engine: copilot
+safe-outputs:
+  add-comment:
+---
+
+# Bot
+`
+	require.NoError(t, os.WriteFile(markdownPath, []byte(content), 0o644))
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(markdownPath), "sticky slash command should compile")
+
+	lockContent, err := os.ReadFile(filepath.Join(tmpDir, "my-bot.lock.yml"))
+	require.NoError(t, err)
+	lock := string(lockContent)
+	keyPrefix := "gh-aw-sticky-mybot-${{ github.event.issue.number || github.event.pull_request.number || github.event.discussion.number }}-${{ github.actor }}-"
+	assert.Contains(t, lock, "- name: Restore sticky command context", "activation job should restore the previous context")
+	assert.Contains(t, lock, "            "+keyPrefix+"\n", "restore should fall back to the latest entry of the thread and user")
+	assert.Contains(t, lock, `GH_AW_STICKY_WINDOW_SECONDS: "7200"`, "window should be passed in seconds")
+	assert.Contains(t, lock, `if [ "$GH_AW_STICKY_RESUMED" = "true" ]; then`, "prompt section should depend on the restored context")
+	assert.Contains(t, lock, "<previous-invocation>", "prompt should include the previous invocation")
+	assert.Contains(t, lock, "${{ steps.sticky-context.outputs.previous }}", "previous context should be passed through an env var")
+	assert.Contains(t, lock, "- name: Save sticky command context", "agent job should save the context")
+	assert.Contains(t, lock, "key: "+keyPrefix+"${{ github.run_id }}-${{ github.run_attempt }}", "context should be saved under a run-specific key")
+	assert.Contains(t, lock, "GH_AW_STICKY_REQUEST: ${{ needs.activation.outputs.text }}", "request text should be saved")
+}
+
+func TestCompileSlashCommandWithoutSticky(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "slash-command-no-sticky-*")
+	markdownPath := filepath.Join(tmpDir, "bot.md")
+	content := `---
+on:
+  slash_command:
+    name: mybot
+permissions:
+  contents: read
+engine: copilot
+---
+
+# Bot
+`
+	require.NoError(t, os.WriteFile(markdownPath, []byte(content), 0o644))
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(markdownPath))
+
+	lockContent, err := os.ReadFile(filepath.Join(tmpDir, "bot.lock.yml"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(lockContent), "sticky", "sticky steps should only be emitted when enabled")
+}
@@ -0,0 +1,127 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSlashCommandSubcommands(t *testing.T) {
+	subcommands, err := parseSlashCommandSubcommands(map[string]any{
+		"triage": map[string]any{
+			"description": "Triage the issue",
+			"args": map[string]any{
+				"priority": map[string]any{"choices": []any{"low", "high", "low"}, "required": true},
+				"area":     "Component",
+			},
+		},
+		"summarize": "Summarize the discussion",
+		"retry":     nil,
+	})
+	require.NoError(t, err, "valid subcommands should parse")
+
+	assert.Equal(t, []SlashCommandSubcommand{
+		{Name: "retry"},
+		{Name: "summarize", Description: "Summarize the discussion"},
+		{Name: "triage", Description: "Triage the issue", Args: []SlashCommandArg{
+			{Name: "area", Description: "Component"},
+			{Name: "priority", Required: true, Choices: []string{"low", "high"}},
+		}},
+	}, subcommands, "subcommands and args should be sorted and choices deduplicated")
+}
+
+func TestParseSlashCommandSubcommandsErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     any
+		wantErr string
+	}{
+		{name: "not a map", raw: []any{"triage"}, wantErr: "expected a map"},
+		{name: "empty", raw: map[string]any{}, wantErr: "at least one subcommand"},
+		{name: "invalid name", raw: map[string]any{"Triage": nil}, wantErr: "subcommand name 'Triage'"},
+		{name: "reserved help", raw: map[string]any{"help": nil}, wantErr: "reserved"},
+		{name: "invalid arg name", raw: map[string]any{"triage": map[string]any{"args": map[string]any{"--priority": nil}}}, wantErr: "argument name '--priority'"},
+		{name: "invalid value", raw: map[string]any{"triage": 3}, wantErr: "must be a description string or an object"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseSlashCommandSubcommands(tt.raw)
+			require.Error(t, err, "invalid subcommands should be rejected")
+			assert.Contains(t, err.Error(), tt.wantErr, "error should explain the problem")
+		})
+	}
+}
+
+func TestBuildSlashCommandHelpText(t *testing.T) {
+	help := buildSlashCommandHelpText([]string{"bot*", "mybot"}, []SlashCommandSubcommand{
+		{Name: "summarize", Description: "Summarize the discussion"},
+		{Name: "triage", Args: []SlashCommandArg{
+			{Name: "area", Description: "Component"},
+			{Name: "priority", Required: true, Choices: []string{"low", "high"}},
+		}},
+	})
+
+	assert.Equal(t, `Usage: /mybot <subcommand> [--argument value]...
+
+Subcommands:
+- /mybot summarize: Summarize the discussion
+- /mybot triage [--area <area>] --priority low|high
+  - --area: Component
+- /mybot help: Show this help`, help, "help text should list subcommands with their arguments")
+}
+
+func TestCompileSlashCommandSubcommands(t *testing.T) {
+	dir := t.TempDir()
+	markdownPath := filepath.Join(dir, "bot.md")
+	content := `---
+on:
+  slash_command:
+    name: mybot
+    subcommands:
+      triage:
+        args:
+          priority:
+            choices: [low, high]
+      summarize: Summarize the discussion
+engine: copilot
+---
+
+# Bot
+
+Run ${{ needs.activation.outputs.subcommand }} with ${{ needs.activation.outputs.args }}.
+`
+	require.NoError(t, os.WriteFile(markdownPath, []byte(content), 0o644), "workflow should be written")
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(markdownPath), "workflow should compile")
+
+	lock, err := os.ReadFile(filepath.Join(dir, "bot.lock.yml"))
+	require.NoError(t, err, "lock file should be written")
+	lockContent := string(lock)
+
+	assert.Contains(t, lockContent, "GH_AW_COMMAND_SUBCOMMANDS:", "command position check should receive the declared subcommands")
+	assert.Contains(t, lockContent, "GH_AW_COMMAND_HELP:", "command position check should receive the generated help text")
+	assert.Contains(t, lockContent, "command_args: ${{ steps.check_command_position.outputs.command_args }}", "pre-activation should expose the parsed arguments")
+	assert.Contains(t, lockContent, "args: ${{ needs.pre_activation.outputs.command_args }}", "activation should expose the parsed arguments")
+	assert.Contains(t, lockContent, "subcommand: ${{ needs.pre_activation.outputs.subcommand }}", "activation should expose the subcommand")
+	assert.Contains(t, lockContent, "GH_AW_NEEDS_ACTIVATION_OUTPUTS_ARGS: ${{ needs.pre_activation.outputs.command_args }}", "prompt references should resolve from pre-activation")
+	assert.NotContains(t, lockContent, "${{ needs.activation.outputs.args }}", "activation job cannot reference its own outputs")
+	assert.Contains(t, lockContent, "<slash-command>", "prompt should describe the invocation")
+}
+
+func TestCompileSlashCommandSubcommandsInvalid(t *testing.T) {
+	dir := t.TempDir()
+	markdownPath := filepath.Join(dir, "bot.md")
+	content := "---\non:\n  slash_command:\n    name: mybot\n    subcommands:\n      help: Show help\nengine: copilot\n---\n\n# Bot\n"
+	require.NoError(t, os.WriteFile(markdownPath, []byte(content), 0o644), "workflow should be written")
+
+	err := NewCompiler().CompileWorkflow(markdownPath)
+	require.Error(t, err, "reserved subcommand name should fail compilation")
+	assert.Contains(t, err.Error(), "reserved", "error should mention the reserved name")
+}
@@ -187,11 +187,11 @@ func isPathScannedBySecretRedaction(path string) bool {
 		return true
 	}
 
-	// Paths must be under /tmp/gh-aw/ or ${RUNNER_TEMP}/gh-aw/ to be scanned.
+	// Paths must be under the job scratch directory or ${RUNNER_TEMP}/gh-aw/ to be scanned.
 	// Accept both literal paths and environment variable references.
 	// Engines that produce output outside /tmp/gh-aw/ must move their files into /tmp/gh-aw/
 	// via GetPreBundleSteps before the unified artifact upload (see gemini_engine.go).
-	if !strings.HasPrefix(path, constants.TmpGhAwDirSlash) && !strings.HasPrefix(path, constants.GhAwRootDirShellSlash) && !strings.HasPrefix(path, constants.GhAwRootDirSlash) {
+	if !strings.HasPrefix(path, constants.TmpGhAwDirExprSlash) && !strings.HasPrefix(path, constants.GhAwRootDirShellSlash) && !strings.HasPrefix(path, constants.GhAwRootDirSlash) {
 		// Check if it's an environment variable that might resolve to /tmp/gh-aw/ or ${RUNNER_TEMP}/gh-aw/
 		// For now, we'll allow ${{ env.* }} patterns through as we can't resolve them at compile time
 		// Assume environment variables that might contain /tmp/gh-aw or ${RUNNER_TEMP}/gh-aw paths are safe
@@ -207,9 +207,11 @@ func isPathScannedBySecretRedaction(path string) bool {
 	// .patch files are git-diff output written to /tmp/gh-aw/ by the safe-outputs MCP server
 	// and are covered by the redact_secrets step before the unified artifact is uploaded.
 	// .bundle files are git bundle files written to /tmp/gh-aw/ when patch-format: bundle is
-	// configured. They are binary files but must still pass through secret redaction.
+	// configured. They are binary, so the redaction skips them rather than rewrite them, and
+	// create_pull_request applies them as they were produced.
 	ext := filepath.Ext(path)
-	scannedExtensions := []string{".txt", ".json", ".log", ".jsonl", ".patch", ".bundle"}
+	// The other extensions mirror TARGET_EXTENSIONS in redact_secrets.cjs.
+	scannedExtensions := []string{".txt", ".json", ".log", ".jsonl", ".patch", ".bundle", ".md", ".mdx", ".yml", ".yaml", ".ndjson", ".diff", ".csv", ".xml", ".html", ".sarif"}
 	if slices.Contains(scannedExtensions, ext) {
 		return true
 	}
@@ -24,8 +24,8 @@ func TestStepOrderTracker_ValidateOrdering_SecretRedactionBeforeUploads(t *testi
 	tracker.RecordSecretRedaction("Redact secrets in logs")
 
 	// Add artifact uploads after
-	tracker.RecordArtifactUpload("Upload agent logs", []string{"/tmp/gh-aw/agent-stdio.log"})
-	tracker.RecordArtifactUpload("Upload MCP logs", []string{"/tmp/gh-aw/mcp-logs/"})
+	tracker.RecordArtifactUpload("Upload agent logs", []string{"${{ env.GH_AW_TMP_DIR }}/agent-stdio.log"})
+	tracker.RecordArtifactUpload("Upload MCP logs", []string{"${{ env.GH_AW_TMP_DIR }}/mcp-logs/"})
 
 	err := tracker.ValidateStepOrdering()
 	if err != nil {
@@ -94,18 +94,18 @@ func TestIsPathScannedBySecretRedaction_ScannableFiles(t *testing.T) {
 		expected bool
 	}{
 		{
-			name:     "JSON file in /tmp/gh-aw/",
-			path:     "/tmp/gh-aw/aw_info.json",
+			name:     "JSON file in ${{ env.GH_AW_TMP_DIR }}/",
+			path:     "${{ env.GH_AW_TMP_DIR }}/aw_info.json",
 			expected: true,
 		},
 		{
-			name:     "TXT file in /tmp/gh-aw/",
-			path:     "/tmp/gh-aw/aw-prompts/prompt.txt",
+			name:     "TXT file in ${{ env.GH_AW_TMP_DIR }}/",
+			path:     "${{ env.GH_AW_TMP_DIR }}/aw-prompts/prompt.txt",
 			expected: true,
 		},
 		{
-			name:     "LOG file in /tmp/gh-aw/",
-			path:     "/tmp/gh-aw/agent-stdio.log",
+			name:     "LOG file in ${{ env.GH_AW_TMP_DIR }}/",
+			path:     "${{ env.GH_AW_TMP_DIR }}/agent-stdio.log",
 			expected: true,
 		},
 		{
@@ -114,13 +114,13 @@ func TestIsPathScannedBySecretRedaction_ScannableFiles(t *testing.T) {
 			expected: true,
 		},
 		{
-			name:     "Directory in /tmp/gh-aw/",
-			path:     "/tmp/gh-aw/mcp-logs/",
+			name:     "Directory in ${{ env.GH_AW_TMP_DIR }}/",
+			path:     "${{ env.GH_AW_TMP_DIR }}/mcp-logs/",
 			expected: true,
 		},
 		{
-			name:     "Subdirectory in /tmp/gh-aw/",
-			path:     "/tmp/gh-aw/access-logs/",
+			name:     "Subdirectory in ${{ env.GH_AW_TMP_DIR }}/",
+			path:     "${{ env.GH_AW_TMP_DIR }}/access-logs/",
 			expected: true,
 		},
 		{
@@ -130,11 +130,11 @@ func TestIsPathScannedBySecretRedaction_ScannableFiles(t *testing.T) {
 		},
 		{
 			name:     "Exclusion pattern (proxy-tls directory)",
-			path:     "!/tmp/gh-aw/proxy-logs/proxy-tls/",
+			path:     "!${{ env.GH_AW_TMP_DIR }}/proxy-logs/proxy-tls/",
 			expected: true,
 		},
 		{
-			name:     "Exclusion pattern (file outside /tmp/gh-aw/)",
+			name:     "Exclusion pattern (file outside ${{ env.GH_AW_TMP_DIR }}/)",
 			path:     "!/some/other/path/file.key",
 			expected: true,
 		},
@@ -208,7 +208,7 @@ func TestStepOrderTracker_ValidateOrdering_UnscannablePath(t *testing.T) {
 	tracker.RecordSecretRedaction("Redact secrets in logs")
 
 	// Add upload with unscannable path (BUG!)
-	tracker.RecordArtifactUpload("Upload workspace file", []string{"/tmp/gh-aw/output.xml"})
+	tracker.RecordArtifactUpload("Upload workspace file", []string{"/tmp/gh-aw/output.png"})
 
 	err := tracker.ValidateStepOrdering()
 	if err == nil {
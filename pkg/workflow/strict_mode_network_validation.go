@@ -80,8 +80,8 @@ func (c *Compiler) validateStrictMCPNetwork(frontmatter map[string]any, networkP
 		// Only stdio servers with containers need network configuration
 		if mcpType == "stdio" {
 			if _, hasContainer := serverConfig["container"]; hasContainer {
-				// Require top-level network configuration
-				if !hasTopLevelNetwork {
+				// Require top-level network configuration unless the server has its own egress allowlist
+				if !hasTopLevelNetwork && len(getMCPServerEgressAllowed(serverConfig)) == 0 {
 					return NewValidationError(
 						"network.allowed",
 						serverName,
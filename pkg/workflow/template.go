@@ -5,6 +5,7 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/github/gh-aw/pkg/constants"
 	"github.com/github/gh-aw/pkg/logger"
 	"github.com/github/gh-aw/pkg/setutil"
 )
@@ -12,6 +13,11 @@ import (
 var templateLog = logger.New("workflow:template")
 var inlineSubAgentPattern = regexp.MustCompile("(?m)^##[ \t]+agent:[ \t]+`[a-z][a-z0-9_-]*`[ \t]*$")
 
+// templateDoubleQuotedLiteralPattern matches double-quoted string literals in template
+// conditions (e.g. github.event_name == "pull_request"). GitHub Actions expressions only
+// support single-quoted literals, so they are rewritten before the condition is wrapped.
+var templateDoubleQuotedLiteralPattern = regexp.MustCompile(`"([^"']*)"`)
+
 // wrapExpressionsInTemplateConditionals transforms template conditionals by wrapping
 // expressions in ${{ }}. For example:
 // {{#if github.event.issue.number}} becomes {{#if ${{ github.event.issue.number }} }}
@@ -56,6 +62,7 @@ func wrapExpressionsInTemplateConditionals(markdown string) string {
 			return match
 		}
 
+		expr = templateDoubleQuotedLiteralPattern.ReplaceAllString(expr, "'$1'")
 		templateLog.Printf("Wrapping expression: %s", expr)
 		return prefix + "${{ " + expr + " }} }}"
 	}
@@ -93,6 +100,7 @@ func wrapExpressionsInTemplateConditionals(markdown string) string {
 //   - Uses actions/github-script action
 //   - Sets GH_AW_PROMPT environment variable to the prompt file path
 //   - Sets GH_AW_EXPR_* environment variables with the actual GitHub expressions (${{ ... }})
+//   - Sets GH_AW_PROMPT_BUDGET to the prompt-budget: configuration as JSON, when present
 //   - Runs interpolate_prompt.cjs script to replace placeholders and render template conditionals
 func (c *Compiler) generateInterpolationAndTemplateStep(yaml *strings.Builder, expressionMappings []*ExpressionMapping, data *WorkflowData) {
 	// Check if we need interpolation
@@ -104,22 +112,28 @@ func (c *Compiler) generateInterpolationAndTemplateStep(yaml *strings.Builder, e
 	hasInlineSubAgents := inlineSubAgentPattern.MatchString(data.MarkdownContent)
 	hasTemplates := hasTemplatePattern || hasGitHubContext || hasInlineSubAgents
 
-	// Skip if neither interpolation nor template rendering is needed
-	if !hasExpressions && !hasTemplates {
+	// The prompt budget is enforced by the same script
+	hasPromptBudget := data.PromptBudget != nil
+
+	// Skip if neither interpolation, template rendering nor budgeting is needed
+	if !hasExpressions && !hasTemplates && !hasPromptBudget {
 		templateLog.Print("No interpolation or template rendering needed, skipping step generation")
 		return
 	}
 
-	templateLog.Printf("Generating interpolation and template step: expressions=%d, hasPattern=%v, hasGitHubContext=%v, hasInlineSubAgents=%v",
-		len(expressionMappings), hasTemplatePattern, hasGitHubContext, hasInlineSubAgents)
+	templateLog.Printf("Generating interpolation and template step: expressions=%d, hasPattern=%v, hasGitHubContext=%v, hasInlineSubAgents=%v, hasPromptBudget=%v",
+		len(expressionMappings), hasTemplatePattern, hasGitHubContext, hasInlineSubAgents, hasPromptBudget)
 
 	yaml.WriteString("      - name: Interpolate variables and render templates\n")
 	fmt.Fprintf(yaml, "        uses: %s\n", getCachedActionPin("actions/github-script", data))
 	yaml.WriteString("        env:\n")
-	yaml.WriteString("          GH_AW_PROMPT: /tmp/gh-aw/aw-prompts/prompt.txt\n")
+	yaml.WriteString("          GH_AW_PROMPT: " + constants.TmpGhAwDirExpr + "/aw-prompts/prompt.txt\n")
 	if data.EngineConfig != nil && data.EngineConfig.ID != "" {
 		fmt.Fprintf(yaml, "          GH_AW_ENGINE_ID: \"%s\"\n", data.EngineConfig.ID)
 	}
+	if hasPromptBudget {
+		fmt.Fprintf(yaml, "          GH_AW_PROMPT_BUDGET: '%s'\n", promptBudgetEnvValue(data.PromptBudget))
+	}
 
 	// Add environment variables for extracted expressions (deduplicated by EnvVar)
 	seen := make(map[string]struct{})
@@ -283,3 +283,65 @@ Steps expression - will be wrapped.
 		t.Error("Should not double-wrap expressions")
 	}
 }
+
+// TestTemplateTriggerConditionals verifies that comparison conditions let one workflow
+// serve several triggers, and that conditions referencing secrets are rejected
+func TestTemplateTriggerConditionals(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "template-trigger-conditionals")
+
+	testContent := `---
+on:
+  issues:
+    types: [opened]
+  pull_request:
+    types: [opened]
+permissions:
+  contents: read
+engine: copilot
+---
+
+# Triage
+
+{{#if github.event_name == "issues"}}
+Label the issue.
+{{#elseif github.event_name == 'pull_request' && !github.event.pull_request.draft}}
+Review the pull request.
+{{#else}}
+Summarize the event.
+{{/if}}
+`
+
+	testFile := filepath.Join(tmpDir, "triage.md")
+	if err := os.WriteFile(testFile, []byte(testContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	compiler := NewCompiler()
+	compiler.inlinePrompt = true
+	if err := compiler.CompileWorkflow(testFile); err != nil {
+		t.Fatalf("Failed to compile workflow: %v", err)
+	}
+
+	compiledYAML, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	if err != nil {
+		t.Fatalf("Failed to read compiled workflow: %v", err)
+	}
+	compiledStr := string(compiledYAML)
+
+	// Inline conditions are evaluated by GitHub Actions, which only accepts single-quoted literals
+	if !strings.Contains(compiledStr, "${{ github.event_name == 'issues' }}") {
+		t.Error("if condition should be evaluated by GitHub Actions with single-quoted literals")
+	}
+	if !strings.Contains(compiledStr, "${{ github.event_name == 'pull_request' && !github.event.pull_request.draft }}") {
+		t.Error("elseif condition should be evaluated by GitHub Actions")
+	}
+
+	secretContent := strings.Replace(testContent, `github.event_name == "issues"`, `secrets.TOKEN == 'x'`, 1)
+	if err := os.WriteFile(testFile, []byte(secretContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+	err = NewCompiler().CompileWorkflow(testFile)
+	if err == nil || !strings.Contains(err.Error(), "secrets cannot be used in template conditions") {
+		t.Errorf("Expected secret reference in template condition to be rejected, got: %v", err)
+	}
+}
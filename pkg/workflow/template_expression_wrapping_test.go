@@ -221,6 +221,21 @@ Regular content`,
 			input:    "{{#if github.actor}}A{{/if}} {{#if github.repository }}B{{/if}} {{#if ${{ github.ref }} }}C{{/if}}",
 			expected: "{{#if ${{ github.actor }} }}A{{/if}} {{#if ${{ github.repository }} }}B{{/if}} {{#if ${{ github.ref }} }}C{{/if}}",
 		},
+		{
+			name:     "comparison with single-quoted literal",
+			input:    "{{#if github.event_name == 'pull_request'}}content{{/if}}",
+			expected: "{{#if ${{ github.event_name == 'pull_request' }} }}content{{/if}}",
+		},
+		{
+			name:     "comparison with double-quoted literals (normalized to single quotes)",
+			input:    `{{#if github.event_name == "issues" || github.event_name == "pull_request"}}content{{/if}}`,
+			expected: "{{#if ${{ github.event_name == 'issues' || github.event_name == 'pull_request' }} }}content{{/if}}",
+		},
+		{
+			name:     "elseif comparison with double-quoted literal",
+			input:    `{{#if false}}A{{#elseif github.event_name == "issues"}}B{{/if}}`,
+			expected: "{{#if ${{ false }} }}A{{#elseif ${{ github.event_name == 'issues' }} }}B{{/if}}",
+		},
 		{
 			name:     "complex expression with spaces",
 			input:    "{{#if needs.setup.outputs.value }}content{{/if}}",
@@ -511,6 +511,68 @@ func TestValidateNoPreExpandedExperimentPlaceholders_ElseIf(t *testing.T) {
 	}
 }
 
+func TestValidateNoSecretsInTemplateConditions(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name:    "valid - trigger comparison",
+			input:   `{{#if github.event_name == "pull_request"}}content{{/if}}`,
+			wantErr: false,
+		},
+		{
+			name:    "valid - compound condition in elseif",
+			input:   `{{#if false}}a{{#elseif github.event.action == 'opened' && !github.event.pull_request.draft}}content{{/if}}`,
+			wantErr: false,
+		},
+		{
+			name:    "valid - secrets in body text only",
+			input:   "{{#if github.actor}}Never print secrets.TOKEN{{/if}}",
+			wantErr: false,
+		},
+		{
+			name:    "invalid - secret comparison in if condition",
+			input:   `{{#if secrets.TOKEN == 'x'}}content{{/if}}`,
+			wantErr: true,
+			errMsg:  `references "secrets"`,
+		},
+		{
+			name:    "invalid - secret in else-if (hyphen) condition",
+			input:   `{{#if false}}a{{#else-if github.actor && secrets['TOKEN']}}content{{/if}}`,
+			wantErr: true,
+			errMsg:  `references "secrets"`,
+		},
+		{
+			name:    "invalid - github.token in condition",
+			input:   `{{#if github.token}}content{{/if}}`,
+			wantErr: true,
+			errMsg:  `references "github.token"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateNoSecretsInTemplateConditions(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("validateNoSecretsInTemplateConditions() expected error, got nil")
+					return
+				}
+				if tt.errMsg != "" && !strings.Contains(err.Error(), tt.errMsg) {
+					t.Errorf("validateNoSecretsInTemplateConditions() error = %q, want to contain %q", err.Error(), tt.errMsg)
+				}
+			} else {
+				if err != nil {
+					t.Errorf("validateNoSecretsInTemplateConditions() unexpected error = %v", err)
+				}
+			}
+		})
+	}
+}
+
 // TestValidateNoIncludesInTemplateRegions_SingleError tests single error behavior
 func TestValidateNoIncludesInTemplateRegions_SingleError(t *testing.T) {
 	// Input with single include inside template region
@@ -9,6 +9,7 @@
 //
 //   - validateNoIncludesInTemplateRegions() - Validates that imports are not inside template blocks
 //   - validateNoPreExpandedExperimentPlaceholders() - Validates that pre-expanded __GH_AW_EXPERIMENTS_*__ placeholders are not used in template conditions
+//   - validateNoSecretsInTemplateConditions() - Validates that template conditions do not reference secrets
 //
 // # Validation Pattern: Structure Validation
 //
@@ -53,6 +54,11 @@ var (
 	// Authors should use the experiments.<name> form (e.g. experiments.prompt_style == "detailed").
 	preExpandedExperimentPattern = regexp.MustCompile(`__GH_AW_EXPERIMENTS_[A-Z0-9_]+__`)
 
+	// templateConditionSecretPattern matches secret references in template conditions.
+	// In inline prompt mode conditions are evaluated by GitHub Actions through GH_AW_EXPR_*
+	// environment variables, so a secret in a condition would be exposed to the prompt step.
+	templateConditionSecretPattern = regexp.MustCompile(`(?:^|[^A-Za-z0-9_.])(secrets\s*[.\[]|github\.token\b)`)
+
 	// experimentDoubleQuotePattern matches experiments.<name> comparison expressions that use
 	// double-quoted string literals (e.g. experiments.mode == "value").  GitHub Actions
 	// expression syntax only supports single-quoted string literals, so double quotes must be
@@ -167,6 +173,44 @@ func validateNoPreExpandedExperimentPlaceholders(markdown string) error {
 	return nil
 }
 
+// validateNoSecretsInTemplateConditions checks that template conditions do not reference
+// secrets (secrets.* or github.token). Conditions select which parts of the prompt are
+// rendered, so they must only depend on the trigger context, inputs and step outputs
+// (e.g. github.event_name == 'pull_request').
+func validateNoSecretsInTemplateConditions(markdown string) error {
+	templateValidationLog.Print("Validating that template conditions do not reference secrets")
+
+	// Fast path: skip expensive regex if the markdown contains no template conditions.
+	if !strings.Contains(markdown, "{{") {
+		return nil
+	}
+
+	ifConditions := TemplateIfPattern.FindAllStringSubmatch(markdown, -1)
+	elseifConditions := TemplateElseIfPattern.FindAllStringSubmatch(markdown, -1)
+	allConditions := append(ifConditions, elseifConditions...)
+
+	var errs []error
+	for _, m := range allConditions {
+		if len(m) < 2 {
+			continue
+		}
+		condition := strings.TrimSpace(m[1])
+		if match := templateConditionSecretPattern.FindStringSubmatch(condition); match != nil {
+			errs = append(errs, fmt.Errorf(
+				"template condition %q references %q: secrets cannot be used in template conditions; use the trigger context, inputs or step outputs instead (e.g. github.event_name == 'pull_request')",
+				condition, strings.TrimRight(match[1], ".[ "),
+			))
+		}
+	}
+
+	if len(errs) > 0 {
+		templateValidationLog.Printf("Found %d template condition(s) referencing secrets", len(errs))
+		return errors.Join(errs...)
+	}
+
+	return nil
+}
+
 // detectDoubleQuotedExperimentComparisons scans template conditions for experiment comparison
 // expressions that use double-quoted string literals (e.g. experiments.mode == "value").
 // GitHub Actions expression syntax only supports single-quoted string literals, so double
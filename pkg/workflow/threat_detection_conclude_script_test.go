@@ -21,6 +21,7 @@ func TestConcludeThreatDetectionScript_MissingResultContinueOnError(t *testing.T
 		"DETECTION_AGENTIC_EXECUTION_OUTCOME=failure",
 		"GH_AW_DETECTION_CONTINUE_ON_ERROR=TRUE",
 		"GITHUB_OUTPUT="+outputFile,
+		"GH_AW_TMP_DIR="+t.TempDir(),
 	)
 
 	out, err := cmd.CombinedOutput()
@@ -65,6 +66,7 @@ func TestConcludeThreatDetectionScript_MissingResultSurfacesDetectorStatus(t *te
 		"GH_AW_DETECTION_CONTINUE_ON_ERROR=true",
 		"DETECTION_LOG_FILE="+detectionLog,
 		"GITHUB_OUTPUT="+outputFile,
+		"GH_AW_TMP_DIR="+t.TempDir(),
 	)
 
 	out, err := cmd.CombinedOutput()
@@ -99,6 +101,7 @@ func TestConcludeThreatDetectionScript_SkippedWhenRunDetectionFalse(t *testing.T
 	cmd.Env = append(os.Environ(),
 		"RUN_DETECTION=false",
 		"GITHUB_OUTPUT="+outputFile,
+		"GH_AW_TMP_DIR="+t.TempDir(),
 		"PATH="+emptyBinDir+":"+os.Getenv("PATH"),
 	)
 
@@ -144,6 +147,7 @@ func TestConcludeThreatDetectionScript_SkippedWhenRunDetectionUnset(t *testing.T
 	}
 	filteredEnv = append(filteredEnv,
 		"GITHUB_OUTPUT="+outputFile,
+		"GH_AW_TMP_DIR="+t.TempDir(),
 		"PATH="+emptyBinDir+":"+os.Getenv("PATH"),
 	)
 
@@ -199,6 +203,7 @@ func TestConcludeThreatDetectionScript_InvokesThreatDetectConclude(t *testing.T)
 	cmd.Env = append(os.Environ(),
 		"RUN_DETECTION=true",
 		"GITHUB_OUTPUT="+outputFile,
+		"GH_AW_TMP_DIR="+t.TempDir(),
 		"GITHUB_ENV="+envFile,
 		"CALL_LOG="+callLog,
 		"PATH="+binDir+":"+os.Getenv("PATH"),
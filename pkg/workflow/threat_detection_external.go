@@ -384,7 +384,7 @@ func extractStepEnvLines(step GitHubActionStep) []string {
 // path uses buildUploadDetectionLogStep which only uploads detection.log.
 func (c *Compiler) buildUploadDetectionArtifactStep(data *WorkflowData) []string {
 	detectionArtifactName := artifactPrefixExprForAgentDownstreamJob(data) + constants.DetectionArtifactName
-	return []string{
+	steps := []string{
 		"      - name: Upload threat detection artifact\n",
 		fmt.Sprintf("        if: %s\n", detectionStepCondition),
 		fmt.Sprintf("        uses: %s\n", c.getActionPin("actions/upload-artifact")),
@@ -392,9 +392,15 @@ func (c *Compiler) buildUploadDetectionArtifactStep(data *WorkflowData) []string
 		"          name: " + detectionArtifactName + "\n",
 		"          path: |\n",
 		"            " + constants.ThreatDetectionResultPath + "\n",
-		"            " + constants.ThreatDetectionLogPath + "\n",
-		"          if-no-files-found: ignore\n",
 	}
+	for _, logFile := range engineLogFiles(constants.ThreatDetectionLogPath) {
+		steps = append(steps, "            "+logFile+"\n")
+	}
+	steps = append(steps, "          if-no-files-found: ignore\n")
+	if line := artifactRetentionDaysLine(data, "          "); line != "" {
+		steps = append(steps, line+"\n")
+	}
+	return steps
 }
 
 // buildExternalDetectorConcludeStep creates the conclude step for the external
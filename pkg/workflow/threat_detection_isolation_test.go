@@ -190,8 +190,8 @@ Test workflow`
 	}
 
 	// The rw mount for the threat-detection directory must be present
-	if !strings.Contains(detectionSection, "/tmp/gh-aw/threat-detection:/tmp/gh-aw/threat-detection:rw") {
-		t.Error("External detector path must include read-write mount for /tmp/gh-aw/threat-detection")
+	if !strings.Contains(detectionSection, "${{ env.GH_AW_TMP_DIR }}/threat-detection:${{ env.GH_AW_TMP_DIR }}/threat-detection:rw") {
+		t.Error("External detector path must include read-write mount for ${{ env.GH_AW_TMP_DIR }}/threat-detection")
 	}
 
 	// The detector invocation must pass the artifacts directory positionally and write a structured result file.
@@ -205,12 +205,12 @@ Test workflow`
 			invocationLineEnd = len(detectionSection) - invocationIndex
 		}
 		invocationLine := detectionSection[invocationIndex : invocationIndex+invocationLineEnd]
-		if !strings.Contains(invocationLine, " /tmp/gh-aw/threat-detection") {
-			t.Error("External detector path must pass /tmp/gh-aw/threat-detection as the positional artifacts directory")
+		if !strings.Contains(invocationLine, ` "${{ env.GH_AW_TMP_DIR }}/threat-detection"`) {
+			t.Error("External detector path must pass ${{ env.GH_AW_TMP_DIR }}/threat-detection as the positional artifacts directory")
 		}
 	}
-	if !strings.Contains(detectionSection, "--output /tmp/gh-aw/threat-detection/detection_result.json") {
-		t.Error("External detector path must pass --output /tmp/gh-aw/threat-detection/detection_result.json to threat-detect")
+	if !strings.Contains(detectionSection, `--output "${{ env.GH_AW_TMP_DIR }}/threat-detection/detection_result.json"`) {
+		t.Error("External detector path must pass --output ${{ env.GH_AW_TMP_DIR }}/threat-detection/detection_result.json to threat-detect")
 	}
 
 	// The AWF execution pipeline must preserve non-zero threat-detect exits.
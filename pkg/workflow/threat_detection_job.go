@@ -198,6 +198,7 @@ func (c *Compiler) buildDetectionJob(data *WorkflowData) (*Job, error) {
 		RunsOn:      c.indentYAMLLines(runsOn, "    "),
 		Environment: c.indentYAMLLines(environment, "    "),
 		Permissions: permissions,
+		Env:         withNetworkProxyEnv(nil, data),
 		Steps:       steps,
 		Outputs:     outputs,
 	}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"maps"
 	"strconv"
+	"strings"
 
 	"github.com/github/gh-aw/pkg/constants"
 )
@@ -82,7 +83,9 @@ func (c *Compiler) buildDetectionJobSteps(data *WorkflowData) []string {
 			steps = append(steps, c.buildCustomThreatDetectionSteps(data.SafeOutputs.ThreatDetection.PostSteps)...)
 		}
 
-		// Step 13: Upload detection_result.json + detection.log as the detection artifact
+		// Step 13: Redact secrets in the detection files, then upload detection_result.json
+		// + detection.log as the detection artifact
+		steps = append(steps, c.buildDetectionSecretRedactionStep(steps, data)...)
 		steps = append(steps, c.buildUploadDetectionArtifactStep(data)...)
 
 		// Step 14: Parse threat-detection token usage for step summary and downstream footer rendering.
@@ -104,7 +107,8 @@ func (c *Compiler) buildDetectionJobSteps(data *WorkflowData) []string {
 		// Step 9: Parse threat-detection token usage for step summary and downstream footer rendering.
 		steps = append(steps, c.buildDetectionTokenUsageSummaryStep(data)...)
 
-		// Step 10: Upload detection-artifact
+		// Step 10: Redact secrets in the detection files, then upload detection-artifact
+		steps = append(steps, c.buildDetectionSecretRedactionStep(steps, data)...)
 		steps = append(steps, c.buildUploadDetectionLogStep(data)...)
 
 		// Step 11: Parse results, log extensively, and set job conclusion (single JS step)
@@ -176,21 +180,21 @@ func (c *Compiler) buildPrepareDetectionFilesStep() []string {
 		"      - name: Prepare threat detection files\n",
 		fmt.Sprintf("        if: %s\n", detectionStepCondition),
 		"        run: |\n",
-		"          mkdir -p /tmp/gh-aw/threat-detection/aw-prompts\n",
-		"          rm -f /tmp/gh-aw/agent_usage.json\n",
-		"          cp /tmp/gh-aw/aw-prompts/prompt.txt /tmp/gh-aw/threat-detection/aw-prompts/prompt.txt 2>/dev/null || true\n",
-		"          if [ ! -s /tmp/gh-aw/threat-detection/aw-prompts/prompt.txt ]; then\n",
-		"            echo \"::warning::ERR_VALIDATION: Missing or empty detection context prompt at /tmp/gh-aw/threat-detection/aw-prompts/prompt.txt. Ensure the agent artifact includes /tmp/gh-aw/aw-prompts/prompt.txt. Detection will continue with fallback workflow context.\"\n",
+		"          mkdir -p " + constants.TmpGhAwDirExpr + "/threat-detection/aw-prompts\n",
+		"          rm -f " + constants.TmpGhAwDirExpr + "/agent_usage.json\n",
+		"          cp " + constants.TmpGhAwDirExpr + "/aw-prompts/prompt.txt " + constants.TmpGhAwDirExpr + "/threat-detection/aw-prompts/prompt.txt 2>/dev/null || true\n",
+		"          if [ ! -s " + constants.TmpGhAwDirExpr + "/threat-detection/aw-prompts/prompt.txt ]; then\n",
+		"            echo \"::warning::ERR_VALIDATION: Missing or empty detection context prompt at " + constants.TmpGhAwDirExpr + "/threat-detection/aw-prompts/prompt.txt. Ensure the agent artifact includes " + constants.TmpGhAwDirExpr + "/aw-prompts/prompt.txt. Detection will continue with fallback workflow context.\"\n",
 		"          fi\n",
-		"          cp /tmp/gh-aw/agent_output.json /tmp/gh-aw/threat-detection/agent_output.json 2>/dev/null || true\n",
-		"          for f in /tmp/gh-aw/aw-*.patch; do\n",
-		"            [ -f \"$f\" ] && cp \"$f\" /tmp/gh-aw/threat-detection/ 2>/dev/null || true\n",
+		"          cp " + constants.TmpGhAwDirExpr + "/agent_output.json " + constants.TmpGhAwDirExpr + "/threat-detection/agent_output.json 2>/dev/null || true\n",
+		"          for f in " + constants.TmpGhAwDirExpr + "/aw-*.patch; do\n",
+		"            [ -f \"$f\" ] && cp \"$f\" " + constants.TmpGhAwDirExpr + "/threat-detection/ 2>/dev/null || true\n",
 		"          done\n",
-		"          for f in /tmp/gh-aw/aw-*.bundle; do\n",
-		"            [ -f \"$f\" ] && cp \"$f\" /tmp/gh-aw/threat-detection/ 2>/dev/null || true\n",
+		"          for f in " + constants.TmpGhAwDirExpr + "/aw-*.bundle; do\n",
+		"            [ -f \"$f\" ] && cp \"$f\" " + constants.TmpGhAwDirExpr + "/threat-detection/ 2>/dev/null || true\n",
 		"          done\n",
 		"          echo \"Prepared threat detection files:\"\n",
-		"          ls -la /tmp/gh-aw/threat-detection/ 2>/dev/null || true\n",
+		"          ls -la " + constants.TmpGhAwDirExpr + "/threat-detection/ 2>/dev/null || true\n",
 	}
 }
 
@@ -322,8 +326,8 @@ func (c *Compiler) buildThreatDetectionAnalysisStep(data *WorkflowData) []string
 		"      - name: Ensure threat-detection directory and log\n",
 		fmt.Sprintf("        if: %s\n", detectionStepCondition),
 		"        run: |\n",
-		"          mkdir -p /tmp/gh-aw/threat-detection\n",
-		"          touch /tmp/gh-aw/threat-detection/detection.log\n",
+		"          mkdir -p " + constants.TmpGhAwDirExpr + "/threat-detection\n",
+		"          touch " + constants.TmpGhAwDirExpr + "/threat-detection/detection.log\n",
 	}...)
 
 	return steps
@@ -415,21 +419,47 @@ func (c *Compiler) buildCustomThreatDetectionSteps(steps []any) []string {
 	return result
 }
 
+// buildDetectionSecretRedactionStep creates the step that redacts the threat detection
+// working directory before its artifact is uploaded. The detection engine log can echo
+// the agent output, which can hold any secret of the agent job, and the secrets
+// referenced by the detection steps built so far, so the step redacts both.
+func (c *Compiler) buildDetectionSecretRedactionStep(steps []string, data *WorkflowData) []string {
+	var content strings.Builder
+	for _, step := range steps {
+		content.WriteString(step)
+	}
+	if agentJob, exists := c.jobManager.GetJob(string(constants.AgentJobName)); exists {
+		for _, step := range agentJob.Steps {
+			content.WriteString(step)
+		}
+		for _, value := range agentJob.Env {
+			content.WriteString(value + "\n")
+		}
+	}
+	secretReferences := CollectSecretReferences(content.String())
+	return buildArtifactSecretRedactionStep("Redact secrets in threat detection files", detectionStepCondition,
+		[]string{constants.ThreatDetectionDir + "/"}, secretReferences, data)
+}
+
 // buildUploadDetectionLogStep creates the step to upload the detection-artifact.
 // In workflow_call context, the artifact name is prefixed to avoid name clashes when the
 // same reusable workflow is called multiple times within a single workflow run.
 // The prefix comes from the agent job output since the detection job depends on the agent job.
 func (c *Compiler) buildUploadDetectionLogStep(data *WorkflowData) []string {
 	detectionArtifactName := artifactPrefixExprForAgentDownstreamJob(data) + constants.DetectionArtifactName
-	return []string{
+	steps := []string{
 		"      - name: Upload threat detection log\n",
 		fmt.Sprintf("        if: %s\n", detectionStepCondition),
 		fmt.Sprintf("        uses: %s\n", c.getActionPin("actions/upload-artifact")),
 		"        with:\n",
 		"          name: " + detectionArtifactName + "\n",
-		"          path: /tmp/gh-aw/threat-detection/detection.log\n",
+		"          path: " + constants.TmpGhAwDirExpr + "/threat-detection/detection.log\n",
 		"          if-no-files-found: ignore\n",
 	}
+	if line := artifactRetentionDaysLine(data, "          "); line != "" {
+		steps = append(steps, line+"\n")
+	}
+	return steps
 }
 
 // buildInstallThreatDetectStep creates a step that installs the threat-detect binary
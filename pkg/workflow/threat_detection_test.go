@@ -799,7 +799,7 @@ func TestBuildDetectionEngineExecutionStepMaxAICredits(t *testing.T) {
 		if strings.Contains(allSteps, "vars."+compilerenv.DefaultDetectionMaxAICredits) {
 			t.Fatalf("expected detection steps not to reference vars.%s when explicit max-ai-credits is set, got:\n%s", compilerenv.DefaultDetectionMaxAICredits, allSteps)
 		}
-		if !strings.Contains(allSteps, `"maxAiCredits":777`) {
+		if !strings.Contains(allSteps, `\"maxAiCredits\":777`) {
 			t.Fatalf("expected detection steps to include maxAiCredits 777, got:\n%s", allSteps)
 		}
 	})
@@ -897,7 +897,7 @@ func TestBuildUploadDetectionLogStep(t *testing.T) {
 		"if: always()",
 		"uses: actions/upload-artifact@043fb46d1a93c77aae656e7c1c64a875d1fc6a0a",
 		"name: " + constants.DetectionArtifactName,
-		"path: /tmp/gh-aw/threat-detection/detection.log",
+		"path: ${{ env.GH_AW_TMP_DIR }}/threat-detection/detection.log",
 		"if-no-files-found: ignore",
 	}
 
@@ -1089,10 +1089,10 @@ func TestPrepareDetectionFilesStepWarnsWhenPromptContextMissingOrEmpty(t *testin
 	}
 
 	joined := strings.Join(steps, "")
-	if !strings.Contains(joined, "rm -f /tmp/gh-aw/agent_usage.json") {
+	if !strings.Contains(joined, "rm -f ${{ env.GH_AW_TMP_DIR }}/agent_usage.json") {
 		t.Error("Expected prepare step to remove stale downloaded agent_usage.json before detection writes its own token usage")
 	}
-	if !strings.Contains(joined, "if [ ! -s /tmp/gh-aw/threat-detection/aw-prompts/prompt.txt ]; then") {
+	if !strings.Contains(joined, "if [ ! -s ${{ env.GH_AW_TMP_DIR }}/threat-detection/aw-prompts/prompt.txt ]; then") {
 		t.Error("Expected prepare step to check for missing or empty detection context prompt")
 	}
 	if !strings.Contains(joined, "ERR_VALIDATION: Missing or empty detection context prompt") {
@@ -20,6 +20,12 @@ var toolConstraintBuilders = map[string]toolConstraintBuilder{
 	"create_agent_session": func(safeOutputs *SafeOutputsConfig) []string {
 		return createAgentSessionConstraints(safeOutputs.CreateAgentSessions)
 	},
+	"handoff_to_copilot": func(safeOutputs *SafeOutputsConfig) []string {
+		return handoffToCopilotConstraints(safeOutputs.HandoffToCopilot)
+	},
+	"schedule_follow_up": func(safeOutputs *SafeOutputsConfig) []string {
+		return scheduleFollowUpConstraints(safeOutputs.ScheduleFollowUp)
+	},
 	"create_discussion": func(safeOutputs *SafeOutputsConfig) []string {
 		return createDiscussionConstraints(safeOutputs.CreateDiscussions)
 	},
@@ -43,6 +49,9 @@ var toolConstraintBuilders = map[string]toolConstraintBuilder{
 	"create_pull_request_review_comment": func(safeOutputs *SafeOutputsConfig) []string {
 		return createPullRequestReviewCommentConstraints(safeOutputs.CreatePullRequestReviewComments)
 	},
+	"create_pull_request_review_suggestion": func(safeOutputs *SafeOutputsConfig) []string {
+		return createPullRequestReviewSuggestionConstraints(safeOutputs.CreatePullRequestReviewSuggestions)
+	},
 	"submit_pull_request_review": func(safeOutputs *SafeOutputsConfig) []string {
 		return submitPullRequestReviewConstraints(safeOutputs.SubmitPullRequestReview)
 	},
@@ -176,6 +185,12 @@ func createIssueConstraints(config *CreateIssuesConfig) []string {
 		constraints = append(constraints, fmt.Sprintf("Only these labels are allowed: %s.", formatStringList(config.AllowedLabels)))
 	}
 	appendAllowedIssueFieldsConstraint(&constraints, config.AllowedFields)
+	if len(config.AllowedTypes) > 0 {
+		constraints = append(constraints, fmt.Sprintf("Only these issue types are allowed: %s.", formatStringList(config.AllowedTypes)))
+	}
+	if config.MaxSubIssues > 0 {
+		constraints = append(constraints, fmt.Sprintf("At most %d issue(s) can be linked as sub-issues of the same parent.", config.MaxSubIssues))
+	}
 	if len(config.Assignees) > 0 {
 		constraints = append(constraints, fmt.Sprintf("Assignees %s will be automatically assigned.", formatStringList(config.Assignees)))
 	}
@@ -224,6 +239,42 @@ func createAgentSessionConstraints(config *CreateAgentSessionConfig) []string {
 	return constraints
 }
 
+func handoffToCopilotConstraints(config *HandoffToCopilotConfig) []string {
+	if config == nil {
+		return nil
+	}
+
+	var constraints []string
+	appendMaxConstraint(&constraints, config.Max, "Maximum %d handoff(s) can be created.")
+	if config.TitlePrefix != "" {
+		constraints = append(constraints, fmt.Sprintf("Titles will be prefixed with %q.", config.TitlePrefix))
+	}
+	if len(config.Labels) > 0 {
+		constraints = append(constraints, fmt.Sprintf("Labels %v will be automatically added.", config.Labels))
+	}
+	if config.TargetRepoSlug != "" {
+		constraints = append(constraints, fmt.Sprintf("Handoffs will be created in repository %q.", config.TargetRepoSlug))
+	}
+	if len(config.AllowedRepos) > 0 {
+		constraints = append(constraints, fmt.Sprintf("Handoffs can target these repositories: %v.", config.AllowedRepos))
+	}
+	return constraints
+}
+
+func scheduleFollowUpConstraints(config *ScheduleFollowUpConfig) []string {
+	if config == nil {
+		return nil
+	}
+
+	var constraints []string
+	appendMaxConstraint(&constraints, config.Max, "Maximum %d follow-up(s) can be scheduled.")
+	constraints = append(constraints, fmt.Sprintf("Follow-ups can be scheduled at most %dh ahead.", config.maxHorizonHours()))
+	if config.MaxPending > 0 {
+		constraints = append(constraints, fmt.Sprintf("At most %d follow-up(s) can be pending at the same time.", config.MaxPending))
+	}
+	return constraints
+}
+
 func createDiscussionConstraints(config *CreateDiscussionsConfig) []string {
 	if config == nil {
 		return nil
@@ -398,6 +449,9 @@ func createPullRequestConstraints(config *CreatePullRequestsConfig) []string {
 	if config.RequireTemporaryID {
 		constraints = append(constraints, "temporary_id is required.")
 	}
+	if config.GroupByDirectory != "" {
+		constraints = append(constraints, fmt.Sprintf("Each pull request must only change files inside a single directory matching %q; use a separate branch and pull request per directory.", config.GroupByDirectory))
+	}
 	if config.NormalizeClosingKeywords != nil && *config.NormalizeClosingKeywords {
 		constraints = append(constraints, "Backtick-wrapped issue-closing keyword references (e.g. `Closes #1`) in the body field will be automatically normalized to plain text.")
 	}
@@ -417,6 +471,19 @@ func createPullRequestReviewCommentConstraints(config *CreatePullRequestReviewCo
 	return constraints
 }
 
+func createPullRequestReviewSuggestionConstraints(config *CreatePRReviewSuggestionsConfig) []string {
+	if config == nil {
+		return nil
+	}
+
+	var constraints []string
+	appendMaxConstraint(&constraints, config.Max, "Maximum %d suggestion(s) can be created.")
+	if config.Target != "" {
+		constraints = append(constraints, fmt.Sprintf("Target: %s.", config.Target))
+	}
+	return constraints
+}
+
 func submitPullRequestReviewConstraints(config *SubmitPullRequestReviewConfig) []string {
 	if config == nil {
 		return nil
@@ -489,6 +556,15 @@ func createCheckRunConstraints(config *CreateCheckRunConfig) []string {
 	if config.Name != "" {
 		constraints = append(constraints, fmt.Sprintf("Check run name: %q.", config.Name))
 	}
+	if config.Annotations != nil {
+		constraints = append(constraints, fmt.Sprintf("Report each finding as an entry in annotations with path, start_line, annotation_level and message. Maximum %d annotation(s).", config.Annotations.Max))
+		if len(config.Annotations.Rules) > 0 {
+			constraints = append(constraints, fmt.Sprintf("Each annotation must set rule to one of: %s.", strings.Join(config.Annotations.Rules, ", ")))
+		}
+		if config.Annotations.Required {
+			constraints = append(constraints, "Annotations are required: a conclusion other than success must include at least one annotation.")
+		}
+	}
 	return constraints
 }
 
@@ -586,6 +662,16 @@ func updateIssueConstraints(config *UpdateIssuesConfig) []string {
 	if config.Status != nil && *config.Status {
 		constraints = append(constraints, "Status updates (open/closed) are allowed.")
 	}
+	if config.Type != nil && *config.Type {
+		if len(config.AllowedTypes) > 0 {
+			constraints = append(constraints, fmt.Sprintf("Issue type updates are allowed, limited to: %s.", formatStringList(config.AllowedTypes)))
+		} else {
+			constraints = append(constraints, "Issue type updates are allowed.")
+		}
+	}
+	if config.Parent != nil && *config.Parent {
+		constraints = append(constraints, "Setting the parent issue is allowed.")
+	}
 	return constraints
 }
 
@@ -281,3 +281,58 @@ func TestEnhanceToolDescriptionNormalizeClosingKeywordsFalseCreatePullRequest(t
 		t.Fatalf("did not expect normalize-closing-keywords note when disabled, got: %s", description)
 	}
 }
+
+func TestEnhanceToolDescriptionGroupByDirectoryCreatePullRequest(t *testing.T) {
+	description := enhanceToolDescription("create_pull_request", "Create a pull request.", &SafeOutputsConfig{
+		CreatePullRequests: &CreatePullRequestsConfig{GroupByDirectory: "docs/*"},
+	})
+	if !strings.Contains(description, `single directory matching "docs/*"`) {
+		t.Fatalf("expected group-by-directory note in description, got: %s", description)
+	}
+}
+
+func TestEnhanceToolDescriptionCreateCheckRunAnnotations(t *testing.T) {
+	description := enhanceToolDescription("create_check_run", "Create a check run.", &SafeOutputsConfig{
+		CreateCheckRun: &CreateCheckRunConfig{
+			Annotations: &CreateCheckRunAnnotationsConfig{Max: 50, Rules: []string{"alt-text"}, Required: true},
+		},
+	})
+	for _, want := range []string{"Maximum 50 annotation(s)", "one of: alt-text", "Annotations are required"} {
+		if !strings.Contains(description, want) {
+			t.Fatalf("expected %q in description, got: %s", want, description)
+		}
+	}
+}
+
+func TestEnhanceToolDescriptionCreateIssueTypesAndSubIssues(t *testing.T) {
+	description := enhanceToolDescription("create_issue", "Create an issue.", &SafeOutputsConfig{
+		CreateIssues: &CreateIssuesConfig{
+			AllowedTypes: []string{"Bug", "Task"},
+			MaxSubIssues: 5,
+		},
+	})
+
+	if !strings.Contains(description, "Only these issue types are allowed: [\"Bug\" \"Task\"].") {
+		t.Fatalf("expected allowed types message in description, got: %s", description)
+	}
+	if !strings.Contains(description, "At most 5 issue(s) can be linked as sub-issues of the same parent.") {
+		t.Fatalf("expected max sub-issues message in description, got: %s", description)
+	}
+}
+
+func TestEnhanceToolDescriptionUpdateIssueTypeAndParent(t *testing.T) {
+	description := enhanceToolDescription("update_issue", "Update an issue.", &SafeOutputsConfig{
+		UpdateIssues: &UpdateIssuesConfig{
+			Type:         testBoolPtr(true),
+			AllowedTypes: []string{"Bug"},
+			Parent:       testBoolPtr(true),
+		},
+	})
+
+	if !strings.Contains(description, "Issue type updates are allowed, limited to: [\"Bug\"].") {
+		t.Fatalf("expected issue type message in description, got: %s", description)
+	}
+	if !strings.Contains(description, "Setting the parent issue is allowed.") {
+		t.Fatalf("expected parent message in description, got: %s", description)
+	}
+}
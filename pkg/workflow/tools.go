@@ -50,10 +50,17 @@ func (c *Compiler) applyDefaults(data *WorkflowData, markdownPath string) error
 		data.TimeoutMinutes = fmt.Sprintf("timeout-minutes: %d", defaultTimeoutMinutes)
 	}
 
+	if err := c.applyResourcesRunsOn(data); err != nil {
+		return err
+	}
 	if data.RunsOn == "" {
 		data.RunsOn = "runs-on: ubuntu-latest"
 	}
 	data.Tools = c.applyDefaultTools(data.Tools, data.SafeOutputs, data.SandboxConfig, data.NetworkPermissions)
+	if err := applyFilesystemTool(data); err != nil {
+		return err
+	}
+	applyContextReposToGitHubTool(data)
 	data.ParsedTools = NewTools(data.Tools)
 
 	// Explicitly empty permissions ({}) means user wants no permissions — do not apply defaults.
@@ -589,6 +596,10 @@ func (c *Compiler) applyDefaultTools(tools map[string]any, safeOutputs *SafeOutp
 			"git merge:*",
 			"git status",
 		}
+		// Backport workflows cherry-pick through the guarded helper rather than raw git cherry-pick.
+		if isBackportEnabled(safeOutputs) {
+			gitCommands = append(gitCommands, backportHelperPath+":*")
+		}
 
 		// Add bash tool with Git commands if not already present
 		if _, exists := tools["bash"]; !exists {
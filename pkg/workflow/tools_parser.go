@@ -28,6 +28,7 @@
 //   - agentic-workflows: Nested workflow execution
 //   - cache-memory: In-workflow memory caching
 //   - repo-memory: Repository-backed persistent memory
+//   - artifacts: Files the agent publishes as run artifacts
 //
 // Configuration Tools:
 //   - safety-prompt: Safety prompt injection
@@ -104,6 +105,7 @@ var knownTools = map[string]struct{}{
 	"timeout":           {},
 	"startup-timeout":   {},
 	"cli-proxy":         {},
+	"artifacts":         {},
 }
 
 func NewTools(toolsMap map[string]any) *Tools {
@@ -149,6 +149,9 @@ func mcpServerConfigToMap(config MCPServerConfig) map[string]any {
 	if config.Auth != nil {
 		result["auth"] = config.Auth
 	}
+	if config.Transport != "" {
+		result["transport"] = config.Transport
+	}
 
 	// Add container-specific fields
 	if config.Container != "" {
@@ -485,6 +488,12 @@ type MCPGatewayRuntimeConfig struct {
 	// sink-visibility="public" enforcement. Emitted as gateway.sinkVisibilityExemptServers.
 	// Set from tools.github.private-to-public-flows: [server-ids...].
 	SinkVisibilityExemptServers []string `yaml:"-"`
+	// Chaos enables seeded fault injection for MCP tool calls (sandbox.mcp.chaos).
+	Chaos *MCPChaosConfig `yaml:"-"`
+	// Record captures every MCP tool call and its result to a recording (sandbox.mcp.record).
+	Record bool `yaml:"-"`
+	// Replay is a repository-relative recording that answers MCP tool calls (sandbox.mcp.replay).
+	Replay string `yaml:"-"`
 }
 
 // HasTool checks if a tool is present in the configuration
@@ -0,0 +1,254 @@
+// This file provides on.filters, first-class trigger filters.
+//
+// # Trigger Filters
+//
+// Filters narrow which events activate a workflow without hand-written if: expressions:
+//
+//	on:
+//	  pull_request:
+//	    types: [opened, synchronize]
+//	  filters:
+//	    paths: ["docs/**", "!docs/generated/**"]
+//	    labels: [documentation]
+//	    author-associations: [NONE, FIRST_TIME_CONTRIBUTOR, FIRST_TIMER, CONTRIBUTOR]
+//
+// Each filter compiles to the cheapest mechanism available for the configured events:
+//   - paths become native GitHub Actions paths filters on push, pull_request, and
+//     pull_request_target. Other pull request events (issue_comment on a pull request,
+//     pull_request_review, pull_request_review_comment) are checked by the
+//     check_changed_paths step in the pre-activation job.
+//   - labels and author-associations compile into the pre-activation job's if condition.
+//
+// Events that carry no pull request, labels, or author association (for example
+// workflow_dispatch or schedule) are never filtered out.
+
+package workflow
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/sliceutil"
+)
+
+var triggerFiltersLog = logger.New("workflow:trigger_filters")
+
+// triggerFilterNativePathEvents lists the events that support a native paths filter.
+var triggerFilterNativePathEvents = []string{"push", "pull_request", "pull_request_target"}
+
+// triggerFilterCheckedPathEvents lists pull request events without a native paths filter,
+// whose changed files are checked in the pre-activation job instead.
+var triggerFilterCheckedPathEvents = []string{"issue_comment", "pull_request_review", "pull_request_review_comment"}
+
+// triggerFilterAuthorAssociationEvents lists the events with an event-specific author_association field.
+var triggerFilterAuthorAssociationEvents = []string{"issues", "issue_comment", "pull_request", "pull_request_target", "pull_request_review", "pull_request_review_comment", "discussion_comment"}
+
+// validAuthorAssociations is the set of author_association values GitHub reports.
+var validAuthorAssociations = []string{"OWNER", "MEMBER", "COLLABORATOR", "CONTRIBUTOR", "FIRST_TIME_CONTRIBUTOR", "FIRST_TIMER", "MANNEQUIN", "NONE"}
+
+// TriggerFiltersConfig holds the configuration for on.filters.
+type TriggerFiltersConfig struct {
+	Paths                   []string // changed-file globs using GitHub Actions paths syntax
+	Labels                  []string // labels of which the issue or pull request must carry at least one
+	AuthorAssociations      []string // author associations allowed to trigger the workflow
+	PathCheckEvents         []string // configured events whose changed paths are checked in pre-activation
+	AuthorAssociationEvents []string // configured events whose author association is filtered
+}
+
+// needsPreActivation reports whether the filters compile into pre-activation checks.
+// Paths that map entirely onto native filters need no pre-activation job.
+func (f *TriggerFiltersConfig) needsPreActivation() bool {
+	return f != nil && (len(f.PathCheckEvents) > 0 || len(f.Labels) > 0 || len(f.AuthorAssociationEvents) > 0)
+}
+
+// parseTriggerFiltersConfig converts on.filters into a TriggerFiltersConfig.
+func parseTriggerFiltersConfig(value any) (*TriggerFiltersConfig, error) {
+	filtersMap, ok := value.(map[string]any)
+	if !ok {
+		return nil, errors.New("on.filters must be an object with paths, labels, or author-associations. Example:\n  filters:\n    paths: [\"docs/**\"]")
+	}
+	config := &TriggerFiltersConfig{}
+	for key, raw := range filtersMap {
+		values := sliceutil.Deduplicate(normalizeStringOrStringSlice(raw))
+		if len(values) == 0 {
+			return nil, fmt.Errorf("on.filters.%s must list at least one value", key)
+		}
+		switch key {
+		case "paths":
+			config.Paths = values
+		case "labels":
+			config.Labels = values
+		case "author-associations":
+			for _, association := range values {
+				normalized := strings.ToUpper(strings.TrimSpace(association))
+				if !slices.Contains(validAuthorAssociations, normalized) {
+					return nil, fmt.Errorf("invalid on.filters.author-associations value '%s': must be one of %s", association, strings.Join(validAuthorAssociations, ", "))
+				}
+				config.AuthorAssociations = append(config.AuthorAssociations, normalized)
+			}
+			config.AuthorAssociations = sliceutil.Deduplicate(config.AuthorAssociations)
+		default:
+			return nil, fmt.Errorf("unknown on.filters field '%s': must be one of paths, labels, author-associations", key)
+		}
+	}
+	return config, nil
+}
+
+// expandTriggerFilterPaths copies on.filters.paths into the native paths filter of every
+// configured push, pull_request, and pull_request_target event before the on: section
+// is rendered. An event that already declares paths or paths-ignore is rejected, since
+// the two filters would silently disagree.
+func expandTriggerFilterPaths(onMap map[string]any) error {
+	value, hasFilters := onMap["filters"]
+	if !hasFilters {
+		return nil
+	}
+	config, err := parseTriggerFiltersConfig(value)
+	if err != nil {
+		return err
+	}
+	if len(config.Paths) == 0 {
+		return nil
+	}
+	for _, eventName := range triggerFilterNativePathEvents {
+		eventValue, hasEvent := onMap[eventName]
+		if !hasEvent {
+			continue
+		}
+		eventMap, ok := eventValue.(map[string]any)
+		if !ok {
+			if eventValue != nil {
+				continue
+			}
+			eventMap = map[string]any{}
+		}
+		for _, existing := range []string{"paths", "paths-ignore"} {
+			if _, hasPaths := eventMap[existing]; hasPaths {
+				return fmt.Errorf("on.%s.%s cannot be combined with on.filters.paths: move the patterns into on.filters.paths", eventName, existing)
+			}
+		}
+		paths := make([]any, len(config.Paths))
+		for i, path := range config.Paths {
+			paths[i] = path
+		}
+		eventMap["paths"] = paths
+		onMap[eventName] = eventMap
+		triggerFiltersLog.Printf("Added native paths filter to on.%s: %v", eventName, config.Paths)
+	}
+	return nil
+}
+
+// processTriggerFiltersConfiguration extracts on.filters into WorkflowData and resolves
+// which configured events need pre-activation checks.
+func (c *Compiler) processTriggerFiltersConfiguration(frontmatter map[string]any, workflowData *WorkflowData) error {
+	onMap, ok := frontmatter["on"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	value, hasFilters := onMap["filters"]
+	if !hasFilters {
+		return nil
+	}
+	config, err := parseTriggerFiltersConfig(value)
+	if err != nil {
+		return err
+	}
+
+	if len(config.Paths) > 0 {
+		supported := false
+		for _, eventName := range triggerFilterNativePathEvents {
+			if _, hasEvent := onMap[eventName]; hasEvent {
+				supported = true
+			}
+		}
+		for _, eventName := range triggerFilterCheckedPathEvents {
+			if _, hasEvent := onMap[eventName]; hasEvent {
+				supported = true
+				config.PathCheckEvents = append(config.PathCheckEvents, eventName)
+			}
+		}
+		if !supported {
+			return fmt.Errorf("on.filters.paths requires at least one of these events: %s", strings.Join(append(slices.Clone(triggerFilterNativePathEvents), triggerFilterCheckedPathEvents...), ", "))
+		}
+	}
+
+	if len(config.AuthorAssociations) > 0 {
+		for _, eventName := range triggerFilterAuthorAssociationEvents {
+			if _, hasEvent := onMap[eventName]; hasEvent {
+				config.AuthorAssociationEvents = append(config.AuthorAssociationEvents, eventName)
+			}
+		}
+		if len(config.AuthorAssociationEvents) == 0 {
+			return fmt.Errorf("on.filters.author-associations requires at least one of these events: %s", strings.Join(triggerFilterAuthorAssociationEvents, ", "))
+		}
+	}
+
+	workflowData.TriggerFilters = config
+	triggerFiltersLog.Printf("Trigger filters configured: paths=%v (checked on %v), labels=%v, author-associations=%v (on %v)",
+		config.Paths, config.PathCheckEvents, config.Labels, config.AuthorAssociations, config.AuthorAssociationEvents)
+	return nil
+}
+
+// appendPreActivationChangedPathsStep adds the step that checks the changed files of the
+// pull request behind events that have no native paths filter.
+func (c *Compiler) appendPreActivationChangedPathsStep(data *WorkflowData, steps []string) []string {
+	pathsJSON, _ := json.Marshal(data.TriggerFilters.Paths) //nolint:jsonmarshalignoredeerror // marshaling a string slice cannot fail
+	steps = append(steps, "      - name: Check changed paths\n")
+	steps = append(steps, fmt.Sprintf("        id: %s\n", constants.CheckChangedPathsStepID))
+	steps = append(steps, fmt.Sprintf("        uses: %s\n", getCachedActionPin("actions/github-script", data)))
+	steps = append(steps, "        env:\n")
+	steps = append(steps, fmt.Sprintf("          GH_AW_FILTER_PATHS: %q\n", string(pathsJSON)))
+	steps = append(steps, "        with:\n")
+	steps = append(steps, "          script: |\n")
+	return append(steps, generateGitHubScriptWithRequire("check_changed_paths.cjs"))
+}
+
+// buildTriggerFilterLabelsCondition returns a condition that is true when the triggering
+// pull request, issue, or discussion carries at least one of the labels, or when the
+// event has no such item.
+func buildTriggerFilterLabelsCondition(labels []string) ConditionNode {
+	itemPaths := []string{"github.event.pull_request", "github.event.issue", "github.event.discussion"}
+
+	var result ConditionNode
+	for _, itemPath := range itemPaths {
+		noItem := BuildEquals(BuildPropertyAccess(itemPath), BuildNullLiteral())
+		if result == nil {
+			result = noItem
+		} else {
+			result = BuildAnd(result, noItem)
+		}
+	}
+	for _, itemPath := range itemPaths {
+		for _, label := range labels {
+			result = BuildOr(result, BuildFunctionCall("contains",
+				BuildPropertyAccess(itemPath+".labels.*.name"),
+				BuildStringLiteral(label),
+			))
+		}
+	}
+	return result
+}
+
+// buildTriggerFilterAuthorAssociationsCondition returns a condition that is false when one
+// of the given events was triggered by an author association outside the allowed list.
+func buildTriggerFilterAuthorAssociationsCondition(associations []string, eventNames []string) ConditionNode {
+	associationJSON, _ := json.Marshal(associations) //nolint:jsonmarshalignoredeerror // marshaling a string slice cannot fail
+
+	denyTerms := make([]ConditionNode, 0, len(eventNames))
+	for _, eventName := range eventNames {
+		denyTerms = append(denyTerms, BuildAnd(
+			BuildEquals(BuildPropertyAccess("github.event_name"), BuildStringLiteral(eventName)),
+			&NotNode{Child: BuildFunctionCall(
+				"contains",
+				BuildFunctionCall("fromJSON", BuildStringLiteral(string(associationJSON))),
+				buildAuthorAssociationNodeForEvent(eventName),
+			)},
+		))
+	}
+	return &NotNode{Child: BuildDisjunction(false, denyTerms...)}
+}
@@ -0,0 +1,145 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTriggerFiltersConfig(t *testing.T) {
+	config, err := parseTriggerFiltersConfig(map[string]any{
+		"paths":               "docs/**",
+		"labels":              []any{"documentation", "documentation"},
+		"author-associations": []any{"none", "First_Timer"},
+	})
+	require.NoError(t, err, "valid filters should parse")
+	assert.Equal(t, []string{"docs/**"}, config.Paths, "a single path should become a list")
+	assert.Equal(t, []string{"documentation"}, config.Labels, "labels should be deduplicated")
+	assert.Equal(t, []string{"NONE", "FIRST_TIMER"}, config.AuthorAssociations, "author associations should be upper-cased")
+}
+
+func TestParseTriggerFiltersConfigErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   any
+		wantErr string
+	}{
+		{name: "not an object", value: "docs/**", wantErr: "on.filters must be an object"},
+		{name: "unknown field", value: map[string]any{"branches": "main"}, wantErr: "unknown on.filters field 'branches'"},
+		{name: "empty list", value: map[string]any{"labels": []any{}}, wantErr: "on.filters.labels must list at least one value"},
+		{name: "invalid association", value: map[string]any{"author-associations": "stranger"}, wantErr: "invalid on.filters.author-associations value 'stranger'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseTriggerFiltersConfig(tt.value)
+			require.Error(t, err, "invalid on.filters should be rejected")
+			assert.Contains(t, err.Error(), tt.wantErr, "error should explain the problem")
+		})
+	}
+}
+
+func TestExpandTriggerFilterPaths(t *testing.T) {
+	onMap := map[string]any{
+		"push":          map[string]any{"branches": []any{"main"}},
+		"pull_request":  nil,
+		"issue_comment": map[string]any{"types": []any{"created"}},
+		"filters":       map[string]any{"paths": []any{"docs/**"}},
+	}
+	require.NoError(t, expandTriggerFilterPaths(onMap), "paths should expand")
+	assert.Equal(t, []any{"docs/**"}, onMap["push"].(map[string]any)["paths"], "push should get a native paths filter")
+	assert.Equal(t, []any{"docs/**"}, onMap["pull_request"].(map[string]any)["paths"], "a bare pull_request should get a native paths filter")
+	assert.NotContains(t, onMap["issue_comment"].(map[string]any), "paths", "issue_comment has no native paths filter")
+
+	conflicting := map[string]any{
+		"pull_request": map[string]any{"paths-ignore": []any{"src/**"}},
+		"filters":      map[string]any{"paths": "docs/**"},
+	}
+	err := expandTriggerFilterPaths(conflicting)
+	require.Error(t, err, "native paths filters should not be merged silently")
+	assert.Contains(t, err.Error(), "on.pull_request.paths-ignore cannot be combined with on.filters.paths", "error should name the conflicting field")
+}
+
+func TestBuildTriggerFilterConditions(t *testing.T) {
+	labels := RenderCondition(buildTriggerFilterLabelsCondition([]string{"docs"}))
+	assert.Contains(t, labels, "github.event.pull_request == null && github.event.issue == null", "events without an item should pass")
+	assert.Contains(t, labels, "contains(github.event.pull_request.labels.*.name, 'docs')", "pull request labels should be checked")
+	assert.Contains(t, labels, "contains(github.event.issue.labels.*.name, 'docs')", "issue labels should be checked")
+
+	associations := RenderCondition(buildTriggerFilterAuthorAssociationsCondition([]string{"NONE"}, []string{"issue_comment"}))
+	assert.Contains(t, associations, "github.event_name == 'issue_comment'", "the check should be scoped to the configured event")
+	assert.Contains(t, associations, `contains(fromJSON('["NONE"]'), github.event.comment.author_association)`, "the event-specific association should be compared")
+}
+
+func TestCompileTriggerFilters(t *testing.T) {
+	dir := t.TempDir()
+	markdownPath := filepath.Join(dir, "docs-review.md")
+	content := `---
+on:
+  pull_request:
+    types: [opened, synchronize]
+  pull_request_review:
+    types: [submitted]
+  filters:
+    paths: ["docs/**", "!docs/generated/**"]
+    labels: documentation
+    author-associations: [none, first_time_contributor]
+  roles: all
+permissions:
+  contents: read
+engine: copilot
+---
+
+# Docs review
+
+Review the documentation change.
+`
+	require.NoError(t, os.WriteFile(markdownPath, []byte(content), 0o644), "workflow should be written")
+	compiler := NewCompiler()
+	compiler.SetWorkflowIdentifier("docs-review.md")
+	require.NoError(t, compiler.CompileWorkflow(markdownPath), "workflow should compile")
+
+	lock, err := os.ReadFile(filepath.Join(dir, "docs-review.lock.yml"))
+	require.NoError(t, err, "lock file should be written")
+	lockContent := string(lock)
+
+	assert.Contains(t, lockContent, "# filters:", "filters should be commented out of the on: section")
+	assert.Contains(t, lockContent, "  pull_request:\n    paths:\n      - docs/**\n      - \"!docs/generated/**\"", "pull_request should get a native paths filter")
+	assert.Contains(t, lockContent, "id: check_changed_paths", "pull_request_review should be checked in pre-activation")
+	assert.Contains(t, lockContent, `GH_AW_FILTER_PATHS: "[\"docs/**\",\"!docs/generated/**\"]"`, "the check should receive the configured paths")
+	assert.Contains(t, lockContent, "steps.check_changed_paths.outputs.changed_paths_ok == 'true'", "activation should depend on the changed paths check")
+	assert.Contains(t, lockContent, "pull-requests: read", "listing pull request files requires pull-requests: read")
+	assert.Contains(t, lockContent, "contains(github.event.pull_request.labels.*.name, 'documentation')", "labels should compile into the pre-activation if")
+	assert.Contains(t, lockContent, "github.event.review.author_association", "review author associations should be checked")
+}
+
+func TestCompileTriggerFiltersNativePathsOnly(t *testing.T) {
+	dir := t.TempDir()
+	markdownPath := filepath.Join(dir, "docs.md")
+	content := "---\non:\n  push:\n    branches: [main]\n  filters:\n    paths: docs/**\n  roles: all\nengine: copilot\n---\n\n# Docs\n"
+	require.NoError(t, os.WriteFile(markdownPath, []byte(content), 0o644), "workflow should be written")
+	compiler := NewCompiler()
+	compiler.SetWorkflowIdentifier("docs.md")
+	require.NoError(t, compiler.CompileWorkflow(markdownPath), "workflow should compile")
+
+	lock, err := os.ReadFile(filepath.Join(dir, "docs.lock.yml"))
+	require.NoError(t, err, "lock file should be written")
+	assert.Contains(t, string(lock), "    paths:\n      - docs/**", "push should get a native paths filter")
+	assert.NotContains(t, string(lock), "check_changed_paths", "native paths filters need no pre-activation check")
+}
+
+func TestCompileTriggerFiltersRequiresSupportedEvent(t *testing.T) {
+	dir := t.TempDir()
+	markdownPath := filepath.Join(dir, "manual.md")
+	content := "---\non:\n  workflow_dispatch:\n  filters:\n    paths: docs/**\nengine: copilot\n---\n\n# Manual\n"
+	require.NoError(t, os.WriteFile(markdownPath, []byte(content), 0o644), "workflow should be written")
+	compiler := NewCompiler()
+	compiler.SetWorkflowIdentifier("manual.md")
+	err := compiler.CompileWorkflow(markdownPath)
+	require.Error(t, err, "paths without a pull request or push event should be rejected")
+	assert.Contains(t, err.Error(), "on.filters.paths requires at least one of these events", "error should list the supported events")
+}
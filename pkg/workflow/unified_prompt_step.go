@@ -25,6 +25,12 @@ type PromptSection struct {
 	EnvVars map[string]string
 }
 
+// promptScratchPath returns path, a location in the job scratch directory, in the form used
+// in prompt text. The agent job resolves the placeholder to its own scratch directory.
+func promptScratchPath(path string) string {
+	return strings.Replace(path, constants.TmpGhAwDirExpr, constants.TmpGhAwDirPlaceholder, 1)
+}
+
 // removeConsecutiveEmptyLines removes consecutive empty lines, keeping only one
 func removeConsecutiveEmptyLines(content string) string {
 	lines := strings.Split(content, "\n")
@@ -83,6 +89,12 @@ func (c *Compiler) collectPromptSections(data *WorkflowData) []PromptSection {
 		IsFile:  true,
 	})
 
+	// 2a. Output language instructions (if output-language is set)
+	if section := buildOutputLanguagePromptSection(data); section != nil {
+		unifiedPromptLog.Printf("Adding output language section: language=%s", data.OutputLanguage)
+		sections = append(sections, *section)
+	}
+
 	// 3. Playwright instructions (if playwright tool is enabled)
 	if hasPlaywrightTool(data.ParsedTools) {
 		unifiedPromptLog.Print("Adding playwright section")
@@ -102,7 +114,49 @@ func (c *Compiler) collectPromptSections(data *WorkflowData) []PromptSection {
 		})
 	}
 
-	// 6. Cache memory instructions (if enabled)
+	// 5. Upstream workflow_run outputs (if chained to an agentic workflow)
+	if section := buildWorkflowRunUpstreamPromptSection(data); section != nil {
+		unifiedPromptLog.Printf("Adding workflow_run upstream section: upstreams=%v", data.WorkflowRunUpstreams)
+		sections = append(sections, *section)
+	}
+
+	// 5a. Outputs of upstream agentic jobs in the same workflow run
+	if section := buildAgenticJobUpstreamPromptSection(data); section != nil {
+		unifiedPromptLog.Printf("Adding agentic job upstream section: upstreams=%d", len(data.AgenticJobUpstreams))
+		sections = append(sections, *section)
+	}
+
+	// 5b. Item of a fan-out (in the companion workflow of a fan-out workflow)
+	if section := buildFanOutItemPromptSection(data); section != nil {
+		unifiedPromptLog.Print("Adding fan-out item section")
+		sections = append(sections, *section)
+	}
+
+	// 6. Slash command subcommand invocation (if subcommands are declared)
+	if section := buildSlashCommandSubcommandPromptSection(data); section != nil {
+		unifiedPromptLog.Printf("Adding slash command subcommand section: subcommands=%d", len(data.CommandSubcommands))
+		sections = append(sections, *section)
+	}
+
+	// 6a. Previous invocation of a sticky slash command (if slash_command.sticky is set)
+	if section := buildStickyContextPromptSection(data); section != nil {
+		unifiedPromptLog.Printf("Adding sticky command context section: window=%s", data.CommandSticky)
+		sections = append(sections, *section)
+	}
+
+	// 7. Reaction command target (if triggered by reaction_command)
+	if section := buildReactionCommandPromptSection(data); section != nil {
+		unifiedPromptLog.Printf("Adding reaction command section: emoji=%s", data.ReactionCommand.Emoji)
+		sections = append(sections, *section)
+	}
+
+	// 7a. Due follow-ups (if safe-outputs.schedule-follow-up is set)
+	if section := buildFollowUpPromptSection(data); section != nil {
+		unifiedPromptLog.Print("Adding scheduled follow-up section")
+		sections = append(sections, *section)
+	}
+
+	// 8. Cache memory instructions (if enabled)
 	if data.CacheMemoryConfig != nil && len(data.CacheMemoryConfig.Caches) > 0 {
 		unifiedPromptLog.Printf("Adding cache memory section: caches=%d", len(data.CacheMemoryConfig.Caches))
 		section := buildCacheMemoryPromptSection(data.CacheMemoryConfig)
@@ -111,7 +165,7 @@ func (c *Compiler) collectPromptSections(data *WorkflowData) []PromptSection {
 		}
 	}
 
-	// 7. Repo memory instructions (if enabled)
+	// 9. Repo memory instructions (if enabled)
 	if data.RepoMemoryConfig != nil && len(data.RepoMemoryConfig.Memories) > 0 {
 		unifiedPromptLog.Printf("Adding repo memory section: memories=%d", len(data.RepoMemoryConfig.Memories))
 		section := buildRepoMemoryPromptSection(data.RepoMemoryConfig)
@@ -120,7 +174,19 @@ func (c *Compiler) collectPromptSections(data *WorkflowData) []PromptSection {
 		}
 	}
 
-	// 8. Safe outputs instructions (if enabled)
+	// 9a. Context repositories (if repos: is configured)
+	if section := buildContextReposPromptSection(data); section != nil {
+		unifiedPromptLog.Printf("Adding context repos section: repos=%d", len(data.ContextRepos))
+		sections = append(sections, *section)
+	}
+
+	// 9b. Artifacts tool instructions (if enabled)
+	if section := buildArtifactsToolPromptSection(data); section != nil {
+		unifiedPromptLog.Print("Adding artifacts tool section")
+		sections = append(sections, *section)
+	}
+
+	// 10. Safe outputs instructions (if enabled)
 	if HasSafeOutputsEnabled(data.SafeOutputs) {
 		unifiedPromptLog.Print("Adding safe outputs section")
 		// Static intro from file (gh CLI warning, temporary ID rules, noop note)
@@ -138,7 +204,13 @@ func (c *Compiler) collectPromptSections(data *WorkflowData) []PromptSection {
 		sections = append(sections, *section)
 	}
 
-	// 9. GitHub context (if GitHub tool is enabled)
+	// 10b. Capabilities summary (if the capabilities-prompt feature flag is enabled)
+	if section := c.buildCapabilitiesPromptSection(data); section != nil {
+		unifiedPromptLog.Print("Adding capabilities section")
+		sections = append(sections, *section)
+	}
+
+	// 11. GitHub context (if GitHub tool is enabled)
 	if hasGitHubTool(data.ParsedTools) {
 		unifiedPromptLog.Print("Adding GitHub context section")
 
@@ -146,6 +218,9 @@ func (c *Compiler) collectPromptSections(data *WorkflowData) []PromptSection {
 		// The checkout list may contain ${{ github.repository }} which must go through
 		// the expression extractor so the placeholder substitution step can resolve it.
 		combinedPromptText := githubContextPromptText
+		if data.Privacy.pseudonymizes() {
+			combinedPromptText = privacyGitHubContextPrompt(combinedPromptText)
+		}
 		if checkoutsContent := buildCheckoutsPromptContent(data.CheckoutConfigs); checkoutsContent != "" {
 			unifiedPromptLog.Printf("Injecting checkout list into GitHub context (%d checkouts)", len(data.CheckoutConfigs))
 			const closeTag = "</github-context>"
@@ -177,7 +252,7 @@ func (c *Compiler) collectPromptSections(data *WorkflowData) []PromptSection {
 		}
 	}
 
-	// 10. GitHub tool-use guidance: directs the model to the correct mechanism for
+	// 12. GitHub tool-use guidance: directs the model to the correct mechanism for
 	// GitHub reads (and writes when safe-outputs is also enabled).
 	// When GitHub mode is gh-proxy, the agent uses the pre-authenticated gh CLI for reads
 	// instead of a GitHub MCP server (which is not registered). Otherwise, the GitHub
@@ -208,7 +283,7 @@ func (c *Compiler) collectPromptSections(data *WorkflowData) []PromptSection {
 		})
 	}
 
-	// 11. PR context (if comment-related triggers and checkout is needed)
+	// 13. PR context (if comment-related triggers and checkout is needed)
 	hasCommentTriggers := c.hasCommentRelatedTriggers(data)
 	needsCheckout := c.shouldAddCheckoutStep(data)
 	var hasContentsRead bool
@@ -251,6 +326,13 @@ func (c *Compiler) collectPromptSections(data *WorkflowData) []PromptSection {
 		}
 	}
 
+	// 14. Run context: current time, triggering event and run URL (always included).
+	// Kept last so it shares a heredoc block with the closing </system> tag.
+	if section := buildRunContextPromptSection(); section != nil {
+		unifiedPromptLog.Print("Adding run context section")
+		sections = append(sections, *section)
+	}
+
 	return sections
 }
 
@@ -333,7 +415,7 @@ func (c *Compiler) generateUnifiedPromptCreationStep(yaml *strings.Builder, buil
 	// Generate the step with all environment variables
 	yaml.WriteString("      - name: Create prompt with built-in context\n")
 	yaml.WriteString("        env:\n")
-	yaml.WriteString("          GH_AW_PROMPT: /tmp/gh-aw/aw-prompts/prompt.txt\n")
+	yaml.WriteString("          GH_AW_PROMPT: " + constants.TmpGhAwDirExpr + "/aw-prompts/prompt.txt\n")
 
 	if data.SafeOutputs != nil {
 		yaml.WriteString("          GH_AW_SAFE_OUTPUTS: ${{ runner.temp }}/gh-aw/safeoutputs/outputs.jsonl\n")
@@ -560,6 +642,78 @@ func buildSafeOutputsSections(safeOutputs *SafeOutputsConfig) []PromptSection {
 	safeOutputsPromptLog.Print("Building safe outputs sections")
 
 	// Build compact list of enabled tool names, annotated with max budget when > 1.
+	tools := safeOutputToolBudgets(safeOutputs)
+	if len(tools) == 0 {
+		return nil
+	}
+
+	var sections []PromptSection
+
+	// Build the inline opening: XML tag + compact tools list.
+	// Extract any ${{ }} expressions from max: values so they do not appear in the
+	// run: heredoc (which is subject to GitHub Actions' 21KB expression-size limit).
+	// Expressions are replaced with __GH_AW_...__  placeholders and added to EnvVars
+	// so the placeholder substitution step can resolve them at runtime.
+	toolsContent := "<safe-output-tools>\nTools: " + strings.Join(tools, ", ")
+	envVars := make(map[string]string)
+	extractor := NewExpressionExtractor()
+	exprMappings, err := extractor.ExtractExpressions(toolsContent)
+	if err == nil && len(exprMappings) > 0 {
+		safeOutputsPromptLog.Printf("Extracted %d expression(s) from safe-output-tools block", len(exprMappings))
+		toolsContent = extractor.ReplaceExpressionsWithEnvVars(toolsContent)
+		for _, mapping := range exprMappings {
+			envVars[mapping.EnvVar] = fmt.Sprintf("${{ %s }}", mapping.Content)
+		}
+	}
+
+	// Inline opening: XML tag + compact tools list (with placeholders for any expressions)
+	sections = append(sections, PromptSection{
+		Content: toolsContent,
+		IsFile:  false,
+		EnvVars: envVars,
+	})
+
+	// File sections for tools with multi-step instructions
+	if safeOutputs.CreatePullRequests != nil {
+		sections = append(sections, PromptSection{Content: safeOutputsCreatePRFile, IsFile: true})
+		if safeOutputs.CreatePullRequests.Backport != nil {
+			sections = append(sections, PromptSection{Content: safeOutputsBackportFile, IsFile: true})
+		}
+	}
+	if safeOutputs.PushToPullRequestBranch != nil {
+		sections = append(sections, PromptSection{Content: safeOutputsPushToBranchFile, IsFile: true})
+	}
+	if safeOutputs.CommentMemory != nil {
+		sections = append(sections, PromptSection{Content: safeOutputsCommentMemoryFile, IsFile: true})
+	}
+	if safeOutputs.UploadAssets != nil {
+		sections = append(sections, PromptSection{
+			Content: "\nupload_asset: provide a file path; returns a URL; assets are published after the workflow completes (" + constants.SafeOutputsMCPServerID.String() + ").",
+			IsFile:  false,
+		})
+	}
+	// Auto-injected create_issue special notice
+	if safeOutputs.CreateIssues != nil && safeOutputs.AutoInjectedCreateIssue {
+		sections = append(sections, PromptSection{Content: safeOutputsAutoCreateIssueFile, IsFile: true})
+	}
+
+	// Inline closing tag
+	sections = append(sections, PromptSection{
+		Content: "</safe-output-tools>",
+		IsFile:  false,
+	})
+
+	return sections
+}
+
+// safeOutputToolBudgets returns the names of the enabled safe output tools, annotated
+// with their max budget when greater than 1 (see toolWithMaxBudget). Custom jobs,
+// scripts and actions follow the built-in tools in sorted order.
+func safeOutputToolBudgets(safeOutputs *SafeOutputsConfig) []string {
+	if safeOutputs == nil {
+		return nil
+	}
+
 	var tools []string
 	if safeOutputs.AddComments != nil {
 		tools = append(tools, toolWithMaxBudget("add_comment", safeOutputs.AddComments.Max))
@@ -585,6 +739,12 @@ func buildSafeOutputsSections(safeOutputs *SafeOutputsConfig) []PromptSection {
 	if safeOutputs.CreateAgentSessions != nil {
 		tools = append(tools, toolWithMaxBudget("create_agent_session", safeOutputs.CreateAgentSessions.Max))
 	}
+	if safeOutputs.HandoffToCopilot != nil {
+		tools = append(tools, toolWithMaxBudget("handoff_to_copilot", safeOutputs.HandoffToCopilot.Max))
+	}
+	if safeOutputs.ScheduleFollowUp != nil {
+		tools = append(tools, toolWithMaxBudget("schedule_follow_up", safeOutputs.ScheduleFollowUp.Max))
+	}
 	if safeOutputs.CreatePullRequests != nil {
 		tools = append(tools, toolWithMaxBudget("create_pull_request", safeOutputs.CreatePullRequests.Max))
 	}
@@ -603,6 +763,9 @@ func buildSafeOutputsSections(safeOutputs *SafeOutputsConfig) []PromptSection {
 	if safeOutputs.CreatePullRequestReviewComments != nil {
 		tools = append(tools, toolWithMaxBudget("create_pull_request_review_comment", safeOutputs.CreatePullRequestReviewComments.Max))
 	}
+	if safeOutputs.CreatePullRequestReviewSuggestions != nil {
+		tools = append(tools, toolWithMaxBudget("create_pull_request_review_suggestion", safeOutputs.CreatePullRequestReviewSuggestions.Max))
+	}
 	if safeOutputs.SubmitPullRequestReview != nil {
 		tools = append(tools, toolWithMaxBudget("submit_pull_request_review", safeOutputs.SubmitPullRequestReview.Max))
 	}
@@ -721,62 +884,5 @@ func buildSafeOutputsSections(safeOutputs *SafeOutputsConfig) []PromptSection {
 		}
 	}
 
-	if len(tools) == 0 {
-		return nil
-	}
-
-	var sections []PromptSection
-
-	// Build the inline opening: XML tag + compact tools list.
-	// Extract any ${{ }} expressions from max: values so they do not appear in the
-	// run: heredoc (which is subject to GitHub Actions' 21KB expression-size limit).
-	// Expressions are replaced with __GH_AW_...__  placeholders and added to EnvVars
-	// so the placeholder substitution step can resolve them at runtime.
-	toolsContent := "<safe-output-tools>\nTools: " + strings.Join(tools, ", ")
-	envVars := make(map[string]string)
-	extractor := NewExpressionExtractor()
-	exprMappings, err := extractor.ExtractExpressions(toolsContent)
-	if err == nil && len(exprMappings) > 0 {
-		safeOutputsPromptLog.Printf("Extracted %d expression(s) from safe-output-tools block", len(exprMappings))
-		toolsContent = extractor.ReplaceExpressionsWithEnvVars(toolsContent)
-		for _, mapping := range exprMappings {
-			envVars[mapping.EnvVar] = fmt.Sprintf("${{ %s }}", mapping.Content)
-		}
-	}
-
-	// Inline opening: XML tag + compact tools list (with placeholders for any expressions)
-	sections = append(sections, PromptSection{
-		Content: toolsContent,
-		IsFile:  false,
-		EnvVars: envVars,
-	})
-
-	// File sections for tools with multi-step instructions
-	if safeOutputs.CreatePullRequests != nil {
-		sections = append(sections, PromptSection{Content: safeOutputsCreatePRFile, IsFile: true})
-	}
-	if safeOutputs.PushToPullRequestBranch != nil {
-		sections = append(sections, PromptSection{Content: safeOutputsPushToBranchFile, IsFile: true})
-	}
-	if safeOutputs.CommentMemory != nil {
-		sections = append(sections, PromptSection{Content: safeOutputsCommentMemoryFile, IsFile: true})
-	}
-	if safeOutputs.UploadAssets != nil {
-		sections = append(sections, PromptSection{
-			Content: "\nupload_asset: provide a file path; returns a URL; assets are published after the workflow completes (" + constants.SafeOutputsMCPServerID.String() + ").",
-			IsFile:  false,
-		})
-	}
-	// Auto-injected create_issue special notice
-	if safeOutputs.CreateIssues != nil && safeOutputs.AutoInjectedCreateIssue {
-		sections = append(sections, PromptSection{Content: safeOutputsAutoCreateIssueFile, IsFile: true})
-	}
-
-	// Inline closing tag
-	sections = append(sections, PromptSection{
-		Content: "</safe-output-tools>",
-		IsFile:  false,
-	})
-
-	return sections
+	return tools
 }
@@ -308,10 +308,10 @@ func (e *UniversalLLMConsumerEngine) BuildCLIEngineExecutionSteps(
 			AllowedDomains: allowedDomains,
 		})
 	} else if cfg.WriteTimestamp {
-		command = fmt.Sprintf("set -o pipefail\nexport no_proxy=\"${NO_PROXY:-}\"\nprintf '%%s' \"$(date +%%s%%3N)\" > %s\n%s 2>&1 | tee -a %s",
-			AgentCLIStartMsPath, engineCommand, logFile)
+		command = fmt.Sprintf("set -o pipefail\nexport no_proxy=\"${NO_PROXY:-}\"\nprintf '%%s' \"$(date +%%s%%3N)\" > %s\n%s",
+			AgentCLIStartMsPath, captureEngineOutput(engineCommand, logFile))
 	} else {
-		command = fmt.Sprintf("set -o pipefail\nexport no_proxy=\"${NO_PROXY:-}\"\n%s 2>&1 | tee -a %s", engineCommand, logFile)
+		command = fmt.Sprintf("set -o pipefail\nexport no_proxy=\"${NO_PROXY:-}\"\n%s", captureEngineOutput(engineCommand, logFile))
 	}
 
 	env := map[string]string{
@@ -15,6 +15,9 @@ type UpdateIssuesConfig struct {
 	Status              *bool    `yaml:"status,omitempty"`                // Allow updating issue status (open/closed) - presence indicates field can be updated
 	Title               *bool    `yaml:"title,omitempty"`                 // Allow updating issue title - presence indicates field can be updated
 	Body                *bool    `yaml:"body,omitempty"`                  // Allow updating issue body - boolean value controls permission (defaults to true)
+	Type                *bool    `yaml:"type,omitempty"`                  // Allow updating issue type - presence indicates field can be updated
+	AllowedTypes        []string `yaml:"allowed-types,omitempty"`         // Issue type names the agent can set (empty = any type)
+	Parent              *bool    `yaml:"parent,omitempty"`                // Allow setting the parent issue - presence indicates field can be updated
 	Footer              *string  `yaml:"footer,omitempty"`                // Controls whether AI-generated footer is added. When false, visible footer is omitted but XML markers are kept.
 	TitlePrefix         string   `yaml:"title-prefix,omitempty"`          // Required title prefix for issue validation - only issues with this prefix can be updated (deprecated: use required-title-prefix)
 	RequiredTitlePrefix string   `yaml:"required-title-prefix,omitempty"` // Title prefix the issue must have (preferred over title-prefix)
@@ -30,6 +33,8 @@ func (c *Compiler) parseUpdateIssuesConfig(outputMap map[string]any) *UpdateIssu
 			return []UpdateEntityFieldSpec{
 				{Name: "status", Mode: FieldParsingKeyExistence, Dest: &cfg.Status},
 				{Name: "title", Mode: FieldParsingKeyExistence, Dest: &cfg.Title},
+				{Name: "type", Mode: FieldParsingKeyExistence, Dest: &cfg.Type},
+				{Name: "parent", Mode: FieldParsingKeyExistence, Dest: &cfg.Parent},
 				{Name: "body", Mode: FieldParsingBoolValue, Dest: &cfg.Body},
 				{Name: "footer", Mode: FieldParsingTemplatableBool, StringDest: &cfg.Footer},
 			}
@@ -40,5 +45,6 @@ func (c *Compiler) parseUpdateIssuesConfig(outputMap map[string]any) *UpdateIssu
 			// RequiredTitlePrefix when only title-prefix is set.
 			cfg.RequiredLabels = ParseStringArrayFromConfig(configMap, "required-labels", updateIssueLog)
 			cfg.RequiredTitlePrefix = extractStringFromMap(configMap, "required-title-prefix", updateIssueLog)
+			cfg.AllowedTypes = ParseStringArrayFromConfig(configMap, "allowed-types", updateIssueLog)
 		})
 }
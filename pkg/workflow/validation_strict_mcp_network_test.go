@@ -450,3 +450,24 @@ func TestValidateStrictMCPNetwork_MultipleServersWithTopLevelNetwork(t *testing.
 		t.Errorf("Expected no error for multiple servers with top-level network, got: %v", err)
 	}
 }
+
+// TestValidateStrictMCPNetwork_PerServerEgress tests that a per-server egress allowlist satisfies strict mode
+func TestValidateStrictMCPNetwork_PerServerEgress(t *testing.T) {
+	compiler := NewCompiler()
+	frontmatter := map[string]any{
+		"on": "push",
+		"mcp-servers": map[string]any{
+			"notion": map[string]any{
+				"container": "mcp/notion",
+				"network": map[string]any{
+					"allowed": []any{"api.notion.com"},
+				},
+			},
+		},
+	}
+
+	err := compiler.validateStrictMCPNetwork(frontmatter, nil)
+	if err != nil {
+		t.Errorf("Expected no error for container server with network.allowed, got: %v", err)
+	}
+}
@@ -0,0 +1,204 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/fileutil"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+)
+
+var warmRunnersWorkflowLog = logger.New("workflow:warm_runners_workflow")
+
+// WarmRunnersWorkflowFileName is the filename for the generated warm runners workflow.
+const WarmRunnersWorkflowFileName = "agentic-warm-runners.yml"
+
+// warmRunnersWorkflowIdentifier is the stable identifier used to scatter the default
+// fuzzy schedule across repositories.
+const warmRunnersWorkflowIdentifier = "agentic-warm-runners"
+
+// warmRunnersDefaultSchedule is the fuzzy schedule used when warm_runners.cron is not set.
+const warmRunnersDefaultSchedule = "FUZZY:HOURLY/6"
+
+// GenerateWarmRunnersWorkflowOptions configures a warm runners workflow generation run.
+type GenerateWarmRunnersWorkflowOptions struct {
+	WorkflowDataList []*WorkflowData
+	WorkflowDir      string
+	Version          string
+	ActionMode       ActionMode
+	ActionTag        string
+	RepoConfig       *RepoConfig
+	RepoSlug         string
+}
+
+// GenerateWarmRunnersWorkflow generates or removes the agentic-warm-runners.yml workflow
+// based on the warm_runners field of the repository's aw.json.
+//
+// When configured, the workflow runs on the configured self-hosted runners on a schedule,
+// installs the engine CLIs used by the compiled workflows, and pulls their container
+// images, so that agent jobs find them cached and start in seconds.
+//
+// When warm_runners is not configured, any existing agentic-warm-runners.yml is deleted.
+func GenerateWarmRunnersWorkflow(ctx context.Context, opts GenerateWarmRunnersWorkflowOptions) error {
+	outputFile := filepath.Join(opts.WorkflowDir, WarmRunnersWorkflowFileName)
+
+	if opts.RepoConfig == nil || opts.RepoConfig.WarmRunners == nil {
+		warmRunnersWorkflowLog.Print("Warm runners not configured, removing agentic-warm-runners.yml if present")
+		if err := removeIfExists(outputFile); err != nil {
+			return fmt.Errorf("failed to delete warm runners workflow: %w", err)
+		}
+		return nil
+	}
+	config := opts.RepoConfig.WarmRunners
+
+	actionMode := opts.ActionMode
+	if actionMode == "" {
+		actionMode = DetectActionMode(opts.Version)
+	}
+
+	cronSchedule := config.Cron
+	isCustomCron := cronSchedule != ""
+	if !isCustomCron {
+		seed := warmRunnersWorkflowIdentifier
+		if actionMode.IsDev() {
+			seed = "dev/" + warmRunnersWorkflowIdentifier
+		} else if opts.RepoSlug != "" {
+			seed = opts.RepoSlug + "/" + warmRunnersWorkflowIdentifier
+		}
+		var err error
+		cronSchedule, err = parser.ScatterSchedule(warmRunnersDefaultSchedule, seed)
+		if err != nil {
+			return fmt.Errorf("failed to scatter %s schedule for warm runners workflow: %w", warmRunnersDefaultSchedule, err)
+		}
+	}
+
+	var resolver SHAResolver
+	for _, workflowData := range opts.WorkflowDataList {
+		if workflowData != nil && workflowData.ActionResolver != nil {
+			resolver = workflowData.ActionResolver
+			break
+		}
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	setupActionRef := ResolveSetupActionReference(ctx, actionMode, opts.Version, opts.ActionTag, resolver)
+
+	installSteps, images := collectWarmRunnerSteps(opts.WorkflowDataList, actionMode)
+	warmRunnersWorkflowLog.Printf("Warming %d install step(s) and %d image(s) on %v", len(installSteps), len(images), config.RunsOn)
+
+	content := buildWarmRunnersWorkflowYAML(
+		cronSchedule,
+		FormatRunsOn(config.RunsOn, ""),
+		setupActionRef,
+		installSteps,
+		images,
+		isCustomCron,
+	)
+
+	if err := fileutil.EnsureParentDir(outputFile, constants.DirPermPublic); err != nil {
+		return fmt.Errorf("failed to create warm runners workflow directory: %w", err)
+	}
+	if err := os.WriteFile(outputFile, []byte(content), constants.FilePermPublic); err != nil {
+		return fmt.Errorf("failed to write warm runners workflow: %w", err)
+	}
+
+	warmRunnersWorkflowLog.Printf("Wrote warm runners workflow: %s", outputFile)
+	return nil
+}
+
+// collectWarmRunnerSteps returns the engine installation steps of the workflows, without
+// duplicates and in first-use order, and the sorted container images they pull.
+func collectWarmRunnerSteps(workflowDataList []*WorkflowData, actionMode ActionMode) ([]string, []string) {
+	registry := GetGlobalEngineRegistry()
+	var steps []string
+	seenSteps := make(map[string]bool)
+	imageSet := make(map[string]bool)
+
+	for _, workflowData := range workflowDataList {
+		if workflowData == nil {
+			continue
+		}
+		if engine, err := registry.GetEngine(ResolveEngineID(workflowData)); err == nil {
+			for _, step := range engine.GetInstallationSteps(workflowData) {
+				text := strings.Join(step, "\n") + "\n"
+				if !seenSteps[text] {
+					seenSteps[text] = true
+					steps = append(steps, text)
+				}
+			}
+		}
+		for _, image := range collectDockerImages(workflowData.Tools, workflowData, actionMode) {
+			imageSet[image] = true
+		}
+	}
+
+	images := make([]string, 0, len(imageSet))
+	for image := range imageSet {
+		images = append(images, image)
+	}
+	sort.Strings(images)
+	return steps, images
+}
+
+// buildWarmRunnersWorkflowYAML generates the YAML content for agentic-warm-runners.yml.
+func buildWarmRunnersWorkflowYAML(cronSchedule, runsOn, setupActionRef string, installSteps, images []string, isCustomCron bool) string {
+	customInstructions := `Alternative regeneration methods:
+  make recompile
+
+Or use the gh-aw CLI directly:
+  ./gh-aw compile --validate --verbose
+
+The workflow is generated when warm_runners is set in aw.json.
+It installs the engine CLIs and pulls the container images used by the compiled
+workflows so that agent jobs on the same self-hosted runners start faster.`
+
+	scheduleComment := "Custom schedule (warm runners)"
+	if !isCustomCron {
+		scheduleComment = "Every 6 hours (warm runners)"
+	}
+
+	var yaml strings.Builder
+	yaml.WriteString(GenerateWorkflowHeader("", "pkg/workflow/warm_runners_workflow.go", customInstructions))
+	yaml.WriteString(`name: Agentic Warm Runners
+
+on:
+  schedule:
+    - cron: "` + cronSchedule + `"  # ` + scheduleComment + `
+  workflow_dispatch:
+
+permissions:
+  contents: read
+
+concurrency:
+  group: agentic-warm-runners
+  cancel-in-progress: true
+
+jobs:
+  warm:
+    runs-on: ` + runsOn + `
+    timeout-minutes: 30
+    steps:
+      - name: Checkout repository
+        uses: ` + getActionPin("actions/checkout") + `
+        with:
+          persist-credentials: false
+
+      - name: Setup Scripts
+        uses: ` + setupActionRef + `
+        with:
+          destination: ${{ runner.temp }}/gh-aw/actions
+
+`)
+	for _, step := range installSteps {
+		yaml.WriteString(step)
+	}
+	generateDownloadDockerImagesStep(&yaml, images)
+	return yaml.String()
+}
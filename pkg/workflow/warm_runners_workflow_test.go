@@ -0,0 +1,76 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateWarmRunnersWorkflow(t *testing.T) {
+	dir := t.TempDir()
+	workflows := []*WorkflowData{
+		{Name: "triage", AI: "claude", EngineConfig: &EngineConfig{ID: "claude"}, Tools: map[string]any{"github": map[string]any{"mode": "local"}}},
+		{Name: "review", AI: "claude", EngineConfig: &EngineConfig{ID: "claude"}},
+		{Name: "fix", AI: "codex", EngineConfig: &EngineConfig{ID: "codex"}},
+	}
+
+	err := GenerateWarmRunnersWorkflow(context.Background(), GenerateWarmRunnersWorkflowOptions{
+		WorkflowDataList: workflows,
+		WorkflowDir:      dir,
+		ActionMode:       ActionModeDev,
+		RepoConfig:       &RepoConfig{WarmRunners: &WarmRunnersConfig{RunsOn: RunsOnValue{"self-hosted", "linux"}}},
+	})
+	require.NoError(t, err, "GenerateWarmRunnersWorkflow should succeed when configured")
+
+	data, err := os.ReadFile(filepath.Join(dir, WarmRunnersWorkflowFileName))
+	require.NoError(t, err, "agentic-warm-runners.yml should be written")
+	content := string(data)
+
+	assert.Contains(t, content, "name: Agentic Warm Runners", "should include workflow name")
+	assert.Contains(t, content, "Every 6 hours (warm runners)", "should use the default schedule")
+	assert.Contains(t, content, `runs-on: ["self-hosted","linux"]`, "should run on the configured runners")
+	assert.Contains(t, content, "npm install -g @anthropic-ai/claude-code@", "should install the Claude Code CLI")
+	assert.Contains(t, content, "@openai/codex@", "should install the Codex CLI")
+	assert.Equal(t, 1, strings.Count(content, "Install Claude Code CLI"), "install steps shared by workflows should not be repeated")
+	assert.Equal(t, 1, strings.Count(content, "name: Setup Node.js"), "Node.js should be set up once")
+	assert.Contains(t, content, "download_docker_images.sh", "should pull container images")
+	assert.Contains(t, content, "ghcr.io/github/github-mcp-server:", "should pull the MCP server images")
+	assert.NotContains(t, content, "secrets.", "should not reference secrets")
+}
+
+func TestGenerateWarmRunnersWorkflow_CustomCron(t *testing.T) {
+	dir := t.TempDir()
+	err := GenerateWarmRunnersWorkflow(context.Background(), GenerateWarmRunnersWorkflowOptions{
+		WorkflowDir: dir,
+		ActionMode:  ActionModeDev,
+		RepoConfig:  &RepoConfig{WarmRunners: &WarmRunnersConfig{RunsOn: RunsOnValue{"self-hosted"}, Cron: "0 */2 * * *"}},
+	})
+	require.NoError(t, err, "GenerateWarmRunnersWorkflow should succeed with a custom cron")
+
+	data, err := os.ReadFile(filepath.Join(dir, WarmRunnersWorkflowFileName))
+	require.NoError(t, err, "agentic-warm-runners.yml should be written")
+	assert.Contains(t, string(data), `cron: "0 */2 * * *"  # Custom schedule (warm runners)`, "should use the custom cron")
+	assert.Contains(t, string(data), "runs-on: self-hosted", "should run on the configured runner")
+}
+
+func TestGenerateWarmRunnersWorkflow_NotConfiguredDeletesExistingFile(t *testing.T) {
+	dir := t.TempDir()
+	outputPath := filepath.Join(dir, WarmRunnersWorkflowFileName)
+	require.NoError(t, os.WriteFile(outputPath, []byte("old content"), 0o644))
+
+	err := GenerateWarmRunnersWorkflow(context.Background(), GenerateWarmRunnersWorkflowOptions{
+		WorkflowDir: dir,
+		RepoConfig:  &RepoConfig{},
+	})
+	require.NoError(t, err, "GenerateWarmRunnersWorkflow should succeed when not configured")
+
+	_, err = os.Stat(outputPath)
+	assert.True(t, os.IsNotExist(err), "existing agentic-warm-runners.yml should be deleted when warm_runners is removed")
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"maps"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
 
@@ -41,6 +42,8 @@ func (c *Compiler) buildInitialWorkflowData(
 		FrontmatterEmoji:           toolsResult.frontmatterEmoji,
 		FrontmatterYAML:            strings.Join(result.FrontmatterLines, "\n"),
 		FrontmatterFieldLines:      result.FieldLines,
+		FrontmatterStartLine:       result.FrontmatterStart,
+		MarkdownStartLine:          result.MarkdownStart,
 		RawMarkdown:                result.Markdown,
 		Description:                c.extractDescription(result.Frontmatter),
 		Source:                     c.extractSource(result.Frontmatter),
@@ -72,9 +75,11 @@ func (c *Compiler) buildInitialWorkflowData(
 		NetworkPermissions:         engineSetup.networkPermissions,
 		SandboxConfig:              applySandboxDefaults(engineSetup.sandboxConfig, engineSetup.engineConfig),
 		RunnerConfig:               extractRunnerConfig(result.Frontmatter),
+		RequiredSecrets:            extractRequiredSecrets(result.Frontmatter),
 		NeedsTextOutput:            toolsResult.needsTextOutput,
 		ToolsTimeout:               toolsResult.toolsTimeout,
 		ToolsStartupTimeout:        toolsResult.toolsStartupTimeout,
+		ArtifactsTool:              toolsResult.artifactsTool,
 		TrialMode:                  c.trialMode,
 		TrialLogicalRepo:           c.trialLogicalRepoSlug,
 		UseSamples:                 c.useSamples,
@@ -596,6 +601,26 @@ func (c *Compiler) extractYAMLSections(frontmatter map[string]any, workflowData
 	workflowData.Permissions = c.extractPermissions(frontmatter)
 	workflowData.Network = c.extractTopLevelYAMLSection(frontmatter, "network")
 	workflowData.ConcurrencyJobDiscriminator = extractConcurrencyJobDiscriminator(frontmatter)
+	concurrencyPool, err := extractConcurrencyPool(frontmatter)
+	if err != nil {
+		return err
+	}
+	workflowData.ConcurrencyPool = concurrencyPool
+	outputLanguage, err := extractOutputLanguage(frontmatter)
+	if err != nil {
+		return err
+	}
+	workflowData.OutputLanguage = outputLanguage
+	retention, err := extractRetentionConfig(frontmatter)
+	if err != nil {
+		return err
+	}
+	workflowData.Retention = retention
+	artifactEncryption, err := extractArtifactEncryptionConfig(frontmatter)
+	if err != nil {
+		return err
+	}
+	workflowData.ArtifactEncryption = artifactEncryption
 	workflowData.Concurrency = c.extractConcurrencySection(frontmatter)
 	workflowData.RunName = c.extractTopLevelYAMLSection(frontmatter, "run-name")
 	workflowData.Env = c.extractTopLevelYAMLSection(frontmatter, "env")
@@ -614,6 +639,11 @@ func (c *Compiler) extractYAMLSections(frontmatter map[string]any, workflowData
 	if v, ok := frontmatter["runs-on-slim"]; ok && !isEmptyRunsOnValue(v) {
 		workflowData.RunsOnSlim = c.extractTopLevelYAMLSection(map[string]any{"runs-on": v}, "runs-on")
 	}
+	resources, err := extractResourcesConfig(frontmatter)
+	if err != nil {
+		return err
+	}
+	workflowData.Resources = resources
 	workflowData.Environment = c.extractTopLevelYAMLSection(frontmatter, "environment")
 	workflowData.Container = c.extractTopLevelYAMLSection(frontmatter, "container")
 	workflowData.Cache = c.extractTopLevelYAMLSection(frontmatter, "cache")
@@ -643,9 +673,13 @@ func extractConcurrencyJobDiscriminator(frontmatter map[string]any) string {
 	return discriminatorStr
 }
 
+// concurrencyExtensionFields are gh-aw-specific concurrency fields that are not valid
+// GitHub Actions YAML and must be stripped from the compiled workflow-level block.
+var concurrencyExtensionFields = []string{"job-discriminator", "pool"}
+
 // extractConcurrencySection extracts the workflow-level concurrency YAML section,
-// stripping the gh-aw-specific job-discriminator field so it does not appear in
-// the compiled lock file (which must be valid GitHub Actions YAML).
+// stripping the gh-aw-specific job-discriminator and pool fields so they do not appear
+// in the compiled lock file (which must be valid GitHub Actions YAML).
 func (c *Compiler) extractConcurrencySection(frontmatter map[string]any) string {
 	concurrencyRaw, ok := frontmatter["concurrency"]
 	if !ok {
@@ -653,26 +687,28 @@ func (c *Compiler) extractConcurrencySection(frontmatter map[string]any) string
 	}
 	concurrencyMap, ok := concurrencyRaw.(map[string]any)
 	if !ok || len(concurrencyMap) == 0 {
-		// String or empty format: serialize as-is (no job-discriminator possible)
+		// String or empty format: serialize as-is (no extension fields possible)
 		return c.extractTopLevelYAMLSection(frontmatter, "concurrency")
 	}
 
-	_, hasDiscriminator := concurrencyMap["job-discriminator"]
-	if !hasDiscriminator {
+	hasExtensionField := slices.ContainsFunc(concurrencyExtensionFields, func(field string) bool {
+		_, ok := concurrencyMap[field]
+		return ok
+	})
+	if !hasExtensionField {
 		return c.extractTopLevelYAMLSection(frontmatter, "concurrency")
 	}
 
-	// Build a copy of the concurrency map without job-discriminator for serialization.
-	// Use len(concurrencyMap) for capacity: at most one entry (job-discriminator) will be
-	// omitted, so this is a slight over-allocation that avoids a subtle negative-capacity
-	// edge case if job-discriminator were the only key.
+	// Build a copy of the concurrency map without the extension fields for serialization.
+	// Use len(concurrencyMap) for capacity: this is a slight over-allocation that avoids
+	// a subtle negative-capacity edge case if the extension fields were the only keys.
 	cleanMap := make(map[string]any, len(concurrencyMap))
 	for k, v := range concurrencyMap {
-		if k != "job-discriminator" {
+		if !slices.Contains(concurrencyExtensionFields, k) {
 			cleanMap[k] = v
 		}
 	}
-	// When job-discriminator is the only field, there is no user-specified workflow-level
+	// When only extension fields are set, there is no user-specified workflow-level
 	// group to emit; return empty so the compiler can generate the default concurrency.
 	if len(cleanMap) == 0 {
 		return ""
@@ -2,6 +2,7 @@ package workflow
 
 import (
 	"context"
+	"time"
 
 	actionpins "github.com/github/gh-aw/pkg/actionpins"
 	"github.com/github/gh-aw/pkg/logger"
@@ -44,6 +45,8 @@ type WorkflowData struct {
 	FrontmatterYAML                string           // raw frontmatter YAML content (rendered as comment in lock file for reference)
 	FrontmatterHash                string           // SHA-256 hash of frontmatter (computed before job building, used to derive stable heredoc delimiters)
 	FrontmatterFieldLines          map[string]int   // absolute 1-based line numbers of top-level frontmatter keys in the source file (populated by parser)
+	FrontmatterStartLine           int              // absolute 1-based line of the first frontmatter line (after the opening ---)
+	MarkdownStartLine              int              // absolute 1-based line where RawMarkdown starts in the source file (0 when unknown)
 	RawMarkdown                    string           // raw markdown body before include expansion, used for frontmatter hash computation without re-reading the file
 	Description                    string           // optional description rendered as comment in lock file
 	Source                         string           // optional source field (owner/repo@ref/path) rendered as comment in lock file
@@ -74,10 +77,11 @@ type WorkflowData struct {
 	PreAgentSteps                  string // steps to run immediately before the agent execution step
 	PostSteps                      string // steps to run after AI execution
 	RunsOn                         string
-	RunsOnSlim                     string // rendered runs-on snippet for framework/generated jobs (activation, safe-outputs, unlock, etc.)
-	Environment                    string // environment setting for the main job
-	Container                      string // container setting for the main job
-	Services                       string // services setting for the main job
+	RunsOnSlim                     string   // rendered runs-on snippet for framework/generated jobs (activation, safe-outputs, unlock, etc.)
+	Environment                    string   // environment setting for the main job
+	RequiredSecrets                []string // secret names from required-secrets, validated in the activation job
+	Container                      string   // container setting for the main job
+	Services                       string   // services setting for the main job
 	Tools                          map[string]any
 	LSP                            map[string]LSPServerConfig // top-level LSP server configuration for Copilot CLI
 	ParsedTools                    *Tools                     // Structured tools configuration (NEW: parsed from Tools map)
@@ -92,9 +96,15 @@ type WorkflowData struct {
 	SkipIfMatch                    *SkipIfMatchConfig              // skip-if-match configuration with query and max threshold
 	SkipIfNoMatch                  *SkipIfNoMatchConfig            // skip-if-no-match configuration with query and min threshold
 	SkipIfCheckFailing             *SkipIfCheckFailingConfig       // skip-if-check-failing configuration
+	ReactionCommand                *ReactionCommandConfig          // reaction_command trigger configuration
+	ScheduleTimezoneChecks         []ScheduleTimezoneCheck         // UTC crons compiled from timezone-aware schedule items that are only valid for one offset
+	ScheduleUnguardedCrons         []string                        // UTC crons that always run, used to skip timezone checks for shared crons
+	ScheduleJitterSeconds          int                             // upper bound of the random delay added to scheduled runs (0 = no jitter)
+	FollowUpPolling                bool                            // true when the schedule was added to poll for due follow-ups (safe-outputs.schedule-follow-up)
 	SkipRoles                      []string                        // roles to skip workflow for (e.g., [admin, maintainer, write])
 	SkipBots                       []string                        // users to skip workflow for (e.g., [user1, user2])
 	SkipAuthorAssociations         map[string][]string             // author associations to skip by event name (on.skip-author-associations)
+	TriggerFilters                 *TriggerFiltersConfig           // on.filters paths, labels, and author-associations
 	AllowBotAuthoredTriggerComment bool                            // allow bot-posted-menu / user-checks-box pattern (on.allow-bot-authored-trigger-comment)
 	OnSteps                        []map[string]any                // steps to inject into the pre-activation job from on.steps
 	OnRestoreMemory                bool                            // enable memory restore in pre-activation for on.steps via on.restore-memory (default false)
@@ -105,6 +115,8 @@ type WorkflowData struct {
 	CommandEvents                  []string                        // events where command should be active (nil = all events)
 	CommandCentralized             bool                            // when true, slash_command uses centralized dispatch routing via workflow_dispatch
 	CommandPlaceholder             string                          // optional footer hint text from slash_command.placeholder
+	CommandSubcommands             []SlashCommandSubcommand        // subcommands declared in slash_command.subcommands
+	CommandSticky                  time.Duration                   // window of slash_command.sticky (0 = disabled)
 	CommandOtherEvents             map[string]any                  // for merging command with other events
 	LabelCommand                   []string                        // for label-command trigger support - label names that act as commands
 	LabelCommandEvents             []string                        // events where label-command should be active (nil = all: issues, pull_request, discussion)
@@ -137,9 +149,11 @@ type WorkflowData struct {
 	RateLimit                      *RateLimitConfig                // rate limiting configuration for workflow triggers
 	CacheMemoryConfig              *CacheMemoryConfig              // parsed cache-memory configuration
 	RepoMemoryConfig               *RepoMemoryConfig               // parsed repo-memory configuration
+	ContextRepos                   []*ContextRepoConfig            // additional repositories fetched read-only for the agent (from the repos: frontmatter field)
 	Runtimes                       map[string]any                  // runtime version overrides from frontmatter
 	ToolsTimeout                   string                          // timeout for tool/MCP operations: numeric string (seconds) or GitHub Actions expression (empty = use engine default)
 	ToolsStartupTimeout            string                          // timeout for MCP server startup: numeric string (seconds) or GitHub Actions expression (empty = use engine default)
+	ArtifactsTool                  *ArtifactsToolConfig            // artifacts: tool configuration (nil when the tool is not enabled)
 	Features                       map[string]any                  // feature flags and configuration options from frontmatter (supports bool and string values)
 	Ctx                            context.Context                 // context propagated from the caller for network operations (e.g. SHA resolution)
 	ActionCache                    *ActionCache                    // cache for action pin resolutions
@@ -166,7 +180,20 @@ type WorkflowData struct {
 	CheckoutExplicitlyDisabled     bool                            // true only when checkout: false is explicitly set in frontmatter (not auto-disabled)
 	IsPullRequestTarget            bool                            // true when the workflow's on: triggers contain pull_request_target (but NOT pull_request)
 	HasDispatchItemNumber          bool                            // true when workflow_dispatch has item_number input (generated by label trigger shorthand)
+	WorkflowRunUpstreams           []string                        // agentic workflows named in on.workflow_run.workflows whose agent output is passed to the agent
+	AgenticJobs                    []*AgenticJob                   // agentic jobs defined in "## job:" blocks of the markdown body, compiled into companion workflows
+	AgenticJobUpstreams            []AgenticJobUpstream            // agentic jobs of the same run whose agent output is passed to the agent
+	FanOut                         *FanOutConfig                   // fan-out over items (from fan-out); the workflow compiles to a dispatcher calling a per-item companion
+	FanOutItem                     bool                            // true when compiling the companion of a fan-out workflow, whose prompt receives one item
+	Preview                        *PreviewConfig                  // safe-output preview in pull requests (from preview); compiles a staged companion triggered by pull requests
+	PreviewRun                     bool                            // true when compiling the preview companion, which posts its safe outputs to the pull request
+	Resources                      *ResourcesConfig                // resource requirements used to select the agent job runner when runs-on is not set (from resources)
 	ConcurrencyJobDiscriminator    string                          // optional discriminator expression appended to job-level concurrency groups (from concurrency.job-discriminator)
+	ConcurrencyPool                *ConcurrencyPoolConfig          // optional named concurrency pool shared with other workflows (from concurrency.pool)
+	OutputLanguage                 string                          // ISO 639-1 code all user-facing output must be written in (from output-language)
+	Privacy                        *PrivacyConfig                  // pseudonymization of usernames and emails in injected context (from privacy)
+	Retention                      *RetentionConfig                // retention of artifacts holding transcripts, logs and safe output items (from retention)
+	ArtifactEncryption             *ArtifactEncryptionConfig       // age encryption of transcript and safe output item artifacts (from artifact-encryption)
 	IsDetectionRun                 bool                            // true when this WorkflowData is used for inline threat detection (not the main agent run)
 	IsEvalsRun                     bool                            // true when this WorkflowData is used for eval execution (separate from agent and detection runs)
 	UpdateCheckDisabled            bool                            // true when check-for-updates: false is set in frontmatter (disables version check step in activation job)
@@ -181,6 +208,8 @@ type WorkflowData struct {
 	ConcurrencyGroupExpr           string                          // cached concurrency group expression extracted from Concurrency YAML (for performance optimization); populated by applyDefaults
 	CachedConcurrencyGroupExprErr  error                           // cached result of validateConcurrencyGroupExpression(ConcurrencyGroupExpr); nil = valid; populated by applyDefaults
 	Experiments                    map[string][]string             // A/B testing experiments: maps experiment name to variant list (from frontmatter)
+	PromptContext                  map[string]string               // Named values interpolated into the body as ${{ context.<name> }} (from the context: frontmatter field)
+	PromptBudget                   *PromptBudget                   // Token budgets for the instructions and injected context sections of the prompt (from the prompt-budget: frontmatter field)
 	ExperimentConfigs              map[string]*ExperimentConfig    // Full A/B experiment metadata (populated alongside Experiments)
 	ExperimentsStorage             string                          // "cache" or "repo" (default "repo"); controls how experiment state is persisted across runs
 	CachedConcurrencyGroupExprSet  bool                            // true once CachedConcurrencyGroupExprErr has been populated; distinguishes "valid (nil)" from "not yet computed"
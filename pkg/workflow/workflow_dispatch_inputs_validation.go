@@ -0,0 +1,116 @@
+// This file validates workflow_dispatch inputs.
+//
+// The JSON schema checks the shape of each input; this validator adds the checks the
+// schema cannot express:
+//   - choice inputs must list options, and only choice inputs may list options
+//   - defaults must be valid values for the input's type
+//   - the prompt should only reference inputs that a trigger declares
+
+package workflow
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/sliceutil"
+)
+
+var dispatchInputsValidationLog = logger.New("workflow:workflow_dispatch_inputs_validation")
+
+// promptExpressionPattern matches ${{ ... }} expressions in the markdown prompt.
+var promptExpressionPattern = regexp.MustCompile(`\$\{\{([^}]*)\}\}`)
+
+// promptInputReferencePattern matches inputs.<name> and github.event.inputs.<name>, but not
+// github.aw.inputs.<name>, which refers to import inputs.
+var promptInputReferencePattern = regexp.MustCompile(`(?:^|[^\w.])(?:github\.event\.)?inputs\.([a-zA-Z0-9_-]+)`)
+
+// validateWorkflowDispatchInputs checks on.workflow_dispatch.inputs for choice options and
+// defaults that GitHub would reject or silently ignore when the workflow is dispatched.
+func validateWorkflowDispatchInputs(frontmatter map[string]any) error {
+	inputs := workflowTriggerInputs(frontmatter, "workflow_dispatch")
+	for _, name := range sliceutil.SortedKeys(inputs) {
+		inputConfig, ok := inputs[name].(map[string]any)
+		if !ok {
+			continue
+		}
+		input := ParseInputDefinition(inputConfig)
+		if input.Type == "choice" && len(input.Options) == 0 {
+			return fmt.Errorf("workflow_dispatch input '%s' has type choice but no options. Example:\n  %s:\n    type: choice\n    options: [quick, deep]", name, name)
+		}
+		if input.Type != "choice" && len(input.Options) > 0 {
+			return fmt.Errorf("workflow_dispatch input '%s' lists options but has type %q: set type: choice", name, inputTypeOrDefault(input.Type))
+		}
+		if input.Default == nil {
+			continue
+		}
+		if err := input.ValidateValue(input.GetDefaultAsString()); err != nil {
+			return fmt.Errorf("invalid default for workflow_dispatch input '%s': %w", name, err)
+		}
+	}
+	dispatchInputsValidationLog.Printf("Validated %d workflow_dispatch input(s)", len(inputs))
+	return nil
+}
+
+// undeclaredPromptInputReferences returns the input names referenced by expressions in the
+// prompt that neither workflow_dispatch nor workflow_call declares. Workflows without either
+// trigger are not checked.
+func undeclaredPromptInputReferences(markdown string, frontmatter map[string]any) []string {
+	onMap, ok := frontmatter["on"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	_, hasDispatch := onMap["workflow_dispatch"]
+	_, hasCall := onMap["workflow_call"]
+	if !hasDispatch && !hasCall {
+		return nil
+	}
+
+	declared := map[string]bool{AwContextInputName: true}
+	for _, trigger := range []string{"workflow_dispatch", "workflow_call"} {
+		for name := range workflowTriggerInputs(frontmatter, trigger) {
+			declared[name] = true
+		}
+	}
+
+	var undeclared []string
+	for _, expression := range promptExpressionPattern.FindAllStringSubmatch(markdown, -1) {
+		for _, reference := range promptInputReferencePattern.FindAllStringSubmatch(expression[1], -1) {
+			name := reference[1]
+			if !declared[name] && !slices.Contains(undeclared, name) {
+				undeclared = append(undeclared, name)
+			}
+		}
+	}
+	sort.Strings(undeclared)
+	return undeclared
+}
+
+// workflowTriggerInputs returns on.<trigger>.inputs, or nil when the trigger declares none.
+func workflowTriggerInputs(frontmatter map[string]any, trigger string) map[string]any {
+	onMap, ok := frontmatter["on"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	triggerMap, ok := onMap[trigger].(map[string]any)
+	if !ok {
+		return nil
+	}
+	inputs, _ := triggerMap["inputs"].(map[string]any)
+	return inputs
+}
+
+func inputTypeOrDefault(inputType string) string {
+	if inputType == "" {
+		return "string"
+	}
+	return inputType
+}
+
+// formatUndeclaredInputsWarning describes prompt input references that will render empty.
+func formatUndeclaredInputsWarning(names []string) string {
+	return fmt.Sprintf("prompt references undeclared input(s) %s: declare them under on.workflow_dispatch.inputs, or they will be empty at runtime", strings.Join(names, ", "))
+}
@@ -0,0 +1,84 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func dispatchInputsFrontmatter(inputs map[string]any) map[string]any {
+	return map[string]any{
+		"on": map[string]any{
+			"workflow_dispatch": map[string]any{"inputs": inputs},
+		},
+	}
+}
+
+func TestValidateWorkflowDispatchInputs(t *testing.T) {
+	tests := []struct {
+		name    string
+		inputs  map[string]any
+		wantErr string
+	}{
+		{
+			name: "valid typed inputs",
+			inputs: map[string]any{
+				"topic":   map[string]any{"type": "string", "required": true},
+				"depth":   map[string]any{"type": "choice", "options": []any{"quick", "deep"}, "default": "quick"},
+				"dry_run": map[string]any{"type": "boolean", "default": false},
+				"limit":   map[string]any{"type": "number", "default": 5},
+			},
+		},
+		{
+			name:    "choice without options",
+			inputs:  map[string]any{"depth": map[string]any{"type": "choice"}},
+			wantErr: "workflow_dispatch input 'depth' has type choice but no options",
+		},
+		{
+			name:    "options on a string input",
+			inputs:  map[string]any{"depth": map[string]any{"options": []any{"quick"}}},
+			wantErr: `workflow_dispatch input 'depth' lists options but has type "string"`,
+		},
+		{
+			name:    "choice default outside options",
+			inputs:  map[string]any{"depth": map[string]any{"type": "choice", "options": []any{"quick", "deep"}, "default": "medium"}},
+			wantErr: "invalid default for workflow_dispatch input 'depth': must be one of quick, deep",
+		},
+		{
+			name:    "boolean default that is not a boolean",
+			inputs:  map[string]any{"dry_run": map[string]any{"type": "boolean", "default": "yes"}},
+			wantErr: "invalid default for workflow_dispatch input 'dry_run': must be true or false",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateWorkflowDispatchInputs(dispatchInputsFrontmatter(tt.inputs))
+			if tt.wantErr == "" {
+				require.NoError(t, err, "valid inputs should pass")
+				return
+			}
+			require.Error(t, err, "invalid inputs should be rejected")
+			assert.Contains(t, err.Error(), tt.wantErr, "error should explain the problem")
+		})
+	}
+}
+
+func TestUndeclaredPromptInputReferences(t *testing.T) {
+	frontmatter := dispatchInputsFrontmatter(map[string]any{"topic": map[string]any{"type": "string"}})
+	markdown := "Research ${{ inputs.topic }} at ${{ github.event.inputs.depth || 'quick' }} depth.\n" +
+		"Import input: ${{ github.aw.inputs.audience }}. Context: ${{ github.event.inputs.aw_context }}. Again ${{ inputs.depth }}."
+
+	assert.Equal(t, []string{"depth"}, undeclaredPromptInputReferences(markdown, frontmatter), "only undeclared dispatch inputs should be reported")
+
+	noDispatch := map[string]any{"on": map[string]any{"issues": map[string]any{"types": []any{"opened"}}}}
+	assert.Empty(t, undeclaredPromptInputReferences(markdown, noDispatch), "workflows without dispatch or call triggers should not be checked")
+
+	withCall := map[string]any{"on": map[string]any{
+		"workflow_dispatch": nil,
+		"workflow_call":     map[string]any{"inputs": map[string]any{"topic": map[string]any{}, "depth": map[string]any{}}},
+	}}
+	assert.Empty(t, undeclaredPromptInputReferences(markdown, withCall), "workflow_call inputs should count as declared")
+}
@@ -95,6 +95,14 @@ func applyTopLevelGitHubAppFallbacks(data *WorkflowData) {
 		}
 	}
 
+	// Fallback for context repositories (repos.<name>.github-app / repos.<name>.github-token)
+	for _, repo := range data.ContextRepos {
+		if topLevelFallbackNeeded(repo.GitHubApp, repo.GitHubToken) {
+			workflowGitHubAppLog.Printf("Applying top-level github-app fallback for repos.%s", repo.Name)
+			repo.GitHubApp = fallback
+		}
+	}
+
 	// Fallback for tools.github (tools.github.github-app / tools.github.github-token).
 	// Also skipped when tools.github is explicitly disabled (github: false) — do not re-enable it.
 	if data.ParsedTools != nil && data.ParsedTools.GitHub != nil &&
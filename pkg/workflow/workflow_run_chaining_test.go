@@ -0,0 +1,136 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeChainingWorkflows(t *testing.T, dir string) {
+	t.Helper()
+	files := map[string]string{
+		"plan.md":     "---\non: issues\nengine: copilot\nsafe-outputs:\n  add-comment:\n---\n\n# Plan\n\nWrite a plan.\n",
+		"report.md":   "---\nname: Weekly Report\non: schedule\n---\n\nReport.\n",
+		"helpers.md":  "# Shared helpers\n",
+		"ci.yml":      "name: CI\non: push\njobs: {}\n",
+		"release.yml": "on: push\njobs: {}\n",
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644), "workflow %s should be written", name)
+	}
+}
+
+func TestCollectWorkflowRunCandidates(t *testing.T) {
+	dir := t.TempDir()
+	writeChainingWorkflows(t, dir)
+
+	names := make(map[string]bool)
+	for _, candidate := range collectWorkflowRunCandidates(dir, "plan.md") {
+		names[candidate.name] = candidate.agentic
+	}
+
+	assert.Equal(t, map[string]bool{
+		"Weekly Report":                 true,
+		"CI":                            false,
+		".github/workflows/release.yml": false,
+	}, names, "candidates should use display names and skip the current workflow and non-workflow markdown")
+}
+
+func TestWorkflowRunWorkflowNames(t *testing.T) {
+	assert.Equal(t, []string{"Plan"}, workflowRunWorkflowNames(" Plan "), "string should be trimmed")
+	assert.Equal(t, []string{"Plan", "CI"}, workflowRunWorkflowNames([]any{"Plan", "", "CI", "Plan", 3}), "list should drop empty, duplicate, and non-string entries")
+	assert.Nil(t, workflowRunWorkflowNames(nil), "missing workflows should yield no names")
+}
+
+func TestCompileWorkflowRunChaining(t *testing.T) {
+	dir := t.TempDir()
+	writeChainingWorkflows(t, dir)
+
+	markdownPath := filepath.Join(dir, "execute.md")
+	content := "---\non:\n  workflow_run:\n    workflows: [\"Plan\", \"CI\"]\n    types: [completed]\n    branches: [main]\npermissions:\n  contents: read\nengine: copilot\n---\n\n# Execute\n\nExecute the plan.\n"
+	require.NoError(t, os.WriteFile(markdownPath, []byte(content), 0o644), "workflow should be written")
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(markdownPath), "chained workflow should compile")
+	assert.Zero(t, compiler.GetWarningCount(), "known upstreams should not warn")
+
+	lock, err := os.ReadFile(filepath.Join(dir, "execute.lock.yml"))
+	require.NoError(t, err, "lock file should be written")
+	lockContent := string(lock)
+
+	assert.Contains(t, lockContent, "- name: Download upstream agent output", "upstream agent artifact should be downloaded")
+	assert.Contains(t, lockContent, "run-id: ${{ github.event.workflow_run.id }}", "download should target the triggering run")
+	assert.Contains(t, lockContent, "path: ${{ env.GH_AW_TMP_DIR }}/upstream/", "upstream output should be downloaded to its own directory")
+	assert.Contains(t, lockContent, `if [ "$GITHUB_EVENT_NAME" = "workflow_run" ]; then`, "prompt section should only apply to workflow_run events")
+	assert.Contains(t, lockContent, "<upstream-workflow-run>", "prompt should describe the upstream run")
+
+	agentJob := lockContent[strings.Index(lockContent, "\n  agent:"):]
+	agentJob = agentJob[:strings.Index(agentJob, "steps:")]
+	assert.Contains(t, agentJob, "actions: read", "agent job should be able to download artifacts from the upstream run")
+}
+
+func TestCompileWorkflowRunChainingNonAgenticUpstream(t *testing.T) {
+	dir := t.TempDir()
+	writeChainingWorkflows(t, dir)
+
+	markdownPath := filepath.Join(dir, "deploy.md")
+	content := "---\non:\n  workflow_run:\n    workflows: [\"CI\"]\n    types: [completed]\n    branches: [main]\nengine: copilot\n---\n\nDeploy.\n"
+	require.NoError(t, os.WriteFile(markdownPath, []byte(content), 0o644), "workflow should be written")
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(markdownPath), "workflow should compile")
+
+	lock, err := os.ReadFile(filepath.Join(dir, "deploy.lock.yml"))
+	require.NoError(t, err, "lock file should be written")
+	assert.NotContains(t, string(lock), "Download upstream agent output", "non-agentic upstreams have no agent output to pass")
+}
+
+func TestCompileWorkflowRunChainingValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		workflows string
+		wantErr   string
+	}{
+		{name: "filename instead of name warns", workflows: `["plan"]`},
+		{name: "misspelled name warns", workflows: `["Weekly Reprot"]`},
+		{name: "self reference fails", workflows: `["Execute"]`, wantErr: "self-reference not allowed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeChainingWorkflows(t, dir)
+			markdownPath := filepath.Join(dir, "execute.md")
+			content := "---\non:\n  workflow_run:\n    workflows: " + tt.workflows + "\n    types: [completed]\n    branches: [main]\nengine: copilot\n---\n\n# Execute\n\nExecute the plan.\n"
+			require.NoError(t, os.WriteFile(markdownPath, []byte(content), 0o644), "workflow should be written")
+
+			compiler := NewCompiler()
+			err := compiler.CompileWorkflow(markdownPath)
+			if tt.wantErr != "" {
+				require.Error(t, err, "compilation should fail")
+				assert.Contains(t, err.Error(), tt.wantErr, "error should explain the problem")
+				return
+			}
+			require.NoError(t, err, "compilation should succeed with a warning")
+			assert.Positive(t, compiler.GetWarningCount(), "unresolved upstream should be reported")
+		})
+	}
+}
+
+func TestCompileWorkflowRunChainingExternalUpstream(t *testing.T) {
+	dir := t.TempDir()
+	writeChainingWorkflows(t, dir)
+	markdownPath := filepath.Join(dir, "execute.md")
+	content := "---\non:\n  workflow_run:\n    workflows: [\"Nightly Build Pipeline\"]\n    types: [completed]\n    branches: [main]\nengine: copilot\n---\n\n# Execute\n\nExecute the plan.\n"
+	require.NoError(t, os.WriteFile(markdownPath, []byte(content), 0o644), "workflow should be written")
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(markdownPath), "workflow should compile")
+	assert.Zero(t, compiler.GetWarningCount(), "names unlike any local workflow may be defined elsewhere and should not warn")
+}